@@ -47,6 +47,12 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) error
 
+	DeleteWorkflowExecution(
+		ctx context.Context,
+		DeleteRequest *history.DeleteWorkflowExecutionRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.DeleteWorkflowExecutionResponse, error)
+
 	DescribeHistoryHost(
 		ctx context.Context,
 		Request *shared.DescribeHistoryHostRequest,
@@ -65,12 +71,24 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) (*shared.DescribeQueueResponse, error)
 
+	DescribeReplicationStatus(
+		ctx context.Context,
+		Request *history.DescribeReplicationStatusRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.DescribeReplicationStatusResponse, error)
+
 	DescribeWorkflowExecution(
 		ctx context.Context,
 		DescribeRequest *history.DescribeWorkflowExecutionRequest,
 		opts ...yarpc.CallOption,
 	) (*shared.DescribeWorkflowExecutionResponse, error)
 
+	ExecuteQueueTask(
+		ctx context.Context,
+		Request *shared.ExecuteQueueTaskRequest,
+		opts ...yarpc.CallOption,
+	) error
+
 	GetCrossClusterTasks(
 		ctx context.Context,
 		Request *shared.GetCrossClusterTasksRequest,
@@ -101,12 +119,24 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) (*replicator.GetReplicationMessagesResponse, error)
 
+	ListQueueTasks(
+		ctx context.Context,
+		Request *shared.ListQueueTasksRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.ListQueueTasksResponse, error)
+
 	MergeDLQMessages(
 		ctx context.Context,
 		Request *replicator.MergeDLQMessagesRequest,
 		opts ...yarpc.CallOption,
 	) (*replicator.MergeDLQMessagesResponse, error)
 
+	MigrateShardExecutions(
+		ctx context.Context,
+		Request *history.MigrateShardExecutionsRequest,
+		opts ...yarpc.CallOption,
+	) (*history.MigrateShardExecutionsResponse, error)
+
 	NotifyFailoverMarkers(
 		ctx context.Context,
 		Request *history.NotifyFailoverMarkersRequest,
@@ -298,6 +328,18 @@ type Interface interface {
 		TerminateRequest *history.TerminateWorkflowExecutionRequest,
 		opts ...yarpc.CallOption,
 	) error
+
+	UpdateActivityOptions(
+		ctx context.Context,
+		UpdateRequest *history.UpdateActivityOptionsRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	VerifyWorkflowExecution(
+		ctx context.Context,
+		Request *history.VerifyWorkflowExecutionRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.VerifyWorkflowExecutionResponse, error)
 }
 
 // New builds a new client for the HistoryService service.
@@ -357,6 +399,34 @@ func (c client) CloseShard(
 	return
 }
 
+func (c client) DeleteWorkflowExecution(
+	ctx context.Context,
+	_DeleteRequest *history.DeleteWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DeleteWorkflowExecutionResponse, err error) {
+
+	var result history.HistoryService_DeleteWorkflowExecution_Result
+	args := history.HistoryService_DeleteWorkflowExecution_Helper.Args(_DeleteRequest)
+
+	if c.nwc != nil && c.nwc.Enabled() {
+		if err = c.nwc.Call(ctx, args, &result, opts...); err != nil {
+			return
+		}
+	} else {
+		var body wire.Value
+		if body, err = c.c.Call(ctx, args, opts...); err != nil {
+			return
+		}
+
+		if err = result.FromWire(body); err != nil {
+			return
+		}
+	}
+
+	success, err = history.HistoryService_DeleteWorkflowExecution_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) DescribeHistoryHost(
 	ctx context.Context,
 	_Request *shared.DescribeHistoryHostRequest,
@@ -441,6 +511,34 @@ func (c client) DescribeQueue(
 	return
 }
 
+func (c client) DescribeReplicationStatus(
+	ctx context.Context,
+	_Request *history.DescribeReplicationStatusRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeReplicationStatusResponse, err error) {
+
+	var result history.HistoryService_DescribeReplicationStatus_Result
+	args := history.HistoryService_DescribeReplicationStatus_Helper.Args(_Request)
+
+	if c.nwc != nil && c.nwc.Enabled() {
+		if err = c.nwc.Call(ctx, args, &result, opts...); err != nil {
+			return
+		}
+	} else {
+		var body wire.Value
+		if body, err = c.c.Call(ctx, args, opts...); err != nil {
+			return
+		}
+
+		if err = result.FromWire(body); err != nil {
+			return
+		}
+	}
+
+	success, err = history.HistoryService_DescribeReplicationStatus_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) DescribeWorkflowExecution(
 	ctx context.Context,
 	_DescribeRequest *history.DescribeWorkflowExecutionRequest,
@@ -469,6 +567,34 @@ func (c client) DescribeWorkflowExecution(
 	return
 }
 
+func (c client) ExecuteQueueTask(
+	ctx context.Context,
+	_Request *shared.ExecuteQueueTaskRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	var result history.HistoryService_ExecuteQueueTask_Result
+	args := history.HistoryService_ExecuteQueueTask_Helper.Args(_Request)
+
+	if c.nwc != nil && c.nwc.Enabled() {
+		if err = c.nwc.Call(ctx, args, &result, opts...); err != nil {
+			return
+		}
+	} else {
+		var body wire.Value
+		if body, err = c.c.Call(ctx, args, opts...); err != nil {
+			return
+		}
+
+		if err = result.FromWire(body); err != nil {
+			return
+		}
+	}
+
+	err = history.HistoryService_ExecuteQueueTask_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) GetCrossClusterTasks(
 	ctx context.Context,
 	_Request *shared.GetCrossClusterTasksRequest,
@@ -609,6 +735,34 @@ func (c client) GetReplicationMessages(
 	return
 }
 
+func (c client) ListQueueTasks(
+	ctx context.Context,
+	_Request *shared.ListQueueTasksRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.ListQueueTasksResponse, err error) {
+
+	var result history.HistoryService_ListQueueTasks_Result
+	args := history.HistoryService_ListQueueTasks_Helper.Args(_Request)
+
+	if c.nwc != nil && c.nwc.Enabled() {
+		if err = c.nwc.Call(ctx, args, &result, opts...); err != nil {
+			return
+		}
+	} else {
+		var body wire.Value
+		if body, err = c.c.Call(ctx, args, opts...); err != nil {
+			return
+		}
+
+		if err = result.FromWire(body); err != nil {
+			return
+		}
+	}
+
+	success, err = history.HistoryService_ListQueueTasks_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) MergeDLQMessages(
 	ctx context.Context,
 	_Request *replicator.MergeDLQMessagesRequest,
@@ -637,6 +791,34 @@ func (c client) MergeDLQMessages(
 	return
 }
 
+func (c client) MigrateShardExecutions(
+	ctx context.Context,
+	_Request *history.MigrateShardExecutionsRequest,
+	opts ...yarpc.CallOption,
+) (success *history.MigrateShardExecutionsResponse, err error) {
+
+	var result history.HistoryService_MigrateShardExecutions_Result
+	args := history.HistoryService_MigrateShardExecutions_Helper.Args(_Request)
+
+	if c.nwc != nil && c.nwc.Enabled() {
+		if err = c.nwc.Call(ctx, args, &result, opts...); err != nil {
+			return
+		}
+	} else {
+		var body wire.Value
+		if body, err = c.c.Call(ctx, args, opts...); err != nil {
+			return
+		}
+
+		if err = result.FromWire(body); err != nil {
+			return
+		}
+	}
+
+	success, err = history.HistoryService_MigrateShardExecutions_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) NotifyFailoverMarkers(
 	ctx context.Context,
 	_Request *history.NotifyFailoverMarkersRequest,
@@ -1532,3 +1714,59 @@ func (c client) TerminateWorkflowExecution(
 	err = history.HistoryService_TerminateWorkflowExecution_Helper.UnwrapResponse(&result)
 	return
 }
+
+func (c client) UpdateActivityOptions(
+	ctx context.Context,
+	_UpdateRequest *history.UpdateActivityOptionsRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	var result history.HistoryService_UpdateActivityOptions_Result
+	args := history.HistoryService_UpdateActivityOptions_Helper.Args(_UpdateRequest)
+
+	if c.nwc != nil && c.nwc.Enabled() {
+		if err = c.nwc.Call(ctx, args, &result, opts...); err != nil {
+			return
+		}
+	} else {
+		var body wire.Value
+		if body, err = c.c.Call(ctx, args, opts...); err != nil {
+			return
+		}
+
+		if err = result.FromWire(body); err != nil {
+			return
+		}
+	}
+
+	err = history.HistoryService_UpdateActivityOptions_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) VerifyWorkflowExecution(
+	ctx context.Context,
+	_Request *history.VerifyWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.VerifyWorkflowExecutionResponse, err error) {
+
+	var result history.HistoryService_VerifyWorkflowExecution_Result
+	args := history.HistoryService_VerifyWorkflowExecution_Helper.Args(_Request)
+
+	if c.nwc != nil && c.nwc.Enabled() {
+		if err = c.nwc.Call(ctx, args, &result, opts...); err != nil {
+			return
+		}
+	} else {
+		var body wire.Value
+		if body, err = c.c.Call(ctx, args, opts...); err != nil {
+			return
+		}
+
+		if err = result.FromWire(body); err != nil {
+			return
+		}
+	}
+
+	success, err = history.HistoryService_VerifyWorkflowExecution_Helper.UnwrapResponse(&result)
+	return
+}
@@ -46,6 +46,11 @@ type Interface interface {
 		Request *shared.CloseShardRequest,
 	) error
 
+	DeleteWorkflowExecution(
+		ctx context.Context,
+		DeleteRequest *history.DeleteWorkflowExecutionRequest,
+	) (*shared.DeleteWorkflowExecutionResponse, error)
+
 	DescribeHistoryHost(
 		ctx context.Context,
 		Request *shared.DescribeHistoryHostRequest,
@@ -61,11 +66,21 @@ type Interface interface {
 		Request *shared.DescribeQueueRequest,
 	) (*shared.DescribeQueueResponse, error)
 
+	DescribeReplicationStatus(
+		ctx context.Context,
+		Request *history.DescribeReplicationStatusRequest,
+	) (*shared.DescribeReplicationStatusResponse, error)
+
 	DescribeWorkflowExecution(
 		ctx context.Context,
 		DescribeRequest *history.DescribeWorkflowExecutionRequest,
 	) (*shared.DescribeWorkflowExecutionResponse, error)
 
+	ExecuteQueueTask(
+		ctx context.Context,
+		Request *shared.ExecuteQueueTaskRequest,
+	) error
+
 	GetCrossClusterTasks(
 		ctx context.Context,
 		Request *shared.GetCrossClusterTasksRequest,
@@ -91,11 +106,21 @@ type Interface interface {
 		Request *replicator.GetReplicationMessagesRequest,
 	) (*replicator.GetReplicationMessagesResponse, error)
 
+	ListQueueTasks(
+		ctx context.Context,
+		Request *shared.ListQueueTasksRequest,
+	) (*shared.ListQueueTasksResponse, error)
+
 	MergeDLQMessages(
 		ctx context.Context,
 		Request *replicator.MergeDLQMessagesRequest,
 	) (*replicator.MergeDLQMessagesResponse, error)
 
+	MigrateShardExecutions(
+		ctx context.Context,
+		Request *history.MigrateShardExecutionsRequest,
+	) (*history.MigrateShardExecutionsResponse, error)
+
 	NotifyFailoverMarkers(
 		ctx context.Context,
 		Request *history.NotifyFailoverMarkersRequest,
@@ -255,6 +280,16 @@ type Interface interface {
 		ctx context.Context,
 		TerminateRequest *history.TerminateWorkflowExecutionRequest,
 	) error
+
+	UpdateActivityOptions(
+		ctx context.Context,
+		UpdateRequest *history.UpdateActivityOptionsRequest,
+	) error
+
+	VerifyWorkflowExecution(
+		ctx context.Context,
+		Request *history.VerifyWorkflowExecutionRequest,
+	) (*shared.VerifyWorkflowExecutionResponse, error)
 }
 
 // New prepares an implementation of the HistoryService service for
@@ -280,6 +315,18 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: history.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "DeleteWorkflowExecution",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:   transport.Unary,
+					Unary:  thrift.UnaryHandler(h.DeleteWorkflowExecution),
+					NoWire: deleteworkflowexecution_NoWireHandler{impl},
+				},
+				Signature:    "DeleteWorkflowExecution(DeleteRequest *history.DeleteWorkflowExecutionRequest) (*shared.DeleteWorkflowExecutionResponse)",
+				ThriftModule: history.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "DescribeHistoryHost",
 				HandlerSpec: thrift.HandlerSpec{
@@ -316,6 +363,18 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: history.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "DescribeReplicationStatus",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:   transport.Unary,
+					Unary:  thrift.UnaryHandler(h.DescribeReplicationStatus),
+					NoWire: describereplicationstatus_NoWireHandler{impl},
+				},
+				Signature:    "DescribeReplicationStatus(Request *history.DescribeReplicationStatusRequest) (*shared.DescribeReplicationStatusResponse)",
+				ThriftModule: history.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "DescribeWorkflowExecution",
 				HandlerSpec: thrift.HandlerSpec{
@@ -328,6 +387,18 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: history.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "ExecuteQueueTask",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:   transport.Unary,
+					Unary:  thrift.UnaryHandler(h.ExecuteQueueTask),
+					NoWire: executequeuetask_NoWireHandler{impl},
+				},
+				Signature:    "ExecuteQueueTask(Request *shared.ExecuteQueueTaskRequest)",
+				ThriftModule: history.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "GetCrossClusterTasks",
 				HandlerSpec: thrift.HandlerSpec{
@@ -388,6 +459,18 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: history.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "ListQueueTasks",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:   transport.Unary,
+					Unary:  thrift.UnaryHandler(h.ListQueueTasks),
+					NoWire: listqueuetasks_NoWireHandler{impl},
+				},
+				Signature:    "ListQueueTasks(Request *shared.ListQueueTasksRequest) (*shared.ListQueueTasksResponse)",
+				ThriftModule: history.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "MergeDLQMessages",
 				HandlerSpec: thrift.HandlerSpec{
@@ -400,6 +483,18 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: history.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "MigrateShardExecutions",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:   transport.Unary,
+					Unary:  thrift.UnaryHandler(h.MigrateShardExecutions),
+					NoWire: migrateshardexecutions_NoWireHandler{impl},
+				},
+				Signature:    "MigrateShardExecutions(Request *history.MigrateShardExecutionsRequest) (*history.MigrateShardExecutionsResponse)",
+				ThriftModule: history.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "NotifyFailoverMarkers",
 				HandlerSpec: thrift.HandlerSpec{
@@ -783,10 +878,34 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				Signature:    "TerminateWorkflowExecution(TerminateRequest *history.TerminateWorkflowExecutionRequest)",
 				ThriftModule: history.ThriftModule,
 			},
+
+			thrift.Method{
+				Name: "UpdateActivityOptions",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:   transport.Unary,
+					Unary:  thrift.UnaryHandler(h.UpdateActivityOptions),
+					NoWire: updateactivityoptions_NoWireHandler{impl},
+				},
+				Signature:    "UpdateActivityOptions(UpdateRequest *history.UpdateActivityOptionsRequest)",
+				ThriftModule: history.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "VerifyWorkflowExecution",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:   transport.Unary,
+					Unary:  thrift.UnaryHandler(h.VerifyWorkflowExecution),
+					NoWire: verifyworkflowexecution_NoWireHandler{impl},
+				},
+				Signature:    "VerifyWorkflowExecution(Request *history.VerifyWorkflowExecutionRequest) (*shared.VerifyWorkflowExecutionResponse)",
+				ThriftModule: history.ThriftModule,
+			},
 		},
 	}
 
-	procedures := make([]transport.Procedure, 0, 43)
+	procedures := make([]transport.Procedure, 0, 50)
 	procedures = append(procedures, thrift.BuildProcedures(service, opts...)...)
 	return procedures
 }
@@ -827,6 +946,36 @@ func (h handler) CloseShard(ctx context.Context, body wire.Value) (thrift.Respon
 	return response, err
 }
 
+func (h handler) DeleteWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_DeleteWorkflowExecution_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, yarpcerrors.InvalidArgumentErrorf(
+			"could not decode Thrift request for service 'HistoryService' procedure 'DeleteWorkflowExecution': %w", err)
+	}
+
+	success, appErr := h.impl.DeleteWorkflowExecution(ctx, args.DeleteRequest)
+
+	hadError := appErr != nil
+	result, err := history.HistoryService_DeleteWorkflowExecution_Helper.WrapResponse(success, appErr)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+		if namer, ok := appErr.(yarpcErrorNamer); ok {
+			response.ApplicationErrorName = namer.YARPCErrorName()
+		}
+		if extractor, ok := appErr.(yarpcErrorCoder); ok {
+			response.ApplicationErrorCode = extractor.YARPCErrorCode()
+		}
+		if appErr != nil {
+			response.ApplicationErrorDetails = appErr.Error()
+		}
+	}
+
+	return response, err
+}
+
 func (h handler) DescribeHistoryHost(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args history.HistoryService_DescribeHistoryHost_Args
 	if err := args.FromWire(body); err != nil {
@@ -917,6 +1066,36 @@ func (h handler) DescribeQueue(ctx context.Context, body wire.Value) (thrift.Res
 	return response, err
 }
 
+func (h handler) DescribeReplicationStatus(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_DescribeReplicationStatus_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, yarpcerrors.InvalidArgumentErrorf(
+			"could not decode Thrift request for service 'HistoryService' procedure 'DescribeReplicationStatus': %w", err)
+	}
+
+	success, appErr := h.impl.DescribeReplicationStatus(ctx, args.Request)
+
+	hadError := appErr != nil
+	result, err := history.HistoryService_DescribeReplicationStatus_Helper.WrapResponse(success, appErr)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+		if namer, ok := appErr.(yarpcErrorNamer); ok {
+			response.ApplicationErrorName = namer.YARPCErrorName()
+		}
+		if extractor, ok := appErr.(yarpcErrorCoder); ok {
+			response.ApplicationErrorCode = extractor.YARPCErrorCode()
+		}
+		if appErr != nil {
+			response.ApplicationErrorDetails = appErr.Error()
+		}
+	}
+
+	return response, err
+}
+
 func (h handler) DescribeWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args history.HistoryService_DescribeWorkflowExecution_Args
 	if err := args.FromWire(body); err != nil {
@@ -947,6 +1126,36 @@ func (h handler) DescribeWorkflowExecution(ctx context.Context, body wire.Value)
 	return response, err
 }
 
+func (h handler) ExecuteQueueTask(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_ExecuteQueueTask_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, yarpcerrors.InvalidArgumentErrorf(
+			"could not decode Thrift request for service 'HistoryService' procedure 'ExecuteQueueTask': %w", err)
+	}
+
+	appErr := h.impl.ExecuteQueueTask(ctx, args.Request)
+
+	hadError := appErr != nil
+	result, err := history.HistoryService_ExecuteQueueTask_Helper.WrapResponse(appErr)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+		if namer, ok := appErr.(yarpcErrorNamer); ok {
+			response.ApplicationErrorName = namer.YARPCErrorName()
+		}
+		if extractor, ok := appErr.(yarpcErrorCoder); ok {
+			response.ApplicationErrorCode = extractor.YARPCErrorCode()
+		}
+		if appErr != nil {
+			response.ApplicationErrorDetails = appErr.Error()
+		}
+	}
+
+	return response, err
+}
+
 func (h handler) GetCrossClusterTasks(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args history.HistoryService_GetCrossClusterTasks_Args
 	if err := args.FromWire(body); err != nil {
@@ -1097,6 +1306,36 @@ func (h handler) GetReplicationMessages(ctx context.Context, body wire.Value) (t
 	return response, err
 }
 
+func (h handler) ListQueueTasks(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_ListQueueTasks_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, yarpcerrors.InvalidArgumentErrorf(
+			"could not decode Thrift request for service 'HistoryService' procedure 'ListQueueTasks': %w", err)
+	}
+
+	success, appErr := h.impl.ListQueueTasks(ctx, args.Request)
+
+	hadError := appErr != nil
+	result, err := history.HistoryService_ListQueueTasks_Helper.WrapResponse(success, appErr)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+		if namer, ok := appErr.(yarpcErrorNamer); ok {
+			response.ApplicationErrorName = namer.YARPCErrorName()
+		}
+		if extractor, ok := appErr.(yarpcErrorCoder); ok {
+			response.ApplicationErrorCode = extractor.YARPCErrorCode()
+		}
+		if appErr != nil {
+			response.ApplicationErrorDetails = appErr.Error()
+		}
+	}
+
+	return response, err
+}
+
 func (h handler) MergeDLQMessages(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args history.HistoryService_MergeDLQMessages_Args
 	if err := args.FromWire(body); err != nil {
@@ -1127,6 +1366,36 @@ func (h handler) MergeDLQMessages(ctx context.Context, body wire.Value) (thrift.
 	return response, err
 }
 
+func (h handler) MigrateShardExecutions(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_MigrateShardExecutions_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, yarpcerrors.InvalidArgumentErrorf(
+			"could not decode Thrift request for service 'HistoryService' procedure 'MigrateShardExecutions': %w", err)
+	}
+
+	success, appErr := h.impl.MigrateShardExecutions(ctx, args.Request)
+
+	hadError := appErr != nil
+	result, err := history.HistoryService_MigrateShardExecutions_Helper.WrapResponse(success, appErr)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+		if namer, ok := appErr.(yarpcErrorNamer); ok {
+			response.ApplicationErrorName = namer.YARPCErrorName()
+		}
+		if extractor, ok := appErr.(yarpcErrorCoder); ok {
+			response.ApplicationErrorCode = extractor.YARPCErrorCode()
+		}
+		if appErr != nil {
+			response.ApplicationErrorDetails = appErr.Error()
+		}
+	}
+
+	return response, err
+}
+
 func (h handler) NotifyFailoverMarkers(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args history.HistoryService_NotifyFailoverMarkers_Args
 	if err := args.FromWire(body); err != nil {
@@ -2087,6 +2356,66 @@ func (h handler) TerminateWorkflowExecution(ctx context.Context, body wire.Value
 	return response, err
 }
 
+func (h handler) UpdateActivityOptions(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_UpdateActivityOptions_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, yarpcerrors.InvalidArgumentErrorf(
+			"could not decode Thrift request for service 'HistoryService' procedure 'UpdateActivityOptions': %w", err)
+	}
+
+	appErr := h.impl.UpdateActivityOptions(ctx, args.UpdateRequest)
+
+	hadError := appErr != nil
+	result, err := history.HistoryService_UpdateActivityOptions_Helper.WrapResponse(appErr)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+		if namer, ok := appErr.(yarpcErrorNamer); ok {
+			response.ApplicationErrorName = namer.YARPCErrorName()
+		}
+		if extractor, ok := appErr.(yarpcErrorCoder); ok {
+			response.ApplicationErrorCode = extractor.YARPCErrorCode()
+		}
+		if appErr != nil {
+			response.ApplicationErrorDetails = appErr.Error()
+		}
+	}
+
+	return response, err
+}
+
+func (h handler) VerifyWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_VerifyWorkflowExecution_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, yarpcerrors.InvalidArgumentErrorf(
+			"could not decode Thrift request for service 'HistoryService' procedure 'VerifyWorkflowExecution': %w", err)
+	}
+
+	success, appErr := h.impl.VerifyWorkflowExecution(ctx, args.Request)
+
+	hadError := appErr != nil
+	result, err := history.HistoryService_VerifyWorkflowExecution_Helper.WrapResponse(success, appErr)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+		if namer, ok := appErr.(yarpcErrorNamer); ok {
+			response.ApplicationErrorName = namer.YARPCErrorName()
+		}
+		if extractor, ok := appErr.(yarpcErrorCoder); ok {
+			response.ApplicationErrorCode = extractor.YARPCErrorCode()
+		}
+		if appErr != nil {
+			response.ApplicationErrorDetails = appErr.Error()
+		}
+	}
+
+	return response, err
+}
+
 type closeshard_NoWireHandler struct{ impl Interface }
 
 func (h closeshard_NoWireHandler) HandleNoWire(ctx context.Context, nwc *thrift.NoWireCall) (thrift.NoWireResponse, error) {
@@ -2124,6 +2453,43 @@ func (h closeshard_NoWireHandler) HandleNoWire(ctx context.Context, nwc *thrift.
 
 }
 
+type deleteworkflowexecution_NoWireHandler struct{ impl Interface }
+
+func (h deleteworkflowexecution_NoWireHandler) HandleNoWire(ctx context.Context, nwc *thrift.NoWireCall) (thrift.NoWireResponse, error) {
+	var (
+		args history.HistoryService_DeleteWorkflowExecution_Args
+		rw   stream.ResponseWriter
+		err  error
+	)
+
+	rw, err = nwc.RequestReader.ReadRequest(ctx, nwc.EnvelopeType, nwc.Reader, &args)
+	if err != nil {
+		return thrift.NoWireResponse{}, yarpcerrors.InvalidArgumentErrorf(
+			"could not decode (via no wire) Thrift request for service 'HistoryService' procedure 'DeleteWorkflowExecution': %w", err)
+	}
+
+	success, appErr := h.impl.DeleteWorkflowExecution(ctx, args.DeleteRequest)
+
+	hadError := appErr != nil
+	result, err := history.HistoryService_DeleteWorkflowExecution_Helper.WrapResponse(success, appErr)
+	response := thrift.NoWireResponse{ResponseWriter: rw}
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+		if namer, ok := appErr.(yarpcErrorNamer); ok {
+			response.ApplicationErrorName = namer.YARPCErrorName()
+		}
+		if extractor, ok := appErr.(yarpcErrorCoder); ok {
+			response.ApplicationErrorCode = extractor.YARPCErrorCode()
+		}
+		if appErr != nil {
+			response.ApplicationErrorDetails = appErr.Error()
+		}
+	}
+	return response, err
+
+}
+
 type describehistoryhost_NoWireHandler struct{ impl Interface }
 
 func (h describehistoryhost_NoWireHandler) HandleNoWire(ctx context.Context, nwc *thrift.NoWireCall) (thrift.NoWireResponse, error) {
@@ -2235,6 +2601,43 @@ func (h describequeue_NoWireHandler) HandleNoWire(ctx context.Context, nwc *thri
 
 }
 
+type describereplicationstatus_NoWireHandler struct{ impl Interface }
+
+func (h describereplicationstatus_NoWireHandler) HandleNoWire(ctx context.Context, nwc *thrift.NoWireCall) (thrift.NoWireResponse, error) {
+	var (
+		args history.HistoryService_DescribeReplicationStatus_Args
+		rw   stream.ResponseWriter
+		err  error
+	)
+
+	rw, err = nwc.RequestReader.ReadRequest(ctx, nwc.EnvelopeType, nwc.Reader, &args)
+	if err != nil {
+		return thrift.NoWireResponse{}, yarpcerrors.InvalidArgumentErrorf(
+			"could not decode (via no wire) Thrift request for service 'HistoryService' procedure 'DescribeReplicationStatus': %w", err)
+	}
+
+	success, appErr := h.impl.DescribeReplicationStatus(ctx, args.Request)
+
+	hadError := appErr != nil
+	result, err := history.HistoryService_DescribeReplicationStatus_Helper.WrapResponse(success, appErr)
+	response := thrift.NoWireResponse{ResponseWriter: rw}
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+		if namer, ok := appErr.(yarpcErrorNamer); ok {
+			response.ApplicationErrorName = namer.YARPCErrorName()
+		}
+		if extractor, ok := appErr.(yarpcErrorCoder); ok {
+			response.ApplicationErrorCode = extractor.YARPCErrorCode()
+		}
+		if appErr != nil {
+			response.ApplicationErrorDetails = appErr.Error()
+		}
+	}
+	return response, err
+
+}
+
 type describeworkflowexecution_NoWireHandler struct{ impl Interface }
 
 func (h describeworkflowexecution_NoWireHandler) HandleNoWire(ctx context.Context, nwc *thrift.NoWireCall) (thrift.NoWireResponse, error) {
@@ -2272,6 +2675,43 @@ func (h describeworkflowexecution_NoWireHandler) HandleNoWire(ctx context.Contex
 
 }
 
+type executequeuetask_NoWireHandler struct{ impl Interface }
+
+func (h executequeuetask_NoWireHandler) HandleNoWire(ctx context.Context, nwc *thrift.NoWireCall) (thrift.NoWireResponse, error) {
+	var (
+		args history.HistoryService_ExecuteQueueTask_Args
+		rw   stream.ResponseWriter
+		err  error
+	)
+
+	rw, err = nwc.RequestReader.ReadRequest(ctx, nwc.EnvelopeType, nwc.Reader, &args)
+	if err != nil {
+		return thrift.NoWireResponse{}, yarpcerrors.InvalidArgumentErrorf(
+			"could not decode (via no wire) Thrift request for service 'HistoryService' procedure 'ExecuteQueueTask': %w", err)
+	}
+
+	appErr := h.impl.ExecuteQueueTask(ctx, args.Request)
+
+	hadError := appErr != nil
+	result, err := history.HistoryService_ExecuteQueueTask_Helper.WrapResponse(appErr)
+	response := thrift.NoWireResponse{ResponseWriter: rw}
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+		if namer, ok := appErr.(yarpcErrorNamer); ok {
+			response.ApplicationErrorName = namer.YARPCErrorName()
+		}
+		if extractor, ok := appErr.(yarpcErrorCoder); ok {
+			response.ApplicationErrorCode = extractor.YARPCErrorCode()
+		}
+		if appErr != nil {
+			response.ApplicationErrorDetails = appErr.Error()
+		}
+	}
+	return response, err
+
+}
+
 type getcrossclustertasks_NoWireHandler struct{ impl Interface }
 
 func (h getcrossclustertasks_NoWireHandler) HandleNoWire(ctx context.Context, nwc *thrift.NoWireCall) (thrift.NoWireResponse, error) {
@@ -2457,6 +2897,43 @@ func (h getreplicationmessages_NoWireHandler) HandleNoWire(ctx context.Context,
 
 }
 
+type listqueuetasks_NoWireHandler struct{ impl Interface }
+
+func (h listqueuetasks_NoWireHandler) HandleNoWire(ctx context.Context, nwc *thrift.NoWireCall) (thrift.NoWireResponse, error) {
+	var (
+		args history.HistoryService_ListQueueTasks_Args
+		rw   stream.ResponseWriter
+		err  error
+	)
+
+	rw, err = nwc.RequestReader.ReadRequest(ctx, nwc.EnvelopeType, nwc.Reader, &args)
+	if err != nil {
+		return thrift.NoWireResponse{}, yarpcerrors.InvalidArgumentErrorf(
+			"could not decode (via no wire) Thrift request for service 'HistoryService' procedure 'ListQueueTasks': %w", err)
+	}
+
+	success, appErr := h.impl.ListQueueTasks(ctx, args.Request)
+
+	hadError := appErr != nil
+	result, err := history.HistoryService_ListQueueTasks_Helper.WrapResponse(success, appErr)
+	response := thrift.NoWireResponse{ResponseWriter: rw}
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+		if namer, ok := appErr.(yarpcErrorNamer); ok {
+			response.ApplicationErrorName = namer.YARPCErrorName()
+		}
+		if extractor, ok := appErr.(yarpcErrorCoder); ok {
+			response.ApplicationErrorCode = extractor.YARPCErrorCode()
+		}
+		if appErr != nil {
+			response.ApplicationErrorDetails = appErr.Error()
+		}
+	}
+	return response, err
+
+}
+
 type mergedlqmessages_NoWireHandler struct{ impl Interface }
 
 func (h mergedlqmessages_NoWireHandler) HandleNoWire(ctx context.Context, nwc *thrift.NoWireCall) (thrift.NoWireResponse, error) {
@@ -2494,6 +2971,43 @@ func (h mergedlqmessages_NoWireHandler) HandleNoWire(ctx context.Context, nwc *t
 
 }
 
+type migrateshardexecutions_NoWireHandler struct{ impl Interface }
+
+func (h migrateshardexecutions_NoWireHandler) HandleNoWire(ctx context.Context, nwc *thrift.NoWireCall) (thrift.NoWireResponse, error) {
+	var (
+		args history.HistoryService_MigrateShardExecutions_Args
+		rw   stream.ResponseWriter
+		err  error
+	)
+
+	rw, err = nwc.RequestReader.ReadRequest(ctx, nwc.EnvelopeType, nwc.Reader, &args)
+	if err != nil {
+		return thrift.NoWireResponse{}, yarpcerrors.InvalidArgumentErrorf(
+			"could not decode (via no wire) Thrift request for service 'HistoryService' procedure 'MigrateShardExecutions': %w", err)
+	}
+
+	success, appErr := h.impl.MigrateShardExecutions(ctx, args.Request)
+
+	hadError := appErr != nil
+	result, err := history.HistoryService_MigrateShardExecutions_Helper.WrapResponse(success, appErr)
+	response := thrift.NoWireResponse{ResponseWriter: rw}
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+		if namer, ok := appErr.(yarpcErrorNamer); ok {
+			response.ApplicationErrorName = namer.YARPCErrorName()
+		}
+		if extractor, ok := appErr.(yarpcErrorCoder); ok {
+			response.ApplicationErrorCode = extractor.YARPCErrorCode()
+		}
+		if appErr != nil {
+			response.ApplicationErrorDetails = appErr.Error()
+		}
+	}
+	return response, err
+
+}
+
 type notifyfailovermarkers_NoWireHandler struct{ impl Interface }
 
 func (h notifyfailovermarkers_NoWireHandler) HandleNoWire(ctx context.Context, nwc *thrift.NoWireCall) (thrift.NoWireResponse, error) {
@@ -3677,3 +4191,77 @@ func (h terminateworkflowexecution_NoWireHandler) HandleNoWire(ctx context.Conte
 	return response, err
 
 }
+
+type updateactivityoptions_NoWireHandler struct{ impl Interface }
+
+func (h updateactivityoptions_NoWireHandler) HandleNoWire(ctx context.Context, nwc *thrift.NoWireCall) (thrift.NoWireResponse, error) {
+	var (
+		args history.HistoryService_UpdateActivityOptions_Args
+		rw   stream.ResponseWriter
+		err  error
+	)
+
+	rw, err = nwc.RequestReader.ReadRequest(ctx, nwc.EnvelopeType, nwc.Reader, &args)
+	if err != nil {
+		return thrift.NoWireResponse{}, yarpcerrors.InvalidArgumentErrorf(
+			"could not decode (via no wire) Thrift request for service 'HistoryService' procedure 'UpdateActivityOptions': %w", err)
+	}
+
+	appErr := h.impl.UpdateActivityOptions(ctx, args.UpdateRequest)
+
+	hadError := appErr != nil
+	result, err := history.HistoryService_UpdateActivityOptions_Helper.WrapResponse(appErr)
+	response := thrift.NoWireResponse{ResponseWriter: rw}
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+		if namer, ok := appErr.(yarpcErrorNamer); ok {
+			response.ApplicationErrorName = namer.YARPCErrorName()
+		}
+		if extractor, ok := appErr.(yarpcErrorCoder); ok {
+			response.ApplicationErrorCode = extractor.YARPCErrorCode()
+		}
+		if appErr != nil {
+			response.ApplicationErrorDetails = appErr.Error()
+		}
+	}
+	return response, err
+
+}
+
+type verifyworkflowexecution_NoWireHandler struct{ impl Interface }
+
+func (h verifyworkflowexecution_NoWireHandler) HandleNoWire(ctx context.Context, nwc *thrift.NoWireCall) (thrift.NoWireResponse, error) {
+	var (
+		args history.HistoryService_VerifyWorkflowExecution_Args
+		rw   stream.ResponseWriter
+		err  error
+	)
+
+	rw, err = nwc.RequestReader.ReadRequest(ctx, nwc.EnvelopeType, nwc.Reader, &args)
+	if err != nil {
+		return thrift.NoWireResponse{}, yarpcerrors.InvalidArgumentErrorf(
+			"could not decode (via no wire) Thrift request for service 'HistoryService' procedure 'VerifyWorkflowExecution': %w", err)
+	}
+
+	success, appErr := h.impl.VerifyWorkflowExecution(ctx, args.Request)
+
+	hadError := appErr != nil
+	result, err := history.HistoryService_VerifyWorkflowExecution_Helper.WrapResponse(success, appErr)
+	response := thrift.NoWireResponse{ResponseWriter: rw}
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+		if namer, ok := appErr.(yarpcErrorNamer); ok {
+			response.ApplicationErrorName = namer.YARPCErrorName()
+		}
+		if extractor, ok := appErr.(yarpcErrorCoder); ok {
+			response.ApplicationErrorCode = extractor.YARPCErrorCode()
+		}
+		if appErr != nil {
+			response.ApplicationErrorDetails = appErr.Error()
+		}
+	}
+	return response, err
+
+}
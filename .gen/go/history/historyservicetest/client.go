@@ -99,6 +99,39 @@ func (mr *_MockClientRecorder) CloseShard(
 	return mr.mock.ctrl.RecordCall(mr.mock, "CloseShard", args...)
 }
 
+// DeleteWorkflowExecution responds to a DeleteWorkflowExecution call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+//	client.EXPECT().DeleteWorkflowExecution(gomock.Any(), ...).Return(...)
+//	... := client.DeleteWorkflowExecution(...)
+func (m *MockClient) DeleteWorkflowExecution(
+	ctx context.Context,
+	_DeleteRequest *history.DeleteWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DeleteWorkflowExecutionResponse, err error) {
+
+	args := []interface{}{ctx, _DeleteRequest}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DeleteWorkflowExecution", args...)
+	success, _ = ret[i].(*shared.DeleteWorkflowExecutionResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DeleteWorkflowExecution(
+	ctx interface{},
+	_DeleteRequest interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _DeleteRequest}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DeleteWorkflowExecution", args...)
+}
+
 // DescribeHistoryHost responds to a DescribeHistoryHost call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -198,6 +231,39 @@ func (mr *_MockClientRecorder) DescribeQueue(
 	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeQueue", args...)
 }
 
+// DescribeReplicationStatus responds to a DescribeReplicationStatus call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+//	client.EXPECT().DescribeReplicationStatus(gomock.Any(), ...).Return(...)
+//	... := client.DescribeReplicationStatus(...)
+func (m *MockClient) DescribeReplicationStatus(
+	ctx context.Context,
+	_Request *history.DescribeReplicationStatusRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeReplicationStatusResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DescribeReplicationStatus", args...)
+	success, _ = ret[i].(*shared.DescribeReplicationStatusResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DescribeReplicationStatus(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeReplicationStatus", args...)
+}
+
 // DescribeWorkflowExecution responds to a DescribeWorkflowExecution call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -231,6 +297,37 @@ func (mr *_MockClientRecorder) DescribeWorkflowExecution(
 	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeWorkflowExecution", args...)
 }
 
+// ExecuteQueueTask responds to a ExecuteQueueTask call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+//	client.EXPECT().ExecuteQueueTask(gomock.Any(), ...).Return(...)
+//	... := client.ExecuteQueueTask(...)
+func (m *MockClient) ExecuteQueueTask(
+	ctx context.Context,
+	_Request *shared.ExecuteQueueTaskRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "ExecuteQueueTask", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) ExecuteQueueTask(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "ExecuteQueueTask", args...)
+}
+
 // GetCrossClusterTasks responds to a GetCrossClusterTasks call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -396,6 +493,39 @@ func (mr *_MockClientRecorder) GetReplicationMessages(
 	return mr.mock.ctrl.RecordCall(mr.mock, "GetReplicationMessages", args...)
 }
 
+// ListQueueTasks responds to a ListQueueTasks call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+//	client.EXPECT().ListQueueTasks(gomock.Any(), ...).Return(...)
+//	... := client.ListQueueTasks(...)
+func (m *MockClient) ListQueueTasks(
+	ctx context.Context,
+	_Request *shared.ListQueueTasksRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.ListQueueTasksResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "ListQueueTasks", args...)
+	success, _ = ret[i].(*shared.ListQueueTasksResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) ListQueueTasks(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "ListQueueTasks", args...)
+}
+
 // MergeDLQMessages responds to a MergeDLQMessages call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -429,6 +559,39 @@ func (mr *_MockClientRecorder) MergeDLQMessages(
 	return mr.mock.ctrl.RecordCall(mr.mock, "MergeDLQMessages", args...)
 }
 
+// MigrateShardExecutions responds to a MigrateShardExecutions call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+//	client.EXPECT().MigrateShardExecutions(gomock.Any(), ...).Return(...)
+//	... := client.MigrateShardExecutions(...)
+func (m *MockClient) MigrateShardExecutions(
+	ctx context.Context,
+	_Request *history.MigrateShardExecutionsRequest,
+	opts ...yarpc.CallOption,
+) (success *history.MigrateShardExecutionsResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "MigrateShardExecutions", args...)
+	success, _ = ret[i].(*history.MigrateShardExecutionsResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) MigrateShardExecutions(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "MigrateShardExecutions", args...)
+}
+
 // NotifyFailoverMarkers responds to a NotifyFailoverMarkers call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -1446,3 +1609,67 @@ func (mr *_MockClientRecorder) TerminateWorkflowExecution(
 	args := append([]interface{}{ctx, _TerminateRequest}, opts...)
 	return mr.mock.ctrl.RecordCall(mr.mock, "TerminateWorkflowExecution", args...)
 }
+
+// UpdateActivityOptions responds to a UpdateActivityOptions call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+//	client.EXPECT().UpdateActivityOptions(gomock.Any(), ...).Return(...)
+//	... := client.UpdateActivityOptions(...)
+func (m *MockClient) UpdateActivityOptions(
+	ctx context.Context,
+	_UpdateRequest *history.UpdateActivityOptionsRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _UpdateRequest}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "UpdateActivityOptions", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) UpdateActivityOptions(
+	ctx interface{},
+	_UpdateRequest interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _UpdateRequest}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "UpdateActivityOptions", args...)
+}
+
+// VerifyWorkflowExecution responds to a VerifyWorkflowExecution call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+//	client.EXPECT().VerifyWorkflowExecution(gomock.Any(), ...).Return(...)
+//	... := client.VerifyWorkflowExecution(...)
+func (m *MockClient) VerifyWorkflowExecution(
+	ctx context.Context,
+	_Request *history.VerifyWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.VerifyWorkflowExecutionResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "VerifyWorkflowExecution", args...)
+	success, _ = ret[i].(*shared.VerifyWorkflowExecutionResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) VerifyWorkflowExecution(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "VerifyWorkflowExecution", args...)
+}
@@ -48,6 +48,7 @@ type DLQType int32
 const (
 	DLQTypeReplication DLQType = 0
 	DLQTypeDomain      DLQType = 1
+	DLQTypeHistoryTask DLQType = 2
 )
 
 // DLQType_Values returns all recognized values of DLQType.
@@ -55,6 +56,7 @@ func DLQType_Values() []DLQType {
 	return []DLQType{
 		DLQTypeReplication,
 		DLQTypeDomain,
+		DLQTypeHistoryTask,
 	}
 }
 
@@ -71,6 +73,9 @@ func (v *DLQType) UnmarshalText(value []byte) error {
 	case "Domain":
 		*v = DLQTypeDomain
 		return nil
+	case "HistoryTask":
+		*v = DLQTypeHistoryTask
+		return nil
 	default:
 		val, err := strconv.ParseInt(s, 10, 32)
 		if err != nil {
@@ -93,6 +98,8 @@ func (v DLQType) MarshalText() ([]byte, error) {
 		return []byte("Replication"), nil
 	case 1:
 		return []byte("Domain"), nil
+	case 2:
+		return []byte("HistoryTask"), nil
 	}
 	return []byte(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -108,6 +115,8 @@ func (v DLQType) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 		enc.AddString("name", "Replication")
 	case 1:
 		enc.AddString("name", "Domain")
+	case 2:
+		enc.AddString("name", "HistoryTask")
 	}
 	return nil
 }
@@ -180,6 +189,8 @@ func (v DLQType) String() string {
 		return "Replication"
 	case 1:
 		return "Domain"
+	case 2:
+		return "HistoryTask"
 	}
 	return fmt.Sprintf("DLQType(%d)", w)
 }
@@ -202,6 +213,8 @@ func (v DLQType) MarshalJSON() ([]byte, error) {
 		return ([]byte)("\"Replication\""), nil
 	case 1:
 		return ([]byte)("\"Domain\""), nil
+	case 2:
+		return ([]byte)("\"HistoryTask\""), nil
 	}
 	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -3870,46 +3883,15 @@ func (v *GetReplicationMessagesResponse) IsSetMessagesByShard() bool {
 	return v != nil && v.MessagesByShard != nil
 }
 
-type HistoryTaskV2Attributes struct {
-	TaskId              *int64                       `json:"taskId,omitempty"`
-	DomainId            *string                      `json:"domainId,omitempty"`
-	WorkflowId          *string                      `json:"workflowId,omitempty"`
-	RunId               *string                      `json:"runId,omitempty"`
-	VersionHistoryItems []*shared.VersionHistoryItem `json:"versionHistoryItems,omitempty"`
-	Events              *shared.DataBlob             `json:"events,omitempty"`
-	NewRunEvents        *shared.DataBlob             `json:"newRunEvents,omitempty"`
-}
-
-type _List_VersionHistoryItem_ValueList []*shared.VersionHistoryItem
-
-func (v _List_VersionHistoryItem_ValueList) ForEach(f func(wire.Value) error) error {
-	for i, x := range v {
-		if x == nil {
-			return fmt.Errorf("invalid list '[]*shared.VersionHistoryItem', index [%v]: value is nil", i)
-		}
-		w, err := x.ToWire()
-		if err != nil {
-			return err
-		}
-		err = f(w)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (v _List_VersionHistoryItem_ValueList) Size() int {
-	return len(v)
-}
-
-func (_List_VersionHistoryItem_ValueList) ValueType() wire.Type {
-	return wire.TStruct
+type HistoryTaskDLQMessage struct {
+	MessageID         *int64                `json:"messageID,omitempty"`
+	ShardID           *int32                `json:"shardID,omitempty"`
+	TaskCategory      *int32                `json:"taskCategory,omitempty"`
+	Task              *shared.QueueTaskInfo `json:"task,omitempty"`
+	EnqueuedTimestamp *int64                `json:"enqueuedTimestamp,omitempty"`
 }
 
-func (_List_VersionHistoryItem_ValueList) Close() {}
-
-// ToWire translates a HistoryTaskV2Attributes struct into a Thrift-level intermediate
+// ToWire translates a HistoryTaskDLQMessage struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -3924,109 +3906,69 @@ func (_List_VersionHistoryItem_ValueList) Close() {}
 //	if err := binaryProtocol.Encode(x, writer); err != nil {
 //	  return err
 //	}
-func (v *HistoryTaskV2Attributes) ToWire() (wire.Value, error) {
+func (v *HistoryTaskDLQMessage) ToWire() (wire.Value, error) {
 	var (
-		fields [7]wire.Field
+		fields [5]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.TaskId != nil {
-		w, err = wire.NewValueI64(*(v.TaskId)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 5, Value: w}
-		i++
-	}
-	if v.DomainId != nil {
-		w, err = wire.NewValueString(*(v.DomainId)), error(nil)
+	if v.MessageID != nil {
+		w, err = wire.NewValueI64(*(v.MessageID)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.WorkflowId != nil {
-		w, err = wire.NewValueString(*(v.WorkflowId)), error(nil)
+	if v.ShardID != nil {
+		w, err = wire.NewValueI32(*(v.ShardID)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.RunId != nil {
-		w, err = wire.NewValueString(*(v.RunId)), error(nil)
+	if v.TaskCategory != nil {
+		w, err = wire.NewValueI32(*(v.TaskCategory)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
-	if v.VersionHistoryItems != nil {
-		w, err = wire.NewValueList(_List_VersionHistoryItem_ValueList(v.VersionHistoryItems)), error(nil)
+	if v.Task != nil {
+		w, err = v.Task.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 40, Value: w}
 		i++
 	}
-	if v.Events != nil {
-		w, err = v.Events.ToWire()
+	if v.EnqueuedTimestamp != nil {
+		w, err = wire.NewValueI64(*(v.EnqueuedTimestamp)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 50, Value: w}
 		i++
 	}
-	if v.NewRunEvents != nil {
-		w, err = v.NewRunEvents.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 70, Value: w}
-		i++
-	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _VersionHistoryItem_Read(w wire.Value) (*shared.VersionHistoryItem, error) {
-	var v shared.VersionHistoryItem
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _List_VersionHistoryItem_Read(l wire.ValueList) ([]*shared.VersionHistoryItem, error) {
-	if l.ValueType() != wire.TStruct {
-		return nil, nil
-	}
-
-	o := make([]*shared.VersionHistoryItem, 0, l.Size())
-	err := l.ForEach(func(x wire.Value) error {
-		i, err := _VersionHistoryItem_Read(x)
-		if err != nil {
-			return err
-		}
-		o = append(o, i)
-		return nil
-	})
-	l.Close()
-	return o, err
-}
-
-func _DataBlob_Read(w wire.Value) (*shared.DataBlob, error) {
-	var v shared.DataBlob
+func _QueueTaskInfo_Read(w wire.Value) (*shared.QueueTaskInfo, error) {
+	var v shared.QueueTaskInfo
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a HistoryTaskV2Attributes struct from its Thrift-level
+// FromWire deserializes a HistoryTaskDLQMessage struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a HistoryTaskV2Attributes struct
+// An error is returned if we were unable to build a HistoryTaskDLQMessage struct
 // from the provided intermediate representation.
 //
 //	x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -4034,75 +3976,59 @@ func _DataBlob_Read(w wire.Value) (*shared.DataBlob, error) {
 //	  return nil, err
 //	}
 //
-//	var v HistoryTaskV2Attributes
+//	var v HistoryTaskDLQMessage
 //	if err := v.FromWire(x); err != nil {
 //	  return nil, err
 //	}
 //	return &v, nil
-func (v *HistoryTaskV2Attributes) FromWire(w wire.Value) error {
+func (v *HistoryTaskDLQMessage) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
-		case 5:
+		case 10:
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.TaskId = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 10:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.DomainId = &x
+				v.MessageID = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.WorkflowId = &x
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.ShardID = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 30:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.RunId = &x
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.TaskCategory = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 40:
-			if field.Value.Type() == wire.TList {
-				v.VersionHistoryItems, err = _List_VersionHistoryItem_Read(field.Value.GetList())
-				if err != nil {
-					return err
-				}
-
-			}
-		case 50:
 			if field.Value.Type() == wire.TStruct {
-				v.Events, err = _DataBlob_Read(field.Value)
+				v.Task, err = _QueueTaskInfo_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 70:
-			if field.Value.Type() == wire.TStruct {
-				v.NewRunEvents, err = _DataBlob_Read(field.Value)
+		case 50:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.EnqueuedTimestamp = &x
 				if err != nil {
 					return err
 				}
@@ -4114,65 +4040,20 @@ func (v *HistoryTaskV2Attributes) FromWire(w wire.Value) error {
 	return nil
 }
 
-func _List_VersionHistoryItem_Encode(val []*shared.VersionHistoryItem, sw stream.Writer) error {
-
-	lh := stream.ListHeader{
-		Type:   wire.TStruct,
-		Length: len(val),
-	}
-	if err := sw.WriteListBegin(lh); err != nil {
-		return err
-	}
-
-	for i, v := range val {
-		if v == nil {
-			return fmt.Errorf("invalid list '[]*shared.VersionHistoryItem', index [%v]: value is nil", i)
-		}
-		if err := v.Encode(sw); err != nil {
-			return err
-		}
-	}
-	return sw.WriteListEnd()
-}
-
-// Encode serializes a HistoryTaskV2Attributes struct directly into bytes, without going
+// Encode serializes a HistoryTaskDLQMessage struct directly into bytes, without going
 // through an intermediary type.
 //
-// An error is returned if a HistoryTaskV2Attributes struct could not be encoded.
-func (v *HistoryTaskV2Attributes) Encode(sw stream.Writer) error {
+// An error is returned if a HistoryTaskDLQMessage struct could not be encoded.
+func (v *HistoryTaskDLQMessage) Encode(sw stream.Writer) error {
 	if err := sw.WriteStructBegin(); err != nil {
 		return err
 	}
 
-	if v.TaskId != nil {
-		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 5, Type: wire.TI64}); err != nil {
-			return err
-		}
-		if err := sw.WriteInt64(*(v.TaskId)); err != nil {
-			return err
-		}
-		if err := sw.WriteFieldEnd(); err != nil {
-			return err
-		}
-	}
-
-	if v.DomainId != nil {
-		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 10, Type: wire.TBinary}); err != nil {
-			return err
-		}
-		if err := sw.WriteString(*(v.DomainId)); err != nil {
-			return err
-		}
-		if err := sw.WriteFieldEnd(); err != nil {
-			return err
-		}
-	}
-
-	if v.WorkflowId != nil {
-		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 20, Type: wire.TBinary}); err != nil {
+	if v.MessageID != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 10, Type: wire.TI64}); err != nil {
 			return err
 		}
-		if err := sw.WriteString(*(v.WorkflowId)); err != nil {
+		if err := sw.WriteInt64(*(v.MessageID)); err != nil {
 			return err
 		}
 		if err := sw.WriteFieldEnd(); err != nil {
@@ -4180,11 +4061,11 @@ func (v *HistoryTaskV2Attributes) Encode(sw stream.Writer) error {
 		}
 	}
 
-	if v.RunId != nil {
-		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 30, Type: wire.TBinary}); err != nil {
+	if v.ShardID != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 20, Type: wire.TI32}); err != nil {
 			return err
 		}
-		if err := sw.WriteString(*(v.RunId)); err != nil {
+		if err := sw.WriteInt32(*(v.ShardID)); err != nil {
 			return err
 		}
 		if err := sw.WriteFieldEnd(); err != nil {
@@ -4192,11 +4073,11 @@ func (v *HistoryTaskV2Attributes) Encode(sw stream.Writer) error {
 		}
 	}
 
-	if v.VersionHistoryItems != nil {
-		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 40, Type: wire.TList}); err != nil {
+	if v.TaskCategory != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 30, Type: wire.TI32}); err != nil {
 			return err
 		}
-		if err := _List_VersionHistoryItem_Encode(v.VersionHistoryItems, sw); err != nil {
+		if err := sw.WriteInt32(*(v.TaskCategory)); err != nil {
 			return err
 		}
 		if err := sw.WriteFieldEnd(); err != nil {
@@ -4204,11 +4085,11 @@ func (v *HistoryTaskV2Attributes) Encode(sw stream.Writer) error {
 		}
 	}
 
-	if v.Events != nil {
-		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 50, Type: wire.TStruct}); err != nil {
+	if v.Task != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 40, Type: wire.TStruct}); err != nil {
 			return err
 		}
-		if err := v.Events.Encode(sw); err != nil {
+		if err := v.Task.Encode(sw); err != nil {
 			return err
 		}
 		if err := sw.WriteFieldEnd(); err != nil {
@@ -4216,11 +4097,11 @@ func (v *HistoryTaskV2Attributes) Encode(sw stream.Writer) error {
 		}
 	}
 
-	if v.NewRunEvents != nil {
-		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 70, Type: wire.TStruct}); err != nil {
+	if v.EnqueuedTimestamp != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 50, Type: wire.TI64}); err != nil {
 			return err
 		}
-		if err := v.NewRunEvents.Encode(sw); err != nil {
+		if err := sw.WriteInt64(*(v.EnqueuedTimestamp)); err != nil {
 			return err
 		}
 		if err := sw.WriteFieldEnd(); err != nil {
@@ -4231,47 +4112,663 @@ func (v *HistoryTaskV2Attributes) Encode(sw stream.Writer) error {
 	return sw.WriteStructEnd()
 }
 
-func _VersionHistoryItem_Decode(sr stream.Reader) (*shared.VersionHistoryItem, error) {
-	var v shared.VersionHistoryItem
+func _QueueTaskInfo_Decode(sr stream.Reader) (*shared.QueueTaskInfo, error) {
+	var v shared.QueueTaskInfo
 	err := v.Decode(sr)
 	return &v, err
 }
 
-func _List_VersionHistoryItem_Decode(sr stream.Reader) ([]*shared.VersionHistoryItem, error) {
-	lh, err := sr.ReadListBegin()
-	if err != nil {
-		return nil, err
-	}
-
-	if lh.Type != wire.TStruct {
-		for i := 0; i < lh.Length; i++ {
-			if err := sr.Skip(lh.Type); err != nil {
-				return nil, err
-			}
-		}
-		return nil, sr.ReadListEnd()
-	}
+// Decode deserializes a HistoryTaskDLQMessage struct directly from its Thrift-level
+// representation, without going through an intemediary type.
+//
+// An error is returned if a HistoryTaskDLQMessage struct could not be generated from the wire
+// representation.
+func (v *HistoryTaskDLQMessage) Decode(sr stream.Reader) error {
 
-	o := make([]*shared.VersionHistoryItem, 0, lh.Length)
-	for i := 0; i < lh.Length; i++ {
-		v, err := _VersionHistoryItem_Decode(sr)
-		if err != nil {
-			return nil, err
-		}
-		o = append(o, v)
+	if err := sr.ReadStructBegin(); err != nil {
+		return err
 	}
 
-	if err = sr.ReadListEnd(); err != nil {
-		return nil, err
+	fh, ok, err := sr.ReadFieldBegin()
+	if err != nil {
+		return err
 	}
-	return o, err
-}
 
-func _DataBlob_Decode(sr stream.Reader) (*shared.DataBlob, error) {
-	var v shared.DataBlob
-	err := v.Decode(sr)
-	return &v, err
-}
+	for ok {
+		switch {
+		case fh.ID == 10 && fh.Type == wire.TI64:
+			var x int64
+			x, err = sr.ReadInt64()
+			v.MessageID = &x
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 20 && fh.Type == wire.TI32:
+			var x int32
+			x, err = sr.ReadInt32()
+			v.ShardID = &x
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 30 && fh.Type == wire.TI32:
+			var x int32
+			x, err = sr.ReadInt32()
+			v.TaskCategory = &x
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 40 && fh.Type == wire.TStruct:
+			v.Task, err = _QueueTaskInfo_Decode(sr)
+			if err != nil {
+				return err
+			}
+
+		case fh.ID == 50 && fh.Type == wire.TI64:
+			var x int64
+			x, err = sr.ReadInt64()
+			v.EnqueuedTimestamp = &x
+			if err != nil {
+				return err
+			}
+
+		default:
+			if err := sr.Skip(fh.Type); err != nil {
+				return err
+			}
+		}
+
+		if err := sr.ReadFieldEnd(); err != nil {
+			return err
+		}
+
+		if fh, ok, err = sr.ReadFieldBegin(); err != nil {
+			return err
+		}
+	}
+
+	if err := sr.ReadStructEnd(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a HistoryTaskDLQMessage
+// struct.
+func (v *HistoryTaskDLQMessage) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.MessageID != nil {
+		fields[i] = fmt.Sprintf("MessageID: %v", *(v.MessageID))
+		i++
+	}
+	if v.ShardID != nil {
+		fields[i] = fmt.Sprintf("ShardID: %v", *(v.ShardID))
+		i++
+	}
+	if v.TaskCategory != nil {
+		fields[i] = fmt.Sprintf("TaskCategory: %v", *(v.TaskCategory))
+		i++
+	}
+	if v.Task != nil {
+		fields[i] = fmt.Sprintf("Task: %v", v.Task)
+		i++
+	}
+	if v.EnqueuedTimestamp != nil {
+		fields[i] = fmt.Sprintf("EnqueuedTimestamp: %v", *(v.EnqueuedTimestamp))
+		i++
+	}
+
+	return fmt.Sprintf("HistoryTaskDLQMessage{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _I32_EqualsPtr(lhs, rhs *int32) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this HistoryTaskDLQMessage match the
+// provided HistoryTaskDLQMessage.
+//
+// This function performs a deep comparison.
+func (v *HistoryTaskDLQMessage) Equals(rhs *HistoryTaskDLQMessage) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_I64_EqualsPtr(v.MessageID, rhs.MessageID) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.ShardID, rhs.ShardID) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.TaskCategory, rhs.TaskCategory) {
+		return false
+	}
+	if !((v.Task == nil && rhs.Task == nil) || (v.Task != nil && rhs.Task != nil && v.Task.Equals(rhs.Task))) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.EnqueuedTimestamp, rhs.EnqueuedTimestamp) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of HistoryTaskDLQMessage.
+func (v *HistoryTaskDLQMessage) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.MessageID != nil {
+		enc.AddInt64("messageID", *v.MessageID)
+	}
+	if v.ShardID != nil {
+		enc.AddInt32("shardID", *v.ShardID)
+	}
+	if v.TaskCategory != nil {
+		enc.AddInt32("taskCategory", *v.TaskCategory)
+	}
+	if v.Task != nil {
+		err = multierr.Append(err, enc.AddObject("task", v.Task))
+	}
+	if v.EnqueuedTimestamp != nil {
+		enc.AddInt64("enqueuedTimestamp", *v.EnqueuedTimestamp)
+	}
+	return err
+}
+
+// GetMessageID returns the value of MessageID if it is set or its
+// zero value if it is unset.
+func (v *HistoryTaskDLQMessage) GetMessageID() (o int64) {
+	if v != nil && v.MessageID != nil {
+		return *v.MessageID
+	}
+
+	return
+}
+
+// IsSetMessageID returns true if MessageID is not nil.
+func (v *HistoryTaskDLQMessage) IsSetMessageID() bool {
+	return v != nil && v.MessageID != nil
+}
+
+// GetShardID returns the value of ShardID if it is set or its
+// zero value if it is unset.
+func (v *HistoryTaskDLQMessage) GetShardID() (o int32) {
+	if v != nil && v.ShardID != nil {
+		return *v.ShardID
+	}
+
+	return
+}
+
+// IsSetShardID returns true if ShardID is not nil.
+func (v *HistoryTaskDLQMessage) IsSetShardID() bool {
+	return v != nil && v.ShardID != nil
+}
+
+// GetTaskCategory returns the value of TaskCategory if it is set or its
+// zero value if it is unset.
+func (v *HistoryTaskDLQMessage) GetTaskCategory() (o int32) {
+	if v != nil && v.TaskCategory != nil {
+		return *v.TaskCategory
+	}
+
+	return
+}
+
+// IsSetTaskCategory returns true if TaskCategory is not nil.
+func (v *HistoryTaskDLQMessage) IsSetTaskCategory() bool {
+	return v != nil && v.TaskCategory != nil
+}
+
+// GetTask returns the value of Task if it is set or its
+// zero value if it is unset.
+func (v *HistoryTaskDLQMessage) GetTask() (o *shared.QueueTaskInfo) {
+	if v != nil && v.Task != nil {
+		return v.Task
+	}
+
+	return
+}
+
+// IsSetTask returns true if Task is not nil.
+func (v *HistoryTaskDLQMessage) IsSetTask() bool {
+	return v != nil && v.Task != nil
+}
+
+// GetEnqueuedTimestamp returns the value of EnqueuedTimestamp if it is set or its
+// zero value if it is unset.
+func (v *HistoryTaskDLQMessage) GetEnqueuedTimestamp() (o int64) {
+	if v != nil && v.EnqueuedTimestamp != nil {
+		return *v.EnqueuedTimestamp
+	}
+
+	return
+}
+
+// IsSetEnqueuedTimestamp returns true if EnqueuedTimestamp is not nil.
+func (v *HistoryTaskDLQMessage) IsSetEnqueuedTimestamp() bool {
+	return v != nil && v.EnqueuedTimestamp != nil
+}
+
+type HistoryTaskV2Attributes struct {
+	TaskId              *int64                       `json:"taskId,omitempty"`
+	DomainId            *string                      `json:"domainId,omitempty"`
+	WorkflowId          *string                      `json:"workflowId,omitempty"`
+	RunId               *string                      `json:"runId,omitempty"`
+	VersionHistoryItems []*shared.VersionHistoryItem `json:"versionHistoryItems,omitempty"`
+	Events              *shared.DataBlob             `json:"events,omitempty"`
+	NewRunEvents        *shared.DataBlob             `json:"newRunEvents,omitempty"`
+}
+
+type _List_VersionHistoryItem_ValueList []*shared.VersionHistoryItem
+
+func (v _List_VersionHistoryItem_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid list '[]*shared.VersionHistoryItem', index [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_VersionHistoryItem_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_VersionHistoryItem_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_VersionHistoryItem_ValueList) Close() {}
+
+// ToWire translates a HistoryTaskV2Attributes struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//	x, err := v.ToWire()
+//	if err != nil {
+//	  return err
+//	}
+//
+//	if err := binaryProtocol.Encode(x, writer); err != nil {
+//	  return err
+//	}
+func (v *HistoryTaskV2Attributes) ToWire() (wire.Value, error) {
+	var (
+		fields [7]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.TaskId != nil {
+		w, err = wire.NewValueI64(*(v.TaskId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
+	if v.DomainId != nil {
+		w, err = wire.NewValueString(*(v.DomainId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.WorkflowId != nil {
+		w, err = wire.NewValueString(*(v.WorkflowId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.RunId != nil {
+		w, err = wire.NewValueString(*(v.RunId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.VersionHistoryItems != nil {
+		w, err = wire.NewValueList(_List_VersionHistoryItem_ValueList(v.VersionHistoryItems)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.Events != nil {
+		w, err = v.Events.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.NewRunEvents != nil {
+		w, err = v.NewRunEvents.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _VersionHistoryItem_Read(w wire.Value) (*shared.VersionHistoryItem, error) {
+	var v shared.VersionHistoryItem
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_VersionHistoryItem_Read(l wire.ValueList) ([]*shared.VersionHistoryItem, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*shared.VersionHistoryItem, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _VersionHistoryItem_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func _DataBlob_Read(w wire.Value) (*shared.DataBlob, error) {
+	var v shared.DataBlob
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a HistoryTaskV2Attributes struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a HistoryTaskV2Attributes struct
+// from the provided intermediate representation.
+//
+//	x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//	if err != nil {
+//	  return nil, err
+//	}
+//
+//	var v HistoryTaskV2Attributes
+//	if err := v.FromWire(x); err != nil {
+//	  return nil, err
+//	}
+//	return &v, nil
+func (v *HistoryTaskV2Attributes) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 5:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.TaskId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.WorkflowId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.RunId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TList {
+				v.VersionHistoryItems, err = _List_VersionHistoryItem_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TStruct {
+				v.Events, err = _DataBlob_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 70:
+			if field.Value.Type() == wire.TStruct {
+				v.NewRunEvents, err = _DataBlob_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+func _List_VersionHistoryItem_Encode(val []*shared.VersionHistoryItem, sw stream.Writer) error {
+
+	lh := stream.ListHeader{
+		Type:   wire.TStruct,
+		Length: len(val),
+	}
+	if err := sw.WriteListBegin(lh); err != nil {
+		return err
+	}
+
+	for i, v := range val {
+		if v == nil {
+			return fmt.Errorf("invalid list '[]*shared.VersionHistoryItem', index [%v]: value is nil", i)
+		}
+		if err := v.Encode(sw); err != nil {
+			return err
+		}
+	}
+	return sw.WriteListEnd()
+}
+
+// Encode serializes a HistoryTaskV2Attributes struct directly into bytes, without going
+// through an intermediary type.
+//
+// An error is returned if a HistoryTaskV2Attributes struct could not be encoded.
+func (v *HistoryTaskV2Attributes) Encode(sw stream.Writer) error {
+	if err := sw.WriteStructBegin(); err != nil {
+		return err
+	}
+
+	if v.TaskId != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 5, Type: wire.TI64}); err != nil {
+			return err
+		}
+		if err := sw.WriteInt64(*(v.TaskId)); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	if v.DomainId != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 10, Type: wire.TBinary}); err != nil {
+			return err
+		}
+		if err := sw.WriteString(*(v.DomainId)); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	if v.WorkflowId != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 20, Type: wire.TBinary}); err != nil {
+			return err
+		}
+		if err := sw.WriteString(*(v.WorkflowId)); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	if v.RunId != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 30, Type: wire.TBinary}); err != nil {
+			return err
+		}
+		if err := sw.WriteString(*(v.RunId)); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	if v.VersionHistoryItems != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 40, Type: wire.TList}); err != nil {
+			return err
+		}
+		if err := _List_VersionHistoryItem_Encode(v.VersionHistoryItems, sw); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	if v.Events != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 50, Type: wire.TStruct}); err != nil {
+			return err
+		}
+		if err := v.Events.Encode(sw); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	if v.NewRunEvents != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 70, Type: wire.TStruct}); err != nil {
+			return err
+		}
+		if err := v.NewRunEvents.Encode(sw); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
+	return sw.WriteStructEnd()
+}
+
+func _VersionHistoryItem_Decode(sr stream.Reader) (*shared.VersionHistoryItem, error) {
+	var v shared.VersionHistoryItem
+	err := v.Decode(sr)
+	return &v, err
+}
+
+func _List_VersionHistoryItem_Decode(sr stream.Reader) ([]*shared.VersionHistoryItem, error) {
+	lh, err := sr.ReadListBegin()
+	if err != nil {
+		return nil, err
+	}
+
+	if lh.Type != wire.TStruct {
+		for i := 0; i < lh.Length; i++ {
+			if err := sr.Skip(lh.Type); err != nil {
+				return nil, err
+			}
+		}
+		return nil, sr.ReadListEnd()
+	}
+
+	o := make([]*shared.VersionHistoryItem, 0, lh.Length)
+	for i := 0; i < lh.Length; i++ {
+		v, err := _VersionHistoryItem_Decode(sr)
+		if err != nil {
+			return nil, err
+		}
+		o = append(o, v)
+	}
+
+	if err = sr.ReadListEnd(); err != nil {
+		return nil, err
+	}
+	return o, err
+}
+
+func _DataBlob_Decode(sr stream.Reader) (*shared.DataBlob, error) {
+	var v shared.DataBlob
+	err := v.Decode(sr)
+	return &v, err
+}
 
 // Decode deserializes a HistoryTaskV2Attributes struct directly from its Thrift-level
 // representation, without going through an intemediary type.
@@ -4999,16 +5496,6 @@ func _DLQType_EqualsPtr(lhs, rhs *DLQType) bool {
 	return lhs == nil && rhs == nil
 }
 
-func _I32_EqualsPtr(lhs, rhs *int32) bool {
-	if lhs != nil && rhs != nil {
-
-		x := *lhs
-		y := *rhs
-		return (x == y)
-	}
-	return lhs == nil && rhs == nil
-}
-
 // Equals returns true if all the fields of this MergeDLQMessagesRequest match the
 // provided MergeDLQMessagesRequest.
 //
@@ -6293,12 +6780,42 @@ func (v *ReadDLQMessagesRequest) IsSetNextPageToken() bool {
 }
 
 type ReadDLQMessagesResponse struct {
-	Type                 *DLQType               `json:"type,omitempty"`
-	ReplicationTasks     []*ReplicationTask     `json:"replicationTasks,omitempty"`
-	NextPageToken        []byte                 `json:"nextPageToken,omitempty"`
-	ReplicationTasksInfo []*ReplicationTaskInfo `json:"replicationTasksInfo,omitempty"`
+	Type                 *DLQType                 `json:"type,omitempty"`
+	ReplicationTasks     []*ReplicationTask       `json:"replicationTasks,omitempty"`
+	NextPageToken        []byte                   `json:"nextPageToken,omitempty"`
+	ReplicationTasksInfo []*ReplicationTaskInfo   `json:"replicationTasksInfo,omitempty"`
+	HistoryTasks         []*HistoryTaskDLQMessage `json:"historyTasks,omitempty"`
+}
+
+type _List_HistoryTaskDLQMessage_ValueList []*HistoryTaskDLQMessage
+
+func (v _List_HistoryTaskDLQMessage_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid list '[]*HistoryTaskDLQMessage', index [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_HistoryTaskDLQMessage_ValueList) Size() int {
+	return len(v)
 }
 
+func (_List_HistoryTaskDLQMessage_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_HistoryTaskDLQMessage_ValueList) Close() {}
+
 // ToWire translates a ReadDLQMessagesResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
@@ -6316,7 +6833,7 @@ type ReadDLQMessagesResponse struct {
 //	}
 func (v *ReadDLQMessagesResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [4]wire.Field
+		fields [5]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -6354,10 +6871,42 @@ func (v *ReadDLQMessagesResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 40, Value: w}
 		i++
 	}
+	if v.HistoryTasks != nil {
+		w, err = wire.NewValueList(_List_HistoryTaskDLQMessage_ValueList(v.HistoryTasks)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
+func _HistoryTaskDLQMessage_Read(w wire.Value) (*HistoryTaskDLQMessage, error) {
+	var v HistoryTaskDLQMessage
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_HistoryTaskDLQMessage_Read(l wire.ValueList) ([]*HistoryTaskDLQMessage, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*HistoryTaskDLQMessage, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _HistoryTaskDLQMessage_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
 // FromWire deserializes a ReadDLQMessagesResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -6413,6 +6962,14 @@ func (v *ReadDLQMessagesResponse) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 50:
+			if field.Value.Type() == wire.TList {
+				v.HistoryTasks, err = _List_HistoryTaskDLQMessage_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -6420,6 +6977,27 @@ func (v *ReadDLQMessagesResponse) FromWire(w wire.Value) error {
 	return nil
 }
 
+func _List_HistoryTaskDLQMessage_Encode(val []*HistoryTaskDLQMessage, sw stream.Writer) error {
+
+	lh := stream.ListHeader{
+		Type:   wire.TStruct,
+		Length: len(val),
+	}
+	if err := sw.WriteListBegin(lh); err != nil {
+		return err
+	}
+
+	for i, v := range val {
+		if v == nil {
+			return fmt.Errorf("invalid list '[]*HistoryTaskDLQMessage', index [%v]: value is nil", i)
+		}
+		if err := v.Encode(sw); err != nil {
+			return err
+		}
+	}
+	return sw.WriteListEnd()
+}
+
 // Encode serializes a ReadDLQMessagesResponse struct directly into bytes, without going
 // through an intermediary type.
 //
@@ -6477,9 +7055,57 @@ func (v *ReadDLQMessagesResponse) Encode(sw stream.Writer) error {
 		}
 	}
 
+	if v.HistoryTasks != nil {
+		if err := sw.WriteFieldBegin(stream.FieldHeader{ID: 50, Type: wire.TList}); err != nil {
+			return err
+		}
+		if err := _List_HistoryTaskDLQMessage_Encode(v.HistoryTasks, sw); err != nil {
+			return err
+		}
+		if err := sw.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+
 	return sw.WriteStructEnd()
 }
 
+func _HistoryTaskDLQMessage_Decode(sr stream.Reader) (*HistoryTaskDLQMessage, error) {
+	var v HistoryTaskDLQMessage
+	err := v.Decode(sr)
+	return &v, err
+}
+
+func _List_HistoryTaskDLQMessage_Decode(sr stream.Reader) ([]*HistoryTaskDLQMessage, error) {
+	lh, err := sr.ReadListBegin()
+	if err != nil {
+		return nil, err
+	}
+
+	if lh.Type != wire.TStruct {
+		for i := 0; i < lh.Length; i++ {
+			if err := sr.Skip(lh.Type); err != nil {
+				return nil, err
+			}
+		}
+		return nil, sr.ReadListEnd()
+	}
+
+	o := make([]*HistoryTaskDLQMessage, 0, lh.Length)
+	for i := 0; i < lh.Length; i++ {
+		v, err := _HistoryTaskDLQMessage_Decode(sr)
+		if err != nil {
+			return nil, err
+		}
+		o = append(o, v)
+	}
+
+	if err = sr.ReadListEnd(); err != nil {
+		return nil, err
+	}
+	return o, err
+}
+
 // Decode deserializes a ReadDLQMessagesResponse struct directly from its Thrift-level
 // representation, without going through an intemediary type.
 //
@@ -6524,6 +7150,12 @@ func (v *ReadDLQMessagesResponse) Decode(sr stream.Reader) error {
 				return err
 			}
 
+		case fh.ID == 50 && fh.Type == wire.TList:
+			v.HistoryTasks, err = _List_HistoryTaskDLQMessage_Decode(sr)
+			if err != nil {
+				return err
+			}
+
 		default:
 			if err := sr.Skip(fh.Type); err != nil {
 				return err
@@ -6553,7 +7185,7 @@ func (v *ReadDLQMessagesResponse) String() string {
 		return "<nil>"
 	}
 
-	var fields [4]string
+	var fields [5]string
 	i := 0
 	if v.Type != nil {
 		fields[i] = fmt.Sprintf("Type: %v", *(v.Type))
@@ -6571,10 +7203,29 @@ func (v *ReadDLQMessagesResponse) String() string {
 		fields[i] = fmt.Sprintf("ReplicationTasksInfo: %v", v.ReplicationTasksInfo)
 		i++
 	}
+	if v.HistoryTasks != nil {
+		fields[i] = fmt.Sprintf("HistoryTasks: %v", v.HistoryTasks)
+		i++
+	}
 
 	return fmt.Sprintf("ReadDLQMessagesResponse{%v}", strings.Join(fields[:i], ", "))
 }
 
+func _List_HistoryTaskDLQMessage_Equals(lhs, rhs []*HistoryTaskDLQMessage) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Equals returns true if all the fields of this ReadDLQMessagesResponse match the
 // provided ReadDLQMessagesResponse.
 //
@@ -6597,10 +7248,24 @@ func (v *ReadDLQMessagesResponse) Equals(rhs *ReadDLQMessagesResponse) bool {
 	if !((v.ReplicationTasksInfo == nil && rhs.ReplicationTasksInfo == nil) || (v.ReplicationTasksInfo != nil && rhs.ReplicationTasksInfo != nil && _List_ReplicationTaskInfo_Equals(v.ReplicationTasksInfo, rhs.ReplicationTasksInfo))) {
 		return false
 	}
+	if !((v.HistoryTasks == nil && rhs.HistoryTasks == nil) || (v.HistoryTasks != nil && rhs.HistoryTasks != nil && _List_HistoryTaskDLQMessage_Equals(v.HistoryTasks, rhs.HistoryTasks))) {
+		return false
+	}
 
 	return true
 }
 
+type _List_HistoryTaskDLQMessage_Zapper []*HistoryTaskDLQMessage
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_HistoryTaskDLQMessage_Zapper.
+func (l _List_HistoryTaskDLQMessage_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
+	}
+	return err
+}
+
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
 // fast logging of ReadDLQMessagesResponse.
 func (v *ReadDLQMessagesResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
@@ -6619,6 +7284,9 @@ func (v *ReadDLQMessagesResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (e
 	if v.ReplicationTasksInfo != nil {
 		err = multierr.Append(err, enc.AddArray("replicationTasksInfo", (_List_ReplicationTaskInfo_Zapper)(v.ReplicationTasksInfo)))
 	}
+	if v.HistoryTasks != nil {
+		err = multierr.Append(err, enc.AddArray("historyTasks", (_List_HistoryTaskDLQMessage_Zapper)(v.HistoryTasks)))
+	}
 	return err
 }
 
@@ -6682,6 +7350,21 @@ func (v *ReadDLQMessagesResponse) IsSetReplicationTasksInfo() bool {
 	return v != nil && v.ReplicationTasksInfo != nil
 }
 
+// GetHistoryTasks returns the value of HistoryTasks if it is set or its
+// zero value if it is unset.
+func (v *ReadDLQMessagesResponse) GetHistoryTasks() (o []*HistoryTaskDLQMessage) {
+	if v != nil && v.HistoryTasks != nil {
+		return v.HistoryTasks
+	}
+
+	return
+}
+
+// IsSetHistoryTasks returns true if HistoryTasks is not nil.
+func (v *ReadDLQMessagesResponse) IsSetHistoryTasks() bool {
+	return v != nil && v.HistoryTasks != nil
+}
+
 type ReplicationMessages struct {
 	ReplicationTasks       []*ReplicationTask `json:"replicationTasks,omitempty"`
 	LastRetrievedMessageId *int64             `json:"lastRetrievedMessageId,omitempty"`
@@ -10799,11 +11482,11 @@ var ThriftModule = &thriftreflect.ThriftModule{
 	Name:     "replicator",
 	Package:  "github.com/uber/cadence/.gen/go/replicator",
 	FilePath: "replicator.thrift",
-	SHA1:     "85475c6529845fd2ee1b039bfa9eda1128146139",
+	SHA1:     "af91acf970844f4ebaac2c00653a9b76b432a5c2",
 	Includes: []*thriftreflect.ThriftModule{
 		shared.ThriftModule,
 	},
 	Raw: rawIDL,
 }
 
-const rawIDL = "// Copyright (c) 2017 Uber Technologies, Inc.\n//\n// Permission is hereby granted, free of charge, to any person obtaining a copy\n// of this software and associated documentation files (the \"Software\"), to deal\n// in the Software without restriction, including without limitation the rights\n// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell\n// copies of the Software, and to permit persons to whom the Software is\n// furnished to do so, subject to the following conditions:\n//\n// The above copyright notice and this permission notice shall be included in\n// all copies or substantial portions of the Software.\n//\n// THE SOFTWARE IS PROVIDED \"AS IS\", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR\n// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,\n// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE\n// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER\n// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,\n// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN\n// THE SOFTWARE.\n\nnamespace java com.uber.cadence.replicator\n\ninclude \"shared.thrift\"\n\nenum ReplicationTaskType {\n  Domain\n  History\n  SyncShardStatus\n  SyncActivity\n  HistoryMetadata\n  HistoryV2\n  FailoverMarker\n}\n\nenum DomainOperation {\n  Create\n  Update\n}\n\nstruct DomainTaskAttributes {\n  05: optional DomainOperation domainOperation\n  10: optional string id\n  20: optional shared.DomainInfo info\n  30: optional shared.DomainConfiguration config\n  40: optional shared.DomainReplicationConfiguration replicationConfig\n  50: optional i64 (js.type = \"Long\") configVersion\n  60: optional i64 (js.type = \"Long\") failoverVersion\n  70: optional i64 (js.type = \"Long\") previousFailoverVersion\n}\n\nstruct SyncShardStatusTaskAttributes {\n  10: optional string sourceCluster\n  20: optional i64 (js.type = \"Long\") shardId\n  30: optional i64 (js.type = \"Long\") timestamp\n}\n\nstruct SyncActivityTaskAttributes {\n  10: optional string domainId\n  20: optional string workflowId\n  30: optional string runId\n  40: optional i64 (js.type = \"Long\") version\n  50: optional i64 (js.type = \"Long\") scheduledId\n  60: optional i64 (js.type = \"Long\") scheduledTime\n  70: optional i64 (js.type = \"Long\") startedId\n  80: optional i64 (js.type = \"Long\") startedTime\n  90: optional i64 (js.type = \"Long\") lastHeartbeatTime\n  100: optional binary details\n  110: optional i32 attempt\n  120: optional string lastFailureReason\n  130: optional string lastWorkerIdentity\n  140: optional binary lastFailureDetails\n  150: optional shared.VersionHistory versionHistory\n}\n\nstruct HistoryTaskV2Attributes {\n  05: optional i64 (js.type = \"Long\") taskId\n  10: optional string domainId\n  20: optional string workflowId\n  30: optional string runId\n  40: optional list<shared.VersionHistoryItem> versionHistoryItems\n  50: optional shared.DataBlob events\n  // new run events does not need version history since there is no prior events\n  70: optional shared.DataBlob newRunEvents\n}\n\nstruct FailoverMarkerAttributes{\n\t10: optional string domainID\n\t20: optional i64 (js.type = \"Long\") failoverVersion\n\t30: optional i64 (js.type = \"Long\") creationTime\n}\n\nstruct FailoverMarkers{\n\t10: optional list<FailoverMarkerAttributes> failoverMarkers\n}\n\nstruct ReplicationTask {\n  10: optional ReplicationTaskType taskType\n  11: optional i64 (js.type = \"Long\") sourceTaskId\n  20: optional DomainTaskAttributes domainTaskAttributes\n  40: optional SyncShardStatusTaskAttributes syncShardStatusTaskAttributes\n  50: optional SyncActivityTaskAttributes syncActivityTaskAttributes\n  70: optional HistoryTaskV2Attributes historyTaskV2Attributes\n  80: optional FailoverMarkerAttributes failoverMarkerAttributes\n  90: optional i64 (js.type = \"Long\") creationTime\n}\n\nstruct ReplicationToken {\n  10: optional i32 shardID\n  // lastRetrivedMessageId is where the next fetch should begin with\n  20: optional i64 (js.type = \"Long\") lastRetrievedMessageId\n  // lastProcessedMessageId is the last messageId that is processed on the passive side.\n  // This can be different than lastRetrievedMessageId if passive side supports prefetching messages.\n  30: optional i64 (js.type = \"Long\") lastProcessedMessageId\n}\n\nstruct SyncShardStatus {\n  10: optional i64 (js.type = \"Long\") timestamp\n}\n\nstruct ReplicationMessages {\n  10: optional list<ReplicationTask> replicationTasks\n  // This can be different than the last taskId in the above list, because sender can decide to skip tasks (e.g. for completed workflows).\n  20: optional i64 (js.type = \"Long\") lastRetrievedMessageId\n  30: optional bool hasMore // Hint for flow control\n  40: optional SyncShardStatus syncShardStatus\n}\n\nstruct ReplicationTaskInfo {\n  10: optional string domainID\n  20: optional string workflowID\n  30: optional string runID\n  40: optional i16 taskType\n  50: optional i64 (js.type = \"Long\") taskID\n  60: optional i64 (js.type = \"Long\") version\n  70: optional i64 (js.type = \"Long\") firstEventID\n  80: optional i64 (js.type = \"Long\") nextEventID\n  90: optional i64 (js.type = \"Long\") scheduledID\n}\n\nstruct GetReplicationMessagesRequest {\n  10: optional list<ReplicationToken> tokens\n  20: optional string clusterName\n}\n\nstruct GetReplicationMessagesResponse {\n  10: optional map<i32, ReplicationMessages> messagesByShard\n}\n\nstruct GetDomainReplicationMessagesRequest {\n  // lastRetrievedMessageId is where the next fetch should begin with\n  10: optional i64 (js.type = \"Long\") lastRetrievedMessageId\n  // lastProcessedMessageId is the last messageId that is processed on the passive side.\n  // This can be different than lastRetrievedMessageId if passive side supports prefetching messages.\n  20: optional i64 (js.type = \"Long\") lastProcessedMessageId\n  // clusterName is the name of the pulling cluster\n  30: optional string clusterName\n}\n\nstruct GetDomainReplicationMessagesResponse {\n  10: optional ReplicationMessages messages\n}\n\nstruct GetDLQReplicationMessagesRequest {\n  10: optional list<ReplicationTaskInfo> taskInfos\n}\n\nstruct GetDLQReplicationMessagesResponse {\n  10: optional list<ReplicationTask> replicationTasks\n}\n\nenum DLQType {\n  Replication,\n  Domain,\n}\n\nstruct ReadDLQMessagesRequest{\n  10: optional DLQType type\n  20: optional i32 shardID\n  30: optional string sourceCluster\n  40: optional i64 (js.type = \"Long\") inclusiveEndMessageID\n  50: optional i32 maximumPageSize\n  60: optional binary nextPageToken\n}\n\nstruct ReadDLQMessagesResponse{\n  10: optional DLQType type\n  20: optional list<ReplicationTask> replicationTasks\n  30: optional binary nextPageToken\n  40: optional list<ReplicationTaskInfo> replicationTasksInfo\n}\n\nstruct PurgeDLQMessagesRequest{\n  10: optional DLQType type\n  20: optional i32 shardID\n  30: optional string sourceCluster\n  40: optional i64 (js.type = \"Long\") inclusiveEndMessageID\n}\n\nstruct MergeDLQMessagesRequest{\n  10: optional DLQType type\n  20: optional i32 shardID\n  30: optional string sourceCluster\n  40: optional i64 (js.type = \"Long\") inclusiveEndMessageID\n  50: optional i32 maximumPageSize\n  60: optional binary nextPageToken\n}\n\nstruct MergeDLQMessagesResponse{\n  10: optional binary nextPageToken\n}\n"
+const rawIDL = "// Copyright (c) 2017 Uber Technologies, Inc.\n//\n// Permission is hereby granted, free of charge, to any person obtaining a copy\n// of this software and associated documentation files (the \"Software\"), to deal\n// in the Software without restriction, including without limitation the rights\n// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell\n// copies of the Software, and to permit persons to whom the Software is\n// furnished to do so, subject to the following conditions:\n//\n// The above copyright notice and this permission notice shall be included in\n// all copies or substantial portions of the Software.\n//\n// THE SOFTWARE IS PROVIDED \"AS IS\", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR\n// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,\n// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE\n// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER\n// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,\n// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN\n// THE SOFTWARE.\n\nnamespace java com.uber.cadence.replicator\n\ninclude \"shared.thrift\"\n\nenum ReplicationTaskType {\n  Domain\n  History\n  SyncShardStatus\n  SyncActivity\n  HistoryMetadata\n  HistoryV2\n  FailoverMarker\n}\n\nenum DomainOperation {\n  Create\n  Update\n}\n\nstruct DomainTaskAttributes {\n  05: optional DomainOperation domainOperation\n  10: optional string id\n  20: optional shared.DomainInfo info\n  30: optional shared.DomainConfiguration config\n  40: optional shared.DomainReplicationConfiguration replicationConfig\n  50: optional i64 (js.type = \"Long\") configVersion\n  60: optional i64 (js.type = \"Long\") failoverVersion\n  70: optional i64 (js.type = \"Long\") previousFailoverVersion\n}\n\nstruct SyncShardStatusTaskAttributes {\n  10: optional string sourceCluster\n  20: optional i64 (js.type = \"Long\") shardId\n  30: optional i64 (js.type = \"Long\") timestamp\n}\n\nstruct SyncActivityTaskAttributes {\n  10: optional string domainId\n  20: optional string workflowId\n  30: optional string runId\n  40: optional i64 (js.type = \"Long\") version\n  50: optional i64 (js.type = \"Long\") scheduledId\n  60: optional i64 (js.type = \"Long\") scheduledTime\n  70: optional i64 (js.type = \"Long\") startedId\n  80: optional i64 (js.type = \"Long\") startedTime\n  90: optional i64 (js.type = \"Long\") lastHeartbeatTime\n  100: optional binary details\n  110: optional i32 attempt\n  120: optional string lastFailureReason\n  130: optional string lastWorkerIdentity\n  140: optional binary lastFailureDetails\n  150: optional shared.VersionHistory versionHistory\n}\n\nstruct HistoryTaskV2Attributes {\n  05: optional i64 (js.type = \"Long\") taskId\n  10: optional string domainId\n  20: optional string workflowId\n  30: optional string runId\n  40: optional list<shared.VersionHistoryItem> versionHistoryItems\n  50: optional shared.DataBlob events\n  // new run events does not need version history since there is no prior events\n  70: optional shared.DataBlob newRunEvents\n}\n\nstruct FailoverMarkerAttributes{\n\t10: optional string domainID\n\t20: optional i64 (js.type = \"Long\") failoverVersion\n\t30: optional i64 (js.type = \"Long\") creationTime\n}\n\nstruct FailoverMarkers{\n\t10: optional list<FailoverMarkerAttributes> failoverMarkers\n}\n\nstruct ReplicationTask {\n  10: optional ReplicationTaskType taskType\n  11: optional i64 (js.type = \"Long\") sourceTaskId\n  20: optional DomainTaskAttributes domainTaskAttributes\n  40: optional SyncShardStatusTaskAttributes syncShardStatusTaskAttributes\n  50: optional SyncActivityTaskAttributes syncActivityTaskAttributes\n  70: optional HistoryTaskV2Attributes historyTaskV2Attributes\n  80: optional FailoverMarkerAttributes failoverMarkerAttributes\n  90: optional i64 (js.type = \"Long\") creationTime\n}\n\nstruct ReplicationToken {\n  10: optional i32 shardID\n  // lastRetrivedMessageId is where the next fetch should begin with\n  20: optional i64 (js.type = \"Long\") lastRetrievedMessageId\n  // lastProcessedMessageId is the last messageId that is processed on the passive side.\n  // This can be different than lastRetrievedMessageId if passive side supports prefetching messages.\n  30: optional i64 (js.type = \"Long\") lastProcessedMessageId\n}\n\nstruct SyncShardStatus {\n  10: optional i64 (js.type = \"Long\") timestamp\n}\n\nstruct ReplicationMessages {\n  10: optional list<ReplicationTask> replicationTasks\n  // This can be different than the last taskId in the above list, because sender can decide to skip tasks (e.g. for completed workflows).\n  20: optional i64 (js.type = \"Long\") lastRetrievedMessageId\n  30: optional bool hasMore // Hint for flow control\n  40: optional SyncShardStatus syncShardStatus\n}\n\nstruct ReplicationTaskInfo {\n  10: optional string domainID\n  20: optional string workflowID\n  30: optional string runID\n  40: optional i16 taskType\n  50: optional i64 (js.type = \"Long\") taskID\n  60: optional i64 (js.type = \"Long\") version\n  70: optional i64 (js.type = \"Long\") firstEventID\n  80: optional i64 (js.type = \"Long\") nextEventID\n  90: optional i64 (js.type = \"Long\") scheduledID\n}\n\nstruct GetReplicationMessagesRequest {\n  10: optional list<ReplicationToken> tokens\n  20: optional string clusterName\n}\n\nstruct GetReplicationMessagesResponse {\n  10: optional map<i32, ReplicationMessages> messagesByShard\n}\n\nstruct GetDomainReplicationMessagesRequest {\n  // lastRetrievedMessageId is where the next fetch should begin with\n  10: optional i64 (js.type = \"Long\") lastRetrievedMessageId\n  // lastProcessedMessageId is the last messageId that is processed on the passive side.\n  // This can be different than lastRetrievedMessageId if passive side supports prefetching messages.\n  20: optional i64 (js.type = \"Long\") lastProcessedMessageId\n  // clusterName is the name of the pulling cluster\n  30: optional string clusterName\n}\n\nstruct GetDomainReplicationMessagesResponse {\n  10: optional ReplicationMessages messages\n}\n\nstruct GetDLQReplicationMessagesRequest {\n  10: optional list<ReplicationTaskInfo> taskInfos\n}\n\nstruct GetDLQReplicationMessagesResponse {\n  10: optional list<ReplicationTask> replicationTasks\n}\n\nenum DLQType {\n  Replication,\n  Domain,\n  HistoryTask,\n}\n\nstruct HistoryTaskDLQMessage {\n  10: optional i64 (js.type = \"Long\") messageID\n  20: optional i32 shardID\n  30: optional i32 taskCategory\n  40: optional shared.QueueTaskInfo task\n  50: optional i64 (js.type = \"Long\") enqueuedTimestamp\n}\n\nstruct ReadDLQMessagesRequest{\n  10: optional DLQType type\n  20: optional i32 shardID\n  30: optional string sourceCluster\n  40: optional i64 (js.type = \"Long\") inclusiveEndMessageID\n  50: optional i32 maximumPageSize\n  60: optional binary nextPageToken\n}\n\nstruct ReadDLQMessagesResponse{\n  10: optional DLQType type\n  20: optional list<ReplicationTask> replicationTasks\n  30: optional binary nextPageToken\n  40: optional list<ReplicationTaskInfo> replicationTasksInfo\n  50: optional list<HistoryTaskDLQMessage> historyTasks\n}\n\nstruct PurgeDLQMessagesRequest{\n  10: optional DLQType type\n  20: optional i32 shardID\n  30: optional string sourceCluster\n  40: optional i64 (js.type = \"Long\") inclusiveEndMessageID\n}\n\nstruct MergeDLQMessagesRequest{\n  10: optional DLQType type\n  20: optional i32 shardID\n  30: optional string sourceCluster\n  40: optional i64 (js.type = \"Long\") inclusiveEndMessageID\n  50: optional i32 maximumPageSize\n  60: optional binary nextPageToken\n}\n\nstruct MergeDLQMessagesResponse{\n  10: optional binary nextPageToken\n}\n"
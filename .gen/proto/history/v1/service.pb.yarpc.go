@@ -75,6 +75,8 @@ type HistoryAPIYARPCClient interface {
 	RemoveTask(context.Context, *RemoveTaskRequest, ...yarpc.CallOption) (*RemoveTaskResponse, error)
 	ResetQueue(context.Context, *ResetQueueRequest, ...yarpc.CallOption) (*ResetQueueResponse, error)
 	DescribeQueue(context.Context, *DescribeQueueRequest, ...yarpc.CallOption) (*DescribeQueueResponse, error)
+	ListQueueTasks(context.Context, *ListQueueTasksRequest, ...yarpc.CallOption) (*ListQueueTasksResponse, error)
+	ExecuteQueueTask(context.Context, *ExecuteQueueTaskRequest, ...yarpc.CallOption) (*ExecuteQueueTaskResponse, error)
 	GetReplicationMessages(context.Context, *GetReplicationMessagesRequest, ...yarpc.CallOption) (*GetReplicationMessagesResponse, error)
 	GetDLQReplicationMessages(context.Context, *GetDLQReplicationMessagesRequest, ...yarpc.CallOption) (*GetDLQReplicationMessagesResponse, error)
 	ReapplyEvents(context.Context, *ReapplyEventsRequest, ...yarpc.CallOption) (*ReapplyEventsResponse, error)
@@ -87,6 +89,11 @@ type HistoryAPIYARPCClient interface {
 	GetCrossClusterTasks(context.Context, *GetCrossClusterTasksRequest, ...yarpc.CallOption) (*GetCrossClusterTasksResponse, error)
 	RespondCrossClusterTasksCompleted(context.Context, *RespondCrossClusterTasksCompletedRequest, ...yarpc.CallOption) (*RespondCrossClusterTasksCompletedResponse, error)
 	GetFailoverInfo(context.Context, *GetFailoverInfoRequest, ...yarpc.CallOption) (*GetFailoverInfoResponse, error)
+	UpdateActivityOptions(context.Context, *UpdateActivityOptionsRequest, ...yarpc.CallOption) (*UpdateActivityOptionsResponse, error)
+	DeleteWorkflowExecution(context.Context, *DeleteWorkflowExecutionRequest, ...yarpc.CallOption) (*DeleteWorkflowExecutionResponse, error)
+	MigrateShardExecutions(context.Context, *MigrateShardExecutionsRequest, ...yarpc.CallOption) (*MigrateShardExecutionsResponse, error)
+	VerifyWorkflowExecution(context.Context, *VerifyWorkflowExecutionRequest, ...yarpc.CallOption) (*VerifyWorkflowExecutionResponse, error)
+	DescribeReplicationStatus(context.Context, *DescribeReplicationStatusRequest, ...yarpc.CallOption) (*DescribeReplicationStatusResponse, error)
 	RatelimitUpdate(context.Context, *RatelimitUpdateRequest, ...yarpc.CallOption) (*RatelimitUpdateResponse, error)
 }
 
@@ -139,6 +146,8 @@ type HistoryAPIYARPCServer interface {
 	RemoveTask(context.Context, *RemoveTaskRequest) (*RemoveTaskResponse, error)
 	ResetQueue(context.Context, *ResetQueueRequest) (*ResetQueueResponse, error)
 	DescribeQueue(context.Context, *DescribeQueueRequest) (*DescribeQueueResponse, error)
+	ListQueueTasks(context.Context, *ListQueueTasksRequest) (*ListQueueTasksResponse, error)
+	ExecuteQueueTask(context.Context, *ExecuteQueueTaskRequest) (*ExecuteQueueTaskResponse, error)
 	GetReplicationMessages(context.Context, *GetReplicationMessagesRequest) (*GetReplicationMessagesResponse, error)
 	GetDLQReplicationMessages(context.Context, *GetDLQReplicationMessagesRequest) (*GetDLQReplicationMessagesResponse, error)
 	ReapplyEvents(context.Context, *ReapplyEventsRequest) (*ReapplyEventsResponse, error)
@@ -151,6 +160,11 @@ type HistoryAPIYARPCServer interface {
 	GetCrossClusterTasks(context.Context, *GetCrossClusterTasksRequest) (*GetCrossClusterTasksResponse, error)
 	RespondCrossClusterTasksCompleted(context.Context, *RespondCrossClusterTasksCompletedRequest) (*RespondCrossClusterTasksCompletedResponse, error)
 	GetFailoverInfo(context.Context, *GetFailoverInfoRequest) (*GetFailoverInfoResponse, error)
+	UpdateActivityOptions(context.Context, *UpdateActivityOptionsRequest) (*UpdateActivityOptionsResponse, error)
+	DeleteWorkflowExecution(context.Context, *DeleteWorkflowExecutionRequest) (*DeleteWorkflowExecutionResponse, error)
+	MigrateShardExecutions(context.Context, *MigrateShardExecutionsRequest) (*MigrateShardExecutionsResponse, error)
+	VerifyWorkflowExecution(context.Context, *VerifyWorkflowExecutionRequest) (*VerifyWorkflowExecutionResponse, error)
+	DescribeReplicationStatus(context.Context, *DescribeReplicationStatusRequest) (*DescribeReplicationStatusResponse, error)
 	RatelimitUpdate(context.Context, *RatelimitUpdateRequest) (*RatelimitUpdateResponse, error)
 }
 
@@ -475,6 +489,26 @@ func buildHistoryAPIYARPCProcedures(params buildHistoryAPIYARPCProceduresParams)
 						},
 					),
 				},
+				{
+					MethodName: "ListQueueTasks",
+					Handler: protobuf.NewUnaryHandler(
+						protobuf.UnaryHandlerParams{
+							Handle:      handler.ListQueueTasks,
+							NewRequest:  newHistoryAPIServiceListQueueTasksYARPCRequest,
+							AnyResolver: params.AnyResolver,
+						},
+					),
+				},
+				{
+					MethodName: "ExecuteQueueTask",
+					Handler: protobuf.NewUnaryHandler(
+						protobuf.UnaryHandlerParams{
+							Handle:      handler.ExecuteQueueTask,
+							NewRequest:  newHistoryAPIServiceExecuteQueueTaskYARPCRequest,
+							AnyResolver: params.AnyResolver,
+						},
+					),
+				},
 				{
 					MethodName: "GetReplicationMessages",
 					Handler: protobuf.NewUnaryHandler(
@@ -595,6 +629,56 @@ func buildHistoryAPIYARPCProcedures(params buildHistoryAPIYARPCProceduresParams)
 						},
 					),
 				},
+				{
+					MethodName: "UpdateActivityOptions",
+					Handler: protobuf.NewUnaryHandler(
+						protobuf.UnaryHandlerParams{
+							Handle:      handler.UpdateActivityOptions,
+							NewRequest:  newHistoryAPIServiceUpdateActivityOptionsYARPCRequest,
+							AnyResolver: params.AnyResolver,
+						},
+					),
+				},
+				{
+					MethodName: "DeleteWorkflowExecution",
+					Handler: protobuf.NewUnaryHandler(
+						protobuf.UnaryHandlerParams{
+							Handle:      handler.DeleteWorkflowExecution,
+							NewRequest:  newHistoryAPIServiceDeleteWorkflowExecutionYARPCRequest,
+							AnyResolver: params.AnyResolver,
+						},
+					),
+				},
+				{
+					MethodName: "MigrateShardExecutions",
+					Handler: protobuf.NewUnaryHandler(
+						protobuf.UnaryHandlerParams{
+							Handle:      handler.MigrateShardExecutions,
+							NewRequest:  newHistoryAPIServiceMigrateShardExecutionsYARPCRequest,
+							AnyResolver: params.AnyResolver,
+						},
+					),
+				},
+				{
+					MethodName: "VerifyWorkflowExecution",
+					Handler: protobuf.NewUnaryHandler(
+						protobuf.UnaryHandlerParams{
+							Handle:      handler.VerifyWorkflowExecution,
+							NewRequest:  newHistoryAPIServiceVerifyWorkflowExecutionYARPCRequest,
+							AnyResolver: params.AnyResolver,
+						},
+					),
+				},
+				{
+					MethodName: "DescribeReplicationStatus",
+					Handler: protobuf.NewUnaryHandler(
+						protobuf.UnaryHandlerParams{
+							Handle:      handler.DescribeReplicationStatus,
+							NewRequest:  newHistoryAPIServiceDescribeReplicationStatusYARPCRequest,
+							AnyResolver: params.AnyResolver,
+						},
+					),
+				},
 				{
 					MethodName: "RatelimitUpdate",
 					Handler: protobuf.NewUnaryHandler(
@@ -1095,6 +1179,30 @@ func (c *_HistoryAPIYARPCCaller) DescribeQueue(ctx context.Context, request *Des
 	return response, err
 }
 
+func (c *_HistoryAPIYARPCCaller) ListQueueTasks(ctx context.Context, request *ListQueueTasksRequest, options ...yarpc.CallOption) (*ListQueueTasksResponse, error) {
+	responseMessage, err := c.streamClient.Call(ctx, "ListQueueTasks", request, newHistoryAPIServiceListQueueTasksYARPCResponse, options...)
+	if responseMessage == nil {
+		return nil, err
+	}
+	response, ok := responseMessage.(*ListQueueTasksResponse)
+	if !ok {
+		return nil, protobuf.CastError(emptyHistoryAPIServiceListQueueTasksYARPCResponse, responseMessage)
+	}
+	return response, err
+}
+
+func (c *_HistoryAPIYARPCCaller) ExecuteQueueTask(ctx context.Context, request *ExecuteQueueTaskRequest, options ...yarpc.CallOption) (*ExecuteQueueTaskResponse, error) {
+	responseMessage, err := c.streamClient.Call(ctx, "ExecuteQueueTask", request, newHistoryAPIServiceExecuteQueueTaskYARPCResponse, options...)
+	if responseMessage == nil {
+		return nil, err
+	}
+	response, ok := responseMessage.(*ExecuteQueueTaskResponse)
+	if !ok {
+		return nil, protobuf.CastError(emptyHistoryAPIServiceExecuteQueueTaskYARPCResponse, responseMessage)
+	}
+	return response, err
+}
+
 func (c *_HistoryAPIYARPCCaller) GetReplicationMessages(ctx context.Context, request *GetReplicationMessagesRequest, options ...yarpc.CallOption) (*GetReplicationMessagesResponse, error) {
 	responseMessage, err := c.streamClient.Call(ctx, "GetReplicationMessages", request, newHistoryAPIServiceGetReplicationMessagesYARPCResponse, options...)
 	if responseMessage == nil {
@@ -1239,6 +1347,66 @@ func (c *_HistoryAPIYARPCCaller) GetFailoverInfo(ctx context.Context, request *G
 	return response, err
 }
 
+func (c *_HistoryAPIYARPCCaller) UpdateActivityOptions(ctx context.Context, request *UpdateActivityOptionsRequest, options ...yarpc.CallOption) (*UpdateActivityOptionsResponse, error) {
+	responseMessage, err := c.streamClient.Call(ctx, "UpdateActivityOptions", request, newHistoryAPIServiceUpdateActivityOptionsYARPCResponse, options...)
+	if responseMessage == nil {
+		return nil, err
+	}
+	response, ok := responseMessage.(*UpdateActivityOptionsResponse)
+	if !ok {
+		return nil, protobuf.CastError(emptyHistoryAPIServiceUpdateActivityOptionsYARPCResponse, responseMessage)
+	}
+	return response, err
+}
+
+func (c *_HistoryAPIYARPCCaller) DeleteWorkflowExecution(ctx context.Context, request *DeleteWorkflowExecutionRequest, options ...yarpc.CallOption) (*DeleteWorkflowExecutionResponse, error) {
+	responseMessage, err := c.streamClient.Call(ctx, "DeleteWorkflowExecution", request, newHistoryAPIServiceDeleteWorkflowExecutionYARPCResponse, options...)
+	if responseMessage == nil {
+		return nil, err
+	}
+	response, ok := responseMessage.(*DeleteWorkflowExecutionResponse)
+	if !ok {
+		return nil, protobuf.CastError(emptyHistoryAPIServiceDeleteWorkflowExecutionYARPCResponse, responseMessage)
+	}
+	return response, err
+}
+
+func (c *_HistoryAPIYARPCCaller) MigrateShardExecutions(ctx context.Context, request *MigrateShardExecutionsRequest, options ...yarpc.CallOption) (*MigrateShardExecutionsResponse, error) {
+	responseMessage, err := c.streamClient.Call(ctx, "MigrateShardExecutions", request, newHistoryAPIServiceMigrateShardExecutionsYARPCResponse, options...)
+	if responseMessage == nil {
+		return nil, err
+	}
+	response, ok := responseMessage.(*MigrateShardExecutionsResponse)
+	if !ok {
+		return nil, protobuf.CastError(emptyHistoryAPIServiceMigrateShardExecutionsYARPCResponse, responseMessage)
+	}
+	return response, err
+}
+
+func (c *_HistoryAPIYARPCCaller) VerifyWorkflowExecution(ctx context.Context, request *VerifyWorkflowExecutionRequest, options ...yarpc.CallOption) (*VerifyWorkflowExecutionResponse, error) {
+	responseMessage, err := c.streamClient.Call(ctx, "VerifyWorkflowExecution", request, newHistoryAPIServiceVerifyWorkflowExecutionYARPCResponse, options...)
+	if responseMessage == nil {
+		return nil, err
+	}
+	response, ok := responseMessage.(*VerifyWorkflowExecutionResponse)
+	if !ok {
+		return nil, protobuf.CastError(emptyHistoryAPIServiceVerifyWorkflowExecutionYARPCResponse, responseMessage)
+	}
+	return response, err
+}
+
+func (c *_HistoryAPIYARPCCaller) DescribeReplicationStatus(ctx context.Context, request *DescribeReplicationStatusRequest, options ...yarpc.CallOption) (*DescribeReplicationStatusResponse, error) {
+	responseMessage, err := c.streamClient.Call(ctx, "DescribeReplicationStatus", request, newHistoryAPIServiceDescribeReplicationStatusYARPCResponse, options...)
+	if responseMessage == nil {
+		return nil, err
+	}
+	response, ok := responseMessage.(*DescribeReplicationStatusResponse)
+	if !ok {
+		return nil, protobuf.CastError(emptyHistoryAPIServiceDescribeReplicationStatusYARPCResponse, responseMessage)
+	}
+	return response, err
+}
+
 func (c *_HistoryAPIYARPCCaller) RatelimitUpdate(ctx context.Context, request *RatelimitUpdateRequest, options ...yarpc.CallOption) (*RatelimitUpdateResponse, error) {
 	responseMessage, err := c.streamClient.Call(ctx, "RatelimitUpdate", request, newHistoryAPIServiceRatelimitUpdateYARPCResponse, options...)
 	if responseMessage == nil {
@@ -1751,6 +1919,38 @@ func (h *_HistoryAPIYARPCHandler) DescribeQueue(ctx context.Context, requestMess
 	return response, err
 }
 
+func (h *_HistoryAPIYARPCHandler) ListQueueTasks(ctx context.Context, requestMessage proto.Message) (proto.Message, error) {
+	var request *ListQueueTasksRequest
+	var ok bool
+	if requestMessage != nil {
+		request, ok = requestMessage.(*ListQueueTasksRequest)
+		if !ok {
+			return nil, protobuf.CastError(emptyHistoryAPIServiceListQueueTasksYARPCRequest, requestMessage)
+		}
+	}
+	response, err := h.server.ListQueueTasks(ctx, request)
+	if response == nil {
+		return nil, err
+	}
+	return response, err
+}
+
+func (h *_HistoryAPIYARPCHandler) ExecuteQueueTask(ctx context.Context, requestMessage proto.Message) (proto.Message, error) {
+	var request *ExecuteQueueTaskRequest
+	var ok bool
+	if requestMessage != nil {
+		request, ok = requestMessage.(*ExecuteQueueTaskRequest)
+		if !ok {
+			return nil, protobuf.CastError(emptyHistoryAPIServiceExecuteQueueTaskYARPCRequest, requestMessage)
+		}
+	}
+	response, err := h.server.ExecuteQueueTask(ctx, request)
+	if response == nil {
+		return nil, err
+	}
+	return response, err
+}
+
 func (h *_HistoryAPIYARPCHandler) GetReplicationMessages(ctx context.Context, requestMessage proto.Message) (proto.Message, error) {
 	var request *GetReplicationMessagesRequest
 	var ok bool
@@ -1943,6 +2143,86 @@ func (h *_HistoryAPIYARPCHandler) GetFailoverInfo(ctx context.Context, requestMe
 	return response, err
 }
 
+func (h *_HistoryAPIYARPCHandler) UpdateActivityOptions(ctx context.Context, requestMessage proto.Message) (proto.Message, error) {
+	var request *UpdateActivityOptionsRequest
+	var ok bool
+	if requestMessage != nil {
+		request, ok = requestMessage.(*UpdateActivityOptionsRequest)
+		if !ok {
+			return nil, protobuf.CastError(emptyHistoryAPIServiceUpdateActivityOptionsYARPCRequest, requestMessage)
+		}
+	}
+	response, err := h.server.UpdateActivityOptions(ctx, request)
+	if response == nil {
+		return nil, err
+	}
+	return response, err
+}
+
+func (h *_HistoryAPIYARPCHandler) DeleteWorkflowExecution(ctx context.Context, requestMessage proto.Message) (proto.Message, error) {
+	var request *DeleteWorkflowExecutionRequest
+	var ok bool
+	if requestMessage != nil {
+		request, ok = requestMessage.(*DeleteWorkflowExecutionRequest)
+		if !ok {
+			return nil, protobuf.CastError(emptyHistoryAPIServiceDeleteWorkflowExecutionYARPCRequest, requestMessage)
+		}
+	}
+	response, err := h.server.DeleteWorkflowExecution(ctx, request)
+	if response == nil {
+		return nil, err
+	}
+	return response, err
+}
+
+func (h *_HistoryAPIYARPCHandler) MigrateShardExecutions(ctx context.Context, requestMessage proto.Message) (proto.Message, error) {
+	var request *MigrateShardExecutionsRequest
+	var ok bool
+	if requestMessage != nil {
+		request, ok = requestMessage.(*MigrateShardExecutionsRequest)
+		if !ok {
+			return nil, protobuf.CastError(emptyHistoryAPIServiceMigrateShardExecutionsYARPCRequest, requestMessage)
+		}
+	}
+	response, err := h.server.MigrateShardExecutions(ctx, request)
+	if response == nil {
+		return nil, err
+	}
+	return response, err
+}
+
+func (h *_HistoryAPIYARPCHandler) VerifyWorkflowExecution(ctx context.Context, requestMessage proto.Message) (proto.Message, error) {
+	var request *VerifyWorkflowExecutionRequest
+	var ok bool
+	if requestMessage != nil {
+		request, ok = requestMessage.(*VerifyWorkflowExecutionRequest)
+		if !ok {
+			return nil, protobuf.CastError(emptyHistoryAPIServiceVerifyWorkflowExecutionYARPCRequest, requestMessage)
+		}
+	}
+	response, err := h.server.VerifyWorkflowExecution(ctx, request)
+	if response == nil {
+		return nil, err
+	}
+	return response, err
+}
+
+func (h *_HistoryAPIYARPCHandler) DescribeReplicationStatus(ctx context.Context, requestMessage proto.Message) (proto.Message, error) {
+	var request *DescribeReplicationStatusRequest
+	var ok bool
+	if requestMessage != nil {
+		request, ok = requestMessage.(*DescribeReplicationStatusRequest)
+		if !ok {
+			return nil, protobuf.CastError(emptyHistoryAPIServiceDescribeReplicationStatusYARPCRequest, requestMessage)
+		}
+	}
+	response, err := h.server.DescribeReplicationStatus(ctx, request)
+	if response == nil {
+		return nil, err
+	}
+	return response, err
+}
+
 func (h *_HistoryAPIYARPCHandler) RatelimitUpdate(ctx context.Context, requestMessage proto.Message) (proto.Message, error) {
 	var request *RatelimitUpdateRequest
 	var ok bool
@@ -2207,6 +2487,22 @@ func newHistoryAPIServiceDescribeQueueYARPCResponse() proto.Message {
 	return &DescribeQueueResponse{}
 }
 
+func newHistoryAPIServiceListQueueTasksYARPCRequest() proto.Message {
+	return &ListQueueTasksRequest{}
+}
+
+func newHistoryAPIServiceListQueueTasksYARPCResponse() proto.Message {
+	return &ListQueueTasksResponse{}
+}
+
+func newHistoryAPIServiceExecuteQueueTaskYARPCRequest() proto.Message {
+	return &ExecuteQueueTaskRequest{}
+}
+
+func newHistoryAPIServiceExecuteQueueTaskYARPCResponse() proto.Message {
+	return &ExecuteQueueTaskResponse{}
+}
+
 func newHistoryAPIServiceGetReplicationMessagesYARPCRequest() proto.Message {
 	return &GetReplicationMessagesRequest{}
 }
@@ -2303,6 +2599,46 @@ func newHistoryAPIServiceGetFailoverInfoYARPCResponse() proto.Message {
 	return &GetFailoverInfoResponse{}
 }
 
+func newHistoryAPIServiceUpdateActivityOptionsYARPCRequest() proto.Message {
+	return &UpdateActivityOptionsRequest{}
+}
+
+func newHistoryAPIServiceUpdateActivityOptionsYARPCResponse() proto.Message {
+	return &UpdateActivityOptionsResponse{}
+}
+
+func newHistoryAPIServiceDeleteWorkflowExecutionYARPCRequest() proto.Message {
+	return &DeleteWorkflowExecutionRequest{}
+}
+
+func newHistoryAPIServiceDeleteWorkflowExecutionYARPCResponse() proto.Message {
+	return &DeleteWorkflowExecutionResponse{}
+}
+
+func newHistoryAPIServiceMigrateShardExecutionsYARPCRequest() proto.Message {
+	return &MigrateShardExecutionsRequest{}
+}
+
+func newHistoryAPIServiceMigrateShardExecutionsYARPCResponse() proto.Message {
+	return &MigrateShardExecutionsResponse{}
+}
+
+func newHistoryAPIServiceVerifyWorkflowExecutionYARPCRequest() proto.Message {
+	return &VerifyWorkflowExecutionRequest{}
+}
+
+func newHistoryAPIServiceVerifyWorkflowExecutionYARPCResponse() proto.Message {
+	return &VerifyWorkflowExecutionResponse{}
+}
+
+func newHistoryAPIServiceDescribeReplicationStatusYARPCRequest() proto.Message {
+	return &DescribeReplicationStatusRequest{}
+}
+
+func newHistoryAPIServiceDescribeReplicationStatusYARPCResponse() proto.Message {
+	return &DescribeReplicationStatusResponse{}
+}
+
 func newHistoryAPIServiceRatelimitUpdateYARPCRequest() proto.Message {
 	return &RatelimitUpdateRequest{}
 }
@@ -2374,6 +2710,10 @@ var (
 	emptyHistoryAPIServiceResetQueueYARPCResponse                        = &ResetQueueResponse{}
 	emptyHistoryAPIServiceDescribeQueueYARPCRequest                      = &DescribeQueueRequest{}
 	emptyHistoryAPIServiceDescribeQueueYARPCResponse                     = &DescribeQueueResponse{}
+	emptyHistoryAPIServiceListQueueTasksYARPCRequest                     = &ListQueueTasksRequest{}
+	emptyHistoryAPIServiceListQueueTasksYARPCResponse                    = &ListQueueTasksResponse{}
+	emptyHistoryAPIServiceExecuteQueueTaskYARPCRequest                   = &ExecuteQueueTaskRequest{}
+	emptyHistoryAPIServiceExecuteQueueTaskYARPCResponse                  = &ExecuteQueueTaskResponse{}
 	emptyHistoryAPIServiceGetReplicationMessagesYARPCRequest             = &GetReplicationMessagesRequest{}
 	emptyHistoryAPIServiceGetReplicationMessagesYARPCResponse            = &GetReplicationMessagesResponse{}
 	emptyHistoryAPIServiceGetDLQReplicationMessagesYARPCRequest          = &GetDLQReplicationMessagesRequest{}
@@ -2398,6 +2738,16 @@ var (
 	emptyHistoryAPIServiceRespondCrossClusterTasksCompletedYARPCResponse = &RespondCrossClusterTasksCompletedResponse{}
 	emptyHistoryAPIServiceGetFailoverInfoYARPCRequest                    = &GetFailoverInfoRequest{}
 	emptyHistoryAPIServiceGetFailoverInfoYARPCResponse                   = &GetFailoverInfoResponse{}
+	emptyHistoryAPIServiceUpdateActivityOptionsYARPCRequest              = &UpdateActivityOptionsRequest{}
+	emptyHistoryAPIServiceUpdateActivityOptionsYARPCResponse             = &UpdateActivityOptionsResponse{}
+	emptyHistoryAPIServiceDeleteWorkflowExecutionYARPCRequest            = &DeleteWorkflowExecutionRequest{}
+	emptyHistoryAPIServiceDeleteWorkflowExecutionYARPCResponse           = &DeleteWorkflowExecutionResponse{}
+	emptyHistoryAPIServiceMigrateShardExecutionsYARPCRequest             = &MigrateShardExecutionsRequest{}
+	emptyHistoryAPIServiceMigrateShardExecutionsYARPCResponse            = &MigrateShardExecutionsResponse{}
+	emptyHistoryAPIServiceVerifyWorkflowExecutionYARPCRequest            = &VerifyWorkflowExecutionRequest{}
+	emptyHistoryAPIServiceVerifyWorkflowExecutionYARPCResponse           = &VerifyWorkflowExecutionResponse{}
+	emptyHistoryAPIServiceDescribeReplicationStatusYARPCRequest          = &DescribeReplicationStatusRequest{}
+	emptyHistoryAPIServiceDescribeReplicationStatusYARPCResponse         = &DescribeReplicationStatusResponse{}
 	emptyHistoryAPIServiceRatelimitUpdateYARPCRequest                    = &RatelimitUpdateRequest{}
 	emptyHistoryAPIServiceRatelimitUpdateYARPCResponse                   = &RatelimitUpdateResponse{}
 )
@@ -2405,316 +2755,411 @@ var (
 var yarpcFileDescriptorClosurefee8ff76963a38ed = [][]byte{
 	// uber/cadence/history/v1/service.proto
 	[]byte{
-		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x3c, 0x4d, 0x6f, 0x1c, 0x47,
-		0x76, 0xe8, 0x19, 0xf1, 0xeb, 0x91, 0x1c, 0x92, 0x25, 0x7e, 0x0c, 0x87, 0x12, 0x45, 0xf6, 0x5a,
-		0x12, 0x2d, 0xaf, 0x87, 0x16, 0x65, 0xeb, 0xcb, 0xf2, 0x6a, 0x25, 0x52, 0x92, 0xc7, 0xd1, 0x67,
-		0x93, 0x96, 0x93, 0x20, 0x71, 0x6f, 0x73, 0xba, 0x86, 0xec, 0xa8, 0xa7, 0x7b, 0xd4, 0xdd, 0x43,
-		0x6a, 0x7c, 0x08, 0x9c, 0x6c, 0x10, 0x60, 0x17, 0x41, 0x76, 0xb3, 0x48, 0x82, 0x00, 0x01, 0x02,
-		0x04, 0x1b, 0x60, 0xb1, 0x46, 0x6e, 0x09, 0x90, 0x43, 0x92, 0x53, 0x2e, 0xf9, 0x0b, 0xb9, 0x7b,
-		0x0f, 0x09, 0x90, 0x53, 0xf6, 0x1c, 0x04, 0xf5, 0xd1, 0x3d, 0xfd, 0x51, 0x5d, 0x3d, 0x24, 0x83,
-		0xc8, 0xeb, 0xf8, 0xc6, 0xa9, 0xaa, 0xf7, 0xea, 0xd5, 0xab, 0xf7, 0x5e, 0xbf, 0xaf, 0x22, 0x9c,
-		0xef, 0xee, 0x62, 0x6f, 0xbd, 0x69, 0x98, 0xd8, 0x69, 0xe2, 0xf5, 0x7d, 0xcb, 0x0f, 0x5c, 0xaf,
-		0xb7, 0x7e, 0x70, 0x79, 0xdd, 0xc7, 0xde, 0x81, 0xd5, 0xc4, 0xf5, 0x8e, 0xe7, 0x06, 0x2e, 0x5a,
-		0x20, 0xcb, 0xea, 0x7c, 0x59, 0x9d, 0x2f, 0xab, 0x1f, 0x5c, 0xae, 0x2d, 0xef, 0xb9, 0xee, 0x9e,
-		0x8d, 0xd7, 0xe9, 0xb2, 0xdd, 0x6e, 0x6b, 0xdd, 0xec, 0x7a, 0x46, 0x60, 0xb9, 0x0e, 0x03, 0xac,
-		0x9d, 0x4b, 0xcf, 0x07, 0x56, 0x1b, 0xfb, 0x81, 0xd1, 0xee, 0xf0, 0x05, 0x19, 0x04, 0x87, 0x9e,
-		0xd1, 0xe9, 0x60, 0xcf, 0xe7, 0xf3, 0x2b, 0x09, 0x02, 0x8d, 0x8e, 0x45, 0x88, 0x6b, 0xba, 0xed,
-		0x76, 0xb4, 0xc5, 0xaa, 0x68, 0x45, 0x48, 0x22, 0xa7, 0x42, 0xb4, 0xe4, 0x65, 0x17, 0x47, 0x0b,
-		0x54, 0xd1, 0x82, 0xc0, 0xf0, 0x5f, 0xd8, 0x96, 0x1f, 0xc8, 0xd6, 0x1c, 0xba, 0xde, 0x8b, 0x96,
-		0xed, 0x1e, 0xf2, 0x35, 0x97, 0x44, 0x6b, 0x38, 0x2b, 0xf5, 0xd4, 0xda, 0xb5, 0xa2, 0xb5, 0xd8,
-		0xe3, 0x2b, 0xbf, 0x95, 0x5c, 0x69, 0xb6, 0x2d, 0x87, 0x72, 0xc1, 0xee, 0xfa, 0x41, 0xd1, 0xa2,
-		0x24, 0x23, 0x56, 0xc5, 0x8b, 0x5e, 0x76, 0x71, 0x97, 0x5f, 0x75, 0xed, 0xa2, 0x78, 0x89, 0x87,
-		0x3b, 0xb6, 0xd5, 0x8c, 0x5f, 0x6d, 0xf2, 0x66, 0xfc, 0x7d, 0xc3, 0xc3, 0x26, 0x59, 0x69, 0x38,
-		0xe1, 0x6e, 0x6f, 0xe4, 0xac, 0x48, 0xd2, 0x74, 0x3e, 0x67, 0x55, 0x92, 0x5d, 0xea, 0x97, 0xc3,
-		0x70, 0x76, 0x3b, 0x30, 0xbc, 0xe0, 0x13, 0x3e, 0x7e, 0xef, 0x15, 0x6e, 0x76, 0x09, 0x3d, 0x1a,
-		0x7e, 0xd9, 0xc5, 0x7e, 0x80, 0x1e, 0xc2, 0x88, 0xc7, 0xfe, 0xac, 0x2a, 0x2b, 0xca, 0xda, 0xf8,
-		0xc6, 0x46, 0x3d, 0x21, 0xb6, 0x46, 0xc7, 0xaa, 0x1f, 0x5c, 0xae, 0x4b, 0x91, 0x68, 0x21, 0x0a,
-		0xb4, 0x04, 0x63, 0xa6, 0xdb, 0x36, 0x2c, 0x47, 0xb7, 0xcc, 0x6a, 0x69, 0x45, 0x59, 0x1b, 0xd3,
-		0x46, 0xd9, 0x40, 0xc3, 0x44, 0xbf, 0x05, 0x73, 0x1d, 0xc3, 0xc3, 0x4e, 0xa0, 0xe3, 0x10, 0x81,
-		0x6e, 0x39, 0x2d, 0xb7, 0x5a, 0xa6, 0x1b, 0xaf, 0x09, 0x37, 0x7e, 0x4a, 0x21, 0xa2, 0x1d, 0x1b,
-		0x4e, 0xcb, 0xd5, 0x4e, 0x77, 0xb2, 0x83, 0xa8, 0x0a, 0x23, 0x46, 0x10, 0xe0, 0x76, 0x27, 0xa8,
-		0x9e, 0x5a, 0x51, 0xd6, 0x86, 0xb4, 0xf0, 0x27, 0xda, 0x84, 0x29, 0xfc, 0xaa, 0x63, 0x31, 0x15,
-		0xd3, 0x89, 0x2e, 0x55, 0x87, 0xe8, 0x8e, 0xb5, 0x3a, 0xd3, 0xa3, 0x7a, 0xa8, 0x47, 0xf5, 0x9d,
-		0x50, 0xd1, 0xb4, 0x4a, 0x1f, 0x84, 0x0c, 0xa2, 0x16, 0x2c, 0x36, 0x5d, 0x27, 0xb0, 0x9c, 0x2e,
-		0xd6, 0x0d, 0x5f, 0x77, 0xf0, 0xa1, 0x6e, 0x39, 0x56, 0x60, 0x19, 0x81, 0xeb, 0x55, 0x87, 0x57,
-		0x94, 0xb5, 0xca, 0xc6, 0x5b, 0xc2, 0x03, 0x6c, 0x72, 0xa8, 0x3b, 0xfe, 0x63, 0x7c, 0xd8, 0x08,
-		0x41, 0xb4, 0xf9, 0xa6, 0x70, 0x1c, 0x35, 0x60, 0x26, 0x9c, 0x31, 0xf5, 0x96, 0x61, 0xd9, 0x5d,
-		0x0f, 0x57, 0x47, 0x28, 0xb9, 0x67, 0x84, 0xf8, 0xef, 0xb3, 0x35, 0xda, 0x74, 0x04, 0xc6, 0x47,
-		0x90, 0x06, 0xf3, 0xb6, 0xe1, 0x07, 0x7a, 0xd3, 0x6d, 0x77, 0x6c, 0x4c, 0x0f, 0xef, 0x61, 0xbf,
-		0x6b, 0x07, 0xd5, 0x51, 0x09, 0xbe, 0xa7, 0x46, 0xcf, 0x76, 0x0d, 0x53, 0x9b, 0x25, 0xb0, 0x9b,
-		0x11, 0xa8, 0x46, 0x21, 0xd1, 0xaf, 0xc3, 0x52, 0xcb, 0xf2, 0xfc, 0x40, 0x37, 0x71, 0xd3, 0xf2,
-		0x29, 0x3f, 0x0d, 0xff, 0x85, 0xbe, 0x6b, 0x34, 0x5f, 0xb8, 0xad, 0x56, 0x75, 0x8c, 0x22, 0x5e,
-		0xcc, 0xf0, 0x75, 0x8b, 0x1b, 0x38, 0xad, 0x4a, 0xa1, 0xb7, 0x38, 0xf0, 0x8e, 0xe1, 0xbf, 0xb8,
-		0xcb, 0x40, 0xd1, 0x01, 0x4c, 0x77, 0x0c, 0x2f, 0xb0, 0x28, 0x9d, 0x4d, 0xd7, 0x69, 0x59, 0x7b,
-		0x55, 0x58, 0x29, 0xaf, 0x8d, 0x6f, 0xfc, 0x5a, 0x3d, 0xc7, 0x90, 0xca, 0xa5, 0x92, 0x88, 0x0e,
-		0x43, 0xb7, 0x49, 0xb1, 0xdd, 0x73, 0x02, 0xaf, 0xa7, 0x4d, 0x75, 0x92, 0xa3, 0xb5, 0xbb, 0x30,
-		0x2b, 0x5a, 0x88, 0xa6, 0xa1, 0xfc, 0x02, 0xf7, 0xa8, 0x52, 0x8c, 0x69, 0xe4, 0x4f, 0x34, 0x0b,
-		0x43, 0x07, 0x86, 0xdd, 0xc5, 0x5c, 0xb0, 0xd9, 0x8f, 0x9b, 0xa5, 0xeb, 0x8a, 0x7a, 0x0d, 0x96,
-		0xf3, 0x48, 0xf1, 0x3b, 0xae, 0xe3, 0x63, 0x34, 0x07, 0xc3, 0x5e, 0x97, 0x6a, 0x05, 0x43, 0x38,
-		0xe4, 0x75, 0x9d, 0x86, 0xa9, 0xfe, 0x4d, 0x09, 0x96, 0xb7, 0xad, 0x3d, 0xc7, 0xb0, 0x73, 0x15,
-		0xf4, 0x51, 0x5a, 0x41, 0xaf, 0x88, 0x15, 0x54, 0x8a, 0x65, 0x40, 0x0d, 0x6d, 0xc1, 0x12, 0x7e,
-		0x15, 0x60, 0xcf, 0x31, 0xec, 0xc8, 0xf0, 0xf6, 0x95, 0x95, 0xeb, 0xe9, 0x05, 0xe1, 0xfe, 0xd9,
-		0x9d, 0x17, 0x43, 0x54, 0x99, 0x29, 0x54, 0x87, 0xd3, 0xcd, 0x7d, 0xcb, 0x36, 0xfb, 0x9b, 0xb8,
-		0x8e, 0xdd, 0xa3, 0x7a, 0x3b, 0xaa, 0xcd, 0xd0, 0xa9, 0x10, 0xe8, 0x89, 0x63, 0xf7, 0xd4, 0x55,
-		0x38, 0x97, 0x7b, 0x3e, 0xc6, 0x60, 0xf5, 0x17, 0x25, 0xb8, 0xc8, 0xd7, 0x58, 0xc1, 0xbe, 0xdc,
-		0xe6, 0x3d, 0x4f, 0xb3, 0xf4, 0x96, 0x8c, 0xa5, 0x45, 0xe8, 0x06, 0xe4, 0xed, 0xe7, 0x8a, 0x40,
-		0xc0, 0xcb, 0x54, 0xc0, 0x3f, 0xce, 0x17, 0xf0, 0xc1, 0x48, 0xf8, 0x3f, 0x14, 0xf5, 0x3b, 0xb0,
-		0x56, 0x4c, 0x94, 0x5c, 0xe8, 0x7f, 0xa8, 0xc0, 0x59, 0x0d, 0xfb, 0xf8, 0xc4, 0x1f, 0x25, 0x29,
-		0x92, 0xc1, 0xae, 0x85, 0xa8, 0x6e, 0x1e, 0x1a, 0xf9, 0x29, 0xbe, 0x28, 0xc1, 0xea, 0x0e, 0xf6,
-		0xda, 0x96, 0x63, 0x04, 0x38, 0xf7, 0x24, 0x4f, 0xd3, 0x27, 0xb9, 0x2a, 0x3c, 0x49, 0x21, 0xa2,
-		0x5f, 0x71, 0x05, 0x7e, 0x03, 0x54, 0xd9, 0x11, 0xb9, 0x0e, 0xff, 0x58, 0x81, 0x95, 0x2d, 0xec,
-		0x37, 0x3d, 0x6b, 0x37, 0x9f, 0xa3, 0x4f, 0xd2, 0x1c, 0x7d, 0x4f, 0x78, 0x9c, 0x22, 0x3c, 0x03,
-		0x8a, 0xc7, 0x7f, 0x97, 0x61, 0x55, 0x82, 0x8a, 0x8b, 0x88, 0x0d, 0x0b, 0x7d, 0x97, 0x86, 0xa9,
-		0x36, 0xff, 0xe0, 0x49, 0x6d, 0x76, 0x06, 0xe1, 0x66, 0x1c, 0x54, 0x9b, 0xc7, 0xc2, 0x71, 0xb4,
-		0x0b, 0x0b, 0xd9, 0xbb, 0x65, 0x9e, 0x54, 0x89, 0xee, 0x76, 0x69, 0xb0, 0xdd, 0xa8, 0x2f, 0x35,
-		0x77, 0x28, 0x1a, 0x46, 0x9f, 0x00, 0xea, 0x60, 0xc7, 0xb4, 0x9c, 0x3d, 0xdd, 0x68, 0x06, 0xd6,
-		0x81, 0x15, 0x58, 0xd8, 0xe7, 0xe6, 0x2a, 0xc7, 0x51, 0x63, 0xcb, 0xef, 0xb0, 0xd5, 0x3d, 0x8a,
-		0x7c, 0xa6, 0x93, 0x18, 0xb4, 0xb0, 0x8f, 0x7e, 0x03, 0xa6, 0x43, 0xc4, 0x54, 0x4c, 0x3c, 0xec,
-		0x54, 0x4f, 0x51, 0xb4, 0x75, 0x19, 0xda, 0x4d, 0xb2, 0x36, 0x49, 0xf9, 0x54, 0x27, 0x36, 0xe5,
-		0x61, 0x07, 0x6d, 0xf7, 0x51, 0x87, 0xde, 0x09, 0x77, 0xf4, 0xa4, 0x14, 0x87, 0xce, 0x48, 0x02,
-		0x69, 0x38, 0xa8, 0xbe, 0x82, 0xd9, 0x67, 0x24, 0xe6, 0x09, 0xb9, 0x17, 0x8a, 0xe1, 0x66, 0x5a,
-		0x0c, 0xdf, 0x14, 0xee, 0x21, 0x82, 0x1d, 0x50, 0xf4, 0x7e, 0xaa, 0xc0, 0x5c, 0x0a, 0x9c, 0x8b,
-		0xdb, 0x6d, 0x98, 0xa0, 0x71, 0x58, 0xe8, 0xce, 0x29, 0x03, 0xb8, 0x73, 0xe3, 0x14, 0x82, 0x7b,
-		0x71, 0x0d, 0xa8, 0x84, 0x08, 0x7e, 0x07, 0x37, 0x03, 0x6c, 0x72, 0xc1, 0x51, 0xf3, 0xcf, 0xa0,
-		0xf1, 0x95, 0xda, 0xe4, 0xcb, 0xf8, 0x4f, 0xf5, 0x0f, 0x14, 0xa8, 0x51, 0x03, 0xba, 0x1d, 0x58,
-		0xcd, 0x17, 0x3d, 0xe2, 0xd1, 0x3d, 0xb4, 0xfc, 0x20, 0x64, 0x53, 0x23, 0xcd, 0xa6, 0xf5, 0x7c,
-		0x4b, 0x2e, 0xc4, 0x30, 0x20, 0xb3, 0xce, 0xc2, 0x92, 0x10, 0x07, 0xb7, 0x2c, 0xff, 0xa5, 0xc0,
-		0xfc, 0x03, 0x1c, 0x3c, 0xea, 0x06, 0xc6, 0xae, 0x8d, 0xb7, 0x03, 0x23, 0xc0, 0x9a, 0x08, 0xad,
-		0x92, 0xb2, 0xa7, 0x1f, 0x03, 0x12, 0x98, 0xd1, 0xd2, 0x91, 0xcc, 0xe8, 0x4c, 0x46, 0xc3, 0xd0,
-		0x15, 0x98, 0xc7, 0xaf, 0x3a, 0x94, 0x81, 0xba, 0x83, 0x5f, 0x05, 0x3a, 0x3e, 0x20, 0x61, 0x91,
-		0x65, 0x52, 0x0b, 0x5d, 0xd6, 0x4e, 0x87, 0xb3, 0x8f, 0xf1, 0xab, 0xe0, 0x1e, 0x99, 0x6b, 0x98,
-		0xe8, 0x1d, 0x98, 0x6d, 0x76, 0x3d, 0x1a, 0x3f, 0xed, 0x7a, 0x86, 0xd3, 0xdc, 0xd7, 0x03, 0xf7,
-		0x05, 0xd5, 0x1e, 0x65, 0x6d, 0x42, 0x43, 0x7c, 0xee, 0x2e, 0x9d, 0xda, 0x21, 0x33, 0xea, 0x3f,
-		0x8c, 0xc1, 0x42, 0xe6, 0xd4, 0x5c, 0x86, 0xc4, 0x27, 0x53, 0x4e, 0x7a, 0xb2, 0xfb, 0x30, 0x19,
-		0xa1, 0x0d, 0x7a, 0x1d, 0xcc, 0x79, 0xb5, 0x2a, 0xc5, 0xb8, 0xd3, 0xeb, 0x60, 0x6d, 0xe2, 0x30,
-		0xf6, 0x0b, 0xa9, 0x30, 0x29, 0x62, 0xcc, 0xb8, 0x13, 0x63, 0xc8, 0x73, 0x58, 0xec, 0x78, 0xf8,
-		0xc0, 0x72, 0xbb, 0xbe, 0xee, 0x13, 0x4f, 0x04, 0x9b, 0xfd, 0xf5, 0xa7, 0xe8, 0xbe, 0x4b, 0x99,
-		0x48, 0xa4, 0xe1, 0x04, 0x57, 0xdf, 0x7d, 0x4e, 0xdc, 0x19, 0x6d, 0x3e, 0x84, 0xde, 0x66, 0xc0,
-		0x21, 0xde, 0xb7, 0xe1, 0x34, 0x8d, 0x9b, 0x58, 0xa0, 0x13, 0x61, 0x1c, 0xa2, 0x14, 0x4c, 0x93,
-		0xa9, 0xfb, 0x64, 0x26, 0x5c, 0x7e, 0x13, 0xc6, 0x68, 0x0c, 0x64, 0x5b, 0x7e, 0x40, 0x23, 0xc1,
-		0xf1, 0x8d, 0xb3, 0xe2, 0x8f, 0x7c, 0x28, 0x95, 0xa3, 0x01, 0xff, 0x0b, 0x3d, 0x80, 0x69, 0x9f,
-		0x4a, 0xac, 0xde, 0x47, 0x31, 0x32, 0x08, 0x8a, 0x8a, 0x9f, 0x10, 0x74, 0xf4, 0x2e, 0xcc, 0x37,
-		0x6d, 0x8b, 0x50, 0x6a, 0x5b, 0xbb, 0x9e, 0xe1, 0xf5, 0xf4, 0x03, 0xec, 0x51, 0x0b, 0x38, 0x4a,
-		0x45, 0x7a, 0x96, 0xcd, 0x3e, 0x64, 0x93, 0xcf, 0xd9, 0x5c, 0x0c, 0xaa, 0x85, 0x8d, 0xa0, 0xeb,
-		0xe1, 0x08, 0x6a, 0x2c, 0x0e, 0x75, 0x9f, 0x4d, 0x86, 0x50, 0xe7, 0x60, 0x9c, 0x43, 0x59, 0xed,
-		0x8e, 0x5d, 0x05, 0xba, 0x14, 0xd8, 0x50, 0xa3, 0xdd, 0xb1, 0x91, 0x0f, 0x97, 0xd2, 0xa7, 0xd2,
-		0xfd, 0xe6, 0x3e, 0x36, 0xbb, 0x36, 0xd6, 0x03, 0x97, 0x5d, 0x16, 0x0d, 0xc4, 0xdd, 0x6e, 0x50,
-		0x1d, 0x2f, 0x8a, 0x19, 0xdf, 0x48, 0x9e, 0x75, 0x9b, 0x63, 0xda, 0x71, 0xe9, 0xbd, 0xed, 0x30,
-		0x34, 0xc4, 0x25, 0x61, 0x57, 0x45, 0x9c, 0xe7, 0xfe, 0x41, 0x26, 0x68, 0x2e, 0x60, 0x86, 0x4e,
-		0x6d, 0x93, 0x99, 0xf0, 0x14, 0x79, 0xea, 0x34, 0x99, 0xa7, 0x4e, 0xe8, 0x21, 0x54, 0x22, 0xd9,
-		0xf6, 0x89, 0x32, 0x55, 0x2b, 0x34, 0xee, 0x3f, 0x9f, 0xbc, 0x2a, 0x96, 0x8c, 0x89, 0xcb, 0x37,
-		0xd3, 0xbc, 0x48, 0x31, 0xe8, 0x4f, 0xd4, 0x84, 0xd9, 0x08, 0x5b, 0xd3, 0x76, 0x7d, 0xcc, 0x71,
-		0x4e, 0x51, 0x9c, 0x97, 0x07, 0x74, 0x18, 0x08, 0x20, 0xc1, 0xd7, 0xf5, 0xb5, 0x48, 0x9f, 0xa3,
-		0x41, 0xa2, 0xe5, 0x33, 0x9c, 0x11, 0x3a, 0x8b, 0x2a, 0xc8, 0x57, 0x7c, 0x5a, 0xf4, 0x4d, 0xec,
-		0x53, 0xcd, 0x19, 0xf4, 0x61, 0xb8, 0x5e, 0x9b, 0x3e, 0x48, 0x8d, 0xa0, 0x5b, 0xb0, 0x64, 0x11,
-		0x9d, 0x4b, 0xdd, 0x31, 0x76, 0x88, 0x9d, 0x31, 0xab, 0x33, 0xd4, 0x0d, 0x5c, 0xb0, 0xfc, 0xa4,
-		0x35, 0xbe, 0xc7, 0xa6, 0xd1, 0x2a, 0x4c, 0xf0, 0x10, 0x47, 0xf7, 0xad, 0xcf, 0x70, 0x15, 0x31,
-		0xd5, 0xe6, 0x63, 0xdb, 0xd6, 0x67, 0x58, 0xfd, 0xa5, 0x02, 0x0b, 0x4f, 0x5d, 0xdb, 0xfe, 0x7f,
-		0x66, 0xb0, 0x7f, 0x36, 0x0a, 0xd5, 0xec, 0xb1, 0xbf, 0xb1, 0xd8, 0xdf, 0x58, 0xec, 0xaf, 0xa3,
-		0xc5, 0xce, 0xd3, 0x8f, 0x89, 0x5c, 0x0b, 0x2c, 0x34, 0x67, 0x93, 0x27, 0x36, 0x67, 0xbf, 0x7a,
-		0x86, 0x5d, 0xfd, 0x97, 0x12, 0xac, 0x68, 0xb8, 0xe9, 0x7a, 0x66, 0x3c, 0x97, 0xca, 0xd5, 0xe2,
-		0x75, 0x5a, 0xca, 0x73, 0x30, 0x1e, 0x09, 0x4e, 0x64, 0x04, 0x20, 0x1c, 0x6a, 0x98, 0x68, 0x01,
-		0x46, 0xa8, 0x8c, 0x71, 0x8d, 0x2f, 0x6b, 0xc3, 0xe4, 0x67, 0xc3, 0x44, 0x67, 0x01, 0xb8, 0xab,
-		0x1f, 0xea, 0xee, 0x98, 0x36, 0xc6, 0x47, 0x1a, 0x26, 0xd2, 0x60, 0xa2, 0xe3, 0xda, 0xb6, 0x1e,
-		0x86, 0x13, 0xc3, 0x92, 0x70, 0x82, 0xd8, 0xd0, 0xfb, 0xae, 0x17, 0x67, 0x4d, 0x18, 0x4e, 0x8c,
-		0x13, 0x24, 0xfc, 0x87, 0xfa, 0xfb, 0xa3, 0xb0, 0x2a, 0xe1, 0x22, 0x37, 0xbc, 0x19, 0x0b, 0xa9,
-		0x1c, 0xcf, 0x42, 0x4a, 0xad, 0x5f, 0xe9, 0xf8, 0xd6, 0xef, 0xdb, 0x80, 0x42, 0xfe, 0x9a, 0x69,
-		0xf3, 0x3b, 0x1d, 0xcd, 0x84, 0xab, 0xd7, 0x88, 0x01, 0x13, 0x98, 0xde, 0x32, 0xb1, 0x50, 0x09,
-		0xbc, 0x19, 0x8b, 0x3e, 0x94, 0xb5, 0xe8, 0xb1, 0xaa, 0xcb, 0x70, 0xb2, 0xea, 0x72, 0x1d, 0xaa,
-		0xdc, 0xa4, 0xf4, 0x73, 0x14, 0xa1, 0x83, 0x30, 0x42, 0x1d, 0x84, 0x79, 0x36, 0x1f, 0xc9, 0x4e,
-		0xe8, 0x1f, 0x68, 0x30, 0x19, 0x55, 0x17, 0x68, 0x56, 0x83, 0x95, 0x2b, 0xde, 0xce, 0xd3, 0xc6,
-		0x1d, 0xcf, 0x70, 0x7c, 0x62, 0xca, 0x12, 0x91, 0xfc, 0x84, 0x19, 0xfb, 0x85, 0x3e, 0x85, 0x33,
-		0x82, 0x9c, 0x49, 0xdf, 0x84, 0x8f, 0x0d, 0x62, 0xc2, 0x17, 0x33, 0xe2, 0x1e, 0x59, 0xf3, 0x1c,
-		0xef, 0x13, 0xf2, 0xbc, 0xcf, 0x55, 0x98, 0x48, 0xd8, 0xbc, 0x71, 0x6a, 0xf3, 0xc6, 0x77, 0x63,
-		0xc6, 0xee, 0x0e, 0x54, 0xfa, 0xd7, 0x4a, 0xab, 0x56, 0x13, 0x85, 0x55, 0xab, 0xc9, 0x08, 0x82,
-		0x16, 0xad, 0x3e, 0x80, 0x89, 0xf0, 0xae, 0x29, 0x82, 0xc9, 0x42, 0x04, 0xe3, 0x7c, 0x3d, 0x05,
-		0x37, 0x60, 0x84, 0x04, 0xfb, 0xc4, 0xc8, 0x56, 0x68, 0x8a, 0xe6, 0x41, 0x6e, 0xa2, 0xba, 0x50,
-		0x8b, 0x68, 0x16, 0xc1, 0xc2, 0x3e, 0x4b, 0x4d, 0x87, 0x78, 0x33, 0xbe, 0xe0, 0x54, 0xc6, 0x17,
-		0xac, 0x7d, 0x0a, 0x13, 0x71, 0x58, 0x41, 0xb6, 0xfa, 0x7a, 0x3c, 0x5b, 0x9d, 0x97, 0xc5, 0x08,
-		0x15, 0x93, 0x65, 0x33, 0x62, 0x19, 0xed, 0xbe, 0x29, 0x0d, 0x73, 0x57, 0xdf, 0x98, 0xd2, 0x8c,
-		0x29, 0x8d, 0xb3, 0x46, 0x68, 0x4a, 0xbf, 0x2c, 0x87, 0xa6, 0x54, 0xc8, 0x45, 0x6e, 0x4a, 0x3f,
-		0x82, 0xa9, 0x94, 0xa9, 0x92, 0x1a, 0x53, 0xf6, 0x89, 0xee, 0x51, 0x63, 0xa3, 0x55, 0x92, 0xa6,
-		0x2c, 0x23, 0xdc, 0xa5, 0xa3, 0x09, 0x77, 0xcc, 0x72, 0x95, 0x93, 0x96, 0xeb, 0x53, 0x58, 0x4e,
-		0x2a, 0x9e, 0xee, 0xb6, 0xf4, 0x60, 0xdf, 0xf2, 0xf5, 0x78, 0x81, 0x59, 0xbe, 0x55, 0x2d, 0xa1,
-		0x88, 0x4f, 0x5a, 0x3b, 0xfb, 0x96, 0x7f, 0x87, 0xe3, 0x6f, 0xc0, 0xcc, 0x3e, 0x36, 0xbc, 0x60,
-		0x17, 0x1b, 0x81, 0x6e, 0xe2, 0xc0, 0xb0, 0x6c, 0x9f, 0x27, 0x2a, 0xe5, 0x39, 0xbc, 0xe9, 0x08,
-		0x6c, 0x8b, 0x41, 0x65, 0x3f, 0x4d, 0xc3, 0xc7, 0xfb, 0x34, 0x5d, 0x84, 0xa9, 0x08, 0x0f, 0x13,
-		0x6b, 0x6a, 0xa3, 0xc7, 0xb4, 0xc8, 0x31, 0xda, 0xa2, 0xa3, 0xea, 0x9f, 0x2b, 0xf0, 0x2d, 0x76,
-		0x9b, 0x09, 0x65, 0xe7, 0x75, 0xe2, 0xbe, 0xbe, 0x68, 0xe9, 0xbc, 0xdf, 0xf5, 0xbc, 0xbc, 0x5f,
-		0x11, 0xaa, 0x01, 0x13, 0x80, 0x7f, 0x57, 0x86, 0x37, 0xe4, 0xd8, 0xb8, 0x08, 0xe2, 0xfe, 0xf7,
-		0xcf, 0xe3, 0x63, 0x9c, 0xc4, 0x9b, 0xc7, 0xb7, 0x6e, 0xda, 0x94, 0x9f, 0x92, 0xf4, 0x9f, 0x2a,
-		0xb0, 0xdc, 0xcf, 0x9c, 0x13, 0x1f, 0xda, 0xb4, 0xfc, 0x8e, 0x11, 0x34, 0xf7, 0x75, 0xdb, 0x6d,
-		0x1a, 0xb6, 0xdd, 0xab, 0x96, 0xa8, 0x4d, 0xfd, 0x54, 0xb2, 0x6b, 0xf1, 0x71, 0xea, 0xfd, 0xd4,
-		0xfa, 0x8e, 0xbb, 0xc5, 0x77, 0x78, 0xc8, 0x36, 0x60, 0xa6, 0x76, 0xc9, 0xc8, 0x5f, 0x51, 0xfb,
-		0x5d, 0x58, 0x29, 0x42, 0x20, 0xb0, 0xb7, 0x5b, 0x49, 0x7b, 0x2b, 0x4e, 0xdc, 0x87, 0x66, 0x80,
-		0xe2, 0x0a, 0x11, 0xd3, 0x2f, 0x73, 0xcc, 0xf6, 0xfe, 0x58, 0x21, 0xb6, 0x37, 0x73, 0xcc, 0xfb,
-		0x86, 0x65, 0xf7, 0x65, 0x69, 0xc0, 0x8a, 0x4f, 0x11, 0x9e, 0x01, 0x05, 0xe9, 0x5b, 0xc4, 0x8e,
-		0xe5, 0x62, 0xe2, 0xf9, 0xe4, 0x3f, 0x55, 0x40, 0xcd, 0x5a, 0xbb, 0x0f, 0x43, 0xf5, 0x0c, 0x29,
-		0x7f, 0x96, 0xa6, 0xfc, 0x5a, 0x0e, 0xe5, 0x45, 0x98, 0x06, 0xa4, 0xfd, 0x29, 0x51, 0x4e, 0x09,
-		0x2e, 0x2e, 0x9b, 0x6f, 0xc2, 0x74, 0xd3, 0x70, 0x9a, 0x38, 0xfa, 0x02, 0x60, 0xf6, 0x4d, 0x1b,
-		0xd5, 0xa6, 0xd8, 0xb8, 0x16, 0x0e, 0xc7, 0xf5, 0x3d, 0x8e, 0xf3, 0x84, 0xfa, 0x2e, 0x43, 0x35,
-		0xe0, 0x51, 0x2f, 0x44, 0xea, 0x9e, 0x83, 0x2c, 0x56, 0x53, 0x14, 0x2c, 0x3c, 0x89, 0x84, 0xe5,
-		0xe2, 0x39, 0xb2, 0x84, 0x89, 0x30, 0x25, 0x24, 0x2c, 0x7b, 0x40, 0x7a, 0x3f, 0x7d, 0xca, 0x07,
-		0x96, 0xb0, 0x22, 0x4c, 0x03, 0xd2, 0x7e, 0x5e, 0x2c, 0x0e, 0x11, 0x2e, 0x4e, 0xfd, 0xdf, 0x2b,
-		0x70, 0x4e, 0xc3, 0x6d, 0xf7, 0x00, 0xb3, 0x66, 0x81, 0xaf, 0x4a, 0x1e, 0x2f, 0xe9, 0x18, 0x95,
-		0x53, 0x8e, 0x91, 0xaa, 0x12, 0x59, 0xc9, 0xa3, 0x9a, 0x1f, 0xed, 0x1f, 0x4b, 0x70, 0x9e, 0x1f,
-		0x81, 0x1d, 0x3b, 0xb7, 0x52, 0x2d, 0x3d, 0xa0, 0x01, 0x95, 0xa4, 0x0e, 0xf2, 0xc3, 0xdd, 0xcc,
-		0xb9, 0xbf, 0x01, 0x36, 0xd4, 0x26, 0x13, 0xda, 0x8b, 0x76, 0x61, 0x21, 0x6a, 0x06, 0x10, 0x76,
-		0xdc, 0x89, 0xeb, 0xc4, 0xf7, 0x38, 0x4c, 0xaa, 0x4e, 0x8c, 0x45, 0xc3, 0x47, 0x6e, 0x04, 0x58,
-		0x83, 0x0b, 0x45, 0x67, 0xe1, 0x7c, 0xfe, 0x67, 0x05, 0x96, 0xc2, 0xc4, 0x91, 0x20, 0x90, 0x7f,
-		0x2d, 0xe2, 0x73, 0x09, 0x66, 0x2c, 0x5f, 0x4f, 0x36, 0xc0, 0x51, 0x5e, 0x8e, 0x6a, 0x53, 0x96,
-		0x7f, 0x3f, 0xde, 0xda, 0xa6, 0x2e, 0xc3, 0x19, 0x31, 0xf9, 0xfc, 0x7c, 0x5f, 0x96, 0x88, 0x05,
-		0x23, 0xc6, 0x3a, 0x59, 0xdb, 0xce, 0x98, 0xd6, 0xd7, 0x71, 0xd0, 0x55, 0x98, 0xe0, 0xdd, 0x8d,
-		0xd8, 0x8c, 0xe5, 0x72, 0xa3, 0xb1, 0x86, 0x89, 0x3e, 0x81, 0xd3, 0xcd, 0x90, 0xd4, 0xd8, 0xd6,
-		0xa7, 0x8e, 0xb4, 0x35, 0x8a, 0x50, 0xf4, 0xf7, 0x7e, 0x08, 0xd3, 0xb1, 0x8e, 0x45, 0x16, 0x24,
-		0x0c, 0x0d, 0x1a, 0x24, 0x4c, 0xf5, 0x41, 0xe9, 0x80, 0x7a, 0x91, 0x68, 0xab, 0x94, 0xcb, 0xfc,
-		0x3e, 0xfe, 0xbd, 0x04, 0x55, 0x8d, 0xf7, 0xeb, 0x62, 0x0a, 0xeb, 0x3f, 0xdf, 0x78, 0x9d, 0x77,
-		0xf0, 0xdb, 0x30, 0x97, 0x4c, 0x76, 0xf6, 0x74, 0x2b, 0xc0, 0xed, 0xb0, 0x0b, 0x23, 0xdd, 0x6f,
-		0x60, 0xb6, 0x2d, 0x27, 0x93, 0xef, 0xec, 0x35, 0x02, 0xdc, 0xd6, 0x4e, 0x1f, 0x64, 0xc6, 0x7c,
-		0xf4, 0x1e, 0x0c, 0x53, 0xde, 0xfa, 0xfc, 0xca, 0xc4, 0xb9, 0x8f, 0x2d, 0x23, 0x30, 0xee, 0xda,
-		0xee, 0xae, 0xc6, 0x17, 0xa3, 0x4d, 0xa8, 0x38, 0xf8, 0x50, 0xf7, 0xba, 0xfc, 0x6a, 0xc2, 0xc8,
-		0xa5, 0x00, 0x7c, 0xc2, 0xc1, 0x87, 0x5a, 0x97, 0xdd, 0x89, 0xaf, 0x2e, 0xc1, 0xa2, 0x80, 0xd5,
-		0xfc, 0x22, 0x7e, 0xa8, 0xc0, 0xfc, 0x76, 0xcf, 0x69, 0x6e, 0xef, 0x1b, 0x9e, 0xc9, 0x53, 0xa0,
-		0xfc, 0x1a, 0xce, 0x43, 0xc5, 0x77, 0xbb, 0x5e, 0x13, 0xeb, 0xbc, 0x8d, 0x9b, 0xdf, 0xc5, 0x24,
-		0x1b, 0xdd, 0x64, 0x83, 0x68, 0x11, 0x46, 0x7d, 0x02, 0x1c, 0x7e, 0xc0, 0x86, 0xb4, 0x11, 0xfa,
-		0xbb, 0x61, 0xa2, 0x3a, 0x9c, 0xa2, 0xc1, 0x62, 0xb9, 0x30, 0x82, 0xa3, 0xeb, 0xd4, 0x45, 0x58,
-		0xc8, 0xd0, 0xc2, 0xe9, 0xfc, 0xd7, 0x21, 0x38, 0x4d, 0xe6, 0xc2, 0x0f, 0xe1, 0xeb, 0x94, 0x95,
-		0x2a, 0x8c, 0x84, 0x29, 0x27, 0xa6, 0xaa, 0xe1, 0x4f, 0xa2, 0xc9, 0xfd, 0x60, 0x36, 0x4a, 0x14,
-		0x44, 0x89, 0x05, 0xc2, 0x93, 0x6c, 0xa2, 0x69, 0xe8, 0xa8, 0x89, 0xa6, 0xb3, 0x00, 0x61, 0x50,
-		0x65, 0x99, 0x34, 0x08, 0x2d, 0x6b, 0x63, 0x7c, 0xa4, 0x61, 0x66, 0x42, 0xf5, 0x91, 0xa3, 0x85,
-		0xea, 0x1f, 0xf1, 0xf2, 0x4e, 0x3f, 0x6a, 0xa6, 0x58, 0x46, 0x0b, 0xb1, 0xcc, 0x10, 0xb0, 0xc8,
-		0xff, 0xa5, 0xb8, 0xae, 0xc2, 0x48, 0x18, 0x72, 0x8f, 0x0d, 0x10, 0x72, 0x87, 0x8b, 0xe3, 0xe9,
-		0x02, 0x48, 0xa6, 0x0b, 0x6e, 0xc3, 0x04, 0x2b, 0x3e, 0xf1, 0x66, 0xed, 0xf1, 0x01, 0x9a, 0xb5,
-		0xc7, 0x69, 0x4d, 0x8a, 0xf7, 0x69, 0xbf, 0x03, 0xb4, 0xd7, 0x9a, 0x3f, 0x5f, 0xd0, 0x2d, 0x13,
-		0x3b, 0x81, 0x15, 0xf4, 0x68, 0xba, 0x6f, 0x4c, 0x43, 0x64, 0xee, 0x13, 0x3a, 0xd5, 0xe0, 0x33,
-		0xe8, 0x31, 0x4c, 0xa5, 0x4c, 0x03, 0x4f, 0xed, 0x9d, 0x1f, 0xc8, 0x28, 0x68, 0x95, 0xa4, 0x41,
-		0x50, 0xe7, 0x61, 0x36, 0x29, 0xc9, 0x5c, 0xc4, 0xff, 0x44, 0x81, 0xa5, 0xb0, 0xfb, 0xed, 0x2b,
-		0xe2, 0xc2, 0xa9, 0x7f, 0xac, 0xc0, 0x19, 0x31, 0x4d, 0x3c, 0xba, 0xb9, 0x02, 0xf3, 0x6d, 0x36,
-		0xce, 0x0a, 0x2f, 0xba, 0xe5, 0xe8, 0x4d, 0xa3, 0xb9, 0x8f, 0x39, 0x85, 0xa7, 0xdb, 0x31, 0xa8,
-		0x86, 0xb3, 0x49, 0xa6, 0xd0, 0x0d, 0x58, 0xcc, 0x00, 0x99, 0x46, 0x60, 0xec, 0x1a, 0x7e, 0xd8,
-		0x04, 0x3b, 0x9f, 0x84, 0xdb, 0xe2, 0xb3, 0xea, 0x19, 0xa8, 0x85, 0xf4, 0x70, 0x7e, 0x7e, 0xe8,
-		0x46, 0xed, 0x4b, 0xea, 0xef, 0x95, 0xfa, 0x2c, 0x4c, 0x4c, 0x73, 0x6a, 0xd7, 0x60, 0xda, 0xe9,
-		0xb6, 0x77, 0xb1, 0xa7, 0xbb, 0x2d, 0x9d, 0x5a, 0x29, 0x9f, 0xd2, 0x39, 0xa4, 0x55, 0xd8, 0xf8,
-		0x93, 0x16, 0x35, 0x3e, 0x3e, 0x61, 0x76, 0x68, 0xd5, 0x7c, 0x9a, 0x3b, 0x18, 0xd2, 0x46, 0xb9,
-		0x59, 0xf3, 0x51, 0x03, 0x26, 0xf8, 0x4d, 0xb0, 0xa3, 0x8a, 0x3b, 0x3d, 0x43, 0x71, 0x60, 0xc9,
-		0x1c, 0x7a, 0x72, 0xea, 0xdc, 0x8d, 0x9b, 0xfd, 0x01, 0x74, 0x15, 0x16, 0xd8, 0x3e, 0x4d, 0xd7,
-		0x09, 0x3c, 0xd7, 0xb6, 0xb1, 0x47, 0x79, 0xd2, 0x65, 0x5f, 0x8a, 0x31, 0x6d, 0x8e, 0x4e, 0x6f,
-		0x46, 0xb3, 0xcc, 0x2e, 0x52, 0x0d, 0x31, 0x4d, 0x0f, 0xfb, 0x3e, 0xcf, 0x38, 0x86, 0x3f, 0xd5,
-		0x3a, 0xcc, 0xb0, 0xd2, 0x15, 0x81, 0x0b, 0x65, 0x27, 0x6e, 0xa4, 0x95, 0x84, 0x91, 0x56, 0x67,
-		0x01, 0xc5, 0xd7, 0x73, 0x61, 0xfc, 0x4f, 0x05, 0x66, 0x98, 0x77, 0x1e, 0x77, 0x03, 0xf3, 0xd1,
-		0xa0, 0x5b, 0xbc, 0xcc, 0x1b, 0x55, 0xb5, 0x2b, 0x1b, 0xe7, 0x72, 0x18, 0x42, 0x30, 0xd2, 0xb4,
-		0x18, 0x2d, 0xf4, 0xd2, 0x94, 0x58, 0x2c, 0xb9, 0x5a, 0x4e, 0x24, 0x57, 0x37, 0x61, 0xea, 0xc0,
-		0xf2, 0xad, 0x5d, 0xcb, 0xb6, 0x82, 0x1e, 0xb3, 0x44, 0xc5, 0xf9, 0xc0, 0x4a, 0x1f, 0x84, 0x9a,
-		0xa1, 0x55, 0x98, 0xe0, 0x9f, 0x30, 0xdd, 0x31, 0xb8, 0xc5, 0x1d, 0xd3, 0xc6, 0xf9, 0xd8, 0x63,
-		0xa3, 0x8d, 0x09, 0x17, 0xe2, 0xc7, 0xe5, 0x5c, 0xf8, 0x11, 0xe5, 0x82, 0x8f, 0x83, 0x67, 0x5d,
-		0xdc, 0xc5, 0x03, 0x70, 0x21, 0xbd, 0x53, 0x29, 0xb3, 0x53, 0x92, 0x51, 0xe5, 0x23, 0x32, 0x8a,
-		0xd1, 0xd9, 0x27, 0x88, 0xd3, 0xf9, 0x13, 0x05, 0x66, 0x43, 0xb9, 0xff, 0xca, 0x90, 0xfa, 0x04,
-		0xe6, 0x52, 0x34, 0x71, 0x2d, 0xbc, 0x0a, 0x0b, 0x1d, 0xcf, 0x6d, 0x62, 0xdf, 0xb7, 0x9c, 0x3d,
-		0x9d, 0xbe, 0xec, 0x62, 0x76, 0x80, 0x28, 0x63, 0x99, 0xc8, 0x7c, 0x7f, 0x9a, 0x42, 0x52, 0x23,
-		0xe0, 0xab, 0xdf, 0x57, 0xe0, 0xec, 0x03, 0x1c, 0x68, 0xfd, 0x77, 0x5e, 0x8f, 0xb0, 0xef, 0x1b,
-		0x7b, 0x38, 0x72, 0x59, 0x6e, 0xc3, 0x30, 0xad, 0xf0, 0x30, 0x44, 0xe3, 0x1b, 0x17, 0x73, 0xa8,
-		0x8d, 0xa1, 0xa0, 0xe5, 0x1f, 0x8d, 0x83, 0x0d, 0xc0, 0x14, 0x62, 0x63, 0x96, 0xf3, 0xa8, 0xe0,
-		0x07, 0x7c, 0x09, 0x15, 0xc6, 0xf5, 0x36, 0x9f, 0xe1, 0xe4, 0x7c, 0x94, 0x9b, 0x7d, 0x94, 0x23,
-		0xac, 0x53, 0xdd, 0x0c, 0x47, 0x59, 0xa6, 0x71, 0xd2, 0x8f, 0x8f, 0xd5, 0x6c, 0x40, 0xd9, 0x45,
-		0xf1, 0x6c, 0xe2, 0x10, 0xcb, 0x26, 0x7e, 0x37, 0x99, 0x4d, 0xbc, 0x54, 0xcc, 0xa0, 0x88, 0x98,
-		0x58, 0x26, 0xb1, 0x0d, 0x2b, 0x0f, 0x70, 0xb0, 0xf5, 0xf0, 0x99, 0xe4, 0x2e, 0x1a, 0x00, 0x4c,
-		0xa5, 0x9d, 0x96, 0x1b, 0x32, 0x60, 0x80, 0xed, 0x88, 0x20, 0x51, 0x33, 0x49, 0x45, 0x8f, 0xfc,
-		0xe5, 0xab, 0xaf, 0x60, 0x55, 0xb2, 0x1d, 0x67, 0xfa, 0x36, 0xcc, 0xc4, 0x5e, 0x00, 0xd2, 0x6a,
-		0x63, 0xb8, 0xed, 0x85, 0xc1, 0xb6, 0xd5, 0xa6, 0xbd, 0xe4, 0x80, 0xaf, 0xfe, 0x9b, 0x02, 0xb3,
-		0x1a, 0x36, 0x3a, 0x1d, 0x9b, 0x85, 0x3c, 0xd1, 0xe9, 0xe6, 0x61, 0x98, 0xa7, 0xee, 0xd9, 0x77,
-		0x8e, 0xff, 0x92, 0x3f, 0x18, 0x10, 0x7f, 0xa4, 0xcb, 0x27, 0xf5, 0x47, 0x8f, 0x17, 0x5c, 0xa8,
-		0x0b, 0x30, 0x97, 0x3a, 0x1a, 0xb7, 0x26, 0x3f, 0x57, 0x60, 0x49, 0xc3, 0x2d, 0x0f, 0xfb, 0xfb,
-		0x51, 0x15, 0x83, 0x70, 0xe3, 0x2b, 0x78, 0x76, 0x12, 0xf8, 0x8b, 0x49, 0xe5, 0x67, 0xb9, 0x01,
-		0x0b, 0x9b, 0x6e, 0xd7, 0x21, 0xc2, 0x93, 0x16, 0xd0, 0x65, 0x80, 0x96, 0xeb, 0x35, 0xf1, 0x7d,
-		0x1c, 0x34, 0xf7, 0x79, 0x4a, 0x36, 0x36, 0xa2, 0x1a, 0x50, 0xcd, 0x82, 0x72, 0x61, 0xbb, 0x07,
-		0x23, 0xd8, 0x09, 0x68, 0xb1, 0x96, 0x89, 0xd8, 0x5b, 0x39, 0x22, 0xc6, 0xbd, 0x90, 0xad, 0x87,
-		0xcf, 0x28, 0x2e, 0x5e, 0x90, 0xe5, 0xb0, 0xea, 0xcf, 0x4b, 0x30, 0xaf, 0x61, 0xc3, 0x14, 0x50,
-		0xb7, 0x01, 0xa7, 0xa2, 0xf6, 0x87, 0xca, 0xc6, 0x72, 0x9e, 0x6f, 0xf1, 0xf0, 0x19, 0xb5, 0xba,
-		0x74, 0xad, 0x2c, 0x14, 0xcb, 0x06, 0x73, 0x65, 0x51, 0x30, 0xb7, 0x03, 0x55, 0xcb, 0x21, 0x2b,
-		0xac, 0x03, 0xac, 0x63, 0x27, 0xb2, 0x60, 0x03, 0xb6, 0x8c, 0xcd, 0x45, 0xc0, 0xf7, 0x9c, 0xd0,
-		0x14, 0x35, 0x4c, 0x22, 0x18, 0x1d, 0x82, 0x84, 0x16, 0x9d, 0x87, 0x28, 0x61, 0xa3, 0x64, 0x60,
-		0xdb, 0xfa, 0x0c, 0xa3, 0x0b, 0x30, 0x45, 0x1b, 0x1f, 0xe8, 0x0a, 0x56, 0x9f, 0x1f, 0xa6, 0xf5,
-		0x79, 0xda, 0x0f, 0xf1, 0xd4, 0xd8, 0xc3, 0xac, 0x5d, 0xef, 0x6f, 0x4b, 0xb0, 0x90, 0xe1, 0x15,
-		0xbf, 0x8e, 0xe3, 0x30, 0x4b, 0x68, 0x2f, 0x4a, 0x27, 0xb3, 0x17, 0xe8, 0x7b, 0x30, 0x9f, 0x41,
-		0x1a, 0x26, 0x01, 0x8f, 0x6a, 0x00, 0x67, 0xd3, 0xd8, 0x69, 0x0e, 0x50, 0xc0, 0xae, 0x53, 0x22,
-		0x76, 0xfd, 0x42, 0x81, 0x85, 0xa7, 0x5d, 0x6f, 0x0f, 0x7f, 0xbd, 0x65, 0x4b, 0xad, 0x41, 0x35,
-		0x7b, 0x4c, 0xae, 0xfc, 0x5f, 0x94, 0x60, 0xe1, 0x11, 0xfe, 0xda, 0xf3, 0xe0, 0x7f, 0x47, 0xbf,
-		0xee, 0x42, 0x35, 0xcb, 0x2b, 0xae, 0x5f, 0x02, 0x1c, 0x8a, 0x08, 0xc7, 0xe7, 0x0a, 0x9c, 0x79,
-		0xec, 0x06, 0x56, 0xab, 0x47, 0xc2, 0x6d, 0xf7, 0x00, 0x7b, 0x8f, 0x0c, 0x12, 0x4b, 0x47, 0x5c,
-		0xff, 0x1e, 0xcc, 0xb7, 0xf8, 0x8c, 0xde, 0xa6, 0x53, 0x7a, 0xc2, 0x61, 0xcb, 0xd3, 0x8f, 0x24,
-		0x3a, 0xe6, 0xb3, 0xcd, 0xb6, 0xb2, 0x83, 0xbe, 0x7a, 0x0e, 0xce, 0xe6, 0x50, 0xc0, 0x85, 0xc2,
-		0x80, 0xa5, 0x07, 0x38, 0xd8, 0xf4, 0x5c, 0xdf, 0xe7, 0xb7, 0x92, 0xf8, 0xb8, 0x25, 0x02, 0x3f,
-		0x25, 0x15, 0xf8, 0x9d, 0x87, 0x4a, 0x60, 0x78, 0x7b, 0x38, 0x88, 0x6e, 0x99, 0x7d, 0xe6, 0x26,
-		0xd9, 0x28, 0xc7, 0xa7, 0xfe, 0xb2, 0x0c, 0x67, 0xc4, 0x7b, 0x70, 0x7e, 0xb6, 0x09, 0x1e, 0x62,
-		0x1a, 0x76, 0x7b, 0x2c, 0x0c, 0xe5, 0xc7, 0x7f, 0x20, 0x73, 0x10, 0x73, 0xd1, 0x51, 0xe7, 0xdb,
-		0xbf, 0xdb, 0xa3, 0x0e, 0x20, 0xfb, 0xc2, 0x4c, 0x04, 0xb1, 0x21, 0xf4, 0xb9, 0x02, 0x73, 0x2d,
-		0x5a, 0xf1, 0xd2, 0x9b, 0x46, 0xd7, 0xc7, 0xfd, 0x6d, 0x99, 0xbd, 0x7b, 0x74, 0xbc, 0x6d, 0x59,
-		0x11, 0x6d, 0x93, 0x60, 0x4c, 0x6c, 0x8e, 0x5a, 0x99, 0x89, 0x5a, 0x07, 0x66, 0x32, 0x54, 0x0a,
-		0xdc, 0xd3, 0x7b, 0x49, 0xf7, 0x74, 0x3d, 0x47, 0x1c, 0xd2, 0x34, 0xf1, 0xcb, 0x8b, 0xfb, 0xa8,
-		0xb5, 0x0e, 0x2c, 0xe4, 0x10, 0x28, 0xd8, 0xf7, 0x76, 0x7c, 0xdf, 0x4a, 0x6e, 0xba, 0xf7, 0x01,
-		0x0e, 0xfa, 0xd5, 0x43, 0x8a, 0x37, 0xee, 0x15, 0xff, 0x87, 0x02, 0x6b, 0xbc, 0x5e, 0x97, 0x61,
-		0x5a, 0xa6, 0xd0, 0x20, 0x89, 0xcc, 0x06, 0x93, 0x32, 0xf4, 0x9c, 0x09, 0x51, 0xd4, 0x58, 0x11,
-		0xe6, 0xaa, 0x07, 0x67, 0x1a, 0x6f, 0xa7, 0x98, 0x0c, 0x62, 0xbf, 0x7c, 0xf4, 0x06, 0x4c, 0xb6,
-		0x88, 0x03, 0xf4, 0x18, 0x33, 0x5f, 0x8a, 0xd7, 0x97, 0x92, 0x83, 0xaa, 0x07, 0x6f, 0x0e, 0x70,
-		0xd6, 0xc8, 0x5d, 0x1a, 0x0a, 0xfd, 0xf1, 0xe3, 0x5d, 0x2b, 0x85, 0x56, 0xdf, 0xa3, 0xef, 0xca,
-		0x42, 0xc5, 0xa6, 0x1f, 0xc9, 0x01, 0x72, 0x63, 0x6a, 0x40, 0x1f, 0x66, 0x25, 0xc1, 0x22, 0xc7,
-		0x61, 0xae, 0x5f, 0x57, 0x09, 0x13, 0x31, 0x5d, 0xde, 0x28, 0x35, 0xa4, 0xf5, 0x8b, 0x2e, 0xdb,
-		0x2c, 0x0b, 0xd3, 0x75, 0x68, 0x5e, 0x3c, 0x7c, 0xf9, 0xc8, 0x53, 0x48, 0x2c, 0x3f, 0x34, 0xc9,
-		0x47, 0x59, 0x06, 0x49, 0x6d, 0xc0, 0xbc, 0x66, 0x04, 0xd8, 0xb6, 0xda, 0x56, 0xf0, 0x71, 0xc7,
-		0x8c, 0x25, 0xf2, 0xd6, 0xe1, 0x94, 0x69, 0x04, 0x06, 0x67, 0xc6, 0x52, 0x5e, 0xa7, 0xe5, 0x1d,
-		0xa7, 0xa7, 0xd1, 0x85, 0xea, 0x47, 0xb0, 0x90, 0x41, 0xc5, 0x0f, 0x70, 0x54, 0x5c, 0x1b, 0xff,
-		0x54, 0x07, 0xe0, 0x4e, 0xe9, 0x9d, 0xa7, 0x0d, 0xf4, 0x03, 0x05, 0xe6, 0xc5, 0x0f, 0xcb, 0xd1,
-		0xd5, 0xe3, 0xfd, 0x27, 0x88, 0xda, 0xb5, 0x23, 0xc3, 0xf1, 0xb3, 0xfc, 0x91, 0x02, 0x0b, 0x39,
-		0xff, 0x79, 0x00, 0x5d, 0x2b, 0x7a, 0xb5, 0x9f, 0x47, 0xcd, 0xf5, 0xa3, 0x03, 0x72, 0x72, 0x7e,
-		0xa6, 0xc0, 0x4a, 0xd1, 0xeb, 0x7b, 0xf4, 0xdd, 0x93, 0xfe, 0x37, 0x81, 0xda, 0x9d, 0x13, 0x60,
-		0xe0, 0x94, 0x92, 0x4b, 0x14, 0xbf, 0xab, 0x97, 0x5c, 0xa2, 0xf4, 0x3d, 0xbf, 0xe4, 0x12, 0x0b,
-		0x1e, 0xf0, 0xff, 0x99, 0x02, 0xb5, 0xfc, 0xd7, 0xe7, 0x28, 0xbf, 0xed, 0xab, 0xf0, 0x55, 0x7e,
-		0xed, 0xfd, 0x63, 0xc1, 0x72, 0xba, 0x7e, 0xa2, 0xc0, 0x62, 0xee, 0xdb, 0x72, 0x74, 0x23, 0x17,
-		0x75, 0xd1, 0xd3, 0xf6, 0xda, 0xcd, 0xe3, 0x80, 0x72, 0xa2, 0x1c, 0x98, 0x4c, 0x3c, 0x3a, 0x46,
-		0x6f, 0xe7, 0x22, 0x13, 0xbd, 0x6d, 0xae, 0xd5, 0x07, 0x5d, 0xce, 0xf7, 0xfb, 0x5c, 0x81, 0xd3,
-		0x82, 0x97, 0xbb, 0xe8, 0x8a, 0xfc, 0xb6, 0x85, 0x6f, 0x85, 0x6b, 0xef, 0x1e, 0x0d, 0x88, 0x93,
-		0x10, 0xc0, 0x54, 0xea, 0x95, 0x2c, 0x5a, 0x97, 0xb9, 0x1f, 0x82, 0x4a, 0x48, 0xed, 0x9d, 0xc1,
-		0x01, 0xf8, 0xae, 0x87, 0x30, 0x9d, 0x7e, 0xea, 0x85, 0xf2, 0xb1, 0xe4, 0x3c, 0x86, 0xab, 0x5d,
-		0x3e, 0x02, 0x44, 0x4c, 0xec, 0x72, 0x1b, 0x1a, 0x25, 0x62, 0x57, 0xf4, 0xdc, 0xa4, 0x76, 0x82,
-		0xfe, 0x49, 0xf4, 0x97, 0x0a, 0x9c, 0x91, 0xf5, 0x3b, 0xa2, 0x5b, 0xc7, 0x6c, 0x93, 0x64, 0xa4,
-		0x7d, 0x70, 0xa2, 0x26, 0x4b, 0xce, 0xb2, 0x9c, 0xa6, 0x40, 0x29, 0xcb, 0xe4, 0x2d, 0x89, 0x52,
-		0x96, 0x15, 0xf4, 0x20, 0xc6, 0xee, 0x51, 0xd0, 0x71, 0x5d, 0x78, 0x8f, 0xf9, 0xbd, 0xee, 0x85,
-		0xf7, 0x28, 0x6b, 0xf0, 0x8e, 0xdd, 0xa3, 0xb0, 0x2f, 0xaf, 0xf8, 0x1e, 0x65, 0xbd, 0x81, 0xc5,
-		0xf7, 0x28, 0x6d, 0x06, 0x8c, 0xdf, 0x63, 0xb6, 0xf5, 0xae, 0xf8, 0x1e, 0x73, 0x1b, 0xff, 0x8a,
-		0xef, 0x31, 0xbf, 0xd3, 0x0f, 0xfd, 0x05, 0xcd, 0x6d, 0xe6, 0xf6, 0xd4, 0xa1, 0xf7, 0x8f, 0x74,
-		0xe6, 0x64, 0x57, 0x5f, 0xed, 0xd6, 0xf1, 0x80, 0x13, 0xa4, 0xe5, 0x36, 0x94, 0x4a, 0x49, 0x2b,
-		0x6a, 0x69, 0x95, 0x92, 0x56, 0xdc, 0xc3, 0xfa, 0xd7, 0x0a, 0x2c, 0xcb, 0x3b, 0xc9, 0xd0, 0x77,
-		0x24, 0x1b, 0x0c, 0xd0, 0x4e, 0x57, 0xbb, 0x7d, 0x6c, 0x78, 0x4e, 0xe3, 0x8f, 0x14, 0xa8, 0xe6,
-		0xf5, 0x13, 0xa2, 0xeb, 0x12, 0xec, 0xd2, 0xc6, 0xc9, 0xda, 0x8d, 0x63, 0x40, 0x72, 0x8a, 0xbe,
-		0xaf, 0xc0, 0xac, 0xa8, 0x2b, 0x0d, 0xe5, 0x7f, 0x39, 0x25, 0x3d, 0x78, 0xb5, 0xf7, 0x8e, 0x08,
-		0xc5, 0xa9, 0xf8, 0x2b, 0xfa, 0x0f, 0xa0, 0x24, 0x4d, 0x59, 0xe8, 0x83, 0x02, 0xd9, 0x90, 0xb7,
-		0xcc, 0xd5, 0xbe, 0x73, 0x5c, 0x70, 0x4e, 0xe0, 0x67, 0x30, 0x93, 0xe9, 0x4f, 0x42, 0x97, 0x25,
-		0x48, 0xc5, 0x6d, 0x63, 0xb5, 0x8d, 0xa3, 0x80, 0xf4, 0xbd, 0x91, 0x54, 0xc7, 0x91, 0xc4, 0x1b,
-		0x11, 0xf7, 0x49, 0x49, 0xbc, 0x91, 0x9c, 0x66, 0x26, 0xf4, 0x02, 0x26, 0xe2, 0x1d, 0x20, 0xe8,
-		0xdb, 0x52, 0x0c, 0xa9, 0x96, 0xa7, 0xda, 0xdb, 0x03, 0xae, 0x8e, 0x49, 0xa1, 0xa8, 0x85, 0x43,
-		0x22, 0x85, 0x92, 0x2e, 0x14, 0x89, 0x14, 0x4a, 0xfb, 0x44, 0x88, 0xe7, 0x29, 0xe8, 0xcc, 0x90,
-		0x78, 0x9e, 0xf9, 0x6d, 0x1e, 0xb5, 0x77, 0x8f, 0x06, 0x14, 0xbd, 0x45, 0x81, 0x7e, 0xa3, 0x03,
-		0xba, 0x94, 0x8b, 0x23, 0xd3, 0x3d, 0x51, 0x7b, 0x6b, 0xa0, 0xb5, 0xfd, 0x6d, 0xfa, 0x9d, 0x04,
-		0x92, 0x6d, 0x32, 0xdd, 0x15, 0x92, 0x6d, 0xb2, 0xad, 0x09, 0x6c, 0x9b, 0xb0, 0x11, 0x40, 0xba,
-		0x4d, 0xaa, 0x7d, 0x41, 0xba, 0x4d, 0xba, 0xb3, 0x80, 0x44, 0x28, 0x89, 0x22, 0xbe, 0x24, 0x42,
-		0x11, 0x35, 0x20, 0x48, 0x22, 0x14, 0x71, 0x6f, 0xc0, 0x0f, 0xd8, 0xff, 0x0e, 0x12, 0x14, 0x7a,
-		0x25, 0xa1, 0xac, 0xb4, 0x29, 0x40, 0x12, 0xca, 0x16, 0x94, 0xf1, 0x89, 0x03, 0x93, 0x5b, 0x77,
-		0x96, 0x38, 0x30, 0x45, 0xa5, 0x71, 0x89, 0x03, 0x53, 0x5c, 0xe6, 0x76, 0x60, 0x32, 0x51, 0xb5,
-		0x95, 0x5c, 0x88, 0xa8, 0x70, 0x2d, 0xb9, 0x10, 0x61, 0x31, 0x98, 0x9a, 0x0f, 0x51, 0x85, 0x15,
-		0xc9, 0xc2, 0xbf, 0xdc, 0xda, 0xb1, 0xc4, 0x7c, 0xc8, 0xca, 0xb8, 0x24, 0x7e, 0x4b, 0xd7, 0x62,
-		0x25, 0xf1, 0x5b, 0x4e, 0xc5, 0x57, 0x12, 0xbf, 0xe5, 0x16, 0x7a, 0x03, 0x98, 0x4a, 0x15, 0x1d,
-		0x25, 0x1f, 0x08, 0x71, 0x29, 0x57, 0xf2, 0x81, 0xc8, 0xab, 0x67, 0x92, 0x70, 0x35, 0x55, 0xd4,
-		0x92, 0x85, 0xab, 0xe2, 0x32, 0x9f, 0x2c, 0x5c, 0xcd, 0xa9, 0x98, 0x91, 0x8d, 0xd3, 0x45, 0x20,
-		0xc9, 0xc6, 0x39, 0xb5, 0x35, 0xc9, 0xc6, 0xb9, 0x15, 0xa6, 0x3f, 0x54, 0x60, 0x4e, 0x58, 0xb7,
-		0x41, 0xf9, 0x12, 0x23, 0xab, 0x34, 0xd5, 0xae, 0x1e, 0x15, 0x2c, 0x26, 0xef, 0xa2, 0xaa, 0x87,
-		0x44, 0xde, 0x25, 0xe5, 0x24, 0x89, 0xbc, 0x4b, 0x0b, 0x44, 0x5f, 0x28, 0xd1, 0xb3, 0xa5, 0xfc,
-		0xf4, 0x3a, 0xba, 0x53, 0x14, 0x6f, 0x14, 0x96, 0x21, 0x6a, 0x77, 0x4f, 0x82, 0x22, 0x91, 0xd2,
-		0x89, 0xe7, 0xd7, 0xe5, 0x29, 0x1d, 0x41, 0x02, 0x5f, 0x9e, 0xd2, 0x11, 0xa6, 0xee, 0x89, 0x66,
-		0x26, 0x93, 0xe2, 0x32, 0xcd, 0x14, 0x66, 0xe2, 0x65, 0x9a, 0x29, 0xce, 0xb7, 0xdf, 0xbd, 0xf1,
-		0x9b, 0xd7, 0xf6, 0xac, 0x60, 0xbf, 0xbb, 0x5b, 0x6f, 0xba, 0xed, 0xf5, 0xc4, 0xff, 0x05, 0xaf,
-		0xef, 0x61, 0x87, 0xfd, 0x93, 0xf8, 0xd8, 0x7f, 0xa9, 0x7f, 0x9f, 0xff, 0x79, 0x70, 0x79, 0x77,
-		0x98, 0xce, 0x5d, 0xf9, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x96, 0x6b, 0x85, 0xe0, 0xd1, 0x5e,
-		0x00, 0x00,
+		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x3d, 0x4b, 0x6c, 0x1c, 0xc9,
+		0x75, 0xe9, 0x19, 0xfe, 0xe6, 0x71, 0xf8, 0x2b, 0xfe, 0x46, 0x4d, 0x89, 0x22, 0x5b, 0xd2, 0xae,
+		0xac, 0xf5, 0x52, 0x9f, 0x5d, 0x7d, 0x56, 0xab, 0xf5, 0x5a, 0x22, 0x29, 0xed, 0xac, 0x49, 0x89,
+		0x6a, 0x72, 0xb5, 0x71, 0x10, 0x6f, 0xbb, 0x39, 0x5d, 0x43, 0x76, 0x34, 0xd3, 0x3d, 0xdb, 0xdd,
+		0x43, 0x69, 0xf6, 0x90, 0x38, 0x71, 0x10, 0x20, 0xce, 0xc7, 0x8e, 0x11, 0x07, 0x01, 0x0c, 0x38,
+		0x08, 0x1c, 0xc0, 0xb0, 0x61, 0xe4, 0x92, 0x04, 0x39, 0x04, 0xb9, 0x24, 0x39, 0x18, 0x39, 0xe5,
+		0x1a, 0x20, 0xb9, 0x39, 0x87, 0x04, 0x08, 0x10, 0x20, 0x46, 0xce, 0x41, 0xfd, 0xfa, 0xdf, 0x3d,
+		0x3d, 0xe4, 0x3a, 0x2b, 0x3b, 0x7b, 0xe3, 0x54, 0xbd, 0xf7, 0xea, 0xd5, 0xab, 0xaa, 0x57, 0xaf,
+		0xde, 0xa7, 0x09, 0x17, 0xba, 0xfb, 0xd8, 0xb9, 0xdc, 0xd0, 0x0d, 0x6c, 0x35, 0xf0, 0xe5, 0x43,
+		0xd3, 0xf5, 0x6c, 0xa7, 0x77, 0xf9, 0xe8, 0xea, 0x65, 0x17, 0x3b, 0x47, 0x66, 0x03, 0xaf, 0x75,
+		0x1c, 0xdb, 0xb3, 0xd1, 0x22, 0x01, 0x5b, 0xe3, 0x60, 0x6b, 0x1c, 0x6c, 0xed, 0xe8, 0xaa, 0xbc,
+		0x7c, 0x60, 0xdb, 0x07, 0x2d, 0x7c, 0x99, 0x82, 0xed, 0x77, 0x9b, 0x97, 0x8d, 0xae, 0xa3, 0x7b,
+		0xa6, 0x6d, 0x31, 0x44, 0xf9, 0x6c, 0xbc, 0xdf, 0x33, 0xdb, 0xd8, 0xf5, 0xf4, 0x76, 0x87, 0x03,
+		0x24, 0x08, 0x3c, 0x73, 0xf4, 0x4e, 0x07, 0x3b, 0x2e, 0xef, 0x5f, 0x89, 0x30, 0xa8, 0x77, 0x4c,
+		0xc2, 0x5c, 0xc3, 0x6e, 0xb7, 0xfd, 0x21, 0x56, 0xd3, 0x20, 0x04, 0x8b, 0x9c, 0x8b, 0x34, 0x90,
+		0x0f, 0xbb, 0xd8, 0x07, 0x50, 0xd2, 0x00, 0x3c, 0xdd, 0x7d, 0xda, 0x32, 0x5d, 0x2f, 0x0f, 0xe6,
+		0x99, 0xed, 0x3c, 0x6d, 0xb6, 0xec, 0x67, 0x1c, 0xe6, 0x52, 0x1a, 0x0c, 0x17, 0xa5, 0x16, 0x83,
+		0xbd, 0xd8, 0x0f, 0x16, 0x3b, 0x1c, 0xf2, 0x5c, 0x14, 0xd2, 0x68, 0x9b, 0x16, 0x95, 0x42, 0xab,
+		0xeb, 0x7a, 0xfd, 0x80, 0xa2, 0x82, 0x58, 0x4d, 0x07, 0xfa, 0xb0, 0x8b, 0xbb, 0x7c, 0xa9, 0xe5,
+		0x97, 0xd3, 0x41, 0x1c, 0xdc, 0x69, 0x99, 0x8d, 0xf0, 0xd2, 0x46, 0x57, 0xc6, 0x3d, 0xd4, 0x1d,
+		0x6c, 0x10, 0x48, 0xdd, 0x12, 0xa3, 0x9d, 0xcf, 0x80, 0x88, 0xf2, 0x74, 0x21, 0x03, 0x2a, 0x2a,
+		0x2e, 0xe5, 0x3b, 0x63, 0x70, 0x66, 0xd7, 0xd3, 0x1d, 0xef, 0x7d, 0xde, 0xbe, 0xf9, 0x1c, 0x37,
+		0xba, 0x84, 0x1f, 0x15, 0x7f, 0xd8, 0xc5, 0xae, 0x87, 0xb6, 0x60, 0xd4, 0x61, 0x7f, 0xd6, 0xa4,
+		0x15, 0xe9, 0xe2, 0xf8, 0xb5, 0x6b, 0x6b, 0x91, 0x6d, 0xab, 0x77, 0xcc, 0xb5, 0xa3, 0xab, 0x6b,
+		0xb9, 0x44, 0x54, 0x41, 0x02, 0x2d, 0x41, 0xc5, 0xb0, 0xdb, 0xba, 0x69, 0x69, 0xa6, 0x51, 0x2b,
+		0xad, 0x48, 0x17, 0x2b, 0xea, 0x18, 0x6b, 0xa8, 0x1b, 0xe8, 0x97, 0x61, 0xbe, 0xa3, 0x3b, 0xd8,
+		0xf2, 0x34, 0x2c, 0x08, 0x68, 0xa6, 0xd5, 0xb4, 0x6b, 0x65, 0x3a, 0xf0, 0xc5, 0xd4, 0x81, 0x77,
+		0x28, 0x86, 0x3f, 0x62, 0xdd, 0x6a, 0xda, 0xea, 0x6c, 0x27, 0xd9, 0x88, 0x6a, 0x30, 0xaa, 0x7b,
+		0x1e, 0x6e, 0x77, 0xbc, 0xda, 0xd0, 0x8a, 0x74, 0x71, 0x58, 0x15, 0x3f, 0xd1, 0x3a, 0x4c, 0xe1,
+		0xe7, 0x1d, 0x93, 0x1d, 0x31, 0x8d, 0x9c, 0xa5, 0xda, 0x30, 0x1d, 0x51, 0x5e, 0x63, 0xe7, 0x68,
+		0x4d, 0x9c, 0xa3, 0xb5, 0x3d, 0x71, 0xd0, 0xd4, 0xc9, 0x00, 0x85, 0x34, 0xa2, 0x26, 0x9c, 0x6a,
+		0xd8, 0x96, 0x67, 0x5a, 0x5d, 0xac, 0xe9, 0xae, 0x66, 0xe1, 0x67, 0x9a, 0x69, 0x99, 0x9e, 0xa9,
+		0x7b, 0xb6, 0x53, 0x1b, 0x59, 0x91, 0x2e, 0x4e, 0x5e, 0x7b, 0x25, 0x75, 0x02, 0xeb, 0x1c, 0xeb,
+		0xae, 0xfb, 0x10, 0x3f, 0xab, 0x0b, 0x14, 0x75, 0xa1, 0x91, 0xda, 0x8e, 0xea, 0x30, 0x23, 0x7a,
+		0x0c, 0xad, 0xa9, 0x9b, 0xad, 0xae, 0x83, 0x6b, 0xa3, 0x94, 0xdd, 0xd3, 0xa9, 0xf4, 0xef, 0x33,
+		0x18, 0x75, 0xda, 0x47, 0xe3, 0x2d, 0x48, 0x85, 0x85, 0x96, 0xee, 0x7a, 0x5a, 0xc3, 0x6e, 0x77,
+		0x5a, 0x98, 0x4e, 0xde, 0xc1, 0x6e, 0xb7, 0xe5, 0xd5, 0xc6, 0x72, 0xe8, 0xed, 0xe8, 0xbd, 0x96,
+		0xad, 0x1b, 0xea, 0x1c, 0xc1, 0x5d, 0xf7, 0x51, 0x55, 0x8a, 0x89, 0x7e, 0x11, 0x96, 0x9a, 0xa6,
+		0xe3, 0x7a, 0x9a, 0x81, 0x1b, 0xa6, 0x4b, 0xe5, 0xa9, 0xbb, 0x4f, 0xb5, 0x7d, 0xbd, 0xf1, 0xd4,
+		0x6e, 0x36, 0x6b, 0x15, 0x4a, 0xf8, 0x54, 0x42, 0xae, 0x1b, 0x5c, 0xc1, 0xa9, 0x35, 0x8a, 0xbd,
+		0xc1, 0x91, 0xf7, 0x74, 0xf7, 0xe9, 0x3d, 0x86, 0x8a, 0x8e, 0x60, 0xba, 0xa3, 0x3b, 0x9e, 0x49,
+		0xf9, 0x6c, 0xd8, 0x56, 0xd3, 0x3c, 0xa8, 0xc1, 0x4a, 0xf9, 0xe2, 0xf8, 0xb5, 0x2f, 0xac, 0x65,
+		0x28, 0xd2, 0xfc, 0x5d, 0xb9, 0xb6, 0x23, 0xc8, 0xad, 0x53, 0x6a, 0x9b, 0x96, 0xe7, 0xf4, 0xd4,
+		0xa9, 0x4e, 0xb4, 0x15, 0xd5, 0x61, 0xd6, 0xb2, 0xb5, 0x8e, 0x63, 0x1f, 0x38, 0xd8, 0x75, 0xe9,
+		0xf6, 0xb0, 0xbb, 0x5e, 0x6d, 0xbc, 0xdf, 0x4c, 0x66, 0x2c, 0x7b, 0x87, 0x23, 0xed, 0x31, 0x1c,
+		0xd4, 0x06, 0x39, 0x85, 0x94, 0xd6, 0xb1, 0x5b, 0x66, 0xa3, 0x57, 0xab, 0xd2, 0x4d, 0x72, 0x25,
+		0x73, 0x32, 0x0f, 0xe3, 0xf4, 0x76, 0x28, 0x9e, 0xba, 0x68, 0xa5, 0x77, 0xc8, 0xf7, 0x60, 0x2e,
+		0x6d, 0x8a, 0x68, 0x1a, 0xca, 0x4f, 0x71, 0x8f, 0x1e, 0xe7, 0x8a, 0x4a, 0xfe, 0x44, 0x73, 0x30,
+		0x7c, 0xa4, 0xb7, 0xba, 0x98, 0x1f, 0x49, 0xf6, 0xe3, 0x76, 0xe9, 0x96, 0xa4, 0xdc, 0x84, 0xe5,
+		0x2c, 0x21, 0xba, 0x1d, 0xdb, 0x72, 0x31, 0x9a, 0x87, 0x11, 0xa7, 0x4b, 0xcf, 0x33, 0x23, 0x38,
+		0xec, 0x74, 0xad, 0xba, 0xa1, 0xfc, 0x59, 0x09, 0x96, 0x77, 0xcd, 0x03, 0x4b, 0x6f, 0x65, 0xaa,
+		0x96, 0xed, 0xb8, 0x6a, 0x79, 0x2d, 0x5d, 0xb5, 0xe4, 0x52, 0x29, 0xa8, 0x5b, 0x9a, 0xb0, 0x84,
+		0x9f, 0x7b, 0xd8, 0xb1, 0xf4, 0x96, 0x7f, 0x65, 0x04, 0x6a, 0x86, 0x6b, 0x98, 0x97, 0x52, 0xc7,
+		0x4f, 0x8e, 0x7c, 0x4a, 0x90, 0x4a, 0x74, 0xa1, 0x35, 0x98, 0x6d, 0x1c, 0x9a, 0x2d, 0x23, 0x18,
+		0xc4, 0xb6, 0x5a, 0x3d, 0xaa, 0x71, 0xc6, 0xd4, 0x19, 0xda, 0x25, 0x90, 0x1e, 0x59, 0xad, 0x9e,
+		0xb2, 0x0a, 0x67, 0x33, 0xe7, 0xc7, 0x04, 0xac, 0xfc, 0xc9, 0x10, 0xbc, 0xcc, 0x61, 0x4c, 0xef,
+		0x30, 0x5f, 0x5b, 0x3f, 0x89, 0x8b, 0xf4, 0x4e, 0x9e, 0x48, 0xfb, 0x91, 0x2b, 0x28, 0xdb, 0xaf,
+		0x48, 0x29, 0x47, 0xb3, 0x4c, 0x8f, 0xe6, 0x7b, 0xd9, 0x47, 0xb3, 0x18, 0x0b, 0x27, 0x3b, 0xa4,
+		0x43, 0x1f, 0xfb, 0x21, 0x1d, 0x7e, 0x11, 0x0f, 0xe9, 0x5d, 0xb8, 0xd8, 0x5f, 0x9c, 0xf9, 0xc7,
+		0xf5, 0x6b, 0x12, 0x9c, 0x51, 0xb1, 0x8b, 0x4f, 0x6c, 0x08, 0xe4, 0x12, 0x29, 0xb6, 0xa1, 0x88,
+		0xd2, 0xc9, 0x22, 0x93, 0x3f, 0x8b, 0x1f, 0x94, 0x60, 0x75, 0x0f, 0x3b, 0x6d, 0xd3, 0xd2, 0x3d,
+		0x9c, 0x39, 0x93, 0x9d, 0xf8, 0x4c, 0x6e, 0xa4, 0xce, 0xa4, 0x2f, 0xa1, 0x9f, 0x71, 0xd5, 0x73,
+		0x1e, 0x94, 0xbc, 0x29, 0x72, 0xed, 0xf3, 0x0d, 0x09, 0x56, 0x36, 0xb0, 0xdb, 0x70, 0xcc, 0xfd,
+		0x6c, 0x89, 0x3e, 0x8a, 0x4b, 0xf4, 0x7a, 0xea, 0x74, 0xfa, 0xd1, 0x29, 0xb8, 0x3d, 0xfe, 0x75,
+		0x08, 0x56, 0x73, 0x48, 0xf1, 0x2d, 0xd2, 0x82, 0xc5, 0xc0, 0x8c, 0x64, 0x4a, 0x89, 0x9f, 0xfa,
+		0xdc, 0xdb, 0x26, 0x41, 0x70, 0x3d, 0x8c, 0xaa, 0x2e, 0xe0, 0xd4, 0x76, 0xb4, 0x0f, 0x8b, 0xc9,
+		0xb5, 0x65, 0xd6, 0x6b, 0x89, 0x8e, 0x76, 0xa9, 0xd8, 0x68, 0xd4, 0x7e, 0x9d, 0x7f, 0x96, 0xd6,
+		0x8c, 0xde, 0x07, 0xd4, 0xc1, 0x96, 0x61, 0x5a, 0x07, 0x9a, 0xde, 0xf0, 0xcc, 0x23, 0xd3, 0x33,
+		0xb1, 0xcb, 0x15, 0x6d, 0x86, 0x71, 0xcc, 0xc0, 0xef, 0x32, 0xe8, 0x1e, 0x25, 0x3e, 0xd3, 0x89,
+		0x34, 0x9a, 0xd8, 0x45, 0x5f, 0x84, 0x69, 0x41, 0x98, 0x6e, 0x13, 0x07, 0x5b, 0xb5, 0x21, 0x4a,
+		0x76, 0x2d, 0x8f, 0xec, 0x3a, 0x81, 0x8d, 0x72, 0x3e, 0xd5, 0x09, 0x75, 0x39, 0xd8, 0x42, 0xbb,
+		0x01, 0x69, 0x61, 0x11, 0x72, 0xe3, 0x3a, 0x97, 0x63, 0x61, 0x00, 0x46, 0x88, 0x8a, 0x46, 0xf4,
+		0x25, 0x98, 0xf3, 0x85, 0xed, 0x9a, 0x1f, 0x61, 0xad, 0x65, 0xb6, 0x4d, 0xcf, 0xa5, 0x66, 0xf6,
+		0xf8, 0xb5, 0x57, 0x32, 0x95, 0xb3, 0x90, 0xf6, 0xae, 0xf9, 0x11, 0xde, 0xa2, 0x28, 0x2a, 0x7a,
+		0x96, 0x68, 0x53, 0xfe, 0xa5, 0x04, 0x28, 0x09, 0x8a, 0x2e, 0xc3, 0xdc, 0x7e, 0xcb, 0xde, 0x0f,
+		0x8d, 0xa8, 0x3d, 0xd3, 0x1d, 0xb6, 0x9b, 0xca, 0xea, 0x0c, 0xe9, 0xf3, 0xa1, 0xdf, 0xd7, 0x1d,
+		0x0b, 0x5d, 0x85, 0xf9, 0x38, 0x02, 0x76, 0x1c, 0xdb, 0xa1, 0x3b, 0xa2, 0xac, 0xa2, 0x08, 0xc6,
+		0x26, 0xe9, 0x41, 0xd7, 0x61, 0x91, 0xf3, 0x9b, 0x18, 0xa6, 0x4c, 0x91, 0xe6, 0x78, 0x77, 0x74,
+		0xa4, 0x9b, 0x50, 0x4b, 0x41, 0x63, 0x83, 0x0d, 0x51, 0xbc, 0xf9, 0x38, 0x1e, 0x1b, 0x2f, 0x84,
+		0xd8, 0xb0, 0xbb, 0x96, 0x17, 0x1e, 0x70, 0x38, 0x82, 0xb8, 0x4e, 0xba, 0x83, 0x11, 0xdf, 0x80,
+		0x53, 0x69, 0x88, 0x6c, 0xc8, 0x11, 0x8a, 0xb9, 0x90, 0xc0, 0xa4, 0x63, 0x2a, 0xcf, 0x61, 0xee,
+		0x31, 0xf1, 0x12, 0x08, 0x11, 0x0b, 0x25, 0xb2, 0x1e, 0x57, 0x22, 0x9f, 0x49, 0xdd, 0x21, 0x69,
+		0xb8, 0x05, 0x15, 0xc7, 0x77, 0x25, 0x98, 0x8f, 0xa1, 0x73, 0x65, 0xf1, 0x36, 0x54, 0xa9, 0xe7,
+		0x42, 0x3c, 0x80, 0xa4, 0x02, 0x0f, 0xa0, 0x71, 0x8a, 0xc1, 0xdf, 0x3d, 0x75, 0x98, 0x14, 0x04,
+		0x7e, 0x05, 0x37, 0x3c, 0x6c, 0xf0, 0x63, 0xaf, 0x64, 0xcf, 0x41, 0xe5, 0x90, 0xea, 0xc4, 0x87,
+		0xe1, 0x9f, 0xca, 0x6f, 0x4a, 0x20, 0xd3, 0xeb, 0x6f, 0xd7, 0x33, 0x1b, 0x4f, 0x7b, 0xe4, 0x0d,
+		0xb4, 0x65, 0xba, 0x9e, 0x10, 0x53, 0x3d, 0x2e, 0xa6, 0xcb, 0xd9, 0xf7, 0x70, 0x2a, 0x85, 0x82,
+		0xc2, 0x3a, 0x03, 0x4b, 0xa9, 0x34, 0xf8, 0xbd, 0xf0, 0xdf, 0x12, 0x2c, 0x3c, 0xc0, 0xde, 0x76,
+		0xd7, 0xd3, 0xf7, 0x5b, 0x78, 0xd7, 0xd3, 0x3d, 0xac, 0xa6, 0x91, 0x95, 0x62, 0xb7, 0xe1, 0x7b,
+		0x80, 0x52, 0x2e, 0xc1, 0xd2, 0x40, 0x97, 0xe0, 0x4c, 0x42, 0x3f, 0xa2, 0xd7, 0x60, 0x01, 0x3f,
+		0xef, 0x50, 0x01, 0x6a, 0x16, 0x7e, 0xee, 0x69, 0xf8, 0x08, 0x5b, 0x1e, 0x61, 0x80, 0x9d, 0x9b,
+		0x59, 0xd1, 0xfb, 0x10, 0x3f, 0xf7, 0x36, 0x49, 0x5f, 0xdd, 0x40, 0x57, 0x60, 0xae, 0xd1, 0x75,
+		0xa8, 0xc7, 0x61, 0xdf, 0xd1, 0xad, 0xc6, 0xa1, 0xe6, 0xd9, 0x4f, 0xa9, 0xee, 0x93, 0x2e, 0x56,
+		0x55, 0xc4, 0xfb, 0xee, 0xd1, 0xae, 0x3d, 0xd2, 0xa3, 0xfc, 0x75, 0x05, 0x16, 0x13, 0xb3, 0xe6,
+		0x7b, 0x28, 0x7d, 0x66, 0xd2, 0x49, 0x67, 0x76, 0x1f, 0x26, 0x7c, 0xb2, 0x5e, 0xaf, 0x83, 0xb9,
+		0xac, 0x56, 0x73, 0x29, 0xee, 0xf5, 0x3a, 0x58, 0xad, 0x3e, 0x0b, 0xfd, 0x42, 0x0a, 0x4c, 0xa4,
+		0x09, 0x66, 0xdc, 0x0a, 0x09, 0xe4, 0x09, 0x9c, 0xea, 0x38, 0xf8, 0xc8, 0xb4, 0xbb, 0xae, 0xe6,
+		0x12, 0x3b, 0x12, 0x1b, 0x01, 0x3c, 0x33, 0xa6, 0x97, 0x12, 0xc6, 0x74, 0xdd, 0xf2, 0x6e, 0xbc,
+		0xfe, 0x84, 0x18, 0xa3, 0xea, 0x82, 0xc0, 0xde, 0x65, 0xc8, 0x82, 0xee, 0xab, 0x30, 0x4b, 0x3d,
+		0x0d, 0xcc, 0x35, 0xe0, 0x53, 0x64, 0x1a, 0x66, 0x9a, 0x74, 0xdd, 0x27, 0x3d, 0x02, 0xfc, 0x36,
+		0x54, 0xa8, 0xd7, 0xa0, 0x65, 0xba, 0x1e, 0x57, 0xea, 0x67, 0xd2, 0x4d, 0x34, 0xb1, 0x2b, 0xc7,
+		0x3c, 0xfe, 0x17, 0x7a, 0x00, 0xd3, 0x2e, 0xdd, 0xb1, 0x5a, 0x40, 0x62, 0xb4, 0x08, 0x89, 0x49,
+		0x37, 0xb2, 0xd1, 0xd1, 0xeb, 0xb0, 0xd0, 0x68, 0x99, 0x98, 0xaa, 0xb6, 0x7d, 0x47, 0x77, 0x7a,
+		0xda, 0x11, 0x76, 0xe8, 0xfd, 0x35, 0x46, 0xb7, 0xf4, 0x1c, 0xeb, 0xdd, 0x62, 0x9d, 0x4f, 0x58,
+		0x5f, 0x08, 0xab, 0x89, 0x75, 0xaf, 0xeb, 0x60, 0x1f, 0xab, 0x12, 0xc6, 0xba, 0xcf, 0x3a, 0x05,
+		0xd6, 0x59, 0x18, 0xe7, 0x58, 0x66, 0xbb, 0xd3, 0xaa, 0x01, 0x05, 0x05, 0xd6, 0x54, 0x6f, 0x77,
+		0x5a, 0xc8, 0x85, 0x4b, 0xf1, 0x59, 0x69, 0x6e, 0xe3, 0x10, 0x1b, 0xdd, 0x16, 0xd6, 0x3c, 0x9b,
+		0x2d, 0x56, 0x71, 0xdf, 0xc4, 0xf9, 0xe8, 0x5c, 0x77, 0x39, 0xa5, 0x3d, 0x9b, 0xae, 0x9b, 0x78,
+		0x09, 0xad, 0xc1, 0x2c, 0x5b, 0x2a, 0xa2, 0xc6, 0x83, 0x89, 0x54, 0xa9, 0xf7, 0x6c, 0x86, 0x76,
+		0xed, 0x7a, 0x76, 0x30, 0x8b, 0xac, 0xe3, 0x34, 0x91, 0x75, 0x9c, 0xd0, 0x16, 0x4c, 0x06, 0x17,
+		0x39, 0x39, 0x4c, 0xb5, 0x49, 0xfa, 0xbe, 0xba, 0x10, 0x5d, 0x2a, 0xe6, 0xbe, 0x8c, 0xdc, 0xe0,
+		0xf4, 0xe4, 0x4d, 0x3c, 0x0b, 0xff, 0x44, 0x8d, 0x90, 0x59, 0xd0, 0x68, 0xd9, 0x2e, 0xe6, 0x34,
+		0xa7, 0x28, 0xcd, 0xab, 0x05, 0xcd, 0x3d, 0x82, 0x48, 0xe8, 0x75, 0x43, 0xc6, 0x81, 0xdf, 0x48,
+		0x4e, 0xf9, 0x0c, 0x17, 0x84, 0xc6, 0xee, 0x37, 0x13, 0xbb, 0xb5, 0xe9, 0x34, 0x8b, 0x26, 0xe0,
+		0x9a, 0x0b, 0xe8, 0x1d, 0x01, 0xaf, 0x4e, 0x1f, 0xc5, 0x5a, 0xd0, 0x1d, 0x58, 0x32, 0x5d, 0x8d,
+		0x2d, 0x4b, 0x68, 0x8d, 0xb1, 0x45, 0xf4, 0x8c, 0x51, 0x9b, 0xa1, 0x46, 0xfc, 0xa2, 0xe9, 0x46,
+		0xb5, 0xf1, 0x26, 0xeb, 0x46, 0xab, 0x50, 0x0d, 0xdf, 0xff, 0x35, 0xc4, 0x8e, 0x76, 0xe8, 0xce,
+		0x57, 0x7e, 0x22, 0xc1, 0xe2, 0x8e, 0xdd, 0x6a, 0xfd, 0x3f, 0x53, 0xd8, 0xdf, 0x1b, 0x83, 0x5a,
+		0x72, 0xda, 0x9f, 0x6a, 0xec, 0x4f, 0x35, 0xf6, 0xcf, 0xa3, 0xc6, 0xce, 0x3a, 0x1f, 0xd5, 0x4c,
+		0x0d, 0x9c, 0xaa, 0xce, 0x26, 0x4e, 0xac, 0xce, 0x7e, 0xf6, 0x14, 0xbb, 0xf2, 0xf7, 0x25, 0x58,
+		0x51, 0x71, 0xc3, 0x76, 0x8c, 0x70, 0xf4, 0x81, 0x1f, 0x8b, 0x4f, 0x52, 0x53, 0x9e, 0x85, 0x71,
+		0x7f, 0xe3, 0xf8, 0x4a, 0x00, 0x44, 0x53, 0xdd, 0x40, 0x8b, 0x30, 0x4a, 0xf7, 0x18, 0x3f, 0xf1,
+		0x65, 0x75, 0x84, 0xfc, 0xac, 0x1b, 0xe8, 0x0c, 0x00, 0x37, 0xf5, 0xc5, 0xd9, 0xad, 0xa8, 0x15,
+		0xde, 0x52, 0x37, 0x90, 0x0a, 0xd5, 0x8e, 0xdd, 0x6a, 0x69, 0xbc, 0xa5, 0x36, 0x92, 0xf3, 0x9c,
+		0x20, 0x3a, 0xf4, 0xbe, 0xed, 0x84, 0x45, 0x23, 0x9e, 0x13, 0xe3, 0x84, 0x08, 0xff, 0xa1, 0xfc,
+		0xc6, 0x18, 0xac, 0xe6, 0x48, 0x91, 0x2b, 0xde, 0x84, 0x86, 0x94, 0x8e, 0xa7, 0x21, 0x73, 0xb5,
+		0x5f, 0xe9, 0xf8, 0xda, 0xef, 0xb3, 0x80, 0x84, 0x7c, 0x8d, 0xb8, 0xfa, 0x9d, 0xf6, 0x7b, 0x04,
+		0xf4, 0x45, 0xa2, 0xc0, 0x52, 0x54, 0x6f, 0x59, 0x9d, 0xe4, 0xed, 0x02, 0x32, 0xa1, 0xd1, 0x87,
+		0x93, 0x1a, 0x3d, 0x14, 0xa7, 0x1c, 0x89, 0xc6, 0x29, 0x6f, 0x41, 0x8d, 0xab, 0x94, 0xc0, 0xc3,
+		0x24, 0x0c, 0x84, 0x51, 0x6a, 0x20, 0x2c, 0xb0, 0x7e, 0x7f, 0xef, 0x08, 0xfb, 0x40, 0x85, 0x09,
+		0x3f, 0x1e, 0x47, 0x7d, 0x52, 0x2c, 0xc0, 0xf7, 0x6a, 0xd6, 0x69, 0xdc, 0x73, 0x74, 0xcb, 0x35,
+		0xb1, 0xe5, 0x45, 0xfc, 0x30, 0x55, 0x23, 0xf4, 0x0b, 0x7d, 0x00, 0xa7, 0x53, 0x3c, 0x5e, 0x81,
+		0x0a, 0xaf, 0x14, 0x51, 0xe1, 0xa7, 0x12, 0xdb, 0x5d, 0x74, 0x65, 0x59, 0x9f, 0x90, 0x65, 0x7d,
+		0xae, 0x42, 0x35, 0xa2, 0xf3, 0xc6, 0xa9, 0xce, 0x1b, 0xdf, 0x0f, 0x29, 0xbb, 0xbb, 0x30, 0x19,
+		0x2c, 0x2b, 0x8d, 0xf3, 0x56, 0xfb, 0xc6, 0x79, 0x27, 0x7c, 0x0c, 0xd2, 0x86, 0xde, 0x82, 0xaa,
+		0x58, 0x6b, 0x4a, 0x60, 0xa2, 0x2f, 0x81, 0x71, 0x0e, 0x4f, 0xd1, 0x75, 0x18, 0x25, 0x8f, 0x7d,
+		0xa2, 0x64, 0x27, 0xa9, 0x83, 0xed, 0x41, 0xa6, 0xb3, 0xaa, 0xef, 0x29, 0xa2, 0x5e, 0x04, 0x13,
+		0xbb, 0x2c, 0x24, 0x22, 0xe8, 0x26, 0x6c, 0xc1, 0xa9, 0x84, 0x2d, 0x28, 0x7f, 0x00, 0xd5, 0x30,
+		0x6e, 0x4a, 0xac, 0xe1, 0x56, 0x38, 0xd6, 0x90, 0xe5, 0xc5, 0x10, 0x07, 0x93, 0x79, 0x33, 0x42,
+		0xf1, 0x88, 0x40, 0x95, 0x0a, 0xcf, 0xe3, 0xa7, 0xaa, 0x34, 0xa1, 0x4a, 0xc3, 0xa2, 0x49, 0x55,
+		0xa5, 0x3f, 0x2e, 0x0b, 0x55, 0x9a, 0x2a, 0x45, 0xae, 0x4a, 0xdf, 0x85, 0xa9, 0x98, 0xaa, 0xca,
+		0x55, 0xa6, 0xec, 0x8a, 0xee, 0x51, 0x65, 0xa3, 0x4e, 0x46, 0x55, 0x59, 0x62, 0x73, 0x97, 0x06,
+		0xdb, 0xdc, 0x21, 0xcd, 0x55, 0x8e, 0x6a, 0xae, 0x0f, 0x60, 0x39, 0x7a, 0xf0, 0x34, 0xbb, 0xa9,
+		0x79, 0x87, 0xa6, 0xab, 0x85, 0x53, 0x32, 0xf2, 0x87, 0x92, 0x23, 0x07, 0xf1, 0x51, 0x73, 0xef,
+		0xd0, 0x74, 0xef, 0x72, 0xfa, 0x75, 0x98, 0x39, 0xc4, 0xba, 0xe3, 0xed, 0x63, 0xdd, 0xd3, 0x0c,
+		0xec, 0xe9, 0x66, 0xcb, 0xad, 0x0d, 0x17, 0xf0, 0xe1, 0x4d, 0xfb, 0x68, 0x1b, 0x0c, 0x2b, 0x79,
+		0x35, 0x8d, 0x1c, 0xef, 0x6a, 0x7a, 0x19, 0xa6, 0x7c, 0x3a, 0x6c, 0x5b, 0x53, 0x1d, 0x5d, 0x51,
+		0x7d, 0xc3, 0x68, 0x83, 0xb6, 0x2a, 0x7f, 0x24, 0xc1, 0x39, 0xb6, 0x9a, 0x91, 0xc3, 0xce, 0x33,
+		0x2b, 0x82, 0xf3, 0xa2, 0xc6, 0xfd, 0x7e, 0xb7, 0xb2, 0xfc, 0x7e, 0xfd, 0x48, 0x15, 0x74, 0x00,
+		0xfe, 0x45, 0x19, 0xce, 0xe7, 0x53, 0xe3, 0x5b, 0x10, 0x07, 0xf7, 0x9f, 0xc3, 0xdb, 0x38, 0x8b,
+		0xb7, 0x8f, 0xaf, 0xdd, 0xd4, 0x29, 0x37, 0xb6, 0xd3, 0xbf, 0x2b, 0xc1, 0x72, 0x10, 0xf7, 0x20,
+		0x36, 0xb4, 0x61, 0xba, 0x1d, 0xdd, 0x6b, 0x1c, 0x6a, 0x2d, 0xbb, 0xa1, 0xb7, 0x5a, 0xbd, 0x5a,
+		0x89, 0xea, 0xd4, 0x0f, 0x72, 0x46, 0xed, 0x3f, 0x9d, 0xb5, 0x20, 0x30, 0xb2, 0x67, 0x6f, 0xf0,
+		0x11, 0xb6, 0xd8, 0x00, 0x4c, 0xd5, 0x2e, 0xe9, 0xd9, 0x10, 0xf2, 0xaf, 0xc2, 0x4a, 0x3f, 0x02,
+		0x29, 0xfa, 0x76, 0x23, 0xaa, 0x6f, 0xd3, 0xc3, 0x2e, 0x42, 0x0d, 0x50, 0x5a, 0x82, 0x30, 0xbd,
+		0x99, 0x43, 0xba, 0x97, 0xc4, 0xeb, 0x52, 0xa6, 0x49, 0x72, 0x7e, 0xb0, 0x31, 0x60, 0xbc, 0xae,
+		0x1f, 0x9d, 0x82, 0x1b, 0xe9, 0x1c, 0xac, 0xe6, 0x50, 0xe2, 0xfe, 0xe4, 0x3f, 0x94, 0x40, 0x49,
+		0x6a, 0xbb, 0x77, 0xc4, 0xf1, 0x14, 0x9c, 0x3f, 0x8e, 0x73, 0x7e, 0x33, 0x83, 0xf3, 0x7e, 0x94,
+		0x0a, 0xf2, 0xbe, 0x03, 0xe7, 0x72, 0x69, 0xf1, 0xbd, 0xf9, 0x19, 0x98, 0x6e, 0xe8, 0x56, 0x03,
+		0xfb, 0x37, 0x00, 0x66, 0x77, 0xda, 0x98, 0x3a, 0xc5, 0xda, 0x55, 0xd1, 0x1c, 0x3e, 0xef, 0x61,
+		0x9a, 0x27, 0x3c, 0xef, 0x79, 0xa4, 0x0a, 0x4e, 0xf5, 0x25, 0x38, 0x9f, 0x4f, 0x2c, 0x14, 0x11,
+		0x4e, 0x01, 0x3c, 0xc9, 0x0e, 0xcb, 0xa4, 0x33, 0xf0, 0x0e, 0x4b, 0xa3, 0x14, 0xd9, 0x61, 0xc9,
+		0x09, 0xd2, 0xf5, 0xc1, 0xc6, 0xc0, 0x3b, 0xac, 0x1f, 0xa5, 0x82, 0xbc, 0x5f, 0x80, 0x73, 0xb9,
+		0xb4, 0x38, 0xf7, 0x7f, 0x29, 0xc1, 0x59, 0x15, 0xb7, 0xed, 0x23, 0xcc, 0x52, 0x3d, 0x5e, 0x14,
+		0x3f, 0x5e, 0xd4, 0x30, 0x2a, 0xc7, 0x0c, 0x23, 0x45, 0x81, 0x95, 0x6c, 0xae, 0xf9, 0xd4, 0xfe,
+		0xa6, 0x04, 0x17, 0xf8, 0x14, 0xd8, 0xb4, 0x33, 0xf3, 0x0c, 0x72, 0x27, 0xa8, 0xc3, 0x64, 0xf4,
+		0x0c, 0xd6, 0x4a, 0x69, 0x97, 0x90, 0xbf, 0x7e, 0x05, 0x06, 0x54, 0x27, 0x22, 0xa7, 0x97, 0x44,
+		0xf9, 0xfd, 0x54, 0x8e, 0xd4, 0x1c, 0xd5, 0xf4, 0x28, 0xff, 0x26, 0xc7, 0x89, 0x45, 0xf9, 0x71,
+		0x5a, 0xf3, 0xc0, 0x69, 0x1c, 0x17, 0xe1, 0xa5, 0x7e, 0x73, 0xe1, 0x72, 0xfe, 0x5b, 0x09, 0x96,
+		0x84, 0xe3, 0x28, 0xe5, 0x21, 0xff, 0x89, 0x6c, 0x9f, 0x4b, 0x30, 0x63, 0xba, 0x5a, 0x34, 0x65,
+		0x94, 0xca, 0x72, 0x4c, 0x9d, 0x32, 0xdd, 0xfb, 0xe1, 0x64, 0x50, 0x65, 0x19, 0x4e, 0xa7, 0xb3,
+		0xcf, 0xe7, 0xf7, 0xe3, 0x12, 0x9c, 0x67, 0xca, 0x3a, 0x9a, 0x99, 0x90, 0x50, 0xad, 0x9f, 0xc4,
+		0x44, 0x57, 0xa1, 0xca, 0xf3, 0x81, 0xb1, 0x11, 0xf2, 0xe5, 0xfa, 0x6d, 0x75, 0x03, 0xbd, 0x0f,
+		0xb3, 0x0d, 0xc1, 0x6a, 0x68, 0xe8, 0xa1, 0x81, 0x86, 0x46, 0x3e, 0x89, 0x60, 0xec, 0x2d, 0x98,
+		0x0e, 0xe5, 0xf8, 0xb2, 0x47, 0xc2, 0x70, 0xd1, 0x47, 0xc2, 0x54, 0x80, 0x4a, 0x1b, 0x94, 0x97,
+		0xe1, 0x42, 0x1f, 0x29, 0xf3, 0xf5, 0xf8, 0xf7, 0x12, 0xd4, 0x54, 0x9e, 0xe1, 0x8e, 0x29, 0xae,
+		0xfb, 0xe4, 0xda, 0x27, 0xb9, 0x06, 0x5f, 0x82, 0xf9, 0xa8, 0xb3, 0xb3, 0xa7, 0x99, 0x1e, 0x6e,
+		0x8b, 0x1c, 0x9a, 0x78, 0xbe, 0x01, 0xc9, 0xd2, 0x4f, 0xf8, 0x3b, 0x7b, 0x75, 0x0f, 0xb7, 0xd5,
+		0xd9, 0xa3, 0x44, 0x9b, 0x8b, 0xae, 0xc3, 0x08, 0x95, 0xad, 0x5b, 0x1b, 0xca, 0xf1, 0x7d, 0x6c,
+		0xe8, 0x9e, 0x7e, 0xaf, 0x65, 0xef, 0xab, 0x1c, 0x18, 0xad, 0xc3, 0x24, 0xc9, 0x16, 0x27, 0xf9,
+		0x6c, 0x1c, 0x7d, 0xb8, 0x08, 0x7a, 0xd5, 0xc2, 0xcf, 0xd4, 0x2e, 0x5b, 0x13, 0x57, 0x59, 0x82,
+		0x53, 0x29, 0xa2, 0xe6, 0x0b, 0xf1, 0x35, 0x09, 0x16, 0x76, 0x7b, 0x56, 0x63, 0xf7, 0x50, 0x77,
+		0x0c, 0xee, 0x02, 0xe5, 0xcb, 0x70, 0x01, 0x26, 0x5d, 0xbb, 0xeb, 0x34, 0xb0, 0xc6, 0x0b, 0x1f,
+		0xf8, 0x5a, 0x4c, 0xb0, 0xd6, 0x75, 0xd6, 0x88, 0x4e, 0xc1, 0x18, 0xf1, 0x0e, 0x19, 0xe2, 0x02,
+		0x1b, 0x56, 0x47, 0xe9, 0xef, 0xba, 0x81, 0xd6, 0x60, 0x88, 0x3e, 0x16, 0xcb, 0x7d, 0x5f, 0x70,
+		0x14, 0x4e, 0x39, 0x05, 0x8b, 0x09, 0x5e, 0x38, 0x9f, 0x3f, 0x1a, 0x86, 0x59, 0xd2, 0x27, 0x2e,
+		0xc2, 0x4f, 0x72, 0xaf, 0xd4, 0x60, 0x54, 0xb8, 0x9c, 0xd8, 0x51, 0x15, 0x3f, 0xc9, 0x49, 0x0e,
+		0x1e, 0xb3, 0xbe, 0xa3, 0xc0, 0x77, 0x2c, 0x10, 0x99, 0x24, 0x1d, 0x4d, 0xc3, 0x83, 0x3a, 0x9a,
+		0xce, 0x00, 0x88, 0x47, 0x95, 0x69, 0xf0, 0x8c, 0x9a, 0x0a, 0x6f, 0xa9, 0x1b, 0x89, 0xa7, 0xfa,
+		0xe8, 0x60, 0x4f, 0xf5, 0x77, 0x79, 0x78, 0x27, 0x78, 0x35, 0x53, 0x2a, 0x63, 0x7d, 0xa9, 0xcc,
+		0x10, 0x34, 0xdf, 0xfe, 0xa5, 0xb4, 0x6e, 0xc0, 0xa8, 0x78, 0x72, 0x57, 0x0a, 0x3c, 0xb9, 0x05,
+		0x70, 0xd8, 0x5d, 0x00, 0x51, 0x77, 0xc1, 0xdb, 0x50, 0x65, 0xc1, 0x27, 0x5e, 0xde, 0x30, 0x5e,
+		0xa0, 0xbc, 0x61, 0x9c, 0xc6, 0xa4, 0xd8, 0x0f, 0x12, 0x07, 0xa1, 0x04, 0x58, 0xc1, 0x8f, 0x66,
+		0x1a, 0xd8, 0xf2, 0x4c, 0x8f, 0xa5, 0xd8, 0x57, 0x54, 0x44, 0xfa, 0xde, 0xa7, 0x5d, 0x75, 0xde,
+		0x83, 0x1e, 0xc2, 0x54, 0x4c, 0x35, 0x70, 0xd7, 0xde, 0x85, 0x42, 0x4a, 0x41, 0x9d, 0x8c, 0x2a,
+		0x04, 0x65, 0x01, 0xe6, 0xa2, 0x3b, 0x99, 0x6f, 0xf1, 0x3f, 0x90, 0x60, 0x49, 0xe4, 0x2e, 0xbe,
+		0x20, 0x26, 0x9c, 0xf2, 0xfb, 0x12, 0x9c, 0x4e, 0xe7, 0x89, 0xbf, 0x6e, 0x5e, 0x83, 0x85, 0x36,
+		0x6b, 0x67, 0x81, 0x17, 0xcd, 0xb4, 0xb4, 0x86, 0xde, 0x38, 0xc4, 0x9c, 0xc3, 0xd9, 0x76, 0x08,
+		0xab, 0x6e, 0xad, 0x93, 0x2e, 0x92, 0x21, 0x96, 0x40, 0x32, 0x74, 0x4f, 0xdf, 0xd7, 0x5d, 0x91,
+		0xc2, 0xbc, 0x10, 0xc5, 0xdb, 0xe0, 0xbd, 0xca, 0x69, 0x90, 0x05, 0x3f, 0x5c, 0x9e, 0xef, 0xd8,
+		0x7e, 0xfa, 0x92, 0xf2, 0x4f, 0x65, 0x58, 0x4a, 0xed, 0xe6, 0xdc, 0x5e, 0x84, 0x69, 0xab, 0xdb,
+		0xde, 0xc7, 0x0e, 0x71, 0x32, 0x51, 0x2d, 0xe5, 0x52, 0x3e, 0x87, 0xd5, 0x49, 0xd6, 0xfe, 0xa8,
+		0x49, 0x95, 0x8f, 0x4b, 0x84, 0x2d, 0xb4, 0x9a, 0x4b, 0x7d, 0x07, 0xc3, 0xea, 0x18, 0x57, 0x6b,
+		0x2e, 0xaa, 0x43, 0x95, 0xaf, 0x04, 0x9b, 0x6a, 0x7a, 0x9e, 0xae, 0xd8, 0x0e, 0xcc, 0x99, 0x43,
+		0x67, 0x4e, 0x8d, 0xbb, 0x71, 0x23, 0x68, 0x40, 0x37, 0x60, 0x91, 0x8d, 0xd3, 0xb0, 0x2d, 0xcf,
+		0xb1, 0x5b, 0x2d, 0xec, 0x50, 0x99, 0x74, 0xd9, 0x4d, 0x51, 0x51, 0xe7, 0x69, 0xf7, 0xba, 0xdf,
+		0xcb, 0xf4, 0x22, 0x3d, 0x21, 0x86, 0xe1, 0x60, 0xd7, 0xe5, 0x1e, 0x47, 0xf1, 0x13, 0x7d, 0x81,
+		0x94, 0x2c, 0xf9, 0xc9, 0xad, 0x94, 0xbf, 0x91, 0x34, 0x4f, 0x6d, 0xc8, 0xf7, 0x11, 0xf0, 0x36,
+		0xe9, 0xa3, 0x32, 0xf6, 0x36, 0xa1, 0xca, 0x2e, 0x1e, 0x4e, 0x69, 0xb4, 0x30, 0xa5, 0x71, 0x86,
+		0x27, 0x16, 0x7c, 0x88, 0x1c, 0xf0, 0xda, 0x58, 0xda, 0xb9, 0x09, 0xa1, 0x93, 0xc5, 0xda, 0xb2,
+		0x75, 0x83, 0x52, 0xa0, 0x28, 0xca, 0x3f, 0x48, 0x50, 0xf1, 0xa9, 0xa2, 0x15, 0xa8, 0x5a, 0xdd,
+		0x36, 0x59, 0x3d, 0x76, 0x3b, 0xb3, 0x04, 0x4b, 0xb0, 0xba, 0xed, 0x47, 0x4d, 0x76, 0xd3, 0xbe,
+		0x0a, 0xb3, 0x1c, 0xa2, 0x63, 0x5a, 0x16, 0x51, 0x91, 0x14, 0x90, 0xe5, 0x55, 0x4e, 0x53, 0xc0,
+		0x1d, 0xda, 0xc1, 0xc0, 0x15, 0x98, 0xa0, 0x69, 0x91, 0xa6, 0xa5, 0xed, 0xf7, 0x3c, 0x9a, 0x33,
+		0xcb, 0x54, 0xb6, 0xf9, 0x11, 0xae, 0x5b, 0xf7, 0x48, 0x13, 0x5a, 0x86, 0x71, 0x4e, 0xf2, 0xd0,
+		0xe4, 0x37, 0x78, 0x59, 0xad, 0x50, 0x52, 0xef, 0x98, 0x1e, 0xa5, 0xc1, 0xfb, 0xdb, 0xa6, 0xeb,
+		0x62, 0xd7, 0x0f, 0xdd, 0x10, 0x88, 0x6d, 0xda, 0x44, 0xfc, 0xde, 0xd5, 0xf0, 0xec, 0x88, 0x2d,
+		0xcf, 0xdf, 0x22, 0xae, 0xd6, 0x21, 0x8b, 0x8e, 0x1b, 0xb6, 0xc5, 0xce, 0xb5, 0xa4, 0xce, 0x88,
+		0xae, 0x1d, 0xec, 0xec, 0xd2, 0x0e, 0xe2, 0x34, 0x6c, 0x74, 0xba, 0x5a, 0xd7, 0x33, 0x5b, 0xe6,
+		0x47, 0xba, 0x7f, 0xba, 0x25, 0x75, 0xb2, 0xd1, 0xe9, 0xbe, 0x17, 0xb4, 0xa2, 0x73, 0x30, 0x21,
+		0xd2, 0x6a, 0x89, 0x83, 0x5a, 0xcc, 0xa8, 0xca, 0x1b, 0x89, 0x59, 0x4c, 0xa6, 0x04, 0xf6, 0x11,
+		0x76, 0x88, 0x84, 0xb1, 0xc1, 0x1f, 0x10, 0xa1, 0x16, 0xb4, 0x09, 0x70, 0x68, 0x7b, 0xe2, 0x88,
+		0x0c, 0xaf, 0x94, 0x93, 0xfb, 0x3b, 0x5c, 0xb0, 0x41, 0xc0, 0xfc, 0x75, 0xab, 0x1c, 0xda, 0x1e,
+		0x3f, 0x45, 0x0f, 0x61, 0x82, 0x90, 0x11, 0x7a, 0x85, 0xa4, 0xe1, 0xa6, 0x58, 0x53, 0x29, 0x69,
+		0xb8, 0x3e, 0xb1, 0xea, 0xa1, 0xed, 0xe7, 0xfa, 0xbb, 0xca, 0xaf, 0xc1, 0x44, 0x64, 0xac, 0x88,
+		0xf1, 0x21, 0xc5, 0x8d, 0x8f, 0x54, 0x01, 0x97, 0xb2, 0x04, 0x5c, 0x44, 0x6e, 0xca, 0xb7, 0x25,
+		0x98, 0x8e, 0xf3, 0x98, 0xaf, 0x98, 0xcf, 0xc2, 0xb8, 0xaf, 0x98, 0xfd, 0x27, 0x3e, 0x88, 0xa6,
+		0xba, 0x11, 0x99, 0x42, 0xb9, 0xd0, 0x14, 0x86, 0x32, 0xa6, 0xa0, 0xac, 0xc1, 0x0c, 0x8b, 0x5a,
+		0x13, 0x7c, 0x71, 0x6d, 0x64, 0x8b, 0x48, 0x99, 0x03, 0x14, 0x86, 0xe7, 0xf7, 0xd0, 0x7f, 0x4a,
+		0x30, 0xc3, 0x1e, 0xe6, 0xe1, 0x17, 0x60, 0x8e, 0xa4, 0xef, 0xf0, 0x0c, 0x0f, 0x3f, 0xa1, 0x65,
+		0xf2, 0xda, 0xd9, 0x0c, 0x5d, 0x48, 0x28, 0x52, 0x8f, 0xf8, 0x98, 0xc7, 0xff, 0x0a, 0xc7, 0x55,
+		0xca, 0x91, 0xb8, 0xca, 0x3a, 0x4c, 0x1d, 0x99, 0xae, 0xb9, 0x6f, 0xb6, 0x4c, 0xaf, 0xc7, 0x8c,
+		0x90, 0xfe, 0xa1, 0x80, 0xc9, 0x00, 0x85, 0x34, 0x12, 0x8b, 0x8c, 0x5b, 0xaf, 0x9a, 0xa5, 0x73,
+		0x63, 0xab, 0xa2, 0x8e, 0xf3, 0xb6, 0x87, 0x7a, 0x1b, 0x13, 0x29, 0x84, 0xa7, 0xcb, 0xa5, 0xf0,
+		0x75, 0x2a, 0x05, 0x17, 0x7b, 0x8f, 0xbb, 0xb8, 0x8b, 0x0b, 0x48, 0x21, 0x3e, 0x52, 0x29, 0x31,
+		0x52, 0x54, 0x50, 0xe5, 0x01, 0x05, 0xc5, 0xf8, 0x0c, 0x18, 0xe2, 0x7c, 0x7e, 0x53, 0x82, 0x39,
+		0x71, 0xe5, 0xbd, 0x30, 0xac, 0x3e, 0x82, 0xf9, 0x18, 0x4f, 0xfc, 0x02, 0xbe, 0x01, 0x8b, 0x1d,
+		0xc7, 0x6e, 0x60, 0xd7, 0x25, 0xe7, 0x8c, 0x96, 0x41, 0x33, 0x13, 0x80, 0xa8, 0xf2, 0x32, 0xb9,
+		0xee, 0x82, 0x6e, 0x8a, 0x49, 0xef, 0x7f, 0x57, 0xf9, 0xbb, 0x32, 0xcc, 0x93, 0xd0, 0x2f, 0x6d,
+		0xa3, 0x47, 0xf1, 0x05, 0x98, 0x26, 0x7a, 0x13, 0xc6, 0xdb, 0x26, 0x0f, 0x6c, 0x17, 0xcb, 0xa9,
+		0xaa, 0xb4, 0x4d, 0xea, 0x98, 0xa8, 0x1b, 0x14, 0x59, 0x7f, 0xee, 0x23, 0x0f, 0x17, 0x41, 0xd6,
+		0x9f, 0x73, 0xe4, 0x77, 0x61, 0x96, 0x8c, 0x1c, 0x3f, 0x1f, 0x23, 0xfd, 0x8d, 0xf4, 0xb6, 0x69,
+		0x3d, 0x89, 0x1e, 0x11, 0x42, 0x4b, 0x7f, 0x9e, 0xa0, 0x35, 0x5a, 0x80, 0x96, 0xfe, 0x3c, 0x46,
+		0x6b, 0x09, 0x2a, 0x1d, 0xfd, 0x00, 0xb3, 0xf0, 0xf2, 0x18, 0x5d, 0x8e, 0x31, 0xd2, 0x40, 0xf3,
+		0x0c, 0x7f, 0x54, 0x82, 0x09, 0x7f, 0x01, 0x3f, 0x06, 0xcd, 0x19, 0x54, 0x7a, 0x95, 0x43, 0x95,
+		0x5e, 0xd9, 0x81, 0xda, 0xa5, 0xf0, 0x62, 0x0f, 0x33, 0xe6, 0xfc, 0xb5, 0x4c, 0xd1, 0x36, 0x23,
+		0x03, 0x6b, 0x9b, 0xd0, 0xcb, 0x70, 0x34, 0xfa, 0x32, 0x9c, 0x83, 0x61, 0x96, 0x98, 0xc4, 0xf2,
+		0xcd, 0xd8, 0x8f, 0xf0, 0x3b, 0xa7, 0x12, 0x7d, 0xe7, 0x9c, 0x01, 0xa0, 0xcf, 0x14, 0x56, 0x35,
+		0xc1, 0x72, 0xc8, 0x2a, 0xa4, 0x85, 0x15, 0x4a, 0x3c, 0x81, 0x85, 0xf8, 0x71, 0xe0, 0x27, 0xec,
+		0x0e, 0x0c, 0xb3, 0xeb, 0x4b, 0xea, 0x73, 0x69, 0x47, 0x56, 0x42, 0x65, 0x48, 0xca, 0x9f, 0x4b,
+		0xb0, 0xc8, 0xac, 0x7f, 0xec, 0xf7, 0xbf, 0x08, 0x27, 0x2d, 0x6b, 0x4d, 0x15, 0x19, 0x6a, 0x49,
+		0x7e, 0xb9, 0x6a, 0xfc, 0xaa, 0x04, 0x67, 0x1e, 0x60, 0x4f, 0x0d, 0xbe, 0xa4, 0xb0, 0x8d, 0x5d,
+		0x57, 0x3f, 0xc0, 0xbe, 0xf2, 0x78, 0x1b, 0x46, 0x68, 0x46, 0x88, 0x90, 0xd6, 0xcb, 0x19, 0x1c,
+		0x85, 0x48, 0xd0, 0x74, 0x11, 0x95, 0xa3, 0x15, 0x98, 0xb8, 0xf2, 0xeb, 0x25, 0x58, 0xce, 0xe2,
+		0x82, 0xaf, 0xd9, 0x87, 0x30, 0xc9, 0x24, 0xdb, 0xe6, 0x3d, 0x9c, 0x9d, 0x77, 0x33, 0x17, 0x2f,
+		0x9f, 0x20, 0x33, 0xc8, 0x44, 0x2b, 0x8b, 0x4c, 0x4e, 0xb8, 0xe1, 0x36, 0xb9, 0x05, 0x28, 0x09,
+		0x14, 0x8e, 0x3e, 0x0e, 0xb3, 0xe8, 0xe3, 0xe7, 0xa3, 0xd1, 0xc7, 0x4b, 0xfd, 0x05, 0xe4, 0x33,
+		0x13, 0x8a, 0x3c, 0xb6, 0x61, 0xe5, 0x01, 0xf6, 0x36, 0xb6, 0x1e, 0xe7, 0xac, 0x45, 0x1d, 0x80,
+		0x2d, 0xb1, 0xd5, 0xb4, 0x85, 0x00, 0x0a, 0x0c, 0xe7, 0xef, 0xe0, 0x8a, 0xc7, 0xff, 0x72, 0x95,
+		0xe7, 0xb0, 0x9a, 0x33, 0x1c, 0x17, 0xfa, 0x2e, 0xcc, 0x84, 0xbe, 0xb1, 0xa1, 0xe5, 0x1c, 0x9a,
+		0xac, 0x61, 0xd5, 0x69, 0x27, 0xda, 0xe0, 0x2a, 0xff, 0x2c, 0xc1, 0x9c, 0x8a, 0xf5, 0x4e, 0xa7,
+		0xc5, 0x5c, 0xa4, 0xfe, 0xec, 0x16, 0x60, 0x84, 0x87, 0xfa, 0x99, 0x9a, 0xe3, 0xbf, 0xf2, 0xcb,
+		0x43, 0xd3, 0x1f, 0xf5, 0xe5, 0x93, 0xfa, 0xaf, 0x8e, 0xe7, 0x8c, 0x54, 0x16, 0x61, 0x3e, 0x36,
+		0x35, 0x7e, 0xce, 0xbe, 0x2f, 0x91, 0x7a, 0xa0, 0xa6, 0x83, 0xdd, 0x43, 0x3f, 0xeb, 0x21, 0x7c,
+		0x45, 0xbf, 0x40, 0x73, 0x27, 0x81, 0x82, 0x74, 0x56, 0xf9, 0x5c, 0xde, 0x80, 0x45, 0x5a, 0x94,
+		0xb6, 0xb1, 0xf5, 0x38, 0xbe, 0x41, 0x97, 0x01, 0x9a, 0xb6, 0xd3, 0xc0, 0xf7, 0xb1, 0xd7, 0x38,
+		0xe4, 0x21, 0xdc, 0x50, 0x8b, 0xa2, 0x43, 0x2d, 0x89, 0xca, 0x37, 0xdb, 0x26, 0x8c, 0x62, 0xcb,
+		0xa3, 0xc9, 0x5d, 0x6c, 0x8b, 0xbd, 0x92, 0xb1, 0xc5, 0xb8, 0xd7, 0x62, 0x63, 0xeb, 0x31, 0xa5,
+		0xc5, 0x13, 0xb8, 0x38, 0xae, 0xf2, 0xfd, 0x12, 0x2c, 0xa8, 0x58, 0x37, 0x52, 0xb8, 0xbb, 0x06,
+		0x43, 0x7e, 0xba, 0xe4, 0xe4, 0xb5, 0xe5, 0x2c, 0x5f, 0xc4, 0xd6, 0x63, 0xaa, 0x59, 0x29, 0x6c,
+		0x9e, 0xeb, 0x36, 0xe9, 0xfc, 0x2d, 0xa7, 0x39, 0x7f, 0xf7, 0xa0, 0x66, 0x5a, 0x04, 0xc2, 0x3c,
+		0xc2, 0x1a, 0xb6, 0x7c, 0x0d, 0x56, 0xd0, 0x1c, 0x9a, 0xf7, 0x91, 0x37, 0x2d, 0xa1, 0x8a, 0xd8,
+		0x45, 0x1d, 0x58, 0x11, 0xc3, 0x51, 0x2b, 0x02, 0xbd, 0x04, 0x53, 0x34, 0x51, 0x92, 0x42, 0xb0,
+		0x7c, 0xbe, 0x11, 0x9a, 0xcf, 0x47, 0xf3, 0x27, 0x77, 0xf4, 0x03, 0xcc, 0xd2, 0xfb, 0x7f, 0x58,
+		0x82, 0xc5, 0x84, 0xac, 0xf8, 0x72, 0x1c, 0x47, 0x58, 0xa9, 0xfa, 0xa2, 0x74, 0x32, 0x7d, 0x81,
+		0xbe, 0x0c, 0x0b, 0x09, 0xa2, 0x22, 0x68, 0x38, 0xa8, 0x02, 0x9c, 0x8b, 0x53, 0x27, 0xad, 0x69,
+		0xe2, 0x1a, 0x4a, 0x13, 0xd7, 0xbf, 0x91, 0x22, 0x90, 0xae, 0x73, 0x80, 0x7f, 0xbe, 0xf7, 0x16,
+		0x31, 0x18, 0x92, 0xd3, 0xe4, 0x87, 0xff, 0x07, 0x25, 0x58, 0xdc, 0xc6, 0x3f, 0xf7, 0x32, 0xf8,
+		0x78, 0xce, 0xd7, 0x3d, 0xa8, 0x6d, 0xe3, 0x74, 0x41, 0xa6, 0xd1, 0x90, 0xd2, 0x68, 0x7c, 0x45,
+		0x82, 0xd3, 0x0f, 0x6d, 0xcf, 0x6c, 0xf6, 0x88, 0x7b, 0xde, 0x3e, 0xc2, 0xce, 0xb6, 0x4e, 0x7c,
+		0xef, 0xbe, 0xd4, 0xbf, 0x0c, 0x0b, 0x4d, 0xde, 0xa3, 0xb5, 0x69, 0x97, 0x16, 0x31, 0xd8, 0xb2,
+		0xce, 0x47, 0x94, 0x1c, 0x1d, 0x4c, 0x9d, 0x6b, 0x26, 0x1b, 0x5d, 0xe5, 0x2c, 0x9c, 0xc9, 0xe0,
+		0x80, 0x6f, 0x0a, 0x1d, 0x96, 0x1e, 0x60, 0x6f, 0xdd, 0xb1, 0x5d, 0x97, 0xaf, 0x4a, 0xe4, 0x72,
+		0x8b, 0x38, 0x8a, 0xa5, 0x98, 0xa3, 0xf8, 0x02, 0x4c, 0x7a, 0xba, 0x73, 0x80, 0x3d, 0x7f, 0x95,
+		0xd9, 0x35, 0x37, 0xc1, 0x5a, 0x39, 0x3d, 0xe5, 0x27, 0x65, 0x38, 0x9d, 0x3e, 0x06, 0x97, 0x67,
+		0x1b, 0x26, 0x99, 0x6a, 0xd8, 0xef, 0x31, 0x9f, 0x5c, 0x4d, 0xea, 0x93, 0x22, 0x9c, 0x47, 0x8e,
+		0x1a, 0xd8, 0xee, 0xbd, 0x1e, 0x35, 0x00, 0xd9, 0x0d, 0x53, 0xf5, 0x42, 0x4d, 0xe4, 0xab, 0x2d,
+		0xf3, 0x4d, 0x9a, 0x21, 0xa3, 0x35, 0xf4, 0xae, 0x8b, 0x83, 0x61, 0x99, 0xbe, 0xdb, 0x3e, 0xde,
+		0xb0, 0x2c, 0xe9, 0x66, 0x9d, 0x50, 0x8c, 0x0c, 0x8e, 0x9a, 0x89, 0x0e, 0xb9, 0x03, 0x33, 0x09,
+		0x2e, 0x53, 0xcc, 0xd3, 0xcd, 0xa8, 0x79, 0x7a, 0x39, 0x63, 0x3b, 0xc4, 0x79, 0xe2, 0x8b, 0x17,
+		0xb6, 0x51, 0xe5, 0x0e, 0x2c, 0x66, 0x30, 0x98, 0x32, 0xee, 0xdb, 0xe1, 0x71, 0x27, 0x33, 0xc3,
+		0xc3, 0x0f, 0xb0, 0x17, 0x64, 0x1b, 0x51, 0xba, 0x61, 0xab, 0xf8, 0x3f, 0x24, 0xb8, 0xc8, 0x84,
+		0x63, 0x24, 0x84, 0x96, 0x48, 0x4c, 0xc8, 0x79, 0x7d, 0x15, 0xdb, 0x65, 0xe8, 0x09, 0xdb, 0x44,
+		0x7e, 0x22, 0xa6, 0x88, 0x6d, 0x17, 0x17, 0x1a, 0xc3, 0x23, 0x74, 0x83, 0x5f, 0x2e, 0x3a, 0x0f,
+		0x13, 0x4d, 0x62, 0x00, 0x3d, 0xc4, 0xcc, 0x96, 0xe2, 0xee, 0xe4, 0x68, 0xa3, 0xe2, 0xc0, 0x67,
+		0x0a, 0xcc, 0xd5, 0x37, 0x97, 0xfc, 0x47, 0xec, 0xf1, 0x96, 0x95, 0xbd, 0x66, 0xaf, 0xd3, 0x3a,
+		0x74, 0x71, 0xb0, 0xe9, 0x25, 0x59, 0x20, 0x96, 0xa6, 0x78, 0xb0, 0x98, 0x40, 0xf3, 0x0d, 0x87,
+		0xf9, 0x20, 0x0f, 0x43, 0x04, 0x6e, 0xba, 0x3c, 0xb1, 0x7a, 0x58, 0x0d, 0x92, 0x34, 0x76, 0x59,
+		0xd4, 0xa6, 0x6b, 0xd1, 0x38, 0xba, 0x70, 0x2c, 0x73, 0x7f, 0x3a, 0x8b, 0x27, 0x09, 0x77, 0x33,
+		0x05, 0x75, 0x95, 0x1f, 0x0e, 0xc3, 0xe9, 0xf7, 0x3a, 0x86, 0xee, 0x61, 0x11, 0x19, 0x7c, 0xd4,
+		0x21, 0x57, 0xb9, 0x5b, 0x28, 0xfe, 0x17, 0xd8, 0xd8, 0xa5, 0x88, 0x8d, 0xfd, 0x53, 0x7a, 0x42,
+		0x9c, 0x85, 0x71, 0x9e, 0xe9, 0xda, 0x13, 0x57, 0x51, 0x45, 0x05, 0xd1, 0x54, 0x37, 0xd0, 0x1e,
+		0x9c, 0x0a, 0x97, 0xb4, 0xb1, 0xd2, 0x2c, 0x51, 0xd2, 0x36, 0xdc, 0xaf, 0xa4, 0x6d, 0xc1, 0xf5,
+		0x8b, 0xd8, 0xa8, 0x83, 0x5a, 0x14, 0xb1, 0xc5, 0xa8, 0x46, 0x0b, 0xe5, 0x46, 0x06, 0xa0, 0x1a,
+		0x29, 0x8d, 0x7b, 0x08, 0x0b, 0x9c, 0x52, 0x9c, 0xd1, 0xd1, 0x7e, 0x24, 0x67, 0x29, 0x62, 0x8c,
+		0xcb, 0xfb, 0xe1, 0x94, 0x73, 0x41, 0x6a, 0xac, 0x1f, 0xa9, 0x20, 0xdf, 0x5c, 0xd0, 0x59, 0x87,
+		0xaa, 0x83, 0x3d, 0xa7, 0x27, 0x3e, 0x30, 0xc5, 0x42, 0xe8, 0x2b, 0x19, 0x39, 0x6b, 0x9e, 0xd3,
+		0xe3, 0x1f, 0x94, 0x1a, 0x77, 0x82, 0x1f, 0xd1, 0xf2, 0x4b, 0x18, 0xac, 0xfc, 0x52, 0x86, 0x31,
+		0x3f, 0x3e, 0x3e, 0xce, 0x36, 0x9c, 0xf8, 0x4d, 0xee, 0xcd, 0x8c, 0xdd, 0xca, 0xef, 0xcd, 0x7f,
+		0x2c, 0xc1, 0xf2, 0x06, 0x26, 0x87, 0xe1, 0x78, 0x39, 0x7b, 0xff, 0xc7, 0x3b, 0xfa, 0x0a, 0xcc,
+		0x79, 0xe2, 0x93, 0x47, 0x9a, 0xd9, 0x24, 0x39, 0x37, 0x96, 0x69, 0x1d, 0x70, 0x65, 0x86, 0xfc,
+		0xbe, 0x7a, 0x53, 0x65, 0x3d, 0xc4, 0x97, 0x4d, 0xcd, 0x2b, 0x03, 0x6b, 0xd1, 0xac, 0x3c, 0x16,
+		0x00, 0x1c, 0xe3, 0xd6, 0x97, 0x81, 0xd7, 0xc3, 0x89, 0x79, 0x2e, 0x99, 0x98, 0x83, 0x75, 0xd7,
+		0x66, 0x76, 0x55, 0x45, 0xe5, 0xbf, 0x22, 0xd2, 0x1e, 0x8d, 0x49, 0xfb, 0x9b, 0x12, 0x9c, 0xcd,
+		0x14, 0x26, 0xd7, 0x4d, 0x36, 0x9c, 0x8a, 0xf2, 0x11, 0x88, 0x47, 0x98, 0x4b, 0xaf, 0x65, 0x07,
+		0x6e, 0xc3, 0x3c, 0x46, 0x93, 0x11, 0x17, 0x1b, 0xa9, 0x7d, 0xae, 0xf2, 0x3b, 0x12, 0xc8, 0xd9,
+		0x78, 0x99, 0xcf, 0xfe, 0x9f, 0x52, 0xaa, 0xc2, 0xff, 0x48, 0x70, 0x66, 0xdb, 0x3c, 0x70, 0x74,
+		0x8f, 0xc5, 0xb3, 0x02, 0x46, 0x0b, 0x5c, 0xa1, 0x2f, 0xc1, 0x14, 0x37, 0xc7, 0x63, 0x06, 0x3b,
+		0xb7, 0xc7, 0x77, 0x39, 0x1c, 0xad, 0xed, 0xb5, 0x5d, 0x6c, 0xb0, 0xcc, 0x4b, 0x96, 0x8d, 0x08,
+		0xac, 0x89, 0xa4, 0x5c, 0x92, 0xf2, 0x40, 0x83, 0xae, 0x93, 0xd6, 0x74, 0xec, 0xb6, 0xc6, 0xb0,
+		0xf9, 0x26, 0x9a, 0x66, 0x3d, 0xf7, 0x1d, 0xbb, 0xbd, 0x4b, 0xdb, 0x3f, 0x1e, 0x43, 0xfc, 0x1b,
+		0x12, 0x2c, 0x67, 0x4d, 0x7c, 0x30, 0x7b, 0x9c, 0xba, 0x33, 0xed, 0x8e, 0x89, 0xc5, 0xad, 0xc6,
+		0x64, 0x30, 0xce, 0xda, 0xd8, 0x6d, 0x76, 0x0e, 0x26, 0xd8, 0x34, 0x04, 0x0c, 0x8b, 0x59, 0x56,
+		0x79, 0x23, 0x05, 0x52, 0x7e, 0x28, 0xc1, 0xf2, 0x13, 0xec, 0x98, 0xcd, 0xde, 0xf1, 0xce, 0xfe,
+		0x4f, 0x29, 0x71, 0x8b, 0x9e, 0xbc, 0x8e, 0x6e, 0x3a, 0x7c, 0xe1, 0xf8, 0x2f, 0xe5, 0x5b, 0x12,
+		0x9c, 0xcd, 0x64, 0x97, 0x8b, 0xf0, 0x09, 0x4c, 0x18, 0xa6, 0xdb, 0x20, 0x18, 0x56, 0x23, 0xf0,
+		0xe3, 0x64, 0x7f, 0xee, 0x2f, 0x9c, 0x2e, 0xb3, 0xe1, 0x63, 0xf6, 0xd4, 0x28, 0x19, 0x72, 0xea,
+		0x19, 0x17, 0xfc, 0xbb, 0x40, 0x63, 0xaa, 0xff, 0x5b, 0xe9, 0xc2, 0x62, 0x06, 0x15, 0x84, 0x42,
+		0xcf, 0xd1, 0x0a, 0x7f, 0x6e, 0x4e, 0x42, 0xc9, 0x77, 0xa4, 0x95, 0x4c, 0x03, 0x9d, 0x86, 0x4a,
+		0x07, 0x3b, 0xae, 0x49, 0x4b, 0x0e, 0x78, 0xfa, 0xb5, 0xdf, 0x40, 0x62, 0x0f, 0x0e, 0xde, 0xef,
+		0x9a, 0x2d, 0x8f, 0x5f, 0xdf, 0xe2, 0xa7, 0xb2, 0x1f, 0x7c, 0xd6, 0x2d, 0xe4, 0x67, 0x88, 0x26,
+		0x07, 0xe6, 0x2e, 0x5f, 0x01, 0xaf, 0xb8, 0x03, 0xab, 0x39, 0x63, 0x70, 0x99, 0x6f, 0xc3, 0x18,
+		0xc7, 0x11, 0xe2, 0xbe, 0x9a, 0xad, 0xc0, 0x18, 0x60, 0x92, 0x98, 0x4f, 0x82, 0xf8, 0x29, 0x6b,
+		0x59, 0x60, 0x09, 0x9e, 0xa5, 0x64, 0x08, 0xe3, 0x5d, 0x18, 0x65, 0x53, 0x14, 0x7e, 0x9f, 0xec,
+		0xc5, 0x67, 0x39, 0x3f, 0x49, 0x66, 0x04, 0x01, 0xe2, 0x4c, 0x34, 0x2d, 0x61, 0x2d, 0x0a, 0x3d,
+		0x12, 0xb4, 0x28, 0x7f, 0x25, 0xc1, 0x62, 0x06, 0x91, 0xbe, 0x51, 0x33, 0xde, 0x19, 0x12, 0x3d,
+		0xb0, 0x26, 0x3a, 0x8b, 0xcf, 0x06, 0x9f, 0x8a, 0xa3, 0x86, 0x41, 0x70, 0x8a, 0xcb, 0xea, 0x74,
+		0x28, 0xd9, 0x81, 0x1d, 0xf7, 0x35, 0x92, 0x0d, 0x78, 0x40, 0xd2, 0x63, 0xda, 0x66, 0xab, 0x65,
+		0xb2, 0x0c, 0x04, 0x91, 0x03, 0x33, 0xd3, 0xd2, 0x0f, 0xea, 0xd6, 0x76, 0xa8, 0x43, 0xa9, 0xc3,
+		0x82, 0xaa, 0x7b, 0x98, 0x7e, 0xf2, 0x8b, 0xd9, 0x07, 0x62, 0xc7, 0x5c, 0x86, 0x21, 0x43, 0xf7,
+		0x74, 0x6e, 0xd2, 0x2f, 0x65, 0xd5, 0x17, 0xdf, 0xb5, 0x7a, 0x2a, 0x05, 0x54, 0xde, 0x85, 0xc5,
+		0x04, 0x29, 0xbe, 0x31, 0x06, 0xa5, 0x75, 0xe9, 0xf7, 0x24, 0x58, 0xcc, 0xf8, 0xfe, 0x26, 0x7a,
+		0x09, 0x94, 0x87, 0x8f, 0xb4, 0x1d, 0xf5, 0xd1, 0x03, 0x75, 0x73, 0x77, 0x57, 0xdb, 0xab, 0x6f,
+		0x6f, 0x3e, 0x7a, 0x6f, 0x4f, 0xdb, 0x79, 0xb4, 0x55, 0x5f, 0xff, 0xa2, 0x56, 0x7f, 0xf8, 0xe4,
+		0xee, 0x56, 0x7d, 0x63, 0xfa, 0x17, 0xd0, 0x05, 0x58, 0xcd, 0x81, 0x7b, 0xf8, 0x68, 0xaf, 0x7e,
+		0xff, 0x8b, 0xd3, 0x12, 0x3a, 0x07, 0x67, 0x73, 0xc0, 0xee, 0xdf, 0xad, 0x6f, 0x4d, 0x97, 0xae,
+		0xfd, 0xd7, 0x75, 0x00, 0xee, 0xea, 0xbd, 0xbb, 0x53, 0x47, 0xbf, 0x4d, 0xb2, 0x70, 0x53, 0x3f,
+		0xce, 0x89, 0x6e, 0x1c, 0xef, 0x0b, 0xc6, 0xf2, 0xcd, 0x81, 0xf1, 0xb8, 0x6c, 0x7f, 0x57, 0x82,
+		0xc5, 0x8c, 0xef, 0xce, 0xa2, 0x9b, 0xfd, 0xbe, 0xd9, 0x9a, 0xc5, 0xcd, 0xad, 0xc1, 0x11, 0x39,
+		0x3b, 0xdf, 0x93, 0x60, 0xa5, 0xdf, 0x17, 0x4c, 0xd1, 0xe7, 0x4f, 0xfa, 0x2d, 0x59, 0xf9, 0xee,
+		0x09, 0x28, 0x70, 0x4e, 0xc9, 0x22, 0xa6, 0x7f, 0x9b, 0x34, 0x67, 0x11, 0x73, 0xbf, 0x89, 0x2a,
+		0xdf, 0x1c, 0x18, 0x8f, 0xf3, 0xf2, 0x2d, 0x09, 0xe4, 0xec, 0x2f, 0x78, 0xa2, 0xec, 0xe2, 0xcb,
+		0xbe, 0x5f, 0x36, 0x95, 0xdf, 0x3c, 0x16, 0x2e, 0xe7, 0xeb, 0x9b, 0x12, 0x9c, 0xca, 0xfc, 0x3e,
+		0x27, 0x7a, 0x23, 0x5b, 0x9f, 0xf6, 0xf9, 0x3c, 0xa8, 0x7c, 0xfb, 0x38, 0xa8, 0x9c, 0x29, 0x0b,
+		0x26, 0x22, 0x9f, 0xfe, 0x43, 0xaf, 0xe6, 0x05, 0xcd, 0x13, 0x5f, 0x18, 0x94, 0xd7, 0x8a, 0x82,
+		0xf3, 0xf1, 0xbe, 0x22, 0xc1, 0x6c, 0xca, 0xf7, 0xf3, 0xd0, 0x6b, 0xf9, 0xab, 0x9d, 0xfa, 0xc5,
+		0x3e, 0xf9, 0xf5, 0xc1, 0x90, 0x38, 0x0b, 0x1e, 0x4c, 0xc5, 0xbe, 0x55, 0x87, 0x2e, 0xe7, 0x39,
+		0xf5, 0x52, 0xf2, 0x91, 0xe5, 0x2b, 0xc5, 0x11, 0xf8, 0xa8, 0xcf, 0x60, 0x3a, 0xfe, 0xc1, 0x25,
+		0x94, 0x4d, 0x25, 0xe3, 0x93, 0x54, 0xf2, 0xd5, 0x01, 0x30, 0x42, 0xdb, 0x2e, 0xb3, 0xac, 0x38,
+		0x67, 0xdb, 0xf5, 0xfb, 0xe8, 0x8b, 0x7c, 0x82, 0x2a, 0x66, 0xf4, 0x6d, 0x09, 0x4e, 0xb3, 0x1f,
+		0xe9, 0x55, 0xc7, 0xe8, 0xce, 0x31, 0x8b, 0x95, 0x19, 0x6b, 0x6f, 0x9d, 0xa8, 0xd4, 0x99, 0x8b,
+		0x2c, 0xa3, 0x34, 0x37, 0x57, 0x64, 0xf9, 0x85, 0xc1, 0xf2, 0xed, 0xe3, 0xa0, 0x26, 0xd6, 0x31,
+		0xe5, 0xbb, 0x07, 0x7d, 0xd7, 0x31, 0xfb, 0x8b, 0x13, 0xf2, 0xed, 0xe3, 0xa0, 0x26, 0xd7, 0x31,
+		0xb5, 0x3a, 0xb6, 0xff, 0x3a, 0xe6, 0x55, 0xe8, 0xca, 0x6f, 0x1d, 0x13, 0x3b, 0xb9, 0x8e, 0xc9,
+		0x02, 0xd8, 0xfe, 0xeb, 0x98, 0x59, 0x7e, 0x2b, 0xdf, 0x3e, 0x0e, 0x2a, 0x67, 0xea, 0x8f, 0x69,
+		0xc6, 0x40, 0x66, 0x65, 0x2b, 0x7a, 0x73, 0xa0, 0x39, 0x47, 0x6b, 0x6b, 0xe5, 0x3b, 0xc7, 0x43,
+		0x8e, 0xb0, 0x96, 0x59, 0xd6, 0x9d, 0xcb, 0x5a, 0xbf, 0xc2, 0x72, 0xf9, 0xce, 0xf1, 0x90, 0x39,
+		0x6b, 0x7f, 0x2a, 0xc1, 0x32, 0xa7, 0x94, 0x51, 0xcf, 0x89, 0x3e, 0x97, 0x33, 0x40, 0x81, 0xa2,
+		0x56, 0xf9, 0xed, 0x63, 0xe3, 0x73, 0x1e, 0xbf, 0x2e, 0x41, 0x8d, 0x65, 0xd3, 0x26, 0xab, 0x7a,
+		0xd1, 0xad, 0x1c, 0xea, 0xb9, 0xe5, 0xcb, 0xf2, 0x1b, 0xc7, 0xc0, 0xe4, 0x1c, 0x7d, 0x55, 0x82,
+		0xb9, 0xb4, 0xda, 0x50, 0x94, 0x7d, 0x73, 0xe6, 0x54, 0xc2, 0xca, 0xd7, 0x07, 0xc4, 0xe2, 0x5c,
+		0x7c, 0x87, 0x7e, 0x44, 0x3f, 0xa7, 0x34, 0x12, 0xbd, 0xd5, 0x67, 0x6f, 0xe4, 0x17, 0xae, 0xca,
+		0x9f, 0x3b, 0x2e, 0x3a, 0x67, 0xf0, 0x23, 0x98, 0x11, 0x2f, 0x4d, 0xbf, 0x4a, 0x10, 0x5d, 0xcd,
+		0x21, 0x9a, 0x5e, 0xbc, 0x29, 0x5f, 0x1b, 0x04, 0x25, 0xb0, 0x46, 0x62, 0x75, 0x7f, 0x39, 0xd6,
+		0x48, 0x7a, 0xb5, 0xa2, 0x7c, 0xa5, 0x38, 0x02, 0x1f, 0xf5, 0x29, 0x54, 0xc3, 0x75, 0x58, 0xe8,
+		0xb3, 0xb9, 0x14, 0x62, 0x85, 0x87, 0xf2, 0xab, 0x05, 0xa1, 0x43, 0xbb, 0x30, 0xad, 0x90, 0x2a,
+		0x67, 0x17, 0xe6, 0xd4, 0x82, 0xc9, 0xd7, 0x07, 0xc4, 0x0a, 0x59, 0x9e, 0x29, 0xf5, 0x51, 0x39,
+		0x96, 0x67, 0x76, 0xb1, 0x95, 0xfc, 0xfa, 0x60, 0x48, 0xfe, 0x17, 0x61, 0x20, 0xa8, 0x39, 0x40,
+		0x97, 0x72, 0xfc, 0x39, 0xb1, 0x42, 0x06, 0xf9, 0x95, 0x42, 0xb0, 0xc1, 0x30, 0x41, 0x52, 0x7f,
+		0xce, 0x30, 0x89, 0x42, 0x07, 0xf9, 0x95, 0x42, 0xb0, 0xe1, 0x61, 0x44, 0x4e, 0x7e, 0xee, 0x30,
+		0xb1, 0x4a, 0x02, 0xf9, 0x95, 0x42, 0xb0, 0xc1, 0x0b, 0x25, 0x92, 0x4f, 0x9f, 0xf3, 0x42, 0x49,
+		0xab, 0x05, 0x90, 0xd7, 0x8a, 0x82, 0x07, 0x09, 0xa9, 0xd1, 0xf4, 0x62, 0x94, 0x4d, 0x21, 0x35,
+		0x2d, 0x5f, 0xbe, 0x5c, 0x18, 0x3e, 0x78, 0x1b, 0xc4, 0x13, 0x79, 0x73, 0xde, 0x06, 0x19, 0x39,
+		0xca, 0xf2, 0xd5, 0x01, 0x30, 0x42, 0xcf, 0xf6, 0xf4, 0x74, 0xda, 0x9c, 0x67, 0x7b, 0x6e, 0x5a,
+		0xb1, 0x7c, 0x73, 0x60, 0xbc, 0x90, 0xb1, 0x96, 0x99, 0xb9, 0x9a, 0x63, 0xac, 0xf5, 0x4b, 0xae,
+		0x95, 0x6f, 0x1f, 0x07, 0x35, 0xd8, 0x7c, 0x91, 0xbc, 0xcf, 0x9c, 0xcd, 0x97, 0x96, 0xfa, 0x2a,
+		0xaf, 0x15, 0x05, 0x0f, 0xa9, 0xca, 0xb4, 0x1c, 0x4d, 0x94, 0xf7, 0xd4, 0xcd, 0xcc, 0x3e, 0x95,
+		0xaf, 0x0f, 0x88, 0x15, 0xec, 0xc7, 0x78, 0x36, 0x67, 0xce, 0x7e, 0xcc, 0xc8, 0x19, 0x95, 0xaf,
+		0x0e, 0x80, 0x11, 0x5c, 0x86, 0xb1, 0xb4, 0xc5, 0x9c, 0xcb, 0x30, 0x3d, 0x19, 0x54, 0xbe, 0x52,
+		0x1c, 0x21, 0xf4, 0x34, 0x8f, 0xa5, 0xc5, 0xe5, 0x3d, 0xcd, 0xd3, 0x13, 0x05, 0xe5, 0xab, 0x03,
+		0x60, 0x04, 0x03, 0x6f, 0xe3, 0xc2, 0x03, 0x6f, 0xe3, 0x41, 0x07, 0xce, 0xcc, 0x51, 0xfb, 0x2d,
+		0x09, 0xe6, 0x53, 0x33, 0xbf, 0xd0, 0xf5, 0x9c, 0x7f, 0xe1, 0x94, 0x9d, 0xab, 0x26, 0xdf, 0x18,
+		0x14, 0x2d, 0xb4, 0xdf, 0xd3, 0xf2, 0xa6, 0x72, 0xf6, 0x7b, 0x4e, 0x42, 0x9a, 0x7c, 0x7d, 0x40,
+		0x2c, 0xce, 0xc5, 0x0f, 0x24, 0xff, 0x43, 0x49, 0xd9, 0x09, 0x3a, 0xe8, 0x6e, 0xbf, 0xb7, 0x55,
+		0xdf, 0x44, 0x26, 0xf9, 0xde, 0x49, 0x48, 0x44, 0xdc, 0x57, 0xe1, 0x0c, 0x9d, 0x7c, 0xf7, 0x55,
+		0x4a, 0x0a, 0x90, 0x7c, 0xa5, 0x38, 0x42, 0x68, 0xc7, 0xa4, 0xe6, 0x3c, 0xe4, 0xec, 0x98, 0xbc,
+		0x8c, 0x1e, 0xf9, 0xc6, 0xa0, 0x68, 0x21, 0x17, 0x7d, 0x46, 0x36, 0x40, 0x8e, 0x8b, 0x3e, 0x3f,
+		0x19, 0x43, 0xbe, 0x35, 0x38, 0x62, 0xe8, 0x06, 0x4d, 0x0f, 0x40, 0xe7, 0xdc, 0xa0, 0xb9, 0xa1,
+		0x7a, 0xf9, 0xe6, 0xc0, 0x78, 0x21, 0xd1, 0x64, 0x84, 0x72, 0x73, 0x44, 0x93, 0x1f, 0xab, 0x96,
+		0x6f, 0x0d, 0x8e, 0x98, 0xe2, 0xef, 0x4e, 0x06, 0xf2, 0xfa, 0xfb, 0xbb, 0xb3, 0xe2, 0xaf, 0xf2,
+		0xed, 0xe3, 0xa0, 0x86, 0x6e, 0x98, 0x68, 0x60, 0x2d, 0xef, 0x86, 0x49, 0x8d, 0xe6, 0xc9, 0x57,
+		0x8a, 0x23, 0xb0, 0x51, 0xef, 0xbd, 0xf1, 0x4b, 0x37, 0x0f, 0x4c, 0xef, 0xb0, 0xbb, 0xbf, 0xd6,
+		0xb0, 0xdb, 0x97, 0x23, 0xff, 0x83, 0x76, 0xed, 0x00, 0x5b, 0xec, 0x1f, 0x12, 0x87, 0xfe, 0x23,
+		0xf2, 0x9b, 0xfc, 0xcf, 0xa3, 0xab, 0xfb, 0x23, 0xb4, 0xef, 0xb5, 0xff, 0x1d, 0x00, 0x77, 0x89,
+		0xf4, 0xdd, 0x3d, 0x79, 0x00, 0x00,
 	},
 	// google/protobuf/duration.proto
 	[]byte{
@@ -2729,7 +3174,7 @@ var yarpcFileDescriptorClosurefee8ff76963a38ed = [][]byte{
 		0x95, 0x05, 0xa9, 0xc5, 0xfa, 0xd9, 0x79, 0xf9, 0xe5, 0x79, 0x70, 0xc7, 0x16, 0x24, 0xfd, 0x60,
 		0x64, 0x5c, 0xc4, 0xc4, 0xec, 0x1e, 0xe0, 0xb4, 0x8a, 0x49, 0xce, 0x1d, 0xa2, 0x39, 0x00, 0xaa,
 		0x43, 0x2f, 0x3c, 0x35, 0x27, 0xc7, 0x1b, 0xa4, 0x3e, 0x04, 0xa4, 0x35, 0x89, 0x0d, 0x6c, 0x94,
-		0x31, 0x20, 0x00, 0x00, 0xff, 0xff, 0xef, 0x8a, 0xb4, 0xc3, 0xfb, 0x00, 0x00, 0x00,
+		0x31, 0x60, 0x00, 0xef, 0x8a, 0xb4, 0xc3, 0xfb, 0x00, 0x00, 0x00,
 	},
 	// google/protobuf/timestamp.proto
 	[]byte{
@@ -2744,7 +3189,7 @@ var yarpcFileDescriptorClosurefee8ff76963a38ed = [][]byte{
 		0xac, 0x2c, 0x48, 0x2d, 0xd6, 0xcf, 0xce, 0xcb, 0x2f, 0xcf, 0x43, 0xb8, 0xb7, 0x20, 0xe9, 0x07,
 		0x23, 0xe3, 0x22, 0x26, 0x66, 0xf7, 0x00, 0xa7, 0x55, 0x4c, 0x72, 0xee, 0x10, 0xdd, 0x01, 0x50,
 		0x2d, 0x7a, 0xe1, 0xa9, 0x39, 0x39, 0xde, 0x20, 0x0d, 0x21, 0x20, 0xbd, 0x49, 0x6c, 0x60, 0xb3,
-		0x8c, 0x01, 0x01, 0x00, 0x00, 0xff, 0xff, 0xae, 0x65, 0xce, 0x7d, 0xff, 0x00, 0x00, 0x00,
+		0x8c, 0x01, 0x03, 0x00, 0xae, 0x65, 0xce, 0x7d, 0xff, 0x00, 0x00, 0x00,
 	},
 	// google/protobuf/wrappers.proto
 	[]byte{
@@ -2763,313 +3208,312 @@ var yarpcFileDescriptorClosurefee8ff76963a38ed = [][]byte{
 		0x94, 0x8e, 0x88, 0xab, 0x92, 0xca, 0x82, 0xd4, 0x62, 0xfd, 0xec, 0xbc, 0xfc, 0xf2, 0x3c, 0x78,
 		0xbc, 0x15, 0x24, 0xfd, 0x60, 0x64, 0x5c, 0xc4, 0xc4, 0xec, 0x1e, 0xe0, 0xb4, 0x8a, 0x49, 0xce,
 		0x1d, 0xa2, 0x39, 0x00, 0xaa, 0x43, 0x2f, 0x3c, 0x35, 0x27, 0xc7, 0x1b, 0xa4, 0x3e, 0x04, 0xa4,
-		0x35, 0x89, 0x0d, 0x6c, 0x94, 0x31, 0x20, 0x00, 0x00, 0xff, 0xff, 0x3c, 0x92, 0x48, 0x30, 0x06,
-		0x02, 0x00, 0x00,
+		0x35, 0x89, 0x0d, 0x6c, 0x94, 0x31, 0x60, 0x00, 0x3c, 0x92, 0x48, 0x30, 0x06, 0x02, 0x00, 0x00,
 	},
 	// uber/cadence/api/v1/common.proto
 	[]byte{
-		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0x51, 0x73, 0xdb, 0xc4,
-		0x13, 0xff, 0x2b, 0x8e, 0x9d, 0x64, 0xed, 0x26, 0xfe, 0x5f, 0x48, 0xe2, 0xa4, 0x04, 0x52, 0xcd,
-		0x30, 0x0d, 0x1d, 0x90, 0x27, 0xee, 0x4b, 0x87, 0x4e, 0x01, 0x27, 0x76, 0x12, 0xb5, 0xc1, 0x36,
-		0xb2, 0x69, 0xa6, 0x30, 0x83, 0xe6, 0x2c, 0x9d, 0xdc, 0xc3, 0xd2, 0x9d, 0x38, 0x9d, 0x9c, 0xf8,
-		0x85, 0xe1, 0x93, 0xf0, 0xc0, 0xd7, 0xe1, 0x91, 0x2f, 0xc4, 0x48, 0x3a, 0xc5, 0x76, 0x71, 0xa6,
-		0x3c, 0x30, 0xbc, 0xdd, 0xed, 0xef, 0xb7, 0xbb, 0xbf, 0x3b, 0xed, 0xae, 0x0e, 0x8e, 0xe2, 0x21,
-		0x11, 0x75, 0x07, 0xbb, 0x84, 0x39, 0xa4, 0x8e, 0x43, 0x5a, 0x9f, 0x9c, 0xd4, 0x1d, 0x1e, 0x04,
-		0x9c, 0x19, 0xa1, 0xe0, 0x92, 0xa3, 0xed, 0x84, 0x61, 0x28, 0x86, 0x81, 0x43, 0x6a, 0x4c, 0x4e,
-		0x0e, 0x3e, 0x1a, 0x71, 0x3e, 0xf2, 0x49, 0x3d, 0xa5, 0x0c, 0x63, 0xaf, 0xee, 0xc6, 0x02, 0x4b,
-		0x9a, 0x3b, 0xe9, 0xaf, 0xe0, 0xff, 0xd7, 0x5c, 0x8c, 0x3d, 0x9f, 0xdf, 0xb4, 0x6f, 0x89, 0x13,
-		0x27, 0x10, 0xfa, 0x18, 0xca, 0x37, 0xca, 0x68, 0x53, 0xb7, 0xa6, 0x1d, 0x69, 0xc7, 0x1b, 0x16,
-		0xe4, 0x26, 0xd3, 0x45, 0x3b, 0x50, 0x12, 0x31, 0x4b, 0xb0, 0x95, 0x14, 0x2b, 0x8a, 0x98, 0x99,
-		0xae, 0xae, 0x43, 0x25, 0x0f, 0x36, 0x98, 0x86, 0x04, 0x21, 0x58, 0x65, 0x38, 0x20, 0x2a, 0x40,
-		0xba, 0x4e, 0x38, 0x4d, 0x47, 0xd2, 0x09, 0x95, 0xd3, 0x7b, 0x39, 0x87, 0xb0, 0xd6, 0xc3, 0x53,
-		0x9f, 0x63, 0x37, 0x81, 0x5d, 0x2c, 0x71, 0x0a, 0x57, 0xac, 0x74, 0xad, 0x3f, 0x87, 0xb5, 0x73,
-		0x4c, 0xfd, 0x58, 0x10, 0xb4, 0x0b, 0x25, 0x41, 0x70, 0xc4, 0x99, 0xf2, 0x57, 0x3b, 0x54, 0x83,
-		0x35, 0x97, 0x48, 0x4c, 0xfd, 0x28, 0x55, 0x58, 0xb1, 0xf2, 0xad, 0xfe, 0x9b, 0x06, 0xab, 0xdf,
-		0x90, 0x80, 0xa3, 0x17, 0x50, 0xf2, 0x28, 0xf1, 0xdd, 0xa8, 0xa6, 0x1d, 0x15, 0x8e, 0xcb, 0x8d,
-		0x4f, 0x8c, 0x25, 0xf7, 0x67, 0x24, 0x54, 0xe3, 0x3c, 0xe5, 0xb5, 0x99, 0x14, 0x53, 0x4b, 0x39,
-		0x1d, 0x5c, 0x43, 0x79, 0xce, 0x8c, 0xaa, 0x50, 0x18, 0x93, 0xa9, 0x52, 0x91, 0x2c, 0x51, 0x03,
-		0x8a, 0x13, 0xec, 0xc7, 0x24, 0x15, 0x50, 0x6e, 0x7c, 0xb8, 0x34, 0xbc, 0x3a, 0xa6, 0x95, 0x51,
-		0xbf, 0x58, 0x79, 0xa6, 0xe9, 0xbf, 0x6b, 0x50, 0xba, 0x24, 0xd8, 0x25, 0x02, 0x7d, 0xf5, 0x8e,
-		0xc4, 0xc7, 0x4b, 0x63, 0x64, 0xe4, 0xff, 0x56, 0xe4, 0x9f, 0x1a, 0x54, 0xfb, 0x04, 0x0b, 0xe7,
-		0x6d, 0x53, 0x4a, 0x41, 0x87, 0xb1, 0x24, 0x11, 0xb2, 0x61, 0x93, 0x32, 0x97, 0xdc, 0x12, 0xd7,
-		0x5e, 0x90, 0xfd, 0x6c, 0x69, 0xd4, 0x77, 0xdd, 0x0d, 0x33, 0xf3, 0x9d, 0x3f, 0xc7, 0x03, 0x3a,
-		0x6f, 0x3b, 0xf8, 0x11, 0xd0, 0xdf, 0x49, 0xff, 0xe2, 0xa9, 0x3c, 0x58, 0x6f, 0x61, 0x89, 0x4f,
-		0x7d, 0x3e, 0x44, 0xe7, 0xf0, 0x80, 0x30, 0x87, 0xbb, 0x94, 0x8d, 0x6c, 0x39, 0x0d, 0xb3, 0x02,
-		0xdd, 0x6c, 0x3c, 0x5a, 0x1a, 0xab, 0xad, 0x98, 0x49, 0x45, 0x5b, 0x15, 0x32, 0xb7, 0xbb, 0x2b,
-		0xe0, 0x95, 0xb9, 0x02, 0xee, 0x65, 0x4d, 0x47, 0xc4, 0x6b, 0x22, 0x22, 0xca, 0x99, 0xc9, 0x3c,
-		0x9e, 0x10, 0x69, 0x10, 0xfa, 0x79, 0x23, 0x24, 0x6b, 0xf4, 0x18, 0xb6, 0x3c, 0x82, 0x65, 0x2c,
-		0x88, 0x3d, 0xc9, 0xa8, 0xaa, 0xe1, 0x36, 0x95, 0x59, 0x05, 0xd0, 0x5f, 0xc1, 0x5e, 0x3f, 0x0e,
-		0x43, 0x2e, 0x24, 0x71, 0xcf, 0x7c, 0x4a, 0x98, 0x54, 0x48, 0x94, 0xf4, 0xea, 0x88, 0xdb, 0x91,
-		0x3b, 0x56, 0x91, 0x8b, 0x23, 0xde, 0x77, 0xc7, 0x68, 0x1f, 0xd6, 0x7f, 0xc2, 0x13, 0x9c, 0x02,
-		0x59, 0xcc, 0xb5, 0x64, 0xdf, 0x77, 0xc7, 0xfa, 0xaf, 0x05, 0x28, 0x5b, 0x44, 0x8a, 0x69, 0x8f,
-		0xfb, 0xd4, 0x99, 0xa2, 0x16, 0x54, 0x29, 0xa3, 0x92, 0x62, 0xdf, 0xa6, 0x4c, 0x12, 0x31, 0xc1,
-		0x99, 0xca, 0x72, 0x63, 0xdf, 0xc8, 0xc6, 0x8b, 0x91, 0x8f, 0x17, 0xa3, 0xa5, 0xc6, 0x8b, 0xb5,
-		0xa5, 0x5c, 0x4c, 0xe5, 0x81, 0xea, 0xb0, 0x3d, 0xc4, 0xce, 0x98, 0x7b, 0x9e, 0xed, 0x70, 0xe2,
-		0x79, 0xd4, 0x49, 0x64, 0xa6, 0xb9, 0x35, 0x0b, 0x29, 0xe8, 0x6c, 0x86, 0x24, 0x69, 0x03, 0x7c,
-		0x4b, 0x83, 0x38, 0x98, 0xa5, 0x2d, 0xbc, 0x37, 0xad, 0x72, 0xb9, 0x4b, 0xfb, 0xe9, 0x2c, 0x0a,
-		0x96, 0x92, 0x04, 0xa1, 0x8c, 0x6a, 0xab, 0x47, 0xda, 0x71, 0xf1, 0x8e, 0xda, 0x54, 0x66, 0xf4,
-		0x02, 0x1e, 0x32, 0xce, 0x6c, 0x91, 0x1c, 0x1d, 0x0f, 0x7d, 0x62, 0x13, 0x21, 0xb8, 0xb0, 0xb3,
-		0x91, 0x12, 0xd5, 0x8a, 0x47, 0x85, 0xe3, 0x0d, 0xab, 0xc6, 0x38, 0xb3, 0x72, 0x46, 0x3b, 0x21,
-		0x58, 0x19, 0x8e, 0x5e, 0xc2, 0x36, 0xb9, 0x0d, 0x69, 0x26, 0x64, 0x26, 0xb9, 0xf4, 0x3e, 0xc9,
-		0x68, 0xe6, 0x95, 0xab, 0xd6, 0x03, 0xd8, 0x33, 0x23, 0xee, 0xa7, 0xc6, 0x0b, 0xc1, 0xe3, 0xb0,
-		0x87, 0x85, 0xa4, 0xe9, 0x70, 0x5e, 0x32, 0x30, 0xd1, 0x97, 0x50, 0x8c, 0x24, 0x96, 0x59, 0xc1,
-		0x6f, 0x36, 0x8e, 0x97, 0x16, 0xe9, 0x62, 0xc0, 0x7e, 0xc2, 0xb7, 0x32, 0x37, 0x7d, 0x02, 0x0f,
-		0x17, 0xd1, 0x33, 0xce, 0x3c, 0x3a, 0x52, 0x0a, 0xd1, 0x35, 0x54, 0x69, 0x0e, 0xdb, 0xa3, 0x04,
-		0xcf, 0x5b, 0xfb, 0xb3, 0x7f, 0x90, 0xe9, 0x4e, 0xba, 0xb5, 0x45, 0x17, 0x80, 0x48, 0xff, 0x43,
-		0x83, 0x83, 0x66, 0x34, 0x65, 0x4e, 0xfe, 0xdb, 0x58, 0xcc, 0x5b, 0x83, 0x35, 0xc2, 0x92, 0x7b,
-		0xce, 0xfe, 0x41, 0xeb, 0x56, 0xbe, 0x45, 0x0d, 0xd8, 0x09, 0x05, 0x71, 0x89, 0x47, 0x19, 0x71,
-		0xed, 0x9f, 0x63, 0x12, 0x13, 0x3b, 0xbd, 0x95, 0xac, 0x94, 0xb7, 0x67, 0xe0, 0xb7, 0x09, 0xd6,
-		0x49, 0x2e, 0xe9, 0x10, 0x20, 0x23, 0xa6, 0xed, 0x5c, 0x48, 0x89, 0x1b, 0xa9, 0x25, 0x6d, 0xd4,
-		0xaf, 0xa1, 0x92, 0xc1, 0x4e, 0xaa, 0x21, 0x2d, 0x92, 0x72, 0xe3, 0x70, 0xe9, 0x01, 0xf3, 0x29,
-		0x61, 0x95, 0x53, 0x97, 0x4c, 0xf5, 0x93, 0x1b, 0xa8, 0xcc, 0x0f, 0x02, 0xb4, 0x0f, 0x3b, 0xed,
-		0xce, 0x59, 0xb7, 0x65, 0x76, 0x2e, 0xec, 0xc1, 0x9b, 0x5e, 0xdb, 0x36, 0x3b, 0xaf, 0x9b, 0x57,
-		0x66, 0xab, 0xfa, 0x3f, 0x74, 0x00, 0xbb, 0x8b, 0xd0, 0xe0, 0xd2, 0x32, 0xcf, 0x07, 0xd6, 0x75,
-		0x55, 0x43, 0xbb, 0x80, 0x16, 0xb1, 0x97, 0xfd, 0x6e, 0xa7, 0xba, 0x82, 0x6a, 0xf0, 0xc1, 0xa2,
-		0xbd, 0x67, 0x75, 0x07, 0xdd, 0xa7, 0xd5, 0xc2, 0x93, 0x5f, 0x60, 0x7b, 0xc9, 0xc7, 0x45, 0x8f,
-		0xe0, 0xd0, 0xec, 0x77, 0xaf, 0x9a, 0x03, 0xb3, 0xdb, 0xb1, 0x2f, 0xac, 0xee, 0x77, 0x3d, 0xbb,
-		0x3f, 0x68, 0x0e, 0xe6, 0x75, 0xdc, 0x4b, 0xb9, 0x6c, 0x37, 0xaf, 0x06, 0x97, 0x6f, 0xaa, 0xda,
-		0xfd, 0x94, 0x96, 0xd5, 0x34, 0x3b, 0xed, 0x56, 0x75, 0xe5, 0xf4, 0x07, 0xd8, 0x73, 0x78, 0xb0,
-		0xec, 0xa6, 0x4e, 0xcb, 0x67, 0xe9, 0x13, 0xa5, 0x97, 0x54, 0x7d, 0x4f, 0xfb, 0xfe, 0x64, 0x44,
-		0xe5, 0xdb, 0x78, 0x68, 0x38, 0x3c, 0xa8, 0xcf, 0x3f, 0x68, 0x3e, 0xa7, 0xae, 0x5f, 0x1f, 0xf1,
-		0xec, 0x99, 0xa2, 0x5e, 0x37, 0xcf, 0x71, 0x48, 0x27, 0x27, 0xc3, 0x52, 0x6a, 0x7b, 0xfa, 0x57,
-		0x00, 0x00, 0x00, 0xff, 0xff, 0x57, 0xd9, 0xb2, 0xe0, 0x01, 0x09, 0x00, 0x00,
+		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0xdf, 0x72, 0xdb, 0xc4,
+		0x17, 0xfe, 0x29, 0x8e, 0x9d, 0xe4, 0xd8, 0x4d, 0xfc, 0xdb, 0x90, 0xc4, 0x49, 0x09, 0xa4, 0x9a,
+		0x61, 0x1a, 0x3a, 0x20, 0x4f, 0xdc, 0x9b, 0x0e, 0x9d, 0x02, 0x4e, 0xec, 0x24, 0x6a, 0x83, 0x6d,
+		0x64, 0xd3, 0x4c, 0x61, 0x06, 0xcd, 0x5a, 0x5a, 0xb9, 0x8b, 0xa5, 0x5d, 0xb1, 0x5a, 0x39, 0xf1,
+		0x0d, 0xc3, 0x93, 0x70, 0xc1, 0xeb, 0x70, 0xc9, 0x0b, 0x31, 0xab, 0x3f, 0xb1, 0x5d, 0x9c, 0x29,
+		0x17, 0x0c, 0x77, 0xbb, 0xe7, 0xfb, 0xce, 0x39, 0xdf, 0xae, 0xce, 0x39, 0x5a, 0x38, 0x8a, 0x87,
+		0x44, 0xd4, 0x1d, 0xec, 0x12, 0xe6, 0x90, 0x3a, 0x0e, 0x69, 0x7d, 0x72, 0x52, 0x77, 0x78, 0x10,
+		0x70, 0x66, 0x84, 0x82, 0x4b, 0x8e, 0xb6, 0x15, 0xc3, 0xc8, 0x18, 0x06, 0x0e, 0xa9, 0x31, 0x39,
+		0x39, 0xf8, 0x68, 0xc4, 0xf9, 0xc8, 0x27, 0xf5, 0x84, 0x32, 0x8c, 0xbd, 0xba, 0x1b, 0x0b, 0x2c,
+		0x69, 0xee, 0xa4, 0xbf, 0x82, 0xff, 0x5f, 0x73, 0x31, 0xf6, 0x7c, 0x7e, 0xd3, 0xbe, 0x25, 0x4e,
+		0xac, 0x20, 0xf4, 0x31, 0x94, 0x6f, 0x32, 0xa3, 0x4d, 0xdd, 0x9a, 0x76, 0xa4, 0x1d, 0x6f, 0x58,
+		0x90, 0x9b, 0x4c, 0x17, 0xed, 0x40, 0x49, 0xc4, 0x4c, 0x61, 0x2b, 0x09, 0x56, 0x14, 0x31, 0x33,
+		0x5d, 0x5d, 0x87, 0x4a, 0x1e, 0x6c, 0x30, 0x0d, 0x09, 0x42, 0xb0, 0xca, 0x70, 0x40, 0xb2, 0x00,
+		0xc9, 0x5a, 0x71, 0x9a, 0x8e, 0xa4, 0x13, 0x2a, 0xa7, 0xf7, 0x72, 0x0e, 0x61, 0xad, 0x87, 0xa7,
+		0x3e, 0xc7, 0xae, 0x82, 0x5d, 0x2c, 0x71, 0x02, 0x57, 0xac, 0x64, 0xad, 0x3f, 0x87, 0xb5, 0x73,
+		0x4c, 0xfd, 0x58, 0x10, 0xb4, 0x0b, 0x25, 0x41, 0x70, 0xc4, 0x59, 0xe6, 0x9f, 0xed, 0x50, 0x0d,
+		0xd6, 0x5c, 0x22, 0x31, 0xf5, 0xa3, 0x44, 0x61, 0xc5, 0xca, 0xb7, 0xfa, 0x6f, 0x1a, 0xac, 0x7e,
+		0x43, 0x02, 0x8e, 0x5e, 0x40, 0xc9, 0xa3, 0xc4, 0x77, 0xa3, 0x9a, 0x76, 0x54, 0x38, 0x2e, 0x37,
+		0x3e, 0x31, 0x96, 0xdc, 0x9f, 0xa1, 0xa8, 0xc6, 0x79, 0xc2, 0x6b, 0x33, 0x29, 0xa6, 0x56, 0xe6,
+		0x74, 0x70, 0x0d, 0xe5, 0x39, 0x33, 0xaa, 0x42, 0x61, 0x4c, 0xa6, 0x99, 0x0a, 0xb5, 0x44, 0x0d,
+		0x28, 0x4e, 0xb0, 0x1f, 0x93, 0x44, 0x40, 0xb9, 0xf1, 0xe1, 0xd2, 0xf0, 0xd9, 0x31, 0xad, 0x94,
+		0xfa, 0xc5, 0xca, 0x33, 0x4d, 0xff, 0x5d, 0x83, 0xd2, 0x25, 0xc1, 0x2e, 0x11, 0xe8, 0xab, 0x77,
+		0x24, 0x3e, 0x5e, 0x1a, 0x23, 0x25, 0xff, 0xb7, 0x22, 0xff, 0xd4, 0xa0, 0xda, 0x27, 0x58, 0x38,
+		0x6f, 0x9b, 0x52, 0x0a, 0x3a, 0x8c, 0x25, 0x89, 0x90, 0x0d, 0x9b, 0x94, 0xb9, 0xe4, 0x96, 0xb8,
+		0xf6, 0x82, 0xec, 0x67, 0x4b, 0xa3, 0xbe, 0xeb, 0x6e, 0x98, 0xa9, 0xef, 0xfc, 0x39, 0x1e, 0xd0,
+		0x79, 0xdb, 0xc1, 0x8f, 0x80, 0xfe, 0x4e, 0xfa, 0x17, 0x4f, 0xe5, 0xc1, 0x7a, 0x0b, 0x4b, 0x7c,
+		0xea, 0xf3, 0x21, 0x3a, 0x87, 0x07, 0x84, 0x39, 0xdc, 0xa5, 0x6c, 0x64, 0xcb, 0x69, 0x98, 0x16,
+		0xe8, 0x66, 0xe3, 0xd1, 0xd2, 0x58, 0xed, 0x8c, 0xa9, 0x2a, 0xda, 0xaa, 0x90, 0xb9, 0xdd, 0x5d,
+		0x01, 0xaf, 0xcc, 0x15, 0x70, 0x2f, 0x6d, 0x3a, 0x22, 0x5e, 0x13, 0x11, 0x51, 0xce, 0x4c, 0xe6,
+		0x71, 0x45, 0xa4, 0x41, 0xe8, 0xe7, 0x8d, 0xa0, 0xd6, 0xe8, 0x31, 0x6c, 0x79, 0x04, 0xcb, 0x58,
+		0x10, 0x7b, 0x92, 0x52, 0xb3, 0x86, 0xdb, 0xcc, 0xcc, 0x59, 0x00, 0xfd, 0x15, 0xec, 0xf5, 0xe3,
+		0x30, 0xe4, 0x42, 0x12, 0xf7, 0xcc, 0xa7, 0x84, 0xc9, 0x0c, 0x89, 0x54, 0xaf, 0x8e, 0xb8, 0x1d,
+		0xb9, 0xe3, 0x2c, 0x72, 0x71, 0xc4, 0xfb, 0xee, 0x18, 0xed, 0xc3, 0xfa, 0x4f, 0x78, 0x82, 0x13,
+		0x20, 0x8d, 0xb9, 0xa6, 0xf6, 0x7d, 0x77, 0xac, 0xff, 0x5a, 0x80, 0xb2, 0x45, 0xa4, 0x98, 0xf6,
+		0xb8, 0x4f, 0x9d, 0x29, 0x6a, 0x41, 0x95, 0x32, 0x2a, 0x29, 0xf6, 0x6d, 0xca, 0x24, 0x11, 0x13,
+		0x9c, 0xaa, 0x2c, 0x37, 0xf6, 0x8d, 0x74, 0xbc, 0x18, 0xf9, 0x78, 0x31, 0x5a, 0xd9, 0x78, 0xb1,
+		0xb6, 0x32, 0x17, 0x33, 0xf3, 0x40, 0x75, 0xd8, 0x1e, 0x62, 0x67, 0xcc, 0x3d, 0xcf, 0x76, 0x38,
+		0xf1, 0x3c, 0xea, 0x28, 0x99, 0x49, 0x6e, 0xcd, 0x42, 0x19, 0x74, 0x36, 0x43, 0x54, 0xda, 0x00,
+		0xdf, 0xd2, 0x20, 0x0e, 0x66, 0x69, 0x0b, 0xef, 0x4d, 0x9b, 0xb9, 0xdc, 0xa5, 0xfd, 0x74, 0x16,
+		0x05, 0x4b, 0x49, 0x82, 0x50, 0x46, 0xb5, 0xd5, 0x23, 0xed, 0xb8, 0x78, 0x47, 0x6d, 0x66, 0x66,
+		0xf4, 0x02, 0x1e, 0x32, 0xce, 0x6c, 0xa1, 0x8e, 0x8e, 0x87, 0x3e, 0xb1, 0x89, 0x10, 0x5c, 0xd8,
+		0xe9, 0x48, 0x89, 0x6a, 0xc5, 0xa3, 0xc2, 0xf1, 0x86, 0x55, 0x63, 0x9c, 0x59, 0x39, 0xa3, 0xad,
+		0x08, 0x56, 0x8a, 0xa3, 0x97, 0xb0, 0x4d, 0x6e, 0x43, 0x9a, 0x0a, 0x99, 0x49, 0x2e, 0xbd, 0x4f,
+		0x32, 0x9a, 0x79, 0xe5, 0xaa, 0xf5, 0x00, 0xf6, 0xcc, 0x88, 0xfb, 0x89, 0xf1, 0x42, 0xf0, 0x38,
+		0xec, 0x61, 0x21, 0xa9, 0xda, 0x2d, 0x1b, 0x98, 0xe8, 0x4b, 0x28, 0x46, 0x12, 0xcb, 0xb4, 0xe0,
+		0x37, 0x1b, 0xc7, 0x4b, 0x8b, 0x74, 0x31, 0x60, 0x5f, 0xf1, 0xad, 0xd4, 0x4d, 0x9f, 0xc0, 0xc3,
+		0x45, 0xf4, 0x8c, 0x33, 0x8f, 0x8e, 0x32, 0x85, 0xe8, 0x1a, 0xaa, 0x34, 0x87, 0xed, 0x91, 0xc2,
+		0xf3, 0xd6, 0xfe, 0xec, 0x1f, 0x64, 0xba, 0x93, 0x6e, 0x6d, 0xd1, 0x05, 0x20, 0xd2, 0xff, 0xd0,
+		0xe0, 0xa0, 0x19, 0x4d, 0x99, 0x93, 0xff, 0x36, 0x16, 0xf3, 0xd6, 0x60, 0x8d, 0x30, 0x75, 0xcf,
+		0xe9, 0x3f, 0x68, 0xdd, 0xca, 0xb7, 0xa8, 0x01, 0x3b, 0xa1, 0x20, 0x2e, 0xf1, 0x28, 0x23, 0xae,
+		0xfd, 0x73, 0x4c, 0x62, 0x62, 0x27, 0xb7, 0x92, 0x96, 0xf2, 0xf6, 0x0c, 0xfc, 0x56, 0x61, 0x1d,
+		0x75, 0x49, 0x87, 0x00, 0x29, 0x31, 0x69, 0xe7, 0x42, 0x42, 0xdc, 0x48, 0x2c, 0x49, 0xa3, 0x7e,
+		0x0d, 0x95, 0x14, 0x76, 0x12, 0x0d, 0x49, 0x91, 0x94, 0x1b, 0x87, 0x4b, 0x0f, 0x98, 0x4f, 0x09,
+		0xab, 0x9c, 0xb8, 0xa4, 0xaa, 0x9f, 0xdc, 0x40, 0x65, 0x7e, 0x10, 0xa0, 0x7d, 0xd8, 0x69, 0x77,
+		0xce, 0xba, 0x2d, 0xb3, 0x73, 0x61, 0x0f, 0xde, 0xf4, 0xda, 0xb6, 0xd9, 0x79, 0xdd, 0xbc, 0x32,
+		0x5b, 0xd5, 0xff, 0xa1, 0x03, 0xd8, 0x5d, 0x84, 0x06, 0x97, 0x96, 0x79, 0x3e, 0xb0, 0xae, 0xab,
+		0x1a, 0xda, 0x05, 0xb4, 0x88, 0xbd, 0xec, 0x77, 0x3b, 0xd5, 0x15, 0x54, 0x83, 0x0f, 0x16, 0xed,
+		0x3d, 0xab, 0x3b, 0xe8, 0x3e, 0xad, 0x16, 0x9e, 0xfc, 0x02, 0xdb, 0x4b, 0x3e, 0x2e, 0x7a, 0x04,
+		0x87, 0x66, 0xbf, 0x7b, 0xd5, 0x1c, 0x98, 0xdd, 0x8e, 0x7d, 0x61, 0x75, 0xbf, 0xeb, 0xd9, 0xfd,
+		0x41, 0x73, 0x30, 0xaf, 0xe3, 0x5e, 0xca, 0x65, 0xbb, 0x79, 0x35, 0xb8, 0x7c, 0x53, 0xd5, 0xee,
+		0xa7, 0xb4, 0xac, 0xa6, 0xd9, 0x69, 0xb7, 0xaa, 0x2b, 0xa7, 0x3f, 0xc0, 0x9e, 0xc3, 0x83, 0x65,
+		0x37, 0x75, 0x5a, 0x3e, 0x4b, 0x9e, 0x28, 0x3d, 0x55, 0xf5, 0x3d, 0xed, 0xfb, 0x93, 0x11, 0x95,
+		0x6f, 0xe3, 0xa1, 0xe1, 0xf0, 0xa0, 0x3e, 0xff, 0xa0, 0xf9, 0x9c, 0xba, 0x7e, 0x7d, 0xc4, 0xd3,
+		0x67, 0x4a, 0xf6, 0xba, 0x79, 0x8e, 0x43, 0x3a, 0x39, 0x19, 0x96, 0x12, 0xdb, 0xd3, 0xbf, 0x06,
+		0x00, 0x57, 0xd9, 0xb2, 0xe0, 0x01, 0x09, 0x00, 0x00,
 	},
 	// uber/cadence/api/v1/history.proto
 	[]byte{
-		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5c, 0x5d, 0x6c, 0x1c, 0xc7,
-		0x91, 0xf6, 0xec, 0x92, 0xbb, 0xdc, 0x5a, 0x8a, 0x22, 0x5b, 0x14, 0x45, 0xea, 0x97, 0x1a, 0xc9,
-		0x12, 0x4d, 0x51, 0x4b, 0x89, 0x92, 0x25, 0x4b, 0xf2, 0xcf, 0x89, 0x14, 0x09, 0x2f, 0xc0, 0x93,
-		0x74, 0x23, 0x4a, 0xbe, 0x3b, 0x18, 0xd8, 0x1b, 0xee, 0x34, 0xc5, 0x39, 0xee, 0xee, 0xac, 0x67,
-		0x7a, 0xb9, 0xe2, 0xe1, 0xee, 0xe9, 0x1e, 0x02, 0x04, 0x31, 0x1c, 0xc3, 0x08, 0x10, 0x03, 0x31,
-		0x12, 0x04, 0x48, 0x10, 0x27, 0x01, 0x1c, 0x24, 0x08, 0xf2, 0xf7, 0x92, 0x04, 0x08, 0x1c, 0x20,
-		0x81, 0x93, 0xa7, 0xbc, 0xe4, 0x35, 0x08, 0xe2, 0xb7, 0x3c, 0xc4, 0x79, 0x0b, 0x10, 0x4c, 0x4f,
-		0xcf, 0xee, 0xce, 0x4c, 0xf7, 0x4c, 0xcf, 0x92, 0xb2, 0x13, 0x58, 0x6f, 0x9c, 0x9e, 0xaa, 0x9a,
-		0xaf, 0xbb, 0xab, 0xaa, 0xab, 0xab, 0x6a, 0x09, 0x27, 0x5b, 0xeb, 0xd8, 0x9e, 0xaf, 0xea, 0x06,
-		0x6e, 0x54, 0xf1, 0xbc, 0xde, 0x34, 0xe7, 0xb7, 0x2f, 0xce, 0x6f, 0x9a, 0x0e, 0xb1, 0xec, 0x9d,
-		0x52, 0xd3, 0xb6, 0x88, 0x85, 0x0e, 0xb8, 0x24, 0x25, 0x46, 0x52, 0xd2, 0x9b, 0x66, 0x69, 0xfb,
-		0xe2, 0xe1, 0xe3, 0x0f, 0x2d, 0xeb, 0x61, 0x0d, 0xcf, 0x53, 0x92, 0xf5, 0xd6, 0xc6, 0xbc, 0xd1,
-		0xb2, 0x75, 0x62, 0x5a, 0x0d, 0x8f, 0xe9, 0xf0, 0x89, 0xf0, 0x7b, 0x62, 0xd6, 0xb1, 0x43, 0xf4,
-		0x7a, 0x93, 0x11, 0x4c, 0xf3, 0x3e, 0x5c, 0xb5, 0xea, 0xf5, 0x8e, 0x08, 0x95, 0x47, 0x41, 0x74,
-		0x67, 0xab, 0x66, 0x3a, 0x24, 0x8e, 0xa6, 0x6d, 0xd9, 0x5b, 0x1b, 0x35, 0xab, 0xed, 0xd1, 0xa8,
-		0xb7, 0x20, 0xff, 0xb2, 0x37, 0x21, 0x74, 0x0d, 0x72, 0x78, 0x1b, 0x37, 0x88, 0x33, 0xa9, 0x4c,
-		0x67, 0x67, 0x8a, 0x0b, 0x27, 0x4b, 0x9c, 0xb9, 0x95, 0x18, 0xf5, 0xb2, 0x4b, 0xa9, 0x31, 0x06,
-		0xf5, 0xc3, 0xab, 0x30, 0xdc, 0xfb, 0x02, 0x4d, 0xc1, 0x10, 0x7d, 0x55, 0x31, 0x8d, 0x49, 0x65,
-		0x5a, 0x99, 0xc9, 0x6a, 0x79, 0xfa, 0x5c, 0x36, 0xd0, 0x35, 0x00, 0xef, 0x95, 0x3b, 0xe9, 0xc9,
-		0xcc, 0xb4, 0x32, 0x53, 0x5c, 0x38, 0x5c, 0xf2, 0x56, 0xa4, 0xe4, 0xaf, 0x48, 0x69, 0xcd, 0x5f,
-		0x11, 0xad, 0x40, 0xa9, 0xdd, 0x67, 0x34, 0x09, 0xf9, 0x6d, 0x6c, 0x3b, 0xa6, 0xd5, 0x98, 0xcc,
-		0x7a, 0x42, 0xd9, 0x23, 0x3a, 0x04, 0x79, 0x77, 0xf2, 0xee, 0xe7, 0x06, 0xe8, 0x9b, 0x9c, 0xfb,
-		0x58, 0x36, 0xd0, 0x97, 0x14, 0x38, 0xe7, 0x4f, 0xb9, 0x82, 0x1f, 0xe1, 0x6a, 0xcb, 0xdd, 0x87,
-		0x8a, 0x43, 0x74, 0x9b, 0x60, 0xa3, 0xe2, 0x21, 0xd1, 0x09, 0xb1, 0xcd, 0xf5, 0x16, 0xc1, 0xce,
-		0xe4, 0x20, 0xc5, 0xf3, 0x3c, 0x77, 0xea, 0xaf, 0x30, 0x39, 0xcb, 0xbe, 0x98, 0x7b, 0x9e, 0x14,
-		0x3a, 0xe5, 0x9b, 0x1d, 0x19, 0x2f, 0x3f, 0xa5, 0x9d, 0x6d, 0xcb, 0x91, 0xa2, 0xaf, 0x2a, 0x70,
-		0x9e, 0x03, 0xaf, 0x6a, 0xd5, 0x9b, 0x35, 0xcc, 0x05, 0x98, 0xa3, 0x00, 0x5f, 0x94, 0x03, 0xb8,
-		0xe4, 0xcb, 0x89, 0x42, 0x7c, 0xa6, 0x2d, 0x4b, 0x8c, 0xde, 0x56, 0x60, 0x96, 0x03, 0x72, 0x43,
-		0x37, 0x6b, 0x3c, 0x84, 0x79, 0x8a, 0xf0, 0x86, 0x1c, 0xc2, 0x15, 0x2a, 0x24, 0x0a, 0xef, 0x4c,
-		0x5b, 0x8a, 0x12, 0x7d, 0x85, 0xbf, 0x80, 0xae, 0x6e, 0x19, 0x15, 0xab, 0x45, 0xa2, 0xf0, 0x86,
-		0x28, 0xbc, 0x17, 0xe4, 0xe0, 0xb9, 0x6a, 0x67, 0xdc, 0x69, 0x91, 0x28, 0xc0, 0x99, 0xb6, 0x24,
-		0x2d, 0x7a, 0x4b, 0x81, 0x19, 0x03, 0x57, 0x4d, 0x87, 0x02, 0x73, 0xb5, 0xd4, 0xa9, 0x6e, 0x62,
-		0xa3, 0xc5, 0x5d, 0xbc, 0x02, 0x45, 0x77, 0x8d, 0x8b, 0xee, 0x16, 0x13, 0xb2, 0xa6, 0x3b, 0x5b,
-		0xf7, 0x7c, 0x11, 0x51, 0x64, 0xa7, 0x0d, 0x09, 0x3a, 0xf4, 0xba, 0x02, 0x67, 0x42, 0xa8, 0x44,
-		0x36, 0x01, 0x14, 0xd3, 0xd5, 0x64, 0x4c, 0x22, 0x73, 0x50, 0x8d, 0x44, 0x2a, 0xce, 0x2a, 0xc5,
-		0x18, 0x41, 0x51, 0x72, 0x95, 0x62, 0xf4, 0x3f, 0xb0, 0x4a, 0x42, 0xd5, 0x7f, 0x33, 0x82, 0x2a,
-		0x46, 0xb3, 0x86, 0x29, 0xaa, 0xe7, 0x12, 0x51, 0x89, 0x95, 0xea, 0x94, 0x91, 0x4c, 0x86, 0x3e,
-		0xab, 0xc0, 0xd3, 0x41, 0x4c, 0x22, 0x4b, 0xdc, 0x47, 0x01, 0x5d, 0x49, 0x04, 0x24, 0x32, 0xc2,
-		0x93, 0x46, 0x12, 0x11, 0xdd, 0x36, 0xbd, 0x4a, 0xcc, 0x6d, 0x93, 0xec, 0x24, 0x2a, 0xf7, 0x48,
-		0xcc, 0xb6, 0xdd, 0x64, 0x42, 0x92, 0x94, 0x5b, 0x97, 0xa0, 0xa3, 0xca, 0x1d, 0x42, 0x25, 0x52,
-		0xee, 0xfd, 0x31, 0xca, 0x1d, 0xc0, 0x24, 0x54, 0x6e, 0x3d, 0x91, 0x8a, 0xb3, 0x4a, 0x31, 0xca,
-		0x3d, 0x2a, 0xb9, 0x4a, 0x71, 0xca, 0xad, 0x4b, 0xd0, 0x51, 0x45, 0x0a, 0xa2, 0x12, 0x29, 0xd2,
-		0x58, 0x8c, 0x22, 0xf5, 0x42, 0x12, 0x2a, 0x92, 0x9e, 0x44, 0x44, 0x2d, 0x2d, 0x08, 0x26, 0xc6,
-		0xd2, 0x50, 0x8c, 0xa5, 0xf5, 0xe2, 0x89, 0xb1, 0x34, 0x3d, 0x99, 0x0c, 0xb5, 0xe1, 0xb8, 0x0b,
-		0xc2, 0x16, 0x6b, 0xcf, 0x01, 0x0a, 0xe4, 0x02, 0x17, 0x88, 0x2b, 0xd5, 0x16, 0xaa, 0xcd, 0x11,
-		0x22, 0x7e, 0x8d, 0x5e, 0x83, 0xa3, 0xde, 0x87, 0x37, 0x4c, 0x9b, 0xf7, 0xd9, 0x71, 0xfa, 0xd9,
-		0x92, 0xf8, 0xb3, 0x2b, 0x2e, 0x5f, 0xf4, 0xa3, 0x53, 0x44, 0xf4, 0x12, 0x7d, 0x5d, 0x81, 0xf9,
-		0x90, 0x8a, 0xea, 0x8d, 0x2a, 0xae, 0x55, 0x6c, 0xfc, 0x5a, 0x0b, 0x3b, 0xdc, 0xd9, 0x1f, 0xa4,
-		0x30, 0x5e, 0x4a, 0xd6, 0x54, 0x2a, 0x49, 0xf3, 0x05, 0x45, 0x71, 0xcd, 0xea, 0xd2, 0xd4, 0xe8,
-		0x7b, 0x0a, 0x5c, 0x66, 0x98, 0x7c, 0x88, 0x72, 0x4a, 0x3c, 0x41, 0xd1, 0x2e, 0x71, 0xd1, 0xb2,
-		0xaf, 0x79, 0x9f, 0x96, 0xd1, 0xe8, 0x92, 0x9d, 0x8a, 0x03, 0x7d, 0x5e, 0x81, 0xb3, 0xbc, 0xe5,
-		0xe5, 0x01, 0x3d, 0x24, 0xa9, 0xdd, 0x4b, 0x4c, 0x42, 0x82, 0x76, 0x0b, 0xc8, 0xd0, 0xff, 0xc0,
-		0x09, 0x4f, 0xc9, 0xc4, 0x48, 0x26, 0x29, 0x92, 0x8b, 0x62, 0x3d, 0x13, 0x43, 0xf0, 0x14, 0x58,
-		0xf4, 0xed, 0xcf, 0x28, 0x70, 0x9a, 0x6d, 0x1e, 0x53, 0x74, 0xc1, 0xa6, 0x4d, 0x51, 0x04, 0xcf,
-		0x72, 0x11, 0x78, 0xc2, 0x3d, 0x7d, 0x17, 0x6c, 0xd3, 0x74, 0x35, 0x81, 0x06, 0xfd, 0x1f, 0x4c,
-		0xd7, 0x75, 0x7b, 0x0b, 0xdb, 0x15, 0x1b, 0x57, 0x2d, 0xdb, 0xe0, 0x81, 0x38, 0x4c, 0x41, 0x2c,
-		0x70, 0x41, 0xfc, 0x2b, 0x65, 0xd6, 0x18, 0x6f, 0x14, 0xc1, 0xb1, 0x7a, 0x1c, 0x01, 0xfa, 0xb2,
-		0x02, 0x73, 0xbc, 0xfb, 0x89, 0xf9, 0xb0, 0xa1, 0x73, 0x17, 0xe4, 0x48, 0x9a, 0xf0, 0xf5, 0x1e,
-		0x13, 0x23, 0x13, 0xbe, 0x0a, 0x68, 0xd1, 0xd7, 0x14, 0x28, 0xf1, 0x22, 0x6c, 0x6c, 0xd7, 0xcd,
-		0x86, 0xce, 0xf5, 0x0b, 0x47, 0x63, 0xfc, 0x42, 0x34, 0xc4, 0xee, 0x08, 0xe2, 0xf8, 0x85, 0xb6,
-		0x34, 0x35, 0xfa, 0xbe, 0x02, 0x97, 0x79, 0x57, 0xa9, 0x44, 0x2f, 0x76, 0x8c, 0xa2, 0xbd, 0x25,
-		0x79, 0xa3, 0x4a, 0x72, 0x65, 0xf3, 0xed, 0x74, 0x2c, 0x22, 0x0d, 0x10, 0x1b, 0xe5, 0xf1, 0x34,
-		0x1a, 0x20, 0x36, 0xd0, 0x99, 0xb6, 0x24, 0x2d, 0xfa, 0x83, 0x02, 0xcb, 0x21, 0x8f, 0x8b, 0x1f,
-		0x11, 0x6c, 0x37, 0xf4, 0x5a, 0x85, 0x83, 0xdc, 0x6c, 0x98, 0xc4, 0xe4, 0x2b, 0xc6, 0x09, 0x0a,
-		0xfd, 0x5e, 0xb2, 0x0b, 0x5e, 0x66, 0xf2, 0x23, 0xf3, 0x29, 0xfb, 0xc2, 0xa3, 0x13, 0x7a, 0xd1,
-		0xde, 0x95, 0x04, 0xf4, 0x7b, 0x05, 0x16, 0x53, 0x4c, 0x53, 0xe4, 0xb1, 0xa6, 0xe9, 0x1c, 0xef,
-		0xee, 0x62, 0x8e, 0x22, 0x67, 0x76, 0xc3, 0xee, 0x9f, 0x1d, 0x7d, 0xa0, 0xc0, 0x0b, 0x71, 0xd3,
-		0x49, 0xb6, 0x93, 0x93, 0x74, 0x62, 0xab, 0xdc, 0x89, 0x09, 0xc1, 0x24, 0xda, 0xcb, 0x55, 0xdc,
-		0x1f, 0x2b, 0x8d, 0x03, 0xb8, 0xa9, 0x93, 0x06, 0x31, 0x1b, 0x2d, 0x6c, 0x54, 0x74, 0xa7, 0xd2,
-		0xc0, 0xed, 0xe8, 0x3c, 0xd4, 0x98, 0x38, 0x80, 0x93, 0x41, 0x61, 0xe2, 0x6e, 0x3a, 0xb7, 0x71,
-		0x9b, 0x13, 0x07, 0xb4, 0x53, 0x71, 0xa0, 0x9f, 0x2b, 0x70, 0x8d, 0x46, 0x93, 0x95, 0xea, 0xa6,
-		0x59, 0x33, 0x52, 0xda, 0xcf, 0x29, 0x0a, 0xfd, 0x65, 0x2e, 0x74, 0x1a, 0x4a, 0x2e, 0xb9, 0x42,
-		0xd3, 0x18, 0xcd, 0x25, 0x27, 0x3d, 0x1b, 0xfa, 0x91, 0x02, 0x57, 0x12, 0x26, 0x21, 0xb2, 0x8e,
-		0xd3, 0x74, 0x06, 0xcb, 0x69, 0x67, 0x20, 0x32, 0x89, 0x0b, 0x4e, 0x4a, 0x1e, 0xf4, 0x6d, 0x05,
-		0x2e, 0x0a, 0x51, 0x0b, 0xe3, 0xfc, 0xa7, 0x29, 0xec, 0x9b, 0xfc, 0x30, 0x84, 0xfb, 0x75, 0x61,
-		0xe0, 0x3f, 0x57, 0x4d, 0x41, 0x8f, 0xbe, 0xab, 0xc0, 0x25, 0x21, 0xdc, 0x98, 0x4b, 0xe4, 0x99,
-		0x18, 0x25, 0xe7, 0x03, 0x8e, 0xb9, 0x4e, 0x96, 0xaa, 0xa9, 0x38, 0xd0, 0xbb, 0x0a, 0x5c, 0x48,
-		0xad, 0x19, 0x67, 0x29, 0xe2, 0x7f, 0x49, 0x81, 0x58, 0xa4, 0x14, 0xe7, 0xaa, 0x29, 0xf4, 0xe1,
-		0x3d, 0x05, 0x16, 0xc4, 0x0b, 0x2c, 0x3c, 0x84, 0x67, 0x28, 0xda, 0xc5, 0x34, 0xeb, 0x2b, 0x3c,
-		0x89, 0xcf, 0x57, 0xd3, 0x30, 0xa0, 0xef, 0xc4, 0xa9, 0x44, 0xcc, 0xa5, 0xf9, 0x99, 0xd4, 0x90,
-		0xc5, 0xd7, 0x67, 0x01, 0x64, 0xd1, 0x45, 0xda, 0x8d, 0xcd, 0xc4, 0x90, 0x63, 0x22, 0xc9, 0xd9,
-		0x98, 0xd8, 0x4c, 0x80, 0x39, 0x26, 0x9c, 0x9c, 0xaf, 0xa6, 0x63, 0xa1, 0x87, 0xa6, 0x17, 0x8a,
-		0xf7, 0x1b, 0xf1, 0x9c, 0x8b, 0x39, 0x34, 0xbd, 0x88, 0xbb, 0x9f, 0x50, 0xe7, 0xaa, 0xd3, 0x1f,
-		0x2b, 0xfa, 0x85, 0x02, 0xd7, 0x25, 0x26, 0x24, 0xb2, 0xd1, 0x39, 0x3a, 0x9b, 0x72, 0x3f, 0xb3,
-		0x11, 0x19, 0xeb, 0x65, 0xa7, 0x0f, 0x3e, 0xf4, 0x43, 0x05, 0x9e, 0x8d, 0x9b, 0x80, 0xf8, 0xfe,
-		0x74, 0x3e, 0xe6, 0x00, 0x12, 0x82, 0x10, 0xdf, 0xa3, 0x2e, 0xe0, 0x94, 0x3c, 0xd4, 0xe1, 0xb4,
-		0x9a, 0x0e, 0xb6, 0x49, 0x17, 0xb8, 0x83, 0x75, 0xbb, 0xba, 0xd9, 0x03, 0x33, 0x8a, 0xbb, 0x14,
-		0x63, 0xbd, 0xf7, 0xa9, 0x38, 0x1f, 0xc1, 0x3d, 0x2a, 0xac, 0xfb, 0x45, 0x8e, 0xf5, 0xb6, 0xd2,
-		0x30, 0x2c, 0x0e, 0x03, 0x74, 0x81, 0xa8, 0x6f, 0x8c, 0xc0, 0x59, 0xd9, 0xd3, 0x6b, 0x05, 0xf6,
-		0x75, 0xe6, 0x48, 0x76, 0x9a, 0x98, 0xd6, 0x02, 0x45, 0x95, 0x45, 0x5f, 0xe8, 0xda, 0x4e, 0x13,
-		0x6b, 0xc3, 0xed, 0x9e, 0x27, 0xf4, 0x2a, 0x1c, 0x6c, 0xea, 0xb6, 0xbb, 0x22, 0xbd, 0x46, 0xb7,
-		0x61, 0xb1, 0xf2, 0xe1, 0x0c, 0x57, 0xde, 0x5d, 0xca, 0xd1, 0x63, 0x13, 0x1b, 0x96, 0x76, 0xa0,
-		0x19, 0x1d, 0x44, 0xd7, 0xa1, 0x40, 0x33, 0x32, 0x35, 0xd3, 0x21, 0xb4, 0xb0, 0x58, 0x5c, 0x38,
-		0xc6, 0x4f, 0x79, 0xe8, 0xce, 0xd6, 0xaa, 0xe9, 0x10, 0x6d, 0x88, 0xb0, 0xbf, 0xd0, 0x02, 0x0c,
-		0x9a, 0x8d, 0x66, 0x8b, 0xd0, 0xb2, 0x63, 0x71, 0xe1, 0xa8, 0x00, 0xc9, 0x4e, 0xcd, 0xd2, 0x0d,
-		0xcd, 0x23, 0x45, 0x3a, 0x4c, 0x87, 0x42, 0x8e, 0x0a, 0xb1, 0x2a, 0xd5, 0x9a, 0xe5, 0x60, 0xea,
-		0xbf, 0xad, 0x16, 0x61, 0x75, 0xc8, 0xa9, 0x48, 0x5d, 0xf4, 0x16, 0xab, 0x24, 0x6b, 0x47, 0x71,
-		0x60, 0xed, 0xd7, 0xac, 0x25, 0x97, 0x7f, 0xcd, 0x63, 0x47, 0xaf, 0xc0, 0x91, 0x6e, 0xda, 0x3b,
-		0x2a, 0x3d, 0x97, 0x24, 0xfd, 0x10, 0xf1, 0x93, 0xd9, 0x21, 0xc1, 0x37, 0xe0, 0x70, 0x37, 0xc2,
-		0xee, 0xce, 0xc2, 0x6e, 0x35, 0x2a, 0xa6, 0x41, 0x4b, 0x7f, 0x05, 0xed, 0x50, 0x87, 0xa2, 0xb3,
-		0xce, 0x5a, 0xab, 0x51, 0x36, 0x50, 0x19, 0x0a, 0xcc, 0x55, 0x5a, 0x36, 0xad, 0xc3, 0x8d, 0x2c,
-		0x9c, 0xe3, 0xbb, 0x76, 0x26, 0x80, 0x86, 0xd0, 0x65, 0x9f, 0x45, 0xeb, 0x72, 0xa3, 0x32, 0x8c,
-		0x75, 0x71, 0xb8, 0xee, 0xaa, 0x65, 0x63, 0x56, 0x3c, 0xe3, 0xef, 0xc1, 0x8a, 0x47, 0xa3, 0x8d,
-		0x76, 0xd8, 0xd8, 0x08, 0xd2, 0x60, 0xa2, 0xa6, 0xbb, 0x77, 0x3e, 0x2f, 0x9c, 0xa1, 0xd3, 0xc1,
-		0x4e, 0xab, 0x46, 0x58, 0xe1, 0x2b, 0x7e, 0x4f, 0xc7, 0x5d, 0xde, 0xa5, 0x0e, 0xab, 0x46, 0x39,
-		0xd1, 0x35, 0x98, 0xb2, 0x6c, 0xf3, 0xa1, 0xe9, 0x39, 0xda, 0xd0, 0x2a, 0x15, 0xe9, 0x2a, 0x4d,
-		0xf8, 0x04, 0xa1, 0x45, 0x3a, 0x0c, 0x43, 0xa6, 0x81, 0x1b, 0xc4, 0x24, 0x3b, 0xb4, 0xa2, 0x54,
-		0xd0, 0x3a, 0xcf, 0xe8, 0x12, 0x4c, 0x6c, 0x98, 0xb6, 0x43, 0xa2, 0x32, 0xf7, 0x51, 0xca, 0x03,
-		0xf4, 0x6d, 0x48, 0xe0, 0x12, 0x0c, 0xdb, 0x98, 0xd8, 0x3b, 0x95, 0xa6, 0x55, 0x33, 0xab, 0x3b,
-		0xac, 0x0a, 0x33, 0x2d, 0xb8, 0xa0, 0x12, 0x7b, 0xe7, 0x2e, 0xa5, 0xd3, 0x8a, 0x76, 0xf7, 0x01,
-		0x4d, 0x42, 0x5e, 0x27, 0x04, 0xd7, 0x9b, 0x84, 0x56, 0x4c, 0x06, 0x35, 0xff, 0x11, 0x2d, 0xc1,
-		0x7e, 0xfc, 0xa8, 0x69, 0x7a, 0x8a, 0xe3, 0x15, 0xf5, 0x47, 0x13, 0x8b, 0xfa, 0x23, 0x5d, 0x16,
-		0x5a, 0xd9, 0x3f, 0x05, 0xfb, 0xaa, 0xb6, 0x6b, 0x0d, 0xac, 0xa2, 0x43, 0x2b, 0x0e, 0x05, 0x6d,
-		0xd8, 0x1d, 0xf4, 0xab, 0x3c, 0xe8, 0xdf, 0xe1, 0x88, 0x37, 0xfb, 0x60, 0xf5, 0x6b, 0x5d, 0xaf,
-		0x6e, 0x59, 0x1b, 0x1b, 0xac, 0x28, 0x10, 0xa3, 0xd4, 0x93, 0x94, 0xbb, 0xb7, 0xf0, 0xb5, 0xe8,
-		0xb1, 0xa2, 0xf3, 0x30, 0x50, 0xc7, 0x75, 0x8b, 0xa5, 0xf3, 0xa7, 0xf8, 0x89, 0x3e, 0x5c, 0xb7,
-		0x34, 0x4a, 0x86, 0x34, 0x18, 0x8b, 0x78, 0x6c, 0x96, 0x93, 0x7f, 0x9a, 0x7f, 0x36, 0x86, 0x3c,
-		0xac, 0x36, 0xea, 0x84, 0x46, 0xd0, 0x7d, 0x98, 0x68, 0xda, 0x78, 0xbb, 0xa2, 0xb7, 0x88, 0xe5,
-		0xea, 0x1f, 0x26, 0x95, 0xa6, 0x65, 0x36, 0x88, 0x9f, 0x65, 0x17, 0xed, 0x97, 0x83, 0xc9, 0x5d,
-		0x4a, 0xa7, 0x1d, 0x70, 0xf9, 0x6f, 0xb6, 0x88, 0xd5, 0x33, 0x88, 0x2e, 0x41, 0x6e, 0x13, 0xeb,
-		0x06, 0xb6, 0x59, 0xfa, 0xfb, 0x08, 0xbf, 0xa9, 0x83, 0x92, 0x68, 0x8c, 0x14, 0xad, 0xc2, 0xb8,
-		0xb7, 0xd0, 0xdd, 0x5a, 0x1e, 0xdd, 0xd7, 0x43, 0x89, 0xfb, 0x8a, 0x28, 0x5f, 0xa7, 0x2e, 0x47,
-		0xf7, 0xf6, 0x7f, 0x61, 0xb4, 0xa9, 0xdb, 0xc4, 0xf4, 0xaf, 0xe7, 0x1b, 0xe6, 0xc3, 0xc9, 0x49,
-		0xda, 0x61, 0xf2, 0x6f, 0xbb, 0x69, 0xb3, 0x70, 0xfd, 0xbb, 0x27, 0x74, 0x89, 0xca, 0x5c, 0x6e,
-		0x10, 0x7b, 0x47, 0xdb, 0xdf, 0x0c, 0x8e, 0xa2, 0x63, 0x00, 0x7e, 0x52, 0xc7, 0x34, 0x68, 0x3a,
-		0xb9, 0xa0, 0x15, 0xd8, 0x48, 0xd9, 0x38, 0xbc, 0x08, 0xe3, 0x3c, 0x39, 0x68, 0x14, 0xb2, 0x5b,
-		0x78, 0x87, 0x9e, 0x57, 0x05, 0xcd, 0xfd, 0x13, 0x8d, 0xc3, 0xe0, 0xb6, 0x5e, 0x6b, 0x79, 0x2d,
-		0x2b, 0x05, 0xcd, 0x7b, 0xb8, 0x9e, 0x79, 0x4e, 0x51, 0xdf, 0x55, 0xe0, 0x19, 0xf9, 0xcb, 0xd1,
-		0x65, 0xc8, 0x31, 0xf7, 0xa2, 0x48, 0xb8, 0x17, 0x46, 0x8b, 0x56, 0x60, 0x3a, 0xbe, 0x3a, 0x6e,
-		0x1a, 0x14, 0x58, 0x56, 0x3b, 0x2a, 0x2e, 0x6c, 0x97, 0x0d, 0xf5, 0x1b, 0x0a, 0x9c, 0x91, 0x8c,
-		0xb1, 0xae, 0x40, 0xde, 0x77, 0xac, 0x8a, 0x84, 0x63, 0xf5, 0x89, 0xf7, 0x0c, 0xaa, 0x05, 0x33,
-		0xd2, 0x17, 0x8c, 0x25, 0x18, 0x66, 0x67, 0x5b, 0x37, 0xce, 0x18, 0x11, 0xd8, 0x0c, 0x3b, 0xca,
-		0x68, 0x98, 0x51, 0x24, 0xdd, 0x07, 0xf5, 0xd7, 0x0a, 0x9c, 0x96, 0xe9, 0xb1, 0x08, 0x06, 0x0c,
-		0x4a, 0xba, 0x80, 0xe1, 0x36, 0x4c, 0x08, 0x0e, 0xe5, 0x4c, 0x92, 0xff, 0x3a, 0xe0, 0x70, 0x0e,
-		0xe4, 0x1e, 0xc7, 0x9c, 0x0d, 0x38, 0x66, 0xf5, 0x75, 0x05, 0xd4, 0xe4, 0xf6, 0x0c, 0x34, 0x07,
-		0x28, 0x5c, 0xb2, 0xef, 0x34, 0x6d, 0x8d, 0x3a, 0x81, 0x25, 0x08, 0x9d, 0x4e, 0x99, 0xd0, 0xe9,
-		0x14, 0x34, 0xb5, 0x6c, 0xc8, 0xd4, 0xd4, 0x3f, 0x87, 0x96, 0x57, 0x68, 0x21, 0xe9, 0x10, 0xcd,
-		0xc0, 0x68, 0x30, 0x6d, 0xd3, 0x51, 0xaf, 0x11, 0xa7, 0x67, 0xc6, 0x21, 0xec, 0xd9, 0x10, 0xf6,
-		0xb3, 0xb0, 0x7f, 0xdd, 0x6c, 0xe8, 0xf6, 0x4e, 0xa5, 0xba, 0x89, 0xab, 0x5b, 0x4e, 0xab, 0x4e,
-		0x23, 0xba, 0x82, 0x36, 0xe2, 0x0d, 0x2f, 0xb1, 0x51, 0x74, 0x0e, 0xc6, 0x82, 0xc9, 0x46, 0xfc,
-		0xc8, 0x8b, 0xd6, 0x86, 0xb5, 0x51, 0xdc, 0x9b, 0x03, 0xc4, 0x8f, 0x88, 0xfa, 0xad, 0x2c, 0x9c,
-		0x92, 0xe8, 0xfc, 0x78, 0x6c, 0x33, 0x0e, 0x9b, 0x45, 0xb6, 0x0f, 0xb3, 0x40, 0xc7, 0xa1, 0xb8,
-		0xae, 0x3b, 0xd8, 0x8f, 0x34, 0xbc, 0x65, 0x29, 0xb8, 0x43, 0x5e, 0x7c, 0x71, 0x14, 0xa0, 0x81,
-		0xdb, 0xfe, 0xeb, 0x41, 0x6f, 0x61, 0x1b, 0xb8, 0xed, 0xbd, 0x9d, 0x03, 0xb4, 0x61, 0xd9, 0x5b,
-		0x0c, 0xa9, 0xdf, 0xbe, 0x97, 0xf3, 0xa6, 0xe6, 0xbe, 0xa1, 0x58, 0x1f, 0xb0, 0x3e, 0xbe, 0x09,
-		0xd7, 0x39, 0xea, 0x8e, 0xd5, 0x60, 0xa1, 0x24, 0x7b, 0x42, 0xb7, 0x60, 0xb0, 0xaa, 0xb7, 0x1c,
-		0xcc, 0xa2, 0xc6, 0x92, 0x74, 0x8f, 0xcd, 0x92, 0xcb, 0xa5, 0x79, 0xcc, 0x21, 0x05, 0x2d, 0x84,
-		0x15, 0xf4, 0xfd, 0x2c, 0x9c, 0x4c, 0x6c, 0x8b, 0x79, 0x6c, 0x7b, 0xb5, 0xe8, 0x4f, 0xd1, 0xdb,
-		0xa4, 0x39, 0xc9, 0xae, 0x9d, 0xc0, 0x04, 0x7b, 0x5c, 0xf6, 0x40, 0x1a, 0x97, 0xdd, 0x6b, 0x19,
-		0x83, 0x21, 0xcb, 0x08, 0x6d, 0x7f, 0x2e, 0x7e, 0xfb, 0xf3, 0x52, 0xdb, 0x3f, 0x24, 0xd8, 0x7e,
-		0x8e, 0x15, 0x16, 0xb8, 0x56, 0x18, 0xdc, 0x49, 0x08, 0xef, 0xe4, 0x3b, 0x39, 0x38, 0x2d, 0xd3,
-		0x50, 0x84, 0x4e, 0x40, 0xb1, 0x53, 0x95, 0x67, 0xbb, 0x58, 0xd0, 0xc0, 0x1f, 0x2a, 0x1b, 0xee,
-		0x0d, 0xb6, 0x5b, 0xb6, 0x77, 0x4d, 0x28, 0x13, 0x73, 0x83, 0xed, 0x7c, 0x92, 0xde, 0x60, 0xf5,
-		0x9e, 0x27, 0x57, 0xb1, 0x0d, 0xab, 0xae, 0x9b, 0x0d, 0xe6, 0x79, 0xd8, 0x53, 0xf0, 0x28, 0x19,
-		0xe8, 0xf3, 0xee, 0x99, 0x93, 0xbf, 0x7b, 0xae, 0xc1, 0x94, 0xaf, 0xa3, 0xd1, 0x13, 0x28, 0x9f,
-		0x74, 0x02, 0x4d, 0xf8, 0xbc, 0xa1, 0x43, 0x28, 0x24, 0x95, 0x1d, 0x70, 0x4c, 0xea, 0x50, 0x0a,
-		0xa9, 0xde, 0x95, 0x93, 0x49, 0x15, 0x1f, 0x95, 0x85, 0xbe, 0x8e, 0xca, 0x15, 0x18, 0xdb, 0xc4,
-		0xba, 0x4d, 0xd6, 0xb1, 0xde, 0x45, 0x07, 0x49, 0xa2, 0x46, 0x3b, 0x3c, 0x5d, 0x39, 0xc9, 0x01,
-		0x4e, 0x31, 0x39, 0xc0, 0x89, 0x5c, 0xcc, 0x86, 0xfb, 0xb9, 0x98, 0x75, 0x03, 0xfc, 0x7d, 0xd2,
-		0x01, 0xbe, 0xfa, 0x27, 0x05, 0xd4, 0xe4, 0xe6, 0xb6, 0x8f, 0x2d, 0x34, 0xe8, 0x0d, 0x62, 0x06,
-		0x82, 0xb7, 0xcb, 0x97, 0x60, 0x98, 0x5e, 0xce, 0x7d, 0xb7, 0x36, 0x28, 0xe1, 0xd6, 0x8a, 0x2e,
-		0x07, 0x7b, 0x50, 0x7f, 0xab, 0x04, 0x5d, 0xc1, 0x1e, 0xc7, 0xe5, 0xfc, 0x25, 0xca, 0xa4, 0x38,
-		0x0d, 0xb2, 0x89, 0xb1, 0xca, 0x40, 0x70, 0x31, 0xd5, 0xdf, 0x28, 0x70, 0x32, 0xb9, 0xe3, 0xa8,
-		0xdf, 0xf0, 0xfd, 0x93, 0x98, 0xd1, 0x4f, 0x32, 0x70, 0x4a, 0xa2, 0x6f, 0xcf, 0x9d, 0x93, 0x81,
-		0x89, 0x6e, 0xd6, 0x1c, 0xa9, 0x4d, 0xf2, 0x89, 0x1f, 0xdb, 0x9c, 0xc2, 0xf1, 0xd5, 0x40, 0x3f,
-		0xf1, 0xd5, 0xae, 0x55, 0xfc, 0x0b, 0x0a, 0xcc, 0xca, 0xb7, 0xdb, 0xc9, 0x9c, 0x79, 0x7b, 0x73,
-		0x81, 0x7b, 0x4f, 0x81, 0x94, 0x8d, 0x75, 0xc9, 0xd8, 0xc6, 0xfd, 0x28, 0x89, 0xdd, 0xc2, 0xbd,
-		0xb8, 0x47, 0x06, 0x71, 0x56, 0x02, 0xf1, 0xdb, 0x21, 0x3d, 0x14, 0x95, 0xe0, 0xfa, 0xd5, 0xc3,
-		0x15, 0x98, 0xae, 0xe9, 0xa4, 0xa7, 0xc1, 0x24, 0xdc, 0x6e, 0xd1, 0x5d, 0x59, 0x8f, 0x8e, 0xb7,
-		0x95, 0x5e, 0x54, 0xc5, 0xd1, 0xe7, 0x6c, 0x0a, 0x7d, 0x1e, 0x48, 0xb4, 0xd1, 0x50, 0x1c, 0xa8,
-		0x7e, 0xa0, 0xc0, 0x91, 0x98, 0x96, 0x56, 0x34, 0x05, 0x43, 0x5e, 0x2b, 0x5f, 0x67, 0xdf, 0xf2,
-		0xf4, 0xb9, 0x6c, 0xa0, 0x55, 0x38, 0xd8, 0x39, 0xc8, 0x37, 0x4c, 0x3b, 0xc5, 0x95, 0x17, 0xb1,
-		0x73, 0x7c, 0xc5, 0xb4, 0x71, 0x9a, 0xe3, 0x57, 0x66, 0xb3, 0xff, 0x0b, 0xa6, 0x84, 0xbd, 0xb2,
-		0x71, 0xb3, 0x91, 0x0e, 0xe9, 0xd5, 0xf7, 0x15, 0x38, 0x1a, 0xd7, 0x26, 0xb9, 0x27, 0x5f, 0xd9,
-		0xab, 0xf5, 0x88, 0x75, 0xd0, 0x3f, 0x50, 0x60, 0x3a, 0xa9, 0xdd, 0x32, 0x6e, 0x36, 0x8f, 0xd5,
-		0x6c, 0x63, 0x91, 0xff, 0x2d, 0x0f, 0x29, 0xbb, 0x7a, 0xd0, 0x3c, 0x8c, 0xd3, 0xc6, 0xa1, 0x70,
-		0x8e, 0xdd, 0x9b, 0xd3, 0x58, 0x03, 0xb7, 0x43, 0x19, 0xf6, 0x48, 0x99, 0x2b, 0xd3, 0x5f, 0x99,
-		0xeb, 0x49, 0x21, 0x4a, 0xbe, 0x10, 0x25, 0xa3, 0x3b, 0x79, 0x09, 0xdd, 0xb9, 0x03, 0x13, 0xac,
-		0x80, 0xc0, 0x30, 0x9a, 0x0d, 0x82, 0xed, 0x6d, 0xbd, 0x96, 0x7c, 0x6f, 0x19, 0x67, 0x8c, 0x14,
-		0x5e, 0x99, 0xb1, 0x05, 0x8b, 0x5c, 0x85, 0x5d, 0x15, 0xb9, 0x7a, 0x42, 0x38, 0x48, 0x13, 0xc2,
-		0x89, 0x2b, 0x5a, 0xc5, 0xbe, 0x2b, 0x5a, 0xdd, 0x7b, 0xc6, 0xb0, 0x7c, 0x21, 0xc1, 0xaf, 0xab,
-		0xec, 0xdb, 0x45, 0x5d, 0x65, 0x64, 0x57, 0x75, 0x15, 0xf5, 0x8f, 0x0a, 0xcc, 0xa7, 0x6d, 0x2d,
-		0xec, 0x78, 0x2b, 0xa5, 0xd7, 0x5b, 0xc5, 0xdd, 0x6f, 0xd6, 0xe1, 0x50, 0xa7, 0x1d, 0x21, 0x54,
-		0xa2, 0xf6, 0xec, 0x78, 0x36, 0xb6, 0xe1, 0x20, 0x58, 0xa4, 0x3e, 0x88, 0x79, 0xc3, 0xa1, 0x3b,
-		0xd4, 0x40, 0x38, 0xe7, 0xf1, 0x4d, 0x85, 0x93, 0x2f, 0x17, 0x1d, 0x3c, 0x32, 0xd6, 0xa3, 0x48,
-		0x58, 0x4f, 0x4f, 0x20, 0x94, 0x49, 0x11, 0x08, 0xa9, 0x1f, 0x29, 0x70, 0x2c, 0xb6, 0x73, 0xde,
-		0x8d, 0x04, 0x59, 0x5f, 0x7e, 0x43, 0xaf, 0xfb, 0x3b, 0x01, 0xde, 0xd0, 0x6d, 0xbd, 0x8e, 0xfb,
-		0xfd, 0xf4, 0x9e, 0x1d, 0x3a, 0x5d, 0x83, 0x18, 0x90, 0xbf, 0x78, 0xff, 0x98, 0xb7, 0x49, 0xa2,
-		0x4e, 0x91, 0x13, 0x50, 0x64, 0xbd, 0x3a, 0xbd, 0x4b, 0xe0, 0x0d, 0xd1, 0x25, 0xe8, 0xf8, 0xfc,
-		0x8c, 0xbc, 0xcf, 0x8f, 0x4b, 0x82, 0x27, 0x68, 0xd8, 0x17, 0x15, 0x98, 0x4d, 0xd1, 0x3c, 0xd5,
-		0xcd, 0xe5, 0x2a, 0x81, 0x5c, 0x6e, 0xbf, 0x1b, 0x17, 0x83, 0x5c, 0xfd, 0x59, 0x06, 0x5e, 0xdc,
-		0x5d, 0x03, 0xf9, 0x9e, 0x99, 0x44, 0x37, 0xd3, 0x97, 0x09, 0x64, 0xfa, 0xee, 0x03, 0x8a, 0x36,
-		0x2a, 0x31, 0xef, 0x70, 0x46, 0xae, 0x10, 0xaa, 0x8d, 0x45, 0xba, 0x8d, 0xd1, 0x24, 0xe4, 0xab,
-		0x56, 0x83, 0xd8, 0x56, 0x8d, 0x6e, 0xd8, 0xb0, 0xe6, 0x3f, 0xa2, 0x12, 0x1c, 0x08, 0xf5, 0xdc,
-		0x59, 0x8d, 0x9a, 0x17, 0xd7, 0x0f, 0x69, 0x63, 0x81, 0x56, 0xb8, 0x3b, 0x8d, 0xda, 0x8e, 0xfa,
-		0x56, 0x16, 0x6e, 0xec, 0xa2, 0x41, 0x1d, 0xdd, 0xef, 0xf5, 0x9a, 0x23, 0x82, 0x9f, 0x7f, 0x48,
-		0x49, 0x0e, 0xe4, 0xb4, 0xf7, 0xe8, 0x36, 0x2a, 0xcc, 0xc0, 0xf2, 0xf7, 0x65, 0x60, 0xb7, 0xfb,
-		0x32, 0x07, 0x28, 0xdc, 0x16, 0xc8, 0xaa, 0x23, 0x59, 0x6d, 0xd4, 0x0c, 0x28, 0xa1, 0x97, 0x00,
-		0xf3, 0x77, 0x31, 0x17, 0xd8, 0x45, 0xf5, 0x77, 0x0a, 0x5c, 0xed, 0xb3, 0xbb, 0x5e, 0x80, 0x41,
-		0x11, 0x60, 0xf8, 0x78, 0x15, 0x57, 0x7d, 0x23, 0x0b, 0x57, 0xfb, 0xec, 0x80, 0xfc, 0x67, 0xb5,
-		0xd5, 0x90, 0x43, 0x1f, 0x10, 0x3b, 0xf4, 0x41, 0x79, 0x87, 0x2e, 0x54, 0x1d, 0x91, 0x03, 0xc8,
-		0x8b, 0x1c, 0xc0, 0xe7, 0xb2, 0x70, 0xb9, 0x9f, 0x2e, 0x4e, 0x39, 0xcb, 0x97, 0x92, 0xfc, 0xc4,
-		0xf2, 0xbb, 0x96, 0xff, 0xa1, 0x02, 0x17, 0xd2, 0x76, 0xa4, 0xfe, 0x43, 0x9b, 0xbc, 0xf8, 0xac,
-		0x52, 0x7f, 0xa5, 0xc0, 0xf9, 0x54, 0x5d, 0xac, 0x7b, 0xe6, 0x02, 0xb8, 0x77, 0x8e, 0xcc, 0xee,
-		0xee, 0x1c, 0xef, 0x14, 0xe0, 0x52, 0x1f, 0x3f, 0xc7, 0xe9, 0xd9, 0x0e, 0x25, 0xb0, 0x1d, 0x27,
-		0xa0, 0xd8, 0xd9, 0x0e, 0xa6, 0xf3, 0x05, 0x0d, 0xfc, 0x21, 0x5e, 0x02, 0x22, 0xbb, 0x07, 0x09,
-		0x88, 0x7e, 0xab, 0x91, 0x83, 0x7b, 0x9b, 0x80, 0xc8, 0x3d, 0xd6, 0x04, 0x44, 0xbe, 0xef, 0x04,
-		0xc4, 0x03, 0x60, 0xcd, 0xc4, 0x4c, 0x22, 0x2b, 0xe2, 0x79, 0x0d, 0x0a, 0x67, 0x62, 0x3a, 0x92,
-		0xa9, 0x14, 0x56, 0xca, 0x1b, 0x6b, 0x86, 0x87, 0x7a, 0x8d, 0xa4, 0x10, 0xf4, 0xe7, 0x32, 0x2a,
-		0x0f, 0x12, 0x2a, 0x5f, 0x85, 0xc9, 0x1e, 0x75, 0xaa, 0xd8, 0xb8, 0xd5, 0x85, 0x5f, 0xa4, 0xf0,
-		0x67, 0x63, 0x15, 0xa7, 0x6c, 0x68, 0x2e, 0x0b, 0x9b, 0xc2, 0xc1, 0x36, 0x6f, 0x38, 0x52, 0xdc,
-		0xdc, 0xd7, 0x4f, 0x71, 0x33, 0xd2, 0x16, 0x3a, 0xc2, 0x69, 0x0b, 0xed, 0x5e, 0xc4, 0xf6, 0xa7,
-		0xcf, 0x4c, 0x8c, 0xee, 0x22, 0x33, 0x31, 0xb6, 0xbb, 0x8e, 0xcf, 0xeb, 0x50, 0x34, 0x70, 0x4d,
-		0xdf, 0xf1, 0x54, 0x33, 0xb9, 0x7d, 0x15, 0x28, 0x35, 0x55, 0x45, 0xf4, 0x3c, 0x0c, 0xff, 0xb7,
-		0x49, 0x88, 0xff, 0xaf, 0x29, 0x3a, 0x8d, 0xab, 0x42, 0xe6, 0xa2, 0x47, 0x4e, 0xb9, 0xd5, 0x37,
-		0xb3, 0x70, 0x21, 0xed, 0x8f, 0xed, 0x3e, 0x79, 0xe7, 0xb4, 0xea, 0x47, 0x19, 0x5e, 0x95, 0xed,
-		0x4a, 0xea, 0x5f, 0x8a, 0x05, 0x82, 0x8b, 0x1e, 0x33, 0x1b, 0x0c, 0x9a, 0x19, 0xff, 0x08, 0xcd,
-		0x09, 0x8e, 0xd0, 0x3d, 0xca, 0x43, 0xaa, 0xbf, 0xcc, 0xc0, 0x5c, 0x9a, 0x5f, 0x12, 0x0a, 0xf7,
-		0x83, 0x7f, 0x76, 0x67, 0x76, 0x7b, 0x76, 0xef, 0xd5, 0x2e, 0xf2, 0x57, 0x77, 0x40, 0xb0, 0xba,
-		0x5d, 0xdb, 0x1e, 0x94, 0x4f, 0xb2, 0x7c, 0x94, 0x81, 0x94, 0xbf, 0x71, 0xfc, 0x74, 0x2c, 0x26,
-		0xaf, 0xa4, 0x34, 0xc8, 0x2d, 0x29, 0x75, 0x7b, 0x21, 0x72, 0xf2, 0xbd, 0x10, 0xea, 0x5f, 0x32,
-		0x70, 0x6e, 0x2f, 0x3c, 0xca, 0xa7, 0x74, 0xd1, 0x7b, 0xb2, 0xfd, 0xb9, 0x14, 0xd9, 0x7e, 0xf5,
-		0xaf, 0x19, 0x38, 0x9f, 0xea, 0x27, 0xa7, 0x4f, 0x16, 0x3e, 0xb2, 0xf0, 0x7e, 0x42, 0x32, 0x97,
-		0x26, 0x89, 0xfd, 0xff, 0x59, 0xd1, 0xc2, 0x8b, 0xfa, 0x57, 0x9e, 0x2c, 0x7c, 0x6c, 0xfb, 0x4c,
-		0xae, 0x9f, 0xae, 0xfd, 0x9f, 0x66, 0x60, 0x3e, 0xe5, 0x4f, 0x81, 0x9f, 0xec, 0x43, 0x60, 0x1f,
-		0x66, 0x09, 0xec, 0xa7, 0x7f, 0xae, 0x98, 0x35, 0x82, 0x6d, 0xfa, 0xa9, 0x63, 0x30, 0xb5, 0xfc,
-		0x60, 0xf9, 0xf6, 0x5a, 0x65, 0xa5, 0xbc, 0xba, 0xb6, 0xac, 0x55, 0xd6, 0xfe, 0xe3, 0xee, 0x72,
-		0xa5, 0x7c, 0xfb, 0xc1, 0xcd, 0xd5, 0xf2, 0xad, 0xd1, 0xa7, 0xd0, 0x09, 0x38, 0x12, 0x7d, 0x7d,
-		0x73, 0x75, 0xb5, 0x42, 0x47, 0x47, 0x15, 0x74, 0x12, 0x8e, 0x45, 0x09, 0x96, 0x56, 0xef, 0xdc,
-		0x5b, 0x66, 0x24, 0x99, 0xc5, 0x57, 0xe1, 0x50, 0xd5, 0xaa, 0xf3, 0xd6, 0x60, 0xd1, 0xff, 0x67,
-		0xb2, 0x77, 0xdd, 0x38, 0xf6, 0xae, 0xf2, 0x9f, 0x17, 0x1f, 0x9a, 0x64, 0xb3, 0xb5, 0x5e, 0xaa,
-		0x5a, 0xf5, 0xf9, 0xde, 0x7f, 0x6a, 0x7b, 0xde, 0x34, 0x6a, 0xf3, 0x0f, 0x2d, 0xef, 0x1f, 0xe9,
-		0xb2, 0xff, 0x70, 0x7b, 0x43, 0x6f, 0x9a, 0xdb, 0x17, 0xd7, 0x73, 0x74, 0xec, 0xd2, 0xdf, 0x03,
-		0x00, 0x00, 0xff, 0xff, 0xbe, 0xbb, 0xb6, 0xc2, 0xc4, 0x57, 0x00, 0x00,
+		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5c, 0x5f, 0x6c, 0x1c, 0x49,
+		0xd1, 0xbf, 0xd9, 0xb5, 0xd7, 0xd9, 0x5a, 0xc7, 0xb1, 0xdb, 0x8e, 0xb3, 0x4e, 0x9c, 0xc4, 0xd9,
+		0xe4, 0x12, 0x9f, 0x63, 0xaf, 0x13, 0x27, 0x97, 0x5c, 0x92, 0xfb, 0xf3, 0xc5, 0x8e, 0xad, 0xac,
+		0xe4, 0x2f, 0xf1, 0x37, 0x71, 0x72, 0xdf, 0xf7, 0xe9, 0xa4, 0xfd, 0xc6, 0x3b, 0xed, 0x78, 0x3e,
+		0xef, 0xee, 0xec, 0xcd, 0xf4, 0x7a, 0x63, 0x04, 0x4f, 0x3c, 0x20, 0x21, 0x4e, 0xc7, 0xe9, 0x84,
+		0xc4, 0x49, 0x9c, 0x40, 0x48, 0x20, 0x0e, 0x90, 0x0e, 0x81, 0x10, 0xff, 0x5e, 0x00, 0x09, 0x1d,
+		0x12, 0xe8, 0xe0, 0x89, 0x17, 0x5e, 0x11, 0xe2, 0xde, 0x78, 0xe0, 0x78, 0x43, 0x42, 0xd3, 0xd3,
+		0xb3, 0xbb, 0x33, 0xd3, 0x3d, 0xd3, 0xb3, 0x76, 0xee, 0x40, 0x97, 0x37, 0x4f, 0x4f, 0x55, 0xcd,
+		0xaf, 0xba, 0xab, 0xaa, 0xab, 0xbb, 0x6a, 0x0d, 0xa7, 0x9a, 0x1b, 0xd8, 0x9a, 0xaf, 0x68, 0x3a,
+		0xae, 0x57, 0xf0, 0xbc, 0xd6, 0x30, 0xe6, 0x77, 0x2e, 0xce, 0x6f, 0x19, 0x36, 0x31, 0xad, 0xdd,
+		0x62, 0xc3, 0x32, 0x89, 0x89, 0x46, 0x1d, 0x92, 0x22, 0x23, 0x29, 0x6a, 0x0d, 0xa3, 0xb8, 0x73,
+		0xf1, 0xe8, 0x89, 0x87, 0xa6, 0xf9, 0xb0, 0x8a, 0xe7, 0x29, 0xc9, 0x46, 0x73, 0x73, 0x5e, 0x6f,
+		0x5a, 0x1a, 0x31, 0xcc, 0xba, 0xcb, 0x74, 0xf4, 0x64, 0xf0, 0x3d, 0x31, 0x6a, 0xd8, 0x26, 0x5a,
+		0xad, 0xc1, 0x08, 0xa6, 0x78, 0x1f, 0xae, 0x98, 0xb5, 0x5a, 0x5b, 0x44, 0x81, 0x47, 0x41, 0x34,
+		0x7b, 0xbb, 0x6a, 0xd8, 0x24, 0x8a, 0xa6, 0x65, 0x5a, 0xdb, 0x9b, 0x55, 0xb3, 0xe5, 0xd2, 0x14,
+		0x6e, 0xc1, 0xc0, 0x6d, 0x57, 0x21, 0x74, 0x0d, 0x32, 0x78, 0x07, 0xd7, 0x89, 0x9d, 0x57, 0xa6,
+		0xd2, 0xd3, 0xb9, 0x85, 0x53, 0x45, 0x8e, 0x6e, 0x45, 0x46, 0xbd, 0xec, 0x50, 0xaa, 0x8c, 0xa1,
+		0xf0, 0xc1, 0x55, 0x18, 0xec, 0x7e, 0x81, 0x26, 0xe0, 0x00, 0x7d, 0x55, 0x36, 0xf4, 0xbc, 0x32,
+		0xa5, 0x4c, 0xa7, 0xd5, 0x01, 0xfa, 0x5c, 0xd2, 0xd1, 0x35, 0x00, 0xf7, 0x95, 0xa3, 0x74, 0x3e,
+		0x35, 0xa5, 0x4c, 0xe7, 0x16, 0x8e, 0x16, 0xdd, 0x19, 0x29, 0x7a, 0x33, 0x52, 0x5c, 0xf7, 0x66,
+		0x44, 0xcd, 0x52, 0x6a, 0xe7, 0x19, 0xe5, 0x61, 0x60, 0x07, 0x5b, 0xb6, 0x61, 0xd6, 0xf3, 0x69,
+		0x57, 0x28, 0x7b, 0x44, 0x47, 0x60, 0xc0, 0x51, 0xde, 0xf9, 0x5c, 0x1f, 0x7d, 0x93, 0x71, 0x1e,
+		0x4b, 0x3a, 0xfa, 0x8a, 0x02, 0xe7, 0x3d, 0x95, 0xcb, 0xf8, 0x11, 0xae, 0x34, 0x9d, 0x75, 0x28,
+		0xdb, 0x44, 0xb3, 0x08, 0xd6, 0xcb, 0x2e, 0x12, 0x8d, 0x10, 0xcb, 0xd8, 0x68, 0x12, 0x6c, 0xe7,
+		0xfb, 0x29, 0x9e, 0xe7, 0xb9, 0xaa, 0xbf, 0xcc, 0xe4, 0x2c, 0x7b, 0x62, 0xee, 0xb9, 0x52, 0xa8,
+		0xca, 0x37, 0xdb, 0x32, 0x6e, 0x3f, 0xa5, 0x9e, 0x6b, 0xc9, 0x91, 0xa2, 0xaf, 0x2b, 0x30, 0xc7,
+		0x81, 0x57, 0x31, 0x6b, 0x8d, 0x2a, 0xe6, 0x02, 0xcc, 0x50, 0x80, 0x2f, 0xca, 0x01, 0x5c, 0xf2,
+		0xe4, 0x84, 0x21, 0x3e, 0xd3, 0x92, 0x25, 0x46, 0x6f, 0x29, 0x30, 0xc3, 0x01, 0xb9, 0xa9, 0x19,
+		0x55, 0x1e, 0xc2, 0x01, 0x8a, 0xf0, 0x86, 0x1c, 0xc2, 0x15, 0x2a, 0x24, 0x0c, 0xef, 0x6c, 0x4b,
+		0x8a, 0x12, 0x7d, 0x8d, 0x3f, 0x81, 0x8e, 0x6d, 0xe9, 0x65, 0xb3, 0x49, 0xc2, 0xf0, 0x0e, 0x50,
+		0x78, 0x2f, 0xc8, 0xc1, 0x73, 0xcc, 0x4e, 0xbf, 0xdb, 0x24, 0x61, 0x80, 0xd3, 0x2d, 0x49, 0x5a,
+		0xf4, 0xa6, 0x02, 0xd3, 0x3a, 0xae, 0x18, 0x36, 0x05, 0xe6, 0x58, 0xa9, 0x5d, 0xd9, 0xc2, 0x7a,
+		0x93, 0x3b, 0x79, 0x59, 0x8a, 0xee, 0x1a, 0x17, 0xdd, 0x2d, 0x26, 0x64, 0x5d, 0xb3, 0xb7, 0xef,
+		0x79, 0x22, 0xc2, 0xc8, 0xce, 0xe8, 0x12, 0x74, 0xe8, 0x35, 0x05, 0xce, 0x06, 0x50, 0x89, 0x7c,
+		0x02, 0x28, 0xa6, 0xab, 0xf1, 0x98, 0x44, 0xee, 0x50, 0xd0, 0x63, 0xa9, 0x38, 0xb3, 0x14, 0xe1,
+		0x04, 0x39, 0xc9, 0x59, 0x8a, 0xb0, 0xff, 0x33, 0xba, 0x04, 0x1d, 0x7a, 0x23, 0x84, 0x2a, 0xc2,
+		0xb2, 0x06, 0x29, 0xaa, 0xe7, 0x62, 0x51, 0x89, 0x8d, 0xea, 0xb4, 0x1e, 0x4f, 0x86, 0x3e, 0xaf,
+		0xc0, 0xd3, 0x7e, 0x4c, 0x22, 0x4f, 0x3c, 0x48, 0x01, 0x5d, 0x89, 0x05, 0x24, 0x72, 0xc2, 0x53,
+		0x7a, 0x1c, 0x11, 0x5d, 0x36, 0xad, 0x42, 0x8c, 0x1d, 0x83, 0xec, 0xc6, 0x1a, 0xf7, 0x50, 0xc4,
+		0xb2, 0xdd, 0x64, 0x42, 0xe2, 0x8c, 0x5b, 0x93, 0xa0, 0xa3, 0xc6, 0x1d, 0x40, 0x25, 0x32, 0xee,
+		0x43, 0x11, 0xc6, 0xed, 0xc3, 0x24, 0x34, 0x6e, 0x2d, 0x96, 0x8a, 0x33, 0x4b, 0x11, 0xc6, 0x3d,
+		0x2c, 0x39, 0x4b, 0x51, 0xc6, 0xad, 0x49, 0xd0, 0x51, 0x43, 0xf2, 0xa3, 0x12, 0x19, 0xd2, 0x48,
+		0x84, 0x21, 0x75, 0x43, 0x12, 0x1a, 0x92, 0x16, 0x47, 0x44, 0x3d, 0xcd, 0x0f, 0x26, 0xc2, 0xd3,
+		0x50, 0x84, 0xa7, 0x75, 0xe3, 0x89, 0xf0, 0x34, 0x2d, 0x9e, 0x0c, 0xb5, 0xe0, 0x84, 0x03, 0xc2,
+		0x12, 0x5b, 0xcf, 0x28, 0x05, 0x72, 0x81, 0x0b, 0xc4, 0x91, 0x6a, 0x09, 0xcd, 0xe6, 0x18, 0x11,
+		0xbf, 0x46, 0xaf, 0xc2, 0xa4, 0xfb, 0xe1, 0x4d, 0xc3, 0xe2, 0x7d, 0x76, 0x8c, 0x7e, 0xb6, 0x28,
+		0xfe, 0xec, 0x8a, 0x61, 0x85, 0xa4, 0xde, 0x7e, 0x4a, 0x9d, 0x20, 0xa2, 0x97, 0xe8, 0x9b, 0x0a,
+		0xcc, 0x07, 0x4c, 0x54, 0xab, 0x57, 0x70, 0xb5, 0x6c, 0xe1, 0x57, 0x9b, 0xd8, 0xe6, 0x6a, 0x7f,
+		0x98, 0xc2, 0x78, 0x29, 0xde, 0x52, 0xa9, 0x24, 0xd5, 0x13, 0x14, 0xc6, 0x35, 0xa3, 0x49, 0x53,
+		0xa3, 0x1f, 0x28, 0x70, 0x99, 0x61, 0xf2, 0x20, 0xca, 0x19, 0xf1, 0x38, 0x45, 0xbb, 0xc4, 0x45,
+		0xcb, 0xbe, 0xe6, 0x7e, 0x5a, 0xc6, 0xa2, 0x8b, 0x56, 0x22, 0x0e, 0xf4, 0x45, 0x05, 0xce, 0xf1,
+		0xa6, 0x97, 0x07, 0xf4, 0x88, 0xa4, 0x75, 0x2f, 0x31, 0x09, 0x31, 0xd6, 0x2d, 0x20, 0x43, 0x9f,
+		0x82, 0x93, 0xae, 0x91, 0x89, 0x91, 0xe4, 0x29, 0x92, 0x8b, 0x62, 0x3b, 0x13, 0x43, 0x98, 0x24,
+		0x11, 0xef, 0xd1, 0xe7, 0x14, 0x38, 0xc3, 0x16, 0x8f, 0x19, 0xba, 0x60, 0xd1, 0x26, 0x28, 0x82,
+		0x67, 0xb9, 0x08, 0x5c, 0xe1, 0xae, 0xbd, 0x0b, 0x96, 0x69, 0xaa, 0x12, 0x43, 0x83, 0x3e, 0x03,
+		0x53, 0x35, 0xcd, 0xda, 0xc6, 0x56, 0xd9, 0xc2, 0x15, 0xd3, 0xd2, 0x79, 0x20, 0x8e, 0x52, 0x10,
+		0x0b, 0x5c, 0x10, 0xff, 0x49, 0x99, 0x55, 0xc6, 0x1b, 0x46, 0x70, 0xbc, 0x16, 0x45, 0x80, 0xbe,
+		0xaa, 0xc0, 0x2c, 0xef, 0x7c, 0x62, 0x3c, 0xac, 0x6b, 0xdc, 0x09, 0x39, 0x96, 0x24, 0x7d, 0xbd,
+		0xc7, 0xc4, 0xc8, 0xa4, 0xaf, 0x02, 0x5a, 0xf4, 0x0d, 0x05, 0x8a, 0x1c, 0x84, 0x04, 0x5b, 0x35,
+		0xa3, 0xae, 0x71, 0xe3, 0xc2, 0x64, 0x44, 0x5c, 0x08, 0xa7, 0xd8, 0x6d, 0x41, 0x9c, 0xb8, 0xd0,
+		0x92, 0xa6, 0x46, 0x3f, 0x54, 0xe0, 0x32, 0xef, 0x28, 0x15, 0x1b, 0xc5, 0x8e, 0x53, 0xb4, 0xb7,
+		0x24, 0x4f, 0x54, 0x71, 0xa1, 0x6c, 0xbe, 0x95, 0x8c, 0x45, 0x64, 0x01, 0x62, 0xa7, 0x3c, 0x91,
+		0xc4, 0x02, 0xc4, 0x0e, 0x3a, 0xdd, 0x92, 0xa4, 0x45, 0x7f, 0x52, 0x60, 0x39, 0x10, 0x71, 0xf1,
+		0x23, 0x82, 0xad, 0xba, 0x56, 0x2d, 0x73, 0x90, 0x1b, 0x75, 0x83, 0x18, 0x7c, 0xc3, 0x38, 0x49,
+		0xa1, 0xdf, 0x8b, 0x0f, 0xc1, 0xcb, 0x4c, 0x7e, 0x48, 0x9f, 0x92, 0x27, 0x3c, 0xac, 0xd0, 0x8b,
+		0xd6, 0x9e, 0x24, 0xa0, 0x3f, 0x2a, 0xb0, 0x98, 0x40, 0x4d, 0x51, 0xc4, 0x9a, 0xa2, 0x3a, 0xae,
+		0xed, 0x41, 0x47, 0x51, 0x30, 0xbb, 0x61, 0xf5, 0xce, 0x8e, 0xde, 0x57, 0xe0, 0x85, 0x28, 0x75,
+		0xe2, 0xfd, 0xe4, 0x14, 0x55, 0x6c, 0x95, 0xab, 0x98, 0x10, 0x4c, 0xac, 0xbf, 0x5c, 0xc5, 0xbd,
+		0xb1, 0xd2, 0x3c, 0x80, 0xa7, 0x87, 0x59, 0x27, 0x46, 0xbd, 0x89, 0xf5, 0xb2, 0x66, 0x97, 0xeb,
+		0xb8, 0x15, 0xd6, 0xa3, 0x10, 0x91, 0x07, 0x84, 0x41, 0x78, 0xe2, 0x6e, 0xda, 0x77, 0x70, 0x2b,
+		0x0c, 0xbf, 0xd8, 0x4a, 0xc4, 0x81, 0x7e, 0xa9, 0xc0, 0x35, 0x9a, 0x4d, 0x96, 0x2b, 0x5b, 0x46,
+		0x55, 0x4f, 0xe8, 0x3f, 0xa7, 0x29, 0xf4, 0xdb, 0x5c, 0xe8, 0x34, 0x95, 0x5c, 0x72, 0x84, 0x26,
+		0x71, 0x9a, 0x4b, 0x76, 0x72, 0x36, 0xf4, 0x13, 0x05, 0xae, 0xc4, 0x28, 0x21, 0xf2, 0x8e, 0x33,
+		0x54, 0x83, 0xe5, 0xa4, 0x1a, 0x88, 0x5c, 0xe2, 0x82, 0x9d, 0x90, 0x07, 0x7d, 0x57, 0x81, 0x8b,
+		0x42, 0xd4, 0xc2, 0x3c, 0xff, 0x69, 0x0a, 0xfb, 0x26, 0x3f, 0x0d, 0xe1, 0x7e, 0x5d, 0x98, 0xf8,
+		0xcf, 0x56, 0x12, 0xd0, 0xa3, 0xef, 0x2b, 0x70, 0x49, 0x08, 0x37, 0xe2, 0x10, 0x79, 0x36, 0xc2,
+		0xc8, 0xf9, 0x80, 0x23, 0x8e, 0x93, 0xc5, 0x4a, 0x22, 0x0e, 0xf4, 0x8e, 0x02, 0x17, 0x12, 0x5b,
+		0xc6, 0x39, 0x8a, 0xf8, 0x3f, 0x12, 0x20, 0x16, 0x19, 0xc5, 0xf9, 0x4a, 0x02, 0x7b, 0x78, 0x57,
+		0x81, 0x05, 0xf1, 0x04, 0x0b, 0x37, 0xe1, 0x69, 0x8a, 0x76, 0x31, 0xc9, 0xfc, 0x0a, 0x77, 0xe2,
+		0xb9, 0x4a, 0x12, 0x06, 0xf4, 0xbd, 0x28, 0x93, 0x88, 0x38, 0x34, 0x3f, 0x93, 0x18, 0xb2, 0xf8,
+		0xf8, 0x3c, 0x57, 0x49, 0xc2, 0x40, 0x73, 0x33, 0x31, 0xe4, 0x88, 0x4c, 0x72, 0x26, 0x22, 0x37,
+		0x13, 0x60, 0x8e, 0x48, 0x27, 0xe7, 0x2b, 0xc9, 0x58, 0xe8, 0xa6, 0xe9, 0xa6, 0xe2, 0xbd, 0x66,
+		0x3c, 0xe7, 0x23, 0x36, 0x4d, 0x37, 0xe3, 0xee, 0x25, 0xd5, 0xb9, 0x6a, 0xf7, 0xc6, 0x8a, 0x7e,
+		0xa5, 0xc0, 0x75, 0x09, 0x85, 0x44, 0x3e, 0x3a, 0x4b, 0xb5, 0x29, 0xf5, 0xa2, 0x8d, 0xc8, 0x59,
+		0x2f, 0xdb, 0x3d, 0xf0, 0xa1, 0x1f, 0x2b, 0xf0, 0x6c, 0x94, 0x02, 0xe2, 0xf3, 0xd3, 0x5c, 0xc4,
+		0x06, 0x24, 0x04, 0x21, 0x3e, 0x47, 0x5d, 0xc0, 0x09, 0x79, 0x68, 0xc0, 0x69, 0x36, 0x6c, 0x6c,
+		0x91, 0x0e, 0x70, 0x1b, 0x6b, 0x56, 0x65, 0xab, 0x0b, 0x66, 0x18, 0x77, 0x31, 0xc2, 0x7b, 0xef,
+		0x53, 0x71, 0x1e, 0x82, 0x7b, 0x54, 0x58, 0xe7, 0x8b, 0x1c, 0xef, 0x6d, 0x26, 0x61, 0x58, 0x1c,
+		0x04, 0xe8, 0x00, 0x29, 0xbc, 0x3e, 0x04, 0xe7, 0x64, 0x77, 0xaf, 0x15, 0x38, 0xd8, 0xd6, 0x91,
+		0xec, 0x36, 0x30, 0xad, 0x05, 0x8a, 0x2a, 0x8b, 0x9e, 0xd0, 0xf5, 0xdd, 0x06, 0x56, 0x07, 0x5b,
+		0x5d, 0x4f, 0xe8, 0x15, 0x38, 0xdc, 0xd0, 0x2c, 0x67, 0x46, 0xba, 0x9d, 0x6e, 0xd3, 0x64, 0xe5,
+		0xc3, 0x69, 0xae, 0xbc, 0x35, 0xca, 0xd1, 0xe5, 0x13, 0x9b, 0xa6, 0x3a, 0xda, 0x08, 0x0f, 0xa2,
+		0xeb, 0x90, 0xa5, 0x37, 0x32, 0x55, 0xc3, 0x26, 0xb4, 0xb0, 0x98, 0x5b, 0x38, 0xce, 0xbf, 0xf2,
+		0xd0, 0xec, 0xed, 0x55, 0xc3, 0x26, 0xea, 0x01, 0xc2, 0xfe, 0x42, 0x0b, 0xd0, 0x6f, 0xd4, 0x1b,
+		0x4d, 0x42, 0xcb, 0x8e, 0xb9, 0x85, 0x49, 0x01, 0x92, 0xdd, 0xaa, 0xa9, 0xe9, 0xaa, 0x4b, 0x8a,
+		0x34, 0x98, 0x0a, 0xa4, 0x1c, 0x65, 0x62, 0x96, 0x2b, 0x55, 0xd3, 0xc6, 0x34, 0x7e, 0x9b, 0x4d,
+		0xc2, 0xea, 0x90, 0x13, 0xa1, 0xba, 0xe8, 0x2d, 0x56, 0x49, 0x56, 0x27, 0xb1, 0x6f, 0xee, 0xd7,
+		0xcd, 0x25, 0x87, 0x7f, 0xdd, 0x65, 0x47, 0x2f, 0xc3, 0xb1, 0xce, 0xb5, 0x77, 0x58, 0x7a, 0x26,
+		0x4e, 0xfa, 0x11, 0xe2, 0x5d, 0x66, 0x07, 0x04, 0xdf, 0x80, 0xa3, 0x9d, 0x0c, 0xbb, 0xa3, 0x85,
+		0xd5, 0xac, 0x3b, 0xb5, 0x57, 0xa7, 0xf4, 0x97, 0x55, 0x8f, 0xb4, 0x29, 0xda, 0xf3, 0xac, 0x36,
+		0xeb, 0x25, 0x1d, 0x95, 0x20, 0xcb, 0x42, 0xa5, 0x69, 0xd1, 0x3a, 0xdc, 0xd0, 0xc2, 0x79, 0x7e,
+		0x68, 0x67, 0x02, 0x68, 0x0a, 0x5d, 0xf2, 0x58, 0xd4, 0x0e, 0x37, 0x2a, 0xc1, 0x48, 0x07, 0x87,
+		0x13, 0xae, 0x9a, 0x16, 0xce, 0x67, 0x23, 0xd6, 0x60, 0xc5, 0xa5, 0x51, 0x87, 0xdb, 0x6c, 0x6c,
+		0x04, 0xa9, 0x30, 0x5e, 0xd5, 0x9c, 0x33, 0x9f, 0x9b, 0xce, 0x50, 0x75, 0xb0, 0xdd, 0xac, 0x92,
+		0x3c, 0x44, 0xc8, 0xf3, 0xd6, 0x74, 0xcc, 0xe1, 0x5d, 0x6a, 0xb3, 0xaa, 0x94, 0x13, 0x5d, 0x83,
+		0x09, 0xd3, 0x32, 0x1e, 0x1a, 0x6e, 0xa0, 0x0d, 0xcc, 0x52, 0x8e, 0xce, 0xd2, 0xb8, 0x47, 0x10,
+		0x98, 0xa4, 0xa3, 0x70, 0xc0, 0xd0, 0x71, 0x9d, 0x18, 0x64, 0x97, 0x56, 0x94, 0xb2, 0x6a, 0xfb,
+		0x19, 0x5d, 0x82, 0xf1, 0x4d, 0xc3, 0xb2, 0x49, 0x58, 0xe6, 0x41, 0x4a, 0x39, 0x4a, 0xdf, 0x06,
+		0x04, 0x2e, 0xc1, 0xa0, 0x85, 0x89, 0xb5, 0x5b, 0x6e, 0x98, 0x55, 0xa3, 0xb2, 0xcb, 0xaa, 0x30,
+		0x53, 0x82, 0x03, 0x2a, 0xb1, 0x76, 0xd7, 0x28, 0x9d, 0x9a, 0xb3, 0x3a, 0x0f, 0x4e, 0xe9, 0x5d,
+		0x23, 0x04, 0xd7, 0x1a, 0x84, 0x56, 0x4c, 0xfa, 0x55, 0xef, 0x11, 0x2d, 0xc1, 0x21, 0xfc, 0xa8,
+		0x61, 0xb8, 0x86, 0xe3, 0x16, 0xf5, 0x87, 0x63, 0x8b, 0xfa, 0x43, 0x1d, 0x16, 0x67, 0x10, 0x9d,
+		0x86, 0x83, 0x15, 0xcb, 0xf1, 0x06, 0x56, 0xd1, 0xa1, 0x15, 0x87, 0xac, 0x3a, 0xe8, 0x0c, 0x7a,
+		0x55, 0x1e, 0xf4, 0xdf, 0x70, 0xcc, 0xd5, 0xde, 0x5f, 0xfd, 0xda, 0xd0, 0x2a, 0xdb, 0xe6, 0xe6,
+		0x66, 0x1e, 0xc5, 0x19, 0x75, 0x9e, 0x72, 0x77, 0x17, 0xbe, 0x16, 0x5d, 0x56, 0x34, 0x07, 0x7d,
+		0x35, 0x5c, 0x33, 0xd9, 0x75, 0xfe, 0x04, 0xff, 0xa2, 0x0f, 0xd7, 0x4c, 0x95, 0x92, 0x21, 0x15,
+		0x46, 0x42, 0x11, 0x9b, 0xdd, 0xc9, 0x3f, 0xcd, 0xdf, 0x1b, 0x03, 0x11, 0x56, 0x1d, 0xb6, 0x03,
+		0x23, 0xe8, 0x3e, 0x8c, 0x37, 0x2c, 0xbc, 0x53, 0xd6, 0x9a, 0xc4, 0x74, 0xec, 0x0f, 0x93, 0x72,
+		0xc3, 0x34, 0xea, 0xc4, 0xbb, 0x65, 0x17, 0xad, 0x97, 0x8d, 0xc9, 0x1a, 0xa5, 0x53, 0x47, 0x1d,
+		0xfe, 0x9b, 0x4d, 0x62, 0x76, 0x0d, 0xa2, 0x4b, 0x90, 0xd9, 0xc2, 0x9a, 0x8e, 0x2d, 0x76, 0xfd,
+		0x7d, 0x8c, 0xdf, 0xd4, 0x41, 0x49, 0x54, 0x46, 0x8a, 0x56, 0x61, 0xcc, 0x9d, 0xe8, 0x4e, 0x2d,
+		0x8f, 0xae, 0xeb, 0x91, 0xd8, 0x75, 0x45, 0x94, 0xaf, 0x5d, 0x97, 0xa3, 0x6b, 0xfb, 0x69, 0x18,
+		0x6e, 0x68, 0x16, 0x31, 0xbc, 0xe3, 0xf9, 0xa6, 0xf1, 0x30, 0x9f, 0xa7, 0x1d, 0x26, 0xff, 0xb5,
+		0x97, 0x36, 0x8b, 0xe2, 0x9a, 0x27, 0x74, 0x89, 0xca, 0x5c, 0xae, 0x13, 0x6b, 0x57, 0x3d, 0xd4,
+		0xf0, 0x8f, 0xa2, 0xe3, 0x00, 0xde, 0xa5, 0x8e, 0xa1, 0xd3, 0xeb, 0xe4, 0xac, 0x9a, 0x65, 0x23,
+		0x25, 0xfd, 0xe8, 0x22, 0x8c, 0xf1, 0xe4, 0xa0, 0x61, 0x48, 0x6f, 0xe3, 0x5d, 0xba, 0x5f, 0x65,
+		0x55, 0xe7, 0x4f, 0x34, 0x06, 0xfd, 0x3b, 0x5a, 0xb5, 0xe9, 0xb6, 0xac, 0x64, 0x55, 0xf7, 0xe1,
+		0x7a, 0xea, 0x39, 0xa5, 0xf0, 0x8e, 0x02, 0xcf, 0xc8, 0x1f, 0x8e, 0x2e, 0x43, 0x86, 0x85, 0x17,
+		0x45, 0x22, 0xbc, 0x30, 0x5a, 0xb4, 0x02, 0x53, 0xd1, 0xd5, 0x71, 0x43, 0xa7, 0xc0, 0xd2, 0xea,
+		0xa4, 0xb8, 0xb0, 0x5d, 0xd2, 0x0b, 0xdf, 0x52, 0xe0, 0xac, 0x64, 0x8e, 0x75, 0x05, 0x06, 0xbc,
+		0xc0, 0xaa, 0x48, 0x04, 0x56, 0x8f, 0x78, 0xdf, 0xa0, 0x9a, 0x30, 0x2d, 0x7d, 0xc0, 0x58, 0x82,
+		0x41, 0xb6, 0xb7, 0x75, 0xf2, 0x8c, 0x21, 0x81, 0xcf, 0xb0, 0xad, 0x8c, 0xa6, 0x19, 0x39, 0xd2,
+		0x79, 0x28, 0xfc, 0x56, 0x81, 0x33, 0x32, 0x3d, 0x16, 0xfe, 0x84, 0x41, 0x49, 0x96, 0x30, 0xdc,
+		0x81, 0x71, 0xc1, 0xa6, 0x9c, 0x8a, 0x8b, 0x5f, 0xa3, 0x36, 0x67, 0x43, 0xee, 0x0a, 0xcc, 0x69,
+		0x5f, 0x60, 0x2e, 0xbc, 0xa6, 0x40, 0x21, 0xbe, 0x3d, 0x03, 0xcd, 0x02, 0x0a, 0x96, 0xec, 0xdb,
+		0x4d, 0x5b, 0xc3, 0xb6, 0x6f, 0x0a, 0x02, 0xbb, 0x53, 0x2a, 0xb0, 0x3b, 0xf9, 0x5d, 0x2d, 0x1d,
+		0x70, 0xb5, 0xc2, 0x5f, 0x03, 0xd3, 0x2b, 0xf4, 0x90, 0x64, 0x88, 0xa6, 0x61, 0xd8, 0x7f, 0x6d,
+		0xd3, 0x36, 0xaf, 0x21, 0xbb, 0x4b, 0xe3, 0x00, 0xf6, 0x74, 0x00, 0xfb, 0x39, 0x38, 0xb4, 0x61,
+		0xd4, 0x35, 0x6b, 0xb7, 0x5c, 0xd9, 0xc2, 0x95, 0x6d, 0xbb, 0x59, 0xa3, 0x19, 0x5d, 0x56, 0x1d,
+		0x72, 0x87, 0x97, 0xd8, 0x28, 0x3a, 0x0f, 0x23, 0xfe, 0xcb, 0x46, 0xfc, 0xc8, 0xcd, 0xd6, 0x06,
+		0xd5, 0x61, 0xdc, 0x7d, 0x07, 0x88, 0x1f, 0x91, 0xc2, 0x77, 0xd2, 0x70, 0x5a, 0xa2, 0xf3, 0xe3,
+		0xb1, 0x69, 0x1c, 0x74, 0x8b, 0x74, 0x0f, 0x6e, 0x81, 0x4e, 0x40, 0x6e, 0x43, 0xb3, 0xb1, 0x97,
+		0x69, 0xb8, 0xd3, 0x92, 0x75, 0x86, 0xdc, 0xfc, 0x62, 0x12, 0xc0, 0xb9, 0x67, 0x65, 0xaf, 0xfb,
+		0xdd, 0x89, 0xad, 0xe3, 0x96, 0xfb, 0x76, 0x16, 0xd0, 0xa6, 0x69, 0x6d, 0x33, 0xa4, 0x5e, 0xfb,
+		0x5e, 0xc6, 0x55, 0xcd, 0x79, 0x43, 0xb1, 0x3e, 0x70, 0xc7, 0xd1, 0xb8, 0x13, 0x1c, 0x35, 0xdb,
+		0xac, 0xb3, 0x54, 0x92, 0x3d, 0xa1, 0x5b, 0xd0, 0x5f, 0xd1, 0x9a, 0x36, 0x66, 0x59, 0x63, 0x51,
+		0xba, 0xc7, 0x66, 0xc9, 0xe1, 0x52, 0x5d, 0xe6, 0x80, 0x81, 0x66, 0x83, 0x06, 0xfa, 0x5e, 0x1a,
+		0x4e, 0xc5, 0xb6, 0xc5, 0x3c, 0xb6, 0xb5, 0x5a, 0xf4, 0x54, 0x74, 0x17, 0x69, 0x56, 0xb2, 0x6b,
+		0xc7, 0xa7, 0x60, 0x57, 0xc8, 0xee, 0x4b, 0x12, 0xb2, 0xbb, 0x3d, 0xa3, 0x3f, 0xe0, 0x19, 0x81,
+		0xe5, 0xcf, 0x44, 0x2f, 0xff, 0x80, 0xd4, 0xf2, 0x1f, 0x10, 0x2c, 0x3f, 0xc7, 0x0b, 0xb3, 0x5c,
+		0x2f, 0xf4, 0xaf, 0x24, 0x04, 0x57, 0xf2, 0xed, 0x0c, 0x9c, 0x91, 0x69, 0x28, 0x42, 0x27, 0x21,
+		0xd7, 0xae, 0xca, 0xb3, 0x55, 0xcc, 0xaa, 0xe0, 0x0d, 0x95, 0x74, 0xe7, 0x04, 0xdb, 0x26, 0xa0,
+		0x2e, 0x94, 0x8a, 0x38, 0xc1, 0xb6, 0x3f, 0x49, 0x4f, 0xb0, 0x5a, 0xd7, 0x93, 0x63, 0xd8, 0xba,
+		0x59, 0xd3, 0x8c, 0x3a, 0x8b, 0x3c, 0xec, 0xc9, 0xbf, 0x95, 0xf4, 0xf5, 0x78, 0xf6, 0xcc, 0xc8,
+		0x9f, 0x3d, 0xd7, 0x61, 0xc2, 0xb3, 0xd1, 0xf0, 0x0e, 0x34, 0x10, 0xb7, 0x03, 0x8d, 0x7b, 0xbc,
+		0x81, 0x4d, 0x28, 0x20, 0x95, 0x6d, 0x70, 0x4c, 0xea, 0x81, 0x04, 0x52, 0xdd, 0x23, 0x27, 0x93,
+		0x2a, 0xde, 0x2a, 0xb3, 0x3d, 0x6d, 0x95, 0x2b, 0x30, 0xb2, 0x85, 0x35, 0x8b, 0x6c, 0x60, 0xad,
+		0x83, 0x0e, 0xe2, 0x44, 0x0d, 0xb7, 0x79, 0x3a, 0x72, 0xe2, 0x13, 0x9c, 0x5c, 0x7c, 0x82, 0x13,
+		0x3a, 0x98, 0x0d, 0xf6, 0x72, 0x30, 0xeb, 0x24, 0xf8, 0x07, 0xa5, 0x13, 0xfc, 0xc2, 0x5f, 0x14,
+		0x28, 0xc4, 0x37, 0xb7, 0x7d, 0x64, 0xa9, 0x41, 0x77, 0x12, 0xd3, 0xe7, 0x3f, 0x5d, 0xbe, 0x04,
+		0x83, 0xf4, 0x70, 0xee, 0x85, 0xb5, 0x7e, 0x89, 0xb0, 0x96, 0x73, 0x38, 0xd8, 0x43, 0xe1, 0xf7,
+		0x8a, 0x3f, 0x14, 0xec, 0x73, 0x5e, 0xce, 0x9f, 0xa2, 0x54, 0x82, 0xdd, 0x20, 0x1d, 0x9b, 0xab,
+		0xf4, 0xf9, 0x27, 0xb3, 0xf0, 0x3b, 0x05, 0x4e, 0xc5, 0x77, 0x1c, 0xf5, 0x9a, 0xbe, 0x7f, 0x1c,
+		0x1a, 0xfd, 0x2c, 0x05, 0xa7, 0x25, 0xfa, 0xf6, 0x1c, 0x9d, 0x74, 0x4c, 0x34, 0xa3, 0x6a, 0x4b,
+		0x2d, 0x92, 0x47, 0xfc, 0xd8, 0x74, 0x0a, 0xe6, 0x57, 0x7d, 0xbd, 0xe4, 0x57, 0x7b, 0x36, 0xf1,
+		0x2f, 0x29, 0x30, 0x23, 0xdf, 0x6e, 0x27, 0xb3, 0xe7, 0xed, 0xcf, 0x01, 0xee, 0x5d, 0x05, 0x12,
+		0x36, 0xd6, 0xc5, 0x63, 0x1b, 0xf3, 0xb2, 0x24, 0x76, 0x0a, 0xa7, 0x0f, 0x52, 0x88, 0xd3, 0x12,
+		0x88, 0xdf, 0x0a, 0xd8, 0xa1, 0xa8, 0x04, 0xd7, 0xab, 0x1d, 0xae, 0xc0, 0x54, 0x55, 0x23, 0x5d,
+		0x0d, 0x26, 0xc1, 0x76, 0x8b, 0xce, 0xcc, 0xba, 0x74, 0xbc, 0xa5, 0x74, 0xb3, 0x2a, 0x8e, 0x3d,
+		0xa7, 0x13, 0xd8, 0x73, 0x5f, 0xac, 0x8f, 0x06, 0xf2, 0xc0, 0xc2, 0xfb, 0x0a, 0x1c, 0x8b, 0x68,
+		0x69, 0x75, 0x7e, 0xf2, 0xe3, 0xb6, 0xf2, 0xb5, 0xd7, 0x6d, 0x80, 0x3e, 0x97, 0x74, 0xb4, 0x0a,
+		0x87, 0xdb, 0x1b, 0xf9, 0xa6, 0x61, 0x25, 0x38, 0xf2, 0x22, 0xb6, 0x8f, 0x3b, 0x2d, 0xab, 0x49,
+		0xb6, 0x5f, 0x99, 0xc5, 0xfe, 0x3f, 0x98, 0x10, 0xf6, 0xca, 0x46, 0x69, 0x23, 0x9d, 0xd2, 0x17,
+		0xde, 0x53, 0x60, 0x32, 0xaa, 0x4d, 0x72, 0x5f, 0xbe, 0xb2, 0x5f, 0xf3, 0x11, 0x19, 0xa0, 0x7f,
+		0xa4, 0xc0, 0x54, 0x5c, 0xbb, 0x65, 0x94, 0x36, 0x8f, 0xd5, 0x6d, 0x23, 0x91, 0xff, 0x63, 0x00,
+		0x12, 0x76, 0xf5, 0xa0, 0x79, 0x18, 0xa3, 0x8d, 0x43, 0xc1, 0x3b, 0x76, 0x57, 0xa7, 0x91, 0x3a,
+		0x6e, 0x05, 0x6e, 0xd8, 0x43, 0x65, 0xae, 0x54, 0x6f, 0x65, 0xae, 0x27, 0x85, 0x28, 0xf9, 0x42,
+		0x94, 0x8c, 0xed, 0x0c, 0x48, 0xd8, 0xce, 0x5d, 0x18, 0x67, 0x05, 0x04, 0x86, 0xd1, 0xa8, 0x13,
+		0x6c, 0xed, 0x68, 0xd5, 0xf8, 0x73, 0xcb, 0x18, 0x63, 0xa4, 0xf0, 0x4a, 0x8c, 0xcd, 0x5f, 0xe4,
+		0xca, 0xee, 0xa9, 0xc8, 0xd5, 0x95, 0xc2, 0x41, 0x92, 0x14, 0x4e, 0x5c, 0xd1, 0xca, 0xf5, 0x5c,
+		0xd1, 0xea, 0x9c, 0x33, 0x06, 0xe5, 0x0b, 0x09, 0x5e, 0x5d, 0xe5, 0xe0, 0x1e, 0xea, 0x2a, 0x43,
+		0x7b, 0xaa, 0xab, 0x14, 0xfe, 0xac, 0xc0, 0x7c, 0xd2, 0xd6, 0xc2, 0x76, 0xb4, 0x52, 0xba, 0xa3,
+		0x55, 0xd4, 0xf9, 0x66, 0x03, 0x8e, 0xb4, 0xdb, 0x11, 0x02, 0x25, 0x6a, 0xd7, 0x8f, 0x67, 0x22,
+		0x1b, 0x0e, 0xfc, 0x45, 0xea, 0xc3, 0x98, 0x37, 0x1c, 0x38, 0x43, 0xf5, 0x05, 0xef, 0x3c, 0xbe,
+		0xad, 0xc0, 0xb4, 0x40, 0x51, 0x5e, 0x61, 0x3e, 0xde, 0x7b, 0x14, 0x09, 0xef, 0xe9, 0x4a, 0x84,
+		0x52, 0x09, 0x12, 0xa1, 0xc2, 0x87, 0x0a, 0x1c, 0x8f, 0xec, 0x9c, 0x77, 0x32, 0x41, 0xd6, 0x97,
+		0x5f, 0xd7, 0x6a, 0xde, 0x4a, 0x80, 0x3b, 0x74, 0x47, 0xab, 0xe1, 0x5e, 0x3f, 0xbd, 0x6f, 0x9b,
+		0x4e, 0xc7, 0x21, 0xfa, 0xe4, 0x0f, 0xde, 0x3f, 0xe5, 0x2d, 0x92, 0xa8, 0x53, 0xe4, 0x24, 0xe4,
+		0x58, 0xaf, 0x4e, 0xf7, 0x14, 0xb8, 0x43, 0x74, 0x0a, 0xda, 0x31, 0x3f, 0x25, 0x1f, 0xf3, 0xa3,
+		0x2e, 0xc1, 0x63, 0x2c, 0xec, 0xcb, 0x0a, 0xcc, 0x24, 0x68, 0x9e, 0xea, 0xdc, 0xe5, 0x2a, 0xbe,
+		0xbb, 0xdc, 0x5e, 0x17, 0x2e, 0x02, 0x79, 0xe1, 0x17, 0x29, 0x78, 0x71, 0x6f, 0x0d, 0xe4, 0xfb,
+		0xe6, 0x12, 0x9d, 0x9b, 0xbe, 0x94, 0xef, 0xa6, 0xef, 0x3e, 0xa0, 0x70, 0xa3, 0x12, 0x8b, 0x0e,
+		0x67, 0xe5, 0x0a, 0xa1, 0xea, 0x48, 0xa8, 0xdb, 0xd8, 0xb9, 0x3a, 0xa9, 0x98, 0x75, 0x62, 0x99,
+		0x55, 0xba, 0x60, 0x83, 0xaa, 0xf7, 0x88, 0x8a, 0x30, 0x1a, 0xe8, 0xb9, 0x33, 0xeb, 0x55, 0x37,
+		0xaf, 0x3f, 0xa0, 0x8e, 0xf8, 0x5a, 0xe1, 0xee, 0xd6, 0xab, 0xbb, 0x85, 0x37, 0xd3, 0x70, 0x63,
+		0x0f, 0x0d, 0xea, 0xe8, 0x7e, 0x77, 0xd4, 0x1c, 0x12, 0xfc, 0xfc, 0x43, 0x4a, 0xb2, 0xef, 0x4e,
+		0x7b, 0x9f, 0x4e, 0xa3, 0xc2, 0x1b, 0x58, 0xfe, 0xba, 0xf4, 0xed, 0x75, 0x5d, 0x66, 0x01, 0x05,
+		0xdb, 0x02, 0x59, 0x75, 0x24, 0xad, 0x0e, 0x1b, 0x3e, 0x23, 0x74, 0x2f, 0xc0, 0xbc, 0x55, 0xcc,
+		0xf8, 0x56, 0xb1, 0xf0, 0x07, 0x05, 0xae, 0xf6, 0xd8, 0x5d, 0x2f, 0xc0, 0xa0, 0x08, 0x30, 0x7c,
+		0xb4, 0x86, 0x5b, 0x78, 0x3d, 0x0d, 0x57, 0x7b, 0xec, 0x80, 0xfc, 0x77, 0xf5, 0xd5, 0x40, 0x40,
+		0xef, 0x13, 0x07, 0xf4, 0x7e, 0xf9, 0x80, 0x2e, 0x34, 0x1d, 0x51, 0x00, 0x18, 0x10, 0x05, 0x80,
+		0x2f, 0xa4, 0xe1, 0x72, 0x2f, 0x5d, 0x9c, 0x72, 0x9e, 0x2f, 0x25, 0xf9, 0x89, 0xe7, 0x77, 0x3c,
+		0xff, 0x03, 0x05, 0x2e, 0x24, 0xed, 0x48, 0xfd, 0x97, 0x76, 0x79, 0xf1, 0x5e, 0x55, 0xf8, 0x8d,
+		0x02, 0x73, 0x89, 0xba, 0x58, 0xf7, 0x2d, 0x04, 0x70, 0xcf, 0x1c, 0xa9, 0xbd, 0x9d, 0x39, 0xde,
+		0xce, 0xc2, 0xa5, 0x1e, 0x7e, 0x8e, 0xd3, 0xb5, 0x1c, 0x8a, 0x6f, 0x39, 0x4e, 0x42, 0xae, 0xbd,
+		0x1c, 0xcc, 0xe6, 0xb3, 0x2a, 0x78, 0x43, 0xbc, 0x0b, 0x88, 0xf4, 0x3e, 0x5c, 0x40, 0xf4, 0x5a,
+		0x8d, 0xec, 0xdf, 0xdf, 0x0b, 0x88, 0xcc, 0x63, 0xbd, 0x80, 0x18, 0xe8, 0xf9, 0x02, 0xe2, 0x01,
+		0xb0, 0x66, 0x62, 0x26, 0x91, 0x15, 0xf1, 0xdc, 0x06, 0x85, 0xb3, 0x11, 0x1d, 0xc9, 0x54, 0x0a,
+		0x2b, 0xe5, 0x8d, 0x34, 0x82, 0x43, 0xdd, 0x4e, 0x92, 0xf5, 0xc7, 0x73, 0x19, 0x93, 0x07, 0x09,
+		0x93, 0xaf, 0x40, 0xbe, 0xcb, 0x9c, 0xca, 0x16, 0x6e, 0x76, 0xe0, 0xe7, 0x28, 0xfc, 0x99, 0x48,
+		0xc3, 0x29, 0xe9, 0x2a, 0x6e, 0x7a, 0x78, 0xd5, 0xc3, 0x2d, 0xde, 0x70, 0xa8, 0xb8, 0x79, 0xb0,
+		0x97, 0xe2, 0x66, 0xa8, 0x2d, 0x74, 0x88, 0xd3, 0x16, 0xda, 0x39, 0x88, 0x1d, 0x4a, 0x7e, 0x33,
+		0x31, 0xbc, 0x87, 0x9b, 0x89, 0x91, 0xbd, 0x75, 0x7c, 0x5e, 0x87, 0x9c, 0x8e, 0xab, 0xda, 0xae,
+		0x6b, 0x9a, 0xf1, 0xed, 0xab, 0x40, 0xa9, 0xa9, 0x29, 0xa2, 0xe7, 0x61, 0xf0, 0xff, 0x0d, 0x42,
+		0xbc, 0x7f, 0x4d, 0x91, 0x1f, 0x8d, 0x63, 0xce, 0xb9, 0xe4, 0x94, 0xbb, 0xf0, 0x46, 0x1a, 0x2e,
+		0x24, 0xfd, 0xb1, 0xdd, 0xc7, 0x1f, 0x9c, 0x56, 0xbd, 0x2c, 0xc3, 0xad, 0xb2, 0x5d, 0x49, 0xfc,
+		0x4b, 0x31, 0x5f, 0x72, 0xd1, 0xe5, 0x66, 0xfd, 0x7e, 0x37, 0xe3, 0x6f, 0xa1, 0x19, 0xc1, 0x16,
+		0xba, 0x4f, 0xf7, 0x90, 0x85, 0x5f, 0xa7, 0x60, 0x36, 0xc9, 0x2f, 0x09, 0x85, 0xeb, 0xc1, 0xdf,
+		0xbb, 0x53, 0x7b, 0xdd, 0xbb, 0xf7, 0x6b, 0x15, 0xf9, 0xb3, 0xdb, 0x27, 0x98, 0xdd, 0x8e, 0x6f,
+		0xf7, 0xcb, 0x5f, 0xb2, 0x7c, 0x98, 0x82, 0x84, 0xbf, 0x71, 0xfc, 0x64, 0x4c, 0x26, 0xaf, 0xa4,
+		0xd4, 0xcf, 0x2d, 0x29, 0x75, 0x7a, 0x21, 0x32, 0xf2, 0xbd, 0x10, 0x85, 0xbf, 0xa5, 0xe0, 0xfc,
+		0x7e, 0x44, 0x94, 0x4f, 0xe8, 0xa4, 0x77, 0xdd, 0xf6, 0x67, 0x12, 0xdc, 0xf6, 0x17, 0xfe, 0x9e,
+		0x82, 0xb9, 0x44, 0x3f, 0x39, 0x7d, 0x32, 0xf1, 0xa1, 0x89, 0xf7, 0x2e, 0x24, 0x33, 0x49, 0x2e,
+		0xb1, 0x3f, 0x9b, 0x16, 0x4d, 0xbc, 0xa8, 0x7f, 0xe5, 0xc9, 0xc4, 0x47, 0xb6, 0xcf, 0x64, 0x7a,
+		0xe9, 0xda, 0xff, 0x79, 0x0a, 0xe6, 0x13, 0xfe, 0x14, 0xf8, 0xc9, 0x3a, 0xf8, 0xd6, 0x61, 0x86,
+		0xc0, 0x21, 0xfa, 0xe7, 0x8a, 0x51, 0x25, 0xd8, 0xa2, 0x9f, 0x3a, 0x0e, 0x13, 0xcb, 0x0f, 0x96,
+		0xef, 0xac, 0x97, 0x57, 0x4a, 0xab, 0xeb, 0xcb, 0x6a, 0x79, 0xfd, 0x7f, 0xd6, 0x96, 0xcb, 0xa5,
+		0x3b, 0x0f, 0x6e, 0xae, 0x96, 0x6e, 0x0d, 0x3f, 0x85, 0x4e, 0xc2, 0xb1, 0xf0, 0xeb, 0x9b, 0xab,
+		0xab, 0x65, 0x3a, 0x3a, 0xac, 0xa0, 0x53, 0x70, 0x3c, 0x4c, 0xb0, 0xb4, 0x7a, 0xf7, 0xde, 0x32,
+		0x23, 0x49, 0x2d, 0xbe, 0x02, 0x47, 0x2a, 0x66, 0x8d, 0x37, 0x07, 0x8b, 0xde, 0x3f, 0x93, 0x5d,
+		0xb3, 0x4c, 0x62, 0xae, 0x29, 0xff, 0x7b, 0xf1, 0xa1, 0x41, 0xb6, 0x9a, 0x1b, 0xc5, 0x8a, 0x59,
+		0x9b, 0xef, 0xfe, 0xa7, 0xb6, 0x73, 0x86, 0x5e, 0x9d, 0x7f, 0x68, 0xba, 0xff, 0x48, 0x97, 0xfd,
+		0x87, 0xdb, 0x1b, 0x5a, 0xc3, 0xd8, 0xb9, 0xb8, 0x91, 0xa1, 0x63, 0x97, 0xfe, 0x39, 0x00, 0xbe,
+		0xbb, 0xb6, 0xc2, 0xc4, 0x57, 0x00, 0x00,
 	},
 	// uber/cadence/api/v1/tasklist.proto
 	[]byte{
@@ -3119,161 +3563,160 @@ var yarpcFileDescriptorClosurefee8ff76963a38ed = [][]byte{
 		0xf0, 0x32, 0xe1, 0xf9, 0x21, 0x45, 0x2f, 0x3b, 0xbb, 0xcd, 0xd5, 0xaa, 0x2c, 0xac, 0x5f, 0x87,
 		0x4b, 0xa6, 0x56, 0x55, 0xec, 0x26, 0x3c, 0xf7, 0xf6, 0xff, 0xca, 0xef, 0x19, 0xc9, 0xbc, 0x25,
 		0xaf, 0xbf, 0xaf, 0xe6, 0xe3, 0xfc, 0x09, 0x97, 0x6c, 0x3d, 0x8c, 0x9f, 0x1a, 0xdb, 0x0f, 0x7f,
-		0x07, 0x00, 0x00, 0xff, 0xff, 0x41, 0xb6, 0x75, 0xa3, 0x5c, 0x05, 0x00, 0x00,
+		0x0f, 0x00, 0x41, 0xb6, 0x75, 0xa3, 0x5c, 0x05, 0x00, 0x00,
 	},
 	// uber/cadence/api/v1/workflow.proto
 	[]byte{
-		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x59, 0x4d, 0x73, 0xdb, 0xc8,
-		0xd1, 0x7e, 0x41, 0x4a, 0xb2, 0xd4, 0xd4, 0x07, 0x34, 0x92, 0x2c, 0x5a, 0xde, 0xb5, 0x65, 0xee,
-		0xda, 0x2b, 0xf3, 0x5d, 0x49, 0x2b, 0xef, 0xda, 0x5e, 0x5b, 0x71, 0x1c, 0x08, 0x84, 0x2c, 0xd8,
-		0x14, 0xc8, 0x0c, 0x41, 0xcb, 0xda, 0x4a, 0x82, 0x82, 0xc8, 0x91, 0x84, 0x98, 0x04, 0x58, 0xc0,
-		0xd0, 0xb6, 0xee, 0xa9, 0xca, 0x39, 0x97, 0x54, 0x2a, 0xa7, 0xfc, 0x80, 0xa4, 0x52, 0xa9, 0x9c,
-		0x53, 0xa9, 0xca, 0x21, 0xb7, 0x5c, 0xf3, 0x1f, 0xf2, 0x2f, 0x52, 0x33, 0x18, 0x90, 0xe0, 0x27,
-		0xe8, 0xa4, 0x6a, 0x73, 0x13, 0x7a, 0x9e, 0xa7, 0xd1, 0xd3, 0xd3, 0xfd, 0xf4, 0x80, 0x82, 0x5c,
-		0xfb, 0x8c, 0xf8, 0xbb, 0x35, 0xbb, 0x4e, 0xdc, 0x1a, 0xd9, 0xb5, 0x5b, 0xce, 0xee, 0xbb, 0xbd,
-		0xdd, 0xf7, 0x9e, 0xff, 0xf6, 0xbc, 0xe1, 0xbd, 0xdf, 0x69, 0xf9, 0x1e, 0xf5, 0xd0, 0x0a, 0xc3,
-		0xec, 0x08, 0xcc, 0x8e, 0xdd, 0x72, 0x76, 0xde, 0xed, 0x6d, 0xdc, 0xba, 0xf0, 0xbc, 0x8b, 0x06,
-		0xd9, 0xe5, 0x90, 0xb3, 0xf6, 0xf9, 0x6e, 0xbd, 0xed, 0xdb, 0xd4, 0xf1, 0xdc, 0x90, 0xb4, 0x71,
-		0xbb, 0x7f, 0x9d, 0x3a, 0x4d, 0x12, 0x50, 0xbb, 0xd9, 0x12, 0x80, 0xcd, 0x61, 0x6f, 0xae, 0x79,
-		0xcd, 0x66, 0xc7, 0xc5, 0xd0, 0xd8, 0xa8, 0x1d, 0xbc, 0x6d, 0x38, 0x01, 0x0d, 0x31, 0xb9, 0x3f,
-		0xcc, 0xc2, 0xda, 0x89, 0x08, 0x57, 0xfb, 0x40, 0x6a, 0x6d, 0x16, 0x82, 0xee, 0x9e, 0x7b, 0xa8,
-		0x0a, 0x28, 0xda, 0x87, 0x45, 0xa2, 0x95, 0xac, 0xb4, 0x29, 0x6d, 0x65, 0x1e, 0xdc, 0xdb, 0x19,
-		0xb2, 0xa5, 0x9d, 0x01, 0x3f, 0x78, 0xf9, 0x7d, 0xbf, 0x09, 0x3d, 0x84, 0x29, 0x7a, 0xd5, 0x22,
-		0xd9, 0x14, 0x77, 0x74, 0x67, 0xac, 0x23, 0xf3, 0xaa, 0x45, 0x30, 0x87, 0xa3, 0x27, 0x00, 0x01,
-		0xb5, 0x7d, 0x6a, 0xb1, 0x34, 0x64, 0xd3, 0x9c, 0xbc, 0xb1, 0x13, 0xe6, 0x68, 0x27, 0xca, 0xd1,
-		0x8e, 0x19, 0xe5, 0x08, 0xcf, 0x71, 0x34, 0x7b, 0x66, 0xd4, 0x5a, 0xc3, 0x0b, 0x48, 0x48, 0x9d,
-		0x4a, 0xa6, 0x72, 0x34, 0xa7, 0x9a, 0x30, 0x1f, 0x52, 0x03, 0x6a, 0xd3, 0x76, 0x90, 0x9d, 0xde,
-		0x94, 0xb6, 0x16, 0x1f, 0xec, 0x4d, 0xb6, 0x7b, 0x95, 0x31, 0x2b, 0x9c, 0x88, 0x33, 0xb5, 0xee,
-		0x03, 0xba, 0x0b, 0x8b, 0x97, 0x4e, 0x40, 0x3d, 0xff, 0xca, 0x6a, 0x10, 0xf7, 0x82, 0x5e, 0x66,
-		0x67, 0x36, 0xa5, 0xad, 0x34, 0x5e, 0x10, 0xd6, 0x22, 0x37, 0xa2, 0x9f, 0xc0, 0x5a, 0xcb, 0xf6,
-		0x89, 0x4b, 0xbb, 0xe9, 0xb7, 0x1c, 0xf7, 0xdc, 0xcb, 0x5e, 0xe3, 0x5b, 0xd8, 0x1a, 0x1a, 0x45,
-		0x99, 0x33, 0x7a, 0x4e, 0x12, 0xaf, 0xb4, 0x06, 0x8d, 0x48, 0x81, 0xc5, 0xae, 0x5b, 0x9e, 0x99,
-		0xd9, 0xc4, 0xcc, 0x2c, 0x74, 0x18, 0x3c, 0x3b, 0xdb, 0x30, 0xd5, 0x24, 0x4d, 0x2f, 0x3b, 0xc7,
-		0x89, 0x37, 0x86, 0xc6, 0x73, 0x4c, 0x9a, 0x1e, 0xe6, 0x30, 0x84, 0x61, 0x39, 0x20, 0xb6, 0x5f,
-		0xbb, 0xb4, 0x6c, 0x4a, 0x7d, 0xe7, 0xac, 0x4d, 0x49, 0x90, 0x05, 0xce, 0xbd, 0x3b, 0x94, 0x5b,
-		0xe1, 0x68, 0xa5, 0x03, 0xc6, 0x72, 0xd0, 0x67, 0x41, 0x45, 0x58, 0xb6, 0xdb, 0xd4, 0xb3, 0x7c,
-		0x12, 0x10, 0x6a, 0xb5, 0x3c, 0xc7, 0xa5, 0x41, 0x36, 0xc3, 0x7d, 0x6e, 0x0e, 0xf5, 0x89, 0x19,
-		0xb0, 0xcc, 0x71, 0x78, 0x89, 0x51, 0x63, 0x06, 0x74, 0x13, 0xe6, 0x58, 0x7b, 0x58, 0xac, 0x3f,
-		0xb2, 0xf3, 0x9b, 0xd2, 0xd6, 0x1c, 0x9e, 0x65, 0x86, 0xa2, 0x13, 0x50, 0xb4, 0x0e, 0xd7, 0x9c,
-		0xc0, 0xaa, 0xf9, 0x9e, 0x9b, 0x5d, 0xd8, 0x94, 0xb6, 0x66, 0xf1, 0x8c, 0x13, 0xa8, 0xbe, 0xe7,
-		0xa2, 0x7d, 0xc8, 0xb4, 0x5b, 0x75, 0x9b, 0x8a, 0x02, 0x5b, 0x4c, 0x4c, 0x23, 0x84, 0x70, 0x9e,
-		0xc3, 0x9f, 0x83, 0xdc, 0xb2, 0x7d, 0xea, 0xf0, 0x63, 0xa8, 0x79, 0xee, 0xb9, 0x73, 0x91, 0x5d,
-		0xda, 0x4c, 0x6f, 0x65, 0x1e, 0x3c, 0x9f, 0xac, 0xca, 0xd8, 0x61, 0xb2, 0x53, 0x0f, 0x5d, 0xa8,
-		0xdc, 0x83, 0xe6, 0x52, 0xff, 0x0a, 0x2f, 0xb5, 0x7a, 0xad, 0x1b, 0x07, 0xb0, 0x3a, 0x0c, 0x88,
-		0x64, 0x48, 0xbf, 0x25, 0x57, 0xbc, 0xb5, 0xe7, 0x30, 0xfb, 0x13, 0xad, 0xc2, 0xf4, 0x3b, 0xbb,
-		0xd1, 0x0e, 0xbb, 0x74, 0x0e, 0x87, 0x0f, 0x4f, 0x53, 0xdf, 0x4a, 0xb9, 0xdf, 0xa4, 0xe0, 0xd6,
-		0x60, 0xa5, 0x73, 0x67, 0x42, 0xbf, 0xd0, 0xd3, 0x78, 0x16, 0x43, 0xbd, 0xf8, 0x74, 0xe8, 0x5e,
-		0x4c, 0x91, 0xda, 0x58, 0x92, 0x6d, 0xd8, 0xec, 0x56, 0xa5, 0x68, 0x78, 0xcf, 0xea, 0xb6, 0xaf,
-		0xd7, 0xa6, 0x42, 0x39, 0x6e, 0x0c, 0x24, 0xb8, 0x20, 0x02, 0xc0, 0x9f, 0x74, 0x5c, 0x54, 0xb8,
-		0x08, 0x78, 0x6a, 0xd4, 0xd0, 0x5e, 0x9b, 0xa2, 0x13, 0xb8, 0xc9, 0xc3, 0x1b, 0xe1, 0x3d, 0x9d,
-		0xe4, 0x7d, 0x9d, 0xb1, 0x87, 0x38, 0xce, 0xfd, 0x43, 0x82, 0x95, 0x21, 0xed, 0xc7, 0xaa, 0xaa,
-		0xee, 0x35, 0x6d, 0xc7, 0xb5, 0x9c, 0xba, 0x48, 0xf2, 0x6c, 0x68, 0xd0, 0xeb, 0xe8, 0x36, 0x64,
-		0xc4, 0xa2, 0x6b, 0x37, 0xa3, 0x7c, 0x43, 0x68, 0x32, 0xec, 0x26, 0x19, 0x21, 0xc3, 0xe9, 0xff,
-		0x56, 0x86, 0xef, 0xc0, 0xbc, 0xe3, 0x3a, 0xd4, 0xb1, 0x29, 0xa9, 0xb3, 0xb8, 0xa6, 0xb8, 0x02,
-		0x65, 0x3a, 0x36, 0xbd, 0x9e, 0xfb, 0x95, 0x04, 0x6b, 0xda, 0x07, 0x4a, 0x7c, 0xd7, 0x6e, 0x7c,
-		0x2f, 0xa3, 0xa1, 0x3f, 0xa6, 0xd4, 0x60, 0x4c, 0xbf, 0x9e, 0x81, 0x95, 0x32, 0x71, 0xeb, 0x8e,
-		0x7b, 0xa1, 0xd4, 0xa8, 0xf3, 0xce, 0xa1, 0x57, 0x3c, 0xa2, 0xdb, 0x90, 0xb1, 0xc5, 0x73, 0x37,
-		0xcb, 0x10, 0x99, 0xf4, 0x3a, 0x3a, 0x84, 0x85, 0x0e, 0x20, 0x71, 0xfe, 0x44, 0xae, 0xf9, 0xfc,
-		0x99, 0xb7, 0x63, 0x4f, 0xe8, 0x39, 0x4c, 0xb3, 0x59, 0x10, 0x8e, 0xa0, 0xc5, 0x07, 0xf7, 0x87,
-		0x8b, 0x70, 0x6f, 0x84, 0x4c, 0xf6, 0x09, 0x0e, 0x79, 0x48, 0x87, 0xe5, 0x4b, 0x62, 0xfb, 0xf4,
-		0x8c, 0xd8, 0xd4, 0xaa, 0x13, 0x6a, 0x3b, 0x8d, 0x40, 0x0c, 0xa5, 0x4f, 0x46, 0x28, 0xfa, 0x55,
-		0xc3, 0xb3, 0xeb, 0x58, 0xee, 0xd0, 0x0a, 0x21, 0x0b, 0xbd, 0x84, 0x95, 0x86, 0x1d, 0x50, 0xab,
-		0xeb, 0x8f, 0x0b, 0xd0, 0x74, 0xa2, 0x00, 0x2d, 0x33, 0xda, 0x51, 0xc4, 0xe2, 0x3a, 0x74, 0x08,
-		0xdc, 0x18, 0x76, 0x05, 0xa9, 0x87, 0x9e, 0x66, 0x12, 0x3d, 0x2d, 0x31, 0x52, 0x25, 0xe4, 0x70,
-		0x3f, 0x59, 0xb8, 0x66, 0x53, 0x4a, 0x9a, 0x2d, 0xca, 0xc7, 0xd4, 0x34, 0x8e, 0x1e, 0xd1, 0x7d,
-		0x90, 0x9b, 0xf6, 0x07, 0xa7, 0xd9, 0x6e, 0x5a, 0xc2, 0x14, 0xf0, 0x91, 0x33, 0x8d, 0x97, 0x84,
-		0x5d, 0x11, 0x66, 0x36, 0x9b, 0x82, 0xda, 0x25, 0xa9, 0xb7, 0x1b, 0x51, 0x24, 0x73, 0xc9, 0xb3,
-		0xa9, 0xc3, 0xe0, 0x71, 0xa8, 0xb0, 0x44, 0x3e, 0xb4, 0x9c, 0xb0, 0x67, 0x43, 0x1f, 0x90, 0xe8,
-		0x63, 0xb1, 0x4b, 0xe1, 0x4e, 0x9e, 0xc3, 0x3c, 0x4f, 0xca, 0xb9, 0xed, 0x34, 0xda, 0x3e, 0x11,
-		0x83, 0x65, 0xf8, 0x31, 0x1d, 0x86, 0x18, 0x9c, 0x61, 0x0c, 0xf1, 0x80, 0xbe, 0x82, 0x55, 0xee,
-		0x80, 0xd5, 0x3a, 0xf1, 0x2d, 0xa7, 0x4e, 0x5c, 0xea, 0xd0, 0x2b, 0x31, 0x5b, 0x10, 0x5b, 0x3b,
-		0xe1, 0x4b, 0xba, 0x58, 0x41, 0x8f, 0x60, 0x3d, 0x3a, 0x82, 0x7e, 0xd2, 0x02, 0x27, 0xad, 0x89,
-		0xe5, 0x5e, 0x5e, 0xee, 0xcf, 0x29, 0xb8, 0x21, 0xca, 0x4e, 0xbd, 0x74, 0x1a, 0xf5, 0xef, 0xa5,
-		0x61, 0xbf, 0x8c, 0xb9, 0x65, 0x4d, 0x15, 0xd7, 0x30, 0xf9, 0x7d, 0xec, 0x12, 0xc7, 0x95, 0xac,
-		0xbf, 0xbd, 0xd3, 0x03, 0xed, 0x8d, 0x5e, 0x83, 0xb8, 0xab, 0x08, 0x51, 0x6e, 0x79, 0x0d, 0xa7,
-		0x76, 0xc5, 0xdb, 0x63, 0x71, 0x44, 0xa0, 0xa1, 0xe2, 0x72, 0x21, 0x2e, 0x73, 0x34, 0x5e, 0x6e,
-		0xf5, 0x9b, 0xd0, 0x75, 0x98, 0x09, 0x25, 0x95, 0x37, 0xc7, 0x1c, 0x16, 0x4f, 0xb9, 0xbf, 0xa7,
-		0x3a, 0x72, 0x52, 0x20, 0x35, 0x27, 0x88, 0xf2, 0xd5, 0xe9, 0x72, 0x29, 0xb9, 0xcb, 0x23, 0x62,
-		0x4f, 0x97, 0x0f, 0x56, 0x70, 0xea, 0x63, 0x2b, 0xf8, 0x19, 0xcc, 0xf7, 0x34, 0x63, 0xf2, 0x9d,
-		0x37, 0x13, 0x0c, 0x6f, 0xc4, 0xa9, 0xde, 0x46, 0xc4, 0xb0, 0xee, 0xf9, 0xce, 0x85, 0xe3, 0xda,
-		0x0d, 0xab, 0x2f, 0xc8, 0x64, 0xe9, 0x58, 0x8b, 0xa8, 0x95, 0x78, 0xb0, 0xb9, 0xbf, 0xa4, 0xe0,
-		0x46, 0x24, 0x77, 0x45, 0xaf, 0x66, 0x37, 0x0a, 0x4e, 0xd0, 0xb2, 0x69, 0xed, 0x72, 0x32, 0x75,
-		0xfe, 0xdf, 0xa7, 0xeb, 0x67, 0x70, 0xab, 0x37, 0x02, 0xcb, 0x3b, 0xb7, 0xe8, 0xa5, 0x13, 0x58,
-		0xf1, 0x2c, 0x8e, 0x77, 0xb8, 0xd1, 0x13, 0x51, 0xe9, 0xdc, 0xbc, 0x74, 0x02, 0xa1, 0x69, 0xe8,
-		0x53, 0x00, 0x7e, 0xeb, 0xa0, 0xde, 0x5b, 0x12, 0x56, 0xe1, 0x3c, 0xe6, 0xd7, 0x24, 0x93, 0x19,
-		0x72, 0x2f, 0x21, 0x13, 0xbf, 0x88, 0xee, 0xc3, 0x8c, 0xb8, 0xcb, 0x4a, 0xfc, 0x2e, 0xf8, 0x59,
-		0xc2, 0x5d, 0x96, 0x5f, 0xf3, 0x05, 0x25, 0xf7, 0xc7, 0x14, 0x2c, 0xf6, 0x2e, 0xa1, 0x2f, 0x60,
-		0xe9, 0xcc, 0x71, 0x6d, 0xff, 0xca, 0xaa, 0x5d, 0x92, 0xda, 0xdb, 0xa0, 0xdd, 0x14, 0x87, 0xb0,
-		0x18, 0x9a, 0x55, 0x61, 0x45, 0x6b, 0x30, 0xe3, 0xb7, 0xdd, 0x68, 0xf8, 0xce, 0xe1, 0x69, 0xbf,
-		0xcd, 0x6e, 0x29, 0xcf, 0xe0, 0xe6, 0xb9, 0xe3, 0x07, 0x6c, 0x60, 0x85, 0xc5, 0x6e, 0xd5, 0xbc,
-		0x66, 0xab, 0x41, 0x7a, 0x3a, 0x39, 0xcb, 0x21, 0x51, 0x3b, 0xa8, 0x11, 0x80, 0xd3, 0xe7, 0x6b,
-		0x3e, 0xb1, 0x3b, 0x67, 0x93, 0x9c, 0xca, 0x8c, 0xc0, 0x0b, 0x19, 0x5e, 0xe0, 0xc2, 0xec, 0xb8,
-		0x17, 0x93, 0x96, 0xe9, 0x7c, 0x44, 0xe0, 0x0e, 0x6e, 0x01, 0xf0, 0x0f, 0x04, 0x6a, 0x9f, 0x35,
-		0xc2, 0xa9, 0x36, 0x8b, 0x63, 0x96, 0xfc, 0x9f, 0x24, 0x58, 0x1d, 0x36, 0xb3, 0x51, 0x0e, 0x6e,
-		0x95, 0x35, 0xa3, 0xa0, 0x1b, 0x2f, 0x2c, 0x45, 0x35, 0xf5, 0xd7, 0xba, 0x79, 0x6a, 0x55, 0x4c,
-		0xc5, 0xd4, 0x2c, 0xdd, 0x78, 0xad, 0x14, 0xf5, 0x82, 0xfc, 0x7f, 0xe8, 0x73, 0xd8, 0x1c, 0x81,
-		0xa9, 0xa8, 0x47, 0x5a, 0xa1, 0x5a, 0xd4, 0x0a, 0xb2, 0x34, 0xc6, 0x53, 0xc5, 0x54, 0xb0, 0xa9,
-		0x15, 0xe4, 0x14, 0xfa, 0x7f, 0xf8, 0x62, 0x04, 0x46, 0x55, 0x0c, 0x55, 0x2b, 0x5a, 0x58, 0xfb,
-		0x71, 0x55, 0xab, 0x30, 0x70, 0x3a, 0xff, 0x8b, 0x6e, 0xcc, 0x3d, 0x0a, 0x14, 0x7f, 0x53, 0x41,
-		0x53, 0xf5, 0x8a, 0x5e, 0x32, 0xc6, 0xc5, 0xdc, 0x87, 0x19, 0x11, 0x73, 0x3f, 0x2a, 0x8a, 0x39,
-		0xff, 0xcb, 0x54, 0xf7, 0xf7, 0x03, 0xbd, 0x8e, 0x49, 0xbb, 0xa3, 0xb9, 0x9f, 0xc3, 0xe6, 0x49,
-		0x09, 0xbf, 0x3a, 0x2c, 0x96, 0x4e, 0x2c, 0xbd, 0x60, 0x61, 0xad, 0x5a, 0xd1, 0xac, 0x72, 0xa9,
-		0xa8, 0xab, 0xa7, 0xb1, 0x48, 0xbe, 0x85, 0x6f, 0x46, 0xa2, 0x94, 0x22, 0xb3, 0x16, 0xaa, 0xe5,
-		0xa2, 0xae, 0xb2, 0xb7, 0x1e, 0x2a, 0x7a, 0x51, 0x2b, 0x58, 0x25, 0xa3, 0x78, 0x2a, 0x4b, 0xe8,
-		0x4b, 0xd8, 0x9a, 0x94, 0x29, 0xa7, 0xd0, 0x36, 0xdc, 0x1f, 0x89, 0xc6, 0xda, 0x4b, 0x4d, 0x35,
-		0x63, 0xf0, 0x34, 0xda, 0x83, 0xed, 0x91, 0x70, 0x53, 0xc3, 0xc7, 0xba, 0xc1, 0x13, 0x7a, 0x68,
-		0xe1, 0xaa, 0x61, 0xe8, 0xc6, 0x0b, 0x79, 0x2a, 0xff, 0x3b, 0x09, 0x96, 0x07, 0x86, 0x11, 0xba,
-		0x0d, 0x37, 0xcb, 0x0a, 0xd6, 0x0c, 0xd3, 0x52, 0x8b, 0xa5, 0x61, 0x09, 0x18, 0x01, 0x50, 0x0e,
-		0x14, 0xa3, 0x50, 0x32, 0x64, 0x09, 0xdd, 0x83, 0xdc, 0x30, 0x80, 0xa8, 0x05, 0x51, 0x1a, 0x72,
-		0x0a, 0xdd, 0x81, 0x4f, 0x87, 0xe1, 0x3a, 0xd1, 0xca, 0xe9, 0xfc, 0xbf, 0x52, 0xf0, 0xc9, 0xb8,
-		0x9f, 0x29, 0x58, 0x05, 0x76, 0xb6, 0xad, 0xbd, 0xd1, 0xd4, 0xaa, 0xc9, 0xce, 0x3c, 0xf4, 0xc7,
-		0x4e, 0xbe, 0x5a, 0x89, 0x45, 0x1e, 0x4f, 0xe9, 0x08, 0xb0, 0x5a, 0x3a, 0x2e, 0x17, 0x35, 0x93,
-		0x57, 0x53, 0x1e, 0xee, 0x25, 0xc1, 0xc3, 0x03, 0x96, 0x53, 0x3d, 0x67, 0x3b, 0xca, 0x35, 0xdf,
-		0x37, 0x6b, 0x05, 0xb4, 0x03, 0xf9, 0x24, 0x74, 0x27, 0x0b, 0x05, 0x79, 0x0a, 0x7d, 0x03, 0x5f,
-		0x25, 0x07, 0x6e, 0x98, 0xba, 0x51, 0xd5, 0x0a, 0x96, 0x52, 0xb1, 0x0c, 0xed, 0x44, 0x9e, 0x9e,
-		0x64, 0xbb, 0xa6, 0x7e, 0xcc, 0xea, 0xb3, 0x6a, 0xca, 0x33, 0xf9, 0xbf, 0x4a, 0x70, 0x5d, 0xf5,
-		0x5c, 0xea, 0xb8, 0x6d, 0xa2, 0x04, 0x06, 0x79, 0xaf, 0x87, 0xf7, 0x1c, 0xcf, 0x47, 0x77, 0xe1,
-		0x4e, 0xe4, 0x5f, 0xb8, 0xb7, 0x74, 0x43, 0x37, 0x75, 0xc5, 0x2c, 0xe1, 0x58, 0x7e, 0xc7, 0xc2,
-		0x58, 0x43, 0x16, 0x34, 0x1c, 0xe6, 0x75, 0x34, 0x0c, 0x6b, 0x26, 0x3e, 0x15, 0xa5, 0x10, 0x2a,
-		0xcc, 0x68, 0xac, 0x8a, 0x59, 0x7f, 0x8b, 0xfe, 0x97, 0xd3, 0xf9, 0xdf, 0x4b, 0x90, 0x11, 0xdf,
-		0xb6, 0xfc, 0xd3, 0x27, 0x0b, 0xab, 0x6c, 0x83, 0xa5, 0xaa, 0x69, 0x99, 0xa7, 0x65, 0xad, 0xb7,
-		0x86, 0x7b, 0x56, 0xb8, 0x3c, 0x58, 0x66, 0x29, 0xcc, 0x4e, 0xa8, 0x24, 0xbd, 0x00, 0xf1, 0x16,
-		0x86, 0xe1, 0x60, 0x39, 0x35, 0x16, 0x13, 0xfa, 0x49, 0xa3, 0x0d, 0xb8, 0xde, 0x83, 0x39, 0xd2,
-		0x14, 0x6c, 0x1e, 0x68, 0x8a, 0x29, 0x4f, 0xe5, 0x7f, 0x2b, 0xc1, 0x8d, 0x48, 0x09, 0x4d, 0x36,
-		0x58, 0x9d, 0x26, 0xa9, 0x97, 0xda, 0x54, 0xb5, 0xdb, 0x01, 0x41, 0xf7, 0xe1, 0x6e, 0x47, 0xc3,
-		0x4c, 0xa5, 0xf2, 0xaa, 0x7b, 0x56, 0x96, 0xaa, 0xb0, 0xe6, 0xee, 0xee, 0x26, 0x11, 0x2a, 0x42,
-		0x90, 0x25, 0xf4, 0x05, 0x7c, 0x36, 0x1e, 0x8a, 0xb5, 0x8a, 0x66, 0xca, 0xa9, 0xfc, 0x3f, 0x33,
-		0xb0, 0x1e, 0x0f, 0x8e, 0x7d, 0x20, 0x90, 0x7a, 0x18, 0xda, 0x3d, 0xc8, 0xf5, 0x3a, 0x11, 0x3a,
-		0xd7, 0x1f, 0xd7, 0x1e, 0x6c, 0x8f, 0xc1, 0x55, 0x8d, 0x23, 0xc5, 0x28, 0xb0, 0xe7, 0x08, 0x24,
-		0x4b, 0xe8, 0x39, 0xec, 0x8f, 0xa1, 0x1c, 0x28, 0x85, 0x6e, 0x96, 0x3b, 0x13, 0x47, 0x31, 0x4d,
-		0xac, 0x1f, 0x54, 0x4d, 0xad, 0x22, 0xa7, 0x90, 0x06, 0x4a, 0x82, 0x83, 0x5e, 0x1d, 0x1a, 0xea,
-		0x26, 0x8d, 0x9e, 0xc0, 0xc3, 0xa4, 0x38, 0xc2, 0x92, 0xd1, 0x8f, 0x35, 0x1c, 0xa7, 0x4e, 0xa1,
-		0xa7, 0xf0, 0x28, 0x81, 0x2a, 0xde, 0x3c, 0xc0, 0x9d, 0x46, 0xfb, 0xf0, 0x38, 0x31, 0x7a, 0xb5,
-		0x84, 0x0b, 0xd6, 0xb1, 0x82, 0x5f, 0xf5, 0x92, 0x67, 0x90, 0x0e, 0x5a, 0xd2, 0x8b, 0x85, 0xba,
-		0x59, 0x43, 0x74, 0x21, 0xe6, 0xea, 0xda, 0x04, 0x59, 0x64, 0x86, 0x04, 0x37, 0xb3, 0xe8, 0x05,
-		0xa8, 0x93, 0xa5, 0x62, 0xbc, 0xa3, 0x39, 0xf4, 0x06, 0xcc, 0x8f, 0x3b, 0x55, 0xed, 0x8d, 0xa9,
-		0x61, 0x43, 0x49, 0xf2, 0x0c, 0xe8, 0x19, 0x3c, 0x49, 0x4c, 0x5a, 0xaf, 0xfe, 0xc4, 0xe8, 0x19,
-		0xf4, 0x18, 0xbe, 0x1e, 0x43, 0x8f, 0xd7, 0x48, 0xf7, 0x56, 0xa0, 0x17, 0xe4, 0x79, 0xf4, 0x10,
-		0xf6, 0xc6, 0x10, 0x79, 0x17, 0x5a, 0x15, 0x53, 0x57, 0x5f, 0x9d, 0x86, 0xcb, 0x45, 0xbd, 0x62,
-		0xca, 0x0b, 0xe8, 0x47, 0xf0, 0x83, 0x31, 0xb4, 0xce, 0x66, 0xd9, 0x1f, 0x1a, 0x8e, 0xb5, 0x18,
-		0x83, 0x55, 0xb1, 0x26, 0x2f, 0x4e, 0x70, 0x26, 0x15, 0xfd, 0x45, 0x72, 0xe6, 0x96, 0x90, 0x0a,
-		0xcf, 0x27, 0x6a, 0x11, 0xf5, 0x48, 0x2f, 0x16, 0x86, 0x3b, 0x91, 0xd1, 0xd7, 0xb0, 0x3b, 0xc6,
-		0xc9, 0x61, 0x09, 0xab, 0x9a, 0x98, 0x58, 0x1d, 0x91, 0x58, 0x46, 0x8f, 0xe0, 0xc1, 0x38, 0x92,
-		0xa2, 0x17, 0x4b, 0xaf, 0x35, 0xdc, 0xcf, 0x43, 0x6c, 0x8c, 0x4e, 0xb6, 0x75, 0xdd, 0x28, 0x57,
-		0x4d, 0xab, 0xa2, 0x7f, 0xa7, 0xc9, 0x2b, 0x6c, 0x8c, 0x26, 0x9e, 0x54, 0x94, 0x2b, 0x79, 0x75,
-		0x50, 0x8c, 0x07, 0x5e, 0x72, 0xa0, 0x1b, 0x0a, 0x3e, 0x95, 0xd7, 0x12, 0x6a, 0x6f, 0x50, 0xe8,
-		0x7a, 0x4a, 0xe8, 0xfa, 0x24, 0xdb, 0xd1, 0x14, 0xac, 0x1e, 0xc5, 0x33, 0xbe, 0xce, 0xa6, 0xce,
-		0x1d, 0xfe, 0x83, 0xcb, 0xc0, 0xbd, 0x2a, 0x2e, 0xf1, 0x7b, 0xb0, 0x1d, 0x9e, 0xdb, 0x90, 0x2a,
-		0x18, 0xa1, 0xf6, 0x07, 0xf0, 0xc3, 0xc9, 0x28, 0x9d, 0x75, 0xa5, 0x88, 0x35, 0xa5, 0x70, 0xda,
-		0xb9, 0x92, 0x4a, 0xf9, 0xbf, 0x49, 0x90, 0x57, 0x6d, 0xb7, 0x46, 0x1a, 0xd1, 0xef, 0xb8, 0x63,
-		0xa3, 0xdc, 0x87, 0xc7, 0x13, 0xf4, 0xfb, 0x88, 0x78, 0x4f, 0xa0, 0xf2, 0xb1, 0xe4, 0xaa, 0xf1,
-		0xca, 0x28, 0x9d, 0x18, 0xe3, 0x08, 0x62, 0x13, 0x15, 0xe7, 0x82, 0xff, 0x08, 0x3d, 0xd9, 0x26,
-		0x44, 0xd9, 0xfd, 0x67, 0x9b, 0xf8, 0x58, 0xf2, 0x44, 0x9b, 0x38, 0xf8, 0x29, 0xac, 0xd7, 0xbc,
-		0xe6, 0xb0, 0xaf, 0xf8, 0x83, 0x85, 0x68, 0x3b, 0x65, 0xf6, 0x19, 0x5b, 0x96, 0xbe, 0xdb, 0xbb,
-		0x70, 0xe8, 0x65, 0xfb, 0x6c, 0xa7, 0xe6, 0x35, 0x77, 0xe3, 0xff, 0xc1, 0xdd, 0x76, 0xea, 0x8d,
-		0xdd, 0x0b, 0x2f, 0xfc, 0x8f, 0xb0, 0xf8, 0x77, 0xee, 0xbe, 0xdd, 0x72, 0xde, 0xed, 0x9d, 0xcd,
-		0x70, 0xdb, 0xd7, 0xff, 0x0e, 0x00, 0x00, 0xff, 0xff, 0x86, 0x60, 0x70, 0x2f, 0x8e, 0x1e, 0x00,
-		0x00,
+		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x59, 0x4d, 0x6f, 0xdb, 0xca,
+		0xd5, 0x7e, 0x29, 0xd9, 0x8e, 0x7d, 0xe4, 0x0f, 0x7a, 0x6c, 0xc7, 0x8a, 0xf3, 0xe5, 0xe8, 0xde,
+		0x24, 0x8e, 0xde, 0x6b, 0xf9, 0x3a, 0xb9, 0x49, 0x6e, 0xe2, 0xa6, 0x29, 0x4d, 0xd1, 0x31, 0x13,
+		0x99, 0x52, 0x87, 0x54, 0x1c, 0x5f, 0xb4, 0x25, 0x68, 0x89, 0xb6, 0xd9, 0x48, 0xa4, 0x40, 0x8e,
+		0x92, 0x78, 0x5f, 0xa0, 0xeb, 0x6e, 0x8a, 0xa2, 0xab, 0xfe, 0x80, 0x16, 0x45, 0xd1, 0x75, 0x51,
+		0xa0, 0x8b, 0xee, 0xba, 0xed, 0x7f, 0xe8, 0xbf, 0x28, 0x66, 0x38, 0x94, 0xa8, 0x4f, 0x2a, 0x2d,
+		0x70, 0xbb, 0x33, 0xcf, 0x3c, 0xcf, 0xe1, 0x99, 0x33, 0xe7, 0x3c, 0x67, 0x28, 0x43, 0xae, 0x7d,
+		0x6a, 0xfb, 0x3b, 0x35, 0xab, 0x6e, 0xbb, 0x35, 0x7b, 0xc7, 0x6a, 0x39, 0x3b, 0x1f, 0x76, 0x77,
+		0x3e, 0x7a, 0xfe, 0xfb, 0xb3, 0x86, 0xf7, 0xb1, 0xd0, 0xf2, 0x3d, 0xe2, 0xa1, 0x15, 0x8a, 0x29,
+		0x70, 0x4c, 0xc1, 0x6a, 0x39, 0x85, 0x0f, 0xbb, 0x1b, 0xb7, 0xce, 0x3d, 0xef, 0xbc, 0x61, 0xef,
+		0x30, 0xc8, 0x69, 0xfb, 0x6c, 0xa7, 0xde, 0xf6, 0x2d, 0xe2, 0x78, 0x6e, 0x48, 0xda, 0xb8, 0xdd,
+		0xbf, 0x4e, 0x9c, 0xa6, 0x1d, 0x10, 0xab, 0xd9, 0xe2, 0x80, 0xcd, 0x61, 0x6f, 0xae, 0x79, 0xcd,
+		0x66, 0xc7, 0xc5, 0xd0, 0xd8, 0x88, 0x15, 0xbc, 0x6f, 0x38, 0x01, 0x09, 0x31, 0xb9, 0x3f, 0xcc,
+		0xc2, 0xda, 0x31, 0x0f, 0x57, 0xf9, 0x64, 0xd7, 0xda, 0x34, 0x04, 0xd5, 0x3d, 0xf3, 0x50, 0x15,
+		0x50, 0xb4, 0x0f, 0xd3, 0x8e, 0x56, 0xb2, 0xc2, 0xa6, 0xb0, 0x95, 0x79, 0x78, 0xaf, 0x30, 0x64,
+		0x4b, 0x85, 0x01, 0x3f, 0x78, 0xf9, 0x63, 0xbf, 0x09, 0x3d, 0x86, 0x29, 0x72, 0xd9, 0xb2, 0xb3,
+		0x29, 0xe6, 0xe8, 0xce, 0x58, 0x47, 0xc6, 0x65, 0xcb, 0xc6, 0x0c, 0x8e, 0x9e, 0x01, 0x04, 0xc4,
+		0xf2, 0x89, 0x49, 0xd3, 0x90, 0x4d, 0x33, 0xf2, 0x46, 0x21, 0xcc, 0x51, 0x21, 0xca, 0x51, 0xc1,
+		0x88, 0x72, 0x84, 0xe7, 0x18, 0x9a, 0x3e, 0x53, 0x6a, 0xad, 0xe1, 0x05, 0x76, 0x48, 0x9d, 0x4a,
+		0xa6, 0x32, 0x34, 0xa3, 0x1a, 0x30, 0x1f, 0x52, 0x03, 0x62, 0x91, 0x76, 0x90, 0x9d, 0xde, 0x14,
+		0xb6, 0x16, 0x1f, 0xee, 0x4e, 0xb6, 0x7b, 0x99, 0x32, 0x75, 0x46, 0xc4, 0x99, 0x5a, 0xf7, 0x01,
+		0xdd, 0x85, 0xc5, 0x0b, 0x27, 0x20, 0x9e, 0x7f, 0x69, 0x36, 0x6c, 0xf7, 0x9c, 0x5c, 0x64, 0x67,
+		0x36, 0x85, 0xad, 0x34, 0x5e, 0xe0, 0xd6, 0x12, 0x33, 0xa2, 0x9f, 0xc0, 0x5a, 0xcb, 0xf2, 0x6d,
+		0x97, 0x74, 0xd3, 0x6f, 0x3a, 0xee, 0x99, 0x97, 0xbd, 0xc2, 0xb6, 0xb0, 0x35, 0x34, 0x8a, 0x0a,
+		0x63, 0xf4, 0x9c, 0x24, 0x5e, 0x69, 0x0d, 0x1a, 0x91, 0x04, 0x8b, 0x5d, 0xb7, 0x2c, 0x33, 0xb3,
+		0x89, 0x99, 0x59, 0xe8, 0x30, 0x58, 0x76, 0xb6, 0x61, 0xaa, 0x69, 0x37, 0xbd, 0xec, 0x1c, 0x23,
+		0x5e, 0x1b, 0x1a, 0xcf, 0x91, 0xdd, 0xf4, 0x30, 0x83, 0x21, 0x0c, 0xcb, 0x81, 0x6d, 0xf9, 0xb5,
+		0x0b, 0xd3, 0x22, 0xc4, 0x77, 0x4e, 0xdb, 0xc4, 0x0e, 0xb2, 0xc0, 0xb8, 0x77, 0x87, 0x72, 0x75,
+		0x86, 0x96, 0x3a, 0x60, 0x2c, 0x06, 0x7d, 0x16, 0x54, 0x82, 0x65, 0xab, 0x4d, 0x3c, 0xd3, 0xb7,
+		0x03, 0x9b, 0x98, 0x2d, 0xcf, 0x71, 0x49, 0x90, 0xcd, 0x30, 0x9f, 0x9b, 0x43, 0x7d, 0x62, 0x0a,
+		0xac, 0x30, 0x1c, 0x5e, 0xa2, 0xd4, 0x98, 0x01, 0x5d, 0x87, 0x39, 0xda, 0x1e, 0x26, 0xed, 0x8f,
+		0xec, 0xfc, 0xa6, 0xb0, 0x35, 0x87, 0x67, 0xa9, 0xa1, 0xe4, 0x04, 0x04, 0xad, 0xc3, 0x15, 0x27,
+		0x30, 0x6b, 0xbe, 0xe7, 0x66, 0x17, 0x36, 0x85, 0xad, 0x59, 0x3c, 0xe3, 0x04, 0xb2, 0xef, 0xb9,
+		0x68, 0x0f, 0x32, 0xed, 0x56, 0xdd, 0x22, 0xbc, 0xc0, 0x16, 0x13, 0xd3, 0x08, 0x21, 0x9c, 0xe5,
+		0xf0, 0xe7, 0x20, 0xb6, 0x2c, 0x9f, 0x38, 0xec, 0x18, 0x6a, 0x9e, 0x7b, 0xe6, 0x9c, 0x67, 0x97,
+		0x36, 0xd3, 0x5b, 0x99, 0x87, 0x2f, 0x27, 0xab, 0x32, 0x7a, 0x98, 0x85, 0x4a, 0xe4, 0x42, 0x66,
+		0x1e, 0x14, 0x97, 0xf8, 0x97, 0x78, 0xa9, 0xd5, 0x6b, 0xdd, 0xd8, 0x87, 0xd5, 0x61, 0x40, 0x24,
+		0x42, 0xfa, 0xbd, 0x7d, 0xc9, 0x5a, 0x7b, 0x0e, 0xd3, 0x3f, 0xd1, 0x2a, 0x4c, 0x7f, 0xb0, 0x1a,
+		0xed, 0xb0, 0x4b, 0xe7, 0x70, 0xf8, 0xf0, 0x3c, 0xf5, 0xad, 0x90, 0xfb, 0x4d, 0x0a, 0x6e, 0x0d,
+		0x56, 0x3a, 0x73, 0xc6, 0xf5, 0x0b, 0x3d, 0x8f, 0x67, 0x31, 0xd4, 0x8b, 0x9b, 0x43, 0xf7, 0x62,
+		0xf0, 0xd4, 0xc6, 0x92, 0x6c, 0xc1, 0x66, 0xb7, 0x2a, 0x79, 0xc3, 0x7b, 0x66, 0xb7, 0x7d, 0xbd,
+		0x36, 0xe1, 0xca, 0x71, 0x6d, 0x20, 0xc1, 0x45, 0x1e, 0x00, 0xbe, 0xd1, 0x71, 0xa1, 0x33, 0x11,
+		0xf0, 0xe4, 0xa8, 0xa1, 0xbd, 0x36, 0x41, 0xc7, 0x70, 0x9d, 0x85, 0x37, 0xc2, 0x7b, 0x3a, 0xc9,
+		0xfb, 0x3a, 0x65, 0x0f, 0x71, 0x9c, 0xfb, 0x87, 0x00, 0x2b, 0x43, 0xda, 0x8f, 0x56, 0x55, 0xdd,
+		0x6b, 0x5a, 0x8e, 0x6b, 0x3a, 0x75, 0x9e, 0xe4, 0xd9, 0xd0, 0xa0, 0xd6, 0xd1, 0x6d, 0xc8, 0xf0,
+		0x45, 0xd7, 0x6a, 0x46, 0xf9, 0x86, 0xd0, 0xa4, 0x59, 0x4d, 0x7b, 0x84, 0x0c, 0xa7, 0xff, 0x5b,
+		0x19, 0xbe, 0x03, 0xf3, 0x8e, 0xeb, 0x10, 0xc7, 0x22, 0x76, 0x9d, 0xc6, 0x35, 0xc5, 0x14, 0x28,
+		0xd3, 0xb1, 0xa9, 0xf5, 0xdc, 0xaf, 0x04, 0x58, 0x53, 0x3e, 0x11, 0xdb, 0x77, 0xad, 0xc6, 0xf7,
+		0x32, 0x1a, 0xfa, 0x63, 0x4a, 0x0d, 0xc6, 0xf4, 0xeb, 0x19, 0x58, 0xa9, 0xd8, 0x6e, 0xdd, 0x71,
+		0xcf, 0xa5, 0x1a, 0x71, 0x3e, 0x38, 0xe4, 0x92, 0x45, 0x74, 0x1b, 0x32, 0x16, 0x7f, 0xee, 0x66,
+		0x19, 0x22, 0x93, 0x5a, 0x47, 0x07, 0xb0, 0xd0, 0x01, 0x24, 0xce, 0x9f, 0xc8, 0x35, 0x9b, 0x3f,
+		0xf3, 0x56, 0xec, 0x09, 0xbd, 0x84, 0x69, 0x3a, 0x0b, 0xc2, 0x11, 0xb4, 0xf8, 0xf0, 0xc1, 0x70,
+		0x11, 0xee, 0x8d, 0x90, 0xca, 0xbe, 0x8d, 0x43, 0x1e, 0x52, 0x61, 0xf9, 0xc2, 0xb6, 0x7c, 0x72,
+		0x6a, 0x5b, 0xc4, 0xac, 0xdb, 0xc4, 0x72, 0x1a, 0x01, 0x1f, 0x4a, 0x37, 0x46, 0x28, 0xfa, 0x65,
+		0xc3, 0xb3, 0xea, 0x58, 0xec, 0xd0, 0x8a, 0x21, 0x0b, 0xbd, 0x86, 0x95, 0x86, 0x15, 0x10, 0xb3,
+		0xeb, 0x8f, 0x09, 0xd0, 0x74, 0xa2, 0x00, 0x2d, 0x53, 0xda, 0x61, 0xc4, 0xa2, 0x76, 0x74, 0x00,
+		0xcc, 0x18, 0x76, 0x85, 0x5d, 0x0f, 0x3d, 0xcd, 0x24, 0x7a, 0x5a, 0xa2, 0x24, 0x3d, 0xe4, 0x30,
+		0x3f, 0x59, 0xb8, 0x62, 0x11, 0x62, 0x37, 0x5b, 0x84, 0x8d, 0xa9, 0x69, 0x1c, 0x3d, 0xa2, 0x07,
+		0x20, 0x36, 0xad, 0x4f, 0x4e, 0xb3, 0xdd, 0x34, 0xb9, 0x29, 0x60, 0x23, 0x67, 0x1a, 0x2f, 0x71,
+		0xbb, 0xc4, 0xcd, 0x74, 0x36, 0x05, 0xb5, 0x0b, 0xbb, 0xde, 0x6e, 0x44, 0x91, 0xcc, 0x25, 0xcf,
+		0xa6, 0x0e, 0x83, 0xc5, 0x21, 0xc3, 0x92, 0xfd, 0xa9, 0xe5, 0x84, 0x3d, 0x1b, 0xfa, 0x80, 0x44,
+		0x1f, 0x8b, 0x5d, 0x0a, 0x73, 0xf2, 0x12, 0xe6, 0x59, 0x52, 0xce, 0x2c, 0xa7, 0xd1, 0xf6, 0xed,
+		0x6c, 0x66, 0xcc, 0x31, 0x1d, 0x84, 0x18, 0x9c, 0xa1, 0x0c, 0xfe, 0x80, 0xbe, 0x86, 0x55, 0xe6,
+		0x80, 0xd6, 0xba, 0xed, 0x9b, 0x4e, 0xdd, 0x76, 0x89, 0x43, 0x2e, 0xf9, 0x6c, 0x41, 0x74, 0xed,
+		0x98, 0x2d, 0xa9, 0x7c, 0x05, 0x3d, 0x81, 0xf5, 0xe8, 0x08, 0xfa, 0x49, 0x0b, 0x8c, 0xb4, 0xc6,
+		0x97, 0x7b, 0x79, 0xb9, 0x3f, 0xa7, 0xe0, 0x1a, 0x2f, 0x3b, 0xf9, 0xc2, 0x69, 0xd4, 0xbf, 0x97,
+		0x86, 0xfd, 0x2a, 0xe6, 0x96, 0x36, 0x55, 0x5c, 0xc3, 0xc4, 0x8f, 0xb1, 0x4b, 0x1c, 0x53, 0xb2,
+		0xfe, 0xf6, 0x4e, 0x0f, 0xb4, 0x37, 0x7a, 0x0b, 0xfc, 0xae, 0xc2, 0x45, 0xb9, 0xe5, 0x35, 0x9c,
+		0xda, 0x25, 0x6b, 0x8f, 0xc5, 0x11, 0x81, 0x86, 0x8a, 0xcb, 0x84, 0xb8, 0xc2, 0xd0, 0x78, 0xb9,
+		0xd5, 0x6f, 0x42, 0x57, 0x61, 0x26, 0x94, 0x54, 0xd6, 0x1c, 0x73, 0x98, 0x3f, 0xe5, 0xfe, 0x9e,
+		0xea, 0xc8, 0x49, 0xd1, 0xae, 0x39, 0x41, 0x94, 0xaf, 0x4e, 0x97, 0x0b, 0xc9, 0x5d, 0x1e, 0x11,
+		0x7b, 0xba, 0x7c, 0xb0, 0x82, 0x53, 0x9f, 0x5b, 0xc1, 0x2f, 0x60, 0xbe, 0xa7, 0x19, 0x93, 0xef,
+		0xbc, 0x99, 0x60, 0x78, 0x23, 0x4e, 0xf5, 0x36, 0x22, 0x86, 0x75, 0xcf, 0x77, 0xce, 0x1d, 0xd7,
+		0x6a, 0x98, 0x7d, 0x41, 0x26, 0x4b, 0xc7, 0x5a, 0x44, 0xd5, 0xe3, 0xc1, 0xe6, 0xfe, 0x92, 0x82,
+		0x6b, 0x91, 0xdc, 0x95, 0xbc, 0x9a, 0xd5, 0x28, 0x3a, 0x41, 0xcb, 0x22, 0xb5, 0x8b, 0xc9, 0xd4,
+		0xf9, 0x7f, 0x9f, 0xae, 0x9f, 0xc1, 0xad, 0xde, 0x08, 0x4c, 0xef, 0xcc, 0x24, 0x17, 0x4e, 0x60,
+		0xc6, 0xb3, 0x38, 0xde, 0xe1, 0x46, 0x4f, 0x44, 0xe5, 0x33, 0xe3, 0xc2, 0x09, 0xb8, 0xa6, 0xa1,
+		0x9b, 0x00, 0xec, 0xd6, 0x41, 0xbc, 0xf7, 0x76, 0x58, 0x85, 0xf3, 0x98, 0x5d, 0x93, 0x0c, 0x6a,
+		0xc8, 0xbd, 0x86, 0x4c, 0xfc, 0x22, 0xba, 0x07, 0x33, 0xfc, 0x2e, 0x2b, 0xb0, 0xbb, 0xe0, 0x17,
+		0x09, 0x77, 0x59, 0x76, 0xcd, 0xe7, 0x94, 0xdc, 0x1f, 0x53, 0xb0, 0xd8, 0xbb, 0x84, 0xee, 0xc3,
+		0xd2, 0xa9, 0xe3, 0x5a, 0xfe, 0xa5, 0x59, 0xbb, 0xb0, 0x6b, 0xef, 0x83, 0x76, 0x93, 0x1f, 0xc2,
+		0x62, 0x68, 0x96, 0xb9, 0x15, 0xad, 0xc1, 0x8c, 0xdf, 0x76, 0xa3, 0xe1, 0x3b, 0x87, 0xa7, 0xfd,
+		0x36, 0xbd, 0xa5, 0xbc, 0x80, 0xeb, 0x67, 0x8e, 0x1f, 0xd0, 0x81, 0x15, 0x16, 0xbb, 0x59, 0xf3,
+		0x9a, 0xad, 0x86, 0xdd, 0xd3, 0xc9, 0x59, 0x06, 0x89, 0xda, 0x41, 0x8e, 0x00, 0x8c, 0x3e, 0x5f,
+		0xf3, 0x6d, 0xab, 0x73, 0x36, 0xc9, 0xa9, 0xcc, 0x70, 0x3c, 0x97, 0xe1, 0x05, 0x26, 0xcc, 0x8e,
+		0x7b, 0x3e, 0x69, 0x99, 0xce, 0x47, 0x04, 0xe6, 0xe0, 0x16, 0x00, 0xfb, 0x40, 0x20, 0xd6, 0x69,
+		0x23, 0x9c, 0x6a, 0xb3, 0x38, 0x66, 0xc9, 0xff, 0x49, 0x80, 0xd5, 0x61, 0x33, 0x1b, 0xe5, 0xe0,
+		0x56, 0x45, 0xd1, 0x8a, 0xaa, 0xf6, 0xca, 0x94, 0x64, 0x43, 0x7d, 0xab, 0x1a, 0x27, 0xa6, 0x6e,
+		0x48, 0x86, 0x62, 0xaa, 0xda, 0x5b, 0xa9, 0xa4, 0x16, 0xc5, 0xff, 0x43, 0x5f, 0xc2, 0xe6, 0x08,
+		0x8c, 0x2e, 0x1f, 0x2a, 0xc5, 0x6a, 0x49, 0x29, 0x8a, 0xc2, 0x18, 0x4f, 0xba, 0x21, 0x61, 0x43,
+		0x29, 0x8a, 0x29, 0xf4, 0xff, 0x70, 0x7f, 0x04, 0x46, 0x96, 0x34, 0x59, 0x29, 0x99, 0x58, 0xf9,
+		0x71, 0x55, 0xd1, 0x29, 0x38, 0x9d, 0xff, 0x45, 0x37, 0xe6, 0x1e, 0x05, 0x8a, 0xbf, 0xa9, 0xa8,
+		0xc8, 0xaa, 0xae, 0x96, 0xb5, 0x71, 0x31, 0xf7, 0x61, 0x46, 0xc4, 0xdc, 0x8f, 0x8a, 0x62, 0xce,
+		0xff, 0x32, 0xd5, 0xfd, 0xfd, 0x40, 0xad, 0x63, 0xbb, 0xdd, 0xd1, 0xdc, 0x2f, 0x61, 0xf3, 0xb8,
+		0x8c, 0xdf, 0x1c, 0x94, 0xca, 0xc7, 0xa6, 0x5a, 0x34, 0xb1, 0x52, 0xd5, 0x15, 0xb3, 0x52, 0x2e,
+		0xa9, 0xf2, 0x49, 0x2c, 0x92, 0x6f, 0xe1, 0x9b, 0x91, 0x28, 0xa9, 0x44, 0xad, 0xc5, 0x6a, 0xa5,
+		0xa4, 0xca, 0xf4, 0xad, 0x07, 0x92, 0x5a, 0x52, 0x8a, 0x66, 0x59, 0x2b, 0x9d, 0x88, 0x02, 0xfa,
+		0x0a, 0xb6, 0x26, 0x65, 0x8a, 0x29, 0xb4, 0x0d, 0x0f, 0x46, 0xa2, 0xb1, 0xf2, 0x5a, 0x91, 0x8d,
+		0x18, 0x3c, 0x8d, 0x76, 0x61, 0x7b, 0x24, 0xdc, 0x50, 0xf0, 0x91, 0xaa, 0xb1, 0x84, 0x1e, 0x98,
+		0xb8, 0xaa, 0x69, 0xaa, 0xf6, 0x4a, 0x9c, 0xca, 0xff, 0x4e, 0x80, 0xe5, 0x81, 0x61, 0x84, 0x6e,
+		0xc3, 0xf5, 0x8a, 0x84, 0x15, 0xcd, 0x30, 0xe5, 0x52, 0x79, 0x58, 0x02, 0x46, 0x00, 0xa4, 0x7d,
+		0x49, 0x2b, 0x96, 0x35, 0x51, 0x40, 0xf7, 0x20, 0x37, 0x0c, 0xc0, 0x6b, 0x81, 0x97, 0x86, 0x98,
+		0x42, 0x77, 0xe0, 0xe6, 0x30, 0x5c, 0x27, 0x5a, 0x31, 0x9d, 0xff, 0x57, 0x0a, 0x6e, 0x8c, 0xfb,
+		0x99, 0x82, 0x56, 0x60, 0x67, 0xdb, 0xca, 0x3b, 0x45, 0xae, 0x1a, 0xf4, 0xcc, 0x43, 0x7f, 0xf4,
+		0xe4, 0xab, 0x7a, 0x2c, 0xf2, 0x78, 0x4a, 0x47, 0x80, 0xe5, 0xf2, 0x51, 0xa5, 0xa4, 0x18, 0xac,
+		0x9a, 0xf2, 0x70, 0x2f, 0x09, 0x1e, 0x1e, 0xb0, 0x98, 0xea, 0x39, 0xdb, 0x51, 0xae, 0xd9, 0xbe,
+		0x69, 0x2b, 0xa0, 0x02, 0xe4, 0x93, 0xd0, 0x9d, 0x2c, 0x14, 0xc5, 0x29, 0xf4, 0x0d, 0x7c, 0x9d,
+		0x1c, 0xb8, 0x66, 0xa8, 0x5a, 0x55, 0x29, 0x9a, 0x92, 0x6e, 0x6a, 0xca, 0xb1, 0x38, 0x3d, 0xc9,
+		0x76, 0x0d, 0xf5, 0x88, 0xd6, 0x67, 0xd5, 0x10, 0x67, 0xf2, 0x7f, 0x15, 0xe0, 0xaa, 0xec, 0xb9,
+		0xc4, 0x71, 0xdb, 0xb6, 0x14, 0x68, 0xf6, 0x47, 0x35, 0xbc, 0xe7, 0x78, 0x3e, 0xba, 0x0b, 0x77,
+		0x22, 0xff, 0xdc, 0xbd, 0xa9, 0x6a, 0xaa, 0xa1, 0x4a, 0x46, 0x19, 0xc7, 0xf2, 0x3b, 0x16, 0x46,
+		0x1b, 0xb2, 0xa8, 0xe0, 0x30, 0xaf, 0xa3, 0x61, 0x58, 0x31, 0xf0, 0x09, 0x2f, 0x85, 0x50, 0x61,
+		0x46, 0x63, 0x65, 0x5c, 0xd6, 0x3a, 0xfd, 0x2f, 0xa6, 0xf3, 0xbf, 0x17, 0x20, 0xc3, 0xbf, 0x6d,
+		0xd9, 0xa7, 0x4f, 0x16, 0x56, 0xe9, 0x06, 0xcb, 0x55, 0xc3, 0x34, 0x4e, 0x2a, 0x4a, 0x6f, 0x0d,
+		0xf7, 0xac, 0x30, 0x79, 0x30, 0x8d, 0x72, 0x98, 0x9d, 0x50, 0x49, 0x7a, 0x01, 0xfc, 0x2d, 0x14,
+		0xc3, 0xc0, 0x62, 0x6a, 0x2c, 0x26, 0xf4, 0x93, 0x46, 0x1b, 0x70, 0xb5, 0x07, 0x73, 0xa8, 0x48,
+		0xd8, 0xd8, 0x57, 0x24, 0x43, 0x9c, 0xca, 0xff, 0x56, 0x80, 0x6b, 0x91, 0x12, 0xd2, 0x5f, 0x16,
+		0x68, 0xe8, 0xf5, 0x72, 0x9b, 0xc8, 0x56, 0x3b, 0xb0, 0xd1, 0x03, 0xb8, 0xdb, 0xd1, 0x30, 0x43,
+		0xd2, 0xdf, 0x74, 0xcf, 0xca, 0x94, 0xa5, 0xaa, 0x1e, 0xdf, 0x4d, 0x22, 0x94, 0x87, 0x20, 0x0a,
+		0xe8, 0x3e, 0x7c, 0x31, 0x1e, 0x8a, 0x15, 0x5d, 0x31, 0xc4, 0x54, 0xfe, 0x9f, 0x19, 0x58, 0x8f,
+		0x07, 0x47, 0x3f, 0x10, 0xec, 0x7a, 0x18, 0xda, 0x3d, 0xc8, 0xf5, 0x3a, 0xe1, 0x3a, 0xd7, 0x1f,
+		0xd7, 0x2e, 0x6c, 0x8f, 0xc1, 0x55, 0xb5, 0x43, 0x49, 0x2b, 0xd2, 0xe7, 0x08, 0x24, 0x0a, 0xe8,
+		0x25, 0xec, 0x8d, 0xa1, 0xec, 0x4b, 0xc5, 0x6e, 0x96, 0x3b, 0x13, 0x47, 0x32, 0x0c, 0xac, 0xee,
+		0x57, 0x0d, 0x45, 0x17, 0x53, 0x48, 0x01, 0x29, 0xc1, 0x41, 0xaf, 0x0e, 0x0d, 0x75, 0x93, 0x46,
+		0xcf, 0xe0, 0x71, 0x52, 0x1c, 0x61, 0xc9, 0xa8, 0x47, 0x0a, 0x8e, 0x53, 0xa7, 0xd0, 0x73, 0x78,
+		0x92, 0x40, 0xe5, 0x6f, 0x1e, 0xe0, 0x4e, 0xa3, 0x3d, 0x78, 0x9a, 0x18, 0xbd, 0x5c, 0xc6, 0x45,
+		0xf3, 0x48, 0xc2, 0x6f, 0x7a, 0xc9, 0x33, 0x48, 0x05, 0x25, 0xe9, 0xc5, 0x5c, 0xdd, 0xcc, 0x21,
+		0xba, 0x10, 0x73, 0x75, 0x65, 0x82, 0x2c, 0x52, 0x43, 0x82, 0x9b, 0x59, 0xf4, 0x0a, 0xe4, 0xc9,
+		0x52, 0x31, 0xde, 0xd1, 0x1c, 0x7a, 0x07, 0xc6, 0xe7, 0x9d, 0xaa, 0xf2, 0xce, 0x50, 0xb0, 0x26,
+		0x25, 0x79, 0x06, 0xf4, 0x02, 0x9e, 0x25, 0x26, 0xad, 0x57, 0x7f, 0x62, 0xf4, 0x0c, 0x7a, 0x0a,
+		0x8f, 0xc6, 0xd0, 0xe3, 0x35, 0xd2, 0xbd, 0x15, 0xa8, 0x45, 0x71, 0x1e, 0x3d, 0x86, 0xdd, 0x31,
+		0x44, 0xd6, 0x85, 0xa6, 0x6e, 0xa8, 0xf2, 0x9b, 0x93, 0x70, 0xb9, 0xa4, 0xea, 0x86, 0xb8, 0x80,
+		0x7e, 0x04, 0x3f, 0x18, 0x43, 0xeb, 0x6c, 0x96, 0xfe, 0xa1, 0xe0, 0x58, 0x8b, 0x51, 0x58, 0x15,
+		0x2b, 0xe2, 0xe2, 0x04, 0x67, 0xa2, 0xab, 0xaf, 0x92, 0x33, 0xb7, 0x84, 0x64, 0x78, 0x39, 0x51,
+		0x8b, 0xc8, 0x87, 0x6a, 0xa9, 0x38, 0xdc, 0x89, 0x88, 0x1e, 0xc1, 0xce, 0x18, 0x27, 0x07, 0x65,
+		0x2c, 0x2b, 0x7c, 0x62, 0x75, 0x44, 0x62, 0x19, 0x3d, 0x81, 0x87, 0xe3, 0x48, 0x92, 0x5a, 0x2a,
+		0xbf, 0x55, 0x70, 0x3f, 0x0f, 0xd1, 0x31, 0x3a, 0xd9, 0xd6, 0x55, 0xad, 0x52, 0x35, 0x4c, 0x5d,
+		0xfd, 0x4e, 0x11, 0x57, 0xe8, 0x18, 0x4d, 0x3c, 0xa9, 0x28, 0x57, 0xe2, 0xea, 0xa0, 0x18, 0x0f,
+		0xbc, 0x64, 0x5f, 0xd5, 0x24, 0x7c, 0x22, 0xae, 0x25, 0xd4, 0xde, 0xa0, 0xd0, 0xf5, 0x94, 0xd0,
+		0xd5, 0x49, 0xb6, 0xa3, 0x48, 0x58, 0x3e, 0x8c, 0x67, 0x7c, 0x9d, 0x4e, 0x9d, 0x3b, 0xec, 0x07,
+		0x97, 0x81, 0x7b, 0x55, 0x5c, 0xe2, 0x77, 0x61, 0x3b, 0x3c, 0xb7, 0x21, 0x55, 0x30, 0x42, 0xed,
+		0xf7, 0xe1, 0x87, 0x93, 0x51, 0x3a, 0xeb, 0x52, 0x09, 0x2b, 0x52, 0xf1, 0xa4, 0x73, 0x25, 0x15,
+		0xf2, 0x7f, 0x13, 0x20, 0x2f, 0x5b, 0x6e, 0xcd, 0x6e, 0x44, 0xbf, 0xe3, 0x8e, 0x8d, 0x72, 0x0f,
+		0x9e, 0x4e, 0xd0, 0xef, 0x23, 0xe2, 0x3d, 0x06, 0xfd, 0x73, 0xc9, 0x55, 0xed, 0x8d, 0x56, 0x3e,
+		0xd6, 0xc6, 0x11, 0xf8, 0x26, 0x74, 0xe7, 0xdc, 0xb5, 0x26, 0xde, 0x04, 0x2f, 0xbb, 0xff, 0x6c,
+		0x13, 0x9f, 0x4b, 0x9e, 0x68, 0x13, 0xfb, 0x3f, 0x85, 0xf5, 0x9a, 0xd7, 0x1c, 0xf6, 0x15, 0xbf,
+		0xbf, 0x10, 0x6d, 0xa7, 0x42, 0x3f, 0x63, 0x2b, 0xc2, 0x77, 0xbb, 0xe7, 0x0e, 0xb9, 0x68, 0x9f,
+		0x16, 0x6a, 0x5e, 0x73, 0x27, 0xfe, 0x1f, 0xdc, 0x6d, 0xa7, 0xde, 0xd8, 0x39, 0xf7, 0xc2, 0xff,
+		0x08, 0xf3, 0x7f, 0xe7, 0xee, 0x59, 0x2d, 0xe7, 0xc3, 0xee, 0xe9, 0x0c, 0xb3, 0x3d, 0xfa, 0xf7,
+		0x00, 0x86, 0x60, 0x70, 0x2f, 0x8e, 0x1e, 0x00, 0x00,
 	},
 	// uber/cadence/api/v1/query.proto
 	[]byte{
@@ -3309,389 +3752,388 @@ var yarpcFileDescriptorClosurefee8ff76963a38ed = [][]byte{
 		0x5c, 0xdb, 0x34, 0x3e, 0x88, 0xb5, 0xab, 0x3b, 0x78, 0x11, 0xd0, 0x69, 0xd5, 0x8b, 0x5e, 0x41,
 		0x11, 0xd0, 0xca, 0x2f, 0xc8, 0x12, 0xee, 0xba, 0x93, 0x30, 0xfd, 0x9c, 0x8d, 0xa4, 0x80, 0x4e,
 		0xe5, 0xcd, 0x93, 0x7b, 0x1d, 0x8e, 0x23, 0x79, 0x42, 0xe5, 0xe2, 0xd2, 0xca, 0xfb, 0xbb, 0xf4,
-		0x93, 0x70, 0xd6, 0x1d, 0xed, 0x14, 0xd8, 0xdb, 0x5f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xbd, 0x69,
-		0x28, 0x5b, 0xfb, 0x03, 0x00, 0x00,
+		0x93, 0x70, 0xd6, 0x1d, 0xed, 0x14, 0xd8, 0xdb, 0x5f, 0x03, 0x00, 0xbd, 0x69, 0x28, 0x5b, 0xfb,
+		0x03, 0x00, 0x00,
 	},
 	// uber/cadence/api/v1/service_workflow.proto
 	[]byte{
 		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x5a, 0x5b, 0x6f, 0xdc, 0xc6,
-		0x15, 0x06, 0x75, 0xd7, 0x59, 0x49, 0x96, 0xc7, 0xba, 0xac, 0x57, 0xd6, 0xc5, 0x74, 0x9c, 0xaa,
-		0x8e, 0xbd, 0xaa, 0xa5, 0xf8, 0x12, 0x27, 0x6d, 0x20, 0xcb, 0x96, 0xa3, 0xc2, 0x0e, 0x54, 0x4a,
-		0x8d, 0xd1, 0xbe, 0x10, 0x23, 0x72, 0x76, 0x77, 0x22, 0x2e, 0x49, 0xcd, 0x0c, 0xa5, 0x6c, 0xf2,
-		0x50, 0xb4, 0x08, 0xd2, 0xa2, 0x37, 0xb4, 0x8f, 0x2d, 0x0a, 0xf4, 0xa1, 0x7d, 0xce, 0x4b, 0xff,
-		0x42, 0xd1, 0xff, 0xd1, 0x5f, 0xd0, 0x7f, 0x10, 0x14, 0x1c, 0x0e, 0xf7, 0xa6, 0x21, 0x77, 0x57,
-		0x46, 0x60, 0xb7, 0x6f, 0xcb, 0x99, 0xf3, 0x9d, 0x39, 0xb7, 0x39, 0x3c, 0xe7, 0x70, 0xe1, 0x56,
-		0x74, 0x44, 0xd8, 0x86, 0x83, 0x5d, 0xe2, 0x3b, 0x64, 0x03, 0x87, 0x74, 0xe3, 0xf4, 0xee, 0x06,
-		0x27, 0xec, 0x94, 0x3a, 0xc4, 0x3e, 0x0b, 0xd8, 0x71, 0xc5, 0x0b, 0xce, 0xca, 0x21, 0x0b, 0x44,
-		0x80, 0xae, 0xc4, 0xb4, 0x65, 0x45, 0x5b, 0xc6, 0x21, 0x2d, 0x9f, 0xde, 0x2d, 0xad, 0x54, 0x83,
-		0xa0, 0xea, 0x91, 0x0d, 0x49, 0x72, 0x14, 0x55, 0x36, 0xdc, 0x88, 0x61, 0x41, 0x03, 0x3f, 0x01,
-		0x95, 0xd6, 0x74, 0x07, 0x38, 0x41, 0xbd, 0xde, 0xa4, 0xb8, 0xae, 0xa3, 0xa8, 0x51, 0x2e, 0x02,
-		0xd6, 0x50, 0x24, 0xab, 0x3a, 0x92, 0x93, 0x88, 0x34, 0x09, 0x4c, 0x1d, 0x81, 0xc0, 0xfc, 0xd8,
-		0xa3, 0x5c, 0xe4, 0xd1, 0x74, 0xaa, 0x68, 0xfe, 0xd3, 0x80, 0x55, 0x8b, 0x70, 0x81, 0x99, 0x78,
-		0xa9, 0x76, 0x9e, 0x7e, 0x46, 0x9c, 0x28, 0x56, 0xc8, 0x22, 0x27, 0x11, 0xe1, 0x02, 0x2d, 0xc0,
-		0x98, 0x1b, 0xd4, 0x31, 0xf5, 0x8b, 0xc6, 0x9a, 0xb1, 0x3e, 0x69, 0xa9, 0x27, 0xf4, 0x63, 0x40,
-		0x29, 0x37, 0x9b, 0xa4, 0xa0, 0xe2, 0xd0, 0x9a, 0xb1, 0x5e, 0xd8, 0x7c, 0xbb, 0xac, 0xb1, 0x5d,
-		0xf9, 0xfc, 0x11, 0x97, 0xcf, 0xba, 0x97, 0x50, 0x09, 0x26, 0xa8, 0x4b, 0x7c, 0x41, 0x45, 0xa3,
-		0x38, 0x2c, 0x0f, 0x6c, 0x3e, 0xc7, 0xa2, 0x30, 0x82, 0x79, 0xe0, 0x17, 0x47, 0x12, 0x51, 0x92,
-		0x27, 0xf3, 0x57, 0x13, 0xb0, 0x7c, 0x70, 0x21, 0x25, 0x56, 0xa1, 0xd0, 0x54, 0x82, 0xba, 0x52,
-		0xfa, 0x49, 0x0b, 0xd2, 0xa5, 0x3d, 0x17, 0xed, 0xc2, 0x74, 0x93, 0x40, 0x34, 0x42, 0x22, 0x65,
-		0x2a, 0x6c, 0x5e, 0xcf, 0x55, 0xf0, 0xb0, 0x11, 0x12, 0x6b, 0xea, 0xac, 0xed, 0x09, 0x3d, 0x82,
-		0xc9, 0xd8, 0x3f, 0x76, 0xec, 0x20, 0x29, 0x7d, 0x61, 0x73, 0x59, 0xcb, 0xe3, 0x10, 0xf3, 0xe3,
-		0xe7, 0x94, 0x0b, 0x6b, 0x42, 0xa8, 0x5f, 0x68, 0x13, 0x46, 0xa9, 0x1f, 0x46, 0xa2, 0x38, 0x2a,
-		0x71, 0xd7, 0xb4, 0xb8, 0x7d, 0xdc, 0xf0, 0x02, 0xec, 0x5a, 0x09, 0x29, 0xc2, 0xb0, 0xd6, 0x74,
-		0x8a, 0x2d, 0x1d, 0x6c, 0x8b, 0xc0, 0x76, 0xbc, 0x80, 0x13, 0x5b, 0xd0, 0x3a, 0x09, 0x22, 0x51,
-		0x1c, 0x93, 0xec, 0xae, 0x96, 0x93, 0x90, 0x2e, 0xa7, 0x21, 0x5d, 0x7e, 0xa2, 0x42, 0xda, 0xba,
-		0xd6, 0x64, 0x21, 0xad, 0x7b, 0x18, 0xec, 0xc4, 0xf8, 0xc3, 0x04, 0x8e, 0x5e, 0xc2, 0x92, 0x54,
-		0x29, 0x83, 0xfb, 0x78, 0x2f, 0xee, 0x8b, 0x31, 0x5a, 0xc7, 0xb8, 0x3d, 0x04, 0x26, 0xba, 0x42,
-		0x60, 0x19, 0x80, 0x25, 0x3e, 0x8d, 0xfd, 0x35, 0x29, 0x77, 0x27, 0xd5, 0xca, 0x9e, 0x8b, 0x1c,
-		0x28, 0xb6, 0xf9, 0xd3, 0x66, 0x24, 0xe2, 0xc4, 0x0e, 0x03, 0x8f, 0x3a, 0x8d, 0x22, 0xac, 0x19,
-		0xeb, 0x33, 0x9b, 0xb7, 0x72, 0x3d, 0xb7, 0xe7, 0x5a, 0x31, 0x64, 0x5f, 0x22, 0xac, 0xf9, 0x33,
-		0xdd, 0x32, 0xda, 0x81, 0x29, 0x46, 0x04, 0x6b, 0xa4, 0x8c, 0x0b, 0x52, 0xd3, 0x35, 0x2d, 0x63,
-		0x2b, 0x26, 0x54, 0xec, 0x0a, 0xac, 0xf5, 0x80, 0x6e, 0xc0, 0xb4, 0xc3, 0x62, 0xdf, 0x38, 0x35,
-		0xe2, 0x46, 0x1e, 0x29, 0x4e, 0x49, 0x5d, 0xa6, 0xe2, 0xc5, 0x03, 0xb5, 0x86, 0xee, 0xc0, 0x48,
-		0x9d, 0xd4, 0x83, 0xe2, 0xb4, 0xb2, 0xa5, 0xee, 0x84, 0x17, 0xa4, 0x1e, 0x58, 0x92, 0x0c, 0x59,
-		0x70, 0x99, 0x13, 0xcc, 0x9c, 0x9a, 0x8d, 0x85, 0x60, 0xf4, 0x28, 0x12, 0x84, 0x17, 0x67, 0x24,
-		0xf6, 0xa6, 0x16, 0x7b, 0x20, 0xa9, 0xb7, 0x9b, 0xc4, 0xd6, 0x2c, 0xef, 0x5a, 0x41, 0x5b, 0x30,
-		0x56, 0x23, 0xd8, 0x25, 0xac, 0x78, 0x49, 0x32, 0x5a, 0xd2, 0x32, 0xfa, 0x48, 0x92, 0x58, 0x8a,
-		0x14, 0x3d, 0x82, 0x82, 0x4b, 0x3c, 0xdc, 0x48, 0x62, 0xa3, 0x38, 0xdb, 0x2b, 0x14, 0x40, 0x52,
-		0xcb, 0x58, 0x40, 0x1f, 0xc0, 0xd4, 0xa7, 0x54, 0x08, 0xc2, 0x14, 0xf8, 0x72, 0x2f, 0x70, 0x21,
-		0x21, 0x97, 0x68, 0xf3, 0x01, 0xac, 0x64, 0x65, 0x02, 0x1e, 0x06, 0x3e, 0x27, 0x68, 0x1e, 0xc6,
-		0x58, 0xe4, 0xc7, 0xd1, 0x93, 0xa4, 0x82, 0x51, 0x16, 0xf9, 0x7b, 0xae, 0xc9, 0xc0, 0xd4, 0x03,
-		0xb7, 0x79, 0xc3, 0x77, 0xd2, 0x3c, 0xf2, 0x1c, 0xc6, 0x55, 0xb0, 0x49, 0x74, 0x61, 0x73, 0x53,
-		0x6f, 0xd7, 0xbc, 0x64, 0x64, 0xa5, 0x2c, 0xcc, 0x9b, 0x70, 0x23, 0xf7, 0xcc, 0x44, 0x62, 0xf3,
-		0x3d, 0x58, 0xcb, 0x4e, 0xd2, 0xf9, 0x5a, 0xfd, 0x6b, 0x08, 0x56, 0x0e, 0x68, 0xd5, 0xc7, 0xde,
-		0xff, 0x42, 0x7e, 0xef, 0xbc, 0xdc, 0x23, 0xdd, 0x97, 0x7b, 0x15, 0x0a, 0x5c, 0xea, 0x62, 0xfb,
-		0xb8, 0x4e, 0x64, 0x36, 0x9c, 0xb4, 0x20, 0x59, 0xfa, 0x18, 0xd7, 0x09, 0xfa, 0x10, 0xa6, 0x14,
-		0x41, 0x92, 0x2f, 0xc7, 0xfa, 0xc8, 0x97, 0x8a, 0xe5, 0x9e, 0xcc, 0x9a, 0x45, 0x18, 0x77, 0x02,
-		0x5f, 0xb0, 0xc0, 0x93, 0xe9, 0x6b, 0xca, 0x4a, 0x1f, 0xcd, 0xeb, 0xb0, 0x9a, 0x69, 0x47, 0xe5,
-		0xa6, 0x6f, 0x0c, 0xf8, 0x8e, 0xa2, 0xa1, 0xa2, 0x96, 0xff, 0x3e, 0x7a, 0x09, 0xd3, 0x49, 0xda,
-		0x7c, 0xf5, 0x68, 0x9a, 0x92, 0x8c, 0x52, 0xc6, 0x5d, 0x36, 0x1a, 0xea, 0x69, 0xa3, 0xe1, 0x57,
-		0xb0, 0xd1, 0x48, 0xa7, 0x8d, 0xb6, 0x61, 0xbd, 0xb7, 0xfe, 0xf9, 0xf1, 0xfa, 0x95, 0x01, 0xb7,
-		0x7b, 0xf1, 0xe8, 0xb8, 0x90, 0x9f, 0x74, 0x5f, 0xc8, 0x0f, 0xf4, 0x26, 0xec, 0xcf, 0x2f, 0xad,
-		0xab, 0xb9, 0x01, 0x77, 0xfa, 0x94, 0x43, 0x79, 0xff, 0xeb, 0x21, 0x58, 0xb6, 0x08, 0x27, 0x6f,
-		0x4c, 0x21, 0xd5, 0x2a, 0x96, 0x86, 0xdb, 0x8b, 0x25, 0xf4, 0x00, 0x8a, 0x2e, 0x71, 0x28, 0x8f,
-		0x0b, 0x83, 0x0a, 0xf5, 0x29, 0xaf, 0xd9, 0xe4, 0x94, 0xf8, 0xcd, 0x2b, 0x37, 0x6c, 0xcd, 0xa7,
-		0xfb, 0xbb, 0x72, 0xfb, 0x69, 0xbc, 0xbb, 0xe7, 0x76, 0xdd, 0xce, 0xd1, 0xee, 0xdb, 0x59, 0x86,
-		0x2b, 0xfc, 0x98, 0x86, 0xb6, 0x8a, 0x2e, 0x46, 0x70, 0x18, 0x7a, 0x0d, 0x79, 0x07, 0x27, 0xac,
-		0xcb, 0xf1, 0x56, 0x62, 0x50, 0x2b, 0xd9, 0x88, 0x33, 0x75, 0x96, 0xbd, 0xf2, 0x63, 0xe4, 0x2f,
-		0x43, 0x70, 0x53, 0xd9, 0x74, 0x07, 0xfb, 0x0e, 0xf9, 0x7f, 0x48, 0x6d, 0x73, 0x30, 0xea, 0xe0,
-		0x88, 0xa7, 0x49, 0x2d, 0x79, 0x40, 0x5b, 0xb0, 0x50, 0xa1, 0x8c, 0x8b, 0x96, 0x90, 0xb6, 0x32,
-		0xc8, 0x98, 0x24, 0xbb, 0x22, 0x77, 0x5b, 0x32, 0x49, 0xf3, 0xac, 0xc3, 0xdb, 0xbd, 0xac, 0xa3,
-		0x42, 0xf6, 0x1f, 0x43, 0x70, 0xfd, 0x90, 0xb0, 0x3a, 0xf5, 0xb1, 0x20, 0x6f, 0x7a, 0xd8, 0xde,
-		0x87, 0x71, 0x97, 0x08, 0x4c, 0x3d, 0xae, 0xca, 0xe7, 0xfc, 0x94, 0x95, 0x12, 0x77, 0x38, 0x65,
-		0xb4, 0xcb, 0x29, 0x17, 0xb2, 0xef, 0x5b, 0x60, 0xe6, 0x19, 0x4d, 0xd9, 0xf6, 0x8f, 0x06, 0xac,
-		0x3d, 0x21, 0xdc, 0x61, 0xf4, 0xe8, 0x4d, 0x31, 0xad, 0xf9, 0xcd, 0x30, 0x5c, 0xcf, 0x91, 0x49,
-		0xdd, 0x3a, 0x0f, 0x16, 0x5b, 0xe6, 0x70, 0x02, 0xbf, 0x42, 0xab, 0xaa, 0xd2, 0x52, 0x19, 0x76,
-		0xab, 0x3f, 0x09, 0x76, 0xda, 0xa1, 0xd6, 0x02, 0xd1, 0xae, 0xa3, 0x23, 0x58, 0x3c, 0xaf, 0xaa,
-		0x4d, 0xfd, 0x4a, 0xa0, 0xf4, 0xbd, 0xd5, 0xdf, 0x69, 0x7b, 0x7e, 0x25, 0x68, 0xd5, 0xeb, 0x1d,
-		0xcb, 0xe8, 0x25, 0xa0, 0x90, 0xf8, 0x2e, 0xf5, 0xab, 0x36, 0x76, 0x04, 0x3d, 0xa5, 0x82, 0x12,
-		0x5e, 0x1c, 0x5e, 0x1b, 0x5e, 0x2f, 0x6c, 0xae, 0xeb, 0xa3, 0x28, 0x21, 0xdf, 0x4e, 0xa8, 0x1b,
-		0x92, 0xf9, 0xe5, 0xb0, 0x63, 0x91, 0x12, 0x8e, 0x7e, 0x02, 0xb3, 0x29, 0x63, 0xa7, 0x46, 0x3d,
-		0x97, 0x91, 0xb8, 0x33, 0x8d, 0xd9, 0x96, 0xf3, 0xd8, 0xee, 0xc4, 0xb4, 0x9d, 0x92, 0x5f, 0x0a,
-		0xdb, 0xb6, 0x18, 0xf1, 0xd1, 0x41, 0x8b, 0x75, 0x9a, 0x8d, 0x55, 0xfb, 0x97, 0x2b, 0xf1, 0x13,
-		0x45, 0xdb, 0xc1, 0x34, 0x5d, 0x34, 0xbf, 0x1c, 0x86, 0xb9, 0x1f, 0x45, 0x84, 0x35, 0x52, 0xf3,
-		0xbd, 0xa6, 0x3b, 0xfe, 0x10, 0x46, 0xe5, 0x34, 0x43, 0x15, 0x1f, 0x66, 0x2e, 0x27, 0x29, 0xb0,
-		0x95, 0x00, 0x90, 0x0d, 0x0b, 0xf2, 0x87, 0xcd, 0xc8, 0xa7, 0xc4, 0x11, 0x71, 0x7c, 0xba, 0x54,
-		0x0a, 0x35, 0x22, 0xbb, 0xbb, 0xef, 0x6a, 0x59, 0x25, 0x2c, 0x24, 0x62, 0x27, 0x05, 0x58, 0x73,
-		0x27, 0x9a, 0xd5, 0x38, 0x1e, 0x93, 0x03, 0x9c, 0xc0, 0xe7, 0x94, 0x0b, 0xe2, 0x3b, 0x0d, 0xdb,
-		0x23, 0xa7, 0xc4, 0x93, 0xe6, 0xcf, 0xea, 0x1f, 0xe5, 0x09, 0x3b, 0x2d, 0xc8, 0xf3, 0x18, 0x61,
-		0xcd, 0x9f, 0xe8, 0x96, 0xcd, 0xbf, 0x19, 0x30, 0xdf, 0xe5, 0x06, 0x75, 0xf7, 0x3e, 0x84, 0xa9,
-		0x54, 0x3d, 0x1e, 0x79, 0x69, 0x49, 0xd3, 0xa3, 0x38, 0x53, 0x7a, 0xc4, 0x00, 0xb4, 0x07, 0x33,
-		0xed, 0xf6, 0x21, 0xae, 0x72, 0x96, 0xd9, 0xcb, 0x2e, 0xc4, 0xb5, 0xa6, 0x4f, 0xda, 0x1f, 0xcd,
-		0xff, 0x18, 0xb0, 0x98, 0x66, 0x8b, 0xe6, 0x50, 0xa2, 0x47, 0xbc, 0x74, 0x4c, 0x39, 0x86, 0x06,
-		0x9b, 0x72, 0x3c, 0x83, 0x99, 0x26, 0xb6, 0x35, 0x6a, 0x99, 0xc9, 0x18, 0xb5, 0xa4, 0x0c, 0x92,
-		0x51, 0x8b, 0x68, 0x7b, 0x8a, 0x0b, 0x1c, 0xea, 0x3b, 0x5e, 0xe4, 0x12, 0xbb, 0xc5, 0x90, 0x0b,
-		0x2c, 0xa2, 0xe4, 0xd5, 0x31, 0x61, 0xcd, 0xab, 0xfd, 0x94, 0xc9, 0x81, 0xdc, 0x34, 0xff, 0x6e,
-		0x40, 0xf1, 0xbc, 0xc6, 0xca, 0x35, 0xef, 0xc1, 0x78, 0x18, 0x78, 0x1e, 0x61, 0xbc, 0x68, 0xc8,
-		0x2b, 0xbe, 0xaa, 0xf7, 0x8a, 0xa4, 0x91, 0xd7, 0x2f, 0xa5, 0x47, 0x2f, 0x60, 0xf6, 0x9c, 0x20,
-		0x89, 0x71, 0x6e, 0xe4, 0xea, 0x96, 0x88, 0x65, 0xcd, 0x88, 0x4e, 0x31, 0xef, 0xc1, 0xd2, 0x33,
-		0x22, 0x52, 0x22, 0xfe, 0xb8, 0xf1, 0x44, 0x1a, 0xbf, 0x87, 0x6f, 0xcc, 0xdf, 0x8f, 0xc0, 0x35,
-		0x3d, 0x4e, 0x69, 0xf8, 0x33, 0x58, 0x68, 0x16, 0x86, 0x2d, 0x79, 0xeb, 0x38, 0x54, 0x0a, 0xff,
-		0x50, 0x2b, 0x6c, 0x1e, 0xcb, 0x72, 0x9a, 0x79, 0x52, 0x8a, 0x17, 0x38, 0x7c, 0xea, 0x0b, 0xd6,
-		0xb0, 0xae, 0xb8, 0xe7, 0x77, 0x62, 0x01, 0x54, 0x7e, 0x6e, 0x74, 0x09, 0x30, 0x74, 0x51, 0x01,
-		0xd2, 0x0c, 0x7e, 0x5e, 0x00, 0x7c, 0x7e, 0xa7, 0x14, 0xc5, 0xfe, 0xd7, 0x4b, 0x8c, 0x66, 0x61,
-		0xf8, 0x98, 0x34, 0x94, 0x4d, 0xe3, 0x9f, 0x68, 0x07, 0x46, 0x4f, 0xb1, 0x17, 0x11, 0xe5, 0xcb,
-		0x3b, 0x5a, 0xe9, 0xb2, 0xe2, 0xc9, 0x4a, 0xb0, 0x8f, 0x86, 0x1e, 0x1a, 0xf1, 0xb1, 0x59, 0x72,
-		0x7e, 0x8b, 0xc7, 0x9a, 0x1c, 0x96, 0xe5, 0x9d, 0x51, 0x24, 0xfb, 0x98, 0x09, 0x99, 0x03, 0xf9,
-		0xb7, 0x78, 0xcb, 0xcd, 0xaf, 0x86, 0x60, 0x25, 0xeb, 0x54, 0x15, 0x87, 0x27, 0xb0, 0xac, 0x09,
-		0x83, 0xb0, 0x49, 0xa8, 0xc2, 0xb1, 0x9c, 0x7b, 0x64, 0x93, 0xef, 0x0b, 0x22, 0xb0, 0x8b, 0x05,
-		0xb6, 0x4a, 0xdd, 0x1e, 0x6f, 0x1d, 0x1d, 0x1f, 0xa9, 0x09, 0xfd, 0xb6, 0x23, 0x87, 0x2e, 0x76,
-		0x64, 0x77, 0x94, 0xb7, 0x8e, 0x34, 0x17, 0x61, 0xfe, 0x19, 0x11, 0x3b, 0x5e, 0xc4, 0x85, 0xca,
-		0x17, 0xaa, 0xf3, 0xfc, 0x85, 0x01, 0x0b, 0xdd, 0x3b, 0xca, 0x32, 0x35, 0xb8, 0xca, 0xa3, 0x30,
-		0x0c, 0x98, 0x20, 0xae, 0xed, 0x78, 0x34, 0xee, 0xda, 0x4e, 0x09, 0xe3, 0xca, 0x2a, 0xb1, 0x23,
-		0x6e, 0xeb, 0xdb, 0xdf, 0x14, 0xb5, 0x23, 0x41, 0x9f, 0x28, 0x8c, 0xb5, 0xc8, 0xf5, 0x1b, 0xe6,
-		0x6f, 0x86, 0xc1, 0x7c, 0xa6, 0xe9, 0xcd, 0x3e, 0x4a, 0xbe, 0x53, 0xbc, 0xa6, 0xba, 0x61, 0x09,
-		0x26, 0x43, 0x5c, 0x25, 0x36, 0xa7, 0x9f, 0x27, 0x6f, 0x87, 0x51, 0x6b, 0x22, 0x5e, 0x38, 0xa0,
-		0x9f, 0x13, 0xf4, 0x36, 0x5c, 0xf2, 0xc9, 0x67, 0xb1, 0xd7, 0xaa, 0xc4, 0x16, 0xc1, 0x31, 0xf1,
-		0xd5, 0x7c, 0x62, 0x3a, 0x5e, 0xde, 0xc7, 0x55, 0x72, 0x18, 0x2f, 0xa2, 0x77, 0x00, 0x9d, 0x61,
-		0x2a, 0xec, 0x4a, 0xc0, 0x6c, 0x9f, 0x9c, 0x25, 0xcd, 0xaf, 0x7c, 0xb9, 0x4f, 0x58, 0x97, 0xe2,
-		0x9d, 0xdd, 0x80, 0x7d, 0x4c, 0xce, 0x64, 0xd7, 0x8b, 0x6c, 0xb8, 0xaa, 0x3e, 0xcd, 0xa8, 0x26,
-		0xb9, 0x42, 0x3d, 0x41, 0x58, 0xf2, 0x7e, 0x1a, 0x93, 0xef, 0xa7, 0xb7, 0xb4, 0xfa, 0x48, 0xf8,
-		0xae, 0x24, 0x96, 0xaf, 0xa8, 0x05, 0xc5, 0xa6, 0x6b, 0x1d, 0xdd, 0x80, 0x69, 0xd9, 0x35, 0x63,
-		0xe6, 0xd4, 0xe8, 0x29, 0x4e, 0xe6, 0x4e, 0x13, 0xd6, 0x54, 0xbc, 0xb8, 0xad, 0xd6, 0xcc, 0x7f,
-		0x1b, 0x70, 0x23, 0xd7, 0x1b, 0x2a, 0x3e, 0xee, 0xc3, 0xb8, 0x3a, 0x26, 0xb7, 0x72, 0x48, 0x61,
-		0x29, 0x31, 0xfa, 0x01, 0x14, 0x18, 0x3e, 0xb3, 0x53, 0x6c, 0x12, 0xec, 0xfa, 0x2b, 0xfd, 0x04,
-		0x0b, 0xfc, 0xd8, 0x0b, 0x8e, 0x2c, 0x60, 0xf8, 0x4c, 0x31, 0xd2, 0x99, 0x7e, 0x58, 0x67, 0xfa,
-		0x12, 0x4c, 0x24, 0x7a, 0x12, 0x57, 0xbd, 0x89, 0x9b, 0xcf, 0x66, 0x03, 0xa6, 0x76, 0x09, 0x16,
-		0x11, 0x23, 0xbb, 0x1e, 0xae, 0x72, 0x44, 0x61, 0x53, 0xd3, 0x18, 0x60, 0x8f, 0x11, 0xec, 0xc6,
-		0xd5, 0x59, 0x3d, 0xf4, 0x48, 0x7c, 0x0d, 0x08, 0x63, 0x01, 0xb3, 0x89, 0x8f, 0x8f, 0x3c, 0x92,
-		0x0c, 0x0a, 0x26, 0xac, 0x3b, 0xe7, 0x42, 0x67, 0x3b, 0xc1, 0xed, 0xa4, 0xb0, 0xa7, 0x31, 0xea,
-		0x69, 0x02, 0x32, 0x7f, 0x6b, 0xc0, 0x92, 0x45, 0x2a, 0x8c, 0xf0, 0x5a, 0xf3, 0x0b, 0x0e, 0xe6,
-		0xc7, 0xfc, 0x35, 0xb5, 0x69, 0x2b, 0x70, 0x4d, 0x2f, 0x4d, 0xe2, 0xe5, 0xcd, 0x3f, 0x23, 0x28,
-		0xa4, 0x3b, 0xdb, 0xfb, 0x7b, 0xe8, 0x97, 0x06, 0x14, 0xb3, 0xe6, 0xc3, 0xe8, 0xdd, 0x8c, 0xaf,
-		0x12, 0xb9, 0xdf, 0xfc, 0x4a, 0xf7, 0x06, 0x44, 0xa9, 0xf8, 0xfb, 0xb9, 0x01, 0x0b, 0xfa, 0x59,
-		0x19, 0xba, 0xc0, 0x64, 0xb3, 0xb4, 0x35, 0x10, 0x46, 0xc9, 0xf0, 0x07, 0x03, 0x96, 0x72, 0xe6,
-		0x75, 0xe8, 0xc1, 0x00, 0x4c, 0xdb, 0x27, 0x8d, 0xa5, 0x87, 0x83, 0x03, 0x95, 0x48, 0x5f, 0x1a,
-		0xb0, 0x98, 0x31, 0x3c, 0x46, 0x5b, 0x79, 0xe3, 0xca, 0x2c, 0xc3, 0xbc, 0x3b, 0x18, 0x48, 0x89,
-		0xf1, 0x57, 0x03, 0xd6, 0x7a, 0xcd, 0x34, 0xd1, 0x2b, 0x8d, 0x4f, 0x4b, 0xdf, 0xbf, 0x20, 0x5a,
-		0x49, 0xf8, 0xb5, 0x01, 0x37, 0xfb, 0x9a, 0xba, 0xa2, 0xed, 0x0b, 0x1d, 0xd4, 0xe1, 0xcf, 0xc7,
-		0xaf, 0xc2, 0xa2, 0x2d, 0xe0, 0xf5, 0x43, 0xcc, 0x8c, 0x80, 0xcf, 0x9d, 0x10, 0x67, 0x04, 0x7c,
-		0x8f, 0x29, 0xe9, 0x9f, 0x0c, 0x58, 0xc9, 0x1f, 0xf8, 0xa1, 0x47, 0x19, 0x7c, 0xfb, 0x98, 0xa1,
-		0x96, 0xde, 0xbf, 0x10, 0x56, 0xc9, 0xf6, 0x3b, 0x03, 0x4a, 0xd9, 0xc3, 0x32, 0x74, 0x5f, 0x5f,
-		0x4f, 0xf5, 0x1a, 0x49, 0x96, 0x1e, 0x0c, 0x8c, 0x53, 0xf2, 0xfc, 0xda, 0x80, 0xab, 0x99, 0x13,
-		0x30, 0x74, 0x2f, 0xb7, 0x94, 0xce, 0x94, 0xe6, 0xfe, 0xa0, 0x30, 0x25, 0x4c, 0x05, 0xa6, 0x3b,
-		0xa6, 0x00, 0x28, 0x67, 0x78, 0xd1, 0x35, 0xb0, 0x29, 0xdd, 0xea, 0x87, 0x54, 0x9d, 0x13, 0xc0,
-		0x6c, 0x77, 0x3b, 0x80, 0x6e, 0xf7, 0xd9, 0x35, 0x24, 0xa7, 0x0d, 0xd6, 0x63, 0xa0, 0x2f, 0x60,
-		0x4e, 0xd7, 0x94, 0xa1, 0xef, 0x0d, 0xd0, 0xbf, 0x25, 0x07, 0xdf, 0x1d, 0xb8, 0xe3, 0x93, 0x57,
-		0x52, 0xdf, 0x60, 0x64, 0x5c, 0xc9, 0xdc, 0x1e, 0x28, 0xe3, 0x4a, 0xf6, 0xe8, 0x60, 0x28, 0xcc,
-		0x74, 0x56, 0xf0, 0xe8, 0x56, 0x96, 0x22, 0xe7, 0x1b, 0x80, 0xd2, 0x3b, 0x7d, 0xd1, 0xb6, 0xbd,
-		0xee, 0x72, 0x4a, 0xc3, 0x8c, 0xd7, 0x5d, 0xef, 0xd2, 0x3e, 0xe3, 0x75, 0xd7, 0x4f, 0x15, 0xfa,
-		0x05, 0xcc, 0xe9, 0xea, 0x97, 0x0c, 0xf7, 0xe7, 0x14, 0x5e, 0x19, 0xee, 0xcf, 0x2b, 0x8e, 0x1e,
-		0xbb, 0xb0, 0xe8, 0x04, 0x75, 0x1d, 0xee, 0xf1, 0x5c, 0x8a, 0x38, 0x48, 0xfe, 0xef, 0xb5, 0xcf,
-		0x02, 0x11, 0xec, 0x1b, 0x3f, 0xbd, 0x5b, 0xa5, 0xa2, 0x16, 0x1d, 0x95, 0x9d, 0xa0, 0xbe, 0xd1,
-		0xfe, 0x9f, 0xa9, 0x3b, 0xd4, 0xf5, 0x36, 0xaa, 0x41, 0xf2, 0x57, 0x2f, 0xf5, 0x07, 0xaa, 0xf7,
-		0x71, 0x48, 0x4f, 0xef, 0x1e, 0x8d, 0xc9, 0xb5, 0xad, 0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0x9b,
-		0x56, 0x6f, 0xd0, 0x4f, 0x26, 0x00, 0x00,
+		0xf5, 0x07, 0x57, 0xf7, 0xb3, 0x92, 0x2c, 0x8f, 0x2d, 0x89, 0x5e, 0x59, 0x37, 0x3a, 0xce, 0x5f,
+		0x7f, 0x27, 0x5e, 0x55, 0x52, 0x7c, 0x89, 0x93, 0x36, 0x90, 0x65, 0xcb, 0x51, 0x61, 0x07, 0x2a,
+		0xa5, 0xc6, 0x68, 0x5f, 0x88, 0x11, 0x39, 0x92, 0x26, 0xe2, 0x92, 0xd4, 0xcc, 0x50, 0xca, 0xa6,
+		0x0f, 0x45, 0x8b, 0x20, 0x2d, 0x7a, 0x43, 0xfb, 0xd8, 0xa2, 0x40, 0x1f, 0xda, 0xe7, 0xbc, 0xf4,
+		0x2b, 0x14, 0xfd, 0x1e, 0xfd, 0x04, 0xfd, 0x06, 0x41, 0x31, 0xc3, 0xe1, 0xde, 0x44, 0x72, 0x77,
+		0x65, 0x04, 0x76, 0xfb, 0xb6, 0x9c, 0x39, 0xbf, 0x73, 0x9f, 0xc3, 0x39, 0x87, 0x0b, 0x77, 0xe2,
+		0x03, 0xc2, 0x56, 0x5d, 0xec, 0x91, 0xc0, 0x25, 0xab, 0x38, 0xa2, 0xab, 0x67, 0x6b, 0xab, 0x9c,
+		0xb0, 0x33, 0xea, 0x12, 0xe7, 0x3c, 0x64, 0x27, 0x87, 0x7e, 0x78, 0x5e, 0x8d, 0x58, 0x28, 0x42,
+		0x74, 0x4d, 0xd2, 0x56, 0x35, 0x6d, 0x15, 0x47, 0xb4, 0x7a, 0xb6, 0x56, 0x59, 0x38, 0x0a, 0xc3,
+		0x23, 0x9f, 0xac, 0x2a, 0x92, 0x83, 0xf8, 0x70, 0xd5, 0x8b, 0x19, 0x16, 0x34, 0x0c, 0x12, 0x50,
+		0x65, 0x29, 0x4b, 0x80, 0x1b, 0xd6, 0x6a, 0x0d, 0x8a, 0xe5, 0x2c, 0x8a, 0x63, 0xca, 0x45, 0xc8,
+		0xea, 0x9a, 0x64, 0x31, 0x8b, 0xe4, 0x34, 0x26, 0x0d, 0x02, 0x2b, 0x8b, 0x40, 0x60, 0x7e, 0xe2,
+		0x53, 0x2e, 0x8a, 0x68, 0xda, 0x4d, 0xb4, 0xfe, 0x61, 0xc0, 0xa2, 0x4d, 0xb8, 0xc0, 0x4c, 0xbc,
+		0xd4, 0x3b, 0x4f, 0x3f, 0x27, 0x6e, 0x2c, 0x0d, 0xb2, 0xc9, 0x69, 0x4c, 0xb8, 0x40, 0x33, 0x30,
+		0xec, 0x85, 0x35, 0x4c, 0x03, 0xd3, 0x58, 0x32, 0x56, 0xc6, 0x6c, 0xfd, 0x84, 0x7e, 0x08, 0x28,
+		0xe5, 0xe6, 0x90, 0x14, 0x64, 0x96, 0x96, 0x8c, 0x95, 0xf2, 0xfa, 0xdb, 0xd5, 0x0c, 0xdf, 0x55,
+		0x2f, 0x8a, 0xb8, 0x7a, 0xde, 0xb9, 0x84, 0x2a, 0x30, 0x4a, 0x3d, 0x12, 0x08, 0x2a, 0xea, 0xe6,
+		0x80, 0x12, 0xd8, 0x78, 0x96, 0xaa, 0x30, 0x82, 0x79, 0x18, 0x98, 0x83, 0x89, 0x2a, 0xc9, 0x93,
+		0xf5, 0xcb, 0x51, 0x98, 0xdf, 0xbb, 0x94, 0x11, 0x8b, 0x50, 0x6e, 0x18, 0x41, 0x3d, 0xa5, 0xfd,
+		0x98, 0x0d, 0xe9, 0xd2, 0x8e, 0x87, 0xb6, 0x61, 0xa2, 0x41, 0x20, 0xea, 0x11, 0x51, 0x3a, 0x95,
+		0xd7, 0x97, 0x0b, 0x0d, 0xdc, 0xaf, 0x47, 0xc4, 0x1e, 0x3f, 0x6f, 0x79, 0x42, 0x8f, 0x60, 0x4c,
+		0xc6, 0xc7, 0x91, 0x01, 0x52, 0xda, 0x97, 0xd7, 0xe7, 0x33, 0x79, 0xec, 0x63, 0x7e, 0xf2, 0x9c,
+		0x72, 0x61, 0x8f, 0x0a, 0xfd, 0x0b, 0xad, 0xc3, 0x10, 0x0d, 0xa2, 0x58, 0x98, 0x43, 0x0a, 0x77,
+		0x33, 0x13, 0xb7, 0x8b, 0xeb, 0x7e, 0x88, 0x3d, 0x3b, 0x21, 0x45, 0x18, 0x96, 0x1a, 0x41, 0x71,
+		0x54, 0x80, 0x1d, 0x11, 0x3a, 0xae, 0x1f, 0x72, 0xe2, 0x08, 0x5a, 0x23, 0x61, 0x2c, 0xcc, 0x61,
+		0xc5, 0xee, 0x46, 0x35, 0x49, 0xe9, 0x6a, 0x9a, 0xd2, 0xd5, 0x27, 0x3a, 0xa5, 0xed, 0x9b, 0x0d,
+		0x16, 0xca, 0xbb, 0xfb, 0xe1, 0x96, 0xc4, 0xef, 0x27, 0x70, 0xf4, 0x12, 0xe6, 0x94, 0x49, 0x39,
+		0xdc, 0x47, 0xba, 0x71, 0x9f, 0x95, 0xe8, 0x2c, 0xc6, 0xad, 0x29, 0x30, 0xda, 0x91, 0x02, 0xf3,
+		0x00, 0x2c, 0x89, 0xa9, 0x8c, 0xd7, 0x98, 0xda, 0x1d, 0xd3, 0x2b, 0x3b, 0x1e, 0x72, 0xc1, 0x6c,
+		0x89, 0xa7, 0xc3, 0x48, 0xcc, 0x89, 0x13, 0x85, 0x3e, 0x75, 0xeb, 0x26, 0x2c, 0x19, 0x2b, 0x93,
+		0xeb, 0x77, 0x0a, 0x23, 0xb7, 0xe3, 0xd9, 0x12, 0xb2, 0xab, 0x10, 0xf6, 0xf4, 0x79, 0xd6, 0x32,
+		0xda, 0x82, 0x71, 0x46, 0x04, 0xab, 0xa7, 0x8c, 0xcb, 0xca, 0xd2, 0xa5, 0x4c, 0xc6, 0xb6, 0x24,
+		0xd4, 0xec, 0xca, 0xac, 0xf9, 0x80, 0x6e, 0xc1, 0x84, 0xcb, 0x64, 0x6c, 0xdc, 0x63, 0xe2, 0xc5,
+		0x3e, 0x31, 0xc7, 0x95, 0x2d, 0xe3, 0x72, 0x71, 0x4f, 0xaf, 0xa1, 0xbb, 0x30, 0x58, 0x23, 0xb5,
+		0xd0, 0x9c, 0xd0, 0xbe, 0xcc, 0x92, 0xf0, 0x82, 0xd4, 0x42, 0x5b, 0x91, 0x21, 0x1b, 0xae, 0x72,
+		0x82, 0x99, 0x7b, 0xec, 0x60, 0x21, 0x18, 0x3d, 0x88, 0x05, 0xe1, 0xe6, 0xa4, 0xc2, 0xde, 0xce,
+		0xc4, 0xee, 0x29, 0xea, 0xcd, 0x06, 0xb1, 0x3d, 0xc5, 0x3b, 0x56, 0xd0, 0x06, 0x0c, 0x1f, 0x13,
+		0xec, 0x11, 0x66, 0x5e, 0x51, 0x8c, 0xe6, 0x32, 0x19, 0x7d, 0xac, 0x48, 0x6c, 0x4d, 0x8a, 0x1e,
+		0x41, 0xd9, 0x23, 0x3e, 0xae, 0x27, 0xb9, 0x61, 0x4e, 0x75, 0x4b, 0x05, 0x50, 0xd4, 0x2a, 0x17,
+		0xd0, 0x87, 0x30, 0xfe, 0x19, 0x15, 0x82, 0x30, 0x0d, 0xbe, 0xda, 0x0d, 0x5c, 0x4e, 0xc8, 0x15,
+		0xda, 0x7a, 0x00, 0x0b, 0x79, 0x95, 0x80, 0x47, 0x61, 0xc0, 0x09, 0x9a, 0x86, 0x61, 0x16, 0x07,
+		0x32, 0x7b, 0x92, 0x52, 0x30, 0xc4, 0xe2, 0x60, 0xc7, 0xb3, 0x18, 0x58, 0xd9, 0xc0, 0x4d, 0x5e,
+		0x0f, 0xdc, 0xb4, 0x8e, 0x3c, 0x87, 0x11, 0x9d, 0x6c, 0x0a, 0x5d, 0x5e, 0x5f, 0xcf, 0xf6, 0x6b,
+		0x51, 0x31, 0xb2, 0x53, 0x16, 0xd6, 0x6d, 0xb8, 0x55, 0x28, 0x33, 0xd1, 0xd8, 0x7a, 0x1f, 0x96,
+		0xf2, 0x8b, 0x74, 0xb1, 0x55, 0xff, 0x2c, 0xc1, 0xc2, 0x1e, 0x3d, 0x0a, 0xb0, 0xff, 0xdf, 0x50,
+		0xdf, 0xdb, 0x0f, 0xf7, 0x60, 0xe7, 0xe1, 0x5e, 0x84, 0x32, 0x57, 0xb6, 0x38, 0x01, 0xae, 0x11,
+		0x55, 0x0d, 0xc7, 0x6c, 0x48, 0x96, 0x3e, 0xc1, 0x35, 0x82, 0x3e, 0x82, 0x71, 0x4d, 0x90, 0xd4,
+		0xcb, 0xe1, 0x1e, 0xea, 0xa5, 0x66, 0xb9, 0xa3, 0xaa, 0xa6, 0x09, 0x23, 0x6e, 0x18, 0x08, 0x16,
+		0xfa, 0xaa, 0x7c, 0x8d, 0xdb, 0xe9, 0xa3, 0xb5, 0x0c, 0x8b, 0xb9, 0x7e, 0xd4, 0x61, 0xfa, 0xc6,
+		0x80, 0xff, 0xd3, 0x34, 0x54, 0x1c, 0x17, 0xbf, 0x8f, 0x5e, 0xc2, 0x44, 0x52, 0x36, 0x5f, 0x3d,
+		0x9b, 0xc6, 0x15, 0xa3, 0x94, 0x71, 0x87, 0x8f, 0x4a, 0x5d, 0x7d, 0x34, 0xf0, 0x0a, 0x3e, 0x1a,
+		0x6c, 0xf7, 0xd1, 0x26, 0xac, 0x74, 0xb7, 0xbf, 0x38, 0x5f, 0xbf, 0x32, 0xe0, 0xdd, 0x6e, 0x3c,
+		0xda, 0x0e, 0xe4, 0xa7, 0x9d, 0x07, 0xf2, 0xc3, 0x6c, 0x17, 0xf6, 0x16, 0x97, 0xe6, 0xd1, 0x5c,
+		0x85, 0xbb, 0x3d, 0xea, 0xa1, 0xa3, 0xff, 0x75, 0x09, 0xe6, 0x6d, 0xc2, 0xc9, 0x1b, 0x73, 0x91,
+		0x6a, 0x5e, 0x96, 0x06, 0x5a, 0x2f, 0x4b, 0xe8, 0x01, 0x98, 0x1e, 0x71, 0x29, 0x97, 0x17, 0x83,
+		0x43, 0x1a, 0x50, 0x7e, 0xec, 0x90, 0x33, 0x12, 0x34, 0x8e, 0xdc, 0x80, 0x3d, 0x9d, 0xee, 0x6f,
+		0xab, 0xed, 0xa7, 0x72, 0x77, 0xc7, 0xeb, 0x38, 0x9d, 0x43, 0x9d, 0xa7, 0xb3, 0x0a, 0xd7, 0xf8,
+		0x09, 0x8d, 0x1c, 0x9d, 0x5d, 0x8c, 0xe0, 0x28, 0xf2, 0xeb, 0xea, 0x0c, 0x8e, 0xda, 0x57, 0xe5,
+		0x56, 0xe2, 0x50, 0x3b, 0xd9, 0x90, 0x95, 0x3a, 0xcf, 0x5f, 0xc5, 0x39, 0xf2, 0xe7, 0x12, 0xdc,
+		0xd6, 0x3e, 0xdd, 0xc2, 0x81, 0x4b, 0xfe, 0x17, 0x4a, 0xdb, 0x75, 0x18, 0x72, 0x71, 0xcc, 0xd3,
+		0xa2, 0x96, 0x3c, 0xa0, 0x0d, 0x98, 0x39, 0xa4, 0x8c, 0x8b, 0xa6, 0x92, 0x8e, 0x76, 0xc8, 0xb0,
+		0x22, 0xbb, 0xa6, 0x76, 0x9b, 0x3a, 0x29, 0xf7, 0xac, 0xc0, 0xdb, 0xdd, 0xbc, 0xa3, 0x53, 0xf6,
+		0xef, 0x25, 0x58, 0xde, 0x27, 0xac, 0x46, 0x03, 0x2c, 0xc8, 0x9b, 0x9e, 0xb6, 0xf7, 0x61, 0xc4,
+		0x23, 0x02, 0x53, 0x9f, 0x9b, 0x83, 0x3d, 0x94, 0xac, 0x94, 0xb8, 0x2d, 0x28, 0x43, 0x1d, 0x41,
+		0xb9, 0x94, 0x7f, 0xdf, 0x02, 0xab, 0xc8, 0x69, 0xda, 0xb7, 0x7f, 0x30, 0x60, 0xe9, 0x09, 0xe1,
+		0x2e, 0xa3, 0x07, 0x6f, 0x8a, 0x6b, 0xad, 0x6f, 0x06, 0x60, 0xb9, 0x40, 0x27, 0x7d, 0xea, 0x7c,
+		0x98, 0x6d, 0xba, 0xc3, 0x0d, 0x83, 0x43, 0x7a, 0xa4, 0x6f, 0x5a, 0xba, 0xc2, 0x6e, 0xf4, 0xa6,
+		0xc1, 0x56, 0x2b, 0xd4, 0x9e, 0x21, 0x99, 0xeb, 0xe8, 0x00, 0x66, 0x2f, 0x9a, 0xea, 0xd0, 0xe0,
+		0x30, 0xd4, 0xf6, 0xde, 0xe9, 0x4d, 0xda, 0x4e, 0x70, 0x18, 0x36, 0xef, 0xeb, 0x6d, 0xcb, 0xe8,
+		0x25, 0xa0, 0x88, 0x04, 0x1e, 0x0d, 0x8e, 0x1c, 0xec, 0x0a, 0x7a, 0x46, 0x05, 0x25, 0xdc, 0x1c,
+		0x58, 0x1a, 0x58, 0x29, 0xaf, 0xaf, 0x64, 0x67, 0x51, 0x42, 0xbe, 0x99, 0x50, 0xd7, 0x15, 0xf3,
+		0xab, 0x51, 0xdb, 0x22, 0x25, 0x1c, 0xfd, 0x08, 0xa6, 0x52, 0xc6, 0xee, 0x31, 0xf5, 0x3d, 0x46,
+		0x64, 0x67, 0x2a, 0xd9, 0x56, 0x8b, 0xd8, 0x6e, 0x49, 0xda, 0x76, 0xcd, 0xaf, 0x44, 0x2d, 0x5b,
+		0x8c, 0x04, 0x68, 0xaf, 0xc9, 0x3a, 0xad, 0xc6, 0xba, 0xfd, 0x2b, 0xd4, 0xf8, 0x89, 0xa6, 0x6d,
+		0x63, 0x9a, 0x2e, 0x5a, 0x5f, 0x0e, 0xc0, 0xf5, 0x1f, 0xc8, 0x31, 0x42, 0xea, 0xbe, 0xd7, 0x74,
+		0xc6, 0x1f, 0xc2, 0x90, 0x9a, 0x66, 0xe8, 0xcb, 0x87, 0x55, 0xc8, 0x49, 0x29, 0x6c, 0x27, 0x00,
+		0xe4, 0xc0, 0x8c, 0xfa, 0xe1, 0x30, 0xf2, 0x19, 0x71, 0x85, 0xcc, 0x4f, 0x8f, 0x2a, 0xa5, 0x06,
+		0x55, 0x77, 0xf7, 0xff, 0x99, 0xac, 0x12, 0x16, 0x0a, 0xb1, 0x95, 0x02, 0xec, 0xeb, 0xa7, 0x19,
+		0xab, 0x32, 0x1f, 0x13, 0x01, 0x6e, 0x18, 0x70, 0xca, 0x05, 0x09, 0xdc, 0xba, 0xe3, 0x93, 0x33,
+		0xe2, 0x9b, 0x43, 0x05, 0xfd, 0xa3, 0x92, 0xb0, 0xd5, 0x84, 0x3c, 0x97, 0x08, 0x7b, 0xfa, 0x34,
+		0x6b, 0xd9, 0xfa, 0xab, 0x01, 0xd3, 0x1d, 0x61, 0xd0, 0x67, 0xef, 0x23, 0x18, 0x4f, 0xcd, 0xe3,
+		0xb1, 0x9f, 0x5e, 0x69, 0xba, 0x5c, 0xce, 0xb4, 0x1d, 0x12, 0x80, 0x76, 0x60, 0xb2, 0xd5, 0x3f,
+		0xc4, 0x33, 0x4b, 0x05, 0x2e, 0x6e, 0xf1, 0x0b, 0xf1, 0xec, 0x89, 0xd3, 0xd6, 0x47, 0xeb, 0xdf,
+		0x06, 0xcc, 0xa6, 0xd5, 0xa2, 0x31, 0x94, 0xe8, 0x92, 0x2f, 0x6d, 0x53, 0x8e, 0x52, 0x7f, 0x53,
+		0x8e, 0x67, 0x30, 0xd9, 0xc0, 0x36, 0x47, 0x2d, 0x93, 0xeb, 0xcb, 0x85, 0x0c, 0x92, 0x51, 0x8b,
+		0x68, 0x79, 0x92, 0x17, 0x1c, 0x1a, 0xb8, 0x7e, 0xec, 0x11, 0xa7, 0xc9, 0x90, 0x0b, 0x2c, 0xe2,
+		0xe4, 0xd5, 0x31, 0x6a, 0x4f, 0xeb, 0xfd, 0x94, 0xc9, 0x9e, 0xda, 0xb4, 0xfe, 0x66, 0x80, 0x79,
+		0xd1, 0x62, 0x1d, 0x9a, 0xf7, 0x61, 0x24, 0x0a, 0x7d, 0x9f, 0x30, 0x6e, 0x1a, 0xea, 0x88, 0x2f,
+		0x66, 0x47, 0x45, 0xd1, 0xa8, 0xe3, 0x97, 0xd2, 0xa3, 0x17, 0x30, 0x75, 0x41, 0x91, 0xc4, 0x39,
+		0xb7, 0x0a, 0x6d, 0x4b, 0xd4, 0xb2, 0x27, 0x45, 0xbb, 0x9a, 0xf7, 0x60, 0xee, 0x19, 0x11, 0x29,
+		0x11, 0x7f, 0x5c, 0x7f, 0xa2, 0x9c, 0xdf, 0x25, 0x36, 0xd6, 0xef, 0x06, 0xe1, 0x66, 0x36, 0x4e,
+		0x5b, 0xf8, 0x53, 0x98, 0x69, 0x5c, 0x0c, 0x9b, 0xfa, 0xd6, 0x70, 0xa4, 0x0d, 0xfe, 0x7e, 0xa6,
+		0xb2, 0x45, 0x2c, 0xab, 0x69, 0xe5, 0x49, 0x29, 0x5e, 0xe0, 0xe8, 0x69, 0x20, 0x58, 0xdd, 0xbe,
+		0xe6, 0x5d, 0xdc, 0x91, 0x0a, 0xe8, 0xfa, 0x5c, 0xef, 0x50, 0xa0, 0x74, 0x59, 0x05, 0xd2, 0x0a,
+		0x7e, 0x51, 0x01, 0x7c, 0x71, 0xa7, 0x12, 0xcb, 0xf8, 0x67, 0x6b, 0x8c, 0xa6, 0x60, 0xe0, 0x84,
+		0xd4, 0xb5, 0x4f, 0xe5, 0x4f, 0xb4, 0x05, 0x43, 0x67, 0xd8, 0x8f, 0x89, 0x8e, 0xe5, 0xdd, 0x4c,
+		0xed, 0xf2, 0xf2, 0xc9, 0x4e, 0xb0, 0x8f, 0x4a, 0x0f, 0x0d, 0x29, 0x36, 0x4f, 0xcf, 0x6f, 0x51,
+		0xac, 0xc5, 0x61, 0x5e, 0x9d, 0x19, 0x4d, 0xb2, 0x8b, 0x99, 0x50, 0x35, 0x90, 0x7f, 0x8b, 0xa7,
+		0xdc, 0xfa, 0xaa, 0x04, 0x0b, 0x79, 0x52, 0x75, 0x1e, 0x9e, 0xc2, 0x7c, 0x46, 0x1a, 0x44, 0x0d,
+		0x42, 0xd3, 0x28, 0x78, 0xc5, 0x5e, 0xe0, 0xfb, 0x82, 0x08, 0xec, 0x61, 0x81, 0xed, 0x4a, 0x67,
+		0xc4, 0x9b, 0xa2, 0xa5, 0xc8, 0x8c, 0xd4, 0x6f, 0x11, 0x59, 0xba, 0x9c, 0xc8, 0xce, 0x2c, 0x6f,
+		0x8a, 0xb4, 0x66, 0x61, 0xfa, 0x19, 0x11, 0x5b, 0x7e, 0xcc, 0x85, 0xae, 0x17, 0xba, 0xf3, 0xfc,
+		0xb9, 0x01, 0x33, 0x9d, 0x3b, 0xda, 0x33, 0xc7, 0x70, 0x83, 0xc7, 0x51, 0x14, 0x32, 0x41, 0x3c,
+		0xc7, 0xf5, 0xa9, 0xec, 0xda, 0xce, 0x08, 0xe3, 0xda, 0x2b, 0x32, 0x10, 0xef, 0x66, 0xb7, 0xbf,
+		0x29, 0x6a, 0x4b, 0x81, 0x3e, 0xd5, 0x18, 0x7b, 0x96, 0x67, 0x6f, 0x58, 0xbf, 0x1e, 0x00, 0xeb,
+		0x59, 0x46, 0x6f, 0xf6, 0x71, 0xf2, 0x9d, 0xe2, 0x35, 0xdd, 0x1b, 0xe6, 0x60, 0x2c, 0xc2, 0x47,
+		0xc4, 0xe1, 0xf4, 0x8b, 0xe4, 0xed, 0x30, 0x64, 0x8f, 0xca, 0x85, 0x3d, 0xfa, 0x05, 0x41, 0x6f,
+		0xc3, 0x95, 0x80, 0x7c, 0x2e, 0xa3, 0x76, 0x44, 0x1c, 0x11, 0x9e, 0x90, 0x40, 0xcf, 0x27, 0x26,
+		0xe4, 0xf2, 0x2e, 0x3e, 0x22, 0xfb, 0x72, 0x11, 0xbd, 0x03, 0xe8, 0x1c, 0x53, 0xe1, 0x1c, 0x86,
+		0xcc, 0x09, 0xc8, 0x79, 0xd2, 0xfc, 0xaa, 0x97, 0xfb, 0xa8, 0x7d, 0x45, 0xee, 0x6c, 0x87, 0xec,
+		0x13, 0x72, 0xae, 0xba, 0x5e, 0xe4, 0xc0, 0x0d, 0xfd, 0x69, 0x46, 0x37, 0xc9, 0x87, 0xd4, 0x97,
+		0xa3, 0x49, 0xf5, 0x7e, 0x1a, 0x56, 0xef, 0xa7, 0xb7, 0x32, 0xed, 0x51, 0xf0, 0x6d, 0x45, 0xac,
+		0x5e, 0x51, 0x33, 0x9a, 0x4d, 0xc7, 0xba, 0x1c, 0x03, 0xab, 0xae, 0x59, 0x4e, 0x5d, 0xe9, 0x19,
+		0x4e, 0xe6, 0x4e, 0xa3, 0xf6, 0xb8, 0x5c, 0xdc, 0xd4, 0x6b, 0xd6, 0xbf, 0x0c, 0xb8, 0x55, 0x18,
+		0x0d, 0x9d, 0x1f, 0xf7, 0x61, 0x44, 0x8b, 0x29, 0xbc, 0x39, 0xa4, 0xb0, 0x94, 0x18, 0x7d, 0x0f,
+		0xca, 0x0c, 0x9f, 0x3b, 0x29, 0x36, 0x49, 0xf6, 0xec, 0x23, 0xfd, 0x04, 0x0b, 0xfc, 0xd8, 0x0f,
+		0x0f, 0x6c, 0x60, 0xf8, 0x5c, 0x33, 0xca, 0x72, 0xfd, 0x40, 0x96, 0xeb, 0x2b, 0x30, 0x9a, 0xd8,
+		0x49, 0x3c, 0xfd, 0x26, 0x6e, 0x3c, 0x5b, 0x75, 0x18, 0xdf, 0x26, 0x58, 0xc4, 0x8c, 0x6c, 0xfb,
+		0xf8, 0x88, 0x23, 0x0a, 0xeb, 0x19, 0x8d, 0x01, 0xf6, 0x19, 0xc1, 0x9e, 0xbc, 0x9d, 0xd5, 0x22,
+		0x9f, 0xc8, 0x63, 0x40, 0x18, 0x0b, 0x99, 0x43, 0x02, 0x7c, 0xe0, 0x93, 0x64, 0x50, 0x30, 0x6a,
+		0xdf, 0xbd, 0x90, 0x3a, 0x9b, 0x09, 0x6e, 0x2b, 0x85, 0x3d, 0x95, 0xa8, 0xa7, 0x09, 0xc8, 0xfa,
+		0x8d, 0x01, 0x73, 0x36, 0x39, 0x64, 0x84, 0x1f, 0x37, 0xbe, 0xe0, 0x60, 0x7e, 0xc2, 0x5f, 0x53,
+		0x9b, 0xb6, 0x00, 0x37, 0xb3, 0xb5, 0x49, 0xa2, 0xbc, 0xfe, 0x27, 0x04, 0xe5, 0x74, 0x67, 0x73,
+		0x77, 0x07, 0xfd, 0xc2, 0x00, 0x33, 0x6f, 0x3e, 0x8c, 0xde, 0xcb, 0xf9, 0x2a, 0x51, 0xf8, 0xcd,
+		0xaf, 0x72, 0xaf, 0x4f, 0x94, 0xce, 0xbf, 0x9f, 0x19, 0x30, 0x93, 0x3d, 0x2b, 0x43, 0x97, 0x98,
+		0x6c, 0x56, 0x36, 0xfa, 0xc2, 0x68, 0x1d, 0x7e, 0x6f, 0xc0, 0x5c, 0xc1, 0xbc, 0x0e, 0x3d, 0xe8,
+		0x83, 0x69, 0xeb, 0xa4, 0xb1, 0xf2, 0xb0, 0x7f, 0xa0, 0x56, 0xe9, 0x4b, 0x03, 0x66, 0x73, 0x86,
+		0xc7, 0x68, 0xa3, 0x68, 0x5c, 0x99, 0xe7, 0x98, 0xf7, 0xfa, 0x03, 0x69, 0x35, 0xfe, 0x62, 0xc0,
+		0x52, 0xb7, 0x99, 0x26, 0x7a, 0xa5, 0xf1, 0x69, 0xe5, 0xbb, 0x97, 0x44, 0x6b, 0x0d, 0xbf, 0x36,
+		0xe0, 0x76, 0x4f, 0x53, 0x57, 0xb4, 0x79, 0x29, 0x41, 0x6d, 0xf1, 0x7c, 0xfc, 0x2a, 0x2c, 0x5a,
+		0x12, 0x3e, 0x7b, 0x88, 0x99, 0x93, 0xf0, 0x85, 0x13, 0xe2, 0xca, 0x46, 0x5f, 0x18, 0xad, 0xc3,
+		0x1f, 0x0d, 0x58, 0xd0, 0x0c, 0x72, 0x06, 0x7e, 0xe8, 0x51, 0x0e, 0xdf, 0x1e, 0x66, 0xa8, 0x95,
+		0x0f, 0x2e, 0x85, 0xd5, 0xba, 0xfd, 0xd6, 0x80, 0x4a, 0xfe, 0xb0, 0x0c, 0xdd, 0xcf, 0xbe, 0x4f,
+		0x75, 0x1b, 0x49, 0x56, 0x1e, 0xf4, 0x8d, 0xd3, 0xfa, 0xfc, 0xca, 0x80, 0x1b, 0xb9, 0x13, 0x30,
+		0x74, 0xaf, 0xf0, 0x2a, 0x9d, 0xab, 0xcd, 0xfd, 0x7e, 0x61, 0x5a, 0x99, 0x43, 0x98, 0x68, 0x9b,
+		0x02, 0xa0, 0x82, 0xe1, 0x45, 0xc7, 0xc0, 0xa6, 0x72, 0xa7, 0x17, 0x52, 0x2d, 0x27, 0x84, 0xa9,
+		0xce, 0x76, 0x00, 0xbd, 0xdb, 0x63, 0xd7, 0x90, 0x48, 0xeb, 0xaf, 0xc7, 0x40, 0x3f, 0x81, 0xeb,
+		0x59, 0x4d, 0x19, 0xfa, 0x4e, 0x1f, 0xfd, 0x5b, 0x22, 0x78, 0xad, 0xef, 0x8e, 0x4f, 0x1d, 0xc9,
+		0xec, 0x06, 0x23, 0xe7, 0x48, 0x16, 0xf6, 0x40, 0x39, 0x47, 0xb2, 0x4b, 0x07, 0x43, 0x61, 0xb2,
+		0xfd, 0x06, 0x8f, 0xee, 0xe4, 0x19, 0x72, 0xb1, 0x01, 0xa8, 0xbc, 0xd3, 0x13, 0x6d, 0xcb, 0xeb,
+		0xae, 0xe0, 0x6a, 0x98, 0xf3, 0xba, 0xeb, 0x7e, 0xb5, 0xaf, 0x3c, 0xec, 0x1f, 0xd8, 0x0c, 0x7f,
+		0xd6, 0xfd, 0x25, 0x27, 0xfc, 0x05, 0x17, 0xaf, 0xca, 0x5a, 0x1f, 0x88, 0x44, 0xf8, 0x63, 0x0f,
+		0x66, 0xdd, 0xb0, 0x96, 0x85, 0x7b, 0x7c, 0x3d, 0x45, 0xec, 0x25, 0xff, 0xf7, 0xda, 0x65, 0xa1,
+		0x08, 0x77, 0x8d, 0x1f, 0xaf, 0x1d, 0x51, 0x71, 0x1c, 0x1f, 0x54, 0xdd, 0xb0, 0xb6, 0xda, 0xfa,
+		0x9f, 0xa9, 0xbb, 0xd4, 0xf3, 0x57, 0x8f, 0xc2, 0xe4, 0xaf, 0x5e, 0xfa, 0x0f, 0x54, 0x1f, 0xe0,
+		0x88, 0x9e, 0xad, 0x1d, 0x0c, 0xab, 0xb5, 0x8d, 0xff, 0x0c, 0x00, 0x9b, 0x56, 0x6f, 0xd0, 0x4f,
+		0x26, 0x00, 0x00,
 	},
 	// uber/cadence/api/v1/service_worker.proto
 	[]byte{
-		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0xcd, 0x73, 0xdb, 0xc6,
-		0x15, 0x1f, 0x48, 0xa2, 0x3e, 0x9e, 0xbe, 0xa8, 0xd5, 0xd4, 0xa5, 0xe8, 0x2f, 0x99, 0xa9, 0x6d,
-		0xa5, 0x4d, 0xc9, 0x5a, 0x49, 0x1d, 0xc7, 0x8e, 0x3b, 0xd5, 0x87, 0x35, 0x56, 0x27, 0x4d, 0x15,
-		0x98, 0x71, 0x66, 0xd2, 0x19, 0x63, 0x96, 0xc0, 0x4a, 0xdc, 0x11, 0x88, 0xa5, 0x81, 0x05, 0x29,
-		0x5e, 0x7a, 0xe8, 0x31, 0xe9, 0xa1, 0x33, 0x9d, 0xa6, 0x97, 0xce, 0xf8, 0xdc, 0x4b, 0x6f, 0xfd,
-		0x6b, 0x7a, 0xea, 0xb9, 0xff, 0x44, 0x07, 0xbb, 0x0b, 0x10, 0x24, 0x17, 0xe0, 0x47, 0x0f, 0xca,
-		0x4c, 0x6e, 0xc2, 0xe2, 0xf7, 0x1e, 0xde, 0xbe, 0xdf, 0xdb, 0xb7, 0xbf, 0x5d, 0x11, 0xf6, 0xc2,
-		0x06, 0xf1, 0x6b, 0x36, 0x76, 0x88, 0x67, 0x93, 0x1a, 0x6e, 0xd3, 0x5a, 0xe7, 0x51, 0x2d, 0x20,
-		0x7e, 0x87, 0xda, 0xc4, 0xea, 0x32, 0xff, 0x92, 0xf8, 0xd5, 0xb6, 0xcf, 0x38, 0x43, 0xdb, 0x11,
-		0xb2, 0xaa, 0x90, 0x55, 0xdc, 0xa6, 0xd5, 0xce, 0xa3, 0xf2, 0x9d, 0x0b, 0xc6, 0x2e, 0x5c, 0x52,
-		0x13, 0x90, 0x46, 0x78, 0x5e, 0x73, 0x42, 0x1f, 0x73, 0xca, 0x3c, 0x69, 0x54, 0xbe, 0x3b, 0xfc,
-		0x9e, 0xd3, 0x16, 0x09, 0x38, 0x6e, 0xb5, 0x15, 0x60, 0xc4, 0x41, 0xd7, 0xc7, 0xed, 0x36, 0xf1,
-		0x03, 0xf5, 0x7e, 0x57, 0x17, 0x9f, 0xcd, 0x5a, 0xad, 0xe4, 0x13, 0x15, 0x1d, 0xc2, 0x21, 0x36,
-		0x0d, 0xfa, 0x61, 0xdc, 0xd3, 0x61, 0x9a, 0x34, 0xe0, 0xcc, 0xef, 0xc5, 0x91, 0xea, 0x20, 0x6f,
-		0x43, 0x92, 0x00, 0xb4, 0xdf, 0xe1, 0x38, 0xb8, 0x74, 0x69, 0xc0, 0xf3, 0x30, 0x51, 0x16, 0xcf,
-		0x5d, 0xd6, 0x95, 0x98, 0xca, 0xbf, 0x0c, 0x28, 0x9f, 0x31, 0xd7, 0x3d, 0x61, 0xfe, 0xb1, 0x8a,
-		0xb2, 0x8e, 0x83, 0x4b, 0x93, 0xbc, 0x0d, 0x49, 0xc0, 0xd1, 0x0d, 0x58, 0x74, 0x58, 0x0b, 0x53,
-		0xaf, 0x64, 0xec, 0x1a, 0x7b, 0x2b, 0xa6, 0x7a, 0x42, 0x4f, 0x61, 0x25, 0xfa, 0x98, 0x15, 0x7d,
-		0xad, 0x34, 0xb7, 0x6b, 0xec, 0xad, 0xee, 0xdf, 0xae, 0x6a, 0x28, 0xa9, 0x46, 0xce, 0x3e, 0xa3,
-		0x01, 0x37, 0x97, 0xb9, 0xfa, 0x0b, 0x95, 0x61, 0x99, 0x3a, 0xc4, 0xe3, 0x94, 0xf7, 0x4a, 0xf3,
-		0xc2, 0x6b, 0xf2, 0x8c, 0x1e, 0xc2, 0x66, 0x83, 0x7a, 0xd8, 0xef, 0x59, 0x76, 0x93, 0xd8, 0x97,
-		0x41, 0xd8, 0x2a, 0x2d, 0x08, 0xc8, 0x86, 0x1c, 0x3e, 0x52, 0xa3, 0x95, 0x7f, 0x2e, 0xc3, 0x4d,
-		0x6d, 0xdc, 0x41, 0x9b, 0x79, 0x01, 0x41, 0xb7, 0x01, 0x44, 0x80, 0x9c, 0x5d, 0x12, 0x19, 0xfc,
-		0x9a, 0x29, 0x42, 0xae, 0x47, 0x03, 0xe8, 0x4b, 0x40, 0x71, 0x22, 0x2c, 0x72, 0x45, 0xec, 0x30,
-		0xaa, 0x12, 0x35, 0x91, 0x07, 0xda, 0x89, 0x7c, 0xa5, 0xe0, 0x2f, 0x62, 0xb4, 0xb9, 0xd5, 0x1d,
-		0x1e, 0x42, 0x27, 0xb0, 0x9e, 0xb8, 0xe5, 0xbd, 0x36, 0x11, 0xf3, 0x5b, 0xdd, 0xbf, 0x97, 0xeb,
-		0xb1, 0xde, 0x6b, 0x13, 0x73, 0xad, 0x9b, 0x7a, 0x42, 0xaf, 0x61, 0xa7, 0xed, 0x93, 0x0e, 0x65,
-		0x61, 0x60, 0x05, 0x1c, 0xfb, 0x9c, 0x38, 0x16, 0xe9, 0x10, 0x8f, 0x5b, 0xd4, 0x11, 0x09, 0x59,
-		0xdd, 0xbf, 0x59, 0x95, 0xb5, 0x5a, 0x8d, 0x6b, 0xb5, 0x7a, 0xea, 0xf1, 0xc7, 0x1f, 0xbd, 0xc6,
-		0x6e, 0x48, 0xcc, 0x1b, 0xb1, 0xf5, 0x2b, 0x69, 0xfc, 0x22, 0xb2, 0x3d, 0x75, 0xd0, 0x1e, 0x14,
-		0x47, 0xdc, 0x15, 0x76, 0x8d, 0xbd, 0x79, 0x73, 0x23, 0x18, 0x44, 0x96, 0x60, 0x09, 0x73, 0x4e,
-		0x5a, 0x6d, 0x5e, 0x5a, 0x14, 0x80, 0xf8, 0x11, 0x7d, 0x00, 0xa8, 0x81, 0xed, 0x4b, 0x97, 0x5d,
-		0x58, 0x36, 0x0b, 0x3d, 0x6e, 0x35, 0xa9, 0xc7, 0x4b, 0x4b, 0x02, 0x54, 0x54, 0x6f, 0x8e, 0xa2,
-		0x17, 0x2f, 0xa9, 0xc7, 0xd1, 0x63, 0x58, 0x52, 0x95, 0x5d, 0x5a, 0x16, 0x71, 0xdf, 0xd2, 0xe6,
-		0xe2, 0xa5, 0xc4, 0x98, 0x31, 0x18, 0x3d, 0x80, 0x4d, 0x8f, 0x5c, 0x71, 0xab, 0x8d, 0x2f, 0x88,
-		0x22, 0x71, 0x45, 0x90, 0xb8, 0x1e, 0x0d, 0x9f, 0xe1, 0x0b, 0x22, 0x89, 0x7c, 0x02, 0x05, 0xb1,
-		0x2c, 0x4a, 0x20, 0xbc, 0x57, 0x72, 0x33, 0xfd, 0x45, 0x84, 0x34, 0xa5, 0x01, 0x7a, 0x03, 0xb7,
-		0x46, 0x4b, 0xc0, 0xea, 0x57, 0xf5, 0xea, 0x24, 0x55, 0xbd, 0x33, 0x52, 0x03, 0xf1, 0x2b, 0x74,
-		0x00, 0x1b, 0x81, 0xdd, 0x24, 0x4e, 0xe8, 0x12, 0xc7, 0x8a, 0x1a, 0x4d, 0x69, 0x4d, 0x78, 0x2c,
-		0x8f, 0x10, 0x57, 0x8f, 0xbb, 0x90, 0xb9, 0x9e, 0x58, 0x44, 0x63, 0xe8, 0x39, 0xac, 0xc5, 0x74,
-		0x09, 0x07, 0xeb, 0x63, 0x1d, 0xac, 0x2a, 0xbc, 0x30, 0xff, 0x0a, 0x96, 0xa2, 0xa9, 0x52, 0x12,
-		0x94, 0x36, 0x76, 0xe7, 0xf7, 0x56, 0xf7, 0x9f, 0x6b, 0x27, 0x93, 0xb3, 0x8c, 0xaa, 0x5f, 0x48,
-		0xfb, 0x17, 0x1e, 0x8f, 0xc8, 0x51, 0xde, 0x50, 0x05, 0x04, 0x0b, 0xfd, 0x1a, 0xda, 0x14, 0xec,
-		0xaf, 0x46, 0x83, 0x71, 0x01, 0x55, 0x61, 0x9b, 0x33, 0x8e, 0x5d, 0x4b, 0x31, 0x6a, 0x35, 0x7a,
-		0x9c, 0x04, 0xa5, 0xa2, 0x40, 0x6e, 0x89, 0x57, 0x8a, 0xf4, 0xc3, 0xe8, 0x45, 0xf9, 0x0d, 0xac,
-		0xa5, 0x3f, 0x86, 0x8a, 0x30, 0x7f, 0x49, 0x7a, 0xaa, 0xed, 0x44, 0x7f, 0x46, 0x54, 0x77, 0xa2,
-		0xea, 0x56, 0xcb, 0x74, 0x22, 0xaa, 0x85, 0xc1, 0xd3, 0xb9, 0x27, 0x46, 0xe5, 0x1f, 0x05, 0x78,
-		0x4f, 0x4e, 0xcb, 0x49, 0xcf, 0xf4, 0x88, 0xb5, 0xda, 0x2e, 0xe1, 0xc4, 0x89, 0x3b, 0xde, 0x98,
-		0xc6, 0xf1, 0x0c, 0x56, 0xe2, 0x6e, 0x1e, 0x94, 0xe6, 0x44, 0x56, 0xf5, 0x25, 0x12, 0x7f, 0xc4,
-		0xec, 0xe3, 0xd1, 0xcf, 0x60, 0xab, 0x5f, 0x69, 0x36, 0xf3, 0x38, 0xb9, 0xe2, 0xa2, 0x45, 0xac,
-		0x99, 0xc5, 0xe4, 0xc5, 0x91, 0x1c, 0x1f, 0x68, 0x93, 0x0b, 0x43, 0x6d, 0xf2, 0xf7, 0xb0, 0x15,
-		0x70, 0x6a, 0x5f, 0xf6, 0x2c, 0xcc, 0xb9, 0x4f, 0x1b, 0x61, 0x94, 0xda, 0x82, 0x48, 0x4b, 0x55,
-		0x1b, 0xcd, 0x2b, 0x81, 0x4e, 0x8a, 0xf4, 0x20, 0xb1, 0x32, 0x8b, 0xd2, 0x51, 0x7f, 0x04, 0x7d,
-		0x0c, 0x25, 0x9f, 0xf0, 0xd0, 0xf7, 0x2c, 0x8f, 0x74, 0xad, 0x38, 0x7a, 0xb1, 0x32, 0x44, 0x2f,
-		0x58, 0x36, 0x7f, 0x24, 0xdf, 0x7f, 0x4e, 0xba, 0xe9, 0x54, 0xa2, 0x43, 0xb8, 0x73, 0xce, 0x7c,
-		0x9b, 0x58, 0xb6, 0x4f, 0x30, 0x27, 0x1a, 0xf3, 0x25, 0x61, 0x5e, 0x16, 0xa8, 0x23, 0x01, 0x1a,
-		0xf6, 0xa1, 0xd9, 0x00, 0x96, 0x75, 0x1b, 0x00, 0x62, 0xb0, 0x2e, 0xd6, 0xb1, 0xe5, 0x93, 0x20,
-		0x74, 0x79, 0x50, 0x5a, 0x11, 0x64, 0xfc, 0x46, 0x3b, 0xfd, 0x09, 0x88, 0xaf, 0xca, 0x8a, 0x91,
-		0xce, 0x64, 0xbd, 0xaf, 0xbd, 0x4d, 0x0d, 0x95, 0x29, 0x6c, 0x8d, 0x40, 0x34, 0x55, 0xfa, 0xab,
-		0xc1, 0x2a, 0xdd, 0x9b, 0xa0, 0x4a, 0x85, 0xc3, 0x74, 0xad, 0xbe, 0x9b, 0x87, 0x9f, 0xe4, 0x87,
-		0xac, 0x76, 0xb9, 0x2f, 0x61, 0x7d, 0x30, 0xc1, 0x86, 0xf8, 0xe8, 0x2f, 0xa6, 0x5d, 0xe7, 0xe6,
-		0x9a, 0x93, 0x26, 0xe1, 0x9d, 0x01, 0x77, 0xb0, 0xcd, 0x69, 0x87, 0x72, 0x4a, 0x02, 0x8b, 0x33,
-		0xcb, 0xa1, 0x41, 0x1b, 0x73, 0xbb, 0x69, 0xb9, 0xcc, 0xc6, 0xae, 0xdb, 0x53, 0xa5, 0xff, 0xf5,
-		0x0c, 0xd9, 0x56, 0x9d, 0xe5, 0x20, 0xf1, 0x5f, 0x67, 0xc7, 0xca, 0xfb, 0x67, 0xd2, 0xb9, 0xcc,
-		0xfe, 0x4d, 0x9c, 0x8d, 0x28, 0xff, 0x01, 0x76, 0xc7, 0x39, 0xd0, 0x70, 0x73, 0x3c, 0xc8, 0x8d,
-		0x7e, 0xa9, 0x28, 0xbf, 0x3d, 0xe1, 0x2b, 0x76, 0x7c, 0xea, 0x9d, 0xb3, 0x34, 0x43, 0x7f, 0x9c,
-		0x83, 0x5d, 0xcd, 0x34, 0x4f, 0x30, 0x75, 0x27, 0x6e, 0x25, 0x87, 0x50, 0xb0, 0x71, 0x18, 0xc8,
-		0x68, 0x36, 0xf6, 0x3f, 0xc8, 0x6d, 0x23, 0x7d, 0xef, 0x47, 0x91, 0x8d, 0x29, 0x4d, 0xa3, 0xed,
-		0xd5, 0x21, 0x1c, 0x53, 0x37, 0x50, 0x52, 0x43, 0xbf, 0xbd, 0x9e, 0xe1, 0x9e, 0xcb, 0xb0, 0x63,
-		0xc6, 0xe0, 0xdc, 0xe6, 0xa2, 0x59, 0x82, 0x05, 0xad, 0x06, 0x7b, 0x0f, 0xee, 0xe5, 0xe4, 0x40,
-		0xf2, 0x5c, 0xf9, 0x4f, 0x5f, 0x60, 0xc6, 0x99, 0xbd, 0x4e, 0x81, 0xf9, 0x0a, 0x50, 0xe2, 0xd7,
-		0x6a, 0x11, 0x8e, 0x1d, 0xcc, 0xb1, 0x92, 0x54, 0xf7, 0x73, 0x3f, 0xf0, 0x5b, 0x05, 0x36, 0x8b,
-		0x7c, 0x68, 0xa4, 0xf2, 0x6d, 0x5f, 0x8c, 0x0e, 0xce, 0xf1, 0x5a, 0xc5, 0xe8, 0x5d, 0x58, 0x55,
-		0x4b, 0xa8, 0x17, 0xed, 0xd1, 0x32, 0x13, 0x10, 0x0f, 0x9d, 0x3a, 0x91, 0x5a, 0x4d, 0x00, 0x42,
-		0xad, 0x2e, 0xe4, 0xa8, 0xd5, 0x64, 0x62, 0x42, 0xad, 0xe2, 0xd4, 0x13, 0xda, 0x87, 0x02, 0xf5,
-		0xda, 0x21, 0x57, 0x3b, 0x50, 0x7e, 0x09, 0x4a, 0xa8, 0x46, 0x1d, 0x2d, 0xfe, 0xbf, 0xea, 0x68,
-		0x69, 0x3a, 0x75, 0x54, 0x87, 0x9d, 0xd8, 0x5f, 0xd4, 0xe1, 0x6c, 0x97, 0x05, 0x44, 0x38, 0x62,
-		0x21, 0x57, 0x5a, 0x75, 0x67, 0xc4, 0xd7, 0xb1, 0x3a, 0x50, 0x9a, 0x37, 0x62, 0xdb, 0x3a, 0x3b,
-		0x8a, 0x2c, 0xeb, 0xd2, 0x10, 0x7d, 0x0e, 0x37, 0xc4, 0x47, 0x46, 0x5d, 0xae, 0x8c, 0x73, 0xb9,
-		0x2d, 0x0c, 0x87, 0xfc, 0x9d, 0xc0, 0x56, 0x93, 0x60, 0x9f, 0x37, 0x08, 0xe6, 0x89, 0x2b, 0x18,
-		0xe7, 0xaa, 0x98, 0xd8, 0xc4, 0x7e, 0x52, 0x7a, 0x3e, 0x12, 0xb6, 0x85, 0xbe, 0x9e, 0x7f, 0x03,
-		0x77, 0x06, 0x99, 0xb0, 0xd8, 0xb9, 0xc5, 0x9b, 0x34, 0xb0, 0x62, 0x83, 0xf1, 0xba, 0xb5, 0x3c,
-		0xc0, 0xcc, 0xef, 0xce, 0xeb, 0x4d, 0x1a, 0x1c, 0x28, 0xff, 0xa7, 0xe9, 0x19, 0xc4, 0xcd, 0x6a,
-		0x7d, 0x82, 0x4a, 0xe9, 0x4f, 0xe2, 0x58, 0x75, 0xad, 0x91, 0xe3, 0xd5, 0xc6, 0x6c, 0xc7, 0xab,
-		0x87, 0xb0, 0x99, 0xf8, 0x51, 0xdd, 0x67, 0x53, 0x76, 0xb8, 0x78, 0xf8, 0x58, 0x76, 0xa1, 0x0f,
-		0x61, 0xb1, 0x49, 0xb0, 0x43, 0x7c, 0xa1, 0x5b, 0xa3, 0x43, 0x97, 0xf6, 0xf0, 0x22, 0x20, 0xa6,
-		0x82, 0x56, 0xbe, 0x33, 0x12, 0xa5, 0x99, 0xee, 0x06, 0xd3, 0x2a, 0xcd, 0x8f, 0x60, 0x51, 0x4a,
-		0x1b, 0xd5, 0x09, 0xf2, 0x93, 0xa5, 0xb0, 0x79, 0xbd, 0xaf, 0xf2, 0x20, 0x51, 0x15, 0x19, 0x71,
-		0xa9, 0x96, 0xfd, 0xed, 0x1c, 0x3c, 0xcc, 0x03, 0x1e, 0xf6, 0x4e, 0x8f, 0xc7, 0xf5, 0xef, 0xeb,
-		0xea, 0x69, 0xfd, 0xac, 0x2d, 0xcc, 0x98, 0xb5, 0xc2, 0x50, 0xd6, 0x7e, 0x0a, 0x7b, 0xe3, 0x93,
-		0xa1, 0x32, 0xf7, 0x57, 0x23, 0x91, 0x05, 0x69, 0xf0, 0x54, 0xb2, 0xe0, 0x31, 0x2c, 0x9d, 0x63,
-		0xea, 0x86, 0x3e, 0xc9, 0x25, 0xfe, 0x44, 0x62, 0xcc, 0x18, 0x9c, 0xcb, 0x7c, 0x7f, 0xa7, 0xd6,
-		0x85, 0xa5, 0x82, 0xff, 0x66, 0x4e, 0x5b, 0x1f, 0x12, 0xf5, 0x7d, 0xe6, 0x3c, 0x95, 0xb1, 0x85,
-		0x59, 0x33, 0x36, 0xcc, 0xfa, 0x43, 0xb8, 0x3f, 0x26, 0x17, 0x2a, 0x6b, 0x7f, 0x33, 0xa0, 0xa2,
-		0xab, 0x0f, 0xec, 0xd9, 0x64, 0x2a, 0xd2, 0xe3, 0xd6, 0x38, 0x37, 0xab, 0x8e, 0x1b, 0x26, 0xfd,
-		0xbe, 0xbe, 0x0d, 0x25, 0x81, 0xa9, 0x09, 0xfc, 0x69, 0x0e, 0x1e, 0xe4, 0xe0, 0xbe, 0xe7, 0xc4,
-		0xc7, 0x59, 0x5b, 0x98, 0x35, 0x6b, 0xc3, 0xc4, 0xbf, 0xaf, 0xef, 0x7d, 0x03, 0xd9, 0x18, 0xa0,
-		0xde, 0x66, 0xfe, 0x00, 0xf4, 0x65, 0xbc, 0x6b, 0x5d, 0x23, 0xf5, 0x67, 0x11, 0xf5, 0x39, 0x81,
-		0x29, 0x5d, 0xfa, 0x3e, 0x14, 0x6d, 0x31, 0x31, 0xcb, 0x97, 0xb1, 0x12, 0x47, 0xc4, 0xb7, 0x6c,
-		0x6e, 0xca, 0x71, 0x33, 0x1e, 0x56, 0x55, 0x92, 0xe9, 0xf2, 0x87, 0x56, 0x25, 0xf5, 0xa8, 0x4a,
-		0xc6, 0x64, 0x63, 0xfa, 0x24, 0xff, 0xbb, 0xbf, 0x7d, 0x88, 0x9b, 0x81, 0x59, 0x64, 0xc3, 0xaf,
-		0x87, 0x64, 0xc3, 0xe4, 0x17, 0x10, 0xf1, 0x66, 0xf8, 0x1a, 0xb6, 0xe5, 0xbf, 0x5a, 0xac, 0x0e,
-		0xf1, 0xc5, 0xd5, 0x02, 0xf5, 0xce, 0x99, 0x3a, 0x5f, 0x66, 0x13, 0x45, 0xfc, 0xd7, 0x12, 0x2e,
-		0xce, 0xca, 0x5b, 0xdd, 0xe1, 0xa1, 0xd4, 0x26, 0xa4, 0x9b, 0x5c, 0xac, 0x3d, 0x0c, 0x28, 0x9b,
-		0x24, 0x20, 0x5c, 0xde, 0x58, 0x25, 0xa7, 0xbb, 0x6b, 0xa9, 0xad, 0xca, 0x6d, 0xb8, 0xa9, 0x0d,
-		0x46, 0x06, 0xbb, 0xff, 0xdf, 0x4d, 0x58, 0x91, 0x53, 0x3f, 0x38, 0x3b, 0x45, 0x57, 0xb0, 0xad,
-		0xb9, 0x62, 0x41, 0xb5, 0xc9, 0x2f, 0x63, 0xc4, 0x1c, 0xcb, 0x53, 0xdf, 0xde, 0xa0, 0xbf, 0x18,
-		0x70, 0x2b, 0xef, 0xd2, 0x05, 0x3d, 0x99, 0xf5, 0x56, 0xac, 0xfc, 0xc9, 0xcc, 0x37, 0x3c, 0xe8,
-		0x1b, 0x03, 0x76, 0x32, 0xef, 0x07, 0xd0, 0x2f, 0x27, 0x75, 0x3c, 0x20, 0x9e, 0xca, 0x8f, 0xa7,
-		0x35, 0x53, 0xc1, 0xf4, 0xc9, 0x49, 0xaf, 0xd8, 0x7c, 0x72, 0x34, 0xf7, 0x15, 0xf9, 0xe4, 0x68,
-		0x0f, 0xff, 0x29, 0x72, 0xb4, 0x02, 0x32, 0x9f, 0x9c, 0xbc, 0x13, 0x44, 0x3e, 0x39, 0xb9, 0x1a,
-		0x1f, 0xbd, 0xd3, 0x2b, 0xd5, 0x01, 0x59, 0x8b, 0x3e, 0x9d, 0xda, 0x7f, 0x6a, 0x1f, 0x28, 0x3f,
-		0x9f, 0xd1, 0x7a, 0xb4, 0x7c, 0x46, 0x25, 0x58, 0x7e, 0xf9, 0x64, 0x6a, 0xef, 0xfc, 0xf2, 0xc9,
-		0xd6, 0xc6, 0xe8, 0x3b, 0x03, 0x6e, 0xe7, 0xea, 0x41, 0xf4, 0xc9, 0x74, 0x9e, 0xd3, 0x89, 0x7a,
-		0x3a, 0x8b, 0xa9, 0x0a, 0xec, 0xcf, 0x86, 0x68, 0x51, 0x59, 0x7a, 0x05, 0x7d, 0x3c, 0x31, 0x09,
-		0x83, 0x82, 0xb5, 0xfc, 0x64, 0x7a, 0x43, 0x15, 0xd2, 0xdf, 0x0d, 0xb8, 0x3b, 0x46, 0x42, 0xa1,
-		0x67, 0xd3, 0x7a, 0x4f, 0xe7, 0xeb, 0xd3, 0xd9, 0x8c, 0x07, 0x32, 0x96, 0xb9, 0x77, 0x67, 0x66,
-		0x6c, 0x9c, 0xce, 0xcb, 0xcc, 0xd8, 0x78, 0x1d, 0x26, 0x33, 0x96, 0x2b, 0x27, 0x32, 0x33, 0x36,
-		0x89, 0x24, 0xcb, 0xcc, 0xd8, 0x64, 0x0a, 0x26, 0xb5, 0x12, 0x47, 0x77, 0xee, 0xfc, 0x95, 0x98,
-		0x29, 0x63, 0xf2, 0x57, 0x62, 0xb6, 0x40, 0x88, 0x1a, 0xb9, 0x66, 0x4b, 0xce, 0x68, 0xe4, 0xd9,
-		0x4a, 0x22, 0xa3, 0x91, 0xe7, 0xec, 0xf6, 0x87, 0x0d, 0xf8, 0xb1, 0xcd, 0x5a, 0x3a, 0xb3, 0x43,
-		0x24, 0x55, 0xc0, 0x2b, 0xf9, 0x4b, 0x95, 0x33, 0x9f, 0x71, 0x76, 0x66, 0x7c, 0xfd, 0xe8, 0x82,
-		0xf2, 0x66, 0xd8, 0xa8, 0xda, 0xac, 0x55, 0x4b, 0xff, 0x14, 0xe3, 0xe7, 0xd4, 0x71, 0x6b, 0x17,
-		0x4c, 0xfe, 0xca, 0x44, 0xfd, 0x2e, 0xe3, 0x19, 0x6e, 0xd3, 0xce, 0xa3, 0xc6, 0xa2, 0x18, 0xfb,
-		0xf0, 0x7f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x78, 0xdf, 0x83, 0x7f, 0x09, 0x23, 0x00, 0x00,
+		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0x49, 0x6f, 0xe3, 0xc8,
+		0x15, 0x06, 0x65, 0xcb, 0xcb, 0xf3, 0x26, 0x97, 0x91, 0x0e, 0x4d, 0xf7, 0xa2, 0x56, 0x2f, 0xf6,
+		0x24, 0x13, 0xa9, 0xdb, 0x33, 0xf1, 0xb8, 0xb7, 0x20, 0x5e, 0xda, 0x68, 0x07, 0x93, 0x89, 0x87,
+		0xad, 0xe9, 0x01, 0x26, 0x40, 0x13, 0x25, 0xb2, 0x6c, 0x15, 0x4c, 0xb1, 0xd4, 0x64, 0x51, 0x6e,
+		0x5d, 0x72, 0xc8, 0x71, 0x26, 0x87, 0x00, 0x41, 0x26, 0x97, 0x00, 0x7d, 0xce, 0x25, 0xb7, 0xfc,
+		0x9a, 0x9c, 0x72, 0xce, 0x9f, 0x08, 0x58, 0x55, 0x94, 0x28, 0x89, 0xa2, 0x96, 0x1c, 0x3c, 0xc0,
+		0xdc, 0xcc, 0xe2, 0xf7, 0xbe, 0x7a, 0xf5, 0xde, 0xab, 0x57, 0x1f, 0xcb, 0x82, 0x9d, 0xb0, 0x46,
+		0xfc, 0x8a, 0x8d, 0x1d, 0xe2, 0xd9, 0xa4, 0x82, 0x9b, 0xb4, 0xd2, 0x7a, 0x5c, 0x09, 0x88, 0xdf,
+		0xa2, 0x36, 0xb1, 0xae, 0x98, 0x7f, 0x49, 0xfc, 0x72, 0xd3, 0x67, 0x9c, 0xa1, 0x8d, 0x08, 0x59,
+		0x56, 0xc8, 0x32, 0x6e, 0xd2, 0x72, 0xeb, 0xb1, 0x71, 0xfb, 0x82, 0xb1, 0x0b, 0x97, 0x54, 0x04,
+		0xa4, 0x16, 0x9e, 0x57, 0x9c, 0xd0, 0xc7, 0x9c, 0x32, 0x4f, 0x1a, 0x19, 0x77, 0xfa, 0xdf, 0x73,
+		0xda, 0x20, 0x01, 0xc7, 0x8d, 0xa6, 0x02, 0x0c, 0x10, 0x5c, 0xf9, 0xb8, 0xd9, 0x24, 0x7e, 0xa0,
+		0xde, 0x17, 0xd3, 0xfc, 0xb3, 0x59, 0xa3, 0xd1, 0x99, 0xa2, 0x94, 0x86, 0x70, 0x88, 0x4d, 0x83,
+		0xae, 0x1b, 0x77, 0xd3, 0x30, 0x75, 0x1a, 0x70, 0xe6, 0xb7, 0x63, 0x4f, 0xd3, 0x20, 0xef, 0x42,
+		0xe2, 0xb7, 0xb3, 0xe6, 0xe1, 0x38, 0xb8, 0x74, 0x69, 0xc0, 0xb3, 0x30, 0x51, 0x14, 0xcf, 0x5d,
+		0x76, 0x25, 0x31, 0xa5, 0x7f, 0x69, 0x60, 0x9c, 0x31, 0xd7, 0x3d, 0x61, 0xfe, 0xb1, 0xf2, 0xb2,
+		0x8a, 0x83, 0x4b, 0x93, 0xbc, 0x0b, 0x49, 0xc0, 0xd1, 0x0d, 0x98, 0x73, 0x58, 0x03, 0x53, 0x4f,
+		0xd7, 0x8a, 0xda, 0xce, 0xa2, 0xa9, 0x9e, 0xd0, 0x53, 0x58, 0x8c, 0x26, 0xb3, 0xa2, 0xd9, 0xf4,
+		0x5c, 0x51, 0xdb, 0x59, 0xda, 0xbd, 0x55, 0x4e, 0x49, 0x49, 0x39, 0x22, 0xfb, 0x9c, 0x06, 0xdc,
+		0x5c, 0xe0, 0xea, 0x2f, 0x64, 0xc0, 0x02, 0x75, 0x88, 0xc7, 0x29, 0x6f, 0xeb, 0x33, 0x82, 0xb5,
+		0xf3, 0x8c, 0xb6, 0x61, 0xad, 0x46, 0x3d, 0xec, 0xb7, 0x2d, 0xbb, 0x4e, 0xec, 0xcb, 0x20, 0x6c,
+		0xe8, 0xb3, 0x02, 0xb2, 0x2a, 0x87, 0x8f, 0xd4, 0x68, 0xe9, 0x9f, 0x0b, 0xb0, 0x95, 0xea, 0x77,
+		0xd0, 0x64, 0x5e, 0x40, 0xd0, 0x2d, 0x00, 0xe1, 0x20, 0x67, 0x97, 0x44, 0x3a, 0xbf, 0x6c, 0x0a,
+		0x97, 0xab, 0xd1, 0x00, 0xfa, 0x0a, 0x50, 0x1c, 0x08, 0x8b, 0xbc, 0x27, 0x76, 0x18, 0x55, 0x89,
+		0x5a, 0xc8, 0xc3, 0xd4, 0x85, 0x7c, 0xad, 0xe0, 0x2f, 0x63, 0xb4, 0xb9, 0x7e, 0xd5, 0x3f, 0x84,
+		0x4e, 0x60, 0xa5, 0x43, 0xcb, 0xdb, 0x4d, 0x22, 0xd6, 0xb7, 0xb4, 0x7b, 0x37, 0x93, 0xb1, 0xda,
+		0x6e, 0x12, 0x73, 0xf9, 0x2a, 0xf1, 0x84, 0xde, 0xc0, 0x66, 0xd3, 0x27, 0x2d, 0xca, 0xc2, 0xc0,
+		0x0a, 0x38, 0xf6, 0x39, 0x71, 0x2c, 0xd2, 0x22, 0x1e, 0xb7, 0xa8, 0x23, 0x02, 0xb2, 0xb4, 0xbb,
+		0x55, 0x96, 0xb5, 0x5a, 0x8e, 0x6b, 0xb5, 0x7c, 0xea, 0xf1, 0xbd, 0x4f, 0xdf, 0x60, 0x37, 0x24,
+		0xe6, 0x8d, 0xd8, 0xfa, 0xb5, 0x34, 0x7e, 0x19, 0xd9, 0x9e, 0x3a, 0x68, 0x07, 0x0a, 0x03, 0x74,
+		0xf9, 0xa2, 0xb6, 0x33, 0x63, 0xae, 0x06, 0xbd, 0x48, 0x1d, 0xe6, 0x31, 0xe7, 0xa4, 0xd1, 0xe4,
+		0xfa, 0x9c, 0x00, 0xc4, 0x8f, 0xe8, 0x63, 0x40, 0x35, 0x6c, 0x5f, 0xba, 0xec, 0xc2, 0xb2, 0x59,
+		0xe8, 0x71, 0xab, 0x4e, 0x3d, 0xae, 0xcf, 0x0b, 0x50, 0x41, 0xbd, 0x39, 0x8a, 0x5e, 0xbc, 0xa2,
+		0x1e, 0x47, 0x7b, 0x30, 0xaf, 0x2a, 0x5b, 0x5f, 0x10, 0x7e, 0xdf, 0x4c, 0x8d, 0xc5, 0x2b, 0x89,
+		0x31, 0x63, 0x30, 0x7a, 0x08, 0x6b, 0x1e, 0x79, 0xcf, 0xad, 0x26, 0xbe, 0x20, 0x2a, 0x89, 0x8b,
+		0x22, 0x89, 0x2b, 0xd1, 0xf0, 0x19, 0xbe, 0x20, 0x32, 0x91, 0xfb, 0x90, 0x17, 0xdb, 0x42, 0x07,
+		0xc1, 0x5e, 0xca, 0x8c, 0xf4, 0x97, 0x11, 0xd2, 0x94, 0x06, 0xe8, 0x2d, 0xdc, 0x1c, 0x2c, 0x01,
+		0xab, 0x5b, 0xd5, 0x4b, 0xe3, 0x54, 0xf5, 0xe6, 0x40, 0x0d, 0xc4, 0xaf, 0xd0, 0x01, 0xac, 0x06,
+		0x76, 0x9d, 0x38, 0xa1, 0x4b, 0x1c, 0x2b, 0x6a, 0x34, 0xfa, 0xb2, 0x60, 0x34, 0x06, 0x12, 0x57,
+		0x8d, 0xbb, 0x90, 0xb9, 0xd2, 0xb1, 0x88, 0xc6, 0xd0, 0x0b, 0x58, 0x8e, 0xd3, 0x25, 0x08, 0x56,
+		0x46, 0x12, 0x2c, 0x29, 0xbc, 0x30, 0xff, 0x1a, 0xe6, 0xa3, 0xa5, 0x52, 0x12, 0xe8, 0xab, 0xc5,
+		0x99, 0x9d, 0xa5, 0xdd, 0x17, 0xa9, 0x8b, 0xc9, 0xd8, 0x46, 0xe5, 0x2f, 0xa5, 0xfd, 0x4b, 0x8f,
+		0x47, 0xc9, 0x51, 0x6c, 0xa8, 0x04, 0x22, 0x0b, 0xdd, 0x1a, 0x5a, 0x13, 0xd9, 0x5f, 0x8a, 0x06,
+		0xe3, 0x02, 0x2a, 0xc3, 0x06, 0x67, 0x1c, 0xbb, 0x96, 0xca, 0xa8, 0x55, 0x6b, 0x73, 0x12, 0xe8,
+		0x05, 0x81, 0x5c, 0x17, 0xaf, 0x54, 0xd2, 0x0f, 0xa3, 0x17, 0xc6, 0x5b, 0x58, 0x4e, 0x4e, 0x86,
+		0x0a, 0x30, 0x73, 0x49, 0xda, 0xaa, 0xed, 0x44, 0x7f, 0x46, 0xa9, 0x6e, 0x45, 0xd5, 0xad, 0xe7,
+		0xc6, 0x4f, 0xb5, 0x30, 0x78, 0x9a, 0xdb, 0xd7, 0x4a, 0xff, 0xc8, 0xc3, 0x3d, 0xb9, 0x2c, 0x27,
+		0xb9, 0xd2, 0x23, 0xd6, 0x68, 0xba, 0x84, 0x13, 0x27, 0xee, 0x78, 0x23, 0x1a, 0xc7, 0x33, 0x58,
+		0x8c, 0xbb, 0x79, 0xa0, 0xe7, 0x8a, 0x33, 0x43, 0x4b, 0x24, 0x9e, 0xc4, 0xec, 0xe2, 0xd1, 0xcf,
+		0x61, 0xbd, 0x5b, 0x69, 0x36, 0xf3, 0x38, 0x79, 0xcf, 0x45, 0x8b, 0x58, 0x36, 0x0b, 0x9d, 0x17,
+		0x47, 0x72, 0xbc, 0xa7, 0x4d, 0xce, 0xf6, 0xb5, 0xc9, 0xdf, 0xc3, 0x7a, 0xc0, 0xa9, 0x7d, 0xd9,
+		0xb6, 0x30, 0xe7, 0x3e, 0xad, 0x85, 0x51, 0x68, 0xf3, 0x22, 0x2c, 0xe5, 0x54, 0x6f, 0x5e, 0x0b,
+		0x74, 0xa7, 0x48, 0x0f, 0x3a, 0x56, 0x66, 0x41, 0x12, 0x75, 0x47, 0xd0, 0x67, 0xa0, 0xfb, 0x84,
+		0x87, 0xbe, 0x67, 0x79, 0xe4, 0xca, 0x8a, 0xbd, 0x17, 0x3b, 0x43, 0xf4, 0x82, 0x05, 0xf3, 0x27,
+		0xf2, 0xfd, 0x17, 0xe4, 0x2a, 0x19, 0x4a, 0x74, 0x08, 0xb7, 0xcf, 0x99, 0x6f, 0x13, 0xcb, 0xf6,
+		0x09, 0xe6, 0x24, 0xc5, 0x7c, 0x5e, 0x98, 0x1b, 0x02, 0x75, 0x24, 0x40, 0xfd, 0x1c, 0x29, 0x07,
+		0xc0, 0x42, 0xda, 0x01, 0x80, 0x18, 0xac, 0x88, 0x7d, 0x6c, 0xf9, 0x24, 0x08, 0x5d, 0x1e, 0xe8,
+		0x8b, 0x22, 0x19, 0xbf, 0x49, 0x5d, 0xfe, 0x18, 0x89, 0x2f, 0xcb, 0x8a, 0x91, 0x64, 0xb2, 0xde,
+		0x97, 0xdf, 0x25, 0x86, 0x0c, 0x0a, 0xeb, 0x03, 0x90, 0x94, 0x2a, 0xfd, 0x55, 0x6f, 0x95, 0xee,
+		0x8c, 0x51, 0xa5, 0x82, 0x30, 0x59, 0xab, 0x1f, 0x66, 0xe0, 0x7e, 0xb6, 0xcb, 0xea, 0x94, 0xfb,
+		0x0a, 0x56, 0x7a, 0x03, 0xac, 0x89, 0x49, 0x1f, 0x4d, 0xba, 0xcf, 0xcd, 0x65, 0x27, 0x99, 0x84,
+		0x0f, 0x1a, 0xdc, 0xc6, 0x36, 0xa7, 0x2d, 0xca, 0x29, 0x09, 0x2c, 0xce, 0x2c, 0x87, 0x06, 0x4d,
+		0xcc, 0xed, 0xba, 0xe5, 0x32, 0x1b, 0xbb, 0x6e, 0x5b, 0x95, 0xfe, 0x37, 0x53, 0x44, 0x5b, 0x75,
+		0x96, 0x83, 0x0e, 0x7f, 0x95, 0x1d, 0x2b, 0xf6, 0xcf, 0x25, 0xb9, 0x8c, 0xfe, 0x16, 0x1e, 0x8e,
+		0x30, 0xfe, 0x00, 0xc5, 0x51, 0x04, 0x29, 0xb9, 0x39, 0xee, 0xcd, 0x4d, 0xfa, 0x56, 0x51, 0xbc,
+		0x6d, 0xc1, 0x15, 0x13, 0x9f, 0x7a, 0xe7, 0x2c, 0x99, 0xa1, 0x3f, 0xe6, 0xa0, 0x98, 0xb2, 0xcc,
+		0x13, 0x4c, 0xdd, 0xb1, 0x5b, 0xc9, 0x21, 0xe4, 0x6d, 0x1c, 0x06, 0xd2, 0x9b, 0xd5, 0xdd, 0x8f,
+		0x33, 0xdb, 0x48, 0x97, 0xfd, 0x28, 0xb2, 0x31, 0xa5, 0x69, 0x74, 0xbc, 0x3a, 0x84, 0x63, 0xea,
+		0x06, 0xfa, 0x4c, 0xc6, 0xf1, 0x7a, 0x86, 0xdb, 0x2e, 0xc3, 0x8e, 0x19, 0x83, 0x33, 0x9b, 0x4b,
+		0xca, 0x16, 0xcc, 0xa7, 0x6a, 0xb0, 0x7b, 0x70, 0x37, 0x23, 0x06, 0x32, 0xcf, 0xa5, 0xff, 0x74,
+		0x05, 0x66, 0x1c, 0xd9, 0xeb, 0x14, 0x98, 0xaf, 0x01, 0x75, 0x78, 0xad, 0x06, 0xe1, 0xd8, 0xc1,
+		0x1c, 0x2b, 0x49, 0xf5, 0x20, 0x73, 0x82, 0xdf, 0x2a, 0xb0, 0x59, 0xe0, 0x7d, 0x23, 0xa5, 0xef,
+		0xba, 0x62, 0xb4, 0x77, 0x8d, 0xd7, 0x2a, 0x46, 0xef, 0xc0, 0x92, 0xda, 0x42, 0xed, 0xe8, 0x8c,
+		0x96, 0x91, 0x80, 0x78, 0xe8, 0xd4, 0x89, 0xd4, 0x6a, 0x07, 0x20, 0xd4, 0xea, 0x6c, 0x86, 0x5a,
+		0xed, 0x2c, 0x4c, 0xa8, 0x55, 0x9c, 0x78, 0x42, 0xbb, 0x90, 0xa7, 0x5e, 0x33, 0xe4, 0x7a, 0x7e,
+		0x8c, 0x12, 0x94, 0xd0, 0x14, 0x75, 0x34, 0xf7, 0xff, 0xaa, 0xa3, 0xf9, 0xc9, 0xd4, 0x51, 0x15,
+		0x36, 0x63, 0xbe, 0xa8, 0xc3, 0xd9, 0x2e, 0x0b, 0x88, 0x20, 0x62, 0x21, 0x57, 0x5a, 0x75, 0x73,
+		0x80, 0xeb, 0x58, 0x7d, 0x50, 0x9a, 0x37, 0x62, 0xdb, 0x2a, 0x3b, 0x8a, 0x2c, 0xab, 0xd2, 0x10,
+		0x7d, 0x01, 0x37, 0xc4, 0x24, 0x83, 0x94, 0x8b, 0xa3, 0x28, 0x37, 0x84, 0x61, 0x1f, 0xdf, 0x09,
+		0xac, 0xd7, 0x09, 0xf6, 0x79, 0x8d, 0x60, 0xde, 0xa1, 0x82, 0x51, 0x54, 0x85, 0x8e, 0x4d, 0xcc,
+		0x93, 0xd0, 0xf3, 0x91, 0xb0, 0xcd, 0x77, 0xf5, 0xfc, 0x5b, 0xb8, 0xdd, 0x9b, 0x09, 0x8b, 0x9d,
+		0x5b, 0xbc, 0x4e, 0x03, 0x2b, 0x36, 0x18, 0xad, 0x5b, 0x8d, 0x9e, 0xcc, 0xfc, 0xee, 0xbc, 0x5a,
+		0xa7, 0xc1, 0x81, 0xe2, 0x3f, 0x4d, 0xae, 0x20, 0x6e, 0x56, 0x2b, 0x63, 0x54, 0x4a, 0x77, 0x11,
+		0xc7, 0xaa, 0x6b, 0x0d, 0x7c, 0x5e, 0xad, 0x4e, 0xf7, 0x79, 0xb5, 0x0d, 0x6b, 0x1d, 0x1e, 0xd5,
+		0x7d, 0xd6, 0x64, 0x87, 0x8b, 0x87, 0x8f, 0xc5, 0x28, 0xfa, 0x04, 0xe6, 0xea, 0x04, 0x3b, 0xc4,
+		0xd7, 0x0b, 0xea, 0xa3, 0x2b, 0xf5, 0xe3, 0x45, 0x40, 0x4c, 0x05, 0x2d, 0x7d, 0xaf, 0x75, 0x94,
+		0x66, 0xb2, 0x1b, 0x4c, 0xaa, 0x34, 0x3f, 0x85, 0x39, 0x29, 0x6d, 0xf4, 0xdc, 0x18, 0xc1, 0x52,
+		0xd8, 0xac, 0xde, 0x57, 0x7a, 0x08, 0xf7, 0xb3, 0xfd, 0x52, 0x2d, 0xfb, 0xbb, 0x1c, 0x6c, 0x67,
+		0x01, 0x0f, 0xdb, 0xa7, 0xc7, 0xa3, 0xfa, 0xf7, 0x75, 0xf5, 0xb4, 0x6e, 0xd4, 0x66, 0xa7, 0x8c,
+		0x5a, 0xbe, 0x2f, 0x6a, 0x3f, 0x83, 0x9d, 0xd1, 0xc1, 0x50, 0x91, 0xfb, 0xab, 0x06, 0xc5, 0x14,
+		0xf0, 0x44, 0xb2, 0x60, 0x0f, 0xe6, 0xcf, 0x31, 0x75, 0x43, 0x9f, 0x64, 0x26, 0xfe, 0x44, 0x62,
+		0xcc, 0x18, 0x9c, 0x99, 0xf9, 0xee, 0x49, 0x9d, 0xe6, 0x96, 0x72, 0xfe, 0xdb, 0x1c, 0xdc, 0x1f,
+		0x8a, 0xfa, 0x21, 0xe7, 0x3c, 0x11, 0xb1, 0xd9, 0x69, 0x23, 0xd6, 0x9f, 0xf5, 0x6d, 0x78, 0x30,
+		0x22, 0x16, 0x2a, 0x6a, 0x7f, 0xd3, 0xa0, 0x94, 0x56, 0x1f, 0xd8, 0xb3, 0xc9, 0x44, 0x49, 0x8f,
+		0x5b, 0x63, 0x6e, 0x5a, 0x1d, 0xd7, 0x9f, 0xf4, 0x07, 0x70, 0x2f, 0xd3, 0x31, 0xb5, 0x80, 0x3f,
+		0xe5, 0xe0, 0x61, 0x06, 0xee, 0x07, 0x9e, 0xf8, 0x38, 0x6a, 0xb3, 0xd3, 0x46, 0xad, 0x3f, 0xf1,
+		0x1f, 0xc1, 0xf6, 0xc8, 0x68, 0xf4, 0xa4, 0xde, 0x66, 0x7e, 0x0f, 0xf4, 0x55, 0x7c, 0x6a, 0x5d,
+		0x63, 0xea, 0xcf, 0xe0, 0x5e, 0xa6, 0x63, 0x4a, 0x97, 0x7e, 0x04, 0x05, 0x5b, 0x2c, 0xcc, 0xf2,
+		0xa5, 0xaf, 0xc4, 0x11, 0xfe, 0x2d, 0x98, 0x6b, 0x72, 0xdc, 0x8c, 0x87, 0x55, 0x95, 0x0c, 0xa5,
+		0xfc, 0xb1, 0x55, 0x49, 0x15, 0xb6, 0x47, 0x46, 0x63, 0xf2, 0x20, 0xff, 0xbb, 0x7b, 0x7c, 0x88,
+		0x9b, 0x81, 0x69, 0x64, 0xc3, 0xaf, 0xfb, 0x64, 0xc3, 0xf8, 0x17, 0x10, 0xf1, 0x61, 0xf8, 0x06,
+		0x36, 0xe4, 0xbf, 0x5a, 0xac, 0x16, 0xf1, 0xc5, 0xd5, 0x02, 0xf5, 0xce, 0x99, 0x3e, 0x33, 0x22,
+		0x51, 0xc4, 0x7f, 0x23, 0xe1, 0xe2, 0x5b, 0x79, 0xfd, 0xaa, 0x7f, 0x28, 0x71, 0x08, 0xa5, 0x2d,
+		0x2e, 0xd6, 0x1e, 0x1a, 0x18, 0x26, 0x09, 0x08, 0x97, 0x37, 0x56, 0x9d, 0xaf, 0xbb, 0x6b, 0xa9,
+		0xad, 0xd2, 0x2d, 0xd8, 0x4a, 0x75, 0x46, 0x3a, 0xbb, 0xfb, 0xdf, 0x35, 0x58, 0x94, 0x4b, 0x3f,
+		0x38, 0x3b, 0x45, 0xef, 0x61, 0x23, 0xe5, 0x8a, 0x05, 0x55, 0xc6, 0xbf, 0x8c, 0x11, 0x6b, 0x34,
+		0x26, 0xbe, 0xbd, 0x41, 0x7f, 0xd1, 0xe0, 0x66, 0xd6, 0xa5, 0x0b, 0xda, 0x9f, 0xf6, 0x56, 0xcc,
+		0x78, 0x32, 0xf5, 0x0d, 0x0f, 0xfa, 0x56, 0x83, 0xcd, 0xa1, 0xf7, 0x03, 0xe8, 0x97, 0xe3, 0x12,
+		0xf7, 0x88, 0x27, 0x63, 0x6f, 0x52, 0x33, 0xe5, 0x4c, 0x37, 0x39, 0xc9, 0x1d, 0x9b, 0x9d, 0x9c,
+		0x94, 0xfb, 0x0a, 0xe3, 0xd1, 0xf8, 0x06, 0x83, 0xc9, 0x49, 0x15, 0x90, 0xd9, 0xc9, 0xc9, 0xfa,
+		0x82, 0x30, 0x9e, 0x4c, 0x61, 0xa9, 0xbc, 0xfa, 0x90, 0xae, 0x54, 0x7b, 0x64, 0x2d, 0x7a, 0x3e,
+		0x31, 0x7f, 0xe2, 0x1c, 0x30, 0x5e, 0x4c, 0x69, 0x3d, 0x58, 0x3e, 0x83, 0x12, 0x2c, 0xbb, 0x7c,
+		0x86, 0x6a, 0x6f, 0x63, 0x6f, 0x52, 0x33, 0xe5, 0xcc, 0xf7, 0x1a, 0xdc, 0xca, 0xd4, 0x83, 0xe8,
+		0xc9, 0x64, 0xcc, 0xc9, 0x40, 0x3d, 0x9d, 0xc6, 0x54, 0x39, 0xf6, 0x67, 0x0d, 0xb6, 0x52, 0x90,
+		0xb1, 0x5e, 0x41, 0x9f, 0x8d, 0x9d, 0x84, 0x5e, 0xc1, 0x6a, 0xec, 0x4f, 0x6e, 0xa8, 0x5c, 0xfa,
+		0xbb, 0x06, 0x77, 0x46, 0x48, 0x28, 0xf4, 0x6c, 0x52, 0xf6, 0x64, 0xbc, 0x9e, 0x4f, 0x67, 0xdc,
+		0x13, 0xb1, 0xa1, 0x67, 0xf7, 0xd0, 0x88, 0x8d, 0xd2, 0x79, 0xc6, 0xfe, 0xe4, 0x86, 0x3d, 0x11,
+		0xcb, 0x94, 0x13, 0x43, 0x23, 0x36, 0x8e, 0x24, 0x33, 0x9e, 0x4f, 0x67, 0x3c, 0xb8, 0x13, 0x07,
+		0x4f, 0xee, 0xec, 0x9d, 0x38, 0x54, 0xc6, 0x18, 0x7b, 0x93, 0x9a, 0x75, 0x1b, 0x79, 0xca, 0x91,
+		0x3c, 0xa4, 0x91, 0x0f, 0x57, 0x12, 0xc6, 0xa3, 0xf1, 0x0d, 0xe4, 0xcc, 0x87, 0x35, 0xf8, 0xa9,
+		0xcd, 0x1a, 0x69, 0x66, 0x87, 0x48, 0xaa, 0x80, 0xd7, 0xf2, 0x97, 0x2a, 0x67, 0x3e, 0xe3, 0xec,
+		0x4c, 0xfb, 0xe6, 0xf1, 0x05, 0xe5, 0xf5, 0xb0, 0x56, 0xb6, 0x59, 0xa3, 0x92, 0xfc, 0x29, 0xc6,
+		0x2f, 0xa8, 0xe3, 0x56, 0x2e, 0x98, 0xfc, 0x95, 0x89, 0xfa, 0x5d, 0xc6, 0x33, 0xdc, 0xa4, 0xad,
+		0xc7, 0xb5, 0x39, 0x31, 0xf6, 0xc9, 0xff, 0x06, 0x00, 0x78, 0xdf, 0x83, 0x7f, 0x09, 0x23, 0x00,
+		0x00,
 	},
 	// uber/cadence/api/v1/decision.proto
 	[]byte{
 		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x59, 0xcd, 0x6e, 0x14, 0xc7,
 		0x16, 0xbe, 0xed, 0x9f, 0xf9, 0x39, 0x1e, 0x03, 0x2e, 0x83, 0xb1, 0xc1, 0x60, 0x33, 0x57, 0xd7,
 		0x5c, 0xb0, 0x3c, 0x63, 0x1b, 0x2e, 0x42, 0x80, 0xd0, 0xc5, 0x06, 0x0b, 0x4b, 0x60, 0xac, 0xc6,
-		0x04, 0x29, 0x52, 0xd4, 0x2a, 0x57, 0x97, 0xed, 0x8a, 0x7b, 0xba, 0x27, 0xd5, 0xd5, 0x1e, 0x26,
+		0x04, 0x29, 0x52, 0xd4, 0x2a, 0x57, 0x97, 0xed, 0x8a, 0x7b, 0xba, 0x26, 0xd5, 0xd5, 0x1e, 0x26,
 		0x52, 0xa4, 0xac, 0x92, 0x6c, 0xf2, 0x00, 0x91, 0xb2, 0xca, 0x2a, 0xd9, 0x90, 0x6d, 0xa2, 0xac,
-		0xf2, 0x08, 0x59, 0xe4, 0x49, 0xf2, 0x02, 0x51, 0x55, 0x57, 0xf7, 0x8c, 0xc7, 0x3d, 0x3d, 0xdd,
-		0x86, 0xb0, 0x73, 0xd7, 0x9c, 0xf3, 0xd5, 0x57, 0x75, 0x4e, 0x9f, 0xef, 0x1b, 0x0f, 0x54, 0x83,
-		0x5d, 0xca, 0xeb, 0x04, 0xdb, 0xd4, 0x25, 0xb4, 0x8e, 0x9b, 0xac, 0x7e, 0xb4, 0x52, 0xb7, 0x29,
-		0x61, 0x3e, 0xf3, 0xdc, 0x5a, 0x93, 0x7b, 0xc2, 0x43, 0x93, 0x32, 0xa6, 0xa6, 0x63, 0x6a, 0xb8,
-		0xc9, 0x6a, 0x47, 0x2b, 0x97, 0xae, 0xee, 0x7b, 0xde, 0xbe, 0x43, 0xeb, 0x2a, 0x64, 0x37, 0xd8,
-		0xab, 0xdb, 0x01, 0xc7, 0x22, 0x4e, 0xba, 0x34, 0x9f, 0x04, 0x4c, 0xbc, 0x46, 0x23, 0x8e, 0x48,
-		0xdc, 0x5a, 0x60, 0xff, 0xd0, 0x61, 0xbe, 0x48, 0x8b, 0x69, 0x79, 0xfc, 0x70, 0xcf, 0xf1, 0x5a,
-		0x61, 0x4c, 0xf5, 0x9b, 0x09, 0x28, 0x3d, 0xd6, 0x8c, 0xd1, 0x77, 0x06, 0xdc, 0xf4, 0xc9, 0x01,
-		0xb5, 0x03, 0x87, 0x5a, 0x98, 0x08, 0x76, 0xc4, 0x44, 0xdb, 0x92, 0xa8, 0x56, 0x74, 0x2a, 0x0b,
-		0x0b, 0xc1, 0xd9, 0x6e, 0x20, 0xa8, 0x3f, 0x6d, 0xcc, 0x1b, 0xff, 0x1d, 0x5b, 0xbd, 0x5f, 0x4b,
-		0x38, 0x61, 0xed, 0xa5, 0x86, 0x79, 0xa4, 0x51, 0x76, 0xb0, 0x7f, 0x18, 0xed, 0xf3, 0x28, 0x86,
-		0x78, 0xfa, 0x2f, 0x73, 0xc1, 0xcf, 0x14, 0x89, 0x3e, 0x87, 0x39, 0x5f, 0x60, 0x2e, 0x2c, 0xc1,
-		0x1a, 0x94, 0x27, 0xf2, 0x19, 0x52, 0x7c, 0x56, 0x92, 0xf9, 0xc8, 0xdc, 0x1d, 0x99, 0x9a, 0xc8,
-		0x62, 0xd6, 0x4f, 0xf9, 0x1c, 0xfd, 0x68, 0x80, 0xbc, 0xfd, 0xa6, 0x43, 0x05, 0xb5, 0xa2, 0x0b,
-		0xb4, 0xe8, 0x1b, 0x4a, 0x02, 0x59, 0xb4, 0x44, 0x32, 0xc3, 0x8a, 0xcc, 0xff, 0x13, 0xc9, 0xac,
-		0x6b, 0xac, 0xd7, 0x1a, 0xea, 0x49, 0x84, 0x94, 0xc8, 0x6d, 0x91, 0x64, 0x0f, 0x47, 0xdf, 0x1b,
-		0xb0, 0xb8, 0x87, 0x99, 0x93, 0x95, 0xe6, 0x88, 0xa2, 0xf9, 0x20, 0x91, 0xe6, 0x06, 0x66, 0x4e,
-		0x36, 0x8a, 0xd7, 0xf7, 0xb2, 0x85, 0xa2, 0x9f, 0x0c, 0x58, 0xe6, 0xf4, 0xb3, 0x80, 0xfa, 0xc2,
-		0x22, 0xd8, 0x25, 0xd4, 0xc9, 0xd0, 0x67, 0xa3, 0x29, 0x57, 0x69, 0x86, 0x60, 0xeb, 0x0a, 0x6b,
-		0x60, 0xb3, 0x2d, 0xf2, 0xec, 0xe1, 0xe8, 0x0b, 0x98, 0xd7, 0x14, 0xfb, 0xb7, 0x5c, 0x41, 0x51,
-		0x5b, 0x4d, 0xae, 0xb2, 0x4a, 0xee, 0xdf, 0x73, 0x57, 0x48, 0x5a, 0x00, 0xfa, 0xc1, 0x80, 0x25,
-		0xbd, 0x7f, 0xc6, 0x5a, 0x16, 0x15, 0x99, 0x87, 0x29, 0x64, 0xb2, 0x55, 0xf3, 0x06, 0xc9, 0x1a,
-		0x8c, 0xfe, 0x30, 0xe0, 0x61, 0x4f, 0x3d, 0xe9, 0x1b, 0x41, 0xb9, 0x8b, 0x33, 0xb3, 0x2e, 0x29,
-		0xd6, 0xcf, 0x07, 0x57, 0xf7, 0x89, 0x06, 0xce, 0x76, 0x88, 0xbb, 0xfc, 0x94, 0xb9, 0xe8, 0x4b,
-		0x03, 0xae, 0x71, 0x4a, 0x3c, 0x6e, 0x5b, 0x0d, 0xcc, 0x0f, 0xfb, 0x54, 0xbe, 0xac, 0x68, 0xdf,
-		0xea, 0x43, 0x5b, 0x66, 0x3f, 0x57, 0xc9, 0x89, 0xe4, 0xae, 0xf2, 0xd4, 0x08, 0xf4, 0xab, 0x01,
-		0x77, 0x88, 0xe7, 0x0a, 0xe6, 0x06, 0xd4, 0xc2, 0xbe, 0xe5, 0xd2, 0x56, 0xd6, 0xeb, 0x04, 0xc5,
-		0xeb, 0x49, 0x9f, 0xb9, 0x13, 0x42, 0x3e, 0xf2, 0xb7, 0x68, 0x2b, 0xdb, 0x35, 0x2e, 0x93, 0x9c,
-		0x39, 0xe8, 0x67, 0x03, 0x56, 0xc3, 0x49, 0x4d, 0x0e, 0x98, 0x63, 0x67, 0xe5, 0x3d, 0xa6, 0x78,
-		0xaf, 0xf5, 0x1f, 0xde, 0xeb, 0x12, 0x2d, 0x1b, 0xe9, 0x25, 0x3f, 0x4f, 0x02, 0xfa, 0xcd, 0x80,
-		0x3b, 0x3e, 0xdb, 0x97, 0x3d, 0x9b, 0xb7, 0x79, 0x2b, 0x8a, 0xf5, 0x46, 0x32, 0x6b, 0x05, 0x99,
-		0xaf, 0x6b, 0x57, 0xfc, 0xbc, 0x49, 0xe8, 0x17, 0x03, 0xfe, 0x17, 0x34, 0x7d, 0xca, 0x45, 0x87,
-		0xb4, 0x4f, 0x31, 0x27, 0x07, 0x5d, 0x44, 0x13, 0xc9, 0x8f, 0xa7, 0xb4, 0xca, 0x2b, 0x85, 0x18,
-		0xed, 0xff, 0x52, 0xe1, 0x75, 0x36, 0x4d, 0x6e, 0x95, 0x20, 0x67, 0xce, 0x5a, 0x05, 0xa0, 0x43,
-		0xa7, 0xfa, 0x6d, 0x01, 0x16, 0xb2, 0xd9, 0x06, 0x34, 0x07, 0x63, 0xb1, 0x6c, 0x30, 0x5b, 0x19,
-		0x91, 0xb2, 0x09, 0xd1, 0xd2, 0xa6, 0x8d, 0x36, 0x60, 0xbc, 0xa3, 0x2b, 0xed, 0x26, 0xd5, 0xde,
-		0xe0, 0x5a, 0xe2, 0x59, 0xe3, 0xcd, 0xda, 0x4d, 0x6a, 0x56, 0x70, 0xd7, 0x13, 0x9a, 0x82, 0x82,
-		0xed, 0x35, 0x30, 0x73, 0x95, 0x9e, 0x97, 0x4d, 0xfd, 0x84, 0xee, 0x41, 0x59, 0xc9, 0x95, 0x74,
-		0x5b, 0x5a, 0x43, 0xaf, 0x24, 0x62, 0xcb, 0x03, 0x3c, 0x63, 0xbe, 0x30, 0x4b, 0x42, 0xff, 0x85,
-		0x56, 0x61, 0x94, 0xb9, 0xcd, 0x40, 0x68, 0x5d, 0x9b, 0x4d, 0xcc, 0xdb, 0xc6, 0x6d, 0xc7, 0xc3,
-		0xb6, 0x19, 0x86, 0xa2, 0x1d, 0x98, 0x89, 0x8d, 0x99, 0xf0, 0x2c, 0xe2, 0x78, 0x3e, 0x55, 0xb2,
-		0xe4, 0x05, 0x42, 0x8b, 0xd0, 0x4c, 0x2d, 0x34, 0x95, 0xb5, 0xc8, 0x54, 0xd6, 0x1e, 0x6b, 0x53,
-		0x69, 0x4e, 0x45, 0xb9, 0x3b, 0xde, 0xba, 0xcc, 0xdc, 0x09, 0x13, 0x7b, 0x51, 0x3b, 0xfe, 0x4a,
-		0xa2, 0x16, 0x73, 0xa0, 0xc6, 0xee, 0x4a, 0xa2, 0x6e, 0xc1, 0x94, 0x46, 0xea, 0x25, 0x5a, 0x1a,
-		0x04, 0x39, 0x19, 0xda, 0xb0, 0xe3, 0x2c, 0x37, 0x60, 0xe2, 0x80, 0x62, 0x2e, 0x76, 0x29, 0xee,
-		0xb0, 0x2b, 0x0f, 0x82, 0x3a, 0x17, 0xe7, 0x44, 0x38, 0xeb, 0x50, 0xe1, 0x54, 0xf0, 0xb6, 0xd5,
-		0xf4, 0x1c, 0x46, 0xda, 0x7a, 0xe2, 0xcc, 0xf7, 0x99, 0xe0, 0x82, 0xb7, 0xb7, 0x55, 0x9c, 0x39,
-		0xc6, 0x3b, 0x0f, 0xe8, 0x16, 0x14, 0x0e, 0x28, 0xb6, 0x29, 0xd7, 0xaf, 0xfe, 0xe5, 0xc4, 0xf4,
-		0xa7, 0x2a, 0xc4, 0xd4, 0xa1, 0xe8, 0x36, 0x4c, 0x45, 0x22, 0xe9, 0x78, 0x04, 0x3b, 0x96, 0xcd,
-		0xfc, 0x26, 0x16, 0xe4, 0x40, 0xbd, 0x82, 0x25, 0xf3, 0xbc, 0xfe, 0xf4, 0x99, 0xfc, 0xf0, 0xb1,
-		0xfe, 0xac, 0xfa, 0xb5, 0x01, 0xb3, 0x69, 0xb6, 0x15, 0xcd, 0x40, 0x29, 0x74, 0x26, 0xf1, 0x2b,
-		0x50, 0x54, 0xcf, 0x9b, 0x36, 0x7a, 0x06, 0x17, 0xe2, 0x1a, 0xec, 0x31, 0xde, 0x29, 0xc1, 0xd0,
-		0xa0, 0x7b, 0x43, 0xba, 0x04, 0x1b, 0x8c, 0x47, 0x15, 0xa8, 0x12, 0x58, 0xcc, 0x61, 0x59, 0xd1,
-		0x6d, 0x28, 0x70, 0xea, 0x07, 0x8e, 0xd0, 0xdf, 0x10, 0xd2, 0x3b, 0x5c, 0xc7, 0x56, 0x31, 0x5c,
-		0xcf, 0x68, 0x38, 0xd1, 0x1d, 0x28, 0x4a, 0xc3, 0x19, 0x70, 0x9a, 0xba, 0xc3, 0x46, 0x18, 0x63,
-		0x46, 0xc1, 0xd5, 0x2d, 0x58, 0xcc, 0xe1, 0x17, 0x07, 0x4e, 0x99, 0xea, 0x3d, 0xb8, 0x92, 0x6a,
-		0xf2, 0x52, 0x2a, 0x54, 0x25, 0x70, 0x23, 0xb3, 0x27, 0x93, 0x07, 0xb6, 0xa9, 0xc0, 0xcc, 0xf1,
-		0x33, 0x5d, 0x69, 0x14, 0x5c, 0xfd, 0xcb, 0x80, 0xbb, 0xa7, 0xf5, 0x50, 0x5d, 0xb3, 0xcf, 0x38,
-		0x36, 0xfb, 0x5e, 0x01, 0x3a, 0xa9, 0x8e, 0xba, 0xb1, 0x16, 0x12, 0x79, 0x9d, 0xd8, 0xcd, 0x9c,
-		0x68, 0xf5, 0x2e, 0xa1, 0x69, 0x28, 0x4a, 0xaf, 0xc1, 0x3d, 0x47, 0xcd, 0xda, 0x8a, 0x19, 0x3d,
-		0xa2, 0x1a, 0x4c, 0xf6, 0x58, 0x09, 0xcf, 0x75, 0xda, 0x6a, 0xec, 0x96, 0xcc, 0x09, 0xd2, 0x2d,
-		0xf3, 0x2f, 0x5c, 0xa7, 0x5d, 0x7d, 0x6b, 0xc0, 0xd5, 0x74, 0x0b, 0x26, 0x4b, 0xab, 0xbd, 0x9d,
-		0x8b, 0x1b, 0x34, 0x2a, 0x6d, 0xb8, 0xb4, 0x85, 0x1b, 0xb4, 0xfb, 0xc6, 0x87, 0x72, 0xdc, 0x78,
-		0xd7, 0x7c, 0x18, 0xce, 0x3c, 0x1f, 0xaa, 0x6f, 0x4b, 0xb0, 0x9c, 0xd7, 0x9b, 0x49, 0x89, 0x8b,
-		0xef, 0x43, 0x49, 0x9c, 0x91, 0x22, 0x71, 0x11, 0x60, 0x28, 0x71, 0xad, 0xae, 0xa7, 0xe3, 0x52,
-		0x36, 0x74, 0x4a, 0x29, 0x1b, 0xce, 0x2e, 0x65, 0x18, 0xe6, 0x3b, 0x9e, 0xaa, 0x8f, 0x50, 0x8c,
-		0x0c, 0x9a, 0x52, 0xb3, 0x31, 0xc4, 0xcb, 0x04, 0xc5, 0x78, 0x0d, 0x97, 0xd5, 0x91, 0xfa, 0xa0,
-		0x8f, 0x0e, 0x42, 0xbf, 0x28, 0xb3, 0x93, 0x80, 0x5f, 0xc0, 0xd4, 0x2e, 0x26, 0x87, 0xde, 0xde,
-		0x9e, 0xc6, 0x66, 0xae, 0xa0, 0xfc, 0x08, 0x3b, 0x83, 0x35, 0xf8, 0xbc, 0x4e, 0x54, 0xb0, 0x9b,
-		0x3a, 0xed, 0x84, 0x26, 0x15, 0x4f, 0xa3, 0x49, 0x9b, 0x50, 0x66, 0x2e, 0x13, 0x0c, 0x0b, 0x8f,
-		0x2b, 0x8d, 0x3d, 0xb3, 0xba, 0x38, 0xd8, 0xff, 0x6f, 0x46, 0x29, 0x66, 0x27, 0xbb, 0x7b, 0xb2,
-		0x96, 0x73, 0x4c, 0x56, 0x64, 0xc2, 0x94, 0x83, 0xe5, 0x77, 0xc0, 0x50, 0x26, 0x64, 0x69, 0xb5,
-		0x04, 0x40, 0x86, 0xce, 0x38, 0x2f, 0x73, 0xd7, 0xe3, 0x54, 0x53, 0x65, 0xa2, 0x7f, 0xc3, 0x38,
-		0xe1, 0xb2, 0x47, 0xb4, 0xcd, 0x50, 0x82, 0x5d, 0x36, 0x2b, 0x72, 0x31, 0xf2, 0x89, 0xa7, 0xd3,
-		0xe3, 0x25, 0x18, 0x69, 0xd0, 0x86, 0xa7, 0x0d, 0xf0, 0x4c, 0x62, 0xca, 0x73, 0xda, 0xf0, 0x4c,
-		0x15, 0x86, 0x4c, 0x98, 0x38, 0x61, 0xa8, 0xa7, 0xcf, 0xa8, 0xdc, 0xff, 0x24, 0x3b, 0xff, 0x1e,
-		0xeb, 0x6b, 0x9e, 0xf3, 0x7b, 0x56, 0xd0, 0x03, 0xa8, 0x7c, 0xca, 0x84, 0xa0, 0x3c, 0x6c, 0xa4,
-		0xe9, 0xb3, 0x83, 0xfa, 0x67, 0x2c, 0x0c, 0x57, 0xed, 0x53, 0xfd, 0xb3, 0x08, 0x4b, 0xb9, 0xbe,
-		0x14, 0xf5, 0x1d, 0xe6, 0x73, 0x30, 0x16, 0x4f, 0x11, 0x66, 0xab, 0xf7, 0xbf, 0x6c, 0x42, 0xb4,
-		0x14, 0x3a, 0xe9, 0xe3, 0x63, 0x66, 0xf8, 0x3d, 0x8c, 0x99, 0x0f, 0xe0, 0x98, 0xb3, 0x8c, 0x99,
-		0xc2, 0x3f, 0x3a, 0x66, 0x8a, 0xa7, 0x1e, 0x33, 0x1f, 0xc1, 0x64, 0x13, 0x73, 0xea, 0x0a, 0x8d,
-		0xa8, 0x87, 0x43, 0xf8, 0x6a, 0x2f, 0xf4, 0x39, 0xbd, 0x8c, 0x57, 0x28, 0x7a, 0x44, 0x4c, 0x34,
-		0x7b, 0x97, 0xba, 0x25, 0xb6, 0x7c, 0x5c, 0x62, 0x09, 0x4c, 0x77, 0xb5, 0x81, 0xc5, 0x69, 0xd0,
-		0xd9, 0x16, 0xd4, 0xb6, 0x37, 0x53, 0x0b, 0xbe, 0x69, 0x9b, 0x32, 0x45, 0x6f, 0x7d, 0xa1, 0x95,
-		0xb4, 0xfc, 0x7e, 0x0c, 0xf8, 0x89, 0xa9, 0x50, 0x49, 0x9d, 0x0a, 0xe3, 0xf9, 0xa7, 0xc2, 0x99,
-		0x77, 0x98, 0x0a, 0x67, 0xdf, 0x69, 0x2a, 0x54, 0x7f, 0x1f, 0x82, 0x95, 0xdc, 0xff, 0x36, 0xf8,
-		0xd0, 0x46, 0x6d, 0x0e, 0xc6, 0xf4, 0x7f, 0x4b, 0x94, 0x77, 0x0a, 0xbf, 0x18, 0x43, 0xb8, 0xa4,
-		0xbc, 0x53, 0xfc, 0xba, 0x8e, 0x64, 0x7f, 0x5d, 0xbb, 0x5a, 0x73, 0x34, 0x93, 0xfb, 0x2b, 0xf4,
-		0x73, 0x7f, 0x5f, 0x19, 0xb0, 0x9c, 0xf7, 0xbf, 0x17, 0xc9, 0xc5, 0x34, 0xde, 0xa9, 0x98, 0x6b,
-		0x9f, 0xc0, 0x45, 0xe2, 0x35, 0x92, 0xb2, 0xd7, 0xc6, 0x23, 0x0a, 0xdb, 0x72, 0x28, 0x6c, 0x1b,
-		0x1f, 0xaf, 0xec, 0x33, 0x71, 0x10, 0xec, 0xd6, 0x88, 0xd7, 0xa8, 0x77, 0xff, 0x6a, 0xb3, 0xc4,
-		0x6c, 0xa7, 0xbe, 0xef, 0x85, 0x3f, 0x14, 0xe9, 0x9f, 0x70, 0xee, 0xe3, 0x26, 0x3b, 0x5a, 0xd9,
-		0x2d, 0xa8, 0xb5, 0x5b, 0x7f, 0x07, 0x00, 0x00, 0xff, 0xff, 0x7f, 0xc1, 0xe0, 0x1d, 0x85, 0x1a,
-		0x00, 0x00,
+		0xf2, 0x08, 0x59, 0xe4, 0x49, 0xf2, 0x02, 0x51, 0x57, 0x57, 0xf7, 0x8c, 0xc7, 0x3d, 0x3d, 0xdd,
+		0x86, 0xb0, 0x73, 0xd7, 0x9c, 0xf3, 0xd5, 0x57, 0x75, 0x4e, 0x9f, 0xef, 0xf3, 0x0c, 0x54, 0xfd,
+		0x5d, 0x2a, 0xea, 0x04, 0xdb, 0xd4, 0x25, 0xb4, 0x8e, 0x9b, 0xac, 0x7e, 0xb4, 0x52, 0xb7, 0x29,
+		0x61, 0x1e, 0xe3, 0x6e, 0xad, 0x29, 0xb8, 0xe4, 0x68, 0x32, 0x88, 0xa9, 0xe9, 0x98, 0x1a, 0x6e,
+		0xb2, 0xda, 0xd1, 0xca, 0xa5, 0xab, 0xfb, 0x9c, 0xef, 0x3b, 0xb4, 0xae, 0x42, 0x76, 0xfd, 0xbd,
+		0xba, 0xed, 0x0b, 0x2c, 0xe3, 0xa4, 0x4b, 0xf3, 0x49, 0xc0, 0x84, 0x37, 0x1a, 0x71, 0x44, 0xe2,
+		0xd6, 0x12, 0x7b, 0x87, 0x0e, 0xf3, 0x64, 0x5a, 0x4c, 0x8b, 0x8b, 0xc3, 0x3d, 0x87, 0xb7, 0xc2,
+		0x98, 0xea, 0x37, 0x13, 0x50, 0x7a, 0xac, 0x19, 0xa3, 0xef, 0x0c, 0xb8, 0xe9, 0x91, 0x03, 0x6a,
+		0xfb, 0x0e, 0xb5, 0x30, 0x91, 0xec, 0x88, 0xc9, 0xb6, 0x15, 0xa0, 0x5a, 0xd1, 0xa9, 0x2c, 0x2c,
+		0xa5, 0x60, 0xbb, 0xbe, 0xa4, 0xde, 0xb4, 0x31, 0x6f, 0xfc, 0x77, 0x6c, 0xf5, 0x7e, 0x2d, 0xe1,
+		0x84, 0xb5, 0x97, 0x1a, 0xe6, 0x91, 0x46, 0xd9, 0xc1, 0xde, 0x61, 0xb4, 0xcf, 0xa3, 0x18, 0xe2,
+		0xe9, 0xbf, 0xcc, 0x05, 0x2f, 0x53, 0x24, 0xfa, 0x1c, 0xe6, 0x3c, 0x89, 0x85, 0xb4, 0x24, 0x6b,
+		0x50, 0x91, 0xc8, 0x67, 0x48, 0xf1, 0x59, 0x49, 0xe6, 0x13, 0xe4, 0xee, 0x04, 0xa9, 0x89, 0x2c,
+		0x66, 0xbd, 0x94, 0xcf, 0xd1, 0x8f, 0x06, 0x04, 0xb7, 0xdf, 0x74, 0xa8, 0xa4, 0x56, 0x74, 0x81,
+		0x16, 0x7d, 0x43, 0x89, 0x1f, 0x14, 0x2d, 0x91, 0xcc, 0xb0, 0x22, 0xf3, 0xff, 0x44, 0x32, 0xeb,
+		0x1a, 0xeb, 0xb5, 0x86, 0x7a, 0x12, 0x21, 0x25, 0x72, 0x5b, 0x24, 0xd9, 0xc3, 0xd1, 0xf7, 0x06,
+		0x2c, 0xee, 0x61, 0xe6, 0x64, 0xa5, 0x39, 0xa2, 0x68, 0x3e, 0x48, 0xa4, 0xb9, 0x81, 0x99, 0x93,
+		0x8d, 0xe2, 0xf5, 0xbd, 0x6c, 0xa1, 0xe8, 0x27, 0x03, 0x96, 0x05, 0xfd, 0xcc, 0xa7, 0x9e, 0xb4,
+		0x08, 0x76, 0x09, 0x75, 0x32, 0xf4, 0xd9, 0x68, 0xca, 0x55, 0x9a, 0x21, 0xd8, 0xba, 0xc2, 0x1a,
+		0xd8, 0x6c, 0x8b, 0x22, 0x7b, 0x38, 0xfa, 0x02, 0xe6, 0x35, 0xc5, 0xfe, 0x2d, 0x57, 0x50, 0xd4,
+		0x56, 0x93, 0xab, 0xac, 0x92, 0xfb, 0xf7, 0xdc, 0x15, 0x92, 0x16, 0x80, 0x7e, 0x30, 0x60, 0x49,
+		0xef, 0x9f, 0xb1, 0x96, 0x45, 0x45, 0xe6, 0x61, 0x0a, 0x99, 0x6c, 0xd5, 0xbc, 0x41, 0xb2, 0x06,
+		0xa3, 0x3f, 0x0c, 0x78, 0xd8, 0x53, 0x4f, 0xfa, 0x46, 0x52, 0xe1, 0xe2, 0xcc, 0xac, 0x4b, 0x8a,
+		0xf5, 0xf3, 0xc1, 0xd5, 0x7d, 0xa2, 0x81, 0xb3, 0x1d, 0xe2, 0xae, 0x38, 0x65, 0x2e, 0xfa, 0xd2,
+		0x80, 0x6b, 0x82, 0x12, 0x2e, 0x6c, 0xab, 0x81, 0xc5, 0x61, 0x9f, 0xca, 0x97, 0x15, 0xed, 0x5b,
+		0x7d, 0x68, 0x07, 0xd9, 0xcf, 0x55, 0x72, 0x22, 0xb9, 0xab, 0x22, 0x35, 0x02, 0xfd, 0x6a, 0xc0,
+		0x1d, 0xc2, 0x5d, 0xc9, 0x5c, 0x9f, 0x5a, 0xd8, 0xb3, 0x5c, 0xda, 0xca, 0x7a, 0x9d, 0xa0, 0x78,
+		0x3d, 0xe9, 0x33, 0x77, 0x42, 0xc8, 0x47, 0xde, 0x16, 0x6d, 0x65, 0xbb, 0xc6, 0x65, 0x92, 0x33,
+		0x07, 0xfd, 0x6c, 0xc0, 0x6a, 0x38, 0xa9, 0xc9, 0x01, 0x73, 0xec, 0xac, 0xbc, 0xc7, 0x14, 0xef,
+		0xb5, 0xfe, 0xc3, 0x7b, 0x3d, 0x40, 0xcb, 0x46, 0x7a, 0xc9, 0xcb, 0x93, 0x80, 0x7e, 0x33, 0xe0,
+		0x8e, 0xc7, 0xf6, 0x5d, 0x9c, 0xbf, 0x79, 0x2b, 0x8a, 0xf5, 0x46, 0x32, 0x6b, 0x05, 0x99, 0xaf,
+		0x6b, 0x57, 0xbc, 0xbc, 0x49, 0xe8, 0x17, 0x03, 0xfe, 0xe7, 0x37, 0x3d, 0x2a, 0x64, 0x87, 0xb4,
+		0x47, 0xb1, 0x20, 0x07, 0x5d, 0x44, 0x13, 0xc9, 0x8f, 0xa7, 0xb4, 0xca, 0x2b, 0x85, 0x18, 0xed,
+		0xff, 0x52, 0xe1, 0x75, 0x36, 0x4d, 0x6e, 0x15, 0x3f, 0x67, 0xce, 0x5a, 0x05, 0xa0, 0x43, 0xa7,
+		0xfa, 0x6d, 0x01, 0x16, 0xb2, 0xd9, 0x06, 0x34, 0x07, 0x63, 0xb1, 0x6c, 0x30, 0x5b, 0x19, 0x91,
+		0xb2, 0x09, 0xd1, 0xd2, 0xa6, 0x8d, 0x36, 0x60, 0x3c, 0x0e, 0x90, 0xed, 0x26, 0xd5, 0xde, 0xe0,
+		0x5a, 0xe2, 0x59, 0xe3, 0xcd, 0xda, 0x4d, 0x6a, 0x56, 0x70, 0xd7, 0x13, 0x9a, 0x82, 0x82, 0xcd,
+		0x1b, 0x98, 0xb9, 0x4a, 0xcf, 0xcb, 0xa6, 0x7e, 0x42, 0xf7, 0xa0, 0xac, 0xe4, 0x2a, 0x70, 0x5b,
+		0x5a, 0x43, 0xaf, 0x24, 0x62, 0x07, 0x07, 0x78, 0xc6, 0x3c, 0x69, 0x96, 0xa4, 0xfe, 0x0b, 0xad,
+		0xc2, 0x28, 0x73, 0x9b, 0xbe, 0xd4, 0xba, 0x36, 0x9b, 0x98, 0xb7, 0x8d, 0xdb, 0x0e, 0xc7, 0xb6,
+		0x19, 0x86, 0xa2, 0x1d, 0x98, 0x89, 0x8d, 0x99, 0xe4, 0x16, 0x71, 0xb8, 0x47, 0x95, 0x2c, 0x71,
+		0x5f, 0x6a, 0x11, 0x9a, 0xa9, 0x85, 0xa6, 0xb2, 0x16, 0x99, 0xca, 0xda, 0x63, 0x6d, 0x2a, 0xcd,
+		0xa9, 0x28, 0x77, 0x87, 0xaf, 0x07, 0x99, 0x3b, 0x61, 0x62, 0x2f, 0x6a, 0xc7, 0x5f, 0x05, 0xa8,
+		0xc5, 0x1c, 0xa8, 0xb1, 0xbb, 0x0a, 0x50, 0xb7, 0x60, 0x4a, 0x23, 0xf5, 0x12, 0x2d, 0x0d, 0x82,
+		0x9c, 0x0c, 0x6d, 0xd8, 0x71, 0x96, 0x1b, 0x30, 0x71, 0x40, 0xb1, 0x90, 0xbb, 0x14, 0x77, 0xd8,
+		0x95, 0x07, 0x41, 0x9d, 0x8b, 0x73, 0x22, 0x9c, 0x75, 0xa8, 0x08, 0x2a, 0x45, 0xdb, 0x6a, 0x72,
+		0x87, 0x91, 0xb6, 0x9e, 0x38, 0xf3, 0x7d, 0x26, 0xb8, 0x14, 0xed, 0x6d, 0x15, 0x67, 0x8e, 0x89,
+		0xce, 0x03, 0xba, 0x05, 0x85, 0x03, 0x8a, 0x6d, 0x2a, 0xf4, 0xab, 0x7f, 0x39, 0x31, 0xfd, 0xa9,
+		0x0a, 0x31, 0x75, 0x28, 0xba, 0x0d, 0x53, 0x91, 0x48, 0x3a, 0x9c, 0x60, 0xc7, 0xb2, 0x99, 0xd7,
+		0xc4, 0x92, 0x1c, 0xa8, 0x57, 0xb0, 0x64, 0x9e, 0xd7, 0x9f, 0x3e, 0x0b, 0x3e, 0x7c, 0xac, 0x3f,
+		0xab, 0x7e, 0x6d, 0xc0, 0x6c, 0x9a, 0x6d, 0x45, 0x33, 0x50, 0x0a, 0x9d, 0x49, 0xfc, 0x0a, 0x14,
+		0xd5, 0xf3, 0xa6, 0x8d, 0x9e, 0xc1, 0x85, 0xb8, 0x06, 0x7b, 0x4c, 0x74, 0x4a, 0x30, 0x34, 0xe8,
+		0xde, 0x90, 0x2e, 0xc1, 0x06, 0x13, 0x51, 0x05, 0xaa, 0x04, 0x16, 0x73, 0x58, 0x56, 0x74, 0x1b,
+		0x0a, 0x82, 0x7a, 0xbe, 0x23, 0xa7, 0x8d, 0x0c, 0x1d, 0xae, 0x63, 0xab, 0x18, 0xae, 0x67, 0x34,
+		0x9c, 0xe8, 0x0e, 0x14, 0x03, 0xc3, 0xe9, 0x0b, 0x9a, 0xba, 0xc3, 0x46, 0x18, 0x63, 0x46, 0xc1,
+		0xd5, 0x2d, 0x58, 0xcc, 0xe1, 0x17, 0x07, 0x4e, 0x99, 0xea, 0x3d, 0xb8, 0x92, 0x6a, 0xf2, 0x52,
+		0x2a, 0x54, 0x25, 0x70, 0x23, 0xb3, 0x27, 0x0b, 0x0e, 0x6c, 0x53, 0x89, 0x99, 0xe3, 0x65, 0xba,
+		0xd2, 0x28, 0xb8, 0xfa, 0x97, 0x01, 0x77, 0x4f, 0xeb, 0xa1, 0xba, 0x66, 0x9f, 0x71, 0x6c, 0xf6,
+		0xbd, 0x02, 0x74, 0x52, 0x1d, 0x75, 0x63, 0x2d, 0x24, 0xf2, 0x3a, 0xb1, 0x9b, 0x39, 0xd1, 0xea,
+		0x5d, 0x42, 0xd3, 0x50, 0x24, 0xdc, 0x95, 0x82, 0x3b, 0x6a, 0xd6, 0x56, 0xcc, 0xe8, 0x11, 0xd5,
+		0x60, 0xb2, 0xc7, 0x4a, 0x70, 0xd7, 0x69, 0xab, 0xb1, 0x5b, 0x32, 0x27, 0x48, 0xb7, 0xcc, 0xbf,
+		0x70, 0x9d, 0x76, 0xf5, 0xad, 0x01, 0x57, 0xd3, 0x2d, 0x58, 0x50, 0x5a, 0xed, 0xed, 0x5c, 0xdc,
+		0xa0, 0x51, 0x69, 0xc3, 0xa5, 0x2d, 0xdc, 0xa0, 0xdd, 0x37, 0x3e, 0x94, 0xe3, 0xc6, 0xbb, 0xe6,
+		0xc3, 0x70, 0xe6, 0xf9, 0x50, 0x7d, 0x5b, 0x82, 0xe5, 0xbc, 0xde, 0x2c, 0x90, 0xb8, 0xf8, 0x3e,
+		0x94, 0xc4, 0x19, 0x29, 0x12, 0x17, 0x01, 0x86, 0x12, 0xd7, 0xea, 0x7a, 0x3a, 0x2e, 0x65, 0x43,
+		0xa7, 0x94, 0xb2, 0xe1, 0xec, 0x52, 0x86, 0x61, 0xbe, 0xe3, 0xa9, 0xfa, 0x08, 0xc5, 0xc8, 0xa0,
+		0x29, 0x35, 0x1b, 0x43, 0xbc, 0x4c, 0x50, 0x8c, 0xd7, 0x70, 0x59, 0x1d, 0xa9, 0x0f, 0xfa, 0xe8,
+		0x20, 0xf4, 0x8b, 0x41, 0x76, 0x12, 0xf0, 0x0b, 0x98, 0xda, 0xc5, 0xe4, 0x90, 0xef, 0xed, 0x69,
+		0x6c, 0xe6, 0x4a, 0x2a, 0x8e, 0xb0, 0x33, 0x58, 0x83, 0xcf, 0xeb, 0x44, 0x05, 0xbb, 0xa9, 0xd3,
+		0x4e, 0x68, 0x52, 0xf1, 0x34, 0x9a, 0xb4, 0x09, 0x65, 0xe6, 0x32, 0xc9, 0xb0, 0xe4, 0x42, 0x69,
+		0xec, 0x99, 0xd5, 0xc5, 0xc1, 0xfe, 0x7f, 0x33, 0x4a, 0x31, 0x3b, 0xd9, 0xdd, 0x93, 0xb5, 0x9c,
+		0x63, 0xb2, 0x22, 0x13, 0xa6, 0x1c, 0x1c, 0xfc, 0x0f, 0x18, 0xca, 0x44, 0x50, 0x5a, 0x2d, 0x01,
+		0x90, 0xa1, 0x33, 0xce, 0x07, 0xb9, 0xeb, 0x71, 0xaa, 0xa9, 0x32, 0xd1, 0xbf, 0x61, 0x9c, 0x88,
+		0xa0, 0x47, 0xb4, 0xcd, 0x50, 0x82, 0x5d, 0x36, 0x2b, 0xc1, 0x62, 0xe4, 0x13, 0x4f, 0xa7, 0xc7,
+		0x4b, 0x30, 0xd2, 0xa0, 0x0d, 0xae, 0x0d, 0xf0, 0x4c, 0x62, 0xca, 0x73, 0xda, 0xe0, 0xa6, 0x0a,
+		0x43, 0x26, 0x4c, 0x9c, 0x30, 0xd4, 0xd3, 0x67, 0x54, 0xee, 0x7f, 0x92, 0x9d, 0x7f, 0x8f, 0xf5,
+		0x35, 0xcf, 0x79, 0x3d, 0x2b, 0xe8, 0x01, 0x54, 0x3e, 0x65, 0x52, 0x52, 0x11, 0x36, 0xd2, 0xf4,
+		0xd9, 0x41, 0xfd, 0x33, 0x16, 0x86, 0xab, 0xf6, 0xa9, 0xfe, 0x59, 0x84, 0xa5, 0x5c, 0xff, 0x14,
+		0xf5, 0x1d, 0xe6, 0x73, 0x30, 0x16, 0x4f, 0x11, 0x66, 0xab, 0xf7, 0xbf, 0x6c, 0x42, 0xb4, 0x14,
+		0x3a, 0xe9, 0xe3, 0x63, 0x66, 0xf8, 0x3d, 0x8c, 0x99, 0x0f, 0xe0, 0x98, 0xb3, 0x8c, 0x99, 0xc2,
+		0x3f, 0x3a, 0x66, 0x8a, 0xa7, 0x1e, 0x33, 0x1f, 0xc1, 0x64, 0x13, 0x0b, 0xea, 0x4a, 0x8d, 0xa8,
+		0x87, 0x43, 0xf8, 0x6a, 0x2f, 0xf4, 0x39, 0x7d, 0x10, 0xaf, 0x50, 0xf4, 0x88, 0x98, 0x68, 0xf6,
+		0x2e, 0x75, 0x4b, 0x6c, 0xf9, 0xb8, 0xc4, 0x12, 0x98, 0xee, 0x6a, 0x03, 0x4b, 0x50, 0xbf, 0xb3,
+		0x2d, 0xa8, 0x6d, 0x6f, 0xa6, 0x16, 0x7c, 0xd3, 0x36, 0xa9, 0x1f, 0xed, 0x63, 0x5e, 0x68, 0x25,
+		0x2d, 0xbf, 0x1f, 0x03, 0x7e, 0x62, 0x2a, 0x54, 0x52, 0xa7, 0xc2, 0x78, 0xfe, 0xa9, 0x70, 0xe6,
+		0x1d, 0xa6, 0xc2, 0xd9, 0x77, 0x9a, 0x0a, 0xd5, 0xdf, 0x87, 0x60, 0x25, 0xf7, 0xd7, 0x06, 0x1f,
+		0xda, 0xa8, 0xcd, 0xc1, 0x98, 0xfe, 0xb6, 0x44, 0x79, 0xa7, 0xf0, 0x1f, 0x63, 0x08, 0x97, 0x94,
+		0x77, 0x8a, 0x5f, 0xd7, 0x91, 0xec, 0xaf, 0x6b, 0x57, 0x6b, 0x8e, 0x66, 0x72, 0x7f, 0x85, 0x7e,
+		0xee, 0xef, 0x2b, 0x03, 0x96, 0xf3, 0x7e, 0x7b, 0x91, 0x5c, 0x4c, 0xe3, 0x9d, 0x8a, 0xb9, 0xf6,
+		0x09, 0x5c, 0x24, 0xbc, 0x91, 0x94, 0xbd, 0x36, 0x1e, 0x51, 0xd8, 0x16, 0x5c, 0xf2, 0x6d, 0xe3,
+		0xe3, 0x95, 0x7d, 0x26, 0x0f, 0xfc, 0xdd, 0x1a, 0xe1, 0x8d, 0x7a, 0xf7, 0xaf, 0x36, 0x4b, 0xcc,
+		0x76, 0xea, 0xfb, 0x3c, 0xfc, 0xa1, 0x48, 0xff, 0x84, 0x73, 0x1f, 0x37, 0xd9, 0xd1, 0xca, 0x6e,
+		0x41, 0xad, 0xdd, 0xfa, 0x7b, 0x00, 0x7f, 0xc1, 0xe0, 0x1d, 0x85, 0x1a, 0x00, 0x00,
 	},
 	// uber/cadence/admin/v1/cluster.proto
 	[]byte{
@@ -3724,8 +4166,7 @@ var yarpcFileDescriptorClosurefee8ff76963a38ed = [][]byte{
 		0x6f, 0x53, 0xb4, 0xa3, 0xd6, 0x32, 0x4b, 0xef, 0xb5, 0x5d, 0xdf, 0x15, 0x64, 0x9a, 0xee, 0x68,
 		0x47, 0x9d, 0x3f, 0xff, 0x7e, 0xb2, 0x12, 0x76, 0x5d, 0x65, 0x31, 0xd7, 0x32, 0xb9, 0x78, 0x75,
 		0x4f, 0x45, 0x5e, 0x24, 0x2b, 0x9d, 0xb8, 0x5b, 0xeb, 0x4e, 0xf0, 0x95, 0x0b, 0x36, 0xb3, 0xec,
-		0xba, 0xab, 0x3f, 0xfb, 0x13, 0x00, 0x00, 0xff, 0xff, 0xaf, 0x49, 0xfd, 0x6d, 0xaa, 0x03, 0x00,
-		0x00,
+		0xba, 0xab, 0x3f, 0xfb, 0x33, 0x00, 0xaf, 0x49, 0xfd, 0x6d, 0xaa, 0x03, 0x00, 0x00,
 	},
 	// uber/cadence/admin/v1/history.proto
 	[]byte{
@@ -3742,294 +4183,293 @@ var yarpcFileDescriptorClosurefee8ff76963a38ed = [][]byte{
 		0x0d, 0x6e, 0x23, 0x4d, 0x3d, 0xac, 0xce, 0xd4, 0xc3, 0x74, 0x63, 0x10, 0x44, 0x9f, 0x93, 0x79,
 		0x94, 0x69, 0x7a, 0x66, 0x49, 0x46, 0x69, 0x92, 0x5e, 0x72, 0x7e, 0xae, 0x3e, 0x72, 0x48, 0xe8,
 		0x66, 0xa6, 0xe4, 0xe8, 0xa7, 0xe7, 0xeb, 0x83, 0xfd, 0x0f, 0x0f, 0x16, 0x6b, 0x30, 0xa3, 0xcc,
-		0x30, 0x89, 0x0d, 0x2c, 0x6e, 0x0c, 0x08, 0x00, 0x00, 0xff, 0xff, 0x44, 0x14, 0xd7, 0xd4, 0x3e,
-		0x01, 0x00, 0x00,
+		0x30, 0x89, 0x0d, 0x2c, 0x6e, 0x0c, 0x18, 0x00, 0x44, 0x14, 0xd7, 0xd4, 0x3e, 0x01, 0x00, 0x00,
 	},
 	// uber/cadence/admin/v1/queue.proto
 	[]byte{
 		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x59, 0x4f, 0x6f, 0xdb, 0xc8,
-		0x15, 0x37, 0x25, 0xcb, 0x96, 0x9f, 0x1d, 0x9b, 0x9e, 0x24, 0x8d, 0xe2, 0xc4, 0x88, 0xa2, 0x05,
-		0x12, 0xc1, 0x9b, 0x48, 0xb5, 0xbd, 0x41, 0x16, 0xbb, 0x45, 0x5b, 0x9a, 0x62, 0x2c, 0xae, 0x65,
-		0x49, 0x18, 0x52, 0x49, 0xbc, 0x58, 0x80, 0xa0, 0xa9, 0xb1, 0x43, 0x84, 0x22, 0xb5, 0x24, 0xe5,
-		0x54, 0x1f, 0xa0, 0xd9, 0x7b, 0xb7, 0xd7, 0x5e, 0x8a, 0xde, 0x0a, 0xf4, 0xd4, 0x1e, 0x8a, 0x5e,
-		0x0b, 0xf4, 0xd4, 0x6b, 0x7b, 0xd8, 0x2f, 0xd1, 0x8f, 0x50, 0x70, 0x48, 0x4a, 0x14, 0x45, 0x59,
-		0x74, 0xe2, 0x02, 0x3d, 0xec, 0x4d, 0x7a, 0xfc, 0xcd, 0xe3, 0xef, 0xfd, 0x99, 0xdf, 0xbc, 0x91,
-		0xe0, 0xe1, 0xe0, 0x94, 0xd8, 0x55, 0x4d, 0xed, 0x12, 0x53, 0x23, 0x55, 0xb5, 0xdb, 0xd3, 0xcd,
-		0xea, 0xc5, 0x6e, 0xf5, 0xdb, 0x01, 0x19, 0x90, 0x4a, 0xdf, 0xb6, 0x5c, 0x0b, 0xdd, 0xf6, 0x20,
-		0x95, 0x00, 0x52, 0xa1, 0x90, 0xca, 0xc5, 0xee, 0xd6, 0x83, 0x73, 0xcb, 0x3a, 0x37, 0x48, 0x95,
-		0x82, 0x4e, 0x07, 0x67, 0x55, 0x57, 0xef, 0x11, 0xc7, 0x55, 0x7b, 0x7d, 0x7f, 0xdd, 0x56, 0x71,
-		0xd2, 0x75, 0x5f, 0xf7, 0x1c, 0x6b, 0x56, 0xaf, 0x67, 0x99, 0x01, 0xe2, 0x61, 0x12, 0xe2, 0x8d,
-		0xee, 0xb8, 0x96, 0x3d, 0x0c, 0x20, 0xa5, 0x24, 0xc8, 0x3b, 0xcb, 0x7e, 0x7b, 0x66, 0x58, 0xef,
-		0x7c, 0x4c, 0xe9, 0x87, 0x0c, 0xdc, 0xe2, 0x6d, 0xcb, 0x71, 0x78, 0x63, 0xe0, 0xb8, 0xc4, 0x96,
-		0x55, 0xe7, 0xad, 0x68, 0x9e, 0x59, 0xe8, 0x1e, 0xac, 0x74, 0xad, 0x9e, 0xaa, 0x9b, 0x8a, 0xde,
-		0x2d, 0x30, 0x45, 0xa6, 0xbc, 0x82, 0xf3, 0xbe, 0x41, 0xec, 0xa2, 0x0e, 0xa0, 0xd0, 0x8f, 0x42,
-		0x7e, 0x45, 0xb4, 0x81, 0xab, 0x5b, 0x66, 0x21, 0x53, 0x64, 0xca, 0xab, 0x7b, 0x8f, 0x2a, 0x93,
-		0x31, 0xf7, 0xf5, 0xca, 0xc5, 0x6e, 0xe5, 0x55, 0x00, 0x17, 0x42, 0x34, 0xde, 0x7c, 0x17, 0x37,
-		0xa1, 0x3a, 0xac, 0xb8, 0xaa, 0xf3, 0x56, 0x71, 0x87, 0x7d, 0x52, 0xc8, 0x16, 0x99, 0xf2, 0xfa,
-		0xde, 0xa7, 0x95, 0xc4, 0x0c, 0x56, 0xe2, 0x9c, 0xe5, 0x61, 0x9f, 0xe0, 0xbc, 0x1b, 0x7c, 0x42,
-		0xdb, 0x00, 0xd4, 0x93, 0xe3, 0xaa, 0x2e, 0x29, 0x2c, 0x16, 0x99, 0x72, 0x0e, 0x53, 0xdf, 0x92,
-		0x67, 0x40, 0x77, 0x60, 0x99, 0x3e, 0xd6, 0xbb, 0x85, 0x5c, 0x91, 0x29, 0x67, 0xf1, 0x92, 0xf7,
-		0x55, 0xec, 0xa2, 0x63, 0xb8, 0x75, 0xa1, 0x3b, 0xfa, 0xa9, 0x6e, 0xe8, 0xee, 0x50, 0x19, 0x55,
-		0xa5, 0xb0, 0x44, 0x43, 0xdb, 0xaa, 0xf8, 0x75, 0xab, 0x84, 0x75, 0xab, 0xc8, 0x21, 0x02, 0xdf,
-		0x1c, 0xaf, 0x1b, 0x19, 0x4b, 0x7f, 0x58, 0x84, 0x9f, 0x46, 0x99, 0x4a, 0xae, 0x6a, 0xbb, 0xfc,
-		0x1b, 0xdd, 0xe8, 0x8e, 0xf3, 0x40, 0xbe, 0x1d, 0x10, 0xc7, 0xe5, 0x5c, 0xd7, 0xd6, 0x4f, 0x07,
-		0x2e, 0x71, 0x50, 0x19, 0x58, 0x57, 0xb5, 0xcf, 0x89, 0xab, 0xc4, 0x0b, 0xb0, 0xee, 0xdb, 0x6b,
-		0x61, 0x19, 0xb6, 0x01, 0x6c, 0x7f, 0xb9, 0x87, 0xc9, 0x50, 0xcc, 0x4a, 0x60, 0x11, 0xbb, 0xe8,
-		0x09, 0x20, 0xdd, 0xd4, 0x5d, 0x5d, 0x75, 0x49, 0x57, 0x21, 0x17, 0xc4, 0xa4, 0xb0, 0x2c, 0x0d,
-		0x98, 0x1d, 0x3d, 0x11, 0xbc, 0x07, 0x62, 0x17, 0xbd, 0x67, 0x60, 0x2b, 0x0e, 0x57, 0x47, 0xac,
-		0x68, 0x0e, 0x57, 0xf7, 0xea, 0x89, 0xc5, 0x1d, 0x87, 0x35, 0x55, 0x66, 0x71, 0xe2, 0x35, 0xe3,
-		0x28, 0x71, 0x41, 0x9f, 0xf1, 0x04, 0x95, 0xe0, 0x46, 0x10, 0xbf, 0x3d, 0x30, 0xc3, 0x12, 0xad,
-		0xe0, 0x55, 0xdf, 0x88, 0x07, 0x5e, 0xe4, 0xdf, 0x31, 0xc0, 0xf6, 0x55, 0xdb, 0xd5, 0xbd, 0x77,
-		0x28, 0x9a, 0x65, 0x9e, 0xe9, 0xe7, 0x85, 0xa5, 0x62, 0xb6, 0xbc, 0xba, 0xf7, 0x4d, 0x8a, 0x8e,
-		0x49, 0x53, 0x87, 0x4a, 0x3b, 0xf4, 0xcf, 0x53, 0xf7, 0x82, 0xe9, 0xda, 0x43, 0xbc, 0xd1, 0x9f,
-		0xb4, 0x6e, 0x1d, 0xc0, 0xad, 0x24, 0x20, 0x62, 0x21, 0xfb, 0x96, 0x0c, 0x83, 0xc2, 0x79, 0x1f,
-		0xd1, 0x2d, 0xc8, 0x5d, 0xa8, 0xc6, 0x80, 0x04, 0x85, 0xf2, 0xbf, 0x7c, 0x91, 0xf9, 0x9c, 0x29,
-		0x7d, 0x05, 0xbb, 0x73, 0xd9, 0x39, 0x7d, 0xcb, 0x74, 0x48, 0x24, 0x4d, 0xb7, 0x61, 0x29, 0xc8,
-		0x8f, 0xff, 0x8e, 0x9c, 0xed, 0x65, 0xa6, 0xf4, 0xd7, 0x0c, 0x3c, 0x89, 0x3a, 0xe3, 0x55, 0x53,
-		0x23, 0xc6, 0xb5, 0xb4, 0xdb, 0x29, 0xdc, 0x0d, 0x90, 0x1f, 0xbd, 0xf9, 0xef, 0xf8, 0x8e, 0xa6,
-		0x1e, 0xc4, 0x5a, 0x3a, 0x9b, 0xae, 0xa5, 0x17, 0x67, 0xb4, 0x74, 0x05, 0x6e, 0x6a, 0x5e, 0x1a,
-		0xc7, 0x7c, 0x2d, 0xd3, 0x18, 0xd2, 0x7e, 0xca, 0xe3, 0x4d, 0x2d, 0xda, 0xb0, 0x2d, 0xd3, 0x18,
-		0x96, 0xaa, 0xf0, 0xf4, 0xd2, 0xd4, 0xc5, 0x6b, 0x50, 0xfa, 0x4b, 0x76, 0x32, 0xd9, 0x92, 0x7e,
-		0x6e, 0xaa, 0x3f, 0x26, 0x3b, 0x4d, 0xb2, 0xd1, 0x03, 0x58, 0x75, 0x68, 0xba, 0x14, 0x53, 0xed,
-		0x11, 0xaa, 0xb0, 0x2b, 0x18, 0x7c, 0x53, 0x53, 0xed, 0x11, 0xf4, 0x0b, 0x58, 0x0b, 0x00, 0xba,
-		0xd9, 0x1f, 0xb8, 0x85, 0x65, 0x1a, 0xf4, 0xfd, 0xc4, 0xa0, 0xdb, 0xea, 0xd0, 0xb0, 0xd4, 0x2e,
-		0x0e, 0x5c, 0x8a, 0xde, 0x02, 0x54, 0x80, 0x65, 0xcd, 0x32, 0x5d, 0xdb, 0x32, 0x0a, 0xf9, 0x22,
-		0x53, 0x5e, 0xc3, 0xe1, 0xd7, 0x78, 0xa1, 0xa7, 0xca, 0x36, 0x55, 0xe8, 0x7f, 0x66, 0x80, 0x8b,
-		0xae, 0xc0, 0x44, 0xb3, 0xec, 0x6e, 0xb2, 0xe4, 0xf1, 0x56, 0xaf, 0x6f, 0x10, 0x97, 0xfc, 0xbf,
-		0x57, 0xff, 0x6a, 0xc7, 0x43, 0x03, 0x58, 0xcd, 0x0f, 0xcc, 0x53, 0x5c, 0x0a, 0x0f, 0xce, 0x84,
-		0x87, 0x89, 0x44, 0xea, 0xfe, 0x28, 0x42, 0x97, 0xe3, 0x8d, 0xf1, 0x52, 0x6a, 0x28, 0xd5, 0xe0,
-		0xe0, 0xea, 0xe9, 0x9c, 0xaa, 0xca, 0x7f, 0x18, 0x28, 0x72, 0xfd, 0xbe, 0x31, 0x6c, 0xab, 0x36,
-		0x31, 0x5d, 0xde, 0xb0, 0x1c, 0xd2, 0xb6, 0x0c, 0x5d, 0x1b, 0x46, 0x92, 0xfe, 0x08, 0x36, 0xfc,
-		0xbe, 0x8c, 0xe7, 0xfc, 0x06, 0x35, 0x8f, 0x52, 0xbe, 0x03, 0x9b, 0xb1, 0xfe, 0x1d, 0x9d, 0xa9,
-		0x1b, 0x13, 0xdd, 0x2b, 0x76, 0x51, 0x11, 0xd6, 0x7c, 0x6c, 0xa0, 0xc0, 0xfe, 0xd6, 0x01, 0x6a,
-		0xf3, 0x0f, 0xa8, 0x97, 0x70, 0xb3, 0x4f, 0x49, 0x29, 0x9a, 0xc7, 0x4a, 0xe9, 0x53, 0x5a, 0x34,
-		0x63, 0xeb, 0x33, 0x4a, 0x37, 0x15, 0x04, 0xde, 0xec, 0xc7, 0x4d, 0xa5, 0xef, 0x19, 0xb8, 0x9f,
-		0x1c, 0xb2, 0x37, 0xd9, 0x0c, 0x1c, 0x74, 0x1f, 0x56, 0x82, 0x64, 0x13, 0x3f, 0xd0, 0x3c, 0x1e,
-		0x1b, 0x50, 0x07, 0xd6, 0xce, 0x54, 0xdd, 0x20, 0x5d, 0x45, 0x53, 0x07, 0x8e, 0x7f, 0x14, 0xad,
-		0xef, 0xed, 0xa5, 0x1c, 0xb2, 0x5e, 0xd0, 0xa5, 0xbc, 0xb7, 0x12, 0xaf, 0x9e, 0x8d, 0xbf, 0x94,
-		0xfe, 0xc6, 0xc0, 0x76, 0x32, 0xab, 0x60, 0x13, 0xa0, 0x63, 0xc8, 0xd1, 0xec, 0x50, 0x4a, 0xab,
-		0x7b, 0xcf, 0x67, 0xbc, 0x71, 0x5e, 0x35, 0xb1, 0xef, 0x05, 0x1d, 0xc1, 0x92, 0x43, 0xe3, 0x0d,
-		0x36, 0xc3, 0xfe, 0x95, 0xfc, 0xf9, 0xa9, 0xc2, 0x81, 0x8b, 0xd2, 0x77, 0x0c, 0xec, 0x47, 0x43,
-		0xbd, 0x34, 0x92, 0x48, 0x67, 0xb5, 0x21, 0x4f, 0xd9, 0xd8, 0xc4, 0x2c, 0x30, 0x74, 0xf6, 0xf8,
-		0xec, 0x4a, 0x34, 0x02, 0x8f, 0x78, 0xe4, 0xa5, 0xf4, 0xf7, 0x99, 0xd5, 0xc5, 0xc4, 0x19, 0x18,
-		0xd7, 0x9e, 0xc6, 0xff, 0x51, 0x3b, 0xfc, 0x96, 0x81, 0xcf, 0xd2, 0x24, 0x74, 0x6a, 0xa6, 0xf9,
-		0x26, 0xd8, 0xab, 0x36, 0x31, 0x95, 0xa0, 0xbe, 0x7e, 0x62, 0xf7, 0xaf, 0x98, 0x58, 0x2f, 0x59,
-		0x78, 0x3d, 0xf4, 0xe5, 0xd7, 0xbb, 0xf4, 0xbb, 0x65, 0xb8, 0x13, 0x8f, 0x21, 0xec, 0xcf, 0xf0,
-		0xea, 0xa1, 0x9b, 0x67, 0x56, 0x90, 0xdc, 0xb4, 0x57, 0x0f, 0xef, 0xba, 0xe4, 0x5f, 0x3d, 0xe8,
-		0xc5, 0xe9, 0x37, 0x0c, 0x14, 0x1d, 0x6f, 0x82, 0x53, 0x7c, 0x89, 0x18, 0xe9, 0x76, 0x74, 0x9a,
-		0xf6, 0xbb, 0xf6, 0xf0, 0x9a, 0x46, 0xd5, 0xfa, 0x02, 0xde, 0x76, 0xa6, 0x71, 0x91, 0xc4, 0xfe,
-		0x9a, 0x81, 0x7b, 0x1a, 0x1d, 0x67, 0x92, 0xf9, 0x64, 0x29, 0x1f, 0x3e, 0x05, 0x9f, 0x79, 0xf3,
-		0x64, 0x7d, 0x01, 0xdf, 0xd5, 0x26, 0x31, 0x31, 0x1e, 0xc1, 0xa1, 0x9e, 0xc8, 0x63, 0x31, 0x35,
-		0x8f, 0x79, 0xa3, 0x96, 0xc7, 0xc3, 0x99, 0xc4, 0x44, 0x78, 0xfc, 0x8b, 0x81, 0x2f, 0x6d, 0x7a,
-		0xe8, 0x28, 0x31, 0xd1, 0x1f, 0xd3, 0x0a, 0x55, 0x53, 0x09, 0x67, 0xa4, 0x08, 0xcf, 0x1c, 0xe5,
-		0xf9, 0x3a, 0x05, 0xcf, 0x0f, 0x9a, 0x14, 0xea, 0x0b, 0xf8, 0x99, 0xfd, 0x41, 0x23, 0xc6, 0x1f,
-		0x19, 0x78, 0xa2, 0x7a, 0x9b, 0x42, 0x49, 0x38, 0x7e, 0x92, 0x22, 0xf1, 0x6f, 0xb6, 0x5f, 0xa5,
-		0x88, 0x24, 0xa5, 0x2c, 0xd6, 0x17, 0xf0, 0x63, 0x35, 0x1d, 0xf4, 0x60, 0x0d, 0x60, 0x4c, 0xa5,
-		0xf4, 0xe7, 0x3c, 0x14, 0xa6, 0xf7, 0xa7, 0x2f, 0x12, 0xd1, 0x2b, 0x3b, 0x33, 0x71, 0x65, 0x9f,
-		0xf8, 0xd1, 0x20, 0x73, 0x7d, 0x3f, 0x1a, 0x64, 0xe3, 0x3f, 0x1a, 0xc4, 0xc5, 0x72, 0xf1, 0x5a,
-		0xc4, 0x12, 0x7d, 0x9f, 0x46, 0x2f, 0x72, 0x89, 0xb7, 0xef, 0xd4, 0x7a, 0x11, 0x17, 0xda, 0xf9,
-		0x82, 0xf1, 0x7e, 0x8e, 0x60, 0xf8, 0x6d, 0x53, 0xfb, 0x10, 0xc1, 0x48, 0x20, 0x73, 0x89, 0x62,
-		0xbc, 0x9f, 0xa3, 0x18, 0xcb, 0xa9, 0x89, 0xcc, 0x9d, 0xf2, 0x2f, 0x97, 0x8c, 0x7f, 0x7f, 0xa4,
-		0x64, 0xe4, 0x29, 0xd1, 0x93, 0x6b, 0x93, 0x8c, 0x04, 0xf6, 0x1f, 0xa8, 0x19, 0x7f, 0x62, 0xe0,
-		0xe9, 0x65, 0x9a, 0xe1, 0xbf, 0x29, 0x1a, 0xcb, 0x0a, 0x8d, 0xe5, 0xe8, 0x23, 0x44, 0x23, 0x81,
-		0x7d, 0x59, 0x4d, 0x89, 0x8d, 0xc9, 0x86, 0x95, 0xa4, 0x1a, 0x34, 0x46, 0x07, 0x49, 0x70, 0x83,
-		0x6e, 0xe9, 0xa0, 0x1e, 0xe1, 0x38, 0x51, 0x49, 0xb9, 0x69, 0xc3, 0x09, 0x6d, 0xcd, 0x8d, 0x38,
-		0xdd, 0x79, 0xcf, 0x40, 0x3e, 0x94, 0x0f, 0x74, 0x1b, 0x36, 0x65, 0x4e, 0x3a, 0x52, 0xe4, 0x93,
-		0xb6, 0xa0, 0x88, 0xcd, 0x97, 0x5c, 0x43, 0xac, 0xb1, 0x0b, 0xe8, 0x27, 0x80, 0xc6, 0x66, 0x19,
-		0x73, 0x4d, 0xe9, 0x85, 0x80, 0x59, 0x06, 0xdd, 0x84, 0x8d, 0x88, 0x5d, 0x3c, 0x16, 0x30, 0x9b,
-		0x41, 0x77, 0xe1, 0xf6, 0xd8, 0x88, 0x85, 0x76, 0x43, 0xe4, 0x39, 0x59, 0x6c, 0x35, 0xd9, 0x2c,
-		0xba, 0x07, 0x77, 0xc6, 0x8f, 0x78, 0xdc, 0x92, 0x24, 0x85, 0x6f, 0x74, 0x24, 0x59, 0xc0, 0xec,
-		0xe2, 0xce, 0x3f, 0x12, 0x7e, 0xbc, 0xa5, 0xa4, 0x3e, 0x81, 0x07, 0x13, 0x58, 0x25, 0x89, 0xe2,
-		0x2e, 0x3c, 0x9d, 0x05, 0x92, 0x64, 0x0e, 0xcb, 0x0a, 0x5f, 0x17, 0x1b, 0x35, 0x45, 0x78, 0x2d,
-		0xf0, 0x1d, 0xca, 0x86, 0x41, 0x4f, 0xa0, 0x3c, 0x6b, 0x09, 0xcf, 0x35, 0x79, 0xa1, 0x11, 0x41,
-		0x67, 0x2e, 0x43, 0x4b, 0xe2, 0x61, 0x93, 0x8b, 0xa2, 0xb3, 0xa8, 0x06, 0xbf, 0x9c, 0x85, 0xc6,
-		0x02, 0xdf, 0xc2, 0xb5, 0x80, 0xcf, 0xab, 0x16, 0x3e, 0x3a, 0x6a, 0xb4, 0x5e, 0x8d, 0x17, 0x2b,
-		0x7c, 0xeb, 0xb8, 0xdd, 0x10, 0x64, 0x81, 0x5d, 0x44, 0xcf, 0x60, 0x77, 0x96, 0x17, 0xae, 0xdd,
-		0x6e, 0x9c, 0x28, 0x6d, 0x0e, 0x0b, 0x4d, 0x59, 0xe1, 0x1b, 0x2d, 0x49, 0x50, 0xda, 0xad, 0x86,
-		0xc8, 0x9f, 0xb0, 0xb9, 0x9d, 0xdf, 0x67, 0xe1, 0xde, 0x25, 0x8a, 0x8d, 0x3e, 0x85, 0xc7, 0x09,
-		0x6e, 0x5f, 0x70, 0x62, 0x43, 0xa8, 0x29, 0x3c, 0xd7, 0x91, 0xa2, 0x89, 0x4d, 0xe6, 0x30, 0x01,
-		0xae, 0xb5, 0x8e, 0x39, 0xb1, 0xa9, 0x34, 0x5b, 0xb2, 0xc2, 0xf1, 0xb2, 0xf8, 0x52, 0x60, 0x99,
-		0x2b, 0x2e, 0x13, 0x5e, 0x8b, 0x92, 0x2c, 0xb1, 0x19, 0xf4, 0x33, 0xf8, 0x7c, 0xde, 0x32, 0x2f,
-		0x65, 0x2f, 0xbc, 0x94, 0x71, 0x0d, 0x2c, 0x70, 0xb5, 0x13, 0x05, 0x77, 0x9a, 0x4d, 0xb1, 0x79,
-		0xc8, 0x66, 0xd1, 0x73, 0xd8, 0x4f, 0xbd, 0x3a, 0xf2, 0xda, 0x45, 0xf4, 0x73, 0xf8, 0xe2, 0xca,
-		0xaf, 0x0d, 0xeb, 0x54, 0x63, 0x73, 0x33, 0xba, 0x6f, 0x62, 0x7d, 0xa7, 0xc9, 0x73, 0xb2, 0x70,
-		0xd8, 0xc2, 0xe2, 0xd7, 0x42, 0x8d, 0x5d, 0xda, 0xf9, 0x81, 0x01, 0x74, 0x48, 0xdc, 0x78, 0x6d,
-		0x1e, 0xc2, 0xf6, 0xa1, 0x20, 0x5f, 0x5a, 0x91, 0x47, 0x50, 0x4a, 0x86, 0x48, 0x02, 0x7e, 0x29,
-		0xf2, 0x82, 0x72, 0xd0, 0x91, 0x4e, 0x58, 0x66, 0xb6, 0x2b, 0x6f, 0xa7, 0xb6, 0x3a, 0x32, 0x9b,
-		0x41, 0x15, 0xd8, 0x99, 0xe1, 0xaa, 0xce, 0xe1, 0x9a, 0xd2, 0x7a, 0xd5, 0x14, 0xb0, 0x54, 0x17,
-		0xdb, 0x4a, 0xa3, 0x25, 0xc9, 0x6c, 0x16, 0x3d, 0x86, 0x4f, 0x92, 0xf1, 0x93, 0xd1, 0x2d, 0x1e,
-		0x3c, 0xff, 0xfa, 0xd9, 0xb9, 0xee, 0xbe, 0x19, 0x9c, 0x56, 0x34, 0xab, 0x57, 0x8d, 0xfe, 0x75,
-		0xf3, 0x54, 0xef, 0x1a, 0xd5, 0x73, 0xcb, 0xff, 0xb7, 0x68, 0xf4, 0x3f, 0xd3, 0x97, 0xf4, 0xc3,
-		0xc5, 0xee, 0xe9, 0x12, 0xb5, 0xef, 0xff, 0x37, 0x00, 0x00, 0xff, 0xff, 0x4b, 0xe2, 0x06, 0x07,
-		0x8f, 0x1a, 0x00, 0x00,
+		0x15, 0x37, 0xf5, 0xc7, 0x96, 0x9f, 0xbd, 0x36, 0x3d, 0x49, 0x1a, 0xc5, 0x89, 0x11, 0x45, 0x0b,
+		0x24, 0x82, 0x37, 0x91, 0x6a, 0x7b, 0x83, 0x2c, 0x76, 0x8b, 0xb6, 0x34, 0xc5, 0x58, 0x5c, 0xcb,
+		0x92, 0x30, 0xa4, 0x92, 0x78, 0xb1, 0x00, 0x41, 0x4b, 0x63, 0x87, 0x30, 0x45, 0x6a, 0x49, 0xca,
+		0xa9, 0x3e, 0x40, 0xb3, 0xf7, 0x6e, 0xaf, 0xbd, 0x14, 0xbd, 0x15, 0xe8, 0xa9, 0x3d, 0x14, 0xbd,
+		0x16, 0xe8, 0xa9, 0xd7, 0xf6, 0x90, 0x2f, 0xd1, 0x8f, 0x50, 0x70, 0x48, 0x4a, 0x14, 0x45, 0x59,
+		0xb4, 0xe3, 0x02, 0x3d, 0xec, 0x4d, 0x7c, 0xfc, 0xbd, 0x37, 0xbf, 0xf7, 0x67, 0xde, 0xbc, 0xa1,
+		0xe0, 0xd1, 0xe0, 0x84, 0x58, 0x95, 0x8e, 0xda, 0x25, 0x46, 0x87, 0x54, 0xd4, 0x6e, 0x4f, 0x33,
+		0x2a, 0x17, 0x3b, 0x95, 0xef, 0x06, 0x64, 0x40, 0xca, 0x7d, 0xcb, 0x74, 0x4c, 0x74, 0xc7, 0x85,
+		0x94, 0x7d, 0x48, 0x99, 0x42, 0xca, 0x17, 0x3b, 0x9b, 0x0f, 0xcf, 0x4c, 0xf3, 0x4c, 0x27, 0x15,
+		0x0a, 0x3a, 0x19, 0x9c, 0x56, 0x1c, 0xad, 0x47, 0x6c, 0x47, 0xed, 0xf5, 0x3d, 0xbd, 0xcd, 0xc2,
+		0xa4, 0xe9, 0xbe, 0xe6, 0x1a, 0xee, 0x98, 0xbd, 0x9e, 0x69, 0xf8, 0x88, 0x47, 0x71, 0x88, 0xb7,
+		0x9a, 0xed, 0x98, 0xd6, 0xd0, 0x87, 0x14, 0xe3, 0x20, 0xef, 0x4c, 0xeb, 0xfc, 0x54, 0x37, 0xdf,
+		0x79, 0x98, 0xe2, 0x87, 0x14, 0xdc, 0xe6, 0x2d, 0xd3, 0xb6, 0x79, 0x7d, 0x60, 0x3b, 0xc4, 0x92,
+		0x55, 0xfb, 0x5c, 0x34, 0x4e, 0x4d, 0x74, 0x1f, 0x96, 0xbb, 0x66, 0x4f, 0xd5, 0x0c, 0x45, 0xeb,
+		0xe6, 0x99, 0x02, 0x53, 0x5a, 0xc6, 0x39, 0x4f, 0x20, 0x76, 0x51, 0x1b, 0x50, 0x60, 0x47, 0x21,
+		0xbf, 0x22, 0x9d, 0x81, 0xa3, 0x99, 0x46, 0x3e, 0x55, 0x60, 0x4a, 0x2b, 0xbb, 0x8f, 0xcb, 0x93,
+		0x3e, 0xf7, 0xb5, 0xf2, 0xc5, 0x4e, 0xf9, 0xb5, 0x0f, 0x17, 0x02, 0x34, 0xde, 0x78, 0x17, 0x15,
+		0xa1, 0x1a, 0x2c, 0x3b, 0xaa, 0x7d, 0xae, 0x38, 0xc3, 0x3e, 0xc9, 0xa7, 0x0b, 0x4c, 0x69, 0x6d,
+		0xf7, 0xb3, 0x72, 0x6c, 0x04, 0xcb, 0x51, 0xce, 0xf2, 0xb0, 0x4f, 0x70, 0xce, 0xf1, 0x7f, 0xa1,
+		0x2d, 0x00, 0x6a, 0xc9, 0x76, 0x54, 0x87, 0xe4, 0x33, 0x05, 0xa6, 0x94, 0xc5, 0xd4, 0xb6, 0xe4,
+		0x0a, 0xd0, 0x5d, 0x58, 0xa2, 0xaf, 0xb5, 0x6e, 0x3e, 0x5b, 0x60, 0x4a, 0x69, 0xbc, 0xe8, 0x3e,
+		0x8a, 0x5d, 0x74, 0x04, 0xb7, 0x2f, 0x34, 0x5b, 0x3b, 0xd1, 0x74, 0xcd, 0x19, 0x2a, 0xa3, 0xac,
+		0xe4, 0x17, 0xa9, 0x6b, 0x9b, 0x65, 0x2f, 0x6f, 0xe5, 0x20, 0x6f, 0x65, 0x39, 0x40, 0xe0, 0x5b,
+		0x63, 0xbd, 0x91, 0xb0, 0xf8, 0x87, 0x0c, 0xfc, 0x34, 0xcc, 0x54, 0x72, 0x54, 0xcb, 0xe1, 0xdf,
+		0x6a, 0x7a, 0x77, 0x1c, 0x07, 0xf2, 0xdd, 0x80, 0xd8, 0x0e, 0xe7, 0x38, 0x96, 0x76, 0x32, 0x70,
+		0x88, 0x8d, 0x4a, 0xc0, 0x3a, 0xaa, 0x75, 0x46, 0x1c, 0x25, 0x9a, 0x80, 0x35, 0x4f, 0x5e, 0x0d,
+		0xd2, 0xb0, 0x05, 0x60, 0x79, 0xea, 0x2e, 0x26, 0x45, 0x31, 0xcb, 0xbe, 0x44, 0xec, 0xa2, 0xa7,
+		0x80, 0x34, 0x43, 0x73, 0x34, 0xd5, 0x21, 0x5d, 0x85, 0x5c, 0x10, 0x83, 0xc2, 0xd2, 0xd4, 0x61,
+		0x76, 0xf4, 0x46, 0x70, 0x5f, 0x88, 0x5d, 0xf4, 0x9e, 0x81, 0xcd, 0x28, 0x5c, 0x1d, 0xb1, 0xa2,
+		0x31, 0x5c, 0xd9, 0xad, 0xc5, 0x26, 0x77, 0xec, 0xd6, 0x54, 0x9a, 0xc5, 0x89, 0x65, 0xc6, 0x5e,
+		0xe2, 0xbc, 0x36, 0xe3, 0x0d, 0x2a, 0xc2, 0x27, 0xbe, 0xff, 0xd6, 0xc0, 0x08, 0x52, 0xb4, 0x8c,
+		0x57, 0x3c, 0x21, 0x1e, 0xb8, 0x9e, 0x7f, 0xcf, 0x00, 0xdb, 0x57, 0x2d, 0x47, 0x73, 0xd7, 0x50,
+		0x3a, 0xa6, 0x71, 0xaa, 0x9d, 0xe5, 0x17, 0x0b, 0xe9, 0xd2, 0xca, 0xee, 0xb7, 0x09, 0x2a, 0x26,
+		0x49, 0x1e, 0xca, 0xad, 0xc0, 0x3e, 0x4f, 0xcd, 0x0b, 0x86, 0x63, 0x0d, 0xf1, 0x7a, 0x7f, 0x52,
+		0xba, 0xb9, 0x0f, 0xb7, 0xe3, 0x80, 0x88, 0x85, 0xf4, 0x39, 0x19, 0xfa, 0x89, 0x73, 0x7f, 0xa2,
+		0xdb, 0x90, 0xbd, 0x50, 0xf5, 0x01, 0xf1, 0x13, 0xe5, 0x3d, 0x7c, 0x99, 0xfa, 0x82, 0x29, 0x7e,
+		0x0d, 0x3b, 0x73, 0xd9, 0xd9, 0x7d, 0xd3, 0xb0, 0x49, 0x28, 0x4c, 0x77, 0x60, 0xd1, 0x8f, 0x8f,
+		0xb7, 0x46, 0xd6, 0x72, 0x23, 0x53, 0xfc, 0x6b, 0x0a, 0x9e, 0x86, 0x8d, 0xf1, 0xaa, 0xd1, 0x21,
+		0xfa, 0x8d, 0x94, 0xdb, 0x09, 0xdc, 0xf3, 0x91, 0x1f, 0xbd, 0xf9, 0xef, 0x7a, 0x86, 0xa6, 0x5e,
+		0x44, 0x4a, 0x3a, 0x9d, 0xac, 0xa4, 0x33, 0x33, 0x4a, 0xba, 0x0c, 0xb7, 0x3a, 0x6e, 0x18, 0xc7,
+		0x7c, 0x4d, 0x43, 0x1f, 0xd2, 0x7a, 0xca, 0xe1, 0x8d, 0x4e, 0xb8, 0x60, 0x9b, 0x86, 0x3e, 0x2c,
+		0x56, 0xe0, 0xd9, 0xa5, 0xa1, 0x8b, 0xe6, 0xa0, 0xf8, 0x97, 0xf4, 0x64, 0xb0, 0x25, 0xed, 0xcc,
+		0x50, 0x7f, 0x0c, 0x76, 0x92, 0x60, 0xa3, 0x87, 0xb0, 0x62, 0xd3, 0x70, 0x29, 0x86, 0xda, 0x23,
+		0xb4, 0xc3, 0x2e, 0x63, 0xf0, 0x44, 0x0d, 0xb5, 0x47, 0xd0, 0x2f, 0x60, 0xd5, 0x07, 0x68, 0x46,
+		0x7f, 0xe0, 0xe4, 0x97, 0xa8, 0xd3, 0x0f, 0x62, 0x9d, 0x6e, 0xa9, 0x43, 0xdd, 0x54, 0xbb, 0xd8,
+		0x37, 0x29, 0xba, 0x0a, 0x28, 0x0f, 0x4b, 0x1d, 0xd3, 0x70, 0x2c, 0x53, 0xcf, 0xe7, 0x0a, 0x4c,
+		0x69, 0x15, 0x07, 0x8f, 0xd1, 0x44, 0x4f, 0xa5, 0x6d, 0x2a, 0xd1, 0xff, 0x4c, 0x01, 0x17, 0xd6,
+		0xc0, 0xa4, 0x63, 0x5a, 0xdd, 0xf8, 0x96, 0xc7, 0x9b, 0xbd, 0xbe, 0x4e, 0x1c, 0xf2, 0xff, 0x9e,
+		0xfd, 0xab, 0x1d, 0x0f, 0x75, 0x60, 0x3b, 0x9e, 0x63, 0x6e, 0xc7, 0xa5, 0x70, 0xff, 0x4c, 0x78,
+		0x14, 0x4b, 0xa4, 0xe6, 0x8d, 0x22, 0x54, 0x1d, 0xaf, 0x8f, 0x55, 0xa9, 0xa0, 0x58, 0x85, 0xfd,
+		0xab, 0x87, 0x73, 0x2a, 0x2b, 0xff, 0x61, 0xa0, 0xc0, 0xf5, 0xfb, 0xfa, 0xb0, 0xa5, 0x5a, 0xc4,
+		0x70, 0x78, 0xdd, 0xb4, 0x49, 0xcb, 0xd4, 0xb5, 0xce, 0x30, 0x14, 0xf4, 0xc7, 0xb0, 0xee, 0xd5,
+		0x65, 0x34, 0xe6, 0x9f, 0x50, 0xf1, 0x28, 0xe4, 0xdb, 0xb0, 0x11, 0xa9, 0xdf, 0xd1, 0x99, 0xba,
+		0x3e, 0x51, 0xbd, 0x62, 0x17, 0x15, 0x60, 0xd5, 0xc3, 0xfa, 0x1d, 0xd8, 0xdb, 0x3a, 0x40, 0x65,
+		0xde, 0x01, 0xf5, 0x0a, 0x6e, 0xf5, 0x29, 0x29, 0xa5, 0xe3, 0xb2, 0x52, 0xfa, 0x94, 0x16, 0x8d,
+		0xd8, 0xda, 0x8c, 0xd4, 0x4d, 0x39, 0x81, 0x37, 0xfa, 0x51, 0x51, 0xf1, 0x07, 0x06, 0x1e, 0xc4,
+		0xbb, 0xec, 0x4e, 0x36, 0x03, 0x1b, 0x3d, 0x80, 0x65, 0x3f, 0xd8, 0xc4, 0x73, 0x34, 0x87, 0xc7,
+		0x02, 0xd4, 0x86, 0xd5, 0x53, 0x55, 0xd3, 0x49, 0x57, 0xe9, 0xa8, 0x03, 0xdb, 0x3b, 0x8a, 0xd6,
+		0x76, 0x77, 0x13, 0x0e, 0x59, 0x2f, 0xa9, 0x2a, 0xef, 0x6a, 0xe2, 0x95, 0xd3, 0xf1, 0x43, 0xf1,
+		0x6f, 0x0c, 0x6c, 0xc5, 0xb3, 0xf2, 0x37, 0x01, 0x3a, 0x82, 0x2c, 0x8d, 0x0e, 0xa5, 0xb4, 0xb2,
+		0xfb, 0x62, 0xc6, 0x8a, 0xf3, 0xb2, 0x89, 0x3d, 0x2b, 0xe8, 0x10, 0x16, 0x6d, 0xea, 0xaf, 0xbf,
+		0x19, 0xf6, 0xae, 0x64, 0xcf, 0x0b, 0x15, 0xf6, 0x4d, 0x14, 0xbf, 0x67, 0x60, 0x2f, 0xec, 0xea,
+		0xa5, 0x9e, 0x84, 0x2a, 0xab, 0x05, 0x39, 0xca, 0xc6, 0x22, 0x46, 0x9e, 0xa1, 0xb3, 0xc7, 0xe7,
+		0x57, 0xa2, 0xe1, 0x5b, 0xc4, 0x23, 0x2b, 0xc5, 0xbf, 0xcf, 0xcc, 0x2e, 0x26, 0xf6, 0x40, 0xbf,
+		0xf1, 0x30, 0xfe, 0x8f, 0xca, 0xe1, 0xb7, 0x0c, 0x7c, 0x9e, 0x24, 0xa0, 0x53, 0x33, 0xcd, 0xb7,
+		0xfe, 0x5e, 0xb5, 0x88, 0xa1, 0xf8, 0xf9, 0xf5, 0x02, 0xbb, 0x77, 0xc5, 0xc0, 0xba, 0xc1, 0xc2,
+		0x6b, 0x81, 0x2d, 0x2f, 0xdf, 0xc5, 0xdf, 0x2d, 0xc1, 0xdd, 0xa8, 0x0f, 0x41, 0x7d, 0x06, 0x57,
+		0x0f, 0xcd, 0x38, 0x35, 0xfd, 0xe0, 0x26, 0xbd, 0x7a, 0xb8, 0xd7, 0x25, 0xef, 0xea, 0xe1, 0xfe,
+		0x42, 0xbf, 0x61, 0xa0, 0x60, 0xbb, 0x13, 0x9c, 0xe2, 0xb5, 0x88, 0x51, 0xdf, 0x0e, 0x4f, 0xd3,
+		0x5e, 0xd5, 0x1e, 0xdc, 0xd0, 0xa8, 0x5a, 0x5b, 0xc0, 0x5b, 0xf6, 0x34, 0x2e, 0x14, 0xd8, 0x5f,
+		0x33, 0x70, 0xbf, 0x43, 0xc7, 0x99, 0x78, 0x3e, 0x69, 0xca, 0x87, 0x4f, 0xc0, 0x67, 0xde, 0x3c,
+		0x59, 0x5b, 0xc0, 0xf7, 0x3a, 0x93, 0x98, 0x08, 0x0f, 0xff, 0x50, 0x8f, 0xe5, 0x91, 0x49, 0xcc,
+		0x63, 0xde, 0xa8, 0xe5, 0xf2, 0xb0, 0x27, 0x31, 0x21, 0x1e, 0xff, 0x62, 0xe0, 0x2b, 0x8b, 0x1e,
+		0x3a, 0x4a, 0xa4, 0xe9, 0x8f, 0x69, 0x05, 0x5d, 0x53, 0x09, 0x66, 0xa4, 0x10, 0xcf, 0x2c, 0xe5,
+		0xf9, 0x26, 0x01, 0xcf, 0x6b, 0x4d, 0x0a, 0xb5, 0x05, 0xfc, 0xdc, 0xba, 0x8e, 0x22, 0xfa, 0x23,
+		0x03, 0x4f, 0x55, 0x77, 0x53, 0x28, 0x31, 0xc7, 0x4f, 0x9c, 0x27, 0xde, 0xcd, 0xf6, 0xeb, 0x04,
+		0x9e, 0x24, 0x6c, 0x8b, 0xb5, 0x05, 0xfc, 0x44, 0x4d, 0x06, 0xdd, 0x5f, 0x05, 0x18, 0x53, 0x29,
+		0xfe, 0x39, 0x07, 0xf9, 0xe9, 0xfd, 0xe9, 0x35, 0x89, 0xf0, 0x95, 0x9d, 0x99, 0xb8, 0xb2, 0x4f,
+		0x7c, 0x34, 0x48, 0xdd, 0xdc, 0x47, 0x83, 0x74, 0xf4, 0xa3, 0x41, 0xb4, 0x59, 0x66, 0x6e, 0xa4,
+		0x59, 0xa2, 0x1f, 0x92, 0xf4, 0x8b, 0x6c, 0xec, 0xed, 0x3b, 0x71, 0xbf, 0x88, 0x36, 0xda, 0xf9,
+		0x0d, 0xe3, 0xfd, 0x9c, 0x86, 0xe1, 0x95, 0x4d, 0xf5, 0x3a, 0x0d, 0x23, 0x86, 0xcc, 0x25, 0x1d,
+		0xe3, 0xfd, 0x9c, 0x8e, 0xb1, 0x94, 0x98, 0xc8, 0xdc, 0x29, 0xff, 0xf2, 0x96, 0xf1, 0xef, 0x8f,
+		0x6c, 0x19, 0x39, 0x4a, 0xf4, 0xf8, 0xc6, 0x5a, 0x46, 0x0c, 0xfb, 0x6b, 0xf6, 0x8c, 0x3f, 0x31,
+		0xf0, 0xec, 0xb2, 0x9e, 0xe1, 0xad, 0x14, 0xf6, 0x65, 0x99, 0xfa, 0x72, 0xf8, 0x11, 0x4d, 0x23,
+		0x86, 0x7d, 0x49, 0x4d, 0x88, 0x8d, 0xb4, 0x0d, 0x33, 0xae, 0x6b, 0x50, 0x1f, 0x6d, 0x24, 0xb9,
+		0xdf, 0x92, 0xec, 0xf3, 0x20, 0x1f, 0xc1, 0x38, 0x51, 0x4e, 0xb8, 0x69, 0x83, 0x09, 0x6d, 0xd5,
+		0x19, 0x3f, 0xd8, 0xdb, 0xef, 0x19, 0xc8, 0x05, 0xed, 0x03, 0xdd, 0x81, 0x0d, 0x99, 0x93, 0x0e,
+		0x15, 0xf9, 0xb8, 0x25, 0x28, 0x62, 0xe3, 0x15, 0x57, 0x17, 0xab, 0xec, 0x02, 0xfa, 0x09, 0xa0,
+		0xb1, 0x58, 0xc6, 0x5c, 0x43, 0x7a, 0x29, 0x60, 0x96, 0x41, 0xb7, 0x60, 0x3d, 0x24, 0x17, 0x8f,
+		0x04, 0xcc, 0xa6, 0xd0, 0x3d, 0xb8, 0x33, 0x16, 0x62, 0xa1, 0x55, 0x17, 0x79, 0x4e, 0x16, 0x9b,
+		0x0d, 0x36, 0x8d, 0xee, 0xc3, 0xdd, 0xf1, 0x2b, 0x1e, 0x37, 0x25, 0x49, 0xe1, 0xeb, 0x6d, 0x49,
+		0x16, 0x30, 0x9b, 0xd9, 0xfe, 0x47, 0xcc, 0xc7, 0x5b, 0x4a, 0xea, 0x53, 0x78, 0x38, 0x81, 0x55,
+		0xe2, 0x28, 0xee, 0xc0, 0xb3, 0x59, 0x20, 0x49, 0xe6, 0xb0, 0xac, 0xf0, 0x35, 0xb1, 0x5e, 0x55,
+		0x84, 0x37, 0x02, 0xdf, 0xa6, 0x6c, 0x18, 0xf4, 0x14, 0x4a, 0xb3, 0x54, 0x78, 0xae, 0xc1, 0x0b,
+		0xf5, 0x10, 0x3a, 0x75, 0x19, 0x5a, 0x12, 0x0f, 0x1a, 0x5c, 0x18, 0x9d, 0x46, 0x55, 0xf8, 0xe5,
+		0x2c, 0x34, 0x16, 0xf8, 0x26, 0xae, 0xfa, 0x7c, 0x5e, 0x37, 0xf1, 0xe1, 0x61, 0xbd, 0xf9, 0x7a,
+		0xac, 0xac, 0xf0, 0xcd, 0xa3, 0x56, 0x5d, 0x90, 0x05, 0x36, 0x83, 0x9e, 0xc3, 0xce, 0x2c, 0x2b,
+		0x5c, 0xab, 0x55, 0x3f, 0x56, 0x5a, 0x1c, 0x16, 0x1a, 0xb2, 0xc2, 0xd7, 0x9b, 0x92, 0xa0, 0xb4,
+		0x9a, 0x75, 0x91, 0x3f, 0x66, 0xb3, 0xdb, 0xbf, 0x4f, 0xc3, 0xfd, 0x4b, 0x3a, 0x36, 0xfa, 0x0c,
+		0x9e, 0xc4, 0x98, 0x7d, 0xc9, 0x89, 0x75, 0xa1, 0xaa, 0xf0, 0x5c, 0x5b, 0x0a, 0x07, 0x36, 0x9e,
+		0xc3, 0x04, 0xb8, 0xda, 0x3c, 0xe2, 0xc4, 0x86, 0xd2, 0x68, 0xca, 0x0a, 0xc7, 0xcb, 0xe2, 0x2b,
+		0x81, 0x65, 0xae, 0xa8, 0x26, 0xbc, 0x11, 0x25, 0x59, 0x62, 0x53, 0xe8, 0x67, 0xf0, 0xc5, 0x3c,
+		0x35, 0x37, 0x64, 0x2f, 0xdd, 0x90, 0x71, 0x75, 0x2c, 0x70, 0xd5, 0x63, 0x05, 0xb7, 0x1b, 0x0d,
+		0xb1, 0x71, 0xc0, 0xa6, 0xd1, 0x0b, 0xd8, 0x4b, 0xac, 0x1d, 0x5a, 0x36, 0x83, 0x7e, 0x0e, 0x5f,
+		0x5e, 0x79, 0xd9, 0x20, 0x4f, 0x55, 0x36, 0x3b, 0xa3, 0xfa, 0x26, 0xf4, 0xdb, 0x0d, 0x9e, 0x93,
+		0x85, 0x83, 0x26, 0x16, 0xbf, 0x11, 0xaa, 0xec, 0xe2, 0xf6, 0x07, 0x06, 0xd0, 0x01, 0x71, 0xa2,
+		0xb9, 0x79, 0x04, 0x5b, 0x07, 0x82, 0x7c, 0x69, 0x46, 0x1e, 0x43, 0x31, 0x1e, 0x22, 0x09, 0xf8,
+		0x95, 0xc8, 0x0b, 0xca, 0x7e, 0x5b, 0x3a, 0x66, 0x99, 0xd9, 0xa6, 0xdc, 0x9d, 0xda, 0x6c, 0xcb,
+		0x6c, 0x0a, 0x95, 0x61, 0x7b, 0x86, 0xa9, 0x1a, 0x87, 0xab, 0x4a, 0xf3, 0x75, 0x43, 0xc0, 0x52,
+		0x4d, 0x6c, 0x29, 0xf5, 0xa6, 0x24, 0xb3, 0x69, 0xf4, 0x04, 0x3e, 0x8d, 0xc7, 0x4f, 0x7a, 0x97,
+		0xd9, 0x7f, 0xf1, 0xcd, 0xf3, 0x33, 0xcd, 0x79, 0x3b, 0x38, 0x29, 0x77, 0xcc, 0x5e, 0x25, 0xfc,
+		0xd7, 0xcd, 0x33, 0xad, 0xab, 0x57, 0xce, 0x4c, 0xef, 0xdf, 0xa2, 0xd1, 0xff, 0x4c, 0x5f, 0xd1,
+		0x1f, 0x17, 0x3b, 0x27, 0x8b, 0x54, 0xbe, 0xf7, 0xdf, 0x01, 0x00, 0x4b, 0xe2, 0x06, 0x07, 0x8f,
+		0x1a, 0x00, 0x00,
 	},
 	// uber/cadence/admin/v1/replication.proto
 	[]byte{
-		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0x4b, 0x73, 0x1a, 0xc7,
-		0x16, 0xf6, 0x80, 0x78, 0xe8, 0x08, 0x01, 0x6a, 0xc9, 0x16, 0x96, 0xac, 0xba, 0x18, 0xbf, 0x64,
-		0xb9, 0x2e, 0x5c, 0xc9, 0xd7, 0xf7, 0x59, 0x29, 0xd7, 0x58, 0xa0, 0x68, 0x62, 0x3d, 0x1b, 0x2c,
-		0x47, 0xa9, 0x4a, 0x4d, 0x8d, 0x66, 0x5a, 0x62, 0x4a, 0x30, 0x43, 0x4d, 0x37, 0xc8, 0xec, 0x92,
-		0x7d, 0xaa, 0xb2, 0xc9, 0x2e, 0xcb, 0xfc, 0x8d, 0xac, 0xb3, 0x4d, 0x7e, 0x52, 0x6a, 0xba, 0x7b,
-		0x80, 0x81, 0x01, 0xcb, 0xe5, 0x45, 0x76, 0xf4, 0x39, 0xdf, 0x79, 0xf4, 0xe9, 0xd3, 0xdf, 0x69,
-		0x06, 0x9e, 0x75, 0x2f, 0x88, 0x57, 0x31, 0x0d, 0x8b, 0x38, 0x26, 0xa9, 0x18, 0x56, 0xdb, 0x76,
-		0x2a, 0xbd, 0xed, 0x8a, 0x47, 0x3a, 0x2d, 0xdb, 0x34, 0x98, 0xed, 0x3a, 0xe5, 0x8e, 0xe7, 0x32,
-		0x17, 0xdd, 0xf5, 0x81, 0x65, 0x09, 0x2c, 0x73, 0x60, 0xb9, 0xb7, 0xbd, 0xf6, 0xb7, 0x2b, 0xd7,
-		0xbd, 0x6a, 0x91, 0x0a, 0x07, 0x5d, 0x74, 0x2f, 0x2b, 0xcc, 0x6e, 0x13, 0xca, 0x8c, 0x76, 0x47,
-		0xd8, 0xad, 0x15, 0xc3, 0x01, 0x3a, 0xb6, 0xef, 0xde, 0x74, 0xdb, 0xed, 0xc0, 0x73, 0x34, 0xc2,
-		0x72, 0xdb, 0x86, 0x1d, 0x20, 0x1e, 0x45, 0x27, 0xd9, 0xb4, 0x29, 0x73, 0xbd, 0xbe, 0x00, 0x95,
-		0x7e, 0x8a, 0xc1, 0x32, 0x1e, 0xa6, 0x7d, 0x48, 0x28, 0x35, 0xae, 0x08, 0x45, 0x75, 0x58, 0x1a,
-		0xd9, 0x8d, 0xce, 0x0c, 0x7a, 0x4d, 0x0b, 0x4a, 0x31, 0xbe, 0xb9, 0xb0, 0xf3, 0xb4, 0x1c, 0xb9,
-		0xa9, 0xf2, 0x88, 0x9b, 0x86, 0x41, 0xaf, 0x71, 0xde, 0x0b, 0x0b, 0x28, 0xfa, 0x2f, 0xdc, 0x6f,
-		0x19, 0x94, 0xe9, 0x1e, 0x61, 0x9e, 0x4d, 0x7a, 0xc4, 0xd2, 0xdb, 0x22, 0x9e, 0x6e, 0x5b, 0x85,
-		0x58, 0x51, 0xd9, 0x8c, 0xe3, 0x7b, 0x3e, 0x00, 0x07, 0x7a, 0x99, 0x8e, 0x66, 0xa1, 0xfb, 0x90,
-		0x6e, 0x1a, 0x54, 0x6f, 0xbb, 0x1e, 0x29, 0xc4, 0x8b, 0xca, 0x66, 0x1a, 0xa7, 0x9a, 0x06, 0x3d,
-		0x74, 0x3d, 0x82, 0x30, 0x2c, 0xd1, 0xbe, 0x63, 0xea, 0xb4, 0x69, 0x78, 0x96, 0x4e, 0x99, 0xc1,
-		0xba, 0xb4, 0x30, 0x57, 0x54, 0x66, 0xa4, 0x5a, 0xef, 0x3b, 0x66, 0xdd, 0x87, 0xd7, 0x39, 0x1a,
-		0xe7, 0x68, 0x58, 0x50, 0xfa, 0x31, 0x09, 0xb9, 0xb1, 0xfd, 0xa0, 0x2f, 0x61, 0xde, 0x2f, 0x83,
-		0xce, 0xfa, 0x1d, 0x52, 0x50, 0x8a, 0xca, 0x66, 0x76, 0x67, 0xeb, 0x76, 0xa5, 0x68, 0xf4, 0x3b,
-		0x04, 0xa7, 0x99, 0xfc, 0x85, 0x1e, 0x43, 0x96, 0xba, 0x5d, 0xcf, 0x24, 0xbc, 0xac, 0xc3, 0xbd,
-		0x67, 0x84, 0xd4, 0xb7, 0xd0, 0x2c, 0xf4, 0x1a, 0x16, 0x4d, 0x8f, 0xc8, 0xf2, 0xdb, 0x6d, 0xb1,
-		0xed, 0x85, 0x9d, 0xb5, 0xb2, 0xe8, 0x9d, 0x72, 0xd0, 0x3b, 0xe5, 0x46, 0xd0, 0x3b, 0x38, 0x13,
-		0x18, 0xf8, 0x22, 0x64, 0xc2, 0x3d, 0xd1, 0x0f, 0x22, 0x8c, 0xc1, 0x98, 0x67, 0x5f, 0x74, 0x19,
-		0x09, 0x8a, 0xf3, 0x62, 0x4a, 0xf2, 0x55, 0x6e, 0xe4, 0x67, 0xa1, 0x0e, 0x4c, 0xf6, 0xef, 0xe0,
-		0x15, 0x2b, 0x42, 0x8e, 0xbe, 0x53, 0xe0, 0xe1, 0x44, 0xf5, 0x27, 0x02, 0x26, 0x78, 0xc0, 0x7f,
-		0xde, 0xee, 0x34, 0x26, 0x22, 0x6f, 0xd0, 0x59, 0x00, 0xd4, 0x03, 0x0e, 0xd0, 0x0d, 0x93, 0xd9,
-		0x3d, 0x9b, 0xf5, 0x27, 0xa2, 0x27, 0x79, 0xf4, 0xed, 0x19, 0xd1, 0x55, 0x69, 0x3a, 0x11, 0x7a,
-		0x8d, 0x4e, 0xd5, 0xa2, 0x36, 0xac, 0xc9, 0xbb, 0x24, 0x22, 0xf6, 0x76, 0x46, 0x83, 0xa6, 0x78,
-		0xd0, 0xf2, 0x94, 0xa0, 0xfb, 0xc2, 0xd0, 0xf7, 0x78, 0xb6, 0x13, 0x8a, 0xb8, 0xda, 0x8c, 0x56,
-		0x21, 0x17, 0xd6, 0x2e, 0x0d, 0xbb, 0xe5, 0xf6, 0x88, 0xa7, 0xb7, 0x0d, 0xef, 0x9a, 0x78, 0xa3,
-		0xe1, 0xd2, 0x3c, 0x5c, 0x65, 0x4a, 0xb8, 0x3d, 0x69, 0x78, 0xc8, 0xed, 0x42, 0xf1, 0x0a, 0x97,
-		0x53, 0x74, 0x6f, 0x32, 0x00, 0xc3, 0x00, 0xa5, 0x5f, 0x63, 0xb0, 0x12, 0xd5, 0x19, 0xe8, 0x14,
-		0xf2, 0xb2, 0xcd, 0xdc, 0x0e, 0xf1, 0x78, 0xfb, 0xc9, 0xdb, 0xf1, 0x74, 0x66, 0x83, 0x1d, 0x07,
-		0x68, 0x9c, 0xb3, 0xc2, 0x02, 0x94, 0x85, 0x98, 0xbc, 0x14, 0xf3, 0x38, 0x66, 0x5b, 0xe8, 0x25,
-		0x24, 0x05, 0x44, 0xde, 0x81, 0xf5, 0x31, 0xc7, 0x1d, 0x7b, 0xe8, 0x16, 0x4b, 0x28, 0x7a, 0x02,
-		0x59, 0xd3, 0x75, 0x2e, 0xed, 0x2b, 0xbd, 0x47, 0x3c, 0xea, 0x67, 0x35, 0xc7, 0x6f, 0xd9, 0xa2,
-		0x90, 0x9e, 0x09, 0x21, 0x7a, 0x0e, 0xf9, 0x41, 0x59, 0x03, 0x60, 0x82, 0x03, 0x73, 0x81, 0x3c,
-		0x80, 0xfe, 0x0f, 0xee, 0x77, 0x3c, 0xd2, 0xb3, 0xdd, 0x2e, 0xd5, 0x27, 0x6c, 0x92, 0xdc, 0x66,
-		0x35, 0x00, 0xec, 0x85, 0x6d, 0x4b, 0x3f, 0x2b, 0xb0, 0x31, 0xb3, 0xcf, 0xfd, 0x7c, 0x25, 0x2b,
-		0x98, 0xad, 0x2e, 0x65, 0xc4, 0xe3, 0x55, 0x9c, 0xc7, 0x8b, 0x42, 0xba, 0x2b, 0x84, 0x3e, 0x11,
-		0x8a, 0xab, 0x26, 0x2b, 0x94, 0xc0, 0x29, 0xbe, 0xd6, 0x2c, 0xf4, 0x1f, 0x98, 0x1f, 0xcc, 0x91,
-		0x5b, 0xb0, 0xc5, 0x10, 0x5c, 0xfa, 0x3d, 0x01, 0x6b, 0xd3, 0xef, 0x01, 0x5a, 0x87, 0x79, 0x79,
-		0xc4, 0xb6, 0x25, 0xb3, 0x4a, 0x0b, 0x81, 0x66, 0xa1, 0x77, 0x80, 0x6e, 0x5c, 0xef, 0xfa, 0xb2,
-		0xe5, 0xde, 0xe8, 0xe4, 0x03, 0x31, 0xbb, 0xbc, 0x03, 0x62, 0x91, 0xfc, 0x2b, 0x0e, 0xea, 0xbd,
-		0x84, 0xd7, 0x02, 0x34, 0x5e, 0xba, 0x19, 0x17, 0xa1, 0x02, 0xa4, 0x82, 0xd2, 0xc6, 0x79, 0x69,
-		0x83, 0x25, 0x7a, 0x08, 0x19, 0x6a, 0x36, 0x89, 0xd5, 0x6d, 0x11, 0x5e, 0x05, 0x71, 0xac, 0x0b,
-		0x03, 0x99, 0x66, 0x21, 0x15, 0xb2, 0x43, 0x08, 0x27, 0xcf, 0xc4, 0x47, 0xcb, 0xb1, 0x38, 0xb0,
-		0xe0, 0xec, 0xb9, 0x01, 0x40, 0x99, 0xe1, 0x31, 0x11, 0x43, 0x9c, 0xee, 0xbc, 0x94, 0x68, 0x16,
-		0xfa, 0x02, 0x32, 0x81, 0x9a, 0xfb, 0x4f, 0x7d, 0xd4, 0xff, 0x82, 0xc4, 0x73, 0xef, 0x5f, 0xc1,
-		0x32, 0x9f, 0x84, 0x4d, 0x62, 0x78, 0xec, 0x82, 0x18, 0x4c, 0x78, 0x49, 0x7f, 0xd4, 0xcb, 0x92,
-		0x6f, 0xb6, 0x1f, 0x58, 0x71, 0x5f, 0xff, 0x82, 0x94, 0x45, 0x98, 0x61, 0xb7, 0x68, 0x61, 0x9e,
-		0xdb, 0x3f, 0x88, 0xac, 0xfa, 0x89, 0xd1, 0x6f, 0xb9, 0x86, 0x85, 0x03, 0xb0, 0x5f, 0x61, 0x83,
-		0x31, 0xd2, 0xee, 0xb0, 0x02, 0x88, 0x46, 0x92, 0x4b, 0xf4, 0x1a, 0x32, 0x3c, 0x3b, 0xbf, 0xc9,
-		0xbb, 0x1e, 0x29, 0x2c, 0xcc, 0x70, 0xbb, 0x27, 0x30, 0x78, 0xc1, 0xb7, 0x90, 0x0b, 0xf4, 0x0f,
-		0x58, 0xe1, 0x0e, 0xfc, 0x63, 0x25, 0x9e, 0x6e, 0x5b, 0xc4, 0x61, 0x36, 0xeb, 0x17, 0x32, 0xbc,
-		0x77, 0x90, 0xaf, 0x7b, 0xcf, 0x55, 0x9a, 0xd4, 0xa0, 0x23, 0xc8, 0xc9, 0xf3, 0xd5, 0x25, 0x01,
-		0x16, 0x16, 0x79, 0xd4, 0x27, 0x53, 0x48, 0x44, 0x5e, 0x2c, 0x49, 0xa4, 0x38, 0xdb, 0x0b, 0xad,
-		0x4b, 0xdf, 0xc7, 0x61, 0x75, 0x0a, 0xc9, 0xa2, 0x55, 0x48, 0x05, 0x83, 0x57, 0xe1, 0xe7, 0x9a,
-		0x64, 0x62, 0xe4, 0x86, 0xfa, 0x3c, 0x76, 0xab, 0x3e, 0x8f, 0x7f, 0x6e, 0x9f, 0x7f, 0x0b, 0x77,
-		0xc7, 0x36, 0xae, 0xdb, 0x8c, 0xb4, 0xfd, 0x21, 0xed, 0x3f, 0xb6, 0x9e, 0xdf, 0x6a, 0xfb, 0x1a,
-		0x23, 0x6d, 0xbc, 0xdc, 0x9b, 0x90, 0x51, 0xf4, 0x0a, 0x92, 0xa4, 0x47, 0x1c, 0x16, 0xcc, 0xe0,
-		0x8d, 0x68, 0xea, 0x34, 0x98, 0xf1, 0xa6, 0xe5, 0x5e, 0x60, 0x09, 0x46, 0xbb, 0x90, 0x75, 0xc8,
-		0x8d, 0xee, 0x75, 0x1d, 0x5d, 0x9a, 0x27, 0x6f, 0x63, 0x9e, 0x71, 0xc8, 0x0d, 0xee, 0x3a, 0x35,
-		0x6e, 0x52, 0xfa, 0x45, 0x81, 0xc2, 0xb4, 0xc9, 0x33, 0x9b, 0x53, 0xa2, 0x48, 0x39, 0x16, 0x4d,
-		0xca, 0x9f, 0xfb, 0x4c, 0x2a, 0xfd, 0xa0, 0xc0, 0x72, 0x38, 0xcb, 0x86, 0x7b, 0x4d, 0x1c, 0x3f,
-		0xc1, 0x80, 0x68, 0xc5, 0xcb, 0x37, 0x81, 0xd3, 0x92, 0x69, 0x29, 0xfa, 0x1a, 0x72, 0x63, 0xc3,
-		0x58, 0x32, 0xde, 0xa7, 0x4e, 0x60, 0x9c, 0x0d, 0xcf, 0xdf, 0xd2, 0x6f, 0xe1, 0x07, 0x39, 0x7f,
-		0x0c, 0x3a, 0x97, 0xee, 0x5f, 0xc2, 0xc1, 0xeb, 0xa3, 0x2f, 0xde, 0x38, 0xe7, 0x88, 0xe1, 0x2b,
-		0x76, 0xe4, 0x16, 0xcd, 0x85, 0x6e, 0xd1, 0x08, 0x73, 0x27, 0xc2, 0xcc, 0xfd, 0x18, 0xb2, 0x97,
-		0xb6, 0x47, 0x99, 0xe8, 0xa9, 0x21, 0xaf, 0x66, 0xb8, 0x94, 0x77, 0x8d, 0x66, 0xa1, 0x12, 0x2c,
-		0x3a, 0xe4, 0xc3, 0x08, 0x28, 0x25, 0x08, 0xde, 0x17, 0x06, 0x98, 0xf1, 0x19, 0x90, 0x9e, 0x98,
-		0x01, 0x7e, 0xf7, 0xe5, 0x47, 0x0b, 0xc9, 0x0f, 0x75, 0x74, 0x7a, 0x2a, 0xe1, 0xe9, 0xf9, 0x19,
-		0x7f, 0x4e, 0x02, 0xd3, 0x8e, 0xe7, 0x9a, 0x84, 0xd2, 0xb0, 0x69, 0x7c, 0x68, 0x7a, 0x12, 0xe8,
-		0x07, 0xa6, 0xa5, 0xb7, 0x90, 0x1b, 0x7b, 0x16, 0x84, 0xc7, 0xb8, 0xf2, 0x29, 0x63, 0xdc, 0x81,
-		0x15, 0x79, 0xf9, 0xab, 0x07, 0xa7, 0xbb, 0x6e, 0xd7, 0x61, 0x35, 0x87, 0x79, 0x7d, 0xb4, 0x02,
-		0x09, 0xd3, 0x5f, 0x49, 0xba, 0x13, 0x8b, 0x59, 0x2f, 0x89, 0xc9, 0xb7, 0x48, 0x3c, 0xe2, 0x2d,
-		0xb2, 0xf5, 0xc7, 0x64, 0xaf, 0xf2, 0xd6, 0x78, 0x08, 0x1b, 0xb8, 0x76, 0x72, 0xa0, 0xed, 0xaa,
-		0x0d, 0xed, 0xf8, 0x48, 0x6f, 0xa8, 0xf5, 0xb7, 0x7a, 0xe3, 0xfc, 0xa4, 0xa6, 0x6b, 0x47, 0x67,
-		0xea, 0x81, 0x56, 0xcd, 0xdf, 0x41, 0x45, 0x78, 0x10, 0x0d, 0xa9, 0x1e, 0x1f, 0xaa, 0xda, 0x51,
-		0x5e, 0x99, 0xee, 0x64, 0x5f, 0xab, 0x37, 0x8e, 0xf1, 0x79, 0x3e, 0x86, 0x5e, 0xc0, 0xb3, 0x68,
-		0x48, 0xfd, 0xfc, 0x68, 0x57, 0xaf, 0xef, 0xab, 0xb8, 0xaa, 0xd7, 0x1b, 0x6a, 0xe3, 0x5d, 0x3d,
-		0x1f, 0x47, 0xcf, 0xe0, 0xd1, 0x0c, 0xb0, 0xba, 0xdb, 0xd0, 0xce, 0xb4, 0xc6, 0x79, 0x7e, 0x0e,
-		0x6d, 0xc1, 0xd3, 0x99, 0x81, 0xf5, 0xc3, 0x5a, 0x43, 0xad, 0xaa, 0x0d, 0x35, 0x9f, 0x40, 0x8f,
-		0xa1, 0x38, 0x1b, 0x7b, 0xb6, 0x93, 0x4f, 0xa2, 0xe7, 0xf0, 0x24, 0x1a, 0xb5, 0xa7, 0x6a, 0x07,
-		0xc7, 0x67, 0x35, 0xac, 0x1f, 0xaa, 0xf8, 0x6d, 0x0d, 0xe7, 0x53, 0x5b, 0x36, 0xe4, 0xc6, 0x9e,
-		0xc7, 0xe8, 0x01, 0x14, 0x44, 0x51, 0xf4, 0xe3, 0x93, 0x1a, 0x16, 0x2e, 0x86, 0x85, 0x5c, 0x87,
-		0xd5, 0x09, 0xed, 0x2e, 0xae, 0xa9, 0x8d, 0x5a, 0x5e, 0x89, 0x54, 0xbe, 0x3b, 0xa9, 0xfa, 0xca,
-		0xd8, 0xd6, 0x11, 0xa4, 0xaa, 0x07, 0xa7, 0xfc, 0xc0, 0x56, 0x20, 0x5f, 0x3d, 0x38, 0x1d, 0x3f,
-		0xa3, 0x02, 0xac, 0x0c, 0xa4, 0x23, 0xf9, 0xe7, 0x15, 0xb4, 0x0c, 0xb9, 0x81, 0x46, 0x1e, 0x58,
-		0xec, 0xcd, 0xbf, 0xbf, 0x79, 0x75, 0x65, 0xb3, 0x66, 0xf7, 0xa2, 0x6c, 0xba, 0xed, 0xca, 0xe8,
-		0xc7, 0x87, 0xbf, 0xdb, 0x56, 0xab, 0x72, 0xe5, 0x8a, 0xcf, 0x1d, 0x83, 0x2f, 0x11, 0xff, 0xe7,
-		0x3f, 0x7a, 0xdb, 0x17, 0x49, 0x2e, 0x7f, 0xf9, 0x67, 0x00, 0x00, 0x00, 0xff, 0xff, 0x40, 0x11,
-		0xc6, 0x58, 0x56, 0x11, 0x00, 0x00,
+		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0xcb, 0x6f, 0xdb, 0x46,
+		0x13, 0x0f, 0x25, 0xeb, 0xe1, 0xb1, 0x2c, 0xc9, 0x6b, 0x27, 0x66, 0xec, 0x18, 0x9f, 0xa2, 0xbc,
+		0x1c, 0x07, 0x9f, 0xf4, 0xd9, 0xf9, 0xf2, 0x3d, 0x51, 0x04, 0x8c, 0x25, 0xd7, 0x6c, 0xfc, 0x5c,
+		0x29, 0x4e, 0x5d, 0xa0, 0x20, 0x68, 0x72, 0x6d, 0x11, 0x96, 0x48, 0x81, 0xbb, 0x92, 0xa3, 0x5b,
+		0x7b, 0x2f, 0xd0, 0x4b, 0x6f, 0x3d, 0xf6, 0xdf, 0xe8, 0xb9, 0xd7, 0xf6, 0x4f, 0x2a, 0xb8, 0xbb,
+		0x94, 0x44, 0xbd, 0xe2, 0x36, 0x87, 0xde, 0xb4, 0x33, 0xbf, 0xdf, 0xcc, 0xec, 0xec, 0xec, 0xcc,
+		0x8a, 0xf0, 0xac, 0x73, 0x41, 0xfc, 0xb2, 0x65, 0xda, 0xc4, 0xb5, 0x48, 0xd9, 0xb4, 0x5b, 0x8e,
+		0x5b, 0xee, 0x6e, 0x97, 0x7d, 0xd2, 0x6e, 0x3a, 0x96, 0xc9, 0x1c, 0xcf, 0x2d, 0xb5, 0x7d, 0x8f,
+		0x79, 0xe8, 0x6e, 0x00, 0x2c, 0x49, 0x60, 0x89, 0x03, 0x4b, 0xdd, 0xed, 0xb5, 0xbf, 0x5d, 0x79,
+		0xde, 0x55, 0x93, 0x94, 0x39, 0xe8, 0xa2, 0x73, 0x59, 0x66, 0x4e, 0x8b, 0x50, 0x66, 0xb6, 0xda,
+		0x82, 0xb7, 0x56, 0x88, 0x3a, 0x68, 0x3b, 0x81, 0x79, 0xcb, 0x6b, 0xb5, 0x3c, 0x77, 0x16, 0xc2,
+		0xf6, 0x5a, 0xa6, 0x13, 0x22, 0x1e, 0x4d, 0x0e, 0xb2, 0xe1, 0x50, 0xe6, 0xf9, 0x3d, 0x01, 0x2a,
+		0xfe, 0x10, 0x83, 0x65, 0x3c, 0x08, 0xfb, 0x90, 0x50, 0x6a, 0x5e, 0x11, 0x8a, 0x6a, 0xb0, 0x34,
+		0xb4, 0x1b, 0x83, 0x99, 0xf4, 0x9a, 0xaa, 0x4a, 0x21, 0xbe, 0xb9, 0xb0, 0xf3, 0xb4, 0x34, 0x71,
+		0x53, 0xa5, 0x21, 0x33, 0x75, 0x93, 0x5e, 0xe3, 0xbc, 0x1f, 0x15, 0x50, 0xf4, 0x5f, 0xb8, 0xdf,
+		0x34, 0x29, 0x33, 0x7c, 0xc2, 0x7c, 0x87, 0x74, 0x89, 0x6d, 0xb4, 0x84, 0x3f, 0xc3, 0xb1, 0xd5,
+		0x58, 0x41, 0xd9, 0x8c, 0xe3, 0x7b, 0x01, 0x00, 0x87, 0x7a, 0x19, 0x8e, 0x6e, 0xa3, 0xfb, 0x90,
+		0x6e, 0x98, 0xd4, 0x68, 0x79, 0x3e, 0x51, 0xe3, 0x05, 0x65, 0x33, 0x8d, 0x53, 0x0d, 0x93, 0x1e,
+		0x7a, 0x3e, 0x41, 0x18, 0x96, 0x68, 0xcf, 0xb5, 0x0c, 0xda, 0x30, 0x7d, 0xdb, 0xa0, 0xcc, 0x64,
+		0x1d, 0xaa, 0xce, 0x15, 0x94, 0x19, 0xa1, 0xd6, 0x7a, 0xae, 0x55, 0x0b, 0xe0, 0x35, 0x8e, 0xc6,
+		0x39, 0x1a, 0x15, 0x14, 0xbf, 0x4f, 0x42, 0x6e, 0x64, 0x3f, 0xe8, 0x73, 0x98, 0x0f, 0xd2, 0x60,
+		0xb0, 0x5e, 0x9b, 0xa8, 0x4a, 0x41, 0xd9, 0xcc, 0xee, 0x6c, 0xdd, 0x2e, 0x15, 0xf5, 0x5e, 0x9b,
+		0xe0, 0x34, 0x93, 0xbf, 0xd0, 0x63, 0xc8, 0x52, 0xaf, 0xe3, 0x5b, 0x84, 0xa7, 0x75, 0xb0, 0xf7,
+		0x8c, 0x90, 0x06, 0x0c, 0xdd, 0x46, 0xaf, 0x61, 0xd1, 0xf2, 0x89, 0x4c, 0xbf, 0xd3, 0x12, 0xdb,
+		0x5e, 0xd8, 0x59, 0x2b, 0x89, 0xda, 0x29, 0x85, 0xb5, 0x53, 0xaa, 0x87, 0xb5, 0x83, 0x33, 0x21,
+		0x21, 0x10, 0x21, 0x0b, 0xee, 0x89, 0x7a, 0x10, 0x6e, 0x4c, 0xc6, 0x7c, 0xe7, 0xa2, 0xc3, 0x48,
+		0x98, 0x9c, 0x17, 0x53, 0x82, 0xaf, 0x70, 0x52, 0x10, 0x85, 0xd6, 0xa7, 0xec, 0xdf, 0xc1, 0x2b,
+		0xf6, 0x04, 0x39, 0xfa, 0x46, 0x81, 0x87, 0x63, 0xd9, 0x1f, 0x73, 0x98, 0xe0, 0x0e, 0xff, 0x79,
+		0xbb, 0xd3, 0x18, 0xf3, 0xbc, 0x41, 0x67, 0x01, 0x50, 0x17, 0x38, 0xc0, 0x30, 0x2d, 0xe6, 0x74,
+		0x1d, 0xd6, 0x1b, 0xf3, 0x9e, 0xe4, 0xde, 0xb7, 0x67, 0x78, 0xd7, 0x24, 0x75, 0xcc, 0xf5, 0x1a,
+		0x9d, 0xaa, 0x45, 0x2d, 0x58, 0x93, 0x77, 0x49, 0x78, 0xec, 0xee, 0x0c, 0x3b, 0x4d, 0x71, 0xa7,
+		0xa5, 0x29, 0x4e, 0xf7, 0x05, 0x31, 0xb0, 0x78, 0xb6, 0x13, 0xf1, 0xb8, 0xda, 0x98, 0xac, 0x42,
+		0x1e, 0xac, 0x5d, 0x9a, 0x4e, 0xd3, 0xeb, 0x12, 0xdf, 0x68, 0x99, 0xfe, 0x35, 0xf1, 0x87, 0xdd,
+		0xa5, 0xb9, 0xbb, 0xf2, 0x14, 0x77, 0x7b, 0x92, 0x78, 0xc8, 0x79, 0x11, 0x7f, 0xea, 0xe5, 0x14,
+		0xdd, 0x9b, 0x0c, 0xc0, 0xc0, 0x41, 0xf1, 0xe7, 0x18, 0xac, 0x4c, 0xaa, 0x0c, 0x74, 0x0a, 0x79,
+		0x59, 0x66, 0x5e, 0x9b, 0xf8, 0xbc, 0xfc, 0xe4, 0xed, 0x78, 0x3a, 0xb3, 0xc0, 0x8e, 0x43, 0x34,
+		0xce, 0xd9, 0x51, 0x01, 0xca, 0x42, 0x4c, 0x5e, 0x8a, 0x79, 0x1c, 0x73, 0x6c, 0xf4, 0x12, 0x92,
+		0x02, 0x22, 0xef, 0xc0, 0xfa, 0x88, 0xe1, 0xb6, 0x33, 0x30, 0x8b, 0x25, 0x14, 0x3d, 0x81, 0xac,
+		0xe5, 0xb9, 0x97, 0xce, 0x95, 0xd1, 0x25, 0x3e, 0x0d, 0xa2, 0x9a, 0xe3, 0xb7, 0x6c, 0x51, 0x48,
+		0xcf, 0x84, 0x10, 0x3d, 0x87, 0x7c, 0x3f, 0xad, 0x21, 0x30, 0xc1, 0x81, 0xb9, 0x50, 0x1e, 0x42,
+		0xff, 0x07, 0xf7, 0xdb, 0x3e, 0xe9, 0x3a, 0x5e, 0x87, 0x1a, 0x63, 0x9c, 0x24, 0xe7, 0xac, 0x86,
+		0x80, 0xbd, 0x28, 0xb7, 0xf8, 0xa3, 0x02, 0x1b, 0x33, 0xeb, 0x3c, 0x88, 0x57, 0x76, 0x05, 0xab,
+		0xd9, 0xa1, 0x8c, 0xf8, 0x3c, 0x8b, 0xf3, 0x78, 0x51, 0x48, 0x77, 0x85, 0x30, 0x68, 0x84, 0xe2,
+		0xaa, 0xc9, 0x0c, 0x25, 0x70, 0x8a, 0xaf, 0x75, 0x1b, 0xfd, 0x07, 0xe6, 0xfb, 0x73, 0xe4, 0x16,
+		0xdd, 0x62, 0x00, 0x2e, 0xfe, 0x9a, 0x80, 0xb5, 0xe9, 0xf7, 0x00, 0xad, 0xc3, 0xbc, 0x3c, 0x62,
+		0xc7, 0x96, 0x51, 0xa5, 0x85, 0x40, 0xb7, 0xd1, 0x3b, 0x40, 0x37, 0x9e, 0x7f, 0x7d, 0xd9, 0xf4,
+		0x6e, 0x0c, 0xf2, 0x81, 0x58, 0x1d, 0x5e, 0x01, 0xb1, 0x89, 0xfd, 0x57, 0x1c, 0xd4, 0x7b, 0x09,
+		0xaf, 0x86, 0x68, 0xbc, 0x74, 0x33, 0x2a, 0x42, 0x2a, 0xa4, 0xc2, 0xd4, 0xc6, 0x79, 0x6a, 0xc3,
+		0x25, 0x7a, 0x08, 0x19, 0x6a, 0x35, 0x88, 0xdd, 0x69, 0x12, 0x9e, 0x05, 0x71, 0xac, 0x0b, 0x7d,
+		0x99, 0x6e, 0x23, 0x0d, 0xb2, 0x03, 0x08, 0x6f, 0x9e, 0x89, 0x8f, 0xa6, 0x63, 0xb1, 0xcf, 0x08,
+		0x64, 0x68, 0x03, 0x80, 0x32, 0xd3, 0x67, 0xc2, 0x87, 0x38, 0xdd, 0x79, 0x29, 0xd1, 0x6d, 0xf4,
+		0x19, 0x64, 0x42, 0x35, 0xb7, 0x9f, 0xfa, 0xa8, 0xfd, 0x05, 0x89, 0xe7, 0xd6, 0xbf, 0x80, 0x65,
+		0x3e, 0x09, 0x1b, 0xc4, 0xf4, 0xd9, 0x05, 0x31, 0x99, 0xb0, 0x92, 0xfe, 0xa8, 0x95, 0xa5, 0x80,
+		0xb6, 0x1f, 0xb2, 0xb8, 0xad, 0x7f, 0x41, 0xca, 0x26, 0xcc, 0x74, 0x9a, 0x54, 0x9d, 0xe7, 0xfc,
+		0x07, 0x13, 0xb3, 0x7e, 0x62, 0xf6, 0x9a, 0x9e, 0x69, 0xe3, 0x10, 0x1c, 0x64, 0xd8, 0x64, 0x8c,
+		0xb4, 0xda, 0x4c, 0x05, 0x51, 0x48, 0x72, 0x89, 0x5e, 0x43, 0x86, 0x47, 0x17, 0x14, 0x79, 0xc7,
+		0x27, 0xea, 0xc2, 0x0c, 0xb3, 0x7b, 0x02, 0x83, 0x17, 0x02, 0x86, 0x5c, 0xa0, 0x7f, 0xc0, 0x0a,
+		0x37, 0x10, 0x1c, 0x2b, 0xf1, 0x0d, 0xc7, 0x26, 0x2e, 0x73, 0x58, 0x4f, 0xcd, 0xf0, 0xda, 0x41,
+		0x81, 0xee, 0x3d, 0x57, 0xe9, 0x52, 0x83, 0x8e, 0x20, 0x27, 0xcf, 0xd7, 0x90, 0x0d, 0x50, 0x5d,
+		0xe4, 0x5e, 0x9f, 0x4c, 0x69, 0x22, 0xf2, 0x62, 0xc9, 0x46, 0x8a, 0xb3, 0xdd, 0xc8, 0xba, 0xf8,
+		0x6d, 0x1c, 0x56, 0xa7, 0x34, 0x59, 0xb4, 0x0a, 0xa9, 0x70, 0xf0, 0x2a, 0xfc, 0x5c, 0x93, 0x4c,
+		0x8c, 0xdc, 0x48, 0x9d, 0xc7, 0x6e, 0x55, 0xe7, 0xf1, 0x4f, 0xad, 0xf3, 0xaf, 0xe1, 0xee, 0xc8,
+		0xc6, 0x0d, 0x87, 0x91, 0x56, 0x30, 0xa4, 0x83, 0xc7, 0xd6, 0xf3, 0x5b, 0x6d, 0x5f, 0x67, 0xa4,
+		0x85, 0x97, 0xbb, 0x63, 0x32, 0x8a, 0x5e, 0x41, 0x92, 0x74, 0x89, 0xcb, 0xc2, 0x19, 0xbc, 0x31,
+		0xb9, 0x75, 0x9a, 0xcc, 0x7c, 0xd3, 0xf4, 0x2e, 0xb0, 0x04, 0xa3, 0x5d, 0xc8, 0xba, 0xe4, 0xc6,
+		0xf0, 0x3b, 0xae, 0x21, 0xe9, 0xc9, 0xdb, 0xd0, 0x33, 0x2e, 0xb9, 0xc1, 0x1d, 0xb7, 0xca, 0x29,
+		0xc5, 0x9f, 0x14, 0x50, 0xa7, 0x4d, 0x9e, 0xd9, 0x3d, 0x65, 0x52, 0x53, 0x8e, 0x4d, 0x6e, 0xca,
+		0x9f, 0xfa, 0x4c, 0x2a, 0x7e, 0xa7, 0xc0, 0x72, 0x34, 0xca, 0xba, 0x77, 0x4d, 0xdc, 0x20, 0xc0,
+		0xb0, 0xd1, 0x8a, 0x97, 0x6f, 0x02, 0xa7, 0x65, 0xa7, 0xa5, 0xe8, 0x4b, 0xc8, 0x8d, 0x0c, 0x63,
+		0x35, 0xf6, 0xa7, 0x26, 0x30, 0xce, 0x46, 0xe7, 0x6f, 0xf1, 0x97, 0xe8, 0x83, 0x9c, 0x3f, 0x06,
+		0xdd, 0x4b, 0xef, 0x2f, 0xe9, 0xc1, 0xeb, 0xc3, 0x2f, 0xde, 0x38, 0xef, 0x11, 0x83, 0x57, 0xec,
+		0xd0, 0x2d, 0x9a, 0x8b, 0xdc, 0xa2, 0xa1, 0xce, 0x9d, 0x88, 0x76, 0xee, 0xc7, 0x90, 0xbd, 0x74,
+		0x7c, 0xca, 0x44, 0x4d, 0x0d, 0xfa, 0x6a, 0x86, 0x4b, 0x79, 0xd5, 0xe8, 0x36, 0x2a, 0xc2, 0xa2,
+		0x4b, 0x3e, 0x0c, 0x81, 0x52, 0xa2, 0xc1, 0x07, 0xc2, 0x10, 0x33, 0x3a, 0x03, 0xd2, 0x63, 0x33,
+		0x20, 0xa8, 0xbe, 0xfc, 0x70, 0x22, 0xf9, 0xa1, 0x0e, 0x4f, 0x4f, 0x25, 0x3a, 0x3d, 0x3f, 0xe1,
+		0xcf, 0x49, 0x48, 0x6d, 0xfb, 0x9e, 0x45, 0x28, 0x8d, 0x52, 0xe3, 0x03, 0xea, 0x49, 0xa8, 0xef,
+		0x53, 0x8b, 0x6f, 0x21, 0x37, 0xf2, 0x2c, 0x88, 0x8e, 0x71, 0xe5, 0x8f, 0x8c, 0x71, 0x17, 0x56,
+		0xe4, 0xe5, 0xaf, 0x1c, 0x9c, 0xee, 0x7a, 0x1d, 0x97, 0x55, 0x5d, 0xe6, 0xf7, 0xd0, 0x0a, 0x24,
+		0xac, 0x60, 0x25, 0xdb, 0x9d, 0x58, 0xcc, 0x7a, 0x49, 0x8c, 0xbf, 0x45, 0xe2, 0x13, 0xde, 0x22,
+		0x5b, 0xbf, 0x8d, 0xd7, 0x2a, 0x2f, 0x8d, 0x87, 0xb0, 0x81, 0xab, 0x27, 0x07, 0xfa, 0xae, 0x56,
+		0xd7, 0x8f, 0x8f, 0x8c, 0xba, 0x56, 0x7b, 0x6b, 0xd4, 0xcf, 0x4f, 0xaa, 0x86, 0x7e, 0x74, 0xa6,
+		0x1d, 0xe8, 0x95, 0xfc, 0x1d, 0x54, 0x80, 0x07, 0x93, 0x21, 0x95, 0xe3, 0x43, 0x4d, 0x3f, 0xca,
+		0x2b, 0xd3, 0x8d, 0xec, 0xeb, 0xb5, 0xfa, 0x31, 0x3e, 0xcf, 0xc7, 0xd0, 0x0b, 0x78, 0x36, 0x19,
+		0x52, 0x3b, 0x3f, 0xda, 0x35, 0x6a, 0xfb, 0x1a, 0xae, 0x18, 0xb5, 0xba, 0x56, 0x7f, 0x57, 0xcb,
+		0xc7, 0xd1, 0x33, 0x78, 0x34, 0x03, 0xac, 0xed, 0xd6, 0xf5, 0x33, 0xbd, 0x7e, 0x9e, 0x9f, 0x43,
+		0x5b, 0xf0, 0x74, 0xa6, 0x63, 0xe3, 0xb0, 0x5a, 0xd7, 0x2a, 0x5a, 0x5d, 0xcb, 0x27, 0xd0, 0x63,
+		0x28, 0xcc, 0xc6, 0x9e, 0xed, 0xe4, 0x93, 0xe8, 0x39, 0x3c, 0x99, 0x8c, 0xda, 0xd3, 0xf4, 0x83,
+		0xe3, 0xb3, 0x2a, 0x36, 0x0e, 0x35, 0xfc, 0xb6, 0x8a, 0xf3, 0xa9, 0x2d, 0x07, 0x72, 0x23, 0xcf,
+		0x63, 0xf4, 0x00, 0x54, 0x91, 0x14, 0xe3, 0xf8, 0xa4, 0x8a, 0x85, 0x89, 0x41, 0x22, 0xd7, 0x61,
+		0x75, 0x4c, 0xbb, 0x8b, 0xab, 0x5a, 0xbd, 0x9a, 0x57, 0x26, 0x2a, 0xdf, 0x9d, 0x54, 0x02, 0x65,
+		0x6c, 0xeb, 0x08, 0x52, 0x95, 0x83, 0x53, 0x7e, 0x60, 0x2b, 0x90, 0xaf, 0x1c, 0x9c, 0x8e, 0x9e,
+		0x91, 0x0a, 0x2b, 0x7d, 0xe9, 0x50, 0xfc, 0x79, 0x05, 0x2d, 0x43, 0xae, 0xaf, 0x91, 0x07, 0x16,
+		0x7b, 0xf3, 0xef, 0xaf, 0x5e, 0x5d, 0x39, 0xac, 0xd1, 0xb9, 0x28, 0x59, 0x5e, 0xab, 0x3c, 0xfc,
+		0xf1, 0xe1, 0xef, 0x8e, 0xdd, 0x2c, 0x5f, 0x79, 0xe2, 0x73, 0x47, 0xff, 0x4b, 0xc4, 0xff, 0xf9,
+		0x8f, 0xee, 0xf6, 0x45, 0x92, 0xcb, 0x5f, 0xfe, 0x3e, 0x00, 0x40, 0x11, 0xc6, 0x58, 0x56, 0x11,
+		0x00, 0x00,
 	},
 	// uber/cadence/api/v1/domain.proto
 	[]byte{
-		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0x5f, 0x6f, 0xdb, 0x36,
-		0x10, 0x9f, 0xec, 0x24, 0x4b, 0x4e, 0x8e, 0xe3, 0x32, 0x4d, 0xa3, 0x78, 0xc3, 0xa2, 0xa6, 0x28,
-		0xe0, 0x15, 0x98, 0xdc, 0x78, 0xc3, 0xd6, 0x6e, 0xd8, 0x83, 0x63, 0xa9, 0x99, 0x87, 0x2c, 0x0b,
-		0x64, 0x37, 0x03, 0x96, 0x07, 0x81, 0x96, 0x68, 0x9b, 0xa8, 0x2c, 0x0a, 0x94, 0xe4, 0x34, 0x6f,
-		0xc3, 0x3e, 0xd6, 0x1e, 0xf7, 0x29, 0xf6, 0x71, 0x06, 0x51, 0x94, 0xe2, 0x3f, 0x42, 0xd2, 0x37,
-		0xf2, 0xee, 0xf7, 0xfb, 0xdd, 0xf1, 0x74, 0x47, 0x0a, 0xf4, 0x64, 0x44, 0x78, 0xdb, 0xc5, 0x1e,
-		0x09, 0x5c, 0xd2, 0xc6, 0x21, 0x6d, 0xcf, 0x4f, 0xdb, 0x1e, 0x9b, 0x61, 0x1a, 0x18, 0x21, 0x67,
-		0x31, 0x43, 0xfb, 0x29, 0xc2, 0x90, 0x08, 0x03, 0x87, 0xd4, 0x98, 0x9f, 0x36, 0xbf, 0x9a, 0x30,
-		0x36, 0xf1, 0x49, 0x5b, 0x40, 0x46, 0xc9, 0xb8, 0xed, 0x25, 0x1c, 0xc7, 0x94, 0x49, 0x52, 0xf3,
-		0x78, 0xd5, 0x1f, 0xd3, 0x19, 0x89, 0x62, 0x3c, 0x0b, 0x25, 0xa0, 0x34, 0xae, 0xcb, 0x66, 0xb3,
-		0x5c, 0xe2, 0xe4, 0xbf, 0x1d, 0xd8, 0x32, 0x45, 0x22, 0xa8, 0x0e, 0x15, 0xea, 0x69, 0x8a, 0xae,
-		0xb4, 0x76, 0xec, 0x0a, 0xf5, 0x10, 0x82, 0x8d, 0x00, 0xcf, 0x88, 0x56, 0x11, 0x16, 0xb1, 0x46,
-		0x6f, 0x61, 0x2b, 0x8a, 0x71, 0x9c, 0x44, 0x5a, 0x55, 0x57, 0x5a, 0xf5, 0xce, 0x73, 0xa3, 0x24,
-		0x6f, 0x23, 0x13, 0x1c, 0x08, 0xa0, 0x2d, 0x09, 0x48, 0x07, 0xd5, 0x23, 0x91, 0xcb, 0x69, 0x98,
-		0x9e, 0x40, 0xdb, 0x10, 0xaa, 0x8b, 0x26, 0x74, 0x0c, 0x2a, 0xbb, 0x0d, 0x08, 0x77, 0xc8, 0x0c,
-		0x53, 0x5f, 0xdb, 0x14, 0x08, 0x10, 0x26, 0x2b, 0xb5, 0xa0, 0xb7, 0xb0, 0xe1, 0xe1, 0x18, 0x6b,
-		0x5b, 0x7a, 0xb5, 0xa5, 0x76, 0x5e, 0x3e, 0x10, 0xdb, 0x30, 0x71, 0x8c, 0xad, 0x20, 0xe6, 0x77,
-		0xb6, 0xa0, 0xa0, 0x29, 0xbc, 0xb8, 0x65, 0xfc, 0xc3, 0xd8, 0x67, 0xb7, 0x0e, 0xf9, 0x48, 0xdc,
-		0x24, 0x8d, 0xe8, 0x70, 0x12, 0x93, 0x40, 0xac, 0x42, 0xc2, 0x29, 0xf3, 0xb4, 0xcf, 0x75, 0xa5,
-		0xa5, 0x76, 0x8e, 0x8c, 0xac, 0xb0, 0x46, 0x5e, 0x58, 0xc3, 0x94, 0x85, 0xb7, 0xf5, 0x5c, 0xc5,
-		0xca, 0x45, 0xec, 0x5c, 0xe3, 0x4a, 0x48, 0xa0, 0x1e, 0xd4, 0x46, 0xd8, 0x73, 0x46, 0x34, 0xc0,
-		0x9c, 0x92, 0x48, 0xdb, 0x16, 0x92, 0x7a, 0x69, 0xb2, 0x67, 0xd8, 0x3b, 0x93, 0x38, 0x5b, 0x1d,
-		0xdd, 0x6f, 0xd0, 0x0d, 0x1c, 0x4e, 0x69, 0x14, 0x33, 0x7e, 0xe7, 0x60, 0xee, 0x4e, 0xe9, 0x1c,
-		0xfb, 0x8e, 0x2c, 0xfc, 0x8e, 0x28, 0xfc, 0x8b, 0x52, 0xbd, 0xae, 0xc4, 0xca, 0xd2, 0x1f, 0x48,
-		0x8d, 0x65, 0x33, 0x7a, 0x0d, 0x4f, 0xd7, 0xc4, 0x13, 0x4e, 0x35, 0x10, 0x05, 0x47, 0x2b, 0xa4,
-		0xf7, 0x9c, 0x22, 0x0c, 0xcd, 0x39, 0x8d, 0xe8, 0x88, 0xfa, 0x34, 0x5e, 0xcf, 0x48, 0xfd, 0xf4,
-		0x8c, 0xb4, 0x7b, 0x99, 0x95, 0xa4, 0xbe, 0x87, 0xc3, 0xb2, 0x10, 0x69, 0x5e, 0x35, 0x91, 0xd7,
-		0xc1, 0x3a, 0x35, 0x4d, 0xcd, 0x80, 0x7d, 0xec, 0xc6, 0x74, 0x4e, 0x1c, 0xd7, 0x4f, 0xa2, 0x98,
-		0x70, 0x47, 0x34, 0xed, 0xae, 0xe0, 0x3c, 0xc9, 0x5c, 0xbd, 0xcc, 0x73, 0x99, 0x76, 0xf0, 0x15,
-		0x6c, 0x4b, 0x60, 0xa4, 0xd5, 0x45, 0x1f, 0x7d, 0x57, 0x9a, 0xb8, 0xe4, 0xd8, 0x24, 0xf4, 0xa9,
-		0x2b, 0xbe, 0x7d, 0x8f, 0x05, 0x63, 0x3a, 0xc9, 0x1b, 0xa1, 0x50, 0x41, 0x5f, 0x43, 0x63, 0x8c,
-		0xa9, 0xcf, 0xe6, 0x84, 0x3b, 0x73, 0xc2, 0xa3, 0xb4, 0xbb, 0xf7, 0x74, 0xa5, 0x55, 0xb5, 0xf7,
-		0x72, 0xfb, 0x75, 0x66, 0x46, 0x2d, 0x68, 0xd0, 0xc8, 0x99, 0xf8, 0x6c, 0x84, 0x7d, 0x27, 0x9b,
-		0x7f, 0xad, 0xa1, 0x2b, 0xad, 0x6d, 0xbb, 0x4e, 0xa3, 0x73, 0x61, 0x96, 0xc3, 0xf8, 0x0e, 0x76,
-		0x0b, 0x51, 0x1a, 0x8c, 0x99, 0xf6, 0x44, 0xb4, 0x51, 0xf9, 0xbc, 0xbd, 0x93, 0xc8, 0x7e, 0x30,
-		0x66, 0x76, 0x6d, 0xbc, 0xb0, 0x43, 0x37, 0x69, 0x44, 0xe6, 0x8b, 0x9c, 0x9d, 0x09, 0x67, 0x49,
-		0x18, 0x69, 0x48, 0x48, 0xbd, 0x2e, 0x95, 0xea, 0xe7, 0xe0, 0xf3, 0x14, 0xbb, 0x7c, 0xe4, 0x3d,
-		0xba, 0xe4, 0x8c, 0x90, 0x0b, 0x07, 0x38, 0xba, 0x0b, 0x5c, 0xa7, 0x18, 0x2d, 0x57, 0x10, 0xb4,
-		0x7d, 0x11, 0xa1, 0x5d, 0xde, 0x11, 0x29, 0xe3, 0x0f, 0x49, 0x58, 0x0e, 0xb0, 0x8f, 0xd7, 0x7d,
-		0xcd, 0x1f, 0x60, 0xa7, 0x18, 0x66, 0xd4, 0x80, 0xea, 0x07, 0x72, 0x27, 0x2f, 0xa9, 0x74, 0x89,
-		0x9e, 0xc2, 0xe6, 0x1c, 0xfb, 0x49, 0x7e, 0x4d, 0x65, 0x9b, 0x1f, 0x2b, 0x6f, 0x94, 0x13, 0x13,
-		0x8e, 0x1f, 0xf9, 0x88, 0xe8, 0x39, 0xd4, 0x96, 0xba, 0x26, 0xd3, 0x55, 0xdd, 0xfb, 0x7e, 0x39,
-		0xf9, 0x47, 0x01, 0x75, 0x61, 0x4c, 0xd1, 0xaf, 0xb0, 0x5d, 0x8c, 0xb6, 0x22, 0xfa, 0xc7, 0x78,
-		0x6c, 0xb4, 0x8d, 0x7c, 0x91, 0x5d, 0x48, 0x05, 0xbf, 0xe9, 0xc0, 0xee, 0x92, 0xab, 0xe4, 0x78,
-		0x6f, 0x16, 0x8f, 0xa7, 0x76, 0x4e, 0x1e, 0x8c, 0x75, 0x27, 0x1a, 0x60, 0xa1, 0x04, 0x7f, 0x2b,
-		0xb0, 0xbb, 0xe4, 0x44, 0xcf, 0x60, 0x8b, 0x13, 0x1c, 0xb1, 0x40, 0x06, 0x91, 0x3b, 0xd4, 0x84,
-		0x6d, 0x16, 0x12, 0x8e, 0x63, 0xc6, 0x65, 0x25, 0x8b, 0x3d, 0xfa, 0x19, 0x6a, 0x2e, 0x27, 0x38,
-		0x26, 0x9e, 0x93, 0x3e, 0x30, 0xe2, 0xea, 0x57, 0x3b, 0xcd, 0xb5, 0x4b, 0x72, 0x98, 0xbf, 0x3e,
-		0xb6, 0x2a, 0xf1, 0xa9, 0xe5, 0xe4, 0xdf, 0x0a, 0xd4, 0x16, 0x3b, 0xb4, 0x74, 0x60, 0x94, 0xf2,
-		0x81, 0x19, 0x82, 0x56, 0x40, 0xa3, 0x18, 0xf3, 0xd8, 0x29, 0x9e, 0x38, 0x59, 0x91, 0x87, 0xd2,
-		0x78, 0x96, 0x73, 0x07, 0x29, 0xb5, 0xb0, 0xa3, 0x6b, 0x38, 0x2a, 0x54, 0xc9, 0xc7, 0x90, 0x72,
-		0xb2, 0x20, 0xfb, 0xf8, 0xe9, 0x0e, 0x73, 0xb2, 0x25, 0xb8, 0xf7, 0xba, 0x1d, 0x38, 0x70, 0xd9,
-		0x2c, 0xf4, 0x49, 0x5a, 0xaa, 0x68, 0x8a, 0xb9, 0xe7, 0xb8, 0x2c, 0x09, 0x62, 0xf1, 0xd8, 0x6d,
-		0xda, 0xfb, 0x85, 0x73, 0x90, 0xfa, 0x7a, 0xa9, 0x0b, 0xbd, 0x84, 0x7a, 0x48, 0x02, 0x8f, 0x06,
-		0x93, 0x8c, 0x11, 0x69, 0x9b, 0x7a, 0xb5, 0xb5, 0x69, 0xef, 0x4a, 0xab, 0x80, 0x46, 0xaf, 0xfe,
-		0x52, 0xa0, 0xb6, 0xf8, 0xac, 0xa2, 0x23, 0x38, 0x30, 0x7f, 0xff, 0xad, 0xdb, 0xbf, 0x74, 0x06,
-		0xc3, 0xee, 0xf0, 0xfd, 0xc0, 0xe9, 0x5f, 0x5e, 0x77, 0x2f, 0xfa, 0x66, 0xe3, 0x33, 0xf4, 0x25,
-		0x68, 0xcb, 0x2e, 0xdb, 0x3a, 0xef, 0x0f, 0x86, 0x96, 0x6d, 0x99, 0x0d, 0x65, 0xdd, 0x6b, 0x5a,
-		0x57, 0xb6, 0xd5, 0xeb, 0x0e, 0x2d, 0xb3, 0x51, 0x59, 0x97, 0x35, 0xad, 0x0b, 0x2b, 0x75, 0x55,
-		0x5f, 0x4d, 0xa1, 0xbe, 0x72, 0x67, 0x7f, 0x01, 0x87, 0x5d, 0xbb, 0xf7, 0x4b, 0xff, 0xba, 0x7b,
-		0x51, 0x9a, 0xc5, 0xaa, 0xd3, 0xec, 0x0f, 0xba, 0x67, 0x17, 0x22, 0x8b, 0x12, 0xaa, 0x75, 0x99,
-		0x39, 0x2b, 0x67, 0x37, 0x70, 0xe8, 0xb2, 0x59, 0x59, 0xab, 0x9f, 0xa9, 0x59, 0x11, 0xae, 0xd2,
-		0xaf, 0x72, 0xa5, 0xfc, 0x79, 0x3a, 0xa1, 0xf1, 0x34, 0x19, 0x19, 0x2e, 0x9b, 0xb5, 0x17, 0xff,
-		0x75, 0xbe, 0xa1, 0x9e, 0xdf, 0x9e, 0xb0, 0xec, 0xcf, 0x48, 0xfe, 0xf8, 0xfc, 0x84, 0x43, 0x3a,
-		0x3f, 0x1d, 0x6d, 0x09, 0xdb, 0xb7, 0xff, 0x07, 0x00, 0x00, 0xff, 0xff, 0x33, 0x96, 0xec, 0x45,
-		0x94, 0x09, 0x00, 0x00,
+		0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0x51, 0x6f, 0xdb, 0xb6,
+		0x13, 0xff, 0xcb, 0x4e, 0xf2, 0x4f, 0x4e, 0x8e, 0xe3, 0x32, 0x4d, 0xa3, 0x78, 0xc3, 0xa2, 0xa6,
+		0x28, 0xe0, 0x15, 0x98, 0xdc, 0x78, 0xc3, 0xd6, 0x6e, 0xd8, 0x83, 0x63, 0xa9, 0x99, 0x87, 0x2c,
+		0x0b, 0x64, 0x37, 0x03, 0x96, 0x07, 0x81, 0x96, 0x68, 0x9b, 0xa8, 0x24, 0x0a, 0x94, 0xe4, 0x34,
+		0x6f, 0xc3, 0x3e, 0xd6, 0x1e, 0xf7, 0x29, 0xf6, 0x71, 0x06, 0x51, 0x94, 0x62, 0xc7, 0x42, 0xb3,
+		0x37, 0xf2, 0xee, 0xf7, 0xfb, 0xdd, 0xf1, 0x74, 0x47, 0x0a, 0xf4, 0x74, 0x42, 0x78, 0xd7, 0xc5,
+		0x1e, 0x09, 0x5d, 0xd2, 0xc5, 0x11, 0xed, 0x2e, 0x4e, 0xbb, 0x1e, 0x0b, 0x30, 0x0d, 0x8d, 0x88,
+		0xb3, 0x84, 0xa1, 0xfd, 0x0c, 0x61, 0x48, 0x84, 0x81, 0x23, 0x6a, 0x2c, 0x4e, 0xdb, 0x5f, 0xcc,
+		0x18, 0x9b, 0xf9, 0xa4, 0x2b, 0x20, 0x93, 0x74, 0xda, 0xf5, 0x52, 0x8e, 0x13, 0xca, 0x24, 0xa9,
+		0x7d, 0xfc, 0xd0, 0x9f, 0xd0, 0x80, 0xc4, 0x09, 0x0e, 0x22, 0x09, 0xa8, 0x8c, 0xeb, 0xb2, 0x20,
+		0x28, 0x24, 0x4e, 0xfe, 0xd9, 0x81, 0x2d, 0x53, 0x24, 0x82, 0x9a, 0x50, 0xa3, 0x9e, 0xa6, 0xe8,
+		0x4a, 0x67, 0xc7, 0xae, 0x51, 0x0f, 0x21, 0xd8, 0x08, 0x71, 0x40, 0xb4, 0x9a, 0xb0, 0x88, 0x35,
+		0x7a, 0x0b, 0x5b, 0x71, 0x82, 0x93, 0x34, 0xd6, 0xea, 0xba, 0xd2, 0x69, 0xf6, 0x9e, 0x1b, 0x15,
+		0x79, 0x1b, 0xb9, 0xe0, 0x48, 0x00, 0x6d, 0x49, 0x40, 0x3a, 0xa8, 0x1e, 0x89, 0x5d, 0x4e, 0xa3,
+		0xec, 0x04, 0xda, 0x86, 0x50, 0x5d, 0x36, 0xa1, 0x63, 0x50, 0xd9, 0x6d, 0x48, 0xb8, 0x43, 0x02,
+		0x4c, 0x7d, 0x6d, 0x53, 0x20, 0x40, 0x98, 0xac, 0xcc, 0x82, 0xde, 0xc2, 0x86, 0x87, 0x13, 0xac,
+		0x6d, 0xe9, 0xf5, 0x8e, 0xda, 0x7b, 0xf9, 0x89, 0xd8, 0x86, 0x89, 0x13, 0x6c, 0x85, 0x09, 0xbf,
+		0xb3, 0x05, 0x05, 0xcd, 0xe1, 0xc5, 0x2d, 0xe3, 0x1f, 0xa6, 0x3e, 0xbb, 0x75, 0xc8, 0x47, 0xe2,
+		0xa6, 0x59, 0x44, 0x87, 0x93, 0x84, 0x84, 0x62, 0x15, 0x11, 0x4e, 0x99, 0xa7, 0xfd, 0x5f, 0x57,
+		0x3a, 0x6a, 0xef, 0xc8, 0xc8, 0x0b, 0x6b, 0x14, 0x85, 0x35, 0x4c, 0x59, 0x78, 0x5b, 0x2f, 0x54,
+		0xac, 0x42, 0xc4, 0x2e, 0x34, 0xae, 0x84, 0x04, 0x1a, 0x40, 0x63, 0x82, 0x3d, 0x67, 0x42, 0x43,
+		0xcc, 0x29, 0x89, 0xb5, 0x6d, 0x21, 0xa9, 0x57, 0x26, 0x7b, 0x86, 0xbd, 0x33, 0x89, 0xb3, 0xd5,
+		0xc9, 0xfd, 0x06, 0xdd, 0xc0, 0xe1, 0x9c, 0xc6, 0x09, 0xe3, 0x77, 0x0e, 0xe6, 0xee, 0x9c, 0x2e,
+		0xb0, 0xef, 0xc8, 0xc2, 0xef, 0x88, 0xc2, 0xbf, 0xa8, 0xd4, 0xeb, 0x4b, 0xac, 0x2c, 0xfd, 0x81,
+		0xd4, 0x58, 0x35, 0xa3, 0xd7, 0xf0, 0x74, 0x4d, 0x3c, 0xe5, 0x54, 0x03, 0x51, 0x70, 0xf4, 0x80,
+		0xf4, 0x9e, 0x53, 0x84, 0xa1, 0xbd, 0xa0, 0x31, 0x9d, 0x50, 0x9f, 0x26, 0xeb, 0x19, 0xa9, 0xff,
+		0x3d, 0x23, 0xed, 0x5e, 0xe6, 0x41, 0x52, 0xdf, 0xc2, 0x61, 0x55, 0x88, 0x2c, 0xaf, 0x86, 0xc8,
+		0xeb, 0x60, 0x9d, 0x9a, 0xa5, 0x66, 0xc0, 0x3e, 0x76, 0x13, 0xba, 0x20, 0x8e, 0xeb, 0xa7, 0x71,
+		0x42, 0xb8, 0x23, 0x9a, 0x76, 0x57, 0x70, 0x9e, 0xe4, 0xae, 0x41, 0xee, 0xb9, 0xcc, 0x3a, 0xf8,
+		0x0a, 0xb6, 0x25, 0x30, 0xd6, 0x9a, 0xa2, 0x8f, 0xbe, 0xa9, 0x4c, 0x5c, 0x72, 0x6c, 0x12, 0xf9,
+		0xd4, 0x15, 0xdf, 0x7e, 0xc0, 0xc2, 0x29, 0x9d, 0x15, 0x8d, 0x50, 0xaa, 0xa0, 0x2f, 0xa1, 0x35,
+		0xc5, 0xd4, 0x67, 0x0b, 0xc2, 0x9d, 0x05, 0xe1, 0x71, 0xd6, 0xdd, 0x7b, 0xba, 0xd2, 0xa9, 0xdb,
+		0x7b, 0x85, 0xfd, 0x3a, 0x37, 0xa3, 0x0e, 0xb4, 0x68, 0xec, 0xcc, 0x7c, 0x36, 0xc1, 0xbe, 0x93,
+		0xcf, 0xbf, 0xd6, 0xd2, 0x95, 0xce, 0xb6, 0xdd, 0xa4, 0xf1, 0xb9, 0x30, 0xcb, 0x61, 0x7c, 0x07,
+		0xbb, 0xa5, 0x28, 0x0d, 0xa7, 0x4c, 0x7b, 0x22, 0xda, 0xa8, 0x7a, 0xde, 0xde, 0x49, 0xe4, 0x30,
+		0x9c, 0x32, 0xbb, 0x31, 0x5d, 0xda, 0xa1, 0x9b, 0x2c, 0x22, 0xf3, 0x45, 0xce, 0xce, 0x8c, 0xb3,
+		0x34, 0x8a, 0x35, 0x24, 0xa4, 0x5e, 0x57, 0x4a, 0x0d, 0x0b, 0xf0, 0x79, 0x86, 0x5d, 0x3d, 0xf2,
+		0x1e, 0x5d, 0x71, 0xc6, 0xc8, 0x85, 0x03, 0x1c, 0xdf, 0x85, 0xae, 0x53, 0x8e, 0x96, 0x2b, 0x08,
+		0xda, 0xbe, 0x88, 0xd0, 0xad, 0xee, 0x88, 0x8c, 0xf1, 0x9b, 0x24, 0xac, 0x06, 0xd8, 0xc7, 0xeb,
+		0xbe, 0xf6, 0x77, 0xb0, 0x53, 0x0e, 0x33, 0x6a, 0x41, 0xfd, 0x03, 0xb9, 0x93, 0x97, 0x54, 0xb6,
+		0x44, 0x4f, 0x61, 0x73, 0x81, 0xfd, 0xb4, 0xb8, 0xa6, 0xf2, 0xcd, 0xf7, 0xb5, 0x37, 0xca, 0x89,
+		0x09, 0xc7, 0x8f, 0x7c, 0x44, 0xf4, 0x1c, 0x1a, 0x2b, 0x5d, 0x93, 0xeb, 0xaa, 0xee, 0x7d, 0xbf,
+		0x9c, 0xfc, 0xa5, 0x80, 0xba, 0x34, 0xa6, 0xe8, 0x67, 0xd8, 0x2e, 0x47, 0x5b, 0x11, 0xfd, 0x63,
+		0x3c, 0x36, 0xda, 0x46, 0xb1, 0xc8, 0x2f, 0xa4, 0x92, 0xdf, 0x76, 0x60, 0x77, 0xc5, 0x55, 0x71,
+		0xbc, 0x37, 0xcb, 0xc7, 0x53, 0x7b, 0x27, 0x9f, 0x8c, 0x75, 0x27, 0x1a, 0x60, 0xa9, 0x04, 0x7f,
+		0x2a, 0xb0, 0xbb, 0xe2, 0x44, 0xcf, 0x60, 0x8b, 0x13, 0x1c, 0xb3, 0x50, 0x06, 0x91, 0x3b, 0xd4,
+		0x86, 0x6d, 0x16, 0x11, 0x8e, 0x13, 0xc6, 0x65, 0x25, 0xcb, 0x3d, 0xfa, 0x11, 0x1a, 0x2e, 0x27,
+		0x38, 0x21, 0x9e, 0x93, 0x3d, 0x30, 0xe2, 0xea, 0x57, 0x7b, 0xed, 0xb5, 0x4b, 0x72, 0x5c, 0xbc,
+		0x3e, 0xb6, 0x2a, 0xf1, 0x99, 0xe5, 0xe4, 0xef, 0x1a, 0x34, 0x96, 0x3b, 0xb4, 0x72, 0x60, 0x94,
+		0xea, 0x81, 0x19, 0x83, 0x56, 0x42, 0xe3, 0x04, 0xf3, 0xc4, 0x29, 0x9f, 0x38, 0xad, 0xf6, 0x68,
+		0x1a, 0xcf, 0x0a, 0xee, 0x28, 0xa3, 0x96, 0x76, 0x74, 0x0d, 0x47, 0xa5, 0x2a, 0xf9, 0x18, 0x51,
+		0x4e, 0x96, 0x64, 0x1f, 0x3f, 0xdd, 0x61, 0x41, 0xb6, 0x04, 0xf7, 0x5e, 0xb7, 0x07, 0x07, 0x2e,
+		0x0b, 0x22, 0x9f, 0x64, 0xa5, 0x8a, 0xe7, 0x98, 0x7b, 0x8e, 0xcb, 0xd2, 0x30, 0x11, 0x8f, 0xdd,
+		0xa6, 0xbd, 0x5f, 0x3a, 0x47, 0x99, 0x6f, 0x90, 0xb9, 0xd0, 0x4b, 0x68, 0x46, 0x24, 0xf4, 0x68,
+		0x38, 0xcb, 0x19, 0xb1, 0xb6, 0xa9, 0xd7, 0x3b, 0x9b, 0xf6, 0xae, 0xb4, 0x0a, 0x68, 0xfc, 0xea,
+		0x0f, 0x05, 0x1a, 0xcb, 0xcf, 0x2a, 0x3a, 0x82, 0x03, 0xf3, 0xd7, 0x5f, 0xfa, 0xc3, 0x4b, 0x67,
+		0x34, 0xee, 0x8f, 0xdf, 0x8f, 0x9c, 0xe1, 0xe5, 0x75, 0xff, 0x62, 0x68, 0xb6, 0xfe, 0x87, 0x3e,
+		0x07, 0x6d, 0xd5, 0x65, 0x5b, 0xe7, 0xc3, 0xd1, 0xd8, 0xb2, 0x2d, 0xb3, 0xa5, 0xac, 0x7b, 0x4d,
+		0xeb, 0xca, 0xb6, 0x06, 0xfd, 0xb1, 0x65, 0xb6, 0x6a, 0xeb, 0xb2, 0xa6, 0x75, 0x61, 0x65, 0xae,
+		0xfa, 0xab, 0x39, 0x34, 0x1f, 0xdc, 0xd9, 0x9f, 0xc1, 0x61, 0xdf, 0x1e, 0xfc, 0x34, 0xbc, 0xee,
+		0x5f, 0x54, 0x66, 0xf1, 0xd0, 0x69, 0x0e, 0x47, 0xfd, 0xb3, 0x0b, 0x91, 0x45, 0x05, 0xd5, 0xba,
+		0xcc, 0x9d, 0xb5, 0xb3, 0x1b, 0x38, 0x74, 0x59, 0x50, 0xd5, 0xea, 0x67, 0x6a, 0x5e, 0x84, 0xab,
+		0xec, 0xab, 0x5c, 0x29, 0xbf, 0x9f, 0xce, 0x68, 0x32, 0x4f, 0x27, 0x86, 0xcb, 0x82, 0xee, 0xf2,
+		0xbf, 0xce, 0x57, 0xd4, 0xf3, 0xbb, 0x33, 0x96, 0xff, 0x19, 0xc9, 0x1f, 0x9f, 0x1f, 0x70, 0x44,
+		0x17, 0xa7, 0x93, 0x2d, 0x61, 0xfb, 0xfa, 0xdf, 0x01, 0x00, 0x33, 0x96, 0xec, 0x45, 0x94, 0x09,
+		0x00, 0x00,
 	},
 	// uber/cadence/shared/v1/any.proto
 	[]byte{
@@ -4042,7 +4482,7 @@ var yarpcFileDescriptorClosurefee8ff76963a38ed = [][]byte{
 		0x82, 0x39, 0x12, 0x4c, 0x0a, 0x8c, 0x1a, 0x3c, 0x41, 0x10, 0x8e, 0x93, 0x79, 0x94, 0x69, 0x7a,
 		0x66, 0x49, 0x46, 0x69, 0x92, 0x5e, 0x72, 0x7e, 0xae, 0x3e, 0x8a, 0x13, 0xf4, 0xd2, 0x53, 0xf3,
 		0xf4, 0xc1, 0x36, 0x23, 0x5c, 0x63, 0x0d, 0x61, 0x95, 0x19, 0x26, 0xb1, 0x81, 0x65, 0x8c, 0x01,
-		0x01, 0x00, 0x00, 0xff, 0xff, 0x4e, 0x0a, 0xa2, 0x2e, 0xb7, 0x00, 0x00, 0x00,
+		0x03, 0x00, 0x4e, 0x0a, 0xa2, 0x2e, 0xb7, 0x00, 0x00, 0x00,
 	},
 	// uber/cadence/shared/v1/history.proto
 	[]byte{
@@ -4063,8 +4503,8 @@ var yarpcFileDescriptorClosurefee8ff76963a38ed = [][]byte{
 		0xb3, 0xa4, 0x83, 0x02, 0x7f, 0x39, 0xf3, 0x44, 0x7c, 0x8b, 0xb6, 0xcb, 0x9f, 0xbc, 0x41, 0x6f,
 		0xf8, 0x6f, 0xd2, 0x9f, 0x9e, 0xfd, 0xea, 0xe6, 0xcf, 0xe7, 0xdb, 0x85, 0x7a, 0xb6, 0xf6, 0x6e,
 		0x2e, 0x5f, 0x2f, 0xe6, 0xc2, 0x96, 0x75, 0x81, 0x99, 0x5a, 0x90, 0xe0, 0xf8, 0x78, 0x0e, 0x92,
-		0x34, 0x07, 0x5f, 0x3f, 0xf4, 0x55, 0xfb, 0xe5, 0xd2, 0x62, 0xab, 0xd9, 0x9c, 0x7f, 0x07, 0x00,
-		0x00, 0xff, 0xff, 0xc6, 0xf4, 0xa6, 0x9c, 0x12, 0x02, 0x00, 0x00,
+		0x34, 0x07, 0x5f, 0x3f, 0xf4, 0x55, 0xfb, 0xe5, 0xd2, 0x62, 0xab, 0xd9, 0x9c, 0x7f, 0x0f, 0x00,
+		0xc6, 0xf4, 0xa6, 0x9c, 0x12, 0x02, 0x00, 0x00,
 	},
 	// uber/cadence/shared/v1/workflow.proto
 	[]byte{
@@ -4081,8 +4521,7 @@ var yarpcFileDescriptorClosurefee8ff76963a38ed = [][]byte{
 		0x30, 0x7f, 0x4f, 0x17, 0x01, 0x56, 0xac, 0x26, 0x06, 0x05, 0x85, 0x06, 0x80, 0x4c, 0x64, 0x73,
 		0x32, 0x8f, 0x32, 0x4d, 0xcf, 0x2c, 0xc9, 0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf, 0xd5, 0x47, 0x09,
 		0x21, 0xbd, 0xf4, 0xd4, 0x3c, 0x7d, 0x70, 0x98, 0x20, 0x02, 0xcb, 0x1a, 0xc2, 0x2a, 0x33, 0x4c,
-		0x62, 0x03, 0xcb, 0x18, 0x03, 0x02, 0x00, 0x00, 0xff, 0xff, 0xff, 0xc3, 0x37, 0x89, 0x56, 0x01,
-		0x00, 0x00,
+		0x62, 0x03, 0xcb, 0x18, 0x03, 0x06, 0x00, 0xff, 0xc3, 0x37, 0x89, 0x56, 0x01, 0x00, 0x00,
 	},
 }
 
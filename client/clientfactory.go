@@ -73,6 +73,7 @@ type (
 		metricsClient         metrics.Client
 		dynConfig             *dynamicconfig.Collection
 		numberOfHistoryShards int
+		historyShardMappingFn history.ShardMappingFn
 		logger                log.Logger
 	}
 )
@@ -84,6 +85,7 @@ func NewRPCClientFactory(
 	metricsClient metrics.Client,
 	dc *dynamicconfig.Collection,
 	numberOfHistoryShards int,
+	historyShardMappingFn history.ShardMappingFn,
 	logger log.Logger,
 ) Factory {
 	return &rpcClientFactory{
@@ -92,6 +94,7 @@ func NewRPCClientFactory(
 		metricsClient:         metricsClient,
 		dynConfig:             dc,
 		numberOfHistoryShards: numberOfHistoryShards,
+		historyShardMappingFn: historyShardMappingFn,
 		logger:                logger,
 	}
 }
@@ -116,7 +119,7 @@ func (cf *rpcClientFactory) NewHistoryClientWithTimeout(timeout time.Duration) (
 		rawClient = thrift.NewHistoryClient(historyserviceclient.New(outboundConfig))
 	}
 
-	peerResolver := history.NewPeerResolver(cf.numberOfHistoryShards, cf.resolver, namedPort)
+	peerResolver := history.NewPeerResolverWithShardMapping(cf.historyShardMappingFn, cf.resolver, namedPort)
 
 	client := history.NewClient(
 		cf.numberOfHistoryShards,
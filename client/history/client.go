@@ -39,6 +39,14 @@ import (
 
 var _ Client = (*clientImpl)(nil)
 
+const (
+	// redirectBackoff is the delay before retrying a request that keeps failing with shard ownership lost errors
+	redirectBackoff = 50 * time.Millisecond
+	// maxRedirects is the number of times a request is redirected or retried on shard ownership lost errors
+	// before the error is returned to the caller
+	maxRedirects = 10
+)
+
 type (
 	clientImpl struct {
 		numberOfShards    int
@@ -259,6 +267,45 @@ func (c *clientImpl) DescribeQueue(
 	return response, nil
 }
 
+func (c *clientImpl) ListQueueTasks(
+	ctx context.Context,
+	request *types.ListQueueTasksRequest,
+	opts ...yarpc.CallOption,
+) (*types.ListQueueTasksResponse, error) {
+	peer, err := c.peerResolver.FromShardID(int(request.GetShardID()))
+	if err != nil {
+		return nil, err
+	}
+	var response *types.ListQueueTasksResponse
+	op := func(ctx context.Context, peer string) error {
+		var err error
+		response, err = c.client.ListQueueTasks(ctx, request, append(opts, yarpc.WithShardKey(peer))...)
+		return err
+	}
+
+	err = c.executeWithRedirect(ctx, peer, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientImpl) ExecuteQueueTask(
+	ctx context.Context,
+	request *types.ExecuteQueueTaskRequest,
+	opts ...yarpc.CallOption,
+) error {
+	peer, err := c.peerResolver.FromShardID(int(request.GetShardID()))
+	if err != nil {
+		return err
+	}
+	op := func(ctx context.Context, peer string) error {
+		return c.client.ExecuteQueueTask(ctx, request, append(opts, yarpc.WithShardKey(peer))...)
+	}
+
+	return c.executeWithRedirect(ctx, peer, op)
+}
+
 func (c *clientImpl) DescribeMutableState(
 	ctx context.Context,
 	request *types.DescribeMutableStateRequest,
@@ -281,6 +328,28 @@ func (c *clientImpl) DescribeMutableState(
 	return response, nil
 }
 
+func (c *clientImpl) VerifyWorkflowExecution(
+	ctx context.Context,
+	request *types.VerifyWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (*types.VerifyWorkflowExecutionResponse, error) {
+	peer, err := c.peerResolver.FromWorkflowID(request.Execution.WorkflowID)
+	if err != nil {
+		return nil, err
+	}
+	var response *types.VerifyWorkflowExecutionResponse
+	op := func(ctx context.Context, peer string) error {
+		var err error
+		response, err = c.client.VerifyWorkflowExecution(ctx, request, append(opts, yarpc.WithShardKey(peer))...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, peer, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 func (c *clientImpl) ResetStickyTaskList(
 	ctx context.Context,
 	request *types.HistoryResetStickyTaskListRequest,
@@ -303,6 +372,28 @@ func (c *clientImpl) ResetStickyTaskList(
 	return response, nil
 }
 
+func (c *clientImpl) DeleteWorkflowExecution(
+	ctx context.Context,
+	request *types.HistoryDeleteWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (*types.DeleteWorkflowExecutionResponse, error) {
+	peer, err := c.peerResolver.FromWorkflowID(request.DeleteRequest.WorkflowExecution.WorkflowID)
+	if err != nil {
+		return nil, err
+	}
+	var response *types.DeleteWorkflowExecutionResponse
+	op := func(ctx context.Context, peer string) error {
+		var err error
+		response, err = c.client.DeleteWorkflowExecution(ctx, request, append(opts, yarpc.WithShardKey(peer))...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, peer, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 func (c *clientImpl) DescribeWorkflowExecution(
 	ctx context.Context,
 	request *types.HistoryDescribeWorkflowExecutionRequest,
@@ -585,6 +676,22 @@ func (c *clientImpl) TerminateWorkflowExecution(
 	return err
 }
 
+func (c *clientImpl) UpdateActivityOptions(
+	ctx context.Context,
+	request *types.HistoryUpdateActivityOptionsRequest,
+	opts ...yarpc.CallOption,
+) error {
+	peer, err := c.peerResolver.FromWorkflowID(request.UpdateRequest.WorkflowExecution.WorkflowID)
+	if err != nil {
+		return err
+	}
+	op := func(ctx context.Context, peer string) error {
+		return c.client.UpdateActivityOptions(ctx, request, append(opts, yarpc.WithShardKey(peer))...)
+	}
+	err = c.executeWithRedirect(ctx, peer, op)
+	return err
+}
+
 func (c *clientImpl) ResetWorkflowExecution(
 	ctx context.Context,
 	request *types.HistoryResetWorkflowExecutionRequest,
@@ -893,6 +1000,43 @@ func (c *clientImpl) CountDLQMessages(
 	return &types.HistoryCountDLQMessagesResponse{Entries: entries}, err
 }
 
+func (c *clientImpl) DescribeReplicationStatus(
+	ctx context.Context,
+	request *types.DescribeReplicationStatusRequest,
+	opts ...yarpc.CallOption,
+) (*types.DescribeReplicationStatusResponse, error) {
+
+	peers, err := c.peerResolver.GetAllPeers()
+	if err != nil {
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	responses := make([]*types.DescribeReplicationStatusResponse, 0, len(peers))
+
+	g := &errgroup.Group{}
+	for _, peer := range peers {
+		peer := peer
+		g.Go(func() (e error) {
+			defer func() { log.CapturePanic(recover(), c.logger, &e) }()
+
+			response, err := c.client.DescribeReplicationStatus(ctx, request, append(opts, yarpc.WithShardKey(peer))...)
+			if err == nil {
+				mu.Lock()
+				responses = append(responses, response)
+				mu.Unlock()
+			}
+
+			return err
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return types.MergeDescribeReplicationStatusResponses(responses), nil
+}
+
 func (c *clientImpl) ReadDLQMessages(
 	ctx context.Context,
 	request *types.ReadDLQMessagesRequest,
@@ -1067,6 +1211,29 @@ func (c *clientImpl) RespondCrossClusterTasksCompleted(
 	return response, nil
 }
 
+func (c *clientImpl) MigrateShardExecutions(
+	ctx context.Context,
+	request *types.MigrateShardExecutionsRequest,
+	opts ...yarpc.CallOption,
+) (*types.MigrateShardExecutionsResponse, error) {
+	peer, err := c.peerResolver.FromShardID(int(request.GetShardID()))
+	if err != nil {
+		return nil, err
+	}
+	var response *types.MigrateShardExecutionsResponse
+	op := func(ctx context.Context, peer string) error {
+		var err error
+		response, err = c.client.MigrateShardExecutions(ctx, request, append(opts, yarpc.WithShardKey(peer))...)
+		return err
+	}
+
+	err = c.executeWithRedirect(ctx, peer, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 func (c *clientImpl) GetFailoverInfo(
 	ctx context.Context,
 	request *types.GetFailoverInfoRequest,
@@ -1088,6 +1255,7 @@ func (c *clientImpl) executeWithRedirect(
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	redirects := 0
 redirectLoop:
 	for {
 		err = common.IsValidContext(ctx)
@@ -1098,6 +1266,21 @@ redirectLoop:
 		if err != nil {
 			if s, ok := err.(*types.ShardOwnershipLostError); ok {
 				// TODO: consider emitting a metric for number of redirects
+				redirects++
+				if redirects > maxRedirects {
+					return err
+				}
+				if redirects > 1 || s.GetOwner() == "" {
+					// the shard is being handed off and the new owner may not have acquired it yet,
+					// back off instead of bouncing between hosts
+					if err := sleepWithContext(ctx, redirectBackoff); err != nil {
+						return err
+					}
+				}
+				if s.GetOwner() == "" {
+					// owner is unknown, retry the same host once the handoff settles
+					continue redirectLoop
+				}
 				peer, err = c.peerResolver.FromHostAddress(s.GetOwner())
 				if err != nil {
 					return err
@@ -1109,3 +1292,14 @@ redirectLoop:
 	}
 	return err
 }
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -429,6 +429,32 @@ func TestClient_withResponse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "DescribeReplicationStatus",
+			op: func(c Client) (any, error) {
+				return c.DescribeReplicationStatus(context.Background(), &types.DescribeReplicationStatusRequest{})
+			},
+			mock: func(p *MockPeerResolver, c *MockClient) {
+				p.EXPECT().GetAllPeers().Return([]string{"test-peer-0", "test-peer-1"}, nil).Times(1)
+				c.EXPECT().DescribeReplicationStatus(gomock.Any(), gomock.Any(), []yarpc.CallOption{yarpc.WithShardKey("test-peer-0")}).
+					Return(&types.DescribeReplicationStatusResponse{
+						Clusters: []*types.ClusterReplicationStatus{
+							{ClusterName: "standby", Domains: []*types.DomainReplicationStatus{{DomainID: "domain", PendingTaskCount: 1, LagInMilliseconds: 10}}},
+						},
+					}, nil).Times(1)
+				c.EXPECT().DescribeReplicationStatus(gomock.Any(), gomock.Any(), []yarpc.CallOption{yarpc.WithShardKey("test-peer-1")}).
+					Return(&types.DescribeReplicationStatusResponse{
+						Clusters: []*types.ClusterReplicationStatus{
+							{ClusterName: "standby", Domains: []*types.DomainReplicationStatus{{DomainID: "domain", PendingTaskCount: 2, LagInMilliseconds: 5}}},
+						},
+					}, nil).Times(1)
+			},
+			want: &types.DescribeReplicationStatusResponse{
+				Clusters: []*types.ClusterReplicationStatus{
+					{ClusterName: "standby", Domains: []*types.DomainReplicationStatus{{DomainID: "domain", PendingTaskCount: 3, LagInMilliseconds: 10}}},
+				},
+			},
+		},
 		{
 			name: "QueryWorkflow",
 			op: func(c Client) (any, error) {
@@ -579,6 +605,46 @@ func TestClient_withResponse(t *testing.T) {
 			},
 			wantError: true,
 		},
+		{
+			name: "StartWorkflowExecution retried on same host when owner is unknown",
+			op: func(c Client) (any, error) {
+				return c.StartWorkflowExecution(context.Background(), &types.HistoryStartWorkflowExecutionRequest{
+					StartRequest: &types.StartWorkflowExecutionRequest{
+						WorkflowID: "test-workflow",
+					},
+				})
+			},
+			mock: func(p *MockPeerResolver, c *MockClient) {
+				p.EXPECT().FromWorkflowID("test-workflow").Return("test-peer-1", nil).Times(1)
+				gomock.InOrder(
+					c.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any(), []yarpc.CallOption{yarpc.WithShardKey("test-peer-1")}).
+						Return(nil, &types.ShardOwnershipLostError{
+							Message: "test-peer-1 is handing off the shard",
+						}).Times(1),
+					c.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any(), []yarpc.CallOption{yarpc.WithShardKey("test-peer-1")}).
+						Return(&types.StartWorkflowExecutionResponse{}, nil).Times(1),
+				)
+			},
+			want: &types.StartWorkflowExecutionResponse{},
+		},
+		{
+			name: "StartWorkflowExecution gives up when owner stays unknown",
+			op: func(c Client) (any, error) {
+				return c.StartWorkflowExecution(context.Background(), &types.HistoryStartWorkflowExecutionRequest{
+					StartRequest: &types.StartWorkflowExecutionRequest{
+						WorkflowID: "test-workflow",
+					},
+				})
+			},
+			mock: func(p *MockPeerResolver, c *MockClient) {
+				p.EXPECT().FromWorkflowID("test-workflow").Return("test-peer-1", nil).Times(1)
+				c.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any(), []yarpc.CallOption{yarpc.WithShardKey("test-peer-1")}).
+					Return(nil, &types.ShardOwnershipLostError{
+						Message: "test-peer-1 is handing off the shard",
+					}).Times(maxRedirects + 1)
+			},
+			wantError: true,
+		},
 		{
 			name: "StartWorkflowExecution redirected failed again with error",
 			op: func(c Client) (any, error) {
@@ -912,6 +978,18 @@ func TestClient_withResponse(t *testing.T) {
 			},
 			wantError: true,
 		},
+		{
+			name: "DescribeReplicationStatus fail",
+			op: func(c Client) (any, error) {
+				return c.DescribeReplicationStatus(context.Background(), &types.DescribeReplicationStatusRequest{})
+			},
+			mock: func(p *MockPeerResolver, c *MockClient) {
+				p.EXPECT().GetAllPeers().Return([]string{"test-peer"}, nil).Times(1)
+				c.EXPECT().DescribeReplicationStatus(gomock.Any(), gomock.Any(), []yarpc.CallOption{yarpc.WithShardKey("test-peer")}).
+					Return(nil, fmt.Errorf("DescribeReplicationStatus failed")).Times(1)
+			},
+			wantError: true,
+		},
 		{
 			name: "QueryWorkflow fail",
 			op: func(c Client) (any, error) {
@@ -98,6 +98,26 @@ func (mr *MockClientMockRecorder) CountDLQMessages(arg0, arg1 interface{}, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDLQMessages", reflect.TypeOf((*MockClient)(nil).CountDLQMessages), varargs...)
 }
 
+// DeleteWorkflowExecution mocks base method.
+func (m *MockClient) DeleteWorkflowExecution(arg0 context.Context, arg1 *types.HistoryDeleteWorkflowExecutionRequest, arg2 ...yarpc.CallOption) (*types.DeleteWorkflowExecutionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteWorkflowExecution", varargs...)
+	ret0, _ := ret[0].(*types.DeleteWorkflowExecutionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWorkflowExecution indicates an expected call of DeleteWorkflowExecution.
+func (mr *MockClientMockRecorder) DeleteWorkflowExecution(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkflowExecution", reflect.TypeOf((*MockClient)(nil).DeleteWorkflowExecution), varargs...)
+}
+
 // DescribeHistoryHost mocks base method.
 func (m *MockClient) DescribeHistoryHost(arg0 context.Context, arg1 *types.DescribeHistoryHostRequest, arg2 ...yarpc.CallOption) (*types.DescribeHistoryHostResponse, error) {
 	m.ctrl.T.Helper()
@@ -158,6 +178,26 @@ func (mr *MockClientMockRecorder) DescribeQueue(arg0, arg1 interface{}, arg2 ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeQueue", reflect.TypeOf((*MockClient)(nil).DescribeQueue), varargs...)
 }
 
+// DescribeReplicationStatus mocks base method.
+func (m *MockClient) DescribeReplicationStatus(arg0 context.Context, arg1 *types.DescribeReplicationStatusRequest, arg2 ...yarpc.CallOption) (*types.DescribeReplicationStatusResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeReplicationStatus", varargs...)
+	ret0, _ := ret[0].(*types.DescribeReplicationStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeReplicationStatus indicates an expected call of DescribeReplicationStatus.
+func (mr *MockClientMockRecorder) DescribeReplicationStatus(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeReplicationStatus", reflect.TypeOf((*MockClient)(nil).DescribeReplicationStatus), varargs...)
+}
+
 // DescribeWorkflowExecution mocks base method.
 func (m *MockClient) DescribeWorkflowExecution(arg0 context.Context, arg1 *types.HistoryDescribeWorkflowExecutionRequest, arg2 ...yarpc.CallOption) (*types.DescribeWorkflowExecutionResponse, error) {
 	m.ctrl.T.Helper()
@@ -178,6 +218,25 @@ func (mr *MockClientMockRecorder) DescribeWorkflowExecution(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeWorkflowExecution", reflect.TypeOf((*MockClient)(nil).DescribeWorkflowExecution), varargs...)
 }
 
+// ExecuteQueueTask mocks base method.
+func (m *MockClient) ExecuteQueueTask(arg0 context.Context, arg1 *types.ExecuteQueueTaskRequest, arg2 ...yarpc.CallOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExecuteQueueTask", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExecuteQueueTask indicates an expected call of ExecuteQueueTask.
+func (mr *MockClientMockRecorder) ExecuteQueueTask(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteQueueTask", reflect.TypeOf((*MockClient)(nil).ExecuteQueueTask), varargs...)
+}
+
 // GetCrossClusterTasks mocks base method.
 func (m *MockClient) GetCrossClusterTasks(arg0 context.Context, arg1 *types.GetCrossClusterTasksRequest, arg2 ...yarpc.CallOption) (*types.GetCrossClusterTasksResponse, error) {
 	m.ctrl.T.Helper()
@@ -278,6 +337,26 @@ func (mr *MockClientMockRecorder) GetReplicationMessages(arg0, arg1 interface{},
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplicationMessages", reflect.TypeOf((*MockClient)(nil).GetReplicationMessages), varargs...)
 }
 
+// ListQueueTasks mocks base method.
+func (m *MockClient) ListQueueTasks(arg0 context.Context, arg1 *types.ListQueueTasksRequest, arg2 ...yarpc.CallOption) (*types.ListQueueTasksResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListQueueTasks", varargs...)
+	ret0, _ := ret[0].(*types.ListQueueTasksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListQueueTasks indicates an expected call of ListQueueTasks.
+func (mr *MockClientMockRecorder) ListQueueTasks(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQueueTasks", reflect.TypeOf((*MockClient)(nil).ListQueueTasks), varargs...)
+}
+
 // MergeDLQMessages mocks base method.
 func (m *MockClient) MergeDLQMessages(arg0 context.Context, arg1 *types.MergeDLQMessagesRequest, arg2 ...yarpc.CallOption) (*types.MergeDLQMessagesResponse, error) {
 	m.ctrl.T.Helper()
@@ -298,6 +377,26 @@ func (mr *MockClientMockRecorder) MergeDLQMessages(arg0, arg1 interface{}, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeDLQMessages", reflect.TypeOf((*MockClient)(nil).MergeDLQMessages), varargs...)
 }
 
+// MigrateShardExecutions mocks base method.
+func (m *MockClient) MigrateShardExecutions(arg0 context.Context, arg1 *types.MigrateShardExecutionsRequest, arg2 ...yarpc.CallOption) (*types.MigrateShardExecutionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MigrateShardExecutions", varargs...)
+	ret0, _ := ret[0].(*types.MigrateShardExecutionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MigrateShardExecutions indicates an expected call of MigrateShardExecutions.
+func (mr *MockClientMockRecorder) MigrateShardExecutions(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateShardExecutions", reflect.TypeOf((*MockClient)(nil).MigrateShardExecutions), varargs...)
+}
+
 // NotifyFailoverMarkers mocks base method.
 func (m *MockClient) NotifyFailoverMarkers(arg0 context.Context, arg1 *types.NotifyFailoverMarkersRequest, arg2 ...yarpc.CallOption) error {
 	m.ctrl.T.Helper()
@@ -898,3 +997,42 @@ func (mr *MockClientMockRecorder) TerminateWorkflowExecution(arg0, arg1 interfac
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerminateWorkflowExecution", reflect.TypeOf((*MockClient)(nil).TerminateWorkflowExecution), varargs...)
 }
+
+// UpdateActivityOptions mocks base method.
+func (m *MockClient) UpdateActivityOptions(arg0 context.Context, arg1 *types.HistoryUpdateActivityOptionsRequest, arg2 ...yarpc.CallOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateActivityOptions", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateActivityOptions indicates an expected call of UpdateActivityOptions.
+func (mr *MockClientMockRecorder) UpdateActivityOptions(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActivityOptions", reflect.TypeOf((*MockClient)(nil).UpdateActivityOptions), varargs...)
+}
+
+// VerifyWorkflowExecution mocks base method.
+func (m *MockClient) VerifyWorkflowExecution(arg0 context.Context, arg1 *types.VerifyWorkflowExecutionRequest, arg2 ...yarpc.CallOption) (*types.VerifyWorkflowExecutionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "VerifyWorkflowExecution", varargs...)
+	ret0, _ := ret[0].(*types.VerifyWorkflowExecutionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyWorkflowExecution indicates an expected call of VerifyWorkflowExecution.
+func (mr *MockClientMockRecorder) VerifyWorkflowExecution(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyWorkflowExecution", reflect.TypeOf((*MockClient)(nil).VerifyWorkflowExecution), varargs...)
+}
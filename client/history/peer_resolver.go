@@ -39,16 +39,25 @@ type PeerResolver interface {
 	GetAllPeers() ([]string, error)
 }
 
+// ShardMappingFn returns the current history shard mapping
+type ShardMappingFn func() *common.HistoryShardMapping
+
 type peerResolver struct {
-	numberOfShards int
+	shardMappingFn ShardMappingFn
 	resolver       membership.Resolver
 	namedPort      string // grpc or tchannel, depends on yarpc configuration
 }
 
-// NewPeerResolver creates a new history peer resolver.
+// NewPeerResolver creates a new history peer resolver for a fixed number of shards.
 func NewPeerResolver(numberOfShards int, resolver membership.Resolver, namedPort string) PeerResolver {
+	mapping := common.NewHistoryShardMapping(numberOfShards)
+	return NewPeerResolverWithShardMapping(func() *common.HistoryShardMapping { return mapping }, resolver, namedPort)
+}
+
+// NewPeerResolverWithShardMapping creates a new history peer resolver which follows history shard splits.
+func NewPeerResolverWithShardMapping(shardMappingFn ShardMappingFn, resolver membership.Resolver, namedPort string) PeerResolver {
 	return peerResolver{
-		numberOfShards: numberOfShards,
+		shardMappingFn: shardMappingFn,
 		resolver:       resolver,
 		namedPort:      namedPort,
 	}
@@ -58,7 +67,7 @@ func NewPeerResolver(numberOfShards int, resolver membership.Resolver, namedPort
 // WorkflowID is converted to logical shardID using a consistent hash function.
 // FromShardID is used for further resolving.
 func (pr peerResolver) FromWorkflowID(workflowID string) (string, error) {
-	shardID := common.WorkflowIDToHistoryShard(workflowID, pr.numberOfShards)
+	shardID := pr.shardMappingFn().WorkflowIDToShard(workflowID)
 	return pr.FromShardID(shardID)
 }
 
@@ -66,7 +75,7 @@ func (pr peerResolver) FromWorkflowID(workflowID string) (string, error) {
 // DomainID is converted to logical shardID using a consistent hash function.
 // FromShardID is used for further resolving.
 func (pr peerResolver) FromDomainID(domainID string) (string, error) {
-	shardID := common.DomainIDToHistoryShard(domainID, pr.numberOfShards)
+	shardID := pr.shardMappingFn().DomainIDToShard(domainID)
 	return pr.FromShardID(shardID)
 }
 
@@ -45,6 +45,11 @@ func (g historyClient) CountDLQMessages(ctx context.Context, cp1 *types.CountDLQ
 	return proto.ToHistoryCountDLQMessagesResponse(response), proto.ToError(err)
 }
 
+func (g historyClient) DeleteWorkflowExecution(ctx context.Context, hp1 *types.HistoryDeleteWorkflowExecutionRequest, pp1 ...yarpc.CallOption) (dp1 *types.DeleteWorkflowExecutionResponse, err error) {
+	response, err := g.c.DeleteWorkflowExecution(ctx, proto.FromHistoryDeleteWorkflowExecutionRequest(hp1), pp1...)
+	return proto.ToHistoryDeleteWorkflowExecutionResponse(response), proto.ToError(err)
+}
+
 func (g historyClient) DescribeHistoryHost(ctx context.Context, dp1 *types.DescribeHistoryHostRequest, p1 ...yarpc.CallOption) (dp2 *types.DescribeHistoryHostResponse, err error) {
 	response, err := g.c.DescribeHistoryHost(ctx, proto.FromHistoryDescribeHistoryHostRequest(dp1), p1...)
 	return proto.ToHistoryDescribeHistoryHostResponse(response), proto.ToError(err)
@@ -60,11 +65,21 @@ func (g historyClient) DescribeQueue(ctx context.Context, dp1 *types.DescribeQue
 	return proto.ToHistoryDescribeQueueResponse(response), proto.ToError(err)
 }
 
+func (g historyClient) DescribeReplicationStatus(ctx context.Context, dp1 *types.DescribeReplicationStatusRequest, pp1 ...yarpc.CallOption) (dp2 *types.DescribeReplicationStatusResponse, err error) {
+	response, err := g.c.DescribeReplicationStatus(ctx, proto.FromHistoryDescribeReplicationStatusRequest(dp1), pp1...)
+	return proto.ToHistoryDescribeReplicationStatusResponse(response), proto.ToError(err)
+}
+
 func (g historyClient) DescribeWorkflowExecution(ctx context.Context, hp1 *types.HistoryDescribeWorkflowExecutionRequest, p1 ...yarpc.CallOption) (dp1 *types.DescribeWorkflowExecutionResponse, err error) {
 	response, err := g.c.DescribeWorkflowExecution(ctx, proto.FromHistoryDescribeWorkflowExecutionRequest(hp1), p1...)
 	return proto.ToHistoryDescribeWorkflowExecutionResponse(response), proto.ToError(err)
 }
 
+func (g historyClient) ExecuteQueueTask(ctx context.Context, ep1 *types.ExecuteQueueTaskRequest, pp1 ...yarpc.CallOption) (err error) {
+	_, err = g.c.ExecuteQueueTask(ctx, proto.FromHistoryExecuteQueueTaskRequest(ep1), pp1...)
+	return proto.ToError(err)
+}
+
 func (g historyClient) GetCrossClusterTasks(ctx context.Context, gp1 *types.GetCrossClusterTasksRequest, p1 ...yarpc.CallOption) (gp2 *types.GetCrossClusterTasksResponse, err error) {
 	response, err := g.c.GetCrossClusterTasks(ctx, proto.FromHistoryGetCrossClusterTasksRequest(gp1), p1...)
 	return proto.ToHistoryGetCrossClusterTasksResponse(response), proto.ToError(err)
@@ -90,11 +105,21 @@ func (g historyClient) GetReplicationMessages(ctx context.Context, gp1 *types.Ge
 	return proto.ToHistoryGetReplicationMessagesResponse(response), proto.ToError(err)
 }
 
+func (g historyClient) ListQueueTasks(ctx context.Context, lp1 *types.ListQueueTasksRequest, pp1 ...yarpc.CallOption) (lp2 *types.ListQueueTasksResponse, err error) {
+	response, err := g.c.ListQueueTasks(ctx, proto.FromHistoryListQueueTasksRequest(lp1), pp1...)
+	return proto.ToHistoryListQueueTasksResponse(response), proto.ToError(err)
+}
+
 func (g historyClient) MergeDLQMessages(ctx context.Context, mp1 *types.MergeDLQMessagesRequest, p1 ...yarpc.CallOption) (mp2 *types.MergeDLQMessagesResponse, err error) {
 	response, err := g.c.MergeDLQMessages(ctx, proto.FromHistoryMergeDLQMessagesRequest(mp1), p1...)
 	return proto.ToHistoryMergeDLQMessagesResponse(response), proto.ToError(err)
 }
 
+func (g historyClient) MigrateShardExecutions(ctx context.Context, mp1 *types.MigrateShardExecutionsRequest, pp1 ...yarpc.CallOption) (mp2 *types.MigrateShardExecutionsResponse, err error) {
+	response, err := g.c.MigrateShardExecutions(ctx, proto.FromHistoryMigrateShardExecutionsRequest(mp1), pp1...)
+	return proto.ToHistoryMigrateShardExecutionsResponse(response), proto.ToError(err)
+}
+
 func (g historyClient) NotifyFailoverMarkers(ctx context.Context, np1 *types.NotifyFailoverMarkersRequest, p1 ...yarpc.CallOption) (err error) {
 	_, err = g.c.NotifyFailoverMarkers(ctx, proto.FromHistoryNotifyFailoverMarkersRequest(np1), p1...)
 	return proto.ToError(err)
@@ -249,3 +274,13 @@ func (g historyClient) TerminateWorkflowExecution(ctx context.Context, hp1 *type
 	_, err = g.c.TerminateWorkflowExecution(ctx, proto.FromHistoryTerminateWorkflowExecutionRequest(hp1), p1...)
 	return proto.ToError(err)
 }
+
+func (g historyClient) UpdateActivityOptions(ctx context.Context, hp1 *types.HistoryUpdateActivityOptionsRequest, pp1 ...yarpc.CallOption) (err error) {
+	_, err = g.c.UpdateActivityOptions(ctx, proto.FromHistoryUpdateActivityOptionsRequest(hp1), pp1...)
+	return proto.ToError(err)
+}
+
+func (g historyClient) VerifyWorkflowExecution(ctx context.Context, vp1 *types.VerifyWorkflowExecutionRequest, pp1 ...yarpc.CallOption) (vp2 *types.VerifyWorkflowExecutionResponse, err error) {
+	response, err := g.c.VerifyWorkflowExecution(ctx, proto.FromHistoryVerifyWorkflowExecutionRequest(vp1), pp1...)
+	return proto.ToHistoryVerifyWorkflowExecutionResponse(response), proto.ToError(err)
+}
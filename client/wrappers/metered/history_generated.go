@@ -76,6 +76,19 @@ func (c *historyClient) CountDLQMessages(ctx context.Context, cp1 *types.CountDL
 	return hp1, err
 }
 
+func (c *historyClient) DeleteWorkflowExecution(ctx context.Context, hp1 *types.HistoryDeleteWorkflowExecutionRequest, pp1 ...yarpc.CallOption) (dp1 *types.DeleteWorkflowExecutionResponse, err error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientDeleteWorkflowExecutionScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientDeleteWorkflowExecutionScope, metrics.CadenceClientLatency)
+	dp1, err = c.client.DeleteWorkflowExecution(ctx, hp1, pp1...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientDeleteWorkflowExecutionScope, metrics.CadenceClientFailures)
+	}
+	return dp1, err
+}
+
 func (c *historyClient) DescribeHistoryHost(ctx context.Context, dp1 *types.DescribeHistoryHostRequest, p1 ...yarpc.CallOption) (dp2 *types.DescribeHistoryHostResponse, err error) {
 	c.metricsClient.IncCounter(metrics.HistoryClientDescribeHistoryHostScope, metrics.CadenceClientRequests)
 
@@ -115,6 +128,19 @@ func (c *historyClient) DescribeQueue(ctx context.Context, dp1 *types.DescribeQu
 	return dp2, err
 }
 
+func (c *historyClient) DescribeReplicationStatus(ctx context.Context, dp1 *types.DescribeReplicationStatusRequest, pp1 ...yarpc.CallOption) (dp2 *types.DescribeReplicationStatusResponse, err error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientDescribeReplicationStatusScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientDescribeReplicationStatusScope, metrics.CadenceClientLatency)
+	dp2, err = c.client.DescribeReplicationStatus(ctx, dp1, pp1...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientDescribeReplicationStatusScope, metrics.CadenceClientFailures)
+	}
+	return dp2, err
+}
+
 func (c *historyClient) DescribeWorkflowExecution(ctx context.Context, hp1 *types.HistoryDescribeWorkflowExecutionRequest, p1 ...yarpc.CallOption) (dp1 *types.DescribeWorkflowExecutionResponse, err error) {
 	c.metricsClient.IncCounter(metrics.HistoryClientDescribeWorkflowExecutionScope, metrics.CadenceClientRequests)
 
@@ -128,6 +154,19 @@ func (c *historyClient) DescribeWorkflowExecution(ctx context.Context, hp1 *type
 	return dp1, err
 }
 
+func (c *historyClient) ExecuteQueueTask(ctx context.Context, ep1 *types.ExecuteQueueTaskRequest, pp1 ...yarpc.CallOption) (err error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientExecuteQueueTaskScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientExecuteQueueTaskScope, metrics.CadenceClientLatency)
+	err = c.client.ExecuteQueueTask(ctx, ep1, pp1...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientExecuteQueueTaskScope, metrics.CadenceClientFailures)
+	}
+	return err
+}
+
 func (c *historyClient) GetCrossClusterTasks(ctx context.Context, gp1 *types.GetCrossClusterTasksRequest, p1 ...yarpc.CallOption) (gp2 *types.GetCrossClusterTasksResponse, err error) {
 	c.metricsClient.IncCounter(metrics.HistoryClientGetCrossClusterTasksScope, metrics.CadenceClientRequests)
 
@@ -193,6 +232,19 @@ func (c *historyClient) GetReplicationMessages(ctx context.Context, gp1 *types.G
 	return gp2, err
 }
 
+func (c *historyClient) ListQueueTasks(ctx context.Context, lp1 *types.ListQueueTasksRequest, pp1 ...yarpc.CallOption) (lp2 *types.ListQueueTasksResponse, err error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientListQueueTasksScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientListQueueTasksScope, metrics.CadenceClientLatency)
+	lp2, err = c.client.ListQueueTasks(ctx, lp1, pp1...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientListQueueTasksScope, metrics.CadenceClientFailures)
+	}
+	return lp2, err
+}
+
 func (c *historyClient) MergeDLQMessages(ctx context.Context, mp1 *types.MergeDLQMessagesRequest, p1 ...yarpc.CallOption) (mp2 *types.MergeDLQMessagesResponse, err error) {
 	c.metricsClient.IncCounter(metrics.HistoryClientMergeDLQMessagesScope, metrics.CadenceClientRequests)
 
@@ -206,6 +258,19 @@ func (c *historyClient) MergeDLQMessages(ctx context.Context, mp1 *types.MergeDL
 	return mp2, err
 }
 
+func (c *historyClient) MigrateShardExecutions(ctx context.Context, mp1 *types.MigrateShardExecutionsRequest, pp1 ...yarpc.CallOption) (mp2 *types.MigrateShardExecutionsResponse, err error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientMigrateShardExecutionsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientMigrateShardExecutionsScope, metrics.CadenceClientLatency)
+	mp2, err = c.client.MigrateShardExecutions(ctx, mp1, pp1...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientMigrateShardExecutionsScope, metrics.CadenceClientFailures)
+	}
+	return mp2, err
+}
+
 func (c *historyClient) NotifyFailoverMarkers(ctx context.Context, np1 *types.NotifyFailoverMarkersRequest, p1 ...yarpc.CallOption) (err error) {
 	c.metricsClient.IncCounter(metrics.HistoryClientNotifyFailoverMarkersScope, metrics.CadenceClientRequests)
 
@@ -608,3 +673,29 @@ func (c *historyClient) TerminateWorkflowExecution(ctx context.Context, hp1 *typ
 	}
 	return err
 }
+
+func (c *historyClient) UpdateActivityOptions(ctx context.Context, hp1 *types.HistoryUpdateActivityOptionsRequest, pp1 ...yarpc.CallOption) (err error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientUpdateActivityOptionsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientUpdateActivityOptionsScope, metrics.CadenceClientLatency)
+	err = c.client.UpdateActivityOptions(ctx, hp1, pp1...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientUpdateActivityOptionsScope, metrics.CadenceClientFailures)
+	}
+	return err
+}
+
+func (c *historyClient) VerifyWorkflowExecution(ctx context.Context, vp1 *types.VerifyWorkflowExecutionRequest, pp1 ...yarpc.CallOption) (vp2 *types.VerifyWorkflowExecutionResponse, err error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientVerifyWorkflowExecutionScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientVerifyWorkflowExecutionScope, metrics.CadenceClientLatency)
+	vp2, err = c.client.VerifyWorkflowExecution(ctx, vp1, pp1...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientVerifyWorkflowExecutionScope, metrics.CadenceClientFailures)
+	}
+	return vp2, err
+}
@@ -71,6 +71,17 @@ func (c *historyClient) CountDLQMessages(ctx context.Context, cp1 *types.CountDL
 	return resp, err
 }
 
+func (c *historyClient) DeleteWorkflowExecution(ctx context.Context, hp1 *types.HistoryDeleteWorkflowExecutionRequest, pp1 ...yarpc.CallOption) (dp1 *types.DeleteWorkflowExecutionResponse, err error) {
+	var resp *types.DeleteWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DeleteWorkflowExecution(ctx, hp1, pp1...)
+		return err
+	}
+	err = c.throttleRetry.Do(ctx, op)
+	return resp, err
+}
+
 func (c *historyClient) DescribeHistoryHost(ctx context.Context, dp1 *types.DescribeHistoryHostRequest, p1 ...yarpc.CallOption) (dp2 *types.DescribeHistoryHostResponse, err error) {
 	var resp *types.DescribeHistoryHostResponse
 	op := func() error {
@@ -104,6 +115,17 @@ func (c *historyClient) DescribeQueue(ctx context.Context, dp1 *types.DescribeQu
 	return resp, err
 }
 
+func (c *historyClient) DescribeReplicationStatus(ctx context.Context, dp1 *types.DescribeReplicationStatusRequest, pp1 ...yarpc.CallOption) (dp2 *types.DescribeReplicationStatusResponse, err error) {
+	var resp *types.DescribeReplicationStatusResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeReplicationStatus(ctx, dp1, pp1...)
+		return err
+	}
+	err = c.throttleRetry.Do(ctx, op)
+	return resp, err
+}
+
 func (c *historyClient) DescribeWorkflowExecution(ctx context.Context, hp1 *types.HistoryDescribeWorkflowExecutionRequest, p1 ...yarpc.CallOption) (dp1 *types.DescribeWorkflowExecutionResponse, err error) {
 	var resp *types.DescribeWorkflowExecutionResponse
 	op := func() error {
@@ -115,6 +137,13 @@ func (c *historyClient) DescribeWorkflowExecution(ctx context.Context, hp1 *type
 	return resp, err
 }
 
+func (c *historyClient) ExecuteQueueTask(ctx context.Context, ep1 *types.ExecuteQueueTaskRequest, pp1 ...yarpc.CallOption) (err error) {
+	op := func() error {
+		return c.client.ExecuteQueueTask(ctx, ep1, pp1...)
+	}
+	return c.throttleRetry.Do(ctx, op)
+}
+
 func (c *historyClient) GetCrossClusterTasks(ctx context.Context, gp1 *types.GetCrossClusterTasksRequest, p1 ...yarpc.CallOption) (gp2 *types.GetCrossClusterTasksResponse, err error) {
 	var resp *types.GetCrossClusterTasksResponse
 	op := func() error {
@@ -170,6 +199,17 @@ func (c *historyClient) GetReplicationMessages(ctx context.Context, gp1 *types.G
 	return resp, err
 }
 
+func (c *historyClient) ListQueueTasks(ctx context.Context, lp1 *types.ListQueueTasksRequest, pp1 ...yarpc.CallOption) (lp2 *types.ListQueueTasksResponse, err error) {
+	var resp *types.ListQueueTasksResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ListQueueTasks(ctx, lp1, pp1...)
+		return err
+	}
+	err = c.throttleRetry.Do(ctx, op)
+	return resp, err
+}
+
 func (c *historyClient) MergeDLQMessages(ctx context.Context, mp1 *types.MergeDLQMessagesRequest, p1 ...yarpc.CallOption) (mp2 *types.MergeDLQMessagesResponse, err error) {
 	var resp *types.MergeDLQMessagesResponse
 	op := func() error {
@@ -181,6 +221,17 @@ func (c *historyClient) MergeDLQMessages(ctx context.Context, mp1 *types.MergeDL
 	return resp, err
 }
 
+func (c *historyClient) MigrateShardExecutions(ctx context.Context, mp1 *types.MigrateShardExecutionsRequest, pp1 ...yarpc.CallOption) (mp2 *types.MigrateShardExecutionsResponse, err error) {
+	var resp *types.MigrateShardExecutionsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.MigrateShardExecutions(ctx, mp1, pp1...)
+		return err
+	}
+	err = c.throttleRetry.Do(ctx, op)
+	return resp, err
+}
+
 func (c *historyClient) NotifyFailoverMarkers(ctx context.Context, np1 *types.NotifyFailoverMarkersRequest, p1 ...yarpc.CallOption) (err error) {
 	op := func() error {
 		return c.client.NotifyFailoverMarkers(ctx, np1, p1...)
@@ -445,3 +496,21 @@ func (c *historyClient) TerminateWorkflowExecution(ctx context.Context, hp1 *typ
 	}
 	return c.throttleRetry.Do(ctx, op)
 }
+
+func (c *historyClient) UpdateActivityOptions(ctx context.Context, hp1 *types.HistoryUpdateActivityOptionsRequest, pp1 ...yarpc.CallOption) (err error) {
+	op := func() error {
+		return c.client.UpdateActivityOptions(ctx, hp1, pp1...)
+	}
+	return c.throttleRetry.Do(ctx, op)
+}
+
+func (c *historyClient) VerifyWorkflowExecution(ctx context.Context, vp1 *types.VerifyWorkflowExecutionRequest, pp1 ...yarpc.CallOption) (vp2 *types.VerifyWorkflowExecutionResponse, err error) {
+	var resp *types.VerifyWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.VerifyWorkflowExecution(ctx, vp1, pp1...)
+		return err
+	}
+	err = c.throttleRetry.Do(ctx, op)
+	return resp, err
+}
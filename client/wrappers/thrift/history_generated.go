@@ -44,6 +44,11 @@ func (g historyClient) CountDLQMessages(ctx context.Context, cp1 *types.CountDLQ
 	return nil, thrift.ToError(&types.BadRequestError{Message: "Feature not supported on TChannel"})
 }
 
+func (g historyClient) DeleteWorkflowExecution(ctx context.Context, hp1 *types.HistoryDeleteWorkflowExecutionRequest, pp1 ...yarpc.CallOption) (dp1 *types.DeleteWorkflowExecutionResponse, err error) {
+	response, err := g.c.DeleteWorkflowExecution(ctx, thrift.FromHistoryDeleteWorkflowExecutionRequest(hp1), pp1...)
+	return thrift.ToHistoryDeleteWorkflowExecutionResponse(response), thrift.ToError(err)
+}
+
 func (g historyClient) DescribeHistoryHost(ctx context.Context, dp1 *types.DescribeHistoryHostRequest, p1 ...yarpc.CallOption) (dp2 *types.DescribeHistoryHostResponse, err error) {
 	response, err := g.c.DescribeHistoryHost(ctx, thrift.FromHistoryDescribeHistoryHostRequest(dp1), p1...)
 	return thrift.ToHistoryDescribeHistoryHostResponse(response), thrift.ToError(err)
@@ -59,11 +64,21 @@ func (g historyClient) DescribeQueue(ctx context.Context, dp1 *types.DescribeQue
 	return thrift.ToHistoryDescribeQueueResponse(response), thrift.ToError(err)
 }
 
+func (g historyClient) DescribeReplicationStatus(ctx context.Context, dp1 *types.DescribeReplicationStatusRequest, pp1 ...yarpc.CallOption) (dp2 *types.DescribeReplicationStatusResponse, err error) {
+	response, err := g.c.DescribeReplicationStatus(ctx, thrift.FromHistoryDescribeReplicationStatusRequest(dp1), pp1...)
+	return thrift.ToHistoryDescribeReplicationStatusResponse(response), thrift.ToError(err)
+}
+
 func (g historyClient) DescribeWorkflowExecution(ctx context.Context, hp1 *types.HistoryDescribeWorkflowExecutionRequest, p1 ...yarpc.CallOption) (dp1 *types.DescribeWorkflowExecutionResponse, err error) {
 	response, err := g.c.DescribeWorkflowExecution(ctx, thrift.FromHistoryDescribeWorkflowExecutionRequest(hp1), p1...)
 	return thrift.ToHistoryDescribeWorkflowExecutionResponse(response), thrift.ToError(err)
 }
 
+func (g historyClient) ExecuteQueueTask(ctx context.Context, ep1 *types.ExecuteQueueTaskRequest, pp1 ...yarpc.CallOption) (err error) {
+	err = g.c.ExecuteQueueTask(ctx, thrift.FromHistoryExecuteQueueTaskRequest(ep1), pp1...)
+	return thrift.ToError(err)
+}
+
 func (g historyClient) GetCrossClusterTasks(ctx context.Context, gp1 *types.GetCrossClusterTasksRequest, p1 ...yarpc.CallOption) (gp2 *types.GetCrossClusterTasksResponse, err error) {
 	response, err := g.c.GetCrossClusterTasks(ctx, thrift.FromHistoryGetCrossClusterTasksRequest(gp1), p1...)
 	return thrift.ToHistoryGetCrossClusterTasksResponse(response), thrift.ToError(err)
@@ -89,11 +104,21 @@ func (g historyClient) GetReplicationMessages(ctx context.Context, gp1 *types.Ge
 	return thrift.ToHistoryGetReplicationMessagesResponse(response), thrift.ToError(err)
 }
 
+func (g historyClient) ListQueueTasks(ctx context.Context, lp1 *types.ListQueueTasksRequest, pp1 ...yarpc.CallOption) (lp2 *types.ListQueueTasksResponse, err error) {
+	response, err := g.c.ListQueueTasks(ctx, thrift.FromHistoryListQueueTasksRequest(lp1), pp1...)
+	return thrift.ToHistoryListQueueTasksResponse(response), thrift.ToError(err)
+}
+
 func (g historyClient) MergeDLQMessages(ctx context.Context, mp1 *types.MergeDLQMessagesRequest, p1 ...yarpc.CallOption) (mp2 *types.MergeDLQMessagesResponse, err error) {
 	response, err := g.c.MergeDLQMessages(ctx, thrift.FromHistoryMergeDLQMessagesRequest(mp1), p1...)
 	return thrift.ToHistoryMergeDLQMessagesResponse(response), thrift.ToError(err)
 }
 
+func (g historyClient) MigrateShardExecutions(ctx context.Context, mp1 *types.MigrateShardExecutionsRequest, pp1 ...yarpc.CallOption) (mp2 *types.MigrateShardExecutionsResponse, err error) {
+	response, err := g.c.MigrateShardExecutions(ctx, thrift.FromHistoryMigrateShardExecutionsRequest(mp1), pp1...)
+	return thrift.ToHistoryMigrateShardExecutionsResponse(response), thrift.ToError(err)
+}
+
 func (g historyClient) NotifyFailoverMarkers(ctx context.Context, np1 *types.NotifyFailoverMarkersRequest, p1 ...yarpc.CallOption) (err error) {
 	err = g.c.NotifyFailoverMarkers(ctx, thrift.FromHistoryNotifyFailoverMarkersRequest(np1), p1...)
 	return thrift.ToError(err)
@@ -248,3 +273,13 @@ func (g historyClient) TerminateWorkflowExecution(ctx context.Context, hp1 *type
 	err = g.c.TerminateWorkflowExecution(ctx, thrift.FromHistoryTerminateWorkflowExecutionRequest(hp1), p1...)
 	return thrift.ToError(err)
 }
+
+func (g historyClient) UpdateActivityOptions(ctx context.Context, hp1 *types.HistoryUpdateActivityOptionsRequest, pp1 ...yarpc.CallOption) (err error) {
+	err = g.c.UpdateActivityOptions(ctx, thrift.FromHistoryUpdateActivityOptionsRequest(hp1), pp1...)
+	return thrift.ToError(err)
+}
+
+func (g historyClient) VerifyWorkflowExecution(ctx context.Context, vp1 *types.VerifyWorkflowExecutionRequest, pp1 ...yarpc.CallOption) (vp2 *types.VerifyWorkflowExecutionResponse, err error) {
+	response, err := g.c.VerifyWorkflowExecution(ctx, thrift.FromHistoryVerifyWorkflowExecutionRequest(vp1), pp1...)
+	return thrift.ToHistoryVerifyWorkflowExecutionResponse(response), thrift.ToError(err)
+}
@@ -65,6 +65,12 @@ func (c *historyClient) CountDLQMessages(ctx context.Context, cp1 *types.CountDL
 	return c.client.CountDLQMessages(ctx, cp1, p1...)
 }
 
+func (c *historyClient) DeleteWorkflowExecution(ctx context.Context, hp1 *types.HistoryDeleteWorkflowExecutionRequest, pp1 ...yarpc.CallOption) (dp1 *types.DeleteWorkflowExecutionResponse, err error) {
+	ctx, cancel := createContext(ctx, c.timeout)
+	defer cancel()
+	return c.client.DeleteWorkflowExecution(ctx, hp1, pp1...)
+}
+
 func (c *historyClient) DescribeHistoryHost(ctx context.Context, dp1 *types.DescribeHistoryHostRequest, p1 ...yarpc.CallOption) (dp2 *types.DescribeHistoryHostResponse, err error) {
 	ctx, cancel := createContext(ctx, c.timeout)
 	defer cancel()
@@ -83,12 +89,24 @@ func (c *historyClient) DescribeQueue(ctx context.Context, dp1 *types.DescribeQu
 	return c.client.DescribeQueue(ctx, dp1, p1...)
 }
 
+func (c *historyClient) DescribeReplicationStatus(ctx context.Context, dp1 *types.DescribeReplicationStatusRequest, pp1 ...yarpc.CallOption) (dp2 *types.DescribeReplicationStatusResponse, err error) {
+	ctx, cancel := createContext(ctx, c.timeout)
+	defer cancel()
+	return c.client.DescribeReplicationStatus(ctx, dp1, pp1...)
+}
+
 func (c *historyClient) DescribeWorkflowExecution(ctx context.Context, hp1 *types.HistoryDescribeWorkflowExecutionRequest, p1 ...yarpc.CallOption) (dp1 *types.DescribeWorkflowExecutionResponse, err error) {
 	ctx, cancel := createContext(ctx, c.timeout)
 	defer cancel()
 	return c.client.DescribeWorkflowExecution(ctx, hp1, p1...)
 }
 
+func (c *historyClient) ExecuteQueueTask(ctx context.Context, ep1 *types.ExecuteQueueTaskRequest, pp1 ...yarpc.CallOption) (err error) {
+	ctx, cancel := createContext(ctx, c.timeout)
+	defer cancel()
+	return c.client.ExecuteQueueTask(ctx, ep1, pp1...)
+}
+
 func (c *historyClient) GetCrossClusterTasks(ctx context.Context, gp1 *types.GetCrossClusterTasksRequest, p1 ...yarpc.CallOption) (gp2 *types.GetCrossClusterTasksResponse, err error) {
 	return c.client.GetCrossClusterTasks(ctx, gp1, p1...)
 }
@@ -111,10 +129,20 @@ func (c *historyClient) GetReplicationMessages(ctx context.Context, gp1 *types.G
 	return c.client.GetReplicationMessages(ctx, gp1, p1...)
 }
 
+func (c *historyClient) ListQueueTasks(ctx context.Context, lp1 *types.ListQueueTasksRequest, pp1 ...yarpc.CallOption) (lp2 *types.ListQueueTasksResponse, err error) {
+	ctx, cancel := createContext(ctx, c.timeout)
+	defer cancel()
+	return c.client.ListQueueTasks(ctx, lp1, pp1...)
+}
+
 func (c *historyClient) MergeDLQMessages(ctx context.Context, mp1 *types.MergeDLQMessagesRequest, p1 ...yarpc.CallOption) (mp2 *types.MergeDLQMessagesResponse, err error) {
 	return c.client.MergeDLQMessages(ctx, mp1, p1...)
 }
 
+func (c *historyClient) MigrateShardExecutions(ctx context.Context, mp1 *types.MigrateShardExecutionsRequest, p1 ...yarpc.CallOption) (mp2 *types.MigrateShardExecutionsResponse, err error) {
+	return c.client.MigrateShardExecutions(ctx, mp1, p1...)
+}
+
 func (c *historyClient) NotifyFailoverMarkers(ctx context.Context, np1 *types.NotifyFailoverMarkersRequest, p1 ...yarpc.CallOption) (err error) {
 	ctx, cancel := createContext(ctx, c.timeout)
 	defer cancel()
@@ -296,3 +324,15 @@ func (c *historyClient) TerminateWorkflowExecution(ctx context.Context, hp1 *typ
 	defer cancel()
 	return c.client.TerminateWorkflowExecution(ctx, hp1, p1...)
 }
+
+func (c *historyClient) UpdateActivityOptions(ctx context.Context, hp1 *types.HistoryUpdateActivityOptionsRequest, pp1 ...yarpc.CallOption) (err error) {
+	ctx, cancel := createContext(ctx, c.timeout)
+	defer cancel()
+	return c.client.UpdateActivityOptions(ctx, hp1, pp1...)
+}
+
+func (c *historyClient) VerifyWorkflowExecution(ctx context.Context, vp1 *types.VerifyWorkflowExecutionRequest, pp1 ...yarpc.CallOption) (vp2 *types.VerifyWorkflowExecutionResponse, err error) {
+	ctx, cancel := createContext(ctx, c.timeout)
+	defer cancel()
+	return c.client.VerifyWorkflowExecution(ctx, vp1, pp1...)
+}
@@ -208,7 +208,7 @@ func (s *server) startService() common.Daemon {
 		dynamicconfig.AdvancedVisibilityWritingMode,
 	)()
 	isAdvancedVisEnabled := common.IsAdvancedVisibilityWritingEnabled(advancedVisMode, params.PersistenceConfig.IsAdvancedVisibilityConfigExist())
-	if isAdvancedVisEnabled {
+	if isAdvancedVisEnabled || isKafkaReplicationEnabled(clusterGroupMetadata) {
 		params.MessagingClient = kafka.NewKafkaClient(&s.cfg.Kafka, params.MetricsClient, params.Logger, params.MetricScope, isAdvancedVisEnabled)
 	} else {
 		params.MessagingClient = nil
@@ -318,3 +318,13 @@ func execute(d common.Daemon, doneC chan struct{}) {
 	d.Start()
 	close(doneC)
 }
+
+// isKafkaReplicationEnabled returns true if any cluster delivers replication tasks through kafka
+func isKafkaReplicationEnabled(clusterGroupMetadata *config.ClusterGroupMetadata) bool {
+	for _, clusterInfo := range clusterGroupMetadata.ClusterGroup {
+		if clusterInfo.ReplicationTransport == config.ReplicationTransportKafka {
+			return true
+		}
+	}
+	return false
+}
@@ -54,6 +54,23 @@ type Cache interface {
 
 	// Size returns the number of entries currently stored in the Cache
 	Size() int
+
+	// Stats returns the access and size statistics of the Cache
+	Stats() Stats
+}
+
+// Stats represents the access and size statistics of a cache
+type Stats struct {
+	// Count is the number of entries currently stored in the cache
+	Count int
+	// SizeInBytes is the total size of the entries, it's always 0 for count based caches
+	SizeInBytes uint64
+	// PinnedCount is the number of entries currently in use and can't be evicted
+	PinnedCount int
+	// Hits is the number of Get calls which found the entry since the cache is created
+	Hits int64
+	// Misses is the number of Get calls which didn't find the entry since the cache is created
+	Misses int64
 }
 
 // Options control the behavior of the cache
@@ -77,6 +94,8 @@ type Options struct {
 	MaxCount int
 
 	// GetCacheItemSizeFunc is a function called upon adding the item to update the cache size.
+	// In Pin mode, it's also called when the item is released, so that the size of
+	// mutable items can be updated after they are modified.
 	// It returns 0 by default, assuming the cache is just count based
 	// It is required option if MaxCount is not provided
 	GetCacheItemSizeFunc GetCacheItemSizeFunc
@@ -819,11 +819,128 @@ func (entry *DomainCacheEntry) IsActiveIn(currentCluster string) (bool, error) {
 		return false, errors.NewDomainPendingActiveError(domainName, currentCluster)
 	}
 
-	if currentCluster != activeCluster {
-		return false, errors.NewDomainNotActiveError(domainName, currentCluster, activeCluster)
+	// workflows of an active-active domain are active in the active clusters of all regions
+	if currentCluster == activeCluster || entry.IsActiveClusterOfRegion(currentCluster) {
+		return true, nil
+	}
+
+	return false, errors.NewDomainNotActiveError(domainName, currentCluster, activeCluster)
+}
+
+// IsActiveClusterOfRegion returns whether the cluster is the active cluster of a region of an active-active domain
+func (entry *DomainCacheEntry) IsActiveClusterOfRegion(clusterName string) bool {
+	for _, regionActiveCluster := range entry.GetActiveClustersByRegion() {
+		if clusterName == regionActiveCluster {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActiveActive returns whether the domain is an active-active domain, i.e. a global domain whose workflows
+// are active in the active cluster of the region selected when they are started
+func (entry *DomainCacheEntry) IsActiveActive() bool {
+	return entry.IsGlobalDomain() && len(entry.GetActiveClustersByRegion()) > 0
+}
+
+// GetActiveClustersByRegion returns the active cluster of each region of an active-active domain
+func (entry *DomainCacheEntry) GetActiveClustersByRegion() map[string]string {
+	return ActiveClustersByRegion(entry.GetInfo().Data)
+}
+
+// ActiveClustersByRegion returns the active cluster of each region from the domain data
+func ActiveClustersByRegion(data map[string]string) map[string]string {
+	var activeClusters map[string]string
+	for key, value := range data {
+		region := strings.TrimPrefix(key, common.DomainDataKeyPrefixForActiveCluster)
+		if region == key || region == "" || value == "" {
+			continue
+		}
+		if activeClusters == nil {
+			activeClusters = make(map[string]string)
+		}
+		activeClusters[region] = value
+	}
+	return activeClusters
+}
+
+// GetActiveRegionForWorkflow returns the region of a workflow of an active-active domain, which is the given region
+// if the domain has it, otherwise a region selected by the hash of the workflow ID
+func (entry *DomainCacheEntry) GetActiveRegionForWorkflow(
+	workflowID string,
+	region string,
+) string {
+
+	activeClusters := entry.GetActiveClustersByRegion()
+	if len(activeClusters) == 0 {
+		return ""
+	}
+	if _, ok := activeClusters[region]; ok {
+		return region
+	}
+
+	regions := make([]string, 0, len(activeClusters))
+	for region := range activeClusters {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(workflowID))
+	return regions[h.Sum32()%uint32(len(regions))]
+}
+
+// GetActiveClusterForWorkflow returns the cluster a workflow is active in, for domains which are not active-active
+// it is always the active cluster of the domain
+func (entry *DomainCacheEntry) GetActiveClusterForWorkflow(
+	workflowID string,
+	region string,
+) string {
+
+	if !entry.IsActiveActive() {
+		return entry.GetReplicationConfig().ActiveClusterName
+	}
+	return entry.GetActiveClustersByRegion()[entry.GetActiveRegionForWorkflow(workflowID, region)]
+}
+
+// GetFailoverVersionForCluster returns the failover version used by the workflows active in the given cluster,
+// for domains which are not active-active it is always the failover version of the domain
+func (entry *DomainCacheEntry) GetFailoverVersionForCluster(
+	clusterMetadata cluster.Metadata,
+	clusterName string,
+) int64 {
+
+	if !entry.IsActiveActive() {
+		return entry.GetFailoverVersion()
+	}
+	return clusterMetadata.GetNextFailoverVersion(clusterName, entry.GetFailoverVersion(), entry.GetInfo().Name)
+}
+
+// IsLocalOnlyWorkflow returns whether the workflows of the given type or task list are local-only, i.e. their
+// replication tasks are not sent to the other clusters of the global domain
+func (entry *DomainCacheEntry) IsLocalOnlyWorkflow(
+	workflowType string,
+	taskList string,
+) bool {
+
+	if !entry.IsGlobalDomain() {
+		return false
 	}
+	data := entry.GetInfo().Data
+	return isDomainDataFlagSet(data, common.DomainDataKeyPrefixForLocalOnlyWorkflowType, workflowType) ||
+		isDomainDataFlagSet(data, common.DomainDataKeyPrefixForLocalOnlyTaskList, taskList)
+}
 
-	return true, nil
+func isDomainDataFlagSet(
+	data map[string]string,
+	keyPrefix string,
+	name string,
+) bool {
+
+	if name == "" {
+		return false
+	}
+	isSet, err := strconv.ParseBool(data[keyPrefix+name])
+	return err == nil && isSet
 }
 
 // IsDomainPendingActive returns whether the domain is in pending active state
@@ -294,6 +294,7 @@ func Test_IsActiveIn(t *testing.T) {
 		isGlobalDomain   bool
 		currentCluster   string
 		activeCluster    string
+		data             map[string]string
 		failoverDeadline *int64
 		expectIsActive   bool
 		expectedErr      error
@@ -323,11 +324,27 @@ func Test_IsActiveIn(t *testing.T) {
 			activeCluster:  "B",
 			expectedErr:    &types.DomainNotActiveError{Message: "Domain: test-domain is active in cluster: B, while current cluster A is a standby cluster.", DomainName: "test-domain", CurrentCluster: "A", ActiveCluster: "B"},
 		},
+		{
+			msg:            "active-active domain on region active cluster",
+			isGlobalDomain: true,
+			currentCluster: "A",
+			activeCluster:  "B",
+			data:           map[string]string{common.DomainDataKeyPrefixForActiveCluster + "region0": "A"},
+			expectIsActive: true,
+		},
+		{
+			msg:            "active-active domain on passive cluster",
+			isGlobalDomain: true,
+			currentCluster: "C",
+			activeCluster:  "B",
+			data:           map[string]string{common.DomainDataKeyPrefixForActiveCluster + "region0": "A"},
+			expectedErr:    &types.DomainNotActiveError{Message: "Domain: test-domain is active in cluster: B, while current cluster C is a standby cluster.", DomainName: "test-domain", CurrentCluster: "C", ActiveCluster: "B"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.msg, func(t *testing.T) {
 			domain := NewDomainCacheEntryForTest(
-				&persistence.DomainInfo{Name: "test-domain"},
+				&persistence.DomainInfo{Name: "test-domain", Data: tt.data},
 				nil,
 				tt.isGlobalDomain,
 				&persistence.DomainReplicationConfig{ActiveClusterName: tt.activeCluster},
@@ -343,6 +360,87 @@ func Test_IsActiveIn(t *testing.T) {
 	}
 }
 
+func Test_GetActiveClusterForWorkflow(t *testing.T) {
+	data := map[string]string{
+		"tier": "gold",
+		common.DomainDataKeyPrefixForActiveCluster + "region0": cluster.TestCurrentClusterName,
+		common.DomainDataKeyPrefixForActiveCluster + "region1": cluster.TestAlternativeClusterName,
+	}
+	activeActiveDomain := NewGlobalDomainCacheEntryForTest(
+		&persistence.DomainInfo{Name: "test-domain", Data: data},
+		nil,
+		&persistence.DomainReplicationConfig{ActiveClusterName: cluster.TestCurrentClusterName},
+		100,
+	)
+	globalDomain := NewGlobalDomainCacheEntryForTest(
+		&persistence.DomainInfo{Name: "test-domain", Data: map[string]string{"tier": "gold"}},
+		nil,
+		&persistence.DomainReplicationConfig{ActiveClusterName: cluster.TestCurrentClusterName},
+		100,
+	)
+	localDomain := NewLocalDomainCacheEntryForTest(
+		&persistence.DomainInfo{Name: "test-domain", Data: data},
+		nil,
+		cluster.TestCurrentClusterName,
+	)
+	clusterMetadata := cluster.GetTestClusterMetadata(true)
+
+	assert.True(t, activeActiveDomain.IsActiveActive())
+	assert.False(t, globalDomain.IsActiveActive())
+	assert.False(t, localDomain.IsActiveActive())
+	assert.Equal(t, map[string]string{
+		"region0": cluster.TestCurrentClusterName,
+		"region1": cluster.TestAlternativeClusterName,
+	}, activeActiveDomain.GetActiveClustersByRegion())
+
+	assert.Equal(t, "region1", activeActiveDomain.GetActiveRegionForWorkflow("wid", "region1"))
+	assert.Equal(t, cluster.TestAlternativeClusterName, activeActiveDomain.GetActiveClusterForWorkflow("wid", "region1"))
+	assert.Equal(t, cluster.TestCurrentClusterName, activeActiveDomain.GetActiveClusterForWorkflow("wid", "region0"))
+	assert.Equal(t, cluster.TestCurrentClusterName, globalDomain.GetActiveClusterForWorkflow("wid", "region1"))
+	assert.True(t, activeActiveDomain.IsActiveClusterOfRegion(cluster.TestAlternativeClusterName))
+	assert.False(t, activeActiveDomain.IsActiveClusterOfRegion("other-cluster"))
+	assert.False(t, globalDomain.IsActiveClusterOfRegion(cluster.TestCurrentClusterName))
+
+	// workflows without a known region are spread across the regions deterministically
+	selectedRegion := activeActiveDomain.GetActiveRegionForWorkflow("wid", "unknown-region")
+	assert.Contains(t, []string{"region0", "region1"}, selectedRegion)
+	assert.Equal(t, selectedRegion, activeActiveDomain.GetActiveRegionForWorkflow("wid", ""))
+
+	assert.Equal(t, int64(100), globalDomain.GetFailoverVersionForCluster(clusterMetadata, cluster.TestAlternativeClusterName))
+	alternativeVersion := activeActiveDomain.GetFailoverVersionForCluster(clusterMetadata, cluster.TestAlternativeClusterName)
+	assert.True(t, alternativeVersion >= 100)
+	alternativeCluster, err := clusterMetadata.ClusterNameForFailoverVersion(alternativeVersion)
+	assert.NoError(t, err)
+	assert.Equal(t, cluster.TestAlternativeClusterName, alternativeCluster)
+}
+
+func Test_IsLocalOnlyWorkflow(t *testing.T) {
+	data := map[string]string{
+		common.DomainDataKeyPrefixForLocalOnlyWorkflowType + "cleanup-workflow":   "true",
+		common.DomainDataKeyPrefixForLocalOnlyWorkflowType + "heartbeat-workflow": "true",
+		common.DomainDataKeyPrefixForLocalOnlyWorkflowType + "order-workflow":     "false",
+		common.DomainDataKeyPrefixForLocalOnlyTaskList + "internal-tl":            "true",
+	}
+	globalDomain := NewGlobalDomainCacheEntryForTest(
+		&persistence.DomainInfo{Name: "test-domain", Data: data},
+		nil,
+		&persistence.DomainReplicationConfig{ActiveClusterName: cluster.TestCurrentClusterName},
+		100,
+	)
+	localDomain := NewLocalDomainCacheEntryForTest(
+		&persistence.DomainInfo{Name: "test-domain", Data: data},
+		nil,
+		cluster.TestCurrentClusterName,
+	)
+
+	assert.True(t, globalDomain.IsLocalOnlyWorkflow("cleanup-workflow", "tl"))
+	assert.True(t, globalDomain.IsLocalOnlyWorkflow("heartbeat-workflow", "tl"))
+	assert.True(t, globalDomain.IsLocalOnlyWorkflow("order-workflow", "internal-tl"))
+	assert.False(t, globalDomain.IsLocalOnlyWorkflow("order-workflow", "tl"))
+	assert.False(t, globalDomain.IsLocalOnlyWorkflow("", ""))
+	assert.False(t, localDomain.IsLocalOnlyWorkflow("cleanup-workflow", "internal-tl"))
+}
+
 func (s *domainCacheSuite) TestRegisterCallback_CatchUp() {
 	domainNotificationVersion := int64(0)
 	domainRecord1 := &persistence.GetDomainResponse{
@@ -0,0 +1,161 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:generate mockgen -package $GOPACKAGE -source $GOFILE -destination historyShardMappingCache_mock.go -self_package github.com/uber/cadence/common/cache
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/persistence"
+)
+
+const (
+	// HistoryShardMappingRefreshInterval is the interval for reloading the history shard mapping
+	HistoryShardMappingRefreshInterval = 10 * time.Second
+
+	historyShardMappingPersistenceTimeout = 3 * time.Second
+)
+
+type (
+	// HistoryShardMappingCache keeps the latest history shard mapping in memory.
+	// The mapping only changes when a history shard is split, the cache reloads it
+	// periodically so that every service eventually routes requests with the same mapping.
+	HistoryShardMappingCache interface {
+		common.Daemon
+		GetHistoryShardMapping() *common.HistoryShardMapping
+		Refresh(ctx context.Context) error
+	}
+
+	historyShardMappingCache struct {
+		status             int32
+		shutdownChan       chan struct{}
+		numberOfShards     int
+		configStoreManager persistence.ConfigStoreManager
+		timeSource         clock.TimeSource
+		logger             log.Logger
+
+		mapping atomic.Value
+	}
+)
+
+// NewHistoryShardMappingCache creates a new history shard mapping cache,
+// the cache starts with the initial mapping derived from the configured number of shards
+func NewHistoryShardMappingCache(
+	numberOfShards int,
+	configStoreManager persistence.ConfigStoreManager,
+	timeSource clock.TimeSource,
+	logger log.Logger,
+) HistoryShardMappingCache {
+	cache := &historyShardMappingCache{
+		status:             common.DaemonStatusInitialized,
+		shutdownChan:       make(chan struct{}),
+		numberOfShards:     numberOfShards,
+		configStoreManager: configStoreManager,
+		timeSource:         timeSource,
+		logger:             logger,
+	}
+	cache.mapping.Store(common.NewHistoryShardMapping(numberOfShards))
+	return cache
+}
+
+// Start loads the history shard mapping and starts the background refresh
+func (c *historyShardMappingCache) Start() {
+	if !atomic.CompareAndSwapInt32(&c.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), historyShardMappingPersistenceTimeout)
+	defer cancel()
+	if err := c.Refresh(ctx); err != nil {
+		c.logger.Error("Unable to load history shard mapping", tag.Error(err))
+	}
+	go c.refreshLoop()
+}
+
+// Stop stops the background refresh
+func (c *historyShardMappingCache) Stop() {
+	if !atomic.CompareAndSwapInt32(&c.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+	close(c.shutdownChan)
+}
+
+// GetHistoryShardMapping returns the latest known history shard mapping
+func (c *historyShardMappingCache) GetHistoryShardMapping() *common.HistoryShardMapping {
+	return c.mapping.Load().(*common.HistoryShardMapping)
+}
+
+// Refresh reloads the history shard mapping from persistence
+func (c *historyShardMappingCache) Refresh(ctx context.Context) error {
+	resp, err := c.configStoreManager.FetchHistoryShardMapping(ctx)
+	if err != nil {
+		return err
+	}
+	if resp == nil || resp.Mapping == nil {
+		// shards have never been split
+		return nil
+	}
+
+	mapping := resp.Mapping
+	if mapping.NumberOfShards != c.numberOfShards {
+		return fmt.Errorf("history shard mapping has %v initial shards, but %v is configured", mapping.NumberOfShards, c.numberOfShards)
+	}
+	if err := mapping.Validate(); err != nil {
+		return err
+	}
+
+	current := c.GetHistoryShardMapping()
+	if mapping.Version <= current.Version {
+		return nil
+	}
+	c.mapping.Store(mapping)
+	c.logger.Info("History shard mapping updated",
+		tag.Number(mapping.Version),
+		tag.Counter(mapping.TotalShards()),
+	)
+	return nil
+}
+
+func (c *historyShardMappingCache) refreshLoop() {
+	ticker := c.timeSource.NewTicker(HistoryShardMappingRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.shutdownChan:
+			return
+		case <-ticker.Chan():
+			ctx, cancel := context.WithTimeout(context.Background(), historyShardMappingPersistenceTimeout)
+			if err := c.Refresh(ctx); err != nil {
+				c.logger.Error("Error refreshing history shard mapping", tag.Error(err))
+			}
+			cancel()
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/persistence"
+)
+
+func TestHistoryShardMappingCache_Refresh(t *testing.T) {
+	split, err := common.NewHistoryShardMapping(4).Split(2)
+	assert.NoError(t, err)
+	mismatched, err := common.NewHistoryShardMapping(8).Split(2)
+	assert.NoError(t, err)
+
+	tests := map[string]struct {
+		fetchResp       *persistence.FetchHistoryShardMappingResponse
+		fetchErr        error
+		expectedErr     bool
+		expectedMapping *common.HistoryShardMapping
+	}{
+		"no mapping persisted": {
+			fetchResp:       nil,
+			expectedMapping: common.NewHistoryShardMapping(4),
+		},
+		"new mapping version": {
+			fetchResp:       &persistence.FetchHistoryShardMappingResponse{Mapping: split},
+			expectedMapping: split,
+		},
+		"persistence error": {
+			fetchErr:        errors.New("some random error"),
+			expectedErr:     true,
+			expectedMapping: common.NewHistoryShardMapping(4),
+		},
+		"number of shards mismatch": {
+			fetchResp:       &persistence.FetchHistoryShardMappingResponse{Mapping: mismatched},
+			expectedErr:     true,
+			expectedMapping: common.NewHistoryShardMapping(4),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			configStoreManager := persistence.NewMockConfigStoreManager(ctrl)
+			configStoreManager.EXPECT().FetchHistoryShardMapping(gomock.Any()).Return(test.fetchResp, test.fetchErr).Times(1)
+
+			cache := NewHistoryShardMappingCache(4, configStoreManager, clock.NewMockedTimeSource(), testlogger.New(t))
+			err := cache.Refresh(context.Background())
+			if test.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedMapping, cache.GetHistoryShardMapping())
+		})
+	}
+}
+
+func TestHistoryShardMappingCache_IgnoreOlderVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	configStoreManager := persistence.NewMockConfigStoreManager(ctrl)
+
+	v1, err := common.NewHistoryShardMapping(4).Split(2)
+	assert.NoError(t, err)
+	v2, err := v1.FenceSplit()
+	assert.NoError(t, err)
+
+	gomock.InOrder(
+		configStoreManager.EXPECT().FetchHistoryShardMapping(gomock.Any()).Return(&persistence.FetchHistoryShardMappingResponse{Mapping: v2}, nil),
+		configStoreManager.EXPECT().FetchHistoryShardMapping(gomock.Any()).Return(&persistence.FetchHistoryShardMappingResponse{Mapping: v1}, nil),
+	)
+
+	cache := NewHistoryShardMappingCache(4, configStoreManager, clock.NewMockedTimeSource(), testlogger.New(t))
+	assert.NoError(t, cache.Refresh(context.Background()))
+	assert.NoError(t, cache.Refresh(context.Background()))
+	assert.Equal(t, v2, cache.GetHistoryShardMapping())
+}
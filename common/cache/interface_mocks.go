@@ -153,6 +153,20 @@ func (mr *MockCacheMockRecorder) Size() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Size", reflect.TypeOf((*MockCache)(nil).Size))
 }
 
+// Stats mocks base method.
+func (m *MockCache) Stats() Stats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats")
+	ret0, _ := ret[0].(Stats)
+	return ret0
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockCacheMockRecorder) Stats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockCache)(nil).Stats))
+}
+
 // MockIterator is a mock of Iterator interface.
 type MockIterator struct {
 	ctrl     *gomock.Controller
@@ -51,6 +51,8 @@ type (
 		sizeByKey     map[interface{}]uint64
 		isSizeBased   bool
 		activelyEvict bool
+		hits          int64
+		misses        int64
 		// We use this instead of time.Now() in order to make testing easier
 		now func() time.Time
 	}
@@ -178,6 +180,7 @@ func (c *lru) Get(key interface{}) interface{} {
 
 	element := c.byKey[key]
 	if element == nil {
+		c.misses++
 		return nil
 	}
 
@@ -186,6 +189,7 @@ func (c *lru) Get(key interface{}) interface{} {
 	if c.isEntryExpired(entry, c.now()) {
 		// Entry has expired
 		c.deleteInternal(element)
+		c.misses++
 		return nil
 	}
 
@@ -193,6 +197,7 @@ func (c *lru) Get(key interface{}) interface{} {
 		entry.refCount++
 	}
 	c.byAccess.MoveToFront(element)
+	c.hits++
 	return entry.value
 }
 
@@ -244,6 +249,12 @@ func (c *lru) Release(key interface{}) {
 	}
 	entry := elt.Value.(*entryImpl)
 	entry.refCount--
+
+	if c.isSizeBased {
+		// the item may have been modified while it's pinned
+		c.updateSizeOnAdd(key, c.sizeFunc(entry.value))
+		c.evictUnpinnedItems()
+	}
 }
 
 // Size returns the number of entries currently in the lru, useful if cache is not full
@@ -256,6 +267,40 @@ func (c *lru) Size() int {
 	return len(c.byKey)
 }
 
+// Stats returns the access and size statistics of the lru
+func (c *lru) Stats() Stats {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	c.evictExpiredItems()
+
+	pinnedCount := 0
+	for _, element := range c.byKey {
+		if element.Value.(*entryImpl).refCount > 0 {
+			pinnedCount++
+		}
+	}
+	return Stats{
+		Count:       len(c.byKey),
+		SizeInBytes: c.currSize,
+		PinnedCount: pinnedCount,
+		Hits:        c.hits,
+		Misses:      c.misses,
+	}
+}
+
+// evictUnpinnedItems evicts the least recently used items which are not in use
+// until the cache is no longer full
+func (c *lru) evictUnpinnedItems() {
+	for elt := c.byAccess.Back(); elt != nil && c.isCacheFull(); {
+		prev := elt.Prev()
+		if elt.Value.(*entryImpl).refCount <= 0 {
+			c.deleteInternal(elt)
+		}
+		elt = prev
+	}
+}
+
 // evictExpiredItems evicts all items in the cache which are expired
 func (c *lru) evictExpiredItems() {
 	if !c.activelyEvict {
@@ -355,6 +400,8 @@ func (c *lru) isCacheFull() bool {
 
 func (c *lru) updateSizeOnAdd(key interface{}, valueSize uint64) {
 	if c.isSizeBased {
+		// the key may already exist when the size is updated on release
+		c.currSize -= c.sizeByKey[key]
 		c.sizeByKey[key] = valueSize
 		// the int overflow should not happen here
 		c.currSize += uint64(valueSize)
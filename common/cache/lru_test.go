@@ -363,6 +363,67 @@ func TestLRU_SizeBased_CountExceeded(t *testing.T) {
 	assert.Equal(t, 4, cache.Size())
 }
 
+func TestLRU_SizeBased_UpdateSizeOnRelease(t *testing.T) {
+	sizes := map[string]uint64{"A": 0, "B": 0}
+	cache := New(&Options{
+		Pin: true,
+		GetCacheItemSizeFunc: func(value interface{}) uint64 {
+			return sizes[value.(string)]
+		},
+		MaxSize: 15,
+	})
+
+	_, err := cache.PutIfNotExist("keyA", "A")
+	assert.NoError(t, err)
+	_, err = cache.PutIfNotExist("keyB", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), cache.Stats().SizeInBytes)
+
+	// item grows while pinned
+	sizes["A"] = 10
+	cache.Release("keyA")
+	assert.Equal(t, uint64(10), cache.Stats().SizeInBytes)
+	assert.Equal(t, 2, cache.Size())
+
+	// pinned items can't be evicted even if the cache is full
+	assert.Equal(t, "A", cache.Get("keyA"))
+	assert.Equal(t, "B", cache.Get("keyB"))
+	sizes["B"] = 10
+	cache.Release("keyB")
+	assert.Equal(t, 2, cache.Size())
+	assert.Equal(t, uint64(20), cache.Stats().SizeInBytes)
+
+	// least recently used item is evicted once it's released
+	cache.Release("keyA")
+	assert.Equal(t, uint64(10), cache.Stats().SizeInBytes)
+	assert.Equal(t, 1, cache.Size())
+	assert.Nil(t, cache.Get("keyA"))
+	assert.Equal(t, "B", cache.Get("keyB"))
+}
+
+func TestLRU_Stats(t *testing.T) {
+	cache := New(&Options{
+		MaxCount: 5,
+		Pin:      true,
+	})
+
+	_, err := cache.PutIfNotExist("A", "Foo")
+	assert.NoError(t, err)
+	_, err = cache.PutIfNotExist("B", "Bar")
+	assert.NoError(t, err)
+	cache.Release("B")
+	assert.Equal(t, "Bar", cache.Get("B"))
+	assert.Nil(t, cache.Get("C"))
+	cache.Release("B")
+
+	assert.Equal(t, Stats{
+		Count:       2,
+		PinnedCount: 1,
+		Hits:        1,
+		Misses:      1,
+	}, cache.Stats())
+}
+
 func TestPanicMaxCountAndSizeNotProvided(t *testing.T) {
 	defer func() {
 		if r := recover(); r == nil {
@@ -25,6 +25,7 @@ package cache
 import (
 	"container/list"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -39,6 +40,8 @@ type (
 		accessMap   map[interface{}]*list.Element
 		iterateList *list.List
 		rmFunc      RemovedFunc
+		hits        int64
+		misses      int64
 	}
 
 	simpleItr struct {
@@ -114,8 +117,10 @@ func (c *simple) Get(key interface{}) interface{} {
 
 	element := c.accessMap[key]
 	if element == nil {
+		atomic.AddInt64(&c.misses, 1)
 		return nil
 	}
+	atomic.AddInt64(&c.hits, 1)
 	return element.Value.(*simpleEntry).Value()
 }
 
@@ -166,6 +171,18 @@ func (c *simple) Size() int {
 	return len(c.accessMap)
 }
 
+// Stats returns the access statistics of the cache, simple cache is not size based and has no pinned entries
+func (c *simple) Stats() Stats {
+	c.RLock()
+	defer c.RUnlock()
+
+	return Stats{
+		Count:  len(c.accessMap),
+		Hits:   atomic.LoadInt64(&c.hits),
+		Misses: atomic.LoadInt64(&c.misses),
+	}
+}
+
 func (c *simple) Iterator() Iterator {
 	c.RLock()
 	iterator := &simpleItr{
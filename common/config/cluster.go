@@ -30,6 +30,13 @@ import (
 	"go.uber.org/yarpc/transport/tchannel"
 )
 
+const (
+	// ReplicationTransportRPC pulls replication tasks from the remote cluster with GetReplicationMessages calls
+	ReplicationTransportRPC = "rpc"
+	// ReplicationTransportKafka consumes replication tasks the remote cluster publishes to Kafka
+	ReplicationTransportKafka = "kafka"
+)
+
 type (
 	// ClusterGroupMetadata contains all the clusters participating in a replication group(aka XDC/GlobalDomain)
 	ClusterGroupMetadata struct {
@@ -72,6 +79,13 @@ type (
 		// Allowed values: tchannel|grpc
 		// Default: tchannel
 		RPCTransport string `yaml:"rpcTransport"`
+		// ReplicationTransport specifies how replication tasks are delivered from this cluster to the other clusters.
+		// With kafka, the cluster publishes replication tasks to the topic of the kafka application
+		// named by ReplicationKafkaApplication and the other clusters report their progress to the topic of
+		// the kafka application named by ReplicationAckKafkaApplication, so that no rpc is needed between clusters.
+		// Allowed values: rpc|kafka
+		// Default: rpc
+		ReplicationTransport string `yaml:"replicationTransport"`
 		// AuthorizationProvider contains the information to authorize the cluster
 		AuthorizationProvider AuthorizationProvider `yaml:"authorizationProvider"`
 		// TLS configures client TLS/SSL authentication for connections to this cluster
@@ -138,6 +152,10 @@ func (m *ClusterGroupMetadata) Validate() error {
 			errs = multierr.Append(errs, fmt.Errorf("cluster %v: rpc transport must %v or %v",
 				clusterName, tchannel.TransportName, grpc.TransportName))
 		}
+		if info.ReplicationTransport != "" && info.ReplicationTransport != ReplicationTransportRPC && info.ReplicationTransport != ReplicationTransportKafka {
+			errs = multierr.Append(errs, fmt.Errorf("cluster %v: replication transport must %v or %v",
+				clusterName, ReplicationTransportRPC, ReplicationTransportKafka))
+		}
 	}
 	if len(versionToClusterName) != len(m.ClusterGroup) {
 		errs = multierr.Append(errs, errors.New("initial versions of the cluster group have duplicates"))
@@ -172,6 +190,21 @@ func (m *ClusterGroupMetadata) FillDefaults() {
 		if cluster.RPCTransport == "" {
 			cluster.RPCTransport = tchannel.TransportName
 		}
+		if cluster.ReplicationTransport == "" {
+			cluster.ReplicationTransport = ReplicationTransportRPC
+		}
 		m.ClusterGroup[name] = cluster
 	}
 }
+
+// ReplicationKafkaApplication returns the name of the kafka application whose topic carries
+// the replication tasks of the cluster, when the cluster uses the kafka replication transport
+func ReplicationKafkaApplication(clusterName string) string {
+	return fmt.Sprintf("cadence-replication-%v", clusterName)
+}
+
+// ReplicationAckKafkaApplication returns the name of the kafka application whose topic carries the acks
+// of the replication tasks of the cluster, when the cluster uses the kafka replication transport
+func ReplicationAckKafkaApplication(clusterName string) string {
+	return fmt.Sprintf("cadence-replication-ack-%v", clusterName)
+}
@@ -39,6 +39,7 @@ func TestClusterGroupMetadataDefaults(t *testing.T) {
 	assert.Equal(t, "active", config.PrimaryClusterName)
 	assert.Equal(t, "cadence-frontend", config.ClusterGroup["active"].RPCName)
 	assert.Equal(t, "tchannel", config.ClusterGroup["active"].RPCTransport)
+	assert.Equal(t, "rpc", config.ClusterGroup["active"].ReplicationTransport)
 }
 
 func TestClusterGroupMetadataValidate(t *testing.T) {
@@ -144,6 +145,15 @@ func TestClusterGroupMetadataValidate(t *testing.T) {
 			}),
 			err: "cluster active: rpc transport must tchannel or grpc",
 		},
+		{
+			msg: "invalid replication transport",
+			config: modify(validClusterGroupMetadata(), func(m *ClusterGroupMetadata) {
+				active := m.ClusterGroup["active"]
+				active.ReplicationTransport = "invalid"
+				m.ClusterGroup["active"] = active
+			}),
+			err: "cluster active: replication transport must rpc or kafka",
+		},
 		{
 			msg: "initial version duplicated",
 			config: modify(validClusterGroupMetadata(), func(m *ClusterGroupMetadata) {
@@ -166,6 +166,22 @@ const (
 	DomainDataKeyForManagedFailover = "IsManagedByCadence"
 	// DomainDataKeyForPreferredCluster is the key of DomainData for domain rebalance
 	DomainDataKeyForPreferredCluster = "PreferredCluster"
+	// DomainDataKeyForAutoFailover is the key of DomainData for automatic failover on remote cluster health loss
+	DomainDataKeyForAutoFailover = "AutoFailover"
+	// DomainDataKeyForFailoverHistory is the key of DomainData for the most recent failovers of the domain
+	DomainDataKeyForFailoverHistory = "FailoverHistory"
+	// DomainDataKeyForFailoverReason is the key of DomainData a failover request sets to record its reason in the failover
+	// history of the domain, it is not stored in the domain data
+	DomainDataKeyForFailoverReason = "FailoverReason"
+	// DomainDataKeyPrefixForActiveCluster is the prefix of the keys of DomainData which map a region to its active cluster,
+	// e.g. "ActiveCluster.us-east": "cluster0", a global domain with such keys is an active-active domain
+	DomainDataKeyPrefixForActiveCluster = "ActiveCluster."
+	// DomainDataKeyPrefixForLocalOnlyWorkflowType is the prefix of the keys of DomainData which mark the workflows of a type
+	// as local-only, i.e. not replicated to the other clusters of a global domain, e.g. "LocalOnlyWorkflowType.cleanup": "true"
+	DomainDataKeyPrefixForLocalOnlyWorkflowType = "LocalOnlyWorkflowType."
+	// DomainDataKeyPrefixForLocalOnlyTaskList is the prefix of the keys of DomainData which mark the workflows of a task list
+	// as local-only, i.e. not replicated to the other clusters of a global domain, e.g. "LocalOnlyTaskList.internal": "true"
+	DomainDataKeyPrefixForLocalOnlyTaskList = "LocalOnlyTaskList."
 	// DomainDataKeyForReadGroups stores which groups have read permission of the domain API
 	DomainDataKeyForReadGroups = "READ_GROUPS"
 	// DomainDataKeyForWriteGroups stores which groups have write permission of the domain API
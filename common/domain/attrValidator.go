@@ -23,6 +23,7 @@ package domain
 import (
 	"fmt"
 
+	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/types"
@@ -123,6 +124,38 @@ func (d *AttrValidatorImpl) validateDomainReplicationConfigForGlobalDomain(
 	return nil
 }
 
+func (d *AttrValidatorImpl) validateActiveClustersByRegion(
+	data map[string]string,
+	replicationConfig *persistence.DomainReplicationConfig,
+	isGlobalDomain bool,
+) error {
+
+	activeClusters := cache.ActiveClustersByRegion(data)
+	if len(activeClusters) == 0 {
+		return nil
+	}
+	if !isGlobalDomain {
+		return errActiveActiveLocalDomain
+	}
+
+	for _, activeCluster := range activeClusters {
+		if err := d.validateClusterName(activeCluster); err != nil {
+			return err
+		}
+		activeClusterInClusters := false
+		for _, clusterConfig := range replicationConfig.Clusters {
+			if clusterConfig.ClusterName == activeCluster {
+				activeClusterInClusters = true
+				break
+			}
+		}
+		if !activeClusterInClusters {
+			return errActiveClusterNotInClusters
+		}
+	}
+	return nil
+}
+
 func (d *AttrValidatorImpl) validateDomainReplicationConfigClustersDoesNotRemove(
 	clustersOld []*persistence.ClusterReplicationConfig,
 	clustersNew []*persistence.ClusterReplicationConfig,
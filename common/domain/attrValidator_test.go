@@ -25,6 +25,7 @@ import (
 
 	"github.com/stretchr/testify/suite"
 
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/types"
@@ -239,6 +240,55 @@ func (s *attrValidatorSuite) TestValidateDomainReplicationConfigForGlobalDomain(
 	s.NoError(err)
 }
 
+func (s *attrValidatorSuite) TestValidateActiveClustersByRegion() {
+	replicationConfig := &persistence.DomainReplicationConfig{
+		ActiveClusterName: cluster.TestCurrentClusterName,
+		Clusters: []*persistence.ClusterReplicationConfig{
+			{ClusterName: cluster.TestCurrentClusterName},
+			{ClusterName: cluster.TestAlternativeClusterName},
+		},
+	}
+
+	err := s.validator.validateActiveClustersByRegion(map[string]string{"tier": "gold"}, replicationConfig, false)
+	s.NoError(err)
+
+	err = s.validator.validateActiveClustersByRegion(
+		map[string]string{
+			common.DomainDataKeyPrefixForActiveCluster + "region0": cluster.TestCurrentClusterName,
+			common.DomainDataKeyPrefixForActiveCluster + "region1": cluster.TestAlternativeClusterName,
+		},
+		replicationConfig,
+		true,
+	)
+	s.NoError(err)
+
+	err = s.validator.validateActiveClustersByRegion(
+		map[string]string{common.DomainDataKeyPrefixForActiveCluster + "region0": cluster.TestCurrentClusterName},
+		replicationConfig,
+		false,
+	)
+	s.Equal(errActiveActiveLocalDomain, err)
+
+	err = s.validator.validateActiveClustersByRegion(
+		map[string]string{common.DomainDataKeyPrefixForActiveCluster + "region0": "some random cluster"},
+		replicationConfig,
+		true,
+	)
+	s.IsType(&types.BadRequestError{}, err)
+
+	err = s.validator.validateActiveClustersByRegion(
+		map[string]string{common.DomainDataKeyPrefixForActiveCluster + "region0": cluster.TestAlternativeClusterName},
+		&persistence.DomainReplicationConfig{
+			ActiveClusterName: cluster.TestCurrentClusterName,
+			Clusters: []*persistence.ClusterReplicationConfig{
+				{ClusterName: cluster.TestCurrentClusterName},
+			},
+		},
+		true,
+	)
+	s.Equal(errActiveClusterNotInClusters, err)
+}
+
 func (s *attrValidatorSuite) TestValidateDomainReplicationConfigClustersDoesNotRemove() {
 	err := s.validator.validateDomainReplicationConfigClustersDoesNotRemove(
 		[]*persistence.ClusterReplicationConfig{
@@ -32,6 +32,10 @@ var (
 	errGracefulFailoverInActiveCluster     = &types.BadRequestError{Message: "Cannot start the graceful failover from an active cluster to an active cluster."}
 	errOngoingGracefulFailover             = &types.BadRequestError{Message: "Cannot start concurrent graceful failover."}
 	errInvalidGracefulFailover             = &types.BadRequestError{Message: "Cannot start graceful failover without updating active cluster or in local domain."}
+	errNoOngoingGracefulFailover           = &types.BadRequestError{Message: "Cannot abort graceful failover, there is no ongoing graceful failover."}
+	errCannotAbortGracefulFailover         = &types.BadRequestError{Message: "Cannot abort the graceful failover from a cluster other than the to-be-active cluster."}
+	errActiveActiveLocalDomain             = &types.BadRequestError{Message: "Cannot set active clusters of regions on a local domain."}
+	errGracefulFailoverActiveActive        = &types.BadRequestError{Message: "Cannot start graceful failover on an active-active domain."}
 
 	errInvalidRetentionPeriod = &types.BadRequestError{Message: "A valid retention period is not set on request."}
 	errInvalidArchivalConfig  = &types.BadRequestError{Message: "Invalid to enable archival without specifying a uri."}
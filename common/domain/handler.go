@@ -24,7 +24,9 @@ package domain
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"regexp"
 	"time"
 
@@ -33,6 +35,7 @@ import (
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/archiver"
 	"github.com/uber/cadence/common/archiver/provider"
+	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/dynamicconfig"
@@ -43,6 +46,11 @@ import (
 	"github.com/uber/cadence/common/types"
 )
 
+const (
+	failoverTypeForce    = "Force"
+	failoverTypeGraceful = "Graceful"
+)
+
 var (
 	errDomainUpdateTooFrequent = &types.ServiceBusyError{Message: "Domain update too frequent."}
 	errInvalidDomainName       = &types.BadRequestError{Message: "Domain name can only include alphanumeric and dash characters."}
@@ -51,6 +59,10 @@ var (
 type (
 	// Handler is the domain operation handler
 	Handler interface {
+		AbortGracefulFailover(
+			ctx context.Context,
+			abortRequest *types.AbortGracefulFailoverRequest,
+		) (*types.AbortGracefulFailoverResponse, error)
 		DeprecateDomain(
 			ctx context.Context,
 			deprecateRequest *types.DeprecateDomainRequest,
@@ -101,6 +113,16 @@ type (
 		RequiredDomainDataKeys dynamicconfig.MapPropertyFn
 		MaxBadBinaryCount      dynamicconfig.IntPropertyFnWithDomainFilter
 		FailoverCoolDown       dynamicconfig.DurationPropertyFnWithDomainFilter
+		FailoverHistoryMaxSize dynamicconfig.IntPropertyFnWithDomainFilter
+	}
+
+	// FailoverEvent is a failover recorded in the failover history of a domain
+	FailoverEvent struct {
+		EventTime    time.Time `json:"eventTime"`
+		FromCluster  string    `json:"fromCluster"`
+		ToCluster    string    `json:"toCluster"`
+		FailoverType string    `json:"failoverType"`
+		Reason       string    `json:"reason,omitempty"`
 	}
 )
 
@@ -241,6 +263,13 @@ func (d *handlerImpl) RegisterDomain(
 	if err := d.domainAttrValidator.validateDomainConfig(config); err != nil {
 		return err
 	}
+	if err := d.domainAttrValidator.validateActiveClustersByRegion(
+		registerRequest.Data,
+		replicationConfig,
+		isGlobalDomain,
+	); err != nil {
+		return err
+	}
 	if isGlobalDomain {
 		if err := d.domainAttrValidator.validateDomainReplicationConfigForGlobalDomain(
 			replicationConfig,
@@ -376,6 +405,8 @@ func (d *handlerImpl) UpdateDomain(
 	updateRequest *types.UpdateDomainRequest,
 ) (*types.UpdateDomainResponse, error) {
 
+	updateRequest, failoverReason := extractFailoverReason(updateRequest)
+
 	// must get the metadata (notificationVersion) first
 	// this version can be regarded as the lock on the v2 domain table
 	// and since we do not know which table will return the domain afterwards
@@ -438,10 +469,13 @@ func (d *handlerImpl) UpdateDomain(
 	}
 
 	// Update domain info
+	activeClustersByRegion := cache.ActiveClustersByRegion(info.Data)
 	info, domainInfoChanged := d.updateDomainInfo(
 		updateRequest,
 		info,
 	)
+	// whether the active cluster of any region of an active-active domain is changed
+	activeClustersByRegionChanged := !reflect.DeepEqual(activeClustersByRegion, cache.ActiveClustersByRegion(info.Data))
 	// Update domain config
 	config, domainConfigChanged, err := d.updateDomainConfiguration(
 		updateRequest.GetName(),
@@ -476,6 +510,10 @@ func (d *handlerImpl) UpdateDomain(
 		if !activeClusterChanged || !isGlobalDomain {
 			return nil, errInvalidGracefulFailover
 		}
+		// the failover markers only track the active cluster of the domain, not the ones of its regions
+		if len(cache.ActiveClustersByRegion(info.Data)) > 0 {
+			return nil, errGracefulFailoverActiveActive
+		}
 		// must start with the passive -> active cluster
 		if replicationConfig.ActiveClusterName != d.clusterMetadata.GetCurrentClusterName() {
 			return nil, errCannotDoGracefulFailoverFromCluster
@@ -497,6 +535,13 @@ func (d *handlerImpl) UpdateDomain(
 	if err := d.domainAttrValidator.validateDomainConfig(config); err != nil {
 		return nil, err
 	}
+	if err := d.domainAttrValidator.validateActiveClustersByRegion(
+		info.Data,
+		replicationConfig,
+		isGlobalDomain,
+	); err != nil {
+		return nil, err
+	}
 	if isGlobalDomain {
 		if err := d.domainAttrValidator.validateDomainReplicationConfigForGlobalDomain(
 			replicationConfig,
@@ -531,6 +576,10 @@ func (d *handlerImpl) UpdateDomain(
 			configVersion++
 		}
 		if activeClusterChanged && isGlobalDomain {
+			info.Data, err = d.updateFailoverHistory(info, currentActiveCluster, replicationConfig.ActiveClusterName, updateRequest, failoverReason, now)
+			if err != nil {
+				return nil, err
+			}
 			// Force failover cleans graceful failover state
 			if updateRequest.FailoverTimeoutInSeconds == nil {
 				// force failover cleanup graceful failover state
@@ -543,6 +592,15 @@ func (d *handlerImpl) UpdateDomain(
 				updateRequest.Name,
 			)
 			failoverNotificationVersion = notificationVersion
+		} else if activeClustersByRegionChanged && isGlobalDomain {
+			// failover of regions of an active-active domain, bump the failover version so that
+			// the failover versions of all regions are higher than the ones before the failover
+			failoverVersion = d.clusterMetadata.GetNextFailoverVersion(
+				replicationConfig.ActiveClusterName,
+				failoverVersion+1,
+				updateRequest.Name,
+			)
+			failoverNotificationVersion = notificationVersion
 		}
 		lastUpdatedTime = now
 
@@ -662,6 +720,94 @@ func (d *handlerImpl) DeprecateDomain(
 	return nil
 }
 
+// AbortGracefulFailover rolls a pending-active domain back to the cluster which was active
+// before the graceful failover started. The rollback is a force failover with a new failover version,
+// so it is not subject to the failover cool down and it invalidates the failover markers of the aborted failover.
+func (d *handlerImpl) AbortGracefulFailover(
+	ctx context.Context,
+	abortRequest *types.AbortGracefulFailoverRequest,
+) (*types.AbortGracefulFailoverResponse, error) {
+
+	// must get the metadata (notificationVersion) first
+	// this version can be regarded as the lock on the v2 domain table
+	// and since we do not know which table will return the domain afterwards
+	// this call has to be made
+	metadata, err := d.domainManager.GetMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	notificationVersion := metadata.NotificationVersion
+	getResponse, err := d.domainManager.GetDomain(ctx, &persistence.GetDomainRequest{Name: abortRequest.GetDomain()})
+	if err != nil {
+		return nil, err
+	}
+
+	if !getResponse.IsGlobalDomain || getResponse.FailoverEndTime == nil {
+		return nil, errNoOngoingGracefulFailover
+	}
+	// the graceful failover is started from the to-be-active cluster, so is the abort
+	if getResponse.ReplicationConfig.ActiveClusterName != d.clusterMetadata.GetCurrentClusterName() {
+		return nil, errCannotAbortGracefulFailover
+	}
+	previousActiveCluster, err := d.clusterMetadata.ClusterNameForFailoverVersion(getResponse.PreviousFailoverVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	replicationConfig := getResponse.ReplicationConfig
+	replicationConfig.ActiveClusterName = previousActiveCluster
+	failoverVersion := d.clusterMetadata.GetNextFailoverVersion(
+		previousActiveCluster,
+		getResponse.FailoverVersion,
+		getResponse.Info.Name,
+	)
+	previousFailoverVersion := common.InitialPreviousFailoverVersion
+
+	updateReq := createUpdateRequest(
+		getResponse.Info,
+		getResponse.Config,
+		replicationConfig,
+		getResponse.ConfigVersion,
+		failoverVersion,
+		notificationVersion,
+		nil,
+		previousFailoverVersion,
+		d.timeSource.Now(),
+		notificationVersion,
+	)
+
+	err = d.domainManager.UpdateDomain(ctx, &updateReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.domainReplicator.HandleTransmissionTask(
+		ctx,
+		types.DomainOperationUpdate,
+		getResponse.Info,
+		getResponse.Config,
+		replicationConfig,
+		getResponse.ConfigVersion,
+		failoverVersion,
+		previousFailoverVersion,
+		getResponse.IsGlobalDomain,
+	); err != nil {
+		return nil, err
+	}
+
+	d.logger.Info("Abort graceful failover succeeded",
+		tag.WorkflowDomainName(getResponse.Info.Name),
+		tag.WorkflowDomainID(getResponse.Info.ID),
+		tag.ClusterName(previousActiveCluster),
+		tag.FailoverVersion(failoverVersion),
+		tag.ActorReason(abortRequest.GetReason()),
+	)
+	return &types.AbortGracefulFailoverResponse{
+		ActiveClusterName: previousActiveCluster,
+		FailoverVersion:   failoverVersion,
+	}, nil
+}
+
 // UpdateIsolationGroups is used for draining and undraining of isolation-groups for a domain.
 // Like the isolation-group API, this controller expects Upsert semantics for
 // isolation-groups and does not modify any other domain information.
@@ -925,6 +1071,69 @@ func (d *handlerImpl) mergeBadBinaries(
 	}
 }
 
+// extractFailoverReason returns the failover reason of the request and a copy of the request without it,
+// since the reason is recorded in the failover history instead of the domain data
+func extractFailoverReason(updateRequest *types.UpdateDomainRequest) (*types.UpdateDomainRequest, string) {
+	reason, ok := updateRequest.Data[common.DomainDataKeyForFailoverReason]
+	if !ok {
+		return updateRequest, ""
+	}
+	request := *updateRequest
+	request.Data = nil
+	for key, value := range updateRequest.Data {
+		if key == common.DomainDataKeyForFailoverReason {
+			continue
+		}
+		if request.Data == nil {
+			request.Data = make(map[string]string)
+		}
+		request.Data[key] = value
+	}
+	return &request, reason
+}
+
+// updateFailoverHistory returns the domain data with the failover added to its failover history,
+// which keeps the most recent FailoverHistoryMaxSize failovers, the most recent first
+func (d *handlerImpl) updateFailoverHistory(
+	info *persistence.DomainInfo,
+	fromCluster string,
+	toCluster string,
+	updateRequest *types.UpdateDomainRequest,
+	reason string,
+	now time.Time,
+) (map[string]string, error) {
+
+	failoverType := failoverTypeForce
+	if updateRequest.FailoverTimeoutInSeconds != nil {
+		failoverType = failoverTypeGraceful
+	}
+	events := []FailoverEvent{{
+		EventTime:    now,
+		FromCluster:  fromCluster,
+		ToCluster:    toCluster,
+		FailoverType: failoverType,
+		Reason:       reason,
+	}}
+
+	var history []FailoverEvent
+	if data, ok := info.Data[common.DomainDataKeyForFailoverHistory]; ok {
+		if err := json.Unmarshal([]byte(data), &history); err != nil {
+			d.logger.Warn("Failed to parse the failover history of the domain, it is reset.",
+				tag.WorkflowDomainName(info.Name), tag.Error(err))
+		}
+	}
+	events = append(events, history...)
+	if maxSize := d.config.FailoverHistoryMaxSize(info.Name); len(events) > maxSize {
+		events = events[:maxSize]
+	}
+
+	data, err := json.Marshal(events)
+	if err != nil {
+		return nil, err
+	}
+	return d.mergeDomainData(info.Data, map[string]string{common.DomainDataKeyForFailoverHistory: string(data)}), nil
+}
+
 func (d *handlerImpl) mergeDomainData(
 	old map[string]string,
 	new map[string]string,
@@ -22,6 +22,7 @@ package domain
 
 import (
 	"context"
+	"encoding/json"
 	"log"
 	"os"
 	"testing"
@@ -102,9 +103,10 @@ func (s *domainHandlerGlobalDomainEnabledPrimaryClusterSuite) SetupTest() {
 	)
 	s.mockArchiverProvider = &provider.MockArchiverProvider{}
 	domainConfig := Config{
-		MinRetentionDays:  dc.GetIntPropertyFn(s.minRetentionDays),
-		MaxBadBinaryCount: dc.GetIntPropertyFilteredByDomain(s.maxBadBinaryCount),
-		FailoverCoolDown:  dc.GetDurationPropertyFnFilteredByDomain(0 * time.Second),
+		MinRetentionDays:       dc.GetIntPropertyFn(s.minRetentionDays),
+		MaxBadBinaryCount:      dc.GetIntPropertyFilteredByDomain(s.maxBadBinaryCount),
+		FailoverCoolDown:       dc.GetDurationPropertyFnFilteredByDomain(0 * time.Second),
+		FailoverHistoryMaxSize: dc.GetIntPropertyFilteredByDomain(5),
 	}
 	s.handler = NewHandler(
 		domainConfig,
@@ -824,6 +826,13 @@ func (s *domainHandlerGlobalDomainEnabledPrimaryClusterSuite) TestUpdateGetDomai
 		replicationConfig *types.DomainReplicationConfiguration, isGlobalDomain bool, failoverVersion int64) {
 		s.NotEmpty(info.GetUUID())
 		info.UUID = ""
+		var failoverHistory []FailoverEvent
+		s.NoError(json.Unmarshal([]byte(info.Data[common.DomainDataKeyForFailoverHistory]), &failoverHistory))
+		s.Len(failoverHistory, 1)
+		s.Equal(prevActiveClusterName, failoverHistory[0].FromCluster)
+		s.Equal(nextActiveClusterName, failoverHistory[0].ToCluster)
+		s.Equal(failoverTypeForce, failoverHistory[0].FailoverType)
+		delete(info.Data, common.DomainDataKeyForFailoverHistory)
 		s.Equal(&types.DomainInfo{
 			Name:        domainName,
 			Status:      types.DomainStatusRegistered.Ptr(),
@@ -882,9 +891,10 @@ func (s *domainHandlerGlobalDomainEnabledPrimaryClusterSuite) TestUpdateGetDomai
 
 func (s *domainHandlerGlobalDomainEnabledPrimaryClusterSuite) TestUpdateDomain_CoolDown() {
 	domainConfig := Config{
-		MinRetentionDays:  dc.GetIntPropertyFn(s.minRetentionDays),
-		MaxBadBinaryCount: dc.GetIntPropertyFilteredByDomain(s.maxBadBinaryCount),
-		FailoverCoolDown:  dc.GetDurationPropertyFnFilteredByDomain(10000 * time.Second),
+		MinRetentionDays:       dc.GetIntPropertyFn(s.minRetentionDays),
+		MaxBadBinaryCount:      dc.GetIntPropertyFilteredByDomain(s.maxBadBinaryCount),
+		FailoverCoolDown:       dc.GetDurationPropertyFnFilteredByDomain(10000 * time.Second),
+		FailoverHistoryMaxSize: dc.GetIntPropertyFilteredByDomain(5),
 	}
 	s.handler = NewHandler(
 		domainConfig,
@@ -108,9 +108,10 @@ func (s *domainHandlerCommonSuite) SetupTest() {
 	)
 	s.mockArchiverProvider = &provider.MockArchiverProvider{}
 	domainConfig := Config{
-		MinRetentionDays:  dc.GetIntPropertyFn(s.minRetentionDays),
-		MaxBadBinaryCount: dc.GetIntPropertyFilteredByDomain(s.maxBadBinaryCount),
-		FailoverCoolDown:  dc.GetDurationPropertyFnFilteredByDomain(0 * time.Second),
+		MinRetentionDays:       dc.GetIntPropertyFn(s.minRetentionDays),
+		MaxBadBinaryCount:      dc.GetIntPropertyFilteredByDomain(s.maxBadBinaryCount),
+		FailoverCoolDown:       dc.GetDurationPropertyFnFilteredByDomain(0 * time.Second),
+		FailoverHistoryMaxSize: dc.GetIntPropertyFilteredByDomain(5),
 	}
 	s.handler = NewHandler(
 		domainConfig,
@@ -503,6 +504,33 @@ func (s *domainHandlerCommonSuite) TestUpdateDomain_GracefulFailover_NotCurrentA
 	s.Error(err)
 }
 
+func (s *domainHandlerCommonSuite) TestUpdateDomain_GracefulFailover_ActiveActive() {
+	s.mockProducer.On("Publish", mock.Anything, mock.Anything).Return(nil).Once()
+	domain := uuid.New()
+	registerRequest := &types.RegisterDomainRequest{
+		Name:                                   domain,
+		Description:                            domain,
+		WorkflowExecutionRetentionPeriodInDays: int32(10),
+		IsGlobalDomain:                         true,
+		ActiveClusterName:                      "standby",
+		Clusters: []*types.ClusterReplicationConfiguration{
+			{ClusterName: s.ClusterMetadata.GetCurrentClusterName()},
+			{ClusterName: "standby"},
+		},
+		Data: map[string]string{common.DomainDataKeyPrefixForActiveCluster + "region0": "standby"},
+	}
+	err := s.handler.RegisterDomain(context.Background(), registerRequest)
+	s.NoError(err)
+
+	updateRequest := &types.UpdateDomainRequest{
+		Name:                     domain,
+		ActiveClusterName:        common.StringPtr(s.ClusterMetadata.GetCurrentClusterName()),
+		FailoverTimeoutInSeconds: common.Int32Ptr(100),
+	}
+	_, err = s.handler.UpdateDomain(context.Background(), updateRequest)
+	s.Equal(errGracefulFailoverActiveActive, err)
+}
+
 func (s *domainHandlerCommonSuite) TestUpdateDomain_GracefulFailover_OngoingFailover() {
 	s.mockProducer.On("Publish", mock.Anything, mock.Anything).Return(nil).Twice()
 	domain := uuid.New()
@@ -722,9 +750,10 @@ func TestHandlerImpl_UpdateIsolationGroups(t *testing.T) {
 				archiverProvider:    nil,
 				timeSource:          clock,
 				config: Config{
-					MinRetentionDays:  func(opts ...dc.FilterOption) int { return 0 },
-					MaxBadBinaryCount: func(string) int { return 3 },
-					FailoverCoolDown:  func(string) time.Duration { return time.Second },
+					MinRetentionDays:       func(opts ...dc.FilterOption) int { return 0 },
+					MaxBadBinaryCount:      func(string) int { return 3 },
+					FailoverCoolDown:       func(string) time.Duration { return time.Second },
+					FailoverHistoryMaxSize: func(string) int { return 5 },
 				},
 				logger: testlogger.New(t),
 			}
@@ -830,9 +859,10 @@ func TestHandlerImpl_UpdateAsyncWorkflowConfiguraton(t *testing.T) {
 				domainReplicator: replicator,
 				timeSource:       clock,
 				config: Config{
-					MinRetentionDays:  func(opts ...dc.FilterOption) int { return 0 },
-					MaxBadBinaryCount: func(string) int { return 3 },
-					FailoverCoolDown:  func(string) time.Duration { return time.Second },
+					MinRetentionDays:       func(opts ...dc.FilterOption) int { return 0 },
+					MaxBadBinaryCount:      func(string) int { return 3 },
+					FailoverCoolDown:       func(string) time.Duration { return time.Second },
+					FailoverHistoryMaxSize: func(string) int { return 5 },
 				},
 				logger: testlogger.New(t),
 			}
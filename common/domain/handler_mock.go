@@ -58,6 +58,21 @@ func (m *MockHandler) EXPECT() *MockHandlerMockRecorder {
 	return m.recorder
 }
 
+// AbortGracefulFailover mocks base method.
+func (m *MockHandler) AbortGracefulFailover(ctx context.Context, abortRequest *types.AbortGracefulFailoverRequest) (*types.AbortGracefulFailoverResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortGracefulFailover", ctx, abortRequest)
+	ret0, _ := ret[0].(*types.AbortGracefulFailoverResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AbortGracefulFailover indicates an expected call of AbortGracefulFailover.
+func (mr *MockHandlerMockRecorder) AbortGracefulFailover(ctx, abortRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortGracefulFailover", reflect.TypeOf((*MockHandler)(nil).AbortGracefulFailover), ctx, abortRequest)
+}
+
 // DeprecateDomain mocks base method.
 func (m *MockHandler) DeprecateDomain(ctx context.Context, deprecateRequest *types.DeprecateDomainRequest) error {
 	m.ctrl.T.Helper()
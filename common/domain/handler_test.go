@@ -22,6 +22,7 @@ package domain
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
@@ -63,6 +64,7 @@ func newTestHandler(domainManager persistence.DomainManager, primaryCluster bool
 		RequiredDomainDataKeys: nil,
 		MaxBadBinaryCount:      func(string) int { return 3 },
 		FailoverCoolDown:       func(string) time.Duration { return time.Second },
+		FailoverHistoryMaxSize: func(string) int { return 5 },
 	}
 
 	return NewHandler(
@@ -939,3 +941,173 @@ func TestHandler_UpdateIsolationGroups(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_AbortGracefulFailover(t *testing.T) {
+	failoverEndTime := time.Now().Add(time.Minute).UnixNano()
+	newDomainResponse := func(activeClusterName string, failoverEndTime *int64) *persistence.GetDomainResponse {
+		return &persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{Name: "global-domain", ID: "domainID"},
+			Config: &persistence.DomainConfig{Retention: 7},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: activeClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					{ClusterName: cluster.TestCurrentClusterName},
+					{ClusterName: cluster.TestAlternativeClusterName},
+				},
+			},
+			ConfigVersion:           1,
+			FailoverVersion:         cluster.TestFailoverVersionIncrement,
+			PreviousFailoverVersion: cluster.TestAlternativeClusterInitialFailoverVersion,
+			FailoverEndTime:         failoverEndTime,
+			IsGlobalDomain:          true,
+		}
+	}
+
+	tests := []struct {
+		name             string
+		setupMocks       func(m *persistence.MockDomainManager, r *MockReplicator)
+		expectedResponse *types.AbortGracefulFailoverResponse
+		expectedErr      error
+	}{
+		{
+			name: "success - roll back to the previous active cluster",
+			setupMocks: func(m *persistence.MockDomainManager, r *MockReplicator) {
+				m.EXPECT().GetMetadata(gomock.Any()).Return(&persistence.GetMetadataResponse{NotificationVersion: 5}, nil)
+				m.EXPECT().GetDomain(gomock.Any(), &persistence.GetDomainRequest{Name: "global-domain"}).
+					Return(newDomainResponse(cluster.TestCurrentClusterName, &failoverEndTime), nil)
+				m.EXPECT().UpdateDomain(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, request *persistence.UpdateDomainRequest) error {
+						assert.Equal(t, cluster.TestAlternativeClusterName, request.ReplicationConfig.ActiveClusterName)
+						assert.Equal(t, cluster.TestAlternativeClusterInitialFailoverVersion+cluster.TestFailoverVersionIncrement, request.FailoverVersion)
+						assert.Equal(t, int64(5), request.FailoverNotificationVersion)
+						assert.Nil(t, request.FailoverEndTime)
+						assert.Equal(t, common.InitialPreviousFailoverVersion, request.PreviousFailoverVersion)
+						return nil
+					})
+				r.EXPECT().HandleTransmissionTask(
+					gomock.Any(),
+					types.DomainOperationUpdate,
+					gomock.Any(),
+					gomock.Any(),
+					gomock.Any(),
+					int64(1),
+					cluster.TestAlternativeClusterInitialFailoverVersion+cluster.TestFailoverVersionIncrement,
+					common.InitialPreviousFailoverVersion,
+					true,
+				).Return(nil)
+			},
+			expectedResponse: &types.AbortGracefulFailoverResponse{
+				ActiveClusterName: cluster.TestAlternativeClusterName,
+				FailoverVersion:   cluster.TestAlternativeClusterInitialFailoverVersion + cluster.TestFailoverVersionIncrement,
+			},
+		},
+		{
+			name: "failure - no ongoing graceful failover",
+			setupMocks: func(m *persistence.MockDomainManager, r *MockReplicator) {
+				m.EXPECT().GetMetadata(gomock.Any()).Return(&persistence.GetMetadataResponse{NotificationVersion: 5}, nil)
+				m.EXPECT().GetDomain(gomock.Any(), &persistence.GetDomainRequest{Name: "global-domain"}).
+					Return(newDomainResponse(cluster.TestCurrentClusterName, nil), nil)
+			},
+			expectedErr: errNoOngoingGracefulFailover,
+		},
+		{
+			name: "failure - abort from the to-be-passive cluster",
+			setupMocks: func(m *persistence.MockDomainManager, r *MockReplicator) {
+				m.EXPECT().GetMetadata(gomock.Any()).Return(&persistence.GetMetadataResponse{NotificationVersion: 5}, nil)
+				m.EXPECT().GetDomain(gomock.Any(), &persistence.GetDomainRequest{Name: "global-domain"}).
+					Return(newDomainResponse(cluster.TestAlternativeClusterName, &failoverEndTime), nil)
+			},
+			expectedErr: errCannotAbortGracefulFailover,
+		},
+		{
+			name: "failure - update domain error",
+			setupMocks: func(m *persistence.MockDomainManager, r *MockReplicator) {
+				m.EXPECT().GetMetadata(gomock.Any()).Return(&persistence.GetMetadataResponse{NotificationVersion: 5}, nil)
+				m.EXPECT().GetDomain(gomock.Any(), &persistence.GetDomainRequest{Name: "global-domain"}).
+					Return(newDomainResponse(cluster.TestCurrentClusterName, &failoverEndTime), nil)
+				m.EXPECT().UpdateDomain(gomock.Any(), gomock.Any()).Return(errors.New("update error"))
+			},
+			expectedErr: errors.New("update error"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+
+			mockDomainManager := persistence.NewMockDomainManager(controller)
+			mockReplicator := NewMockReplicator(controller)
+
+			handler := newTestHandler(mockDomainManager, true, mockReplicator)
+			tc.setupMocks(mockDomainManager, mockReplicator)
+
+			resp, err := handler.AbortGracefulFailover(context.Background(), &types.AbortGracefulFailoverRequest{
+				Domain: "global-domain",
+				Reason: "stuck failover",
+			})
+
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedResponse, resp)
+			}
+		})
+	}
+}
+
+func TestExtractFailoverReason(t *testing.T) {
+	request := &types.UpdateDomainRequest{
+		Name:              "test-domain",
+		ActiveClusterName: common.StringPtr("standby"),
+		Data:              map[string]string{common.DomainDataKeyForFailoverReason: "cluster unhealthy"},
+	}
+	updateRequest, reason := extractFailoverReason(request)
+	assert.Equal(t, "cluster unhealthy", reason)
+	assert.Nil(t, updateRequest.Data)
+	assert.Equal(t, common.StringPtr("standby"), updateRequest.ActiveClusterName)
+	// the request of the caller is not modified
+	assert.Len(t, request.Data, 1)
+
+	request.Data["key"] = "value"
+	updateRequest, _ = extractFailoverReason(request)
+	assert.Equal(t, map[string]string{"key": "value"}, updateRequest.Data)
+
+	request = &types.UpdateDomainRequest{Name: "test-domain"}
+	updateRequest, reason = extractFailoverReason(request)
+	assert.Empty(t, reason)
+	assert.Equal(t, request, updateRequest)
+}
+
+func TestUpdateFailoverHistory(t *testing.T) {
+	handler := newTestHandler(nil, true, nil).(*handlerImpl)
+	handler.config.FailoverHistoryMaxSize = func(string) int { return 2 }
+	info := &persistence.DomainInfo{Name: "test-domain", Data: map[string]string{"key": "value"}}
+	now := time.Unix(100, 0).UTC()
+
+	var err error
+	info.Data, err = handler.updateFailoverHistory(info, "active", "standby", &types.UpdateDomainRequest{}, "cluster unhealthy", now)
+	assert.NoError(t, err)
+	info.Data, err = handler.updateFailoverHistory(info, "standby", "active", &types.UpdateDomainRequest{FailoverTimeoutInSeconds: common.Int32Ptr(10)}, "", now.Add(time.Minute))
+	assert.NoError(t, err)
+	info.Data, err = handler.updateFailoverHistory(info, "active", "standby", &types.UpdateDomainRequest{}, "", now.Add(2*time.Minute))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "value", info.Data["key"])
+	var history []FailoverEvent
+	assert.NoError(t, json.Unmarshal([]byte(info.Data[common.DomainDataKeyForFailoverHistory]), &history))
+	// the most recent failovers are kept, the most recent first
+	assert.Equal(t, []FailoverEvent{
+		{EventTime: now.Add(2 * time.Minute), FromCluster: "active", ToCluster: "standby", FailoverType: failoverTypeForce},
+		{EventTime: now.Add(time.Minute), FromCluster: "standby", ToCluster: "active", FailoverType: failoverTypeGraceful},
+	}, history)
+
+	// an invalid history is reset
+	info.Data[common.DomainDataKeyForFailoverHistory] = "invalid"
+	info.Data, err = handler.updateFailoverHistory(info, "active", "standby", &types.UpdateDomainRequest{}, "cluster unhealthy", now)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal([]byte(info.Data[common.DomainDataKeyForFailoverHistory]), &history))
+	assert.Equal(t, []FailoverEvent{
+		{EventTime: now, FromCluster: "active", ToCluster: "standby", FailoverType: failoverTypeForce, Reason: "cluster unhealthy"},
+	}, history)
+}
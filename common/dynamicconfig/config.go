@@ -167,6 +167,9 @@ type BoolPropertyFnWithTaskListInfoFilters func(domain string, taskList string,
 // IntPropertyFnWithWorkflowTypeFilter is a wrapper to get int property from dynamic config with domain as filter
 type IntPropertyFnWithWorkflowTypeFilter func(domainName string, workflowType string) int
 
+// IntPropertyFnWithWorkflowTypeAndTaskListFilters is a wrapper to get int property from dynamic config with three filters: domain, workflowType, taskList
+type IntPropertyFnWithWorkflowTypeAndTaskListFilters func(domainName string, workflowType string, taskList string) int
+
 // DurationPropertyFnWithDomainFilter is a wrapper to get duration property from dynamic config with domain as filter
 type DurationPropertyFnWithWorkflowTypeFilter func(domainName string, workflowType string) time.Duration
 
@@ -240,6 +243,27 @@ func (c *Collection) GetIntPropertyFilteredByWorkflowType(key IntKey) IntPropert
 	}
 }
 
+// GetIntPropertyFilteredByWorkflowTypeAndTaskList gets property with domain, workflow type and tasklist as filters and asserts that it's an integer
+func (c *Collection) GetIntPropertyFilteredByWorkflowTypeAndTaskList(key IntKey) IntPropertyFnWithWorkflowTypeAndTaskListFilters {
+	return func(domainName string, workflowType string, taskList string) int {
+		filters := c.toFilterMap(
+			DomainFilter(domainName),
+			WorkflowTypeFilter(workflowType),
+			TaskListFilter(taskList),
+		)
+		val, err := c.client.GetIntValue(
+			key,
+			filters,
+		)
+		if err != nil {
+			c.logError(key, filters, err)
+			return key.DefaultInt()
+		}
+		c.logValue(key, filters, val, key.DefaultValue(), intCompareEquals)
+		return val
+	}
+}
+
 // GetDurationPropertyFilteredByWorkflowType gets property with workflow type filter and asserts that it's a duration
 func (c *Collection) GetDurationPropertyFilteredByWorkflowType(key DurationKey) DurationPropertyFnWithWorkflowTypeFilter {
 	return func(domainName string, workflowType string) time.Duration {
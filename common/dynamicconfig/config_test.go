@@ -82,6 +82,17 @@ func (s *configSuite) TestGetIntPropertyFilteredByWorkflowType() {
 	s.Equal(50, value(domain, workflowType))
 }
 
+func (s *configSuite) TestGetIntPropertyFilteredByWorkflowTypeAndTaskList() {
+	key := TestGetIntPropertyFilteredByWorkflowTypeAndTaskListKey
+	domain := "testDomain"
+	workflowType := "testWorkflowType"
+	taskList := "testTaskList"
+	value := s.cln.GetIntPropertyFilteredByWorkflowTypeAndTaskList(key)
+	s.Equal(key.DefaultInt(), value(domain, workflowType, taskList))
+	s.client.SetValue(key, 50)
+	s.Equal(50, value(domain, workflowType, taskList))
+}
+
 func (s *configSuite) TestGetIntPropertyFilteredByShardID() {
 	key := TestGetIntPropertyFilteredByShardIDKey
 	shardID := 1
@@ -343,6 +343,7 @@ const (
 	TestGetIntPropertyFilteredByWorkflowTypeKey
 	TestGetIntPropertyFilteredByTaskListInfoKey
 	TestGetIntPropertyFilteredByShardIDKey
+	TestGetIntPropertyFilteredByWorkflowTypeAndTaskListKey
 
 	// key for common & admin
 
@@ -355,31 +356,31 @@ const (
 	// KeyName: limit.blobSize.warn
 	// Value type: Int
 	// Default value: 262144 (256*1024)
-	// Allowed filters: DomainName
+	// Allowed filters: DomainName, WorkflowType, TaskListName
 	BlobSizeLimitWarn
 	// HistorySizeLimitError is the per workflow execution history size limit
 	// KeyName: limit.historySize.error
 	// Value type: Int
 	// Default value: 209715200 (200*1024*1024)
-	// Allowed filters: DomainName
+	// Allowed filters: DomainName, WorkflowType, TaskListName
 	HistorySizeLimitError
 	// HistorySizeLimitWarn is the per workflow execution history size limit for warning
 	// KeyName: limit.historySize.warn
 	// Value type: Int
 	// Default value: 52428800 (50*1024*1024)
-	// Allowed filters: DomainName
+	// Allowed filters: DomainName, WorkflowType, TaskListName
 	HistorySizeLimitWarn
 	// HistoryCountLimitError is the per workflow execution history event count limit
 	// KeyName: limit.historyCount.error
 	// Value type: Int
 	// Default value: 204800 (200*1024)
-	// Allowed filters: DomainName
+	// Allowed filters: DomainName, WorkflowType, TaskListName
 	HistoryCountLimitError
 	// HistoryCountLimitWarn is the per workflow execution history event count limit for warning
 	// KeyName: limit.historyCount.warn
 	// Value type: Int
 	// Default value: 51200 (50*1024)
-	// Allowed filters: DomainName
+	// Allowed filters: DomainName, WorkflowType, TaskListName
 	HistoryCountLimitWarn
 	// PendingActivitiesCountLimitError is the limit of how many pending activities a workflow can have at a point in time
 	// KeyName: limit.pendingActivityCount.error
@@ -606,6 +607,12 @@ const (
 	// Default value: 10 (see domain.MaxBadBinaries)
 	// Allowed filters: DomainName
 	FrontendMaxBadBinaries
+	// FrontendFailoverHistoryMaxSize is the max number of failovers recorded in the failover history of a domain
+	// KeyName: frontend.failoverHistoryMaxSize
+	// Value type: Int
+	// Default value: 5
+	// Allowed filters: DomainName
+	FrontendFailoverHistoryMaxSize
 	// SearchAttributesNumberOfKeysLimit is the limit of number of keys
 	// KeyName: frontend.searchAttributesNumberOfKeysLimit
 	// Value type: Int
@@ -750,6 +757,20 @@ const (
 	// Default value: 3000
 	// Allowed filters: N/A
 	HistoryRPS
+	// HotShardLoadSheddingTopN is the number of hottest shards and workflows on a history host whose requests
+	// are rejected when load shedding is enabled and the host is overloaded
+	// KeyName: history.hotShardLoadSheddingTopN
+	// Value type: Int
+	// Default value: 3
+	// Allowed filters: N/A
+	HotShardLoadSheddingTopN
+	// HotShardLoadSheddingPendingTasksThreshold is the total number of pending queue tasks on a history host
+	// above which the host is considered overloaded. 0 disables the check
+	// KeyName: history.hotShardLoadSheddingPendingTasksThreshold
+	// Value type: Int
+	// Default value: 0
+	// Allowed filters: N/A
+	HotShardLoadSheddingPendingTasksThreshold
 	// HistoryPersistenceMaxQPS is the max qps history host can query DB
 	// KeyName: history.persistenceMaxQPS
 	// Value type: Int
@@ -786,6 +807,13 @@ const (
 	// Default value: 512
 	// Allowed filters: N/A
 	HistoryCacheMaxSize
+	// HistoryCacheMaxSizeInBytes is max size of history cache in bytes, estimated from the size of mutable states.
+	// The history cache is bounded by HistoryCacheMaxSize if this is 0
+	// KeyName: history.cacheMaxSizeInBytes
+	// Value type: Int
+	// Default value: 0
+	// Allowed filters: N/A
+	HistoryCacheMaxSizeInBytes
 	// EventsCacheInitialCount is initial count of events cache
 	// KeyName: history.eventsCacheInitialSize
 	// Value type: Int
@@ -804,6 +832,13 @@ const (
 	// Default value: 0
 	// Allowed filters: N/A
 	EventsCacheMaxSize
+	// EventsCacheShardMaxSize is max size of the per-shard events cache in bytes.
+	// The per-shard events cache is bounded by EventsCacheMaxCount if this is 0
+	// KeyName: history.eventsCacheShardMaxSizeInBytes
+	// Value type: Int
+	// Default value: 0
+	// Allowed filters: N/A
+	EventsCacheShardMaxSize
 	// EventsCacheGlobalInitialCount is initial count of global events cache
 	// KeyName: history.eventsCacheGlobalInitialSize
 	// Value type: Int
@@ -864,6 +899,20 @@ const (
 	// Default value: 1
 	// Allowed filters: N/A
 	TaskSchedulerDispatcherCount
+	// TaskSchedulerDomainRoundRobinWeight is the weight of a domain in weighted round robin task scheduler,
+	// only takes effect when TaskSchedulerEnableDomainRoundRobin is true
+	// KeyName: history.taskSchedulerDomainRoundRobinWeight
+	// Value type: Int
+	// Default value: 1
+	// Allowed filters: DomainName
+	TaskSchedulerDomainRoundRobinWeight
+	// TaskSchedulerDomainDispatchRPS is the max rate per second tasks of a domain are dispatched by weighted round robin task scheduler,
+	// 0 means no limit. Only takes effect when TaskSchedulerEnableDomainRoundRobin is true
+	// KeyName: history.taskSchedulerDomainDispatchRPS
+	// Value type: Int
+	// Default value: 0
+	// Allowed filters: DomainName
+	TaskSchedulerDomainDispatchRPS
 	// TaskCriticalRetryCount is the critical retry count for background tasks
 	// when task attempt exceeds this threshold:
 	// - task attempt metrics and additional error logs will be emitted
@@ -873,12 +922,25 @@ const (
 	// Default value: 50
 	// Allowed filters: N/A
 	TaskCriticalRetryCount
+	// TaskDLQMaxAttempts is the max number of attempts for a transfer or timer task failing with non-retryable errors,
+	// the task will be moved to the history task DLQ once the limit is reached. 0 means tasks are never moved to DLQ
+	// KeyName: history.taskDLQMaxAttempts
+	// Value type: Int
+	// Default value: 0
+	// Allowed filters: N/A
+	TaskDLQMaxAttempts
 	// QueueProcessorSplitMaxLevel is the max processing queue level
 	// KeyName: history.queueProcessorSplitMaxLevel
 	// Value type: Int
 	// Default value: 2 // 3 levels, start from 0
 	// Allowed filters: N/A
 	QueueProcessorSplitMaxLevel
+	// QueueProcessorDomainVirtualQueueMaxPollRPS is max poll rate per second for the virtual processing queue of a single domain
+	// KeyName: history.queueProcessorDomainVirtualQueueMaxPollRPS
+	// Value type: Int
+	// Default value: 20
+	// Allowed filters: DomainName
+	QueueProcessorDomainVirtualQueueMaxPollRPS
 	// TimerTaskBatchSize is batch size for timer processor to process tasks
 	// KeyName: history.timerTaskBatchSize
 	// Value type: Int
@@ -1042,6 +1104,13 @@ const (
 	// Default value: 4000
 	// Allowed filters: N/A
 	ReplicatorTaskDeleteBatchSize
+	// ReplicatorDomainLagMaxScanTasks is the max number of pending replication tasks scanned per shard and remote cluster
+	// to compute the replication lag of each domain
+	// KeyName: history.replicatorDomainLagMaxScanTasks
+	// Value type: Int
+	// Default value: 1000
+	// Allowed filters: N/A
+	ReplicatorDomainLagMaxScanTasks
 	// ReplicatorReadTaskMaxRetryCount is the number of read replication task retry time
 	// KeyName: history.replicatorReadTaskMaxRetryCount
 	// Value type: Int
@@ -1182,6 +1251,12 @@ const (
 	// Default value: 0
 	// Allowed filters: DomainName
 	MaxActivityCountDispatchByDomain
+	// DeleteWorkflowExecutionMaxChildScanEvents is the max history length that is scanned for child workflows when a workflow is deleted with its children
+	// KeyName: history.deleteWorkflowExecutionMaxChildScanEvents
+	// Value type: Int
+	// Default value: 10000
+	// Allowed filters: DomainName
+	DeleteWorkflowExecutionMaxChildScanEvents
 
 	// key for history replication
 
@@ -1191,6 +1266,32 @@ const (
 	// Default value: 1
 	// Allowed filters: N/A
 	ReplicationTaskFetcherParallelism
+	// ReplicationTaskStreamMaxBufferedBatchesPerShard is the max number of replication task batches of a shard buffered
+	// by the consumer of the kafka replication transport
+	// KeyName: history.ReplicationTaskStreamMaxBufferedBatchesPerShard
+	// Value type: Int
+	// Default value: 100
+	// Allowed filters: N/A
+	ReplicationTaskStreamMaxBufferedBatchesPerShard
+	// ReplicationDLQAutoRetryBatchSize is the number of replication DLQ messages read per page by the DLQ auto retry processor
+	// KeyName: history.ReplicationDLQAutoRetryBatchSize
+	// Value type: Int
+	// Default value: 100
+	// Allowed filters: N/A
+	ReplicationDLQAutoRetryBatchSize
+	// ReplicationDLQAutoRetryMaxAttempts is the max number of automatic retries of a transient replication DLQ message
+	// KeyName: history.ReplicationDLQAutoRetryMaxAttempts
+	// Value type: Int
+	// Default value: 10
+	// Allowed filters: N/A
+	ReplicationDLQAutoRetryMaxAttempts
+	// NDCConflictResolutionAuditMaxPendingWrites is the max number of NDC conflict resolution records of a shard
+	// waiting to be written, records beyond it are dropped
+	// KeyName: history.ndcConflictResolutionAuditMaxPendingWrites
+	// Value type: Int
+	// Default value: 100
+	// Allowed filters: N/A
+	NDCConflictResolutionAuditMaxPendingWrites
 	// ReplicationTaskProcessorErrorRetryMaxAttempts is the max retry attempts for applying replication tasks
 	// KeyName: history.ReplicationTaskProcessorErrorRetryMaxAttempts
 	// Value type: Int
@@ -1376,6 +1477,30 @@ const (
 	// Default value: 3
 	// Allowed filters: N/A
 	TimersScannerPeriodEnd
+	// ReplicationConsistencyScannerConcurrency is the concurrency of replication consistency scanner
+	// KeyName: worker.replicationConsistencyScannerConcurrency
+	// Value type: Int
+	// Default value: 5
+	// Allowed filters: N/A
+	ReplicationConsistencyScannerConcurrency
+	// ReplicationConsistencyScannerPersistencePageSize is the page size of execution persistence fetches in replication consistency scanner
+	// KeyName: worker.replicationConsistencyScannerPersistencePageSize
+	// Value type: Int
+	// Default value: 1000
+	// Allowed filters: N/A
+	ReplicationConsistencyScannerPersistencePageSize
+	// ReplicationConsistencyScannerBlobstoreFlushThreshold is threshold to flush blob store
+	// KeyName: worker.replicationConsistencyScannerBlobstoreFlushThreshold
+	// Value type: Int
+	// Default value: 100
+	// Allowed filters: N/A
+	ReplicationConsistencyScannerBlobstoreFlushThreshold
+	// ReplicationConsistencyScannerActivityBatchSize is the number of shards scanned by one activity of replication consistency scanner
+	// KeyName: worker.replicationConsistencyScannerActivityBatchSize
+	// Value type: Int
+	// Default value: 25
+	// Allowed filters: N/A
+	ReplicationConsistencyScannerActivityBatchSize
 	// ESAnalyzerMaxNumDomains defines how many domains to check
 	// KeyName: worker.ESAnalyzerMaxNumDomains
 	// Value type: int
@@ -1611,6 +1736,19 @@ const (
 	// Default value: false
 	// Allowed filters: N/A
 	EventsCacheGlobalEnable
+	// EnableHotShardTracking indicates whether history hosts track the request rate of shards and workflows
+	// KeyName: history.enableHotShardTracking
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	EnableHotShardTracking
+	// EnableHotShardLoadShedding indicates whether an overloaded history host rejects requests for its hottest
+	// shards and workflows. It requires EnableHotShardTracking
+	// KeyName: history.enableHotShardLoadShedding
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	EnableHotShardLoadShedding
 	// QueueProcessorEnableSplit indicates whether processing queue split policy should be enabled
 	// KeyName: history.queueProcessorEnableSplit
 	// Value type: Bool
@@ -1647,6 +1785,20 @@ const (
 	// Default value: true
 	// Allowed filters: N/A
 	QueueProcessorEnableLoadQueueStates
+	// QueueProcessorEnableDomainVirtualQueue indicates whether domains split out of the default processing queue
+	// should each get an independent virtual queue with its own read level and rate limit
+	// KeyName: history.queueProcessorEnableDomainVirtualQueue
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	QueueProcessorEnableDomainVirtualQueue
+	// TaskSchedulerEnableDomainRoundRobin indicates whether weighted round robin task scheduler should dispatch
+	// tasks of different domains from separate channels, so that the backlog of one domain won't delay other domains
+	// KeyName: history.taskSchedulerEnableDomainRoundRobin
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	TaskSchedulerEnableDomainRoundRobin
 	// QueueProcessorEnableGracefulSyncShutdown indicates whether processing queue should be shutdown gracefully & synchronously
 	// KeyName: history.queueProcessorEnableGracefulSyncShutdown
 	// Value type: Bool
@@ -1659,6 +1811,13 @@ const (
 	// Default value: false
 	// Allowed filters: N/A
 	ReplicationTaskFetcherEnableGracefulSyncShutdown
+	// EnableGracefulShardHandoff indicates whether shards should be released explicitly when their ownership moves
+	// to another host or the host is shutting down, instead of waiting for the new owner to steal them
+	// KeyName: history.enableGracefulShardHandoff
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	EnableGracefulShardHandoff
 	// TransferProcessorEnableValidator is whether validator should be enabled for transferQueueProcessor
 	// KeyName: history.transferProcessorEnableValidator
 	// Value type: Bool
@@ -1732,6 +1891,25 @@ const (
 	// Default value: true
 	// Allowed filters: DomainID, WorkflowID
 	EnableReplicationTaskGeneration
+	// ReplicatorEmitDomainLagMetrics is the flag to periodically scan the pending replication tasks of each shard to
+	// emit per domain replication lag metrics. The scan is bounded by history.replicatorDomainLagMaxScanTasks
+	// KeyName: history.replicatorEmitDomainLagMetrics
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	ReplicatorEmitDomainLagMetrics
+	// EnableReplicationDLQAutoRetry is the flag to enable the background processor that classifies replication DLQ messages and retries the transient ones
+	// KeyName: history.enableReplicationDLQAutoRetry
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	EnableReplicationDLQAutoRetry
+	// EnableNDCConflictResolutionAudit is the flag to persist an audit record whenever NDC switches the current branch or reapplies events of a workflow
+	// KeyName: history.enableNDCConflictResolutionAudit
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: DomainName
+	EnableNDCConflictResolutionAudit
 	// UseNewInitialFailoverVersion is a switch to issue a failover version based on the minFailoverVersion
 	// rather than the default initialFailoverVersion. USed as a per-domain migration switch
 	// KeyName: history.useNewInitialFailoverVersion
@@ -1891,6 +2069,30 @@ const (
 	// Default value: true
 	// Allowed filters: N/A
 	EnableFailoverManager
+	// EnableDomainMigrationWorker indicates if the worker that migrates workflow executions between domains is enabled
+	// KeyName: worker.enableDomainMigration
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	EnableDomainMigrationWorker
+	// EnableShardSplitWorker indicates if the worker that splits history shards is enabled
+	// KeyName: worker.enableShardSplit
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	EnableShardSplitWorker
+	// EnableAutoFailover indicates if the controller that fails over domains away from unhealthy remote clusters is enabled
+	// KeyName: worker.enableAutoFailover
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	EnableAutoFailover
+	// AutoFailoverDryRun indicates if the auto failover controller only records its decisions without failing over domains
+	// KeyName: worker.autoFailoverDryRun
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	AutoFailoverDryRun
 	// ConcreteExecutionFixerDomainAllow is which domains are allowed to be fixed by concrete fixer workflow
 	// KeyName: worker.concreteExecutionFixerDomainAllow
 	// Value type: Bool
@@ -1921,6 +2123,24 @@ const (
 	// Default value: false
 	// Allowed filters: DomainName
 	TimersFixerDomainAllow
+	// ReplicationConsistencyScannerEnabled is if replication consistency scanner should be started as part of worker.Scanner
+	// KeyName: worker.replicationConsistencyScannerEnabled
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	ReplicationConsistencyScannerEnabled
+	// ReplicationConsistencyFixerEnabled is if replication consistency fixer should be started as part of worker.Scanner
+	// KeyName: worker.replicationConsistencyFixerEnabled
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	ReplicationConsistencyFixerEnabled
+	// ReplicationConsistencyFixerDomainAllow is which domains are allowed to be fixed by replication consistency fixer workflow
+	// KeyName: worker.replicationConsistencyFixerDomainAllow
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: DomainName
+	ReplicationConsistencyFixerDomainAllow
 	// ConcreteExecutionFixerEnabled is if concrete execution fixer workflow is enabled
 	// KeyName: worker.concreteExecutionFixerEnabled
 	// Value type: Bool
@@ -2080,6 +2300,13 @@ const (
 	// Default value: 0.01
 	// Allowed filters: N/A
 	QueueProcessorRandomSplitProbability
+	// HotShardLoadSheddingCPUThreshold is the CPU utilization of a history host, between 0 and 1,
+	// above which the host is considered overloaded. 0 disables the check
+	// KeyName: history.hotShardLoadSheddingCPUThreshold
+	// Value type: Float64
+	// Default value: 0.9
+	// Allowed filters: N/A
+	HotShardLoadSheddingCPUThreshold
 	// QueueProcessorPollBackoffIntervalJitterCoefficient is backoff interval jitter coefficient
 	// KeyName: history.queueProcessorPollBackoffIntervalJitterCoefficient
 	// Value type: Float64
@@ -2210,6 +2437,13 @@ const (
 	// Default value: N/A
 	// TODO: https://github.com/uber/cadence/issues/3861
 	WorkerBlobIntegrityCheckProbability
+	// ReplicationConsistencyScannerSampleRate is the rate of executions checked by replication consistency scanner,
+	// 1 means all executions are checked
+	// KeyName: worker.replicationConsistencyScannerSampleRate
+	// Value type: Float64
+	// Default value: 1
+	// Allowed filters: N/A
+	ReplicationConsistencyScannerSampleRate
 
 	// LastFloatKey must be the last one in this const group
 	LastFloatKey
@@ -2374,6 +2608,12 @@ const (
 	// Default value: 1h (time.Hour)
 	// Allowed filters: N/A
 	EventsCacheTTL
+	// HotShardTrackingWindow is the sliding window over which the request rate of shards and workflows is measured
+	// KeyName: history.hotShardTrackingWindow
+	// Value type: Duration
+	// Default value: 1m (1*time.Minute)
+	// Allowed filters: N/A
+	HotShardTrackingWindow
 	// AcquireShardInterval is interval that timer used to acquire shard
 	// KeyName: history.acquireShardInterval
 	// Value type: Duration
@@ -2480,6 +2720,14 @@ const (
 	// Default value: 1s (1*time.Second)
 	// Allowed filters: N/A
 	TimerProcessorMaxTimeShift
+	// TimerProcessorInMemoryTimerHorizon is the horizon below which newly created active timers are kept in memory
+	// and fired directly by the timer queue processor instead of waiting for them to be loaded from persistence.
+	// 0 disables the in-memory timers.
+	// KeyName: history.timerProcessorInMemoryTimerHorizon
+	// Value type: Duration
+	// Default value: 0s (0*time.Second)
+	// Allowed filters: N/A
+	TimerProcessorInMemoryTimerHorizon
 	// TransferProcessorFailoverMaxStartJitterInterval is the max jitter interval for starting transfer
 	// failover queue processing. The actual jitter interval used will be a random duration between
 	// 0 and the max interval so that timer failover queue across different shards won't start at
@@ -2624,6 +2872,31 @@ const (
 	// Default value: 2s (2 * time.Second)
 	// Allowed filters: N/A
 	ReplicationTaskFetcherAggregationInterval
+	// ReplicationTaskPublisherInterval determines how frequently the replication tasks are published when the kafka replication transport is used
+	// KeyName: history.ReplicationTaskPublisherInterval
+	// Value type: Duration
+	// Default value: 2s (2 * time.Second)
+	// Allowed filters: N/A
+	ReplicationTaskPublisherInterval
+	// ReplicationDLQAutoRetryInterval determines how frequently the DLQ auto retry processor scans the replication DLQ,
+	// it is also the initial backoff of a transient message
+	// KeyName: history.ReplicationDLQAutoRetryInterval
+	// Value type: Duration
+	// Default value: 1m (1 * time.Minute)
+	// Allowed filters: N/A
+	ReplicationDLQAutoRetryInterval
+	// ReplicationDLQAutoRetryMaxBackoff is the max backoff between two automatic retries of a transient replication DLQ message
+	// KeyName: history.ReplicationDLQAutoRetryMaxBackoff
+	// Value type: Duration
+	// Default value: 1h (1 * time.Hour)
+	// Allowed filters: N/A
+	ReplicationDLQAutoRetryMaxBackoff
+	// NDCConflictResolutionAuditTTL is how long an NDC conflict resolution record is kept
+	// KeyName: history.ndcConflictResolutionAuditTTL
+	// Value type: Duration
+	// Default value: 7 days (7 * 24 * time.Hour)
+	// Allowed filters: DomainName
+	NDCConflictResolutionAuditTTL
 	// ReplicationTaskFetcherErrorRetryWait is the wait time when fetcher encounters error
 	// KeyName: history.ReplicationTaskFetcherErrorRetryWait
 	// Value type: Duration
@@ -2696,6 +2969,18 @@ const (
 	// Default value: 10m (time.Minute*10)
 	// Allowed filters: N/A
 	WorkerReplicationTaskMaxRetryDuration
+	// AutoFailoverHealthCheckInterval is the interval between two health checks of the remote clusters by the auto failover controller
+	// KeyName: worker.autoFailoverHealthCheckInterval
+	// Value type: Duration
+	// Default value: 30s (30*time.Second)
+	// Allowed filters: N/A
+	AutoFailoverHealthCheckInterval
+	// AutoFailoverConfirmationWindow is how long a remote cluster must stay unhealthy before the auto failover controller fails over its domains
+	// KeyName: worker.autoFailoverConfirmationWindow
+	// Value type: Duration
+	// Default value: 5m (5*time.Minute)
+	// Allowed filters: N/A
+	AutoFailoverConfirmationWindow
 	// ESAnalyzerTimeWindow defines the time window ElasticSearch Analyzer will consider while taking workflow averages
 	// KeyName: worker.ESAnalyzerTimeWindow
 	// Value type: Duration
@@ -2838,6 +3123,11 @@ var IntKeys = map[IntKey]DynamicInt{
 		DefaultValue: 0,
 		Filters:      nil,
 	},
+	TestGetIntPropertyFilteredByWorkflowTypeAndTaskListKey: {
+		KeyName:      "testGetIntPropertyFilteredByWorkflowTypeAndTaskListKey",
+		Description:  "",
+		DefaultValue: 0,
+	},
 	TransactionSizeLimit: {
 		KeyName:      "system.transactionSizeLimit",
 		Description:  "TransactionSizeLimit is the largest allowed transaction size to persistence",
@@ -2860,36 +3150,37 @@ var IntKeys = map[IntKey]DynamicInt{
 	},
 	BlobSizeLimitError: {
 		KeyName:      "limit.blobSize.error",
+		Filters:      []Filter{DomainName, WorkflowType, TaskListName},
 		Description:  "BlobSizeLimitError is the per event blob size limit",
 		DefaultValue: 2 * 1024 * 1024,
 	},
 	BlobSizeLimitWarn: {
 		KeyName:      "limit.blobSize.warn",
-		Filters:      []Filter{DomainName},
+		Filters:      []Filter{DomainName, WorkflowType, TaskListName},
 		Description:  "BlobSizeLimitWarn is the per event blob size limit for warning",
 		DefaultValue: 256 * 1024,
 	},
 	HistorySizeLimitError: {
 		KeyName:      "limit.historySize.error",
-		Filters:      []Filter{DomainName},
+		Filters:      []Filter{DomainName, WorkflowType, TaskListName},
 		Description:  "HistorySizeLimitError is the per workflow execution history size limit",
 		DefaultValue: 200 * 1024 * 1024,
 	},
 	HistorySizeLimitWarn: {
 		KeyName:      "limit.historySize.warn",
-		Filters:      []Filter{DomainName},
+		Filters:      []Filter{DomainName, WorkflowType, TaskListName},
 		Description:  "HistorySizeLimitWarn is the per workflow execution history size limit for warning",
 		DefaultValue: 50 * 1024 * 1024,
 	},
 	HistoryCountLimitError: {
 		KeyName:      "limit.historyCount.error",
-		Filters:      []Filter{DomainName},
+		Filters:      []Filter{DomainName, WorkflowType, TaskListName},
 		Description:  "HistoryCountLimitError is the per workflow execution history event count limit",
 		DefaultValue: 200 * 1024,
 	},
 	HistoryCountLimitWarn: {
 		KeyName:      "limit.historyCount.warn",
-		Filters:      []Filter{DomainName},
+		Filters:      []Filter{DomainName, WorkflowType, TaskListName},
 		Description:  "HistoryCountLimitWarn is the per workflow execution history event count limit for warning",
 		DefaultValue: 50 * 1024,
 	},
@@ -3103,6 +3394,12 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "FrontendMaxBadBinaries is the max number of bad binaries in domain config",
 		DefaultValue: 10,
 	},
+	FrontendFailoverHistoryMaxSize: {
+		KeyName:      "frontend.failoverHistoryMaxSize",
+		Filters:      []Filter{DomainName},
+		Description:  "FrontendFailoverHistoryMaxSize is the max number of failovers recorded in the failover history of a domain",
+		DefaultValue: 5,
+	},
 	SearchAttributesNumberOfKeysLimit: {
 		KeyName:      "frontend.searchAttributesNumberOfKeysLimit",
 		Filters:      []Filter{DomainName},
@@ -3232,6 +3529,16 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "HistoryRPS is request rate per second for each history host",
 		DefaultValue: 3000,
 	},
+	HotShardLoadSheddingTopN: {
+		KeyName:      "history.hotShardLoadSheddingTopN",
+		Description:  "HotShardLoadSheddingTopN is the number of hottest shards and workflows on a history host whose requests are rejected when load shedding is enabled and the host is overloaded",
+		DefaultValue: 3,
+	},
+	HotShardLoadSheddingPendingTasksThreshold: {
+		KeyName:      "history.hotShardLoadSheddingPendingTasksThreshold",
+		Description:  "HotShardLoadSheddingPendingTasksThreshold is the total number of pending queue tasks on a history host above which the host is considered overloaded. 0 disables the check",
+		DefaultValue: 0,
+	},
 	HistoryPersistenceMaxQPS: {
 		KeyName:      "history.persistenceMaxQPS",
 		Description:  "HistoryPersistenceMaxQPS is the max qps history host can query DB",
@@ -3264,6 +3571,11 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "HistoryCacheMaxSize is max size of history cache",
 		DefaultValue: 512,
 	},
+	HistoryCacheMaxSizeInBytes: {
+		KeyName:      "history.cacheMaxSizeInBytes",
+		Description:  "HistoryCacheMaxSizeInBytes is max size of history cache in bytes, estimated from the size of mutable states. The history cache is bounded by HistoryCacheMaxSize if this is 0",
+		DefaultValue: 0,
+	},
 	EventsCacheInitialCount: {
 		KeyName:      "history.eventsCacheInitialSize",
 		Description:  "EventsCacheInitialCount is initial count of events cache",
@@ -3279,6 +3591,11 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "EventsCacheMaxSize is max size of events cache in bytes",
 		DefaultValue: 0,
 	},
+	EventsCacheShardMaxSize: {
+		KeyName:      "history.eventsCacheShardMaxSizeInBytes",
+		Description:  "EventsCacheShardMaxSize is max size of the per-shard events cache in bytes. The per-shard events cache is bounded by EventsCacheMaxCount if this is 0",
+		DefaultValue: 0,
+	},
 	EventsCacheGlobalInitialCount: {
 		KeyName:      "history.eventsCacheGlobalInitialSize",
 		Description:  "EventsCacheGlobalInitialCount is initial count of global events cache",
@@ -3330,16 +3647,39 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "TaskSchedulerDispatcherCount is the number of task dispatcher in task scheduler (only applies to host level task scheduler)",
 		DefaultValue: 1,
 	},
+	TaskSchedulerDomainRoundRobinWeight: {
+		KeyName:      "history.taskSchedulerDomainRoundRobinWeight",
+		Filters:      []Filter{DomainName},
+		Description:  "TaskSchedulerDomainRoundRobinWeight is the weight of a domain in weighted round robin task scheduler, only takes effect when TaskSchedulerEnableDomainRoundRobin is true",
+		DefaultValue: 1,
+	},
+	TaskSchedulerDomainDispatchRPS: {
+		KeyName:      "history.taskSchedulerDomainDispatchRPS",
+		Filters:      []Filter{DomainName},
+		Description:  "TaskSchedulerDomainDispatchRPS is the max rate per second tasks of a domain are dispatched by weighted round robin task scheduler, 0 means no limit. Only takes effect when TaskSchedulerEnableDomainRoundRobin is true",
+		DefaultValue: 0,
+	},
 	TaskCriticalRetryCount: {
 		KeyName:      "history.taskCriticalRetryCount",
 		Description:  "TaskCriticalRetryCount is the critical retry count for background tasks, when task attempt exceeds this threshold:- task attempt metrics and additional error logs will be emitted- task priority will be lowered",
 		DefaultValue: 50,
 	},
+	TaskDLQMaxAttempts: {
+		KeyName:      "history.taskDLQMaxAttempts",
+		Description:  "TaskDLQMaxAttempts is the max number of attempts for a transfer or timer task failing with non-retryable errors, the task will be moved to the history task DLQ once the limit is reached. 0 means tasks are never moved to DLQ",
+		DefaultValue: 0,
+	},
 	QueueProcessorSplitMaxLevel: {
 		KeyName:      "history.queueProcessorSplitMaxLevel",
 		Description:  "QueueProcessorSplitMaxLevel is the max processing queue level",
 		DefaultValue: 2, // 3 levels, start from 0
 	},
+	QueueProcessorDomainVirtualQueueMaxPollRPS: {
+		KeyName:      "history.queueProcessorDomainVirtualQueueMaxPollRPS",
+		Filters:      []Filter{DomainName},
+		Description:  "QueueProcessorDomainVirtualQueueMaxPollRPS is max poll rate per second for the virtual processing queue of a single domain",
+		DefaultValue: 20,
+	},
 	TimerTaskBatchSize: {
 		KeyName:      "history.timerTaskBatchSize",
 		Description:  "TimerTaskBatchSize is batch size for timer processor to process tasks",
@@ -3471,6 +3811,11 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "ReplicatorTaskDeleteBatchSize is batch size for ReplicatorProcessor to delete replication tasks",
 		DefaultValue: 4000,
 	},
+	ReplicatorDomainLagMaxScanTasks: {
+		KeyName:      "history.replicatorDomainLagMaxScanTasks",
+		Description:  "ReplicatorDomainLagMaxScanTasks is the max number of pending replication tasks scanned per shard and remote cluster to compute the replication lag of each domain",
+		DefaultValue: 1000,
+	},
 	ReplicatorReadTaskMaxRetryCount: {
 		KeyName:      "history.replicatorReadTaskMaxRetryCount",
 		Description:  "ReplicatorReadTaskMaxRetryCount is the number of read replication task retry time",
@@ -3593,11 +3938,37 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "MaxActivityCountDispatchByDomain max # of activity tasks to dispatch to matching before creating transfer tasks. This is an performance optimization to skip activity scheduling efforts.",
 		DefaultValue: 0,
 	},
+	DeleteWorkflowExecutionMaxChildScanEvents: {
+		KeyName:      "history.deleteWorkflowExecutionMaxChildScanEvents",
+		Filters:      []Filter{DomainName},
+		Description:  "DeleteWorkflowExecutionMaxChildScanEvents is the max history length that is scanned for child workflows when a workflow is deleted with its children",
+		DefaultValue: 10000,
+	},
 	ReplicationTaskFetcherParallelism: {
 		KeyName:      "history.ReplicationTaskFetcherParallelism",
 		Description:  "ReplicationTaskFetcherParallelism determines how many go routines we spin up for fetching tasks",
 		DefaultValue: 1,
 	},
+	ReplicationTaskStreamMaxBufferedBatchesPerShard: {
+		KeyName:      "history.ReplicationTaskStreamMaxBufferedBatchesPerShard",
+		Description:  "ReplicationTaskStreamMaxBufferedBatchesPerShard is the max number of replication task batches of a shard buffered by the consumer of the kafka replication transport",
+		DefaultValue: 100,
+	},
+	ReplicationDLQAutoRetryBatchSize: {
+		KeyName:      "history.ReplicationDLQAutoRetryBatchSize",
+		Description:  "ReplicationDLQAutoRetryBatchSize is the number of replication DLQ messages read per page by the DLQ auto retry processor",
+		DefaultValue: 100,
+	},
+	ReplicationDLQAutoRetryMaxAttempts: {
+		KeyName:      "history.ReplicationDLQAutoRetryMaxAttempts",
+		Description:  "ReplicationDLQAutoRetryMaxAttempts is the max number of automatic retries of a transient replication DLQ message",
+		DefaultValue: 10,
+	},
+	NDCConflictResolutionAuditMaxPendingWrites: {
+		KeyName:      "history.ndcConflictResolutionAuditMaxPendingWrites",
+		Description:  "NDCConflictResolutionAuditMaxPendingWrites is the max number of NDC conflict resolution records of a shard waiting to be written, records beyond it are dropped",
+		DefaultValue: 100,
+	},
 	ReplicationTaskProcessorErrorRetryMaxAttempts: {
 		KeyName:      "history.ReplicationTaskProcessorErrorRetryMaxAttempts",
 		Filters:      []Filter{ShardID},
@@ -3751,6 +4122,26 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "TimersScannerPeriodEnd is interval end for fetching scheduled timers",
 		DefaultValue: 3,
 	},
+	ReplicationConsistencyScannerConcurrency: {
+		KeyName:      "worker.replicationConsistencyScannerConcurrency",
+		Description:  "ReplicationConsistencyScannerConcurrency is the concurrency of replication consistency scanner",
+		DefaultValue: 5,
+	},
+	ReplicationConsistencyScannerPersistencePageSize: {
+		KeyName:      "worker.replicationConsistencyScannerPersistencePageSize",
+		Description:  "ReplicationConsistencyScannerPersistencePageSize is the page size of execution persistence fetches in replication consistency scanner",
+		DefaultValue: 1000,
+	},
+	ReplicationConsistencyScannerBlobstoreFlushThreshold: {
+		KeyName:      "worker.replicationConsistencyScannerBlobstoreFlushThreshold",
+		Description:  "ReplicationConsistencyScannerBlobstoreFlushThreshold is threshold to flush blob store",
+		DefaultValue: 100,
+	},
+	ReplicationConsistencyScannerActivityBatchSize: {
+		KeyName:      "worker.replicationConsistencyScannerActivityBatchSize",
+		Description:  "ReplicationConsistencyScannerActivityBatchSize is the number of shards scanned by one activity of replication consistency scanner",
+		DefaultValue: 25,
+	},
 	ESAnalyzerMaxNumDomains: {
 		KeyName:      "worker.ESAnalyzerMaxNumDomains",
 		Description:  "ESAnalyzerMaxNumDomains defines how many domains to check",
@@ -3978,6 +4369,16 @@ var BoolKeys = map[BoolKey]DynamicBool{
 		Description:  "EventsCacheGlobalEnable is enables global cache over all history shards",
 		DefaultValue: false,
 	},
+	EnableHotShardTracking: {
+		KeyName:      "history.enableHotShardTracking",
+		Description:  "EnableHotShardTracking indicates whether history hosts track the request rate of shards and workflows",
+		DefaultValue: false,
+	},
+	EnableHotShardLoadShedding: {
+		KeyName:      "history.enableHotShardLoadShedding",
+		Description:  "EnableHotShardLoadShedding indicates whether an overloaded history host rejects requests for its hottest shards and workflows. It requires EnableHotShardTracking",
+		DefaultValue: false,
+	},
 	QueueProcessorEnableSplit: {
 		KeyName:      "history.queueProcessorEnableSplit",
 		Description:  "QueueProcessorEnableSplit indicates whether processing queue split policy should be enabled",
@@ -4011,6 +4412,16 @@ var BoolKeys = map[BoolKey]DynamicBool{
 		Description:  "QueueProcessorEnableLoadQueueStates indicates whether processing queue states should be loaded",
 		DefaultValue: true,
 	},
+	QueueProcessorEnableDomainVirtualQueue: {
+		KeyName:      "history.queueProcessorEnableDomainVirtualQueue",
+		Description:  "QueueProcessorEnableDomainVirtualQueue indicates whether domains split out of the default processing queue should each get an independent virtual queue with its own read level and rate limit",
+		DefaultValue: false,
+	},
+	TaskSchedulerEnableDomainRoundRobin: {
+		KeyName:      "history.taskSchedulerEnableDomainRoundRobin",
+		Description:  "TaskSchedulerEnableDomainRoundRobin indicates whether weighted round robin task scheduler should dispatch tasks of different domains from separate channels, so that the backlog of one domain won't delay other domains",
+		DefaultValue: false,
+	},
 	QueueProcessorEnableGracefulSyncShutdown: {
 		KeyName:      "history.queueProcessorEnableGracefulSyncShutdown",
 		Description:  "QueueProcessorEnableGracefulSyncShutdown indicates whether processing queue should be shutdown gracefully & synchronously",
@@ -4021,6 +4432,11 @@ var BoolKeys = map[BoolKey]DynamicBool{
 		Description:  "ReplicationTaskFetcherEnableGracefulSyncShutdown is whether we should gracefully drain replication task fetcher on shutdown",
 		DefaultValue: false,
 	},
+	EnableGracefulShardHandoff: {
+		KeyName:      "history.enableGracefulShardHandoff",
+		Description:  "EnableGracefulShardHandoff indicates whether shards should be released explicitly when their ownership moves to another host or the host is shutting down, instead of waiting for the new owner to steal them",
+		DefaultValue: false,
+	},
 	TransferProcessorEnableValidator: {
 		KeyName:      "history.transferProcessorEnableValidator",
 		Description:  "TransferProcessorEnableValidator is whether validator should be enabled for transferQueueProcessor",
@@ -4089,6 +4505,22 @@ var BoolKeys = map[BoolKey]DynamicBool{
 		Description:  "EnableReplicationTaskGeneration is the flag to control replication generation",
 		DefaultValue: true,
 	},
+	ReplicatorEmitDomainLagMetrics: {
+		KeyName:      "history.replicatorEmitDomainLagMetrics",
+		Description:  "ReplicatorEmitDomainLagMetrics is the flag to periodically scan the pending replication tasks of each shard to emit per domain replication lag metrics",
+		DefaultValue: false,
+	},
+	EnableReplicationDLQAutoRetry: {
+		KeyName:      "history.enableReplicationDLQAutoRetry",
+		Description:  "EnableReplicationDLQAutoRetry is the flag to enable the background processor that classifies replication DLQ messages and retries the transient ones",
+		DefaultValue: false,
+	},
+	EnableNDCConflictResolutionAudit: {
+		KeyName:      "history.enableNDCConflictResolutionAudit",
+		Filters:      []Filter{DomainName},
+		Description:  "EnableNDCConflictResolutionAudit is the flag to persist an audit record whenever NDC switches the current branch or reapplies events of a workflow",
+		DefaultValue: false,
+	},
 	UseNewInitialFailoverVersion: {
 		KeyName:      "history.useNewInitialFailoverVersion",
 		Description:  "use the minInitialFailover version",
@@ -4195,6 +4627,26 @@ var BoolKeys = map[BoolKey]DynamicBool{
 		Description:  "EnableFailoverManager indicates if failover manager is enabled",
 		DefaultValue: true,
 	},
+	EnableDomainMigrationWorker: {
+		KeyName:      "worker.enableDomainMigration",
+		Description:  "EnableDomainMigrationWorker indicates if the worker that migrates workflow executions between domains is enabled",
+		DefaultValue: false,
+	},
+	EnableShardSplitWorker: {
+		KeyName:      "worker.enableShardSplit",
+		Description:  "EnableShardSplitWorker indicates if the worker that splits history shards is enabled",
+		DefaultValue: false,
+	},
+	EnableAutoFailover: {
+		KeyName:      "worker.enableAutoFailover",
+		Description:  "EnableAutoFailover indicates if the controller that fails over domains away from unhealthy remote clusters is enabled",
+		DefaultValue: false,
+	},
+	AutoFailoverDryRun: {
+		KeyName:      "worker.autoFailoverDryRun",
+		Description:  "AutoFailoverDryRun indicates if the auto failover controller only records its decisions without failing over domains",
+		DefaultValue: false,
+	},
 	ConcreteExecutionFixerDomainAllow: {
 		KeyName:      "worker.concreteExecutionFixerDomainAllow",
 		Filters:      []Filter{DomainName},
@@ -4223,6 +4675,22 @@ var BoolKeys = map[BoolKey]DynamicBool{
 		Description:  "TimersFixerDomainAllow is which domains are allowed to be fixed by timer fixer workflow",
 		DefaultValue: false,
 	},
+	ReplicationConsistencyScannerEnabled: {
+		KeyName:      "worker.replicationConsistencyScannerEnabled",
+		Description:  "ReplicationConsistencyScannerEnabled is if replication consistency scanner should be started as part of worker.Scanner",
+		DefaultValue: false,
+	},
+	ReplicationConsistencyFixerEnabled: {
+		KeyName:      "worker.replicationConsistencyFixerEnabled",
+		Description:  "ReplicationConsistencyFixerEnabled is if replication consistency fixer should be started as part of worker.Scanner",
+		DefaultValue: false,
+	},
+	ReplicationConsistencyFixerDomainAllow: {
+		KeyName:      "worker.replicationConsistencyFixerDomainAllow",
+		Filters:      []Filter{DomainName},
+		Description:  "ReplicationConsistencyFixerDomainAllow is which domains are allowed to be fixed by replication consistency fixer workflow",
+		DefaultValue: false,
+	},
 	ConcreteExecutionFixerEnabled: {
 		KeyName:      "worker.concreteExecutionFixerEnabled",
 		Description:  "ConcreteExecutionFixerEnabled is if concrete execution fixer workflow is enabled",
@@ -4384,6 +4852,11 @@ var FloatKeys = map[FloatKey]DynamicFloat{
 		Description:  "QueueProcessorRandomSplitProbability is the probability for a domain to be split to a new processing queue",
 		DefaultValue: 0.01,
 	},
+	HotShardLoadSheddingCPUThreshold: {
+		KeyName:      "history.hotShardLoadSheddingCPUThreshold",
+		Description:  "HotShardLoadSheddingCPUThreshold is the CPU utilization of a history host, between 0 and 1, above which the host is considered overloaded. 0 disables the check",
+		DefaultValue: 0.9,
+	},
 	QueueProcessorPollBackoffIntervalJitterCoefficient: {
 		KeyName:      "history.queueProcessorPollBackoffIntervalJitterCoefficient",
 		Description:  "QueueProcessorPollBackoffIntervalJitterCoefficient is backoff interval jitter coefficient",
@@ -4496,6 +4969,11 @@ var FloatKeys = map[FloatKey]DynamicFloat{
 		Description:  "WorkerBlobIntegrityCheckProbability controls the probability of running an integrity check for any given archival",
 		DefaultValue: 0.002,
 	},
+	ReplicationConsistencyScannerSampleRate: {
+		KeyName:      "worker.replicationConsistencyScannerSampleRate",
+		Description:  "ReplicationConsistencyScannerSampleRate is the rate of executions checked by replication consistency scanner, 1 means all executions are checked",
+		DefaultValue: 1.0,
+	},
 }
 
 var StringKeys = map[StringKey]DynamicString{
@@ -4663,6 +5141,11 @@ var DurationKeys = map[DurationKey]DynamicDuration{
 		Description:  "EventsCacheTTL is TTL of events cache",
 		DefaultValue: time.Hour,
 	},
+	HotShardTrackingWindow: {
+		KeyName:      "history.hotShardTrackingWindow",
+		Description:  "HotShardTrackingWindow is the sliding window over which the request rate of shards and workflows is measured",
+		DefaultValue: time.Minute,
+	},
 	AcquireShardInterval: {
 		KeyName:      "history.acquireShardInterval",
 		Description:  "AcquireShardInterval is interval that timer used to acquire shard",
@@ -4751,6 +5234,11 @@ var DurationKeys = map[DurationKey]DynamicDuration{
 		Description:  "TimerProcessorMaxTimeShift is the max shift timer processor can have",
 		DefaultValue: time.Second,
 	},
+	TimerProcessorInMemoryTimerHorizon: {
+		KeyName:      "history.timerProcessorInMemoryTimerHorizon",
+		Description:  "TimerProcessorInMemoryTimerHorizon is the horizon below which newly created active timers are kept in memory and fired directly by the timer queue processor. 0 disables the in-memory timers",
+		DefaultValue: 0,
+	},
 	TransferProcessorFailoverMaxStartJitterInterval: {
 		KeyName:      "history.transferProcessorFailoverMaxStartJitterInterval",
 		Description:  "TransferProcessorFailoverMaxStartJitterInterval is the max jitter interval for starting transfer failover queue processing. The actual jitter interval used will be a random duration between 0 and the max interval so that timer failover queue across different shards won't start at the same time",
@@ -4870,6 +5358,27 @@ var DurationKeys = map[DurationKey]DynamicDuration{
 		Description:  "ReplicationTaskFetcherAggregationInterval determines how frequently the fetch requests are sent",
 		DefaultValue: time.Second * 2,
 	},
+	ReplicationTaskPublisherInterval: {
+		KeyName:      "history.ReplicationTaskPublisherInterval",
+		Description:  "ReplicationTaskPublisherInterval determines how frequently the replication tasks are published when the kafka replication transport is used",
+		DefaultValue: time.Second * 2,
+	},
+	ReplicationDLQAutoRetryInterval: {
+		KeyName:      "history.ReplicationDLQAutoRetryInterval",
+		Description:  "ReplicationDLQAutoRetryInterval determines how frequently the DLQ auto retry processor scans the replication DLQ, it is also the initial backoff of a transient message",
+		DefaultValue: time.Minute,
+	},
+	ReplicationDLQAutoRetryMaxBackoff: {
+		KeyName:      "history.ReplicationDLQAutoRetryMaxBackoff",
+		Description:  "ReplicationDLQAutoRetryMaxBackoff is the max backoff between two automatic retries of a transient replication DLQ message",
+		DefaultValue: time.Hour,
+	},
+	NDCConflictResolutionAuditTTL: {
+		KeyName:      "history.ndcConflictResolutionAuditTTL",
+		Filters:      []Filter{DomainName},
+		Description:  "NDCConflictResolutionAuditTTL is how long an NDC conflict resolution record is kept",
+		DefaultValue: 7 * 24 * time.Hour,
+	},
 	ReplicationTaskFetcherErrorRetryWait: {
 		KeyName:      "history.ReplicationTaskFetcherErrorRetryWait",
 		Description:  "ReplicationTaskFetcherErrorRetryWait is the wait time when fetcher encounters error",
@@ -4937,6 +5446,16 @@ var DurationKeys = map[DurationKey]DynamicDuration{
 		Description:  "WorkerReplicationTaskMaxRetryDuration is the max retry duration for any task",
 		DefaultValue: time.Minute * 10,
 	},
+	AutoFailoverHealthCheckInterval: {
+		KeyName:      "worker.autoFailoverHealthCheckInterval",
+		Description:  "AutoFailoverHealthCheckInterval is the interval between two health checks of the remote clusters by the auto failover controller",
+		DefaultValue: time.Second * 30,
+	},
+	AutoFailoverConfirmationWindow: {
+		KeyName:      "worker.autoFailoverConfirmationWindow",
+		Description:  "AutoFailoverConfirmationWindow is how long a remote cluster must stay unhealthy before the auto failover controller fails over its domains",
+		DefaultValue: time.Minute * 5,
+	},
 	ESAnalyzerTimeWindow: {
 		KeyName:      "worker.ESAnalyzerTimeWindow",
 		Description:  "ESAnalyzerTimeWindow defines the time window ElasticSearch Analyzer will consider while taking workflow averages",
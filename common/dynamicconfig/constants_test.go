@@ -128,7 +128,7 @@ func (s *constantSuite) TestIntKey() {
 			expectedString:       "limit.blobSize.warn",
 			expectedDescription:  "BlobSizeLimitWarn is the per event blob size limit for warning",
 			expectedDefaultValue: 256 * 1024,
-			expectedFilters:      []Filter{DomainName},
+			expectedFilters:      []Filter{DomainName, WorkflowType, TaskListName},
 		},
 	}
 
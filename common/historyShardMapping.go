@@ -0,0 +1,247 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/dgryski/go-farm"
+)
+
+// maxHistoryShardSplitDepth is the number of bits in the hash used for splitting shards
+const maxHistoryShardSplitDepth = 32
+
+// HistoryShardSplitState is the migration state of a history shard split
+type HistoryShardSplitState int
+
+const (
+	// HistoryShardSplitStateCompleted means the moving workflows are owned by the child shard
+	HistoryShardSplitStateCompleted HistoryShardSplitState = iota
+	// HistoryShardSplitStateCopying means the child shard is created and the moving workflows are being copied
+	// into it, while they are still owned and processed by the parent shard
+	HistoryShardSplitStateCopying
+	// HistoryShardSplitStateFenced means the moving workflows are still owned by the parent shard, but requests
+	// and tasks for them are rejected until the split is completed
+	HistoryShardSplitStateFenced
+)
+
+// HistoryShardOwnership describes how a shard should handle the requests and tasks of a workflow
+type HistoryShardOwnership int
+
+const (
+	// HistoryShardOwned means the workflow is owned by the shard
+	HistoryShardOwned HistoryShardOwnership = iota
+	// HistoryShardMoving means the workflow is being moved by a split, requests and tasks need to be retried later
+	HistoryShardMoving
+	// HistoryShardNotOwned means the workflow is not owned by the shard, e.g. it has been moved out by a split
+	HistoryShardNotOwned
+)
+
+type (
+	// HistoryShardSplit records that part of the workflows owned by the parent shard
+	// are moved to a newly created child shard. Only completed splits change the ownership of workflows.
+	HistoryShardSplit struct {
+		ParentShardID int                    `json:"parent_shard_id"`
+		ChildShardID  int                    `json:"child_shard_id"`
+		State         HistoryShardSplitState `json:"state,omitempty"`
+	}
+
+	// HistoryShardMapping describes how workflow IDs and domain IDs are mapped to history shards.
+	// The mapping starts with NumberOfShards shards and is extended by splitting existing shards,
+	// each split bumps the mapping version and creates a new shard with the next available shard ID.
+	HistoryShardMapping struct {
+		Version        int64               `json:"version"`
+		NumberOfShards int                 `json:"number_of_shards"`
+		Splits         []HistoryShardSplit `json:"splits,omitempty"`
+	}
+)
+
+// NewHistoryShardMapping creates the initial history shard mapping with no splits
+func NewHistoryShardMapping(numberOfShards int) *HistoryShardMapping {
+	return &HistoryShardMapping{
+		NumberOfShards: numberOfShards,
+	}
+}
+
+// TotalShards returns the total number of history shards, including the ones created by splits.
+// The child shard of a split in progress is included so that it can be acquired before the split completes.
+func (m *HistoryShardMapping) TotalShards() int {
+	return m.NumberOfShards + len(m.Splits)
+}
+
+// WorkflowIDToShard maps a workflowID to a shardID
+func (m *HistoryShardMapping) WorkflowIDToShard(workflowID string) int {
+	return m.keyToShard([]byte(workflowID), false)
+}
+
+// DomainIDToShard maps a domainID to a shardID
+func (m *HistoryShardMapping) DomainIDToShard(domainID string) int {
+	return m.keyToShard([]byte(domainID), false)
+}
+
+// WorkflowOwnership returns how the given shard should handle the requests and tasks of a workflow
+func (m *HistoryShardMapping) WorkflowOwnership(workflowID string, shardID int) HistoryShardOwnership {
+	key := []byte(workflowID)
+	owner := m.keyToShard(key, false)
+	if split := m.SplitInProgress(); split != nil && m.keyToShard(key, true) != owner {
+		if shardID == split.ChildShardID || split.State == HistoryShardSplitStateFenced {
+			return HistoryShardMoving
+		}
+	}
+	if shardID != owner {
+		return HistoryShardNotOwned
+	}
+	return HistoryShardOwned
+}
+
+// IsWorkflowMovingToShard returns true if the workflow is moved into the given shard by the split in progress
+func (m *HistoryShardMapping) IsWorkflowMovingToShard(workflowID string, shardID int) bool {
+	split := m.SplitInProgress()
+	return split != nil && split.ChildShardID == shardID && m.keyToShard([]byte(workflowID), true) == shardID
+}
+
+// SplitInProgress returns the split which is not completed yet, or nil if there is none
+func (m *HistoryShardMapping) SplitInProgress() *HistoryShardSplit {
+	if len(m.Splits) == 0 {
+		return nil
+	}
+	if split := m.Splits[len(m.Splits)-1]; split.State != HistoryShardSplitStateCompleted {
+		return &split
+	}
+	return nil
+}
+
+// Split returns a new mapping in which the given shard starts splitting into two.
+// The new shard ID is always TotalShards() of the current mapping. Workflows are not moved
+// until the split is fenced and completed, and only one split can be in progress at a time.
+func (m *HistoryShardMapping) Split(parentShardID int) (*HistoryShardMapping, error) {
+	if parentShardID < 0 || parentShardID >= m.TotalShards() {
+		return nil, fmt.Errorf("shard ID %v is out of range [0, %v)", parentShardID, m.TotalShards())
+	}
+	if split := m.SplitInProgress(); split != nil {
+		return nil, fmt.Errorf("split of shard %v into shard %v is still in progress", split.ParentShardID, split.ChildShardID)
+	}
+	if m.splitDepth(parentShardID) >= maxHistoryShardSplitDepth {
+		return nil, fmt.Errorf("shard ID %v can not be split more than %v times", parentShardID, maxHistoryShardSplitDepth)
+	}
+
+	splits := make([]HistoryShardSplit, 0, len(m.Splits)+1)
+	splits = append(splits, m.Splits...)
+	splits = append(splits, HistoryShardSplit{
+		ParentShardID: parentShardID,
+		ChildShardID:  m.TotalShards(),
+		State:         HistoryShardSplitStateCopying,
+	})
+	return &HistoryShardMapping{
+		Version:        m.Version + 1,
+		NumberOfShards: m.NumberOfShards,
+		Splits:         splits,
+	}, nil
+}
+
+// FenceSplit returns a new mapping in which the split in progress stops accepting requests and tasks for the moving workflows
+func (m *HistoryShardMapping) FenceSplit() (*HistoryShardMapping, error) {
+	return m.advanceSplit(HistoryShardSplitStateCopying, HistoryShardSplitStateFenced)
+}
+
+// CompleteSplit returns a new mapping in which the moving workflows of the fenced split are owned by the child shard
+func (m *HistoryShardMapping) CompleteSplit() (*HistoryShardMapping, error) {
+	return m.advanceSplit(HistoryShardSplitStateFenced, HistoryShardSplitStateCompleted)
+}
+
+func (m *HistoryShardMapping) advanceSplit(from, to HistoryShardSplitState) (*HistoryShardMapping, error) {
+	split := m.SplitInProgress()
+	if split == nil {
+		return nil, fmt.Errorf("no split is in progress")
+	}
+	if split.State != from {
+		return nil, fmt.Errorf("split of shard %v into shard %v is in state %v, expecting %v", split.ParentShardID, split.ChildShardID, split.State, from)
+	}
+
+	splits := make([]HistoryShardSplit, len(m.Splits))
+	copy(splits, m.Splits)
+	splits[len(splits)-1].State = to
+	return &HistoryShardMapping{
+		Version:        m.Version + 1,
+		NumberOfShards: m.NumberOfShards,
+		Splits:         splits,
+	}, nil
+}
+
+// Validate checks that every split refers to an existing parent shard and creates the next shard ID
+func (m *HistoryShardMapping) Validate() error {
+	if m.NumberOfShards <= 0 {
+		return fmt.Errorf("invalid number of shards: %v", m.NumberOfShards)
+	}
+	for i, split := range m.Splits {
+		childShardID := m.NumberOfShards + i
+		if split.ChildShardID != childShardID {
+			return fmt.Errorf("split %v creates shard %v, expecting %v", i, split.ChildShardID, childShardID)
+		}
+		if split.ParentShardID < 0 || split.ParentShardID >= childShardID {
+			return fmt.Errorf("split %v has invalid parent shard %v", i, split.ParentShardID)
+		}
+		if split.State != HistoryShardSplitStateCompleted && i != len(m.Splits)-1 {
+			return fmt.Errorf("split %v is in progress but is not the last split", i)
+		}
+	}
+	return nil
+}
+
+// keyToShard first maps the key to one of the initial shards, then walks through the splits in order.
+// All keys owned by a shard have gone through the same splits, so every split of the shard consumes
+// the next bit of a second, independent hash to decide whether the key stays or moves to the child shard.
+// The split in progress, if any, is only followed when includeInProgress is true.
+func (m *HistoryShardMapping) keyToShard(key []byte, includeInProgress bool) int {
+	shardID := int(farm.Fingerprint32(key) % uint32(m.NumberOfShards))
+	if len(m.Splits) == 0 {
+		return shardID
+	}
+
+	splitHash := uint32(farm.Fingerprint64(key) >> 32)
+	depth := 0
+	for _, split := range m.Splits {
+		if split.ParentShardID != shardID || depth >= maxHistoryShardSplitDepth {
+			continue
+		}
+		if split.State != HistoryShardSplitStateCompleted && !includeInProgress {
+			break
+		}
+		if splitHash&(1<<depth) != 0 {
+			shardID = split.ChildShardID
+		}
+		depth++
+	}
+	return shardID
+}
+
+// splitDepth returns the number of hash bits consumed by the keys owned by the given shard
+func (m *HistoryShardMapping) splitDepth(shardID int) int {
+	depth := 0
+	for i := len(m.Splits) - 1; i >= 0; i-- {
+		split := m.Splits[i]
+		if split.ParentShardID == shardID || split.ChildShardID == shardID {
+			depth++
+			shardID = split.ParentShardID
+		}
+	}
+	return depth
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package common
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryShardMapping_NoSplit(t *testing.T) {
+	mapping := NewHistoryShardMapping(16)
+	assert.Equal(t, 16, mapping.TotalShards())
+	for i := 0; i < 100; i++ {
+		workflowID := fmt.Sprintf("workflow-%v", i)
+		assert.Equal(t, WorkflowIDToHistoryShard(workflowID, 16), mapping.WorkflowIDToShard(workflowID))
+		assert.Equal(t, DomainIDToHistoryShard(workflowID, 16), mapping.DomainIDToShard(workflowID))
+	}
+}
+
+func TestHistoryShardMapping_Split(t *testing.T) {
+	mapping := NewHistoryShardMapping(4)
+
+	split := completeSplit(t, mapping, 1)
+	assert.Equal(t, int64(3), split.Version)
+	assert.Equal(t, 5, split.TotalShards())
+	assert.NoError(t, split.Validate())
+
+	split = completeSplit(t, split, 4)
+	split = completeSplit(t, split, 1)
+	assert.Equal(t, int64(9), split.Version)
+	assert.Equal(t, 7, split.TotalShards())
+	assert.NoError(t, split.Validate())
+
+	counts := make(map[int]int)
+	for i := 0; i < 10000; i++ {
+		workflowID := fmt.Sprintf("workflow-%v", i)
+		oldShardID := mapping.WorkflowIDToShard(workflowID)
+		newShardID := split.WorkflowIDToShard(workflowID)
+		counts[newShardID]++
+		if oldShardID != 1 {
+			// workflows in shards that are not split never move
+			assert.Equal(t, oldShardID, newShardID)
+		} else {
+			assert.Contains(t, []int{1, 4, 5, 6}, newShardID)
+		}
+	}
+	for shardID := 0; shardID < split.TotalShards(); shardID++ {
+		assert.NotZero(t, counts[shardID], "shard %v should own some workflows", shardID)
+	}
+}
+
+func TestHistoryShardMapping_SplitOnlyMovesWorkflowsOutOfParent(t *testing.T) {
+	mapping := completeSplit(t, NewHistoryShardMapping(2), 0)
+	split := completeSplit(t, mapping, 2)
+	for i := 0; i < 1000; i++ {
+		workflowID := fmt.Sprintf("workflow-%v", i)
+		oldShardID := mapping.WorkflowIDToShard(workflowID)
+		newShardID := split.WorkflowIDToShard(workflowID)
+		if newShardID != oldShardID {
+			assert.Equal(t, 2, oldShardID)
+			assert.Equal(t, 3, newShardID)
+		}
+	}
+}
+
+func TestHistoryShardMapping_SplitInvalidShard(t *testing.T) {
+	mapping := NewHistoryShardMapping(2)
+	_, err := mapping.Split(2)
+	assert.Error(t, err)
+	_, err = mapping.Split(-1)
+	assert.Error(t, err)
+}
+
+func TestHistoryShardMapping_Validate(t *testing.T) {
+	assert.Error(t, NewHistoryShardMapping(0).Validate())
+	assert.Error(t, (&HistoryShardMapping{
+		NumberOfShards: 2,
+		Splits:         []HistoryShardSplit{{ParentShardID: 0, ChildShardID: 3}},
+	}).Validate())
+	assert.Error(t, (&HistoryShardMapping{
+		NumberOfShards: 2,
+		Splits:         []HistoryShardSplit{{ParentShardID: 2, ChildShardID: 2}},
+	}).Validate())
+	assert.Error(t, (&HistoryShardMapping{
+		NumberOfShards: 2,
+		Splits: []HistoryShardSplit{
+			{ParentShardID: 0, ChildShardID: 2, State: HistoryShardSplitStateCopying},
+			{ParentShardID: 1, ChildShardID: 3},
+		},
+	}).Validate())
+}
+
+func TestHistoryShardMapping_SplitInProgress(t *testing.T) {
+	mapping := NewHistoryShardMapping(2)
+	copying, err := mapping.Split(0)
+	require.NoError(t, err)
+	assert.Equal(t, 3, copying.TotalShards())
+	assert.Equal(t, &HistoryShardSplit{ParentShardID: 0, ChildShardID: 2, State: HistoryShardSplitStateCopying}, copying.SplitInProgress())
+	assert.NoError(t, copying.Validate())
+
+	_, err = copying.Split(1)
+	assert.Error(t, err, "only one split can be in progress")
+	_, err = copying.CompleteSplit()
+	assert.Error(t, err, "split needs to be fenced before completing")
+
+	fenced, err := copying.FenceSplit()
+	require.NoError(t, err)
+	completed, err := fenced.CompleteSplit()
+	require.NoError(t, err)
+	assert.Nil(t, completed.SplitInProgress())
+	_, err = completed.FenceSplit()
+	assert.Error(t, err)
+
+	moved := 0
+	for i := 0; i < 1000; i++ {
+		workflowID := fmt.Sprintf("workflow-%v", i)
+		oldShardID := mapping.WorkflowIDToShard(workflowID)
+		// workflows are not moved until the split is completed
+		assert.Equal(t, oldShardID, copying.WorkflowIDToShard(workflowID))
+		assert.Equal(t, oldShardID, fenced.WorkflowIDToShard(workflowID))
+
+		if completed.WorkflowIDToShard(workflowID) == oldShardID {
+			assert.Equal(t, HistoryShardOwned, copying.WorkflowOwnership(workflowID, oldShardID))
+			assert.Equal(t, HistoryShardOwned, fenced.WorkflowOwnership(workflowID, oldShardID))
+			assert.Equal(t, HistoryShardNotOwned, fenced.WorkflowOwnership(workflowID, 2))
+			assert.False(t, fenced.IsWorkflowMovingToShard(workflowID, 2))
+			continue
+		}
+
+		moved++
+		assert.Equal(t, 0, oldShardID)
+		assert.True(t, copying.IsWorkflowMovingToShard(workflowID, 2))
+		assert.Equal(t, HistoryShardOwned, copying.WorkflowOwnership(workflowID, 0))
+		assert.Equal(t, HistoryShardMoving, copying.WorkflowOwnership(workflowID, 2))
+		assert.Equal(t, HistoryShardMoving, fenced.WorkflowOwnership(workflowID, 0))
+		assert.Equal(t, HistoryShardMoving, fenced.WorkflowOwnership(workflowID, 2))
+		assert.Equal(t, HistoryShardNotOwned, completed.WorkflowOwnership(workflowID, 0))
+		assert.Equal(t, HistoryShardOwned, completed.WorkflowOwnership(workflowID, 2))
+	}
+	assert.NotZero(t, moved)
+}
+
+func completeSplit(t *testing.T, mapping *HistoryShardMapping, parentShardID int) *HistoryShardMapping {
+	mapping, err := mapping.Split(parentShardID)
+	require.NoError(t, err)
+	mapping, err = mapping.FenceSplit()
+	require.NoError(t, err)
+	mapping, err = mapping.CompleteSplit()
+	require.NoError(t, err)
+	return mapping
+}
@@ -519,6 +519,11 @@ func ActorEmail(actorEmail string) Tag {
 	return newStringTag("actor-email", actorEmail)
 }
 
+// ActorReason returns tag for the reason given by the actor
+func ActorReason(reason string) Tag {
+	return newStringTag("actor-reason", reason)
+}
+
 // HandlerCall returns tag for the API name of a request
 func HandlerCall(handlerCall string) Tag {
 	return newStringTag("handler-call", handlerCall)
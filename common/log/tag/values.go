@@ -23,13 +23,14 @@ package tag
 // Pre-defined values for TagWorkflowAction
 var (
 	// workflow start / finish
-	WorkflowActionWorkflowStarted       = workflowAction("add-workflow-started-event")
-	WorkflowActionWorkflowCanceled      = workflowAction("add-workflow-canceled-event")
-	WorkflowActionWorkflowCompleted     = workflowAction("add-workflow-completed--event")
-	WorkflowActionWorkflowFailed        = workflowAction("add-workflow-failed-event")
-	WorkflowActionWorkflowTimeout       = workflowAction("add-workflow-timeout-event")
-	WorkflowActionWorkflowTerminated    = workflowAction("add-workflow-terminated-event")
-	WorkflowActionWorkflowContinueAsNew = workflowAction("add-workflow-continue-as-new-event")
+	WorkflowActionWorkflowStarted            = workflowAction("add-workflow-started-event")
+	WorkflowActionWorkflowCanceled           = workflowAction("add-workflow-canceled-event")
+	WorkflowActionWorkflowCompleted          = workflowAction("add-workflow-completed--event")
+	WorkflowActionWorkflowFailed             = workflowAction("add-workflow-failed-event")
+	WorkflowActionWorkflowTimeout            = workflowAction("add-workflow-timeout-event")
+	WorkflowActionWorkflowTerminated         = workflowAction("add-workflow-terminated-event")
+	WorkflowActionWorkflowContinueAsNew      = workflowAction("add-workflow-continue-as-new-event")
+	WorkflowActionWorkflowNoProgressTimedOut = workflowAction("add-workflow-no-progress-timedout-event")
 
 	// workflow cancellation / sign
 	WorkflowActionWorkflowCancelRequested        = workflowAction("add-workflow-cancel-requested-event")
@@ -58,6 +59,7 @@ var (
 	WorkflowActionActivityTaskCancelRequested = workflowAction("add-activitytask-cancel-requested-event")
 	WorkflowActionActivityTaskCancelFailed    = workflowAction("add-activitytask-cancel-failed-event")
 	WorkflowActionActivityTaskRetry           = workflowAction("add-activitytask-retry-event")
+	WorkflowActionActivityTaskOptionsUpdated  = workflowAction("update-activitytask-options")
 
 	// timer
 	WorkflowActionTimerStarted      = workflowAction("add-timer-started-event")
@@ -134,6 +136,9 @@ var (
 	ComponentShardFixer                 = component("shardscanner-fixer")
 	ComponentPinotVisibilityManager     = component("pinot-visibility-manager")
 	ComponentAsyncWFConsumptionManager  = component("async-wf-consumption-manager")
+	ComponentDomainMigration            = component("domain-migration")
+	ComponentShardSplit                 = component("shard-split")
+	ComponentAutoFailover               = component("auto-failover")
 )
 
 // Pre-defined values for TagSysLifecycle
@@ -214,6 +219,8 @@ var (
 	StoreOperationGetTimerIndexTasks                = storeOperation("get-timer-index-tasks")
 	StoreOperationCompleteTimerTask                 = storeOperation("complete-timer-task")
 	StoreOperationRangeCompleteTimerTask            = storeOperation("range-complete-timer-task")
+	StoreOperationCreateNDCConflictResolution       = storeOperation("create-ndc-conflict-resolution")
+	StoreOperationListNDCConflictResolutions        = storeOperation("list-ndc-conflict-resolutions")
 
 	StoreOperationCreateTasks           = storeOperation("create-tasks")
 	StoreOperationGetTasks              = storeOperation("get-tasks")
@@ -275,8 +282,10 @@ var (
 	StoreOperationGetDLQSize                 = storeOperation("get-dlq-size")
 	StoreOperationDeleteMessageFromDLQ       = storeOperation("delete-message-from-dlq")
 
-	StoreOperationFetchDynamicConfig  = storeOperation("fetch-dynamic-config")
-	StoreOperationUpdateDynamicConfig = storeOperation("update-dynamic-config")
+	StoreOperationFetchDynamicConfig        = storeOperation("fetch-dynamic-config")
+	StoreOperationUpdateDynamicConfig       = storeOperation("update-dynamic-config")
+	StoreOperationFetchHistoryShardMapping  = storeOperation("fetch-history-shard-mapping")
+	StoreOperationUpdateHistoryShardMapping = storeOperation("update-history-shard-mapping")
 )
 
 // Pre-defined values for TagSysClientOperation
@@ -410,6 +419,13 @@ var (
 	HistoryClientOperationRespondActivityTaskFailed         = clientOperation("history-respond-activity-task-failed")
 	HistoryClientOperationRespondDecisionTaskCompleted      = clientOperation("history-respond-decision-task-completed")
 	HistoryClientOperationRespondDecisionTaskFailed         = clientOperation("history-respond-decision-task-failed")
+	HistoryClientOperationUpdateActivityOptions             = clientOperation("history-update-activity-options")
+	HistoryClientOperationDeleteWorkflowExecution           = clientOperation("history-delete-workflow-execution")
+	HistoryClientOperationListQueueTasks                    = clientOperation("history-list-queue-tasks")
+	HistoryClientOperationExecuteQueueTask                  = clientOperation("history-execute-queue-task")
+	HistoryClientOperationMigrateShardExecutions            = clientOperation("history-migrate-shard-executions")
+	HistoryClientOperationVerifyWorkflowExecution           = clientOperation("history-verify-workflow-execution")
+	HistoryClientOperationDescribeReplicationStatus         = clientOperation("history-describe-replication-status")
 
 	MatchingClientOperationAddActivityTask           = clientOperation("matching-add-activity-task")
 	MatchingClientOperationAddDecisionTask           = clientOperation("matching-add-decision-task")
@@ -23,6 +23,7 @@ package kafka
 import (
 	"context"
 	"errors"
+	"strconv"
 
 	"github.com/Shopify/sarama"
 
@@ -130,6 +131,29 @@ func (p *producerImpl) getProducerMessage(message interface{}) (*sarama.Producer
 			Value: sarama.ByteEncoder(payload),
 		}
 		return msg, nil
+	case *messaging.ReplicationMessage:
+		payload, err := messaging.EncodeReplicationMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		// all the messages of a shard are published to the same partition to keep them in order
+		msg := &sarama.ProducerMessage{
+			Topic: p.topic,
+			Key:   sarama.StringEncoder(strconv.Itoa(int(message.ShardID))),
+			Value: sarama.ByteEncoder(payload),
+		}
+		return msg, nil
+	case *messaging.ReplicationAckMessage:
+		payload, err := messaging.EncodeReplicationAckMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		msg := &sarama.ProducerMessage{
+			Topic: p.topic,
+			Key:   sarama.StringEncoder(strconv.Itoa(int(message.ShardID))),
+			Value: sarama.ByteEncoder(payload),
+		}
+		return msg, nil
 	default:
 		return nil, errors.New("unknown producer message type")
 	}
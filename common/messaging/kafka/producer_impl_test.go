@@ -32,6 +32,8 @@ import (
 	"github.com/uber/cadence/.gen/go/indexer"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/messaging"
+	"github.com/uber/cadence/common/types"
 )
 
 func TestNewKafkaProducer(t *testing.T) {
@@ -70,6 +72,28 @@ func TestPublish(t *testing.T) {
 			},
 			hasErr: false,
 		},
+		{
+			name: "Publish replication message succeeded",
+			message: &messaging.ReplicationMessage{
+				SourceCluster: "source",
+				TargetCluster: "target",
+				ShardID:       1,
+				Messages: &types.ReplicationMessages{
+					LastRetrievedMessageID: 10,
+				},
+			},
+			hasErr: false,
+		},
+		{
+			name: "Publish replication ack message succeeded",
+			message: &messaging.ReplicationAckMessage{
+				SourceCluster:  "source",
+				TargetCluster:  "target",
+				ShardID:        1,
+				AckedMessageID: 10,
+			},
+			hasErr: false,
+		},
 		{
 			name:    "Unrecognized message type",
 			message: "This is not a recognized message type",
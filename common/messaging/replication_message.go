@@ -0,0 +1,85 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package messaging
+
+import (
+	"encoding/json"
+
+	"github.com/uber/cadence/common/types"
+)
+
+// ReplicationMessage is a batch of replication tasks of a shard, published by the source cluster
+// for a target cluster when replication tasks are delivered through the messaging system
+type ReplicationMessage struct {
+	SourceCluster string `json:"sourceCluster"`
+	TargetCluster string `json:"targetCluster"`
+	ShardID       int32  `json:"shardID"`
+	// PreviousMessageID is the ID the batch starts after, which is the last retrieved message ID of the
+	// previous batch of the shard
+	PreviousMessageID int64 `json:"previousMessageID"`
+	// ReplicationLevel is the replication level of the target cluster in the source cluster when the batch is
+	// published, which is the last message ID the target cluster acknowledged
+	ReplicationLevel int64                      `json:"replicationLevel"`
+	Messages         *types.ReplicationMessages `json:"messages"`
+}
+
+// ReplicationAckMessage reports the replication progress of a shard, published by the target cluster
+// for the source cluster when replication tasks are delivered through the messaging system
+type ReplicationAckMessage struct {
+	SourceCluster string `json:"sourceCluster"`
+	TargetCluster string `json:"targetCluster"`
+	ShardID       int32  `json:"shardID"`
+	// AckedMessageID is the last message ID processed by the target cluster, it is empty when unknown
+	AckedMessageID int64 `json:"ackedMessageID"`
+	// Rewind asks the source cluster to publish again the replication tasks after AckedMessageID,
+	// or after the replication level of the target cluster when AckedMessageID is empty
+	Rewind bool `json:"rewind"`
+}
+
+// EncodeReplicationMessage serializes a replication message into the payload published to the messaging system
+func EncodeReplicationMessage(message *ReplicationMessage) ([]byte, error) {
+	return json.Marshal(message)
+}
+
+// DecodeReplicationMessage deserializes a replication message consumed from the messaging system
+func DecodeReplicationMessage(payload []byte) (*ReplicationMessage, error) {
+	var message ReplicationMessage
+	if err := json.Unmarshal(payload, &message); err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// EncodeReplicationAckMessage serializes a replication ack message into the payload published to the messaging system
+func EncodeReplicationAckMessage(message *ReplicationAckMessage) ([]byte, error) {
+	return json.Marshal(message)
+}
+
+// DecodeReplicationAckMessage deserializes a replication ack message consumed from the messaging system
+func DecodeReplicationAckMessage(payload []byte) (*ReplicationAckMessage, error) {
+	var message ReplicationAckMessage
+	if err := json.Unmarshal(payload, &message); err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
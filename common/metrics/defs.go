@@ -160,6 +160,10 @@ const (
 	PersistenceGetCurrentExecutionScope
 	// PersistenceIsWorkflowExecutionExistsScope tracks IsWorkflowExecutionExists calls made by service to persistence layer
 	PersistenceIsWorkflowExecutionExistsScope
+	// PersistenceCreateNDCConflictResolutionScope tracks CreateNDCConflictResolution calls made by service to persistence layer
+	PersistenceCreateNDCConflictResolutionScope
+	// PersistenceListNDCConflictResolutionsScope tracks ListNDCConflictResolutions calls made by service to persistence layer
+	PersistenceListNDCConflictResolutionsScope
 	// PersistenceListCurrentExecutionsScope tracks ListCurrentExecutions calls made by service to persistence layer
 	PersistenceListCurrentExecutionsScope
 	// PersistenceListConcreteExecutionsScope tracks ListConcreteExecutions calls made by service to persistence layer
@@ -302,6 +306,10 @@ const (
 	PersistenceFetchDynamicConfigScope
 	// PersistenceUpdateDynamicConfigScope tracks UpdateDynamicConfig calls made by service to persistence layer
 	PersistenceUpdateDynamicConfigScope
+	// PersistenceFetchHistoryShardMappingScope tracks FetchHistoryShardMapping calls made by service to persistence layer
+	PersistenceFetchHistoryShardMappingScope
+	// PersistenceUpdateHistoryShardMappingScope tracks UpdateHistoryShardMapping calls made by service to persistence layer
+	PersistenceUpdateHistoryShardMappingScope
 	// PersistenceShardRequestCountScope tracks number of persistence calls made to each shard
 	PersistenceShardRequestCountScope
 
@@ -401,6 +409,20 @@ const (
 	HistoryClientGetReplicationMessagesScope
 	// HistoryClientWfIDCacheScope tracks workflow ID cache metrics
 	HistoryClientWfIDCacheScope
+	// HistoryClientUpdateActivityOptionsScope tracks RPC calls to history service
+	HistoryClientUpdateActivityOptionsScope
+	// HistoryClientDeleteWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientDeleteWorkflowExecutionScope
+	// HistoryClientListQueueTasksScope tracks RPC calls to history service
+	HistoryClientListQueueTasksScope
+	// HistoryClientExecuteQueueTaskScope tracks RPC calls to history service
+	HistoryClientExecuteQueueTaskScope
+	// HistoryClientMigrateShardExecutionsScope tracks RPC calls to history service
+	HistoryClientMigrateShardExecutionsScope
+	// HistoryClientVerifyWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientVerifyWorkflowExecutionScope
+	// HistoryClientDescribeReplicationStatusScope tracks RPC calls to history service
+	HistoryClientDescribeReplicationStatusScope
 
 	// MatchingClientPollForDecisionTaskScope tracks RPC calls to matching service
 	MatchingClientPollForDecisionTaskScope
@@ -1024,6 +1046,14 @@ const (
 	HistoryResetQueueScope
 	// HistoryDescribeQueueScope tracks DescribeQueue API calls received by service
 	HistoryDescribeQueueScope
+	// HistoryDescribeReplicationStatusScope tracks DescribeReplicationStatus API calls received by service
+	HistoryDescribeReplicationStatusScope
+	// HistoryListQueueTasksScope tracks ListQueueTasks API calls received by service
+	HistoryListQueueTasksScope
+	// HistoryExecuteQueueTaskScope tracks ExecuteQueueTask API calls received by service
+	HistoryExecuteQueueTaskScope
+	// HistoryMigrateShardExecutionsScope tracks MigrateShardExecutions API calls received by service
+	HistoryMigrateShardExecutionsScope
 	// HistoryDescribeMutabelStateScope tracks DescribeMutableState API calls received by service
 	HistoryDescribeMutabelStateScope
 	// HistoryGetMutableStateScope tracks GetMutableState API calls received by service
@@ -1082,6 +1112,12 @@ const (
 	HistoryReapplyEventsScope
 	// HistoryRefreshWorkflowTasksScope tracks RefreshWorkflowTasks API calls received by service
 	HistoryRefreshWorkflowTasksScope
+	// HistoryVerifyWorkflowExecutionScope tracks VerifyWorkflowExecution API calls received by service
+	HistoryVerifyWorkflowExecutionScope
+	// HistoryUpdateActivityOptionsScope tracks UpdateActivityOptions API calls received by service
+	HistoryUpdateActivityOptionsScope
+	// HistoryDeleteWorkflowExecutionScope tracks DeleteWorkflowExecution API calls received by service
+	HistoryDeleteWorkflowExecutionScope
 	// HistoryNotifyFailoverMarkersScope is the scope used by notify failover marker API
 	HistoryNotifyFailoverMarkersScope
 	// HistoryGetCrossClusterTasksScope tracks GetCrossClusterTasks API calls received by service
@@ -1160,6 +1196,8 @@ const (
 	TimerActiveTaskUserTimerScope
 	// TimerActiveTaskWorkflowTimeoutScope is the scope used by metric emitted by timer queue processor for processing workflow timeouts.
 	TimerActiveTaskWorkflowTimeoutScope
+	// TimerActiveTaskWorkflowNoProgressTimeoutScope is the scope used by metric emitted by timer queue processor for processing workflow no progress timeouts.
+	TimerActiveTaskWorkflowNoProgressTimeoutScope
 	// TimerActiveTaskActivityRetryTimerScope is the scope used by metric emitted by timer queue processor for processing retry task.
 	TimerActiveTaskActivityRetryTimerScope
 	// TimerActiveTaskWorkflowBackoffTimerScope is the scope used by metric emitted by timer queue processor for processing retry task.
@@ -1174,6 +1212,8 @@ const (
 	TimerStandbyTaskUserTimerScope
 	// TimerStandbyTaskWorkflowTimeoutScope is the scope used by metric emitted by timer queue processor for processing workflow timeouts.
 	TimerStandbyTaskWorkflowTimeoutScope
+	// TimerStandbyTaskWorkflowNoProgressTimeoutScope is the scope used by metric emitted by timer queue processor for processing workflow no progress timeouts.
+	TimerStandbyTaskWorkflowNoProgressTimeoutScope
 	// TimerStandbyTaskActivityRetryTimerScope is the scope used by metric emitted by timer queue processor for processing retry task.
 	TimerStandbyTaskActivityRetryTimerScope
 	// TimerStandbyTaskDeleteHistoryEventScope is the scope used by metric emitted by timer queue processor for processing history event cleanup
@@ -1258,6 +1298,8 @@ const (
 	ArchiverClientScope
 	// ReplicationTaskFetcherScope is scope used by all metrics emitted by ReplicationTaskFetcher
 	ReplicationTaskFetcherScope
+	// ReplicationTaskPublisherScope is scope used by all metrics emitted by ReplicationTaskPublisher
+	ReplicationTaskPublisherScope
 	// ReplicationTaskCleanupScope is scope used by all metrics emitted by ReplicationTaskProcessor cleanup
 	ReplicationTaskCleanupScope
 	// ReplicationDLQStatsScope is scope used by all metrics emitted related to replication DLQ
@@ -1370,6 +1412,8 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceDeleteCurrentWorkflowExecutionScope:           {operation: "DeleteCurrentWorkflowExecution"},
 		PersistenceGetCurrentExecutionScope:                      {operation: "GetCurrentExecution"},
 		PersistenceIsWorkflowExecutionExistsScope:                {operation: "IsWorkflowExecutionExists"},
+		PersistenceCreateNDCConflictResolutionScope:              {operation: "CreateNDCConflictResolution"},
+		PersistenceListNDCConflictResolutionsScope:               {operation: "ListNDCConflictResolutions"},
 		PersistenceListCurrentExecutionsScope:                    {operation: "ListCurrentExecutions"},
 		PersistenceListConcreteExecutionsScope:                   {operation: "ListConcreteExecutions"},
 		PersistenceGetTransferTasksScope:                         {operation: "GetTransferTasks"},
@@ -1450,6 +1494,8 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceGetDLQSizeScope:                               {operation: "GetDLQSize"},
 		PersistenceFetchDynamicConfigScope:                       {operation: "FetchDynamicConfig"},
 		PersistenceUpdateDynamicConfigScope:                      {operation: "UpdateDynamicConfig"},
+		PersistenceFetchHistoryShardMappingScope:                 {operation: "FetchHistoryShardMapping"},
+		PersistenceUpdateHistoryShardMappingScope:                {operation: "UpdateHistoryShardMapping"},
 		PersistenceShardRequestCountScope:                        {operation: "ShardIdPersistenceRequest"},
 		ResolverHostNotFoundScope:                                {operation: "ResolverHostNotFound"},
 
@@ -1501,6 +1547,13 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryClientGetDLQReplicationMessagesScope:         {operation: "HistoryClientGetDLQReplicationMessages", tags: map[string]string{CadenceRoleTagName: HistoryClientRoleTagValue}},
 		HistoryClientGetReplicationMessagesScope:            {operation: "HistoryClientGetReplicationMessages", tags: map[string]string{CadenceRoleTagName: HistoryClientRoleTagValue}},
 		HistoryClientWfIDCacheScope:                         {operation: "HistoryClientWfIDCache", tags: map[string]string{CadenceRoleTagName: HistoryClientRoleTagValue}},
+		HistoryClientUpdateActivityOptionsScope:             {operation: "HistoryClientUpdateActivityOptions", tags: map[string]string{CadenceRoleTagName: HistoryClientRoleTagValue}},
+		HistoryClientDeleteWorkflowExecutionScope:           {operation: "HistoryClientDeleteWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryClientRoleTagValue}},
+		HistoryClientListQueueTasksScope:                    {operation: "HistoryClientListQueueTasks", tags: map[string]string{CadenceRoleTagName: HistoryClientRoleTagValue}},
+		HistoryClientExecuteQueueTaskScope:                  {operation: "HistoryClientExecuteQueueTask", tags: map[string]string{CadenceRoleTagName: HistoryClientRoleTagValue}},
+		HistoryClientMigrateShardExecutionsScope:            {operation: "HistoryClientMigrateShardExecutions", tags: map[string]string{CadenceRoleTagName: HistoryClientRoleTagValue}},
+		HistoryClientVerifyWorkflowExecutionScope:           {operation: "HistoryClientVerifyWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryClientRoleTagValue}},
+		HistoryClientDescribeReplicationStatusScope:         {operation: "HistoryClientDescribeReplicationStatus", tags: map[string]string{CadenceRoleTagName: HistoryClientRoleTagValue}},
 
 		MatchingClientPollForDecisionTaskScope:                   {operation: "MatchingClientPollForDecisionTask", tags: map[string]string{CadenceRoleTagName: MatchingClientRoleTagValue}},
 		MatchingClientPollForActivityTaskScope:                   {operation: "MatchingClientPollForActivityTask", tags: map[string]string{CadenceRoleTagName: MatchingClientRoleTagValue}},
@@ -1805,6 +1858,10 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryRespondActivityTaskCanceledScope:                         {operation: "RespondActivityTaskCanceled"},
 		HistoryResetQueueScope:                                          {operation: "ResetQueue"},
 		HistoryDescribeQueueScope:                                       {operation: "DescribeQueue"},
+		HistoryDescribeReplicationStatusScope:                           {operation: "DescribeReplicationStatus"},
+		HistoryListQueueTasksScope:                                      {operation: "ListQueueTasks"},
+		HistoryExecuteQueueTaskScope:                                    {operation: "ExecuteQueueTask"},
+		HistoryMigrateShardExecutionsScope:                              {operation: "MigrateShardExecutions"},
 		HistoryDescribeMutabelStateScope:                                {operation: "DescribeMutableState"},
 		HistoryGetMutableStateScope:                                     {operation: "GetMutableState"},
 		HistoryPollMutableStateScope:                                    {operation: "PollMutableState"},
@@ -1837,6 +1894,9 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryShardControllerScope:                                     {operation: "ShardController"},
 		HistoryReapplyEventsScope:                                       {operation: "EventReapplication"},
 		HistoryRefreshWorkflowTasksScope:                                {operation: "RefreshWorkflowTasks"},
+		HistoryVerifyWorkflowExecutionScope:                             {operation: "VerifyWorkflowExecution"},
+		HistoryUpdateActivityOptionsScope:                               {operation: "UpdateActivityOptions"},
+		HistoryDeleteWorkflowExecutionScope:                             {operation: "DeleteWorkflowExecution"},
 		HistoryNotifyFailoverMarkersScope:                               {operation: "NotifyFailoverMarkers"},
 		HistoryGetCrossClusterTasksScope:                                {operation: "GetCrossClusterTasks"},
 		HistoryRespondCrossClusterTasksCompletedScope:                   {operation: "RespondCrossClusterTasksCompleted"},
@@ -1876,6 +1936,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		TimerActiveTaskDecisionTimeoutScope:                             {operation: "TimerActiveTaskDecisionTimeout"},
 		TimerActiveTaskUserTimerScope:                                   {operation: "TimerActiveTaskUserTimer"},
 		TimerActiveTaskWorkflowTimeoutScope:                             {operation: "TimerActiveTaskWorkflowTimeout"},
+		TimerActiveTaskWorkflowNoProgressTimeoutScope:                   {operation: "TimerActiveTaskWorkflowNoProgressTimeout"},
 		TimerActiveTaskActivityRetryTimerScope:                          {operation: "TimerActiveTaskActivityRetryTimer"},
 		TimerActiveTaskWorkflowBackoffTimerScope:                        {operation: "TimerActiveTaskWorkflowBackoffTimer"},
 		TimerActiveTaskDeleteHistoryEventScope:                          {operation: "TimerActiveTaskDeleteHistoryEvent"},
@@ -1883,6 +1944,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		TimerStandbyTaskDecisionTimeoutScope:                            {operation: "TimerStandbyTaskDecisionTimeout"},
 		TimerStandbyTaskUserTimerScope:                                  {operation: "TimerStandbyTaskUserTimer"},
 		TimerStandbyTaskWorkflowTimeoutScope:                            {operation: "TimerStandbyTaskWorkflowTimeout"},
+		TimerStandbyTaskWorkflowNoProgressTimeoutScope:                  {operation: "TimerStandbyTaskWorkflowNoProgressTimeout"},
 		TimerStandbyTaskActivityRetryTimerScope:                         {operation: "TimerStandbyTaskActivityRetryTimer"},
 		TimerStandbyTaskWorkflowBackoffTimerScope:                       {operation: "TimerStandbyTaskWorkflowBackoffTimer"},
 		TimerStandbyTaskDeleteHistoryEventScope:                         {operation: "TimerStandbyTaskDeleteHistoryEvent"},
@@ -1922,6 +1984,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		WorkflowCompletionStatsScope:                                    {operation: "CompletionStats", tags: map[string]string{StatsTypeTagName: CountStatsTypeTagValue}},
 		ArchiverClientScope:                                             {operation: "ArchiverClient"},
 		ReplicationTaskFetcherScope:                                     {operation: "ReplicationTaskFetcher"},
+		ReplicationTaskPublisherScope:                                   {operation: "ReplicationTaskPublisher"},
 		ReplicationTaskCleanupScope:                                     {operation: "ReplicationTaskCleanup"},
 		ReplicationDLQStatsScope:                                        {operation: "ReplicationDLQStats"},
 		FailoverMarkerScope:                                             {operation: "FailoverMarker"},
@@ -2104,6 +2167,7 @@ const (
 
 	PriorityTaskSubmitRequest
 	PriorityTaskSubmitLatency
+	PriorityTaskThrottledCounter
 
 	KafkaConsumerMessageIn
 	KafkaConsumerMessageAck
@@ -2210,6 +2274,8 @@ const (
 	TaskLimitExceededCounterPerDomain
 	TaskProcessingLatencyPerDomain
 	TaskQueueLatencyPerDomain
+	TaskScheduleLatencyPerDomain
+	TaskMovedToDLQCounterPerDomain
 	TransferTaskMissingEventCounterPerDomain
 	ReplicationTasksAppliedPerDomain
 
@@ -2227,6 +2293,7 @@ const (
 	ProcessingQueueStuckTaskSplitCounter
 	ProcessingQueueSelectedDomainSplitCounter
 	ProcessingQueueRandomSplitCounter
+	ProcessingQueueDomainVirtualQueueSplitCounter
 	ProcessingQueueThrottledCounter
 
 	QueueValidatorLostTaskCounter
@@ -2290,9 +2357,11 @@ const (
 	ScheduleToCloseTimeoutCounter
 	NewTimerCounter
 	NewTimerNotifyCounter
+	InMemoryTimerFiredCounter
 	AcquireShardsCounter
 	AcquireShardsLatency
 	ShardClosedCounter
+	ShardReleasedCounter
 	ShardItemCreatedCounter
 	ShardItemRemovedCounter
 	ShardItemAcquisitionLatency
@@ -2375,6 +2444,7 @@ const (
 	WorkflowCancelCount
 	WorkflowFailedCount
 	WorkflowTimeoutCount
+	WorkflowNoProgressTimeoutCount
 	WorkflowTerminateCount
 	WorkflowContinuedAsNew
 	WorkflowCompletedUnknownType
@@ -2401,6 +2471,8 @@ const (
 	LastRetrievedMessageID
 	LastProcessedMessageID
 	ReplicationLatency
+	ReplicationLatencyPerDomain
+	ReplicationPendingTasksPerDomain
 	ReplicationTasksApplied
 	ReplicationTasksFailed
 	ReplicationTasksLag
@@ -2414,6 +2486,17 @@ const (
 	ReplicationDLQProbeFailed
 	ReplicationDLQSize
 	ReplicationDLQValidationFailed
+	ReplicationDLQSizeByReason
+	ReplicationDLQAutoRetrySucceeded
+	ReplicationDLQAutoRetryFailed
+	ReplicationDLQAutoRetryExhausted
+	NDCConflictResolutionAuditDropped
+	NDCConflictResolutionAuditFailed
+	ReplicationMessagesPublished
+	ReplicationMessagesPublishFailed
+	ReplicationStreamBatchesDropped
+	ReplicationStreamFallbackCount
+	ReplicationStreamRewindCount
 	GetReplicationMessagesForShardLatency
 	GetDLQReplicationMessagesLatency
 	EventReapplySkippedCount
@@ -2710,6 +2793,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ParallelTaskTaskProcessingLatency:                            {metricName: "paralleltask_task_processing_latency", metricType: Timer},
 		PriorityTaskSubmitRequest:                                    {metricName: "prioritytask_submit_request", metricType: Counter},
 		PriorityTaskSubmitLatency:                                    {metricName: "prioritytask_submit_latency", metricType: Timer},
+		PriorityTaskThrottledCounter:                                 {metricName: "prioritytask_throttled_counter", metricType: Counter},
 		KafkaConsumerMessageIn:                                       {metricName: "kafka_consumer_message_in", metricType: Counter},
 		KafkaConsumerMessageAck:                                      {metricName: "kafka_consumer_message_ack", metricType: Counter},
 		KafkaConsumerMessageNack:                                     {metricName: "kafka_consumer_message_nack", metricType: Counter},
@@ -2846,6 +2930,8 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		TaskLimitExceededCounterPerDomain:        {metricName: "task_errors_limit_exceeded_counter_per_domain", metricRollupName: "task_errors_limit_exceeded_counter", metricType: Counter},
 		TaskProcessingLatencyPerDomain:           {metricName: "task_latency_processing_per_domain", metricRollupName: "task_latency_processing", metricType: Timer},
 		TaskQueueLatencyPerDomain:                {metricName: "task_latency_queue_per_domain", metricRollupName: "task_latency_queue", metricType: Timer},
+		TaskScheduleLatencyPerDomain:             {metricName: "task_latency_schedule_per_domain", metricRollupName: "task_latency_schedule", metricType: Timer},
+		TaskMovedToDLQCounterPerDomain:           {metricName: "task_moved_to_dlq_per_domain", metricRollupName: "task_moved_to_dlq", metricType: Counter},
 		TransferTaskMissingEventCounterPerDomain: {metricName: "transfer_task_missing_event_counter_per_domain", metricRollupName: "transfer_task_missing_event_counter", metricType: Counter},
 		ReplicationTasksAppliedPerDomain:         {metricName: "replication_tasks_applied_per_domain", metricRollupName: "replication_tasks_applied", metricType: Counter},
 
@@ -2862,6 +2948,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ProcessingQueueStuckTaskSplitCounter:                         {metricName: "processing_queue_stuck_task_split_counter", metricType: Counter},
 		ProcessingQueueSelectedDomainSplitCounter:                    {metricName: "processing_queue_selected_domain_split_counter", metricType: Counter},
 		ProcessingQueueRandomSplitCounter:                            {metricName: "processing_queue_random_split_counter", metricType: Counter},
+		ProcessingQueueDomainVirtualQueueSplitCounter:                {metricName: "processing_queue_domain_virtual_queue_split_counter", metricType: Counter},
 		ProcessingQueueThrottledCounter:                              {metricName: "processing_queue_throttled_counter", metricType: Counter},
 		QueueValidatorLostTaskCounter:                                {metricName: "queue_validator_lost_task_counter", metricType: Counter},
 		QueueValidatorDropTaskCounter:                                {metricName: "queue_validator_drop_task_counter", metricType: Counter},
@@ -2921,9 +3008,11 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ScheduleToCloseTimeoutCounter:                                {metricName: "schedule_to_close_timeout", metricType: Counter},
 		NewTimerCounter:                                              {metricName: "new_timer", metricType: Counter},
 		NewTimerNotifyCounter:                                        {metricName: "new_timer_notifications", metricType: Counter},
+		InMemoryTimerFiredCounter:                                    {metricName: "in_memory_timer_fired", metricType: Counter},
 		AcquireShardsCounter:                                         {metricName: "acquire_shards_count", metricType: Counter},
 		AcquireShardsLatency:                                         {metricName: "acquire_shards_latency", metricType: Timer},
 		ShardClosedCounter:                                           {metricName: "shard_closed_count", metricType: Counter},
+		ShardReleasedCounter:                                         {metricName: "shard_released_count", metricType: Counter},
 		ShardItemCreatedCounter:                                      {metricName: "sharditem_created_count", metricType: Counter},
 		ShardItemRemovedCounter:                                      {metricName: "sharditem_removed_count", metricType: Counter},
 		ShardItemAcquisitionLatency:                                  {metricName: "sharditem_acquisition_latency", metricType: Timer},
@@ -3002,6 +3091,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		WorkflowCancelCount:                                          {metricName: "workflow_cancel", metricType: Counter},
 		WorkflowFailedCount:                                          {metricName: "workflow_failed", metricType: Counter},
 		WorkflowTimeoutCount:                                         {metricName: "workflow_timeout", metricType: Counter},
+		WorkflowNoProgressTimeoutCount:                               {metricName: "workflow_no_progress_timeout", metricType: Counter},
 		WorkflowTerminateCount:                                       {metricName: "workflow_terminate", metricType: Counter},
 		WorkflowContinuedAsNew:                                       {metricName: "workflow_continued_as_new", metricType: Counter},
 		WorkflowCompletedUnknownType:                                 {metricName: "workflow_completed_unknown_type", metricType: Counter},
@@ -3028,6 +3118,8 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		LastRetrievedMessageID:                                       {metricName: "last_retrieved_message_id", metricType: Gauge},
 		LastProcessedMessageID:                                       {metricName: "last_processed_message_id", metricType: Gauge},
 		ReplicationLatency:                                           {metricName: "replication_latency", metricType: Gauge},
+		ReplicationLatencyPerDomain:                                  {metricName: "replication_latency_per_domain", metricType: Gauge},
+		ReplicationPendingTasksPerDomain:                             {metricName: "replication_pending_tasks_per_domain", metricType: Gauge},
 		ReplicationTasksApplied:                                      {metricName: "replication_tasks_applied", metricType: Counter},
 		ReplicationTasksFailed:                                       {metricName: "replication_tasks_failed", metricType: Counter},
 		ReplicationTasksLag:                                          {metricName: "replication_tasks_lag", metricType: Timer},
@@ -3041,6 +3133,17 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ReplicationDLQProbeFailed:                                    {metricName: "replication_dlq_probe_failed", metricType: Counter},
 		ReplicationDLQSize:                                           {metricName: "replication_dlq_size", metricType: Gauge},
 		ReplicationDLQValidationFailed:                               {metricName: "replication_dlq_validation_failed", metricType: Counter},
+		ReplicationDLQSizeByReason:                                   {metricName: "replication_dlq_size_by_reason", metricType: Gauge},
+		ReplicationDLQAutoRetrySucceeded:                             {metricName: "replication_dlq_auto_retry_succeeded", metricType: Counter},
+		ReplicationDLQAutoRetryFailed:                                {metricName: "replication_dlq_auto_retry_failed", metricType: Counter},
+		ReplicationDLQAutoRetryExhausted:                             {metricName: "replication_dlq_auto_retry_exhausted", metricType: Counter},
+		NDCConflictResolutionAuditDropped:                            {metricName: "ndc_conflict_resolution_audit_dropped", metricType: Counter},
+		NDCConflictResolutionAuditFailed:                             {metricName: "ndc_conflict_resolution_audit_failed", metricType: Counter},
+		ReplicationMessagesPublished:                                 {metricName: "replication_messages_published", metricType: Counter},
+		ReplicationMessagesPublishFailed:                             {metricName: "replication_messages_publish_failed", metricType: Counter},
+		ReplicationStreamBatchesDropped:                              {metricName: "replication_stream_batches_dropped", metricType: Counter},
+		ReplicationStreamFallbackCount:                               {metricName: "replication_stream_fallback", metricType: Counter},
+		ReplicationStreamRewindCount:                                 {metricName: "replication_stream_rewind", metricType: Counter},
 		GetReplicationMessagesForShardLatency:                        {metricName: "get_replication_messages_for_shard", metricType: Timer},
 		GetDLQReplicationMessagesLatency:                             {metricName: "get_dlq_replication_messages", metricType: Timer},
 		EventReapplySkippedCount:                                     {metricName: "event_reapply_skipped_count", metricType: Counter},
@@ -59,6 +59,7 @@ const (
 	host                   = "host"
 	pollerIsolationGroup   = "poller_isolation_group"
 	asyncWFRequestType     = "async_wf_request_type"
+	replicationDLQReason   = "replication_dlq_reason"
 
 	allValue     = "all"
 	unknownValue = "_unknown_"
@@ -242,6 +243,11 @@ func AsyncWFRequestTypeTag(value string) Tag {
 	return metricWithUnknown(asyncWFRequestType, value)
 }
 
+// ReplicationDLQReasonTag returns a new replication DLQ failure reason tag
+func ReplicationDLQReasonTag(value string) Tag {
+	return metricWithUnknown(replicationDLQReason, value)
+}
+
 // PartitionConfigTags returns a list of partition config tags
 func PartitionConfigTags(partitionConfig map[string]string) []Tag {
 	tags := make([]Tag, 0, len(partitionConfig))
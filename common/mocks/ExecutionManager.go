@@ -135,6 +135,20 @@ func (_m *ExecutionManager) CreateFailoverMarkerTasks(ctx context.Context, reque
 	return r0
 }
 
+// CreateNDCConflictResolution provides a mock function with given fields: ctx, request
+func (_m *ExecutionManager) CreateNDCConflictResolution(ctx context.Context, request *persistence.CreateNDCConflictResolutionRequest) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.CreateNDCConflictResolutionRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateWorkflowExecution provides a mock function with given fields: ctx, request
 func (_m *ExecutionManager) CreateWorkflowExecution(ctx context.Context, request *persistence.CreateWorkflowExecutionRequest) (*persistence.CreateWorkflowExecutionResponse, error) {
 	ret := _m.Called(ctx, request)
@@ -481,6 +495,29 @@ func (_m *ExecutionManager) ListCurrentExecutions(ctx context.Context, request *
 	return r0, r1
 }
 
+// ListNDCConflictResolutions provides a mock function with given fields: ctx, request
+func (_m *ExecutionManager) ListNDCConflictResolutions(ctx context.Context, request *persistence.ListNDCConflictResolutionsRequest) (*persistence.ListNDCConflictResolutionsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *persistence.ListNDCConflictResolutionsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.ListNDCConflictResolutionsRequest) *persistence.ListNDCConflictResolutionsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListNDCConflictResolutionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.ListNDCConflictResolutionsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PutReplicationTaskToDLQ provides a mock function with given fields: ctx, request
 func (_m *ExecutionManager) PutReplicationTaskToDLQ(ctx context.Context, request *persistence.PutReplicationTaskToDLQRequest) error {
 	ret := _m.Called(ctx, request)
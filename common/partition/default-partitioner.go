@@ -39,6 +39,10 @@ import (
 const (
 	IsolationGroupKey = "isolation-group"
 	WorkflowIDKey     = "wf-id"
+	// ActiveRegionKey is the key of the region of a workflow of an active-active domain
+	ActiveRegionKey = "active-region"
+	// LocalOnlyKey is the key which marks a workflow of a global domain as local-only, i.e. not replicated
+	LocalOnlyKey = "local-only"
 )
 
 // ErrNoIsolationGroupsAvailable is returned when there are no available isolation-groups
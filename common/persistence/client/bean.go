@@ -51,6 +51,9 @@ type (
 		GetDomainReplicationQueueManager() persistence.QueueManager
 		SetDomainReplicationQueueManager(persistence.QueueManager)
 
+		GetHistoryTaskQueueManager(int) (persistence.QueueManager, error)
+		SetHistoryTaskQueueManager(int, persistence.QueueManager)
+
 		GetShardManager() persistence.ShardManager
 		SetShardManager(persistence.ShardManager)
 
@@ -73,10 +76,11 @@ type (
 		shardManager                  persistence.ShardManager
 		historyManager                persistence.HistoryManager
 		configStoreManager            persistence.ConfigStoreManager
-		executionManagerFactory       persistence.ExecutionManagerFactory
+		factory                       Factory
 
 		sync.RWMutex
-		shardIDToExecutionManager map[int]persistence.ExecutionManager
+		shardIDToExecutionManager        map[int]persistence.ExecutionManager
+		shardIDToHistoryTaskQueueManager map[int]persistence.QueueManager
 	}
 
 	// Params contains dependencies for persistence
@@ -154,7 +158,7 @@ func NewBean(
 	shardManager persistence.ShardManager,
 	historyManager persistence.HistoryManager,
 	configStoreManager persistence.ConfigStoreManager,
-	executionManagerFactory persistence.ExecutionManagerFactory,
+	factory Factory,
 ) *BeanImpl {
 	return &BeanImpl{
 		domainManager:                 domainManager,
@@ -164,9 +168,10 @@ func NewBean(
 		shardManager:                  shardManager,
 		historyManager:                historyManager,
 		configStoreManager:            configStoreManager,
-		executionManagerFactory:       executionManagerFactory,
+		factory:                       factory,
 
-		shardIDToExecutionManager: make(map[int]persistence.ExecutionManager),
+		shardIDToExecutionManager:        make(map[int]persistence.ExecutionManager),
+		shardIDToHistoryTaskQueueManager: make(map[int]persistence.QueueManager),
 	}
 }
 
@@ -250,6 +255,48 @@ func (s *BeanImpl) SetDomainReplicationQueueManager(
 	s.domainReplicationQueueManager = domainReplicationQueueManager
 }
 
+// GetHistoryTaskQueueManager gets history task QueueManager of the given shard
+func (s *BeanImpl) GetHistoryTaskQueueManager(
+	shardID int,
+) (persistence.QueueManager, error) {
+
+	s.RLock()
+	historyTaskQueueManager, ok := s.shardIDToHistoryTaskQueueManager[shardID]
+	if ok {
+		s.RUnlock()
+		return historyTaskQueueManager, nil
+	}
+	s.RUnlock()
+
+	s.Lock()
+	defer s.Unlock()
+
+	historyTaskQueueManager, ok = s.shardIDToHistoryTaskQueueManager[shardID]
+	if ok {
+		return historyTaskQueueManager, nil
+	}
+
+	historyTaskQueueManager, err := s.factory.NewHistoryTaskQueueManager(shardID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.shardIDToHistoryTaskQueueManager[shardID] = historyTaskQueueManager
+	return historyTaskQueueManager, nil
+}
+
+// SetHistoryTaskQueueManager sets history task QueueManager of the given shard
+func (s *BeanImpl) SetHistoryTaskQueueManager(
+	shardID int,
+	historyTaskQueueManager persistence.QueueManager,
+) {
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.shardIDToHistoryTaskQueueManager[shardID] = historyTaskQueueManager
+}
+
 // GetShardManager get ShardManager
 func (s *BeanImpl) GetShardManager() persistence.ShardManager {
 
@@ -311,7 +358,7 @@ func (s *BeanImpl) GetExecutionManager(
 		return executionManager, nil
 	}
 
-	executionManager, err := s.executionManagerFactory.NewExecutionManager(shardID)
+	executionManager, err := s.factory.NewExecutionManager(shardID)
 	if err != nil {
 		return nil, err
 	}
@@ -367,8 +414,11 @@ func (s *BeanImpl) Close() {
 	s.domainReplicationQueueManager.Close()
 	s.shardManager.Close()
 	s.historyManager.Close()
-	s.executionManagerFactory.Close()
+	s.factory.Close()
 	for _, executionMgr := range s.shardIDToExecutionManager {
 		executionMgr.Close()
 	}
+	for _, historyTaskQueueMgr := range s.shardIDToHistoryTaskQueueManager {
+		historyTaskQueueMgr.Close()
+	}
 }
@@ -140,6 +140,21 @@ func (mr *MockBeanMockRecorder) GetHistoryManager() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoryManager", reflect.TypeOf((*MockBean)(nil).GetHistoryManager))
 }
 
+// GetHistoryTaskQueueManager mocks base method.
+func (m *MockBean) GetHistoryTaskQueueManager(arg0 int) (persistence.QueueManager, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistoryTaskQueueManager", arg0)
+	ret0, _ := ret[0].(persistence.QueueManager)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistoryTaskQueueManager indicates an expected call of GetHistoryTaskQueueManager.
+func (mr *MockBeanMockRecorder) GetHistoryTaskQueueManager(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoryTaskQueueManager", reflect.TypeOf((*MockBean)(nil).GetHistoryTaskQueueManager), arg0)
+}
+
 // GetShardManager mocks base method.
 func (m *MockBean) GetShardManager() persistence.ShardManager {
 	m.ctrl.T.Helper()
@@ -242,6 +257,18 @@ func (mr *MockBeanMockRecorder) SetHistoryManager(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHistoryManager", reflect.TypeOf((*MockBean)(nil).SetHistoryManager), arg0)
 }
 
+// SetHistoryTaskQueueManager mocks base method.
+func (m *MockBean) SetHistoryTaskQueueManager(arg0 int, arg1 persistence.QueueManager) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetHistoryTaskQueueManager", arg0, arg1)
+}
+
+// SetHistoryTaskQueueManager indicates an expected call of SetHistoryTaskQueueManager.
+func (mr *MockBeanMockRecorder) SetHistoryTaskQueueManager(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHistoryTaskQueueManager", reflect.TypeOf((*MockBean)(nil).SetHistoryTaskQueueManager), arg0, arg1)
+}
+
 // SetShardManager mocks base method.
 func (m *MockBean) SetShardManager(arg0 persistence.ShardManager) {
 	m.ctrl.T.Helper()
@@ -69,6 +69,8 @@ type (
 		NewVisibilityManager(params *Params, serviceConfig *service.Config) (p.VisibilityManager, error)
 		// NewDomainReplicationQueueManager returns a new queue for domain replication
 		NewDomainReplicationQueueManager() (p.QueueManager, error)
+		// NewHistoryTaskQueueManager returns a new queue for history tasks of the given shard
+		NewHistoryTaskQueueManager(shardID int) (p.QueueManager, error)
 		// NewConfigStoreManager returns a new config store manager
 		NewConfigStoreManager() (p.ConfigStoreManager, error)
 	}
@@ -425,8 +427,16 @@ func (f *factoryImpl) newDBVisibilityManager(
 }
 
 func (f *factoryImpl) NewDomainReplicationQueueManager() (p.QueueManager, error) {
+	return f.newQueueManager(p.DomainReplicationQueueType)
+}
+
+func (f *factoryImpl) NewHistoryTaskQueueManager(shardID int) (p.QueueManager, error) {
+	return f.newQueueManager(p.HistoryTaskQueueType(shardID))
+}
+
+func (f *factoryImpl) newQueueManager(queueType p.QueueType) (p.QueueManager, error) {
 	ds := f.datastores[storeTypeQueue]
-	store, err := ds.factory.NewQueue(p.DomainReplicationQueueType)
+	store, err := ds.factory.NewQueue(queueType)
 	if err != nil {
 		return nil, err
 	}
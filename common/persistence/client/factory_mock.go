@@ -145,6 +145,21 @@ func (mr *MockFactoryMockRecorder) NewHistoryManager() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewHistoryManager", reflect.TypeOf((*MockFactory)(nil).NewHistoryManager))
 }
 
+// NewHistoryTaskQueueManager mocks base method.
+func (m *MockFactory) NewHistoryTaskQueueManager(shardID int) (persistence.QueueManager, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewHistoryTaskQueueManager", shardID)
+	ret0, _ := ret[0].(persistence.QueueManager)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewHistoryTaskQueueManager indicates an expected call of NewHistoryTaskQueueManager.
+func (mr *MockFactoryMockRecorder) NewHistoryTaskQueueManager(shardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewHistoryTaskQueueManager", reflect.TypeOf((*MockFactory)(nil).NewHistoryTaskQueueManager), shardID)
+}
+
 // NewShardManager mocks base method.
 func (m *MockFactory) NewShardManager() (persistence.ShardManager, error) {
 	m.ctrl.T.Helper()
@@ -22,6 +22,7 @@ package persistence
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/uber/cadence/common"
@@ -85,3 +86,38 @@ func (m *configStoreManagerImpl) UpdateDynamicConfig(ctx context.Context, reques
 
 	return m.persistence.UpdateConfig(ctx, entry)
 }
+
+func (m *configStoreManagerImpl) FetchHistoryShardMapping(ctx context.Context) (*FetchHistoryShardMappingResponse, error) {
+	values, err := m.persistence.FetchConfig(ctx, HistoryShardMappingConfig)
+	if err != nil || values == nil {
+		return nil, err
+	}
+
+	var mapping common.HistoryShardMapping
+	if err := json.Unmarshal(values.Values.Data, &mapping); err != nil {
+		return nil, NewCadenceDeserializationError(err.Error())
+	}
+	mapping.Version = values.Version
+
+	return &FetchHistoryShardMappingResponse{Mapping: &mapping}, nil
+}
+
+func (m *configStoreManagerImpl) UpdateHistoryShardMapping(ctx context.Context, request *UpdateHistoryShardMappingRequest) error {
+	if err := request.Mapping.Validate(); err != nil {
+		return &InvalidPersistenceRequestError{Msg: err.Error()}
+	}
+
+	data, err := json.Marshal(request.Mapping)
+	if err != nil {
+		return NewCadenceSerializationError(err.Error())
+	}
+
+	entry := &InternalConfigStoreEntry{
+		RowType:   int(HistoryShardMappingConfig),
+		Version:   request.Mapping.Version,
+		Timestamp: time.Now(),
+		Values:    NewDataBlob(data, common.EncodingTypeJSON),
+	}
+
+	return m.persistence.UpdateConfig(ctx, entry)
+}
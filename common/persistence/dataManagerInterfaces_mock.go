@@ -361,6 +361,20 @@ func (mr *MockExecutionManagerMockRecorder) CreateFailoverMarkerTasks(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFailoverMarkerTasks", reflect.TypeOf((*MockExecutionManager)(nil).CreateFailoverMarkerTasks), arg0, arg1)
 }
 
+// CreateNDCConflictResolution mocks base method.
+func (m *MockExecutionManager) CreateNDCConflictResolution(arg0 context.Context, arg1 *CreateNDCConflictResolutionRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNDCConflictResolution", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNDCConflictResolution indicates an expected call of CreateNDCConflictResolution.
+func (mr *MockExecutionManagerMockRecorder) CreateNDCConflictResolution(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNDCConflictResolution", reflect.TypeOf((*MockExecutionManager)(nil).CreateNDCConflictResolution), arg0, arg1)
+}
+
 // CreateWorkflowExecution mocks base method.
 func (m *MockExecutionManager) CreateWorkflowExecution(arg0 context.Context, arg1 *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
 	m.ctrl.T.Helper()
@@ -611,6 +625,21 @@ func (mr *MockExecutionManagerMockRecorder) ListCurrentExecutions(arg0, arg1 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCurrentExecutions", reflect.TypeOf((*MockExecutionManager)(nil).ListCurrentExecutions), arg0, arg1)
 }
 
+// ListNDCConflictResolutions mocks base method.
+func (m *MockExecutionManager) ListNDCConflictResolutions(arg0 context.Context, arg1 *ListNDCConflictResolutionsRequest) (*ListNDCConflictResolutionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNDCConflictResolutions", arg0, arg1)
+	ret0, _ := ret[0].(*ListNDCConflictResolutionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNDCConflictResolutions indicates an expected call of ListNDCConflictResolutions.
+func (mr *MockExecutionManagerMockRecorder) ListNDCConflictResolutions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNDCConflictResolutions", reflect.TypeOf((*MockExecutionManager)(nil).ListNDCConflictResolutions), arg0, arg1)
+}
+
 // PutReplicationTaskToDLQ mocks base method.
 func (m *MockExecutionManager) PutReplicationTaskToDLQ(arg0 context.Context, arg1 *PutReplicationTaskToDLQRequest) error {
 	m.ctrl.T.Helper()
@@ -1540,6 +1569,21 @@ func (mr *MockConfigStoreManagerMockRecorder) FetchDynamicConfig(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDynamicConfig", reflect.TypeOf((*MockConfigStoreManager)(nil).FetchDynamicConfig), arg0, arg1)
 }
 
+// FetchHistoryShardMapping mocks base method.
+func (m *MockConfigStoreManager) FetchHistoryShardMapping(arg0 context.Context) (*FetchHistoryShardMappingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchHistoryShardMapping", arg0)
+	ret0, _ := ret[0].(*FetchHistoryShardMappingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchHistoryShardMapping indicates an expected call of FetchHistoryShardMapping.
+func (mr *MockConfigStoreManagerMockRecorder) FetchHistoryShardMapping(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchHistoryShardMapping", reflect.TypeOf((*MockConfigStoreManager)(nil).FetchHistoryShardMapping), arg0)
+}
+
 // UpdateDynamicConfig mocks base method.
 func (m *MockConfigStoreManager) UpdateDynamicConfig(arg0 context.Context, arg1 *UpdateDynamicConfigRequest, arg2 ConfigType) error {
 	m.ctrl.T.Helper()
@@ -1553,3 +1597,17 @@ func (mr *MockConfigStoreManagerMockRecorder) UpdateDynamicConfig(arg0, arg1, ar
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDynamicConfig", reflect.TypeOf((*MockConfigStoreManager)(nil).UpdateDynamicConfig), arg0, arg1, arg2)
 }
+
+// UpdateHistoryShardMapping mocks base method.
+func (m *MockConfigStoreManager) UpdateHistoryShardMapping(arg0 context.Context, arg1 *UpdateHistoryShardMappingRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHistoryShardMapping", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateHistoryShardMapping indicates an expected call of UpdateHistoryShardMapping.
+func (mr *MockConfigStoreManagerMockRecorder) UpdateHistoryShardMapping(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHistoryShardMapping", reflect.TypeOf((*MockConfigStoreManager)(nil).UpdateHistoryShardMapping), arg0, arg1)
+}
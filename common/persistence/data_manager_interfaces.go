@@ -94,6 +94,15 @@ const (
 	DomainReplicationQueueType QueueType = iota + 1
 )
 
+// historyTaskQueueTypeBase is the first queue type used by the per shard history task queues,
+// it is far above the fixed queue types so that the two ranges never overlap
+const historyTaskQueueTypeBase QueueType = 1 << 20
+
+// HistoryTaskQueueType returns the queue type of the history task queue of the given shard
+func HistoryTaskQueueType(shardID int) QueueType {
+	return historyTaskQueueTypeBase + QueueType(shardID)
+}
+
 // Create Workflow Execution Mode
 const (
 	// Fail if current record exists
@@ -215,6 +224,7 @@ const (
 	TaskTypeDeleteHistoryEvent
 	TaskTypeActivityRetryTimer
 	TaskTypeWorkflowBackoffTimer
+	TaskTypeWorkflowNoProgressTimeout
 )
 
 // WorkflowRequestType is the type of workflow request
@@ -277,6 +287,7 @@ type ConfigType int
 const (
 	DynamicConfig ConfigType = iota
 	GlobalIsolationGroupConfig
+	HistoryShardMappingConfig
 )
 
 type (
@@ -348,6 +359,11 @@ type (
 		Memo                               map[string][]byte
 		SearchAttributes                   map[string][]byte
 		PartitionConfig                    map[string]string
+		// for no progress timeout
+		NoProgressTimeout        int32
+		NoProgressTimeoutPolicy  int32
+		LastProgressTimestamp    time.Time
+		NoProgressTimerTimestamp time.Time
 		// for retry
 		Attempt            int32
 		HasRetryPolicy     bool
@@ -716,6 +732,30 @@ type (
 		Exists bool
 	}
 
+	// CreateNDCConflictResolutionRequest is used to persist the record of an NDC conflict resolution of a workflow run
+	CreateNDCConflictResolutionRequest struct {
+		Record *types.NDCConflictResolutionRecord
+		// TTL is how long the record is kept
+		TTL        time.Duration
+		DomainName string
+	}
+
+	// ListNDCConflictResolutionsRequest is used to list the NDC conflict resolutions of a workflow run
+	ListNDCConflictResolutionsRequest struct {
+		DomainID      string
+		WorkflowID    string
+		RunID         string
+		PageSize      int
+		NextPageToken []byte
+		DomainName    string
+	}
+
+	// ListNDCConflictResolutionsResponse is the response to ListNDCConflictResolutions
+	ListNDCConflictResolutionsResponse struct {
+		Records       []*types.NDCConflictResolutionRecord
+		NextPageToken []byte
+	}
+
 	// UpdateWorkflowExecutionRequest is used to update a workflow execution
 	UpdateWorkflowExecutionRequest struct {
 		RangeID int64
@@ -1504,6 +1544,16 @@ type (
 		Snapshot *DynamicConfigSnapshot
 	}
 
+	// FetchHistoryShardMappingResponse is the response of FetchHistoryShardMapping
+	FetchHistoryShardMappingResponse struct {
+		Mapping *common.HistoryShardMapping
+	}
+
+	// UpdateHistoryShardMappingRequest is used to persist a new version of the history shard mapping
+	UpdateHistoryShardMappingRequest struct {
+		Mapping *common.HistoryShardMapping
+	}
+
 	DynamicConfigSnapshot struct {
 		Version int64
 		Values  *types.DynamicConfigBlob
@@ -1538,6 +1588,10 @@ type (
 		GetCurrentExecution(ctx context.Context, request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error)
 		IsWorkflowExecutionExists(ctx context.Context, request *IsWorkflowExecutionExistsRequest) (*IsWorkflowExecutionExistsResponse, error)
 
+		// NDC conflict resolution audit related methods
+		CreateNDCConflictResolution(ctx context.Context, request *CreateNDCConflictResolutionRequest) error
+		ListNDCConflictResolutions(ctx context.Context, request *ListNDCConflictResolutionsRequest) (*ListNDCConflictResolutionsResponse, error)
+
 		// Transfer task related methods
 		GetTransferTasks(ctx context.Context, request *GetTransferTasksRequest) (*GetTransferTasksResponse, error)
 		CompleteTransferTask(ctx context.Context, request *CompleteTransferTaskRequest) error
@@ -1663,7 +1717,8 @@ type (
 		Closeable
 		FetchDynamicConfig(ctx context.Context, cfgType ConfigType) (*FetchDynamicConfigResponse, error)
 		UpdateDynamicConfig(ctx context.Context, request *UpdateDynamicConfigRequest, cfgType ConfigType) error
-		// can add functions for config types other than dynamic config
+		FetchHistoryShardMapping(ctx context.Context) (*FetchHistoryShardMappingResponse, error)
+		UpdateHistoryShardMapping(ctx context.Context, request *UpdateHistoryShardMappingRequest) error
 	}
 )
 
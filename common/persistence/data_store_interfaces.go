@@ -109,6 +109,10 @@ type (
 		GetCurrentExecution(ctx context.Context, request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error)
 		IsWorkflowExecutionExists(ctx context.Context, request *IsWorkflowExecutionExistsRequest) (*IsWorkflowExecutionExistsResponse, error)
 
+		// NDC conflict resolution audit related methods
+		CreateNDCConflictResolution(ctx context.Context, request *InternalCreateNDCConflictResolutionRequest) error
+		ListNDCConflictResolutions(ctx context.Context, request *ListNDCConflictResolutionsRequest) (*InternalListNDCConflictResolutionsResponse, error)
+
 		// Transfer task related methods
 		GetTransferTasks(ctx context.Context, request *GetTransferTasksRequest) (*GetTransferTasksResponse, error)
 		CompleteTransferTask(ctx context.Context, request *CompleteTransferTaskRequest) error
@@ -335,6 +339,11 @@ type (
 		SearchAttributes   map[string][]byte
 		PartitionConfig    map[string]string
 
+		NoProgressTimeout        time.Duration
+		NoProgressTimeoutPolicy  int32
+		LastProgressTimestamp    time.Time
+		NoProgressTimerTimestamp time.Time
+
 		// attributes which are not related to mutable state at all
 		HistorySize int64
 		IsCron      bool
@@ -552,6 +561,23 @@ type (
 		State *InternalWorkflowMutableState
 	}
 
+	// InternalCreateNDCConflictResolutionRequest is used to persist the record of an NDC conflict resolution
+	// of a workflow run. Records of a run are ordered by CreatedTime and expire after TTL
+	InternalCreateNDCConflictResolutionRequest struct {
+		DomainID    string
+		WorkflowID  string
+		RunID       string
+		CreatedTime time.Time
+		TTL         time.Duration
+		Record      *DataBlob
+	}
+
+	// InternalListNDCConflictResolutionsResponse is the response to ListNDCConflictResolutions for Persistence Interface
+	InternalListNDCConflictResolutionsResponse struct {
+		Records       []*DataBlob
+		NextPageToken []byte
+	}
+
 	// InternalListConcreteExecutionsResponse is the response to ListConcreteExecutions for Persistence Interface
 	InternalListConcreteExecutionsResponse struct {
 		Executions    []*InternalListConcreteExecutionsEntity
@@ -152,6 +152,20 @@ func (mr *MockExecutionStoreMockRecorder) CreateFailoverMarkerTasks(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFailoverMarkerTasks", reflect.TypeOf((*MockExecutionStore)(nil).CreateFailoverMarkerTasks), arg0, arg1)
 }
 
+// CreateNDCConflictResolution mocks base method.
+func (m *MockExecutionStore) CreateNDCConflictResolution(arg0 context.Context, arg1 *InternalCreateNDCConflictResolutionRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNDCConflictResolution", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNDCConflictResolution indicates an expected call of CreateNDCConflictResolution.
+func (mr *MockExecutionStoreMockRecorder) CreateNDCConflictResolution(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNDCConflictResolution", reflect.TypeOf((*MockExecutionStore)(nil).CreateNDCConflictResolution), arg0, arg1)
+}
+
 // CreateWorkflowExecution mocks base method.
 func (m *MockExecutionStore) CreateWorkflowExecution(arg0 context.Context, arg1 *InternalCreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
 	m.ctrl.T.Helper()
@@ -402,6 +416,21 @@ func (mr *MockExecutionStoreMockRecorder) ListCurrentExecutions(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCurrentExecutions", reflect.TypeOf((*MockExecutionStore)(nil).ListCurrentExecutions), arg0, arg1)
 }
 
+// ListNDCConflictResolutions mocks base method.
+func (m *MockExecutionStore) ListNDCConflictResolutions(arg0 context.Context, arg1 *ListNDCConflictResolutionsRequest) (*InternalListNDCConflictResolutionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNDCConflictResolutions", arg0, arg1)
+	ret0, _ := ret[0].(*InternalListNDCConflictResolutionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNDCConflictResolutions indicates an expected call of ListNDCConflictResolutions.
+func (mr *MockExecutionStoreMockRecorder) ListNDCConflictResolutions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNDCConflictResolutions", reflect.TypeOf((*MockExecutionStore)(nil).ListNDCConflictResolutions), arg0, arg1)
+}
+
 // PutReplicationTaskToDLQ mocks base method.
 func (m *MockExecutionStore) PutReplicationTaskToDLQ(arg0 context.Context, arg1 *InternalPutReplicationTaskToDLQRequest) error {
 	m.ctrl.T.Helper()
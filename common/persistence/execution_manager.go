@@ -23,6 +23,7 @@ package persistence
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/uber/cadence/common"
@@ -197,6 +198,10 @@ func (m *executionManagerImpl) DeserializeExecutionInfo(
 		SearchAttributes:                   info.SearchAttributes,
 		Memo:                               info.Memo,
 		PartitionConfig:                    info.PartitionConfig,
+		NoProgressTimeout:                  int32(info.NoProgressTimeout.Seconds()),
+		NoProgressTimeoutPolicy:            info.NoProgressTimeoutPolicy,
+		LastProgressTimestamp:              info.LastProgressTimestamp,
+		NoProgressTimerTimestamp:           info.NoProgressTimerTimestamp,
 	}
 	newStats := &ExecutionStats{
 		HistorySize: info.HistorySize,
@@ -525,6 +530,10 @@ func (m *executionManagerImpl) SerializeExecutionInfo(
 		Memo:                               info.Memo,
 		SearchAttributes:                   info.SearchAttributes,
 		PartitionConfig:                    info.PartitionConfig,
+		NoProgressTimeout:                  common.SecondsToDuration(int64(info.NoProgressTimeout)),
+		NoProgressTimeoutPolicy:            info.NoProgressTimeoutPolicy,
+		LastProgressTimestamp:              info.LastProgressTimestamp,
+		NoProgressTimerTimestamp:           info.NoProgressTimerTimestamp,
 
 		// attributes which are not related to mutable state
 		HistorySize: stats.HistorySize,
@@ -815,6 +824,47 @@ func (m *executionManagerImpl) IsWorkflowExecutionExists(
 	return m.persistence.IsWorkflowExecutionExists(ctx, request)
 }
 
+func (m *executionManagerImpl) CreateNDCConflictResolution(
+	ctx context.Context,
+	request *CreateNDCConflictResolutionRequest,
+) error {
+	record := request.Record
+	data, err := json.Marshal(record)
+	if err != nil {
+		return NewCadenceSerializationError(err.Error())
+	}
+	return m.persistence.CreateNDCConflictResolution(ctx, &InternalCreateNDCConflictResolutionRequest{
+		DomainID:    record.DomainID,
+		WorkflowID:  record.WorkflowID,
+		RunID:       record.RunID,
+		CreatedTime: time.Unix(0, record.Timestamp),
+		TTL:         request.TTL,
+		Record:      NewDataBlob(data, common.EncodingTypeJSON),
+	})
+}
+
+func (m *executionManagerImpl) ListNDCConflictResolutions(
+	ctx context.Context,
+	request *ListNDCConflictResolutionsRequest,
+) (*ListNDCConflictResolutionsResponse, error) {
+	response, err := m.persistence.ListNDCConflictResolutions(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	newResponse := &ListNDCConflictResolutionsResponse{
+		Records:       make([]*types.NDCConflictResolutionRecord, 0, len(response.Records)),
+		NextPageToken: response.NextPageToken,
+	}
+	for _, blob := range response.Records {
+		var record types.NDCConflictResolutionRecord
+		if err := json.Unmarshal(blob.Data, &record); err != nil {
+			return nil, NewCadenceDeserializationError(err.Error())
+		}
+		newResponse.Records = append(newResponse.Records, &record)
+	}
+	return newResponse, nil
+}
+
 func (m *executionManagerImpl) ListConcreteExecutions(
 	ctx context.Context,
 	request *ListConcreteExecutionsRequest,
@@ -286,6 +286,70 @@ func TestGetReplicationTasks(t *testing.T) {
 	}
 }
 
+func TestExecutionManager_NDCConflictResolutions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockedStore := NewMockExecutionStore(ctrl)
+	manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), nil)
+
+	record := &types.NDCConflictResolutionRecord{
+		Type:              types.NDCConflictResolutionTypeEventsReapply,
+		DomainID:          testDomainID,
+		WorkflowID:        testWorkflowID,
+		RunID:             testRunID,
+		Timestamp:         testEarliestTime,
+		SourceBranchIndex: 1,
+		WinningVersion:    10,
+		LosingVersion:     1,
+		ReappliedEvents: []*types.NDCReappliedEvent{
+			{EventID: 5, Version: 1, EventType: types.EventTypeWorkflowExecutionSignaled.Ptr()},
+		},
+		ReappliedToRunID: testRunID,
+	}
+	var blob *DataBlob
+	mockedStore.EXPECT().CreateNDCConflictResolution(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *InternalCreateNDCConflictResolutionRequest) error {
+			assert.Equal(t, testDomainID, request.DomainID)
+			assert.Equal(t, testWorkflowID, request.WorkflowID)
+			assert.Equal(t, testRunID, request.RunID)
+			assert.Equal(t, time.Unix(0, testEarliestTime), request.CreatedTime)
+			assert.Equal(t, time.Hour, request.TTL)
+			blob = request.Record
+			return nil
+		},
+	)
+	err := manager.CreateNDCConflictResolution(context.Background(), &CreateNDCConflictResolutionRequest{
+		Record:     record,
+		TTL:        time.Hour,
+		DomainName: testDomain,
+	})
+	assert.NoError(t, err)
+
+	listRequest := &ListNDCConflictResolutionsRequest{
+		DomainID:   testDomainID,
+		WorkflowID: testWorkflowID,
+		RunID:      testRunID,
+		PageSize:   testPageSize,
+		DomainName: testDomain,
+	}
+	mockedStore.EXPECT().ListNDCConflictResolutions(gomock.Any(), listRequest).Return(&InternalListNDCConflictResolutionsResponse{
+		Records:       []*DataBlob{blob},
+		NextPageToken: []byte("next page token"),
+	}, nil)
+	res, err := manager.ListNDCConflictResolutions(context.Background(), listRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, &ListNDCConflictResolutionsResponse{
+		Records:       []*types.NDCConflictResolutionRecord{record},
+		NextPageToken: []byte("next page token"),
+	}, res)
+
+	mockedStore.EXPECT().ListNDCConflictResolutions(gomock.Any(), listRequest).Return(&InternalListNDCConflictResolutionsResponse{
+		Records: []*DataBlob{NewDataBlob([]byte("corrupted"), common.EncodingTypeJSON)},
+	}, nil)
+	_, err = manager.ListNDCConflictResolutions(context.Background(), listRequest)
+	assert.Error(t, err)
+}
+
 func TestExecutionManager_GetWorkflowExecution(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockedStore := NewMockExecutionStore(ctrl)
@@ -137,6 +137,14 @@ func (r *IsWorkflowExecutionExistsRequest) GetDomainName() string {
 	return r.DomainName
 }
 
+func (r *CreateNDCConflictResolutionRequest) GetDomainName() string {
+	return r.DomainName
+}
+
+func (r *ListNDCConflictResolutionsRequest) GetDomainName() string {
+	return r.DomainName
+}
+
 func (r *PutReplicationTaskToDLQRequest) MetricTags() []metrics.Tag {
 	return []metrics.Tag{metrics.DomainTag(r.DomainName)}
 }
@@ -36,6 +36,7 @@ type (
 		clusterName      string
 		logger           log.Logger
 		execStoreFactory *executionStoreFactory
+		queueStore       shardedNosqlStore
 		dc               *persistence.DynamicConfiguration
 	}
 
@@ -90,9 +91,14 @@ func (f *Factory) NewVisibilityStore(sortByCloseTime bool) (persistence.Visibili
 	return newNoSQLVisibilityStore(sortByCloseTime, f.cfg, f.logger, f.dc)
 }
 
-// NewQueue returns a new queue backed by cassandra
+// NewQueue returns a new queue backed by cassandra, all queues share the same connection
+// since there is one history task queue per shard
 func (f *Factory) NewQueue(queueType persistence.QueueType) (persistence.Queue, error) {
-	return newNoSQLQueueStore(f.cfg, f.logger, queueType, f.dc)
+	queueStore, err := f.sharedQueueStore()
+	if err != nil {
+		return nil, err
+	}
+	return newNoSQLQueueStore(queueStore.GetDefaultShard(), queueType, true)
 }
 
 // NewConfigStore returns a new config store
@@ -107,6 +113,30 @@ func (f *Factory) Close() {
 	if f.execStoreFactory != nil {
 		f.execStoreFactory.close()
 	}
+	if f.queueStore != nil {
+		f.queueStore.Close()
+	}
+}
+
+func (f *Factory) sharedQueueStore() (shardedNosqlStore, error) {
+	f.RLock()
+	if f.queueStore != nil {
+		f.RUnlock()
+		return f.queueStore, nil
+	}
+	f.RUnlock()
+	f.Lock()
+	defer f.Unlock()
+	if f.queueStore != nil {
+		return f.queueStore, nil
+	}
+
+	queueStore, err := newShardedNosqlStore(f.cfg, f.logger, f.dc)
+	if err != nil {
+		return nil, err
+	}
+	f.queueStore = queueStore
+	return f.queueStore, nil
 }
 
 func (f *Factory) executionStoreFactory() (*executionStoreFactory, error) {
@@ -573,6 +573,47 @@ func (d *nosqlExecutionStore) IsWorkflowExecutionExists(
 	}, nil
 }
 
+func (d *nosqlExecutionStore) CreateNDCConflictResolution(
+	ctx context.Context,
+	request *persistence.InternalCreateNDCConflictResolutionRequest,
+) error {
+	err := d.db.InsertNDCConflictResolution(ctx, &nosqlplugin.NDCConflictResolutionRow{
+		DomainID:    request.DomainID,
+		WorkflowID:  request.WorkflowID,
+		RunID:       request.RunID,
+		CreatedTime: request.CreatedTime,
+		Record:      request.Record,
+	}, request.TTL)
+	if err != nil {
+		return convertCommonErrors(d.db, "CreateNDCConflictResolution", err)
+	}
+	return nil
+}
+
+func (d *nosqlExecutionStore) ListNDCConflictResolutions(
+	ctx context.Context,
+	request *persistence.ListNDCConflictResolutionsRequest,
+) (*persistence.InternalListNDCConflictResolutionsResponse, error) {
+	rows, nextPageToken, err := d.db.SelectNDCConflictResolutions(
+		ctx,
+		request.DomainID,
+		request.WorkflowID,
+		request.RunID,
+		request.PageSize,
+		request.NextPageToken,
+	)
+	if err != nil {
+		return nil, convertCommonErrors(d.db, "ListNDCConflictResolutions", err)
+	}
+	response := &persistence.InternalListNDCConflictResolutionsResponse{
+		NextPageToken: nextPageToken,
+	}
+	for _, row := range rows {
+		response.Records = append(response.Records, row.Record)
+	}
+	return response, nil
+}
+
 func (d *nosqlExecutionStore) ListConcreteExecutions(
 	ctx context.Context,
 	request *persistence.ListConcreteExecutionsRequest,
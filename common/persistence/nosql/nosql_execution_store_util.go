@@ -231,7 +231,7 @@ func (d *nosqlExecutionStore) prepareTimerTasksForWorkflowTxn(domainID, workflow
 			eventID = t.EventID
 			timeoutType = t.TimeoutType
 
-		case *persistence.WorkflowTimeoutTask:
+		case *persistence.WorkflowTimeoutTask, *persistence.WorkflowNoProgressTimeoutTask:
 			// noop
 
 		case *persistence.DeleteHistoryEventTask:
@@ -24,8 +24,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/uber/cadence/common/config"
-	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
 	"github.com/uber/cadence/common/types"
@@ -38,21 +36,20 @@ const (
 type nosqlQueueStore struct {
 	queueType persistence.QueueType
 	nosqlStore
+	// sharedConnection is true when the connection is shared with other
+	// queue stores, in which case it is closed by the factory instead
+	sharedConnection bool
 }
 
 func newNoSQLQueueStore(
-	cfg config.ShardedNoSQL,
-	logger log.Logger,
+	store nosqlStore,
 	queueType persistence.QueueType,
-	dc *persistence.DynamicConfiguration,
+	sharedConnection bool,
 ) (persistence.Queue, error) {
-	shardedStore, err := newShardedNosqlStore(cfg, logger, dc)
-	if err != nil {
-		return nil, err
-	}
 	queue := &nosqlQueueStore{
-		nosqlStore: shardedStore.GetDefaultShard(),
-		queueType:  queueType,
+		nosqlStore:       store,
+		queueType:        queueType,
+		sharedConnection: sharedConnection,
 	}
 	if err := queue.createQueueMetadataEntryIfNotExist(); err != nil {
 		return nil, fmt.Errorf("failed to check and create queue metadata entry: %v", err)
@@ -61,6 +58,12 @@ func newNoSQLQueueStore(
 	return queue, nil
 }
 
+func (q *nosqlQueueStore) Close() {
+	if !q.sharedConnection {
+		q.nosqlStore.Close()
+	}
+}
+
 func (q *nosqlQueueStore) createQueueMetadataEntryIfNotExist() error {
 	queueMetadata, err := q.getQueueMetadata(context.Background(), q.queueType)
 	if err != nil {
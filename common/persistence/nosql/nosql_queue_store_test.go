@@ -60,7 +60,11 @@ func newQueueStoreTestData(t *testing.T) *queueStoreTestData {
 
 func (td *queueStoreTestData) newQueueStore() (persistence.Queue, error) {
 	cfg := getValidShardedNoSQLConfig()
-	return newNoSQLQueueStore(cfg, log.NewNoop(), testQueueType, nil)
+	shardedStore, err := newShardedNosqlStore(cfg, log.NewNoop(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return newNoSQLQueueStore(shardedStore.GetDefaultShard(), testQueueType, false)
 }
 
 func (td *queueStoreTestData) createValidQueueStore(t *testing.T) persistence.Queue {
@@ -106,6 +110,28 @@ func TestNewNoSQLQueueStore_Succeeds(t *testing.T) {
 	td.createValidQueueStore(t) // all the validation already performed inside
 }
 
+func TestFactoryNewQueue_SharesConnection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := nosqlplugin.NewMockDB(ctrl)
+	mockPlugin := nosqlplugin.NewMockPlugin(ctrl)
+	// the queues of all shards use the connection of the default shard
+	mockPlugin.EXPECT().CreateDB(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockDB, nil).Times(1)
+	RegisterPluginForTest(t, "cassandra", mockPlugin)
+	mockDB.EXPECT().SelectQueueMetadata(gomock.Any(), gomock.Any()).Return(&nosqlplugin.QueueMetadataRow{}, nil).Times(4)
+
+	factory := NewFactory(getValidShardedNoSQLConfig(), "test-cluster", log.NewNoop(), nil)
+	queue1, err := factory.NewQueue(persistence.HistoryTaskQueueType(1))
+	require.NoError(t, err)
+	queue2, err := factory.NewQueue(persistence.HistoryTaskQueueType(2))
+	require.NoError(t, err)
+
+	// the shared connection is only closed by the factory
+	queue1.Close()
+	queue2.Close()
+	mockDB.EXPECT().Close().Times(1)
+	factory.Close()
+}
+
 func TestNewNoSQLQueueStore_FailsIfCantReadMetadata(t *testing.T) {
 	selectErr := errors.New("select main-queue metadata failed")
 	td := newQueueStoreTestData(t)
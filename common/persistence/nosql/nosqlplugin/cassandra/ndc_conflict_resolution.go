@@ -0,0 +1,92 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+)
+
+// InsertNDCConflictResolution inserts a record of a workflow run, the record expires after the ttl
+func (db *cdb) InsertNDCConflictResolution(
+	ctx context.Context,
+	row *nosqlplugin.NDCConflictResolutionRow,
+	ttl time.Duration,
+) error {
+	query := db.session.Query(templateInsertNDCConflictResolutionQuery,
+		row.DomainID,
+		row.WorkflowID,
+		row.RunID,
+		row.CreatedTime.UnixNano(),
+		row.Record.Data,
+		row.Record.GetEncodingString(),
+		int64(ttl.Seconds()),
+	).WithContext(ctx)
+	return query.Exec()
+}
+
+// SelectNDCConflictResolutions pages through the records of a workflow run, ordered by created_time(ASC)
+func (db *cdb) SelectNDCConflictResolutions(
+	ctx context.Context,
+	domainID string,
+	workflowID string,
+	runID string,
+	pageSize int,
+	pageToken []byte,
+) ([]*nosqlplugin.NDCConflictResolutionRow, []byte, error) {
+	query := db.session.Query(templateSelectNDCConflictResolutionsQuery,
+		domainID,
+		workflowID,
+		runID,
+	).PageSize(pageSize).PageState(pageToken).WithContext(ctx)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, nil, fmt.Errorf("SelectNDCConflictResolutions operation failed. Not able to create query iterator")
+	}
+
+	var rows []*nosqlplugin.NDCConflictResolutionRow
+	result := make(map[string]interface{})
+	for iter.MapScan(result) {
+		rows = append(rows, &nosqlplugin.NDCConflictResolutionRow{
+			DomainID:    domainID,
+			WorkflowID:  workflowID,
+			RunID:       runID,
+			CreatedTime: time.Unix(0, result["created_time"].(int64)),
+			Record: persistence.NewDataBlob(
+				result["data"].([]byte),
+				common.EncodingType(result["data_encoding"].(string)),
+			),
+		})
+		result = make(map[string]interface{})
+	}
+	nextPageToken := getNextPageToken(iter)
+
+	if err := iter.Close(); err != nil {
+		return nil, nil, err
+	}
+	return rows, nextPageToken, nil
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cassandra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/config"
+	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin/cassandra/gocql"
+)
+
+func TestInsertNDCConflictResolution(t *testing.T) {
+	tests := []struct {
+		name        string
+		queryMockFn func(query *gocql.MockQuery)
+		wantQueries []string
+		wantErr     bool
+	}{
+		{
+			name: "success",
+			queryMockFn: func(query *gocql.MockQuery) {
+				query.EXPECT().WithContext(gomock.Any()).Return(query).Times(1)
+				query.EXPECT().Exec().Return(nil).Times(1)
+			},
+			wantQueries: []string{
+				`INSERT INTO ndc_conflict_resolutions (domain_id, workflow_id, run_id, created_time, data, data_encoding) ` +
+					`VALUES(test-domain-id, test-workflow-id, test-run-id, 1000, [123 125], json) USING TTL 3600`,
+			},
+		},
+		{
+			name: "exec failed",
+			queryMockFn: func(query *gocql.MockQuery) {
+				query.EXPECT().WithContext(gomock.Any()).Return(query).Times(1)
+				query.EXPECT().Exec().Return(errors.New("exec failed")).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			query := gocql.NewMockQuery(ctrl)
+			tc.queryMockFn(query)
+			session := &fakeSession{
+				query: query,
+			}
+			client := gocql.NewMockClient(ctrl)
+			cfg := &config.NoSQL{}
+			logger := testlogger.New(t)
+			db := newCassandraDBFromSession(cfg, session, logger, nil, dbWithClient(client))
+
+			err := db.InsertNDCConflictResolution(context.Background(), &nosqlplugin.NDCConflictResolutionRow{
+				DomainID:    "test-domain-id",
+				WorkflowID:  "test-workflow-id",
+				RunID:       "test-run-id",
+				CreatedTime: time.Unix(0, 1000),
+				Record:      persistence.NewDataBlob([]byte(`{}`), common.EncodingTypeJSON),
+			}, time.Hour)
+
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Got error = %v, wantErr %v", err, tc.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.wantQueries, session.queries); diff != "" {
+				t.Fatalf("Query mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSelectNDCConflictResolutions(t *testing.T) {
+	tests := []struct {
+		name          string
+		iter          *fakeIter
+		wantQueries   []string
+		wantRows      []*nosqlplugin.NDCConflictResolutionRow
+		wantPageToken []byte
+		wantErr       bool
+	}{
+		{
+			name:    "nil iter",
+			iter:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "iter close failed",
+			iter:    &fakeIter{closeErr: errors.New("some random error")},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			iter: &fakeIter{
+				mapScanInputs: []map[string]interface{}{
+					{
+						"created_time":  int64(1000),
+						"data":          []byte(`{"type":0}`),
+						"data_encoding": "json",
+					},
+					{
+						"created_time":  int64(2000),
+						"data":          []byte(`{"type":1}`),
+						"data_encoding": "json",
+					},
+				},
+				pageState: []byte("test-page-state"),
+			},
+			wantRows: []*nosqlplugin.NDCConflictResolutionRow{
+				{
+					DomainID:    "test-domain-id",
+					WorkflowID:  "test-workflow-id",
+					RunID:       "test-run-id",
+					CreatedTime: time.Unix(0, 1000),
+					Record:      persistence.NewDataBlob([]byte(`{"type":0}`), common.EncodingTypeJSON),
+				},
+				{
+					DomainID:    "test-domain-id",
+					WorkflowID:  "test-workflow-id",
+					RunID:       "test-run-id",
+					CreatedTime: time.Unix(0, 2000),
+					Record:      persistence.NewDataBlob([]byte(`{"type":1}`), common.EncodingTypeJSON),
+				},
+			},
+			wantPageToken: []byte("test-page-state"),
+			wantQueries: []string{
+				`SELECT created_time, data, data_encoding FROM ndc_conflict_resolutions ` +
+					`WHERE domain_id = test-domain-id and workflow_id = test-workflow-id and run_id = test-run-id`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			query := gocql.NewMockQuery(ctrl)
+			query.EXPECT().PageSize(10).Return(query).Times(1)
+			query.EXPECT().PageState([]byte("test-page-token")).Return(query).Times(1)
+			query.EXPECT().WithContext(gomock.Any()).Return(query).Times(1)
+			if tc.iter != nil {
+				query.EXPECT().Iter().Return(tc.iter).Times(1)
+			} else {
+				query.EXPECT().Iter().Return(nil).Times(1)
+			}
+
+			session := &fakeSession{
+				query: query,
+			}
+			client := gocql.NewMockClient(ctrl)
+			cfg := &config.NoSQL{}
+			logger := testlogger.New(t)
+			db := newCassandraDBFromSession(cfg, session, logger, nil, dbWithClient(client))
+
+			gotRows, gotPageToken, err := db.SelectNDCConflictResolutions(
+				context.Background(),
+				"test-domain-id",
+				"test-workflow-id",
+				"test-run-id",
+				10,
+				[]byte("test-page-token"),
+			)
+
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Got error = %v, wantErr %v", err, tc.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.wantQueries, session.queries); diff != "" {
+				t.Fatalf("Query mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantRows, gotRows); diff != "" {
+				t.Fatalf("rows mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantPageToken, gotPageToken); diff != "" {
+				t.Fatalf("page token mismatch (-want +got):\n%s", diff)
+			}
+
+			if !tc.iter.closed {
+				t.Fatal("iterator not closed")
+			}
+		})
+	}
+}
@@ -82,7 +82,11 @@ const (
 		`expiration_seconds: ?, ` +
 		`search_attributes: ?, ` +
 		`memo: ?, ` +
-		`partition_config: ? ` +
+		`partition_config: ?, ` +
+		`no_progress_timeout: ?, ` +
+		`no_progress_timeout_policy: ?, ` +
+		`last_progress_timestamp: ?, ` +
+		`no_progress_timer_timestamp: ? ` +
 		`}`
 
 	templateTransferTaskType = `{` +
@@ -175,6 +175,14 @@ func parseWorkflowExecutionInfo(result map[string]interface{}) *persistence.Inte
 			info.Memo = v.(map[string][]byte)
 		case "partition_config":
 			info.PartitionConfig = v.(map[string]string)
+		case "no_progress_timeout":
+			info.NoProgressTimeout = common.SecondsToDuration(int64(v.(int)))
+		case "no_progress_timeout_policy":
+			info.NoProgressTimeoutPolicy = int32(v.(int))
+		case "last_progress_timestamp":
+			info.LastProgressTimestamp = timeFromUnixNanoOrZero(v.(int64))
+		case "no_progress_timer_timestamp":
+			info.NoProgressTimerTimestamp = timeFromUnixNanoOrZero(v.(int64))
 		}
 	}
 	info.CompletionEvent = persistence.NewDataBlob(completionEventData, completionEventEncoding)
@@ -599,3 +607,11 @@ func parseChecksum(result map[string]interface{}) checksum.Checksum {
 	}
 	return csum
 }
+
+// timeFromUnixNanoOrZero maps the 0 stored for unset timestamps back to a zero time.Time
+func timeFromUnixNanoOrZero(v int64) time.Time {
+	if v == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, v)
+}
@@ -118,6 +118,8 @@ func Test_parseWorkflowExecutionInfo(t *testing.T) {
 				"completion_event_data_encoding":        "Proto3",
 				"auto_reset_points":                     autoResetPointsData,
 				"auto_reset_points_encoding":            "Proto3",
+				"last_progress_timestamp":               int64(300),
+				"no_progress_timer_timestamp":           int64(0),
 			},
 			want: &persistence.InternalWorkflowExecutionInfo{
 				DomainID:                           "domain_id",
@@ -168,6 +170,7 @@ func Test_parseWorkflowExecutionInfo(t *testing.T) {
 				NonRetriableErrors:                 []string{"error1", "error2"},
 				Memo:                               memo,
 				PartitionConfig:                    partitionConfig,
+				LastProgressTimestamp:              time.Unix(0, int64(300)),
 			},
 		},
 		{
@@ -235,6 +238,8 @@ func Test_parseWorkflowExecutionInfo(t *testing.T) {
 		assert.Equal(t, result.DecisionOriginalScheduledTimestamp, tt.want.DecisionOriginalScheduledTimestamp)
 		assert.Equal(t, result.DecisionAttempt, tt.want.DecisionAttempt)
 		assert.Equal(t, result.ParentDomainID, tt.want.ParentDomainID)
+		assert.Equal(t, result.LastProgressTimestamp, tt.want.LastProgressTimestamp)
+		assert.Equal(t, result.NoProgressTimerTimestamp, tt.want.NoProgressTimerTimestamp)
 	}
 }
 
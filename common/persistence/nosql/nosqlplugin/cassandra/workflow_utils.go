@@ -1360,6 +1360,10 @@ func updateWorkflowExecution(
 		execution.SearchAttributes,
 		execution.Memo,
 		execution.PartitionConfig,
+		int32(execution.NoProgressTimeout.Seconds()),
+		execution.NoProgressTimeoutPolicy,
+		unixNanoOrZero(execution.LastProgressTimestamp),
+		unixNanoOrZero(execution.NoProgressTimerTimestamp),
 		execution.NextEventID,
 		execution.VersionHistories.Data,
 		execution.VersionHistories.GetEncodingString(),
@@ -1456,6 +1460,10 @@ func createWorkflowExecution(
 		execution.SearchAttributes,
 		execution.Memo,
 		execution.PartitionConfig,
+		int32(execution.NoProgressTimeout.Seconds()),
+		execution.NoProgressTimeoutPolicy,
+		unixNanoOrZero(execution.LastProgressTimestamp),
+		unixNanoOrZero(execution.NoProgressTimerTimestamp),
 		execution.NextEventID,
 		defaultVisibilityTimestamp,
 		rowTypeExecutionTaskID,
@@ -1630,6 +1638,14 @@ func createOrUpdateCurrentWorkflow(
 	return nil
 }
 
+// unixNanoOrZero stores unset timestamps as 0, UnixNano of a zero time.Time is not 0
+func unixNanoOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
 func mustConvertToSlice(value interface{}) []interface{} {
 	v := reflect.ValueOf(value)
 	switch v.Kind() {
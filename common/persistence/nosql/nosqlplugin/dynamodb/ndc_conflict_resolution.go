@@ -0,0 +1,41 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+)
+
+var _ nosqlplugin.NDCConflictResolutionCRUD = (*ddb)(nil)
+
+var errNDCConflictResolutionNotImplemented = errors.New("ndc conflict resolution records are not implemented for dynamodb")
+
+func (db *ddb) InsertNDCConflictResolution(ctx context.Context, row *nosqlplugin.NDCConflictResolutionRow, ttl time.Duration) error {
+	return errNDCConflictResolutionNotImplemented
+}
+
+func (db *ddb) SelectNDCConflictResolutions(ctx context.Context, domainID, workflowID, runID string, pageSize int, pageToken []byte) ([]*nosqlplugin.NDCConflictResolutionRow, []byte, error) {
+	return nil, nil, errNDCConflictResolutionNotImplemented
+}
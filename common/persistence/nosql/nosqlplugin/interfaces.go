@@ -71,6 +71,7 @@ type (
 		TaskCRUD
 		WorkflowCRUD
 		ConfigStoreCRUD
+		NDCConflictResolutionCRUD
 	}
 
 	// ClientErrorChecker checks for common nosql errors on client
@@ -532,4 +533,19 @@ type (
 		// SelectLatestConfig returns the config entry of the row_type with the largest(latest) version value
 		SelectLatestConfig(ctx context.Context, rowType int) (*persistence.InternalConfigStoreEntry, error)
 	}
+
+	/***
+	* NDCConflictResolutionCRUD is for storing the audit records of NDC conflict resolutions
+	*
+	* Recommendation: one table, with a TTL on every row
+	*
+	* Significant columns:
+	* ndc_conflict_resolutions: partition key(domainID, workflowID, runID), range key(createdTime)
+	 */
+	NDCConflictResolutionCRUD interface {
+		// InsertNDCConflictResolution inserts a record of a workflow run, the record expires after the ttl
+		InsertNDCConflictResolution(ctx context.Context, row *NDCConflictResolutionRow, ttl time.Duration) error
+		// SelectNDCConflictResolutions pages through the records of a workflow run, ordered by createdTime(ASC)
+		SelectNDCConflictResolutions(ctx context.Context, domainID, workflowID, runID string, pageSize int, pageToken []byte) ([]*NDCConflictResolutionRow, []byte, error)
+	}
 )
@@ -459,6 +459,20 @@ func (mr *MockDBMockRecorder) InsertIntoQueue(ctx, row interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertIntoQueue", reflect.TypeOf((*MockDB)(nil).InsertIntoQueue), ctx, row)
 }
 
+// InsertNDCConflictResolution mocks base method.
+func (m *MockDB) InsertNDCConflictResolution(ctx context.Context, row *NDCConflictResolutionRow, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNDCConflictResolution", ctx, row, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertNDCConflictResolution indicates an expected call of InsertNDCConflictResolution.
+func (mr *MockDBMockRecorder) InsertNDCConflictResolution(ctx, row, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNDCConflictResolution", reflect.TypeOf((*MockDB)(nil).InsertNDCConflictResolution), ctx, row, ttl)
+}
+
 // InsertQueueMetadata mocks base method.
 func (m *MockDB) InsertQueueMetadata(ctx context.Context, queueType persistence.QueueType, version int64) error {
 	m.ctrl.T.Helper()
@@ -972,6 +986,22 @@ func (mr *MockDBMockRecorder) SelectMessagesFrom(ctx, queueType, exclusiveBeginM
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectMessagesFrom", reflect.TypeOf((*MockDB)(nil).SelectMessagesFrom), ctx, queueType, exclusiveBeginMessageID, maxRows)
 }
 
+// SelectNDCConflictResolutions mocks base method.
+func (m *MockDB) SelectNDCConflictResolutions(ctx context.Context, domainID, workflowID, runID string, pageSize int, pageToken []byte) ([]*NDCConflictResolutionRow, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectNDCConflictResolutions", ctx, domainID, workflowID, runID, pageSize, pageToken)
+	ret0, _ := ret[0].([]*NDCConflictResolutionRow)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SelectNDCConflictResolutions indicates an expected call of SelectNDCConflictResolutions.
+func (mr *MockDBMockRecorder) SelectNDCConflictResolutions(ctx, domainID, workflowID, runID, pageSize, pageToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectNDCConflictResolutions", reflect.TypeOf((*MockDB)(nil).SelectNDCConflictResolutions), ctx, domainID, workflowID, runID, pageSize, pageToken)
+}
+
 // SelectOneClosedWorkflow mocks base method.
 func (m *MockDB) SelectOneClosedWorkflow(ctx context.Context, domainID, workflowID, runID string) (*VisibilityRow, error) {
 	m.ctrl.T.Helper()
@@ -1574,6 +1604,20 @@ func (mr *MocktableCRUDMockRecorder) InsertIntoQueue(ctx, row interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertIntoQueue", reflect.TypeOf((*MocktableCRUD)(nil).InsertIntoQueue), ctx, row)
 }
 
+// InsertNDCConflictResolution mocks base method.
+func (m *MocktableCRUD) InsertNDCConflictResolution(ctx context.Context, row *NDCConflictResolutionRow, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNDCConflictResolution", ctx, row, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertNDCConflictResolution indicates an expected call of InsertNDCConflictResolution.
+func (mr *MocktableCRUDMockRecorder) InsertNDCConflictResolution(ctx, row, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNDCConflictResolution", reflect.TypeOf((*MocktableCRUD)(nil).InsertNDCConflictResolution), ctx, row, ttl)
+}
+
 // InsertQueueMetadata mocks base method.
 func (m *MocktableCRUD) InsertQueueMetadata(ctx context.Context, queueType persistence.QueueType, version int64) error {
 	m.ctrl.T.Helper()
@@ -2017,6 +2061,22 @@ func (mr *MocktableCRUDMockRecorder) SelectMessagesFrom(ctx, queueType, exclusiv
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectMessagesFrom", reflect.TypeOf((*MocktableCRUD)(nil).SelectMessagesFrom), ctx, queueType, exclusiveBeginMessageID, maxRows)
 }
 
+// SelectNDCConflictResolutions mocks base method.
+func (m *MocktableCRUD) SelectNDCConflictResolutions(ctx context.Context, domainID, workflowID, runID string, pageSize int, pageToken []byte) ([]*NDCConflictResolutionRow, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectNDCConflictResolutions", ctx, domainID, workflowID, runID, pageSize, pageToken)
+	ret0, _ := ret[0].([]*NDCConflictResolutionRow)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SelectNDCConflictResolutions indicates an expected call of SelectNDCConflictResolutions.
+func (mr *MocktableCRUDMockRecorder) SelectNDCConflictResolutions(ctx, domainID, workflowID, runID, pageSize, pageToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectNDCConflictResolutions", reflect.TypeOf((*MocktableCRUD)(nil).SelectNDCConflictResolutions), ctx, domainID, workflowID, runID, pageSize, pageToken)
+}
+
 // SelectOneClosedWorkflow mocks base method.
 func (m *MocktableCRUD) SelectOneClosedWorkflow(ctx context.Context, domainID, workflowID, runID string) (*VisibilityRow, error) {
 	m.ctrl.T.Helper()
@@ -3598,3 +3658,56 @@ func (mr *MockConfigStoreCRUDMockRecorder) SelectLatestConfig(ctx, rowType inter
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLatestConfig", reflect.TypeOf((*MockConfigStoreCRUD)(nil).SelectLatestConfig), ctx, rowType)
 }
+
+// MockNDCConflictResolutionCRUD is a mock of NDCConflictResolutionCRUD interface.
+type MockNDCConflictResolutionCRUD struct {
+	ctrl     *gomock.Controller
+	recorder *MockNDCConflictResolutionCRUDMockRecorder
+}
+
+// MockNDCConflictResolutionCRUDMockRecorder is the mock recorder for MockNDCConflictResolutionCRUD.
+type MockNDCConflictResolutionCRUDMockRecorder struct {
+	mock *MockNDCConflictResolutionCRUD
+}
+
+// NewMockNDCConflictResolutionCRUD creates a new mock instance.
+func NewMockNDCConflictResolutionCRUD(ctrl *gomock.Controller) *MockNDCConflictResolutionCRUD {
+	mock := &MockNDCConflictResolutionCRUD{ctrl: ctrl}
+	mock.recorder = &MockNDCConflictResolutionCRUDMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNDCConflictResolutionCRUD) EXPECT() *MockNDCConflictResolutionCRUDMockRecorder {
+	return m.recorder
+}
+
+// InsertNDCConflictResolution mocks base method.
+func (m *MockNDCConflictResolutionCRUD) InsertNDCConflictResolution(ctx context.Context, row *NDCConflictResolutionRow, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNDCConflictResolution", ctx, row, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertNDCConflictResolution indicates an expected call of InsertNDCConflictResolution.
+func (mr *MockNDCConflictResolutionCRUDMockRecorder) InsertNDCConflictResolution(ctx, row, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNDCConflictResolution", reflect.TypeOf((*MockNDCConflictResolutionCRUD)(nil).InsertNDCConflictResolution), ctx, row, ttl)
+}
+
+// SelectNDCConflictResolutions mocks base method.
+func (m *MockNDCConflictResolutionCRUD) SelectNDCConflictResolutions(ctx context.Context, domainID, workflowID, runID string, pageSize int, pageToken []byte) ([]*NDCConflictResolutionRow, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectNDCConflictResolutions", ctx, domainID, workflowID, runID, pageSize, pageToken)
+	ret0, _ := ret[0].([]*NDCConflictResolutionRow)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SelectNDCConflictResolutions indicates an expected call of SelectNDCConflictResolutions.
+func (mr *MockNDCConflictResolutionCRUDMockRecorder) SelectNDCConflictResolutions(ctx, domainID, workflowID, runID, pageSize, pageToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectNDCConflictResolutions", reflect.TypeOf((*MockNDCConflictResolutionCRUD)(nil).SelectNDCConflictResolutions), ctx, domainID, workflowID, runID, pageSize, pageToken)
+}
@@ -123,6 +123,15 @@ type (
 		LastWriteVersion int64
 	}
 
+	// NDCConflictResolutionRow is the audit record of an NDC conflict resolution of a workflow run
+	NDCConflictResolutionRow struct {
+		DomainID    string
+		WorkflowID  string
+		RunID       string
+		CreatedTime time.Time
+		Record      *persistence.DataBlob
+	}
+
 	// WorkflowRequestRow is the request which has been applied to a workflow
 	WorkflowRequestRow struct {
 		ShardID     int
@@ -884,6 +884,38 @@ func (w *WorkflowExecutionInfo) GetPartitionConfig() (o map[string]string) {
 	return
 }
 
+// GetNoProgressTimeout internal sql blob getter
+func (w *WorkflowExecutionInfo) GetNoProgressTimeout() time.Duration {
+	if w != nil {
+		return w.NoProgressTimeout
+	}
+	return time.Duration(0)
+}
+
+// GetNoProgressTimeoutPolicy internal sql blob getter
+func (w *WorkflowExecutionInfo) GetNoProgressTimeoutPolicy() (o int32) {
+	if w != nil {
+		return w.NoProgressTimeoutPolicy
+	}
+	return
+}
+
+// GetLastProgressTimestamp internal sql blob getter
+func (w *WorkflowExecutionInfo) GetLastProgressTimestamp() time.Time {
+	if w != nil {
+		return w.LastProgressTimestamp
+	}
+	return time.Unix(0, 0)
+}
+
+// GetNoProgressTimerTimestamp internal sql blob getter
+func (w *WorkflowExecutionInfo) GetNoProgressTimerTimestamp() time.Time {
+	if w != nil {
+		return w.NoProgressTimerTimestamp
+	}
+	return time.Unix(0, 0)
+}
+
 // GetCheckSum internal sql blob getter
 func (w *WorkflowExecutionInfo) GetChecksum() (o []byte) {
 	if w != nil {
@@ -76,6 +76,10 @@ var expectedNil = map[string]map[string]any{
 		"GetParentDomainID":                     []uint8(nil),
 		"GetParentRunID":                        []uint8(nil),
 		"GetPartitionConfig":                    map[string]string(nil),
+		"GetNoProgressTimeout":                  time.Duration(0),
+		"GetNoProgressTimeoutPolicy":            int32(0),
+		"GetLastProgressTimestamp":              zeroUnix,
+		"GetNoProgressTimerTimestamp":           zeroUnix,
 		"GetHistorySize":                        int64(0),
 		"GetRetryAttempt":                       int64(0),
 		"GetRetryBackoffCoefficient":            float64(0),
@@ -303,6 +307,10 @@ var expectedEmpty = map[string]map[string]any{
 		"GetParentDomainID":                     []uint8(nil),
 		"GetParentRunID":                        []uint8(nil),
 		"GetPartitionConfig":                    map[string]string(nil),
+		"GetNoProgressTimeout":                  time.Duration(0),
+		"GetNoProgressTimeoutPolicy":            int32(0),
+		"GetLastProgressTimestamp":              time.Time{},
+		"GetNoProgressTimerTimestamp":           time.Time{},
 		"GetHistorySize":                        int64(0),
 		"GetRetryAttempt":                       int64(0),
 		"GetRetryBackoffCoefficient":            float64(0),
@@ -530,6 +538,10 @@ var expectedNonEmpty = map[string]map[string]any{
 		"GetParentDomainID":                     []byte(parentDomainID),
 		"GetParentRunID":                        []byte(parentRunID),
 		"GetPartitionConfig":                    map[string]string(nil),
+		"GetNoProgressTimeout":                  time.Duration(0),
+		"GetNoProgressTimeoutPolicy":            int32(0),
+		"GetLastProgressTimestamp":              time.Time{},
+		"GetNoProgressTimerTimestamp":           time.Time{},
 		"GetHistorySize":                        int64(0),
 		"GetRetryAttempt":                       int64(0),
 		"GetRetryBackoffCoefficient":            float64(0),
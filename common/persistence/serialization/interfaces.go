@@ -171,6 +171,10 @@ type (
 		PartitionConfig                    map[string]string
 		Checksum                           []byte
 		ChecksumEncoding                   string
+		NoProgressTimeout                  time.Duration
+		NoProgressTimeoutPolicy            int32
+		LastProgressTimestamp              time.Time
+		NoProgressTimerTimestamp           time.Time
 	}
 
 	// ActivityInfo blob in a serialization agnostic format
@@ -75,6 +75,10 @@ func ToInternalWorkflowExecutionInfo(info *WorkflowExecutionInfo) *persistence.I
 		FirstExecutionRunID:                info.FirstExecutionRunID.String(),
 		PartitionConfig:                    info.PartitionConfig,
 		IsCron:                             info.IsCron,
+		NoProgressTimeout:                  info.GetNoProgressTimeout(),
+		NoProgressTimeoutPolicy:            info.GetNoProgressTimeoutPolicy(),
+		LastProgressTimestamp:              info.GetLastProgressTimestamp(),
+		NoProgressTimerTimestamp:           info.GetNoProgressTimerTimestamp(),
 	}
 	if info.ParentDomainID != nil {
 		result.ParentDomainID = info.ParentDomainID.String()
@@ -158,6 +162,10 @@ func FromInternalWorkflowExecutionInfo(executionInfo *persistence.InternalWorkfl
 		FirstExecutionRunID:                MustParseUUID(executionInfo.FirstExecutionRunID),
 		PartitionConfig:                    executionInfo.PartitionConfig,
 		IsCron:                             executionInfo.IsCron,
+		NoProgressTimeout:                  executionInfo.NoProgressTimeout,
+		NoProgressTimeoutPolicy:            executionInfo.NoProgressTimeoutPolicy,
+		LastProgressTimestamp:              executionInfo.LastProgressTimestamp,
+		NoProgressTimerTimestamp:           executionInfo.NoProgressTimerTimestamp,
 	}
 
 	if executionInfo.CompletionEvent != nil {
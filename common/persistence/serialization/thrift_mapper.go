@@ -258,6 +258,10 @@ func workflowExecutionInfoToThrift(info *WorkflowExecutionInfo) *sqlblobs.Workfl
 		PartitionConfig:                         info.PartitionConfig,
 		Checksum:                                info.Checksum,
 		ChecksumEncoding:                        &info.ChecksumEncoding,
+		NoProgressTimeoutSeconds:                durationToSecondsInt32Ptr(info.NoProgressTimeout),
+		NoProgressTimeoutPolicy:                 &info.NoProgressTimeoutPolicy,
+		LastProgressTimestampNanos:              timeToUnixNanoPtr(info.LastProgressTimestamp),
+		NoProgressTimerTimestampNanos:           timeToUnixNanoPtr(info.NoProgressTimerTimestamp),
 	}
 }
 
@@ -329,6 +333,10 @@ func workflowExecutionInfoFromThrift(info *sqlblobs.WorkflowExecutionInfo) *Work
 		IsCron:                             info.GetCronSchedule() != "",
 		Checksum:                           info.Checksum,
 		ChecksumEncoding:                   info.GetChecksumEncoding(),
+		NoProgressTimeout:                  common.SecondsToDuration(int64(info.GetNoProgressTimeoutSeconds())),
+		NoProgressTimeoutPolicy:            info.GetNoProgressTimeoutPolicy(),
+		LastProgressTimestamp:              timeFromUnixNano(info.GetLastProgressTimestampNanos()),
+		NoProgressTimerTimestamp:           timeFromUnixNano(info.GetNoProgressTimerTimestampNanos()),
 	}
 }
 
@@ -44,6 +44,8 @@ import (
 const (
 	emptyWorkflowID       string = ""
 	emptyReplicationRunID string = "30000000-5000-f000-f000-000000000000"
+
+	ndcConflictResolutionPurgeBatchSize = 10
 )
 
 type sqlExecutionStore struct {
@@ -778,6 +780,72 @@ func (m *sqlExecutionStore) IsWorkflowExecutionExists(
 	return nil, &types.InternalServiceError{Message: "Not yet implemented"}
 }
 
+func (m *sqlExecutionStore) CreateNDCConflictResolution(
+	ctx context.Context,
+	request *p.InternalCreateNDCConflictResolutionRequest,
+) error {
+	domainID := serialization.MustParseUUID(request.DomainID)
+	runID := serialization.MustParseUUID(request.RunID)
+	// SQL databases do not expire rows, so a batch of expired records of any run in the database shard is deleted
+	// before inserting a new one. The batch is larger than one, so the purge keeps up with the inserts.
+	if _, err := m.db.DeleteFromNDCConflictResolutions(ctx, &sqlplugin.NDCConflictResolutionsFilter{
+		DomainID:   domainID,
+		WorkflowID: request.WorkflowID,
+		Now:        time.Now().UnixNano(),
+		PageSize:   ndcConflictResolutionPurgeBatchSize,
+	}); err != nil {
+		return convertCommonErrors(m.db, "CreateNDCConflictResolution", "", err)
+	}
+	if _, err := m.db.InsertIntoNDCConflictResolutions(ctx, &sqlplugin.NDCConflictResolutionsRow{
+		DomainID:     domainID,
+		WorkflowID:   request.WorkflowID,
+		RunID:        runID,
+		CreatedTime:  request.CreatedTime.UnixNano(),
+		ExpiryTime:   request.CreatedTime.Add(request.TTL).UnixNano(),
+		Data:         request.Record.Data,
+		DataEncoding: request.Record.GetEncodingString(),
+	}); err != nil {
+		return convertCommonErrors(m.db, "CreateNDCConflictResolution", "", err)
+	}
+	return nil
+}
+
+func (m *sqlExecutionStore) ListNDCConflictResolutions(
+	ctx context.Context,
+	request *p.ListNDCConflictResolutionsRequest,
+) (*p.InternalListNDCConflictResolutionsResponse, error) {
+	minCreatedTime := int64(math.MinInt64)
+	if len(request.NextPageToken) > 0 {
+		var err error
+		minCreatedTime, err = deserializePageToken(request.NextPageToken)
+		if err != nil {
+			return nil, &types.InternalServiceError{
+				Message: fmt.Sprintf("ListNDCConflictResolutions failed. Error: %v", err),
+			}
+		}
+	}
+	rows, err := m.db.SelectFromNDCConflictResolutions(ctx, &sqlplugin.NDCConflictResolutionsFilter{
+		DomainID:       serialization.MustParseUUID(request.DomainID),
+		WorkflowID:     request.WorkflowID,
+		RunID:          serialization.MustParseUUID(request.RunID),
+		MinCreatedTime: minCreatedTime,
+		Now:            time.Now().UnixNano(),
+		PageSize:       request.PageSize,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return nil, convertCommonErrors(m.db, "ListNDCConflictResolutions", "", err)
+	}
+
+	response := &p.InternalListNDCConflictResolutionsResponse{}
+	for _, row := range rows {
+		response.Records = append(response.Records, p.NewDataBlob(row.Data, common.EncodingType(row.DataEncoding)))
+	}
+	if len(rows) > 0 && len(rows) == request.PageSize {
+		response.NextPageToken = serializePageToken(rows[len(rows)-1].CreatedTime)
+	}
+	return response, nil
+}
+
 func (m *sqlExecutionStore) ListConcreteExecutions(
 	ctx context.Context,
 	request *p.ListConcreteExecutionsRequest,
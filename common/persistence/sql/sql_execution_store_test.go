@@ -24,6 +24,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"math"
 	"testing"
 	"time"
 
@@ -3699,3 +3700,187 @@ func TestGetWorkflowExecution(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateNDCConflictResolution(t *testing.T) {
+	shardID := 100
+	createdTime := time.Unix(0, 1000)
+	req := &persistence.InternalCreateNDCConflictResolutionRequest{
+		DomainID:    "abdcea69-61d5-44c3-9d55-afe23505a542",
+		WorkflowID:  "aaaa",
+		RunID:       "fd65967f-777d-45de-8dee-be49dfda6716",
+		CreatedTime: createdTime,
+		TTL:         time.Hour,
+		Record:      persistence.NewDataBlob([]byte(`{}`), common.EncodingTypeJSON),
+	}
+	testCases := []struct {
+		name      string
+		mockSetup func(*sqlplugin.MockDB)
+		wantErr   bool
+	}{
+		{
+			name: "Success case",
+			mockSetup: func(mockDB *sqlplugin.MockDB) {
+				mockDB.EXPECT().DeleteFromNDCConflictResolutions(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, filter *sqlplugin.NDCConflictResolutionsFilter) (sql.Result, error) {
+						assert.Equal(t, serialization.MustParseUUID(req.DomainID), filter.DomainID)
+						assert.Equal(t, req.WorkflowID, filter.WorkflowID)
+						assert.NotZero(t, filter.Now)
+						assert.Equal(t, ndcConflictResolutionPurgeBatchSize, filter.PageSize)
+						return &sqlResult{rowsAffected: 1}, nil
+					},
+				)
+				mockDB.EXPECT().InsertIntoNDCConflictResolutions(gomock.Any(), &sqlplugin.NDCConflictResolutionsRow{
+					DomainID:     serialization.MustParseUUID(req.DomainID),
+					WorkflowID:   req.WorkflowID,
+					RunID:        serialization.MustParseUUID(req.RunID),
+					CreatedTime:  createdTime.UnixNano(),
+					ExpiryTime:   createdTime.Add(time.Hour).UnixNano(),
+					Data:         []byte(`{}`),
+					DataEncoding: string(common.EncodingTypeJSON),
+				}).Return(&sqlResult{rowsAffected: 1}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error case - failed to delete expired records",
+			mockSetup: func(mockDB *sqlplugin.MockDB) {
+				err := errors.New("some error")
+				mockDB.EXPECT().DeleteFromNDCConflictResolutions(gomock.Any(), gomock.Any()).Return(nil, err)
+				mockDB.EXPECT().IsNotFoundError(err).Return(true)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error case - failed to insert",
+			mockSetup: func(mockDB *sqlplugin.MockDB) {
+				err := errors.New("some error")
+				mockDB.EXPECT().DeleteFromNDCConflictResolutions(gomock.Any(), gomock.Any()).Return(&sqlResult{}, nil)
+				mockDB.EXPECT().InsertIntoNDCConflictResolutions(gomock.Any(), gomock.Any()).Return(nil, err)
+				mockDB.EXPECT().IsNotFoundError(err).Return(true)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			mockDB := sqlplugin.NewMockDB(ctrl)
+			store, err := NewSQLExecutionStore(mockDB, nil, shardID, nil, nil)
+			require.NoError(t, err, "failed to create execution store")
+
+			tc.mockSetup(mockDB)
+
+			err = store.CreateNDCConflictResolution(context.Background(), req)
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error for test case")
+			} else {
+				assert.NoError(t, err, "Did not expect an error for test case")
+			}
+		})
+	}
+}
+
+func TestListNDCConflictResolutions(t *testing.T) {
+	shardID := 100
+	testCases := []struct {
+		name      string
+		req       *persistence.ListNDCConflictResolutionsRequest
+		mockSetup func(*sqlplugin.MockDB)
+		want      *persistence.InternalListNDCConflictResolutionsResponse
+		wantErr   bool
+	}{
+		{
+			name: "Success case - full page",
+			req: &persistence.ListNDCConflictResolutionsRequest{
+				DomainID:   "abdcea69-61d5-44c3-9d55-afe23505a542",
+				WorkflowID: "aaaa",
+				RunID:      "fd65967f-777d-45de-8dee-be49dfda6716",
+				PageSize:   2,
+			},
+			mockSetup: func(mockDB *sqlplugin.MockDB) {
+				mockDB.EXPECT().SelectFromNDCConflictResolutions(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, filter *sqlplugin.NDCConflictResolutionsFilter) ([]sqlplugin.NDCConflictResolutionsRow, error) {
+						assert.Equal(t, "aaaa", filter.WorkflowID)
+						assert.Equal(t, int64(math.MinInt64), filter.MinCreatedTime)
+						assert.Equal(t, 2, filter.PageSize)
+						return []sqlplugin.NDCConflictResolutionsRow{
+							{CreatedTime: 1, Data: []byte(`{"type":0}`), DataEncoding: "json"},
+							{CreatedTime: 2, Data: []byte(`{"type":1}`), DataEncoding: "json"},
+						}, nil
+					},
+				)
+			},
+			want: &persistence.InternalListNDCConflictResolutionsResponse{
+				Records: []*persistence.DataBlob{
+					persistence.NewDataBlob([]byte(`{"type":0}`), common.EncodingTypeJSON),
+					persistence.NewDataBlob([]byte(`{"type":1}`), common.EncodingTypeJSON),
+				},
+				NextPageToken: serializePageToken(2),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Success case - last page",
+			req: &persistence.ListNDCConflictResolutionsRequest{
+				DomainID:      "abdcea69-61d5-44c3-9d55-afe23505a542",
+				WorkflowID:    "aaaa",
+				RunID:         "fd65967f-777d-45de-8dee-be49dfda6716",
+				PageSize:      2,
+				NextPageToken: serializePageToken(2),
+			},
+			mockSetup: func(mockDB *sqlplugin.MockDB) {
+				mockDB.EXPECT().SelectFromNDCConflictResolutions(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, filter *sqlplugin.NDCConflictResolutionsFilter) ([]sqlplugin.NDCConflictResolutionsRow, error) {
+						assert.Equal(t, int64(2), filter.MinCreatedTime)
+						return []sqlplugin.NDCConflictResolutionsRow{
+							{CreatedTime: 3, Data: []byte(`{"type":0}`), DataEncoding: "json"},
+						}, nil
+					},
+				)
+			},
+			want: &persistence.InternalListNDCConflictResolutionsResponse{
+				Records: []*persistence.DataBlob{
+					persistence.NewDataBlob([]byte(`{"type":0}`), common.EncodingTypeJSON),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error case",
+			req: &persistence.ListNDCConflictResolutionsRequest{
+				DomainID:   "abdcea69-61d5-44c3-9d55-afe23505a542",
+				WorkflowID: "aaaa",
+				RunID:      "fd65967f-777d-45de-8dee-be49dfda6716",
+				PageSize:   2,
+			},
+			mockSetup: func(mockDB *sqlplugin.MockDB) {
+				err := errors.New("some error")
+				mockDB.EXPECT().SelectFromNDCConflictResolutions(gomock.Any(), gomock.Any()).Return(nil, err)
+				mockDB.EXPECT().IsNotFoundError(err).Return(true)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			mockDB := sqlplugin.NewMockDB(ctrl)
+			store, err := NewSQLExecutionStore(mockDB, nil, shardID, nil, nil)
+			require.NoError(t, err, "failed to create execution store")
+
+			tc.mockSetup(mockDB)
+
+			resp, err := store.ListNDCConflictResolutions(context.Background(), tc.req)
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error for test case")
+			} else {
+				assert.NoError(t, err, "Did not expect an error for test case")
+				assert.Equal(t, tc.want, resp, "Response mismatch")
+			}
+		})
+	}
+}
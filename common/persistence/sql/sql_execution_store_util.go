@@ -1115,7 +1115,7 @@ func createTimerTasks(
 			info.EventID = t.EventID
 			info.TimeoutType = common.Int16Ptr(int16(t.TimeoutType))
 
-		case *p.WorkflowTimeoutTask:
+		case *p.WorkflowTimeoutTask, *p.WorkflowNoProgressTimeoutTask:
 			// noop
 
 		case *p.DeleteHistoryEventTask:
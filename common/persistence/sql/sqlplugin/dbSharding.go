@@ -47,6 +47,12 @@ func GetDBShardIDFromDomainIDAndTasklist(domainID, tasklistName string, numDBSha
 	return int(hash) % numDBShards
 }
 
+// GetDBShardIDFromDomainIDAndWorkflowID maps <domainID, workflowID> to a DBShardID
+func GetDBShardIDFromDomainIDAndWorkflowID(domainID, workflowID string, numDBShards int) int {
+	hash := farm.Hash32([]byte(domainID+"_"+workflowID)) % uint32(numDBShards)
+	return int(hash) % numDBShards
+}
+
 // GetDBShardIDFromDomainID maps domainID to a DBShardID
 func GetDBShardIDFromDomainID(domainID string, numDBShards int) int {
 	hash := farm.Hash32([]byte(domainID)) % uint32(numDBShards)
@@ -249,6 +249,21 @@ func (mr *MocktableCRUDMockRecorder) DeleteFromHistoryTree(ctx, filter interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFromHistoryTree", reflect.TypeOf((*MocktableCRUD)(nil).DeleteFromHistoryTree), ctx, filter)
 }
 
+// DeleteFromNDCConflictResolutions mocks base method.
+func (m *MocktableCRUD) DeleteFromNDCConflictResolutions(ctx context.Context, filter *NDCConflictResolutionsFilter) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFromNDCConflictResolutions", ctx, filter)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFromNDCConflictResolutions indicates an expected call of DeleteFromNDCConflictResolutions.
+func (mr *MocktableCRUDMockRecorder) DeleteFromNDCConflictResolutions(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFromNDCConflictResolutions", reflect.TypeOf((*MocktableCRUD)(nil).DeleteFromNDCConflictResolutions), ctx, filter)
+}
+
 // DeleteFromReplicationTasks mocks base method.
 func (m *MocktableCRUD) DeleteFromReplicationTasks(ctx context.Context, filter *ReplicationTasksFilter) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -697,6 +712,21 @@ func (mr *MocktableCRUDMockRecorder) InsertIntoHistoryTree(ctx, row interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertIntoHistoryTree", reflect.TypeOf((*MocktableCRUD)(nil).InsertIntoHistoryTree), ctx, row)
 }
 
+// InsertIntoNDCConflictResolutions mocks base method.
+func (m *MocktableCRUD) InsertIntoNDCConflictResolutions(ctx context.Context, row *NDCConflictResolutionsRow) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertIntoNDCConflictResolutions", ctx, row)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertIntoNDCConflictResolutions indicates an expected call of InsertIntoNDCConflictResolutions.
+func (mr *MocktableCRUDMockRecorder) InsertIntoNDCConflictResolutions(ctx, row interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertIntoNDCConflictResolutions", reflect.TypeOf((*MocktableCRUD)(nil).InsertIntoNDCConflictResolutions), ctx, row)
+}
+
 // InsertIntoQueue mocks base method.
 func (m *MocktableCRUD) InsertIntoQueue(ctx context.Context, row *QueueRow) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -1311,6 +1341,21 @@ func (mr *MocktableCRUDMockRecorder) SelectFromHistoryTree(ctx, filter interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFromHistoryTree", reflect.TypeOf((*MocktableCRUD)(nil).SelectFromHistoryTree), ctx, filter)
 }
 
+// SelectFromNDCConflictResolutions mocks base method.
+func (m *MocktableCRUD) SelectFromNDCConflictResolutions(ctx context.Context, filter *NDCConflictResolutionsFilter) ([]NDCConflictResolutionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectFromNDCConflictResolutions", ctx, filter)
+	ret0, _ := ret[0].([]NDCConflictResolutionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectFromNDCConflictResolutions indicates an expected call of SelectFromNDCConflictResolutions.
+func (mr *MocktableCRUDMockRecorder) SelectFromNDCConflictResolutions(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFromNDCConflictResolutions", reflect.TypeOf((*MocktableCRUD)(nil).SelectFromNDCConflictResolutions), ctx, filter)
+}
+
 // SelectFromReplicationDLQ mocks base method.
 func (m *MocktableCRUD) SelectFromReplicationDLQ(ctx context.Context, filter *ReplicationTaskDLQFilter) (int64, error) {
 	m.ctrl.T.Helper()
@@ -2040,6 +2085,21 @@ func (mr *MockTxMockRecorder) DeleteFromHistoryTree(ctx, filter interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFromHistoryTree", reflect.TypeOf((*MockTx)(nil).DeleteFromHistoryTree), ctx, filter)
 }
 
+// DeleteFromNDCConflictResolutions mocks base method.
+func (m *MockTx) DeleteFromNDCConflictResolutions(ctx context.Context, filter *NDCConflictResolutionsFilter) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFromNDCConflictResolutions", ctx, filter)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFromNDCConflictResolutions indicates an expected call of DeleteFromNDCConflictResolutions.
+func (mr *MockTxMockRecorder) DeleteFromNDCConflictResolutions(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFromNDCConflictResolutions", reflect.TypeOf((*MockTx)(nil).DeleteFromNDCConflictResolutions), ctx, filter)
+}
+
 // DeleteFromReplicationTasks mocks base method.
 func (m *MockTx) DeleteFromReplicationTasks(ctx context.Context, filter *ReplicationTasksFilter) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -2488,6 +2548,21 @@ func (mr *MockTxMockRecorder) InsertIntoHistoryTree(ctx, row interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertIntoHistoryTree", reflect.TypeOf((*MockTx)(nil).InsertIntoHistoryTree), ctx, row)
 }
 
+// InsertIntoNDCConflictResolutions mocks base method.
+func (m *MockTx) InsertIntoNDCConflictResolutions(ctx context.Context, row *NDCConflictResolutionsRow) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertIntoNDCConflictResolutions", ctx, row)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertIntoNDCConflictResolutions indicates an expected call of InsertIntoNDCConflictResolutions.
+func (mr *MockTxMockRecorder) InsertIntoNDCConflictResolutions(ctx, row interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertIntoNDCConflictResolutions", reflect.TypeOf((*MockTx)(nil).InsertIntoNDCConflictResolutions), ctx, row)
+}
+
 // InsertIntoQueue mocks base method.
 func (m *MockTx) InsertIntoQueue(ctx context.Context, row *QueueRow) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -3172,6 +3247,21 @@ func (mr *MockTxMockRecorder) SelectFromHistoryTree(ctx, filter interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFromHistoryTree", reflect.TypeOf((*MockTx)(nil).SelectFromHistoryTree), ctx, filter)
 }
 
+// SelectFromNDCConflictResolutions mocks base method.
+func (m *MockTx) SelectFromNDCConflictResolutions(ctx context.Context, filter *NDCConflictResolutionsFilter) ([]NDCConflictResolutionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectFromNDCConflictResolutions", ctx, filter)
+	ret0, _ := ret[0].([]NDCConflictResolutionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectFromNDCConflictResolutions indicates an expected call of SelectFromNDCConflictResolutions.
+func (mr *MockTxMockRecorder) SelectFromNDCConflictResolutions(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFromNDCConflictResolutions", reflect.TypeOf((*MockTx)(nil).SelectFromNDCConflictResolutions), ctx, filter)
+}
+
 // SelectFromReplicationDLQ mocks base method.
 func (m *MockTx) SelectFromReplicationDLQ(ctx context.Context, filter *ReplicationTaskDLQFilter) (int64, error) {
 	m.ctrl.T.Helper()
@@ -3746,6 +3836,21 @@ func (mr *MockDBMockRecorder) DeleteFromHistoryTree(ctx, filter interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFromHistoryTree", reflect.TypeOf((*MockDB)(nil).DeleteFromHistoryTree), ctx, filter)
 }
 
+// DeleteFromNDCConflictResolutions mocks base method.
+func (m *MockDB) DeleteFromNDCConflictResolutions(ctx context.Context, filter *NDCConflictResolutionsFilter) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFromNDCConflictResolutions", ctx, filter)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFromNDCConflictResolutions indicates an expected call of DeleteFromNDCConflictResolutions.
+func (mr *MockDBMockRecorder) DeleteFromNDCConflictResolutions(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFromNDCConflictResolutions", reflect.TypeOf((*MockDB)(nil).DeleteFromNDCConflictResolutions), ctx, filter)
+}
+
 // DeleteFromReplicationTasks mocks base method.
 func (m *MockDB) DeleteFromReplicationTasks(ctx context.Context, filter *ReplicationTasksFilter) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -4208,6 +4313,21 @@ func (mr *MockDBMockRecorder) InsertIntoHistoryTree(ctx, row interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertIntoHistoryTree", reflect.TypeOf((*MockDB)(nil).InsertIntoHistoryTree), ctx, row)
 }
 
+// InsertIntoNDCConflictResolutions mocks base method.
+func (m *MockDB) InsertIntoNDCConflictResolutions(ctx context.Context, row *NDCConflictResolutionsRow) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertIntoNDCConflictResolutions", ctx, row)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertIntoNDCConflictResolutions indicates an expected call of InsertIntoNDCConflictResolutions.
+func (mr *MockDBMockRecorder) InsertIntoNDCConflictResolutions(ctx, row interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertIntoNDCConflictResolutions", reflect.TypeOf((*MockDB)(nil).InsertIntoNDCConflictResolutions), ctx, row)
+}
+
 // InsertIntoQueue mocks base method.
 func (m *MockDB) InsertIntoQueue(ctx context.Context, row *QueueRow) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -4892,6 +5012,21 @@ func (mr *MockDBMockRecorder) SelectFromHistoryTree(ctx, filter interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFromHistoryTree", reflect.TypeOf((*MockDB)(nil).SelectFromHistoryTree), ctx, filter)
 }
 
+// SelectFromNDCConflictResolutions mocks base method.
+func (m *MockDB) SelectFromNDCConflictResolutions(ctx context.Context, filter *NDCConflictResolutionsFilter) ([]NDCConflictResolutionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectFromNDCConflictResolutions", ctx, filter)
+	ret0, _ := ret[0].([]NDCConflictResolutionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectFromNDCConflictResolutions indicates an expected call of SelectFromNDCConflictResolutions.
+func (mr *MockDBMockRecorder) SelectFromNDCConflictResolutions(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFromNDCConflictResolutions", reflect.TypeOf((*MockDB)(nil).SelectFromNDCConflictResolutions), ctx, filter)
+}
+
 // SelectFromReplicationDLQ mocks base method.
 func (m *MockDB) SelectFromReplicationDLQ(ctx context.Context, filter *ReplicationTaskDLQFilter) (int64, error) {
 	m.ctrl.T.Helper()
@@ -571,6 +571,30 @@ type (
 		Data      []byte
 	}
 
+	// NDCConflictResolutionsRow represents a row in ndc_conflict_resolutions table
+	NDCConflictResolutionsRow struct {
+		DomainID     serialization.UUID
+		WorkflowID   string
+		RunID        serialization.UUID
+		CreatedTime  int64
+		ExpiryTime   int64
+		Data         []byte
+		DataEncoding string
+	}
+
+	// NDCConflictResolutionsFilter contains the column names within ndc_conflict_resolutions table that
+	// can be used to filter results through a WHERE clause
+	NDCConflictResolutionsFilter struct {
+		DomainID   serialization.UUID
+		WorkflowID string
+		RunID      serialization.UUID
+		// MinCreatedTime is exclusive
+		MinCreatedTime int64
+		// Now is used to skip or delete the rows which are expired
+		Now      int64
+		PageSize int
+	}
+
 	// ClusterConfigRow represents a row in cluster_config table
 	ClusterConfigRow struct {
 		RowType      int
@@ -812,6 +836,16 @@ type (
 		GetAckLevels(ctx context.Context, queueType persistence.QueueType, forUpdate bool) (map[string]int64, error)
 		GetQueueSize(ctx context.Context, queueType persistence.QueueType) (int64, error)
 
+		// InsertIntoNDCConflictResolutions inserts a row into ndc_conflict_resolutions table
+		InsertIntoNDCConflictResolutions(ctx context.Context, row *NDCConflictResolutionsRow) (sql.Result, error)
+		// SelectFromNDCConflictResolutions returns the rows of a workflow run which are not expired, ordered by created_time
+		// Required filter params - {domainID, workflowID, runID, minCreatedTime, now, pageSize}
+		SelectFromNDCConflictResolutions(ctx context.Context, filter *NDCConflictResolutionsFilter) ([]NDCConflictResolutionsRow, error)
+		// DeleteFromNDCConflictResolutions deletes up to pageSize expired rows of any workflow run, in the database shard
+		// of the workflow of the filter
+		// Required filter params - {domainID, workflowID, now, pageSize}
+		DeleteFromNDCConflictResolutions(ctx context.Context, filter *NDCConflictResolutionsFilter) (sql.Result, error)
+
 		// InsertConfig insert a config entry with version. Return nosqlplugin.NewConditionFailure if the same version of the row_type is existing
 		InsertConfig(ctx context.Context, row *persistence.InternalConfigStoreEntry) error
 		// SelectLatestConfig returns the config entry of the row_type with the largest(latest) version value
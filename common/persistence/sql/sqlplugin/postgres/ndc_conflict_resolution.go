@@ -0,0 +1,83 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/uber/cadence/common/persistence/sql/sqlplugin"
+)
+
+const (
+	ndcConflictResolutionsColumns = `domain_id, workflow_id, run_id, created_time, expiry_time, data, data_encoding`
+
+	createNDCConflictResolutionQuery = `INSERT INTO ndc_conflict_resolutions(` + ndcConflictResolutionsColumns + `)
+ VALUES(:domain_id, :workflow_id, :run_id, :created_time, :expiry_time, :data, :data_encoding)`
+
+	getNDCConflictResolutionsQuery = `SELECT ` + ndcConflictResolutionsColumns + ` FROM ndc_conflict_resolutions
+ WHERE domain_id = $1 AND workflow_id = $2 AND run_id = $3 AND created_time > $4 AND expiry_time > $5
+ ORDER BY created_time LIMIT $6`
+
+	deleteExpiredNDCConflictResolutionsQuery = `DELETE FROM ndc_conflict_resolutions
+ WHERE (domain_id, workflow_id, run_id, created_time) IN (SELECT domain_id, workflow_id, run_id, created_time
+ FROM ndc_conflict_resolutions WHERE expiry_time <= $1 ORDER BY expiry_time LIMIT $2)`
+)
+
+// InsertIntoNDCConflictResolutions inserts a row into ndc_conflict_resolutions table
+func (pdb *db) InsertIntoNDCConflictResolutions(ctx context.Context, row *sqlplugin.NDCConflictResolutionsRow) (sql.Result, error) {
+	dbShardID := sqlplugin.GetDBShardIDFromDomainIDAndWorkflowID(row.DomainID.String(), row.WorkflowID, pdb.GetTotalNumDBShards())
+	return pdb.driver.NamedExecContext(ctx, dbShardID, createNDCConflictResolutionQuery, row)
+}
+
+// SelectFromNDCConflictResolutions reads the rows of a workflow run which are not expired from ndc_conflict_resolutions table
+func (pdb *db) SelectFromNDCConflictResolutions(ctx context.Context, filter *sqlplugin.NDCConflictResolutionsFilter) ([]sqlplugin.NDCConflictResolutionsRow, error) {
+	var rows []sqlplugin.NDCConflictResolutionsRow
+	dbShardID := sqlplugin.GetDBShardIDFromDomainIDAndWorkflowID(filter.DomainID.String(), filter.WorkflowID, pdb.GetTotalNumDBShards())
+	err := pdb.driver.SelectContext(
+		ctx,
+		dbShardID,
+		&rows,
+		getNDCConflictResolutionsQuery,
+		filter.DomainID,
+		filter.WorkflowID,
+		filter.RunID,
+		filter.MinCreatedTime,
+		filter.Now,
+		filter.PageSize,
+	)
+	return rows, err
+}
+
+// DeleteFromNDCConflictResolutions deletes up to pageSize expired rows of any workflow run from ndc_conflict_resolutions
+// table, in the database shard of the workflow of the filter
+func (pdb *db) DeleteFromNDCConflictResolutions(ctx context.Context, filter *sqlplugin.NDCConflictResolutionsFilter) (sql.Result, error) {
+	dbShardID := sqlplugin.GetDBShardIDFromDomainIDAndWorkflowID(filter.DomainID.String(), filter.WorkflowID, pdb.GetTotalNumDBShards())
+	return pdb.driver.ExecContext(
+		ctx,
+		dbShardID,
+		deleteExpiredNDCConflictResolutionsQuery,
+		filter.Now,
+		filter.PageSize,
+	)
+}
@@ -93,6 +93,11 @@ type (
 		TaskData
 	}
 
+	// WorkflowNoProgressTimeoutTask identifies a timeout task for a workflow not completing any decision
+	WorkflowNoProgressTimeoutTask struct {
+		TaskData
+	}
+
 	// CancelExecutionTask identifies a transfer task for cancel of execution
 	CancelExecutionTask struct {
 		TaskData
@@ -240,6 +245,7 @@ var (
 	_ Task = (*DeleteHistoryEventTask)(nil)
 	_ Task = (*DecisionTimeoutTask)(nil)
 	_ Task = (*WorkflowTimeoutTask)(nil)
+	_ Task = (*WorkflowNoProgressTimeoutTask)(nil)
 	_ Task = (*CancelExecutionTask)(nil)
 	_ Task = (*SignalExecutionTask)(nil)
 	_ Task = (*RecordChildExecutionCompletedTask)(nil)
@@ -356,6 +362,11 @@ func (u *WorkflowTimeoutTask) GetType() int {
 	return TaskTypeWorkflowTimeout
 }
 
+// GetType returns the type of the no progress timeout task.
+func (u *WorkflowNoProgressTimeoutTask) GetType() int {
+	return TaskTypeWorkflowNoProgressTimeout
+}
+
 // GetType returns the type of the cancel transfer task
 func (u *CancelExecutionTask) GetType() int {
 	return TransferTaskTypeCancelExecution
@@ -73,6 +73,21 @@ func (c *injectorConfigStoreManager) FetchDynamicConfig(ctx context.Context, cfg
 	return
 }
 
+func (c *injectorConfigStoreManager) FetchHistoryShardMapping(ctx context.Context) (fp1 *persistence.FetchHistoryShardMappingResponse, err error) {
+	fakeErr := generateFakeError(c.errorRate)
+	var forwardCall bool
+	if forwardCall = shouldForwardCallToPersistence(fakeErr); forwardCall {
+		fp1, err = c.wrapped.FetchHistoryShardMapping(ctx)
+	}
+
+	if fakeErr != nil {
+		logErr(c.logger, "ConfigStoreManager.FetchHistoryShardMapping", fakeErr, forwardCall, err)
+		err = fakeErr
+		return
+	}
+	return
+}
+
 func (c *injectorConfigStoreManager) UpdateDynamicConfig(ctx context.Context, request *persistence.UpdateDynamicConfigRequest, cfgType persistence.ConfigType) (err error) {
 	fakeErr := generateFakeError(c.errorRate)
 	var forwardCall bool
@@ -87,3 +102,18 @@ func (c *injectorConfigStoreManager) UpdateDynamicConfig(ctx context.Context, re
 	}
 	return
 }
+
+func (c *injectorConfigStoreManager) UpdateHistoryShardMapping(ctx context.Context, request *persistence.UpdateHistoryShardMappingRequest) (err error) {
+	fakeErr := generateFakeError(c.errorRate)
+	var forwardCall bool
+	if forwardCall = shouldForwardCallToPersistence(fakeErr); forwardCall {
+		err = c.wrapped.UpdateHistoryShardMapping(ctx, request)
+	}
+
+	if fakeErr != nil {
+		logErr(c.logger, "ConfigStoreManager.UpdateHistoryShardMapping", fakeErr, forwardCall, err)
+		err = fakeErr
+		return
+	}
+	return
+}
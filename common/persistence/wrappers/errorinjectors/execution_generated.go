@@ -148,6 +148,21 @@ func (c *injectorExecutionManager) CreateFailoverMarkerTasks(ctx context.Context
 	return
 }
 
+func (c *injectorExecutionManager) CreateNDCConflictResolution(ctx context.Context, request *persistence.CreateNDCConflictResolutionRequest) (err error) {
+	fakeErr := generateFakeError(c.errorRate)
+	var forwardCall bool
+	if forwardCall = shouldForwardCallToPersistence(fakeErr); forwardCall {
+		err = c.wrapped.CreateNDCConflictResolution(ctx, request)
+	}
+
+	if fakeErr != nil {
+		logErr(c.logger, "ExecutionManager.CreateNDCConflictResolution", fakeErr, forwardCall, err)
+		err = fakeErr
+		return
+	}
+	return
+}
+
 func (c *injectorExecutionManager) CreateWorkflowExecution(ctx context.Context, request *persistence.CreateWorkflowExecutionRequest) (cp1 *persistence.CreateWorkflowExecutionResponse, err error) {
 	fakeErr := generateFakeError(c.errorRate)
 	var forwardCall bool
@@ -381,6 +396,21 @@ func (c *injectorExecutionManager) ListCurrentExecutions(ctx context.Context, re
 	return
 }
 
+func (c *injectorExecutionManager) ListNDCConflictResolutions(ctx context.Context, request *persistence.ListNDCConflictResolutionsRequest) (lp1 *persistence.ListNDCConflictResolutionsResponse, err error) {
+	fakeErr := generateFakeError(c.errorRate)
+	var forwardCall bool
+	if forwardCall = shouldForwardCallToPersistence(fakeErr); forwardCall {
+		lp1, err = c.wrapped.ListNDCConflictResolutions(ctx, request)
+	}
+
+	if fakeErr != nil {
+		logErr(c.logger, "ExecutionManager.ListNDCConflictResolutions", fakeErr, forwardCall, err)
+		err = fakeErr
+		return
+	}
+	return
+}
+
 func (c *injectorExecutionManager) PutReplicationTaskToDLQ(ctx context.Context, request *persistence.PutReplicationTaskToDLQRequest) (err error) {
 	fakeErr := generateFakeError(c.errorRate)
 	var forwardCall bool
@@ -134,6 +134,10 @@ func configManagerTags(op string) *tag.Tag {
 		return &tag.StoreOperationFetchDynamicConfig
 	case "ConfigStoreManager.UpdateDynamicConfig":
 		return &tag.StoreOperationUpdateDynamicConfig
+	case "ConfigStoreManager.FetchHistoryShardMapping":
+		return &tag.StoreOperationFetchHistoryShardMapping
+	case "ConfigStoreManager.UpdateHistoryShardMapping":
+		return &tag.StoreOperationUpdateHistoryShardMapping
 	}
 	return nil
 }
@@ -252,6 +256,10 @@ func executionManagerTags(op string) *tag.Tag {
 		return &tag.StoreOperationRangeCompleteTimerTask
 	case "ExecutionManager.CreateFailoverMarkerTasks":
 		return &tag.StoreOperationCreateFailoverMarkerTasks
+	case "ExecutionManager.CreateNDCConflictResolution":
+		return &tag.StoreOperationCreateNDCConflictResolution
+	case "ExecutionManager.ListNDCConflictResolutions":
+		return &tag.StoreOperationListNDCConflictResolutions
 	}
 	return nil
 }
@@ -74,6 +74,16 @@ func (c *meteredConfigStoreManager) FetchDynamicConfig(ctx context.Context, cfgT
 	return
 }
 
+func (c *meteredConfigStoreManager) FetchHistoryShardMapping(ctx context.Context) (fp1 *persistence.FetchHistoryShardMappingResponse, err error) {
+	op := func() error {
+		fp1, err = c.wrapped.FetchHistoryShardMapping(ctx)
+		return err
+	}
+
+	err = c.call(metrics.PersistenceFetchHistoryShardMappingScope, op)
+	return
+}
+
 func (c *meteredConfigStoreManager) UpdateDynamicConfig(ctx context.Context, request *persistence.UpdateDynamicConfigRequest, cfgType persistence.ConfigType) (err error) {
 	op := func() error {
 		err = c.wrapped.UpdateDynamicConfig(ctx, request, cfgType)
@@ -84,3 +94,14 @@ func (c *meteredConfigStoreManager) UpdateDynamicConfig(ctx context.Context, req
 	err = c.call(metrics.PersistenceUpdateDynamicConfigScope, op, getCustomMetricTags(request)...)
 	return
 }
+
+func (c *meteredConfigStoreManager) UpdateHistoryShardMapping(ctx context.Context, request *persistence.UpdateHistoryShardMappingRequest) (err error) {
+	op := func() error {
+		err = c.wrapped.UpdateHistoryShardMapping(ctx, request)
+		c.emptyMetric("ConfigStoreManager.UpdateHistoryShardMapping", request, err, err)
+		return err
+	}
+
+	err = c.call(metrics.PersistenceUpdateHistoryShardMappingScope, op, getCustomMetricTags(request)...)
+	return
+}
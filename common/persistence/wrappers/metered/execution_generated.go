@@ -208,6 +208,29 @@ func (c *meteredExecutionManager) CreateFailoverMarkerTasks(ctx context.Context,
 	return
 }
 
+func (c *meteredExecutionManager) CreateNDCConflictResolution(ctx context.Context, request *persistence.CreateNDCConflictResolutionRequest) (err error) {
+	op := func() error {
+		err = c.wrapped.CreateNDCConflictResolution(ctx, request)
+		return err
+	}
+
+	if domainName, hasDomainName := getDomainNameFromRequest(request); hasDomainName {
+		logTags := append([]tag.Tag{tag.WorkflowDomainName(domainName)}, getCustomLogTags(request)...)
+		c.logger.SampleInfo("Persistence CreateNDCConflictResolution called", c.sampleLoggingRate(), logTags...)
+		if c.enableShardIDMetrics() {
+			err = c.callWithDomainAndShardScope(metrics.PersistenceCreateNDCConflictResolutionScope, op, metrics.DomainTag(domainName),
+				metrics.ShardIDTag(c.GetShardID()))
+		} else {
+			err = c.call(metrics.PersistenceCreateNDCConflictResolutionScope, op, metrics.DomainTag(domainName))
+		}
+		return
+	}
+
+	err = c.call(metrics.PersistenceCreateNDCConflictResolutionScope, op, getCustomMetricTags(request)...)
+
+	return
+}
+
 func (c *meteredExecutionManager) CreateWorkflowExecution(ctx context.Context, request *persistence.CreateWorkflowExecutionRequest) (cp1 *persistence.CreateWorkflowExecutionResponse, err error) {
 	op := func() error {
 		cp1, err = c.wrapped.CreateWorkflowExecution(ctx, request)
@@ -573,6 +596,30 @@ func (c *meteredExecutionManager) ListCurrentExecutions(ctx context.Context, req
 	return
 }
 
+func (c *meteredExecutionManager) ListNDCConflictResolutions(ctx context.Context, request *persistence.ListNDCConflictResolutionsRequest) (lp1 *persistence.ListNDCConflictResolutionsResponse, err error) {
+	op := func() error {
+		lp1, err = c.wrapped.ListNDCConflictResolutions(ctx, request)
+		c.emptyMetric("ExecutionManager.ListNDCConflictResolutions", request, lp1, err)
+		return err
+	}
+
+	if domainName, hasDomainName := getDomainNameFromRequest(request); hasDomainName {
+		logTags := append([]tag.Tag{tag.WorkflowDomainName(domainName)}, getCustomLogTags(request)...)
+		c.logger.SampleInfo("Persistence ListNDCConflictResolutions called", c.sampleLoggingRate(), logTags...)
+		if c.enableShardIDMetrics() {
+			err = c.callWithDomainAndShardScope(metrics.PersistenceListNDCConflictResolutionsScope, op, metrics.DomainTag(domainName),
+				metrics.ShardIDTag(c.GetShardID()))
+		} else {
+			err = c.call(metrics.PersistenceListNDCConflictResolutionsScope, op, metrics.DomainTag(domainName))
+		}
+		return
+	}
+
+	err = c.call(metrics.PersistenceListNDCConflictResolutionsScope, op, getCustomMetricTags(request)...)
+
+	return
+}
+
 func (c *meteredExecutionManager) PutReplicationTaskToDLQ(ctx context.Context, request *persistence.PutReplicationTaskToDLQRequest) (err error) {
 	op := func() error {
 		err = c.wrapped.PutReplicationTaskToDLQ(ctx, request)
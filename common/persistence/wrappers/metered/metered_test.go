@@ -173,6 +173,8 @@ func prepareMockForTest(t *testing.T, input interface{}, expectedErr error) {
 	case *persistence.MockConfigStoreManager:
 		mocked.EXPECT().UpdateDynamicConfig(gomock.Any(), gomock.Any(), gomock.Any()).Return(expectedErr).Times(1)
 		mocked.EXPECT().FetchDynamicConfig(gomock.Any(), gomock.Any()).Return(&persistence.FetchDynamicConfigResponse{}, expectedErr).Times(1)
+		mocked.EXPECT().FetchHistoryShardMapping(gomock.Any()).Return(&persistence.FetchHistoryShardMappingResponse{}, expectedErr).Times(1)
+		mocked.EXPECT().UpdateHistoryShardMapping(gomock.Any(), gomock.Any()).Return(expectedErr).Times(1)
 	case *persistence.MockDomainManager:
 		mocked.EXPECT().CreateDomain(gomock.Any(), gomock.Any()).Return(&persistence.CreateDomainResponse{}, expectedErr).Times(1)
 		mocked.EXPECT().GetDomain(gomock.Any(), gomock.Any()).Return(&persistence.GetDomainResponse{}, expectedErr).Times(1)
@@ -265,6 +267,8 @@ func prepareMockForTest(t *testing.T, input interface{}, expectedErr error) {
 		mocked.EXPECT().RangeCompleteTimerTask(gomock.Any(), gomock.Any()).Return(&persistence.RangeCompleteTimerTaskResponse{}, expectedErr).Times(1)
 		mocked.EXPECT().RangeCompleteTransferTask(gomock.Any(), gomock.Any()).Return(&persistence.RangeCompleteTransferTaskResponse{}, expectedErr).Times(1)
 		mocked.EXPECT().RangeDeleteReplicationTaskFromDLQ(gomock.Any(), gomock.Any()).Return(&persistence.RangeDeleteReplicationTaskFromDLQResponse{}, expectedErr).Times(1)
+		mocked.EXPECT().CreateNDCConflictResolution(gomock.Any(), gomock.Any()).Return(expectedErr).Times(1)
+		mocked.EXPECT().ListNDCConflictResolutions(gomock.Any(), gomock.Any()).Return(&persistence.ListNDCConflictResolutionsResponse{}, expectedErr).Times(1)
 	default:
 		t.Errorf("unsupported type %v", reflect.TypeOf(input))
 		t.FailNow()
@@ -63,6 +63,14 @@ func (c *ratelimitedConfigStoreManager) FetchDynamicConfig(ctx context.Context,
 	return c.wrapped.FetchDynamicConfig(ctx, cfgType)
 }
 
+func (c *ratelimitedConfigStoreManager) FetchHistoryShardMapping(ctx context.Context) (fp1 *persistence.FetchHistoryShardMappingResponse, err error) {
+	if ok := c.rateLimiter.Allow(); !ok {
+		err = ErrPersistenceLimitExceeded
+		return
+	}
+	return c.wrapped.FetchHistoryShardMapping(ctx)
+}
+
 func (c *ratelimitedConfigStoreManager) UpdateDynamicConfig(ctx context.Context, request *persistence.UpdateDynamicConfigRequest, cfgType persistence.ConfigType) (err error) {
 	if ok := c.rateLimiter.Allow(); !ok {
 		err = ErrPersistenceLimitExceeded
@@ -70,3 +78,11 @@ func (c *ratelimitedConfigStoreManager) UpdateDynamicConfig(ctx context.Context,
 	}
 	return c.wrapped.UpdateDynamicConfig(ctx, request, cfgType)
 }
+
+func (c *ratelimitedConfigStoreManager) UpdateHistoryShardMapping(ctx context.Context, request *persistence.UpdateHistoryShardMappingRequest) (err error) {
+	if ok := c.rateLimiter.Allow(); !ok {
+		err = ErrPersistenceLimitExceeded
+		return
+	}
+	return c.wrapped.UpdateHistoryShardMapping(ctx, request)
+}
@@ -103,6 +103,14 @@ func (c *ratelimitedExecutionManager) CreateFailoverMarkerTasks(ctx context.Cont
 	return c.wrapped.CreateFailoverMarkerTasks(ctx, request)
 }
 
+func (c *ratelimitedExecutionManager) CreateNDCConflictResolution(ctx context.Context, request *persistence.CreateNDCConflictResolutionRequest) (err error) {
+	if ok := c.rateLimiter.Allow(); !ok {
+		err = ErrPersistenceLimitExceeded
+		return
+	}
+	return c.wrapped.CreateNDCConflictResolution(ctx, request)
+}
+
 func (c *ratelimitedExecutionManager) CreateWorkflowExecution(ctx context.Context, request *persistence.CreateWorkflowExecutionRequest) (cp1 *persistence.CreateWorkflowExecutionResponse, err error) {
 	if ok := c.rateLimiter.Allow(); !ok {
 		err = ErrPersistenceLimitExceeded
@@ -231,6 +239,14 @@ func (c *ratelimitedExecutionManager) ListCurrentExecutions(ctx context.Context,
 	return c.wrapped.ListCurrentExecutions(ctx, request)
 }
 
+func (c *ratelimitedExecutionManager) ListNDCConflictResolutions(ctx context.Context, request *persistence.ListNDCConflictResolutionsRequest) (lp1 *persistence.ListNDCConflictResolutionsResponse, err error) {
+	if ok := c.rateLimiter.Allow(); !ok {
+		err = ErrPersistenceLimitExceeded
+		return
+	}
+	return c.wrapped.ListNDCConflictResolutions(ctx, request)
+}
+
 func (c *ratelimitedExecutionManager) PutReplicationTaskToDLQ(ctx context.Context, request *persistence.PutReplicationTaskToDLQRequest) (err error) {
 	if ok := c.rateLimiter.Allow(); !ok {
 		err = ErrPersistenceLimitExceeded
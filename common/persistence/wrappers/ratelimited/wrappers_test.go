@@ -163,6 +163,8 @@ func builderForPassThrough(t *testing.T, injector any, limiter quotas.Limiter, e
 		if expectCalls {
 			mocked.EXPECT().UpdateDynamicConfig(gomock.Any(), gomock.Any(), gomock.Any()).Return(expectedErr)
 			mocked.EXPECT().FetchDynamicConfig(gomock.Any(), gomock.Any()).Return(&persistence.FetchDynamicConfigResponse{}, expectedErr)
+			mocked.EXPECT().FetchHistoryShardMapping(gomock.Any()).Return(&persistence.FetchHistoryShardMappingResponse{}, expectedErr)
+			mocked.EXPECT().UpdateHistoryShardMapping(gomock.Any(), gomock.Any()).Return(expectedErr)
 		}
 	case *ratelimitedDomainManager:
 		mocked := persistence.NewMockDomainManager(ctrl)
@@ -283,6 +285,8 @@ func builderForPassThrough(t *testing.T, injector any, limiter quotas.Limiter, e
 			mocked.EXPECT().RangeCompleteTimerTask(gomock.Any(), gomock.Any()).Return(&persistence.RangeCompleteTimerTaskResponse{}, expectedErr)
 			mocked.EXPECT().RangeCompleteTransferTask(gomock.Any(), gomock.Any()).Return(&persistence.RangeCompleteTransferTaskResponse{}, expectedErr)
 			mocked.EXPECT().RangeDeleteReplicationTaskFromDLQ(gomock.Any(), gomock.Any()).Return(&persistence.RangeDeleteReplicationTaskFromDLQResponse{}, expectedErr)
+			mocked.EXPECT().CreateNDCConflictResolution(gomock.Any(), gomock.Any()).Return(expectedErr)
+			mocked.EXPECT().ListNDCConflictResolutions(gomock.Any(), gomock.Any()).Return(&persistence.ListNDCConflictResolutionsResponse{}, expectedErr)
 		}
 	default:
 		t.Errorf("unsupported type %v", reflect.TypeOf(injector))
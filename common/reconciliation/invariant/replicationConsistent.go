@@ -0,0 +1,490 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package invariant
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/uber/cadence/client"
+	c "github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/checksum"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/partition"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/reconciliation/entity"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/common/types/mapper/thrift"
+)
+
+const (
+	replicationHistoryPageSize = 1000
+	eventChecksumVersion       = 1
+	sampleBuckets              = 10000
+)
+
+type (
+	replicationConsistent struct {
+		pr              persistence.Retryer
+		dc              cache.DomainCache
+		clientBean      client.Bean
+		clusterMetadata cluster.Metadata
+		serializer      persistence.PayloadSerializer
+		sampleRate      float64
+	}
+
+	// historyResend describes the resend of the history of an execution which fixes an inconsistency,
+	// the history is sent from the source cluster to the target cluster
+	historyResend struct {
+		sourceCluster string
+		targetCluster string
+	}
+)
+
+// NewReplicationConsistent returns an invariant which compares the current version history and the event
+// checksums of an execution of a global domain with the other clusters of the domain.
+// Only the sampleRate share of the executions is checked, the sampling is stable for a given execution.
+func NewReplicationConsistent(
+	pr persistence.Retryer,
+	dc cache.DomainCache,
+	clientBean client.Bean,
+	clusterMetadata cluster.Metadata,
+	sampleRate float64,
+) Invariant {
+	return &replicationConsistent{
+		pr:              pr,
+		dc:              dc,
+		clientBean:      clientBean,
+		clusterMetadata: clusterMetadata,
+		serializer:      persistence.NewPayloadSerializer(),
+		sampleRate:      sampleRate,
+	}
+}
+
+func (r *replicationConsistent) Check(
+	ctx context.Context,
+	execution interface{},
+) CheckResult {
+	if checkResult := validateCheckContext(ctx, r.Name()); checkResult != nil {
+		return *checkResult
+	}
+
+	checkResult, _ := r.check(ctx, execution)
+	return checkResult
+}
+
+// Fix resends the history of the execution from the active cluster to the clusters which are not consistent with it
+func (r *replicationConsistent) Fix(
+	ctx context.Context,
+	execution interface{},
+) FixResult {
+	if fixResult := validateFixContext(ctx, r.Name()); fixResult != nil {
+		return *fixResult
+	}
+
+	checkResult, resends := r.check(ctx, execution)
+	switch checkResult.CheckResultType {
+	case CheckResultTypeHealthy:
+		return FixResult{
+			FixResultType: FixResultTypeSkipped,
+			InvariantName: r.Name(),
+			CheckResult:   checkResult,
+			Info:          "skipped fix because execution was healthy",
+		}
+	case CheckResultTypeFailed:
+		return FixResult{
+			FixResultType: FixResultTypeFailed,
+			InvariantName: r.Name(),
+			CheckResult:   checkResult,
+			Info:          "failed fix because check failed",
+		}
+	}
+	if len(resends) == 0 {
+		return FixResult{
+			FixResultType: FixResultTypeSkipped,
+			InvariantName: r.Name(),
+			CheckResult:   checkResult,
+			Info:          "skipped fix because execution is consistent with the active cluster",
+		}
+	}
+
+	concreteExecution := execution.(*entity.ConcreteExecution)
+	for _, resend := range resends {
+		if err := r.clientBean.GetRemoteAdminClient(resend.targetCluster).ResendReplicationTasks(
+			ctx,
+			&types.ResendReplicationTasksRequest{
+				DomainID:      concreteExecution.DomainID,
+				WorkflowID:    concreteExecution.WorkflowID,
+				RunID:         concreteExecution.RunID,
+				RemoteCluster: resend.sourceCluster,
+			},
+		); err != nil {
+			return FixResult{
+				FixResultType: FixResultTypeFailed,
+				InvariantName: r.Name(),
+				CheckResult:   checkResult,
+				Info:          fmt.Sprintf("failed to resend history from cluster %v to cluster %v", resend.sourceCluster, resend.targetCluster),
+				InfoDetails:   err.Error(),
+			}
+		}
+	}
+	return FixResult{
+		FixResultType: FixResultTypeFixed,
+		InvariantName: r.Name(),
+		CheckResult:   checkResult,
+	}
+}
+
+func (r *replicationConsistent) Name() Name {
+	return ReplicationConsistent
+}
+
+// check compares the execution with all other clusters of its domain, and returns the resends
+// needed to fix the inconsistencies with the active cluster of the execution
+func (r *replicationConsistent) check(
+	ctx context.Context,
+	execution interface{},
+) (CheckResult, []historyResend) {
+
+	concreteExecution, ok := execution.(*entity.ConcreteExecution)
+	if !ok {
+		return CheckResult{
+			CheckResultType: CheckResultTypeFailed,
+			InvariantName:   r.Name(),
+			Info:            "failed to check: expected concrete execution",
+		}, nil
+	}
+	if !r.isSampled(concreteExecution) {
+		return CheckResult{
+			CheckResultType: CheckResultTypeHealthy,
+			InvariantName:   r.Name(),
+			Info:            "skipped check because execution was not sampled",
+		}, nil
+	}
+
+	domainEntry, err := r.dc.GetDomainByID(concreteExecution.DomainID)
+	if err != nil {
+		return CheckResult{
+			CheckResultType: CheckResultTypeFailed,
+			InvariantName:   r.Name(),
+			Info:            "failed to check: expected domain",
+			InfoDetails:     err.Error(),
+		}, nil
+	}
+	if !domainEntry.IsGlobalDomain() || len(domainEntry.GetReplicationConfig().Clusters) < 2 {
+		return CheckResult{
+			CheckResultType: CheckResultTypeHealthy,
+			InvariantName:   r.Name(),
+			Info:            "skipped check because domain is not replicated",
+		}, nil
+	}
+	domainName := domainEntry.GetInfo().Name
+
+	response, err := r.pr.GetWorkflowExecution(ctx, &persistence.GetWorkflowExecutionRequest{
+		DomainID: concreteExecution.DomainID,
+		Execution: types.WorkflowExecution{
+			WorkflowID: concreteExecution.WorkflowID,
+			RunID:      concreteExecution.RunID,
+		},
+		DomainName: domainName,
+	})
+	if err != nil {
+		if _, ok := err.(*types.EntityNotExistsError); ok {
+			return CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   r.Name(),
+				Info:            "determined execution was healthy because concrete execution no longer exists",
+			}, nil
+		}
+		return CheckResult{
+			CheckResultType: CheckResultTypeFailed,
+			InvariantName:   r.Name(),
+			Info:            "failed to get concrete execution",
+			InfoDetails:     err.Error(),
+		}, nil
+	}
+	executionInfo := response.State.ExecutionInfo
+	if executionInfo.PartitionConfig[partition.LocalOnlyKey] == "true" {
+		return CheckResult{
+			CheckResultType: CheckResultTypeHealthy,
+			InvariantName:   r.Name(),
+			Info:            "skipped check because execution is local-only",
+		}, nil
+	}
+	if response.State.VersionHistories == nil {
+		return CheckResult{
+			CheckResultType: CheckResultTypeHealthy,
+			InvariantName:   r.Name(),
+			Info:            "skipped check because execution has no version histories",
+		}, nil
+	}
+	localVersionHistory, err := response.State.VersionHistories.GetCurrentVersionHistory()
+	if err != nil {
+		return CheckResult{
+			CheckResultType: CheckResultTypeFailed,
+			InvariantName:   r.Name(),
+			Info:            "failed to get current version history",
+			InfoDetails:     err.Error(),
+		}, nil
+	}
+	localChecksums, err := r.getLocalEventChecksums(ctx, concreteExecution, domainName, localVersionHistory)
+	if err != nil {
+		return CheckResult{
+			CheckResultType: CheckResultTypeFailed,
+			InvariantName:   r.Name(),
+			Info:            "failed to read history",
+			InfoDetails:     err.Error(),
+		}, nil
+	}
+
+	currentCluster := r.clusterMetadata.GetCurrentClusterName()
+	activeCluster := domainEntry.GetActiveClusterForWorkflow(
+		executionInfo.WorkflowID,
+		executionInfo.PartitionConfig[partition.ActiveRegionKey],
+	)
+	enabledClusters := r.clusterMetadata.GetEnabledClusterInfo()
+
+	var inconsistencies []string
+	var resends []historyResend
+	for _, replicationCluster := range domainEntry.GetReplicationConfig().Clusters {
+		clusterName := replicationCluster.ClusterName
+		if _, ok := enabledClusters[clusterName]; !ok || clusterName == currentCluster {
+			continue
+		}
+
+		inconsistency, err := r.compareWithCluster(
+			ctx,
+			domainName,
+			executionInfo,
+			localVersionHistory,
+			localChecksums,
+			clusterName,
+		)
+		if err != nil {
+			return CheckResult{
+				CheckResultType: CheckResultTypeFailed,
+				InvariantName:   r.Name(),
+				Info:            fmt.Sprintf("failed to compare history with cluster %v", clusterName),
+				InfoDetails:     err.Error(),
+			}, nil
+		}
+		if inconsistency == "" {
+			continue
+		}
+
+		inconsistencies = append(inconsistencies, fmt.Sprintf("cluster %v: %v", clusterName, inconsistency))
+		switch activeCluster {
+		case currentCluster:
+			resends = append(resends, historyResend{sourceCluster: currentCluster, targetCluster: clusterName})
+		case clusterName:
+			resends = append(resends, historyResend{sourceCluster: clusterName, targetCluster: currentCluster})
+		}
+	}
+
+	if len(inconsistencies) != 0 {
+		return CheckResult{
+			CheckResultType: CheckResultTypeCorrupted,
+			InvariantName:   r.Name(),
+			Info:            "history is not consistent with other clusters",
+			InfoDetails:     strings.Join(inconsistencies, "; "),
+		}, resends
+	}
+	return CheckResult{
+		CheckResultType: CheckResultTypeHealthy,
+		InvariantName:   r.Name(),
+	}, nil
+}
+
+// compareWithCluster compares the current version history and the event checksums of the execution with the
+// given cluster, and returns the description of the inconsistency, empty if the execution is consistent.
+// A cluster lagging behind is only an inconsistency for closed executions, since the history of
+// open executions may still be in flight
+func (r *replicationConsistent) compareWithCluster(
+	ctx context.Context,
+	domainName string,
+	executionInfo *persistence.WorkflowExecutionInfo,
+	localVersionHistory *persistence.VersionHistory,
+	localChecksums []checksum.Checksum,
+	clusterName string,
+) (string, error) {
+
+	adminClient := r.clientBean.GetRemoteAdminClient(clusterName)
+	request := &types.GetWorkflowExecutionRawHistoryV2Request{
+		Domain: domainName,
+		Execution: &types.WorkflowExecution{
+			WorkflowID: executionInfo.WorkflowID,
+			RunID:      executionInfo.RunID,
+		},
+		MaximumPageSize: replicationHistoryPageSize,
+	}
+	response, err := adminClient.GetWorkflowExecutionRawHistoryV2(ctx, request)
+	if err != nil {
+		if _, ok := err.(*types.EntityNotExistsError); ok {
+			if Open(executionInfo.State) {
+				return "", nil
+			}
+			return "execution does not exist", nil
+		}
+		return "", err
+	}
+
+	remoteVersionHistory := persistence.NewVersionHistoryFromInternalType(response.VersionHistory)
+	localLastItem, err := localVersionHistory.GetLastItem()
+	if err != nil {
+		return "", err
+	}
+	remoteLastItem, err := remoteVersionHistory.GetLastItem()
+	if err != nil {
+		return "", err
+	}
+	lcaItem, err := localVersionHistory.FindLCAItem(remoteVersionHistory)
+	if err != nil {
+		return "", err
+	}
+	if !lcaItem.Equals(localLastItem) && !lcaItem.Equals(remoteLastItem) {
+		return fmt.Sprintf(
+			"version history diverged, local version history: %v, remote version history: %v",
+			formatVersionHistory(localVersionHistory),
+			formatVersionHistory(remoteVersionHistory),
+		), nil
+	}
+
+	// compare the events up to the lowest common ancestor, which are the events both clusters must agree on
+	nextEventID := c.FirstEventID
+	for {
+		for _, blob := range response.HistoryBatches {
+			events, err := r.serializer.DeserializeBatchEvents(persistence.NewDataBlobFromInternal(blob))
+			if err != nil {
+				return "", err
+			}
+			for _, event := range events {
+				if event.ID > lcaItem.EventID {
+					break
+				}
+				if event.ID != nextEventID {
+					return fmt.Sprintf("event %v is missing", nextEventID), nil
+				}
+				eventChecksum, err := checksum.GenerateCRC32(thrift.FromHistoryEvent(event), eventChecksumVersion)
+				if err != nil {
+					return "", err
+				}
+				if !bytes.Equal(eventChecksum.Value, localChecksums[event.ID-c.FirstEventID].Value) {
+					return fmt.Sprintf("checksum of event %v does not match", event.ID), nil
+				}
+				nextEventID++
+			}
+		}
+		if nextEventID > lcaItem.EventID || len(response.NextPageToken) == 0 {
+			break
+		}
+		request.NextPageToken = response.NextPageToken
+		if response, err = adminClient.GetWorkflowExecutionRawHistoryV2(ctx, request); err != nil {
+			return "", err
+		}
+	}
+	if nextEventID <= lcaItem.EventID {
+		return fmt.Sprintf("event %v is missing", nextEventID), nil
+	}
+
+	if localLastItem.Equals(remoteLastItem) || Open(executionInfo.State) {
+		return "", nil
+	}
+	return fmt.Sprintf(
+		"history is not up to date, local last event: %v, remote last event: %v",
+		localLastItem.EventID,
+		remoteLastItem.EventID,
+	), nil
+}
+
+// getLocalEventChecksums returns the checksums of the events of the current branch of the execution,
+// indexed by event ID
+func (r *replicationConsistent) getLocalEventChecksums(
+	ctx context.Context,
+	execution *entity.ConcreteExecution,
+	domainName string,
+	versionHistory *persistence.VersionHistory,
+) ([]checksum.Checksum, error) {
+
+	lastItem, err := versionHistory.GetLastItem()
+	if err != nil {
+		return nil, err
+	}
+
+	checksums := make([]checksum.Checksum, 0, lastItem.EventID)
+	var pageToken []byte
+	for {
+		response, err := r.pr.ReadHistoryBranch(ctx, &persistence.ReadHistoryBranchRequest{
+			BranchToken:   versionHistory.GetBranchToken(),
+			MinEventID:    c.FirstEventID,
+			MaxEventID:    lastItem.EventID + 1,
+			PageSize:      replicationHistoryPageSize,
+			NextPageToken: pageToken,
+			ShardID:       c.IntPtr(execution.ShardID),
+			DomainName:    domainName,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range response.HistoryEvents {
+			eventChecksum, err := checksum.GenerateCRC32(thrift.FromHistoryEvent(event), eventChecksumVersion)
+			if err != nil {
+				return nil, err
+			}
+			checksums = append(checksums, eventChecksum)
+		}
+		if len(response.NextPageToken) == 0 {
+			break
+		}
+		pageToken = response.NextPageToken
+	}
+	if int64(len(checksums)) != lastItem.EventID {
+		return nil, fmt.Errorf("expected %v events in history, got %v", lastItem.EventID, len(checksums))
+	}
+	return checksums, nil
+}
+
+func (r *replicationConsistent) isSampled(
+	execution *entity.ConcreteExecution,
+) bool {
+	if r.sampleRate >= 1 {
+		return true
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(execution.RunID))
+	return float64(h.Sum32()%sampleBuckets) < r.sampleRate*sampleBuckets
+}
+
+// formatVersionHistory formats the items of the version history as (event ID, version) pairs
+func formatVersionHistory(
+	versionHistory *persistence.VersionHistory,
+) string {
+	items := make([]string, 0, len(versionHistory.Items))
+	for _, item := range versionHistory.Items {
+		items = append(items, fmt.Sprintf("(%v, %v)", item.EventID, item.Version))
+	}
+	return "[" + strings.Join(items, ", ") + "]"
+}
@@ -0,0 +1,361 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package invariant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/client/admin"
+	c2 "github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/partition"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+type ReplicationConsistentSuite struct {
+	*require.Assertions
+	suite.Suite
+
+	controller        *gomock.Controller
+	domainCache       *cache.MockDomainCache
+	clientBean        *client.MockBean
+	activeAdminClient *admin.MockClient
+	remoteAdminClient *admin.MockClient
+	execManager       *mocks.ExecutionManager
+	historyManager    *mocks.HistoryV2Manager
+}
+
+func TestReplicationConsistentSuite(t *testing.T) {
+	suite.Run(t, new(ReplicationConsistentSuite))
+}
+
+func (s *ReplicationConsistentSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.controller = gomock.NewController(s.T())
+	s.domainCache = cache.NewMockDomainCache(s.controller)
+	s.clientBean = client.NewMockBean(s.controller)
+	s.activeAdminClient = admin.NewMockClient(s.controller)
+	s.remoteAdminClient = admin.NewMockClient(s.controller)
+	s.execManager = &mocks.ExecutionManager{}
+	s.historyManager = &mocks.HistoryV2Manager{}
+
+	s.clientBean.EXPECT().GetRemoteAdminClient(cluster.TestCurrentClusterName).Return(s.activeAdminClient).AnyTimes()
+	s.clientBean.EXPECT().GetRemoteAdminClient(cluster.TestAlternativeClusterName).Return(s.remoteAdminClient).AnyTimes()
+}
+
+func (s *ReplicationConsistentSuite) TearDownTest() {
+	s.controller.Finish()
+}
+
+func (s *ReplicationConsistentSuite) TestCheck() {
+	localEvents := s.newEvents(3, 0)
+	testCases := []struct {
+		name           string
+		state          int
+		sampleRate     float64
+		localOnly      bool
+		remoteItems    []*persistence.VersionHistoryItem
+		remoteEvents   []*types.HistoryEvent
+		remoteErr      error
+		expectedResult CheckResult
+	}{
+		{
+			name:           "consistent",
+			state:          closedState,
+			sampleRate:     1,
+			remoteItems:    []*persistence.VersionHistoryItem{persistence.NewVersionHistoryItem(3, 0)},
+			remoteEvents:   localEvents,
+			expectedResult: CheckResult{CheckResultType: CheckResultTypeHealthy, InvariantName: ReplicationConsistent},
+		},
+		{
+			name:         "checksum mismatch",
+			state:        closedState,
+			sampleRate:   1,
+			remoteItems:  []*persistence.VersionHistoryItem{persistence.NewVersionHistoryItem(3, 0)},
+			remoteEvents: append(s.newEvents(1, 0), s.newEvents(3, 100)[1:]...),
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   ReplicationConsistent,
+				Info:            "history is not consistent with other clusters",
+				InfoDetails:     "cluster standby: checksum of event 2 does not match",
+			},
+		},
+		{
+			name:       "version history diverged",
+			state:      openState,
+			sampleRate: 1,
+			remoteItems: []*persistence.VersionHistoryItem{
+				persistence.NewVersionHistoryItem(2, 0),
+				persistence.NewVersionHistoryItem(3, 1),
+			},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   ReplicationConsistent,
+				Info:            "history is not consistent with other clusters",
+				InfoDetails:     "cluster standby: version history diverged, local version history: [(3, 0)], remote version history: [(2, 0), (3, 1)]",
+			},
+		},
+		{
+			name:         "open execution behind in remote cluster",
+			state:        openState,
+			sampleRate:   1,
+			remoteItems:  []*persistence.VersionHistoryItem{persistence.NewVersionHistoryItem(2, 0)},
+			remoteEvents: localEvents[:2],
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   ReplicationConsistent,
+			},
+		},
+		{
+			name:         "closed execution behind in remote cluster",
+			state:        closedState,
+			sampleRate:   1,
+			remoteItems:  []*persistence.VersionHistoryItem{persistence.NewVersionHistoryItem(2, 0)},
+			remoteEvents: localEvents[:2],
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   ReplicationConsistent,
+				Info:            "history is not consistent with other clusters",
+				InfoDetails:     "cluster standby: history is not up to date, local last event: 3, remote last event: 2",
+			},
+		},
+		{
+			name:         "events missing in remote cluster",
+			state:        closedState,
+			sampleRate:   1,
+			remoteItems:  []*persistence.VersionHistoryItem{persistence.NewVersionHistoryItem(3, 0)},
+			remoteEvents: localEvents[:1],
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   ReplicationConsistent,
+				Info:            "history is not consistent with other clusters",
+				InfoDetails:     "cluster standby: event 2 is missing",
+			},
+		},
+		{
+			name:       "closed execution missing in remote cluster",
+			state:      closedState,
+			sampleRate: 1,
+			remoteErr:  &types.EntityNotExistsError{},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   ReplicationConsistent,
+				Info:            "history is not consistent with other clusters",
+				InfoDetails:     "cluster standby: execution does not exist",
+			},
+		},
+		{
+			name:       "remote cluster unavailable",
+			state:      closedState,
+			sampleRate: 1,
+			remoteErr:  &types.InternalServiceError{Message: "unavailable"},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeFailed,
+				InvariantName:   ReplicationConsistent,
+				Info:            "failed to compare history with cluster standby",
+				InfoDetails:     "unavailable",
+			},
+		},
+		{
+			name:       "local-only execution",
+			state:      closedState,
+			sampleRate: 1,
+			localOnly:  true,
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   ReplicationConsistent,
+				Info:            "skipped check because execution is local-only",
+			},
+		},
+		{
+			name:       "not sampled",
+			state:      closedState,
+			sampleRate: 0,
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   ReplicationConsistent,
+				Info:            "skipped check because execution was not sampled",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			s.expectDomain(cluster.TestCurrentClusterName)
+			s.expectLocalExecution(tc.state, tc.localOnly, localEvents)
+			if tc.remoteItems != nil || tc.remoteErr != nil {
+				s.expectRemoteHistory(tc.remoteItems, tc.remoteEvents, tc.remoteErr)
+			}
+
+			i := s.newInvariant(tc.sampleRate)
+			s.Equal(tc.expectedResult, i.Check(context.Background(), getClosedConcreteExecution()))
+		})
+	}
+}
+
+func (s *ReplicationConsistentSuite) TestFix_ActiveInCurrentCluster() {
+	localEvents := s.newEvents(3, 0)
+	s.expectDomain(cluster.TestCurrentClusterName)
+	s.expectLocalExecution(closedState, false, localEvents)
+	s.expectRemoteHistory([]*persistence.VersionHistoryItem{persistence.NewVersionHistoryItem(2, 0)}, localEvents[:2], nil)
+	s.remoteAdminClient.EXPECT().ResendReplicationTasks(gomock.Any(), &types.ResendReplicationTasksRequest{
+		DomainID:      domainID,
+		WorkflowID:    workflowID,
+		RunID:         runID,
+		RemoteCluster: cluster.TestCurrentClusterName,
+	}).Return(nil).Times(1)
+
+	result := s.newInvariant(1).Fix(context.Background(), getClosedConcreteExecution())
+	s.Equal(FixResultTypeFixed, result.FixResultType)
+	s.Equal(CheckResultTypeCorrupted, result.CheckResult.CheckResultType)
+}
+
+func (s *ReplicationConsistentSuite) TestFix_ActiveInRemoteCluster() {
+	localEvents := s.newEvents(3, 0)
+	s.expectDomain(cluster.TestAlternativeClusterName)
+	s.expectLocalExecution(closedState, false, localEvents)
+	s.expectRemoteHistory([]*persistence.VersionHistoryItem{persistence.NewVersionHistoryItem(3, 0)}, localEvents[:1], nil)
+	s.activeAdminClient.EXPECT().ResendReplicationTasks(gomock.Any(), &types.ResendReplicationTasksRequest{
+		DomainID:      domainID,
+		WorkflowID:    workflowID,
+		RunID:         runID,
+		RemoteCluster: cluster.TestAlternativeClusterName,
+	}).Return(nil).Times(1)
+
+	result := s.newInvariant(1).Fix(context.Background(), getClosedConcreteExecution())
+	s.Equal(FixResultTypeFixed, result.FixResultType)
+}
+
+func (s *ReplicationConsistentSuite) TestFix_Healthy() {
+	localEvents := s.newEvents(3, 0)
+	s.expectDomain(cluster.TestCurrentClusterName)
+	s.expectLocalExecution(closedState, false, localEvents)
+	s.expectRemoteHistory([]*persistence.VersionHistoryItem{persistence.NewVersionHistoryItem(3, 0)}, localEvents, nil)
+
+	result := s.newInvariant(1).Fix(context.Background(), getClosedConcreteExecution())
+	s.Equal(FixResultTypeSkipped, result.FixResultType)
+	s.Equal("skipped fix because execution was healthy", result.Info)
+}
+
+func (s *ReplicationConsistentSuite) newInvariant(
+	sampleRate float64,
+) Invariant {
+	return NewReplicationConsistent(
+		persistence.NewPersistenceRetryer(s.execManager, s.historyManager, c2.CreatePersistenceRetryPolicy()),
+		s.domainCache,
+		s.clientBean,
+		cluster.TestActiveClusterMetadata,
+		sampleRate,
+	)
+}
+
+func (s *ReplicationConsistentSuite) newEvents(
+	lastEventID int64,
+	timestamp int64,
+) []*types.HistoryEvent {
+	var events []*types.HistoryEvent
+	for eventID := c2.FirstEventID; eventID <= lastEventID; eventID++ {
+		events = append(events, &types.HistoryEvent{
+			ID:        eventID,
+			Version:   0,
+			Timestamp: c2.Int64Ptr(timestamp),
+			EventType: types.EventTypeWorkflowExecutionSignaled.Ptr(),
+		})
+	}
+	return events
+}
+
+func (s *ReplicationConsistentSuite) expectDomain(
+	activeCluster string,
+) {
+	s.domainCache.EXPECT().GetDomainByID(domainID).Return(cache.NewGlobalDomainCacheEntryForTest(
+		&persistence.DomainInfo{ID: domainID, Name: "test-domain-name"},
+		&persistence.DomainConfig{},
+		&persistence.DomainReplicationConfig{
+			ActiveClusterName: activeCluster,
+			Clusters: []*persistence.ClusterReplicationConfig{
+				{ClusterName: cluster.TestCurrentClusterName},
+				{ClusterName: cluster.TestAlternativeClusterName},
+			},
+		},
+		1,
+	), nil).AnyTimes()
+}
+
+func (s *ReplicationConsistentSuite) expectLocalExecution(
+	state int,
+	localOnly bool,
+	events []*types.HistoryEvent,
+) {
+	partitionConfig := map[string]string{}
+	if localOnly {
+		partitionConfig[partition.LocalOnlyKey] = "true"
+	}
+	s.execManager.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{
+		State: &persistence.WorkflowMutableState{
+			ExecutionInfo: &persistence.WorkflowExecutionInfo{
+				DomainID:        domainID,
+				WorkflowID:      workflowID,
+				RunID:           runID,
+				State:           state,
+				PartitionConfig: partitionConfig,
+			},
+			VersionHistories: persistence.NewVersionHistories(persistence.NewVersionHistory(
+				branchToken,
+				[]*persistence.VersionHistoryItem{persistence.NewVersionHistoryItem(int64(len(events)), 0)},
+			)),
+		},
+	}, nil)
+	s.historyManager.On("ReadHistoryBranch", mock.Anything, mock.Anything).Return(&persistence.ReadHistoryBranchResponse{
+		HistoryEvents: events,
+	}, nil)
+}
+
+func (s *ReplicationConsistentSuite) expectRemoteHistory(
+	items []*persistence.VersionHistoryItem,
+	events []*types.HistoryEvent,
+	err error,
+) {
+	if err != nil {
+		s.remoteAdminClient.EXPECT().GetWorkflowExecutionRawHistoryV2(gomock.Any(), gomock.Any()).Return(nil, err).Times(1)
+		return
+	}
+
+	blob, serializeErr := persistence.NewPayloadSerializer().SerializeBatchEvents(events, c2.EncodingTypeThriftRW)
+	s.NoError(serializeErr)
+	s.remoteAdminClient.EXPECT().GetWorkflowExecutionRawHistoryV2(gomock.Any(), gomock.Any()).Return(&types.GetWorkflowExecutionRawHistoryV2Response{
+		HistoryBatches: []*types.DataBlob{blob.ToInternal()},
+		VersionHistory: persistence.NewVersionHistory([]byte("remote branch token"), items).ToInternalType(),
+	}, nil).Times(1)
+}
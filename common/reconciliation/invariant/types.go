@@ -57,6 +57,10 @@ const (
 	// implying a failed cleanup / lost timers / etc of some kind.
 	StaleWorkflow Name = "stale_workflow"
 
+	// ReplicationConsistent asserts that the history of an execution of a global domain is the same in all
+	// clusters of the domain
+	ReplicationConsistent Name = "replication_consistent"
+
 	// CollectionMutableState is the collection of invariants relating to mutable state
 	CollectionMutableState Collection = 0
 	// CollectionHistory is the collection  of invariants relating to history
@@ -72,6 +72,7 @@ type (
 		GetMessagingClient() messaging.Client
 		GetBlobstoreClient() blobstore.Client
 		GetDomainReplicationQueue() domain.ReplicationQueue
+		GetHistoryShardMappingCache() cache.HistoryShardMappingCache
 
 		// membership infos
 		GetMembershipResolver() membership.Resolver
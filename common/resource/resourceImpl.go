@@ -94,6 +94,7 @@ type (
 		archivalMetadata        archiver.ArchivalMetadata
 		archiverProvider        provider.ArchiverProvider
 		domainReplicationQueue  domain.ReplicationQueue
+		historyShardMapping     cache.HistoryShardMappingCache
 
 		// membership infos
 
@@ -160,22 +161,6 @@ func New(
 		logger,
 		dynamicconfig.ClusterNameFilter(params.ClusterMetadata.GetCurrentClusterName()),
 	)
-	clientBean, err := client.NewClientBean(
-		client.NewRPCClientFactory(
-			params.RPCFactory,
-			membershipResolver,
-			params.MetricsClient,
-			dynamicCollection,
-			numShards,
-			logger,
-		),
-		params.RPCFactory.GetDispatcher(),
-		params.ClusterMetadata,
-	)
-	if err != nil {
-		return nil, err
-	}
-
 	persistenceBean, err := persistenceClient.NewBeanFromFactory(persistenceClient.NewFactory(
 		&params.PersistenceConfig,
 		func() float64 {
@@ -203,6 +188,30 @@ func New(
 		return nil, err
 	}
 
+	historyShardMapping := cache.NewHistoryShardMappingCache(
+		numShards,
+		persistenceBean.GetConfigStoreManager(),
+		clock.NewRealTimeSource(),
+		logger,
+	)
+
+	clientBean, err := client.NewClientBean(
+		client.NewRPCClientFactory(
+			params.RPCFactory,
+			membershipResolver,
+			params.MetricsClient,
+			dynamicCollection,
+			numShards,
+			historyShardMapping.GetHistoryShardMapping,
+			logger,
+		),
+		params.RPCFactory.GetDispatcher(),
+		params.ClusterMetadata,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	domainCache := cache.NewDomainCache(
 		persistenceBean.GetDomainManager(),
 		params.ClusterMetadata,
@@ -299,6 +308,7 @@ func New(
 		archivalMetadata:        params.ArchivalMetadata,
 		archiverProvider:        params.ArchiverProvider,
 		domainReplicationQueue:  domainReplicationQueue,
+		historyShardMapping:     historyShardMapping,
 
 		// membership infos
 		membershipResolver: membershipResolver,
@@ -367,6 +377,7 @@ func (h *Impl) Start() {
 		h.logger.WithTags(tag.Error(err)).Fatal("fail to start dispatcher")
 	}
 	h.membershipResolver.Start()
+	h.historyShardMapping.Start()
 	h.domainCache.Start()
 	h.domainMetricsScopeCache.Start()
 
@@ -398,6 +409,7 @@ func (h *Impl) Stop() {
 
 	h.domainCache.Stop()
 	h.domainMetricsScopeCache.Stop()
+	h.historyShardMapping.Stop()
 	h.membershipResolver.Stop()
 	if err := h.dispatcher.Stop(); err != nil {
 		h.logger.WithTags(tag.Error(err)).Error("failed to stop dispatcher")
@@ -477,6 +489,11 @@ func (h *Impl) GetDomainReplicationQueue() domain.ReplicationQueue {
 	return h.domainReplicationQueue
 }
 
+// GetHistoryShardMappingCache return history shard mapping cache
+func (h *Impl) GetHistoryShardMappingCache() cache.HistoryShardMappingCache {
+	return h.historyShardMapping
+}
+
 // GetMembershipResolver return the membership resolver
 func (h *Impl) GetMembershipResolver() membership.Resolver {
 	return h.membershipResolver
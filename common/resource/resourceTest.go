@@ -68,6 +68,7 @@ type (
 		DomainCache             *cache.MockDomainCache
 		DomainMetricsScopeCache cache.DomainMetricsScopeCache
 		DomainReplicationQueue  *domain.MockReplicationQueue
+		HistoryShardMapping     *cache.MockHistoryShardMappingCache
 		TimeSource              clock.TimeSource
 		PayloadSerializer       persistence.PayloadSerializer
 		MetricsClient           metrics.Client
@@ -90,13 +91,14 @@ type (
 
 		// persistence clients
 
-		MetadataMgr     *mocks.MetadataManager
-		TaskMgr         *mocks.TaskManager
-		VisibilityMgr   *mocks.VisibilityManager
-		ShardMgr        *mocks.ShardManager
-		HistoryMgr      *mocks.HistoryV2Manager
-		ExecutionMgr    *mocks.ExecutionManager
-		PersistenceBean *persistenceClient.MockBean
+		MetadataMgr             *mocks.MetadataManager
+		TaskMgr                 *mocks.TaskManager
+		VisibilityMgr           *mocks.VisibilityManager
+		ShardMgr                *mocks.ShardManager
+		HistoryMgr              *mocks.HistoryV2Manager
+		ExecutionMgr            *mocks.ExecutionManager
+		HistoryTaskQueueManager *persistence.MockQueueManager
+		PersistenceBean         *persistenceClient.MockBean
 
 		IsolationGroups     *isolationgroup.MockState
 		IsolationGroupStore *configstore.MockClient
@@ -145,6 +147,7 @@ func NewTest(
 	shardMgr := &mocks.ShardManager{}
 	historyMgr := &mocks.HistoryV2Manager{}
 	executionMgr := &mocks.ExecutionManager{}
+	historyTaskQueueManager := persistence.NewMockQueueManager(controller)
 	domainReplicationQueue := domain.NewMockReplicationQueue(controller)
 	domainReplicationQueue.EXPECT().Start().AnyTimes()
 	domainReplicationQueue.EXPECT().Stop().AnyTimes()
@@ -155,6 +158,7 @@ func NewTest(
 	persistenceBean.EXPECT().GetHistoryManager().Return(historyMgr).AnyTimes()
 	persistenceBean.EXPECT().GetShardManager().Return(shardMgr).AnyTimes()
 	persistenceBean.EXPECT().GetExecutionManager(gomock.Any()).Return(executionMgr, nil).AnyTimes()
+	persistenceBean.EXPECT().GetHistoryTaskQueueManager(gomock.Any()).Return(historyTaskQueueManager, nil).AnyTimes()
 
 	isolationGroupMock := isolationgroup.NewMockState(controller)
 	isolationGroupMock.EXPECT().Stop().AnyTimes()
@@ -178,6 +182,7 @@ func NewTest(
 		DomainCache:             cache.NewMockDomainCache(controller),
 		DomainMetricsScopeCache: cache.NewDomainMetricsScopeCache(),
 		DomainReplicationQueue:  domainReplicationQueue,
+		HistoryShardMapping:     cache.NewMockHistoryShardMappingCache(controller),
 		TimeSource:              clock.NewRealTimeSource(),
 		PayloadSerializer:       persistence.NewPayloadSerializer(),
 		MetricsClient:           metrics.NewClient(scope, serviceMetricsIndex),
@@ -200,15 +205,16 @@ func NewTest(
 
 		// persistence clients
 
-		MetadataMgr:     metadataMgr,
-		TaskMgr:         taskMgr,
-		VisibilityMgr:   visibilityMgr,
-		ShardMgr:        shardMgr,
-		HistoryMgr:      historyMgr,
-		ExecutionMgr:    executionMgr,
-		PersistenceBean: persistenceBean,
-		IsolationGroups: isolationGroupMock,
-		Partitioner:     partitionMock,
+		MetadataMgr:             metadataMgr,
+		TaskMgr:                 taskMgr,
+		VisibilityMgr:           visibilityMgr,
+		ShardMgr:                shardMgr,
+		HistoryMgr:              historyMgr,
+		ExecutionMgr:            executionMgr,
+		HistoryTaskQueueManager: historyTaskQueueManager,
+		PersistenceBean:         persistenceBean,
+		IsolationGroups:         isolationGroupMock,
+		Partitioner:             partitionMock,
 
 		// logger
 
@@ -257,6 +263,11 @@ func (s *Test) GetDomainMetricsScopeCache() cache.DomainMetricsScopeCache {
 	return s.DomainMetricsScopeCache
 }
 
+// GetHistoryShardMappingCache for testing
+func (s *Test) GetHistoryShardMappingCache() cache.HistoryShardMappingCache {
+	return s.HistoryShardMapping
+}
+
 // GetDomainReplicationQueue for testing
 func (s *Test) GetDomainReplicationQueue() domain.ReplicationQueue {
 	// user should implement this method for test
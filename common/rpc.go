@@ -58,6 +58,12 @@ const (
 
 	// ClientIsolationGroupHeaderName refers to the name of the header that contains the isolation group which the client request is from
 	ClientIsolationGroupHeaderName = "cadence-client-isolation-group"
+	// ClientActiveRegionHeaderName refers to the name of the header that contains the region whose active cluster
+	// a workflow of an active-active domain started by the client request should be active in
+	ClientActiveRegionHeaderName = "cadence-client-active-region"
+	// ClientLocalOnlyHeaderName refers to the name of the header that marks a workflow of a global domain
+	// started by the client request as local-only, i.e. not replicated to the other clusters
+	ClientLocalOnlyHeaderName = "cadence-client-local-only"
 )
 
 type (
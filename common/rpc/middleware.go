@@ -24,6 +24,7 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"strconv"
 
 	"go.uber.org/cadence/worker"
 	"go.uber.org/yarpc"
@@ -248,16 +249,26 @@ func (m *ForwardPartitionConfigMiddleware) Call(ctx context.Context, request *tr
 }
 
 // ClientPartitionConfigMiddleware stores the partition config and isolation group of the request into the context
-// It reads a header from client request and uses it as the isolation group
+// It reads headers from client request and uses them as the isolation group, the active region and the local-only flag
 type ClientPartitionConfigMiddleware struct{}
 
 func (m *ClientPartitionConfigMiddleware) Handle(ctx context.Context, req *transport.Request, resw transport.ResponseWriter, h transport.UnaryHandler) error {
+	partitionConfig := map[string]string{}
 	zone, _ := req.Headers.Get(common.ClientIsolationGroupHeaderName)
 	if zone != "" {
-		ctx = partition.ContextWithConfig(ctx, map[string]string{
-			partition.IsolationGroupKey: zone,
-		})
+		partitionConfig[partition.IsolationGroupKey] = zone
 		ctx = partition.ContextWithIsolationGroup(ctx, zone)
 	}
+	region, _ := req.Headers.Get(common.ClientActiveRegionHeaderName)
+	if region != "" {
+		partitionConfig[partition.ActiveRegionKey] = region
+	}
+	localOnly, _ := req.Headers.Get(common.ClientLocalOnlyHeaderName)
+	if isLocalOnly, err := strconv.ParseBool(localOnly); err == nil && isLocalOnly {
+		partitionConfig[partition.LocalOnlyKey] = "true"
+	}
+	if len(partitionConfig) > 0 {
+		ctx = partition.ContextWithConfig(ctx, partitionConfig)
+	}
 	return h.Handle(ctx, req, resw)
 }
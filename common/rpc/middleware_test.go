@@ -316,6 +316,41 @@ func TestClientPartitionConfigMiddleware(t *testing.T) {
 		assert.Equal(t, "dca1", partition.IsolationGroupFromContext(h.ctx))
 	})
 
+	t.Run("it sets the active region", func(t *testing.T) {
+		m := &ClientPartitionConfigMiddleware{}
+		h := &fakeHandler{}
+		headers := transport.NewHeaders().
+			With(common.ClientIsolationGroupHeaderName, "dca1").
+			With(common.ClientActiveRegionHeaderName, "us-east")
+		err := m.Handle(context.Background(), &transport.Request{Headers: headers}, nil, h)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			partition.IsolationGroupKey: "dca1",
+			partition.ActiveRegionKey:   "us-east",
+		}, partition.ConfigFromContext(h.ctx))
+		assert.Equal(t, "dca1", partition.IsolationGroupFromContext(h.ctx))
+	})
+
+	t.Run("it sets the local-only flag", func(t *testing.T) {
+		m := &ClientPartitionConfigMiddleware{}
+		h := &fakeHandler{}
+		headers := transport.NewHeaders().
+			With(common.ClientLocalOnlyHeaderName, "true")
+		err := m.Handle(context.Background(), &transport.Request{Headers: headers}, nil, h)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{partition.LocalOnlyKey: "true"}, partition.ConfigFromContext(h.ctx))
+	})
+
+	t.Run("it ignores the local-only flag when it is not true", func(t *testing.T) {
+		m := &ClientPartitionConfigMiddleware{}
+		h := &fakeHandler{}
+		headers := transport.NewHeaders().
+			With(common.ClientLocalOnlyHeaderName, "false")
+		err := m.Handle(context.Background(), &transport.Request{Headers: headers}, nil, h)
+		assert.NoError(t, err)
+		assert.Nil(t, partition.ConfigFromContext(h.ctx))
+	})
+
 	t.Run("noop when header is empty", func(t *testing.T) {
 		m := &ClientPartitionConfigMiddleware{}
 		h := &fakeHandler{}
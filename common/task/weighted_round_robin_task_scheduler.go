@@ -31,6 +31,7 @@ import (
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/quotas"
 )
 
 type weightedRoundRobinTaskSchedulerImpl struct {
@@ -38,20 +39,38 @@ type weightedRoundRobinTaskSchedulerImpl struct {
 
 	status       int32
 	weights      atomic.Value // store the currently used weights
-	taskChs      map[int]chan PriorityTask
-	shutdownCh   chan struct{}
-	notifyCh     chan struct{}
-	dispatcherWG sync.WaitGroup
-	logger       log.Logger
-	metricsScope metrics.Scope
-	options      *WeightedRoundRobinTaskSchedulerOptions
+	groupConfigs atomic.Value // store the currently used weight and rps for each task group
+	taskChs      map[weightedRoundRobinChannelKey]chan PriorityTask
+	// lastSubmitTimes records when a task was last submitted to each task group channel,
+	// so that channels of idle groups can be evicted
+	lastSubmitTimes map[weightedRoundRobinChannelKey]*atomic.Int64
+	rateLimiters    map[string]quotas.Limiter
+	shutdownCh      chan struct{}
+	notifyCh        chan struct{}
+	dispatcherWG    sync.WaitGroup
+	logger          log.Logger
+	metricsScope    metrics.Scope
+	options         *WeightedRoundRobinTaskSchedulerOptions
 
 	processor Processor
 }
 
+type weightedRoundRobinChannelKey struct {
+	priority int
+	group    string
+}
+
+type weightedRoundRobinGroupConfig struct {
+	weight int
+	rps    float64
+}
+
 const (
 	wRRTaskProcessorQueueSize    = 1
 	defaultUpdateWeightsInterval = 5 * time.Second
+	// taskGroupIdleTimeout is how long a task group channel can stay empty without new tasks
+	// before it's evicted together with the group's rate limiter
+	taskGroupIdleTimeout = time.Minute
 )
 
 var (
@@ -75,13 +94,15 @@ func NewWeightedRoundRobinTaskScheduler(
 	}
 
 	scheduler := &weightedRoundRobinTaskSchedulerImpl{
-		status:       common.DaemonStatusInitialized,
-		taskChs:      make(map[int]chan PriorityTask),
-		shutdownCh:   make(chan struct{}),
-		notifyCh:     make(chan struct{}, 1),
-		logger:       logger,
-		metricsScope: metricsClient.Scope(metrics.TaskSchedulerScope),
-		options:      options,
+		status:          common.DaemonStatusInitialized,
+		taskChs:         make(map[weightedRoundRobinChannelKey]chan PriorityTask),
+		lastSubmitTimes: make(map[weightedRoundRobinChannelKey]*atomic.Int64),
+		rateLimiters:    make(map[string]quotas.Limiter),
+		shutdownCh:      make(chan struct{}),
+		notifyCh:        make(chan struct{}, 1),
+		logger:          logger,
+		metricsScope:    metricsClient.Scope(metrics.TaskSchedulerScope),
+		options:         options,
 		processor: NewParallelTaskProcessor(
 			logger,
 			metricsClient,
@@ -93,6 +114,7 @@ func NewWeightedRoundRobinTaskScheduler(
 		),
 	}
 	scheduler.weights.Store(weights)
+	scheduler.groupConfigs.Store(make(map[string]weightedRoundRobinGroupConfig))
 
 	return scheduler, nil
 }
@@ -144,7 +166,8 @@ func (w *weightedRoundRobinTaskSchedulerImpl) Submit(task PriorityTask) error {
 		return ErrTaskSchedulerClosed
 	}
 
-	taskCh, err := w.getOrCreateTaskChan(task.Priority())
+	key := w.getChannelKey(task)
+	taskCh, err := w.getOrCreateTaskChan(key)
 	if err != nil {
 		return err
 	}
@@ -154,6 +177,8 @@ func (w *weightedRoundRobinTaskSchedulerImpl) Submit(task PriorityTask) error {
 		w.notifyDispatcher()
 		if w.isStopped() {
 			drainAndNackPriorityTask(taskCh)
+		} else {
+			w.resubmitIfEvicted(key, taskCh)
 		}
 		return nil
 	case <-w.shutdownCh:
@@ -168,7 +193,8 @@ func (w *weightedRoundRobinTaskSchedulerImpl) TrySubmit(
 		return false, ErrTaskSchedulerClosed
 	}
 
-	taskCh, err := w.getOrCreateTaskChan(task.Priority())
+	key := w.getChannelKey(task)
+	taskCh, err := w.getOrCreateTaskChan(key)
 	if err != nil {
 		return false, err
 	}
@@ -180,6 +206,7 @@ func (w *weightedRoundRobinTaskSchedulerImpl) TrySubmit(
 			drainAndNackPriorityTask(taskCh)
 		} else {
 			w.notifyDispatcher()
+			w.resubmitIfEvicted(key, taskCh)
 		}
 		return true, nil
 	case <-w.shutdownCh:
@@ -193,33 +220,70 @@ func (w *weightedRoundRobinTaskSchedulerImpl) dispatcher() {
 	defer w.dispatcherWG.Done()
 
 	outstandingTasks := false
-	taskChs := make(map[int]chan PriorityTask)
+	// throttleRetryDelay is the time until the earliest throttled task group is allowed to dispatch again,
+	// zero means no task is pending because of throttling
+	var throttleRetryDelay time.Duration
+	taskChs := make(map[weightedRoundRobinChannelKey]chan PriorityTask)
 
 	for {
 		if !outstandingTasks {
 			// if no task is dispatched in the last round,
 			// wait for a notification
 			w.logger.Debug("Weighted round robin task scheduler is waiting for new task notification because there was no task dispatched in the last round.")
+			var throttleRetryTimer *time.Timer
+			var throttleRetryCh <-chan time.Time
+			if throttleRetryDelay > 0 {
+				// some tasks are pending only because their group is throttled,
+				// check them again once the rate limiter allows even if there's no new task
+				throttleRetryTimer = time.NewTimer(throttleRetryDelay)
+				throttleRetryCh = throttleRetryTimer.C
+			}
 			select {
 			case <-w.notifyCh:
 				// block until there's a new task
 				w.logger.Debug("Weighted round robin task scheduler got notification so will check for new tasks.")
+			case <-throttleRetryCh:
 			case <-w.shutdownCh:
+				if throttleRetryTimer != nil {
+					throttleRetryTimer.Stop()
+				}
 				return
 			}
+			if throttleRetryTimer != nil {
+				throttleRetryTimer.Stop()
+			}
 		}
 
 		outstandingTasks = false
+		throttleRetryDelay = 0
 		w.updateTaskChs(taskChs)
 		weights := w.getWeights()
-		for priority, taskCh := range taskChs {
-			count, ok := weights[priority]
+		groupConfigs := w.getGroupConfigs()
+		for key, taskCh := range taskChs {
+			count, ok := weights[key.priority]
 			if !ok {
-				w.logger.Error("weights not found for task priority", tag.Dynamic("priority", priority), tag.Dynamic("weights", weights))
+				w.logger.Error("weights not found for task priority", tag.Dynamic("priority", key.priority), tag.Dynamic("weights", weights))
 				continue
 			}
+			var rateLimiter quotas.Limiter
+			if key.group != "" {
+				if config, ok := groupConfigs[key.group]; ok {
+					count *= config.weight
+				}
+				rateLimiter = w.getRateLimiter(key.group)
+			}
 		Submit_Loop:
 			for i := 0; i < count; i++ {
+				if rateLimiter != nil && len(taskCh) != 0 {
+					if delay := reserveToken(rateLimiter); delay > 0 {
+						// group is throttled, skip to next channel so that other groups are not blocked
+						if throttleRetryDelay == 0 || delay < throttleRetryDelay {
+							throttleRetryDelay = delay
+						}
+						w.metricsScope.IncCounter(metrics.PriorityTaskThrottledCounter)
+						break Submit_Loop
+					}
+				}
 				select {
 				case task := <-taskCh:
 					// dispatched at least one task in this round
@@ -240,13 +304,27 @@ func (w *weightedRoundRobinTaskSchedulerImpl) dispatcher() {
 	}
 }
 
-func (w *weightedRoundRobinTaskSchedulerImpl) getOrCreateTaskChan(priority int) (chan PriorityTask, error) {
-	if _, ok := w.getWeights()[priority]; !ok {
-		return nil, fmt.Errorf("unknown task priority: %v", priority)
+func (w *weightedRoundRobinTaskSchedulerImpl) getChannelKey(task PriorityTask) weightedRoundRobinChannelKey {
+	key := weightedRoundRobinChannelKey{
+		priority: task.Priority(),
+	}
+	if w.options.TaskToGroupFn != nil {
+		key.group = w.options.TaskToGroupFn(task)
+	}
+	return key
+}
+
+func (w *weightedRoundRobinTaskSchedulerImpl) getOrCreateTaskChan(key weightedRoundRobinChannelKey) (chan PriorityTask, error) {
+	if _, ok := w.getWeights()[key.priority]; !ok {
+		return nil, fmt.Errorf("unknown task priority: %v", key.priority)
 	}
 
+	now := time.Now().UnixNano()
 	w.RLock()
-	if taskCh, ok := w.taskChs[priority]; ok {
+	if taskCh, ok := w.taskChs[key]; ok {
+		if lastSubmitTime, ok := w.lastSubmitTimes[key]; ok {
+			lastSubmitTime.Store(now)
+		}
 		w.RUnlock()
 		return taskCh, nil
 	}
@@ -254,21 +332,137 @@ func (w *weightedRoundRobinTaskSchedulerImpl) getOrCreateTaskChan(priority int)
 
 	w.Lock()
 	defer w.Unlock()
-	if taskCh, ok := w.taskChs[priority]; ok {
+	if taskCh, ok := w.taskChs[key]; ok {
+		if lastSubmitTime, ok := w.lastSubmitTimes[key]; ok {
+			lastSubmitTime.Store(now)
+		}
 		return taskCh, nil
 	}
+	if key.group != "" {
+		w.addGroupLocked(key.group)
+		lastSubmitTime := &atomic.Int64{}
+		lastSubmitTime.Store(now)
+		w.lastSubmitTimes[key] = lastSubmitTime
+	}
 	taskCh := make(chan PriorityTask, w.options.QueueSize)
-	w.taskChs[priority] = taskCh
+	w.taskChs[key] = taskCh
 	return taskCh, nil
 }
 
-func (w *weightedRoundRobinTaskSchedulerImpl) updateTaskChs(taskChs map[int]chan PriorityTask) {
+// resubmitIfEvicted moves tasks out of a task group channel which was evicted while the task was being
+// submitted to it, as dispatchers no longer read from an evicted channel
+func (w *weightedRoundRobinTaskSchedulerImpl) resubmitIfEvicted(key weightedRoundRobinChannelKey, taskCh chan PriorityTask) {
+	w.RLock()
+	currentTaskCh := w.taskChs[key]
+	w.RUnlock()
+	if currentTaskCh == taskCh {
+		return
+	}
+
+	for {
+		select {
+		case task := <-taskCh:
+			if err := w.Submit(task); err != nil {
+				task.Nack()
+			}
+		default:
+			return
+		}
+	}
+}
+
+// evictIdleTaskGroups removes task group channels which have been empty and received no task for
+// taskGroupIdleTimeout, the group's config and rate limiter are removed with its last channel
+func (w *weightedRoundRobinTaskSchedulerImpl) evictIdleTaskGroups() {
+	w.Lock()
+	defer w.Unlock()
+
+	evictBefore := time.Now().Add(-taskGroupIdleTimeout).UnixNano()
+	for key, lastSubmitTime := range w.lastSubmitTimes {
+		if len(w.taskChs[key]) != 0 || lastSubmitTime.Load() > evictBefore {
+			continue
+		}
+		delete(w.taskChs, key)
+		delete(w.lastSubmitTimes, key)
+	}
+
+	activeGroups := make(map[string]struct{}, len(w.lastSubmitTimes))
+	for key := range w.lastSubmitTimes {
+		activeGroups[key.group] = struct{}{}
+	}
+	groupConfigs := w.getGroupConfigs()
+	if len(activeGroups) == len(groupConfigs) {
+		return
+	}
+	newGroupConfigs := make(map[string]weightedRoundRobinGroupConfig, len(activeGroups))
+	for group, config := range groupConfigs {
+		if _, ok := activeGroups[group]; ok {
+			newGroupConfigs[group] = config
+		} else {
+			delete(w.rateLimiters, group)
+		}
+	}
+	w.groupConfigs.Store(newGroupConfigs)
+}
+
+func (w *weightedRoundRobinTaskSchedulerImpl) addGroupLocked(group string) {
+	groupConfigs := w.getGroupConfigs()
+	if _, ok := groupConfigs[group]; ok {
+		return
+	}
+
+	newGroupConfigs := make(map[string]weightedRoundRobinGroupConfig, len(groupConfigs)+1)
+	for g, config := range groupConfigs {
+		newGroupConfigs[g] = config
+	}
+	newGroupConfigs[group] = w.loadGroupConfig(group)
+	w.groupConfigs.Store(newGroupConfigs)
+
+	if w.options.GroupRPSFn != nil {
+		w.rateLimiters[group] = quotas.NewDynamicRateLimiter(func() float64 {
+			return w.getGroupConfigs()[group].rps
+		})
+	}
+}
+
+func (w *weightedRoundRobinTaskSchedulerImpl) loadGroupConfig(group string) weightedRoundRobinGroupConfig {
+	config := weightedRoundRobinGroupConfig{
+		weight: 1,
+	}
+	if w.options.GroupWeightFn != nil {
+		if weight := w.options.GroupWeightFn(group); weight > 0 {
+			config.weight = weight
+		}
+	}
+	if w.options.GroupRPSFn != nil {
+		config.rps = w.options.GroupRPSFn(group)
+	}
+	return config
+}
+
+func (w *weightedRoundRobinTaskSchedulerImpl) getRateLimiter(group string) quotas.Limiter {
+	if w.getGroupConfigs()[group].rps <= 0 {
+		return nil
+	}
+
+	w.RLock()
+	defer w.RUnlock()
+	return w.rateLimiters[group]
+}
+
+func (w *weightedRoundRobinTaskSchedulerImpl) updateTaskChs(taskChs map[weightedRoundRobinChannelKey]chan PriorityTask) {
 	w.RLock()
 	defer w.RUnlock()
 
-	for priority, taskCh := range w.taskChs {
-		if _, ok := taskChs[priority]; !ok {
-			taskChs[priority] = taskCh
+	for key, taskCh := range w.taskChs {
+		if _, ok := taskChs[key]; !ok {
+			taskChs[key] = taskCh
+		}
+	}
+	for key := range taskChs {
+		if _, ok := w.taskChs[key]; !ok {
+			// channel is evicted
+			delete(taskChs, key)
 		}
 	}
 }
@@ -286,6 +480,22 @@ func (w *weightedRoundRobinTaskSchedulerImpl) getWeights() map[int]int {
 	return w.weights.Load().(map[int]int)
 }
 
+func (w *weightedRoundRobinTaskSchedulerImpl) getGroupConfigs() map[string]weightedRoundRobinGroupConfig {
+	return w.groupConfigs.Load().(map[string]weightedRoundRobinGroupConfig)
+}
+
+func (w *weightedRoundRobinTaskSchedulerImpl) updateGroupConfigs() {
+	w.Lock()
+	defer w.Unlock()
+
+	groupConfigs := w.getGroupConfigs()
+	newGroupConfigs := make(map[string]weightedRoundRobinGroupConfig, len(groupConfigs))
+	for group := range groupConfigs {
+		newGroupConfigs[group] = w.loadGroupConfig(group)
+	}
+	w.groupConfigs.Store(newGroupConfigs)
+}
+
 func (w *weightedRoundRobinTaskSchedulerImpl) updateWeights() {
 	ticker := time.NewTicker(defaultUpdateWeightsInterval)
 	for {
@@ -297,6 +507,10 @@ func (w *weightedRoundRobinTaskSchedulerImpl) updateWeights() {
 			} else {
 				w.weights.Store(weights)
 			}
+			if w.options.TaskToGroupFn != nil {
+				w.evictIdleTaskGroups()
+				w.updateGroupConfigs()
+			}
 		case <-w.shutdownCh:
 			ticker.Stop()
 			return
@@ -308,6 +522,21 @@ func (w *weightedRoundRobinTaskSchedulerImpl) isStopped() bool {
 	return atomic.LoadInt32(&w.status) == common.DaemonStatusStopped
 }
 
+// reserveToken takes a token from the rate limiter if one is available now,
+// otherwise it returns how long to wait until the next token is available
+func reserveToken(rateLimiter quotas.Limiter) time.Duration {
+	reservation := rateLimiter.Reserve()
+	if !reservation.OK() {
+		// the limiter can't grant any token with its current config, check again after the config is refreshed
+		return defaultUpdateWeightsInterval
+	}
+	delay := reservation.Delay()
+	if delay > 0 {
+		reservation.Cancel()
+	}
+	return delay
+}
+
 func drainAndNackPriorityTask(taskCh <-chan PriorityTask) {
 	for {
 		select {
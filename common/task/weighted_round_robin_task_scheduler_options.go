@@ -34,6 +34,16 @@ type WeightedRoundRobinTaskSchedulerOptions struct {
 	WorkerCount     dynamicconfig.IntPropertyFn
 	DispatcherCount int
 	RetryPolicy     backoff.RetryPolicy
+
+	// TaskToGroupFn, if specified, groups tasks with the same priority into separate channels,
+	// so that the backlog of one group won't delay tasks from other groups
+	TaskToGroupFn func(PriorityTask) string
+	// GroupWeightFn returns the weight of a task group, the number of tasks dispatched
+	// from a group channel in each round is the priority weight times the group weight.
+	// Default weight is 1 if not specified
+	GroupWeightFn func(group string) int
+	// GroupRPSFn returns the max dispatch rps of a task group, non-positive value means no limit
+	GroupRPSFn func(group string) float64
 }
 
 func (o *WeightedRoundRobinTaskSchedulerOptions) String() string {
@@ -38,6 +38,7 @@ import (
 	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/log/testlogger"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/quotas"
 )
 
 type (
@@ -103,7 +104,7 @@ func (s *weightedRoundRobinTaskSchedulerSuite) TestSubmit_Success() {
 	err := s.scheduler.Submit(mockTask)
 	s.NoError(err)
 
-	task := <-s.scheduler.taskChs[taskPriority]
+	task := <-s.scheduler.taskChs[weightedRoundRobinChannelKey{priority: taskPriority}]
 	s.Equal(mockTask, task)
 	for _, taskCh := range s.scheduler.taskChs {
 		s.Empty(taskCh)
@@ -182,7 +183,7 @@ func (s *weightedRoundRobinTaskSchedulerSuite) TestDispatcher_SubmitWithNoError(
 				if expectedRemainingTasksNum < 0 {
 					expectedRemainingTasksNum = 0
 				}
-				s.Equal(expectedRemainingTasksNum, len(s.scheduler.taskChs[priority]))
+				s.Equal(expectedRemainingTasksNum, len(s.scheduler.taskChs[weightedRoundRobinChannelKey{priority: priority}]))
 			}
 		}
 
@@ -245,6 +246,103 @@ func (s *weightedRoundRobinTaskSchedulerSuite) TestDispatcher_FailToSubmit() {
 	<-doneCh
 }
 
+func (s *weightedRoundRobinTaskSchedulerSuite) TestSubmit_TaskGroup() {
+	groupWeights := map[string]int{"group-a": 3, "group-b": 0}
+	taskGroups := make(map[PriorityTask]string)
+	scheduler := s.newTestWeightedRoundRobinTaskScheduler(
+		&WeightedRoundRobinTaskSchedulerOptions{
+			Weights:         testSchedulerWeights,
+			QueueSize:       s.queueSize,
+			WorkerCount:     dynamicconfig.GetIntPropertyFn(1),
+			DispatcherCount: 3,
+			RetryPolicy:     backoff.NewExponentialRetryPolicy(time.Millisecond),
+			TaskToGroupFn: func(task PriorityTask) string {
+				return taskGroups[task]
+			},
+			GroupWeightFn: func(group string) int {
+				return groupWeights[group]
+			},
+		},
+	)
+
+	for _, group := range []string{"group-a", "group-b"} {
+		mockTask := NewMockPriorityTask(s.controller)
+		mockTask.EXPECT().Priority().Return(1)
+		taskGroups[mockTask] = group
+		s.NoError(scheduler.Submit(mockTask))
+
+		taskCh, ok := scheduler.taskChs[weightedRoundRobinChannelKey{priority: 1, group: group}]
+		s.True(ok)
+		s.Len(taskCh, 1)
+	}
+	s.Len(scheduler.taskChs, 2)
+
+	groupConfigs := scheduler.getGroupConfigs()
+	s.Equal(3, groupConfigs["group-a"].weight)
+	s.Equal(1, groupConfigs["group-b"].weight) // non-positive weight falls back to 1
+	s.Nil(scheduler.getRateLimiter("group-a"))
+}
+
+func (s *weightedRoundRobinTaskSchedulerSuite) TestDispatcher_TaskGroupThrottled() {
+	taskGroups := make(map[PriorityTask]string)
+	scheduler := s.newTestWeightedRoundRobinTaskScheduler(
+		&WeightedRoundRobinTaskSchedulerOptions{
+			Weights:         testSchedulerWeights,
+			QueueSize:       s.queueSize,
+			WorkerCount:     dynamicconfig.GetIntPropertyFn(1),
+			DispatcherCount: 1,
+			RetryPolicy:     backoff.NewExponentialRetryPolicy(time.Millisecond),
+			TaskToGroupFn: func(task PriorityTask) string {
+				return taskGroups[task]
+			},
+			GroupRPSFn: func(group string) float64 {
+				if group == "noisy-group" {
+					return 0.001
+				}
+				return 0
+			},
+		},
+	)
+
+	var taskWG sync.WaitGroup
+	numNoisyTasks := 100
+	numTasks := 10
+	for i := 0; i != numNoisyTasks; i++ {
+		mockTask := NewMockPriorityTask(s.controller)
+		mockTask.EXPECT().Priority().Return(0)
+		taskGroups[mockTask] = "noisy-group"
+		s.NoError(scheduler.Submit(mockTask))
+		s.mockProcessor.EXPECT().Submit(newMockPriorityTaskMatcher(mockTask)).Return(nil).MaxTimes(1)
+	}
+	for i := 0; i != numTasks; i++ {
+		mockTask := NewMockPriorityTask(s.controller)
+		mockTask.EXPECT().Priority().Return(0)
+		taskGroups[mockTask] = "other-group"
+		s.NoError(scheduler.Submit(mockTask))
+		taskWG.Add(1)
+		s.mockProcessor.EXPECT().Submit(newMockPriorityTaskMatcher(mockTask)).DoAndReturn(func(_ Task) error {
+			taskWG.Done()
+			return nil
+		})
+	}
+	scheduler.processor = s.mockProcessor
+
+	doneCh := make(chan struct{})
+	scheduler.dispatcherWG.Add(1)
+	go func() {
+		scheduler.dispatcher()
+		close(doneCh)
+	}()
+
+	// tasks from other group should not be blocked by the throttled noisy group
+	taskWG.Wait()
+	close(scheduler.shutdownCh)
+	<-doneCh
+
+	noisyTaskCh := scheduler.taskChs[weightedRoundRobinChannelKey{priority: 0, group: "noisy-group"}]
+	s.True(len(noisyTaskCh) > numNoisyTasks/2)
+}
+
 func (s *weightedRoundRobinTaskSchedulerSuite) TestWRR() {
 	numTasks := 1000
 	var taskWG sync.WaitGroup
@@ -284,6 +382,73 @@ func (s *weightedRoundRobinTaskSchedulerSuite) TestSchedulerContract() {
 	testSchedulerContract(s.Assertions, s.controller, s.scheduler)
 }
 
+func (s *weightedRoundRobinTaskSchedulerSuite) TestEvictIdleTaskGroups() {
+	taskGroups := make(map[PriorityTask]string)
+	scheduler := s.newTestWeightedRoundRobinTaskScheduler(
+		&WeightedRoundRobinTaskSchedulerOptions{
+			Weights:         testSchedulerWeights,
+			QueueSize:       s.queueSize,
+			WorkerCount:     dynamicconfig.GetIntPropertyFn(1),
+			DispatcherCount: 1,
+			RetryPolicy:     backoff.NewExponentialRetryPolicy(time.Millisecond),
+			TaskToGroupFn: func(task PriorityTask) string {
+				return taskGroups[task]
+			},
+			GroupRPSFn: func(group string) float64 {
+				return 10
+			},
+		},
+	)
+
+	for _, group := range []string{"idle-group", "busy-group", "pending-group"} {
+		mockTask := NewMockPriorityTask(s.controller)
+		mockTask.EXPECT().Priority().Return(0)
+		taskGroups[mockTask] = group
+		s.NoError(scheduler.Submit(mockTask))
+	}
+	idleKey := weightedRoundRobinChannelKey{priority: 0, group: "idle-group"}
+	busyKey := weightedRoundRobinChannelKey{priority: 0, group: "busy-group"}
+	pendingKey := weightedRoundRobinChannelKey{priority: 0, group: "pending-group"}
+	<-scheduler.taskChs[idleKey]
+	<-scheduler.taskChs[busyKey]
+	idleSince := time.Now().Add(-2 * taskGroupIdleTimeout).UnixNano()
+	scheduler.lastSubmitTimes[idleKey].Store(idleSince)
+	scheduler.lastSubmitTimes[pendingKey].Store(idleSince)
+
+	scheduler.evictIdleTaskGroups()
+
+	// only the group which is empty and has been idle is evicted
+	s.Len(scheduler.taskChs, 2)
+	s.NotContains(scheduler.taskChs, idleKey)
+	s.Len(scheduler.lastSubmitTimes, 2)
+	s.NotContains(scheduler.getGroupConfigs(), "idle-group")
+	s.Contains(scheduler.getGroupConfigs(), "busy-group")
+	s.Contains(scheduler.getGroupConfigs(), "pending-group")
+	s.Len(scheduler.rateLimiters, 2)
+	s.NotNil(scheduler.getRateLimiter("busy-group"))
+
+	// task submitted to an evicted channel is moved to the new channel
+	evictedTaskCh := make(chan PriorityTask, 1)
+	mockTask := NewMockPriorityTask(s.controller)
+	mockTask.EXPECT().Priority().Return(0)
+	taskGroups[mockTask] = "idle-group"
+	evictedTaskCh <- mockTask
+	scheduler.resubmitIfEvicted(idleKey, evictedTaskCh)
+	s.Len(evictedTaskCh, 0)
+	s.Len(scheduler.taskChs[idleKey], 1)
+	s.Contains(scheduler.getGroupConfigs(), "idle-group")
+}
+
+func (s *weightedRoundRobinTaskSchedulerSuite) TestReserveToken() {
+	rateLimiter := quotas.NewSimpleRateLimiter(1)
+	s.Zero(reserveToken(rateLimiter))
+
+	delay := reserveToken(rateLimiter)
+	s.True(delay > 0 && delay <= time.Second)
+	// a throttled reservation doesn't consume the token
+	s.Equal(delay.Round(100*time.Millisecond), reserveToken(rateLimiter).Round(100*time.Millisecond))
+}
+
 func (s *weightedRoundRobinTaskSchedulerSuite) newTestWeightedRoundRobinTaskScheduler(
 	options *WeightedRoundRobinTaskSchedulerOptions,
 ) *weightedRoundRobinTaskSchedulerImpl {
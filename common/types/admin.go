@@ -20,7 +20,12 @@
 
 package types
 
-import "sort"
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
 
 // AddSearchAttributeRequest is an internal type (TBD...)
 type AddSearchAttributeRequest struct {
@@ -185,6 +190,159 @@ func (v *GetWorkflowExecutionRawHistoryV2Response) GetVersionHistory() (o *Versi
 	return
 }
 
+// NDCConflictResolutionRecord is an internal type (TBD...)
+type NDCConflictResolutionRecord struct {
+	Type              NDCConflictResolutionType `json:"type"`
+	DomainID          string                    `json:"domainID,omitempty"`
+	WorkflowID        string                    `json:"workflowID,omitempty"`
+	RunID             string                    `json:"runID,omitempty"`
+	Timestamp         int64                     `json:"timestamp,omitempty"`
+	VersionHistories  *VersionHistories         `json:"versionHistories,omitempty"`
+	SourceBranchIndex int32                     `json:"sourceBranchIndex"`
+	TargetBranchIndex int32                     `json:"targetBranchIndex"`
+	WinningVersion    int64                     `json:"winningVersion"`
+	WinningCluster    string                    `json:"winningCluster,omitempty"`
+	LosingVersion     int64                     `json:"losingVersion"`
+	LosingCluster     string                    `json:"losingCluster,omitempty"`
+	ReappliedEvents   []*NDCReappliedEvent      `json:"reappliedEvents,omitempty"`
+	ReappliedToRunID  string                    `json:"reappliedToRunID,omitempty"`
+	ReapplyForwarded  bool                      `json:"reapplyForwarded,omitempty"`
+	ReapplySkipped    bool                      `json:"reapplySkipped,omitempty"`
+}
+
+// GetDomainID is an internal getter (TBD...)
+func (v *NDCConflictResolutionRecord) GetDomainID() (o string) {
+	if v != nil {
+		return v.DomainID
+	}
+	return
+}
+
+// GetWorkflowID is an internal getter (TBD...)
+func (v *NDCConflictResolutionRecord) GetWorkflowID() (o string) {
+	if v != nil {
+		return v.WorkflowID
+	}
+	return
+}
+
+// GetRunID is an internal getter (TBD...)
+func (v *NDCConflictResolutionRecord) GetRunID() (o string) {
+	if v != nil {
+		return v.RunID
+	}
+	return
+}
+
+// NDCReappliedEvent is an internal type (TBD...)
+type NDCReappliedEvent struct {
+	EventID   int64      `json:"eventID,omitempty"`
+	Version   int64      `json:"version,omitempty"`
+	EventType *EventType `json:"eventType,omitempty"`
+}
+
+// NDCConflictResolutionType is an internal type (TBD...)
+type NDCConflictResolutionType int32
+
+// Ptr is a helper function for getting pointer value
+func (e NDCConflictResolutionType) Ptr() *NDCConflictResolutionType {
+	return &e
+}
+
+// String returns a readable string representation of NDCConflictResolutionType.
+func (e NDCConflictResolutionType) String() string {
+	w := int32(e)
+	switch w {
+	case 0:
+		return "BranchSwitch"
+	case 1:
+		return "EventsReapply"
+	}
+	return fmt.Sprintf("NDCConflictResolutionType(%d)", w)
+}
+
+// UnmarshalText parses enum value from string representation
+func (e *NDCConflictResolutionType) UnmarshalText(value []byte) error {
+	switch s := strings.ToUpper(string(value)); s {
+	case "BRANCHSWITCH":
+		*e = NDCConflictResolutionTypeBranchSwitch
+		return nil
+	case "EVENTSREAPPLY":
+		*e = NDCConflictResolutionTypeEventsReapply
+		return nil
+	default:
+		val, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", s, "NDCConflictResolutionType", err)
+		}
+		*e = NDCConflictResolutionType(val)
+		return nil
+	}
+}
+
+// MarshalText encodes NDCConflictResolutionType to text.
+func (e NDCConflictResolutionType) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+const (
+	// NDCConflictResolutionTypeBranchSwitch is an option for NDCConflictResolutionType
+	NDCConflictResolutionTypeBranchSwitch NDCConflictResolutionType = iota
+	// NDCConflictResolutionTypeEventsReapply is an option for NDCConflictResolutionType
+	NDCConflictResolutionTypeEventsReapply
+)
+
+// AbortGracefulFailoverRequest is an internal type (TBD...)
+type AbortGracefulFailoverRequest struct {
+	Domain string `json:"domain,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func (v *AbortGracefulFailoverRequest) SerializeForLogging() (string, error) {
+	if v == nil {
+		return "", nil
+	}
+	return SerializeRequest(v)
+}
+
+// GetDomain is an internal getter (TBD...)
+func (v *AbortGracefulFailoverRequest) GetDomain() (o string) {
+	if v != nil {
+		return v.Domain
+	}
+	return
+}
+
+// GetReason is an internal getter (TBD...)
+func (v *AbortGracefulFailoverRequest) GetReason() (o string) {
+	if v != nil {
+		return v.Reason
+	}
+	return
+}
+
+// AbortGracefulFailoverResponse is an internal type (TBD...)
+type AbortGracefulFailoverResponse struct {
+	ActiveClusterName string `json:"activeClusterName,omitempty"`
+	FailoverVersion   int64  `json:"failoverVersion,omitempty"`
+}
+
+// GetActiveClusterName is an internal getter (TBD...)
+func (v *AbortGracefulFailoverResponse) GetActiveClusterName() (o string) {
+	if v != nil {
+		return v.ActiveClusterName
+	}
+	return
+}
+
+// GetFailoverVersion is an internal getter (TBD...)
+func (v *AbortGracefulFailoverResponse) GetFailoverVersion() (o int64) {
+	if v != nil {
+		return v.FailoverVersion
+	}
+	return
+}
+
 // HostInfo is an internal type (TBD...)
 type HostInfo struct {
 	Identity string `json:"Identity,omitempty"`
@@ -20,6 +20,8 @@
 
 package types
 
+import "sort"
+
 // DescribeMutableStateRequest is an internal type (TBD...)
 type DescribeMutableStateRequest struct {
 	DomainUUID string             `json:"domainUUID,omitempty"`
@@ -335,6 +337,7 @@ type ProcessingQueueState struct {
 	AckLevel     *int64        `json:"ackLevel,omitempty"`
 	MaxLevel     *int64        `json:"maxLevel,omitempty"`
 	DomainFilter *DomainFilter `json:"domainFilter,omitempty"`
+	ReadLevel    *int64        `json:"readLevel,omitempty"`
 }
 
 // GetLevel is an internal getter (TBD...)
@@ -369,6 +372,14 @@ func (v *ProcessingQueueState) GetDomainFilter() (o *DomainFilter) {
 	return
 }
 
+// GetReadLevel is an internal getter (TBD...)
+func (v *ProcessingQueueState) GetReadLevel() (o int64) {
+	if v != nil && v.ReadLevel != nil {
+		return *v.ReadLevel
+	}
+	return
+}
+
 // ProcessingQueueStates is an internal type (TBD...)
 type ProcessingQueueStates struct {
 	StatesByCluster map[string][]*ProcessingQueueState `json:"statesByCluster,omitempty"`
@@ -622,6 +633,157 @@ func (v *HistoryRefreshWorkflowTasksRequest) GetRequest() (o *RefreshWorkflowTas
 	return
 }
 
+// VerifyWorkflowExecutionRequest is an internal type (TBD...)
+type VerifyWorkflowExecutionRequest struct {
+	DomainUUID string             `json:"domainUUID,omitempty"`
+	Execution  *WorkflowExecution `json:"execution,omitempty"`
+	Repair     bool               `json:"repair,omitempty"`
+}
+
+// GetDomainUUID is an internal getter (TBD...)
+func (v *VerifyWorkflowExecutionRequest) GetDomainUUID() (o string) {
+	if v != nil {
+		return v.DomainUUID
+	}
+	return
+}
+
+// GetExecution is an internal getter (TBD...)
+func (v *VerifyWorkflowExecutionRequest) GetExecution() (o *WorkflowExecution) {
+	if v != nil && v.Execution != nil {
+		return v.Execution
+	}
+	return
+}
+
+// GetRepair is an internal getter (TBD...)
+func (v *VerifyWorkflowExecutionRequest) GetRepair() (o bool) {
+	if v != nil {
+		return v.Repair
+	}
+	return
+}
+
+// VerifyWorkflowExecutionResponse is an internal type (TBD...)
+type VerifyWorkflowExecutionResponse struct {
+	Discrepancies []*MutableStateDiscrepancy `json:"discrepancies,omitempty"`
+	Repaired      bool                       `json:"repaired,omitempty"`
+}
+
+// GetDiscrepancies is an internal getter (TBD...)
+func (v *VerifyWorkflowExecutionResponse) GetDiscrepancies() (o []*MutableStateDiscrepancy) {
+	if v != nil && v.Discrepancies != nil {
+		return v.Discrepancies
+	}
+	return
+}
+
+// MutableStateDiscrepancy is an internal type (TBD...)
+type MutableStateDiscrepancy struct {
+	Type      string `json:"type,omitempty"`
+	ID        string `json:"id,omitempty"`
+	Persisted string `json:"persisted,omitempty"`
+	Rebuilt   string `json:"rebuilt,omitempty"`
+}
+
+// DescribeReplicationStatusRequest is an internal type (TBD...)
+type DescribeReplicationStatusRequest struct {
+	ClusterName string `json:"clusterName,omitempty"`
+	DomainUUID  string `json:"domainUUID,omitempty"`
+}
+
+// GetClusterName is an internal getter (TBD...)
+func (v *DescribeReplicationStatusRequest) GetClusterName() (o string) {
+	if v != nil {
+		return v.ClusterName
+	}
+	return
+}
+
+// GetDomainUUID is an internal getter (TBD...)
+func (v *DescribeReplicationStatusRequest) GetDomainUUID() (o string) {
+	if v != nil {
+		return v.DomainUUID
+	}
+	return
+}
+
+// DescribeReplicationStatusResponse is an internal type (TBD...)
+type DescribeReplicationStatusResponse struct {
+	Clusters []*ClusterReplicationStatus `json:"clusters,omitempty"`
+}
+
+// GetClusters is an internal getter (TBD...)
+func (v *DescribeReplicationStatusResponse) GetClusters() (o []*ClusterReplicationStatus) {
+	if v != nil && v.Clusters != nil {
+		return v.Clusters
+	}
+	return
+}
+
+// ClusterReplicationStatus is an internal type (TBD...)
+type ClusterReplicationStatus struct {
+	ClusterName string                     `json:"clusterName,omitempty"`
+	Domains     []*DomainReplicationStatus `json:"domains,omitempty"`
+	Incomplete  bool                       `json:"incomplete,omitempty"`
+}
+
+// DomainReplicationStatus is an internal type (TBD...)
+type DomainReplicationStatus struct {
+	DomainID          string `json:"domainID,omitempty"`
+	DomainName        string `json:"domainName,omitempty"`
+	PendingTaskCount  int64  `json:"pendingTaskCount,omitempty"`
+	LagInMilliseconds int64  `json:"lagInMilliseconds,omitempty"`
+}
+
+// MergeDescribeReplicationStatusResponses aggregates the replication status of several shards or hosts:
+// pending tasks are summed up and the largest lag is kept for each domain
+func MergeDescribeReplicationStatusResponses(responses []*DescribeReplicationStatusResponse) *DescribeReplicationStatusResponse {
+	clusters := make(map[string]*ClusterReplicationStatus)
+	domains := make(map[string]map[string]*DomainReplicationStatus)
+	for _, response := range responses {
+		for _, partialStatus := range response.GetClusters() {
+			status, ok := clusters[partialStatus.ClusterName]
+			if !ok {
+				status = &ClusterReplicationStatus{ClusterName: partialStatus.ClusterName}
+				clusters[partialStatus.ClusterName] = status
+				domains[partialStatus.ClusterName] = make(map[string]*DomainReplicationStatus)
+			}
+			status.Incomplete = status.Incomplete || partialStatus.Incomplete
+			for _, partialDomainStatus := range partialStatus.Domains {
+				domainStatus, ok := domains[partialStatus.ClusterName][partialDomainStatus.DomainID]
+				if !ok {
+					domainStatus = &DomainReplicationStatus{
+						DomainID:   partialDomainStatus.DomainID,
+						DomainName: partialDomainStatus.DomainName,
+					}
+					domains[partialStatus.ClusterName][partialDomainStatus.DomainID] = domainStatus
+					status.Domains = append(status.Domains, domainStatus)
+				}
+				domainStatus.PendingTaskCount += partialDomainStatus.PendingTaskCount
+				if partialDomainStatus.LagInMilliseconds > domainStatus.LagInMilliseconds {
+					domainStatus.LagInMilliseconds = partialDomainStatus.LagInMilliseconds
+				}
+			}
+		}
+	}
+
+	merged := &DescribeReplicationStatusResponse{}
+	for _, status := range clusters {
+		sort.Slice(status.Domains, func(i, j int) bool {
+			if status.Domains[i].LagInMilliseconds != status.Domains[j].LagInMilliseconds {
+				return status.Domains[i].LagInMilliseconds > status.Domains[j].LagInMilliseconds
+			}
+			return status.Domains[i].DomainID < status.Domains[j].DomainID
+		})
+		merged.Clusters = append(merged.Clusters, status)
+	}
+	sort.Slice(merged.Clusters, func(i, j int) bool {
+		return merged.Clusters[i].ClusterName < merged.Clusters[j].ClusterName
+	})
+	return merged
+}
+
 // RemoveSignalMutableStateRequest is an internal type (TBD...)
 type RemoveSignalMutableStateRequest struct {
 	DomainUUID        string             `json:"domainUUID,omitempty"`
@@ -1160,6 +1322,50 @@ func (v *HistoryTerminateWorkflowExecutionRequest) GetChildWorkflowOnly() (o boo
 	return
 }
 
+// HistoryDeleteWorkflowExecutionRequest is an internal type (TBD...)
+type HistoryDeleteWorkflowExecutionRequest struct {
+	DomainUUID    string                          `json:"domainUUID,omitempty"`
+	DeleteRequest *DeleteWorkflowExecutionRequest `json:"deleteRequest,omitempty"`
+}
+
+// GetDomainUUID is an internal getter (TBD...)
+func (v *HistoryDeleteWorkflowExecutionRequest) GetDomainUUID() (o string) {
+	if v != nil {
+		return v.DomainUUID
+	}
+	return
+}
+
+// GetDeleteRequest is an internal getter (TBD...)
+func (v *HistoryDeleteWorkflowExecutionRequest) GetDeleteRequest() (o *DeleteWorkflowExecutionRequest) {
+	if v != nil && v.DeleteRequest != nil {
+		return v.DeleteRequest
+	}
+	return
+}
+
+// HistoryUpdateActivityOptionsRequest is an internal type (TBD...)
+type HistoryUpdateActivityOptionsRequest struct {
+	DomainUUID    string                        `json:"domainUUID,omitempty"`
+	UpdateRequest *UpdateActivityOptionsRequest `json:"updateRequest,omitempty"`
+}
+
+// GetDomainUUID is an internal getter (TBD...)
+func (v *HistoryUpdateActivityOptionsRequest) GetDomainUUID() (o string) {
+	if v != nil {
+		return v.DomainUUID
+	}
+	return
+}
+
+// GetUpdateRequest is an internal getter (TBD...)
+func (v *HistoryUpdateActivityOptionsRequest) GetUpdateRequest() (o *UpdateActivityOptionsRequest) {
+	if v != nil && v.UpdateRequest != nil {
+		return v.UpdateRequest
+	}
+	return
+}
+
 // GetFailoverInfoRequest is an internal type (TBD...)
 type GetFailoverInfoRequest struct {
 	DomainID string `json:"domainID,omitempty"`
@@ -1202,3 +1408,92 @@ type RatelimitUpdateRequest struct {
 type RatelimitUpdateResponse struct {
 	Any *Any `json:"any"`
 }
+
+// MigrateShardExecutionsRequest is an internal type (TBD...)
+type MigrateShardExecutionsRequest struct {
+	ShardID          int32  `json:"shardID,omitempty"`
+	SourceShardID    int32  `json:"sourceShardID,omitempty"`
+	ClosedOnly       bool   `json:"closedOnly,omitempty"`
+	DeleteFromSource bool   `json:"deleteFromSource,omitempty"`
+	PageSize         int32  `json:"pageSize,omitempty"`
+	NextPageToken    []byte `json:"nextPageToken,omitempty"`
+}
+
+// GetShardID is an internal getter (TBD...)
+func (v *MigrateShardExecutionsRequest) GetShardID() (o int32) {
+	if v != nil {
+		return v.ShardID
+	}
+	return
+}
+
+// GetSourceShardID is an internal getter (TBD...)
+func (v *MigrateShardExecutionsRequest) GetSourceShardID() (o int32) {
+	if v != nil {
+		return v.SourceShardID
+	}
+	return
+}
+
+// GetClosedOnly is an internal getter (TBD...)
+func (v *MigrateShardExecutionsRequest) GetClosedOnly() (o bool) {
+	if v != nil {
+		return v.ClosedOnly
+	}
+	return
+}
+
+// GetDeleteFromSource is an internal getter (TBD...)
+func (v *MigrateShardExecutionsRequest) GetDeleteFromSource() (o bool) {
+	if v != nil {
+		return v.DeleteFromSource
+	}
+	return
+}
+
+// GetPageSize is an internal getter (TBD...)
+func (v *MigrateShardExecutionsRequest) GetPageSize() (o int32) {
+	if v != nil {
+		return v.PageSize
+	}
+	return
+}
+
+// GetNextPageToken is an internal getter (TBD...)
+func (v *MigrateShardExecutionsRequest) GetNextPageToken() (o []byte) {
+	if v != nil {
+		return v.NextPageToken
+	}
+	return
+}
+
+// MigrateShardExecutionsResponse is an internal type (TBD...)
+type MigrateShardExecutionsResponse struct {
+	NextPageToken []byte `json:"nextPageToken,omitempty"`
+	CopiedCount   int32  `json:"copiedCount,omitempty"`
+	DeletedCount  int32  `json:"deletedCount,omitempty"`
+}
+
+// GetNextPageToken is an internal getter (TBD...)
+func (v *MigrateShardExecutionsResponse) GetNextPageToken() (o []byte) {
+	if v != nil {
+		return v.NextPageToken
+	}
+	return
+}
+
+// GetCopiedCount is an internal getter (TBD...)
+func (v *MigrateShardExecutionsResponse) GetCopiedCount() (o int32) {
+	if v != nil {
+		return v.CopiedCount
+	}
+	return
+}
+
+// GetDeletedCount is an internal getter (TBD...)
+func (v *MigrateShardExecutionsResponse) GetDeletedCount() (o int32) {
+	if v != nil {
+		return v.DeletedCount
+	}
+	return
+}
@@ -0,0 +1,73 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeDescribeReplicationStatusResponses(t *testing.T) {
+	merged := MergeDescribeReplicationStatusResponses([]*DescribeReplicationStatusResponse{
+		{
+			Clusters: []*ClusterReplicationStatus{
+				{
+					ClusterName: "cluster2",
+					Domains: []*DomainReplicationStatus{
+						{DomainID: "domain1", DomainName: "domain-name-1", PendingTaskCount: 2, LagInMilliseconds: 100},
+					},
+				},
+			},
+		},
+		{
+			Clusters: []*ClusterReplicationStatus{
+				{
+					ClusterName: "cluster2",
+					Domains: []*DomainReplicationStatus{
+						{DomainID: "domain2", DomainName: "domain-name-2", PendingTaskCount: 1, LagInMilliseconds: 50},
+						{DomainID: "domain1", DomainName: "domain-name-1", PendingTaskCount: 3, LagInMilliseconds: 20},
+					},
+					Incomplete: true,
+				},
+				{ClusterName: "cluster1"},
+			},
+		},
+		nil,
+	})
+	assert.Equal(t, &DescribeReplicationStatusResponse{
+		Clusters: []*ClusterReplicationStatus{
+			{ClusterName: "cluster1"},
+			{
+				ClusterName: "cluster2",
+				Domains: []*DomainReplicationStatus{
+					{DomainID: "domain1", DomainName: "domain-name-1", PendingTaskCount: 5, LagInMilliseconds: 100},
+					{DomainID: "domain2", DomainName: "domain-name-2", PendingTaskCount: 1, LagInMilliseconds: 50},
+				},
+				Incomplete: true,
+			},
+		},
+	}, merged)
+
+	assert.Equal(t, &DescribeReplicationStatusResponse{}, MergeDescribeReplicationStatusResponses(nil))
+}
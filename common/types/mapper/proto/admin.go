@@ -26,6 +26,7 @@ import (
 	adminv1 "github.com/uber/cadence-idl/go/proto/admin/v1"
 	apiv1 "github.com/uber/cadence-idl/go/proto/api/v1"
 
+	historyv1 "github.com/uber/cadence/.gen/proto/history/v1"
 	"github.com/uber/cadence/common/types"
 )
 
@@ -166,9 +167,40 @@ func FromAdminDescribeHistoryHostResponse(t *types.DescribeHistoryHostResponse)
 		DomainCache:           FromDomainCacheInfo(t.DomainCache),
 		ShardControllerStatus: t.ShardControllerStatus,
 		Address:               t.Address,
+		XXX_unrecognized:      fromAdminHistoryHostStats(t),
 	}
 }
 
+// The admin proto does not define the cache and load stats of a history host yet.
+// They are carried as unknown fields, using the field numbers of the history proto response.
+func fromAdminHistoryHostStats(t *types.DescribeHistoryHostResponse) []byte {
+	if t.ExecutionCache == nil && t.EventsCache == nil && t.Load == nil {
+		return nil
+	}
+	data, err := (&historyv1.DescribeHistoryHostResponse{
+		ExecutionCache: FromHistoryCacheInfo(t.ExecutionCache),
+		EventsCache:    FromHistoryCacheInfo(t.EventsCache),
+		Load:           FromHistoryHostLoadInfo(t.Load),
+	}).Marshal()
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func toAdminHistoryHostStats(data []byte, t *types.DescribeHistoryHostResponse) {
+	if len(data) == 0 {
+		return
+	}
+	var stats historyv1.DescribeHistoryHostResponse
+	if err := stats.Unmarshal(data); err != nil {
+		return
+	}
+	t.ExecutionCache = ToHistoryCacheInfo(stats.ExecutionCache)
+	t.EventsCache = ToHistoryCacheInfo(stats.EventsCache)
+	t.Load = ToHistoryHostLoadInfo(stats.Load)
+}
+
 func ToAdminDescribeShardDistributionResponse(t *adminv1.DescribeShardDistributionResponse) *types.DescribeShardDistributionResponse {
 	if t == nil {
 		return nil
@@ -183,13 +215,15 @@ func ToAdminDescribeHistoryHostResponse(t *adminv1.DescribeHistoryHostResponse)
 	if t == nil {
 		return nil
 	}
-	return &types.DescribeHistoryHostResponse{
+	resp := &types.DescribeHistoryHostResponse{
 		NumberOfShards:        t.NumberOfShards,
 		ShardIDs:              t.ShardIds,
 		DomainCache:           ToDomainCacheInfo(t.DomainCache),
 		ShardControllerStatus: t.ShardControllerStatus,
 		Address:               t.Address,
 	}
+	toAdminHistoryHostStats(t.XXX_unrecognized, resp)
+	return resp
 }
 
 func FromAdminDescribeQueueRequest(t *types.DescribeQueueRequest) *adminv1.DescribeQueueRequest {
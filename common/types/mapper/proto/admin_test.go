@@ -25,6 +25,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	adminv1 "github.com/uber/cadence-idl/go/proto/admin/v1"
 	v1 "github.com/uber/cadence-idl/go/proto/api/v1"
 
@@ -64,6 +65,13 @@ func TestAdminDescribeHistoryHostResponse(t *testing.T) {
 		assert.Equal(t, item, ToAdminDescribeHistoryHostResponse(FromAdminDescribeHistoryHostResponse(item)))
 	}
 }
+func TestAdminDescribeHistoryHostResponse_Wire(t *testing.T) {
+	data, err := FromAdminDescribeHistoryHostResponse(&testdata.AdminDescribeHistoryHostResponse).Marshal()
+	require.NoError(t, err)
+	var decoded adminv1.DescribeHistoryHostResponse
+	require.NoError(t, decoded.Unmarshal(data))
+	assert.Equal(t, &testdata.AdminDescribeHistoryHostResponse, ToAdminDescribeHistoryHostResponse(&decoded))
+}
 func TestAdminDescribeQueueRequest(t *testing.T) {
 	for _, item := range []*types.DescribeQueueRequest{nil, {}, &testdata.AdminDescribeQueueRequest} {
 		assert.Equal(t, item, ToAdminDescribeQueueRequest(FromAdminDescribeQueueRequest(item)))
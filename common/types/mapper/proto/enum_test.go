@@ -27,6 +27,7 @@ import (
 	adminv1 "github.com/uber/cadence-idl/go/proto/admin/v1"
 	apiv1 "github.com/uber/cadence-idl/go/proto/api/v1"
 
+	historyv1 "github.com/uber/cadence/.gen/proto/history/v1"
 	sharedv1 "github.com/uber/cadence/.gen/proto/shared/v1"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/persistence"
@@ -54,6 +55,7 @@ func TestDLQType(t *testing.T) {
 	} {
 		assert.Equal(t, item, ToDLQType(FromDLQType(item)))
 	}
+	assert.Equal(t, adminv1.DLQType_DLQ_TYPE_INVALID, FromDLQType(types.DLQTypeHistoryTask.Ptr()))
 	assert.Panics(t, func() { ToDLQType(adminv1.DLQType(UnknownValue)) })
 	assert.Panics(t, func() { FromDLQType(types.DLQType(UnknownValue).Ptr()) })
 }
@@ -381,6 +383,17 @@ func TestWorkflowIDReusePolicy(t *testing.T) {
 	assert.Panics(t, func() { ToWorkflowIDReusePolicy(apiv1.WorkflowIdReusePolicy(UnknownValue)) })
 	assert.Panics(t, func() { FromWorkflowIDReusePolicy(types.WorkflowIDReusePolicy(UnknownValue).Ptr()) })
 }
+func TestNoProgressTimeoutPolicy(t *testing.T) {
+	for _, item := range []*types.NoProgressTimeoutPolicy{
+		nil,
+		types.NoProgressTimeoutPolicyNotify.Ptr(),
+		types.NoProgressTimeoutPolicyFail.Ptr(),
+	} {
+		assert.Equal(t, item, ToNoProgressTimeoutPolicy(FromNoProgressTimeoutPolicy(item)))
+	}
+	assert.Panics(t, func() { ToNoProgressTimeoutPolicy(historyv1.NoProgressTimeoutPolicy(UnknownValue)) })
+	assert.Panics(t, func() { FromNoProgressTimeoutPolicy(types.NoProgressTimeoutPolicy(UnknownValue).Ptr()) })
+}
 func TestWorkflowState(t *testing.T) {
 	for _, item := range []*int32{
 		nil,
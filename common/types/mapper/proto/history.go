@@ -72,6 +72,9 @@ func FromHistoryDescribeHistoryHostResponse(t *types.DescribeHistoryHostResponse
 		DomainCache:           FromDomainCacheInfo(t.DomainCache),
 		ShardControllerStatus: t.ShardControllerStatus,
 		Address:               t.Address,
+		ExecutionCache:        FromHistoryCacheInfo(t.ExecutionCache),
+		EventsCache:           FromHistoryCacheInfo(t.EventsCache),
+		Load:                  FromHistoryHostLoadInfo(t.Load),
 	}
 }
 
@@ -85,9 +88,156 @@ func ToHistoryDescribeHistoryHostResponse(t *historyv1.DescribeHistoryHostRespon
 		DomainCache:           ToDomainCacheInfo(t.DomainCache),
 		ShardControllerStatus: t.ShardControllerStatus,
 		Address:               t.Address,
+		ExecutionCache:        ToHistoryCacheInfo(t.ExecutionCache),
+		EventsCache:           ToHistoryCacheInfo(t.EventsCache),
+		Load:                  ToHistoryHostLoadInfo(t.Load),
 	}
 }
 
+func FromHistoryCacheInfo(t *types.CacheInfo) *historyv1.CacheInfo {
+	if t == nil {
+		return nil
+	}
+	return &historyv1.CacheInfo{
+		NumOfItems:       t.NumOfItems,
+		NumOfPinnedItems: t.NumOfPinnedItems,
+		SizeInBytes:      t.SizeInBytes,
+		NumOfHits:        t.NumOfHits,
+		NumOfMisses:      t.NumOfMisses,
+	}
+}
+
+func ToHistoryCacheInfo(t *historyv1.CacheInfo) *types.CacheInfo {
+	if t == nil {
+		return nil
+	}
+	return &types.CacheInfo{
+		NumOfItems:       t.NumOfItems,
+		NumOfPinnedItems: t.NumOfPinnedItems,
+		SizeInBytes:      t.SizeInBytes,
+		NumOfHits:        t.NumOfHits,
+		NumOfMisses:      t.NumOfMisses,
+	}
+}
+
+func FromHistoryHostLoadInfo(t *types.HostLoadInfo) *historyv1.HostLoadInfo {
+	if t == nil {
+		return nil
+	}
+	return &historyv1.HostLoadInfo{
+		RequestsPerSecond: t.RequestsPerSecond,
+		CpuUtilization:    t.CPUUtilization,
+		PendingTasks:      t.PendingTasks,
+		Overloaded:        t.Overloaded,
+		HotShards:         FromHistoryShardLoadInfoArray(t.HotShards),
+		HotWorkflows:      FromHistoryWorkflowLoadInfoArray(t.HotWorkflows),
+	}
+}
+
+func ToHistoryHostLoadInfo(t *historyv1.HostLoadInfo) *types.HostLoadInfo {
+	if t == nil {
+		return nil
+	}
+	return &types.HostLoadInfo{
+		RequestsPerSecond: t.RequestsPerSecond,
+		CPUUtilization:    t.CpuUtilization,
+		PendingTasks:      t.PendingTasks,
+		Overloaded:        t.Overloaded,
+		HotShards:         ToHistoryShardLoadInfoArray(t.HotShards),
+		HotWorkflows:      ToHistoryWorkflowLoadInfoArray(t.HotWorkflows),
+	}
+}
+
+func FromHistoryShardLoadInfo(t *types.ShardLoadInfo) *historyv1.ShardLoadInfo {
+	if t == nil {
+		return nil
+	}
+	return &historyv1.ShardLoadInfo{
+		ShardId:           t.ShardID,
+		RequestsPerSecond: t.RequestsPerSecond,
+		PendingTasks:      t.PendingTasks,
+	}
+}
+
+func ToHistoryShardLoadInfo(t *historyv1.ShardLoadInfo) *types.ShardLoadInfo {
+	if t == nil {
+		return nil
+	}
+	return &types.ShardLoadInfo{
+		ShardID:           t.ShardId,
+		RequestsPerSecond: t.RequestsPerSecond,
+		PendingTasks:      t.PendingTasks,
+	}
+}
+
+func FromHistoryShardLoadInfoArray(t []*types.ShardLoadInfo) []*historyv1.ShardLoadInfo {
+	if t == nil {
+		return nil
+	}
+	v := make([]*historyv1.ShardLoadInfo, len(t))
+	for i := range t {
+		v[i] = FromHistoryShardLoadInfo(t[i])
+	}
+	return v
+}
+
+func ToHistoryShardLoadInfoArray(t []*historyv1.ShardLoadInfo) []*types.ShardLoadInfo {
+	if t == nil {
+		return nil
+	}
+	v := make([]*types.ShardLoadInfo, len(t))
+	for i := range t {
+		v[i] = ToHistoryShardLoadInfo(t[i])
+	}
+	return v
+}
+
+func FromHistoryWorkflowLoadInfo(t *types.WorkflowLoadInfo) *historyv1.WorkflowLoadInfo {
+	if t == nil {
+		return nil
+	}
+	return &historyv1.WorkflowLoadInfo{
+		DomainId:          t.DomainID,
+		WorkflowId:        t.WorkflowID,
+		ShardId:           t.ShardID,
+		RequestsPerSecond: t.RequestsPerSecond,
+	}
+}
+
+func ToHistoryWorkflowLoadInfo(t *historyv1.WorkflowLoadInfo) *types.WorkflowLoadInfo {
+	if t == nil {
+		return nil
+	}
+	return &types.WorkflowLoadInfo{
+		DomainID:          t.DomainId,
+		WorkflowID:        t.WorkflowId,
+		ShardID:           t.ShardId,
+		RequestsPerSecond: t.RequestsPerSecond,
+	}
+}
+
+func FromHistoryWorkflowLoadInfoArray(t []*types.WorkflowLoadInfo) []*historyv1.WorkflowLoadInfo {
+	if t == nil {
+		return nil
+	}
+	v := make([]*historyv1.WorkflowLoadInfo, len(t))
+	for i := range t {
+		v[i] = FromHistoryWorkflowLoadInfo(t[i])
+	}
+	return v
+}
+
+func ToHistoryWorkflowLoadInfoArray(t []*historyv1.WorkflowLoadInfo) []*types.WorkflowLoadInfo {
+	if t == nil {
+		return nil
+	}
+	v := make([]*types.WorkflowLoadInfo, len(t))
+	for i := range t {
+		v[i] = ToHistoryWorkflowLoadInfo(t[i])
+	}
+	return v
+}
+
 func FromHistoryDescribeMutableStateRequest(t *types.DescribeMutableStateRequest) *historyv1.DescribeMutableStateRequest {
 	if t == nil {
 		return nil
@@ -168,6 +318,138 @@ func ToHistoryDescribeQueueResponse(t *historyv1.DescribeQueueResponse) *types.D
 	}
 }
 
+func FromHistoryListQueueTasksRequest(t *types.ListQueueTasksRequest) *historyv1.ListQueueTasksRequest {
+	if t == nil {
+		return nil
+	}
+	return &historyv1.ListQueueTasksRequest{
+		ShardId:           t.ShardID,
+		ClusterName:       t.ClusterName,
+		TaskType:          FromTaskType(t.Type),
+		MinTaskId:         fromInt64Value(t.MinTaskID),
+		MaxTaskId:         fromInt64Value(t.MaxTaskID),
+		MinVisibilityTime: unixNanoToTime(t.MinVisibilityTimestamp),
+		MaxVisibilityTime: unixNanoToTime(t.MaxVisibilityTimestamp),
+		PageSize:          t.PageSize,
+	}
+}
+
+func ToHistoryListQueueTasksRequest(t *historyv1.ListQueueTasksRequest) *types.ListQueueTasksRequest {
+	if t == nil {
+		return nil
+	}
+	return &types.ListQueueTasksRequest{
+		ShardID:                t.ShardId,
+		ClusterName:            t.ClusterName,
+		Type:                   ToTaskType(t.TaskType),
+		MinTaskID:              toInt64Value(t.MinTaskId),
+		MaxTaskID:              toInt64Value(t.MaxTaskId),
+		MinVisibilityTimestamp: timeToUnixNano(t.MinVisibilityTime),
+		MaxVisibilityTimestamp: timeToUnixNano(t.MaxVisibilityTime),
+		PageSize:               t.PageSize,
+	}
+}
+
+func FromHistoryListQueueTasksResponse(t *types.ListQueueTasksResponse) *historyv1.ListQueueTasksResponse {
+	if t == nil {
+		return nil
+	}
+	return &historyv1.ListQueueTasksResponse{
+		Tasks: FromHistoryQueueTaskInfoArray(t.Tasks),
+	}
+}
+
+func ToHistoryListQueueTasksResponse(t *historyv1.ListQueueTasksResponse) *types.ListQueueTasksResponse {
+	if t == nil {
+		return nil
+	}
+	return &types.ListQueueTasksResponse{
+		Tasks: ToHistoryQueueTaskInfoArray(t.Tasks),
+	}
+}
+
+func FromHistoryQueueTaskInfo(t *types.QueueTaskInfo) *historyv1.QueueTaskInfo {
+	if t == nil {
+		return nil
+	}
+	return &historyv1.QueueTaskInfo{
+		DomainId:       t.DomainID,
+		WorkflowId:     t.WorkflowID,
+		RunId:          t.RunID,
+		TaskId:         t.TaskID,
+		TaskType:       t.TaskType,
+		VisibilityTime: unixNanoToTime(&t.VisibilityTimestamp),
+		Version:        t.Version,
+		State:          t.State,
+		Attempt:        t.Attempt,
+		LastError:      t.LastError,
+	}
+}
+
+func ToHistoryQueueTaskInfo(t *historyv1.QueueTaskInfo) *types.QueueTaskInfo {
+	if t == nil {
+		return nil
+	}
+	return &types.QueueTaskInfo{
+		DomainID:            t.DomainId,
+		WorkflowID:          t.WorkflowId,
+		RunID:               t.RunId,
+		TaskID:              t.TaskId,
+		TaskType:            t.TaskType,
+		VisibilityTimestamp: common.Int64Default(timeToUnixNano(t.VisibilityTime)),
+		Version:             t.Version,
+		State:               t.State,
+		Attempt:             t.Attempt,
+		LastError:           t.LastError,
+	}
+}
+
+func FromHistoryQueueTaskInfoArray(t []*types.QueueTaskInfo) []*historyv1.QueueTaskInfo {
+	if t == nil {
+		return nil
+	}
+	v := make([]*historyv1.QueueTaskInfo, len(t))
+	for i := range t {
+		v[i] = FromHistoryQueueTaskInfo(t[i])
+	}
+	return v
+}
+
+func ToHistoryQueueTaskInfoArray(t []*historyv1.QueueTaskInfo) []*types.QueueTaskInfo {
+	if t == nil {
+		return nil
+	}
+	v := make([]*types.QueueTaskInfo, len(t))
+	for i := range t {
+		v[i] = ToHistoryQueueTaskInfo(t[i])
+	}
+	return v
+}
+
+func FromHistoryExecuteQueueTaskRequest(t *types.ExecuteQueueTaskRequest) *historyv1.ExecuteQueueTaskRequest {
+	if t == nil {
+		return nil
+	}
+	return &historyv1.ExecuteQueueTaskRequest{
+		ShardId:     t.ShardID,
+		ClusterName: t.ClusterName,
+		TaskType:    FromTaskType(t.Type),
+		TaskId:      t.TaskID,
+	}
+}
+
+func ToHistoryExecuteQueueTaskRequest(t *historyv1.ExecuteQueueTaskRequest) *types.ExecuteQueueTaskRequest {
+	if t == nil {
+		return nil
+	}
+	return &types.ExecuteQueueTaskRequest{
+		ShardID:     t.ShardId,
+		ClusterName: t.ClusterName,
+		Type:        ToTaskType(t.TaskType),
+		TaskID:      t.TaskId,
+	}
+}
+
 func FromHistoryDescribeWorkflowExecutionRequest(t *types.HistoryDescribeWorkflowExecutionRequest) *historyv1.DescribeWorkflowExecutionRequest {
 	if t == nil {
 		return nil
@@ -198,6 +480,7 @@ func FromHistoryDescribeWorkflowExecutionResponse(t *types.DescribeWorkflowExecu
 		PendingActivities:      FromPendingActivityInfoArray(t.PendingActivities),
 		PendingChildren:        FromPendingChildExecutionInfoArray(t.PendingChildren),
 		PendingDecision:        FromPendingDecisionInfo(t.PendingDecision),
+		WorkflowSizeLimits:     FromWorkflowSizeLimits(t.WorkflowSizeLimits),
 	}
 }
 
@@ -211,6 +494,35 @@ func ToHistoryDescribeWorkflowExecutionResponse(t *historyv1.DescribeWorkflowExe
 		PendingActivities:      ToPendingActivityInfoArray(t.PendingActivities),
 		PendingChildren:        ToPendingChildExecutionInfoArray(t.PendingChildren),
 		PendingDecision:        ToPendingDecisionInfo(t.PendingDecision),
+		WorkflowSizeLimits:     ToWorkflowSizeLimits(t.WorkflowSizeLimits),
+	}
+}
+
+func FromWorkflowSizeLimits(t *types.WorkflowSizeLimits) *historyv1.WorkflowSizeLimits {
+	if t == nil {
+		return nil
+	}
+	return &historyv1.WorkflowSizeLimits{
+		BlobSizeLimitWarn:      t.BlobSizeLimitWarn,
+		BlobSizeLimitError:     t.BlobSizeLimitError,
+		HistorySizeLimitWarn:   t.HistorySizeLimitWarn,
+		HistorySizeLimitError:  t.HistorySizeLimitError,
+		HistoryCountLimitWarn:  t.HistoryCountLimitWarn,
+		HistoryCountLimitError: t.HistoryCountLimitError,
+	}
+}
+
+func ToWorkflowSizeLimits(t *historyv1.WorkflowSizeLimits) *types.WorkflowSizeLimits {
+	if t == nil {
+		return nil
+	}
+	return &types.WorkflowSizeLimits{
+		BlobSizeLimitWarn:      t.BlobSizeLimitWarn,
+		BlobSizeLimitError:     t.BlobSizeLimitError,
+		HistorySizeLimitWarn:   t.HistorySizeLimitWarn,
+		HistorySizeLimitError:  t.HistorySizeLimitError,
+		HistoryCountLimitWarn:  t.HistoryCountLimitWarn,
+		HistoryCountLimitError: t.HistoryCountLimitError,
 	}
 }
 
@@ -288,6 +600,272 @@ func ToHistoryGetFailoverInfoResponse(t *historyv1.GetFailoverInfoResponse) *typ
 	}
 }
 
+func FromHistoryMigrateShardExecutionsRequest(t *types.MigrateShardExecutionsRequest) *historyv1.MigrateShardExecutionsRequest {
+	if t == nil {
+		return nil
+	}
+	return &historyv1.MigrateShardExecutionsRequest{
+		ShardId:          t.ShardID,
+		SourceShardId:    t.SourceShardID,
+		ClosedOnly:       t.ClosedOnly,
+		DeleteFromSource: t.DeleteFromSource,
+		PageSize:         t.PageSize,
+		NextPageToken:    t.NextPageToken,
+	}
+}
+
+func ToHistoryMigrateShardExecutionsRequest(t *historyv1.MigrateShardExecutionsRequest) *types.MigrateShardExecutionsRequest {
+	if t == nil {
+		return nil
+	}
+	return &types.MigrateShardExecutionsRequest{
+		ShardID:          t.ShardId,
+		SourceShardID:    t.SourceShardId,
+		ClosedOnly:       t.ClosedOnly,
+		DeleteFromSource: t.DeleteFromSource,
+		PageSize:         t.PageSize,
+		NextPageToken:    t.NextPageToken,
+	}
+}
+
+func FromHistoryMigrateShardExecutionsResponse(t *types.MigrateShardExecutionsResponse) *historyv1.MigrateShardExecutionsResponse {
+	if t == nil {
+		return nil
+	}
+	return &historyv1.MigrateShardExecutionsResponse{
+		NextPageToken: t.NextPageToken,
+		CopiedCount:   t.CopiedCount,
+		DeletedCount:  t.DeletedCount,
+	}
+}
+
+func ToHistoryMigrateShardExecutionsResponse(t *historyv1.MigrateShardExecutionsResponse) *types.MigrateShardExecutionsResponse {
+	if t == nil {
+		return nil
+	}
+	return &types.MigrateShardExecutionsResponse{
+		NextPageToken: t.NextPageToken,
+		CopiedCount:   t.CopiedCount,
+		DeletedCount:  t.DeletedCount,
+	}
+}
+
+func FromHistoryVerifyWorkflowExecutionRequest(t *types.VerifyWorkflowExecutionRequest) *historyv1.VerifyWorkflowExecutionRequest {
+	if t == nil {
+		return nil
+	}
+	return &historyv1.VerifyWorkflowExecutionRequest{
+		DomainId:          t.DomainUUID,
+		WorkflowExecution: FromWorkflowExecution(t.Execution),
+		Repair:            t.Repair,
+	}
+}
+
+func ToHistoryVerifyWorkflowExecutionRequest(t *historyv1.VerifyWorkflowExecutionRequest) *types.VerifyWorkflowExecutionRequest {
+	if t == nil {
+		return nil
+	}
+	return &types.VerifyWorkflowExecutionRequest{
+		DomainUUID: t.DomainId,
+		Execution:  ToWorkflowExecution(t.WorkflowExecution),
+		Repair:     t.Repair,
+	}
+}
+
+func FromHistoryVerifyWorkflowExecutionResponse(t *types.VerifyWorkflowExecutionResponse) *historyv1.VerifyWorkflowExecutionResponse {
+	if t == nil {
+		return nil
+	}
+	return &historyv1.VerifyWorkflowExecutionResponse{
+		Discrepancies: FromHistoryMutableStateDiscrepancyArray(t.Discrepancies),
+		Repaired:      t.Repaired,
+	}
+}
+
+func ToHistoryVerifyWorkflowExecutionResponse(t *historyv1.VerifyWorkflowExecutionResponse) *types.VerifyWorkflowExecutionResponse {
+	if t == nil {
+		return nil
+	}
+	return &types.VerifyWorkflowExecutionResponse{
+		Discrepancies: ToHistoryMutableStateDiscrepancyArray(t.Discrepancies),
+		Repaired:      t.Repaired,
+	}
+}
+
+func FromHistoryMutableStateDiscrepancy(t *types.MutableStateDiscrepancy) *historyv1.MutableStateDiscrepancy {
+	if t == nil {
+		return nil
+	}
+	return &historyv1.MutableStateDiscrepancy{
+		Type:      t.Type,
+		Id:        t.ID,
+		Persisted: t.Persisted,
+		Rebuilt:   t.Rebuilt,
+	}
+}
+
+func ToHistoryMutableStateDiscrepancy(t *historyv1.MutableStateDiscrepancy) *types.MutableStateDiscrepancy {
+	if t == nil {
+		return nil
+	}
+	return &types.MutableStateDiscrepancy{
+		Type:      t.Type,
+		ID:        t.Id,
+		Persisted: t.Persisted,
+		Rebuilt:   t.Rebuilt,
+	}
+}
+
+func FromHistoryMutableStateDiscrepancyArray(t []*types.MutableStateDiscrepancy) []*historyv1.MutableStateDiscrepancy {
+	if t == nil {
+		return nil
+	}
+	v := make([]*historyv1.MutableStateDiscrepancy, len(t))
+	for i := range t {
+		v[i] = FromHistoryMutableStateDiscrepancy(t[i])
+	}
+	return v
+}
+
+func ToHistoryMutableStateDiscrepancyArray(t []*historyv1.MutableStateDiscrepancy) []*types.MutableStateDiscrepancy {
+	if t == nil {
+		return nil
+	}
+	v := make([]*types.MutableStateDiscrepancy, len(t))
+	for i := range t {
+		v[i] = ToHistoryMutableStateDiscrepancy(t[i])
+	}
+	return v
+}
+
+func FromHistoryDescribeReplicationStatusRequest(t *types.DescribeReplicationStatusRequest) *historyv1.DescribeReplicationStatusRequest {
+	if t == nil {
+		return nil
+	}
+	return &historyv1.DescribeReplicationStatusRequest{
+		DomainId:    t.DomainUUID,
+		ClusterName: t.ClusterName,
+	}
+}
+
+func ToHistoryDescribeReplicationStatusRequest(t *historyv1.DescribeReplicationStatusRequest) *types.DescribeReplicationStatusRequest {
+	if t == nil {
+		return nil
+	}
+	return &types.DescribeReplicationStatusRequest{
+		DomainUUID:  t.DomainId,
+		ClusterName: t.ClusterName,
+	}
+}
+
+func FromHistoryDescribeReplicationStatusResponse(t *types.DescribeReplicationStatusResponse) *historyv1.DescribeReplicationStatusResponse {
+	if t == nil {
+		return nil
+	}
+	return &historyv1.DescribeReplicationStatusResponse{
+		Clusters: FromHistoryClusterReplicationStatusArray(t.Clusters),
+	}
+}
+
+func ToHistoryDescribeReplicationStatusResponse(t *historyv1.DescribeReplicationStatusResponse) *types.DescribeReplicationStatusResponse {
+	if t == nil {
+		return nil
+	}
+	return &types.DescribeReplicationStatusResponse{
+		Clusters: ToHistoryClusterReplicationStatusArray(t.Clusters),
+	}
+}
+
+func FromHistoryClusterReplicationStatus(t *types.ClusterReplicationStatus) *historyv1.ClusterReplicationStatus {
+	if t == nil {
+		return nil
+	}
+	return &historyv1.ClusterReplicationStatus{
+		ClusterName: t.ClusterName,
+		Domains:     FromHistoryDomainReplicationStatusArray(t.Domains),
+		Incomplete:  t.Incomplete,
+	}
+}
+
+func ToHistoryClusterReplicationStatus(t *historyv1.ClusterReplicationStatus) *types.ClusterReplicationStatus {
+	if t == nil {
+		return nil
+	}
+	return &types.ClusterReplicationStatus{
+		ClusterName: t.ClusterName,
+		Domains:     ToHistoryDomainReplicationStatusArray(t.Domains),
+		Incomplete:  t.Incomplete,
+	}
+}
+
+func FromHistoryClusterReplicationStatusArray(t []*types.ClusterReplicationStatus) []*historyv1.ClusterReplicationStatus {
+	if t == nil {
+		return nil
+	}
+	v := make([]*historyv1.ClusterReplicationStatus, len(t))
+	for i := range t {
+		v[i] = FromHistoryClusterReplicationStatus(t[i])
+	}
+	return v
+}
+
+func ToHistoryClusterReplicationStatusArray(t []*historyv1.ClusterReplicationStatus) []*types.ClusterReplicationStatus {
+	if t == nil {
+		return nil
+	}
+	v := make([]*types.ClusterReplicationStatus, len(t))
+	for i := range t {
+		v[i] = ToHistoryClusterReplicationStatus(t[i])
+	}
+	return v
+}
+
+func FromHistoryDomainReplicationStatus(t *types.DomainReplicationStatus) *historyv1.DomainReplicationStatus {
+	if t == nil {
+		return nil
+	}
+	return &historyv1.DomainReplicationStatus{
+		DomainId:          t.DomainID,
+		DomainName:        t.DomainName,
+		PendingTaskCount:  t.PendingTaskCount,
+		LagInMilliseconds: t.LagInMilliseconds,
+	}
+}
+
+func ToHistoryDomainReplicationStatus(t *historyv1.DomainReplicationStatus) *types.DomainReplicationStatus {
+	if t == nil {
+		return nil
+	}
+	return &types.DomainReplicationStatus{
+		DomainID:          t.DomainId,
+		DomainName:        t.DomainName,
+		PendingTaskCount:  t.PendingTaskCount,
+		LagInMilliseconds: t.LagInMilliseconds,
+	}
+}
+
+func FromHistoryDomainReplicationStatusArray(t []*types.DomainReplicationStatus) []*historyv1.DomainReplicationStatus {
+	if t == nil {
+		return nil
+	}
+	v := make([]*historyv1.DomainReplicationStatus, len(t))
+	for i := range t {
+		v[i] = FromHistoryDomainReplicationStatus(t[i])
+	}
+	return v
+}
+
+func ToHistoryDomainReplicationStatusArray(t []*historyv1.DomainReplicationStatus) []*types.DomainReplicationStatus {
+	if t == nil {
+		return nil
+	}
+	v := make([]*types.DomainReplicationStatus, len(t))
+	for i := range t {
+		v[i] = ToHistoryDomainReplicationStatus(t[i])
+	}
+	return v
+}
+
 func FromHistoryGetMutableStateRequest(t *types.GetMutableStateRequest) *historyv1.GetMutableStateRequest {
 	if t == nil {
 		return nil
@@ -1275,22 +1853,32 @@ func FromHistorySignalWithStartWorkflowExecutionRequest(t *types.HistorySignalWi
 	if t == nil {
 		return nil
 	}
-	return &historyv1.SignalWithStartWorkflowExecutionRequest{
+	result := &historyv1.SignalWithStartWorkflowExecutionRequest{
 		Request:         FromSignalWithStartWorkflowExecutionRequest(t.SignalWithStartRequest),
 		DomainId:        t.DomainUUID,
 		PartitionConfig: t.PartitionConfig,
 	}
+	if t.SignalWithStartRequest != nil {
+		result.NoProgressTimeout = secondsToDuration(t.SignalWithStartRequest.NoProgressTimeoutSeconds)
+		result.NoProgressTimeoutPolicy = FromNoProgressTimeoutPolicy(t.SignalWithStartRequest.NoProgressTimeoutPolicy)
+	}
+	return result
 }
 
 func ToHistorySignalWithStartWorkflowExecutionRequest(t *historyv1.SignalWithStartWorkflowExecutionRequest) *types.HistorySignalWithStartWorkflowExecutionRequest {
 	if t == nil {
 		return nil
 	}
-	return &types.HistorySignalWithStartWorkflowExecutionRequest{
+	result := &types.HistorySignalWithStartWorkflowExecutionRequest{
 		SignalWithStartRequest: ToSignalWithStartWorkflowExecutionRequest(t.Request),
 		DomainUUID:             t.DomainId,
 		PartitionConfig:        t.PartitionConfig,
 	}
+	if result.SignalWithStartRequest != nil {
+		result.SignalWithStartRequest.NoProgressTimeoutSeconds = durationToSeconds(t.NoProgressTimeout)
+		result.SignalWithStartRequest.NoProgressTimeoutPolicy = ToNoProgressTimeoutPolicy(t.NoProgressTimeoutPolicy)
+	}
+	return result
 }
 
 func FromHistorySignalWithStartWorkflowExecutionResponse(t *types.StartWorkflowExecutionResponse) *historyv1.SignalWithStartWorkflowExecutionResponse {
@@ -1339,7 +1927,7 @@ func FromHistoryStartWorkflowExecutionRequest(t *types.HistoryStartWorkflowExecu
 	if t == nil {
 		return nil
 	}
-	return &historyv1.StartWorkflowExecutionRequest{
+	result := &historyv1.StartWorkflowExecutionRequest{
 		Request:                  FromStartWorkflowExecutionRequest(t.StartRequest),
 		DomainId:                 t.DomainUUID,
 		ParentExecutionInfo:      FromParentExecutionInfo(t.ParentExecutionInfo),
@@ -1351,13 +1939,18 @@ func FromHistoryStartWorkflowExecutionRequest(t *types.HistoryStartWorkflowExecu
 		FirstDecisionTaskBackoff: secondsToDuration(t.FirstDecisionTaskBackoffSeconds),
 		PartitionConfig:          t.PartitionConfig,
 	}
+	if t.StartRequest != nil {
+		result.NoProgressTimeout = secondsToDuration(t.StartRequest.NoProgressTimeoutSeconds)
+		result.NoProgressTimeoutPolicy = FromNoProgressTimeoutPolicy(t.StartRequest.NoProgressTimeoutPolicy)
+	}
+	return result
 }
 
 func ToHistoryStartWorkflowExecutionRequest(t *historyv1.StartWorkflowExecutionRequest) *types.HistoryStartWorkflowExecutionRequest {
 	if t == nil {
 		return nil
 	}
-	return &types.HistoryStartWorkflowExecutionRequest{
+	result := &types.HistoryStartWorkflowExecutionRequest{
 		StartRequest:                    ToStartWorkflowExecutionRequest(t.Request),
 		DomainUUID:                      t.DomainId,
 		ParentExecutionInfo:             ToParentExecutionInfo(t.ParentExecutionInfo),
@@ -1370,6 +1963,36 @@ func ToHistoryStartWorkflowExecutionRequest(t *historyv1.StartWorkflowExecutionR
 		FirstDecisionTaskBackoffSeconds: durationToSeconds(t.FirstDecisionTaskBackoff),
 		PartitionConfig:                 t.PartitionConfig,
 	}
+	if result.StartRequest != nil {
+		result.StartRequest.NoProgressTimeoutSeconds = durationToSeconds(t.NoProgressTimeout)
+		result.StartRequest.NoProgressTimeoutPolicy = ToNoProgressTimeoutPolicy(t.NoProgressTimeoutPolicy)
+	}
+	return result
+}
+
+func FromNoProgressTimeoutPolicy(t *types.NoProgressTimeoutPolicy) historyv1.NoProgressTimeoutPolicy {
+	if t == nil {
+		return historyv1.NoProgressTimeoutPolicy_NO_PROGRESS_TIMEOUT_POLICY_INVALID
+	}
+	switch *t {
+	case types.NoProgressTimeoutPolicyNotify:
+		return historyv1.NoProgressTimeoutPolicy_NO_PROGRESS_TIMEOUT_POLICY_NOTIFY
+	case types.NoProgressTimeoutPolicyFail:
+		return historyv1.NoProgressTimeoutPolicy_NO_PROGRESS_TIMEOUT_POLICY_FAIL
+	}
+	panic("unexpected enum value")
+}
+
+func ToNoProgressTimeoutPolicy(t historyv1.NoProgressTimeoutPolicy) *types.NoProgressTimeoutPolicy {
+	switch t {
+	case historyv1.NoProgressTimeoutPolicy_NO_PROGRESS_TIMEOUT_POLICY_INVALID:
+		return nil
+	case historyv1.NoProgressTimeoutPolicy_NO_PROGRESS_TIMEOUT_POLICY_NOTIFY:
+		return types.NoProgressTimeoutPolicyNotify.Ptr()
+	case historyv1.NoProgressTimeoutPolicy_NO_PROGRESS_TIMEOUT_POLICY_FAIL:
+		return types.NoProgressTimeoutPolicyFail.Ptr()
+	}
+	panic("unexpected enum value")
 }
 
 func FromHistoryStartWorkflowExecutionResponse(t *types.StartWorkflowExecutionResponse) *historyv1.StartWorkflowExecutionResponse {
@@ -1480,6 +2103,150 @@ func ToHistoryTerminateWorkflowExecutionRequest(t *historyv1.TerminateWorkflowEx
 	}
 }
 
+func FromHistoryDeleteWorkflowExecutionRequest(t *types.HistoryDeleteWorkflowExecutionRequest) *historyv1.DeleteWorkflowExecutionRequest {
+	if t == nil {
+		return nil
+	}
+	request := t.DeleteRequest
+	if request == nil {
+		return &historyv1.DeleteWorkflowExecutionRequest{
+			DomainId: t.DomainUUID,
+		}
+	}
+	return &historyv1.DeleteWorkflowExecutionRequest{
+		DomainId:              t.DomainUUID,
+		Domain:                request.Domain,
+		WorkflowExecution:     FromWorkflowExecution(request.WorkflowExecution),
+		TerminateIfRunning:    request.TerminateIfRunning,
+		IncludeChildWorkflows: request.IncludeChildWorkflows,
+		Reason:                request.Reason,
+		Identity:              request.Identity,
+	}
+}
+
+func ToHistoryDeleteWorkflowExecutionRequest(t *historyv1.DeleteWorkflowExecutionRequest) *types.HistoryDeleteWorkflowExecutionRequest {
+	if t == nil {
+		return nil
+	}
+	return &types.HistoryDeleteWorkflowExecutionRequest{
+		DomainUUID: t.DomainId,
+		DeleteRequest: &types.DeleteWorkflowExecutionRequest{
+			Domain:                t.Domain,
+			WorkflowExecution:     ToWorkflowExecution(t.WorkflowExecution),
+			TerminateIfRunning:    t.TerminateIfRunning,
+			IncludeChildWorkflows: t.IncludeChildWorkflows,
+			Reason:                t.Reason,
+			Identity:              t.Identity,
+		},
+	}
+}
+
+func FromHistoryDeleteWorkflowExecutionResponse(t *types.DeleteWorkflowExecutionResponse) *historyv1.DeleteWorkflowExecutionResponse {
+	if t == nil {
+		return nil
+	}
+	return &historyv1.DeleteWorkflowExecutionResponse{
+		ChildWorkflowExecutions: FromChildWorkflowExecutionInfoArray(t.ChildWorkflowExecutions),
+	}
+}
+
+func ToHistoryDeleteWorkflowExecutionResponse(t *historyv1.DeleteWorkflowExecutionResponse) *types.DeleteWorkflowExecutionResponse {
+	if t == nil {
+		return nil
+	}
+	return &types.DeleteWorkflowExecutionResponse{
+		ChildWorkflowExecutions: ToChildWorkflowExecutionInfoArray(t.ChildWorkflowExecutions),
+	}
+}
+
+func FromChildWorkflowExecutionInfo(t *types.ChildWorkflowExecutionInfo) *historyv1.ChildWorkflowExecutionInfo {
+	if t == nil {
+		return nil
+	}
+	return &historyv1.ChildWorkflowExecutionInfo{
+		Domain:            t.Domain,
+		WorkflowExecution: FromWorkflowExecution(t.WorkflowExecution),
+	}
+}
+
+func ToChildWorkflowExecutionInfo(t *historyv1.ChildWorkflowExecutionInfo) *types.ChildWorkflowExecutionInfo {
+	if t == nil {
+		return nil
+	}
+	return &types.ChildWorkflowExecutionInfo{
+		Domain:            t.Domain,
+		WorkflowExecution: ToWorkflowExecution(t.WorkflowExecution),
+	}
+}
+
+func FromChildWorkflowExecutionInfoArray(t []*types.ChildWorkflowExecutionInfo) []*historyv1.ChildWorkflowExecutionInfo {
+	if t == nil {
+		return nil
+	}
+	v := make([]*historyv1.ChildWorkflowExecutionInfo, len(t))
+	for i := range t {
+		v[i] = FromChildWorkflowExecutionInfo(t[i])
+	}
+	return v
+}
+
+func ToChildWorkflowExecutionInfoArray(t []*historyv1.ChildWorkflowExecutionInfo) []*types.ChildWorkflowExecutionInfo {
+	if t == nil {
+		return nil
+	}
+	v := make([]*types.ChildWorkflowExecutionInfo, len(t))
+	for i := range t {
+		v[i] = ToChildWorkflowExecutionInfo(t[i])
+	}
+	return v
+}
+
+func FromHistoryUpdateActivityOptionsRequest(t *types.HistoryUpdateActivityOptionsRequest) *historyv1.UpdateActivityOptionsRequest {
+	if t == nil {
+		return nil
+	}
+	request := t.UpdateRequest
+	if request == nil {
+		return &historyv1.UpdateActivityOptionsRequest{
+			DomainId: t.DomainUUID,
+		}
+	}
+	return &historyv1.UpdateActivityOptionsRequest{
+		DomainId:               t.DomainUUID,
+		Domain:                 request.Domain,
+		WorkflowExecution:      FromWorkflowExecution(request.WorkflowExecution),
+		ActivityId:             request.ActivityID,
+		ScheduleToCloseTimeout: secondsToDuration(request.ScheduleToCloseTimeoutSeconds),
+		ScheduleToStartTimeout: secondsToDuration(request.ScheduleToStartTimeoutSeconds),
+		StartToCloseTimeout:    secondsToDuration(request.StartToCloseTimeoutSeconds),
+		HeartbeatTimeout:       secondsToDuration(request.HeartbeatTimeoutSeconds),
+		RetryPolicy:            FromRetryPolicy(request.RetryPolicy),
+		TaskList:               FromTaskList(request.TaskList),
+		Identity:               request.Identity,
+	}
+}
+
+func ToHistoryUpdateActivityOptionsRequest(t *historyv1.UpdateActivityOptionsRequest) *types.HistoryUpdateActivityOptionsRequest {
+	if t == nil {
+		return nil
+	}
+	return &types.HistoryUpdateActivityOptionsRequest{
+		DomainUUID: t.DomainId,
+		UpdateRequest: &types.UpdateActivityOptionsRequest{
+			Domain:                        t.Domain,
+			WorkflowExecution:             ToWorkflowExecution(t.WorkflowExecution),
+			ActivityID:                    t.ActivityId,
+			ScheduleToCloseTimeoutSeconds: durationToSeconds(t.ScheduleToCloseTimeout),
+			ScheduleToStartTimeoutSeconds: durationToSeconds(t.ScheduleToStartTimeout),
+			StartToCloseTimeoutSeconds:    durationToSeconds(t.StartToCloseTimeout),
+			HeartbeatTimeoutSeconds:       durationToSeconds(t.HeartbeatTimeout),
+			RetryPolicy:                   ToRetryPolicy(t.RetryPolicy),
+			TaskList:                      ToTaskList(t.TaskList),
+			Identity:                      t.Identity,
+		},
+	}
+}
+
 // FromHistoryGetCrossClusterTasksRequest converts internal GetCrossClusterTasksRequest type to proto
 func FromHistoryGetCrossClusterTasksRequest(t *types.GetCrossClusterTasksRequest) *historyv1.GetCrossClusterTasksRequest {
 	if t == nil {
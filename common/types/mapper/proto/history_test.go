@@ -27,6 +27,7 @@ import (
 
 	historyv1 "github.com/uber/cadence/.gen/proto/history/v1"
 	sharedv1 "github.com/uber/cadence/.gen/proto/shared/v1"
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/common/types/testdata"
 )
@@ -46,6 +47,17 @@ func TestHistoryDescribeHistoryHostResponse(t *testing.T) {
 		assert.Equal(t, item, ToHistoryDescribeHistoryHostResponse(FromHistoryDescribeHistoryHostResponse(item)))
 	}
 }
+func TestHistoryCacheInfo(t *testing.T) {
+	for _, item := range []*types.CacheInfo{nil, {}, &testdata.CacheInfo} {
+		assert.Equal(t, item, ToHistoryCacheInfo(FromHistoryCacheInfo(item)))
+	}
+}
+
+func TestHistoryHostLoadInfo(t *testing.T) {
+	for _, item := range []*types.HostLoadInfo{nil, {}, &testdata.HostLoadInfo} {
+		assert.Equal(t, item, ToHistoryHostLoadInfo(FromHistoryHostLoadInfo(item)))
+	}
+}
 func TestHistoryDescribeMutableStateRequest(t *testing.T) {
 	for _, item := range []*types.DescribeMutableStateRequest{nil, {}, &testdata.HistoryDescribeMutableStateRequest} {
 		assert.Equal(t, item, ToHistoryDescribeMutableStateRequest(FromHistoryDescribeMutableStateRequest(item)))
@@ -66,6 +78,21 @@ func TestHistoryDescribeQueueResponse(t *testing.T) {
 		assert.Equal(t, item, ToHistoryDescribeQueueResponse(FromHistoryDescribeQueueResponse(item)))
 	}
 }
+func TestHistoryListQueueTasksRequest(t *testing.T) {
+	for _, item := range []*types.ListQueueTasksRequest{nil, {}, &testdata.HistoryListQueueTasksRequest} {
+		assert.Equal(t, item, ToHistoryListQueueTasksRequest(FromHistoryListQueueTasksRequest(item)))
+	}
+}
+func TestHistoryListQueueTasksResponse(t *testing.T) {
+	for _, item := range []*types.ListQueueTasksResponse{nil, {}, &testdata.HistoryListQueueTasksResponse} {
+		assert.Equal(t, item, ToHistoryListQueueTasksResponse(FromHistoryListQueueTasksResponse(item)))
+	}
+}
+func TestHistoryExecuteQueueTaskRequest(t *testing.T) {
+	for _, item := range []*types.ExecuteQueueTaskRequest{nil, {}, &testdata.HistoryExecuteQueueTaskRequest} {
+		assert.Equal(t, item, ToHistoryExecuteQueueTaskRequest(FromHistoryExecuteQueueTaskRequest(item)))
+	}
+}
 func TestHistoryDescribeWorkflowExecutionRequest(t *testing.T) {
 	for _, item := range []*types.HistoryDescribeWorkflowExecutionRequest{nil, {}, &testdata.HistoryDescribeWorkflowExecutionRequest} {
 		assert.Equal(t, item, ToHistoryDescribeWorkflowExecutionRequest(FromHistoryDescribeWorkflowExecutionRequest(item)))
@@ -309,6 +336,21 @@ func TestHistoryStartWorkflowExecutionRequest(t *testing.T) {
 		assert.Equal(t, item, ToHistoryStartWorkflowExecutionRequest(FromHistoryStartWorkflowExecutionRequest(item)))
 	}
 }
+func TestHistoryStartWorkflowExecutionRequest_NoProgressTimeout(t *testing.T) {
+	startRequest := testdata.StartWorkflowExecutionRequest
+	startRequest.NoProgressTimeoutSeconds = common.Int32Ptr(60)
+	startRequest.NoProgressTimeoutPolicy = types.NoProgressTimeoutPolicyFail.Ptr()
+	item := testdata.HistoryStartWorkflowExecutionRequest
+	item.StartRequest = &startRequest
+	assert.Equal(t, &item, ToHistoryStartWorkflowExecutionRequest(FromHistoryStartWorkflowExecutionRequest(&item)))
+
+	signalWithStartRequest := testdata.SignalWithStartWorkflowExecutionRequest
+	signalWithStartRequest.NoProgressTimeoutSeconds = common.Int32Ptr(60)
+	signalWithStartRequest.NoProgressTimeoutPolicy = types.NoProgressTimeoutPolicyNotify.Ptr()
+	signalWithStartItem := testdata.HistorySignalWithStartWorkflowExecutionRequest
+	signalWithStartItem.SignalWithStartRequest = &signalWithStartRequest
+	assert.Equal(t, &signalWithStartItem, ToHistorySignalWithStartWorkflowExecutionRequest(FromHistorySignalWithStartWorkflowExecutionRequest(&signalWithStartItem)))
+}
 func TestHistoryStartWorkflowExecutionResponse(t *testing.T) {
 	for _, item := range []*types.StartWorkflowExecutionResponse{nil, {}, &testdata.HistoryStartWorkflowExecutionResponse} {
 		assert.Equal(t, item, ToHistoryStartWorkflowExecutionResponse(FromHistoryStartWorkflowExecutionResponse(item)))
@@ -330,6 +372,18 @@ func TestHistoryTerminateWorkflowExecutionRequest(t *testing.T) {
 	}
 }
 
+func TestHistoryDeleteWorkflowExecutionRequest(t *testing.T) {
+	for _, item := range []*types.HistoryDeleteWorkflowExecutionRequest{nil, &testdata.HistoryDeleteWorkflowExecutionRequest} {
+		assert.Equal(t, item, ToHistoryDeleteWorkflowExecutionRequest(FromHistoryDeleteWorkflowExecutionRequest(item)))
+	}
+}
+
+func TestHistoryDeleteWorkflowExecutionResponse(t *testing.T) {
+	for _, item := range []*types.DeleteWorkflowExecutionResponse{nil, {}, &testdata.DeleteWorkflowExecutionResponse} {
+		assert.Equal(t, item, ToHistoryDeleteWorkflowExecutionResponse(FromHistoryDeleteWorkflowExecutionResponse(item)))
+	}
+}
+
 func TestHistoryGetCrossClusterTasksRequest(t *testing.T) {
 	for _, item := range []*types.GetCrossClusterTasksRequest{nil, {}, &testdata.HistoryGetCrossClusterTasksRequest} {
 		assert.Equal(t, item, ToHistoryGetCrossClusterTasksRequest(FromHistoryGetCrossClusterTasksRequest(item)))
@@ -366,6 +420,42 @@ func TestHistoryGetTaskInfoResponse(t *testing.T) {
 	}
 }
 
+func TestHistoryMigrateShardExecutionsRequest(t *testing.T) {
+	for _, item := range []*types.MigrateShardExecutionsRequest{nil, {}, &testdata.MigrateShardExecutionsRequest} {
+		assert.Equal(t, item, ToHistoryMigrateShardExecutionsRequest(FromHistoryMigrateShardExecutionsRequest(item)))
+	}
+}
+
+func TestHistoryVerifyWorkflowExecutionRequest(t *testing.T) {
+	for _, item := range []*types.VerifyWorkflowExecutionRequest{nil, {}, &testdata.VerifyWorkflowExecutionRequest} {
+		assert.Equal(t, item, ToHistoryVerifyWorkflowExecutionRequest(FromHistoryVerifyWorkflowExecutionRequest(item)))
+	}
+}
+
+func TestHistoryVerifyWorkflowExecutionResponse(t *testing.T) {
+	for _, item := range []*types.VerifyWorkflowExecutionResponse{nil, {}, &testdata.VerifyWorkflowExecutionResponse} {
+		assert.Equal(t, item, ToHistoryVerifyWorkflowExecutionResponse(FromHistoryVerifyWorkflowExecutionResponse(item)))
+	}
+}
+
+func TestHistoryDescribeReplicationStatusRequest(t *testing.T) {
+	for _, item := range []*types.DescribeReplicationStatusRequest{nil, {}, &testdata.DescribeReplicationStatusRequest} {
+		assert.Equal(t, item, ToHistoryDescribeReplicationStatusRequest(FromHistoryDescribeReplicationStatusRequest(item)))
+	}
+}
+
+func TestHistoryDescribeReplicationStatusResponse(t *testing.T) {
+	for _, item := range []*types.DescribeReplicationStatusResponse{nil, {}, &testdata.DescribeReplicationStatusResponse} {
+		assert.Equal(t, item, ToHistoryDescribeReplicationStatusResponse(FromHistoryDescribeReplicationStatusResponse(item)))
+	}
+}
+
+func TestHistoryMigrateShardExecutionsResponse(t *testing.T) {
+	for _, item := range []*types.MigrateShardExecutionsResponse{nil, {}, &testdata.MigrateShardExecutionsResponse} {
+		assert.Equal(t, item, ToHistoryMigrateShardExecutionsResponse(FromHistoryMigrateShardExecutionsResponse(item)))
+	}
+}
+
 func TestRatelimitUpdate(t *testing.T) {
 	t.Run("nil", func(t *testing.T) {
 		assert.Nil(t, ToHistoryRatelimitUpdateRequest(nil), "request to internal")
@@ -470,6 +470,9 @@ func FromDLQType(t *types.DLQType) adminv1.DLQType {
 		return adminv1.DLQType_DLQ_TYPE_REPLICATION
 	case types.DLQTypeDomain:
 		return adminv1.DLQType_DLQ_TYPE_DOMAIN
+	case types.DLQTypeHistoryTask:
+		// history task DLQ is only exposed through thrift, the server rejects the invalid type
+		return adminv1.DLQType_DLQ_TYPE_INVALID
 	}
 	panic("unexpected enum value")
 }
@@ -36,6 +36,18 @@ var (
 	ToHistoryDescribeQueueRequest                        = ToAdminDescribeQueueRequest
 	FromHistoryDescribeQueueResponse                     = FromAdminDescribeQueueResponse
 	ToHistoryDescribeQueueResponse                       = ToAdminDescribeQueueResponse
+	FromHistoryListQueueTasksRequest                     = FromAdminListQueueTasksRequest
+	ToHistoryListQueueTasksRequest                       = ToAdminListQueueTasksRequest
+	FromHistoryListQueueTasksResponse                    = FromAdminListQueueTasksResponse
+	ToHistoryListQueueTasksResponse                      = ToAdminListQueueTasksResponse
+	FromHistoryExecuteQueueTaskRequest                   = FromAdminExecuteQueueTaskRequest
+	ToHistoryExecuteQueueTaskRequest                     = ToAdminExecuteQueueTaskRequest
+	FromHistoryVerifyWorkflowExecutionResponse           = FromAdminVerifyWorkflowExecutionResponse
+	ToHistoryVerifyWorkflowExecutionResponse             = ToAdminVerifyWorkflowExecutionResponse
+	FromHistoryDescribeReplicationStatusResponse         = FromAdminDescribeReplicationStatusResponse
+	ToHistoryDescribeReplicationStatusResponse           = ToAdminDescribeReplicationStatusResponse
+	FromHistoryDeleteWorkflowExecutionResponse           = FromDeleteWorkflowExecutionResponse
+	ToHistoryDeleteWorkflowExecutionResponse             = ToDeleteWorkflowExecutionResponse
 	FromHistoryDescribeWorkflowExecutionResponse         = FromDescribeWorkflowExecutionResponse
 	ToHistoryDescribeWorkflowExecutionResponse           = ToDescribeWorkflowExecutionResponse
 	FromHistoryGetCrossClusterTasksRequest               = FromAdminGetCrossClusterTasksRequest
@@ -434,6 +446,7 @@ func FromProcessingQueueState(t *types.ProcessingQueueState) *history.Processing
 		AckLevel:     t.AckLevel,
 		MaxLevel:     t.MaxLevel,
 		DomainFilter: FromDomainFilter(t.DomainFilter),
+		ReadLevel:    t.ReadLevel,
 	}
 }
 
@@ -447,6 +460,7 @@ func ToProcessingQueueState(t *history.ProcessingQueueState) *types.ProcessingQu
 		AckLevel:     t.AckLevel,
 		MaxLevel:     t.MaxLevel,
 		DomainFilter: ToDomainFilter(t.DomainFilter),
+		ReadLevel:    t.ReadLevel,
 	}
 }
 
@@ -1252,6 +1266,50 @@ func ToHistoryTerminateWorkflowExecutionRequest(t *history.TerminateWorkflowExec
 	}
 }
 
+// FromHistoryDeleteWorkflowExecutionRequest converts internal DeleteWorkflowExecutionRequest type to thrift
+func FromHistoryDeleteWorkflowExecutionRequest(t *types.HistoryDeleteWorkflowExecutionRequest) *history.DeleteWorkflowExecutionRequest {
+	if t == nil {
+		return nil
+	}
+	return &history.DeleteWorkflowExecutionRequest{
+		DomainUUID:    &t.DomainUUID,
+		DeleteRequest: FromDeleteWorkflowExecutionRequest(t.DeleteRequest),
+	}
+}
+
+// ToHistoryDeleteWorkflowExecutionRequest converts thrift DeleteWorkflowExecutionRequest type to internal
+func ToHistoryDeleteWorkflowExecutionRequest(t *history.DeleteWorkflowExecutionRequest) *types.HistoryDeleteWorkflowExecutionRequest {
+	if t == nil {
+		return nil
+	}
+	return &types.HistoryDeleteWorkflowExecutionRequest{
+		DomainUUID:    t.GetDomainUUID(),
+		DeleteRequest: ToDeleteWorkflowExecutionRequest(t.DeleteRequest),
+	}
+}
+
+// FromHistoryUpdateActivityOptionsRequest converts internal UpdateActivityOptionsRequest type to thrift
+func FromHistoryUpdateActivityOptionsRequest(t *types.HistoryUpdateActivityOptionsRequest) *history.UpdateActivityOptionsRequest {
+	if t == nil {
+		return nil
+	}
+	return &history.UpdateActivityOptionsRequest{
+		DomainUUID:    &t.DomainUUID,
+		UpdateRequest: FromUpdateActivityOptionsRequest(t.UpdateRequest),
+	}
+}
+
+// ToHistoryUpdateActivityOptionsRequest converts thrift UpdateActivityOptionsRequest type to internal
+func ToHistoryUpdateActivityOptionsRequest(t *history.UpdateActivityOptionsRequest) *types.HistoryUpdateActivityOptionsRequest {
+	if t == nil {
+		return nil
+	}
+	return &types.HistoryUpdateActivityOptionsRequest{
+		DomainUUID:    t.GetDomainUUID(),
+		UpdateRequest: ToUpdateActivityOptionsRequest(t.UpdateRequest),
+	}
+}
+
 // FromFailoverMarkerTokenArray converts internal FailoverMarkerToken type array to thrift
 func FromFailoverMarkerTokenArray(t []*types.FailoverMarkerToken) []*history.FailoverMarkerToken {
 	if t == nil {
@@ -1366,6 +1424,106 @@ func ToGetFailoverInfoResponse(t *history.GetFailoverInfoResponse) *types.GetFai
 	}
 }
 
+// FromHistoryMigrateShardExecutionsRequest converts internal MigrateShardExecutionsRequest type to thrift
+func FromHistoryMigrateShardExecutionsRequest(t *types.MigrateShardExecutionsRequest) *history.MigrateShardExecutionsRequest {
+	if t == nil {
+		return nil
+	}
+	return &history.MigrateShardExecutionsRequest{
+		ShardID:          &t.ShardID,
+		SourceShardID:    &t.SourceShardID,
+		ClosedOnly:       &t.ClosedOnly,
+		DeleteFromSource: &t.DeleteFromSource,
+		PageSize:         &t.PageSize,
+		NextPageToken:    t.NextPageToken,
+	}
+}
+
+// ToHistoryMigrateShardExecutionsRequest converts thrift MigrateShardExecutionsRequest type to internal
+func ToHistoryMigrateShardExecutionsRequest(t *history.MigrateShardExecutionsRequest) *types.MigrateShardExecutionsRequest {
+	if t == nil {
+		return nil
+	}
+	return &types.MigrateShardExecutionsRequest{
+		ShardID:          t.GetShardID(),
+		SourceShardID:    t.GetSourceShardID(),
+		ClosedOnly:       t.GetClosedOnly(),
+		DeleteFromSource: t.GetDeleteFromSource(),
+		PageSize:         t.GetPageSize(),
+		NextPageToken:    t.NextPageToken,
+	}
+}
+
+// FromHistoryMigrateShardExecutionsResponse converts internal MigrateShardExecutionsResponse type to thrift
+func FromHistoryMigrateShardExecutionsResponse(t *types.MigrateShardExecutionsResponse) *history.MigrateShardExecutionsResponse {
+	if t == nil {
+		return nil
+	}
+	return &history.MigrateShardExecutionsResponse{
+		NextPageToken: t.NextPageToken,
+		CopiedCount:   &t.CopiedCount,
+		DeletedCount:  &t.DeletedCount,
+	}
+}
+
+// ToHistoryMigrateShardExecutionsResponse converts thrift MigrateShardExecutionsResponse type to internal
+func ToHistoryMigrateShardExecutionsResponse(t *history.MigrateShardExecutionsResponse) *types.MigrateShardExecutionsResponse {
+	if t == nil {
+		return nil
+	}
+	return &types.MigrateShardExecutionsResponse{
+		NextPageToken: t.NextPageToken,
+		CopiedCount:   t.GetCopiedCount(),
+		DeletedCount:  t.GetDeletedCount(),
+	}
+}
+
+// FromHistoryVerifyWorkflowExecutionRequest converts internal VerifyWorkflowExecutionRequest type to thrift
+func FromHistoryVerifyWorkflowExecutionRequest(t *types.VerifyWorkflowExecutionRequest) *history.VerifyWorkflowExecutionRequest {
+	if t == nil {
+		return nil
+	}
+	return &history.VerifyWorkflowExecutionRequest{
+		DomainUUID: &t.DomainUUID,
+		Execution:  FromWorkflowExecution(t.Execution),
+		Repair:     &t.Repair,
+	}
+}
+
+// ToHistoryVerifyWorkflowExecutionRequest converts thrift VerifyWorkflowExecutionRequest type to internal
+func ToHistoryVerifyWorkflowExecutionRequest(t *history.VerifyWorkflowExecutionRequest) *types.VerifyWorkflowExecutionRequest {
+	if t == nil {
+		return nil
+	}
+	return &types.VerifyWorkflowExecutionRequest{
+		DomainUUID: t.GetDomainUUID(),
+		Execution:  ToWorkflowExecution(t.Execution),
+		Repair:     t.GetRepair(),
+	}
+}
+
+// FromHistoryDescribeReplicationStatusRequest converts internal DescribeReplicationStatusRequest type to thrift
+func FromHistoryDescribeReplicationStatusRequest(t *types.DescribeReplicationStatusRequest) *history.DescribeReplicationStatusRequest {
+	if t == nil {
+		return nil
+	}
+	return &history.DescribeReplicationStatusRequest{
+		DomainUUID:  &t.DomainUUID,
+		ClusterName: &t.ClusterName,
+	}
+}
+
+// ToHistoryDescribeReplicationStatusRequest converts thrift DescribeReplicationStatusRequest type to internal
+func ToHistoryDescribeReplicationStatusRequest(t *history.DescribeReplicationStatusRequest) *types.DescribeReplicationStatusRequest {
+	if t == nil {
+		return nil
+	}
+	return &types.DescribeReplicationStatusRequest{
+		DomainUUID:  t.GetDomainUUID(),
+		ClusterName: t.GetClusterName(),
+	}
+}
+
 func ToHistoryRatelimitUpdateRequest(t *history.RatelimitUpdateRequest) *types.RatelimitUpdateRequest {
 	if t == nil {
 		return nil
@@ -40,6 +40,12 @@ func TestHistoryTerminateWorkflowExecutionRequestConversion(t *testing.T) {
 	}
 }
 
+func TestHistoryDeleteWorkflowExecutionRequestConversion(t *testing.T) {
+	for _, item := range []*types.HistoryDeleteWorkflowExecutionRequest{nil, {}, &testdata.HistoryDeleteWorkflowExecutionRequest} {
+		assert.Equal(t, item, ToHistoryDeleteWorkflowExecutionRequest(FromHistoryDeleteWorkflowExecutionRequest(item)))
+	}
+}
+
 func TestDescribeMutableStateRequestConversion(t *testing.T) {
 	testCases := []*types.DescribeMutableStateRequest{
 		nil,
@@ -212,7 +218,7 @@ func TestProcessingQueueStateConversion(t *testing.T) {
 	testCases := []*types.ProcessingQueueState{
 		nil,
 		{},
-		{Level: common.Int32Ptr(1), AckLevel: common.Int64Ptr(1), MaxLevel: common.Int64Ptr(1), DomainFilter: &types.DomainFilter{DomainIDs: []string{"test"}, ReverseMatch: true}},
+		{Level: common.Int32Ptr(1), AckLevel: common.Int64Ptr(1), MaxLevel: common.Int64Ptr(1), DomainFilter: &types.DomainFilter{DomainIDs: []string{"test"}, ReverseMatch: true}, ReadLevel: common.Int64Ptr(1)},
 	}
 
 	for _, original := range testCases {
@@ -678,6 +684,42 @@ func TestSyncShardStatusRequestConversion(t *testing.T) {
 	}
 }
 
+func TestMigrateShardExecutionsRequestConversion(t *testing.T) {
+	for _, item := range []*types.MigrateShardExecutionsRequest{nil, {}, &testdata.MigrateShardExecutionsRequest} {
+		assert.Equal(t, item, ToHistoryMigrateShardExecutionsRequest(FromHistoryMigrateShardExecutionsRequest(item)))
+	}
+}
+
+func TestVerifyWorkflowExecutionRequestConversion(t *testing.T) {
+	for _, item := range []*types.VerifyWorkflowExecutionRequest{nil, {}, &testdata.VerifyWorkflowExecutionRequest} {
+		assert.Equal(t, item, ToHistoryVerifyWorkflowExecutionRequest(FromHistoryVerifyWorkflowExecutionRequest(item)))
+	}
+}
+
+func TestVerifyWorkflowExecutionResponseConversion(t *testing.T) {
+	for _, item := range []*types.VerifyWorkflowExecutionResponse{nil, {}, &testdata.VerifyWorkflowExecutionResponse} {
+		assert.Equal(t, item, ToHistoryVerifyWorkflowExecutionResponse(FromHistoryVerifyWorkflowExecutionResponse(item)))
+	}
+}
+
+func TestDescribeReplicationStatusRequestConversion(t *testing.T) {
+	for _, item := range []*types.DescribeReplicationStatusRequest{nil, {}, &testdata.DescribeReplicationStatusRequest} {
+		assert.Equal(t, item, ToHistoryDescribeReplicationStatusRequest(FromHistoryDescribeReplicationStatusRequest(item)))
+	}
+}
+
+func TestDescribeReplicationStatusResponseConversion(t *testing.T) {
+	for _, item := range []*types.DescribeReplicationStatusResponse{nil, {}, &testdata.DescribeReplicationStatusResponse} {
+		assert.Equal(t, item, ToHistoryDescribeReplicationStatusResponse(FromHistoryDescribeReplicationStatusResponse(item)))
+	}
+}
+
+func TestMigrateShardExecutionsResponseConversion(t *testing.T) {
+	for _, item := range []*types.MigrateShardExecutionsResponse{nil, {}, &testdata.MigrateShardExecutionsResponse} {
+		assert.Equal(t, item, ToHistoryMigrateShardExecutionsResponse(FromHistoryMigrateShardExecutionsResponse(item)))
+	}
+}
+
 func TestRatelimitUpdate(t *testing.T) {
 	t.Run("nil", func(t *testing.T) {
 		assert.Nil(t, ToHistoryRatelimitUpdateRequest(nil), "request to internal")
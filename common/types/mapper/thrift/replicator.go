@@ -37,6 +37,9 @@ func FromDLQType(t *types.DLQType) *replicator.DLQType {
 	case types.DLQTypeDomain:
 		v := replicator.DLQTypeDomain
 		return &v
+	case types.DLQTypeHistoryTask:
+		v := replicator.DLQTypeHistoryTask
+		return &v
 	}
 	panic("unexpected enum value")
 }
@@ -53,6 +56,9 @@ func ToDLQType(t *replicator.DLQType) *types.DLQType {
 	case replicator.DLQTypeDomain:
 		v := types.DLQTypeDomain
 		return &v
+	case replicator.DLQTypeHistoryTask:
+		v := types.DLQTypeHistoryTask
+		return &v
 	}
 	panic("unexpected enum value")
 }
@@ -439,6 +445,7 @@ func FromAdminReadDLQMessagesResponse(t *types.ReadDLQMessagesResponse) *replica
 		ReplicationTasks:     FromReplicationTaskArray(t.ReplicationTasks),
 		ReplicationTasksInfo: FromReplicationTaskInfoArray(t.ReplicationTasksInfo),
 		NextPageToken:        t.NextPageToken,
+		HistoryTasks:         FromHistoryTaskDLQMessageArray(t.HistoryTasks),
 	}
 }
 
@@ -452,7 +459,60 @@ func ToAdminReadDLQMessagesResponse(t *replicator.ReadDLQMessagesResponse) *type
 		ReplicationTasks:     ToReplicationTaskArray(t.ReplicationTasks),
 		ReplicationTasksInfo: ToReplicationTaskInfoArray(t.ReplicationTasksInfo),
 		NextPageToken:        t.NextPageToken,
+		HistoryTasks:         ToHistoryTaskDLQMessageArray(t.HistoryTasks),
+	}
+}
+
+// FromHistoryTaskDLQMessage converts internal HistoryTaskDLQMessage type to thrift
+func FromHistoryTaskDLQMessage(t *types.HistoryTaskDLQMessage) *replicator.HistoryTaskDLQMessage {
+	if t == nil {
+		return nil
+	}
+	return &replicator.HistoryTaskDLQMessage{
+		MessageID:         &t.MessageID,
+		ShardID:           &t.ShardID,
+		TaskCategory:      &t.TaskCategory,
+		Task:              FromQueueTaskInfo(t.Task),
+		EnqueuedTimestamp: &t.EnqueuedTimestamp,
+	}
+}
+
+// ToHistoryTaskDLQMessage converts thrift HistoryTaskDLQMessage type to internal
+func ToHistoryTaskDLQMessage(t *replicator.HistoryTaskDLQMessage) *types.HistoryTaskDLQMessage {
+	if t == nil {
+		return nil
+	}
+	return &types.HistoryTaskDLQMessage{
+		MessageID:         t.GetMessageID(),
+		ShardID:           t.GetShardID(),
+		TaskCategory:      t.GetTaskCategory(),
+		Task:              ToQueueTaskInfo(t.Task),
+		EnqueuedTimestamp: t.GetEnqueuedTimestamp(),
+	}
+}
+
+// FromHistoryTaskDLQMessageArray converts internal HistoryTaskDLQMessage type array to thrift
+func FromHistoryTaskDLQMessageArray(t []*types.HistoryTaskDLQMessage) []*replicator.HistoryTaskDLQMessage {
+	if t == nil {
+		return nil
 	}
+	v := make([]*replicator.HistoryTaskDLQMessage, len(t))
+	for i := range t {
+		v[i] = FromHistoryTaskDLQMessage(t[i])
+	}
+	return v
+}
+
+// ToHistoryTaskDLQMessageArray converts thrift HistoryTaskDLQMessage type array to internal
+func ToHistoryTaskDLQMessageArray(t []*replicator.HistoryTaskDLQMessage) []*types.HistoryTaskDLQMessage {
+	if t == nil {
+		return nil
+	}
+	v := make([]*types.HistoryTaskDLQMessage, len(t))
+	for i := range t {
+		v[i] = ToHistoryTaskDLQMessage(t[i])
+	}
+	return v
 }
 
 // FromReplicationMessages converts internal ReplicationMessages type to thrift
@@ -38,6 +38,10 @@ func TestDLQType(t *testing.T) {
 			desc:  "non-nil input test",
 			input: types.DLQTypeReplication.Ptr(),
 		},
+		{
+			desc:  "history task input test",
+			input: types.DLQTypeHistoryTask.Ptr(),
+		},
 		{
 			desc:  "nil input test",
 			input: nil,
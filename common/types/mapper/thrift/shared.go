@@ -188,6 +188,40 @@ func ToActivityTaskFailedEventAttributes(t *shared.ActivityTaskFailedEventAttrib
 	}
 }
 
+// FromActivityTaskOptionsUpdatedEventAttributes converts internal ActivityTaskOptionsUpdatedEventAttributes type to thrift
+func FromActivityTaskOptionsUpdatedEventAttributes(t *types.ActivityTaskOptionsUpdatedEventAttributes) *shared.ActivityTaskOptionsUpdatedEventAttributes {
+	if t == nil {
+		return nil
+	}
+	return &shared.ActivityTaskOptionsUpdatedEventAttributes{
+		ScheduledEventId:              &t.ScheduledEventID,
+		ScheduleToCloseTimeoutSeconds: t.ScheduleToCloseTimeoutSeconds,
+		ScheduleToStartTimeoutSeconds: t.ScheduleToStartTimeoutSeconds,
+		StartToCloseTimeoutSeconds:    t.StartToCloseTimeoutSeconds,
+		HeartbeatTimeoutSeconds:       t.HeartbeatTimeoutSeconds,
+		RetryPolicy:                   FromRetryPolicy(t.RetryPolicy),
+		TaskList:                      FromTaskList(t.TaskList),
+		Identity:                      &t.Identity,
+	}
+}
+
+// ToActivityTaskOptionsUpdatedEventAttributes converts thrift ActivityTaskOptionsUpdatedEventAttributes type to internal
+func ToActivityTaskOptionsUpdatedEventAttributes(t *shared.ActivityTaskOptionsUpdatedEventAttributes) *types.ActivityTaskOptionsUpdatedEventAttributes {
+	if t == nil {
+		return nil
+	}
+	return &types.ActivityTaskOptionsUpdatedEventAttributes{
+		ScheduledEventID:              t.GetScheduledEventId(),
+		ScheduleToCloseTimeoutSeconds: t.ScheduleToCloseTimeoutSeconds,
+		ScheduleToStartTimeoutSeconds: t.ScheduleToStartTimeoutSeconds,
+		StartToCloseTimeoutSeconds:    t.StartToCloseTimeoutSeconds,
+		HeartbeatTimeoutSeconds:       t.HeartbeatTimeoutSeconds,
+		RetryPolicy:                   ToRetryPolicy(t.RetryPolicy),
+		TaskList:                      ToTaskList(t.TaskList),
+		Identity:                      t.GetIdentity(),
+	}
+}
+
 // FromActivityTaskScheduledEventAttributes converts internal ActivityTaskScheduledEventAttributes type to thrift
 func FromActivityTaskScheduledEventAttributes(t *types.ActivityTaskScheduledEventAttributes) *shared.ActivityTaskScheduledEventAttributes {
 	if t == nil {
@@ -1637,6 +1671,9 @@ func FromAdminDescribeHistoryHostResponse(t *types.DescribeHistoryHostResponse)
 		DomainCache:           FromDomainCacheInfo(t.DomainCache),
 		ShardControllerStatus: &t.ShardControllerStatus,
 		Address:               &t.Address,
+		ExecutionCache:        FromCacheInfo(t.ExecutionCache),
+		EventsCache:           FromCacheInfo(t.EventsCache),
+		Load:                  FromHostLoadInfo(t.Load),
 	}
 }
 
@@ -1651,6 +1688,9 @@ func ToAdminDescribeHistoryHostResponse(t *shared.DescribeHistoryHostResponse) *
 		DomainCache:           ToDomainCacheInfo(t.DomainCache),
 		ShardControllerStatus: t.GetShardControllerStatus(),
 		Address:               t.GetAddress(),
+		ExecutionCache:        ToCacheInfo(t.ExecutionCache),
+		EventsCache:           ToCacheInfo(t.EventsCache),
+		Load:                  ToHostLoadInfo(t.Load),
 	}
 }
 
@@ -1720,6 +1760,148 @@ func ToAdminDescribeQueueResponse(t *shared.DescribeQueueResponse) *types.Descri
 	}
 }
 
+// FromAdminListQueueTasksRequest converts internal ListQueueTasksRequest type to thrift
+func FromAdminListQueueTasksRequest(t *types.ListQueueTasksRequest) *shared.ListQueueTasksRequest {
+	if t == nil {
+		return nil
+	}
+	return &shared.ListQueueTasksRequest{
+		ShardID:                &t.ShardID,
+		ClusterName:            &t.ClusterName,
+		Type:                   t.Type,
+		MinTaskID:              t.MinTaskID,
+		MaxTaskID:              t.MaxTaskID,
+		MinVisibilityTimestamp: t.MinVisibilityTimestamp,
+		MaxVisibilityTimestamp: t.MaxVisibilityTimestamp,
+		PageSize:               &t.PageSize,
+	}
+}
+
+// ToAdminListQueueTasksRequest converts thrift ListQueueTasksRequest type to internal
+func ToAdminListQueueTasksRequest(t *shared.ListQueueTasksRequest) *types.ListQueueTasksRequest {
+	if t == nil {
+		return nil
+	}
+	return &types.ListQueueTasksRequest{
+		ShardID:                t.GetShardID(),
+		ClusterName:            t.GetClusterName(),
+		Type:                   t.Type,
+		MinTaskID:              t.MinTaskID,
+		MaxTaskID:              t.MaxTaskID,
+		MinVisibilityTimestamp: t.MinVisibilityTimestamp,
+		MaxVisibilityTimestamp: t.MaxVisibilityTimestamp,
+		PageSize:               t.GetPageSize(),
+	}
+}
+
+// FromAdminListQueueTasksResponse converts internal ListQueueTasksResponse type to thrift
+func FromAdminListQueueTasksResponse(t *types.ListQueueTasksResponse) *shared.ListQueueTasksResponse {
+	if t == nil {
+		return nil
+	}
+	return &shared.ListQueueTasksResponse{
+		Tasks: FromQueueTaskInfoArray(t.Tasks),
+	}
+}
+
+// ToAdminListQueueTasksResponse converts thrift ListQueueTasksResponse type to internal
+func ToAdminListQueueTasksResponse(t *shared.ListQueueTasksResponse) *types.ListQueueTasksResponse {
+	if t == nil {
+		return nil
+	}
+	return &types.ListQueueTasksResponse{
+		Tasks: ToQueueTaskInfoArray(t.Tasks),
+	}
+}
+
+// FromQueueTaskInfo converts internal QueueTaskInfo type to thrift
+func FromQueueTaskInfo(t *types.QueueTaskInfo) *shared.QueueTaskInfo {
+	if t == nil {
+		return nil
+	}
+	return &shared.QueueTaskInfo{
+		DomainID:            &t.DomainID,
+		WorkflowID:          &t.WorkflowID,
+		RunID:               &t.RunID,
+		TaskID:              &t.TaskID,
+		TaskType:            &t.TaskType,
+		VisibilityTimestamp: &t.VisibilityTimestamp,
+		Version:             &t.Version,
+		State:               &t.State,
+		Attempt:             &t.Attempt,
+		LastError:           &t.LastError,
+	}
+}
+
+// ToQueueTaskInfo converts thrift QueueTaskInfo type to internal
+func ToQueueTaskInfo(t *shared.QueueTaskInfo) *types.QueueTaskInfo {
+	if t == nil {
+		return nil
+	}
+	return &types.QueueTaskInfo{
+		DomainID:            t.GetDomainID(),
+		WorkflowID:          t.GetWorkflowID(),
+		RunID:               t.GetRunID(),
+		TaskID:              t.GetTaskID(),
+		TaskType:            t.GetTaskType(),
+		VisibilityTimestamp: t.GetVisibilityTimestamp(),
+		Version:             t.GetVersion(),
+		State:               t.GetState(),
+		Attempt:             t.GetAttempt(),
+		LastError:           t.GetLastError(),
+	}
+}
+
+// FromQueueTaskInfoArray converts internal QueueTaskInfo type array to thrift
+func FromQueueTaskInfoArray(t []*types.QueueTaskInfo) []*shared.QueueTaskInfo {
+	if t == nil {
+		return nil
+	}
+	v := make([]*shared.QueueTaskInfo, len(t))
+	for i := range t {
+		v[i] = FromQueueTaskInfo(t[i])
+	}
+	return v
+}
+
+// ToQueueTaskInfoArray converts thrift QueueTaskInfo type array to internal
+func ToQueueTaskInfoArray(t []*shared.QueueTaskInfo) []*types.QueueTaskInfo {
+	if t == nil {
+		return nil
+	}
+	v := make([]*types.QueueTaskInfo, len(t))
+	for i := range t {
+		v[i] = ToQueueTaskInfo(t[i])
+	}
+	return v
+}
+
+// FromAdminExecuteQueueTaskRequest converts internal ExecuteQueueTaskRequest type to thrift
+func FromAdminExecuteQueueTaskRequest(t *types.ExecuteQueueTaskRequest) *shared.ExecuteQueueTaskRequest {
+	if t == nil {
+		return nil
+	}
+	return &shared.ExecuteQueueTaskRequest{
+		ShardID:     &t.ShardID,
+		ClusterName: &t.ClusterName,
+		Type:        t.Type,
+		TaskID:      &t.TaskID,
+	}
+}
+
+// ToAdminExecuteQueueTaskRequest converts thrift ExecuteQueueTaskRequest type to internal
+func ToAdminExecuteQueueTaskRequest(t *shared.ExecuteQueueTaskRequest) *types.ExecuteQueueTaskRequest {
+	if t == nil {
+		return nil
+	}
+	return &types.ExecuteQueueTaskRequest{
+		ShardID:     t.GetShardID(),
+		ClusterName: t.GetClusterName(),
+		Type:        t.Type,
+		TaskID:      t.GetTaskID(),
+	}
+}
+
 // FromDescribeTaskListRequest converts internal DescribeTaskListRequest type to thrift
 func FromDescribeTaskListRequest(t *types.DescribeTaskListRequest) *shared.DescribeTaskListRequest {
 	if t == nil {
@@ -1801,6 +1983,7 @@ func FromDescribeWorkflowExecutionResponse(t *types.DescribeWorkflowExecutionRes
 		PendingActivities:      FromPendingActivityInfoArray(t.PendingActivities),
 		PendingChildren:        FromPendingChildExecutionInfoArray(t.PendingChildren),
 		PendingDecision:        FromPendingDecisionInfo(t.PendingDecision),
+		WorkflowSizeLimits:     FromWorkflowSizeLimits(t.WorkflowSizeLimits),
 	}
 }
 
@@ -1815,6 +1998,7 @@ func ToDescribeWorkflowExecutionResponse(t *shared.DescribeWorkflowExecutionResp
 		PendingActivities:      ToPendingActivityInfoArray(t.PendingActivities),
 		PendingChildren:        ToPendingChildExecutionInfoArray(t.PendingChildren),
 		PendingDecision:        ToPendingDecisionInfo(t.PendingDecision),
+		WorkflowSizeLimits:     ToWorkflowSizeLimits(t.WorkflowSizeLimits),
 	}
 }
 
@@ -1860,6 +2044,352 @@ func ToDomainCacheInfo(t *shared.DomainCacheInfo) *types.DomainCacheInfo {
 	}
 }
 
+// FromCacheInfo converts internal CacheInfo type to thrift
+func FromCacheInfo(t *types.CacheInfo) *shared.CacheInfo {
+	if t == nil {
+		return nil
+	}
+	return &shared.CacheInfo{
+		NumOfItems:       &t.NumOfItems,
+		NumOfPinnedItems: &t.NumOfPinnedItems,
+		SizeInBytes:      &t.SizeInBytes,
+		NumOfHits:        &t.NumOfHits,
+		NumOfMisses:      &t.NumOfMisses,
+	}
+}
+
+// ToCacheInfo converts thrift CacheInfo type to internal
+func ToCacheInfo(t *shared.CacheInfo) *types.CacheInfo {
+	if t == nil {
+		return nil
+	}
+	return &types.CacheInfo{
+		NumOfItems:       t.GetNumOfItems(),
+		NumOfPinnedItems: t.GetNumOfPinnedItems(),
+		SizeInBytes:      t.GetSizeInBytes(),
+		NumOfHits:        t.GetNumOfHits(),
+		NumOfMisses:      t.GetNumOfMisses(),
+	}
+}
+
+// FromAdminVerifyWorkflowExecutionResponse converts internal VerifyWorkflowExecutionResponse type to thrift
+func FromAdminVerifyWorkflowExecutionResponse(t *types.VerifyWorkflowExecutionResponse) *shared.VerifyWorkflowExecutionResponse {
+	if t == nil {
+		return nil
+	}
+	return &shared.VerifyWorkflowExecutionResponse{
+		Discrepancies: FromMutableStateDiscrepancyArray(t.Discrepancies),
+		Repaired:      &t.Repaired,
+	}
+}
+
+// ToAdminVerifyWorkflowExecutionResponse converts thrift VerifyWorkflowExecutionResponse type to internal
+func ToAdminVerifyWorkflowExecutionResponse(t *shared.VerifyWorkflowExecutionResponse) *types.VerifyWorkflowExecutionResponse {
+	if t == nil {
+		return nil
+	}
+	return &types.VerifyWorkflowExecutionResponse{
+		Discrepancies: ToMutableStateDiscrepancyArray(t.Discrepancies),
+		Repaired:      t.GetRepaired(),
+	}
+}
+
+// FromMutableStateDiscrepancy converts internal MutableStateDiscrepancy type to thrift
+func FromMutableStateDiscrepancy(t *types.MutableStateDiscrepancy) *shared.MutableStateDiscrepancy {
+	if t == nil {
+		return nil
+	}
+	return &shared.MutableStateDiscrepancy{
+		Type:      &t.Type,
+		ID:        &t.ID,
+		Persisted: &t.Persisted,
+		Rebuilt:   &t.Rebuilt,
+	}
+}
+
+// ToMutableStateDiscrepancy converts thrift MutableStateDiscrepancy type to internal
+func ToMutableStateDiscrepancy(t *shared.MutableStateDiscrepancy) *types.MutableStateDiscrepancy {
+	if t == nil {
+		return nil
+	}
+	return &types.MutableStateDiscrepancy{
+		Type:      t.GetType(),
+		ID:        t.GetID(),
+		Persisted: t.GetPersisted(),
+		Rebuilt:   t.GetRebuilt(),
+	}
+}
+
+// FromMutableStateDiscrepancyArray converts internal MutableStateDiscrepancy type array to thrift
+func FromMutableStateDiscrepancyArray(t []*types.MutableStateDiscrepancy) []*shared.MutableStateDiscrepancy {
+	if t == nil {
+		return nil
+	}
+	v := make([]*shared.MutableStateDiscrepancy, len(t))
+	for i := range t {
+		v[i] = FromMutableStateDiscrepancy(t[i])
+	}
+	return v
+}
+
+// ToMutableStateDiscrepancyArray converts thrift MutableStateDiscrepancy type array to internal
+func ToMutableStateDiscrepancyArray(t []*shared.MutableStateDiscrepancy) []*types.MutableStateDiscrepancy {
+	if t == nil {
+		return nil
+	}
+	v := make([]*types.MutableStateDiscrepancy, len(t))
+	for i := range t {
+		v[i] = ToMutableStateDiscrepancy(t[i])
+	}
+	return v
+}
+
+// FromAdminDescribeReplicationStatusResponse converts internal DescribeReplicationStatusResponse type to thrift
+func FromAdminDescribeReplicationStatusResponse(t *types.DescribeReplicationStatusResponse) *shared.DescribeReplicationStatusResponse {
+	if t == nil {
+		return nil
+	}
+	return &shared.DescribeReplicationStatusResponse{
+		Clusters: FromClusterReplicationStatusArray(t.Clusters),
+	}
+}
+
+// ToAdminDescribeReplicationStatusResponse converts thrift DescribeReplicationStatusResponse type to internal
+func ToAdminDescribeReplicationStatusResponse(t *shared.DescribeReplicationStatusResponse) *types.DescribeReplicationStatusResponse {
+	if t == nil {
+		return nil
+	}
+	return &types.DescribeReplicationStatusResponse{
+		Clusters: ToClusterReplicationStatusArray(t.Clusters),
+	}
+}
+
+// FromClusterReplicationStatus converts internal ClusterReplicationStatus type to thrift
+func FromClusterReplicationStatus(t *types.ClusterReplicationStatus) *shared.ClusterReplicationStatus {
+	if t == nil {
+		return nil
+	}
+	return &shared.ClusterReplicationStatus{
+		ClusterName: &t.ClusterName,
+		Domains:     FromDomainReplicationStatusArray(t.Domains),
+		Incomplete:  &t.Incomplete,
+	}
+}
+
+// ToClusterReplicationStatus converts thrift ClusterReplicationStatus type to internal
+func ToClusterReplicationStatus(t *shared.ClusterReplicationStatus) *types.ClusterReplicationStatus {
+	if t == nil {
+		return nil
+	}
+	return &types.ClusterReplicationStatus{
+		ClusterName: t.GetClusterName(),
+		Domains:     ToDomainReplicationStatusArray(t.Domains),
+		Incomplete:  t.GetIncomplete(),
+	}
+}
+
+// FromClusterReplicationStatusArray converts internal ClusterReplicationStatus type array to thrift
+func FromClusterReplicationStatusArray(t []*types.ClusterReplicationStatus) []*shared.ClusterReplicationStatus {
+	if t == nil {
+		return nil
+	}
+	v := make([]*shared.ClusterReplicationStatus, len(t))
+	for i := range t {
+		v[i] = FromClusterReplicationStatus(t[i])
+	}
+	return v
+}
+
+// ToClusterReplicationStatusArray converts thrift ClusterReplicationStatus type array to internal
+func ToClusterReplicationStatusArray(t []*shared.ClusterReplicationStatus) []*types.ClusterReplicationStatus {
+	if t == nil {
+		return nil
+	}
+	v := make([]*types.ClusterReplicationStatus, len(t))
+	for i := range t {
+		v[i] = ToClusterReplicationStatus(t[i])
+	}
+	return v
+}
+
+// FromDomainReplicationStatus converts internal DomainReplicationStatus type to thrift
+func FromDomainReplicationStatus(t *types.DomainReplicationStatus) *shared.DomainReplicationStatus {
+	if t == nil {
+		return nil
+	}
+	return &shared.DomainReplicationStatus{
+		DomainID:          &t.DomainID,
+		DomainName:        &t.DomainName,
+		PendingTaskCount:  &t.PendingTaskCount,
+		LagInMilliseconds: &t.LagInMilliseconds,
+	}
+}
+
+// ToDomainReplicationStatus converts thrift DomainReplicationStatus type to internal
+func ToDomainReplicationStatus(t *shared.DomainReplicationStatus) *types.DomainReplicationStatus {
+	if t == nil {
+		return nil
+	}
+	return &types.DomainReplicationStatus{
+		DomainID:          t.GetDomainID(),
+		DomainName:        t.GetDomainName(),
+		PendingTaskCount:  t.GetPendingTaskCount(),
+		LagInMilliseconds: t.GetLagInMilliseconds(),
+	}
+}
+
+// FromDomainReplicationStatusArray converts internal DomainReplicationStatus type array to thrift
+func FromDomainReplicationStatusArray(t []*types.DomainReplicationStatus) []*shared.DomainReplicationStatus {
+	if t == nil {
+		return nil
+	}
+	v := make([]*shared.DomainReplicationStatus, len(t))
+	for i := range t {
+		v[i] = FromDomainReplicationStatus(t[i])
+	}
+	return v
+}
+
+// ToDomainReplicationStatusArray converts thrift DomainReplicationStatus type array to internal
+func ToDomainReplicationStatusArray(t []*shared.DomainReplicationStatus) []*types.DomainReplicationStatus {
+	if t == nil {
+		return nil
+	}
+	v := make([]*types.DomainReplicationStatus, len(t))
+	for i := range t {
+		v[i] = ToDomainReplicationStatus(t[i])
+	}
+	return v
+}
+
+// FromHostLoadInfo converts internal HostLoadInfo type to thrift
+func FromHostLoadInfo(t *types.HostLoadInfo) *shared.HostLoadInfo {
+	if t == nil {
+		return nil
+	}
+	return &shared.HostLoadInfo{
+		RequestsPerSecond: &t.RequestsPerSecond,
+		CpuUtilization:    &t.CPUUtilization,
+		PendingTasks:      &t.PendingTasks,
+		Overloaded:        &t.Overloaded,
+		HotShards:         FromShardLoadInfoArray(t.HotShards),
+		HotWorkflows:      FromWorkflowLoadInfoArray(t.HotWorkflows),
+	}
+}
+
+// ToHostLoadInfo converts thrift HostLoadInfo type to internal
+func ToHostLoadInfo(t *shared.HostLoadInfo) *types.HostLoadInfo {
+	if t == nil {
+		return nil
+	}
+	return &types.HostLoadInfo{
+		RequestsPerSecond: t.GetRequestsPerSecond(),
+		CPUUtilization:    t.GetCpuUtilization(),
+		PendingTasks:      t.GetPendingTasks(),
+		Overloaded:        t.GetOverloaded(),
+		HotShards:         ToShardLoadInfoArray(t.HotShards),
+		HotWorkflows:      ToWorkflowLoadInfoArray(t.HotWorkflows),
+	}
+}
+
+// FromShardLoadInfo converts internal ShardLoadInfo type to thrift
+func FromShardLoadInfo(t *types.ShardLoadInfo) *shared.ShardLoadInfo {
+	if t == nil {
+		return nil
+	}
+	return &shared.ShardLoadInfo{
+		ShardID:           &t.ShardID,
+		RequestsPerSecond: &t.RequestsPerSecond,
+		PendingTasks:      &t.PendingTasks,
+	}
+}
+
+// ToShardLoadInfo converts thrift ShardLoadInfo type to internal
+func ToShardLoadInfo(t *shared.ShardLoadInfo) *types.ShardLoadInfo {
+	if t == nil {
+		return nil
+	}
+	return &types.ShardLoadInfo{
+		ShardID:           t.GetShardID(),
+		RequestsPerSecond: t.GetRequestsPerSecond(),
+		PendingTasks:      t.GetPendingTasks(),
+	}
+}
+
+// FromShardLoadInfoArray converts internal ShardLoadInfo type array to thrift
+func FromShardLoadInfoArray(t []*types.ShardLoadInfo) []*shared.ShardLoadInfo {
+	if t == nil {
+		return nil
+	}
+	v := make([]*shared.ShardLoadInfo, len(t))
+	for i := range t {
+		v[i] = FromShardLoadInfo(t[i])
+	}
+	return v
+}
+
+// ToShardLoadInfoArray converts thrift ShardLoadInfo type array to internal
+func ToShardLoadInfoArray(t []*shared.ShardLoadInfo) []*types.ShardLoadInfo {
+	if t == nil {
+		return nil
+	}
+	v := make([]*types.ShardLoadInfo, len(t))
+	for i := range t {
+		v[i] = ToShardLoadInfo(t[i])
+	}
+	return v
+}
+
+// FromWorkflowLoadInfo converts internal WorkflowLoadInfo type to thrift
+func FromWorkflowLoadInfo(t *types.WorkflowLoadInfo) *shared.WorkflowLoadInfo {
+	if t == nil {
+		return nil
+	}
+	return &shared.WorkflowLoadInfo{
+		DomainID:          &t.DomainID,
+		WorkflowID:        &t.WorkflowID,
+		ShardID:           &t.ShardID,
+		RequestsPerSecond: &t.RequestsPerSecond,
+	}
+}
+
+// ToWorkflowLoadInfo converts thrift WorkflowLoadInfo type to internal
+func ToWorkflowLoadInfo(t *shared.WorkflowLoadInfo) *types.WorkflowLoadInfo {
+	if t == nil {
+		return nil
+	}
+	return &types.WorkflowLoadInfo{
+		DomainID:          t.GetDomainID(),
+		WorkflowID:        t.GetWorkflowID(),
+		ShardID:           t.GetShardID(),
+		RequestsPerSecond: t.GetRequestsPerSecond(),
+	}
+}
+
+// FromWorkflowLoadInfoArray converts internal WorkflowLoadInfo type array to thrift
+func FromWorkflowLoadInfoArray(t []*types.WorkflowLoadInfo) []*shared.WorkflowLoadInfo {
+	if t == nil {
+		return nil
+	}
+	v := make([]*shared.WorkflowLoadInfo, len(t))
+	for i := range t {
+		v[i] = FromWorkflowLoadInfo(t[i])
+	}
+	return v
+}
+
+// ToWorkflowLoadInfoArray converts thrift WorkflowLoadInfo type array to internal
+func ToWorkflowLoadInfoArray(t []*shared.WorkflowLoadInfo) []*types.WorkflowLoadInfo {
+	if t == nil {
+		return nil
+	}
+	v := make([]*types.WorkflowLoadInfo, len(t))
+	for i := range t {
+		v[i] = ToWorkflowLoadInfo(t[i])
+	}
+	return v
+}
+
 // FromDomainConfiguration converts internal DomainConfiguration type to thrift
 func FromDomainConfiguration(t *types.DomainConfiguration) *shared.DomainConfiguration {
 	if t == nil {
@@ -2248,6 +2778,12 @@ func FromEventType(t *types.EventType) *shared.EventType {
 	case types.EventTypeUpsertWorkflowSearchAttributes:
 		v := shared.EventTypeUpsertWorkflowSearchAttributes
 		return &v
+	case types.EventTypeActivityTaskOptionsUpdated:
+		v := shared.EventTypeActivityTaskOptionsUpdated
+		return &v
+	case types.EventTypeWorkflowNoProgressTimedOut:
+		v := shared.EventTypeWorkflowNoProgressTimedOut
+		return &v
 	}
 	panic("unexpected enum value")
 }
@@ -2384,6 +2920,12 @@ func ToEventType(t *shared.EventType) *types.EventType {
 	case shared.EventTypeUpsertWorkflowSearchAttributes:
 		v := types.EventTypeUpsertWorkflowSearchAttributes
 		return &v
+	case shared.EventTypeActivityTaskOptionsUpdated:
+		v := types.EventTypeActivityTaskOptionsUpdated
+		return &v
+	case shared.EventTypeWorkflowNoProgressTimedOut:
+		v := types.EventTypeWorkflowNoProgressTimedOut
+		return &v
 	}
 	panic("unexpected enum value")
 }
@@ -2679,6 +3221,8 @@ func FromHistoryEvent(t *types.HistoryEvent) *shared.HistoryEvent {
 		SignalExternalWorkflowExecutionFailedEventAttributes:           FromSignalExternalWorkflowExecutionFailedEventAttributes(t.SignalExternalWorkflowExecutionFailedEventAttributes),
 		ExternalWorkflowExecutionSignaledEventAttributes:               FromExternalWorkflowExecutionSignaledEventAttributes(t.ExternalWorkflowExecutionSignaledEventAttributes),
 		UpsertWorkflowSearchAttributesEventAttributes:                  FromUpsertWorkflowSearchAttributesEventAttributes(t.UpsertWorkflowSearchAttributesEventAttributes),
+		ActivityTaskOptionsUpdatedEventAttributes:                      FromActivityTaskOptionsUpdatedEventAttributes(t.ActivityTaskOptionsUpdatedEventAttributes),
+		WorkflowNoProgressTimedOutEventAttributes:                      FromWorkflowNoProgressTimedOutEventAttributes(t.WorkflowNoProgressTimedOutEventAttributes),
 	}
 }
 
@@ -2735,6 +3279,8 @@ func ToHistoryEvent(t *shared.HistoryEvent) *types.HistoryEvent {
 		SignalExternalWorkflowExecutionFailedEventAttributes:           ToSignalExternalWorkflowExecutionFailedEventAttributes(t.SignalExternalWorkflowExecutionFailedEventAttributes),
 		ExternalWorkflowExecutionSignaledEventAttributes:               ToExternalWorkflowExecutionSignaledEventAttributes(t.ExternalWorkflowExecutionSignaledEventAttributes),
 		UpsertWorkflowSearchAttributesEventAttributes:                  ToUpsertWorkflowSearchAttributesEventAttributes(t.UpsertWorkflowSearchAttributesEventAttributes),
+		ActivityTaskOptionsUpdatedEventAttributes:                      ToActivityTaskOptionsUpdatedEventAttributes(t.ActivityTaskOptionsUpdatedEventAttributes),
+		WorkflowNoProgressTimedOutEventAttributes:                      ToWorkflowNoProgressTimedOutEventAttributes(t.WorkflowNoProgressTimedOutEventAttributes),
 	}
 }
 
@@ -3274,6 +3820,38 @@ func ToMemo(t *shared.Memo) *types.Memo {
 	}
 }
 
+// FromNoProgressTimeoutPolicy converts internal NoProgressTimeoutPolicy type to thrift
+func FromNoProgressTimeoutPolicy(t *types.NoProgressTimeoutPolicy) *shared.NoProgressTimeoutPolicy {
+	if t == nil {
+		return nil
+	}
+	switch *t {
+	case types.NoProgressTimeoutPolicyNotify:
+		v := shared.NoProgressTimeoutPolicyNotify
+		return &v
+	case types.NoProgressTimeoutPolicyFail:
+		v := shared.NoProgressTimeoutPolicyFail
+		return &v
+	}
+	panic("unexpected enum value")
+}
+
+// ToNoProgressTimeoutPolicy converts thrift NoProgressTimeoutPolicy type to internal
+func ToNoProgressTimeoutPolicy(t *shared.NoProgressTimeoutPolicy) *types.NoProgressTimeoutPolicy {
+	if t == nil {
+		return nil
+	}
+	switch *t {
+	case shared.NoProgressTimeoutPolicyNotify:
+		v := types.NoProgressTimeoutPolicyNotify
+		return &v
+	case shared.NoProgressTimeoutPolicyFail:
+		v := types.NoProgressTimeoutPolicyFail
+		return &v
+	}
+	panic("unexpected enum value")
+}
+
 // FromParentClosePolicy converts internal ParentClosePolicy type to thrift
 func FromParentClosePolicy(t *types.ParentClosePolicy) *shared.ParentClosePolicy {
 	if t == nil {
@@ -5034,6 +5612,8 @@ func FromSignalWithStartWorkflowExecutionRequest(t *types.SignalWithStartWorkflo
 		Memo:                                FromMemo(t.Memo),
 		SearchAttributes:                    FromSearchAttributes(t.SearchAttributes),
 		Header:                              FromHeader(t.Header),
+		NoProgressTimeoutSeconds:            t.NoProgressTimeoutSeconds,
+		NoProgressTimeoutPolicy:             FromNoProgressTimeoutPolicy(t.NoProgressTimeoutPolicy),
 	}
 }
 
@@ -5061,6 +5641,8 @@ func ToSignalWithStartWorkflowExecutionRequest(t *shared.SignalWithStartWorkflow
 		Memo:                                ToMemo(t.Memo),
 		SearchAttributes:                    ToSearchAttributes(t.SearchAttributes),
 		Header:                              ToHeader(t.Header),
+		NoProgressTimeoutSeconds:            t.NoProgressTimeoutSeconds,
+		NoProgressTimeoutPolicy:             ToNoProgressTimeoutPolicy(t.NoProgressTimeoutPolicy),
 	}
 }
 
@@ -5357,6 +5939,8 @@ func FromStartWorkflowExecutionRequest(t *types.StartWorkflowExecutionRequest) *
 		Header:                              FromHeader(t.Header),
 		DelayStartSeconds:                   t.DelayStartSeconds,
 		JitterStartSeconds:                  t.JitterStartSeconds,
+		NoProgressTimeoutSeconds:            t.NoProgressTimeoutSeconds,
+		NoProgressTimeoutPolicy:             FromNoProgressTimeoutPolicy(t.NoProgressTimeoutPolicy),
 	}
 }
 
@@ -5383,6 +5967,8 @@ func ToStartWorkflowExecutionRequest(t *shared.StartWorkflowExecutionRequest) *t
 		Header:                              ToHeader(t.Header),
 		DelayStartSeconds:                   t.DelayStartSeconds,
 		JitterStartSeconds:                  t.JitterStartSeconds,
+		NoProgressTimeoutSeconds:            t.NoProgressTimeoutSeconds,
+		NoProgressTimeoutPolicy:             ToNoProgressTimeoutPolicy(t.NoProgressTimeoutPolicy),
 	}
 }
 
@@ -5816,6 +6402,140 @@ func ToTransientDecisionInfo(t *shared.TransientDecisionInfo) *types.TransientDe
 	}
 }
 
+// FromDeleteWorkflowExecutionRequest converts internal DeleteWorkflowExecutionRequest type to thrift
+func FromDeleteWorkflowExecutionRequest(t *types.DeleteWorkflowExecutionRequest) *shared.DeleteWorkflowExecutionRequest {
+	if t == nil {
+		return nil
+	}
+	return &shared.DeleteWorkflowExecutionRequest{
+		Domain:                &t.Domain,
+		WorkflowExecution:     FromWorkflowExecution(t.WorkflowExecution),
+		TerminateIfRunning:    &t.TerminateIfRunning,
+		IncludeChildWorkflows: &t.IncludeChildWorkflows,
+		Reason:                &t.Reason,
+		Identity:              &t.Identity,
+	}
+}
+
+// ToDeleteWorkflowExecutionRequest converts thrift DeleteWorkflowExecutionRequest type to internal
+func ToDeleteWorkflowExecutionRequest(t *shared.DeleteWorkflowExecutionRequest) *types.DeleteWorkflowExecutionRequest {
+	if t == nil {
+		return nil
+	}
+	return &types.DeleteWorkflowExecutionRequest{
+		Domain:                t.GetDomain(),
+		WorkflowExecution:     ToWorkflowExecution(t.WorkflowExecution),
+		TerminateIfRunning:    t.GetTerminateIfRunning(),
+		IncludeChildWorkflows: t.GetIncludeChildWorkflows(),
+		Reason:                t.GetReason(),
+		Identity:              t.GetIdentity(),
+	}
+}
+
+// FromDeleteWorkflowExecutionResponse converts internal DeleteWorkflowExecutionResponse type to thrift
+func FromDeleteWorkflowExecutionResponse(t *types.DeleteWorkflowExecutionResponse) *shared.DeleteWorkflowExecutionResponse {
+	if t == nil {
+		return nil
+	}
+	return &shared.DeleteWorkflowExecutionResponse{
+		ChildWorkflowExecutions: FromChildWorkflowExecutionInfoArray(t.ChildWorkflowExecutions),
+	}
+}
+
+// ToDeleteWorkflowExecutionResponse converts thrift DeleteWorkflowExecutionResponse type to internal
+func ToDeleteWorkflowExecutionResponse(t *shared.DeleteWorkflowExecutionResponse) *types.DeleteWorkflowExecutionResponse {
+	if t == nil {
+		return nil
+	}
+	return &types.DeleteWorkflowExecutionResponse{
+		ChildWorkflowExecutions: ToChildWorkflowExecutionInfoArray(t.ChildWorkflowExecutions),
+	}
+}
+
+// FromChildWorkflowExecutionInfo converts internal ChildWorkflowExecutionInfo type to thrift
+func FromChildWorkflowExecutionInfo(t *types.ChildWorkflowExecutionInfo) *shared.ChildWorkflowExecutionInfo {
+	if t == nil {
+		return nil
+	}
+	return &shared.ChildWorkflowExecutionInfo{
+		Domain:            &t.Domain,
+		WorkflowExecution: FromWorkflowExecution(t.WorkflowExecution),
+	}
+}
+
+// ToChildWorkflowExecutionInfo converts thrift ChildWorkflowExecutionInfo type to internal
+func ToChildWorkflowExecutionInfo(t *shared.ChildWorkflowExecutionInfo) *types.ChildWorkflowExecutionInfo {
+	if t == nil {
+		return nil
+	}
+	return &types.ChildWorkflowExecutionInfo{
+		Domain:            t.GetDomain(),
+		WorkflowExecution: ToWorkflowExecution(t.WorkflowExecution),
+	}
+}
+
+// FromChildWorkflowExecutionInfoArray converts internal ChildWorkflowExecutionInfo type array to thrift
+func FromChildWorkflowExecutionInfoArray(t []*types.ChildWorkflowExecutionInfo) []*shared.ChildWorkflowExecutionInfo {
+	if t == nil {
+		return nil
+	}
+	v := make([]*shared.ChildWorkflowExecutionInfo, len(t))
+	for i := range t {
+		v[i] = FromChildWorkflowExecutionInfo(t[i])
+	}
+	return v
+}
+
+// ToChildWorkflowExecutionInfoArray converts thrift ChildWorkflowExecutionInfo type array to internal
+func ToChildWorkflowExecutionInfoArray(t []*shared.ChildWorkflowExecutionInfo) []*types.ChildWorkflowExecutionInfo {
+	if t == nil {
+		return nil
+	}
+	v := make([]*types.ChildWorkflowExecutionInfo, len(t))
+	for i := range t {
+		v[i] = ToChildWorkflowExecutionInfo(t[i])
+	}
+	return v
+}
+
+// FromUpdateActivityOptionsRequest converts internal UpdateActivityOptionsRequest type to thrift
+func FromUpdateActivityOptionsRequest(t *types.UpdateActivityOptionsRequest) *shared.UpdateActivityOptionsRequest {
+	if t == nil {
+		return nil
+	}
+	return &shared.UpdateActivityOptionsRequest{
+		Domain:                        &t.Domain,
+		WorkflowExecution:             FromWorkflowExecution(t.WorkflowExecution),
+		ActivityId:                    &t.ActivityID,
+		ScheduleToCloseTimeoutSeconds: t.ScheduleToCloseTimeoutSeconds,
+		ScheduleToStartTimeoutSeconds: t.ScheduleToStartTimeoutSeconds,
+		StartToCloseTimeoutSeconds:    t.StartToCloseTimeoutSeconds,
+		HeartbeatTimeoutSeconds:       t.HeartbeatTimeoutSeconds,
+		RetryPolicy:                   FromRetryPolicy(t.RetryPolicy),
+		TaskList:                      FromTaskList(t.TaskList),
+		Identity:                      &t.Identity,
+	}
+}
+
+// ToUpdateActivityOptionsRequest converts thrift UpdateActivityOptionsRequest type to internal
+func ToUpdateActivityOptionsRequest(t *shared.UpdateActivityOptionsRequest) *types.UpdateActivityOptionsRequest {
+	if t == nil {
+		return nil
+	}
+	return &types.UpdateActivityOptionsRequest{
+		Domain:                        t.GetDomain(),
+		WorkflowExecution:             ToWorkflowExecution(t.WorkflowExecution),
+		ActivityID:                    t.GetActivityId(),
+		ScheduleToCloseTimeoutSeconds: t.ScheduleToCloseTimeoutSeconds,
+		ScheduleToStartTimeoutSeconds: t.ScheduleToStartTimeoutSeconds,
+		StartToCloseTimeoutSeconds:    t.StartToCloseTimeoutSeconds,
+		HeartbeatTimeoutSeconds:       t.HeartbeatTimeoutSeconds,
+		RetryPolicy:                   ToRetryPolicy(t.RetryPolicy),
+		TaskList:                      ToTaskList(t.TaskList),
+		Identity:                      t.GetIdentity(),
+	}
+}
+
 // FromUpdateDomainRequest converts internal UpdateDomainRequest type to thrift
 func FromUpdateDomainRequest(t *types.UpdateDomainRequest) *shared.UpdateDomainRequest {
 	if t == nil {
@@ -6457,6 +7177,8 @@ func FromWorkflowExecutionStartedEventAttributes(t *types.WorkflowExecutionStart
 		Header:                              FromHeader(t.Header),
 		PartitionConfig:                     t.PartitionConfig,
 		RequestId:                           &t.RequestID,
+		NoProgressTimeoutSeconds:            t.NoProgressTimeoutSeconds,
+		NoProgressTimeoutPolicy:             FromNoProgressTimeoutPolicy(t.NoProgressTimeoutPolicy),
 	}
 }
 
@@ -6495,6 +7217,8 @@ func ToWorkflowExecutionStartedEventAttributes(t *shared.WorkflowExecutionStarte
 		Header:                              ToHeader(t.Header),
 		PartitionConfig:                     t.PartitionConfig,
 		RequestID:                           t.GetRequestId(),
+		NoProgressTimeoutSeconds:            t.NoProgressTimeoutSeconds,
+		NoProgressTimeoutPolicy:             ToNoProgressTimeoutPolicy(t.NoProgressTimeoutPolicy),
 	}
 }
 
@@ -6586,6 +7310,30 @@ func ToWorkflowIDReusePolicy(t *shared.WorkflowIdReusePolicy) *types.WorkflowIDR
 	panic("unexpected enum value")
 }
 
+// FromWorkflowNoProgressTimedOutEventAttributes converts internal WorkflowNoProgressTimedOutEventAttributes type to thrift
+func FromWorkflowNoProgressTimedOutEventAttributes(t *types.WorkflowNoProgressTimedOutEventAttributes) *shared.WorkflowNoProgressTimedOutEventAttributes {
+	if t == nil {
+		return nil
+	}
+	return &shared.WorkflowNoProgressTimedOutEventAttributes{
+		NoProgressTimeoutSeconds: &t.NoProgressTimeoutSeconds,
+		NoProgressTimeoutPolicy:  FromNoProgressTimeoutPolicy(t.NoProgressTimeoutPolicy),
+		LastProgressTimestamp:    t.LastProgressTimestamp,
+	}
+}
+
+// ToWorkflowNoProgressTimedOutEventAttributes converts thrift WorkflowNoProgressTimedOutEventAttributes type to internal
+func ToWorkflowNoProgressTimedOutEventAttributes(t *shared.WorkflowNoProgressTimedOutEventAttributes) *types.WorkflowNoProgressTimedOutEventAttributes {
+	if t == nil {
+		return nil
+	}
+	return &types.WorkflowNoProgressTimedOutEventAttributes{
+		NoProgressTimeoutSeconds: t.GetNoProgressTimeoutSeconds(),
+		NoProgressTimeoutPolicy:  ToNoProgressTimeoutPolicy(t.NoProgressTimeoutPolicy),
+		LastProgressTimestamp:    t.LastProgressTimestamp,
+	}
+}
+
 // FromWorkflowQuery converts internal WorkflowQuery type to thrift
 func FromWorkflowQuery(t *types.WorkflowQuery) *shared.WorkflowQuery {
 	if t == nil {
@@ -6632,6 +7380,36 @@ func ToWorkflowQueryResult(t *shared.WorkflowQueryResult) *types.WorkflowQueryRe
 	}
 }
 
+// FromWorkflowSizeLimits converts internal WorkflowSizeLimits type to thrift
+func FromWorkflowSizeLimits(t *types.WorkflowSizeLimits) *shared.WorkflowSizeLimits {
+	if t == nil {
+		return nil
+	}
+	return &shared.WorkflowSizeLimits{
+		BlobSizeLimitWarn:      &t.BlobSizeLimitWarn,
+		BlobSizeLimitError:     &t.BlobSizeLimitError,
+		HistorySizeLimitWarn:   &t.HistorySizeLimitWarn,
+		HistorySizeLimitError:  &t.HistorySizeLimitError,
+		HistoryCountLimitWarn:  &t.HistoryCountLimitWarn,
+		HistoryCountLimitError: &t.HistoryCountLimitError,
+	}
+}
+
+// ToWorkflowSizeLimits converts thrift WorkflowSizeLimits type to internal
+func ToWorkflowSizeLimits(t *shared.WorkflowSizeLimits) *types.WorkflowSizeLimits {
+	if t == nil {
+		return nil
+	}
+	return &types.WorkflowSizeLimits{
+		BlobSizeLimitWarn:      t.GetBlobSizeLimitWarn(),
+		BlobSizeLimitError:     t.GetBlobSizeLimitError(),
+		HistorySizeLimitWarn:   t.GetHistorySizeLimitWarn(),
+		HistorySizeLimitError:  t.GetHistorySizeLimitError(),
+		HistoryCountLimitWarn:  t.GetHistoryCountLimitWarn(),
+		HistoryCountLimitError: t.GetHistoryCountLimitError(),
+	}
+}
+
 // FromWorkflowType converts internal WorkflowType type to thrift
 func FromWorkflowType(t *types.WorkflowType) *shared.WorkflowType {
 	if t == nil {
@@ -46,6 +46,18 @@ func TestCrossClusterTaskInfoConversion(t *testing.T) {
 	}
 }
 
+func TestDeleteWorkflowExecutionRequestConversion(t *testing.T) {
+	for _, item := range []*types.DeleteWorkflowExecutionRequest{nil, {}, &testdata.DeleteWorkflowExecutionRequest} {
+		assert.Equal(t, item, ToDeleteWorkflowExecutionRequest(FromDeleteWorkflowExecutionRequest(item)))
+	}
+}
+
+func TestDeleteWorkflowExecutionResponseConversion(t *testing.T) {
+	for _, item := range []*types.DeleteWorkflowExecutionResponse{nil, {}, &testdata.DeleteWorkflowExecutionResponse} {
+		assert.Equal(t, item, ToDeleteWorkflowExecutionResponse(FromDeleteWorkflowExecutionResponse(item)))
+	}
+}
+
 func TestCrossClusterTaskRequestConversion(t *testing.T) {
 	for _, item := range []*types.CrossClusterTaskRequest{
 		nil,
@@ -1030,6 +1042,7 @@ func TestDescribeHistoryHostResponseConversion(t *testing.T) {
 		nil,
 		{},
 		{NumberOfShards: 10, ShardIDs: []int32{1, 2, 3}, Address: "test-address"},
+		&testdata.HistoryDescribeHistoryHostResponse,
 	}
 
 	for _, original := range testCases {
@@ -1081,6 +1094,48 @@ func TestDescribeQueueResponseConversion(t *testing.T) {
 	}
 }
 
+func TestListQueueTasksRequestConversion(t *testing.T) {
+	testCases := []*types.ListQueueTasksRequest{
+		nil,
+		{},
+		&testdata.AdminListQueueTasksRequest,
+	}
+
+	for _, original := range testCases {
+		thriftObj := FromAdminListQueueTasksRequest(original)
+		roundTripObj := ToAdminListQueueTasksRequest(thriftObj)
+		assert.Equal(t, original, roundTripObj)
+	}
+}
+
+func TestListQueueTasksResponseConversion(t *testing.T) {
+	testCases := []*types.ListQueueTasksResponse{
+		nil,
+		{},
+		&testdata.AdminListQueueTasksResponse,
+	}
+
+	for _, original := range testCases {
+		thriftObj := FromAdminListQueueTasksResponse(original)
+		roundTripObj := ToAdminListQueueTasksResponse(thriftObj)
+		assert.Equal(t, original, roundTripObj)
+	}
+}
+
+func TestExecuteQueueTaskRequestConversion(t *testing.T) {
+	testCases := []*types.ExecuteQueueTaskRequest{
+		nil,
+		{},
+		&testdata.AdminExecuteQueueTaskRequest,
+	}
+
+	for _, original := range testCases {
+		thriftObj := FromAdminExecuteQueueTaskRequest(original)
+		roundTripObj := ToAdminExecuteQueueTaskRequest(thriftObj)
+		assert.Equal(t, original, roundTripObj)
+	}
+}
+
 func TestDescribeTaskListRequestConversion(t *testing.T) {
 	testCases := []*types.DescribeTaskListRequest{
 		nil,
@@ -1128,6 +1183,7 @@ func TestDescribeWorkflowExecutionResponseConversion(t *testing.T) {
 		nil,
 		{},
 		&testdata.DescribeWorkflowExecutionResponse,
+		&testdata.HistoryDescribeWorkflowExecutionResponse,
 	}
 
 	for _, original := range testCases {
@@ -1165,6 +1221,34 @@ func TestDomainCacheInfoConversion(t *testing.T) {
 	}
 }
 
+func TestCacheInfoConversion(t *testing.T) {
+	testCases := []*types.CacheInfo{
+		nil,
+		{},
+		&testdata.CacheInfo,
+	}
+
+	for _, original := range testCases {
+		thriftObj := FromCacheInfo(original)
+		roundTripObj := ToCacheInfo(thriftObj)
+		assert.Equal(t, original, roundTripObj)
+	}
+}
+
+func TestHostLoadInfoConversion(t *testing.T) {
+	testCases := []*types.HostLoadInfo{
+		nil,
+		{},
+		&testdata.HostLoadInfo,
+	}
+
+	for _, original := range testCases {
+		thriftObj := FromHostLoadInfo(original)
+		roundTripObj := ToHostLoadInfo(thriftObj)
+		assert.Equal(t, original, roundTripObj)
+	}
+}
+
 func TestDomainConfigurationConversion(t *testing.T) {
 	testCases := []*types.DomainConfiguration{
 		nil,
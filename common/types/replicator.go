@@ -42,6 +42,8 @@ func (e DLQType) String() string {
 		return "Replication"
 	case 1:
 		return "Domain"
+	case 2:
+		return "HistoryTask"
 	}
 	return fmt.Sprintf("DLQType(%d)", w)
 }
@@ -55,6 +57,9 @@ func (e *DLQType) UnmarshalText(value []byte) error {
 	case "DOMAIN":
 		*e = DLQTypeDomain
 		return nil
+	case "HISTORYTASK":
+		*e = DLQTypeHistoryTask
+		return nil
 	default:
 		val, err := strconv.ParseInt(s, 10, 32)
 		if err != nil {
@@ -75,6 +80,8 @@ const (
 	DLQTypeReplication DLQType = iota
 	// DLQTypeDomain is an option for DLQType
 	DLQTypeDomain
+	// DLQTypeHistoryTask is an option for DLQType
+	DLQTypeHistoryTask
 )
 
 // DomainOperation is an internal type (TBD...)
@@ -595,10 +602,36 @@ func (v *ReadDLQMessagesRequest) GetNextPageToken() (o []byte) {
 
 // ReadDLQMessagesResponse is an internal type (TBD...)
 type ReadDLQMessagesResponse struct {
-	Type                 *DLQType               `json:"type,omitempty"`
-	ReplicationTasks     []*ReplicationTask     `json:"replicationTasks,omitempty"`
-	ReplicationTasksInfo []*ReplicationTaskInfo `json:"replicationTasksInfo,omitempty"`
-	NextPageToken        []byte                 `json:"nextPageToken,omitempty"`
+	Type                 *DLQType                 `json:"type,omitempty"`
+	ReplicationTasks     []*ReplicationTask       `json:"replicationTasks,omitempty"`
+	ReplicationTasksInfo []*ReplicationTaskInfo   `json:"replicationTasksInfo,omitempty"`
+	HistoryTasks         []*HistoryTaskDLQMessage `json:"historyTasks,omitempty"`
+	NextPageToken        []byte                   `json:"nextPageToken,omitempty"`
+}
+
+// HistoryTaskDLQMessage is an internal type (TBD...)
+type HistoryTaskDLQMessage struct {
+	MessageID         int64          `json:"messageID,omitempty"`
+	ShardID           int32          `json:"shardID,omitempty"`
+	TaskCategory      int32          `json:"taskCategory,omitempty"`
+	Task              *QueueTaskInfo `json:"task,omitempty"`
+	EnqueuedTimestamp int64          `json:"enqueuedTimestamp,omitempty"`
+}
+
+// GetShardID is an internal getter (TBD...)
+func (v *HistoryTaskDLQMessage) GetShardID() (o int32) {
+	if v != nil {
+		return v.ShardID
+	}
+	return
+}
+
+// GetTask is an internal getter (TBD...)
+func (v *HistoryTaskDLQMessage) GetTask() (o *QueueTaskInfo) {
+	if v != nil && v.Task != nil {
+		return v.Task
+	}
+	return
 }
 
 // ReplicationMessages is an internal type (TBD...)
@@ -81,6 +81,42 @@ func (v *ActivityTaskCanceledEventAttributes) GetScheduledEventID() (o int64) {
 	return
 }
 
+// ActivityTaskOptionsUpdatedEventAttributes is an internal type (TBD...)
+type ActivityTaskOptionsUpdatedEventAttributes struct {
+	ScheduledEventID              int64        `json:"scheduledEventId,omitempty"`
+	ScheduleToCloseTimeoutSeconds *int32       `json:"scheduleToCloseTimeoutSeconds,omitempty"`
+	ScheduleToStartTimeoutSeconds *int32       `json:"scheduleToStartTimeoutSeconds,omitempty"`
+	StartToCloseTimeoutSeconds    *int32       `json:"startToCloseTimeoutSeconds,omitempty"`
+	HeartbeatTimeoutSeconds       *int32       `json:"heartbeatTimeoutSeconds,omitempty"`
+	RetryPolicy                   *RetryPolicy `json:"retryPolicy,omitempty"`
+	TaskList                      *TaskList    `json:"taskList,omitempty"`
+	Identity                      string       `json:"identity,omitempty"`
+}
+
+// GetScheduledEventID is an internal getter (TBD...)
+func (v *ActivityTaskOptionsUpdatedEventAttributes) GetScheduledEventID() (o int64) {
+	if v != nil {
+		return v.ScheduledEventID
+	}
+	return
+}
+
+// GetRetryPolicy is an internal getter (TBD...)
+func (v *ActivityTaskOptionsUpdatedEventAttributes) GetRetryPolicy() (o *RetryPolicy) {
+	if v != nil && v.RetryPolicy != nil {
+		return v.RetryPolicy
+	}
+	return
+}
+
+// GetTaskList is an internal getter (TBD...)
+func (v *ActivityTaskOptionsUpdatedEventAttributes) GetTaskList() (o *TaskList) {
+	if v != nil && v.TaskList != nil {
+		return v.TaskList
+	}
+	return
+}
+
 // ActivityTaskCompletedEventAttributes is an internal type (TBD...)
 type ActivityTaskCompletedEventAttributes struct {
 	Result           []byte `json:"result,omitempty"`
@@ -1520,6 +1556,106 @@ func (v *DeprecateDomainRequest) GetName() (o string) {
 	return
 }
 
+// DeleteWorkflowExecutionRequest is an internal type (TBD...)
+type DeleteWorkflowExecutionRequest struct {
+	Domain                string             `json:"domain,omitempty"`
+	WorkflowExecution     *WorkflowExecution `json:"workflowExecution,omitempty"`
+	TerminateIfRunning    bool               `json:"terminateIfRunning,omitempty"`
+	IncludeChildWorkflows bool               `json:"includeChildWorkflows,omitempty"`
+	Reason                string             `json:"reason,omitempty"`
+	Identity              string             `json:"identity,omitempty"`
+}
+
+func (v *DeleteWorkflowExecutionRequest) SerializeForLogging() (string, error) {
+	if v == nil {
+		return "", nil
+	}
+	return SerializeRequest(v)
+}
+
+// GetDomain is an internal getter (TBD...)
+func (v *DeleteWorkflowExecutionRequest) GetDomain() (o string) {
+	if v != nil {
+		return v.Domain
+	}
+	return
+}
+
+// GetWorkflowExecution is an internal getter (TBD...)
+func (v *DeleteWorkflowExecutionRequest) GetWorkflowExecution() (o *WorkflowExecution) {
+	if v != nil && v.WorkflowExecution != nil {
+		return v.WorkflowExecution
+	}
+	return
+}
+
+// GetTerminateIfRunning is an internal getter (TBD...)
+func (v *DeleteWorkflowExecutionRequest) GetTerminateIfRunning() (o bool) {
+	if v != nil {
+		return v.TerminateIfRunning
+	}
+	return
+}
+
+// GetIncludeChildWorkflows is an internal getter (TBD...)
+func (v *DeleteWorkflowExecutionRequest) GetIncludeChildWorkflows() (o bool) {
+	if v != nil {
+		return v.IncludeChildWorkflows
+	}
+	return
+}
+
+// GetReason is an internal getter (TBD...)
+func (v *DeleteWorkflowExecutionRequest) GetReason() (o string) {
+	if v != nil {
+		return v.Reason
+	}
+	return
+}
+
+// GetIdentity is an internal getter (TBD...)
+func (v *DeleteWorkflowExecutionRequest) GetIdentity() (o string) {
+	if v != nil {
+		return v.Identity
+	}
+	return
+}
+
+// DeleteWorkflowExecutionResponse is an internal type (TBD...)
+type DeleteWorkflowExecutionResponse struct {
+	ChildWorkflowExecutions []*ChildWorkflowExecutionInfo `json:"childWorkflowExecutions,omitempty"`
+}
+
+// GetChildWorkflowExecutions is an internal getter (TBD...)
+func (v *DeleteWorkflowExecutionResponse) GetChildWorkflowExecutions() (o []*ChildWorkflowExecutionInfo) {
+	if v != nil && v.ChildWorkflowExecutions != nil {
+		return v.ChildWorkflowExecutions
+	}
+	return
+}
+
+// ChildWorkflowExecutionInfo is an internal type (TBD...)
+type ChildWorkflowExecutionInfo struct {
+	Domain            string             `json:"domain,omitempty"`
+	WorkflowExecution *WorkflowExecution `json:"workflowExecution,omitempty"`
+}
+
+// GetDomain is an internal getter (TBD...)
+func (v *ChildWorkflowExecutionInfo) GetDomain() (o string) {
+	if v != nil {
+		return v.Domain
+	}
+	return
+}
+
+// GetWorkflowExecution is an internal getter (TBD...)
+func (v *ChildWorkflowExecutionInfo) GetWorkflowExecution() (o *WorkflowExecution) {
+	if v != nil && v.WorkflowExecution != nil {
+		return v.WorkflowExecution
+	}
+	return
+}
+
 // DescribeDomainRequest is an internal type (TBD...)
 type DescribeDomainRequest struct {
 	Name *string `json:"name,omitempty"`
@@ -1647,6 +1783,9 @@ type DescribeHistoryHostResponse struct {
 	DomainCache           *DomainCacheInfo `json:"domainCache,omitempty"`
 	ShardControllerStatus string           `json:"shardControllerStatus,omitempty"`
 	Address               string           `json:"address,omitempty"`
+	ExecutionCache        *CacheInfo       `json:"executionCache,omitempty"`
+	EventsCache           *CacheInfo       `json:"eventsCache,omitempty"`
+	Load                  *HostLoadInfo    `json:"load,omitempty"`
 }
 
 // DescribeQueueRequest is an internal type (TBD...)
@@ -1692,6 +1831,163 @@ type DescribeQueueResponse struct {
 	ProcessingQueueStates []string `json:"processingQueueStates,omitempty"`
 }
 
+// ListQueueTasksRequest is an internal type (TBD...)
+type ListQueueTasksRequest struct {
+	ShardID                int32  `json:"shardID,omitempty"`
+	ClusterName            string `json:"clusterName,omitempty"`
+	Type                   *int32 `json:"type,omitempty"`
+	MinTaskID              *int64 `json:"minTaskID,omitempty"`
+	MaxTaskID              *int64 `json:"maxTaskID,omitempty"`
+	MinVisibilityTimestamp *int64 `json:"minVisibilityTimestamp,omitempty"`
+	MaxVisibilityTimestamp *int64 `json:"maxVisibilityTimestamp,omitempty"`
+	PageSize               int32  `json:"pageSize,omitempty"`
+}
+
+func (v *ListQueueTasksRequest) SerializeForLogging() (string, error) {
+	if v == nil {
+		return "", nil
+	}
+	return SerializeRequest(v)
+}
+
+// GetShardID is an internal getter (TBD...)
+func (v *ListQueueTasksRequest) GetShardID() (o int32) {
+	if v != nil {
+		return v.ShardID
+	}
+	return
+}
+
+// GetClusterName is an internal getter (TBD...)
+func (v *ListQueueTasksRequest) GetClusterName() (o string) {
+	if v != nil {
+		return v.ClusterName
+	}
+	return
+}
+
+// GetType is an internal getter (TBD...)
+func (v *ListQueueTasksRequest) GetType() (o int32) {
+	if v != nil && v.Type != nil {
+		return *v.Type
+	}
+	return
+}
+
+// GetMinTaskID is an internal getter (TBD...)
+func (v *ListQueueTasksRequest) GetMinTaskID() (o int64) {
+	if v != nil && v.MinTaskID != nil {
+		return *v.MinTaskID
+	}
+	return
+}
+
+// GetMaxTaskID is an internal getter (TBD...)
+func (v *ListQueueTasksRequest) GetMaxTaskID() (o int64) {
+	if v != nil && v.MaxTaskID != nil {
+		return *v.MaxTaskID
+	}
+	return
+}
+
+// GetMinVisibilityTimestamp is an internal getter (TBD...)
+func (v *ListQueueTasksRequest) GetMinVisibilityTimestamp() (o int64) {
+	if v != nil && v.MinVisibilityTimestamp != nil {
+		return *v.MinVisibilityTimestamp
+	}
+	return
+}
+
+// GetMaxVisibilityTimestamp is an internal getter (TBD...)
+func (v *ListQueueTasksRequest) GetMaxVisibilityTimestamp() (o int64) {
+	if v != nil && v.MaxVisibilityTimestamp != nil {
+		return *v.MaxVisibilityTimestamp
+	}
+	return
+}
+
+// GetPageSize is an internal getter (TBD...)
+func (v *ListQueueTasksRequest) GetPageSize() (o int32) {
+	if v != nil {
+		return v.PageSize
+	}
+	return
+}
+
+// ListQueueTasksResponse is an internal type (TBD...)
+type ListQueueTasksResponse struct {
+	Tasks []*QueueTaskInfo `json:"tasks,omitempty"`
+}
+
+// GetTasks is an internal getter (TBD...)
+func (v *ListQueueTasksResponse) GetTasks() (o []*QueueTaskInfo) {
+	if v != nil && v.Tasks != nil {
+		return v.Tasks
+	}
+	return
+}
+
+// QueueTaskInfo is an internal type (TBD...)
+type QueueTaskInfo struct {
+	DomainID            string `json:"domainID,omitempty"`
+	WorkflowID          string `json:"workflowID,omitempty"`
+	RunID               string `json:"runID,omitempty"`
+	TaskID              int64  `json:"taskID,omitempty"`
+	TaskType            int32  `json:"taskType,omitempty"`
+	VisibilityTimestamp int64  `json:"visibilityTimestamp,omitempty"`
+	Version             int64  `json:"version,omitempty"`
+	State               string `json:"state,omitempty"`
+	Attempt             int32  `json:"attempt,omitempty"`
+	LastError           string `json:"lastError,omitempty"`
+}
+
+// ExecuteQueueTaskRequest is an internal type (TBD...)
+type ExecuteQueueTaskRequest struct {
+	ShardID     int32  `json:"shardID,omitempty"`
+	ClusterName string `json:"clusterName,omitempty"`
+	Type        *int32 `json:"type,omitempty"`
+	TaskID      int64  `json:"taskID,omitempty"`
+}
+
+func (v *ExecuteQueueTaskRequest) SerializeForLogging() (string, error) {
+	if v == nil {
+		return "", nil
+	}
+	return SerializeRequest(v)
+}
+
+// GetShardID is an internal getter (TBD...)
+func (v *ExecuteQueueTaskRequest) GetShardID() (o int32) {
+	if v != nil {
+		return v.ShardID
+	}
+	return
+}
+
+// GetClusterName is an internal getter (TBD...)
+func (v *ExecuteQueueTaskRequest) GetClusterName() (o string) {
+	if v != nil {
+		return v.ClusterName
+	}
+	return
+}
+
+// GetType is an internal getter (TBD...)
+func (v *ExecuteQueueTaskRequest) GetType() (o int32) {
+	if v != nil && v.Type != nil {
+		return *v.Type
+	}
+	return
+}
+
+// GetTaskID is an internal getter (TBD...)
+func (v *ExecuteQueueTaskRequest) GetTaskID() (o int64) {
+	if v != nil {
+		return v.TaskID
+	}
+	return
+}
+
 // DescribeTaskListRequest is an internal type (TBD...)
 type DescribeTaskListRequest struct {
 	Domain                string        `json:"domain,omitempty"`
@@ -1797,6 +2093,15 @@ type DescribeWorkflowExecutionResponse struct {
 	PendingActivities      []*PendingActivityInfo          `json:"pendingActivities,omitempty"`
 	PendingChildren        []*PendingChildExecutionInfo    `json:"pendingChildren,omitempty"`
 	PendingDecision        *PendingDecisionInfo            `json:"pendingDecision,omitempty"`
+	WorkflowSizeLimits     *WorkflowSizeLimits             `json:"workflowSizeLimits,omitempty"`
+}
+
+// GetWorkflowSizeLimits is an internal getter (TBD...)
+func (v *DescribeWorkflowExecutionResponse) GetWorkflowSizeLimits() (o *WorkflowSizeLimits) {
+	if v != nil && v.WorkflowSizeLimits != nil {
+		return v.WorkflowSizeLimits
+	}
+	return
 }
 
 // GetWorkflowExecutionInfo is an internal getter (TBD...)
@@ -1826,6 +2131,40 @@ type DomainCacheInfo struct {
 	NumOfItemsInCacheByName int64 `json:"numOfItemsInCacheByName,omitempty"`
 }
 
+// CacheInfo is an internal type (TBD...)
+type CacheInfo struct {
+	NumOfItems       int64 `json:"numOfItems,omitempty"`
+	NumOfPinnedItems int64 `json:"numOfPinnedItems,omitempty"`
+	SizeInBytes      int64 `json:"sizeInBytes,omitempty"`
+	NumOfHits        int64 `json:"numOfHits,omitempty"`
+	NumOfMisses      int64 `json:"numOfMisses,omitempty"`
+}
+
+// HostLoadInfo is an internal type (TBD...)
+type HostLoadInfo struct {
+	RequestsPerSecond float64             `json:"requestsPerSecond,omitempty"`
+	CPUUtilization    float64             `json:"cpuUtilization,omitempty"`
+	PendingTasks      int64               `json:"pendingTasks,omitempty"`
+	Overloaded        bool                `json:"overloaded,omitempty"`
+	HotShards         []*ShardLoadInfo    `json:"hotShards,omitempty"`
+	HotWorkflows      []*WorkflowLoadInfo `json:"hotWorkflows,omitempty"`
+}
+
+// ShardLoadInfo is an internal type (TBD...)
+type ShardLoadInfo struct {
+	ShardID           int32   `json:"shardID,omitempty"`
+	RequestsPerSecond float64 `json:"requestsPerSecond,omitempty"`
+	PendingTasks      int64   `json:"pendingTasks,omitempty"`
+}
+
+// WorkflowLoadInfo is an internal type (TBD...)
+type WorkflowLoadInfo struct {
+	DomainID          string  `json:"domainID,omitempty"`
+	WorkflowID        string  `json:"workflowID,omitempty"`
+	ShardID           int32   `json:"shardID,omitempty"`
+	RequestsPerSecond float64 `json:"requestsPerSecond,omitempty"`
+}
+
 // DomainConfiguration is an internal type (TBD...)
 type DomainConfiguration struct {
 	WorkflowExecutionRetentionPeriodInDays int32                        `json:"workflowExecutionRetentionPeriodInDays,omitempty"`
@@ -2231,6 +2570,10 @@ func (e EventType) String() string {
 		return "ExternalWorkflowExecutionSignaled"
 	case 41:
 		return "UpsertWorkflowSearchAttributes"
+	case 42:
+		return "ActivityTaskOptionsUpdated"
+	case 43:
+		return "WorkflowNoProgressTimedOut"
 	}
 	return fmt.Sprintf("EventType(%d)", w)
 }
@@ -2364,6 +2707,12 @@ func (e *EventType) UnmarshalText(value []byte) error {
 	case "UPSERTWORKFLOWSEARCHATTRIBUTES":
 		*e = EventTypeUpsertWorkflowSearchAttributes
 		return nil
+	case "ACTIVITYTASKOPTIONSUPDATED":
+		*e = EventTypeActivityTaskOptionsUpdated
+		return nil
+	case "WORKFLOWNOPROGRESSTIMEDOUT":
+		*e = EventTypeWorkflowNoProgressTimedOut
+		return nil
 	default:
 		val, err := strconv.ParseInt(s, 10, 32)
 		if err != nil {
@@ -2464,6 +2813,10 @@ const (
 	EventTypeExternalWorkflowExecutionSignaled
 	// EventTypeUpsertWorkflowSearchAttributes is an option for EventType
 	EventTypeUpsertWorkflowSearchAttributes
+	// EventTypeActivityTaskOptionsUpdated is an option for EventType
+	EventTypeActivityTaskOptionsUpdated
+	// EventTypeWorkflowNoProgressTimedOut is an option for EventType
+	EventTypeWorkflowNoProgressTimedOut
 )
 
 // ExternalWorkflowExecutionCancelRequestedEventAttributes is an internal type (TBD...)
@@ -2768,6 +3121,8 @@ type HistoryEvent struct {
 	SignalExternalWorkflowExecutionFailedEventAttributes           *SignalExternalWorkflowExecutionFailedEventAttributes           `json:"signalExternalWorkflowExecutionFailedEventAttributes,omitempty"`
 	ExternalWorkflowExecutionSignaledEventAttributes               *ExternalWorkflowExecutionSignaledEventAttributes               `json:"externalWorkflowExecutionSignaledEventAttributes,omitempty"`
 	UpsertWorkflowSearchAttributesEventAttributes                  *UpsertWorkflowSearchAttributesEventAttributes                  `json:"upsertWorkflowSearchAttributesEventAttributes,omitempty"`
+	ActivityTaskOptionsUpdatedEventAttributes                      *ActivityTaskOptionsUpdatedEventAttributes                      `json:"activityTaskOptionsUpdatedEventAttributes,omitempty"`
+	WorkflowNoProgressTimedOutEventAttributes                      *WorkflowNoProgressTimedOutEventAttributes                      `json:"workflowNoProgressTimedOutEventAttributes,omitempty"`
 }
 
 // GetTimestamp is an internal getter (TBD...)
@@ -3122,6 +3477,22 @@ func (v *HistoryEvent) GetUpsertWorkflowSearchAttributesEventAttributes() (o *Up
 	return
 }
 
+// GetActivityTaskOptionsUpdatedEventAttributes is an internal getter (TBD...)
+func (v *HistoryEvent) GetActivityTaskOptionsUpdatedEventAttributes() (o *ActivityTaskOptionsUpdatedEventAttributes) {
+	if v != nil && v.ActivityTaskOptionsUpdatedEventAttributes != nil {
+		return v.ActivityTaskOptionsUpdatedEventAttributes
+	}
+	return
+}
+
+// GetWorkflowNoProgressTimedOutEventAttributes is an internal getter (TBD...)
+func (v *HistoryEvent) GetWorkflowNoProgressTimedOutEventAttributes() (o *WorkflowNoProgressTimedOutEventAttributes) {
+	if v != nil && v.WorkflowNoProgressTimedOutEventAttributes != nil {
+		return v.WorkflowNoProgressTimedOutEventAttributes
+	}
+	return
+}
+
 // HistoryEventFilterType is an internal type (TBD...)
 type HistoryEventFilterType int32
 
@@ -3699,6 +4070,57 @@ const (
 	ParentClosePolicyTerminate
 )
 
+// NoProgressTimeoutPolicy is an internal type (TBD...)
+type NoProgressTimeoutPolicy int32
+
+// Ptr is a helper function for getting pointer value
+func (e NoProgressTimeoutPolicy) Ptr() *NoProgressTimeoutPolicy {
+	return &e
+}
+
+// String returns a readable string representation of NoProgressTimeoutPolicy.
+func (e NoProgressTimeoutPolicy) String() string {
+	w := int32(e)
+	switch w {
+	case 0:
+		return "NOTIFY"
+	case 1:
+		return "FAIL"
+	}
+	return fmt.Sprintf("NoProgressTimeoutPolicy(%d)", w)
+}
+
+// UnmarshalText parses enum value from string representation
+func (e *NoProgressTimeoutPolicy) UnmarshalText(value []byte) error {
+	switch s := strings.ToUpper(string(value)); s {
+	case "NOTIFY":
+		*e = NoProgressTimeoutPolicyNotify
+		return nil
+	case "FAIL":
+		*e = NoProgressTimeoutPolicyFail
+		return nil
+	default:
+		val, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", s, "NoProgressTimeoutPolicy", err)
+		}
+		*e = NoProgressTimeoutPolicy(val)
+		return nil
+	}
+}
+
+// MarshalText encodes NoProgressTimeoutPolicy to text.
+func (e NoProgressTimeoutPolicy) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+const (
+	// NoProgressTimeoutPolicyNotify is an option for NoProgressTimeoutPolicy
+	NoProgressTimeoutPolicyNotify NoProgressTimeoutPolicy = iota
+	// NoProgressTimeoutPolicyFail is an option for NoProgressTimeoutPolicy
+	NoProgressTimeoutPolicyFail
+)
+
 // PendingActivityInfo is an internal type (TBD...)
 type PendingActivityInfo struct {
 	ActivityID             string                `json:"activityID,omitempty"`
@@ -5840,26 +6262,28 @@ func (v *SignalExternalWorkflowExecutionInitiatedEventAttributes) GetChildWorkfl
 
 // SignalWithStartWorkflowExecutionRequest is an internal type (TBD...)
 type SignalWithStartWorkflowExecutionRequest struct {
-	Domain                              string                 `json:"domain,omitempty"`
-	WorkflowID                          string                 `json:"workflowId,omitempty"`
-	WorkflowType                        *WorkflowType          `json:"workflowType,omitempty"`
-	TaskList                            *TaskList              `json:"taskList,omitempty"`
-	Input                               []byte                 `json:"-"` // Filtering PII
-	ExecutionStartToCloseTimeoutSeconds *int32                 `json:"executionStartToCloseTimeoutSeconds,omitempty"`
-	TaskStartToCloseTimeoutSeconds      *int32                 `json:"taskStartToCloseTimeoutSeconds,omitempty"`
-	Identity                            string                 `json:"identity,omitempty"`
-	RequestID                           string                 `json:"requestId,omitempty"`
-	WorkflowIDReusePolicy               *WorkflowIDReusePolicy `json:"workflowIdReusePolicy,omitempty"`
-	SignalName                          string                 `json:"signalName,omitempty"`
-	SignalInput                         []byte                 `json:"-"` // Filtering PII
-	Control                             []byte                 `json:"control,omitempty"`
-	RetryPolicy                         *RetryPolicy           `json:"retryPolicy,omitempty"`
-	CronSchedule                        string                 `json:"cronSchedule,omitempty"`
-	Memo                                *Memo                  `json:"-"` // Filtering PII
-	SearchAttributes                    *SearchAttributes      `json:"-"` // Filtering PII
-	Header                              *Header                `json:"header,omitempty"`
-	DelayStartSeconds                   *int32                 `json:"delayStartSeconds,omitempty"`
-	JitterStartSeconds                  *int32                 `json:"jitterStartSeconds,omitempty"`
+	Domain                              string                   `json:"domain,omitempty"`
+	WorkflowID                          string                   `json:"workflowId,omitempty"`
+	WorkflowType                        *WorkflowType            `json:"workflowType,omitempty"`
+	TaskList                            *TaskList                `json:"taskList,omitempty"`
+	Input                               []byte                   `json:"-"` // Filtering PII
+	ExecutionStartToCloseTimeoutSeconds *int32                   `json:"executionStartToCloseTimeoutSeconds,omitempty"`
+	TaskStartToCloseTimeoutSeconds      *int32                   `json:"taskStartToCloseTimeoutSeconds,omitempty"`
+	Identity                            string                   `json:"identity,omitempty"`
+	RequestID                           string                   `json:"requestId,omitempty"`
+	WorkflowIDReusePolicy               *WorkflowIDReusePolicy   `json:"workflowIdReusePolicy,omitempty"`
+	SignalName                          string                   `json:"signalName,omitempty"`
+	SignalInput                         []byte                   `json:"-"` // Filtering PII
+	Control                             []byte                   `json:"control,omitempty"`
+	RetryPolicy                         *RetryPolicy             `json:"retryPolicy,omitempty"`
+	CronSchedule                        string                   `json:"cronSchedule,omitempty"`
+	Memo                                *Memo                    `json:"-"` // Filtering PII
+	SearchAttributes                    *SearchAttributes        `json:"-"` // Filtering PII
+	Header                              *Header                  `json:"header,omitempty"`
+	DelayStartSeconds                   *int32                   `json:"delayStartSeconds,omitempty"`
+	JitterStartSeconds                  *int32                   `json:"jitterStartSeconds,omitempty"`
+	NoProgressTimeoutSeconds            *int32                   `json:"noProgressTimeoutSeconds,omitempty"`
+	NoProgressTimeoutPolicy             *NoProgressTimeoutPolicy `json:"noProgressTimeoutPolicy,omitempty"`
 }
 
 func (v *SignalWithStartWorkflowExecutionRequest) SerializeForLogging() (string, error) {
@@ -5965,6 +6389,14 @@ func (v *SignalWithStartWorkflowExecutionRequest) GetCronSchedule() (o string) {
 	return
 }
 
+// GetNoProgressTimeoutSeconds is an internal getter (TBD...)
+func (v *SignalWithStartWorkflowExecutionRequest) GetNoProgressTimeoutSeconds() (o int32) {
+	if v != nil && v.NoProgressTimeoutSeconds != nil {
+		return *v.NoProgressTimeoutSeconds
+	}
+	return
+}
+
 // SignalWithStartWorkflowExecutionAsyncRequest is an internal type (TBD...)
 type SignalWithStartWorkflowExecutionAsyncRequest struct {
 	*SignalWithStartWorkflowExecutionRequest
@@ -6263,23 +6695,25 @@ func (v *RestartWorkflowExecutionRequest) GetWorkflowExecution() (o *WorkflowExe
 
 // StartWorkflowExecutionRequest is an internal type (TBD...)
 type StartWorkflowExecutionRequest struct {
-	Domain                              string                 `json:"domain,omitempty"`
-	WorkflowID                          string                 `json:"workflowId,omitempty"`
-	WorkflowType                        *WorkflowType          `json:"workflowType,omitempty"`
-	TaskList                            *TaskList              `json:"taskList,omitempty"`
-	Input                               []byte                 `json:"-"`
-	ExecutionStartToCloseTimeoutSeconds *int32                 `json:"executionStartToCloseTimeoutSeconds,omitempty"`
-	TaskStartToCloseTimeoutSeconds      *int32                 `json:"taskStartToCloseTimeoutSeconds,omitempty"`
-	Identity                            string                 `json:"identity,omitempty"`
-	RequestID                           string                 `json:"requestId,omitempty"`
-	WorkflowIDReusePolicy               *WorkflowIDReusePolicy `json:"workflowIdReusePolicy,omitempty"`
-	RetryPolicy                         *RetryPolicy           `json:"retryPolicy,omitempty"`
-	CronSchedule                        string                 `json:"cronSchedule,omitempty"`
-	Memo                                *Memo                  `json:"-"`
-	SearchAttributes                    *SearchAttributes      `json:"-"`
-	Header                              *Header                `json:"header,omitempty"`
-	DelayStartSeconds                   *int32                 `json:"delayStartSeconds,omitempty"`
-	JitterStartSeconds                  *int32                 `json:"jitterStartSeconds,omitempty"`
+	Domain                              string                   `json:"domain,omitempty"`
+	WorkflowID                          string                   `json:"workflowId,omitempty"`
+	WorkflowType                        *WorkflowType            `json:"workflowType,omitempty"`
+	TaskList                            *TaskList                `json:"taskList,omitempty"`
+	Input                               []byte                   `json:"-"`
+	ExecutionStartToCloseTimeoutSeconds *int32                   `json:"executionStartToCloseTimeoutSeconds,omitempty"`
+	TaskStartToCloseTimeoutSeconds      *int32                   `json:"taskStartToCloseTimeoutSeconds,omitempty"`
+	Identity                            string                   `json:"identity,omitempty"`
+	RequestID                           string                   `json:"requestId,omitempty"`
+	WorkflowIDReusePolicy               *WorkflowIDReusePolicy   `json:"workflowIdReusePolicy,omitempty"`
+	RetryPolicy                         *RetryPolicy             `json:"retryPolicy,omitempty"`
+	CronSchedule                        string                   `json:"cronSchedule,omitempty"`
+	Memo                                *Memo                    `json:"-"`
+	SearchAttributes                    *SearchAttributes        `json:"-"`
+	Header                              *Header                  `json:"header,omitempty"`
+	DelayStartSeconds                   *int32                   `json:"delayStartSeconds,omitempty"`
+	JitterStartSeconds                  *int32                   `json:"jitterStartSeconds,omitempty"`
+	NoProgressTimeoutSeconds            *int32                   `json:"noProgressTimeoutSeconds,omitempty"`
+	NoProgressTimeoutPolicy             *NoProgressTimeoutPolicy `json:"noProgressTimeoutPolicy,omitempty"`
 }
 
 func (v *StartWorkflowExecutionRequest) SerializeForLogging() (string, error) {
@@ -6337,6 +6771,22 @@ func (v *StartWorkflowExecutionRequest) GetJitterStartSeconds() (o int32) {
 	return
 }
 
+// GetNoProgressTimeoutSeconds is an internal getter (TBD...)
+func (v *StartWorkflowExecutionRequest) GetNoProgressTimeoutSeconds() (o int32) {
+	if v != nil && v.NoProgressTimeoutSeconds != nil {
+		return *v.NoProgressTimeoutSeconds
+	}
+	return
+}
+
+// GetNoProgressTimeoutPolicy is an internal getter (TBD...)
+func (v *StartWorkflowExecutionRequest) GetNoProgressTimeoutPolicy() (o NoProgressTimeoutPolicy) {
+	if v != nil && v.NoProgressTimeoutPolicy != nil {
+		return *v.NoProgressTimeoutPolicy
+	}
+	return
+}
+
 // GetRequestID is an internal getter (TBD...)
 func (v *StartWorkflowExecutionRequest) GetRequestID() (o string) {
 	if v != nil {
@@ -6925,6 +7375,107 @@ func (v *UpdateDomainResponse) GetIsGlobalDomain() (o bool) {
 	return
 }
 
+// UpdateActivityOptionsRequest is an internal type (TBD...)
+type UpdateActivityOptionsRequest struct {
+	Domain                        string             `json:"domain,omitempty"`
+	WorkflowExecution             *WorkflowExecution `json:"workflowExecution,omitempty"`
+	ActivityID                    string             `json:"activityID,omitempty"`
+	ScheduleToCloseTimeoutSeconds *int32             `json:"scheduleToCloseTimeoutSeconds,omitempty"`
+	ScheduleToStartTimeoutSeconds *int32             `json:"scheduleToStartTimeoutSeconds,omitempty"`
+	StartToCloseTimeoutSeconds    *int32             `json:"startToCloseTimeoutSeconds,omitempty"`
+	HeartbeatTimeoutSeconds       *int32             `json:"heartbeatTimeoutSeconds,omitempty"`
+	RetryPolicy                   *RetryPolicy       `json:"retryPolicy,omitempty"`
+	TaskList                      *TaskList          `json:"taskList,omitempty"`
+	Identity                      string             `json:"identity,omitempty"`
+}
+
+func (v *UpdateActivityOptionsRequest) SerializeForLogging() (string, error) {
+	if v == nil {
+		return "", nil
+	}
+	return SerializeRequest(v)
+}
+
+// GetDomain is an internal getter (TBD...)
+func (v *UpdateActivityOptionsRequest) GetDomain() (o string) {
+	if v != nil {
+		return v.Domain
+	}
+	return
+}
+
+// GetWorkflowExecution is an internal getter (TBD...)
+func (v *UpdateActivityOptionsRequest) GetWorkflowExecution() (o *WorkflowExecution) {
+	if v != nil && v.WorkflowExecution != nil {
+		return v.WorkflowExecution
+	}
+	return
+}
+
+// GetActivityID is an internal getter (TBD...)
+func (v *UpdateActivityOptionsRequest) GetActivityID() (o string) {
+	if v != nil {
+		return v.ActivityID
+	}
+	return
+}
+
+// GetScheduleToCloseTimeoutSeconds is an internal getter (TBD...)
+func (v *UpdateActivityOptionsRequest) GetScheduleToCloseTimeoutSeconds() (o int32) {
+	if v != nil && v.ScheduleToCloseTimeoutSeconds != nil {
+		return *v.ScheduleToCloseTimeoutSeconds
+	}
+	return
+}
+
+// GetScheduleToStartTimeoutSeconds is an internal getter (TBD...)
+func (v *UpdateActivityOptionsRequest) GetScheduleToStartTimeoutSeconds() (o int32) {
+	if v != nil && v.ScheduleToStartTimeoutSeconds != nil {
+		return *v.ScheduleToStartTimeoutSeconds
+	}
+	return
+}
+
+// GetStartToCloseTimeoutSeconds is an internal getter (TBD...)
+func (v *UpdateActivityOptionsRequest) GetStartToCloseTimeoutSeconds() (o int32) {
+	if v != nil && v.StartToCloseTimeoutSeconds != nil {
+		return *v.StartToCloseTimeoutSeconds
+	}
+	return
+}
+
+// GetHeartbeatTimeoutSeconds is an internal getter (TBD...)
+func (v *UpdateActivityOptionsRequest) GetHeartbeatTimeoutSeconds() (o int32) {
+	if v != nil && v.HeartbeatTimeoutSeconds != nil {
+		return *v.HeartbeatTimeoutSeconds
+	}
+	return
+}
+
+// GetRetryPolicy is an internal getter (TBD...)
+func (v *UpdateActivityOptionsRequest) GetRetryPolicy() (o *RetryPolicy) {
+	if v != nil && v.RetryPolicy != nil {
+		return v.RetryPolicy
+	}
+	return
+}
+
+// GetTaskList is an internal getter (TBD...)
+func (v *UpdateActivityOptionsRequest) GetTaskList() (o *TaskList) {
+	if v != nil && v.TaskList != nil {
+		return v.TaskList
+	}
+	return
+}
+
+// GetIdentity is an internal getter (TBD...)
+func (v *UpdateActivityOptionsRequest) GetIdentity() (o string) {
+	if v != nil {
+		return v.Identity
+	}
+	return
+}
+
 // UpsertWorkflowSearchAttributesDecisionAttributes is an internal type (TBD...)
 type UpsertWorkflowSearchAttributesDecisionAttributes struct {
 	SearchAttributes *SearchAttributes `json:"searchAttributes,omitempty"`
@@ -7406,7 +7957,9 @@ type WorkflowExecutionStartedEventAttributes struct {
 	Header                              *Header                 `json:"header,omitempty"`
 	JitterStartSeconds                  *int32                  `json:"jitterStartSeconds,omitempty"`
 	PartitionConfig                     map[string]string
-	RequestID                           string `json:"requestId,omitempty"`
+	RequestID                           string                   `json:"requestId,omitempty"`
+	NoProgressTimeoutSeconds            *int32                   `json:"noProgressTimeoutSeconds,omitempty"`
+	NoProgressTimeoutPolicy             *NoProgressTimeoutPolicy `json:"noProgressTimeoutPolicy,omitempty"`
 }
 
 // GetParentWorkflowDomain is an internal getter (TBD...)
@@ -7552,6 +8105,37 @@ func (v *WorkflowExecutionStartedEventAttributes) GetRequestID() (o string) {
 	return
 }
 
+// GetNoProgressTimeoutSeconds is an internal getter (TBD...)
+func (v *WorkflowExecutionStartedEventAttributes) GetNoProgressTimeoutSeconds() (o int32) {
+	if v != nil && v.NoProgressTimeoutSeconds != nil {
+		return *v.NoProgressTimeoutSeconds
+	}
+	return
+}
+
+// GetNoProgressTimeoutPolicy is an internal getter (TBD...)
+func (v *WorkflowExecutionStartedEventAttributes) GetNoProgressTimeoutPolicy() (o NoProgressTimeoutPolicy) {
+	if v != nil && v.NoProgressTimeoutPolicy != nil {
+		return *v.NoProgressTimeoutPolicy
+	}
+	return
+}
+
+// WorkflowNoProgressTimedOutEventAttributes is an internal type (TBD...)
+type WorkflowNoProgressTimedOutEventAttributes struct {
+	NoProgressTimeoutSeconds int32                    `json:"noProgressTimeoutSeconds,omitempty"`
+	NoProgressTimeoutPolicy  *NoProgressTimeoutPolicy `json:"noProgressTimeoutPolicy,omitempty"`
+	LastProgressTimestamp    *int64                   `json:"lastProgressTimestamp,omitempty"`
+}
+
+// GetNoProgressTimeoutPolicy is an internal getter (TBD...)
+func (v *WorkflowNoProgressTimedOutEventAttributes) GetNoProgressTimeoutPolicy() (o NoProgressTimeoutPolicy) {
+	if v != nil && v.NoProgressTimeoutPolicy != nil {
+		return *v.NoProgressTimeoutPolicy
+	}
+	return
+}
+
 // WorkflowExecutionTerminatedEventAttributes is an internal type (TBD...)
 type WorkflowExecutionTerminatedEventAttributes struct {
 	Reason   string `json:"reason,omitempty"`
@@ -7706,6 +8290,64 @@ func (v *WorkflowQueryResult) GetErrorMessage() (o string) {
 	return
 }
 
+// WorkflowSizeLimits is an internal type (TBD...)
+type WorkflowSizeLimits struct {
+	BlobSizeLimitWarn      int64 `json:"blobSizeLimitWarn,omitempty"`
+	BlobSizeLimitError     int64 `json:"blobSizeLimitError,omitempty"`
+	HistorySizeLimitWarn   int64 `json:"historySizeLimitWarn,omitempty"`
+	HistorySizeLimitError  int64 `json:"historySizeLimitError,omitempty"`
+	HistoryCountLimitWarn  int64 `json:"historyCountLimitWarn,omitempty"`
+	HistoryCountLimitError int64 `json:"historyCountLimitError,omitempty"`
+}
+
+// GetBlobSizeLimitWarn is an internal getter (TBD...)
+func (v *WorkflowSizeLimits) GetBlobSizeLimitWarn() (o int64) {
+	if v != nil {
+		return v.BlobSizeLimitWarn
+	}
+	return
+}
+
+// GetBlobSizeLimitError is an internal getter (TBD...)
+func (v *WorkflowSizeLimits) GetBlobSizeLimitError() (o int64) {
+	if v != nil {
+		return v.BlobSizeLimitError
+	}
+	return
+}
+
+// GetHistorySizeLimitWarn is an internal getter (TBD...)
+func (v *WorkflowSizeLimits) GetHistorySizeLimitWarn() (o int64) {
+	if v != nil {
+		return v.HistorySizeLimitWarn
+	}
+	return
+}
+
+// GetHistorySizeLimitError is an internal getter (TBD...)
+func (v *WorkflowSizeLimits) GetHistorySizeLimitError() (o int64) {
+	if v != nil {
+		return v.HistorySizeLimitError
+	}
+	return
+}
+
+// GetHistoryCountLimitWarn is an internal getter (TBD...)
+func (v *WorkflowSizeLimits) GetHistoryCountLimitWarn() (o int64) {
+	if v != nil {
+		return v.HistoryCountLimitWarn
+	}
+	return
+}
+
+// GetHistoryCountLimitError is an internal getter (TBD...)
+func (v *WorkflowSizeLimits) GetHistoryCountLimitError() (o int64) {
+	if v != nil {
+		return v.HistoryCountLimitError
+	}
+	return
+}
+
 // WorkflowType is an internal type (TBD...)
 type WorkflowType struct {
 	Name string `json:"name,omitempty"`
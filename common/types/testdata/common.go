@@ -288,6 +288,25 @@ var (
 		NumOfItemsInCacheByID:   int64(2000),
 		NumOfItemsInCacheByName: int64(3000),
 	}
+	CacheInfo = types.CacheInfo{
+		NumOfItems:       int64(100),
+		NumOfPinnedItems: int64(10),
+		SizeInBytes:      int64(4096),
+		NumOfHits:        int64(500),
+		NumOfMisses:      int64(50),
+	}
+	HostLoadInfo = types.HostLoadInfo{
+		RequestsPerSecond: 150,
+		CPUUtilization:    0.75,
+		PendingTasks:      int64(200),
+		Overloaded:        true,
+		HotShards: []*types.ShardLoadInfo{
+			{ShardID: ShardID, RequestsPerSecond: 100, PendingTasks: int64(200)},
+		},
+		HotWorkflows: []*types.WorkflowLoadInfo{
+			{DomainID: DomainID, WorkflowID: WorkflowID, ShardID: ShardID, RequestsPerSecond: 50},
+		},
+	}
 	VersionHistoryItem = types.VersionHistoryItem{
 		EventID: EventID1,
 		Version: Version1,
@@ -582,6 +582,55 @@ var (
 		CompletedShardCount: 0,
 		PendingShards:       []int32{1, 2, 3},
 	}
+	MigrateShardExecutionsRequest = types.MigrateShardExecutionsRequest{
+		ShardID:          ShardID,
+		SourceShardID:    ShardID + 1,
+		ClosedOnly:       true,
+		DeleteFromSource: true,
+		PageSize:         PageSize,
+		NextPageToken:    Token1,
+	}
+	MigrateShardExecutionsResponse = types.MigrateShardExecutionsResponse{
+		NextPageToken: Token2,
+		CopiedCount:   10,
+		DeletedCount:  5,
+	}
+	VerifyWorkflowExecutionRequest = types.VerifyWorkflowExecutionRequest{
+		DomainUUID: DomainID,
+		Execution:  &WorkflowExecution,
+		Repair:     true,
+	}
+	VerifyWorkflowExecutionResponse = types.VerifyWorkflowExecutionResponse{
+		Discrepancies: []*types.MutableStateDiscrepancy{
+			{
+				Type:      "PendingTimer",
+				ID:        TimerID,
+				Persisted: "5",
+				Rebuilt:   "missing",
+			},
+		},
+		Repaired: true,
+	}
+	DescribeReplicationStatusRequest = types.DescribeReplicationStatusRequest{
+		DomainUUID:  DomainID,
+		ClusterName: ClusterName1,
+	}
+	DescribeReplicationStatusResponse = types.DescribeReplicationStatusResponse{
+		Clusters: []*types.ClusterReplicationStatus{
+			{
+				ClusterName: ClusterName1,
+				Domains: []*types.DomainReplicationStatus{
+					{
+						DomainID:          DomainID,
+						DomainName:        DomainName,
+						PendingTaskCount:  10,
+						LagInMilliseconds: 60000,
+					},
+				},
+				Incomplete: true,
+			},
+		},
+	}
 )
 
 func generateEvent(modifier func(e *types.HistoryEvent)) types.HistoryEvent {
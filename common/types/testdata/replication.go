@@ -182,5 +182,14 @@ var (
 		ReplicationTasks:     ReplicationTaskArray,
 		ReplicationTasksInfo: ReplicationTaskInfoArray,
 		NextPageToken:        Token1,
+		HistoryTasks: []*types.HistoryTaskDLQMessage{
+			{
+				MessageID:         MessageID1,
+				ShardID:           ShardID,
+				TaskCategory:      1,
+				Task:              AdminListQueueTasksResponse.Tasks[0],
+				EnqueuedTimestamp: Timestamp1,
+			},
+		},
 	}
 )
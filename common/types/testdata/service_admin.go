@@ -56,6 +56,9 @@ var (
 		DomainCache:           &DomainCacheInfo,
 		ShardControllerStatus: "ShardControllerStatus",
 		Address:               HostName,
+		ExecutionCache:        &CacheInfo,
+		EventsCache:           &CacheInfo,
+		Load:                  &HostLoadInfo,
 	}
 	AdminDescribeQueueRequest = types.DescribeQueueRequest{
 		ShardID:     ShardID,
@@ -65,6 +68,38 @@ var (
 	AdminDescribeQueueResponse = types.DescribeQueueResponse{
 		ProcessingQueueStates: []string{"state1", "state2"},
 	}
+	AdminListQueueTasksRequest = types.ListQueueTasksRequest{
+		ShardID:                ShardID,
+		ClusterName:            ClusterName1,
+		Type:                   common.Int32Ptr(QueueType),
+		MinTaskID:              common.Int64Ptr(TaskID),
+		MaxTaskID:              common.Int64Ptr(TaskID + 1),
+		MinVisibilityTimestamp: &Timestamp1,
+		MaxVisibilityTimestamp: &Timestamp2,
+		PageSize:               PageSize,
+	}
+	AdminListQueueTasksResponse = types.ListQueueTasksResponse{
+		Tasks: []*types.QueueTaskInfo{
+			{
+				DomainID:            DomainID,
+				WorkflowID:          WorkflowID,
+				RunID:               RunID,
+				TaskID:              TaskID,
+				TaskType:            1,
+				VisibilityTimestamp: Timestamp1,
+				Version:             Version1,
+				State:               "Pending",
+				Attempt:             Attempt,
+				LastError:           ErrorMessage,
+			},
+		},
+	}
+	AdminExecuteQueueTaskRequest = types.ExecuteQueueTaskRequest{
+		ShardID:     ShardID,
+		ClusterName: ClusterName1,
+		Type:        common.Int32Ptr(QueueType),
+		TaskID:      TaskID,
+	}
 	AdminDescribeWorkflowExecutionRequest = types.AdminDescribeWorkflowExecutionRequest{
 		Domain:    DomainName,
 		Execution: &WorkflowExecution,
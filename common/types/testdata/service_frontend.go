@@ -380,6 +380,22 @@ var (
 		Identity:            Identity,
 		FirstExecutionRunID: RunID,
 	}
+	DeleteWorkflowExecutionRequest = types.DeleteWorkflowExecutionRequest{
+		Domain:                DomainName,
+		WorkflowExecution:     &WorkflowExecution,
+		TerminateIfRunning:    true,
+		IncludeChildWorkflows: true,
+		Reason:                Reason,
+		Identity:              Identity,
+	}
+	DeleteWorkflowExecutionResponse = types.DeleteWorkflowExecutionResponse{
+		ChildWorkflowExecutions: []*types.ChildWorkflowExecutionInfo{
+			{
+				Domain:            DomainName,
+				WorkflowExecution: &WorkflowExecution,
+			},
+		},
+	}
 	DescribeWorkflowExecutionRequest = types.DescribeWorkflowExecutionRequest{
 		Domain:    DomainName,
 		Execution: &WorkflowExecution,
@@ -39,11 +39,28 @@ var (
 	}
 	HistoryDescribeQueueRequest             = AdminDescribeQueueRequest
 	HistoryDescribeQueueResponse            = AdminDescribeQueueResponse
+	HistoryListQueueTasksRequest            = AdminListQueueTasksRequest
+	HistoryListQueueTasksResponse           = AdminListQueueTasksResponse
+	HistoryExecuteQueueTaskRequest          = AdminExecuteQueueTaskRequest
 	HistoryDescribeWorkflowExecutionRequest = types.HistoryDescribeWorkflowExecutionRequest{
 		DomainUUID: DomainID,
 		Request:    &DescribeWorkflowExecutionRequest,
 	}
-	HistoryDescribeWorkflowExecutionResponse = DescribeWorkflowExecutionResponse
+	HistoryDescribeWorkflowExecutionResponse = types.DescribeWorkflowExecutionResponse{
+		ExecutionConfiguration: &WorkflowExecutionConfiguration,
+		WorkflowExecutionInfo:  &WorkflowExecutionInfo,
+		PendingActivities:      PendingActivityInfoArray,
+		PendingChildren:        PendingChildExecutionInfoArray,
+		PendingDecision:        &PendingDecisionInfo,
+		WorkflowSizeLimits: &types.WorkflowSizeLimits{
+			BlobSizeLimitWarn:      1,
+			BlobSizeLimitError:     2,
+			HistorySizeLimitWarn:   3,
+			HistorySizeLimitError:  4,
+			HistoryCountLimitWarn:  5,
+			HistoryCountLimitError: 6,
+		},
+	}
 	HistoryGetDLQReplicationMessagesRequest  = AdminGetDLQReplicationMessagesRequest
 	HistoryGetDLQReplicationMessagesResponse = AdminGetDLQReplicationMessagesResponse
 	HistoryGetMutableStateRequest            = types.GetMutableStateRequest{
@@ -296,6 +313,10 @@ var (
 		ExternalWorkflowExecution: &WorkflowExecution,
 		ChildWorkflowOnly:         true,
 	}
+	HistoryDeleteWorkflowExecutionRequest = types.HistoryDeleteWorkflowExecutionRequest{
+		DomainUUID:    DomainID,
+		DeleteRequest: &DeleteWorkflowExecutionRequest,
+	}
 	HistoryGetCrossClusterTasksRequest               = GetCrossClusterTasksRequest
 	HistoryGetCrossClusterTasksResponse              = GetCrossClusterTasksResponse
 	HistoryRespondCrossClusterTasksCompletedRequest  = RespondCrossClusterTasksCompletedRequest
@@ -97,6 +97,8 @@ const (
 	FailureReasonTransactionSizeExceedsLimit = "TRANSACTION_SIZE_EXCEEDS_LIMIT"
 	// FailureReasonDecisionAttemptsExceedsLimit is reason to fail workflow when decision attempts fail too many times
 	FailureReasonDecisionAttemptsExceedsLimit = "DECISION_ATTEMPTS_EXCEEDS_LIMIT"
+	// FailureReasonWorkflowNoProgressTimedOut is reason to fail workflow when it has not completed a decision within the no progress timeout
+	FailureReasonWorkflowNoProgressTimedOut = "WORKFLOW_NO_PROGRESS_TIMED_OUT"
 )
 
 var (
@@ -790,6 +792,8 @@ func GetSizeOfHistoryEvent(event *types.HistoryEvent) uint64 {
 		if event.UpsertWorkflowSearchAttributesEventAttributes.SearchAttributes != nil {
 			res += GetSizeOfMapStringToByteArray(event.UpsertWorkflowSearchAttributesEventAttributes.SearchAttributes.IndexedFields)
 		}
+	case types.EventTypeActivityTaskOptionsUpdated:
+	case types.EventTypeWorkflowNoProgressTimedOut:
 	}
 	return uint64(res)
 }
@@ -162,8 +162,17 @@ func (h *serviceImpl) Start() {
 	}
 	h.hostInfo = hostInfo
 
+	historyShardMapping := common.NewHistoryShardMapping(h.numberOfHistoryShards)
 	h.clientBean, err = client.NewClientBean(
-		client.NewRPCClientFactory(h.rpcFactory, h.membershipResolver, h.metricsClient, h.dynamicCollection, h.numberOfHistoryShards, h.logger),
+		client.NewRPCClientFactory(
+			h.rpcFactory,
+			h.membershipResolver,
+			h.metricsClient,
+			h.dynamicCollection,
+			h.numberOfHistoryShards,
+			func() *common.HistoryShardMapping { return historyShardMapping },
+			h.logger,
+		),
 		h.rpcFactory.GetDispatcher(),
 		h.clusterMetadata,
 	)
@@ -275,3 +275,17 @@ CREATE TABLE cluster_config (
   data_encoding  VARCHAR(16) NOT NULL,
   PRIMARY KEY (row_type, version)
 );
+
+CREATE TABLE ndc_conflict_resolutions (
+  domain_id BINARY(16) NOT NULL,
+  workflow_id VARCHAR(255) NOT NULL,
+  run_id BINARY(16) NOT NULL,
+  created_time BIGINT NOT NULL,
+  --
+  expiry_time BIGINT NOT NULL,
+  data MEDIUMBLOB NOT NULL,
+  data_encoding VARCHAR(16) NOT NULL,
+  PRIMARY KEY (domain_id, workflow_id, run_id, created_time)
+);
+
+CREATE INDEX ndc_conflict_resolutions_by_expiry_time ON ndc_conflict_resolutions(expiry_time);
@@ -70,6 +70,7 @@ type (
 		params                *resource.Params
 		config                *config.Config
 		domainDLQHandler      domain.DLQMessageHandler
+		historyTaskDLQHandler *historyTaskDLQHandler
 		domainFailoverWatcher domain.FailoverWatcher
 		eventSerializer       persistence.PayloadSerializer
 		esClient              elasticsearch.GenericClient
@@ -131,6 +132,11 @@ func NewHandler(
 			resource.GetLogger(),
 			resource.GetMetricsClient(),
 		),
+		historyTaskDLQHandler: newHistoryTaskDLQHandler(
+			resource.GetPersistenceBean(),
+			resource.GetHistoryClient(),
+			resource.GetDomainCache(),
+		),
 		domainFailoverWatcher: domain.NewFailoverWatcher(
 			resource.GetDomainCache(),
 			resource.GetDomainManager(),
@@ -257,7 +263,7 @@ func (adh *adminHandlerImpl) DescribeWorkflowExecution(
 		return nil, adh.error(err, scope)
 	}
 
-	shardID := common.WorkflowIDToHistoryShard(request.Execution.WorkflowID, adh.numberOfHistoryShards)
+	shardID := adh.GetHistoryShardMappingCache().GetHistoryShardMapping().WorkflowIDToShard(request.Execution.WorkflowID)
 	shardIDstr := string(rune(shardID)) // originally `string(int_shard_id)`, but changing it will change the ring hashing
 	shardIDForOutput := strconv.Itoa(shardID)
 
@@ -663,7 +669,7 @@ func (adh *adminHandlerImpl) DescribeShardDistribution(
 	_, sw := adh.startRequestProfile(ctx, metrics.AdminDescribeShardDistributionScope)
 	defer sw.Stop()
 
-	numShards := adh.config.NumHistoryShards
+	numShards := adh.GetHistoryShardMappingCache().GetHistoryShardMapping().TotalShards()
 	resp = &types.DescribeShardDistributionResponse{
 		NumberOfShards: int32(numShards),
 		Shards:         make(map[int32]string),
@@ -784,10 +790,7 @@ func (adh *adminHandlerImpl) GetWorkflowExecutionRawHistoryV2(
 		}, nil
 	}
 	pageSize := int(request.GetMaximumPageSize())
-	shardID := common.WorkflowIDToHistoryShard(
-		execution.GetWorkflowID(),
-		adh.numberOfHistoryShards,
-	)
+	shardID := adh.GetHistoryShardMappingCache().GetHistoryShardMapping().WorkflowIDToShard(execution.GetWorkflowID())
 
 	rawHistoryResponse, err := adh.GetHistoryManager().ReadRawHistoryBranch(ctx, &persistence.ReadHistoryBranchRequest{
 		BranchToken: targetVersionHistory.GetBranchToken(),
@@ -1085,11 +1088,28 @@ func (adh *adminHandlerImpl) ReadDLQMessages(
 	}
 
 	var tasks []*types.ReplicationTask
+	var historyTasks []*types.HistoryTaskDLQMessage
 	var token []byte
 	var op func() error
 	switch request.GetType() {
 	case types.DLQTypeReplication:
 		return adh.GetHistoryClient().ReadDLQMessages(ctx, request)
+	case types.DLQTypeHistoryTask:
+		op = func() error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+				var err error
+				historyTasks, token, err = adh.historyTaskDLQHandler.Read(
+					ctx,
+					request.GetShardID(),
+					request.GetInclusiveEndMessageID(),
+					int(request.GetMaximumPageSize()),
+					request.GetNextPageToken())
+				return err
+			}
+		}
 	case types.DLQTypeDomain:
 		op = func() error {
 			select {
@@ -1115,6 +1135,7 @@ func (adh *adminHandlerImpl) ReadDLQMessages(
 
 	return &types.ReadDLQMessagesResponse{
 		ReplicationTasks: tasks,
+		HistoryTasks:     historyTasks,
 		NextPageToken:    token,
 	}, nil
 }
@@ -1145,6 +1166,19 @@ func (adh *adminHandlerImpl) PurgeDLQMessages(
 	switch request.GetType() {
 	case types.DLQTypeReplication:
 		return adh.GetHistoryClient().PurgeDLQMessages(ctx, request)
+	case types.DLQTypeHistoryTask:
+		op = func() error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+				return adh.historyTaskDLQHandler.Purge(
+					ctx,
+					request.GetShardID(),
+					request.GetInclusiveEndMessageID(),
+				)
+			}
+		}
 	case types.DLQTypeDomain:
 		op = func() error {
 			select {
@@ -1220,6 +1254,23 @@ func (adh *adminHandlerImpl) MergeDLQMessages(
 	switch request.GetType() {
 	case types.DLQTypeReplication:
 		return adh.GetHistoryClient().MergeDLQMessages(ctx, request)
+	case types.DLQTypeHistoryTask:
+		op = func() error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+				var err error
+				token, err = adh.historyTaskDLQHandler.Merge(
+					ctx,
+					request.GetShardID(),
+					request.GetInclusiveEndMessageID(),
+					int(request.GetMaximumPageSize()),
+					request.GetNextPageToken(),
+				)
+				return err
+			}
+		}
 	case types.DLQTypeDomain:
 
 		op = func() error {
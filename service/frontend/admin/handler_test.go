@@ -96,6 +96,7 @@ func (s *adminHandlerSuite) SetupTest() {
 	s.mockHistoryClient = s.mockResource.HistoryClient
 	s.mockHistoryV2Mgr = s.mockResource.HistoryMgr
 	s.frontendClient = s.mockResource.FrontendClient
+	s.mockResource.HistoryShardMapping.EXPECT().GetHistoryShardMapping().Return(common.NewHistoryShardMapping(1)).AnyTimes()
 	s.mockResolver = s.mockResource.MembershipResolver
 
 	params := &resource.Params{
@@ -1143,3 +1144,85 @@ func Test_UpdateDomainAsyncWorkflowConfiguraton(t *testing.T) {
 		})
 	}
 }
+
+func (s *adminHandlerSuite) newHistoryTaskDLQMessage(id int64, shardID int32) *persistence.QueueMessage {
+	payload, err := json.Marshal(&types.HistoryTaskDLQMessage{
+		ShardID:      shardID,
+		TaskCategory: int32(common.TaskTypeTransfer),
+		Task: &types.QueueTaskInfo{
+			DomainID:   s.domainID,
+			WorkflowID: "some random workflow ID",
+			RunID:      "some random run ID",
+			TaskID:     id,
+		},
+	})
+	s.NoError(err)
+	return &persistence.QueueMessage{
+		ID:        id,
+		QueueType: persistence.HistoryTaskQueueType(1),
+		Payload:   payload,
+	}
+}
+
+func (s *adminHandlerSuite) TestReadDLQMessages_HistoryTask() {
+	queueManager := s.mockResource.HistoryTaskQueueManager
+	queueManager.EXPECT().ReadMessagesFromDLQ(gomock.Any(), defaultLastMessageID, int64(100), 10, nil).
+		Return([]*persistence.QueueMessage{
+			s.newHistoryTaskDLQMessage(1, 1),
+			s.newHistoryTaskDLQMessage(2, 1),
+		}, nil, nil).Times(1)
+
+	resp, err := s.handler.ReadDLQMessages(context.Background(), &types.ReadDLQMessagesRequest{
+		Type:                  types.DLQTypeHistoryTask.Ptr(),
+		ShardID:               1,
+		InclusiveEndMessageID: common.Int64Ptr(100),
+		MaximumPageSize:       10,
+	})
+	s.NoError(err)
+	s.Len(resp.HistoryTasks, 2)
+	s.Equal(int64(1), resp.HistoryTasks[0].MessageID)
+	s.Equal(int64(2), resp.HistoryTasks[1].MessageID)
+	s.Equal(int32(1), resp.HistoryTasks[1].GetShardID())
+}
+
+func (s *adminHandlerSuite) TestMergeDLQMessages_HistoryTask() {
+	queueManager := s.mockResource.HistoryTaskQueueManager
+	queueManager.EXPECT().ReadMessagesFromDLQ(gomock.Any(), defaultLastMessageID, int64(100), 10, nil).
+		Return([]*persistence.QueueMessage{
+			s.newHistoryTaskDLQMessage(1, 1),
+			s.newHistoryTaskDLQMessage(2, 1),
+		}, nil, nil).Times(1)
+	s.mockDomainCache.EXPECT().GetDomainName(s.domainID).Return(s.domainName, nil).Times(2)
+	s.mockHistoryClient.EXPECT().RefreshWorkflowTasks(gomock.Any(), &types.HistoryRefreshWorkflowTasksRequest{
+		DomainUIID: s.domainID,
+		Request: &types.RefreshWorkflowTasksRequest{
+			Domain: s.domainName,
+			Execution: &types.WorkflowExecution{
+				WorkflowID: "some random workflow ID",
+				RunID:      "some random run ID",
+			},
+		},
+	}).Return(nil).Times(2)
+	queueManager.EXPECT().DeleteMessageFromDLQ(gomock.Any(), int64(1)).Return(nil).Times(1)
+	queueManager.EXPECT().DeleteMessageFromDLQ(gomock.Any(), int64(2)).Return(nil).Times(1)
+
+	_, err := s.handler.MergeDLQMessages(context.Background(), &types.MergeDLQMessagesRequest{
+		Type:                  types.DLQTypeHistoryTask.Ptr(),
+		ShardID:               1,
+		InclusiveEndMessageID: common.Int64Ptr(100),
+		MaximumPageSize:       10,
+	})
+	s.NoError(err)
+}
+
+func (s *adminHandlerSuite) TestPurgeDLQMessages_HistoryTask() {
+	queueManager := s.mockResource.HistoryTaskQueueManager
+	queueManager.EXPECT().RangeDeleteMessagesFromDLQ(gomock.Any(), defaultLastMessageID, int64(100)).Return(nil).Times(1)
+
+	err := s.handler.PurgeDLQMessages(context.Background(), &types.PurgeDLQMessagesRequest{
+		Type:                  types.DLQTypeHistoryTask.Ptr(),
+		ShardID:               0,
+		InclusiveEndMessageID: common.Int64Ptr(100),
+	})
+	s.NoError(err)
+}
@@ -0,0 +1,144 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/persistence"
+	persistenceClient "github.com/uber/cadence/common/persistence/client"
+	"github.com/uber/cadence/common/types"
+)
+
+type (
+	// historyTaskDLQHandler handles history task DLQ messages
+	historyTaskDLQHandler struct {
+		persistenceBean persistenceClient.Bean
+		historyClient   history.Client
+		domainCache     cache.DomainCache
+	}
+)
+
+func newHistoryTaskDLQHandler(
+	persistenceBean persistenceClient.Bean,
+	historyClient history.Client,
+	domainCache cache.DomainCache,
+) *historyTaskDLQHandler {
+	return &historyTaskDLQHandler{
+		persistenceBean: persistenceBean,
+		historyClient:   historyClient,
+		domainCache:     domainCache,
+	}
+}
+
+// Read reads history task DLQ messages of the given shard
+func (h *historyTaskDLQHandler) Read(
+	ctx context.Context,
+	shardID int32,
+	lastMessageID int64,
+	pageSize int,
+	pageToken []byte,
+) ([]*types.HistoryTaskDLQMessage, []byte, error) {
+
+	queueManager, err := h.persistenceBean.GetHistoryTaskQueueManager(int(shardID))
+	if err != nil {
+		return nil, nil, err
+	}
+	queueMessages, token, err := queueManager.ReadMessagesFromDLQ(
+		ctx,
+		defaultLastMessageID,
+		lastMessageID,
+		pageSize,
+		pageToken,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var messages []*types.HistoryTaskDLQMessage
+	for _, queueMessage := range queueMessages {
+		message, err := h.decode(queueMessage)
+		if err != nil {
+			return nil, nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, token, nil
+}
+
+// Purge deletes history task DLQ messages of the given shard
+func (h *historyTaskDLQHandler) Purge(
+	ctx context.Context,
+	shardID int32,
+	lastMessageID int64,
+) error {
+
+	queueManager, err := h.persistenceBean.GetHistoryTaskQueueManager(int(shardID))
+	if err != nil {
+		return err
+	}
+	return queueManager.RangeDeleteMessagesFromDLQ(ctx, defaultLastMessageID, lastMessageID)
+}
+
+// Merge regenerates the tasks of the workflows referenced by the history task DLQ messages
+// of the given shard and deletes the messages afterwards
+func (h *historyTaskDLQHandler) Merge(
+	ctx context.Context,
+	shardID int32,
+	lastMessageID int64,
+	pageSize int,
+	pageToken []byte,
+) ([]byte, error) {
+
+	queueManager, err := h.persistenceBean.GetHistoryTaskQueueManager(int(shardID))
+	if err != nil {
+		return nil, err
+	}
+	messages, token, err := h.Read(ctx, shardID, lastMessageID, pageSize, pageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, message := range messages {
+		task := message.GetTask()
+		if task == nil {
+			return nil, &types.InternalServiceError{Message: "Encounter history task DLQ message without task."}
+		}
+		domainName, err := h.domainCache.GetDomainName(task.DomainID)
+		if err != nil {
+			return nil, err
+		}
+		if err := h.historyClient.RefreshWorkflowTasks(ctx, &types.HistoryRefreshWorkflowTasksRequest{
+			DomainUIID: task.DomainID,
+			Request: &types.RefreshWorkflowTasksRequest{
+				Domain: domainName,
+				Execution: &types.WorkflowExecution{
+					WorkflowID: task.WorkflowID,
+					RunID:      task.RunID,
+				},
+			},
+		}); err != nil {
+			if _, ok := err.(*types.EntityNotExistsError); !ok {
+				return nil, err
+			}
+		}
+		if err := queueManager.DeleteMessageFromDLQ(ctx, message.MessageID); err != nil {
+			return nil, err
+		}
+	}
+	return token, nil
+}
+
+func (h *historyTaskDLQHandler) decode(
+	queueMessage *persistence.QueueMessage,
+) (*types.HistoryTaskDLQMessage, error) {
+
+	var message types.HistoryTaskDLQMessage
+	if err := json.Unmarshal(queueMessage.Payload, &message); err != nil {
+		return nil, fmt.Errorf("failed to decode history task dlq message: %v", err)
+	}
+	message.MessageID = queueMessage.ID
+	return &message, nil
+}
@@ -772,8 +772,8 @@ func (wh *WorkflowHandler) RecordActivityTaskHeartbeat(
 		domain: domainName,
 	}
 	scope := getMetricsScopeWithDomain(metrics.FrontendRecordActivityTaskHeartbeatScope, dw, wh.GetMetricsClient()).Tagged(metrics.GetContextTags(ctx)...)
-	sizeLimitError := wh.config.BlobSizeLimitError(domainName)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName)
+	sizeLimitError := wh.config.BlobSizeLimitError(domainName, taskToken.WorkflowType, "")
+	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName, taskToken.WorkflowType, "")
 
 	if err := common.CheckEventBlobSizeLimit(
 		len(heartbeatRequest.Details),
@@ -868,8 +868,8 @@ func (wh *WorkflowHandler) RecordActivityTaskHeartbeatByID(
 	}
 
 	scope := getMetricsScopeWithDomain(metrics.FrontendRecordActivityTaskHeartbeatByIDScope, heartbeatRequest, wh.GetMetricsClient()).Tagged(metrics.GetContextTags(ctx)...)
-	sizeLimitError := wh.config.BlobSizeLimitError(domainName)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName)
+	sizeLimitError := wh.config.BlobSizeLimitError(domainName, "", "")
+	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName, "", "")
 
 	if err := common.CheckEventBlobSizeLimit(
 		len(heartbeatRequest.Details),
@@ -965,8 +965,8 @@ func (wh *WorkflowHandler) RespondActivityTaskCompleted(
 		return validate.ErrIdentityTooLong
 	}
 
-	sizeLimitError := wh.config.BlobSizeLimitError(domainName)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName)
+	sizeLimitError := wh.config.BlobSizeLimitError(domainName, taskToken.WorkflowType, "")
+	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName, taskToken.WorkflowType, "")
 
 	if err := common.CheckEventBlobSizeLimit(
 		len(completeRequest.Result),
@@ -1070,8 +1070,8 @@ func (wh *WorkflowHandler) RespondActivityTaskCompletedByID(
 		return err
 	}
 
-	sizeLimitError := wh.config.BlobSizeLimitError(domainName)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName)
+	sizeLimitError := wh.config.BlobSizeLimitError(domainName, "", "")
+	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName, "", "")
 
 	if err := common.CheckEventBlobSizeLimit(
 		len(completeRequest.Result),
@@ -1166,8 +1166,8 @@ func (wh *WorkflowHandler) RespondActivityTaskFailed(
 		return validate.ErrIdentityTooLong
 	}
 
-	sizeLimitError := wh.config.BlobSizeLimitError(domainName)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName)
+	sizeLimitError := wh.config.BlobSizeLimitError(domainName, taskToken.WorkflowType, "")
+	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName, taskToken.WorkflowType, "")
 
 	if err := common.CheckEventBlobSizeLimit(
 		len(failedRequest.Details),
@@ -1260,8 +1260,8 @@ func (wh *WorkflowHandler) RespondActivityTaskFailedByID(
 		return err
 	}
 
-	sizeLimitError := wh.config.BlobSizeLimitError(domainName)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName)
+	sizeLimitError := wh.config.BlobSizeLimitError(domainName, "", "")
+	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName, "", "")
 
 	if err := common.CheckEventBlobSizeLimit(
 		len(failedRequest.Details),
@@ -1347,8 +1347,8 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceled(
 		return validate.ErrIdentityTooLong
 	}
 
-	sizeLimitError := wh.config.BlobSizeLimitError(domainName)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName)
+	sizeLimitError := wh.config.BlobSizeLimitError(domainName, taskToken.WorkflowType, "")
+	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName, taskToken.WorkflowType, "")
 
 	if err := common.CheckEventBlobSizeLimit(
 		len(cancelRequest.Details),
@@ -1452,8 +1452,8 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceledByID(
 		return err
 	}
 
-	sizeLimitError := wh.config.BlobSizeLimitError(domainName)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName)
+	sizeLimitError := wh.config.BlobSizeLimitError(domainName, "", "")
+	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName, "", "")
 
 	if err := common.CheckEventBlobSizeLimit(
 		len(cancelRequest.Details),
@@ -1639,8 +1639,8 @@ func (wh *WorkflowHandler) RespondDecisionTaskFailed(
 		return validate.ErrIdentityTooLong
 	}
 
-	sizeLimitError := wh.config.BlobSizeLimitError(domainName)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName)
+	sizeLimitError := wh.config.BlobSizeLimitError(domainName, taskToken.WorkflowType, "")
+	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName, taskToken.WorkflowType, "")
 
 	if err := common.CheckEventBlobSizeLimit(
 		len(failedRequest.Details),
@@ -1703,8 +1703,8 @@ func (wh *WorkflowHandler) RespondQueryTaskCompleted(
 	dw := domainWrapper{
 		domain: domainName,
 	}
-	sizeLimitError := wh.config.BlobSizeLimitError(domainName)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName)
+	sizeLimitError := wh.config.BlobSizeLimitError(domainName, "", queryTaskToken.TaskList)
+	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName, "", queryTaskToken.TaskList)
 
 	scope := getMetricsScopeWithDomain(metrics.FrontendRespondQueryTaskCompletedScope, dw, wh.GetMetricsClient()).Tagged(metrics.GetContextTags(ctx)...)
 	if err := common.CheckEventBlobSizeLimit(
@@ -1901,6 +1901,9 @@ func (wh *WorkflowHandler) validateStartWorkflowExecutionRequest(ctx context.Con
 	if startRequest.GetJitterStartSeconds() < 0 {
 		return validate.ErrInvalidJitterStartSeconds
 	}
+	if startRequest.GetNoProgressTimeoutSeconds() < 0 {
+		return validate.ErrInvalidNoProgressTimeoutSeconds
+	}
 	jitter := startRequest.GetJitterStartSeconds()
 	cron := startRequest.GetCronSchedule()
 	if cron != "" {
@@ -1941,8 +1944,8 @@ func (wh *WorkflowHandler) validateStartWorkflowExecutionRequest(ctx context.Con
 	if err != nil {
 		return err
 	}
-	sizeLimitError := wh.config.BlobSizeLimitError(domainName)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName)
+	sizeLimitError := wh.config.BlobSizeLimitError(domainName, startRequest.WorkflowType.GetName(), startRequest.TaskList.GetName())
+	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName, startRequest.WorkflowType.GetName(), startRequest.TaskList.GetName())
 	actualSize := len(startRequest.Input)
 	if startRequest.Memo != nil {
 		actualSize += common.GetSizeOfMapStringToByteArray(startRequest.Memo.GetFields())
@@ -2295,8 +2298,8 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(
 		return err
 	}
 
-	sizeLimitError := wh.config.BlobSizeLimitError(domainName)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName)
+	sizeLimitError := wh.config.BlobSizeLimitError(domainName, "", "")
+	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName, "", "")
 	if err := common.CheckEventBlobSizeLimit(
 		len(signalRequest.Input),
 		sizeLimitWarn,
@@ -2508,6 +2511,10 @@ func (wh *WorkflowHandler) validateSignalWithStartWorkflowExecutionRequest(ctx c
 		return validate.ErrInvalidTaskStartToCloseTimeoutSeconds
 	}
 
+	if signalWithStartRequest.GetNoProgressTimeoutSeconds() < 0 {
+		return validate.ErrInvalidNoProgressTimeoutSeconds
+	}
+
 	if err := common.ValidateRetryPolicy(signalWithStartRequest.RetryPolicy); err != nil {
 		return err
 	}
@@ -2527,8 +2534,8 @@ func (wh *WorkflowHandler) validateSignalWithStartWorkflowExecutionRequest(ctx c
 		return err
 	}
 
-	sizeLimitError := wh.config.BlobSizeLimitError(domainName)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName)
+	sizeLimitError := wh.config.BlobSizeLimitError(domainName, signalWithStartRequest.GetWorkflowType().GetName(), signalWithStartRequest.GetTaskList().GetName())
+	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName, signalWithStartRequest.GetWorkflowType().GetName(), signalWithStartRequest.GetTaskList().GetName())
 	if err := common.CheckEventBlobSizeLimit(
 		len(signalWithStartRequest.SignalInput),
 		sizeLimitWarn,
@@ -3348,8 +3355,8 @@ func (wh *WorkflowHandler) QueryWorkflow(
 		return nil, err
 	}
 
-	sizeLimitError := wh.config.BlobSizeLimitError(domainName)
-	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName)
+	sizeLimitError := wh.config.BlobSizeLimitError(domainName, "", "")
+	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName, "", "")
 
 	scope := getMetricsScopeWithDomain(metrics.FrontendQueryWorkflowScope, queryRequest, wh.GetMetricsClient()).Tagged(metrics.GetContextTags(ctx)...)
 	if err := common.CheckEventBlobSizeLimit(
@@ -3560,7 +3567,7 @@ func (wh *WorkflowHandler) getRawHistory(
 	branchToken []byte,
 ) ([]*types.DataBlob, []byte, error) {
 	rawHistory := []*types.DataBlob{}
-	shardID := common.WorkflowIDToHistoryShard(execution.WorkflowID, wh.config.NumHistoryShards)
+	shardID := wh.GetHistoryShardMappingCache().GetHistoryShardMapping().WorkflowIDToShard(execution.WorkflowID)
 
 	resp, err := wh.GetHistoryManager().ReadRawHistoryBranch(ctx, &persistence.ReadHistoryBranchRequest{
 		BranchToken:   branchToken,
@@ -3630,7 +3637,7 @@ func (wh *WorkflowHandler) getHistory(
 	var size int
 
 	isFirstPage := len(nextPageToken) == 0
-	shardID := common.WorkflowIDToHistoryShard(execution.WorkflowID, wh.config.NumHistoryShards)
+	shardID := wh.GetHistoryShardMappingCache().GetHistoryShardMapping().WorkflowIDToShard(execution.WorkflowID)
 	var err error
 	historyEvents, size, nextPageToken, err := persistenceutils.ReadFullPageV2Events(ctx, wh.GetHistoryManager(), &persistence.ReadHistoryBranchRequest{
 		BranchToken:   branchToken,
@@ -139,6 +139,7 @@ func (s *workflowHandlerSuite) SetupTest() {
 
 	mockMonitor := s.mockResource.MembershipResolver
 	mockMonitor.EXPECT().MemberCount(service.Frontend).Return(5, nil).AnyTimes()
+	s.mockResource.HistoryShardMapping.EXPECT().GetHistoryShardMapping().Return(common.NewHistoryShardMapping(numHistoryShards)).AnyTimes()
 	s.mockVersionChecker.EXPECT().ClientSupported(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 }
 
@@ -365,6 +366,37 @@ func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_BadDelayStartSe
 	s.Equal(validate.ErrInvalidDelayStartSeconds, err)
 }
 
+func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_BadNoProgressTimeoutSeconds() {
+	config := s.newConfig(dc.NewInMemoryClient())
+	config.UserRPS = dc.GetIntPropertyFn(10)
+	wh := s.getWorkflowHandler(config)
+
+	startWorkflowExecutionRequest := &types.StartWorkflowExecutionRequest{
+		Domain:     s.testDomain,
+		WorkflowID: "workflow-id",
+		WorkflowType: &types.WorkflowType{
+			Name: "workflow-type",
+		},
+		TaskList: &types.TaskList{
+			Name: "task-list",
+		},
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(1),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+		RetryPolicy: &types.RetryPolicy{
+			InitialIntervalInSeconds:    1,
+			BackoffCoefficient:          2,
+			MaximumIntervalInSeconds:    2,
+			MaximumAttempts:             1,
+			ExpirationIntervalInSeconds: 1,
+		},
+		RequestID:                uuid.New(),
+		NoProgressTimeoutSeconds: common.Int32Ptr(-1),
+	}
+	_, err := wh.StartWorkflowExecution(context.Background(), startWorkflowExecutionRequest)
+	s.Error(err)
+	s.Equal(validate.ErrInvalidNoProgressTimeoutSeconds, err)
+}
+
 func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_StartRequestNotSet() {
 	config := s.newConfig(dc.NewInMemoryClient())
 	config.UserRPS = dc.GetIntPropertyFn(10)
@@ -553,6 +585,43 @@ func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_InvalidTaskStar
 	s.Equal(validate.ErrInvalidTaskStartToCloseTimeoutSeconds, err)
 }
 
+func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_BlobSizeLimitByWorkflowType() {
+	config := s.newConfig(dc.NewInMemoryClient())
+	config.UserRPS = dc.GetIntPropertyFn(10)
+	config.BlobSizeLimitError = func(domainName string, workflowType string, taskList string) int {
+		if workflowType == "small-workflow-type" && taskList == "task-list" {
+			return 1
+		}
+		return 2 * 1024 * 1024
+	}
+	config.BlobSizeLimitWarn = func(domainName string, workflowType string, taskList string) int {
+		if workflowType == "small-workflow-type" && taskList == "task-list" {
+			return 1
+		}
+		return 256 * 1024
+	}
+	wh := s.getWorkflowHandler(config)
+
+	s.mockDomainCache.EXPECT().GetDomainID(s.testDomain).Return(s.testDomainID, nil)
+
+	startWorkflowExecutionRequest := &types.StartWorkflowExecutionRequest{
+		Domain:     s.testDomain,
+		WorkflowID: "workflow-id",
+		WorkflowType: &types.WorkflowType{
+			Name: "small-workflow-type",
+		},
+		TaskList: &types.TaskList{
+			Name: "task-list",
+		},
+		Input:                               []byte("input"),
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(1),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+		RequestID:                           uuid.New(),
+	}
+	_, err := wh.StartWorkflowExecution(context.Background(), startWorkflowExecutionRequest)
+	s.Equal(common.ErrBlobSizeExceedsLimit, err)
+}
+
 func (s *workflowHandlerSuite) TestStartWorkflowExecution_IsolationGroupDrained() {
 	config := s.newConfig(dc.NewInMemoryClient())
 	config.UserRPS = dc.GetIntPropertyFn(10)
@@ -84,9 +84,9 @@ type Config struct {
 	AdminOperationToken           dynamicconfig.StringPropertyFn
 	DisableListVisibilityByFilter dynamicconfig.BoolPropertyFnWithDomainFilter
 
-	// size limit system protection
-	BlobSizeLimitError dynamicconfig.IntPropertyFnWithDomainFilter
-	BlobSizeLimitWarn  dynamicconfig.IntPropertyFnWithDomainFilter
+	// size limit system protection, workflow type and tasklist filters are only applied when they are known from the request
+	BlobSizeLimitError dynamicconfig.IntPropertyFnWithWorkflowTypeAndTaskListFilters
+	BlobSizeLimitWarn  dynamicconfig.IntPropertyFnWithWorkflowTypeAndTaskListFilters
 
 	ThrottledLogRPS dynamicconfig.IntPropertyFn
 
@@ -161,8 +161,8 @@ func NewConfig(dc *dynamicconfig.Collection, numHistoryShards int, isAdvancedVis
 		EnableAdminProtection:                       dc.GetBoolProperty(dynamicconfig.EnableAdminProtection),
 		AdminOperationToken:                         dc.GetStringProperty(dynamicconfig.AdminOperationToken),
 		DisableListVisibilityByFilter:               dc.GetBoolPropertyFilteredByDomain(dynamicconfig.DisableListVisibilityByFilter),
-		BlobSizeLimitError:                          dc.GetIntPropertyFilteredByDomain(dynamicconfig.BlobSizeLimitError),
-		BlobSizeLimitWarn:                           dc.GetIntPropertyFilteredByDomain(dynamicconfig.BlobSizeLimitWarn),
+		BlobSizeLimitError:                          dc.GetIntPropertyFilteredByWorkflowTypeAndTaskList(dynamicconfig.BlobSizeLimitError),
+		BlobSizeLimitWarn:                           dc.GetIntPropertyFilteredByWorkflowTypeAndTaskList(dynamicconfig.BlobSizeLimitWarn),
 		ThrottledLogRPS:                             dc.GetIntProperty(dynamicconfig.FrontendThrottledLogRPS),
 		ShutdownDrainDuration:                       dc.GetDurationProperty(dynamicconfig.FrontendShutdownDrainDuration),
 		EnableDomainNotActiveAutoForwarding:         dc.GetBoolPropertyFilteredByDomain(dynamicconfig.EnableDomainNotActiveAutoForwarding),
@@ -187,6 +187,7 @@ func NewConfig(dc *dynamicconfig.Collection, numHistoryShards int, isAdvancedVis
 			MinRetentionDays:       dc.GetIntProperty(dynamicconfig.MinRetentionDays),
 			MaxRetentionDays:       dc.GetIntProperty(dynamicconfig.MaxRetentionDays),
 			FailoverCoolDown:       dc.GetDurationPropertyFilteredByDomain(dynamicconfig.FrontendFailoverCoolDown),
+			FailoverHistoryMaxSize: dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendFailoverHistoryMaxSize),
 			RequiredDomainDataKeys: dc.GetMapProperty(dynamicconfig.RequiredDomainDataKeys),
 		},
 		HostName: hostName,
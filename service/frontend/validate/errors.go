@@ -52,6 +52,7 @@ var (
 	ErrInvalidDelayStartSeconds                   = &types.BadRequestError{Message: "A valid DelayStartSeconds is not set on request."}
 	ErrInvalidJitterStartSeconds                  = &types.BadRequestError{Message: "A valid JitterStartSeconds is not set on request (negative)."}
 	ErrInvalidJitterStartSeconds2                 = &types.BadRequestError{Message: "A valid JitterStartSeconds is not set on request (larger than cron duration)."}
+	ErrInvalidNoProgressTimeoutSeconds            = &types.BadRequestError{Message: "A valid NoProgressTimeoutSeconds is not set on request (negative)."}
 	ErrQueryDisallowedForDomain                   = &types.BadRequestError{Message: "Domain is not allowed to query, please contact cadence team to re-enable queries."}
 	ErrClusterNameNotSet                          = &types.BadRequestError{Message: "Cluster name is not set."}
 	ErrEmptyReplicationInfo                       = &types.BadRequestError{Message: "Replication task info is not set."}
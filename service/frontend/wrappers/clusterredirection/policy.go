@@ -255,6 +255,13 @@ func (policy *selectedOrAllAPIsForwardingRedirectionPolicy) getTargetClusterAndI
 		return policy.currentClusterName, false
 	}
 
+	if domainEntry.IsActiveActive() {
+		// the active cluster of an active-active domain is decided per workflow by the history service,
+		// so always try the current cluster first and rely on the domain not active error for forwarding
+		_, ok := policy.selectedAPIs[apiName]
+		return policy.currentClusterName, ok || policy.allDomainAPIs
+	}
+
 	currentActiveCluster := domainEntry.GetReplicationConfig().ActiveClusterName
 	if policy.allDomainAPIs {
 		if policy.targetCluster == "" {
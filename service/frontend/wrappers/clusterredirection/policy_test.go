@@ -29,6 +29,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/dynamicconfig"
@@ -336,6 +337,61 @@ func (s *selectedAPIsForwardingRedirectionPolicySuite) TestGetTargetDataCenter_G
 	s.Equal(2*len(selectedAPIsForwardingRedirectionPolicyAPIAllowlist), alternativeClustercallCount)
 }
 
+func (s *selectedAPIsForwardingRedirectionPolicySuite) TestGetTargetDataCenter_ActiveActiveDomain_Forwarding_CurrentClusterToAlternativeCluster() {
+	s.setupActiveActiveDomainWithTwoReplicationCluster(true)
+
+	currentClustercallCount := 0
+	alternativeClustercallCount := 0
+	callFn := func(targetCluster string) error {
+		switch targetCluster {
+		case s.currentClusterName:
+			currentClustercallCount++
+			return &types.DomainNotActiveError{
+				CurrentCluster: s.currentClusterName,
+				ActiveCluster:  s.alternativeClusterName,
+			}
+		case s.alternativeClusterName:
+			alternativeClustercallCount++
+			return nil
+		default:
+			panic(fmt.Sprintf("unknown cluster name %v", targetCluster))
+		}
+	}
+
+	for apiName := range selectedAPIsForwardingRedirectionPolicyAPIAllowlist {
+		err := s.policy.WithDomainIDRedirect(context.Background(), s.domainID, apiName, callFn)
+		s.Nil(err)
+
+		err = s.policy.WithDomainNameRedirect(context.Background(), s.domainName, apiName, callFn)
+		s.Nil(err)
+	}
+
+	s.Equal(2*len(selectedAPIsForwardingRedirectionPolicyAPIAllowlist), currentClustercallCount)
+	s.Equal(2*len(selectedAPIsForwardingRedirectionPolicyAPIAllowlist), alternativeClustercallCount)
+}
+
+func (s *selectedAPIsForwardingRedirectionPolicySuite) TestGetTargetDataCenter_ActiveActiveDomain_NoForwarding_APINotWhiltelisted() {
+	s.setupActiveActiveDomainWithTwoReplicationCluster(true)
+
+	callCount := 0
+	callFn := func(targetCluster string) error {
+		callCount++
+		s.Equal(s.currentClusterName, targetCluster)
+		return &types.DomainNotActiveError{
+			CurrentCluster: s.currentClusterName,
+			ActiveCluster:  s.alternativeClusterName,
+		}
+	}
+
+	err := s.policy.WithDomainIDRedirect(context.Background(), s.domainID, "any random API name", callFn)
+	s.IsType(&types.DomainNotActiveError{}, err)
+
+	err = s.policy.WithDomainNameRedirect(context.Background(), s.domainName, "any random API name", callFn)
+	s.IsType(&types.DomainNotActiveError{}, err)
+
+	s.Equal(2, callCount)
+}
+
 func (s *selectedAPIsForwardingRedirectionPolicySuite) TestGetTargetDataCenter_GlobalDomain_NoDomainInCache() {
 	currentClustercallCount := 0
 	alternativeClustercallCount := 0
@@ -443,6 +499,32 @@ func (s *selectedAPIsForwardingRedirectionPolicySuite) setupGlobalDomainWithTwoR
 	s.mockConfig.EnableDomainNotActiveAutoForwarding = dynamicconfig.GetBoolPropertyFnFilteredByDomain(forwardingEnabled)
 }
 
+func (s *selectedAPIsForwardingRedirectionPolicySuite) setupActiveActiveDomainWithTwoReplicationCluster(forwardingEnabled bool) {
+	domainEntry := cache.NewGlobalDomainCacheEntryForTest(
+		&persistence.DomainInfo{
+			ID:   s.domainID,
+			Name: s.domainName,
+			Data: map[string]string{
+				common.DomainDataKeyPrefixForActiveCluster + "region0": s.currentClusterName,
+				common.DomainDataKeyPrefixForActiveCluster + "region1": s.alternativeClusterName,
+			},
+		},
+		&persistence.DomainConfig{Retention: 1},
+		&persistence.DomainReplicationConfig{
+			ActiveClusterName: s.alternativeClusterName,
+			Clusters: []*persistence.ClusterReplicationConfig{
+				{ClusterName: cluster.TestCurrentClusterName},
+				{ClusterName: cluster.TestAlternativeClusterName},
+			},
+		},
+		1234, // not used
+	)
+
+	s.mockDomainCache.EXPECT().GetDomainByID(s.domainID).Return(domainEntry, nil).AnyTimes()
+	s.mockDomainCache.EXPECT().GetDomain(s.domainName).Return(domainEntry, nil).AnyTimes()
+	s.mockConfig.EnableDomainNotActiveAutoForwarding = dynamicconfig.GetBoolPropertyFnFilteredByDomain(forwardingEnabled)
+}
+
 func (s *selectedAPIsForwardingRedirectionPolicySuite) setupGlobalDeprecatedDomainWithTwoReplicationCluster(forwardingEnabled bool, isRecordActive bool) {
 	activeCluster := s.alternativeClusterName
 	if isRecordActive {
@@ -32,6 +32,7 @@ import (
 // Config represents configuration for cadence-history service
 type Config struct {
 	NumberOfShards                   int
+	HistoryShardMapping              func() *common.HistoryShardMapping // nil if the mapping never changes
 	IsAdvancedVisConfigExist         bool
 	RPS                              dynamicconfig.IntPropertyFn
 	MaxIDLengthWarnLimit             dynamicconfig.IntPropertyFn
@@ -62,17 +63,27 @@ type Config struct {
 	DeleteHistoryEventContextTimeout dynamicconfig.IntPropertyFn
 	MaxResponseSize                  int
 
+	// HotShard settings
+	EnableHotShardTracking                    dynamicconfig.BoolPropertyFn
+	HotShardTrackingWindow                    dynamicconfig.DurationPropertyFn
+	EnableHotShardLoadShedding                dynamicconfig.BoolPropertyFn
+	HotShardLoadSheddingTopN                  dynamicconfig.IntPropertyFn
+	HotShardLoadSheddingCPUThreshold          dynamicconfig.FloatPropertyFn
+	HotShardLoadSheddingPendingTasksThreshold dynamicconfig.IntPropertyFn
+
 	// HistoryCache settings
 	// Change of these configs require shard restart
-	HistoryCacheInitialSize dynamicconfig.IntPropertyFn
-	HistoryCacheMaxSize     dynamicconfig.IntPropertyFn
-	HistoryCacheTTL         dynamicconfig.DurationPropertyFn
+	HistoryCacheInitialSize    dynamicconfig.IntPropertyFn
+	HistoryCacheMaxSize        dynamicconfig.IntPropertyFn
+	HistoryCacheMaxSizeInBytes dynamicconfig.IntPropertyFn
+	HistoryCacheTTL            dynamicconfig.DurationPropertyFn
 
 	// EventsCache settings
 	// Change of these configs require shard restart
 	EventsCacheInitialCount       dynamicconfig.IntPropertyFn
 	EventsCacheMaxCount           dynamicconfig.IntPropertyFn
 	EventsCacheMaxSize            dynamicconfig.IntPropertyFn
+	EventsCacheShardMaxSize       dynamicconfig.IntPropertyFn
 	EventsCacheTTL                dynamicconfig.DurationPropertyFn
 	EventsCacheGlobalEnable       dynamicconfig.BoolPropertyFn
 	EventsCacheGlobalInitialCount dynamicconfig.IntPropertyFn
@@ -82,6 +93,8 @@ type Config struct {
 	RangeSizeBits           uint
 	AcquireShardInterval    dynamicconfig.DurationPropertyFn
 	AcquireShardConcurrency dynamicconfig.IntPropertyFn
+	// EnableGracefulShardHandoff releases shards explicitly instead of waiting for them to be stolen
+	EnableGracefulShardHandoff dynamicconfig.BoolPropertyFn
 
 	// the artificial delay added to standby cluster's view of active cluster's time
 	StandbyClusterDelay                  dynamicconfig.DurationPropertyFn
@@ -97,7 +110,11 @@ type Config struct {
 	TaskSchedulerShardQueueSize             dynamicconfig.IntPropertyFn
 	TaskSchedulerDispatcherCount            dynamicconfig.IntPropertyFn
 	TaskSchedulerRoundRobinWeights          dynamicconfig.MapPropertyFn
+	TaskSchedulerEnableDomainRoundRobin     dynamicconfig.BoolPropertyFn
+	TaskSchedulerDomainRoundRobinWeight     dynamicconfig.IntPropertyFnWithDomainFilter
+	TaskSchedulerDomainDispatchRPS          dynamicconfig.IntPropertyFnWithDomainFilter
 	TaskCriticalRetryCount                  dynamicconfig.IntPropertyFn
+	TaskDLQMaxAttempts                      dynamicconfig.IntPropertyFn
 	ActiveTaskRedispatchInterval            dynamicconfig.DurationPropertyFn
 	StandbyTaskRedispatchInterval           dynamicconfig.DurationPropertyFn
 	TaskRedispatchIntervalJitterCoefficient dynamicconfig.FloatPropertyFn
@@ -120,6 +137,8 @@ type Config struct {
 	QueueProcessorEnablePersistQueueStates             dynamicconfig.BoolPropertyFn
 	QueueProcessorEnableLoadQueueStates                dynamicconfig.BoolPropertyFn
 	QueueProcessorEnableGracefulSyncShutdown           dynamicconfig.BoolPropertyFn
+	QueueProcessorEnableDomainVirtualQueue             dynamicconfig.BoolPropertyFn
+	QueueProcessorDomainVirtualQueueMaxPollRPS         dynamicconfig.IntPropertyFnWithDomainFilter
 
 	// TimerQueueProcessor settings
 	TimerTaskBatchSize                                dynamicconfig.IntPropertyFn
@@ -138,6 +157,7 @@ type Config struct {
 	TimerProcessorSplitQueueIntervalJitterCoefficient dynamicconfig.FloatPropertyFn
 	TimerProcessorMaxRedispatchQueueSize              dynamicconfig.IntPropertyFn
 	TimerProcessorMaxTimeShift                        dynamicconfig.DurationPropertyFn
+	TimerProcessorInMemoryTimerHorizon                dynamicconfig.DurationPropertyFn
 	TimerProcessorHistoryArchivalSizeLimit            dynamicconfig.IntPropertyFn
 	TimerProcessorArchivalTimeLimit                   dynamicconfig.DurationPropertyFn
 
@@ -192,6 +212,8 @@ type Config struct {
 	ReplicatorProcessorFetchTasksBatchSize dynamicconfig.IntPropertyFnWithShardIDFilter
 	ReplicatorUpperLatency                 dynamicconfig.DurationPropertyFn
 	ReplicatorCacheCapacity                dynamicconfig.IntPropertyFn
+	ReplicatorDomainLagMaxScanTasks        dynamicconfig.IntPropertyFn
+	ReplicatorEmitDomainLagMetrics         dynamicconfig.BoolPropertyFn
 
 	// Persistence settings
 	ExecutionMgrNumConns dynamicconfig.IntPropertyFn
@@ -235,12 +257,12 @@ type Config struct {
 	AllowArchivingIncompleteHistory  dynamicconfig.BoolPropertyFn
 
 	// Size limit related settings
-	BlobSizeLimitError               dynamicconfig.IntPropertyFnWithDomainFilter
-	BlobSizeLimitWarn                dynamicconfig.IntPropertyFnWithDomainFilter
-	HistorySizeLimitError            dynamicconfig.IntPropertyFnWithDomainFilter
-	HistorySizeLimitWarn             dynamicconfig.IntPropertyFnWithDomainFilter
-	HistoryCountLimitError           dynamicconfig.IntPropertyFnWithDomainFilter
-	HistoryCountLimitWarn            dynamicconfig.IntPropertyFnWithDomainFilter
+	BlobSizeLimitError               dynamicconfig.IntPropertyFnWithWorkflowTypeAndTaskListFilters
+	BlobSizeLimitWarn                dynamicconfig.IntPropertyFnWithWorkflowTypeAndTaskListFilters
+	HistorySizeLimitError            dynamicconfig.IntPropertyFnWithWorkflowTypeAndTaskListFilters
+	HistorySizeLimitWarn             dynamicconfig.IntPropertyFnWithWorkflowTypeAndTaskListFilters
+	HistoryCountLimitError           dynamicconfig.IntPropertyFnWithWorkflowTypeAndTaskListFilters
+	HistoryCountLimitWarn            dynamicconfig.IntPropertyFnWithWorkflowTypeAndTaskListFilters
 	PendingActivitiesCountLimitError dynamicconfig.IntPropertyFn
 	PendingActivitiesCountLimitWarn  dynamicconfig.IntPropertyFn
 	PendingActivityValidationEnabled dynamicconfig.BoolPropertyFn
@@ -289,6 +311,22 @@ type Config struct {
 	EnableReplicationTaskGeneration                    dynamicconfig.BoolPropertyFnWithDomainIDAndWorkflowIDFilter
 	EnableRecordWorkflowExecutionUninitialized         dynamicconfig.BoolPropertyFnWithDomainFilter
 
+	// The following is used by the kafka replication transport
+	ReplicationTaskPublisherInterval                dynamicconfig.DurationPropertyFn
+	ReplicationTaskStreamMaxBufferedBatchesPerShard dynamicconfig.IntPropertyFn
+
+	// The following is used by the replication DLQ auto retry processor
+	EnableReplicationDLQAutoRetry      dynamicconfig.BoolPropertyFn
+	ReplicationDLQAutoRetryInterval    dynamicconfig.DurationPropertyFn
+	ReplicationDLQAutoRetryMaxBackoff  dynamicconfig.DurationPropertyFn
+	ReplicationDLQAutoRetryMaxAttempts dynamicconfig.IntPropertyFn
+	ReplicationDLQAutoRetryBatchSize   dynamicconfig.IntPropertyFn
+
+	// The following are used to persist the conflict resolutions of NDC
+	EnableNDCConflictResolutionAudit           dynamicconfig.BoolPropertyFnWithDomainFilter
+	NDCConflictResolutionAuditTTL              dynamicconfig.DurationPropertyFnWithDomainFilter
+	NDCConflictResolutionAuditMaxPendingWrites dynamicconfig.IntPropertyFn
+
 	// The following are used by the history workflowID cache
 	WorkflowIDCacheExternalEnabled     dynamicconfig.BoolPropertyFnWithDomainFilter
 	WorkflowIDCacheInternalEnabled     dynamicconfig.BoolPropertyFnWithDomainFilter
@@ -326,6 +364,9 @@ type Config struct {
 
 	ActivityMaxScheduleToStartTimeoutForRetry dynamicconfig.DurationPropertyFnWithDomainFilter
 
+	// Max # of history events scanned for child workflows when deleting a workflow with its children
+	DeleteWorkflowExecutionMaxChildScanEvents dynamicconfig.IntPropertyFnWithDomainFilter
+
 	// Debugging configurations
 	EnableDebugMode               bool // note that this value is initialized once on service start
 	EnableTaskInfoLogByDomainID   dynamicconfig.BoolPropertyFnWithDomainIDFilter
@@ -375,10 +416,12 @@ func New(dc *dynamicconfig.Collection, numberOfShards int, maxMessageSize int, s
 		EmitShardDiffLog:                     dc.GetBoolProperty(dynamicconfig.EmitShardDiffLog),
 		HistoryCacheInitialSize:              dc.GetIntProperty(dynamicconfig.HistoryCacheInitialSize),
 		HistoryCacheMaxSize:                  dc.GetIntProperty(dynamicconfig.HistoryCacheMaxSize),
+		HistoryCacheMaxSizeInBytes:           dc.GetIntProperty(dynamicconfig.HistoryCacheMaxSizeInBytes),
 		HistoryCacheTTL:                      dc.GetDurationProperty(dynamicconfig.HistoryCacheTTL),
 		EventsCacheInitialCount:              dc.GetIntProperty(dynamicconfig.EventsCacheInitialCount),
 		EventsCacheMaxCount:                  dc.GetIntProperty(dynamicconfig.EventsCacheMaxCount),
 		EventsCacheMaxSize:                   dc.GetIntProperty(dynamicconfig.EventsCacheMaxSize),
+		EventsCacheShardMaxSize:              dc.GetIntProperty(dynamicconfig.EventsCacheShardMaxSize),
 		EventsCacheTTL:                       dc.GetDurationProperty(dynamicconfig.EventsCacheTTL),
 		EventsCacheGlobalEnable:              dc.GetBoolProperty(dynamicconfig.EventsCacheGlobalEnable),
 		EventsCacheGlobalInitialCount:        dc.GetIntProperty(dynamicconfig.EventsCacheGlobalInitialCount),
@@ -386,6 +429,7 @@ func New(dc *dynamicconfig.Collection, numberOfShards int, maxMessageSize int, s
 		RangeSizeBits:                        20, // 20 bits for sequencer, 2^20 sequence number for any range
 		AcquireShardInterval:                 dc.GetDurationProperty(dynamicconfig.AcquireShardInterval),
 		AcquireShardConcurrency:              dc.GetIntProperty(dynamicconfig.AcquireShardConcurrency),
+		EnableGracefulShardHandoff:           dc.GetBoolProperty(dynamicconfig.EnableGracefulShardHandoff),
 		StandbyClusterDelay:                  dc.GetDurationProperty(dynamicconfig.StandbyClusterDelay),
 		StandbyTaskMissingEventsResendDelay:  dc.GetDurationProperty(dynamicconfig.StandbyTaskMissingEventsResendDelay),
 		StandbyTaskMissingEventsDiscardDelay: dc.GetDurationProperty(dynamicconfig.StandbyTaskMissingEventsDiscardDelay),
@@ -401,7 +445,11 @@ func New(dc *dynamicconfig.Collection, numberOfShards int, maxMessageSize int, s
 		TaskSchedulerShardQueueSize:             dc.GetIntProperty(dynamicconfig.TaskSchedulerShardQueueSize),
 		TaskSchedulerDispatcherCount:            dc.GetIntProperty(dynamicconfig.TaskSchedulerDispatcherCount),
 		TaskSchedulerRoundRobinWeights:          dc.GetMapProperty(dynamicconfig.TaskSchedulerRoundRobinWeights),
+		TaskSchedulerEnableDomainRoundRobin:     dc.GetBoolProperty(dynamicconfig.TaskSchedulerEnableDomainRoundRobin),
+		TaskSchedulerDomainRoundRobinWeight:     dc.GetIntPropertyFilteredByDomain(dynamicconfig.TaskSchedulerDomainRoundRobinWeight),
+		TaskSchedulerDomainDispatchRPS:          dc.GetIntPropertyFilteredByDomain(dynamicconfig.TaskSchedulerDomainDispatchRPS),
 		TaskCriticalRetryCount:                  dc.GetIntProperty(dynamicconfig.TaskCriticalRetryCount),
+		TaskDLQMaxAttempts:                      dc.GetIntProperty(dynamicconfig.TaskDLQMaxAttempts),
 		ActiveTaskRedispatchInterval:            dc.GetDurationProperty(dynamicconfig.ActiveTaskRedispatchInterval),
 		StandbyTaskRedispatchInterval:           dc.GetDurationProperty(dynamicconfig.StandbyTaskRedispatchInterval),
 		TaskRedispatchIntervalJitterCoefficient: dc.GetFloat64Property(dynamicconfig.TaskRedispatchIntervalJitterCoefficient),
@@ -423,6 +471,8 @@ func New(dc *dynamicconfig.Collection, numberOfShards int, maxMessageSize int, s
 		QueueProcessorEnablePersistQueueStates:             dc.GetBoolProperty(dynamicconfig.QueueProcessorEnablePersistQueueStates),
 		QueueProcessorEnableLoadQueueStates:                dc.GetBoolProperty(dynamicconfig.QueueProcessorEnableLoadQueueStates),
 		QueueProcessorEnableGracefulSyncShutdown:           dc.GetBoolProperty(dynamicconfig.QueueProcessorEnableGracefulSyncShutdown),
+		QueueProcessorEnableDomainVirtualQueue:             dc.GetBoolProperty(dynamicconfig.QueueProcessorEnableDomainVirtualQueue),
+		QueueProcessorDomainVirtualQueueMaxPollRPS:         dc.GetIntPropertyFilteredByDomain(dynamicconfig.QueueProcessorDomainVirtualQueueMaxPollRPS),
 
 		TimerTaskBatchSize:                                dc.GetIntProperty(dynamicconfig.TimerTaskBatchSize),
 		TimerTaskDeleteBatchSize:                          dc.GetIntProperty(dynamicconfig.TimerTaskDeleteBatchSize),
@@ -440,6 +490,7 @@ func New(dc *dynamicconfig.Collection, numberOfShards int, maxMessageSize int, s
 		TimerProcessorSplitQueueIntervalJitterCoefficient: dc.GetFloat64Property(dynamicconfig.TimerProcessorSplitQueueIntervalJitterCoefficient),
 		TimerProcessorMaxRedispatchQueueSize:              dc.GetIntProperty(dynamicconfig.TimerProcessorMaxRedispatchQueueSize),
 		TimerProcessorMaxTimeShift:                        dc.GetDurationProperty(dynamicconfig.TimerProcessorMaxTimeShift),
+		TimerProcessorInMemoryTimerHorizon:                dc.GetDurationProperty(dynamicconfig.TimerProcessorInMemoryTimerHorizon),
 		TimerProcessorHistoryArchivalSizeLimit:            dc.GetIntProperty(dynamicconfig.TimerProcessorHistoryArchivalSizeLimit),
 		TimerProcessorArchivalTimeLimit:                   dc.GetDurationProperty(dynamicconfig.TimerProcessorArchivalTimeLimit),
 
@@ -489,6 +540,8 @@ func New(dc *dynamicconfig.Collection, numberOfShards int, maxMessageSize int, s
 		ReplicatorProcessorFetchTasksBatchSize: dc.GetIntPropertyFilteredByShardID(dynamicconfig.ReplicatorTaskBatchSize),
 		ReplicatorUpperLatency:                 dc.GetDurationProperty(dynamicconfig.ReplicatorUpperLatency),
 		ReplicatorCacheCapacity:                dc.GetIntProperty(dynamicconfig.ReplicatorCacheCapacity),
+		ReplicatorDomainLagMaxScanTasks:        dc.GetIntProperty(dynamicconfig.ReplicatorDomainLagMaxScanTasks),
+		ReplicatorEmitDomainLagMetrics:         dc.GetBoolProperty(dynamicconfig.ReplicatorEmitDomainLagMetrics),
 
 		ExecutionMgrNumConns:            dc.GetIntProperty(dynamicconfig.ExecutionMgrNumConns),
 		HistoryMgrNumConns:              dc.GetIntProperty(dynamicconfig.HistoryMgrNumConns),
@@ -514,12 +567,12 @@ func New(dc *dynamicconfig.Collection, numberOfShards int, maxMessageSize int, s
 		ArchiveInlineVisibilityGlobalRPS: dc.GetIntProperty(dynamicconfig.ArchiveInlineVisibilityGlobalRPS),
 		AllowArchivingIncompleteHistory:  dc.GetBoolProperty(dynamicconfig.AllowArchivingIncompleteHistory),
 
-		BlobSizeLimitError:               dc.GetIntPropertyFilteredByDomain(dynamicconfig.BlobSizeLimitError),
-		BlobSizeLimitWarn:                dc.GetIntPropertyFilteredByDomain(dynamicconfig.BlobSizeLimitWarn),
-		HistorySizeLimitError:            dc.GetIntPropertyFilteredByDomain(dynamicconfig.HistorySizeLimitError),
-		HistorySizeLimitWarn:             dc.GetIntPropertyFilteredByDomain(dynamicconfig.HistorySizeLimitWarn),
-		HistoryCountLimitError:           dc.GetIntPropertyFilteredByDomain(dynamicconfig.HistoryCountLimitError),
-		HistoryCountLimitWarn:            dc.GetIntPropertyFilteredByDomain(dynamicconfig.HistoryCountLimitWarn),
+		BlobSizeLimitError:               dc.GetIntPropertyFilteredByWorkflowTypeAndTaskList(dynamicconfig.BlobSizeLimitError),
+		BlobSizeLimitWarn:                dc.GetIntPropertyFilteredByWorkflowTypeAndTaskList(dynamicconfig.BlobSizeLimitWarn),
+		HistorySizeLimitError:            dc.GetIntPropertyFilteredByWorkflowTypeAndTaskList(dynamicconfig.HistorySizeLimitError),
+		HistorySizeLimitWarn:             dc.GetIntPropertyFilteredByWorkflowTypeAndTaskList(dynamicconfig.HistorySizeLimitWarn),
+		HistoryCountLimitError:           dc.GetIntPropertyFilteredByWorkflowTypeAndTaskList(dynamicconfig.HistoryCountLimitError),
+		HistoryCountLimitWarn:            dc.GetIntPropertyFilteredByWorkflowTypeAndTaskList(dynamicconfig.HistoryCountLimitWarn),
 		PendingActivitiesCountLimitError: dc.GetIntProperty(dynamicconfig.PendingActivitiesCountLimitError),
 		PendingActivitiesCountLimitWarn:  dc.GetIntProperty(dynamicconfig.PendingActivitiesCountLimitWarn),
 		PendingActivityValidationEnabled: dc.GetBoolProperty(dynamicconfig.EnablePendingActivityValidation),
@@ -560,6 +613,19 @@ func New(dc *dynamicconfig.Collection, numberOfShards int, maxMessageSize int, s
 		EnableReplicationTaskGeneration:                    dc.GetBoolPropertyFilteredByDomainIDAndWorkflowID(dynamicconfig.EnableReplicationTaskGeneration),
 		EnableRecordWorkflowExecutionUninitialized:         dc.GetBoolPropertyFilteredByDomain(dynamicconfig.EnableRecordWorkflowExecutionUninitialized),
 
+		ReplicationTaskPublisherInterval:                dc.GetDurationProperty(dynamicconfig.ReplicationTaskPublisherInterval),
+		ReplicationTaskStreamMaxBufferedBatchesPerShard: dc.GetIntProperty(dynamicconfig.ReplicationTaskStreamMaxBufferedBatchesPerShard),
+
+		EnableReplicationDLQAutoRetry:      dc.GetBoolProperty(dynamicconfig.EnableReplicationDLQAutoRetry),
+		ReplicationDLQAutoRetryInterval:    dc.GetDurationProperty(dynamicconfig.ReplicationDLQAutoRetryInterval),
+		ReplicationDLQAutoRetryMaxBackoff:  dc.GetDurationProperty(dynamicconfig.ReplicationDLQAutoRetryMaxBackoff),
+		ReplicationDLQAutoRetryMaxAttempts: dc.GetIntProperty(dynamicconfig.ReplicationDLQAutoRetryMaxAttempts),
+		ReplicationDLQAutoRetryBatchSize:   dc.GetIntProperty(dynamicconfig.ReplicationDLQAutoRetryBatchSize),
+
+		EnableNDCConflictResolutionAudit:           dc.GetBoolPropertyFilteredByDomain(dynamicconfig.EnableNDCConflictResolutionAudit),
+		NDCConflictResolutionAuditTTL:              dc.GetDurationPropertyFilteredByDomain(dynamicconfig.NDCConflictResolutionAuditTTL),
+		NDCConflictResolutionAuditMaxPendingWrites: dc.GetIntProperty(dynamicconfig.NDCConflictResolutionAuditMaxPendingWrites),
+
 		WorkflowIDCacheExternalEnabled:     dc.GetBoolPropertyFilteredByDomain(dynamicconfig.WorkflowIDCacheExternalEnabled),
 		WorkflowIDCacheInternalEnabled:     dc.GetBoolPropertyFilteredByDomain(dynamicconfig.WorkflowIDCacheInternalEnabled),
 		WorkflowIDExternalRateLimitEnabled: dc.GetBoolPropertyFilteredByDomain(dynamicconfig.WorkflowIDExternalRateLimitEnabled),
@@ -587,6 +653,7 @@ func New(dc *dynamicconfig.Collection, numberOfShards int, maxMessageSize int, s
 		MaxActivityCountDispatchByDomain:    dc.GetIntPropertyFilteredByDomain(dynamicconfig.MaxActivityCountDispatchByDomain),
 
 		ActivityMaxScheduleToStartTimeoutForRetry: dc.GetDurationPropertyFilteredByDomain(dynamicconfig.ActivityMaxScheduleToStartTimeoutForRetry),
+		DeleteWorkflowExecutionMaxChildScanEvents: dc.GetIntPropertyFilteredByDomain(dynamicconfig.DeleteWorkflowExecutionMaxChildScanEvents),
 
 		EnableDebugMode:               dc.GetBoolProperty(dynamicconfig.EnableDebugMode)(),
 		EnableTaskInfoLogByDomainID:   dc.GetBoolPropertyFilteredByDomainID(dynamicconfig.HistoryEnableTaskInfoLogByDomainID),
@@ -600,6 +667,13 @@ func New(dc *dynamicconfig.Collection, numberOfShards int, maxMessageSize int, s
 
 		EnableStrongIdempotency: dc.GetBoolPropertyFilteredByDomain(dynamicconfig.EnableStrongIdempotency),
 
+		EnableHotShardTracking:                    dc.GetBoolProperty(dynamicconfig.EnableHotShardTracking),
+		HotShardTrackingWindow:                    dc.GetDurationProperty(dynamicconfig.HotShardTrackingWindow),
+		EnableHotShardLoadShedding:                dc.GetBoolProperty(dynamicconfig.EnableHotShardLoadShedding),
+		HotShardLoadSheddingTopN:                  dc.GetIntProperty(dynamicconfig.HotShardLoadSheddingTopN),
+		HotShardLoadSheddingCPUThreshold:          dc.GetFloat64Property(dynamicconfig.HotShardLoadSheddingCPUThreshold),
+		HotShardLoadSheddingPendingTasksThreshold: dc.GetIntProperty(dynamicconfig.HotShardLoadSheddingPendingTasksThreshold),
+
 		HostName: hostname,
 	}
 
@@ -668,5 +742,24 @@ func NewForTestByShardNumber(shardNumber int) *Config {
 
 // GetShardID return the corresponding shard ID for a given workflow ID
 func (config *Config) GetShardID(workflowID string) int {
-	return common.WorkflowIDToHistoryShard(workflowID, config.NumberOfShards)
+	if config.HistoryShardMapping == nil {
+		return common.WorkflowIDToHistoryShard(workflowID, config.NumberOfShards)
+	}
+	return config.HistoryShardMapping().WorkflowIDToShard(workflowID)
+}
+
+// GetWorkflowOwnership returns how the given shard should handle the requests and tasks of a workflow
+func (config *Config) GetWorkflowOwnership(workflowID string, shardID int) common.HistoryShardOwnership {
+	if config.HistoryShardMapping == nil {
+		return common.HistoryShardOwned
+	}
+	return config.HistoryShardMapping().WorkflowOwnership(workflowID, shardID)
+}
+
+// GetTotalShards returns the number of history shards, including the ones created by splitting shards
+func (config *Config) GetTotalShards() int {
+	if config.HistoryShardMapping == nil {
+		return config.NumberOfShards
+	}
+	return config.HistoryShardMapping().TotalShards()
 }
@@ -34,5 +34,6 @@ var (
 	ErrTimestampNotSet         = &types.BadRequestError{Message: "Timestamp not set on request."}
 	ErrInvalidTaskType         = &types.BadRequestError{Message: "Invalid task type"}
 	ErrHistoryHostThrottle     = &types.ServiceBusyError{Message: "History host rps exceeded"}
+	ErrHistoryHostOverloaded   = &types.ServiceBusyError{Message: "History host is overloaded"}
 	ErrShuttingDown            = &types.InternalServiceError{Message: "Shutting down"}
 )
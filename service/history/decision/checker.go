@@ -88,6 +88,25 @@ func newAttrValidator(
 	}
 }
 
+// GetWorkflowSizeLimits returns the blob, history size and history count limits of a workflow,
+// resolved by its domain, workflow type and tasklist
+func GetWorkflowSizeLimits(
+	config *config.Config,
+	domainName string,
+	executionInfo *persistence.WorkflowExecutionInfo,
+) *types.WorkflowSizeLimits {
+	workflowType := executionInfo.WorkflowTypeName
+	taskList := executionInfo.TaskList
+	return &types.WorkflowSizeLimits{
+		BlobSizeLimitWarn:      int64(config.BlobSizeLimitWarn(domainName, workflowType, taskList)),
+		BlobSizeLimitError:     int64(config.BlobSizeLimitError(domainName, workflowType, taskList)),
+		HistorySizeLimitWarn:   int64(config.HistorySizeLimitWarn(domainName, workflowType, taskList)),
+		HistorySizeLimitError:  int64(config.HistorySizeLimitError(domainName, workflowType, taskList)),
+		HistoryCountLimitWarn:  int64(config.HistoryCountLimitWarn(domainName, workflowType, taskList)),
+		HistoryCountLimitError: int64(config.HistoryCountLimitError(domainName, workflowType, taskList)),
+	}
+}
+
 func newWorkflowSizeChecker(
 	blobSizeLimitWarn int,
 	blobSizeLimitError int,
@@ -756,3 +756,48 @@ func (s *attrValidatorSuite) TestValidateActivityScheduleAttributes_WithRetryPol
 	s.Nil(err)
 	s.Equal(expectedAttributesAfterValidation, attributes)
 }
+
+func TestGetWorkflowSizeLimits(t *testing.T) {
+	limitFn := func(defaultLimit int) dynamicconfig.IntPropertyFnWithWorkflowTypeAndTaskListFilters {
+		return func(domainName string, workflowType string, taskList string) int {
+			if domainName == constants.TestDomainName && workflowType == "long-running-workflow" && taskList == "test-tasklist" {
+				return defaultLimit * 10
+			}
+			return defaultLimit
+		}
+	}
+	config := &config.Config{
+		BlobSizeLimitWarn:      limitFn(1),
+		BlobSizeLimitError:     limitFn(2),
+		HistorySizeLimitWarn:   limitFn(3),
+		HistorySizeLimitError:  limitFn(4),
+		HistoryCountLimitWarn:  limitFn(5),
+		HistoryCountLimitError: limitFn(6),
+	}
+
+	limits := GetWorkflowSizeLimits(config, constants.TestDomainName, &persistence.WorkflowExecutionInfo{
+		WorkflowTypeName: "long-running-workflow",
+		TaskList:         "test-tasklist",
+	})
+	require.Equal(t, &types.WorkflowSizeLimits{
+		BlobSizeLimitWarn:      10,
+		BlobSizeLimitError:     20,
+		HistorySizeLimitWarn:   30,
+		HistorySizeLimitError:  40,
+		HistoryCountLimitWarn:  50,
+		HistoryCountLimitError: 60,
+	}, limits)
+
+	limits = GetWorkflowSizeLimits(config, constants.TestDomainName, &persistence.WorkflowExecutionInfo{
+		WorkflowTypeName: "short-workflow",
+		TaskList:         "test-tasklist",
+	})
+	require.Equal(t, &types.WorkflowSizeLimits{
+		BlobSizeLimitWarn:      1,
+		BlobSizeLimitError:     2,
+		HistorySizeLimitWarn:   3,
+		HistorySizeLimitError:  4,
+		HistoryCountLimitWarn:  5,
+		HistoryCountLimitError: 6,
+	}, limits)
+}
@@ -424,13 +424,14 @@ Update_History_Loop:
 			failCause = types.DecisionTaskFailedCauseBadBinary
 			failMessage = fmt.Sprintf("binary %v is already marked as bad deployment", binChecksum)
 		} else {
+			sizeLimits := GetWorkflowSizeLimits(handler.config, domainName, executionInfo)
 			workflowSizeChecker := newWorkflowSizeChecker(
-				handler.config.BlobSizeLimitWarn(domainName),
-				handler.config.BlobSizeLimitError(domainName),
-				handler.config.HistorySizeLimitWarn(domainName),
-				handler.config.HistorySizeLimitError(domainName),
-				handler.config.HistoryCountLimitWarn(domainName),
-				handler.config.HistoryCountLimitError(domainName),
+				int(sizeLimits.BlobSizeLimitWarn),
+				int(sizeLimits.BlobSizeLimitError),
+				int(sizeLimits.HistorySizeLimitWarn),
+				int(sizeLimits.HistorySizeLimitError),
+				int(sizeLimits.HistoryCountLimitWarn),
+				int(sizeLimits.HistoryCountLimitError),
 				completedEvent.ID,
 				msBuilder,
 				executionStats,
@@ -769,8 +770,9 @@ func (handler *handlerImpl) handleBufferedQueries(
 		return
 	}
 
-	sizeLimitError := handler.config.BlobSizeLimitError(domain)
-	sizeLimitWarn := handler.config.BlobSizeLimitWarn(domain)
+	executionInfo := msBuilder.GetExecutionInfo()
+	sizeLimitError := handler.config.BlobSizeLimitError(domain, executionInfo.WorkflowTypeName, executionInfo.TaskList)
+	sizeLimitWarn := handler.config.BlobSizeLimitWarn(domain, executionInfo.WorkflowTypeName, executionInfo.TaskList)
 
 	// Complete or fail all queries we have results for
 	for id, result := range queryResults {
@@ -27,6 +27,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/pborman/uuid"
@@ -47,13 +49,16 @@ import (
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/metrics"
 	cndc "github.com/uber/cadence/common/ndc"
+	"github.com/uber/cadence/common/partition"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/quotas"
 	"github.com/uber/cadence/common/reconciliation/invariant"
 	"github.com/uber/cadence/common/service"
+	ctask "github.com/uber/cadence/common/task"
 	"github.com/uber/cadence/common/types"
 	hcommon "github.com/uber/cadence/service/history/common"
 	"github.com/uber/cadence/service/history/config"
+	"github.com/uber/cadence/service/history/constants"
 	"github.com/uber/cadence/service/history/decision"
 	"github.com/uber/cadence/service/history/engine"
 	"github.com/uber/cadence/service/history/events"
@@ -76,6 +81,7 @@ const (
 	queryFirstDecisionTaskCheckInterval   = 200 * time.Millisecond
 	contextLockTimeout                    = 500 * time.Millisecond
 	longPollCompletionBuffer              = 50 * time.Millisecond
+	localOnlyWorkflowRefreshPageSize      = 100
 
 	// TerminateIfRunningReason reason for terminateIfRunning
 	TerminateIfRunningReason = "TerminateIfRunning Policy"
@@ -114,6 +120,7 @@ type (
 		queueTaskProcessor             task.Processor
 		crossClusterTaskProcessors     common.Daemon
 		replicationTaskProcessors      []replication.TaskProcessor
+		replicationTaskPublishers      []replication.TaskPublisher
 		replicationAckManager          replication.TaskAckManager
 		replicationTaskStore           *replication.TaskStore
 		replicationHydrator            replication.TaskHydrator
@@ -127,6 +134,7 @@ type (
 		failoverMarkerNotifier         failover.MarkerNotifier
 		wfIDCache                      workflowcache.WFCache
 		ratelimitInternalPerWorkflowID dynamicconfig.BoolPropertyFnWithDomainFilter
+		shutdownCh                     chan struct{}
 	}
 )
 
@@ -153,6 +161,7 @@ func NewEngineWithShardContext(
 	config *config.Config,
 	crossClusterTaskFetchers task.Fetchers,
 	replicationTaskFetchers replication.TaskFetchers,
+	replicationTaskPublishers replication.TaskPublishers,
 	rawMatchingClient matching.Client,
 	queueTaskProcessor task.Processor,
 	failoverCoordinator failover.Coordinator,
@@ -178,6 +187,7 @@ func NewEngineWithShardContext(
 	replicationReader := replication.NewDynamicTaskReader(shard.GetShardID(), executionManager, shard.GetTimeSource(), config)
 
 	historyEngImpl := &historyEngineImpl{
+		shutdownCh:           make(chan struct{}),
 		currentClusterName:   currentClusterName,
 		shard:                shard,
 		clusterMetadata:      shard.GetClusterMetadata(),
@@ -239,7 +249,8 @@ func NewEngineWithShardContext(
 		),
 		replicationTaskStore: replicationTaskStore,
 		replicationMetricsEmitter: replication.NewMetricsEmitter(
-			shard.GetShardID(), shard, replicationReader, shard.GetMetricsClient()),
+			shard.GetShardID(), shard, replicationReader, shard.GetMetricsClient(),
+			config.ReplicatorDomainLagMaxScanTasks, config.ReplicatorEmitDomainLagMetrics),
 		wfIDCache:                      wfIDCache,
 		ratelimitInternalPerWorkflowID: ratelimitInternalPerWorkflowID,
 	}
@@ -365,6 +376,19 @@ func NewEngineWithShardContext(
 	replicationMessageHandler := replication.NewDLQHandler(shard, replicationTaskExecutors)
 	historyEngImpl.replicationDLQHandler = replicationMessageHandler
 
+	if replicationTaskPublishers != nil {
+		for clusterName := range shard.GetClusterMetadata().GetRemoteClusterInfo() {
+			historyEngImpl.replicationTaskPublishers = append(historyEngImpl.replicationTaskPublishers, replicationTaskPublishers.NewTaskPublisher(
+				shard.GetShardID(),
+				clusterName,
+				shard,
+				&historyEngImpl.replicationAckManager,
+				shard.GetTimeSource(),
+				logger,
+			))
+		}
+	}
+
 	shard.SetEngine(historyEngImpl)
 	return historyEngImpl
 }
@@ -394,6 +418,9 @@ func (e *historyEngineImpl) Start() {
 	for _, replicationTaskProcessor := range e.replicationTaskProcessors {
 		replicationTaskProcessor.Start()
 	}
+	for _, replicationTaskPublisher := range e.replicationTaskPublishers {
+		replicationTaskPublisher.Start()
+	}
 	if e.config.EnableGracefulFailover() {
 		e.failoverMarkerNotifier.Start()
 	}
@@ -405,6 +432,7 @@ func (e *historyEngineImpl) Stop() {
 	e.logger.Info("History engine state changed", tag.LifeCycleStopping)
 	defer e.logger.Info("History engine state changed", tag.LifeCycleStopped)
 
+	close(e.shutdownCh)
 	e.txProcessor.Stop()
 	e.timerProcessor.Stop()
 	e.crossClusterProcessor.Stop()
@@ -416,6 +444,9 @@ func (e *historyEngineImpl) Stop() {
 	for _, replicationTaskProcessor := range e.replicationTaskProcessors {
 		replicationTaskProcessor.Stop()
 	}
+	for _, replicationTaskPublisher := range e.replicationTaskPublishers {
+		replicationTaskPublisher.Stop()
+	}
 
 	if e.queueTaskProcessor != nil {
 		e.queueTaskProcessor.StopShardProcessor(e.shard)
@@ -427,6 +458,77 @@ func (e *historyEngineImpl) Stop() {
 	e.shard.GetDomainCache().UnregisterDomainChangeCallback(e.shard.GetShardID())
 }
 
+// refreshLocalOnlyWorkflowTasks regenerates the tasks of the open local-only workflows of the shard in the given domains.
+// Local-only workflows are never replicated, the standby executors ack their tasks while the domain is active in
+// another cluster, so timers which fired and tasks which were pending meanwhile are recreated after the failback.
+func (e *historyEngineImpl) refreshLocalOnlyWorkflowTasks(domainIDs map[string]struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-e.shutdownCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	refreshed := 0
+	var pageToken []byte
+	for {
+		resp, err := e.executionManager.ListConcreteExecutions(ctx, &persistence.ListConcreteExecutionsRequest{
+			PageSize:  localOnlyWorkflowRefreshPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			e.logger.Error("Failed to list executions to refresh local-only workflows.", tag.WorkflowDomainIDs(domainIDs), tag.Error(err))
+			return
+		}
+		for _, entity := range resp.Executions {
+			info := entity.ExecutionInfo
+			if info == nil || !info.IsRunning() || !execution.IsLocalOnlyWorkflow(info) {
+				continue
+			}
+			if _, ok := domainIDs[info.DomainID]; !ok {
+				continue
+			}
+			if err := e.RefreshWorkflowTasks(ctx, info.DomainID, types.WorkflowExecution{
+				WorkflowID: info.WorkflowID,
+				RunID:      info.RunID,
+			}); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				e.logger.Error("Failed to refresh tasks of local-only workflow.",
+					tag.WorkflowDomainID(info.DomainID),
+					tag.WorkflowID(info.WorkflowID),
+					tag.WorkflowRunID(info.RunID),
+					tag.Error(err),
+				)
+				continue
+			}
+			refreshed++
+		}
+		pageToken = resp.PageToken
+		if len(pageToken) == 0 {
+			break
+		}
+	}
+	e.logger.Info("Refreshed tasks of local-only workflows after domain failover.", tag.WorkflowDomainIDs(domainIDs), tag.Counter(refreshed))
+}
+
+// isDomainFailoverToCluster returns whether the domain change is a failover which requires the cluster to process
+// the tasks of the domain as active. The failover of a region of an active-active domain does not change the active
+// cluster of the domain, the executors skip the tasks of the workflows of the domain which are active in other clusters.
+func isDomainFailoverToCluster(
+	nextDomain *cache.DomainCacheEntry,
+	shardNotificationVersion int64,
+	clusterName string,
+) bool {
+	return nextDomain.IsGlobalDomain() &&
+		nextDomain.GetFailoverNotificationVersion() >= shardNotificationVersion &&
+		(nextDomain.GetReplicationConfig().ActiveClusterName == clusterName || nextDomain.IsActiveClusterOfRegion(clusterName))
+}
+
 func (e *historyEngineImpl) registerDomainFailoverCallback() {
 
 	// NOTE: READ BEFORE MODIFICATION
@@ -449,12 +551,7 @@ func (e *historyEngineImpl) registerDomainFailoverCallback() {
 	// above 2 guarantees that failover start is after persistence of the task.
 
 	failoverPredicate := func(shardNotificationVersion int64, nextDomain *cache.DomainCacheEntry, action func()) {
-		domainFailoverNotificationVersion := nextDomain.GetFailoverNotificationVersion()
-		domainActiveCluster := nextDomain.GetReplicationConfig().ActiveClusterName
-
-		if nextDomain.IsGlobalDomain() &&
-			domainFailoverNotificationVersion >= shardNotificationVersion &&
-			domainActiveCluster == e.currentClusterName {
+		if isDomainFailoverToCluster(nextDomain, shardNotificationVersion, e.currentClusterName) {
 			action()
 		}
 	}
@@ -502,6 +599,10 @@ func (e *historyEngineImpl) registerDomainFailoverCallback() {
 				fakeDecisionTimeoutTask := []persistence.Task{&persistence.DecisionTimeoutTask{TaskData: persistence.TaskData{VisibilityTimestamp: now}}}
 				e.txProcessor.NotifyNewTask(e.currentClusterName, &hcommon.NotifyTaskInfo{Tasks: fakeDecisionTask})
 				e.timerProcessor.NotifyNewTask(e.currentClusterName, &hcommon.NotifyTaskInfo{Tasks: fakeDecisionTimeoutTask})
+
+				// the standby tasks of local-only workflows are acked while the domain is active in another cluster,
+				// regenerate them now that the domain failed back
+				go e.refreshLocalOnlyWorkflowTasks(failoverDomainIDs)
 			}
 
 			// handle graceful failover on active to passive
@@ -630,6 +731,57 @@ func (e *historyEngineImpl) newDomainNotActiveError(
 	)
 }
 
+// selectActiveClusterForWorkflow selects the region of a workflow of an active-active domain and records it in the
+// partition config of the workflow, the workflow can only be started in the active cluster of the region
+func (e *historyEngineImpl) selectActiveClusterForWorkflow(
+	domainEntry *cache.DomainCacheEntry,
+	startRequest *types.HistoryStartWorkflowExecutionRequest,
+	mutableState execution.MutableState,
+) error {
+
+	partitionConfig := make(map[string]string, len(startRequest.PartitionConfig)+1)
+	for key, value := range startRequest.PartitionConfig {
+		partitionConfig[key] = value
+	}
+	region := domainEntry.GetActiveRegionForWorkflow(
+		startRequest.StartRequest.GetWorkflowID(),
+		partitionConfig[partition.ActiveRegionKey],
+	)
+	partitionConfig[partition.ActiveRegionKey] = region
+	startRequest.PartitionConfig = partitionConfig
+
+	activeCluster := domainEntry.GetActiveClustersByRegion()[region]
+	currentCluster := e.clusterMetadata.GetCurrentClusterName()
+	if activeCluster != currentCluster {
+		return ce.NewDomainNotActiveError(domainEntry.GetInfo().Name, currentCluster, activeCluster)
+	}
+	return mutableState.UpdateCurrentVersion(domainEntry.GetFailoverVersionForCluster(e.clusterMetadata, activeCluster), true)
+}
+
+// selectReplicationForWorkflow marks a workflow of a global domain as local-only in its partition config if it
+// matches the local-only filters of the domain. Child workflows follow their parent, whose partition config
+// they inherit, so that a replicated parent never waits for a child which does not exist in the other clusters
+func selectReplicationForWorkflow(
+	domainEntry *cache.DomainCacheEntry,
+	startRequest *types.HistoryStartWorkflowExecutionRequest,
+) {
+
+	if startRequest.ParentExecutionInfo != nil || startRequest.PartitionConfig[partition.LocalOnlyKey] == "true" {
+		return
+	}
+	request := startRequest.StartRequest
+	if !domainEntry.IsLocalOnlyWorkflow(request.WorkflowType.GetName(), request.TaskList.GetName()) {
+		return
+	}
+
+	partitionConfig := make(map[string]string, len(startRequest.PartitionConfig)+1)
+	for key, value := range startRequest.PartitionConfig {
+		partitionConfig[key] = value
+	}
+	partitionConfig[partition.LocalOnlyKey] = "true"
+	startRequest.PartitionConfig = partitionConfig
+}
+
 func (e *historyEngineImpl) startWorkflowHelper(
 	ctx context.Context,
 	startRequest *types.HistoryStartWorkflowExecutionRequest,
@@ -679,6 +831,14 @@ func (e *historyEngineImpl) startWorkflowHelper(
 	if err != nil {
 		return nil, err
 	}
+	if domainEntry.IsActiveActive() {
+		if err := e.selectActiveClusterForWorkflow(domainEntry, startRequest, curMutableState); err != nil {
+			return nil, err
+		}
+	}
+	if domainEntry.IsGlobalDomain() {
+		selectReplicationForWorkflow(domainEntry, startRequest)
+	}
 
 	// preprocess for signalWithStart
 	var prevMutableState execution.MutableState
@@ -1293,7 +1453,7 @@ func (e *historyEngineImpl) QueryWorkflow(
 	// 2. the workflow is not running, whenever a workflow is not running dispatching query directly is consistent
 	// 3. the client requested eventual consistency, in this case there are no consistency requirements so dispatching directly through matching is safe
 	// 4. if there is no pending or started decision it means no events came before query arrived, so its safe to dispatch directly
-	isActive, _ := de.IsActiveIn(e.clusterMetadata.GetCurrentClusterName())
+	isActive := e.isWorkflowActive(de, mutableState)
 	safeToDispatchDirectly := !isActive ||
 		!mutableState.IsWorkflowExecutionRunning() ||
 		req.GetQueryConsistencyLevel() == types.QueryConsistencyLevelEventual ||
@@ -1305,7 +1465,7 @@ func (e *historyEngineImpl) QueryWorkflow(
 			return nil, err
 		}
 		req.Execution.RunID = msResp.Execution.RunID
-		return e.queryDirectlyThroughMatching(ctx, msResp, request.GetDomainUUID(), req, scope)
+		return e.queryDirectlyThroughMatching(ctx, msResp, isActive, request.GetDomainUUID(), req, scope)
 	}
 
 	// If we get here it means query could not be dispatched through matching directly, so it must block
@@ -1349,7 +1509,7 @@ func (e *historyEngineImpl) QueryWorkflow(
 				return nil, err
 			}
 			req.Execution.RunID = msResp.Execution.RunID
-			return e.queryDirectlyThroughMatching(ctx, msResp, request.GetDomainUUID(), req, scope)
+			return e.queryDirectlyThroughMatching(ctx, msResp, isActive, request.GetDomainUUID(), req, scope)
 		case query.TerminationTypeFailed:
 			return nil, state.Failure
 		default:
@@ -1362,9 +1522,19 @@ func (e *historyEngineImpl) QueryWorkflow(
 	}
 }
 
+// isWorkflowActive returns whether the workflow is active in the current cluster, for active-active domains
+// it depends on the region of the workflow
+func (e *historyEngineImpl) isWorkflowActive(
+	domainEntry *cache.DomainCacheEntry,
+	mutableState execution.MutableState,
+) bool {
+	return execution.IsWorkflowActiveIn(domainEntry, mutableState.GetExecutionInfo(), e.clusterMetadata.GetCurrentClusterName())
+}
+
 func (e *historyEngineImpl) queryDirectlyThroughMatching(
 	ctx context.Context,
 	msResp *types.GetMutableStateResponse,
+	workflowIsActive bool,
 	domainID string,
 	queryRequest *types.QueryWorkflowRequest,
 	scope metrics.Scope,
@@ -1379,17 +1549,12 @@ func (e *historyEngineImpl) queryDirectlyThroughMatching(
 	// Stickiness might be outdated if the customer did a restart of their nodes causing a query
 	// dispatched on the standby side on sticky to hang. We decided it made sense to simply not attempt
 	// query on sticky task list at all on the passive side.
-	de, err := e.shard.GetDomainCache().GetDomainByID(domainID)
-	if err != nil {
-		return nil, err
-	}
 	supportsStickyQuery := e.clientChecker.SupportsStickyQuery(msResp.GetClientImpl(), msResp.GetClientFeatureVersion()) == nil
-	domainIsActive, _ := de.IsActiveIn(e.clusterMetadata.GetCurrentClusterName())
 	if msResp.GetIsStickyTaskListEnabled() &&
 		len(msResp.GetStickyTaskList().GetName()) != 0 &&
 		supportsStickyQuery &&
 		e.config.EnableStickyQuery(queryRequest.GetDomain()) &&
-		domainIsActive {
+		workflowIsActive {
 
 		stickyMatchingRequest := &types.MatchingQueryWorkflowRequest{
 			DomainUUID:   domainID,
@@ -1684,6 +1849,7 @@ func (e *historyEngineImpl) DescribeWorkflowExecution(
 			SearchAttributes: &types.SearchAttributes{IndexedFields: executionInfo.SearchAttributes},
 			PartitionConfig:  executionInfo.PartitionConfig,
 		},
+		WorkflowSizeLimits: decision.GetWorkflowSizeLimits(e.config, wfContext.GetDomainName(), executionInfo),
 	}
 
 	// TODO: we need to consider adding execution time to mutable state
@@ -1903,7 +2069,7 @@ func (e *historyEngineImpl) RecordActivityTaskStarted(
 			if err != nil {
 				return err
 			}
-			response.ScheduledEvent = scheduledEvent
+			response.ScheduledEvent = overrideActivityScheduledEventOptions(scheduledEvent, ai)
 			response.ScheduledTimestampOfThisAttempt = common.Int64Ptr(ai.ScheduledTime.UnixNano())
 
 			response.Attempt = int64(ai.Attempt)
@@ -1925,6 +2091,13 @@ func (e *historyEngineImpl) RecordActivityTaskStarted(
 				return &types.EventAlreadyStartedError{Message: "Activity task already started."}
 			}
 
+			if pollTaskList := request.PollRequest.GetTaskList().GetName(); pollTaskList != "" && taskListRootName(pollTaskList) != ai.TaskList {
+				// the task list of the activity is updated, the task is left over on the previous task list
+				// and the activity is dispatched to the new task list by another task
+				e.logger.Debug("Activity task from previous task list.", tag.TaskID(request.GetTaskID()), tag.WorkflowScheduleID(scheduleID), tag.WorkflowTaskListName(pollTaskList))
+				return workflow.ErrActivityTaskNotFound
+			}
+
 			if _, err := mutableState.AddActivityTaskStartedEvent(
 				ai, scheduleID, requestID, request.PollRequest.GetIdentity(),
 			); err != nil {
@@ -1946,6 +2119,57 @@ func (e *historyEngineImpl) RecordActivityTaskStarted(
 	return response, err
 }
 
+// taskListRootName returns the name of the task list which the given task list partition belongs to,
+// partitions other than the root are named /__cadence_sys/<root>/<partition>
+func taskListRootName(name string) string {
+	if !strings.HasPrefix(name, common.ReservedTaskListPrefix) {
+		return name
+	}
+	suffixOff := strings.LastIndex(name, "/")
+	if suffixOff <= len(common.ReservedTaskListPrefix) {
+		return name
+	}
+	return name[len(common.ReservedTaskListPrefix):suffixOff]
+}
+
+// overrideActivityScheduledEventOptions returns the scheduled event with the timeouts and
+// task list of the activity info, since those can be updated after the activity is scheduled.
+// The original event is returned as is if there's nothing to override, as it may be shared
+// through the events cache and must not be mutated.
+func overrideActivityScheduledEventOptions(
+	scheduledEvent *types.HistoryEvent,
+	ai *persistence.ActivityInfo,
+) *types.HistoryEvent {
+
+	attributes := scheduledEvent.GetActivityTaskScheduledEventAttributes()
+	if attributes == nil ||
+		(attributes.GetScheduleToCloseTimeoutSeconds() == ai.ScheduleToCloseTimeout &&
+			attributes.GetScheduleToStartTimeoutSeconds() == ai.ScheduleToStartTimeout &&
+			attributes.GetStartToCloseTimeoutSeconds() == ai.StartToCloseTimeout &&
+			attributes.GetHeartbeatTimeoutSeconds() == ai.HeartbeatTimeout &&
+			attributes.TaskList.GetName() == ai.TaskList) {
+		return scheduledEvent
+	}
+
+	overriddenAttributes := *attributes
+	overriddenAttributes.ScheduleToCloseTimeoutSeconds = common.Int32Ptr(ai.ScheduleToCloseTimeout)
+	overriddenAttributes.ScheduleToStartTimeoutSeconds = common.Int32Ptr(ai.ScheduleToStartTimeout)
+	overriddenAttributes.StartToCloseTimeoutSeconds = common.Int32Ptr(ai.StartToCloseTimeout)
+	overriddenAttributes.HeartbeatTimeoutSeconds = common.Int32Ptr(ai.HeartbeatTimeout)
+	if attributes.TaskList.GetName() != ai.TaskList {
+		taskList := types.TaskList{}
+		if attributes.TaskList != nil {
+			taskList = *attributes.TaskList
+		}
+		taskList.Name = ai.TaskList
+		overriddenAttributes.TaskList = &taskList
+	}
+
+	overriddenEvent := *scheduledEvent
+	overriddenEvent.ActivityTaskScheduledEventAttributes = &overriddenAttributes
+	return &overriddenEvent
+}
+
 // ScheduleDecisionTask schedules a decision if no outstanding decision found
 func (e *historyEngineImpl) ScheduleDecisionTask(
 	ctx context.Context,
@@ -2351,6 +2575,91 @@ func (e *historyEngineImpl) RecordActivityTaskHeartbeat(
 	return &types.RecordActivityTaskHeartbeatResponse{CancelRequested: cancelRequested}, nil
 }
 
+// UpdateActivityOptions records an ActivityTaskOptionsUpdated event which patches the timeouts,
+// retry policy and task list of a pending activity.
+// Timer tasks of the activity are regenerated with the new options when the update is persisted.
+func (e *historyEngineImpl) UpdateActivityOptions(
+	ctx context.Context,
+	req *types.HistoryUpdateActivityOptionsRequest,
+) error {
+
+	domainEntry, err := e.getActiveDomainByID(req.DomainUUID)
+	if err != nil {
+		return err
+	}
+	domainID := domainEntry.GetInfo().ID
+
+	request := req.UpdateRequest
+	if err := validateUpdateActivityOptionsRequest(request); err != nil {
+		return err
+	}
+
+	workflowExecution := types.WorkflowExecution{
+		WorkflowID: request.WorkflowExecution.GetWorkflowID(),
+		RunID:      request.WorkflowExecution.GetRunID(),
+	}
+
+	return workflow.UpdateWithAction(ctx, e.executionCache, domainID, workflowExecution, false, e.timeSource.Now(),
+		func(wfContext execution.Context, mutableState execution.MutableState) error {
+			if !mutableState.IsWorkflowExecutionRunning() {
+				return workflow.ErrAlreadyCompleted
+			}
+
+			ai, ok := mutableState.GetActivityByActivityID(request.GetActivityID())
+			if !ok {
+				return workflow.ErrActivityTaskNotFound
+			}
+			if ai.CancelRequested {
+				return &types.BadRequestError{Message: "Cannot update options of an activity with cancellation requested."}
+			}
+			if request.GetTaskList() != nil && ai.DomainID == "" {
+				return &types.BadRequestError{Message: "Cannot update task list of an activity scheduled by an old server version."}
+			}
+
+			e.logger.Info("Updating activity options",
+				tag.WorkflowDomainName(domainEntry.GetInfo().Name),
+				tag.WorkflowID(workflowExecution.GetWorkflowID()),
+				tag.WorkflowRunID(workflowExecution.GetRunID()),
+				tag.WorkflowScheduleID(ai.ScheduleID),
+				tag.WorkflowActivityID(ai.ActivityID),
+			)
+
+			_, err := mutableState.AddActivityTaskOptionsUpdatedEvent(ctx, ai, request)
+			return err
+		})
+}
+
+func validateUpdateActivityOptionsRequest(
+	request *types.UpdateActivityOptionsRequest,
+) error {
+
+	if request == nil {
+		return &types.BadRequestError{Message: "Request is not set on update activity options request."}
+	}
+	if request.GetWorkflowExecution().GetWorkflowID() == "" {
+		return &types.BadRequestError{Message: "WorkflowID is not set on update activity options request."}
+	}
+	if request.GetActivityID() == "" {
+		return &types.BadRequestError{Message: "ActivityID is not set on update activity options request."}
+	}
+	if request.ScheduleToCloseTimeoutSeconds != nil && request.GetScheduleToCloseTimeoutSeconds() <= 0 {
+		return &types.BadRequestError{Message: "ScheduleToCloseTimeoutSeconds must be greater than 0."}
+	}
+	if request.ScheduleToStartTimeoutSeconds != nil && request.GetScheduleToStartTimeoutSeconds() <= 0 {
+		return &types.BadRequestError{Message: "ScheduleToStartTimeoutSeconds must be greater than 0."}
+	}
+	if request.StartToCloseTimeoutSeconds != nil && request.GetStartToCloseTimeoutSeconds() <= 0 {
+		return &types.BadRequestError{Message: "StartToCloseTimeoutSeconds must be greater than 0."}
+	}
+	if request.HeartbeatTimeoutSeconds != nil && request.GetHeartbeatTimeoutSeconds() < 0 {
+		return &types.BadRequestError{Message: "HeartbeatTimeoutSeconds cannot be less than 0."}
+	}
+	if request.TaskList != nil && request.GetTaskList().GetName() == "" {
+		return &types.BadRequestError{Message: "TaskList name cannot be empty."}
+	}
+	return common.ValidateRetryPolicy(request.RetryPolicy)
+}
+
 // RequestCancelWorkflowExecution records request cancellation event for workflow execution
 func (e *historyEngineImpl) RequestCancelWorkflowExecution(
 	ctx context.Context,
@@ -2813,6 +3122,108 @@ func (e *historyEngineImpl) TerminateWorkflowExecution(
 		})
 }
 
+// DeleteWorkflowExecution deletes a workflow run without waiting for the domain retention period.
+// A running workflow is terminated first if requested. History branches, visibility and execution
+// records are removed by the same timer task used for retention based deletion, so that archival
+// and cleanup stay consistent. Child workflow executions started by the run are returned so that the caller can cascade the
+// deletion to them.
+func (e *historyEngineImpl) DeleteWorkflowExecution(
+	ctx context.Context,
+	request *types.HistoryDeleteWorkflowExecutionRequest,
+) (resp *types.DeleteWorkflowExecutionResponse, retError error) {
+
+	domainEntry, err := e.getActiveDomainByID(request.DomainUUID)
+	if err != nil {
+		return nil, err
+	}
+	domainID := domainEntry.GetInfo().ID
+	domainName := domainEntry.GetInfo().Name
+
+	deleteRequest := request.DeleteRequest
+	workflowID := deleteRequest.GetWorkflowExecution().GetWorkflowID()
+	runID := deleteRequest.GetWorkflowExecution().GetRunID()
+	if workflowID == "" {
+		return nil, &types.BadRequestError{Message: "WorkflowID is not set on delete workflow execution request."}
+	}
+
+	if deleteRequest.GetTerminateIfRunning() {
+		err := e.TerminateWorkflowExecution(ctx, &types.HistoryTerminateWorkflowExecutionRequest{
+			DomainUUID: domainID,
+			TerminateRequest: &types.TerminateWorkflowExecutionRequest{
+				Domain: domainName,
+				WorkflowExecution: &types.WorkflowExecution{
+					WorkflowID: workflowID,
+					RunID:      runID,
+				},
+				Reason:   deleteRequest.GetReason(),
+				Identity: deleteRequest.GetIdentity(),
+			},
+		})
+		if err != nil && err != workflow.ErrAlreadyCompleted {
+			return nil, err
+		}
+	}
+
+	wfContext, err := workflow.Load(ctx, e.executionCache, e.executionManager, domainID, domainName, workflowID, runID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { wfContext.GetReleaseFn()(retError) }()
+
+	mutableState := wfContext.GetMutableState()
+	if mutableState.IsWorkflowExecutionRunning() {
+		return nil, &types.BadRequestError{Message: "Workflow execution is still running, it can only be deleted with TerminateIfRunning set."}
+	}
+
+	var childExecutions []*types.ChildWorkflowExecutionInfo
+	if deleteRequest.GetIncludeChildWorkflows() {
+		childExecutions, err = execution.GetChildWorkflowExecutions(
+			ctx,
+			e.shard,
+			mutableState,
+			e.config.DeleteWorkflowExecutionMaxChildScanEvents(domainName),
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, childExecution := range childExecutions {
+			if childExecution.Domain == "" {
+				childExecution.Domain = domainName
+			}
+		}
+	}
+
+	lastWriteVersion, err := mutableState.GetLastWriteVersion()
+	if err != nil {
+		return nil, err
+	}
+	now := e.timeSource.Now()
+	mutableState.AddTimerTasks(&persistence.DeleteHistoryEventTask{
+		TaskData: persistence.TaskData{
+			// TaskID is set by shard
+			VisibilityTimestamp: now,
+			Version:             lastWriteVersion,
+		},
+	})
+	if err := wfContext.GetContext().UpdateWorkflowExecutionTasks(ctx, now); err != nil {
+		return nil, err
+	}
+
+	e.logger.Info("Workflow execution deletion requested",
+		tag.HandlerCall("DeleteWorkflowExecution"),
+		tag.WorkflowDomainName(domainName),
+		tag.WorkflowID(workflowID),
+		tag.WorkflowRunID(wfContext.GetRunID()),
+		tag.ActorID(deleteRequest.GetIdentity()),
+		tag.ActorReason(deleteRequest.GetReason()),
+		tag.Counter(len(childExecutions)),
+	)
+
+	return &types.DeleteWorkflowExecutionResponse{
+		ChildWorkflowExecutions: childExecutions,
+	}, nil
+}
+
 // RecordChildExecutionCompleted records the completion of child execution into parent execution history
 func (e *historyEngineImpl) RecordChildExecutionCompleted(
 	ctx context.Context,
@@ -3156,7 +3567,7 @@ func hydrateReplicationTask(
 	}
 
 	hydrator := replication.NewImmediateTaskHydrator(
-		exec.IsRunning(),
+		exec,
 		versionHistories,
 		activities,
 		history.Find(info.BranchToken, info.FirstEventID),
@@ -3230,6 +3641,105 @@ func (e *historyEngineImpl) describeQueue(
 	}, nil
 }
 
+func (e *historyEngineImpl) ListQueueTasks(
+	ctx context.Context,
+	request *types.ListQueueTasksRequest,
+) (*types.ListQueueTasksResponse, error) {
+	queueProcessor, err := e.getQueueProcessor(request.GetType())
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := &queue.ListTasksAttributes{
+		MinTaskID: request.GetMinTaskID(),
+		MaxTaskID: request.GetMaxTaskID(),
+		PageSize:  int(request.GetPageSize()),
+	}
+	if request.MinVisibilityTimestamp != nil {
+		attributes.MinVisibilityTimestamp = time.Unix(0, request.GetMinVisibilityTimestamp())
+	}
+	if request.MaxVisibilityTimestamp != nil {
+		attributes.MaxVisibilityTimestamp = time.Unix(0, request.GetMaxVisibilityTimestamp())
+	}
+
+	resp, err := queueProcessor.HandleAction(ctx, request.GetClusterName(), queue.NewListTasksAction(attributes))
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*types.QueueTaskInfo, 0, len(resp.ListTasksResult.Tasks))
+	for _, t := range resp.ListTasksResult.Tasks {
+		taskInfo := &types.QueueTaskInfo{
+			DomainID:            t.GetDomainID(),
+			WorkflowID:          t.GetWorkflowID(),
+			RunID:               t.GetRunID(),
+			TaskID:              t.GetTaskID(),
+			TaskType:            int32(t.GetTaskType()),
+			VisibilityTimestamp: t.GetVisibilityTimestamp().UnixNano(),
+			Version:             t.GetVersion(),
+			State:               taskStateToString(t.State()),
+			Attempt:             int32(t.GetAttempt()),
+		}
+		if lastErr := t.GetLastError(); lastErr != nil {
+			taskInfo.LastError = lastErr.Error()
+		}
+		tasks = append(tasks, taskInfo)
+	}
+	return &types.ListQueueTasksResponse{
+		Tasks: tasks,
+	}, nil
+}
+
+func (e *historyEngineImpl) ExecuteQueueTask(
+	ctx context.Context,
+	request *types.ExecuteQueueTaskRequest,
+) error {
+	queueProcessor, err := e.getQueueProcessor(request.GetType())
+	if err != nil {
+		return err
+	}
+
+	_, err = queueProcessor.HandleAction(ctx, request.GetClusterName(), queue.NewExecuteTaskAction(request.GetTaskID()))
+	return err
+}
+
+// GetExecutionCacheStats returns the stats of the workflow execution cache of this shard
+func (e *historyEngineImpl) GetExecutionCacheStats() cache.Stats {
+	return e.executionCache.Stats()
+}
+
+// GetEventsCacheStats returns the stats of the events cache used by this shard,
+// which is shared by all shards on the host if global events cache is enabled
+func (e *historyEngineImpl) GetEventsCacheStats() cache.Stats {
+	return e.shard.GetEventsCache().Stats()
+}
+
+func (e *historyEngineImpl) getQueueProcessor(
+	queueType int32,
+) (queue.Processor, error) {
+	switch common.TaskType(queueType) {
+	case common.TaskTypeTransfer:
+		return e.txProcessor, nil
+	case common.TaskTypeTimer:
+		return e.timerProcessor, nil
+	default:
+		return nil, constants.ErrInvalidTaskType
+	}
+}
+
+func taskStateToString(state ctask.State) string {
+	switch state {
+	case ctask.TaskStatePending:
+		return "Pending"
+	case ctask.TaskStateAcked:
+		return "Acked"
+	case ctask.TaskStateNacked:
+		return "Nacked"
+	default:
+		return fmt.Sprintf("Unknown(%v)", state)
+	}
+}
+
 func (e *historyEngineImpl) serializeQueueState(
 	state queue.ProcessingQueueState,
 ) string {
@@ -3367,6 +3877,8 @@ func getStartRequest(
 		Header:                              request.Header,
 		DelayStartSeconds:                   request.DelayStartSeconds,
 		JitterStartSeconds:                  request.JitterStartSeconds,
+		NoProgressTimeoutSeconds:            request.NoProgressTimeoutSeconds,
+		NoProgressTimeoutPolicy:             request.NoProgressTimeoutPolicy,
 	}
 
 	return common.CreateHistoryStartWorkflowRequest(domainID, req, time.Now(), partitionConfig)
@@ -3752,6 +4264,163 @@ func (e *historyEngineImpl) RefreshWorkflowTasks(
 	return nil
 }
 
+// VerifyWorkflowExecution rebuilds the mutable state of a workflow run by replaying its history and reports the
+// discrepancies between the rebuilt and the persisted mutable state. If requested, discrepancies are repaired:
+// a mismatching checksum is recomputed from the persisted mutable state, while other discrepancies are only repaired
+// for closed runs by replacing the persisted mutable state with the rebuilt one, since the rebuilt mutable state
+// lacks the fields of open runs which are not derived from history, like activity attempts and heartbeats.
+func (e *historyEngineImpl) VerifyWorkflowExecution(
+	ctx context.Context,
+	request *types.VerifyWorkflowExecutionRequest,
+) (retResp *types.VerifyWorkflowExecutionResponse, retError error) {
+	domainEntry, err := e.shard.GetDomainCache().GetDomainByID(request.GetDomainUUID())
+	if err != nil {
+		return nil, err
+	}
+	domainID := domainEntry.GetInfo().ID
+
+	wfContext, release, err := e.executionCache.GetOrCreateWorkflowExecution(ctx, domainID, *request.GetExecution())
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(retError) }()
+
+	mutableState, err := wfContext.LoadWorkflowExecution(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versionHistories := mutableState.GetVersionHistories()
+	if versionHistories == nil {
+		return nil, execution.ErrMissingVersionHistories
+	}
+	currentVersionHistory, err := versionHistories.GetCurrentVersionHistory()
+	if err != nil {
+		return nil, err
+	}
+	lastItem, err := currentVersionHistory.GetLastItem()
+	if err != nil {
+		return nil, err
+	}
+
+	executionInfo := mutableState.GetExecutionInfo()
+	workflowIdentifier := definition.NewWorkflowIdentifier(
+		executionInfo.DomainID,
+		executionInfo.WorkflowID,
+		executionInfo.RunID,
+	)
+	rebuiltMutableState, rebuiltHistorySize, err := execution.NewStateRebuilder(e.shard, e.logger).Rebuild(
+		ctx,
+		executionInfo.StartTimestamp,
+		workflowIdentifier,
+		currentVersionHistory.GetBranchToken(),
+		lastItem.EventID,
+		lastItem.Version,
+		workflowIdentifier,
+		currentVersionHistory.GetBranchToken(),
+		uuid.New(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	discrepancies := execution.GetMutableStateDiscrepancies(mutableState, rebuiltMutableState)
+	resp := &types.VerifyWorkflowExecutionResponse{
+		Discrepancies: discrepancies,
+	}
+	if !request.GetRepair() || len(discrepancies) == 0 {
+		return resp, nil
+	}
+
+	if mutableState.HasBufferedEvents() {
+		return nil, &types.BadRequestError{Message: "Cannot repair workflow execution with buffered events."}
+	}
+	if execution.HasOnlyChecksumDiscrepancies(discrepancies) {
+		e.logger.Warn("Repairing mutable state checksum.",
+			tag.WorkflowDomainID(domainID),
+			tag.WorkflowID(executionInfo.WorkflowID),
+			tag.WorkflowRunID(executionInfo.RunID),
+		)
+		// the checksum is recomputed when the persisted mutable state is written back
+		if err := wfContext.UpdateWorkflowExecutionTasks(ctx, e.shard.GetTimeSource().Now()); err != nil {
+			return nil, err
+		}
+		resp.Repaired = true
+		return resp, nil
+	}
+	if mutableState.IsWorkflowExecutionRunning() {
+		return nil, &types.BadRequestError{Message: "Cannot repair open workflow execution from history, reset or terminate it first."}
+	}
+	e.logger.Warn("Repairing mutable state rebuilt from history.",
+		tag.WorkflowDomainID(domainID),
+		tag.WorkflowID(executionInfo.WorkflowID),
+		tag.WorkflowRunID(executionInfo.RunID),
+		tag.Counter(len(discrepancies)),
+	)
+
+	// set the update condition from the persisted mutable state
+	rebuiltMutableState.SetUpdateCondition(mutableState.GetUpdateCondition())
+	wfContext.Clear()
+	wfContext.SetHistorySize(rebuiltHistorySize)
+	if err := wfContext.ConflictResolveWorkflowExecution(
+		ctx,
+		e.shard.GetTimeSource().Now(),
+		persistence.ConflictResolveWorkflowModeBypassCurrent,
+		rebuiltMutableState,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	); err != nil {
+		return nil, err
+	}
+	// the rebuilt mutable state is loaded again from persistence by the next request
+	wfContext.Clear()
+
+	resp.Repaired = true
+	return resp, nil
+}
+
+// DescribeReplicationStatus returns the replication lag of the domains with replication tasks pending in the shard
+func (e *historyEngineImpl) DescribeReplicationStatus(
+	ctx context.Context,
+	request *types.DescribeReplicationStatusRequest,
+) (*types.DescribeReplicationStatusResponse, error) {
+	remoteClusters := e.clusterMetadata.GetRemoteClusterInfo()
+	var clusterNames []string
+	if clusterName := request.GetClusterName(); clusterName != "" {
+		if _, ok := remoteClusters[clusterName]; !ok {
+			return nil, &types.BadRequestError{Message: fmt.Sprintf("Unknown remote cluster: %v", clusterName)}
+		}
+		clusterNames = append(clusterNames, clusterName)
+	} else {
+		for clusterName := range remoteClusters {
+			clusterNames = append(clusterNames, clusterName)
+		}
+		sort.Strings(clusterNames)
+	}
+
+	resp := &types.DescribeReplicationStatusResponse{}
+	for _, clusterName := range clusterNames {
+		status, err := e.replicationMetricsEmitter.GetDomainReplicationStatus(ctx, clusterName)
+		if err != nil {
+			return nil, err
+		}
+		if domainID := request.GetDomainUUID(); domainID != "" {
+			var domains []*types.DomainReplicationStatus
+			for _, domainStatus := range status.Domains {
+				if domainStatus.DomainID == domainID {
+					domains = append(domains, domainStatus)
+				}
+			}
+			status.Domains = domains
+		}
+		resp.Clusters = append(resp.Clusters, status)
+	}
+	return resp, nil
+}
+
 func (e *historyEngineImpl) GetCrossClusterTasks(
 	ctx context.Context,
 	targetCluster string,
@@ -43,6 +43,7 @@ import (
 	"github.com/uber/cadence/common/log/testlogger"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/partition"
 	p "github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/common/types/mapper/thrift"
@@ -757,6 +758,307 @@ func (s *engine2Suite) TestRecordActivityTaskStartedSuccess() {
 	s.Equal(scheduledEvent, response.ScheduledEvent)
 }
 
+func (s *engine2Suite) TestRecordActivityTaskStarted_UpdatedActivityOptions() {
+	domainID := constants.TestDomainID
+	workflowExecution := types.WorkflowExecution{
+		WorkflowID: "wId",
+		RunID:      constants.TestRunID,
+	}
+	identity := "testIdentity"
+	tl := "testTaskList"
+
+	msBuilder := s.createExecutionStartedState(workflowExecution, tl, identity, true)
+	decisionCompletedEvent := test.AddDecisionTaskCompletedEvent(msBuilder, int64(2), int64(3), nil, identity)
+	scheduledEvent, ai := test.AddActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent.ID, "activity1_id",
+		"activity_type1", tl, []byte("input1"), 100, 10, 1, 5)
+	ai.StartToCloseTimeout = 50
+	ai.TaskList = "newTaskList"
+
+	ms1 := execution.CreatePersistenceMutableState(msBuilder)
+	gwmsResponse1 := &p.GetWorkflowExecutionResponse{State: ms1}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse1, nil).Once()
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything, mock.Anything).Return(&p.AppendHistoryNodesResponse{}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(&p.UpdateWorkflowExecutionResponse{
+		MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{},
+	}, nil).Once()
+
+	s.mockEventsCache.EXPECT().GetEvent(
+		gomock.Any(), gomock.Any(), domainID, workflowExecution.GetWorkflowID(), workflowExecution.GetRunID(),
+		decisionCompletedEvent.ID, scheduledEvent.ID, gomock.Any(),
+	).Return(scheduledEvent, nil)
+	response, err := s.historyEngine.RecordActivityTaskStarted(context.Background(), &types.RecordActivityTaskStartedRequest{
+		DomainUUID:        domainID,
+		WorkflowExecution: &workflowExecution,
+		ScheduleID:        scheduledEvent.ID,
+		TaskID:            100,
+		RequestID:         "reqId",
+		PollRequest:       &types.PollForActivityTaskRequest{TaskList: &types.TaskList{Name: "newTaskList"}, Identity: identity},
+	})
+	s.Nil(err)
+	attributes := response.ScheduledEvent.ActivityTaskScheduledEventAttributes
+	s.Equal(int32(50), attributes.GetStartToCloseTimeoutSeconds())
+	s.Equal(int32(100), attributes.GetScheduleToCloseTimeoutSeconds())
+	s.Equal("newTaskList", attributes.TaskList.GetName())
+	// the cached event must not be modified
+	s.Equal(int32(1), scheduledEvent.ActivityTaskScheduledEventAttributes.GetStartToCloseTimeoutSeconds())
+	s.Equal(tl, scheduledEvent.ActivityTaskScheduledEventAttributes.TaskList.GetName())
+}
+
+func (s *engine2Suite) TestRecordActivityTaskStarted_PreviousTaskList() {
+	domainID := constants.TestDomainID
+	workflowExecution := types.WorkflowExecution{
+		WorkflowID: "wId",
+		RunID:      constants.TestRunID,
+	}
+	identity := "testIdentity"
+	tl := "testTaskList"
+
+	msBuilder := s.createExecutionStartedState(workflowExecution, tl, identity, true)
+	decisionCompletedEvent := test.AddDecisionTaskCompletedEvent(msBuilder, int64(2), int64(3), nil, identity)
+	scheduledEvent, ai := test.AddActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent.ID, "activity1_id",
+		"activity_type1", tl, []byte("input1"), 100, 10, 1, 5)
+	ai.TaskList = "newTaskList"
+
+	ms1 := execution.CreatePersistenceMutableState(msBuilder)
+	gwmsResponse1 := &p.GetWorkflowExecutionResponse{State: ms1}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse1, nil).Once()
+	s.mockEventsCache.EXPECT().GetEvent(
+		gomock.Any(), gomock.Any(), domainID, workflowExecution.GetWorkflowID(), workflowExecution.GetRunID(),
+		decisionCompletedEvent.ID, scheduledEvent.ID, gomock.Any(),
+	).Return(scheduledEvent, nil)
+	// the task is left over on a partition of the previous task list
+	_, err := s.historyEngine.RecordActivityTaskStarted(context.Background(), &types.RecordActivityTaskStartedRequest{
+		DomainUUID:        domainID,
+		WorkflowExecution: &workflowExecution,
+		ScheduleID:        scheduledEvent.ID,
+		TaskID:            100,
+		RequestID:         "reqId",
+		PollRequest:       &types.PollForActivityTaskRequest{TaskList: &types.TaskList{Name: "/__cadence_sys/testTaskList/1"}, Identity: identity},
+	})
+	s.Equal(workflow.ErrActivityTaskNotFound, err)
+}
+
+func (s *engine2Suite) TestRefreshLocalOnlyWorkflowTasks() {
+	domainID := constants.TestDomainID
+	workflowExecution := types.WorkflowExecution{
+		WorkflowID: "wId",
+		RunID:      constants.TestRunID,
+	}
+	identity := "testIdentity"
+	tl := "testTaskList"
+
+	msBuilder := s.createExecutionStartedState(workflowExecution, tl, identity, true)
+	msBuilder.GetExecutionInfo().PartitionConfig = map[string]string{partition.LocalOnlyKey: "true"}
+	ms1 := execution.CreatePersistenceMutableState(msBuilder)
+
+	replicated := *ms1.ExecutionInfo
+	replicated.WorkflowID = "replicated"
+	replicated.PartitionConfig = nil
+	otherDomain := *ms1.ExecutionInfo
+	otherDomain.WorkflowID = "otherDomain"
+	otherDomain.DomainID = constants.TestParentDomainID
+	s.mockExecutionMgr.On("ListConcreteExecutions", mock.Anything, &p.ListConcreteExecutionsRequest{
+		PageSize: localOnlyWorkflowRefreshPageSize,
+	}).Return(&p.ListConcreteExecutionsResponse{
+		Executions: []*p.ListConcreteExecutionsEntity{
+			{ExecutionInfo: &replicated},
+			{ExecutionInfo: &otherDomain},
+			{ExecutionInfo: ms1.ExecutionInfo},
+		},
+		PageToken: []byte("token"),
+	}, nil).Once()
+	s.mockExecutionMgr.On("ListConcreteExecutions", mock.Anything, &p.ListConcreteExecutionsRequest{
+		PageSize:  localOnlyWorkflowRefreshPageSize,
+		PageToken: []byte("token"),
+	}).Return(&p.ListConcreteExecutionsResponse{}, nil).Once()
+
+	// only the tasks of the local-only workflow of the domain are refreshed
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.MatchedBy(func(request *p.GetWorkflowExecutionRequest) bool {
+		return request.Execution.GetWorkflowID() == workflowExecution.GetWorkflowID()
+	})).Return(&p.GetWorkflowExecutionResponse{State: ms1}, nil).Once()
+	s.mockEventsCache.EXPECT().GetEvent(
+		gomock.Any(), gomock.Any(), domainID, workflowExecution.GetWorkflowID(), workflowExecution.GetRunID(),
+		gomock.Any(), gomock.Any(), gomock.Any(),
+	).Return(msBuilder.GetHistoryBuilder().GetHistory().Events[0], nil).AnyTimes()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil).Once()
+
+	s.historyEngine.refreshLocalOnlyWorkflowTasks(map[string]struct{}{domainID: {}})
+	s.mockExecutionMgr.AssertExpectations(s.T())
+}
+
+func TestTaskListRootName(t *testing.T) {
+	require.Equal(t, "testTaskList", taskListRootName("testTaskList"))
+	require.Equal(t, "testTaskList", taskListRootName("/__cadence_sys/testTaskList/3"))
+	require.Equal(t, "test/TaskList", taskListRootName("/__cadence_sys/test/TaskList/3"))
+}
+
+func (s *engine2Suite) TestUpdateActivityOptions_Success() {
+	domainID := constants.TestDomainID
+	workflowExecution := types.WorkflowExecution{
+		WorkflowID: "wId",
+		RunID:      constants.TestRunID,
+	}
+	identity := "testIdentity"
+	tl := "testTaskList"
+
+	msBuilder := s.createExecutionStartedState(workflowExecution, tl, identity, true)
+	decisionCompletedEvent := test.AddDecisionTaskCompletedEvent(msBuilder, int64(2), int64(3), nil, identity)
+	scheduledEvent, _ := test.AddActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent.ID, "activity1_id",
+		"activity_type1", tl, []byte("input1"), 100, 10, 1, 5)
+	test.AddActivityTaskStartedEvent(msBuilder, scheduledEvent.ID, identity)
+
+	ms1 := execution.CreatePersistenceMutableState(msBuilder)
+	gwmsResponse1 := &p.GetWorkflowExecutionResponse{State: ms1}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse1, nil).Once()
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything, mock.MatchedBy(func(request *p.AppendHistoryNodesRequest) bool {
+		return len(request.Events) == 1 &&
+			request.Events[0].GetEventType() == types.EventTypeActivityTaskOptionsUpdated
+	})).Return(&p.AppendHistoryNodesResponse{}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.MatchedBy(func(request *p.UpdateWorkflowExecutionRequest) bool {
+		mutation := request.UpdateWorkflowMutation
+		return len(mutation.UpsertActivityInfos) == 1 &&
+			mutation.UpsertActivityInfos[0].StartToCloseTimeout == 30 &&
+			mutation.UpsertActivityInfos[0].HeartbeatTimeout == 3 &&
+			len(mutation.TimerTasks) == 1
+	})).Return(&p.UpdateWorkflowExecutionResponse{
+		MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{},
+	}, nil).Once()
+
+	err := s.historyEngine.UpdateActivityOptions(context.Background(), &types.HistoryUpdateActivityOptionsRequest{
+		DomainUUID: domainID,
+		UpdateRequest: &types.UpdateActivityOptionsRequest{
+			WorkflowExecution:          &workflowExecution,
+			ActivityID:                 "activity1_id",
+			StartToCloseTimeoutSeconds: common.Int32Ptr(30),
+			HeartbeatTimeoutSeconds:    common.Int32Ptr(3),
+		},
+	})
+	s.Nil(err)
+}
+
+func (s *engine2Suite) TestUpdateActivityOptions_ActivityNotFound() {
+	domainID := constants.TestDomainID
+	workflowExecution := types.WorkflowExecution{
+		WorkflowID: "wId",
+		RunID:      constants.TestRunID,
+	}
+	identity := "testIdentity"
+	tl := "testTaskList"
+
+	msBuilder := s.createExecutionStartedState(workflowExecution, tl, identity, true)
+	ms1 := execution.CreatePersistenceMutableState(msBuilder)
+	gwmsResponse1 := &p.GetWorkflowExecutionResponse{State: ms1}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse1, nil).Once()
+
+	err := s.historyEngine.UpdateActivityOptions(context.Background(), &types.HistoryUpdateActivityOptionsRequest{
+		DomainUUID: domainID,
+		UpdateRequest: &types.UpdateActivityOptionsRequest{
+			WorkflowExecution:       &workflowExecution,
+			ActivityID:              "activity1_id",
+			HeartbeatTimeoutSeconds: common.Int32Ptr(3),
+		},
+	})
+	s.Equal(workflow.ErrActivityTaskNotFound, err)
+}
+
+func (s *engine2Suite) TestUpdateActivityOptions_InvalidRequest() {
+	err := s.historyEngine.UpdateActivityOptions(context.Background(), &types.HistoryUpdateActivityOptionsRequest{
+		DomainUUID: constants.TestDomainID,
+		UpdateRequest: &types.UpdateActivityOptionsRequest{
+			WorkflowExecution:          &types.WorkflowExecution{WorkflowID: "wId", RunID: constants.TestRunID},
+			ActivityID:                 "activity1_id",
+			StartToCloseTimeoutSeconds: common.Int32Ptr(0),
+		},
+	})
+	s.IsType(&types.BadRequestError{}, err)
+}
+
+func (s *engine2Suite) TestDeleteWorkflowExecution_Closed() {
+	domainID := constants.TestDomainID
+	workflowExecution := types.WorkflowExecution{
+		WorkflowID: "wId",
+		RunID:      constants.TestRunID,
+	}
+	identity := "testIdentity"
+	tl := "testTaskList"
+
+	msBuilder := s.createExecutionStartedState(workflowExecution, tl, identity, true)
+	decisionCompletedEvent := test.AddDecisionTaskCompletedEvent(msBuilder, int64(2), int64(3), nil, identity)
+	test.AddCompleteWorkflowEvent(msBuilder, decisionCompletedEvent.ID, nil)
+
+	ms1 := execution.CreatePersistenceMutableState(msBuilder)
+	gwmsResponse1 := &p.GetWorkflowExecutionResponse{State: ms1}
+
+	childExecution := &types.WorkflowExecution{WorkflowID: "child-wId", RunID: "child-rId"}
+	historyEvents := []*types.HistoryEvent{
+		{ID: 1, EventType: types.EventTypeWorkflowExecutionStarted.Ptr()},
+		{
+			ID:        2,
+			EventType: types.EventTypeChildWorkflowExecutionStarted.Ptr(),
+			ChildWorkflowExecutionStartedEventAttributes: &types.ChildWorkflowExecutionStartedEventAttributes{
+				Domain:            "child-domain",
+				WorkflowExecution: childExecution,
+			},
+		},
+	}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse1, nil).Once()
+	s.mockHistoryV2Mgr.On("ReadHistoryBranch", mock.Anything, mock.Anything).Return(&p.ReadHistoryBranchResponse{HistoryEvents: historyEvents}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.MatchedBy(func(request *p.UpdateWorkflowExecutionRequest) bool {
+		timerTasks := request.UpdateWorkflowMutation.TimerTasks
+		if len(timerTasks) != 1 {
+			return false
+		}
+		_, ok := timerTasks[0].(*p.DeleteHistoryEventTask)
+		return ok
+	})).Return(&p.UpdateWorkflowExecutionResponse{
+		MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{},
+	}, nil).Once()
+
+	resp, err := s.historyEngine.DeleteWorkflowExecution(context.Background(), &types.HistoryDeleteWorkflowExecutionRequest{
+		DomainUUID: domainID,
+		DeleteRequest: &types.DeleteWorkflowExecutionRequest{
+			WorkflowExecution:     &workflowExecution,
+			IncludeChildWorkflows: true,
+			Reason:                "test",
+			Identity:              identity,
+		},
+	})
+	s.Nil(err)
+	s.Len(resp.ChildWorkflowExecutions, 1)
+	s.Equal(childExecution, resp.ChildWorkflowExecutions[0].WorkflowExecution)
+	s.Equal("child-domain", resp.ChildWorkflowExecutions[0].Domain)
+}
+
+func (s *engine2Suite) TestDeleteWorkflowExecution_Running() {
+	domainID := constants.TestDomainID
+	workflowExecution := types.WorkflowExecution{
+		WorkflowID: "wId",
+		RunID:      constants.TestRunID,
+	}
+	identity := "testIdentity"
+	tl := "testTaskList"
+
+	msBuilder := s.createExecutionStartedState(workflowExecution, tl, identity, true)
+	ms1 := execution.CreatePersistenceMutableState(msBuilder)
+	gwmsResponse1 := &p.GetWorkflowExecutionResponse{State: ms1}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse1, nil).Once()
+
+	_, err := s.historyEngine.DeleteWorkflowExecution(context.Background(), &types.HistoryDeleteWorkflowExecutionRequest{
+		DomainUUID: domainID,
+		DeleteRequest: &types.DeleteWorkflowExecutionRequest{
+			WorkflowExecution: &workflowExecution,
+			Identity:          identity,
+		},
+	})
+	s.IsType(&types.BadRequestError{}, err)
+}
+
 func (s *engine2Suite) TestRecordActivityTaskStartedResurrected() {
 	domainID := constants.TestDomainID
 	workflowExecution := types.WorkflowExecution{WorkflowID: constants.TestWorkflowID, RunID: constants.TestRunID}
@@ -47,7 +47,9 @@ import (
 	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/log/testlogger"
 	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/partition"
 	"github.com/uber/cadence/common/persistence"
+	ctask "github.com/uber/cadence/common/task"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/common/types/mapper/thrift"
 	"github.com/uber/cadence/service/history/config"
@@ -60,6 +62,7 @@ import (
 	"github.com/uber/cadence/service/history/queue"
 	"github.com/uber/cadence/service/history/reset"
 	"github.com/uber/cadence/service/history/shard"
+	"github.com/uber/cadence/service/history/task"
 	test "github.com/uber/cadence/service/history/testing"
 	"github.com/uber/cadence/service/history/workflow"
 )
@@ -5224,6 +5227,74 @@ func (s *engineSuite) TestReapplyEvents_ResetWorkflow() {
 	s.NoError(err)
 }
 
+func (s *engineSuite) TestListQueueTasks() {
+	now := time.Now()
+	mockTask := task.NewMockTask(s.controller)
+	mockTask.EXPECT().GetDomainID().Return(constants.TestDomainID).AnyTimes()
+	mockTask.EXPECT().GetWorkflowID().Return(constants.TestWorkflowID).AnyTimes()
+	mockTask.EXPECT().GetRunID().Return(constants.TestRunID).AnyTimes()
+	mockTask.EXPECT().GetTaskID().Return(int64(123)).AnyTimes()
+	mockTask.EXPECT().GetTaskType().Return(persistence.TransferTaskTypeActivityTask).AnyTimes()
+	mockTask.EXPECT().GetVisibilityTimestamp().Return(now).AnyTimes()
+	mockTask.EXPECT().GetVersion().Return(int64(10)).AnyTimes()
+	mockTask.EXPECT().State().Return(ctask.TaskStatePending).AnyTimes()
+	mockTask.EXPECT().GetAttempt().Return(3).AnyTimes()
+	mockTask.EXPECT().GetLastError().Return(errors.New("some random error")).AnyTimes()
+
+	request := &types.ListQueueTasksRequest{
+		ShardID:     1,
+		ClusterName: "cluster",
+		Type:        common.Int32Ptr(int32(common.TaskTypeTransfer)),
+		MinTaskID:   common.Int64Ptr(100),
+		PageSize:    10,
+	}
+	s.mockTxProcessor.EXPECT().HandleAction(gomock.Any(), "cluster", queue.NewListTasksAction(&queue.ListTasksAttributes{
+		MinTaskID: 100,
+		PageSize:  10,
+	})).Return(&queue.ActionResult{
+		ActionType: queue.ActionTypeListTasks,
+		ListTasksResult: &queue.ListTasksResult{
+			Tasks: []task.Task{mockTask},
+		},
+	}, nil).Times(1)
+
+	resp, err := s.mockHistoryEngine.ListQueueTasks(context.Background(), request)
+	s.NoError(err)
+	s.Equal([]*types.QueueTaskInfo{
+		{
+			DomainID:            constants.TestDomainID,
+			WorkflowID:          constants.TestWorkflowID,
+			RunID:               constants.TestRunID,
+			TaskID:              123,
+			TaskType:            int32(persistence.TransferTaskTypeActivityTask),
+			VisibilityTimestamp: now.UnixNano(),
+			Version:             10,
+			State:               "Pending",
+			Attempt:             3,
+			LastError:           "some random error",
+		},
+	}, resp.Tasks)
+
+	request.Type = common.Int32Ptr(int32(common.TaskTypeCrossCluster))
+	_, err = s.mockHistoryEngine.ListQueueTasks(context.Background(), request)
+	s.Equal(constants.ErrInvalidTaskType, err)
+}
+
+func (s *engineSuite) TestExecuteQueueTask() {
+	request := &types.ExecuteQueueTaskRequest{
+		ShardID:     1,
+		ClusterName: "cluster",
+		Type:        common.Int32Ptr(int32(common.TaskTypeTimer)),
+		TaskID:      123,
+	}
+	s.mockTimerProcessor.EXPECT().HandleAction(gomock.Any(), "cluster", queue.NewExecuteTaskAction(123)).Return(&queue.ActionResult{
+		ActionType:        queue.ActionTypeExecuteTask,
+		ExecuteTaskResult: &queue.ExecuteTaskResult{},
+	}, nil).Times(1)
+
+	s.NoError(s.mockHistoryEngine.ExecuteQueueTask(context.Background(), request))
+}
+
 func (s *engineSuite) getBuilder(testDomainID string, we types.WorkflowExecution) execution.MutableState {
 	context, release, err := s.mockHistoryEngine.executionCache.GetOrCreateWorkflowExecutionForBackground(testDomainID, we)
 	if err != nil {
@@ -5245,3 +5316,66 @@ func (s *engineSuite) getActivityScheduledEvent(
 func (s *engineSuite) printHistory(builder execution.MutableState) string {
 	return thrift.FromHistory(builder.GetHistoryBuilder().GetHistory()).String()
 }
+
+func TestSelectReplicationForWorkflow(t *testing.T) {
+	domainEntry := cache.NewGlobalDomainCacheEntryForTest(
+		&persistence.DomainInfo{
+			ID:   constants.TestDomainID,
+			Name: constants.TestDomainName,
+			Data: map[string]string{common.DomainDataKeyPrefixForLocalOnlyWorkflowType + "local-workflow": "true"},
+		},
+		&persistence.DomainConfig{},
+		&persistence.DomainReplicationConfig{ActiveClusterName: cluster.TestCurrentClusterName},
+		1,
+	)
+	newStartRequest := func(workflowType string, partitionConfig map[string]string) *types.HistoryStartWorkflowExecutionRequest {
+		return &types.HistoryStartWorkflowExecutionRequest{
+			DomainUUID: constants.TestDomainID,
+			StartRequest: &types.StartWorkflowExecutionRequest{
+				WorkflowType: &types.WorkflowType{Name: workflowType},
+				TaskList:     &types.TaskList{Name: "tl"},
+			},
+			PartitionConfig: partitionConfig,
+		}
+	}
+
+	startRequest := newStartRequest("local-workflow", map[string]string{partition.IsolationGroupKey: "zone"})
+	selectReplicationForWorkflow(domainEntry, startRequest)
+	require.Equal(t, map[string]string{
+		partition.IsolationGroupKey: "zone",
+		partition.LocalOnlyKey:      "true",
+	}, startRequest.PartitionConfig)
+
+	startRequest = newStartRequest("replicated-workflow", nil)
+	selectReplicationForWorkflow(domainEntry, startRequest)
+	require.Nil(t, startRequest.PartitionConfig)
+
+	// child workflows follow their parent
+	startRequest = newStartRequest("local-workflow", nil)
+	startRequest.ParentExecutionInfo = &types.ParentExecutionInfo{DomainUUID: constants.TestDomainID}
+	selectReplicationForWorkflow(domainEntry, startRequest)
+	require.Nil(t, startRequest.PartitionConfig)
+}
+
+func TestIsDomainFailoverToCluster(t *testing.T) {
+	newDomainEntry := func(activeCluster string, data map[string]string) *cache.DomainCacheEntry {
+		return cache.NewGlobalDomainCacheEntryForTest(
+			&persistence.DomainInfo{ID: constants.TestDomainID, Name: constants.TestDomainName, Data: data},
+			&persistence.DomainConfig{},
+			&persistence.DomainReplicationConfig{ActiveClusterName: activeCluster},
+			1,
+		)
+	}
+
+	require.True(t, isDomainFailoverToCluster(newDomainEntry(cluster.TestCurrentClusterName, nil), 0, cluster.TestCurrentClusterName))
+	require.False(t, isDomainFailoverToCluster(newDomainEntry(cluster.TestAlternativeClusterName, nil), 0, cluster.TestCurrentClusterName))
+	require.False(t, isDomainFailoverToCluster(newDomainEntry(cluster.TestCurrentClusterName, nil), 1, cluster.TestCurrentClusterName))
+
+	// a region of an active-active domain is failed over to the current cluster
+	activeActiveDomain := newDomainEntry(cluster.TestAlternativeClusterName, map[string]string{
+		common.DomainDataKeyPrefixForActiveCluster + "region0": cluster.TestAlternativeClusterName,
+		common.DomainDataKeyPrefixForActiveCluster + "region1": cluster.TestCurrentClusterName,
+	})
+	require.True(t, isDomainFailoverToCluster(activeActiveDomain, 0, cluster.TestCurrentClusterName))
+	require.False(t, isDomainFailoverToCluster(activeActiveDomain, 0, "other-cluster"))
+}
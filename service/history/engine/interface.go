@@ -26,6 +26,7 @@ import (
 	"context"
 
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/types"
 	hcommon "github.com/uber/cadence/service/history/common"
 	"github.com/uber/cadence/service/history/events"
@@ -50,11 +51,13 @@ type (
 		RespondActivityTaskFailed(ctx context.Context, request *types.HistoryRespondActivityTaskFailedRequest) error
 		RespondActivityTaskCanceled(ctx context.Context, request *types.HistoryRespondActivityTaskCanceledRequest) error
 		RecordActivityTaskHeartbeat(ctx context.Context, request *types.HistoryRecordActivityTaskHeartbeatRequest) (*types.RecordActivityTaskHeartbeatResponse, error)
+		UpdateActivityOptions(ctx context.Context, request *types.HistoryUpdateActivityOptionsRequest) error
 		RequestCancelWorkflowExecution(ctx context.Context, request *types.HistoryRequestCancelWorkflowExecutionRequest) error
 		SignalWorkflowExecution(ctx context.Context, request *types.HistorySignalWorkflowExecutionRequest) error
 		SignalWithStartWorkflowExecution(ctx context.Context, request *types.HistorySignalWithStartWorkflowExecutionRequest) (*types.StartWorkflowExecutionResponse, error)
 		RemoveSignalMutableState(ctx context.Context, request *types.RemoveSignalMutableStateRequest) error
 		TerminateWorkflowExecution(ctx context.Context, request *types.HistoryTerminateWorkflowExecutionRequest) error
+		DeleteWorkflowExecution(ctx context.Context, request *types.HistoryDeleteWorkflowExecutionRequest) (*types.DeleteWorkflowExecutionResponse, error)
 		ResetWorkflowExecution(ctx context.Context, request *types.HistoryResetWorkflowExecutionRequest) (*types.ResetWorkflowExecutionResponse, error)
 		ScheduleDecisionTask(ctx context.Context, request *types.ScheduleDecisionTaskRequest) error
 		RecordChildExecutionCompleted(ctx context.Context, request *types.RecordChildExecutionCompletedRequest) error
@@ -72,12 +75,18 @@ type (
 		PurgeDLQMessages(ctx context.Context, messagesRequest *types.PurgeDLQMessagesRequest) error
 		MergeDLQMessages(ctx context.Context, messagesRequest *types.MergeDLQMessagesRequest) (*types.MergeDLQMessagesResponse, error)
 		RefreshWorkflowTasks(ctx context.Context, domainUUID string, execution types.WorkflowExecution) error
+		VerifyWorkflowExecution(ctx context.Context, request *types.VerifyWorkflowExecutionRequest) (*types.VerifyWorkflowExecutionResponse, error)
 		ResetTransferQueue(ctx context.Context, clusterName string) error
 		ResetTimerQueue(ctx context.Context, clusterName string) error
 		ResetCrossClusterQueue(ctx context.Context, clusterName string) error
 		DescribeTransferQueue(ctx context.Context, clusterName string) (*types.DescribeQueueResponse, error)
 		DescribeTimerQueue(ctx context.Context, clusterName string) (*types.DescribeQueueResponse, error)
 		DescribeCrossClusterQueue(ctx context.Context, clusterName string) (*types.DescribeQueueResponse, error)
+		DescribeReplicationStatus(ctx context.Context, request *types.DescribeReplicationStatusRequest) (*types.DescribeReplicationStatusResponse, error)
+		ListQueueTasks(ctx context.Context, request *types.ListQueueTasksRequest) (*types.ListQueueTasksResponse, error)
+		ExecuteQueueTask(ctx context.Context, request *types.ExecuteQueueTaskRequest) error
+		GetExecutionCacheStats() cache.Stats
+		GetEventsCacheStats() cache.Stats
 
 		NotifyNewHistoryEvent(event *events.Notification)
 		NotifyNewTransferTasks(info *hcommon.NotifyTaskInfo)
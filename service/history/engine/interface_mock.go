@@ -32,6 +32,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 
+	cache "github.com/uber/cadence/common/cache"
 	types "github.com/uber/cadence/common/types"
 	common "github.com/uber/cadence/service/history/common"
 	events "github.com/uber/cadence/service/history/events"
@@ -75,6 +76,21 @@ func (mr *MockEngineMockRecorder) CountDLQMessages(ctx, forceFetch interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDLQMessages", reflect.TypeOf((*MockEngine)(nil).CountDLQMessages), ctx, forceFetch)
 }
 
+// DeleteWorkflowExecution mocks base method.
+func (m *MockEngine) DeleteWorkflowExecution(ctx context.Context, request *types.HistoryDeleteWorkflowExecutionRequest) (*types.DeleteWorkflowExecutionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkflowExecution", ctx, request)
+	ret0, _ := ret[0].(*types.DeleteWorkflowExecutionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWorkflowExecution indicates an expected call of DeleteWorkflowExecution.
+func (mr *MockEngineMockRecorder) DeleteWorkflowExecution(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkflowExecution", reflect.TypeOf((*MockEngine)(nil).DeleteWorkflowExecution), ctx, request)
+}
+
 // DescribeCrossClusterQueue mocks base method.
 func (m *MockEngine) DescribeCrossClusterQueue(ctx context.Context, clusterName string) (*types.DescribeQueueResponse, error) {
 	m.ctrl.T.Helper()
@@ -105,6 +121,21 @@ func (mr *MockEngineMockRecorder) DescribeMutableState(ctx, request interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeMutableState", reflect.TypeOf((*MockEngine)(nil).DescribeMutableState), ctx, request)
 }
 
+// DescribeReplicationStatus mocks base method.
+func (m *MockEngine) DescribeReplicationStatus(ctx context.Context, request *types.DescribeReplicationStatusRequest) (*types.DescribeReplicationStatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeReplicationStatus", ctx, request)
+	ret0, _ := ret[0].(*types.DescribeReplicationStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeReplicationStatus indicates an expected call of DescribeReplicationStatus.
+func (mr *MockEngineMockRecorder) DescribeReplicationStatus(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeReplicationStatus", reflect.TypeOf((*MockEngine)(nil).DescribeReplicationStatus), ctx, request)
+}
+
 // DescribeTimerQueue mocks base method.
 func (m *MockEngine) DescribeTimerQueue(ctx context.Context, clusterName string) (*types.DescribeQueueResponse, error) {
 	m.ctrl.T.Helper()
@@ -150,6 +181,20 @@ func (mr *MockEngineMockRecorder) DescribeWorkflowExecution(ctx, request interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeWorkflowExecution", reflect.TypeOf((*MockEngine)(nil).DescribeWorkflowExecution), ctx, request)
 }
 
+// ExecuteQueueTask mocks base method.
+func (m *MockEngine) ExecuteQueueTask(ctx context.Context, request *types.ExecuteQueueTaskRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteQueueTask", ctx, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExecuteQueueTask indicates an expected call of ExecuteQueueTask.
+func (mr *MockEngineMockRecorder) ExecuteQueueTask(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteQueueTask", reflect.TypeOf((*MockEngine)(nil).ExecuteQueueTask), ctx, request)
+}
+
 // GetCrossClusterTasks mocks base method.
 func (m *MockEngine) GetCrossClusterTasks(ctx context.Context, targetCluster string) ([]*types.CrossClusterTaskRequest, error) {
 	m.ctrl.T.Helper()
@@ -180,6 +225,34 @@ func (mr *MockEngineMockRecorder) GetDLQReplicationMessages(ctx, taskInfos inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDLQReplicationMessages", reflect.TypeOf((*MockEngine)(nil).GetDLQReplicationMessages), ctx, taskInfos)
 }
 
+// GetEventsCacheStats mocks base method.
+func (m *MockEngine) GetEventsCacheStats() cache.Stats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventsCacheStats")
+	ret0, _ := ret[0].(cache.Stats)
+	return ret0
+}
+
+// GetEventsCacheStats indicates an expected call of GetEventsCacheStats.
+func (mr *MockEngineMockRecorder) GetEventsCacheStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventsCacheStats", reflect.TypeOf((*MockEngine)(nil).GetEventsCacheStats))
+}
+
+// GetExecutionCacheStats mocks base method.
+func (m *MockEngine) GetExecutionCacheStats() cache.Stats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExecutionCacheStats")
+	ret0, _ := ret[0].(cache.Stats)
+	return ret0
+}
+
+// GetExecutionCacheStats indicates an expected call of GetExecutionCacheStats.
+func (mr *MockEngineMockRecorder) GetExecutionCacheStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExecutionCacheStats", reflect.TypeOf((*MockEngine)(nil).GetExecutionCacheStats))
+}
+
 // GetMutableState mocks base method.
 func (m *MockEngine) GetMutableState(ctx context.Context, request *types.GetMutableStateRequest) (*types.GetMutableStateResponse, error) {
 	m.ctrl.T.Helper()
@@ -210,6 +283,21 @@ func (mr *MockEngineMockRecorder) GetReplicationMessages(ctx, pollingCluster, la
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplicationMessages", reflect.TypeOf((*MockEngine)(nil).GetReplicationMessages), ctx, pollingCluster, lastReadMessageID)
 }
 
+// ListQueueTasks mocks base method.
+func (m *MockEngine) ListQueueTasks(ctx context.Context, request *types.ListQueueTasksRequest) (*types.ListQueueTasksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQueueTasks", ctx, request)
+	ret0, _ := ret[0].(*types.ListQueueTasksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListQueueTasks indicates an expected call of ListQueueTasks.
+func (mr *MockEngineMockRecorder) ListQueueTasks(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQueueTasks", reflect.TypeOf((*MockEngine)(nil).ListQueueTasks), ctx, request)
+}
+
 // MergeDLQMessages mocks base method.
 func (m *MockEngine) MergeDLQMessages(ctx context.Context, messagesRequest *types.MergeDLQMessagesRequest) (*types.MergeDLQMessagesResponse, error) {
 	m.ctrl.T.Helper()
@@ -753,3 +841,32 @@ func (mr *MockEngineMockRecorder) TerminateWorkflowExecution(ctx, request interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerminateWorkflowExecution", reflect.TypeOf((*MockEngine)(nil).TerminateWorkflowExecution), ctx, request)
 }
+
+// UpdateActivityOptions mocks base method.
+func (m *MockEngine) UpdateActivityOptions(ctx context.Context, request *types.HistoryUpdateActivityOptionsRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateActivityOptions", ctx, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateActivityOptions indicates an expected call of UpdateActivityOptions.
+func (mr *MockEngineMockRecorder) UpdateActivityOptions(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActivityOptions", reflect.TypeOf((*MockEngine)(nil).UpdateActivityOptions), ctx, request)
+}
+
+// VerifyWorkflowExecution mocks base method.
+func (m *MockEngine) VerifyWorkflowExecution(ctx context.Context, request *types.VerifyWorkflowExecutionRequest) (*types.VerifyWorkflowExecutionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyWorkflowExecution", ctx, request)
+	ret0, _ := ret[0].(*types.VerifyWorkflowExecutionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyWorkflowExecution indicates an expected call of VerifyWorkflowExecution.
+func (mr *MockEngineMockRecorder) VerifyWorkflowExecution(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyWorkflowExecution", reflect.TypeOf((*MockEngine)(nil).VerifyWorkflowExecution), ctx, request)
+}
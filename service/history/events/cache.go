@@ -56,6 +56,7 @@ type (
 			eventID int64,
 			event *types.HistoryEvent,
 		)
+		Stats() cache.Stats
 	}
 
 	cacheImpl struct {
@@ -125,7 +126,7 @@ func NewCache(
 		false,
 		logger,
 		metricsClient,
-		0,
+		uint64(config.EventsCacheShardMaxSize()),
 		domainCache,
 	)
 }
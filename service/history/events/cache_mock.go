@@ -32,6 +32,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 
+	cache "github.com/uber/cadence/common/cache"
 	types "github.com/uber/cadence/common/types"
 )
 
@@ -84,3 +85,17 @@ func (mr *MockCacheMockRecorder) PutEvent(domainID, workflowID, runID, eventID,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutEvent", reflect.TypeOf((*MockCache)(nil).PutEvent), domainID, workflowID, runID, eventID, event)
 }
+
+// Stats mocks base method.
+func (m *MockCache) Stats() cache.Stats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats")
+	ret0, _ := ret[0].(cache.Stats)
+	return ret0
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockCacheMockRecorder) Stats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockCache)(nil).Stats))
+}
@@ -74,6 +74,13 @@ func NewCache(shard shard.Context) *Cache {
 	opts.TTL = config.HistoryCacheTTL()
 	opts.Pin = true
 	opts.MaxCount = config.HistoryCacheMaxSize()
+	if maxSize := config.HistoryCacheMaxSizeInBytes(); maxSize > 0 {
+		// size of the context is updated when it's released, after mutable state is loaded or updated
+		opts.MaxSize = uint64(maxSize)
+		opts.GetCacheItemSizeFunc = func(value interface{}) uint64 {
+			return value.(Context).ByteSize()
+		}
+	}
 
 	return &Cache{
 		Cache:            cache.New(opts),
@@ -147,6 +147,42 @@ func (s *historyCacheSuite) TestHistoryCachePinning() {
 	release(err4)
 }
 
+func (s *historyCacheSuite) TestHistoryCacheSizeBased() {
+	s.mockShard.GetConfig().HistoryCacheMaxSizeInBytes = dynamicconfig.GetIntPropertyFn(100)
+	domainID := "test_domain_id"
+	s.cache = NewCache(s.mockShard)
+	s.mockShard.Resource.DomainCache.EXPECT().GetDomainName(gomock.Any()).Return("test_domain_name", nil).AnyTimes()
+
+	we := types.WorkflowExecution{
+		WorkflowID: "wf-cache-test-size-based",
+		RunID:      uuid.New(),
+	}
+	context, release, err := s.cache.GetOrCreateWorkflowExecutionForBackground(domainID, we)
+	s.Nil(err)
+	// size is updated after mutable state is loaded
+	context.(*contextImpl).setMutableStateSize(80)
+	release(nil)
+	s.Equal(uint64(80), s.cache.Stats().SizeInBytes)
+
+	we2 := types.WorkflowExecution{
+		WorkflowID: "wf-cache-test-size-based",
+		RunID:      uuid.New(),
+	}
+	context2, release2, err := s.cache.GetOrCreateWorkflowExecutionForBackground(domainID, we2)
+	s.Nil(err)
+	context2.(*contextImpl).setMutableStateSize(80)
+	release2(nil)
+
+	// the least recently used context should be evicted
+	stats := s.cache.Stats()
+	s.Equal(1, stats.Count)
+	s.Equal(uint64(80), stats.SizeInBytes)
+	newContext, release, err := s.cache.GetOrCreateWorkflowExecutionForBackground(domainID, we)
+	s.Nil(err)
+	s.False(context == newContext)
+	release(nil)
+}
+
 func (s *historyCacheSuite) TestHistoryCacheClear() {
 	s.mockShard.GetConfig().HistoryCacheMaxSize = dynamicconfig.GetIntPropertyFn(20)
 	domainID := "test_domain_id"
@@ -28,6 +28,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -94,6 +95,8 @@ type (
 
 		GetHistorySize() int64
 		SetHistorySize(size int64)
+		// ByteSize returns the estimated size of the cached mutable state in bytes
+		ByteSize() uint64
 
 		ReapplyEvents(
 			eventBatches []*persistence.WorkflowEvents,
@@ -177,6 +180,9 @@ type (
 		mutex        locks.Mutex
 		mutableState MutableState
 		stats        *persistence.ExecutionStats
+		// estimated size of mutable state, accessed by the execution cache without holding the mutex
+		mutableStateSize          int64
+		mutableStateSizeEstimator mutableStateSizeEstimator
 
 		appendHistoryNodesFn                  func(context.Context, string, types.WorkflowExecution, *persistence.AppendHistoryNodesRequest) (*persistence.AppendHistoryNodesResponse, error)
 		persistStartWorkflowBatchEventsFn     func(context.Context, *persistence.WorkflowEvents) (events.PersistedBlob, error)
@@ -278,6 +284,8 @@ func (c *contextImpl) Clear() {
 	c.stats = &persistence.ExecutionStats{
 		HistorySize: 0,
 	}
+	c.mutableStateSizeEstimator = mutableStateSizeEstimator{}
+	atomic.StoreInt64(&c.mutableStateSize, 0)
 }
 
 func (c *contextImpl) GetDomainID() string {
@@ -307,6 +315,14 @@ func (c *contextImpl) SetHistorySize(size int64) {
 	}
 }
 
+func (c *contextImpl) ByteSize() uint64 {
+	return uint64(atomic.LoadInt64(&c.mutableStateSize))
+}
+
+func (c *contextImpl) setMutableStateSize(size int) {
+	atomic.StoreInt64(&c.mutableStateSize, int64(size))
+}
+
 func (c *contextImpl) LoadExecutionStats(
 	ctx context.Context,
 ) (*persistence.ExecutionStats, error) {
@@ -361,6 +377,9 @@ func (c *contextImpl) LoadWorkflowExecutionWithTaskVersion(
 		}
 
 		c.stats = response.State.ExecutionStats
+		if response.MutableStateStats != nil {
+			c.setMutableStateSize(c.mutableStateSizeEstimator.reset(response.MutableStateStats))
+		}
 
 		// finally emit execution and session stats
 		c.emitWorkflowExecutionStatsFn(domainEntry.GetInfo().Name, response.MutableStateStats, c.stats.HistorySize)
@@ -455,6 +474,9 @@ func (c *contextImpl) CreateWorkflowExecution(
 	}
 
 	c.notifyTasksFromWorkflowSnapshotFn(newWorkflow, events.PersistedBlobs{persistedHistory}, false)
+	if resp.MutableStateUpdateSessionStats != nil {
+		c.setMutableStateSize(c.mutableStateSizeEstimator.resetFromSnapshot(resp.MutableStateUpdateSessionStats))
+	}
 
 	// finally emit session stats
 	c.emitSessionUpdateStatsFn(domain, resp.MutableStateUpdateSessionStats)
@@ -613,6 +635,10 @@ func (c *contextImpl) ConflictResolveWorkflowExecution(
 	c.notifyTasksFromWorkflowSnapshotFn(newWorkflow, persistedBlobs, false)
 	c.notifyTasksFromWorkflowMutationFn(currentWorkflow, persistedBlobs, false)
 
+	if resp.MutableStateUpdateSessionStats != nil {
+		c.setMutableStateSize(c.mutableStateSizeEstimator.update(resp.MutableStateUpdateSessionStats, false, resetMutableState))
+	}
+
 	// finally emit session stats
 	c.emitWorkflowHistoryStatsFn(domain, int(c.stats.HistorySize), int(resetMutableState.GetNextEventID()-1))
 	c.emitSessionUpdateStatsFn(domain, resp.MutableStateUpdateSessionStats)
@@ -853,6 +879,10 @@ func (c *contextImpl) UpdateWorkflowExecutionWithNew(
 	// notify new workflow tasks
 	c.notifyTasksFromWorkflowSnapshotFn(newWorkflow, persistedBlobs, false)
 
+	if resp.MutableStateUpdateSessionStats != nil {
+		c.setMutableStateSize(c.mutableStateSizeEstimator.update(resp.MutableStateUpdateSessionStats, newWorkflow == nil, c.mutableState))
+	}
+
 	// finally emit session stats
 	c.emitWorkflowHistoryStatsFn(domain, int(c.stats.HistorySize), int(c.mutableState.GetNextEventID()-1))
 	c.emitSessionUpdateStatsFn(domain, resp.MutableStateUpdateSessionStats)
@@ -1342,6 +1372,10 @@ func (c *contextImpl) ReapplyEvents(
 	defer cancel()
 
 	activeCluster := domainEntry.GetReplicationConfig().ActiveClusterName
+	if c.mutableState != nil && domainEntry.IsActiveActive() {
+		// events of an active-active domain are reapplied in the active cluster of the region of the workflow
+		activeCluster = GetActiveClusterForWorkflow(domainEntry, c.mutableState.GetExecutionInfo())
+	}
 	if activeCluster == c.shard.GetClusterMetadata().GetCurrentClusterName() {
 		return c.shard.GetEngine().ReapplyEvents(
 			ctx,
@@ -1773,6 +1773,7 @@ func TestUpdateWorkflowExecutionWithNew(t *testing.T) {
 				mockMutableState.EXPECT().GetNextEventID().Return(int64(10))
 				mockMutableState.EXPECT().GetPreviousStartedEventID().Return(int64(12))
 				mockMutableState.EXPECT().GetNextEventID().Return(int64(20))
+				expectMutableStateSizeEstimation(mockMutableState)
 				mockMutableState.EXPECT().GetCompletionEvent(gomock.Any()).Return(&types.HistoryEvent{
 					ID: 123,
 				}, nil)
@@ -2547,6 +2548,7 @@ func TestConflictResolveWorkflowExecution(t *testing.T) {
 				mockResetMutableState.EXPECT().GetNextEventID().Return(int64(456))
 				mockResetMutableState.EXPECT().GetPreviousStartedEventID().Return(int64(789))
 				mockResetMutableState.EXPECT().GetNextEventID().Return(int64(1111))
+				expectMutableStateSizeEstimation(mockResetMutableState)
 				mockResetMutableState.EXPECT().GetCompletionEvent(gomock.Any()).Return(&types.HistoryEvent{
 					ID: 123,
 				}, nil)
@@ -100,6 +100,8 @@ func (b *HistoryBuilder) AddWorkflowExecutionStartedEvent(startRequest *types.Hi
 		SearchAttributes:                    request.SearchAttributes,
 		JitterStartSeconds:                  request.JitterStartSeconds,
 		PartitionConfig:                     startRequest.PartitionConfig,
+		NoProgressTimeoutSeconds:            request.NoProgressTimeoutSeconds,
+		NoProgressTimeoutPolicy:             request.NoProgressTimeoutPolicy,
 		RequestID:                           request.RequestID,
 	}
 	if parentInfo := startRequest.ParentExecutionInfo; parentInfo != nil {
@@ -459,6 +461,25 @@ func (b *HistoryBuilder) AddActivityTaskCanceledEvent(scheduleEventID, startedEv
 	return b.addEventToHistory(event)
 }
 
+// AddActivityTaskOptionsUpdatedEvent adds ActivityTaskOptionsUpdated event to history
+func (b *HistoryBuilder) AddActivityTaskOptionsUpdatedEvent(scheduleEventID int64,
+	request *types.UpdateActivityOptionsRequest) *types.HistoryEvent {
+
+	event := b.msBuilder.CreateNewHistoryEvent(types.EventTypeActivityTaskOptionsUpdated)
+	event.ActivityTaskOptionsUpdatedEventAttributes = &types.ActivityTaskOptionsUpdatedEventAttributes{
+		ScheduledEventID:              scheduleEventID,
+		ScheduleToCloseTimeoutSeconds: request.ScheduleToCloseTimeoutSeconds,
+		ScheduleToStartTimeoutSeconds: request.ScheduleToStartTimeoutSeconds,
+		StartToCloseTimeoutSeconds:    request.StartToCloseTimeoutSeconds,
+		HeartbeatTimeoutSeconds:       request.HeartbeatTimeoutSeconds,
+		RetryPolicy:                   request.RetryPolicy,
+		TaskList:                      request.TaskList,
+		Identity:                      request.Identity,
+	}
+
+	return b.addEventToHistory(event)
+}
+
 // AddTimerCanceledEvent adds TimerCanceled event to history
 func (b *HistoryBuilder) AddTimerCanceledEvent(startedEventID int64,
 	decisionTaskCompletedEventID int64, timerID string, identity string) *types.HistoryEvent {
@@ -672,6 +693,22 @@ func (b *HistoryBuilder) AddWorkflowExecutionSignaledEvent(
 	return b.addEventToHistory(event)
 }
 
+// AddWorkflowNoProgressTimedOutEvent adds WorkflowNoProgressTimedOut event to history
+func (b *HistoryBuilder) AddWorkflowNoProgressTimedOutEvent(
+	noProgressTimeoutSeconds int32,
+	policy types.NoProgressTimeoutPolicy,
+	lastProgressTimestamp int64,
+) *types.HistoryEvent {
+	event := b.msBuilder.CreateNewHistoryEvent(types.EventTypeWorkflowNoProgressTimedOut)
+	event.WorkflowNoProgressTimedOutEventAttributes = &types.WorkflowNoProgressTimedOutEventAttributes{
+		NoProgressTimeoutSeconds: noProgressTimeoutSeconds,
+		NoProgressTimeoutPolicy:  policy.Ptr(),
+		LastProgressTimestamp:    common.Int64Ptr(lastProgressTimestamp),
+	}
+
+	return b.addEventToHistory(event)
+}
+
 // AddStartChildWorkflowExecutionInitiatedEvent adds ChildWorkflowExecutionInitiated event to history
 func (b *HistoryBuilder) AddStartChildWorkflowExecutionInitiatedEvent(
 	decisionCompletedEventID int64,
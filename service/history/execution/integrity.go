@@ -23,6 +23,9 @@ package execution
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strconv"
 
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/collection"
@@ -31,6 +34,26 @@ import (
 	"github.com/uber/cadence/service/history/shard"
 )
 
+const (
+	// DiscrepancyTypeExecutionInfo is the type of discrepancies in execution info fields
+	DiscrepancyTypeExecutionInfo = "ExecutionInfo"
+	// DiscrepancyTypePendingActivity is the type of discrepancies in pending activities
+	DiscrepancyTypePendingActivity = "PendingActivity"
+	// DiscrepancyTypePendingTimer is the type of discrepancies in pending timers
+	DiscrepancyTypePendingTimer = "PendingTimer"
+	// DiscrepancyTypePendingChildExecution is the type of discrepancies in pending child executions
+	DiscrepancyTypePendingChildExecution = "PendingChildExecution"
+	// DiscrepancyTypePendingSignal is the type of discrepancies in pending external signals
+	DiscrepancyTypePendingSignal = "PendingSignal"
+	// DiscrepancyTypePendingRequestCancel is the type of discrepancies in pending external cancellation requests
+	DiscrepancyTypePendingRequestCancel = "PendingRequestCancel"
+	// DiscrepancyTypeChecksum is the type of discrepancies between the persisted mutable state and its checksum
+	DiscrepancyTypeChecksum = "Checksum"
+
+	discrepancyValueMissing = "missing"
+	discrepancyValuePresent = "present"
+)
+
 // GetResurrectedTimers returns a set of timers (timer IDs) that were resurrected.
 // Meaning timers that are still pending in mutable state, but were already completed based on event history.
 func GetResurrectedTimers(
@@ -139,6 +162,179 @@ func GetResurrectedActivities(
 	return resurrectedActivity, nil
 }
 
+// GetChildWorkflowExecutions returns all child workflow executions started by the workflow,
+// including the ones that are already closed, based on event history.
+// Histories longer than maxEvents are not scanned and a BadRequestError is returned instead.
+func GetChildWorkflowExecutions(
+	ctx context.Context,
+	shard shard.Context,
+	mutableState MutableState,
+	maxEvents int,
+) ([]*types.ChildWorkflowExecutionInfo, error) {
+	if historyLength := mutableState.GetNextEventID() - common.FirstEventID; historyLength > int64(maxEvents) {
+		return nil, &types.BadRequestError{Message: fmt.Sprintf(
+			"Workflow history has %v events which exceeds the limit of %v for child workflow lookup, child workflows need to be deleted separately.",
+			historyLength,
+			maxEvents,
+		)}
+	}
+
+	branchToken, err := mutableState.GetCurrentBranchToken()
+	if err != nil {
+		return nil, err
+	}
+	domainID := mutableState.GetExecutionInfo().DomainID
+	iter := collection.NewPagingIterator(getHistoryPaginationFn(
+		ctx,
+		shard,
+		1,
+		mutableState.GetNextEventID(),
+		branchToken,
+		domainID,
+	))
+
+	var childExecutions []*types.ChildWorkflowExecutionInfo
+	for iter.HasNext() {
+		item, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		event := item.(*types.HistoryEvent)
+		if event.GetEventType() != types.EventTypeChildWorkflowExecutionStarted {
+			continue
+		}
+		attributes := event.ChildWorkflowExecutionStartedEventAttributes
+		childExecutions = append(childExecutions, &types.ChildWorkflowExecutionInfo{
+			Domain:            attributes.GetDomain(),
+			WorkflowExecution: attributes.WorkflowExecution,
+		})
+	}
+	return childExecutions, nil
+}
+
+// GetMutableStateDiscrepancies compares the persisted mutable state of a workflow with the mutable state
+// rebuilt from its history, and verifies the checksum of the persisted mutable state.
+// Fields which are not derived from history events, like transient decisions and sticky task lists, are not compared.
+func GetMutableStateDiscrepancies(
+	persisted MutableState,
+	rebuilt MutableState,
+) []*types.MutableStateDiscrepancy {
+	var discrepancies []*types.MutableStateDiscrepancy
+	addDiscrepancy := func(discrepancyType string, id string, persistedValue string, rebuiltValue string) {
+		discrepancies = append(discrepancies, &types.MutableStateDiscrepancy{
+			Type:      discrepancyType,
+			ID:        id,
+			Persisted: persistedValue,
+			Rebuilt:   rebuiltValue,
+		})
+	}
+	compareValue := func(discrepancyType string, id string, persistedValue interface{}, rebuiltValue interface{}) {
+		if persistedValue != rebuiltValue {
+			addDiscrepancy(discrepancyType, id, fmt.Sprint(persistedValue), fmt.Sprint(rebuiltValue))
+		}
+	}
+
+	persistedInfo := persisted.GetExecutionInfo()
+	rebuiltInfo := rebuilt.GetExecutionInfo()
+	compareValue(DiscrepancyTypeExecutionInfo, "State", persistedInfo.State, rebuiltInfo.State)
+	compareValue(DiscrepancyTypeExecutionInfo, "CloseStatus", persistedInfo.CloseStatus, rebuiltInfo.CloseStatus)
+	compareValue(DiscrepancyTypeExecutionInfo, "NextEventID", persistedInfo.NextEventID, rebuiltInfo.NextEventID)
+	compareValue(DiscrepancyTypeExecutionInfo, "LastFirstEventID", persistedInfo.LastFirstEventID, rebuiltInfo.LastFirstEventID)
+	compareValue(DiscrepancyTypeExecutionInfo, "SignalCount", persistedInfo.SignalCount, rebuiltInfo.SignalCount)
+
+	persistedActivities := persisted.GetPendingActivityInfos()
+	rebuiltActivities := rebuilt.GetPendingActivityInfos()
+	for scheduleID, activityInfo := range persistedActivities {
+		id := strconv.FormatInt(scheduleID, 10)
+		if rebuiltActivityInfo, ok := rebuiltActivities[scheduleID]; ok {
+			compareValue(DiscrepancyTypePendingActivity, id, activityInfo.ActivityID, rebuiltActivityInfo.ActivityID)
+		} else {
+			addDiscrepancy(DiscrepancyTypePendingActivity, id, activityInfo.ActivityID, discrepancyValueMissing)
+		}
+	}
+	for scheduleID, activityInfo := range rebuiltActivities {
+		if _, ok := persistedActivities[scheduleID]; !ok {
+			addDiscrepancy(DiscrepancyTypePendingActivity, strconv.FormatInt(scheduleID, 10), discrepancyValueMissing, activityInfo.ActivityID)
+		}
+	}
+
+	persistedTimers := persisted.GetPendingTimerInfos()
+	rebuiltTimers := rebuilt.GetPendingTimerInfos()
+	for timerID, timerInfo := range persistedTimers {
+		if rebuiltTimerInfo, ok := rebuiltTimers[timerID]; ok {
+			compareValue(DiscrepancyTypePendingTimer, timerID, timerInfo.StartedID, rebuiltTimerInfo.StartedID)
+		} else {
+			addDiscrepancy(DiscrepancyTypePendingTimer, timerID, strconv.FormatInt(timerInfo.StartedID, 10), discrepancyValueMissing)
+		}
+	}
+	for timerID, timerInfo := range rebuiltTimers {
+		if _, ok := persistedTimers[timerID]; !ok {
+			addDiscrepancy(DiscrepancyTypePendingTimer, timerID, discrepancyValueMissing, strconv.FormatInt(timerInfo.StartedID, 10))
+		}
+	}
+
+	persistedChildren := persisted.GetPendingChildExecutionInfos()
+	rebuiltChildren := rebuilt.GetPendingChildExecutionInfos()
+	for initiatedID, childInfo := range persistedChildren {
+		id := strconv.FormatInt(initiatedID, 10)
+		if rebuiltChildInfo, ok := rebuiltChildren[initiatedID]; ok {
+			compareValue(DiscrepancyTypePendingChildExecution, id, childInfo.StartedID, rebuiltChildInfo.StartedID)
+		} else {
+			addDiscrepancy(DiscrepancyTypePendingChildExecution, id, childInfo.StartedWorkflowID, discrepancyValueMissing)
+		}
+	}
+	for initiatedID, childInfo := range rebuiltChildren {
+		if _, ok := persistedChildren[initiatedID]; !ok {
+			addDiscrepancy(DiscrepancyTypePendingChildExecution, strconv.FormatInt(initiatedID, 10), discrepancyValueMissing, childInfo.StartedWorkflowID)
+		}
+	}
+
+	compareInitiatedIDs := func(discrepancyType string, persistedIDs map[int64]struct{}, rebuiltIDs map[int64]struct{}) {
+		for initiatedID := range persistedIDs {
+			if _, ok := rebuiltIDs[initiatedID]; !ok {
+				addDiscrepancy(discrepancyType, strconv.FormatInt(initiatedID, 10), discrepancyValuePresent, discrepancyValueMissing)
+			}
+		}
+		for initiatedID := range rebuiltIDs {
+			if _, ok := persistedIDs[initiatedID]; !ok {
+				addDiscrepancy(discrepancyType, strconv.FormatInt(initiatedID, 10), discrepancyValueMissing, discrepancyValuePresent)
+			}
+		}
+	}
+	compareInitiatedIDs(
+		DiscrepancyTypePendingSignal,
+		initiatedIDSet(persisted.GetPendingSignalExternalInfos()),
+		initiatedIDSet(rebuilt.GetPendingSignalExternalInfos()),
+	)
+	compareInitiatedIDs(
+		DiscrepancyTypePendingRequestCancel,
+		initiatedIDSet(persisted.GetPendingRequestCancelExternalInfos()),
+		initiatedIDSet(rebuilt.GetPendingRequestCancelExternalInfos()),
+	)
+
+	if csum := persisted.CopyToPersistence().Checksum; len(csum.Value) > 0 {
+		if err := verifyMutableStateChecksum(persisted, csum); err != nil {
+			addDiscrepancy(DiscrepancyTypeChecksum, "", err.Error(), "")
+		}
+	}
+
+	sort.SliceStable(discrepancies, func(i, j int) bool {
+		if discrepancies[i].Type != discrepancies[j].Type {
+			return discrepancies[i].Type < discrepancies[j].Type
+		}
+		return discrepancies[i].ID < discrepancies[j].ID
+	})
+	return discrepancies
+}
+
+func initiatedIDSet[T any](infos map[int64]T) map[int64]struct{} {
+	ids := make(map[int64]struct{}, len(infos))
+	for initiatedID := range infos {
+		ids[initiatedID] = struct{}{}
+	}
+	return ids
+}
+
 func getHistoryPaginationFn(
 	ctx context.Context,
 	shard shard.Context,
@@ -173,3 +369,19 @@ func getHistoryPaginationFn(
 		return items, token, nil
 	}
 }
+
+// HasOnlyChecksumDiscrepancies returns true if the persisted mutable state matches the one rebuilt from history
+// and only its checksum is mismatching
+func HasOnlyChecksumDiscrepancies(
+	discrepancies []*types.MutableStateDiscrepancy,
+) bool {
+	if len(discrepancies) == 0 {
+		return false
+	}
+	for _, discrepancy := range discrepancies {
+		if discrepancy.Type != DiscrepancyTypeChecksum {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package execution
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uber/cadence/common/checksum"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+func newMockMutableStateForDiscrepancies(
+	ctrl *gomock.Controller,
+	executionInfo *persistence.WorkflowExecutionInfo,
+	activityInfos map[int64]*persistence.ActivityInfo,
+	timerInfos map[string]*persistence.TimerInfo,
+	childInfos map[int64]*persistence.ChildExecutionInfo,
+	signalInfos map[int64]*persistence.SignalInfo,
+	csum checksum.Checksum,
+) *MockMutableState {
+	mutableState := NewMockMutableState(ctrl)
+	mutableState.EXPECT().GetExecutionInfo().Return(executionInfo).AnyTimes()
+	mutableState.EXPECT().GetPendingActivityInfos().Return(activityInfos).AnyTimes()
+	mutableState.EXPECT().GetPendingTimerInfos().Return(timerInfos).AnyTimes()
+	mutableState.EXPECT().GetPendingChildExecutionInfos().Return(childInfos).AnyTimes()
+	mutableState.EXPECT().GetPendingSignalExternalInfos().Return(signalInfos).AnyTimes()
+	mutableState.EXPECT().GetPendingRequestCancelExternalInfos().Return(map[int64]*persistence.RequestCancelInfo{}).AnyTimes()
+	mutableState.EXPECT().GetVersionHistories().Return(nil).AnyTimes()
+	mutableState.EXPECT().CopyToPersistence().Return(&persistence.WorkflowMutableState{Checksum: csum}).AnyTimes()
+	return mutableState
+}
+
+func TestGetMutableStateDiscrepancies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	rebuilt := newMockMutableStateForDiscrepancies(
+		ctrl,
+		&persistence.WorkflowExecutionInfo{State: persistence.WorkflowStateRunning, NextEventID: 10, LastFirstEventID: 8},
+		map[int64]*persistence.ActivityInfo{5: {ActivityID: "activity-5"}},
+		map[string]*persistence.TimerInfo{"timer-1": {StartedID: 6}},
+		map[int64]*persistence.ChildExecutionInfo{7: {StartedID: 9, StartedWorkflowID: "child"}},
+		map[int64]*persistence.SignalInfo{},
+		checksum.Checksum{},
+	)
+
+	consistent := newMockMutableStateForDiscrepancies(
+		ctrl,
+		&persistence.WorkflowExecutionInfo{State: persistence.WorkflowStateRunning, NextEventID: 10, LastFirstEventID: 8},
+		map[int64]*persistence.ActivityInfo{5: {ActivityID: "activity-5"}},
+		map[string]*persistence.TimerInfo{"timer-1": {StartedID: 6}},
+		map[int64]*persistence.ChildExecutionInfo{7: {StartedID: 9, StartedWorkflowID: "child"}},
+		map[int64]*persistence.SignalInfo{},
+		checksum.Checksum{},
+	)
+	assert.Empty(t, GetMutableStateDiscrepancies(consistent, rebuilt))
+
+	inconsistent := newMockMutableStateForDiscrepancies(
+		ctrl,
+		&persistence.WorkflowExecutionInfo{State: persistence.WorkflowStateRunning, NextEventID: 11, LastFirstEventID: 8},
+		map[int64]*persistence.ActivityInfo{},
+		map[string]*persistence.TimerInfo{"timer-1": {StartedID: 6}, "timer-2": {StartedID: 4}},
+		map[int64]*persistence.ChildExecutionInfo{7: {StartedID: 0, StartedWorkflowID: "child"}},
+		map[int64]*persistence.SignalInfo{3: {}},
+		checksum.Checksum{Version: mutableStateChecksumPayloadV1, Flavor: checksum.FlavorIEEECRC32OverThriftBinary, Value: []byte{1, 2, 3, 4}},
+	)
+	discrepancies := GetMutableStateDiscrepancies(inconsistent, rebuilt)
+	assert.Len(t, discrepancies, 6)
+	assert.Equal(t, DiscrepancyTypeChecksum, discrepancies[0].Type)
+	assert.Equal(t, []*types.MutableStateDiscrepancy{
+		{Type: DiscrepancyTypeExecutionInfo, ID: "NextEventID", Persisted: "11", Rebuilt: "10"},
+		{Type: DiscrepancyTypePendingActivity, ID: "5", Persisted: discrepancyValueMissing, Rebuilt: "activity-5"},
+		{Type: DiscrepancyTypePendingChildExecution, ID: "7", Persisted: "0", Rebuilt: "9"},
+		{Type: DiscrepancyTypePendingSignal, ID: "3", Persisted: discrepancyValuePresent, Rebuilt: discrepancyValueMissing},
+		{Type: DiscrepancyTypePendingTimer, ID: "timer-2", Persisted: "4", Rebuilt: discrepancyValueMissing},
+	}, discrepancies[1:])
+}
+
+func TestGetChildWorkflowExecutions_HistoryTooLong(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mutableState := NewMockMutableState(ctrl)
+	mutableState.EXPECT().GetNextEventID().Return(int64(102)).AnyTimes()
+
+	childExecutions, err := GetChildWorkflowExecutions(context.Background(), nil, mutableState, 100)
+	assert.Nil(t, childExecutions)
+	assert.IsType(t, &types.BadRequestError{}, err)
+}
+
+func TestHasOnlyChecksumDiscrepancies(t *testing.T) {
+	checksumDiscrepancy := &types.MutableStateDiscrepancy{Type: DiscrepancyTypeChecksum}
+	timerDiscrepancy := &types.MutableStateDiscrepancy{Type: DiscrepancyTypePendingTimer, ID: "timer"}
+
+	assert.False(t, HasOnlyChecksumDiscrepancies(nil))
+	assert.True(t, HasOnlyChecksumDiscrepancies([]*types.MutableStateDiscrepancy{checksumDiscrepancy}))
+	assert.False(t, HasOnlyChecksumDiscrepancies([]*types.MutableStateDiscrepancy{timerDiscrepancy}))
+	assert.False(t, HasOnlyChecksumDiscrepancies([]*types.MutableStateDiscrepancy{checksumDiscrepancy, timerDiscrepancy}))
+}
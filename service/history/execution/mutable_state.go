@@ -59,6 +59,7 @@ type (
 	MutableState interface {
 		AddActivityTaskCancelRequestedEvent(int64, string, string) (*types.HistoryEvent, *persistence.ActivityInfo, error)
 		AddActivityTaskCanceledEvent(int64, int64, int64, []uint8, string) (*types.HistoryEvent, error)
+		AddActivityTaskOptionsUpdatedEvent(context.Context, *persistence.ActivityInfo, *types.UpdateActivityOptionsRequest) (*types.HistoryEvent, error)
 		AddActivityTaskCompletedEvent(int64, int64, *types.RespondActivityTaskCompletedRequest) (*types.HistoryEvent, error)
 		AddActivityTaskFailedEvent(int64, int64, *types.RespondActivityTaskFailedRequest) (*types.HistoryEvent, error)
 		AddActivityTaskScheduledEvent(context.Context, int64, *types.ScheduleActivityTaskDecisionAttributes, bool) (*types.HistoryEvent, *persistence.ActivityInfo, *types.ActivityLocalDispatchInfo, bool, bool, error)
@@ -104,6 +105,7 @@ type (
 		AddWorkflowExecutionSignaled(signalName string, input []byte, identity string, reqeustID string) (*types.HistoryEvent, error)
 		AddWorkflowExecutionStartedEvent(types.WorkflowExecution, *types.HistoryStartWorkflowExecutionRequest) (*types.HistoryEvent, error)
 		AddWorkflowExecutionTerminatedEvent(firstEventID int64, reason string, details []byte, identity string) (*types.HistoryEvent, error)
+		AddWorkflowNoProgressTimedOutEvent() (*types.HistoryEvent, error)
 		ClearStickyness()
 		CheckResettable() error
 		CopyToPersistence() *persistence.WorkflowMutableState
@@ -171,6 +173,7 @@ type (
 		ReplicateActivityInfo(*types.SyncActivityRequest, bool) error
 		ReplicateActivityTaskCancelRequestedEvent(*types.HistoryEvent) error
 		ReplicateActivityTaskCanceledEvent(*types.HistoryEvent) error
+		ReplicateActivityTaskOptionsUpdatedEvent(*types.HistoryEvent) error
 		ReplicateActivityTaskCompletedEvent(*types.HistoryEvent) error
 		ReplicateActivityTaskFailedEvent(*types.HistoryEvent) error
 		ReplicateActivityTaskScheduledEvent(int64, *types.HistoryEvent, bool) (*persistence.ActivityInfo, error)
@@ -209,6 +212,7 @@ type (
 		ReplicateWorkflowExecutionStartedEvent(*string, types.WorkflowExecution, string, *types.HistoryEvent, bool) error
 		ReplicateWorkflowExecutionTerminatedEvent(int64, *types.HistoryEvent) error
 		ReplicateWorkflowExecutionTimedoutEvent(int64, *types.HistoryEvent) error
+		ReplicateWorkflowNoProgressTimedOutEvent(*types.HistoryEvent) error
 		SetCurrentBranchToken(branchToken []byte) error
 		SetHistoryBuilder(hBuilder *HistoryBuilder)
 		SetHistoryTree(treeID string) error
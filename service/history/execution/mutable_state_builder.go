@@ -1329,6 +1329,99 @@ func (e *mutableStateBuilder) UpdateActivityProgress(
 	e.syncActivityTasks[ai.ScheduleID] = struct{}{}
 }
 
+// AddActivityTaskOptionsUpdatedEvent records the new timeouts, retry policy and task list of a pending activity.
+func (e *mutableStateBuilder) AddActivityTaskOptionsUpdatedEvent(
+	_ context.Context,
+	ai *persistence.ActivityInfo,
+	request *types.UpdateActivityOptionsRequest,
+) (*types.HistoryEvent, error) {
+
+	opTag := tag.WorkflowActionActivityTaskOptionsUpdated
+	if err := e.checkMutability(opTag); err != nil {
+		return nil, err
+	}
+
+	if _, ok := e.pendingActivityInfoIDs[ai.ScheduleID]; !ok {
+		e.logWarn(mutableStateInvalidHistoryActionMsg, opTag,
+			tag.WorkflowEventID(e.GetNextEventID()),
+			tag.ErrorTypeInvalidHistoryAction,
+			tag.WorkflowScheduleID(ai.ScheduleID))
+		return nil, e.createInternalServerError(opTag)
+	}
+
+	event := e.hBuilder.AddActivityTaskOptionsUpdatedEvent(ai.ScheduleID, request)
+	if err := e.ReplicateActivityTaskOptionsUpdatedEvent(event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// ReplicateActivityTaskOptionsUpdatedEvent applies the options recorded in the event to the pending activity.
+// Activity timer task status is reset so that timer tasks are regenerated with the new
+// timeouts when the transaction is closed. If the task list is changed while the activity is
+// waiting to be started, a new activity transfer task is generated for the new task list.
+func (e *mutableStateBuilder) ReplicateActivityTaskOptionsUpdatedEvent(
+	event *types.HistoryEvent,
+) error {
+
+	attributes := event.ActivityTaskOptionsUpdatedEventAttributes
+	ai, ok := e.GetActivityInfo(attributes.GetScheduledEventID())
+	if !ok {
+		e.logError(
+			fmt.Sprintf("unable to find activity event ID: %v in mutable state", attributes.GetScheduledEventID()),
+			tag.ErrorTypeInvalidMutableStateAction,
+		)
+		return ErrMissingActivityInfo
+	}
+
+	if attributes.ScheduleToCloseTimeoutSeconds != nil {
+		ai.ScheduleToCloseTimeout = *attributes.ScheduleToCloseTimeoutSeconds
+	}
+	if attributes.ScheduleToStartTimeoutSeconds != nil {
+		ai.ScheduleToStartTimeout = *attributes.ScheduleToStartTimeoutSeconds
+	}
+	if attributes.StartToCloseTimeoutSeconds != nil {
+		ai.StartToCloseTimeout = *attributes.StartToCloseTimeoutSeconds
+	}
+	if attributes.HeartbeatTimeoutSeconds != nil {
+		ai.HeartbeatTimeout = *attributes.HeartbeatTimeoutSeconds
+	}
+
+	if policy := attributes.GetRetryPolicy(); policy != nil {
+		ai.HasRetryPolicy = true
+		ai.InitialInterval = policy.GetInitialIntervalInSeconds()
+		ai.BackoffCoefficient = policy.GetBackoffCoefficient()
+		ai.MaximumInterval = policy.GetMaximumIntervalInSeconds()
+		ai.MaximumAttempts = policy.GetMaximumAttempts()
+		ai.NonRetriableErrors = policy.NonRetriableErrorReasons
+		ai.ExpirationTime = time.Time{}
+		if policy.GetExpirationIntervalInSeconds() != 0 {
+			// the original schedule time of the first attempt is not tracked,
+			// so the new expiration interval starts from the time of the update
+			ai.ExpirationTime = time.Unix(0, event.GetTimestamp()).Add(
+				time.Duration(policy.GetExpirationIntervalInSeconds()) * time.Second,
+			)
+		}
+	}
+
+	taskListChanged := false
+	if taskList := attributes.GetTaskList(); taskList != nil && taskList.GetName() != ai.TaskList {
+		ai.TaskList = taskList.GetName()
+		taskListChanged = true
+	}
+
+	ai.Version = event.Version
+	ai.TimerTaskStatus = TimerTaskStatusNone
+	e.updateActivityInfos[ai.ScheduleID] = ai
+
+	// activity is already started or is waiting for the retry timer,
+	// in both cases the new task list will be used for the next dispatch
+	if !taskListChanged || ai.StartedID != common.EmptyEventID || ai.ScheduledTime.After(time.Unix(0, event.GetTimestamp())) {
+		return nil
+	}
+	return e.taskGenerator.GenerateActivityTaskListUpdateTasks(ai.ScheduleID)
+}
+
 // ReplicateActivityInfo replicate the necessary activity information
 func (e *mutableStateBuilder) ReplicateActivityInfo(
 	request *types.SyncActivityRequest,
@@ -1697,6 +1790,10 @@ func (e *mutableStateBuilder) addWorkflowExecutionStartedEventForContinueAsNew(
 		SearchAttributes:                    attributes.SearchAttributes,
 		JitterStartSeconds:                  attributes.JitterStartSeconds,
 	}
+	if previousExecutionInfo.NoProgressTimeout > 0 {
+		createRequest.NoProgressTimeoutSeconds = common.Int32Ptr(previousExecutionInfo.NoProgressTimeout)
+		createRequest.NoProgressTimeoutPolicy = types.NoProgressTimeoutPolicy(previousExecutionInfo.NoProgressTimeoutPolicy).Ptr()
+	}
 
 	req := &types.HistoryStartWorkflowExecutionRequest{
 		DomainUUID:                      e.domainEntry.GetInfo().ID,
@@ -1886,6 +1983,8 @@ func (e *mutableStateBuilder) ReplicateWorkflowExecutionStartedEvent(
 		e.executionInfo.SearchAttributes = event.SearchAttributes.GetIndexedFields()
 	}
 	e.executionInfo.PartitionConfig = event.PartitionConfig
+	e.executionInfo.NoProgressTimeout = event.GetNoProgressTimeoutSeconds()
+	e.executionInfo.NoProgressTimeoutPolicy = int32(event.GetNoProgressTimeoutPolicy())
 
 	e.writeEventToCache(startEvent)
 
@@ -1895,6 +1994,14 @@ func (e *mutableStateBuilder) ReplicateWorkflowExecutionStartedEvent(
 	if err := e.taskGenerator.GenerateRecordWorkflowStartedTasks(startEvent); err != nil {
 		return err
 	}
+	if e.executionInfo.NoProgressTimeout > 0 {
+		// progress is measured from the time the first decision can be scheduled
+		firstDecisionDelayDuration := time.Duration(event.GetFirstDecisionTaskBackoffSeconds()) * time.Second
+		e.executionInfo.LastProgressTimestamp = e.unixNanoToTime(startEvent.GetTimestamp()).Add(firstDecisionDelayDuration)
+		if err := e.taskGenerator.GenerateWorkflowNoProgressTimeoutTasks(startEvent.Version); err != nil {
+			return err
+		}
+	}
 	if generateDelayedDecisionTasks && event.GetFirstDecisionTaskBackoffSeconds() > 0 {
 		if err := e.taskGenerator.GenerateDelayedDecisionTasks(startEvent); err != nil {
 			return err
@@ -3390,6 +3497,43 @@ func (e *mutableStateBuilder) ReplicateWorkflowExecutionSignaled(
 	return nil
 }
 
+// AddWorkflowNoProgressTimedOutEvent records that no decision has completed within the no progress timeout.
+func (e *mutableStateBuilder) AddWorkflowNoProgressTimedOutEvent() (*types.HistoryEvent, error) {
+
+	opTag := tag.WorkflowActionWorkflowNoProgressTimedOut
+	if err := e.checkMutability(opTag); err != nil {
+		return nil, err
+	}
+
+	event := e.hBuilder.AddWorkflowNoProgressTimedOutEvent(
+		e.executionInfo.NoProgressTimeout,
+		types.NoProgressTimeoutPolicy(e.executionInfo.NoProgressTimeoutPolicy),
+		e.executionInfo.LastProgressTimestamp.UnixNano(),
+	)
+	if err := e.ReplicateWorkflowNoProgressTimedOutEvent(event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// ReplicateWorkflowNoProgressTimedOutEvent restarts the no progress period from the event.
+// When the policy is to fail the workflow, the close event follows and no timer is re-armed.
+func (e *mutableStateBuilder) ReplicateWorkflowNoProgressTimedOutEvent(
+	event *types.HistoryEvent,
+) error {
+
+	attributes := event.WorkflowNoProgressTimedOutEventAttributes
+	e.executionInfo.LastProgressTimestamp = e.unixNanoToTime(event.GetTimestamp())
+	if attributes.GetNoProgressTimeoutPolicy() == types.NoProgressTimeoutPolicyFail {
+		return nil
+	}
+	startVersion, err := e.GetStartVersion()
+	if err != nil {
+		return err
+	}
+	return e.taskGenerator.GenerateWorkflowNoProgressTimeoutTasks(startVersion)
+}
+
 func (e *mutableStateBuilder) AddContinueAsNewEvent(
 	ctx context.Context,
 	firstEventID int64,
@@ -4065,7 +4209,7 @@ func (e *mutableStateBuilder) StartTransaction(
 ) (bool, error) {
 
 	e.domainEntry = domainEntry
-	if err := e.UpdateCurrentVersion(domainEntry.GetFailoverVersion(), false); err != nil {
+	if err := e.UpdateCurrentVersion(GetFailoverVersionForWorkflow(domainEntry, e.clusterMetadata, e.executionInfo), false); err != nil {
 		return false, err
 	}
 
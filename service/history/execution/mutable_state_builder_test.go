@@ -876,6 +876,139 @@ func (s *mutableStateSuite) TestUpdateCurrentVersion_WorkflowClosed() {
 	s.Equal(lastWriteVersion, s.msBuilder.GetCurrentVersion())
 }
 
+func (s *mutableStateSuite) TestAddActivityTaskOptionsUpdatedEvent_StartedActivity() {
+	mutableState := s.buildWorkflowMutableState()
+	s.msBuilder.Load(mutableState)
+
+	ai, ok := s.msBuilder.GetActivityInfo(5)
+	s.True(ok)
+	ai.StartedID = 6
+	ai.TaskList = "testTaskList"
+	ai.TimerTaskStatus = TimerTaskStatusCreatedStartToClose | TimerTaskStatusCreatedHeartbeat
+
+	event, err := s.msBuilder.AddActivityTaskOptionsUpdatedEvent(context.Background(), ai, &types.UpdateActivityOptionsRequest{
+		StartToCloseTimeoutSeconds: common.Int32Ptr(600),
+		HeartbeatTimeoutSeconds:    common.Int32Ptr(0),
+		RetryPolicy: &types.RetryPolicy{
+			InitialIntervalInSeconds:    1,
+			BackoffCoefficient:          2,
+			MaximumAttempts:             5,
+			ExpirationIntervalInSeconds: 3600,
+		},
+		TaskList: &types.TaskList{Name: "newTaskList"},
+	})
+	s.NoError(err)
+	s.Equal(types.EventTypeActivityTaskOptionsUpdated, event.GetEventType())
+	s.Equal(ai.ScheduleID, event.ActivityTaskOptionsUpdatedEventAttributes.GetScheduledEventID())
+
+	s.Equal(int32(100), ai.ScheduleToStartTimeout)
+	s.Equal(int32(200), ai.ScheduleToCloseTimeout)
+	s.Equal(int32(600), ai.StartToCloseTimeout)
+	s.Equal(int32(0), ai.HeartbeatTimeout)
+	s.True(ai.HasRetryPolicy)
+	s.Equal(int32(5), ai.MaximumAttempts)
+	s.False(ai.ExpirationTime.IsZero())
+	s.Equal("newTaskList", ai.TaskList)
+	s.Equal(int32(TimerTaskStatusNone), ai.TimerTaskStatus)
+	s.Contains(s.msBuilder.updateActivityInfos, ai.ScheduleID)
+	// started activity picks up the new task list on the next retry
+	s.Empty(s.msBuilder.GetTransferTasks())
+}
+
+func (s *mutableStateSuite) TestAddActivityTaskOptionsUpdatedEvent_ScheduledActivity_TaskListChanged() {
+	mutableState := s.buildWorkflowMutableState()
+	s.msBuilder.Load(mutableState)
+
+	ai, ok := s.msBuilder.GetActivityInfo(5)
+	s.True(ok)
+	ai.DomainID = constants.TestDomainID
+	ai.TaskList = "testTaskList"
+	ai.ScheduledTime = s.msBuilder.timeSource.Now().Add(-time.Minute)
+
+	_, err := s.msBuilder.AddActivityTaskOptionsUpdatedEvent(context.Background(), ai, &types.UpdateActivityOptionsRequest{
+		TaskList: &types.TaskList{Name: "newTaskList"},
+	})
+	s.NoError(err)
+
+	transferTasks := s.msBuilder.GetTransferTasks()
+	s.Len(transferTasks, 1)
+	activityTask, ok := transferTasks[0].(*persistence.ActivityTask)
+	s.True(ok)
+	s.Equal("newTaskList", activityTask.TaskList)
+	s.Equal(ai.ScheduleID, activityTask.ScheduleID)
+}
+
+func (s *mutableStateSuite) TestReplicateActivityTaskOptionsUpdatedEvent_TaskListChanged() {
+	mutableState := s.buildWorkflowMutableState()
+	s.msBuilder.Load(mutableState)
+
+	ai, ok := s.msBuilder.GetActivityInfo(5)
+	s.True(ok)
+	ai.DomainID = constants.TestDomainID
+	ai.TaskList = "testTaskList"
+	ai.ScheduledTime = s.msBuilder.timeSource.Now().Add(-time.Minute)
+
+	err := s.msBuilder.ReplicateActivityTaskOptionsUpdatedEvent(&types.HistoryEvent{
+		ID:        10,
+		Timestamp: common.Int64Ptr(s.msBuilder.timeSource.Now().UnixNano()),
+		Version:   2000,
+		EventType: types.EventTypeActivityTaskOptionsUpdated.Ptr(),
+		ActivityTaskOptionsUpdatedEventAttributes: &types.ActivityTaskOptionsUpdatedEventAttributes{
+			ScheduledEventID: ai.ScheduleID,
+			TaskList:         &types.TaskList{Name: "newTaskList"},
+		},
+	})
+	s.NoError(err)
+	s.Equal(int64(2000), ai.Version)
+	s.Equal("newTaskList", ai.TaskList)
+
+	// standby clusters generate the task for the new task list as well
+	transferTasks := s.msBuilder.GetTransferTasks()
+	s.Len(transferTasks, 1)
+	activityTask, ok := transferTasks[0].(*persistence.ActivityTask)
+	s.True(ok)
+	s.Equal("newTaskList", activityTask.TaskList)
+	s.Equal(int64(2000), activityTask.Version)
+}
+
+func (s *mutableStateSuite) TestAddActivityTaskOptionsUpdatedEvent_WorkflowClosed() {
+	mutableState := s.buildWorkflowMutableState()
+	mutableState.ExecutionInfo.State = persistence.WorkflowStateCompleted
+	mutableState.ExecutionInfo.CloseStatus = persistence.WorkflowCloseStatusCompleted
+	s.msBuilder.Load(mutableState)
+
+	ai, ok := s.msBuilder.GetActivityInfo(5)
+	s.True(ok)
+	_, err := s.msBuilder.AddActivityTaskOptionsUpdatedEvent(context.Background(), ai, &types.UpdateActivityOptionsRequest{
+		HeartbeatTimeoutSeconds: common.Int32Ptr(10),
+	})
+	s.Error(err)
+}
+
+func (s *mutableStateSuite) TestAddWorkflowNoProgressTimedOutEvent() {
+	mutableState := s.buildWorkflowMutableState()
+	mutableState.ExecutionInfo.NoProgressTimeout = 60
+	mutableState.ExecutionInfo.NoProgressTimeoutPolicy = int32(types.NoProgressTimeoutPolicyNotify)
+	mutableState.ExecutionInfo.LastProgressTimestamp = s.msBuilder.timeSource.Now().Add(-time.Minute)
+	s.msBuilder.Load(mutableState)
+
+	event, err := s.msBuilder.AddWorkflowNoProgressTimedOutEvent()
+	s.NoError(err)
+	s.Equal(types.EventTypeWorkflowNoProgressTimedOut, event.GetEventType())
+	attributes := event.WorkflowNoProgressTimedOutEventAttributes
+	s.Equal(int32(60), attributes.NoProgressTimeoutSeconds)
+	s.Equal(types.NoProgressTimeoutPolicyNotify, attributes.GetNoProgressTimeoutPolicy())
+
+	// the no progress period restarts from the event and a single timer is re-armed
+	executionInfo := s.msBuilder.GetExecutionInfo()
+	s.Equal(time.Unix(0, event.GetTimestamp()), executionInfo.LastProgressTimestamp)
+	s.Equal(executionInfo.LastProgressTimestamp.Add(time.Minute), executionInfo.NoProgressTimerTimestamp)
+	timerTasks := s.msBuilder.GetTimerTasks()
+	s.Len(timerTasks, 1)
+	s.IsType(&persistence.WorkflowNoProgressTimeoutTask{}, timerTasks[0])
+	s.Equal(executionInfo.NoProgressTimerTimestamp, timerTasks[0].GetVisibilityTimestamp())
+}
+
 func (s *mutableStateSuite) newDomainCacheEntry() *cache.DomainCacheEntry {
 	return cache.NewDomainCacheEntryForTest(
 		&persistence.DomainInfo{Name: "mutableStateTest"},
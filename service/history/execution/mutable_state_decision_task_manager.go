@@ -863,6 +863,10 @@ func (m *mutableStateDecisionTaskManagerImpl) afterAddDecisionTaskCompletedEvent
 	maxResetPoints int,
 ) error {
 	m.msb.executionInfo.LastProcessedEvent = event.GetDecisionTaskCompletedEventAttributes().GetStartedEventID()
+	if m.msb.executionInfo.NoProgressTimeout > 0 {
+		// the outstanding no progress timer re-arms itself from this timestamp when it fires
+		m.msb.executionInfo.LastProgressTimestamp = m.msb.unixNanoToTime(event.GetTimestamp())
+	}
 	return m.msb.addBinaryCheckSumIfNotExists(event, maxResetPoints)
 }
 
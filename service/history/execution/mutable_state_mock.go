@@ -124,6 +124,21 @@ func (mr *MockMutableStateMockRecorder) AddActivityTaskFailedEvent(arg0, arg1, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActivityTaskFailedEvent", reflect.TypeOf((*MockMutableState)(nil).AddActivityTaskFailedEvent), arg0, arg1, arg2)
 }
 
+// AddActivityTaskOptionsUpdatedEvent mocks base method.
+func (m *MockMutableState) AddActivityTaskOptionsUpdatedEvent(arg0 context.Context, arg1 *persistence.ActivityInfo, arg2 *types.UpdateActivityOptionsRequest) (*types.HistoryEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddActivityTaskOptionsUpdatedEvent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types.HistoryEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddActivityTaskOptionsUpdatedEvent indicates an expected call of AddActivityTaskOptionsUpdatedEvent.
+func (mr *MockMutableStateMockRecorder) AddActivityTaskOptionsUpdatedEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActivityTaskOptionsUpdatedEvent", reflect.TypeOf((*MockMutableState)(nil).AddActivityTaskOptionsUpdatedEvent), arg0, arg1, arg2)
+}
+
 // AddActivityTaskScheduledEvent mocks base method.
 func (m *MockMutableState) AddActivityTaskScheduledEvent(arg0 context.Context, arg1 int64, arg2 *types.ScheduleActivityTaskDecisionAttributes, arg3 bool) (*types.HistoryEvent, *persistence.ActivityInfo, *types.ActivityLocalDispatchInfo, bool, bool, error) {
 	m.ctrl.T.Helper()
@@ -823,6 +838,21 @@ func (mr *MockMutableStateMockRecorder) AddWorkflowExecutionTerminatedEvent(firs
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWorkflowExecutionTerminatedEvent", reflect.TypeOf((*MockMutableState)(nil).AddWorkflowExecutionTerminatedEvent), firstEventID, reason, details, identity)
 }
 
+// AddWorkflowNoProgressTimedOutEvent mocks base method.
+func (m *MockMutableState) AddWorkflowNoProgressTimedOutEvent() (*types.HistoryEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWorkflowNoProgressTimedOutEvent")
+	ret0, _ := ret[0].(*types.HistoryEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWorkflowNoProgressTimedOutEvent indicates an expected call of AddWorkflowNoProgressTimedOutEvent.
+func (mr *MockMutableStateMockRecorder) AddWorkflowNoProgressTimedOutEvent() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWorkflowNoProgressTimedOutEvent", reflect.TypeOf((*MockMutableState)(nil).AddWorkflowNoProgressTimedOutEvent))
+}
+
 // CheckResettable mocks base method.
 func (m *MockMutableState) CheckResettable() error {
 	m.ctrl.T.Helper()
@@ -1898,6 +1928,20 @@ func (mr *MockMutableStateMockRecorder) ReplicateActivityTaskFailedEvent(arg0 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplicateActivityTaskFailedEvent", reflect.TypeOf((*MockMutableState)(nil).ReplicateActivityTaskFailedEvent), arg0)
 }
 
+// ReplicateActivityTaskOptionsUpdatedEvent mocks base method.
+func (m *MockMutableState) ReplicateActivityTaskOptionsUpdatedEvent(arg0 *types.HistoryEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplicateActivityTaskOptionsUpdatedEvent", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplicateActivityTaskOptionsUpdatedEvent indicates an expected call of ReplicateActivityTaskOptionsUpdatedEvent.
+func (mr *MockMutableStateMockRecorder) ReplicateActivityTaskOptionsUpdatedEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplicateActivityTaskOptionsUpdatedEvent", reflect.TypeOf((*MockMutableState)(nil).ReplicateActivityTaskOptionsUpdatedEvent), arg0)
+}
+
 // ReplicateActivityTaskScheduledEvent mocks base method.
 func (m *MockMutableState) ReplicateActivityTaskScheduledEvent(arg0 int64, arg1 *types.HistoryEvent, arg2 bool) (*persistence.ActivityInfo, error) {
 	m.ctrl.T.Helper()
@@ -2409,6 +2453,20 @@ func (mr *MockMutableStateMockRecorder) ReplicateWorkflowExecutionTimedoutEvent(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplicateWorkflowExecutionTimedoutEvent", reflect.TypeOf((*MockMutableState)(nil).ReplicateWorkflowExecutionTimedoutEvent), arg0, arg1)
 }
 
+// ReplicateWorkflowNoProgressTimedOutEvent mocks base method.
+func (m *MockMutableState) ReplicateWorkflowNoProgressTimedOutEvent(arg0 *types.HistoryEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplicateWorkflowNoProgressTimedOutEvent", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplicateWorkflowNoProgressTimedOutEvent indicates an expected call of ReplicateWorkflowNoProgressTimedOutEvent.
+func (mr *MockMutableStateMockRecorder) ReplicateWorkflowNoProgressTimedOutEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplicateWorkflowNoProgressTimedOutEvent", reflect.TypeOf((*MockMutableState)(nil).ReplicateWorkflowNoProgressTimedOutEvent), arg0)
+}
+
 // RetryActivity mocks base method.
 func (m *MockMutableState) RetryActivity(ai *persistence.ActivityInfo, failureReason string, failureDetails []byte) (bool, error) {
 	m.ctrl.T.Helper()
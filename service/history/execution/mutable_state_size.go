@@ -0,0 +1,109 @@
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package execution
+
+import (
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	// mutableStateSizeEstimator estimates the size of a cached mutable state. Updates only contain the modified
+	// items of mutable state, so instead of adding them up, the average size of each kind of item is learned from
+	// the items loaded or written, and the size is re-measured from the number of pending items after each update.
+	// This way overwritten and deleted items are not counted.
+	mutableStateSizeEstimator struct {
+		executionInfoSize  int
+		bufferedEventsSize int
+		activityInfos      itemSizeStats
+		timerInfos         itemSizeStats
+		childInfos         itemSizeStats
+		signalInfos        itemSizeStats
+	}
+
+	itemSizeStats struct {
+		totalSize int
+		count     int
+	}
+)
+
+// reset starts the estimation over from the stats of a fully loaded mutable state
+func (e *mutableStateSizeEstimator) reset(stats *persistence.MutableStateStats) int {
+	*e = mutableStateSizeEstimator{
+		executionInfoSize:  stats.ExecutionInfoSize,
+		bufferedEventsSize: stats.BufferedEventsSize,
+		activityInfos:      itemSizeStats{totalSize: stats.ActivityInfoSize, count: stats.ActivityInfoCount},
+		timerInfos:         itemSizeStats{totalSize: stats.TimerInfoSize, count: stats.TimerInfoCount},
+		childInfos:         itemSizeStats{totalSize: stats.ChildInfoSize, count: stats.ChildInfoCount},
+		signalInfos:        itemSizeStats{totalSize: stats.SignalInfoSize, count: stats.SignalInfoCount},
+	}
+	return stats.MutableStateSize
+}
+
+// resetFromSnapshot starts the estimation over from the stats of a newly created mutable state
+func (e *mutableStateSizeEstimator) resetFromSnapshot(stats *persistence.MutableStateUpdateSessionStats) int {
+	*e = mutableStateSizeEstimator{}
+	e.observe(stats, true)
+	return stats.MutableStateSize
+}
+
+// update learns the item sizes from the stats of an update and re-measures the size of the updated mutable state.
+// The execution info size is only taken from updates of a single workflow, as the stats of updates also creating
+// or resetting other workflows are merged.
+func (e *mutableStateSizeEstimator) update(
+	stats *persistence.MutableStateUpdateSessionStats,
+	singleWorkflow bool,
+	mutableState MutableState,
+) int {
+	e.observe(stats, singleWorkflow)
+	if !mutableState.HasBufferedEvents() {
+		e.bufferedEventsSize = 0
+	}
+	return e.executionInfoSize +
+		e.bufferedEventsSize +
+		e.activityInfos.estimate(len(mutableState.GetPendingActivityInfos())) +
+		e.timerInfos.estimate(len(mutableState.GetPendingTimerInfos())) +
+		e.childInfos.estimate(len(mutableState.GetPendingChildExecutionInfos())) +
+		e.signalInfos.estimate(len(mutableState.GetPendingSignalExternalInfos()))
+}
+
+func (e *mutableStateSizeEstimator) observe(stats *persistence.MutableStateUpdateSessionStats, includeExecutionInfo bool) {
+	if includeExecutionInfo && stats.ExecutionInfoSize > 0 {
+		e.executionInfoSize = stats.ExecutionInfoSize
+	}
+	// buffered events are only appended by updates, and cleared all together
+	e.bufferedEventsSize += stats.BufferedEventsSize
+	e.activityInfos.add(stats.ActivityInfoSize, stats.ActivityInfoCount)
+	e.timerInfos.add(stats.TimerInfoSize, stats.TimerInfoCount)
+	e.childInfos.add(stats.ChildInfoSize, stats.ChildInfoCount)
+	e.signalInfos.add(stats.SignalInfoSize, stats.SignalInfoCount)
+}
+
+func (s *itemSizeStats) add(size int, count int) {
+	s.totalSize += size
+	s.count += count
+}
+
+func (s *itemSizeStats) estimate(count int) int {
+	if s.count == 0 {
+		return 0
+	}
+	return s.totalSize * count / s.count
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package execution
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uber/cadence/common/persistence"
+)
+
+func TestMutableStateSizeEstimator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mutableState := NewMockMutableState(ctrl)
+
+	var estimator mutableStateSizeEstimator
+	size := estimator.reset(&persistence.MutableStateStats{
+		MutableStateSize:   1000,
+		ExecutionInfoSize:  300,
+		ActivityInfoSize:   400,
+		ActivityInfoCount:  4,
+		TimerInfoSize:      200,
+		TimerInfoCount:     2,
+		BufferedEventsSize: 100,
+	})
+	assert.Equal(t, 1000, size)
+
+	// the same activity is updated repeatedly, the size should not grow
+	activityUpdate := &persistence.MutableStateUpdateSessionStats{
+		MutableStateSize:  400,
+		ExecutionInfoSize: 300,
+		ActivityInfoSize:  100,
+		ActivityInfoCount: 1,
+	}
+	mutableState.EXPECT().HasBufferedEvents().Return(true).AnyTimes()
+	mutableState.EXPECT().GetPendingActivityInfos().Return(map[int64]*persistence.ActivityInfo{1: {}, 2: {}, 3: {}, 4: {}}).AnyTimes()
+	mutableState.EXPECT().GetPendingTimerInfos().Return(map[string]*persistence.TimerInfo{"1": {}, "2": {}}).AnyTimes()
+	mutableState.EXPECT().GetPendingChildExecutionInfos().Return(nil).AnyTimes()
+	mutableState.EXPECT().GetPendingSignalExternalInfos().Return(nil).AnyTimes()
+	for i := 0; i < 3; i++ {
+		size = estimator.update(activityUpdate, true, mutableState)
+		assert.Equal(t, 1000, size)
+	}
+}
+
+func TestMutableStateSizeEstimator_DeletedItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mutableState := NewMockMutableState(ctrl)
+
+	var estimator mutableStateSizeEstimator
+	estimator.reset(&persistence.MutableStateStats{
+		MutableStateSize:   1000,
+		ExecutionInfoSize:  300,
+		ActivityInfoSize:   400,
+		ActivityInfoCount:  4,
+		TimerInfoSize:      200,
+		TimerInfoCount:     2,
+		BufferedEventsSize: 100,
+	})
+
+	// two activities and the buffered events are deleted
+	mutableState.EXPECT().HasBufferedEvents().Return(false)
+	mutableState.EXPECT().GetPendingActivityInfos().Return(map[int64]*persistence.ActivityInfo{1: {}, 2: {}})
+	mutableState.EXPECT().GetPendingTimerInfos().Return(map[string]*persistence.TimerInfo{"1": {}, "2": {}})
+	mutableState.EXPECT().GetPendingChildExecutionInfos().Return(nil)
+	mutableState.EXPECT().GetPendingSignalExternalInfos().Return(nil)
+	size := estimator.update(&persistence.MutableStateUpdateSessionStats{
+		MutableStateSize:        350,
+		ExecutionInfoSize:       350,
+		DeleteActivityInfoCount: 2,
+	}, true, mutableState)
+	assert.Equal(t, 350+200+200, size)
+}
+
+func TestMutableStateSizeEstimator_ResetFromSnapshot(t *testing.T) {
+	var estimator mutableStateSizeEstimator
+	estimator.reset(&persistence.MutableStateStats{ExecutionInfoSize: 300, ActivityInfoSize: 400, ActivityInfoCount: 4})
+
+	size := estimator.resetFromSnapshot(&persistence.MutableStateUpdateSessionStats{
+		MutableStateSize:  250,
+		ExecutionInfoSize: 200,
+		TimerInfoSize:     50,
+		TimerInfoCount:    1,
+	})
+	assert.Equal(t, 250, size)
+	assert.Equal(t, mutableStateSizeEstimator{
+		executionInfoSize: 200,
+		timerInfos:        itemSizeStats{totalSize: 50, count: 1},
+	}, estimator)
+}
+
+func expectMutableStateSizeEstimation(mutableState *MockMutableState) {
+	mutableState.EXPECT().HasBufferedEvents().Return(false)
+	mutableState.EXPECT().GetPendingActivityInfos().Return(nil)
+	mutableState.EXPECT().GetPendingTimerInfos().Return(nil)
+	mutableState.EXPECT().GetPendingChildExecutionInfos().Return(nil)
+	mutableState.EXPECT().GetPendingSignalExternalInfos().Return(nil)
+}
@@ -56,6 +56,9 @@ type (
 		GenerateDelayedDecisionTasks(
 			startEvent *types.HistoryEvent,
 		) error
+		GenerateWorkflowNoProgressTimeoutTasks(
+			startVersion int64,
+		) error
 		GenerateDecisionScheduleTasks(
 			decisionScheduleID int64,
 		) error
@@ -68,6 +71,9 @@ type (
 		GenerateActivityRetryTasks(
 			activityScheduleID int64,
 		) error
+		GenerateActivityTaskListUpdateTasks(
+			activityScheduleID int64,
+		) error
 		GenerateChildWorkflowTasks(
 			event *types.HistoryEvent,
 		) error
@@ -314,6 +320,30 @@ func (r *mutableStateTaskGeneratorImpl) GenerateDelayedDecisionTasks(
 	return nil
 }
 
+func (r *mutableStateTaskGeneratorImpl) GenerateWorkflowNoProgressTimeoutTasks(
+	startVersion int64,
+) error {
+
+	executionInfo := r.mutableState.GetExecutionInfo()
+	if executionInfo.NoProgressTimeout <= 0 {
+		return nil
+	}
+
+	// only one no progress timer is outstanding at a time, it is re-armed
+	// from the last progress timestamp when it fires before the deadline
+	deadline := executionInfo.LastProgressTimestamp.Add(time.Duration(executionInfo.NoProgressTimeout) * time.Second)
+	executionInfo.NoProgressTimerTimestamp = deadline
+	r.mutableState.AddTimerTasks(&persistence.WorkflowNoProgressTimeoutTask{
+		TaskData: persistence.TaskData{
+			// TaskID is set by shard
+			VisibilityTimestamp: deadline,
+			Version:             startVersion,
+		},
+	})
+
+	return nil
+}
+
 func (r *mutableStateTaskGeneratorImpl) GenerateRecordWorkflowStartedTasks(
 	startEvent *types.HistoryEvent,
 ) error {
@@ -453,6 +483,36 @@ func (r *mutableStateTaskGeneratorImpl) GenerateActivityTransferTasks(
 	return nil
 }
 
+func (r *mutableStateTaskGeneratorImpl) GenerateActivityTaskListUpdateTasks(
+	activityScheduleID int64,
+) error {
+
+	activityInfo, ok := r.mutableState.GetActivityInfo(activityScheduleID)
+	if !ok {
+		return &types.InternalServiceError{
+			Message: fmt.Sprintf("it could be a bug, cannot get pending activity: %v", activityScheduleID),
+		}
+	}
+	if activityInfo.DomainID == "" {
+		return &types.InternalServiceError{
+			Message: fmt.Sprintf("it could be a bug, cannot update task list of activity without domain ID: %v", activityScheduleID),
+		}
+	}
+
+	// the task generated for the previous task list is skipped, since it records the previous task list
+	r.mutableState.AddTransferTasks(&persistence.ActivityTask{
+		TaskData: persistence.TaskData{
+			// TaskID and VisibilityTimestamp are set by shard context
+			Version: activityInfo.Version,
+		},
+		DomainID:   activityInfo.DomainID,
+		TaskList:   activityInfo.TaskList,
+		ScheduleID: activityInfo.ScheduleID,
+	})
+
+	return nil
+}
+
 func (r *mutableStateTaskGeneratorImpl) GenerateActivityRetryTasks(
 	activityScheduleID int64,
 ) error {
@@ -850,7 +910,7 @@ func (r *mutableStateTaskGeneratorImpl) GenerateFromCrossClusterTask(
 	var targetCluster string
 
 	sourceDomainEntry := r.mutableState.GetDomainEntry()
-	if isActive, _ := sourceDomainEntry.IsActiveIn(r.clusterMetadata.GetCurrentClusterName()); !isActive && !sourceDomainEntry.IsDomainPendingActive() {
+	if !r.isWorkflowActive(sourceDomainEntry) && !sourceDomainEntry.IsDomainPendingActive() {
 		// domain is passive, generate (passive) transfer task
 		generateTransferTask = true
 	}
@@ -1014,8 +1074,8 @@ func (r *mutableStateTaskGeneratorImpl) isCrossClusterTask(
 		return "", false, err
 	}
 
-	// case 2: source domain is not active in the current cluster
-	if isActive, _ := sourceDomainEntry.IsActiveIn(r.clusterMetadata.GetCurrentClusterName()); !isActive {
+	// case 2: source workflow is not active in the current cluster
+	if !r.isWorkflowActive(sourceDomainEntry) {
 		return "", false, nil
 	}
 
@@ -1034,6 +1094,18 @@ func (r *mutableStateTaskGeneratorImpl) isCrossClusterTask(
 	return targetCluster, true, nil
 }
 
+// isWorkflowActive returns whether the workflow is active in the current cluster,
+// for active-active domains it depends on the region of the workflow
+func (r *mutableStateTaskGeneratorImpl) isWorkflowActive(
+	domainEntry *cache.DomainCacheEntry,
+) bool {
+	if domainEntry.IsActiveActive() {
+		return IsWorkflowActiveIn(domainEntry, r.mutableState.GetExecutionInfo(), r.clusterMetadata.GetCurrentClusterName())
+	}
+	isActive, _ := domainEntry.IsActiveIn(r.clusterMetadata.GetCurrentClusterName())
+	return isActive
+}
+
 func getTargetCluster(
 	domainID string,
 	domainCache cache.DomainCache,
@@ -73,6 +73,20 @@ func (mr *MockMutableStateTaskGeneratorMockRecorder) GenerateActivityRetryTasks(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateActivityRetryTasks", reflect.TypeOf((*MockMutableStateTaskGenerator)(nil).GenerateActivityRetryTasks), activityScheduleID)
 }
 
+// GenerateActivityTaskListUpdateTasks mocks base method.
+func (m *MockMutableStateTaskGenerator) GenerateActivityTaskListUpdateTasks(activityScheduleID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateActivityTaskListUpdateTasks", activityScheduleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GenerateActivityTaskListUpdateTasks indicates an expected call of GenerateActivityTaskListUpdateTasks.
+func (mr *MockMutableStateTaskGeneratorMockRecorder) GenerateActivityTaskListUpdateTasks(activityScheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateActivityTaskListUpdateTasks", reflect.TypeOf((*MockMutableStateTaskGenerator)(nil).GenerateActivityTaskListUpdateTasks), activityScheduleID)
+}
+
 // GenerateActivityTimerTasks mocks base method.
 func (m *MockMutableStateTaskGenerator) GenerateActivityTimerTasks() error {
 	m.ctrl.T.Helper()
@@ -283,6 +297,20 @@ func (mr *MockMutableStateTaskGeneratorMockRecorder) GenerateWorkflowCloseTasks(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateWorkflowCloseTasks", reflect.TypeOf((*MockMutableStateTaskGenerator)(nil).GenerateWorkflowCloseTasks), closeEvent, workflowDeletionTaskJitterRange)
 }
 
+// GenerateWorkflowNoProgressTimeoutTasks mocks base method.
+func (m *MockMutableStateTaskGenerator) GenerateWorkflowNoProgressTimeoutTasks(startVersion int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateWorkflowNoProgressTimeoutTasks", startVersion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GenerateWorkflowNoProgressTimeoutTasks indicates an expected call of GenerateWorkflowNoProgressTimeoutTasks.
+func (mr *MockMutableStateTaskGeneratorMockRecorder) GenerateWorkflowNoProgressTimeoutTasks(startVersion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateWorkflowNoProgressTimeoutTasks", reflect.TypeOf((*MockMutableStateTaskGenerator)(nil).GenerateWorkflowNoProgressTimeoutTasks), startVersion)
+}
+
 // GenerateWorkflowResetTasks mocks base method.
 func (m *MockMutableStateTaskGenerator) GenerateWorkflowResetTasks() error {
 	m.ctrl.T.Helper()
@@ -646,6 +646,30 @@ func (s *mutableStateTaskGeneratorSuite) TestGenerateFromCrossClusterTask() {
 	}
 }
 
+func (s *mutableStateTaskGeneratorSuite) TestGenerateWorkflowNoProgressTimeoutTasks() {
+	now := time.Now()
+	version := int64(123)
+	executionInfo := &persistence.WorkflowExecutionInfo{
+		NoProgressTimeout:     3600,
+		LastProgressTimestamp: now,
+	}
+
+	s.mockMutableState.EXPECT().GetExecutionInfo().Return(executionInfo).Times(1)
+	s.mockMutableState.EXPECT().AddTimerTasks(&persistence.WorkflowNoProgressTimeoutTask{
+		TaskData: persistence.TaskData{
+			VisibilityTimestamp: now.Add(time.Hour),
+			Version:             version,
+		},
+	}).Times(1)
+
+	s.NoError(s.taskGenerator.GenerateWorkflowNoProgressTimeoutTasks(version))
+	s.Equal(now.Add(time.Hour), executionInfo.NoProgressTimerTimestamp)
+
+	// no task is generated when the timeout is not set
+	s.mockMutableState.EXPECT().GetExecutionInfo().Return(&persistence.WorkflowExecutionInfo{}).Times(1)
+	s.NoError(s.taskGenerator.GenerateWorkflowNoProgressTimeoutTasks(version))
+}
+
 func (s *mutableStateTaskGeneratorSuite) TestGetNextDecisionTimeout() {
 	defaultStartToCloseTimeout := 10 * time.Second
 	expectedResult := []time.Duration{
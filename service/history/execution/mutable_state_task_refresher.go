@@ -107,6 +107,13 @@ func (r *mutableStateTaskRefresherImpl) RefreshTasks(
 		return err
 	}
 
+	if err := r.refreshTasksForWorkflowNoProgressTimeout(
+		mutableState,
+		taskGenerator,
+	); err != nil {
+		return err
+	}
+
 	if err := r.refreshTasksForDecision(
 		ctx,
 		mutableState,
@@ -242,6 +249,23 @@ func (r *mutableStateTaskRefresherImpl) refreshTasksForRecordWorkflowStarted(
 	return nil
 }
 
+func (r *mutableStateTaskRefresherImpl) refreshTasksForWorkflowNoProgressTimeout(
+	mutableState MutableState,
+	taskGenerator MutableStateTaskGenerator,
+) error {
+
+	executionInfo := mutableState.GetExecutionInfo()
+	if executionInfo.CloseStatus != persistence.WorkflowCloseStatusNone || executionInfo.NoProgressTimeout <= 0 {
+		return nil
+	}
+
+	startVersion, err := mutableState.GetStartVersion()
+	if err != nil {
+		return err
+	}
+	return taskGenerator.GenerateWorkflowNoProgressTimeoutTasks(startVersion)
+}
+
 func (r *mutableStateTaskRefresherImpl) refreshTasksForDecision(
 	ctx context.Context,
 	mutableState MutableState,
@@ -28,6 +28,8 @@ import (
 
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/partition"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/types"
 )
@@ -382,6 +384,10 @@ func CopyWorkflowExecutionInfo(sourceInfo *persistence.WorkflowExecutionInfo) *p
 		Memo:                               sourceInfo.Memo,
 		SearchAttributes:                   sourceInfo.SearchAttributes,
 		PartitionConfig:                    sourceInfo.PartitionConfig,
+		NoProgressTimeout:                  sourceInfo.NoProgressTimeout,
+		NoProgressTimeoutPolicy:            sourceInfo.NoProgressTimeoutPolicy,
+		LastProgressTimestamp:              sourceInfo.LastProgressTimestamp,
+		NoProgressTimerTimestamp:           sourceInfo.NoProgressTimerTimestamp,
 		Attempt:                            sourceInfo.Attempt,
 		HasRetryPolicy:                     sourceInfo.HasRetryPolicy,
 		InitialInterval:                    sourceInfo.InitialInterval,
@@ -568,6 +574,56 @@ func GetChildExecutionDomainEntry(
 	return parentDomainEntry, nil
 }
 
+// GetActiveClusterForWorkflow returns the cluster the workflow is active in, which for active-active domains
+// is the active cluster of the region recorded in the partition config of the workflow
+func GetActiveClusterForWorkflow(
+	domainEntry *cache.DomainCacheEntry,
+	executionInfo *persistence.WorkflowExecutionInfo,
+) string {
+	return domainEntry.GetActiveClusterForWorkflow(
+		executionInfo.WorkflowID,
+		executionInfo.PartitionConfig[partition.ActiveRegionKey],
+	)
+}
+
+// IsWorkflowActiveIn returns whether the workflow is active in the cluster, which for active-active domains
+// is whether the cluster is the active cluster of the region of the workflow
+func IsWorkflowActiveIn(
+	domainEntry *cache.DomainCacheEntry,
+	executionInfo *persistence.WorkflowExecutionInfo,
+	clusterName string,
+) bool {
+	if domainEntry.IsActiveActive() {
+		return GetActiveClusterForWorkflow(domainEntry, executionInfo) == clusterName
+	}
+	isActive, _ := domainEntry.IsActiveIn(clusterName)
+	return isActive
+}
+
+// GetFailoverVersionForWorkflow returns the failover version of the domain for the workflow, which for active-active
+// domains is the failover version of the active cluster of the workflow
+func GetFailoverVersionForWorkflow(
+	domainEntry *cache.DomainCacheEntry,
+	clusterMetadata cluster.Metadata,
+	executionInfo *persistence.WorkflowExecutionInfo,
+) int64 {
+	if !domainEntry.IsActiveActive() {
+		return domainEntry.GetFailoverVersion()
+	}
+	return domainEntry.GetFailoverVersionForCluster(
+		clusterMetadata,
+		GetActiveClusterForWorkflow(domainEntry, executionInfo),
+	)
+}
+
+// IsLocalOnlyWorkflow returns whether the workflow is local-only, i.e. its replication tasks are not sent to
+// the other clusters of the global domain. It is decided when the workflow starts and recorded in its partition config
+func IsLocalOnlyWorkflow(
+	executionInfo *persistence.WorkflowExecutionInfo,
+) bool {
+	return executionInfo.PartitionConfig[partition.LocalOnlyKey] == "true"
+}
+
 func trimBinaryChecksums(recentBinaryChecksums []string, currResetPoints []*types.ResetPointInfo, maxResetPoints int) ([]string, []*types.ResetPointInfo) {
 	numResetPoints := len(currResetPoints)
 	if numResetPoints >= maxResetPoints {
@@ -27,7 +27,10 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/partition"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/testing/testdatagen/idlfuzzedtestdata"
 	"github.com/uber/cadence/common/types"
@@ -316,3 +319,30 @@ func TestConvertWorkflowRequests(t *testing.T) {
 
 	assert.ElementsMatch(t, expectedOutputs, convertWorkflowRequests(inputs))
 }
+
+func TestIsWorkflowActiveIn(t *testing.T) {
+	activeActiveDomain := cache.NewGlobalDomainCacheEntryForTest(
+		&persistence.DomainInfo{Data: map[string]string{
+			common.DomainDataKeyPrefixForActiveCluster + "region0": cluster.TestCurrentClusterName,
+			common.DomainDataKeyPrefixForActiveCluster + "region1": cluster.TestAlternativeClusterName,
+		}},
+		&persistence.DomainConfig{},
+		&persistence.DomainReplicationConfig{ActiveClusterName: cluster.TestCurrentClusterName},
+		1,
+	)
+	globalDomain := cache.NewGlobalDomainCacheEntryForTest(
+		&persistence.DomainInfo{},
+		&persistence.DomainConfig{},
+		&persistence.DomainReplicationConfig{ActiveClusterName: cluster.TestCurrentClusterName},
+		1,
+	)
+	executionInfo := &persistence.WorkflowExecutionInfo{
+		WorkflowID:      "wid",
+		PartitionConfig: map[string]string{partition.ActiveRegionKey: "region1"},
+	}
+
+	assert.True(t, IsWorkflowActiveIn(activeActiveDomain, executionInfo, cluster.TestAlternativeClusterName))
+	assert.False(t, IsWorkflowActiveIn(activeActiveDomain, executionInfo, cluster.TestCurrentClusterName))
+	assert.True(t, IsWorkflowActiveIn(globalDomain, executionInfo, cluster.TestCurrentClusterName))
+	assert.False(t, IsWorkflowActiveIn(globalDomain, executionInfo, cluster.TestAlternativeClusterName))
+}
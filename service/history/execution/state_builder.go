@@ -268,9 +268,23 @@ func (b *stateBuilderImpl) ApplyEvents(
 				return nil, err
 			}
 
+		case types.EventTypeActivityTaskOptionsUpdated:
+			if err := b.mutableState.ReplicateActivityTaskOptionsUpdatedEvent(
+				event,
+			); err != nil {
+				return nil, err
+			}
+
 		case types.EventTypeRequestCancelActivityTaskFailed:
 			// No mutable state action is needed
 
+		case types.EventTypeWorkflowNoProgressTimedOut:
+			if err := b.mutableState.ReplicateWorkflowNoProgressTimedOutEvent(
+				event,
+			); err != nil {
+				return nil, err
+			}
+
 		case types.EventTypeTimerStarted:
 			if _, err := b.mutableState.ReplicateTimerStartedEvent(
 				event,
@@ -1193,6 +1193,71 @@ func (s *stateBuilderSuite) TestApplyEvents_EventTypeActivityTaskCanceled() {
 	s.Nil(err)
 }
 
+func (s *stateBuilderSuite) TestApplyEvents_EventTypeActivityTaskOptionsUpdated() {
+	version := int64(1)
+	requestID := uuid.New()
+
+	workflowExecution := types.WorkflowExecution{
+		WorkflowID: "some random workflow ID",
+		RunID:      constants.TestRunID,
+	}
+
+	now := time.Now()
+	evenType := types.EventTypeActivityTaskOptionsUpdated
+	event := &types.HistoryEvent{
+		Version:   version,
+		ID:        130,
+		Timestamp: common.Int64Ptr(now.UnixNano()),
+		EventType: &evenType,
+		ActivityTaskOptionsUpdatedEventAttributes: &types.ActivityTaskOptionsUpdatedEventAttributes{
+			ScheduledEventID:        100,
+			HeartbeatTimeoutSeconds: common.Int32Ptr(10),
+		},
+	}
+
+	s.mockMutableState.EXPECT().ReplicateActivityTaskOptionsUpdatedEvent(event).Return(nil).Times(1)
+	s.mockUpdateVersion(event)
+	s.mockMutableState.EXPECT().GetExecutionInfo().Return(&persistence.WorkflowExecutionInfo{}).AnyTimes()
+	// assertion on timer generated is in `mockUpdateVersion` function, since activity / user timer
+	// need to be refreshed each time
+	s.mockMutableState.EXPECT().ClearStickyness().Times(1)
+
+	_, err := s.stateBuilder.ApplyEvents(constants.TestDomainID, requestID, workflowExecution, s.toHistory(event), nil)
+	s.Nil(err)
+}
+
+func (s *stateBuilderSuite) TestApplyEvents_EventTypeWorkflowNoProgressTimedOut() {
+	version := int64(1)
+	requestID := uuid.New()
+
+	workflowExecution := types.WorkflowExecution{
+		WorkflowID: "some random workflow ID",
+		RunID:      constants.TestRunID,
+	}
+
+	now := time.Now()
+	evenType := types.EventTypeWorkflowNoProgressTimedOut
+	event := &types.HistoryEvent{
+		Version:   version,
+		ID:        130,
+		Timestamp: common.Int64Ptr(now.UnixNano()),
+		EventType: &evenType,
+		WorkflowNoProgressTimedOutEventAttributes: &types.WorkflowNoProgressTimedOutEventAttributes{
+			NoProgressTimeoutSeconds: 60,
+			NoProgressTimeoutPolicy:  types.NoProgressTimeoutPolicyNotify.Ptr(),
+			LastProgressTimestamp:    common.Int64Ptr(now.Add(-time.Minute).UnixNano()),
+		},
+	}
+
+	s.mockMutableState.EXPECT().ReplicateWorkflowNoProgressTimedOutEvent(event).Return(nil).Times(1)
+	s.mockUpdateVersion(event)
+	s.mockMutableState.EXPECT().GetExecutionInfo().Return(&persistence.WorkflowExecutionInfo{}).AnyTimes()
+	s.mockMutableState.EXPECT().ClearStickyness().Times(1)
+
+	_, err := s.stateBuilder.ApplyEvents(constants.TestDomainID, requestID, workflowExecution, s.toHistory(event), nil)
+	s.Nil(err)
+}
+
 // child workflow operations
 
 func (s *stateBuilderSuite) TestApplyEvents_EventTypeStartChildWorkflowExecutionInitiated() {
@@ -1648,7 +1713,7 @@ func (s *stateBuilderSuite) TestApplyEvents_EventTypeExternalWorkflowExecutionSi
 
 func (s *stateBuilderSuite) TestApplyEventsNewEventsNotHandled() {
 	eventTypes := types.EventTypeValues()
-	s.Equal(42, len(eventTypes), "If you see this error, you are adding new event type. "+
+	s.Equal(44, len(eventTypes), "If you see this error, you are adding new event type. "+
 		"Before updating the number to make this test pass, please make sure you update stateBuilderImpl.ApplyEvents method "+
 		"to handle the new decision type. Otherwise cross dc will not work on the new event.")
 }
@@ -200,7 +200,7 @@ func (c *coordinatorImpl) GetFailoverInfo(
 	}
 
 	var pendingShards []int32
-	for i := 0; i < c.config.NumberOfShards; i++ {
+	for i := 0; i < c.config.GetTotalShards(); i++ {
 		if _, ok := record.shards[int32(i)]; !ok {
 			pendingShards = append(pendingShards, int32(i))
 		}
@@ -305,7 +305,7 @@ func (c *coordinatorImpl) handleFailoverMarkers(
 		return
 	}
 
-	if len(record.shards) == c.config.NumberOfShards {
+	if len(record.shards) == c.config.GetTotalShards() {
 		if err := domain.CleanPendingActiveState(
 			c.domainManager,
 			domainID,
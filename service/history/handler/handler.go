@@ -24,7 +24,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -34,6 +33,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/definition"
 	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/future"
@@ -77,6 +77,7 @@ type (
 		rateLimiter                    quotas.Limiter
 		crossClusterTaskFetchers       task.Fetchers
 		replicationTaskFetchers        replication.TaskFetchers
+		replicationTaskPublishers      replication.TaskPublishers
 		queueTaskProcessor             task.Processor
 		failoverCoordinator            failover.Coordinator
 		workflowIDCache                workflowcache.WFCache
@@ -130,10 +131,25 @@ func (h *handlerImpl) Start() {
 		h.config,
 		h.GetClusterMetadata(),
 		h.GetClientBean(),
+		h.GetMessagingClient(),
+		h.GetMetricsClient(),
+		h.GetHostName(),
 	)
 
 	h.replicationTaskFetchers.Start()
 
+	h.replicationTaskPublishers = replication.NewTaskPublishers(
+		h.GetClusterMetadata(),
+		h.GetMessagingClient(),
+		h.config,
+		h.GetMetricsClient(),
+		h.GetHostName(),
+		h.GetLogger(),
+	)
+	if h.replicationTaskPublishers != nil {
+		h.replicationTaskPublishers.Start()
+	}
+
 	var err error
 	taskPriorityAssigner := task.NewPriorityAssigner(
 		h.GetClusterMetadata().GetCurrentClusterName(),
@@ -146,6 +162,7 @@ func (h *handlerImpl) Start() {
 	h.queueTaskProcessor, err = task.NewProcessor(
 		taskPriorityAssigner,
 		h.config,
+		h.GetDomainCache(),
 		h.GetLogger(),
 		h.GetMetricsClient(),
 	)
@@ -186,6 +203,9 @@ func (h *handlerImpl) Stop() {
 	h.prepareToShutDown()
 	h.crossClusterTaskFetchers.Stop()
 	h.replicationTaskFetchers.Stop()
+	if h.replicationTaskPublishers != nil {
+		h.replicationTaskPublishers.Stop()
+	}
 	h.queueTaskProcessor.Stop()
 	h.controller.Stop()
 	h.historyEventNotifier.Stop()
@@ -224,6 +244,7 @@ func (h *handlerImpl) CreateEngine(
 		h.config,
 		h.crossClusterTaskFetchers,
 		h.replicationTaskFetchers,
+		h.replicationTaskPublishers,
 		h.GetMatchingRawClient(),
 		h.queueTaskProcessor,
 		h.failoverCoordinator,
@@ -274,7 +295,7 @@ func (h *handlerImpl) RecordActivityTaskHeartbeat(
 	}
 	workflowID := token.WorkflowID
 
-	engine, err1 := h.controller.GetEngine(workflowID)
+	engine, err1 := h.getEngine(domainID, workflowID)
 	if err1 != nil {
 		return nil, h.error(err1, scope, domainID, workflowID, "")
 	}
@@ -287,6 +308,47 @@ func (h *handlerImpl) RecordActivityTaskHeartbeat(
 	return response, nil
 }
 
+// UpdateActivityOptions - updates the timeouts, retry policy and task list of a pending activity.
+func (h *handlerImpl) UpdateActivityOptions(
+	ctx context.Context,
+	wrappedRequest *types.HistoryUpdateActivityOptionsRequest,
+) (retError error) {
+
+	defer func() { log.CapturePanic(recover(), h.GetLogger(), &retError) }()
+	h.startWG.Wait()
+
+	scope, sw := h.startRequestProfile(ctx, metrics.HistoryUpdateActivityOptionsScope)
+	defer sw.Stop()
+
+	if h.isShuttingDown() {
+		return constants.ErrShuttingDown
+	}
+
+	domainID := wrappedRequest.GetDomainUUID()
+	if domainID == "" {
+		return h.error(constants.ErrDomainNotSet, scope, domainID, "", "")
+	}
+
+	if ok := h.rateLimiter.Allow(); !ok {
+		return h.error(constants.ErrHistoryHostThrottle, scope, domainID, "", "")
+	}
+
+	workflowExecution := wrappedRequest.GetUpdateRequest().GetWorkflowExecution()
+	workflowID := workflowExecution.GetWorkflowID()
+	runID := workflowExecution.GetRunID()
+	engine, err1 := h.getEngine(domainID, workflowID)
+	if err1 != nil {
+		return h.error(err1, scope, domainID, workflowID, runID)
+	}
+
+	err2 := engine.UpdateActivityOptions(ctx, wrappedRequest)
+	if err2 != nil {
+		return h.error(err2, scope, domainID, workflowID, runID)
+	}
+
+	return nil
+}
+
 // RecordActivityTaskStarted - Record Activity Task started.
 func (h *handlerImpl) RecordActivityTaskStarted(
 	ctx context.Context,
@@ -320,7 +382,7 @@ func (h *handlerImpl) RecordActivityTaskStarted(
 		return nil, h.error(constants.ErrHistoryHostThrottle, scope, domainID, workflowID, "")
 	}
 
-	engine, err1 := h.controller.GetEngine(workflowID)
+	engine, err1 := h.getEngine(domainID, workflowID)
 	if err1 != nil {
 		return nil, h.error(err1, scope, domainID, workflowID, "")
 	}
@@ -371,7 +433,7 @@ func (h *handlerImpl) RecordDecisionTaskStarted(
 		return nil, h.error(constants.ErrTaskListNotSet, scope, domainID, workflowID, runID)
 	}
 
-	engine, err1 := h.controller.GetEngine(workflowID)
+	engine, err1 := h.getEngine(domainID, workflowID)
 	if err1 != nil {
 		h.GetLogger().Error("RecordDecisionTaskStarted failed.",
 			tag.Error(err1),
@@ -426,7 +488,7 @@ func (h *handlerImpl) RespondActivityTaskCompleted(
 	workflowID := token.WorkflowID
 	runID := token.RunID
 
-	engine, err1 := h.controller.GetEngine(workflowID)
+	engine, err1 := h.getEngine(domainID, workflowID)
 	if err1 != nil {
 		return h.error(err1, scope, domainID, workflowID, runID)
 	}
@@ -474,7 +536,7 @@ func (h *handlerImpl) RespondActivityTaskFailed(
 	workflowID := token.WorkflowID
 	runID := token.RunID
 
-	engine, err1 := h.controller.GetEngine(workflowID)
+	engine, err1 := h.getEngine(domainID, workflowID)
 	if err1 != nil {
 		return h.error(err1, scope, domainID, workflowID, runID)
 	}
@@ -522,7 +584,7 @@ func (h *handlerImpl) RespondActivityTaskCanceled(
 	workflowID := token.WorkflowID
 	runID := token.RunID
 
-	engine, err1 := h.controller.GetEngine(workflowID)
+	engine, err1 := h.getEngine(domainID, workflowID)
 	if err1 != nil {
 		return h.error(err1, scope, domainID, workflowID, runID)
 	}
@@ -579,7 +641,7 @@ func (h *handlerImpl) RespondDecisionTaskCompleted(
 	workflowID := token.WorkflowID
 	runID := token.RunID
 
-	engine, err1 := h.controller.GetEngine(workflowID)
+	engine, err1 := h.getEngine(domainID, workflowID)
 	if err1 != nil {
 		return nil, h.error(err1, scope, domainID, workflowID, runID)
 	}
@@ -646,7 +708,7 @@ func (h *handlerImpl) RespondDecisionTaskFailed(
 	workflowID := token.WorkflowID
 	runID := token.RunID
 
-	engine, err1 := h.controller.GetEngine(workflowID)
+	engine, err1 := h.getEngine(domainID, workflowID)
 	if err1 != nil {
 		return h.error(err1, scope, domainID, workflowID, runID)
 	}
@@ -683,7 +745,8 @@ func (h *handlerImpl) StartWorkflowExecution(
 	startRequest := wrappedRequest.StartRequest
 	workflowID := startRequest.GetWorkflowID()
 
-	engine, err1 := h.controller.GetEngine(workflowID)
+	// child workflows are started by the transfer queue of their parent, they are not shed
+	engine, err1 := h.getEngineOrShed(domainID, workflowID, wrappedRequest.ParentExecutionInfo == nil)
 	if err1 != nil {
 		return nil, h.error(err1, scope, domainID, workflowID, "")
 	}
@@ -727,9 +790,41 @@ func (h *handlerImpl) DescribeHistoryHost(
 		ShardControllerStatus: status,
 		Address:               h.GetHostInfo().GetAddress(),
 	}
+	resp.ExecutionCache, resp.EventsCache = h.describeCaches()
+	resp.Load = h.GetLoadTracker().Describe()
 	return resp, nil
 }
 
+// describeCaches aggregates the stats of execution and events caches across all shards owned by the host
+func (h *handlerImpl) describeCaches() (*types.CacheInfo, *types.CacheInfo) {
+	executionCacheInfo := &types.CacheInfo{}
+	eventsCacheInfo := &types.CacheInfo{}
+	globalEventsCache := h.config.EventsCacheGlobalEnable()
+	for _, shardID := range h.controller.ShardIDs() {
+		engine, err := h.controller.GetEngineForShard(int(shardID))
+		if err != nil {
+			// shard may be closed after getting the shard IDs
+			continue
+		}
+		addCacheStats(executionCacheInfo, engine.GetExecutionCacheStats())
+		if !globalEventsCache {
+			addCacheStats(eventsCacheInfo, engine.GetEventsCacheStats())
+		}
+	}
+	if globalEventsCache {
+		addCacheStats(eventsCacheInfo, h.GetEventCache().Stats())
+	}
+	return executionCacheInfo, eventsCacheInfo
+}
+
+func addCacheStats(info *types.CacheInfo, stats cache.Stats) {
+	info.NumOfItems += int64(stats.Count)
+	info.NumOfPinnedItems += int64(stats.PinnedCount)
+	info.SizeInBytes += int64(stats.SizeInBytes)
+	info.NumOfHits += stats.Hits
+	info.NumOfMisses += stats.Misses
+}
+
 // RemoveTask returns information about the internal states of a history host
 func (h *handlerImpl) RemoveTask(
 	ctx context.Context,
@@ -841,6 +936,101 @@ func (h *handlerImpl) DescribeQueue(
 	return resp, nil
 }
 
+// DescribeReplicationStatus returns the replication lag of each domain, aggregated across all shards owned by the host
+func (h *handlerImpl) DescribeReplicationStatus(
+	ctx context.Context,
+	request *types.DescribeReplicationStatusRequest,
+) (resp *types.DescribeReplicationStatusResponse, retError error) {
+
+	defer func() { log.CapturePanic(recover(), h.GetLogger(), &retError) }()
+	h.startWG.Wait()
+
+	scope, sw := h.startRequestProfile(ctx, metrics.HistoryDescribeReplicationStatusScope)
+	defer sw.Stop()
+
+	var shardResponses []*types.DescribeReplicationStatusResponse
+	for _, shardID := range h.controller.ShardIDs() {
+		engine, err := h.controller.GetEngineForShard(int(shardID))
+		if err != nil {
+			// shard may be closed after getting the shard IDs
+			continue
+		}
+		shardResp, err := engine.DescribeReplicationStatus(ctx, request)
+		if err != nil {
+			return nil, h.error(err, scope, request.GetDomainUUID(), "", "")
+		}
+		shardResponses = append(shardResponses, shardResp)
+	}
+	resp = types.MergeDescribeReplicationStatusResponses(shardResponses)
+	return resp, nil
+}
+
+// ListQueueTasks lists the outstanding tasks loaded by a queue processor
+func (h *handlerImpl) ListQueueTasks(
+	ctx context.Context,
+	request *types.ListQueueTasksRequest,
+) (resp *types.ListQueueTasksResponse, retError error) {
+
+	defer func() { log.CapturePanic(recover(), h.GetLogger(), &retError) }()
+	h.startWG.Wait()
+
+	scope, sw := h.startRequestProfile(ctx, metrics.HistoryListQueueTasksScope)
+	defer sw.Stop()
+
+	engine, err := h.controller.GetEngineForShard(int(request.GetShardID()))
+	if err != nil {
+		return nil, h.error(err, scope, "", "", "")
+	}
+
+	resp, err = engine.ListQueueTasks(ctx, request)
+	if err != nil {
+		return nil, h.error(err, scope, "", "", "")
+	}
+	return resp, nil
+}
+
+// ExecuteQueueTask immediately executes an outstanding task that is waiting to be redispatched
+func (h *handlerImpl) ExecuteQueueTask(
+	ctx context.Context,
+	request *types.ExecuteQueueTaskRequest,
+) (retError error) {
+
+	defer func() { log.CapturePanic(recover(), h.GetLogger(), &retError) }()
+	h.startWG.Wait()
+
+	scope, sw := h.startRequestProfile(ctx, metrics.HistoryExecuteQueueTaskScope)
+	defer sw.Stop()
+
+	engine, err := h.controller.GetEngineForShard(int(request.GetShardID()))
+	if err != nil {
+		return h.error(err, scope, "", "", "")
+	}
+
+	if err := engine.ExecuteQueueTask(ctx, request); err != nil {
+		return h.error(err, scope, "", "", "")
+	}
+	return nil
+}
+
+// MigrateShardExecutions copies or deletes the executions moved by a shard split
+func (h *handlerImpl) MigrateShardExecutions(
+	ctx context.Context,
+	request *types.MigrateShardExecutionsRequest,
+) (resp *types.MigrateShardExecutionsResponse, retError error) {
+
+	defer func() { log.CapturePanic(recover(), h.GetLogger(), &retError) }()
+	h.startWG.Wait()
+
+	scope, sw := h.startRequestProfile(ctx, metrics.HistoryMigrateShardExecutionsScope)
+	defer sw.Stop()
+
+	resp, err := h.controller.MigrateShardExecutions(ctx, request)
+	if err != nil {
+		return nil, h.error(err, scope, "", "", "")
+	}
+	return resp, nil
+}
+
 // DescribeMutableState - returns the internal analysis of workflow execution state
 func (h *handlerImpl) DescribeMutableState(
 	ctx context.Context,
@@ -861,7 +1051,7 @@ func (h *handlerImpl) DescribeMutableState(
 	workflowExecution := request.Execution
 	workflowID := workflowExecution.GetWorkflowID()
 	runID := workflowExecution.GetRunID()
-	engine, err1 := h.controller.GetEngine(workflowID)
+	engine, err1 := h.getEngine(domainID, workflowID)
 	if err1 != nil {
 		return nil, h.error(err1, scope, domainID, workflowID, runID)
 	}
@@ -897,7 +1087,7 @@ func (h *handlerImpl) GetMutableState(
 	workflowExecution := getRequest.Execution
 	workflowID := workflowExecution.GetWorkflowID()
 	runID := workflowExecution.GetWorkflowID()
-	engine, err1 := h.controller.GetEngine(workflowID)
+	engine, err1 := h.getEngine(domainID, workflowID)
 	if err1 != nil {
 		return nil, h.error(err1, scope, domainID, workflowID, runID)
 	}
@@ -933,7 +1123,7 @@ func (h *handlerImpl) PollMutableState(
 	workflowExecution := getRequest.Execution
 	workflowID := workflowExecution.GetWorkflowID()
 	runID := workflowExecution.GetRunID()
-	engine, err1 := h.controller.GetEngine(workflowID)
+	engine, err1 := h.getEngine(domainID, workflowID)
 	if err1 != nil {
 		return nil, h.error(err1, scope, domainID, workflowID, runID)
 	}
@@ -969,7 +1159,7 @@ func (h *handlerImpl) DescribeWorkflowExecution(
 	workflowExecution := request.Request.Execution
 	workflowID := workflowExecution.GetWorkflowID()
 	runID := workflowExecution.GetRunID()
-	engine, err1 := h.controller.GetEngine(workflowID)
+	engine, err1 := h.getEngine(domainID, workflowID)
 	if err1 != nil {
 		return nil, h.error(err1, scope, domainID, workflowID, runID)
 	}
@@ -1015,7 +1205,7 @@ func (h *handlerImpl) RequestCancelWorkflowExecution(
 
 	workflowID := cancelRequest.WorkflowExecution.GetWorkflowID()
 	runID := cancelRequest.WorkflowExecution.GetRunID()
-	engine, err1 := h.controller.GetEngine(workflowID)
+	engine, err1 := h.getEngine(domainID, workflowID)
 	if err1 != nil {
 		return h.error(err1, scope, domainID, workflowID, runID)
 	}
@@ -1057,7 +1247,8 @@ func (h *handlerImpl) SignalWorkflowExecution(
 	workflowExecution := wrappedRequest.SignalRequest.WorkflowExecution
 	workflowID := workflowExecution.GetWorkflowID()
 	runID := workflowExecution.GetRunID()
-	engine, err1 := h.controller.GetEngine(workflowID)
+	// signals sent by other workflows come from their transfer queue, they are not shed
+	engine, err1 := h.getEngineOrShed(domainID, workflowID, wrappedRequest.ExternalWorkflowExecution == nil)
 	if err1 != nil {
 		return h.error(err1, scope, domainID, workflowID, runID)
 	}
@@ -1101,7 +1292,7 @@ func (h *handlerImpl) SignalWithStartWorkflowExecution(
 
 	signalWithStartRequest := wrappedRequest.SignalWithStartRequest
 	workflowID := signalWithStartRequest.GetWorkflowID()
-	engine, err1 := h.controller.GetEngine(workflowID)
+	engine, err1 := h.getEngineOrShed(domainID, workflowID, true)
 	if err1 != nil {
 		return nil, h.error(err1, scope, domainID, workflowID, "")
 	}
@@ -1159,7 +1350,7 @@ func (h *handlerImpl) RemoveSignalMutableState(
 	workflowExecution := wrappedRequest.WorkflowExecution
 	workflowID := workflowExecution.GetWorkflowID()
 	runID := workflowExecution.GetRunID()
-	engine, err1 := h.controller.GetEngine(workflowID)
+	engine, err1 := h.getEngine(domainID, workflowID)
 	if err1 != nil {
 		return h.error(err1, scope, domainID, workflowID, runID)
 	}
@@ -1201,7 +1392,7 @@ func (h *handlerImpl) TerminateWorkflowExecution(
 	workflowExecution := wrappedRequest.TerminateRequest.WorkflowExecution
 	workflowID := workflowExecution.GetWorkflowID()
 	runID := workflowExecution.GetRunID()
-	engine, err1 := h.controller.GetEngine(workflowID)
+	engine, err1 := h.getEngine(domainID, workflowID)
 	if err1 != nil {
 		return h.error(err1, scope, domainID, workflowID, runID)
 	}
@@ -1214,6 +1405,47 @@ func (h *handlerImpl) TerminateWorkflowExecution(
 	return nil
 }
 
+// DeleteWorkflowExecution deletes a workflow execution without waiting for the domain retention period
+func (h *handlerImpl) DeleteWorkflowExecution(
+	ctx context.Context,
+	wrappedRequest *types.HistoryDeleteWorkflowExecutionRequest,
+) (resp *types.DeleteWorkflowExecutionResponse, retError error) {
+
+	defer func() { log.CapturePanic(recover(), h.GetLogger(), &retError) }()
+	h.startWG.Wait()
+
+	scope, sw := h.startRequestProfile(ctx, metrics.HistoryDeleteWorkflowExecutionScope)
+	defer sw.Stop()
+
+	if h.isShuttingDown() {
+		return nil, constants.ErrShuttingDown
+	}
+
+	domainID := wrappedRequest.GetDomainUUID()
+	if domainID == "" {
+		return nil, h.error(constants.ErrDomainNotSet, scope, domainID, "", "")
+	}
+
+	if ok := h.rateLimiter.Allow(); !ok {
+		return nil, h.error(constants.ErrHistoryHostThrottle, scope, domainID, "", "")
+	}
+
+	workflowExecution := wrappedRequest.GetDeleteRequest().GetWorkflowExecution()
+	workflowID := workflowExecution.GetWorkflowID()
+	runID := workflowExecution.GetRunID()
+	engine, err1 := h.getEngine(domainID, workflowID)
+	if err1 != nil {
+		return nil, h.error(err1, scope, domainID, workflowID, runID)
+	}
+
+	resp, err2 := engine.DeleteWorkflowExecution(ctx, wrappedRequest)
+	if err2 != nil {
+		return nil, h.error(err2, scope, domainID, workflowID, runID)
+	}
+
+	return resp, nil
+}
+
 // ResetWorkflowExecution reset an existing workflow execution
 // in the history and immediately terminating the execution instance.
 func (h *handlerImpl) ResetWorkflowExecution(
@@ -1243,7 +1475,7 @@ func (h *handlerImpl) ResetWorkflowExecution(
 	workflowExecution := wrappedRequest.ResetRequest.WorkflowExecution
 	workflowID := workflowExecution.GetWorkflowID()
 	runID := workflowExecution.GetRunID()
-	engine, err1 := h.controller.GetEngine(workflowID)
+	engine, err1 := h.getEngine(domainID, workflowID)
 	if err1 != nil {
 		return nil, h.error(err1, scope, domainID, workflowID, runID)
 	}
@@ -1282,7 +1514,7 @@ func (h *handlerImpl) QueryWorkflow(
 
 	workflowID := request.GetRequest().GetExecution().GetWorkflowID()
 	runID := request.GetRequest().GetExecution().GetRunID()
-	engine, err1 := h.controller.GetEngine(workflowID)
+	engine, err1 := h.getEngineOrShed(domainID, workflowID, true)
 	if err1 != nil {
 		return nil, h.error(err1, scope, domainID, workflowID, runID)
 	}
@@ -1330,7 +1562,7 @@ func (h *handlerImpl) ScheduleDecisionTask(
 	workflowExecution := request.WorkflowExecution
 	workflowID := workflowExecution.GetWorkflowID()
 	runID := workflowExecution.GetRunID()
-	engine, err1 := h.controller.GetEngine(workflowID)
+	engine, err1 := h.getEngine(domainID, workflowID)
 	if err1 != nil {
 		return h.error(err1, scope, domainID, workflowID, runID)
 	}
@@ -1376,7 +1608,7 @@ func (h *handlerImpl) RecordChildExecutionCompleted(
 	workflowExecution := request.WorkflowExecution
 	workflowID := workflowExecution.GetWorkflowID()
 	runID := workflowExecution.GetRunID()
-	engine, err1 := h.controller.GetEngine(workflowID)
+	engine, err1 := h.getEngine(domainID, workflowID)
 	if err1 != nil {
 		return h.error(err1, scope, domainID, workflowID, runID)
 	}
@@ -1422,7 +1654,7 @@ func (h *handlerImpl) ResetStickyTaskList(
 
 	workflowID := resetRequest.Execution.GetWorkflowID()
 	runID := resetRequest.Execution.GetRunID()
-	engine, err := h.controller.GetEngine(workflowID)
+	engine, err := h.getEngine(domainID, workflowID)
 	if err != nil {
 		return nil, h.error(err, scope, domainID, workflowID, runID)
 	}
@@ -1463,7 +1695,7 @@ func (h *handlerImpl) ReplicateEventsV2(
 	workflowExecution := replicateRequest.WorkflowExecution
 	workflowID := workflowExecution.GetWorkflowID()
 	runID := workflowExecution.GetRunID()
-	engine, err1 := h.controller.GetEngine(workflowID)
+	engine, err1 := h.getEngine(domainID, workflowID)
 	if err1 != nil {
 		return h.error(err1, scope, domainID, workflowID, runID)
 	}
@@ -1553,7 +1785,7 @@ func (h *handlerImpl) SyncActivity(
 
 	workflowID := syncActivityRequest.GetWorkflowID()
 	runID := syncActivityRequest.GetRunID()
-	engine, err := h.controller.GetEngine(workflowID)
+	engine, err := h.getEngine(domainID, workflowID)
 	if err != nil {
 		return h.error(err, scope, domainID, workflowID, runID)
 	}
@@ -1736,7 +1968,7 @@ func (h *handlerImpl) ReapplyEvents(
 	domainID := request.GetDomainUUID()
 	workflowID := request.GetRequest().GetWorkflowExecution().GetWorkflowID()
 	runID := request.GetRequest().GetWorkflowExecution().GetRunID()
-	engine, err := h.controller.GetEngine(workflowID)
+	engine, err := h.getEngine(domainID, workflowID)
 	if err != nil {
 		return h.error(err, scope, domainID, workflowID, runID)
 	}
@@ -1896,7 +2128,7 @@ func (h *handlerImpl) RefreshWorkflowTasks(
 	execution := request.GetRequest().GetExecution()
 	workflowID := execution.GetWorkflowID()
 	runID := execution.GetWorkflowID()
-	engine, err := h.controller.GetEngine(workflowID)
+	engine, err := h.getEngine(domainID, workflowID)
 	if err != nil {
 		return h.error(err, scope, domainID, workflowID, runID)
 	}
@@ -1917,6 +2149,46 @@ func (h *handlerImpl) RefreshWorkflowTasks(
 	return nil
 }
 
+// VerifyWorkflowExecution replays the history of a workflow run and reports the discrepancies between
+// the rebuilt and the persisted mutable state, optionally repairing the persisted mutable state
+func (h *handlerImpl) VerifyWorkflowExecution(
+	ctx context.Context,
+	request *types.VerifyWorkflowExecutionRequest,
+) (resp *types.VerifyWorkflowExecutionResponse, retError error) {
+
+	defer func() { log.CapturePanic(recover(), h.GetLogger(), &retError) }()
+	h.startWG.Wait()
+
+	scope, sw := h.startRequestProfile(ctx, metrics.HistoryVerifyWorkflowExecutionScope)
+	defer sw.Stop()
+
+	if h.isShuttingDown() {
+		return nil, constants.ErrShuttingDown
+	}
+
+	domainID := request.GetDomainUUID()
+	if domainID == "" {
+		return nil, h.error(constants.ErrDomainNotSet, scope, domainID, "", "")
+	}
+	if request.GetExecution() == nil {
+		return nil, h.error(constants.ErrWorkflowExecutionNotSet, scope, domainID, "", "")
+	}
+
+	workflowExecution := request.GetExecution()
+	workflowID := workflowExecution.GetWorkflowID()
+	runID := workflowExecution.GetRunID()
+	engine, err := h.getEngine(domainID, workflowID)
+	if err != nil {
+		return nil, h.error(err, scope, domainID, workflowID, runID)
+	}
+
+	resp, err = engine.VerifyWorkflowExecution(ctx, request)
+	if err != nil {
+		return nil, h.error(err, scope, domainID, workflowID, runID)
+	}
+	return resp, nil
+}
+
 // NotifyFailoverMarkers sends the failover markers to failover coordinator.
 // The coordinator decides when the failover finishes based on received failover marker.
 func (h *handlerImpl) NotifyFailoverMarkers(
@@ -1962,7 +2234,7 @@ func (h *handlerImpl) GetCrossClusterTasks(
 			if err != nil {
 				logger.Error("History engine not found for shard", tag.Error(err))
 				var owner membership.HostInfo
-				if info, err := h.GetMembershipResolver().Lookup(service.History, strconv.Itoa(int(shardID))); err == nil {
+				if info, err := h.GetMembershipResolver().Lookup(service.History, string(rune(shardID))); err == nil {
 					owner = info
 				}
 				settable.Set(nil, shard.CreateShardOwnershipLostError(h.GetHostInfo(), owner))
@@ -2069,7 +2341,7 @@ func (h *handlerImpl) RatelimitUpdate(
 func (h *handlerImpl) convertError(err error) error {
 	switch err := err.(type) {
 	case *persistence.ShardOwnershipLostError:
-		info, err2 := h.GetMembershipResolver().Lookup(service.History, strconv.Itoa(err.ShardID))
+		info, err2 := h.GetMembershipResolver().Lookup(service.History, string(rune(err.ShardID)))
 		if err2 != nil {
 			return shard.CreateShardOwnershipLostError(h.GetHostInfo(), membership.HostInfo{})
 		}
@@ -2205,6 +2477,25 @@ func (h *handlerImpl) startRequestProfile(ctx context.Context, scope int) (metri
 	return metricsScope, sw
 }
 
+// getEngine returns the engine of the shard owning the workflow after recording the request in the load tracker
+func (h *handlerImpl) getEngine(domainID string, workflowID string) (engine.Engine, error) {
+	return h.getEngineOrShed(domainID, workflowID, false)
+}
+
+// getEngineOrShed is getEngine for the requests which can be shed: external starts, signals and queries.
+// They are rejected when the host is overloaded and they are for the hottest shards or workflows. Requests
+// driving existing workflows, like task completions and requests from queue processors, are never shed,
+// as retrying them only adds load and delays the progress of the workflows.
+func (h *handlerImpl) getEngineOrShed(domainID string, workflowID string, sheddable bool) (engine.Engine, error) {
+	shardID := h.config.GetShardID(workflowID)
+	loadTracker := h.GetLoadTracker()
+	loadTracker.RecordRequest(shardID, domainID, workflowID)
+	if sheddable && loadTracker.ShouldShed(shardID, domainID, workflowID) {
+		return nil, constants.ErrHistoryHostOverloaded
+	}
+	return h.controller.GetEngine(workflowID)
+}
+
 func validateTaskToken(token *common.TaskToken) error {
 	if token.WorkflowID == "" {
 		return constants.ErrWorkflowIDNotSet
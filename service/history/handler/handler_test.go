@@ -34,16 +34,21 @@ import (
 	"go.uber.org/yarpc/yarpcerrors"
 
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/membership"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/metrics/mocks"
+	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/quotas"
+	"github.com/uber/cadence/common/service"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/history/config"
 	"github.com/uber/cadence/service/history/constants"
 	"github.com/uber/cadence/service/history/engine"
 	"github.com/uber/cadence/service/history/events"
+	"github.com/uber/cadence/service/history/loadtracker"
 	"github.com/uber/cadence/service/history/resource"
 	"github.com/uber/cadence/service/history/shard"
 	"github.com/uber/cadence/service/history/task"
@@ -783,6 +788,14 @@ func (s *handlerSuite) TestGetCrossClusterTasks() {
 	}
 }
 
+func (s *handlerSuite) TestConvertError_ShardOwnershipLost() {
+	// the owner must be looked up with the same key the shard controller uses
+	s.mockResource.MembershipResolver.EXPECT().Lookup(service.History, string(rune(7))).Return(membership.NewHostInfo("owner-host"), nil).Times(1)
+
+	err := s.handler.convertError(&persistence.ShardOwnershipLostError{ShardID: 7})
+	s.Equal(shard.CreateShardOwnershipLostError(s.mockResource.GetHostInfo(), membership.NewHostInfo("owner-host")), err)
+}
+
 func (s *handlerSuite) TestRespondCrossClusterTaskCompleted_FetchNewTask() {
 	s.testRespondCrossClusterTaskCompleted(true)
 }
@@ -840,6 +853,169 @@ func (s *handlerSuite) TestStartWorkflowExecution() {
 	s.Nil(err)
 }
 
+func (s *handlerSuite) TestDescribeHistoryHost() {
+	s.mockResource.DomainCache.EXPECT().GetCacheSize().Return(int64(3), int64(4)).Times(1)
+	s.mockShardController.EXPECT().Status().Return(common.DaemonStatusStarted).Times(1)
+	s.mockShardController.EXPECT().NumShards().Return(2).Times(1)
+	s.mockShardController.EXPECT().ShardIDs().Return([]int32{1, 2}).Times(2)
+	s.mockEngine.EXPECT().GetExecutionCacheStats().Return(cache.Stats{
+		Count:       2,
+		SizeInBytes: 100,
+		PinnedCount: 1,
+		Hits:        10,
+		Misses:      5,
+	}).Times(2)
+	s.mockEngine.EXPECT().GetEventsCacheStats().Return(cache.Stats{
+		Count:  3,
+		Hits:   7,
+		Misses: 1,
+	}).Times(2)
+
+	resp, err := s.handler.DescribeHistoryHost(context.Background(), &types.DescribeHistoryHostRequest{})
+	s.NoError(err)
+	s.Equal(&types.DescribeHistoryHostResponse{
+		NumberOfShards: 2,
+		ShardIDs:       []int32{1, 2},
+		DomainCache: &types.DomainCacheInfo{
+			NumOfItemsInCacheByID:   3,
+			NumOfItemsInCacheByName: 4,
+		},
+		ShardControllerStatus: "started",
+		Address:               s.mockResource.GetHostInfo().GetAddress(),
+		ExecutionCache: &types.CacheInfo{
+			NumOfItems:       4,
+			NumOfPinnedItems: 2,
+			SizeInBytes:      200,
+			NumOfHits:        20,
+			NumOfMisses:      10,
+		},
+		EventsCache: &types.CacheInfo{
+			NumOfItems:  6,
+			NumOfHits:   14,
+			NumOfMisses: 2,
+		},
+	}, resp)
+}
+
+func (s *handlerSuite) TestDescribeReplicationStatus() {
+	request := &types.DescribeReplicationStatusRequest{}
+	s.mockShardController.EXPECT().ShardIDs().Return([]int32{1, 2}).Times(1)
+	s.mockEngine.EXPECT().DescribeReplicationStatus(gomock.Any(), request).Return(&types.DescribeReplicationStatusResponse{
+		Clusters: []*types.ClusterReplicationStatus{
+			{
+				ClusterName: "cluster2",
+				Domains: []*types.DomainReplicationStatus{
+					{DomainID: "domain1", DomainName: "domain-name-1", PendingTaskCount: 3, LagInMilliseconds: 100},
+				},
+			},
+		},
+	}, nil).Times(1)
+	s.mockEngine.EXPECT().DescribeReplicationStatus(gomock.Any(), request).Return(&types.DescribeReplicationStatusResponse{
+		Clusters: []*types.ClusterReplicationStatus{
+			{
+				ClusterName: "cluster3",
+			},
+			{
+				ClusterName: "cluster2",
+				Domains: []*types.DomainReplicationStatus{
+					{DomainID: "domain1", DomainName: "domain-name-1", PendingTaskCount: 2, LagInMilliseconds: 50},
+					{DomainID: "domain2", DomainName: "domain-name-2", PendingTaskCount: 1, LagInMilliseconds: 200},
+				},
+				Incomplete: true,
+			},
+		},
+	}, nil).Times(1)
+
+	resp, err := s.handler.DescribeReplicationStatus(context.Background(), request)
+	s.NoError(err)
+	s.Equal(&types.DescribeReplicationStatusResponse{
+		Clusters: []*types.ClusterReplicationStatus{
+			{
+				ClusterName: "cluster2",
+				Domains: []*types.DomainReplicationStatus{
+					{DomainID: "domain2", DomainName: "domain-name-2", PendingTaskCount: 1, LagInMilliseconds: 200},
+					{DomainID: "domain1", DomainName: "domain-name-1", PendingTaskCount: 5, LagInMilliseconds: 100},
+				},
+				Incomplete: true,
+			},
+			{
+				ClusterName: "cluster3",
+			},
+		},
+	}, resp)
+}
+
+func (s *handlerSuite) TestGetEngine_LoadShedding() {
+	mockLoadTracker := loadtracker.NewMockTracker(s.controller)
+	s.mockResource.LoadTracker = mockLoadTracker
+	shardID := s.handler.config.GetShardID(testWorkflowID)
+
+	mockLoadTracker.EXPECT().RecordRequest(shardID, testDomainID, testWorkflowID).Times(3)
+	mockLoadTracker.EXPECT().ShouldShed(shardID, testDomainID, testWorkflowID).Return(false).Times(1)
+	s.mockShardController.EXPECT().GetEngine(testWorkflowID).Return(s.mockEngine, nil).Times(2)
+	engine, err := s.handler.getEngineOrShed(testDomainID, testWorkflowID, true)
+	s.NoError(err)
+	s.Equal(s.mockEngine, engine)
+
+	mockLoadTracker.EXPECT().ShouldShed(shardID, testDomainID, testWorkflowID).Return(true).Times(1)
+	_, err = s.handler.getEngineOrShed(testDomainID, testWorkflowID, true)
+	s.Equal(constants.ErrHistoryHostOverloaded, err)
+
+	// requests which can't be shed are only recorded
+	engine, err = s.handler.getEngine(testDomainID, testWorkflowID)
+	s.NoError(err)
+	s.Equal(s.mockEngine, engine)
+}
+
+func (s *handlerSuite) TestSignalWorkflowExecution_NotShedFromOtherWorkflows() {
+	mockLoadTracker := loadtracker.NewMockTracker(s.controller)
+	s.mockResource.LoadTracker = mockLoadTracker
+	shardID := s.handler.config.GetShardID(testWorkflowID)
+	request := &types.HistorySignalWorkflowExecutionRequest{
+		DomainUUID: testDomainID,
+		SignalRequest: &types.SignalWorkflowExecutionRequest{
+			WorkflowExecution: &types.WorkflowExecution{WorkflowID: testWorkflowID},
+		},
+		ExternalWorkflowExecution: &types.WorkflowExecution{WorkflowID: "parent"},
+	}
+
+	s.mockRatelimiter.EXPECT().Allow().Return(true).Times(1)
+	mockLoadTracker.EXPECT().RecordRequest(shardID, testDomainID, testWorkflowID).Times(1)
+	s.mockShardController.EXPECT().GetEngine(testWorkflowID).Return(s.mockEngine, nil).Times(1)
+	s.mockEngine.EXPECT().SignalWorkflowExecution(gomock.Any(), request).Return(nil).Times(1)
+	s.NoError(s.handler.SignalWorkflowExecution(context.Background(), request))
+}
+
+func (s *handlerSuite) TestVerifyWorkflowExecution() {
+	_, err := s.handler.VerifyWorkflowExecution(context.Background(), &types.VerifyWorkflowExecutionRequest{})
+	s.Equal(constants.ErrDomainNotSet, err)
+
+	_, err = s.handler.VerifyWorkflowExecution(context.Background(), &types.VerifyWorkflowExecutionRequest{
+		DomainUUID: testDomainID,
+	})
+	s.Equal(constants.ErrWorkflowExecutionNotSet, err)
+
+	request := &types.VerifyWorkflowExecutionRequest{
+		DomainUUID: testDomainID,
+		Execution: &types.WorkflowExecution{
+			WorkflowID: testWorkflowID,
+			RunID:      testValidUUID,
+		},
+		Repair: true,
+	}
+	expected := &types.VerifyWorkflowExecutionResponse{
+		Discrepancies: []*types.MutableStateDiscrepancy{
+			{Type: "PendingTimer", ID: "timer-1", Persisted: "5", Rebuilt: "missing"},
+		},
+		Repaired: true,
+	}
+	s.mockShardController.EXPECT().GetEngine(testWorkflowID).Return(s.mockEngine, nil).Times(1)
+	s.mockEngine.EXPECT().VerifyWorkflowExecution(gomock.Any(), request).Return(expected, nil).Times(1)
+	resp, err := s.handler.VerifyWorkflowExecution(context.Background(), request)
+	s.NoError(err)
+	s.Equal(expected, resp)
+}
+
 func (s *handlerSuite) TestEmitInfoOrDebugLog() {
 	// test emitInfoOrDebugLog
 	s.mockResource.Logger = testlogger.New(s.Suite.T())
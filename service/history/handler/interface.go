@@ -47,13 +47,18 @@ type Handler interface {
 	DescribeHistoryHost(context.Context, *types.DescribeHistoryHostRequest) (*types.DescribeHistoryHostResponse, error)
 	DescribeMutableState(context.Context, *types.DescribeMutableStateRequest) (*types.DescribeMutableStateResponse, error)
 	DescribeQueue(context.Context, *types.DescribeQueueRequest) (*types.DescribeQueueResponse, error)
+	DescribeReplicationStatus(context.Context, *types.DescribeReplicationStatusRequest) (*types.DescribeReplicationStatusResponse, error)
+	DeleteWorkflowExecution(context.Context, *types.HistoryDeleteWorkflowExecutionRequest) (*types.DeleteWorkflowExecutionResponse, error)
 	DescribeWorkflowExecution(context.Context, *types.HistoryDescribeWorkflowExecutionRequest) (*types.DescribeWorkflowExecutionResponse, error)
+	ExecuteQueueTask(context.Context, *types.ExecuteQueueTaskRequest) error
 	GetCrossClusterTasks(context.Context, *types.GetCrossClusterTasksRequest) (*types.GetCrossClusterTasksResponse, error)
 	CountDLQMessages(context.Context, *types.CountDLQMessagesRequest) (*types.HistoryCountDLQMessagesResponse, error)
 	GetDLQReplicationMessages(context.Context, *types.GetDLQReplicationMessagesRequest) (*types.GetDLQReplicationMessagesResponse, error)
 	GetMutableState(context.Context, *types.GetMutableStateRequest) (*types.GetMutableStateResponse, error)
 	GetReplicationMessages(context.Context, *types.GetReplicationMessagesRequest) (*types.GetReplicationMessagesResponse, error)
+	ListQueueTasks(context.Context, *types.ListQueueTasksRequest) (*types.ListQueueTasksResponse, error)
 	MergeDLQMessages(context.Context, *types.MergeDLQMessagesRequest) (*types.MergeDLQMessagesResponse, error)
+	MigrateShardExecutions(context.Context, *types.MigrateShardExecutionsRequest) (*types.MigrateShardExecutionsResponse, error)
 	NotifyFailoverMarkers(context.Context, *types.NotifyFailoverMarkersRequest) error
 	PollMutableState(context.Context, *types.PollMutableStateRequest) (*types.PollMutableStateResponse, error)
 	PurgeDLQMessages(context.Context, *types.PurgeDLQMessagesRequest) error
@@ -65,6 +70,7 @@ type Handler interface {
 	RecordChildExecutionCompleted(context.Context, *types.RecordChildExecutionCompletedRequest) error
 	RecordDecisionTaskStarted(context.Context, *types.RecordDecisionTaskStartedRequest) (*types.RecordDecisionTaskStartedResponse, error)
 	RefreshWorkflowTasks(context.Context, *types.HistoryRefreshWorkflowTasksRequest) error
+	VerifyWorkflowExecution(context.Context, *types.VerifyWorkflowExecutionRequest) (*types.VerifyWorkflowExecutionResponse, error)
 	RemoveSignalMutableState(context.Context, *types.RemoveSignalMutableStateRequest) error
 	RemoveTask(context.Context, *types.RemoveTaskRequest) error
 	ReplicateEventsV2(context.Context, *types.ReplicateEventsV2Request) error
@@ -85,6 +91,7 @@ type Handler interface {
 	SyncActivity(context.Context, *types.SyncActivityRequest) error
 	SyncShardStatus(context.Context, *types.SyncShardStatusRequest) error
 	TerminateWorkflowExecution(context.Context, *types.HistoryTerminateWorkflowExecutionRequest) error
+	UpdateActivityOptions(context.Context, *types.HistoryUpdateActivityOptionsRequest) error
 	GetFailoverInfo(context.Context, *types.GetFailoverInfoRequest) (*types.GetFailoverInfoResponse, error)
 	RatelimitUpdate(context.Context, *types.RatelimitUpdateRequest) (*types.RatelimitUpdateResponse, error)
 }
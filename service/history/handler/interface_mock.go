@@ -88,6 +88,21 @@ func (mr *MockHandlerMockRecorder) CountDLQMessages(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDLQMessages", reflect.TypeOf((*MockHandler)(nil).CountDLQMessages), arg0, arg1)
 }
 
+// DeleteWorkflowExecution mocks base method.
+func (m *MockHandler) DeleteWorkflowExecution(arg0 context.Context, arg1 *types.HistoryDeleteWorkflowExecutionRequest) (*types.DeleteWorkflowExecutionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkflowExecution", arg0, arg1)
+	ret0, _ := ret[0].(*types.DeleteWorkflowExecutionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWorkflowExecution indicates an expected call of DeleteWorkflowExecution.
+func (mr *MockHandlerMockRecorder) DeleteWorkflowExecution(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkflowExecution", reflect.TypeOf((*MockHandler)(nil).DeleteWorkflowExecution), arg0, arg1)
+}
+
 // DescribeHistoryHost mocks base method.
 func (m *MockHandler) DescribeHistoryHost(arg0 context.Context, arg1 *types.DescribeHistoryHostRequest) (*types.DescribeHistoryHostResponse, error) {
 	m.ctrl.T.Helper()
@@ -133,6 +148,21 @@ func (mr *MockHandlerMockRecorder) DescribeQueue(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeQueue", reflect.TypeOf((*MockHandler)(nil).DescribeQueue), arg0, arg1)
 }
 
+// DescribeReplicationStatus mocks base method.
+func (m *MockHandler) DescribeReplicationStatus(arg0 context.Context, arg1 *types.DescribeReplicationStatusRequest) (*types.DescribeReplicationStatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeReplicationStatus", arg0, arg1)
+	ret0, _ := ret[0].(*types.DescribeReplicationStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeReplicationStatus indicates an expected call of DescribeReplicationStatus.
+func (mr *MockHandlerMockRecorder) DescribeReplicationStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeReplicationStatus", reflect.TypeOf((*MockHandler)(nil).DescribeReplicationStatus), arg0, arg1)
+}
+
 // DescribeWorkflowExecution mocks base method.
 func (m *MockHandler) DescribeWorkflowExecution(arg0 context.Context, arg1 *types.HistoryDescribeWorkflowExecutionRequest) (*types.DescribeWorkflowExecutionResponse, error) {
 	m.ctrl.T.Helper()
@@ -148,6 +178,20 @@ func (mr *MockHandlerMockRecorder) DescribeWorkflowExecution(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeWorkflowExecution", reflect.TypeOf((*MockHandler)(nil).DescribeWorkflowExecution), arg0, arg1)
 }
 
+// ExecuteQueueTask mocks base method.
+func (m *MockHandler) ExecuteQueueTask(arg0 context.Context, arg1 *types.ExecuteQueueTaskRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteQueueTask", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExecuteQueueTask indicates an expected call of ExecuteQueueTask.
+func (mr *MockHandlerMockRecorder) ExecuteQueueTask(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteQueueTask", reflect.TypeOf((*MockHandler)(nil).ExecuteQueueTask), arg0, arg1)
+}
+
 // GetCrossClusterTasks mocks base method.
 func (m *MockHandler) GetCrossClusterTasks(arg0 context.Context, arg1 *types.GetCrossClusterTasksRequest) (*types.GetCrossClusterTasksResponse, error) {
 	m.ctrl.T.Helper()
@@ -238,6 +282,21 @@ func (mr *MockHandlerMockRecorder) Health(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Health", reflect.TypeOf((*MockHandler)(nil).Health), arg0)
 }
 
+// ListQueueTasks mocks base method.
+func (m *MockHandler) ListQueueTasks(arg0 context.Context, arg1 *types.ListQueueTasksRequest) (*types.ListQueueTasksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQueueTasks", arg0, arg1)
+	ret0, _ := ret[0].(*types.ListQueueTasksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListQueueTasks indicates an expected call of ListQueueTasks.
+func (mr *MockHandlerMockRecorder) ListQueueTasks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQueueTasks", reflect.TypeOf((*MockHandler)(nil).ListQueueTasks), arg0, arg1)
+}
+
 // MergeDLQMessages mocks base method.
 func (m *MockHandler) MergeDLQMessages(arg0 context.Context, arg1 *types.MergeDLQMessagesRequest) (*types.MergeDLQMessagesResponse, error) {
 	m.ctrl.T.Helper()
@@ -253,6 +312,21 @@ func (mr *MockHandlerMockRecorder) MergeDLQMessages(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeDLQMessages", reflect.TypeOf((*MockHandler)(nil).MergeDLQMessages), arg0, arg1)
 }
 
+// MigrateShardExecutions mocks base method.
+func (m *MockHandler) MigrateShardExecutions(arg0 context.Context, arg1 *types.MigrateShardExecutionsRequest) (*types.MigrateShardExecutionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrateShardExecutions", arg0, arg1)
+	ret0, _ := ret[0].(*types.MigrateShardExecutionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MigrateShardExecutions indicates an expected call of MigrateShardExecutions.
+func (mr *MockHandlerMockRecorder) MigrateShardExecutions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateShardExecutions", reflect.TypeOf((*MockHandler)(nil).MigrateShardExecutions), arg0, arg1)
+}
+
 // NotifyFailoverMarkers mocks base method.
 func (m *MockHandler) NotifyFailoverMarkers(arg0 context.Context, arg1 *types.NotifyFailoverMarkersRequest) error {
 	m.ctrl.T.Helper()
@@ -751,3 +825,32 @@ func (mr *MockHandlerMockRecorder) TerminateWorkflowExecution(arg0, arg1 interfa
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerminateWorkflowExecution", reflect.TypeOf((*MockHandler)(nil).TerminateWorkflowExecution), arg0, arg1)
 }
+
+// UpdateActivityOptions mocks base method.
+func (m *MockHandler) UpdateActivityOptions(arg0 context.Context, arg1 *types.HistoryUpdateActivityOptionsRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateActivityOptions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateActivityOptions indicates an expected call of UpdateActivityOptions.
+func (mr *MockHandlerMockRecorder) UpdateActivityOptions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActivityOptions", reflect.TypeOf((*MockHandler)(nil).UpdateActivityOptions), arg0, arg1)
+}
+
+// VerifyWorkflowExecution mocks base method.
+func (m *MockHandler) VerifyWorkflowExecution(arg0 context.Context, arg1 *types.VerifyWorkflowExecutionRequest) (*types.VerifyWorkflowExecutionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyWorkflowExecution", arg0, arg1)
+	ret0, _ := ret[0].(*types.VerifyWorkflowExecutionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyWorkflowExecution indicates an expected call of VerifyWorkflowExecution.
+func (mr *MockHandlerMockRecorder) VerifyWorkflowExecution(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyWorkflowExecution", reflect.TypeOf((*MockHandler)(nil).VerifyWorkflowExecution), arg0, arg1)
+}
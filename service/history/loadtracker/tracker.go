@@ -0,0 +1,427 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:generate mockgen -package $GOPACKAGE -source $GOFILE -destination tracker_mock.go -self_package github.com/uber/cadence/service/history/loadtracker
+
+package loadtracker
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/history/config"
+)
+
+const (
+	// maxTrackedWorkflowsPerShard bounds the number of workflows of a shard whose request rate is tracked,
+	// workflows without recent requests are dropped when the snapshot is refreshed
+	maxTrackedWorkflowsPerShard = 1000
+	// describeTopN is the minimum number of hot shards and workflows returned by Describe
+	describeTopN = 10
+	// snapshotInterval is how often the hot shards and workflows and the host overload status are re-evaluated
+	snapshotInterval = time.Second
+	// pendingTasksTTL is how long a pending task count reported by a queue processor is considered valid,
+	// so that counts of processors of shards which are no longer owned by the host are dropped
+	pendingTasksTTL = 5 * time.Minute
+)
+
+type (
+	// Tracker tracks the request rate of shards and workflows on a history host and decides
+	// whether requests for the hottest ones should be shed when the host is overloaded
+	Tracker interface {
+		// RecordRequest records a request for the given workflow
+		RecordRequest(shardID int, domainID string, workflowID string)
+		// RecordPendingTasks records the number of pending tasks of the given queue processor of a shard
+		RecordPendingTasks(shardID int, processorID string, numPendingTasks int)
+		// ShouldShed returns true if the host is overloaded and the request is for one of the hottest shards or workflows
+		ShouldShed(shardID int, domainID string, workflowID string) bool
+		// Describe returns the load of the host, or nil if tracking is disabled
+		Describe() *types.HostLoadInfo
+	}
+
+	trackerImpl struct {
+		config     *config.Config
+		timeSource clock.TimeSource
+		cpuTimeFn  func() (time.Duration, error)
+
+		// shards maps shard IDs to their *shardLoad, so that requests only lock the load of their own shard
+		shards sync.Map
+		// snapshot is the latest *snapshot, read by requests without locking
+		snapshot atomic.Value
+
+		pendingTasksLock sync.Mutex
+		pendingTasks     map[pendingTasksKey]pendingTasksValue
+
+		// refreshLock serializes the refreshes of the snapshot and guards the CPU sampler
+		refreshLock sync.Mutex
+		cpu         cpuSampler
+	}
+
+	noopTracker struct{}
+
+	shardLoad struct {
+		sync.Mutex
+		counter   *rateCounter
+		workflows map[workflowKey]*rateCounter
+	}
+
+	workflowKey struct {
+		domainID   string
+		workflowID string
+	}
+
+	pendingTasksKey struct {
+		shardID     int
+		processorID string
+	}
+
+	pendingTasksValue struct {
+		numPendingTasks int
+		updateTime      time.Time
+	}
+
+	snapshot struct {
+		updateTime   time.Time
+		info         *types.HostLoadInfo
+		hotShards    map[int]struct{}
+		hotWorkflows map[workflowKey]struct{}
+	}
+
+	// rateCounter counts events in fixed windows and estimates the rate over a sliding window
+	// by weighting the count of the previous window with its overlap with the sliding window
+	rateCounter struct {
+		windowStart time.Time
+		current     int64
+		previous    int64
+	}
+
+	cpuSampler struct {
+		lastSampleTime time.Time
+		lastCPUTime    time.Duration
+		utilization    float64
+	}
+)
+
+var _ Tracker = (*trackerImpl)(nil)
+var _ Tracker = (*noopTracker)(nil)
+
+// NewTracker creates a new load tracker
+func NewTracker(
+	config *config.Config,
+	timeSource clock.TimeSource,
+) Tracker {
+	return &trackerImpl{
+		config:       config,
+		timeSource:   timeSource,
+		cpuTimeFn:    processCPUTime,
+		pendingTasks: make(map[pendingTasksKey]pendingTasksValue),
+	}
+}
+
+// NewNoopTracker creates a load tracker which tracks nothing and never sheds requests
+func NewNoopTracker() Tracker {
+	return &noopTracker{}
+}
+
+func (t *trackerImpl) RecordRequest(
+	shardID int,
+	domainID string,
+	workflowID string,
+) {
+	if !t.config.EnableHotShardTracking() {
+		return
+	}
+
+	now := t.timeSource.Now()
+	window := t.config.HotShardTrackingWindow()
+	load := t.getShardLoad(shardID, now)
+
+	load.Lock()
+	defer load.Unlock()
+
+	load.counter.add(now, window)
+	key := workflowKey{domainID: domainID, workflowID: workflowID}
+	counter, ok := load.workflows[key]
+	if !ok {
+		if len(load.workflows) >= maxTrackedWorkflowsPerShard {
+			// the workflow is tracked once idle workflows are dropped
+			return
+		}
+		counter = newRateCounter(now)
+		load.workflows[key] = counter
+	}
+	counter.add(now, window)
+}
+
+func (t *trackerImpl) RecordPendingTasks(
+	shardID int,
+	processorID string,
+	numPendingTasks int,
+) {
+	if !t.config.EnableHotShardTracking() {
+		return
+	}
+
+	now := t.timeSource.Now()
+
+	t.pendingTasksLock.Lock()
+	defer t.pendingTasksLock.Unlock()
+
+	t.pendingTasks[pendingTasksKey{shardID: shardID, processorID: processorID}] = pendingTasksValue{
+		numPendingTasks: numPendingTasks,
+		updateTime:      now,
+	}
+}
+
+func (t *trackerImpl) ShouldShed(
+	shardID int,
+	domainID string,
+	workflowID string,
+) bool {
+	if !t.config.EnableHotShardTracking() || !t.config.EnableHotShardLoadShedding() {
+		return false
+	}
+
+	s := t.getSnapshot()
+	if s == nil || !s.info.Overloaded {
+		return false
+	}
+	if _, ok := s.hotShards[shardID]; ok {
+		return true
+	}
+	_, ok := s.hotWorkflows[workflowKey{domainID: domainID, workflowID: workflowID}]
+	return ok
+}
+
+func (t *trackerImpl) Describe() *types.HostLoadInfo {
+	if !t.config.EnableHotShardTracking() {
+		return nil
+	}
+
+	t.refreshLock.Lock()
+	defer t.refreshLock.Unlock()
+
+	now := t.timeSource.Now()
+	if s, ok := t.snapshot.Load().(*snapshot); ok && now.Sub(s.updateTime) < snapshotInterval {
+		return s.info
+	}
+	return t.refreshSnapshotLocked(now).info
+}
+
+func (t *trackerImpl) getShardLoad(shardID int, now time.Time) *shardLoad {
+	if load, ok := t.shards.Load(shardID); ok {
+		return load.(*shardLoad)
+	}
+	load, _ := t.shards.LoadOrStore(shardID, &shardLoad{
+		counter:   newRateCounter(now),
+		workflows: make(map[workflowKey]*rateCounter),
+	})
+	return load.(*shardLoad)
+}
+
+// getSnapshot returns the latest snapshot, which is refreshed by a single request once it is stale,
+// while the other requests keep using the stale one
+func (t *trackerImpl) getSnapshot() *snapshot {
+	now := t.timeSource.Now()
+	s, _ := t.snapshot.Load().(*snapshot)
+	if s != nil && now.Sub(s.updateTime) < snapshotInterval {
+		return s
+	}
+	if !t.refreshLock.TryLock() {
+		return s
+	}
+	defer t.refreshLock.Unlock()
+	return t.refreshSnapshotLocked(now)
+}
+
+func (t *trackerImpl) refreshSnapshotLocked(now time.Time) *snapshot {
+	window := t.config.HotShardTrackingWindow()
+	topN := t.config.HotShardLoadSheddingTopN()
+	info := &types.HostLoadInfo{
+		CPUUtilization: t.cpu.sample(now, t.cpuTimeFn),
+	}
+
+	shardPendingTasks := make(map[int]int64)
+	t.pendingTasksLock.Lock()
+	for key, value := range t.pendingTasks {
+		if now.Sub(value.updateTime) > pendingTasksTTL {
+			delete(t.pendingTasks, key)
+			continue
+		}
+		shardPendingTasks[key.shardID] += int64(value.numPendingTasks)
+		info.PendingTasks += int64(value.numPendingTasks)
+	}
+	t.pendingTasksLock.Unlock()
+
+	var shards []*types.ShardLoadInfo
+	var workflows []*types.WorkflowLoadInfo
+	t.shards.Range(func(key, value interface{}) bool {
+		shardID := key.(int)
+		load := value.(*shardLoad)
+
+		load.Lock()
+		defer load.Unlock()
+
+		for workflow, counter := range load.workflows {
+			rate := counter.rate(now, window)
+			if rate == 0 {
+				delete(load.workflows, workflow)
+				continue
+			}
+			workflows = append(workflows, &types.WorkflowLoadInfo{
+				DomainID:          workflow.domainID,
+				WorkflowID:        workflow.workflowID,
+				ShardID:           int32(shardID),
+				RequestsPerSecond: rate,
+			})
+		}
+		rate := load.counter.rate(now, window)
+		if rate == 0 {
+			return true
+		}
+		info.RequestsPerSecond += rate
+		shards = append(shards, &types.ShardLoadInfo{
+			ShardID:           int32(shardID),
+			RequestsPerSecond: rate,
+			PendingTasks:      shardPendingTasks[shardID],
+		})
+		return true
+	})
+	sort.Slice(shards, func(i, j int) bool {
+		return shards[i].RequestsPerSecond > shards[j].RequestsPerSecond
+	})
+	sort.Slice(workflows, func(i, j int) bool {
+		return workflows[i].RequestsPerSecond > workflows[j].RequestsPerSecond
+	})
+
+	s := &snapshot{
+		updateTime:   now,
+		info:         info,
+		hotShards:    make(map[int]struct{}),
+		hotWorkflows: make(map[workflowKey]struct{}),
+	}
+	for i := 0; i < len(shards) && i < topN; i++ {
+		s.hotShards[int(shards[i].ShardID)] = struct{}{}
+	}
+	for i := 0; i < len(workflows) && i < topN; i++ {
+		s.hotWorkflows[workflowKey{domainID: workflows[i].DomainID, workflowID: workflows[i].WorkflowID}] = struct{}{}
+	}
+
+	describeN := topN
+	if describeN < describeTopN {
+		describeN = describeTopN
+	}
+	if len(shards) > describeN {
+		shards = shards[:describeN]
+	}
+	if len(workflows) > describeN {
+		workflows = workflows[:describeN]
+	}
+	info.HotShards = shards
+	info.HotWorkflows = workflows
+
+	cpuThreshold := t.config.HotShardLoadSheddingCPUThreshold()
+	pendingTasksThreshold := t.config.HotShardLoadSheddingPendingTasksThreshold()
+	info.Overloaded = (cpuThreshold > 0 && info.CPUUtilization >= cpuThreshold) ||
+		(pendingTasksThreshold > 0 && info.PendingTasks >= int64(pendingTasksThreshold))
+
+	t.snapshot.Store(s)
+	return s
+}
+
+func (t *noopTracker) RecordRequest(int, string, string) {}
+
+func (t *noopTracker) RecordPendingTasks(int, string, int) {}
+
+func (t *noopTracker) ShouldShed(int, string, string) bool {
+	return false
+}
+
+func (t *noopTracker) Describe() *types.HostLoadInfo {
+	return nil
+}
+
+func newRateCounter(now time.Time) *rateCounter {
+	return &rateCounter{windowStart: now}
+}
+
+func (c *rateCounter) add(now time.Time, window time.Duration) {
+	c.rotate(now, window)
+	c.current++
+}
+
+func (c *rateCounter) rate(now time.Time, window time.Duration) float64 {
+	if window <= 0 {
+		return 0
+	}
+	c.rotate(now, window)
+	previousWeight := 1 - float64(now.Sub(c.windowStart))/float64(window)
+	return (float64(c.previous)*previousWeight + float64(c.current)) / window.Seconds()
+}
+
+func (c *rateCounter) rotate(now time.Time, window time.Duration) {
+	if window <= 0 {
+		return
+	}
+	elapsed := now.Sub(c.windowStart)
+	if elapsed < window {
+		return
+	}
+	if elapsed < 2*window {
+		c.previous = c.current
+	} else {
+		c.previous = 0
+	}
+	c.current = 0
+	c.windowStart = c.windowStart.Add(elapsed / window * window)
+}
+
+// sample returns the CPU utilization of the process between 0 and 1 since the last sample
+func (s *cpuSampler) sample(
+	now time.Time,
+	cpuTimeFn func() (time.Duration, error),
+) float64 {
+	cpuTime, err := cpuTimeFn()
+	if err != nil {
+		return s.utilization
+	}
+	if !s.lastSampleTime.IsZero() {
+		if elapsed := now.Sub(s.lastSampleTime); elapsed > 0 {
+			s.utilization = float64(cpuTime-s.lastCPUTime) / float64(elapsed) / float64(runtime.NumCPU())
+		}
+	}
+	s.lastSampleTime = now
+	s.lastCPUTime = cpuTime
+	return s.utilization
+}
+
+func processCPUTime() (time.Duration, error) {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return 0, err
+	}
+	return time.Duration(usage.Utime.Nano() + usage.Stime.Nano()), nil
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package loadtracker
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/dynamicconfig"
+	"github.com/uber/cadence/service/history/config"
+)
+
+const (
+	testDomainID    = "B59344B2-4166-462D-9CBD-22B25D2A7B1B"
+	testWorkflowID  = "8ED9219B-36A2-4FD0-B9EA-6298A0F2ED1A"
+	testWorkflowID2 = "F6E31C3D-3E54-4530-BDBE-68AEBA475473"
+)
+
+func newTestTracker(enableShedding bool) (*trackerImpl, clock.MockedTimeSource) {
+	cfg := config.NewForTest()
+	cfg.EnableHotShardTracking = dynamicconfig.GetBoolPropertyFn(true)
+	cfg.HotShardTrackingWindow = dynamicconfig.GetDurationPropertyFn(10 * time.Second)
+	cfg.EnableHotShardLoadShedding = dynamicconfig.GetBoolPropertyFn(enableShedding)
+	cfg.HotShardLoadSheddingTopN = dynamicconfig.GetIntPropertyFn(1)
+	cfg.HotShardLoadSheddingCPUThreshold = dynamicconfig.GetFloatPropertyFn(0)
+	cfg.HotShardLoadSheddingPendingTasksThreshold = dynamicconfig.GetIntPropertyFn(100)
+
+	timeSource := clock.NewMockedTimeSourceAt(time.Unix(0, 0))
+	tracker := NewTracker(cfg, timeSource).(*trackerImpl)
+	tracker.cpuTimeFn = func() (time.Duration, error) { return 0, nil }
+	return tracker, timeSource
+}
+
+func TestRateCounter(t *testing.T) {
+	now := time.Unix(0, 0)
+	window := 10 * time.Second
+	counter := newRateCounter(now)
+	for i := 0; i < 100; i++ {
+		counter.add(now, window)
+	}
+	assert.Equal(t, 10.0, counter.rate(now, window))
+
+	// half of the previous window overlaps with the sliding window
+	now = now.Add(15 * time.Second)
+	assert.Equal(t, 5.0, counter.rate(now, window))
+
+	counter.add(now, window)
+	assert.Equal(t, 5.1, counter.rate(now, window))
+
+	now = now.Add(30 * time.Second)
+	assert.Equal(t, 0.0, counter.rate(now, window))
+}
+
+func TestDescribe(t *testing.T) {
+	tracker, timeSource := newTestTracker(false)
+
+	for i := 0; i < 30; i++ {
+		tracker.RecordRequest(1, testDomainID, testWorkflowID)
+	}
+	for i := 0; i < 10; i++ {
+		tracker.RecordRequest(2, testDomainID, testWorkflowID2)
+	}
+	tracker.RecordPendingTasks(1, "transfer", 20)
+	tracker.RecordPendingTasks(1, "timer", 5)
+
+	info := tracker.Describe()
+	require.NotNil(t, info)
+	assert.Equal(t, 4.0, info.RequestsPerSecond)
+	assert.Equal(t, int64(25), info.PendingTasks)
+	assert.False(t, info.Overloaded)
+	require.Len(t, info.HotShards, 2)
+	assert.Equal(t, int32(1), info.HotShards[0].ShardID)
+	assert.Equal(t, 3.0, info.HotShards[0].RequestsPerSecond)
+	assert.Equal(t, int64(25), info.HotShards[0].PendingTasks)
+	assert.Equal(t, int32(2), info.HotShards[1].ShardID)
+	require.Len(t, info.HotWorkflows, 2)
+	assert.Equal(t, testWorkflowID, info.HotWorkflows[0].WorkflowID)
+	assert.Equal(t, testWorkflowID2, info.HotWorkflows[1].WorkflowID)
+	assert.Equal(t, int32(2), info.HotWorkflows[1].ShardID)
+
+	// pending task counts which are not refreshed are dropped
+	timeSource.Advance(pendingTasksTTL + time.Second)
+	info = tracker.Describe()
+	assert.Zero(t, info.PendingTasks)
+	assert.Empty(t, info.HotShards)
+	assert.Empty(t, info.HotWorkflows)
+
+	tracker.config.EnableHotShardTracking = dynamicconfig.GetBoolPropertyFn(false)
+	assert.Nil(t, tracker.Describe())
+}
+
+func TestShouldShed(t *testing.T) {
+	tracker, timeSource := newTestTracker(true)
+
+	for i := 0; i < 30; i++ {
+		tracker.RecordRequest(1, testDomainID, testWorkflowID)
+	}
+	tracker.RecordRequest(2, testDomainID, testWorkflowID2)
+
+	// host is not overloaded
+	assert.False(t, tracker.ShouldShed(1, testDomainID, testWorkflowID))
+
+	tracker.RecordPendingTasks(2, "transfer", 200)
+	timeSource.Advance(snapshotInterval)
+	assert.True(t, tracker.ShouldShed(1, testDomainID, testWorkflowID))
+	assert.True(t, tracker.ShouldShed(1, testDomainID, "other-workflow"))
+	assert.False(t, tracker.ShouldShed(2, testDomainID, testWorkflowID2))
+
+	tracker.config.EnableHotShardLoadShedding = dynamicconfig.GetBoolPropertyFn(false)
+	assert.False(t, tracker.ShouldShed(1, testDomainID, testWorkflowID))
+}
+
+func TestShouldShed_CPUThreshold(t *testing.T) {
+	tracker, timeSource := newTestTracker(true)
+	tracker.config.HotShardLoadSheddingPendingTasksThreshold = dynamicconfig.GetIntPropertyFn(0)
+	tracker.config.HotShardLoadSheddingCPUThreshold = dynamicconfig.GetFloatPropertyFn(0.5)
+
+	var cpuTime time.Duration
+	tracker.cpuTimeFn = func() (time.Duration, error) { return cpuTime, nil }
+
+	tracker.RecordRequest(1, testDomainID, testWorkflowID)
+	assert.False(t, tracker.ShouldShed(1, testDomainID, testWorkflowID))
+
+	cpuTime = snapshotInterval * time.Duration(runtime.NumCPU())
+	timeSource.Advance(snapshotInterval)
+	assert.True(t, tracker.ShouldShed(1, testDomainID, testWorkflowID))
+	assert.Equal(t, 1.0, tracker.Describe().CPUUtilization)
+}
+
+func TestShouldShed_StaleSnapshotWhileRefreshing(t *testing.T) {
+	tracker, timeSource := newTestTracker(true)
+
+	tracker.RecordRequest(1, testDomainID, testWorkflowID)
+	tracker.RecordPendingTasks(1, "transfer", 200)
+	assert.True(t, tracker.ShouldShed(1, testDomainID, testWorkflowID))
+
+	// requests don't wait for the snapshot being refreshed by another request
+	tracker.RecordPendingTasks(1, "transfer", 0)
+	timeSource.Advance(snapshotInterval)
+	tracker.refreshLock.Lock()
+	assert.True(t, tracker.ShouldShed(1, testDomainID, testWorkflowID))
+	tracker.refreshLock.Unlock()
+	assert.False(t, tracker.ShouldShed(1, testDomainID, testWorkflowID))
+}
+
+func TestRecordRequest_MaxTrackedWorkflows(t *testing.T) {
+	tracker, timeSource := newTestTracker(false)
+
+	for i := 0; i < maxTrackedWorkflowsPerShard; i++ {
+		tracker.RecordRequest(1, testDomainID, fmt.Sprintf("workflow-%v", i))
+	}
+	tracker.RecordRequest(1, testDomainID, testWorkflowID)
+	load := tracker.getShardLoad(1, timeSource.Now())
+	assert.Len(t, load.workflows, maxTrackedWorkflowsPerShard)
+	assert.NotContains(t, load.workflows, workflowKey{domainID: testDomainID, workflowID: testWorkflowID})
+	assert.Equal(t, float64(maxTrackedWorkflowsPerShard+1)/10, tracker.Describe().RequestsPerSecond)
+
+	// idle workflows are dropped when the snapshot is refreshed
+	timeSource.Advance(2 * tracker.config.HotShardTrackingWindow())
+	tracker.Describe()
+	assert.Empty(t, load.workflows)
+	tracker.RecordRequest(1, testDomainID, testWorkflowID)
+	assert.Contains(t, load.workflows, workflowKey{domainID: testDomainID, workflowID: testWorkflowID})
+}
+
+func TestRecordRequest_Concurrent(t *testing.T) {
+	tracker, timeSource := newTestTracker(true)
+
+	var wg sync.WaitGroup
+	for shardID := 0; shardID < 4; shardID++ {
+		wg.Add(1)
+		go func(shardID int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				tracker.RecordRequest(shardID, testDomainID, testWorkflowID)
+				tracker.ShouldShed(shardID, testDomainID, testWorkflowID)
+			}
+		}(shardID)
+	}
+	wg.Wait()
+
+	timeSource.Advance(snapshotInterval)
+	info := tracker.Describe()
+	assert.Equal(t, 40.0, info.RequestsPerSecond)
+	assert.Len(t, info.HotShards, 4)
+}
+
+func TestNoopTracker(t *testing.T) {
+	tracker := NewNoopTracker()
+	tracker.RecordRequest(1, testDomainID, testWorkflowID)
+	tracker.RecordPendingTasks(1, "transfer", 100)
+	assert.False(t, tracker.ShouldShed(1, testDomainID, testWorkflowID))
+	assert.Nil(t, tracker.Describe())
+}
@@ -0,0 +1,268 @@
+// Copyright (c) 2019 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:generate mockgen -package $GOPACKAGE -source $GOFILE -destination conflict_auditor_mock.go
+
+package ndc
+
+import (
+	"context"
+	"time"
+
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/history/execution"
+	"github.com/uber/cadence/service/history/shard"
+)
+
+type (
+	conflictAuditor interface {
+		recordBranchSwitch(
+			mutableState execution.MutableState,
+			previousBranchIndex int,
+			incomingVersion int64,
+		)
+		recordEventsReapply(
+			mutableState execution.MutableState,
+			events []*types.HistoryEvent,
+			result *eventsReapplyResult,
+		)
+	}
+
+	conflictAuditorImpl struct {
+		shard           shard.Context
+		clusterMetadata cluster.Metadata
+		metricsClient   metrics.Client
+		logger          log.Logger
+		// pendingWrites bounds the number of records being written, so that a slow
+		// database neither delays replication nor piles up goroutines
+		pendingWrites chan struct{}
+	}
+
+	// eventsReapplyResult describes how the events of a non current branch were reapplied
+	eventsReapplyResult struct {
+		// reappliedEvents are the events actually reapplied, nil means all events
+		reappliedEvents []*types.HistoryEvent
+		// runID is the run the events were reapplied to, empty if the events were forwarded or skipped
+		runID     string
+		forwarded bool
+		skipped   bool
+	}
+)
+
+const (
+	conflictAuditWriteTimeout = 10 * time.Second
+)
+
+var _ conflictAuditor = (*conflictAuditorImpl)(nil)
+
+func newConflictAuditor(
+	shard shard.Context,
+	logger log.Logger,
+) conflictAuditor {
+
+	return &conflictAuditorImpl{
+		shard:           shard,
+		clusterMetadata: shard.GetClusterMetadata(),
+		metricsClient:   shard.GetMetricsClient(),
+		logger:          logger,
+		pendingWrites:   make(chan struct{}, shard.GetConfig().NDCConflictResolutionAuditMaxPendingWrites()),
+	}
+}
+
+// recordBranchSwitch persists the switch of the current branch of a workflow, from the previous branch
+// to the branch of the incoming version which wins because of its higher version
+func (a *conflictAuditorImpl) recordBranchSwitch(
+	mutableState execution.MutableState,
+	previousBranchIndex int,
+	incomingVersion int64,
+) {
+
+	if !a.isEnabled(mutableState) {
+		return
+	}
+
+	versionHistories := mutableState.GetVersionHistories()
+	if versionHistories == nil {
+		return
+	}
+	previousVersionHistory, err := versionHistories.GetVersionHistory(previousBranchIndex)
+	if err != nil {
+		a.logger.Warn("Failed to find previous branch for NDC conflict resolution record.", tag.Error(err))
+		return
+	}
+	previousLastItem, err := previousVersionHistory.GetLastItem()
+	if err != nil {
+		a.logger.Warn("Failed to find previous branch for NDC conflict resolution record.", tag.Error(err))
+		return
+	}
+
+	record := a.newRecord(mutableState, types.NDCConflictResolutionTypeBranchSwitch)
+	record.SourceBranchIndex = int32(previousBranchIndex)
+	record.TargetBranchIndex = int32(versionHistories.GetCurrentVersionHistoryIndex())
+	record.WinningVersion = incomingVersion
+	record.WinningCluster = a.getClusterName(incomingVersion)
+	record.LosingVersion = previousLastItem.Version
+	record.LosingCluster = a.getClusterName(previousLastItem.Version)
+	a.record(mutableState, record)
+}
+
+// recordEventsReapply persists the reapplication of the events of a non current branch of a workflow
+// to the current branch, which wins because of its higher version
+func (a *conflictAuditorImpl) recordEventsReapply(
+	mutableState execution.MutableState,
+	events []*types.HistoryEvent,
+	result *eventsReapplyResult,
+) {
+
+	if len(events) == 0 || !a.isEnabled(mutableState) {
+		return
+	}
+
+	versionHistories := mutableState.GetVersionHistories()
+	if versionHistories == nil {
+		return
+	}
+	currentVersionHistory, err := versionHistories.GetCurrentVersionHistory()
+	if err != nil {
+		a.logger.Warn("Failed to find current branch for NDC conflict resolution record.", tag.Error(err))
+		return
+	}
+	currentLastItem, err := currentVersionHistory.GetLastItem()
+	if err != nil {
+		a.logger.Warn("Failed to find current branch for NDC conflict resolution record.", tag.Error(err))
+		return
+	}
+	lastEvent := events[len(events)-1]
+	sourceBranchIndex, _, err := versionHistories.FindFirstVersionHistoryByItem(
+		persistence.NewVersionHistoryItem(lastEvent.ID, lastEvent.Version),
+	)
+	if err != nil {
+		a.logger.Warn("Failed to find source branch for NDC conflict resolution record.", tag.Error(err))
+		return
+	}
+
+	reappliedEvents := result.reappliedEvents
+	if reappliedEvents == nil {
+		reappliedEvents = events
+	}
+
+	record := a.newRecord(mutableState, types.NDCConflictResolutionTypeEventsReapply)
+	record.SourceBranchIndex = int32(sourceBranchIndex)
+	record.TargetBranchIndex = int32(versionHistories.GetCurrentVersionHistoryIndex())
+	record.WinningVersion = currentLastItem.Version
+	record.WinningCluster = a.getClusterName(currentLastItem.Version)
+	record.LosingVersion = lastEvent.Version
+	record.LosingCluster = a.getClusterName(lastEvent.Version)
+	record.ReappliedToRunID = result.runID
+	record.ReapplyForwarded = result.forwarded
+	record.ReapplySkipped = result.skipped
+	if !result.skipped {
+		for _, event := range reappliedEvents {
+			record.ReappliedEvents = append(record.ReappliedEvents, &types.NDCReappliedEvent{
+				EventID:   event.ID,
+				Version:   event.Version,
+				EventType: event.EventType,
+			})
+		}
+	}
+	a.record(mutableState, record)
+}
+
+func (a *conflictAuditorImpl) isEnabled(
+	mutableState execution.MutableState,
+) bool {
+
+	return a.shard.GetConfig().EnableNDCConflictResolutionAudit(mutableState.GetDomainEntry().GetInfo().Name)
+}
+
+func (a *conflictAuditorImpl) newRecord(
+	mutableState execution.MutableState,
+	resolutionType types.NDCConflictResolutionType,
+) *types.NDCConflictResolutionRecord {
+
+	executionInfo := mutableState.GetExecutionInfo()
+	return &types.NDCConflictResolutionRecord{
+		Type:             resolutionType,
+		DomainID:         executionInfo.DomainID,
+		WorkflowID:       executionInfo.WorkflowID,
+		RunID:            executionInfo.RunID,
+		Timestamp:        a.shard.GetTimeSource().Now().UnixNano(),
+		VersionHistories: mutableState.GetVersionHistories().ToInternalType(),
+	}
+}
+
+func (a *conflictAuditorImpl) getClusterName(
+	version int64,
+) string {
+
+	clusterName, err := a.clusterMetadata.ClusterNameForFailoverVersion(version)
+	if err != nil {
+		return ""
+	}
+	return clusterName
+}
+
+// record persists the conflict resolution record in the background, keyed by the workflow run. The record
+// is dropped if too many records are being written, failures are only logged since the audit must not
+// block replication
+func (a *conflictAuditorImpl) record(
+	mutableState execution.MutableState,
+	record *types.NDCConflictResolutionRecord,
+) {
+
+	domainName := mutableState.GetDomainEntry().GetInfo().Name
+	logger := a.logger.WithTags(
+		tag.WorkflowDomainName(domainName),
+		tag.WorkflowID(record.WorkflowID),
+		tag.WorkflowRunID(record.RunID),
+	)
+	select {
+	case a.pendingWrites <- struct{}{}:
+	default:
+		a.metricsClient.Scope(metrics.ReplicateHistoryEventsScope, metrics.DomainTag(domainName)).
+			IncCounter(metrics.NDCConflictResolutionAuditDropped)
+		logger.Warn("NDC conflict resolution record dropped, too many records are being written.")
+		return
+	}
+
+	go func() {
+		defer func() { <-a.pendingWrites }()
+		defer func() { log.CapturePanic(recover(), logger, nil) }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), conflictAuditWriteTimeout)
+		defer cancel()
+		if err := a.shard.GetExecutionManager().CreateNDCConflictResolution(ctx, &persistence.CreateNDCConflictResolutionRequest{
+			Record:     record,
+			TTL:        a.shard.GetConfig().NDCConflictResolutionAuditTTL(domainName),
+			DomainName: domainName,
+		}); err != nil {
+			a.metricsClient.Scope(metrics.ReplicateHistoryEventsScope, metrics.DomainTag(domainName)).
+				IncCounter(metrics.NDCConflictResolutionAuditFailed)
+			logger.Error("Failed to persist NDC conflict resolution record.", tag.Error(err))
+			return
+		}
+		logger.Info("NDC conflict resolution recorded.", tag.Value(record.Type.String()))
+	}()
+}
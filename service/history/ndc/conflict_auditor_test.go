@@ -0,0 +1,291 @@
+// Copyright (c) 2019 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ndc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/dynamicconfig"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/history/config"
+	"github.com/uber/cadence/service/history/execution"
+	"github.com/uber/cadence/service/history/shard"
+)
+
+type (
+	conflictAuditorSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		controller       *gomock.Controller
+		mockShard        *shard.TestContext
+		mockMutableState *execution.MockMutableState
+		mockExecutionMgr *mocks.ExecutionManager
+		config           *config.Config
+
+		domainID   string
+		domainName string
+		workflowID string
+		runID      string
+
+		conflictAuditor *conflictAuditorImpl
+	}
+)
+
+func TestConflictAuditorSuite(t *testing.T) {
+	s := new(conflictAuditorSuite)
+	suite.Run(t, s)
+}
+
+func (s *conflictAuditorSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.controller = gomock.NewController(s.T())
+	s.mockMutableState = execution.NewMockMutableState(s.controller)
+
+	s.config = config.NewForTest()
+	s.config.EnableNDCConflictResolutionAudit = dynamicconfig.GetBoolPropertyFnFilteredByDomain(true)
+	s.mockShard = shard.NewTestContext(
+		s.T(),
+		s.controller,
+		&persistence.ShardInfo{
+			ShardID:          10,
+			RangeID:          1,
+			TransferAckLevel: 0,
+		},
+		s.config,
+	)
+	s.mockExecutionMgr = s.mockShard.Resource.ExecutionMgr
+
+	s.domainID = uuid.New()
+	s.domainName = "some random domain name"
+	s.workflowID = "some random workflow ID"
+	s.runID = uuid.New()
+
+	s.mockMutableState.EXPECT().GetDomainEntry().Return(cache.NewGlobalDomainCacheEntryForTest(
+		&persistence.DomainInfo{ID: s.domainID, Name: s.domainName},
+		&persistence.DomainConfig{},
+		&persistence.DomainReplicationConfig{
+			ActiveClusterName: cluster.TestCurrentClusterName,
+			Clusters: []*persistence.ClusterReplicationConfig{
+				{ClusterName: cluster.TestCurrentClusterName},
+				{ClusterName: cluster.TestAlternativeClusterName},
+			},
+		},
+		1,
+	)).AnyTimes()
+	s.mockMutableState.EXPECT().GetExecutionInfo().Return(&persistence.WorkflowExecutionInfo{
+		DomainID:   s.domainID,
+		WorkflowID: s.workflowID,
+		RunID:      s.runID,
+	}).AnyTimes()
+
+	s.conflictAuditor = newConflictAuditor(s.mockShard, s.mockShard.GetLogger()).(*conflictAuditorImpl)
+}
+
+func (s *conflictAuditorSuite) TearDownTest() {
+	s.controller.Finish()
+	s.mockShard.Finish(s.T())
+}
+
+func (s *conflictAuditorSuite) TestRecordBranchSwitch() {
+	// branch 0 is written by the alternative cluster, branch 1 by the current cluster with a higher version
+	versionHistories := s.newVersionHistories(
+		[]*persistence.VersionHistoryItem{persistence.NewVersionHistoryItem(5, cluster.TestAlternativeClusterInitialFailoverVersion)},
+		[]*persistence.VersionHistoryItem{
+			persistence.NewVersionHistoryItem(3, cluster.TestAlternativeClusterInitialFailoverVersion),
+			persistence.NewVersionHistoryItem(6, cluster.TestFailoverVersionIncrement),
+		},
+	)
+	s.NoError(versionHistories.SetCurrentVersionHistoryIndex(1))
+	s.mockMutableState.EXPECT().GetVersionHistories().Return(versionHistories).AnyTimes()
+
+	recordCh := s.expectRecord(nil)
+	s.conflictAuditor.recordBranchSwitch(s.mockMutableState, 0, cluster.TestFailoverVersionIncrement)
+	record := s.waitRecord(recordCh)
+
+	s.Equal(types.NDCConflictResolutionTypeBranchSwitch, record.Type)
+	s.Equal(s.domainID, record.DomainID)
+	s.Equal(s.workflowID, record.WorkflowID)
+	s.Equal(s.runID, record.RunID)
+	s.Equal(int32(0), record.SourceBranchIndex)
+	s.Equal(int32(1), record.TargetBranchIndex)
+	s.Equal(cluster.TestFailoverVersionIncrement, record.WinningVersion)
+	s.Equal(cluster.TestCurrentClusterName, record.WinningCluster)
+	s.Equal(cluster.TestAlternativeClusterInitialFailoverVersion, record.LosingVersion)
+	s.Equal(cluster.TestAlternativeClusterName, record.LosingCluster)
+	s.Equal(versionHistories.ToInternalType(), record.VersionHistories)
+}
+
+func (s *conflictAuditorSuite) TestRecordEventsReapply() {
+	// branch 0 is the current branch written by the current cluster with a higher version,
+	// branch 1 contains the events written by the alternative cluster
+	versionHistories := s.newVersionHistories(
+		[]*persistence.VersionHistoryItem{
+			persistence.NewVersionHistoryItem(3, cluster.TestAlternativeClusterInitialFailoverVersion),
+			persistence.NewVersionHistoryItem(7, cluster.TestFailoverVersionIncrement),
+		},
+		[]*persistence.VersionHistoryItem{persistence.NewVersionHistoryItem(5, cluster.TestAlternativeClusterInitialFailoverVersion)},
+	)
+	s.mockMutableState.EXPECT().GetVersionHistories().Return(versionHistories).AnyTimes()
+	events := []*types.HistoryEvent{
+		{ID: 4, Version: cluster.TestAlternativeClusterInitialFailoverVersion, EventType: types.EventTypeWorkflowExecutionSignaled.Ptr()},
+		{ID: 5, Version: cluster.TestAlternativeClusterInitialFailoverVersion, EventType: types.EventTypeDecisionTaskScheduled.Ptr()},
+	}
+
+	recordCh := s.expectRecord(nil)
+	s.conflictAuditor.recordEventsReapply(s.mockMutableState, events, &eventsReapplyResult{
+		reappliedEvents: events[:1],
+		runID:           s.runID,
+	})
+	record := s.waitRecord(recordCh)
+
+	s.Equal(types.NDCConflictResolutionTypeEventsReapply, record.Type)
+	s.Equal(int32(1), record.SourceBranchIndex)
+	s.Equal(int32(0), record.TargetBranchIndex)
+	s.Equal(cluster.TestFailoverVersionIncrement, record.WinningVersion)
+	s.Equal(cluster.TestCurrentClusterName, record.WinningCluster)
+	s.Equal(cluster.TestAlternativeClusterInitialFailoverVersion, record.LosingVersion)
+	s.Equal(cluster.TestAlternativeClusterName, record.LosingCluster)
+	s.Equal(s.runID, record.ReappliedToRunID)
+	s.False(record.ReapplyForwarded)
+	s.False(record.ReapplySkipped)
+	s.Equal([]*types.NDCReappliedEvent{
+		{EventID: 4, Version: cluster.TestAlternativeClusterInitialFailoverVersion, EventType: types.EventTypeWorkflowExecutionSignaled.Ptr()},
+	}, record.ReappliedEvents)
+}
+
+func (s *conflictAuditorSuite) TestRecordEventsReapply_Skipped() {
+	versionHistories := s.newVersionHistories(
+		[]*persistence.VersionHistoryItem{
+			persistence.NewVersionHistoryItem(3, cluster.TestAlternativeClusterInitialFailoverVersion),
+			persistence.NewVersionHistoryItem(7, cluster.TestFailoverVersionIncrement),
+		},
+		[]*persistence.VersionHistoryItem{persistence.NewVersionHistoryItem(5, cluster.TestAlternativeClusterInitialFailoverVersion)},
+	)
+	s.mockMutableState.EXPECT().GetVersionHistories().Return(versionHistories).AnyTimes()
+	events := []*types.HistoryEvent{
+		{ID: 5, Version: cluster.TestAlternativeClusterInitialFailoverVersion, EventType: types.EventTypeWorkflowExecutionSignaled.Ptr()},
+	}
+
+	recordCh := s.expectRecord(nil)
+	s.conflictAuditor.recordEventsReapply(s.mockMutableState, events, &eventsReapplyResult{skipped: true})
+	record := s.waitRecord(recordCh)
+
+	s.True(record.ReapplySkipped)
+	s.Empty(record.ReappliedToRunID)
+	s.Empty(record.ReappliedEvents)
+}
+
+func (s *conflictAuditorSuite) TestRecord_Disabled() {
+	s.config.EnableNDCConflictResolutionAudit = dynamicconfig.GetBoolPropertyFnFilteredByDomain(false)
+
+	s.conflictAuditor.recordBranchSwitch(s.mockMutableState, 0, cluster.TestFailoverVersionIncrement)
+	s.conflictAuditor.recordEventsReapply(
+		s.mockMutableState,
+		[]*types.HistoryEvent{{ID: 5, Version: cluster.TestAlternativeClusterInitialFailoverVersion}},
+		&eventsReapplyResult{forwarded: true},
+	)
+}
+
+func (s *conflictAuditorSuite) TestRecord_Dropped() {
+	s.mockMutableState.EXPECT().GetVersionHistories().Return(s.newVersionHistories(
+		[]*persistence.VersionHistoryItem{persistence.NewVersionHistoryItem(5, cluster.TestAlternativeClusterInitialFailoverVersion)},
+		[]*persistence.VersionHistoryItem{
+			persistence.NewVersionHistoryItem(3, cluster.TestAlternativeClusterInitialFailoverVersion),
+			persistence.NewVersionHistoryItem(6, cluster.TestFailoverVersionIncrement),
+		},
+	)).AnyTimes()
+	for i := 0; i < cap(s.conflictAuditor.pendingWrites); i++ {
+		s.conflictAuditor.pendingWrites <- struct{}{}
+	}
+
+	// no write is expected while the pending writes are at their limit
+	s.conflictAuditor.recordBranchSwitch(s.mockMutableState, 0, cluster.TestFailoverVersionIncrement)
+}
+
+func (s *conflictAuditorSuite) TestRecord_WriteFailed() {
+	s.mockMutableState.EXPECT().GetVersionHistories().Return(s.newVersionHistories(
+		[]*persistence.VersionHistoryItem{persistence.NewVersionHistoryItem(5, cluster.TestAlternativeClusterInitialFailoverVersion)},
+		[]*persistence.VersionHistoryItem{
+			persistence.NewVersionHistoryItem(3, cluster.TestAlternativeClusterInitialFailoverVersion),
+			persistence.NewVersionHistoryItem(6, cluster.TestFailoverVersionIncrement),
+		},
+	)).AnyTimes()
+
+	recordCh := s.expectRecord(errors.New("some random error"))
+	s.conflictAuditor.recordBranchSwitch(s.mockMutableState, 0, cluster.TestFailoverVersionIncrement)
+	s.waitRecord(recordCh)
+	s.Eventually(func() bool {
+		return len(s.conflictAuditor.pendingWrites) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func (s *conflictAuditorSuite) newVersionHistories(
+	items0 []*persistence.VersionHistoryItem,
+	items1 []*persistence.VersionHistoryItem,
+) *persistence.VersionHistories {
+
+	versionHistories := persistence.NewVersionHistories(persistence.NewVersionHistory([]byte("branch token 0"), items0))
+	_, _, err := versionHistories.AddVersionHistory(persistence.NewVersionHistory([]byte("branch token 1"), items1))
+	s.NoError(err)
+	return versionHistories
+}
+
+func (s *conflictAuditorSuite) expectRecord(
+	err error,
+) <-chan *types.NDCConflictResolutionRecord {
+
+	recordCh := make(chan *types.NDCConflictResolutionRecord, 1)
+	s.mockExecutionMgr.On("CreateNDCConflictResolution", mock.Anything, mock.MatchedBy(
+		func(request *persistence.CreateNDCConflictResolutionRequest) bool {
+			return request.DomainName == s.domainName && request.TTL == s.config.NDCConflictResolutionAuditTTL(s.domainName)
+		},
+	)).Run(func(args mock.Arguments) {
+		recordCh <- args.Get(1).(*persistence.CreateNDCConflictResolutionRequest).Record
+	}).Return(err).Once()
+	return recordCh
+}
+
+func (s *conflictAuditorSuite) waitRecord(
+	recordCh <-chan *types.NDCConflictResolutionRecord,
+) *types.NDCConflictResolutionRecord {
+
+	select {
+	case record := <-recordCh:
+		return record
+	case <-time.After(time.Second):
+		s.FailNow("conflict resolution record is not written")
+		return nil
+	}
+}
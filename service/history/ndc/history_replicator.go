@@ -62,6 +62,7 @@ type (
 		executionCache     *execution.Cache
 		eventsReapplier    EventsReapplier
 		transactionManager transactionManager
+		conflictAuditor    conflictAuditor
 		logger             log.Logger
 
 		newBranchManager    branchManagerProvider
@@ -125,6 +126,7 @@ func NewHistoryReplicator(
 		executionCache:     executionCache,
 		transactionManager: transactionManager,
 		eventsReapplier:    eventsReapplier,
+		conflictAuditor:    transactionManager.conflictAuditor,
 		logger:             logger.WithTags(tag.ComponentHistoryReplicator),
 
 		newBranchManager: func(
@@ -244,13 +246,20 @@ func (r *historyReplicatorImpl) applyEvents(
 				return nil
 			}
 
+			previousBranchIndex := mutableState.GetVersionHistories().GetCurrentVersionHistoryIndex()
 			mutableState, isRebuilt, err := r.applyNonStartEventsPrepareMutableState(ctx, context, mutableState, branchIndex, task)
 			if err != nil {
 				return err
 			}
 
 			if mutableState.GetVersionHistories().GetCurrentVersionHistoryIndex() == branchIndex {
-				return r.applyNonStartEventsToCurrentBranch(ctx, context, mutableState, isRebuilt, releaseFn, task)
+				if err := r.applyNonStartEventsToCurrentBranch(ctx, context, mutableState, isRebuilt, releaseFn, task); err != nil {
+					return err
+				}
+				if isRebuilt {
+					r.conflictAuditor.recordBranchSwitch(mutableState, previousBranchIndex, task.getVersion())
+				}
+				return nil
 			}
 			return r.applyNonStartEventsToNoneCurrentBranch(ctx, context, mutableState, branchIndex, releaseFn, task)
 
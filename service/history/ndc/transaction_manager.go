@@ -152,6 +152,7 @@ type (
 		metricsClient    metrics.Client
 		workflowResetter reset.WorkflowResetter
 		eventsReapplier  EventsReapplier
+		conflictAuditor  conflictAuditor
 		logger           log.Logger
 
 		createManager transactionManagerForNewWorkflow
@@ -181,6 +182,7 @@ func newTransactionManager(
 			logger,
 		),
 		eventsReapplier: eventsReapplier,
+		conflictAuditor: newConflictAuditor(shard, logger),
 		logger:          logger.WithTags(tag.ComponentHistoryReplicator),
 
 		createManager: nil,
@@ -244,7 +246,7 @@ func (r *transactionManagerImpl) backfillWorkflow(
 		return err
 	}
 
-	updateMode, transactionPolicy, err := r.backfillWorkflowEventsReapply(
+	updateMode, transactionPolicy, reapplyResult, err := r.backfillWorkflowEventsReapply(
 		ctx,
 		targetWorkflow,
 		targetWorkflowEvents,
@@ -253,7 +255,7 @@ func (r *transactionManagerImpl) backfillWorkflow(
 		return err
 	}
 
-	return targetWorkflow.GetContext().UpdateWorkflowExecutionWithNew(
+	if err := targetWorkflow.GetContext().UpdateWorkflowExecutionWithNew(
 		ctx,
 		now,
 		updateMode,
@@ -262,25 +264,38 @@ func (r *transactionManagerImpl) backfillWorkflow(
 		transactionPolicy,
 		nil,
 		persistence.CreateWorkflowRequestModeReplicated,
+	); err != nil {
+		return err
+	}
+
+	r.conflictAuditor.recordEventsReapply(
+		targetWorkflow.GetMutableState(),
+		targetWorkflowEvents.Events,
+		reapplyResult,
 	)
+	return nil
 }
 
 func (r *transactionManagerImpl) backfillWorkflowEventsReapply(
 	ctx context.Context,
 	targetWorkflow execution.Workflow,
 	targetWorkflowEvents *persistence.WorkflowEvents,
-) (persistence.UpdateWorkflowMode, execution.TransactionPolicy, error) {
+) (persistence.UpdateWorkflowMode, execution.TransactionPolicy, *eventsReapplyResult, error) {
 
 	isCurrentWorkflow, err := r.isWorkflowCurrent(ctx, targetWorkflow)
 	if err != nil {
-		return 0, execution.TransactionPolicyActive, err
+		return 0, execution.TransactionPolicyActive, nil, err
 	}
 	isWorkflowRunning := targetWorkflow.GetMutableState().IsWorkflowExecutionRunning()
 	targetWorkflowActiveCluster, err := r.clusterMetadata.ClusterNameForFailoverVersion(
-		targetWorkflow.GetMutableState().GetDomainEntry().GetFailoverVersion(),
+		execution.GetFailoverVersionForWorkflow(
+			targetWorkflow.GetMutableState().GetDomainEntry(),
+			r.clusterMetadata,
+			targetWorkflow.GetMutableState().GetExecutionInfo(),
+		),
 	)
 	if err != nil {
-		return 0, execution.TransactionPolicyActive, err
+		return 0, execution.TransactionPolicyActive, nil, err
 	}
 	currentCluster := r.clusterMetadata.GetCurrentClusterName()
 	isActiveCluster := targetWorkflowActiveCluster == currentCluster
@@ -296,15 +311,20 @@ func (r *transactionManagerImpl) backfillWorkflowEventsReapply(
 	if isCurrentWorkflow && isActiveCluster {
 		// case 1.a
 		if isWorkflowRunning {
-			if _, err := r.eventsReapplier.ReapplyEvents(
+			runID := targetWorkflow.GetMutableState().GetExecutionInfo().RunID
+			reappliedEvents, err := r.eventsReapplier.ReapplyEvents(
 				ctx,
 				targetWorkflow.GetMutableState(),
 				targetWorkflowEvents.Events,
-				targetWorkflow.GetMutableState().GetExecutionInfo().RunID,
-			); err != nil {
-				return 0, execution.TransactionPolicyActive, err
+				runID,
+			)
+			if err != nil {
+				return 0, execution.TransactionPolicyActive, nil, err
 			}
-			return persistence.UpdateWorkflowModeUpdateCurrent, execution.TransactionPolicyActive, nil
+			return persistence.UpdateWorkflowModeUpdateCurrent, execution.TransactionPolicyActive, &eventsReapplyResult{
+				reappliedEvents: reappliedEvents,
+				runID:           runID,
+			}, nil
 		}
 
 		// case 1.b
@@ -325,20 +345,20 @@ func (r *transactionManagerImpl) backfillWorkflowEventsReapply(
 				tag.WorkflowID(workflowID),
 			)
 			r.metricsClient.IncCounter(metrics.HistoryReapplyEventsScope, metrics.EventReapplySkippedCount)
-			return persistence.UpdateWorkflowModeBypassCurrent, execution.TransactionPolicyPassive, nil
+			return persistence.UpdateWorkflowModeBypassCurrent, execution.TransactionPolicyPassive, &eventsReapplyResult{skipped: true}, nil
 		}
 
 		baseVersionHistories := baseMutableState.GetVersionHistories()
 		if baseVersionHistories == nil {
-			return 0, execution.TransactionPolicyActive, execution.ErrMissingVersionHistories
+			return 0, execution.TransactionPolicyActive, nil, execution.ErrMissingVersionHistories
 		}
 		baseCurrentVersionHistory, err := baseVersionHistories.GetCurrentVersionHistory()
 		if err != nil {
-			return 0, execution.TransactionPolicyActive, err
+			return 0, execution.TransactionPolicyActive, nil, err
 		}
 		baseRebuildLastEventVersion, err := baseCurrentVersionHistory.GetEventVersion(baseRebuildLastEventID)
 		if err != nil {
-			return 0, execution.TransactionPolicyActive, err
+			return 0, execution.TransactionPolicyActive, nil, err
 		}
 		baseCurrentBranchToken := baseCurrentVersionHistory.GetBranchToken()
 		baseNextEventID := baseMutableState.GetNextEventID()
@@ -359,11 +379,11 @@ func (r *transactionManagerImpl) backfillWorkflowEventsReapply(
 			targetWorkflowEvents.Events,
 			false,
 		); err != nil {
-			return 0, execution.TransactionPolicyActive, err
+			return 0, execution.TransactionPolicyActive, nil, err
 		}
 		// after the reset of target workflow (current workflow) with additional events to be reapplied
 		// target workflow is no longer the current workflow
-		return persistence.UpdateWorkflowModeBypassCurrent, execution.TransactionPolicyPassive, nil
+		return persistence.UpdateWorkflowModeBypassCurrent, execution.TransactionPolicyPassive, &eventsReapplyResult{runID: resetRunID}, nil
 	}
 
 	// case 2
@@ -371,13 +391,14 @@ func (r *transactionManagerImpl) backfillWorkflowEventsReapply(
 	if err := targetWorkflow.GetContext().ReapplyEvents(
 		[]*persistence.WorkflowEvents{targetWorkflowEvents},
 	); err != nil {
-		return 0, execution.TransactionPolicyActive, err
+		return 0, execution.TransactionPolicyActive, nil, err
 	}
 
+	reapplyResult := &eventsReapplyResult{forwarded: true}
 	if isCurrentWorkflow {
-		return persistence.UpdateWorkflowModeUpdateCurrent, execution.TransactionPolicyPassive, nil
+		return persistence.UpdateWorkflowModeUpdateCurrent, execution.TransactionPolicyPassive, reapplyResult, nil
 	}
-	return persistence.UpdateWorkflowModeBypassCurrent, execution.TransactionPolicyPassive, nil
+	return persistence.UpdateWorkflowModeBypassCurrent, execution.TransactionPolicyPassive, reapplyResult, nil
 }
 
 func (r *transactionManagerImpl) checkWorkflowExists(
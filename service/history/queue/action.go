@@ -20,7 +20,12 @@
 
 package queue
 
-import "github.com/uber/cadence/common/types"
+import (
+	"time"
+
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/history/task"
+)
 
 type (
 	// ActionType specifies the type of the Action
@@ -33,6 +38,8 @@ type (
 		GetStateActionAttributes *GetStateActionAttributes
 		GetTasksAttributes       *GetTasksAttributes
 		UpdateTaskAttributes     *UpdateTasksAttributes
+		ListTasksAttributes      *ListTasksAttributes
+		ExecuteTaskAttributes    *ExecuteTaskAttributes
 		// add attributes for other action types here
 	}
 
@@ -43,6 +50,8 @@ type (
 		GetStateActionResult *GetStateActionResult
 		GetTasksResult       *GetTasksResult
 		UpdateTaskResult     *UpdateTasksResult
+		ListTasksResult      *ListTasksResult
+		ExecuteTaskResult    *ExecuteTaskResult
 	}
 
 	// ResetActionAttributes contains the parameter for performing Reset Action
@@ -70,6 +79,27 @@ type (
 	// UpdateTasksResult is the result for performing UpdateTask Action
 	UpdateTasksResult struct {
 	}
+
+	// ListTasksAttributes contains the parameter to list outstanding tasks,
+	// zero value for a range boundary means the boundary is not specified
+	ListTasksAttributes struct {
+		MinTaskID              int64
+		MaxTaskID              int64
+		MinVisibilityTimestamp time.Time
+		MaxVisibilityTimestamp time.Time
+		PageSize               int
+	}
+	// ListTasksResult is the result for performing ListTasks Action
+	ListTasksResult struct {
+		Tasks []task.Task
+	}
+
+	// ExecuteTaskAttributes contains the parameter to force execute an outstanding task
+	ExecuteTaskAttributes struct {
+		TaskID int64
+	}
+	// ExecuteTaskResult is the result for performing ExecuteTask Action
+	ExecuteTaskResult struct{}
 )
 
 const (
@@ -81,6 +111,10 @@ const (
 	ActionTypeGetTasks
 	// ActionTypeUpdateTask is the ActionType to update outstanding task
 	ActionTypeUpdateTask
+	// ActionTypeListTasks is the ActionType for listing outstanding tasks
+	ActionTypeListTasks
+	// ActionTypeExecuteTask is the ActionType for force executing an outstanding task
+	ActionTypeExecuteTask
 	// add more ActionType here
 )
 
@@ -120,3 +154,27 @@ func NewUpdateTasksAction(
 		},
 	}
 }
+
+// NewListTasksAction creates a queue action for listing outstanding tasks
+// that are loaded by the queue processor but not yet acked
+func NewListTasksAction(
+	attributes *ListTasksAttributes,
+) *Action {
+	return &Action{
+		ActionType:          ActionTypeListTasks,
+		ListTasksAttributes: attributes,
+	}
+}
+
+// NewExecuteTaskAction creates a queue action for force executing an outstanding
+// task that is waiting to be redispatched
+func NewExecuteTaskAction(
+	taskID int64,
+) *Action {
+	return &Action{
+		ActionType: ActionTypeExecuteTask,
+		ExecuteTaskAttributes: &ExecuteTaskAttributes{
+			TaskID: taskID,
+		},
+	}
+}
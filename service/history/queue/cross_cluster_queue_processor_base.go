@@ -737,6 +737,7 @@ func newCrossClusterQueueProcessorOptions(
 		EnableSplit:                          dynamicconfig.GetBoolPropertyFn(false),
 		EnablePersistQueueStates:             dynamicconfig.GetBoolPropertyFn(true),
 		EnableLoadQueueStates:                dynamicconfig.GetBoolPropertyFn(true),
+		EnableDomainVirtualQueue:             dynamicconfig.GetBoolPropertyFn(false),
 		MaxPendingTaskSize:                   config.CrossClusterSourceProcessorMaxPendingTaskSize,
 		MetricScope:                          metrics.CrossClusterQueueProcessorScope,
 	}
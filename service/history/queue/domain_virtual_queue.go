@@ -0,0 +1,156 @@
+// Copyright (c) 2017-2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package queue
+
+import (
+	"sync"
+
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/dynamicconfig"
+	"github.com/uber/cadence/common/quotas"
+)
+
+// domainVirtualQueueBaseLevel is the first processing queue level reserved for domain virtual queues.
+// Levels below it are used by the regular split policies. Levels are persisted as part of the processing
+// queue states, so the base must stay above any value history.queueProcessorSplitMaxLevel can be set to.
+// A level is released once its virtual queue finishes and is reused for the next domain split out.
+const domainVirtualQueueBaseLevel = 1000
+
+type (
+	// domainVirtualQueues keeps track of the processing queue level dedicated to each noisy domain.
+	// Each level has its own queue collection, so a virtual queue has an independent read level and
+	// is rate limited separately from the rest of the shard.
+	domainVirtualQueues struct {
+		sync.Mutex
+
+		levelByDomainID map[string]int
+		domainIDByLevel map[int]string
+		rateLimiters    map[int]quotas.Limiter
+
+		maxPollRPS  dynamicconfig.IntPropertyFnWithDomainFilter
+		domainCache cache.DomainCache
+	}
+)
+
+// newDomainVirtualQueues restores the virtual queue levels from persisted processing queue states,
+// so that a domain keeps reading from the same virtual queue after shard reload or failover
+func newDomainVirtualQueues(
+	processingQueueStates []ProcessingQueueState,
+	maxPollRPS dynamicconfig.IntPropertyFnWithDomainFilter,
+	domainCache cache.DomainCache,
+) *domainVirtualQueues {
+	q := &domainVirtualQueues{
+		levelByDomainID: make(map[string]int),
+		domainIDByLevel: make(map[int]string),
+		rateLimiters:    make(map[int]quotas.Limiter),
+		maxPollRPS:      maxPollRPS,
+		domainCache:     domainCache,
+	}
+
+	for _, state := range processingQueueStates {
+		if !isDomainVirtualQueueLevel(state.Level()) {
+			continue
+		}
+
+		domainFilter := state.DomainFilter()
+		if domainFilter.ReverseMatch || len(domainFilter.DomainIDs) != 1 {
+			continue
+		}
+		for domainID := range domainFilter.DomainIDs {
+			q.assignLocked(domainID, state.Level())
+		}
+	}
+
+	return q
+}
+
+// level returns the virtual queue level of the domain, a new level will be allocated
+// if the domain doesn't have one yet
+func (q *domainVirtualQueues) level(domainID string) int {
+	q.Lock()
+	defer q.Unlock()
+
+	if level, ok := q.levelByDomainID[domainID]; ok {
+		return level
+	}
+
+	// reuse the lowest level released by a finished virtual queue
+	level := domainVirtualQueueBaseLevel
+	for {
+		if _, ok := q.domainIDByLevel[level]; !ok {
+			break
+		}
+		level++
+	}
+	q.assignLocked(domainID, level)
+	return level
+}
+
+// release frees the virtual queue level after its queue collection has finished processing,
+// so that the level can be assigned to another domain
+func (q *domainVirtualQueues) release(level int) {
+	q.Lock()
+	defer q.Unlock()
+
+	domainID, ok := q.domainIDByLevel[level]
+	if !ok {
+		return
+	}
+	delete(q.domainIDByLevel, level)
+	delete(q.levelByDomainID, domainID)
+	delete(q.rateLimiters, level)
+}
+
+// rateLimiter returns the rate limiter for the virtual queue at the given level,
+// false will be returned if the level doesn't belong to a virtual queue
+func (q *domainVirtualQueues) rateLimiter(level int) (quotas.Limiter, bool) {
+	q.Lock()
+	defer q.Unlock()
+
+	domainID, ok := q.domainIDByLevel[level]
+	if !ok || q.maxPollRPS == nil {
+		return nil, false
+	}
+
+	if limiter, ok := q.rateLimiters[level]; ok {
+		return limiter, true
+	}
+
+	limiter := quotas.NewDynamicRateLimiter(func() float64 {
+		domainName, err := q.domainCache.GetDomainName(domainID)
+		if err != nil {
+			// fallback to the default value
+			domainName = ""
+		}
+		return float64(q.maxPollRPS(domainName))
+	})
+	q.rateLimiters[level] = limiter
+	return limiter, true
+}
+
+func (q *domainVirtualQueues) assignLocked(domainID string, level int) {
+	q.levelByDomainID[domainID] = level
+	q.domainIDByLevel[level] = domainID
+}
+
+func isDomainVirtualQueueLevel(level int) bool {
+	return level >= domainVirtualQueueBaseLevel
+}
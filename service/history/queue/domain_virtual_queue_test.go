@@ -0,0 +1,97 @@
+// Copyright (c) 2017-2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package queue
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uber/cadence/common/cache"
+)
+
+func TestDomainVirtualQueues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDomainCache := cache.NewMockDomainCache(ctrl)
+	mockDomainCache.EXPECT().GetDomainName("testDomain2").Return("testDomainName2", nil).AnyTimes()
+
+	var requestedDomains []string
+	virtualQueues := newDomainVirtualQueues(
+		[]ProcessingQueueState{
+			NewProcessingQueueState(
+				defaultProcessingQueueLevel,
+				testKey{ID: 0},
+				testKey{ID: 100},
+				NewDomainFilter(map[string]struct{}{"testDomain1": {}}, true),
+			),
+			NewProcessingQueueState(
+				domainVirtualQueueBaseLevel+3,
+				testKey{ID: 0},
+				testKey{ID: 10},
+				NewDomainFilter(map[string]struct{}{"testDomain2": {}}, false),
+			),
+		},
+		func(domain string) int {
+			requestedDomains = append(requestedDomains, domain)
+			return 5
+		},
+		mockDomainCache,
+	)
+
+	// levels are restored from persisted states
+	assert.Equal(t, domainVirtualQueueBaseLevel+3, virtualQueues.level("testDomain2"))
+	// new levels reuse the lowest free level and never collide with restored ones
+	assert.Equal(t, domainVirtualQueueBaseLevel, virtualQueues.level("testDomain1"))
+	assert.Equal(t, domainVirtualQueueBaseLevel, virtualQueues.level("testDomain1"))
+	assert.Equal(t, domainVirtualQueueBaseLevel+1, virtualQueues.level("testDomain3"))
+
+	_, ok := virtualQueues.rateLimiter(defaultProcessingQueueLevel)
+	assert.False(t, ok)
+
+	limiter, ok := virtualQueues.rateLimiter(domainVirtualQueueBaseLevel + 3)
+	assert.True(t, ok)
+	assert.NotNil(t, limiter)
+	assert.Contains(t, requestedDomains, "testDomainName2")
+	sameLimiter, _ := virtualQueues.rateLimiter(domainVirtualQueueBaseLevel + 3)
+	assert.Equal(t, limiter, sameLimiter)
+}
+
+func TestDomainVirtualQueues_Release(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDomainCache := cache.NewMockDomainCache(ctrl)
+	mockDomainCache.EXPECT().GetDomainName(gomock.Any()).Return("testDomainName", nil).AnyTimes()
+
+	virtualQueues := newDomainVirtualQueues(nil, func(string) int { return 5 }, mockDomainCache)
+	assert.Equal(t, domainVirtualQueueBaseLevel, virtualQueues.level("testDomain1"))
+	assert.Equal(t, domainVirtualQueueBaseLevel+1, virtualQueues.level("testDomain2"))
+	_, ok := virtualQueues.rateLimiter(domainVirtualQueueBaseLevel)
+	assert.True(t, ok)
+
+	virtualQueues.release(domainVirtualQueueBaseLevel)
+	_, ok = virtualQueues.rateLimiter(domainVirtualQueueBaseLevel)
+	assert.False(t, ok)
+
+	// the released level is assigned to the next domain split out
+	assert.Equal(t, domainVirtualQueueBaseLevel, virtualQueues.level("testDomain3"))
+	assert.Equal(t, domainVirtualQueueBaseLevel+2, virtualQueues.level("testDomain1"))
+	assert.Equal(t, domainVirtualQueueBaseLevel+1, virtualQueues.level("testDomain2"))
+}
@@ -23,14 +23,19 @@ package queue
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/pborman/uuid"
+
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/quotas"
+	t "github.com/uber/cadence/common/task"
+	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/history/shard"
 	"github.com/uber/cadence/service/history/task"
 )
@@ -57,6 +62,7 @@ type (
 	}
 
 	processorBase struct {
+		id            string
 		shard         shard.Context
 		taskProcessor task.Processor
 		redispatcher  task.Redispatcher
@@ -71,7 +77,8 @@ type (
 		metricsClient metrics.Client
 		metricsScope  metrics.Scope
 
-		rateLimiter quotas.Limiter
+		rateLimiter   quotas.Limiter
+		virtualQueues *domainVirtualQueues
 
 		status         int32
 		shutdownWG     sync.WaitGroup
@@ -96,6 +103,7 @@ func newProcessorBase(
 ) *processorBase {
 	metricsScope := metricsClient.Scope(options.MetricScope).Tagged(metrics.ShardIDTag(shard.GetShardID()))
 	return &processorBase{
+		id:            uuid.New(),
 		shard:         shard,
 		taskProcessor: taskProcessor,
 		redispatcher: task.NewRedispatcher(
@@ -117,6 +125,7 @@ func newProcessorBase(
 		metricsClient:               metricsClient,
 		metricsScope:                metricsScope,
 		rateLimiter:                 quotas.NewDynamicRateLimiter(options.MaxPollRPS.AsFloat64()),
+		virtualQueues:               newDomainVirtualQueues(processingQueueStates, options.DomainVirtualQueueMaxPollRPS, shard.GetDomainCache()),
 		status:                      common.DaemonStatusInitialized,
 		shutdownCh:                  make(chan struct{}),
 		actionNotifyCh:              make(chan actionNotification),
@@ -144,6 +153,8 @@ func (p *processorBase) updateAckLevel() (bool, task.Key, error) {
 		}
 	}
 
+	p.releaseFinishedDomainVirtualQueues()
+
 	if minAckLevel == nil {
 		// note that only failover processor will meet this condition
 		err := p.queueShutdown()
@@ -158,6 +169,7 @@ func (p *processorBase) updateAckLevel() (bool, task.Key, error) {
 	if totalPengingTasks > warnPendingTasks {
 		p.logger.Warn("Too many pending tasks.", tag.Number(int64(totalPengingTasks)))
 	}
+	p.shard.GetService().GetLoadTracker().RecordPendingTasks(p.shard.GetShardID(), p.id, totalPengingTasks)
 	// TODO: consider move pendingTasksTime metrics from shardInfoScope to queue processor scope
 	p.metricsClient.RecordTimer(metrics.ShardInfoScope, getPendingTasksMetricIdx(p.options.MetricScope), time.Duration(totalPengingTasks))
 
@@ -179,6 +191,22 @@ func (p *processorBase) updateAckLevel() (bool, task.Key, error) {
 	return false, minAckLevel, nil
 }
 
+// releaseFinishedDomainVirtualQueues removes the queue collections of domain virtual queues which have
+// processed all their tasks and releases their levels, so that the number of levels stays bounded by
+// the number of domains being split out at the same time
+func (p *processorBase) releaseFinishedDomainVirtualQueues() {
+	remainingCollections := make([]ProcessingQueueCollection, 0, len(p.processingQueueCollections))
+	for _, queueCollection := range p.processingQueueCollections {
+		level := queueCollection.Level()
+		if isDomainVirtualQueueLevel(level) && len(queueCollection.Queues()) == 0 {
+			p.virtualQueues.release(level)
+			continue
+		}
+		remainingCollections = append(remainingCollections, queueCollection)
+	}
+	p.processingQueueCollections = remainingCollections
+}
+
 func (p *processorBase) initializeSplitPolicy(lookAheadFunc lookAheadFunc) ProcessingQueueSplitPolicy {
 	if !p.options.EnableSplit() {
 		return nil
@@ -231,7 +259,20 @@ func (p *processorBase) initializeSplitPolicy(lookAheadFunc lookAheadFunc) Proce
 		return nil
 	}
 
-	return NewAggregatedSplitPolicy(policies...)
+	splitPolicy := NewAggregatedSplitPolicy(policies...)
+	if p.options.EnableDomainVirtualQueue() {
+		splitPolicy = newDomainVirtualQueueSplitPolicy(splitPolicy, p.virtualQueues, p.logger, p.metricsScope)
+	}
+	return splitPolicy
+}
+
+// rateLimiterForLevel returns the rate limiter used for loading tasks for the queue collection at the given level.
+// Domain virtual queues are rate limited independently so that a noisy domain won't slow down the whole shard.
+func (p *processorBase) rateLimiterForLevel(level int) quotas.Limiter {
+	if limiter, ok := p.virtualQueues.rateLimiter(level); ok {
+		return limiter
+	}
+	return p.rateLimiter
 }
 
 func (p *processorBase) splitProcessingQueueCollection(splitPolicy ProcessingQueueSplitPolicy, upsertPollTimeFn func(int, time.Time)) {
@@ -325,6 +366,10 @@ func (p *processorBase) handleActionNotification(notification actionNotification
 		result, err = p.resetProcessingQueueStates()
 	case ActionTypeGetState:
 		result = p.getProcessingQueueStates()
+	case ActionTypeListTasks:
+		result = p.listTasks(notification.action.ListTasksAttributes)
+	case ActionTypeExecuteTask:
+		result, err = p.executeTask(notification.action.ExecuteTaskAttributes)
 	default:
 		err = fmt.Errorf("unknown queue action type: %v", notification.action.ActionType)
 	}
@@ -373,6 +418,12 @@ func (p *processorBase) resetProcessingQueueStates() (*ActionResult, error) {
 		maxReadLevel = maximumTimerTaskKey
 	}
 
+	for _, queueCollection := range p.processingQueueCollections {
+		if isDomainVirtualQueueLevel(queueCollection.Level()) {
+			p.virtualQueues.release(queueCollection.Level())
+		}
+	}
+
 	p.processingQueueCollections = newProcessingQueueCollections(
 		[]ProcessingQueueState{
 			NewProcessingQueueState(
@@ -408,6 +459,81 @@ func (p *processorBase) getProcessingQueueStates() *ActionResult {
 	}
 }
 
+func (p *processorBase) listTasks(
+	attributes *ListTasksAttributes,
+) *ActionResult {
+	var tasks []task.Task
+	for _, queueCollection := range p.processingQueueCollections {
+		for _, outstandingTask := range queueCollection.GetTasks() {
+			if attributes.MinTaskID != 0 && outstandingTask.GetTaskID() < attributes.MinTaskID {
+				continue
+			}
+			if attributes.MaxTaskID != 0 && outstandingTask.GetTaskID() > attributes.MaxTaskID {
+				continue
+			}
+			if !attributes.MinVisibilityTimestamp.IsZero() && outstandingTask.GetVisibilityTimestamp().Before(attributes.MinVisibilityTimestamp) {
+				continue
+			}
+			if !attributes.MaxVisibilityTimestamp.IsZero() && outstandingTask.GetVisibilityTimestamp().After(attributes.MaxVisibilityTimestamp) {
+				continue
+			}
+			tasks = append(tasks, outstandingTask)
+		}
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].GetTaskID() < tasks[j].GetTaskID()
+	})
+	if attributes.PageSize > 0 && len(tasks) > attributes.PageSize {
+		tasks = tasks[:attributes.PageSize]
+	}
+
+	return &ActionResult{
+		ActionType: ActionTypeListTasks,
+		ListTasksResult: &ListTasksResult{
+			Tasks: tasks,
+		},
+	}
+}
+
+func (p *processorBase) executeTask(
+	attributes *ExecuteTaskAttributes,
+) (*ActionResult, error) {
+	for _, queueCollection := range p.processingQueueCollections {
+		for _, outstandingTask := range queueCollection.GetTasks() {
+			if outstandingTask.GetTaskID() != attributes.TaskID {
+				continue
+			}
+
+			// only tasks waiting for redispatch can be executed, otherwise the task is either
+			// completed or being executed by the task processor
+			notWaitingErr := &types.BadRequestError{
+				Message: fmt.Sprintf("task %v is not waiting to be redispatched", attributes.TaskID),
+			}
+			if outstandingTask.State() != t.TaskStatePending {
+				return nil, notWaitingErr
+			}
+			found, err := p.redispatcher.RedispatchTask(outstandingTask)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				return nil, notWaitingErr
+			}
+
+			p.logger.Info("Task force executed", tag.TaskID(outstandingTask.GetTaskID()), tag.TaskType(outstandingTask.GetTaskType()))
+			return &ActionResult{
+				ActionType:        ActionTypeExecuteTask,
+				ExecuteTaskResult: &ExecuteTaskResult{},
+			}, nil
+		}
+	}
+
+	return nil, &types.EntityNotExistsError{
+		Message: fmt.Sprintf("task %v is not found in queue processor", attributes.TaskID),
+	}
+}
+
 func (p *processorBase) submitTask(task task.Task) (bool, error) {
 	submitted, err := p.taskProcessor.TrySubmit(task)
 	if err != nil {
@@ -36,6 +36,7 @@ import (
 	"github.com/uber/cadence/common/log/testlogger"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
+	ctask "github.com/uber/cadence/common/task"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/history/config"
 	"github.com/uber/cadence/service/history/shard"
@@ -257,6 +258,51 @@ func (s *processorBaseSuite) TestUpdateAckLevel_Tranfer_ProcessNotFinished() {
 	s.Equal(int64(2), ackLevel.(transferTaskKey).taskID)
 }
 
+func (s *processorBaseSuite) TestUpdateAckLevel_ReleaseFinishedDomainVirtualQueues() {
+	processingQueueStates := []ProcessingQueueState{
+		NewProcessingQueueState(
+			domainVirtualQueueBaseLevel,
+			newTransferTaskKey(100),
+			newTransferTaskKey(100),
+			NewDomainFilter(map[string]struct{}{"testDomain1": {}}, false),
+		),
+		NewProcessingQueueState(
+			domainVirtualQueueBaseLevel+1,
+			newTransferTaskKey(5),
+			newTransferTaskKey(100),
+			NewDomainFilter(map[string]struct{}{"testDomain2": {}}, false),
+		),
+		NewProcessingQueueState(
+			0,
+			newTransferTaskKey(100),
+			newTransferTaskKey(1000),
+			NewDomainFilter(map[string]struct{}{"testDomain1": {}, "testDomain2": {}}, true),
+		),
+	}
+
+	processorBase := s.newTestProcessorBase(
+		processingQueueStates,
+		nil,
+		func(task.Key) error { return nil },
+		nil,
+		nil,
+	)
+
+	processFinished, ackLevel, err := processorBase.updateAckLevel()
+	s.NoError(err)
+	s.False(processFinished)
+	s.Equal(int64(5), ackLevel.(transferTaskKey).taskID)
+
+	levels := make(map[int]struct{})
+	for _, queueCollection := range processorBase.processingQueueCollections {
+		levels[queueCollection.Level()] = struct{}{}
+	}
+	s.Equal(map[int]struct{}{0: {}, domainVirtualQueueBaseLevel + 1: {}}, levels)
+	// level of the finished virtual queue is reused
+	s.Equal(domainVirtualQueueBaseLevel, processorBase.virtualQueues.level("testDomain3"))
+	s.Equal(domainVirtualQueueBaseLevel+1, processorBase.virtualQueues.level("testDomain2"))
+}
+
 func (s *processorBaseSuite) TestUpdateAckLevel_Timer_UpdateAckLevel() {
 	now := time.Now()
 	processingQueueStates := []ProcessingQueueState{
@@ -385,6 +431,81 @@ func (s *processorBaseSuite) TestResetProcessingQueueStates() {
 	s.Equal(ActionTypeReset, res.ActionType, "got action type %v, want %v", res.ActionType, ActionTypeReset)
 }
 
+func (s *processorBaseSuite) TestListTasks() {
+	processingQueueStates := []ProcessingQueueState{
+		NewProcessingQueueState(
+			0,
+			newTransferTaskKey(0),
+			newTransferTaskKey(100),
+			NewDomainFilter(map[string]struct{}{}, true),
+		),
+	}
+	processorBase := s.newTestProcessorBase(processingQueueStates, nil, nil, nil, nil)
+
+	taskMap := make(map[task.Key]task.Task)
+	for _, taskID := range []int64{30, 10, 20, 40} {
+		mockTask := task.NewMockTask(s.controller)
+		mockTask.EXPECT().GetTaskID().Return(taskID).AnyTimes()
+		mockTask.EXPECT().GetVisibilityTimestamp().Return(time.Now()).AnyTimes()
+		mockTask.EXPECT().GetDomainID().Return("testDomain").AnyTimes()
+		taskMap[newTransferTaskKey(taskID)] = mockTask
+	}
+	processorBase.processingQueueCollections[0].AddTasks(taskMap, newTransferTaskKey(50))
+
+	res := processorBase.listTasks(&ListTasksAttributes{
+		MinTaskID: 15,
+		PageSize:  2,
+	})
+	s.Equal(ActionTypeListTasks, res.ActionType)
+	s.Len(res.ListTasksResult.Tasks, 2)
+	s.Equal(int64(20), res.ListTasksResult.Tasks[0].GetTaskID())
+	s.Equal(int64(30), res.ListTasksResult.Tasks[1].GetTaskID())
+}
+
+func (s *processorBaseSuite) TestExecuteTask() {
+	processingQueueStates := []ProcessingQueueState{
+		NewProcessingQueueState(
+			0,
+			newTransferTaskKey(0),
+			newTransferTaskKey(100),
+			NewDomainFilter(map[string]struct{}{}, true),
+		),
+	}
+	processorBase := s.newTestProcessorBase(processingQueueStates, nil, nil, nil, nil)
+	mockRedispatcher := task.NewMockRedispatcher(s.controller)
+	processorBase.redispatcher = mockRedispatcher
+
+	waitingTask := task.NewMockTask(s.controller)
+	waitingTask.EXPECT().GetTaskID().Return(int64(10)).AnyTimes()
+	waitingTask.EXPECT().GetTaskType().Return(persistence.TransferTaskTypeDecisionTask).AnyTimes()
+	waitingTask.EXPECT().State().Return(ctask.TaskStatePending).AnyTimes()
+	waitingTask.EXPECT().GetDomainID().Return("testDomain").AnyTimes()
+	executingTask := task.NewMockTask(s.controller)
+	executingTask.EXPECT().GetTaskID().Return(int64(20)).AnyTimes()
+	executingTask.EXPECT().State().Return(ctask.TaskStatePending).AnyTimes()
+	executingTask.EXPECT().GetDomainID().Return("testDomain").AnyTimes()
+	processorBase.processingQueueCollections[0].AddTasks(map[task.Key]task.Task{
+		newTransferTaskKey(10): waitingTask,
+		newTransferTaskKey(20): executingTask,
+	}, newTransferTaskKey(50))
+
+	mockRedispatcher.EXPECT().RedispatchTask(waitingTask).Return(true, nil).Times(1)
+	res, err := processorBase.executeTask(&ExecuteTaskAttributes{TaskID: 10})
+	s.NoError(err)
+	s.Equal(ActionTypeExecuteTask, res.ActionType)
+
+	mockRedispatcher.EXPECT().RedispatchTask(waitingTask).Return(true, &types.ServiceBusyError{}).Times(1)
+	_, err = processorBase.executeTask(&ExecuteTaskAttributes{TaskID: 10})
+	s.IsType(&types.ServiceBusyError{}, err)
+
+	mockRedispatcher.EXPECT().RedispatchTask(executingTask).Return(false, nil).Times(1)
+	_, err = processorBase.executeTask(&ExecuteTaskAttributes{TaskID: 20})
+	s.IsType(&types.BadRequestError{}, err)
+
+	_, err = processorBase.executeTask(&ExecuteTaskAttributes{TaskID: 30})
+	s.IsType(&types.EntityNotExistsError{}, err)
+}
+
 func (s *processorBaseSuite) newTestProcessorBase(
 	processingQueueStates []ProcessingQueueState,
 	updateMaxReadLevel updateMaxReadLevelFn,
@@ -49,9 +49,13 @@ type queueProcessorOptions struct {
 	PollBackoffIntervalJitterCoefficient dynamicconfig.FloatPropertyFn
 	EnablePersistQueueStates             dynamicconfig.BoolPropertyFn
 	EnableLoadQueueStates                dynamicconfig.BoolPropertyFn
+	EnableDomainVirtualQueue             dynamicconfig.BoolPropertyFn
+	DomainVirtualQueueMaxPollRPS         dynamicconfig.IntPropertyFnWithDomainFilter
 	EnableGracefulSyncShutdown           dynamicconfig.BoolPropertyFn
 	EnableValidator                      dynamicconfig.BoolPropertyFn
 	ValidationInterval                   dynamicconfig.DurationPropertyFn
+	// InMemoryTimerHorizon is used in timer queue to fire newly created timers without loading them from persistence
+	InMemoryTimerHorizon dynamicconfig.DurationPropertyFn
 	// MaxPendingTaskSize is used in cross cluster queue to limit the pending task count
 	MaxPendingTaskSize dynamicconfig.IntPropertyFn
 	MetricScope        int
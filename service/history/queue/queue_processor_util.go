@@ -25,6 +25,7 @@ import (
 
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/history/task"
 )
 
 func convertToPersistenceTransferProcessingQueueStates(states []ProcessingQueueState) []*types.ProcessingQueueState {
@@ -35,6 +36,7 @@ func convertToPersistenceTransferProcessingQueueStates(states []ProcessingQueueS
 			AckLevel:     common.Int64Ptr(state.AckLevel().(transferTaskKey).taskID),
 			MaxLevel:     common.Int64Ptr(state.MaxLevel().(transferTaskKey).taskID),
 			DomainFilter: convertToPersistenceDomainFilter(state.DomainFilter()),
+			ReadLevel:    common.Int64Ptr(state.ReadLevel().(transferTaskKey).taskID),
 		})
 	}
 
@@ -44,12 +46,13 @@ func convertToPersistenceTransferProcessingQueueStates(states []ProcessingQueueS
 func convertFromPersistenceTransferProcessingQueueStates(pStates []*types.ProcessingQueueState) []ProcessingQueueState {
 	states := make([]ProcessingQueueState, 0, len(pStates))
 	for _, pState := range pStates {
-		states = append(states, NewProcessingQueueState(
+		states = append(states, newRestoredProcessingQueueStates(
 			int(pState.GetLevel()),
 			newTransferTaskKey(pState.GetAckLevel()),
+			newTransferTaskKey(pState.GetReadLevel()),
 			newTransferTaskKey(pState.GetMaxLevel()),
 			convertFromPersistenceDomainFilter(pState.DomainFilter),
-		))
+		)...)
 	}
 
 	return states
@@ -63,6 +66,7 @@ func convertToPersistenceTimerProcessingQueueStates(states []ProcessingQueueStat
 			AckLevel:     common.Int64Ptr(state.AckLevel().(timerTaskKey).visibilityTimestamp.UnixNano()),
 			MaxLevel:     common.Int64Ptr(state.MaxLevel().(timerTaskKey).visibilityTimestamp.UnixNano()),
 			DomainFilter: convertToPersistenceDomainFilter(state.DomainFilter()),
+			ReadLevel:    common.Int64Ptr(state.ReadLevel().(timerTaskKey).visibilityTimestamp.UnixNano()),
 		})
 	}
 
@@ -72,17 +76,40 @@ func convertToPersistenceTimerProcessingQueueStates(states []ProcessingQueueStat
 func convertFromPersistenceTimerProcessingQueueStates(pStates []*types.ProcessingQueueState) []ProcessingQueueState {
 	states := make([]ProcessingQueueState, 0, len(pStates))
 	for _, pState := range pStates {
-		states = append(states, NewProcessingQueueState(
+		states = append(states, newRestoredProcessingQueueStates(
 			int(pState.GetLevel()),
 			newTimerTaskKey(time.Unix(0, pState.GetAckLevel()), 0),
+			newTimerTaskKey(time.Unix(0, pState.GetReadLevel()), 0),
 			newTimerTaskKey(time.Unix(0, pState.GetMaxLevel()), 0),
 			convertFromPersistenceDomainFilter(pState.DomainFilter),
-		))
+		)...)
 	}
 
 	return states
 }
 
+// newRestoredProcessingQueueStates restores a persisted processing queue state so that new tasks are
+// read from the persisted read level. Tasks between the ack level and the read level may not have been
+// completed when the state was persisted, so they are loaded again by a separate queue at the same level.
+func newRestoredProcessingQueueStates(
+	level int,
+	ackLevel task.Key,
+	readLevel task.Key,
+	maxLevel task.Key,
+	domainFilter DomainFilter,
+) []ProcessingQueueState {
+	if !ackLevel.Less(readLevel) || !readLevel.Less(maxLevel) {
+		// read level is not persisted or there's nothing beyond the read level,
+		// all tasks need to be loaded again from the ack level
+		return []ProcessingQueueState{NewProcessingQueueState(level, ackLevel, maxLevel, domainFilter)}
+	}
+
+	return []ProcessingQueueState{
+		NewProcessingQueueState(level, ackLevel, readLevel, domainFilter),
+		NewProcessingQueueState(level, readLevel, maxLevel, domainFilter.copy()),
+	}
+}
+
 func convertToPersistenceDomainFilter(domainFilter DomainFilter) *types.DomainFilter {
 	domainIDs := make([]string, 0, len(domainFilter.DomainIDs))
 	for domainID := range domainFilter.DomainIDs {
@@ -159,6 +159,60 @@ func (s *queueProcessorUtilSuite) TestConvertFromPersistenceTimerProcessingQueue
 	}
 }
 
+func (s *queueProcessorUtilSuite) TestConvertTransferProcessingQueueStates_RestoreReadLevel() {
+	domainFilter := NewDomainFilter(map[string]struct{}{"domain 1": {}}, false)
+	states := []ProcessingQueueState{
+		newProcessingQueueState(
+			domainVirtualQueueBaseLevel,
+			newTransferTaskKey(123),
+			newTransferTaskKey(456),
+			newTransferTaskKey(789),
+			domainFilter,
+		),
+	}
+
+	pStates := convertToPersistenceTransferProcessingQueueStates(states)
+	s.Len(pStates, 1)
+	s.Equal(int64(456), pStates[0].GetReadLevel())
+
+	// reading resumes from the persisted read level, tasks before it are loaded again by a separate queue
+	restoredStates := convertFromPersistenceTransferProcessingQueueStates(pStates)
+	s.Len(restoredStates, 2)
+	s.Equal(newProcessingQueueState(domainVirtualQueueBaseLevel, newTransferTaskKey(123), newTransferTaskKey(123), newTransferTaskKey(456), domainFilter), restoredStates[0])
+	s.Equal(newProcessingQueueState(domainVirtualQueueBaseLevel, newTransferTaskKey(456), newTransferTaskKey(456), newTransferTaskKey(789), domainFilter), restoredStates[1])
+}
+
+func (s *queueProcessorUtilSuite) TestConvertTimerProcessingQueueStates_RestoreReadLevel() {
+	ackLevel := time.Unix(0, 1000)
+	readLevel := time.Unix(0, 2000)
+	maxLevel := time.Unix(0, 3000)
+	domainFilter := NewDomainFilter(nil, true)
+	states := []ProcessingQueueState{
+		newProcessingQueueState(
+			defaultProcessingQueueLevel,
+			newTimerTaskKey(ackLevel, 0),
+			newTimerTaskKey(readLevel, 0),
+			newTimerTaskKey(maxLevel, 0),
+			domainFilter,
+		),
+	}
+
+	pStates := convertToPersistenceTimerProcessingQueueStates(states)
+	s.Len(pStates, 1)
+	s.Equal(readLevel.UnixNano(), pStates[0].GetReadLevel())
+
+	restoredStates := convertFromPersistenceTimerProcessingQueueStates(pStates)
+	s.Len(restoredStates, 2)
+	s.Equal(newProcessingQueueState(defaultProcessingQueueLevel, newTimerTaskKey(ackLevel, 0), newTimerTaskKey(ackLevel, 0), newTimerTaskKey(readLevel, 0), domainFilter), restoredStates[0])
+	s.Equal(newProcessingQueueState(defaultProcessingQueueLevel, newTimerTaskKey(readLevel, 0), newTimerTaskKey(readLevel, 0), newTimerTaskKey(maxLevel, 0), domainFilter), restoredStates[1])
+
+	// nothing left beyond the read level, all tasks are loaded again from the ack level
+	pStates[0].MaxLevel = pStates[0].ReadLevel
+	restoredStates = convertFromPersistenceTimerProcessingQueueStates(pStates)
+	s.Len(restoredStates, 1)
+	s.Equal(newProcessingQueueState(defaultProcessingQueueLevel, newTimerTaskKey(ackLevel, 0), newTimerTaskKey(ackLevel, 0), newTimerTaskKey(readLevel, 0), domainFilter), restoredStates[0])
+}
+
 func (s *queueProcessorUtilSuite) assertProcessingQueueStateEqual(
 	state ProcessingQueueState,
 	pState *types.ProcessingQueueState,
@@ -37,6 +37,7 @@ const (
 	policyTypeStuckTask
 	policyTypeSelectedDomain
 	policyTypeRandom
+	policyTypeDomainVirtualQueue
 )
 
 type (
@@ -82,6 +83,14 @@ type (
 	aggregatedSplitPolicy struct {
 		policies []ProcessingQueueSplitPolicy
 	}
+
+	domainVirtualQueueSplitPolicy struct {
+		basePolicy    ProcessingQueueSplitPolicy
+		virtualQueues *domainVirtualQueues
+
+		logger       log.Logger
+		metricsScope metrics.Scope
+	}
 )
 
 // NewPendingTaskSplitPolicy creates a new processing queue split policy
@@ -168,6 +177,22 @@ func NewAggregatedSplitPolicy(policies ...ProcessingQueueSplitPolicy) Processing
 	}
 }
 
+// newDomainVirtualQueueSplitPolicy creates a split policy which moves each domain split out
+// by the base policy into its own virtual queue instead of sharing the next queue level
+func newDomainVirtualQueueSplitPolicy(
+	basePolicy ProcessingQueueSplitPolicy,
+	virtualQueues *domainVirtualQueues,
+	logger log.Logger,
+	metricsScope metrics.Scope,
+) ProcessingQueueSplitPolicy {
+	return &domainVirtualQueueSplitPolicy{
+		basePolicy:    basePolicy,
+		virtualQueues: virtualQueues,
+		logger:        logger,
+		metricsScope:  metricsScope,
+	}
+}
+
 func (p *pendingTaskSplitPolicy) Evaluate(queue ProcessingQueue) []ProcessingQueueState {
 	queueImpl := queue.(*processingQueueImpl)
 
@@ -364,6 +389,51 @@ func (p *aggregatedSplitPolicy) Evaluate(queue ProcessingQueue) []ProcessingQueu
 	return nil
 }
 
+func (p *domainVirtualQueueSplitPolicy) Evaluate(queue ProcessingQueue) []ProcessingQueueState {
+	currentQueueState := queue.State()
+	if isDomainVirtualQueueLevel(currentQueueState.Level()) {
+		// virtual queue only contains a single domain, no need to split it further
+		return nil
+	}
+
+	newQueueStates := p.basePolicy.Evaluate(queue)
+	if len(newQueueStates) == 0 {
+		return nil
+	}
+
+	virtualQueueStates := make([]ProcessingQueueState, 0, len(newQueueStates))
+	domainToSplit := make(map[string]struct{})
+	for _, state := range newQueueStates {
+		domainFilter := state.DomainFilter()
+		if state.Level() == currentQueueState.Level() || domainFilter.ReverseMatch {
+			virtualQueueStates = append(virtualQueueStates, state)
+			continue
+		}
+
+		for domainID := range domainFilter.DomainIDs {
+			domainToSplit[domainID] = struct{}{}
+			virtualQueueStates = append(virtualQueueStates, newProcessingQueueState(
+				p.virtualQueues.level(domainID),
+				state.AckLevel(),
+				state.ReadLevel(),
+				state.MaxLevel(),
+				NewDomainFilter(map[string]struct{}{domainID: {}}, false),
+			))
+		}
+	}
+
+	if len(domainToSplit) != 0 {
+		p.logger.Info("Split processing queue into domain virtual queues",
+			tag.PreviousQueueLevel(currentQueueState.Level()),
+			tag.WorkflowDomainIDs(domainToSplit),
+			tag.QueueSplitPolicyType(policyTypeDomainVirtualQueue),
+		)
+		p.metricsScope.IncCounter(metrics.ProcessingQueueDomainVirtualQueueSplitCounter)
+	}
+
+	return virtualQueueStates
+}
+
 // splitQueueHelper assumes domainToSplit is not empty
 func splitQueueHelper(
 	queueImpl *processingQueueImpl,
@@ -689,6 +689,81 @@ func (s *splitPolicySuite) TestAggregatedSplitPolicy() {
 	s.Equal(expectedNewStates, aggregatedSplitPolicy.Evaluate(mockProcessingQueue))
 }
 
+func (s *splitPolicySuite) TestDomainVirtualQueueSplitPolicy() {
+	virtualQueues := newDomainVirtualQueues(
+		[]ProcessingQueueState{
+			NewProcessingQueueState(
+				domainVirtualQueueBaseLevel,
+				testKey{ID: 0},
+				testKey{ID: 10},
+				NewDomainFilter(map[string]struct{}{"testDomain1": {}}, false),
+			),
+		},
+		nil,
+		nil,
+	)
+
+	currentState := newProcessingQueueState(
+		0,
+		testKey{ID: 0},
+		testKey{ID: 5},
+		testKey{ID: 10},
+		NewDomainFilter(nil, true),
+	)
+	mockBasePolicy := NewMockProcessingQueueSplitPolicy(s.controller)
+	mockBasePolicy.EXPECT().Evaluate(gomock.Any()).Return([]ProcessingQueueState{
+		newProcessingQueueState(
+			0,
+			testKey{ID: 0},
+			testKey{ID: 5},
+			testKey{ID: 10},
+			NewDomainFilter(map[string]struct{}{"testDomain1": {}, "testDomain2": {}}, true),
+		),
+		newProcessingQueueState(
+			1,
+			testKey{ID: 0},
+			testKey{ID: 5},
+			testKey{ID: 8},
+			NewDomainFilter(map[string]struct{}{"testDomain1": {}, "testDomain2": {}}, false),
+		),
+	}).Times(1)
+
+	splitPolicy := newDomainVirtualQueueSplitPolicy(mockBasePolicy, virtualQueues, s.logger, s.metricsScope)
+	s.assertQueueStatesEqual([]ProcessingQueueState{
+		newProcessingQueueState(
+			0,
+			testKey{ID: 0},
+			testKey{ID: 5},
+			testKey{ID: 10},
+			NewDomainFilter(map[string]struct{}{"testDomain1": {}, "testDomain2": {}}, true),
+		),
+		newProcessingQueueState(
+			domainVirtualQueueBaseLevel,
+			testKey{ID: 0},
+			testKey{ID: 5},
+			testKey{ID: 8},
+			NewDomainFilter(map[string]struct{}{"testDomain1": {}}, false),
+		),
+		newProcessingQueueState(
+			domainVirtualQueueBaseLevel+1,
+			testKey{ID: 0},
+			testKey{ID: 5},
+			testKey{ID: 8},
+			NewDomainFilter(map[string]struct{}{"testDomain2": {}}, false),
+		),
+	}, splitPolicy.Evaluate(NewProcessingQueue(currentState, nil, nil)))
+
+	// virtual queues are never split further
+	virtualQueueState := newProcessingQueueState(
+		domainVirtualQueueBaseLevel,
+		testKey{ID: 0},
+		testKey{ID: 5},
+		testKey{ID: 8},
+		NewDomainFilter(map[string]struct{}{"testDomain1": {}}, false),
+	)
+	s.Nil(splitPolicy.Evaluate(NewProcessingQueue(virtualQueueState, nil, nil)))
+}
+
 func (s *splitPolicySuite) assertQueueStatesEqual(
 	expected []ProcessingQueueState,
 	actual []ProcessingQueueState,
@@ -25,6 +25,7 @@ import (
 	"sync"
 
 	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/persistence"
@@ -47,6 +48,7 @@ type (
 		currentClusterName string
 		shard              shard.Context
 		domainCache        cache.DomainCache
+		clusterMetadata    cluster.Metadata
 		logger             log.Logger
 
 		locker sync.RWMutex
@@ -59,6 +61,7 @@ func NewTaskAllocator(shard shard.Context) TaskAllocator {
 		currentClusterName: shard.GetService().GetClusterMetadata().GetCurrentClusterName(),
 		shard:              shard,
 		domainCache:        shard.GetDomainCache(),
+		clusterMetadata:    shard.GetClusterMetadata(),
 		logger:             shard.GetLogger(),
 	}
 }
@@ -79,7 +82,13 @@ func (t *taskAllocatorImpl) VerifyActiveTask(taskDomainID string, task interface
 		t.logger.Warn("Cannot find domain, default to process task.", tag.WorkflowDomainID(taskDomainID), tag.Value(task))
 		return true, nil
 	}
-	if domainEntry.IsGlobalDomain() && t.currentClusterName != domainEntry.GetReplicationConfig().ActiveClusterName {
+	if domainEntry.IsActiveActive() {
+		if t.getTaskCluster(task) != t.currentClusterName {
+			// the workflow of the task is active in another cluster
+			t.logger.Debug("Workflow is not active, skip task.", tag.WorkflowDomainID(taskDomainID), tag.Value(task))
+			return false, nil
+		}
+	} else if domainEntry.IsGlobalDomain() && t.currentClusterName != domainEntry.GetReplicationConfig().ActiveClusterName {
 		// timer task does not belong to cluster name
 		t.logger.Debug("Domain is not active, skip task.", tag.WorkflowDomainID(taskDomainID), tag.Value(task))
 		return false, nil
@@ -150,6 +159,12 @@ func (t *taskAllocatorImpl) VerifyStandbyTask(standbyCluster string, taskDomainI
 		// non global domain, timer task does not belong here
 		t.logger.Debug("Domain is not global, skip task.", tag.WorkflowDomainID(taskDomainID), tag.Value(task))
 		return false, nil
+	} else if domainEntry.IsActiveActive() {
+		if t.getTaskCluster(task) != standbyCluster {
+			// the workflow of the task is not active in the standby cluster
+			t.logger.Debug("Workflow is not standby, skip task.", tag.WorkflowDomainID(taskDomainID), tag.Value(task))
+			return false, nil
+		}
 	} else if domainEntry.IsGlobalDomain() && domainEntry.GetReplicationConfig().ActiveClusterName != standbyCluster {
 		// timer task does not belong here
 		t.logger.Debug("Domain is not standby, skip task.", tag.WorkflowDomainID(taskDomainID), tag.Value(task))
@@ -168,6 +183,21 @@ func (t *taskAllocatorImpl) VerifyStandbyTask(standbyCluster string, taskDomainI
 	return true, nil
 }
 
+// getTaskCluster returns the cluster the workflow of a task of an active-active domain was active in when the task
+// was generated, i.e. the cluster of the task version
+func (t *taskAllocatorImpl) getTaskCluster(task interface{}) string {
+	versionedTask, ok := task.(interface{ GetVersion() int64 })
+	if !ok {
+		return ""
+	}
+	clusterName, err := t.clusterMetadata.ClusterNameForFailoverVersion(versionedTask.GetVersion())
+	if err != nil {
+		t.logger.Warn("Cannot find the cluster of the task version", tag.FailoverVersion(versionedTask.GetVersion()), tag.Error(err))
+		return ""
+	}
+	return clusterName
+}
+
 func (t *taskAllocatorImpl) checkDomainPendingActive(domainEntry *cache.DomainCacheEntry, taskDomainID string, task interface{}) error {
 
 	if domainEntry.IsGlobalDomain() && domainEntry.GetFailoverEndTime() != nil {
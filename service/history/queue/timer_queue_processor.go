@@ -218,7 +218,7 @@ func (t *timerQueueProcessor) Stop() {
 
 func (t *timerQueueProcessor) NotifyNewTask(clusterName string, info *hcommon.NotifyTaskInfo) {
 	if clusterName == t.currentClusterName {
-		t.activeQueueProcessor.notifyNewTimers(info.Tasks)
+		t.activeQueueProcessor.notifyNewTimers(t.activeQueueProcessor.addInMemoryTimers(info))
 		return
 	}
 
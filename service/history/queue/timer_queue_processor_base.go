@@ -31,11 +31,13 @@ import (
 
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/collection"
 	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
+	hcommon "github.com/uber/cadence/service/history/common"
 	"github.com/uber/cadence/service/history/config"
 	"github.com/uber/cadence/service/history/shard"
 	"github.com/uber/cadence/service/history/task"
@@ -78,6 +80,13 @@ type (
 
 		processingQueueReadProgress map[int]timeTaskReadProgress
 
+		// timers created by this host which are due within InMemoryTimerHorizon,
+		// they are fired without waiting for the load from persistence.
+		// firedInMemoryTimers is keyed by taskID as the precision of the persisted
+		// visibility timestamp may be lower than the one in memory.
+		inMemoryTimers      collection.Queue
+		firedInMemoryTimers map[int64]time.Time
+
 		updateAckLevelFn                 func() (bool, task.Key, error)
 		splitProcessingQueueCollectionFn func(splitPolicy ProcessingQueueSplitPolicy, upsertPollTimeFn func(int, time.Time))
 	}
@@ -144,6 +153,8 @@ func newTimerQueueProcessorBase(
 		timerGate:                   timerGate,
 		newTimerCh:                  make(chan struct{}, 1),
 		processingQueueReadProgress: make(map[int]timeTaskReadProgress),
+		inMemoryTimers:              collection.NewConcurrentPriorityQueue(compareTimerTaskInfo),
+		firedInMemoryTimers:         make(map[int64]time.Time),
 	}
 
 	t.updateAckLevelFn = t.updateAckLevel
@@ -197,6 +208,13 @@ func (t *timerQueueProcessorBase) Stop() {
 		t.logger.Warn("timerQueueProcessorBase timed out on shut down", tag.LifeCycleStopTimedout)
 	}
 
+	if t.shard.GetConfig().EnableGracefulShardHandoff() {
+		// flush the latest ack levels so that the next shard owner won't reload completed tasks
+		if _, _, err := t.updateAckLevelFn(); err != nil && err != shard.ErrShardClosed {
+			t.logger.Warn("Failed to update ack level on shut down", tag.Error(err))
+		}
+	}
+
 	t.redispatcher.Stop()
 }
 
@@ -276,7 +294,7 @@ func (t *timerQueueProcessorBase) processQueueCollections(levels map[int]struct{
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), loadQueueTaskThrottleRetryDelay)
-		if err := t.rateLimiter.Wait(ctx); err != nil {
+		if err := t.rateLimiterForLevel(level).Wait(ctx); err != nil {
 			cancel()
 			if level == defaultProcessingQueueLevel {
 				t.upsertPollTime(level, time.Time{})
@@ -301,6 +319,11 @@ func (t *timerQueueProcessorBase) processQueueCollections(levels map[int]struct{
 			if !domainFilter.Filter(taskInfo.GetDomainID()) {
 				continue
 			}
+			if _, ok := t.firedInMemoryTimers[taskInfo.GetTaskID()]; ok {
+				// already submitted when the in-memory timer fired
+				delete(t.firedInMemoryTimers, taskInfo.GetTaskID())
+				continue
+			}
 
 			task := t.taskInitializer(taskInfo)
 			tasks[newTimerTaskKey(taskInfo.GetVisibilityTimestamp(), taskInfo.GetTaskID())] = task
@@ -356,6 +379,9 @@ func (t *timerQueueProcessorBase) processQueueCollections(levels map[int]struct{
 			}
 		}
 		queueCollection.AddTasks(tasks, newReadLevel)
+		if newReadLevel != nil {
+			t.pruneFiredInMemoryTimers(newReadLevel)
+		}
 	}
 }
 
@@ -414,6 +440,8 @@ func (t *timerQueueProcessorBase) updateTimerGates() {
 		return
 	}
 
+	t.fireInMemoryTimers()
+
 	t.pollTimeLock.Lock()
 	levels := make(map[int]struct{})
 	now := t.shard.GetCurrentTime(t.clusterName)
@@ -562,6 +590,118 @@ func (t *timerQueueProcessorBase) notifyNewTimers(timerTasks []persistence.Task)
 	t.notifyNewTimer(minNewTime)
 }
 
+// addInMemoryTimers keeps the timers due within InMemoryTimerHorizon in memory so that they can be
+// fired by the timer gate directly, and returns the remaining timers which need to be loaded from persistence.
+// The persisted timer tasks are still the source of truth, in-memory timers are simply dropped when the
+// processor is stopped (e.g. on shard movement) and will be loaded again by the new owner.
+func (t *timerQueueProcessorBase) addInMemoryTimers(info *hcommon.NotifyTaskInfo) []persistence.Task {
+	if t.options.InMemoryTimerHorizon == nil || info.PersistenceError || info.ExecutionInfo == nil {
+		return info.Tasks
+	}
+	horizon := t.options.InMemoryTimerHorizon()
+	if horizon <= 0 {
+		return info.Tasks
+	}
+
+	maxInMemoryTime := t.shard.GetCurrentTime(t.clusterName).Add(horizon)
+	var minNewTime time.Time
+	remainingTasks := make([]persistence.Task, 0, len(info.Tasks))
+	for _, timerTask := range info.Tasks {
+		ts := timerTask.GetVisibilityTimestamp()
+		timerInfo := newTimerTaskInfoFromTask(info.ExecutionInfo, timerTask)
+		if timerInfo == nil || ts.After(maxInMemoryTime) {
+			remainingTasks = append(remainingTasks, timerTask)
+			continue
+		}
+
+		t.inMemoryTimers.Add(timerInfo)
+		if minNewTime.IsZero() || ts.Before(minNewTime) {
+			minNewTime = ts
+		}
+	}
+
+	if !minNewTime.IsZero() {
+		t.pollTimeLock.Lock()
+		t.timerGate.Update(minNewTime)
+		t.pollTimeLock.Unlock()
+	}
+	return remainingTasks
+}
+
+// fireInMemoryTimers submits the in-memory timers which are due to the default processing queue.
+// Timers which can't be added to the queue are left to the load from persistence.
+func (t *timerQueueProcessorBase) fireInMemoryTimers() {
+	if t.inMemoryTimers.IsEmpty() {
+		return
+	}
+
+	var queueCollection ProcessingQueueCollection
+	for _, c := range t.processingQueueCollections {
+		if c.Level() == defaultProcessingQueueLevel {
+			queueCollection = c
+			break
+		}
+	}
+
+	now := t.shard.GetCurrentTime(t.clusterName)
+	tasks := make(map[task.Key]task.Task)
+	needLoad := false
+	for !t.inMemoryTimers.IsEmpty() {
+		if t.inMemoryTimers.Peek().(*persistence.TimerTaskInfo).VisibilityTimestamp.After(now) {
+			break
+		}
+		// new timers may be added concurrently, but they can only be earlier than the one peeked
+		timerInfo := t.inMemoryTimers.Remove().(*persistence.TimerTaskInfo)
+		key := newTimerTaskKey(timerInfo.VisibilityTimestamp, timerInfo.TaskID)
+
+		var activeQueue ProcessingQueue
+		if queueCollection != nil {
+			activeQueue = queueCollection.ActiveQueue()
+		}
+		if activeQueue == nil ||
+			!activeQueue.State().ReadLevel().Less(key) ||
+			activeQueue.State().MaxLevel().Less(key) ||
+			!activeQueue.State().DomainFilter().Filter(timerInfo.DomainID) {
+			// the timer has been or will be loaded by a different queue
+			needLoad = true
+			continue
+		}
+
+		timerTask := t.taskInitializer(timerInfo)
+		if _, err := t.submitTask(timerTask); err != nil {
+			// only err here is due to the fact that processor has been shutdown
+			return
+		}
+		tasks[key] = timerTask
+		t.firedInMemoryTimers[timerInfo.TaskID] = timerInfo.VisibilityTimestamp
+		t.metricsScope.IncCounter(metrics.InMemoryTimerFiredCounter)
+	}
+
+	if len(tasks) != 0 {
+		// read level is not changed as there might be other persisted tasks before the in-memory timers
+		queueCollection.AddTasks(tasks, queueCollection.ActiveQueue().State().ReadLevel())
+	}
+	if needLoad {
+		t.upsertPollTime(defaultProcessingQueueLevel, time.Time{})
+	}
+	if !t.inMemoryTimers.IsEmpty() {
+		t.pollTimeLock.Lock()
+		t.timerGate.Update(t.inMemoryTimers.Peek().(*persistence.TimerTaskInfo).VisibilityTimestamp)
+		t.pollTimeLock.Unlock()
+	}
+}
+
+// pruneFiredInMemoryTimers removes the fired in-memory timers that must have been
+// loaded from persistence given the read level
+func (t *timerQueueProcessorBase) pruneFiredInMemoryTimers(readLevel task.Key) {
+	readLevelTimestamp := readLevel.(timerTaskKey).visibilityTimestamp
+	for taskID, ts := range t.firedInMemoryTimers {
+		if ts.Before(readLevelTimestamp) {
+			delete(t.firedInMemoryTimers, taskID)
+		}
+	}
+}
+
 func (t *timerQueueProcessorBase) notifyNewTimer(newTime time.Time) {
 	t.newTimeLock.Lock()
 	defer t.newTimeLock.Unlock()
@@ -635,6 +775,56 @@ func (t *timerQueueProcessorBase) setupBackoffTimer(level int) {
 	})
 }
 
+func newTimerTaskInfoFromTask(
+	executionInfo *persistence.WorkflowExecutionInfo,
+	timerTask persistence.Task,
+) *persistence.TimerTaskInfo {
+	info := &persistence.TimerTaskInfo{
+		DomainID:            executionInfo.DomainID,
+		WorkflowID:          executionInfo.WorkflowID,
+		RunID:               executionInfo.RunID,
+		VisibilityTimestamp: timerTask.GetVisibilityTimestamp(),
+		TaskID:              timerTask.GetTaskID(),
+		TaskType:            timerTask.GetType(),
+		EventID:             common.EmptyEventID,
+		Version:             timerTask.GetVersion(),
+	}
+
+	switch t := timerTask.(type) {
+	case *persistence.DecisionTimeoutTask:
+		info.EventID = t.EventID
+		info.TimeoutType = t.TimeoutType
+		info.ScheduleAttempt = t.ScheduleAttempt
+	case *persistence.ActivityTimeoutTask:
+		info.EventID = t.EventID
+		info.TimeoutType = t.TimeoutType
+		info.ScheduleAttempt = t.Attempt
+	case *persistence.UserTimerTask:
+		info.EventID = t.EventID
+	case *persistence.ActivityRetryTimerTask:
+		info.EventID = t.EventID
+		info.ScheduleAttempt = int64(t.Attempt)
+	case *persistence.WorkflowBackoffTimerTask:
+		info.EventID = t.EventID
+		info.TimeoutType = t.TimeoutType
+	case *persistence.WorkflowTimeoutTask, *persistence.WorkflowNoProgressTimeoutTask:
+		// noop
+	case *persistence.DeleteHistoryEventTask:
+		// noop
+	default:
+		return nil
+	}
+	return info
+}
+
+func compareTimerTaskInfo(this interface{}, other interface{}) bool {
+	thisInfo := this.(*persistence.TimerTaskInfo)
+	otherInfo := other.(*persistence.TimerTaskInfo)
+	return newTimerTaskKey(thisInfo.VisibilityTimestamp, thisInfo.TaskID).Less(
+		newTimerTaskKey(otherInfo.VisibilityTimestamp, otherInfo.TaskID),
+	)
+}
+
 func newTimerTaskKey(visibilityTimestamp time.Time, taskID int64) task.Key {
 	return timerTaskKey{
 		visibilityTimestamp: visibilityTimestamp,
@@ -676,6 +866,7 @@ func newTimerQueueProcessorOptions(
 		PollBackoffInterval:                  config.QueueProcessorPollBackoffInterval,
 		PollBackoffIntervalJitterCoefficient: config.QueueProcessorPollBackoffIntervalJitterCoefficient,
 		EnableGracefulSyncShutdown:           config.QueueProcessorEnableGracefulSyncShutdown,
+		DomainVirtualQueueMaxPollRPS:         config.QueueProcessorDomainVirtualQueueMaxPollRPS,
 	}
 
 	if isFailover {
@@ -685,8 +876,10 @@ func newTimerQueueProcessorOptions(
 		// disable persist and load processing queue states for failover processor as it will never be split
 		options.EnablePersistQueueStates = dynamicconfig.GetBoolPropertyFn(false)
 		options.EnableLoadQueueStates = dynamicconfig.GetBoolPropertyFn(false)
+		options.EnableDomainVirtualQueue = dynamicconfig.GetBoolPropertyFn(false)
 
 		options.MaxStartJitterInterval = config.TimerProcessorFailoverMaxStartJitterInterval
+		options.InMemoryTimerHorizon = dynamicconfig.GetDurationPropertyFn(0)
 	} else {
 		options.EnableSplit = config.QueueProcessorEnableSplit
 		options.SplitMaxLevel = config.QueueProcessorSplitMaxLevel
@@ -700,8 +893,10 @@ func newTimerQueueProcessorOptions(
 
 		options.EnablePersistQueueStates = config.QueueProcessorEnablePersistQueueStates
 		options.EnableLoadQueueStates = config.QueueProcessorEnableLoadQueueStates
+		options.EnableDomainVirtualQueue = config.QueueProcessorEnableDomainVirtualQueue
 
 		options.MaxStartJitterInterval = dynamicconfig.GetDurationPropertyFn(0)
+		options.InMemoryTimerHorizon = config.TimerProcessorInMemoryTimerHorizon
 	}
 
 	if isActive {
@@ -710,6 +905,8 @@ func newTimerQueueProcessorOptions(
 	} else {
 		options.MetricScope = metrics.TimerStandbyQueueProcessorScope
 		options.RedispatchInterval = config.StandbyTaskRedispatchInterval
+		// standby timer gate is driven by remote cluster time, timers can only be fired after being replicated
+		options.InMemoryTimerHorizon = dynamicconfig.GetDurationPropertyFn(0)
 	}
 
 	return options
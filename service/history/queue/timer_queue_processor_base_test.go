@@ -39,6 +39,7 @@ import (
 	"github.com/uber/cadence/common/log/testlogger"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
+	hcommon "github.com/uber/cadence/service/history/common"
 	"github.com/uber/cadence/service/history/config"
 	"github.com/uber/cadence/service/history/constants"
 	"github.com/uber/cadence/service/history/shard"
@@ -534,6 +535,156 @@ func (s *timerQueueProcessorBaseSuite) TestNotifyNewTimes() {
 	}
 }
 
+func (s *timerQueueProcessorBaseSuite) TestAddInMemoryTimers() {
+	timerQueueProcessBase, done := s.newTestTimerQueueProcessorBase(nil, nil, nil, nil, nil)
+	defer done()
+
+	now := s.mockShard.GetCurrentTime(s.clusterName)
+	shortTimer := &persistence.UserTimerTask{
+		TaskData: persistence.TaskData{
+			VisibilityTimestamp: now.Add(100 * time.Millisecond),
+			TaskID:              int64(59),
+		},
+		EventID: int64(28),
+	}
+	longTimer := &persistence.UserTimerTask{
+		TaskData: persistence.TaskData{
+			VisibilityTimestamp: now.Add(5 * time.Second),
+			TaskID:              int64(60),
+		},
+		EventID: int64(29),
+	}
+	info := &hcommon.NotifyTaskInfo{
+		ExecutionInfo: &persistence.WorkflowExecutionInfo{
+			DomainID:   constants.TestDomainID,
+			WorkflowID: constants.TestWorkflowID,
+			RunID:      constants.TestRunID,
+		},
+		Tasks: []persistence.Task{shortTimer, longTimer},
+	}
+
+	// disabled by default
+	s.Equal(info.Tasks, timerQueueProcessBase.addInMemoryTimers(info))
+	s.True(timerQueueProcessBase.inMemoryTimers.IsEmpty())
+
+	timerQueueProcessBase.options.InMemoryTimerHorizon = dynamicconfig.GetDurationPropertyFn(time.Second)
+	info.PersistenceError = true
+	s.Equal(info.Tasks, timerQueueProcessBase.addInMemoryTimers(info))
+	s.True(timerQueueProcessBase.inMemoryTimers.IsEmpty())
+
+	info.PersistenceError = false
+	s.Equal([]persistence.Task{longTimer}, timerQueueProcessBase.addInMemoryTimers(info))
+	s.Equal(1, timerQueueProcessBase.inMemoryTimers.Len())
+	s.Equal(&persistence.TimerTaskInfo{
+		DomainID:            constants.TestDomainID,
+		WorkflowID:          constants.TestWorkflowID,
+		RunID:               constants.TestRunID,
+		VisibilityTimestamp: shortTimer.VisibilityTimestamp,
+		TaskID:              shortTimer.TaskID,
+		TaskType:            persistence.TaskTypeUserTimer,
+		EventID:             shortTimer.EventID,
+	}, timerQueueProcessBase.inMemoryTimers.Peek())
+
+	time.Sleep(200 * time.Millisecond)
+	select {
+	case <-timerQueueProcessBase.timerGate.FireChan():
+	default:
+		s.Fail("timer gate should fire")
+	}
+}
+
+func (s *timerQueueProcessorBaseSuite) TestFireInMemoryTimers() {
+	now := time.Now()
+	queueLevel := 0
+	ackLevel := newTimerTaskKey(now.Add(-5*time.Second), 0)
+	shardMaxReadLevel := newTimerTaskKey(now.Add(1*time.Second), 0)
+	maxLevel := newTimerTaskKey(now.Add(10*time.Second), 0)
+	processingQueueStates := []ProcessingQueueState{
+		NewProcessingQueueState(
+			queueLevel,
+			ackLevel,
+			maxLevel,
+			NewDomainFilter(map[string]struct{}{"excludedDomain": {}}, true),
+		),
+	}
+	updateMaxReadLevel := func() task.Key {
+		return shardMaxReadLevel
+	}
+
+	dueTimer := &persistence.TimerTaskInfo{
+		DomainID:            "some random domain ID",
+		WorkflowID:          "some random workflow ID",
+		RunID:               uuid.New(),
+		VisibilityTimestamp: now.Add(-time.Second),
+		TaskID:              int64(59),
+		TaskType:            persistence.TaskTypeUserTimer,
+		EventID:             int64(28),
+	}
+	excludedTimer := &persistence.TimerTaskInfo{
+		DomainID:            "excludedDomain",
+		WorkflowID:          "some random workflow ID",
+		RunID:               uuid.New(),
+		VisibilityTimestamp: now.Add(-time.Second),
+		TaskID:              int64(60),
+		TaskType:            persistence.TaskTypeUserTimer,
+		EventID:             int64(28),
+	}
+	futureTimer := &persistence.TimerTaskInfo{
+		DomainID:            "some random domain ID",
+		WorkflowID:          "some random workflow ID",
+		RunID:               uuid.New(),
+		VisibilityTimestamp: now.Add(time.Minute),
+		TaskID:              int64(61),
+		TaskType:            persistence.TaskTypeUserTimer,
+		EventID:             int64(28),
+	}
+
+	// the in-memory timer should be submitted only once
+	s.mockTaskProcessor.EXPECT().TrySubmit(gomock.Any()).Return(true, nil).Times(1)
+
+	timerQueueProcessBase, done := s.newTestTimerQueueProcessorBase(processingQueueStates, updateMaxReadLevel, nil, nil, nil)
+	defer done()
+	timerQueueProcessBase.inMemoryTimers.Add(futureTimer)
+	timerQueueProcessBase.inMemoryTimers.Add(excludedTimer)
+	timerQueueProcessBase.inMemoryTimers.Add(dueTimer)
+	timerQueueProcessBase.fireInMemoryTimers()
+
+	activeQueue := timerQueueProcessBase.processingQueueCollections[0].ActiveQueue()
+	s.Equal(ackLevel, activeQueue.State().ReadLevel())
+	s.Len(activeQueue.(*processingQueueImpl).outstandingTasks, 1)
+	s.Equal(map[int64]time.Time{dueTimer.TaskID: dueTimer.VisibilityTimestamp}, timerQueueProcessBase.firedInMemoryTimers)
+	s.Equal(1, timerQueueProcessBase.inMemoryTimers.Len())
+	// excluded timer is left to the load from persistence
+	s.True(timerQueueProcessBase.nextPollTime[queueLevel].IsZero())
+
+	// persisted timestamp may have a lower precision
+	persistedTimer := *dueTimer
+	persistedTimer.VisibilityTimestamp = dueTimer.VisibilityTimestamp.Truncate(time.Millisecond)
+	request := &persistence.GetTimerIndexTasksRequest{
+		MinTimestamp:  ackLevel.(timerTaskKey).visibilityTimestamp,
+		MaxTimestamp:  shardMaxReadLevel.(timerTaskKey).visibilityTimestamp,
+		BatchSize:     s.mockShard.GetConfig().TimerTaskBatchSize(),
+		NextPageToken: nil,
+	}
+	lookAheadRequest := &persistence.GetTimerIndexTasksRequest{
+		MinTimestamp:  shardMaxReadLevel.(timerTaskKey).visibilityTimestamp,
+		MaxTimestamp:  maximumTimerTaskKey.(timerTaskKey).visibilityTimestamp,
+		BatchSize:     1,
+		NextPageToken: nil,
+	}
+	mockExecutionMgr := s.mockShard.Resource.ExecutionMgr
+	mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, request).Return(&persistence.GetTimerIndexTasksResponse{
+		Timers: []*persistence.TimerTaskInfo{&persistedTimer},
+	}, nil).Once()
+	mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, lookAheadRequest).Return(&persistence.GetTimerIndexTasksResponse{}, nil).Once()
+
+	timerQueueProcessBase.processQueueCollections(map[int]struct{}{queueLevel: {}})
+
+	s.Equal(shardMaxReadLevel, activeQueue.State().ReadLevel())
+	s.Len(activeQueue.(*processingQueueImpl).outstandingTasks, 1)
+	s.Empty(timerQueueProcessBase.firedInMemoryTimers)
+}
+
 func (s *timerQueueProcessorBaseSuite) TestProcessQueueCollections_SkipRead() {
 	now := time.Now()
 	queueLevel := 0
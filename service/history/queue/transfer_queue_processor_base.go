@@ -202,6 +202,13 @@ func (t *transferQueueProcessorBase) Stop() {
 		t.logger.Warn("transferQueueProcessorBase timed out on shut down", tag.LifeCycleStopTimedout)
 	}
 
+	if t.shard.GetConfig().EnableGracefulShardHandoff() {
+		// flush the latest ack levels so that the next shard owner won't reload completed tasks
+		if _, _, err := t.updateAckLevelFn(); err != nil && err != shard.ErrShardClosed {
+			t.logger.Warn("Failed to update ack level on shut down", tag.Error(err))
+		}
+	}
+
 	t.redispatcher.Stop()
 }
 
@@ -396,7 +403,7 @@ func (t *transferQueueProcessorBase) processQueueCollections() {
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), loadQueueTaskThrottleRetryDelay)
-		if err := t.rateLimiter.Wait(ctx); err != nil {
+		if err := t.rateLimiterForLevel(level).Wait(ctx); err != nil {
 			cancel()
 			if level != defaultProcessingQueueLevel {
 				t.setupBackoffTimer(level)
@@ -580,6 +587,7 @@ func newTransferQueueProcessorOptions(
 		EnableValidator:                      config.TransferProcessorEnableValidator,
 		ValidationInterval:                   config.TransferProcessorValidationInterval,
 		EnableGracefulSyncShutdown:           config.QueueProcessorEnableGracefulSyncShutdown,
+		DomainVirtualQueueMaxPollRPS:         config.QueueProcessorDomainVirtualQueueMaxPollRPS,
 	}
 
 	if isFailover {
@@ -589,6 +597,7 @@ func newTransferQueueProcessorOptions(
 		// disable persist and load processing queue states for failover processor as it will never be split
 		options.EnablePersistQueueStates = dynamicconfig.GetBoolPropertyFn(false)
 		options.EnableLoadQueueStates = dynamicconfig.GetBoolPropertyFn(false)
+		options.EnableDomainVirtualQueue = dynamicconfig.GetBoolPropertyFn(false)
 
 		options.MaxStartJitterInterval = config.TransferProcessorFailoverMaxStartJitterInterval
 	} else {
@@ -604,6 +613,7 @@ func newTransferQueueProcessorOptions(
 
 		options.EnablePersistQueueStates = config.QueueProcessorEnablePersistQueueStates
 		options.EnableLoadQueueStates = config.QueueProcessorEnableLoadQueueStates
+		options.EnableDomainVirtualQueue = config.QueueProcessorEnableDomainVirtualQueue
 
 		options.MaxStartJitterInterval = dynamicconfig.GetDurationPropertyFn(0)
 	}
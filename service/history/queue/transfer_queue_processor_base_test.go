@@ -549,6 +549,33 @@ func (s *transferQueueProcessorBaseSuite) TestTransferProcessorPump_UpdateAckLev
 	}
 }
 
+func (s *transferQueueProcessorBaseSuite) TestStop_GracefulShardHandoff_FlushAckLevel() {
+	processingQueueStates := []ProcessingQueueState{
+		NewProcessingQueueState(
+			0,
+			newTransferTaskKey(0),
+			newTransferTaskKey(1000),
+			NewDomainFilter(map[string]struct{}{"testDomain1": {}}, false),
+		),
+	}
+	updateMaxReadLevel := func() task.Key {
+		return newTransferTaskKey(1000)
+	}
+
+	s.mockShard.GetConfig().EnableGracefulShardHandoff = dynamicconfig.GetBoolPropertyFn(true)
+	processorBase := s.newTestTransferQueueProcessorBase(processingQueueStates, updateMaxReadLevel, nil, nil, nil)
+	processorBase.processQueueCollectionsFn = func() {}
+	updated := false
+	processorBase.updateAckLevelFn = func() (bool, task.Key, error) {
+		updated = true
+		return false, nil, nil
+	}
+
+	processorBase.Start()
+	processorBase.Stop()
+	s.True(updated)
+}
+
 func (s *transferQueueProcessorBaseSuite) newTestTransferQueueProcessorBase(
 	processingQueueStates []ProcessingQueueState,
 	maxReadLevel updateMaxReadLevelFn,
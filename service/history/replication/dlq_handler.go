@@ -22,6 +22,8 @@ package replication
 
 import (
 	"context"
+	"math"
+	"sort"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -29,16 +31,29 @@ import (
 
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/history/config"
+	"github.com/uber/cadence/service/history/execution"
 	"github.com/uber/cadence/service/history/shard"
 )
 
 const (
 	defaultBeginningMessageID = -1
+
+	// DLQReasonMissingHistory means the events of the DLQ message cannot be found,
+	// neither in the current cluster nor in the source cluster
+	DLQReasonMissingHistory = "missing-history"
+	// DLQReasonVersionConflict means the DLQ message conflicts with the version or events of the workflow
+	DLQReasonVersionConflict = "version-conflict"
+	// DLQReasonTransient means the DLQ message failed with an error which may go away by retrying
+	DLQReasonTransient = "transient"
+	// DLQReasonUnclassified is reported for DLQ messages not yet attempted by the DLQ auto retry processor
+	DLQReasonUnclassified = "unclassified"
 )
 
 var (
@@ -78,6 +93,8 @@ type (
 	dlqHandlerImpl struct {
 		taskExecutors map[string]TaskExecutor
 		shard         shard.Context
+		config        *config.Config
+		timeSource    clock.TimeSource
 		logger        log.Logger
 		metricsClient metrics.Client
 		done          chan struct{}
@@ -85,6 +102,15 @@ type (
 
 		mu           sync.Mutex
 		latestCounts map[string]int64
+		// retryStates tracks the DLQ messages attempted by the auto retry processor by source cluster and task ID,
+		// it is kept in memory only and starts over when the shard moves to another host
+		retryStates map[string]map[int64]*dlqRetryState
+	}
+
+	dlqRetryState struct {
+		reason      string
+		attempts    int
+		nextAttempt time.Time
 	}
 )
 
@@ -103,9 +129,12 @@ func NewDLQHandler(
 	return &dlqHandlerImpl{
 		shard:         shard,
 		taskExecutors: taskExecutors,
+		config:        shard.GetConfig(),
+		timeSource:    shard.GetTimeSource(),
 		logger:        shard.GetLogger(),
 		metricsClient: shard.GetMetricsClient(),
 		done:          make(chan struct{}),
+		retryStates:   make(map[string]map[int64]*dlqRetryState),
 	}
 }
 
@@ -116,6 +145,7 @@ func (r *dlqHandlerImpl) Start() {
 	}
 
 	go r.emitDLQSizeMetricsLoop()
+	go r.autoRetryLoop()
 	r.logger.Info("DLQ handler started.")
 }
 
@@ -215,7 +245,32 @@ func (r *dlqHandlerImpl) readMessagesWithAckLevel(
 	pageToken []byte,
 ) ([]*types.ReplicationTask, []*types.ReplicationTaskInfo, []byte, error) {
 
-	resp, err := r.shard.GetExecutionManager().GetReplicationTasksFromDLQ(
+	resp, err := r.getRawMessages(ctx, sourceCluster, lastMessageID, pageSize, pageToken)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	taskInfo := make([]*types.ReplicationTaskInfo, 0, len(resp.Tasks))
+	for _, task := range resp.Tasks {
+		taskInfo = append(taskInfo, toReplicationTaskInfo(task))
+	}
+	tasks, err := r.hydrateMessages(ctx, sourceCluster, taskInfo)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return tasks, taskInfo, resp.NextPageToken, nil
+}
+
+func (r *dlqHandlerImpl) getRawMessages(
+	ctx context.Context,
+	sourceCluster string,
+	lastMessageID int64,
+	pageSize int,
+	pageToken []byte,
+) (*persistence.GetReplicationTasksFromDLQResponse, error) {
+
+	return r.shard.GetExecutionManager().GetReplicationTasksFromDLQ(
 		ctx,
 		&persistence.GetReplicationTasksFromDLQRequest{
 			SourceClusterName: sourceCluster,
@@ -227,43 +282,47 @@ func (r *dlqHandlerImpl) readMessagesWithAckLevel(
 			},
 		},
 	)
-	if err != nil {
-		return nil, nil, nil, err
-	}
+}
+
+// hydrateMessages fetches the replication tasks of the DLQ messages from the source cluster
+func (r *dlqHandlerImpl) hydrateMessages(
+	ctx context.Context,
+	sourceCluster string,
+	taskInfo []*types.ReplicationTaskInfo,
+) ([]*types.ReplicationTask, error) {
 
 	remoteAdminClient := r.shard.GetService().GetClientBean().GetRemoteAdminClient(sourceCluster)
 	if remoteAdminClient == nil {
-		return nil, nil, nil, errInvalidCluster
+		return nil, errInvalidCluster
 	}
 
-	taskInfo := make([]*types.ReplicationTaskInfo, 0, len(resp.Tasks))
-	for _, task := range resp.Tasks {
-		taskInfo = append(taskInfo, &types.ReplicationTaskInfo{
-			DomainID:     task.GetDomainID(),
-			WorkflowID:   task.GetWorkflowID(),
-			RunID:        task.GetRunID(),
-			TaskType:     int16(task.GetTaskType()),
-			TaskID:       task.GetTaskID(),
-			Version:      task.GetVersion(),
-			FirstEventID: task.FirstEventID,
-			NextEventID:  task.NextEventID,
-			ScheduledID:  task.ScheduledID,
-		})
-	}
-	response := &types.GetDLQReplicationMessagesResponse{}
-	if len(taskInfo) > 0 {
-		response, err = remoteAdminClient.GetDLQReplicationMessages(
-			ctx,
-			&types.GetDLQReplicationMessagesRequest{
-				TaskInfos: taskInfo,
-			},
-		)
-		if err != nil {
-			return nil, nil, nil, err
-		}
+	if len(taskInfo) == 0 {
+		return nil, nil
+	}
+	response, err := remoteAdminClient.GetDLQReplicationMessages(
+		ctx,
+		&types.GetDLQReplicationMessagesRequest{
+			TaskInfos: taskInfo,
+		},
+	)
+	if err != nil {
+		return nil, err
 	}
+	return response.ReplicationTasks, nil
+}
 
-	return response.ReplicationTasks, taskInfo, resp.NextPageToken, nil
+func toReplicationTaskInfo(task *persistence.ReplicationTaskInfo) *types.ReplicationTaskInfo {
+	return &types.ReplicationTaskInfo{
+		DomainID:     task.GetDomainID(),
+		WorkflowID:   task.GetWorkflowID(),
+		RunID:        task.GetRunID(),
+		TaskType:     int16(task.GetTaskType()),
+		TaskID:       task.GetTaskID(),
+		Version:      task.GetVersion(),
+		FirstEventID: task.FirstEventID,
+		NextEventID:  task.NextEventID,
+		ScheduledID:  task.ScheduledID,
+	}
 }
 
 func (r *dlqHandlerImpl) PurgeMessages(
@@ -342,3 +401,210 @@ func (r *dlqHandlerImpl) MergeMessages(
 	}
 	return token, nil
 }
+
+func (r *dlqHandlerImpl) autoRetryLoop() {
+	getInterval := func() time.Duration {
+		return backoff.JitDuration(
+			r.config.ReplicationDLQAutoRetryInterval(),
+			dlqMetricsEmitTimerCoefficient,
+		)
+	}
+
+	timer := time.NewTimer(getInterval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			if r.config.EnableReplicationDLQAutoRetry() {
+				r.retryMessages(context.Background())
+			}
+			timer.Reset(getInterval())
+		case <-r.done:
+			return
+		}
+	}
+}
+
+func (r *dlqHandlerImpl) retryMessages(ctx context.Context) {
+	sourceClusters := make([]string, 0, len(r.taskExecutors))
+	for sourceCluster := range r.taskExecutors {
+		sourceClusters = append(sourceClusters, sourceCluster)
+	}
+	sort.Strings(sourceClusters)
+
+	for _, sourceCluster := range sourceClusters {
+		if err := r.retryMessagesForCluster(ctx, sourceCluster); err != nil {
+			r.logger.Warn("failed to auto retry replication DLQ messages", tag.SourceCluster(sourceCluster), tag.Error(err))
+		}
+	}
+}
+
+// retryMessagesForCluster re-applies the DLQ messages of the source cluster which are new or failed with a transient
+// error and are due for retry. Applied messages are deleted from the DLQ, the others stay until they are merged or purged.
+func (r *dlqHandlerImpl) retryMessagesForCluster(ctx context.Context, sourceCluster string) error {
+	shardID := strconv.Itoa(r.shard.GetShardID())
+	scope := r.metricsClient.Scope(
+		metrics.ReplicationDLQStatsScope,
+		metrics.SourceClusterTag(sourceCluster),
+		metrics.InstanceTag(shardID),
+	)
+	maxAttempts := r.config.ReplicationDLQAutoRetryMaxAttempts()
+
+	seen := make(map[int64]struct{})
+	var pageToken []byte
+	for {
+		resp, err := r.getRawMessages(ctx, sourceCluster, math.MaxInt64, r.config.ReplicationDLQAutoRetryBatchSize(), pageToken)
+		if err != nil {
+			return err
+		}
+
+		now := r.timeSource.Now()
+		var taskInfo []*types.ReplicationTaskInfo
+		for _, task := range resp.Tasks {
+			seen[task.GetTaskID()] = struct{}{}
+			if state := r.getRetryState(sourceCluster, task.GetTaskID()); state != nil {
+				if state.reason != DLQReasonTransient || state.attempts >= maxAttempts || now.Before(state.nextAttempt) {
+					continue
+				}
+			}
+			taskInfo = append(taskInfo, toReplicationTaskInfo(task))
+		}
+
+		tasks, err := r.hydrateMessages(ctx, sourceCluster, taskInfo)
+		if err != nil {
+			return err
+		}
+		replicationTasks := make(map[int64]*types.ReplicationTask, len(tasks))
+		for _, task := range tasks {
+			replicationTasks[task.SourceTaskID] = task
+		}
+
+		for _, info := range taskInfo {
+			task, ok := replicationTasks[info.TaskID]
+			if !ok {
+				// the source cluster no longer has the events of the message
+				r.recordFailure(sourceCluster, info.TaskID, DLQReasonMissingHistory, now)
+				scope.Tagged(metrics.ReplicationDLQReasonTag(DLQReasonMissingHistory)).IncCounter(metrics.ReplicationDLQAutoRetryFailed)
+				continue
+			}
+
+			if _, err := r.taskExecutors[sourceCluster].execute(task, true); err != nil {
+				reason := classifyDLQError(err)
+				state := r.recordFailure(sourceCluster, info.TaskID, reason, now)
+				scope.Tagged(metrics.ReplicationDLQReasonTag(reason)).IncCounter(metrics.ReplicationDLQAutoRetryFailed)
+				if reason == DLQReasonTransient && state.attempts >= maxAttempts {
+					scope.IncCounter(metrics.ReplicationDLQAutoRetryExhausted)
+					r.logger.Warn("replication DLQ message exhausted auto retry attempts",
+						tag.SourceCluster(sourceCluster),
+						tag.TaskID(info.TaskID),
+						tag.WorkflowDomainID(info.DomainID),
+						tag.WorkflowID(info.WorkflowID),
+						tag.WorkflowRunID(info.RunID),
+						tag.Error(err),
+					)
+				}
+				continue
+			}
+
+			if err := r.shard.GetExecutionManager().DeleteReplicationTaskFromDLQ(
+				ctx,
+				&persistence.DeleteReplicationTaskFromDLQRequest{
+					SourceClusterName: sourceCluster,
+					TaskID:            info.TaskID,
+				},
+			); err != nil {
+				return err
+			}
+			delete(seen, info.TaskID)
+			scope.IncCounter(metrics.ReplicationDLQAutoRetrySucceeded)
+		}
+
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// forget the messages no longer in the DLQ, e.g. applied, merged or purged
+	states := r.retryStates[sourceCluster]
+	for taskID := range states {
+		if _, ok := seen[taskID]; !ok {
+			delete(states, taskID)
+		}
+	}
+	reasonCounts := map[string]int{
+		DLQReasonMissingHistory:  0,
+		DLQReasonVersionConflict: 0,
+		DLQReasonTransient:       0,
+		DLQReasonUnclassified:    len(seen) - len(states),
+	}
+	for _, state := range states {
+		reasonCounts[state.reason]++
+	}
+	for reason, count := range reasonCounts {
+		scope.Tagged(metrics.ReplicationDLQReasonTag(reason)).UpdateGauge(metrics.ReplicationDLQSizeByReason, float64(count))
+	}
+	return nil
+}
+
+func (r *dlqHandlerImpl) getRetryState(sourceCluster string, taskID int64) *dlqRetryState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.retryStates[sourceCluster][taskID]
+}
+
+func (r *dlqHandlerImpl) getReason(sourceCluster string, taskID int64) string {
+	if state := r.getRetryState(sourceCluster, taskID); state != nil {
+		return state.reason
+	}
+	return DLQReasonUnclassified
+}
+
+func (r *dlqHandlerImpl) recordFailure(
+	sourceCluster string,
+	taskID int64,
+	reason string,
+	now time.Time,
+) dlqRetryState {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	states, ok := r.retryStates[sourceCluster]
+	if !ok {
+		states = make(map[int64]*dlqRetryState)
+		r.retryStates[sourceCluster] = states
+	}
+	state, ok := states[taskID]
+	if !ok {
+		state = &dlqRetryState{}
+		states[taskID] = state
+	}
+	state.reason = reason
+	state.attempts++
+
+	retryPolicy := backoff.NewExponentialRetryPolicy(r.config.ReplicationDLQAutoRetryInterval())
+	retryPolicy.SetMaximumInterval(r.config.ReplicationDLQAutoRetryMaxBackoff())
+	retryPolicy.SetExpirationInterval(backoff.NoInterval)
+	state.nextAttempt = now.Add(retryPolicy.ComputeNextDelay(0, state.attempts-1))
+	return *state
+}
+
+// classifyDLQError maps the error of applying a DLQ message to the reason the message stays in the DLQ
+func classifyDLQError(err error) string {
+	switch err.(type) {
+	case *types.RetryTaskV2Error, *types.EntityNotExistsError:
+		return DLQReasonMissingHistory
+	case *types.BadRequestError:
+		// the ndc replicator reports mismatched versions and events as bad requests
+		return DLQReasonVersionConflict
+	}
+	if err == execution.ErrMissingVersionHistories {
+		return DLQReasonVersionConflict
+	}
+	return DLQReasonTransient
+}
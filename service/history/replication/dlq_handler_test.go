@@ -23,6 +23,7 @@ package replication
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/pborman/uuid"
@@ -32,10 +33,13 @@ import (
 
 	"github.com/uber/cadence/client"
 	"github.com/uber/cadence/client/admin"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/mocks"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/history/config"
+	"github.com/uber/cadence/service/history/execution"
 	"github.com/uber/cadence/service/history/shard"
 )
 
@@ -222,14 +226,144 @@ func (s *dlqHandlerSuite) TestMergeMessages_OK() {
 	s.Equal(1, len(s.taskExecutor.executedTasks))
 }
 
+func (s *dlqHandlerSuite) TestRetryMessages() {
+	ctx := context.Background()
+	timeSource := clock.NewMockedTimeSource()
+	s.messageHandler.timeSource = timeSource
+	s.config.ReplicationDLQAutoRetryMaxAttempts = dynamicconfig.GetIntPropertyFn(2)
+	s.config.ReplicationDLQAutoRetryInterval = dynamicconfig.GetDurationPropertyFn(time.Minute)
+	s.config.ReplicationDLQAutoRetryMaxBackoff = dynamicconfig.GetDurationPropertyFn(time.Hour)
+	domainID := uuid.New()
+	newTask := func(taskID int64) *persistence.ReplicationTaskInfo {
+		return &persistence.ReplicationTaskInfo{
+			DomainID:   domainID,
+			WorkflowID: uuid.New(),
+			RunID:      uuid.New(),
+			TaskID:     taskID,
+		}
+	}
+	newReplicationTask := func(taskID int64) *types.ReplicationTask {
+		return &types.ReplicationTask{
+			TaskType:     types.ReplicationTaskTypeHistory.Ptr(),
+			SourceTaskID: taskID,
+		}
+	}
+	s.mockClientBean.EXPECT().GetRemoteAdminClient(s.sourceCluster).Return(s.adminClient).AnyTimes()
+	s.mockShard.Resource.DomainCache.EXPECT().GetDomainName(domainID).Return("test-domain", nil).AnyTimes()
+
+	// first pass: task 1 is applied, task 2 fails with a transient error and task 3 no longer exists in the source cluster
+	s.executionManager.On("GetReplicationTasksFromDLQ", mock.Anything, mock.Anything).Return(&persistence.GetReplicationTasksFromDLQResponse{
+		Tasks: []*persistence.ReplicationTaskInfo{newTask(1), newTask(2), newTask(3)},
+	}, nil).Once()
+	s.adminClient.EXPECT().GetDLQReplicationMessages(ctx, gomock.Any()).Return(&types.GetDLQReplicationMessagesResponse{
+		ReplicationTasks: []*types.ReplicationTask{newReplicationTask(1), newReplicationTask(2)},
+	}, nil).Times(1)
+	s.taskExecutor.errs = map[int64]error{2: &types.ServiceBusyError{}}
+	s.executionManager.On("DeleteReplicationTaskFromDLQ", mock.Anything, &persistence.DeleteReplicationTaskFromDLQRequest{
+		SourceClusterName: s.sourceCluster,
+		TaskID:            1,
+	}).Return(nil).Once()
+
+	s.NoError(s.messageHandler.retryMessagesForCluster(ctx, s.sourceCluster))
+	s.Len(s.taskExecutor.executedTasks, 2)
+	s.Equal(DLQReasonTransient, s.messageHandler.getReason(s.sourceCluster, 2))
+	s.Equal(DLQReasonMissingHistory, s.messageHandler.getReason(s.sourceCluster, 3))
+
+	// second pass: task 2 is still backing off and task 3 is not retried
+	s.executionManager.On("GetReplicationTasksFromDLQ", mock.Anything, mock.Anything).Return(&persistence.GetReplicationTasksFromDLQResponse{
+		Tasks: []*persistence.ReplicationTaskInfo{newTask(2), newTask(3)},
+	}, nil).Once()
+
+	s.NoError(s.messageHandler.retryMessagesForCluster(ctx, s.sourceCluster))
+	s.Len(s.taskExecutor.executedTasks, 2)
+
+	// third pass: task 2 is retried after the backoff and fails with a version conflict
+	timeSource.Advance(2 * time.Minute)
+	s.executionManager.On("GetReplicationTasksFromDLQ", mock.Anything, mock.Anything).Return(&persistence.GetReplicationTasksFromDLQResponse{
+		Tasks: []*persistence.ReplicationTaskInfo{newTask(2), newTask(3)},
+	}, nil).Once()
+	s.adminClient.EXPECT().GetDLQReplicationMessages(ctx, gomock.Any()).Return(&types.GetDLQReplicationMessagesResponse{
+		ReplicationTasks: []*types.ReplicationTask{newReplicationTask(2)},
+	}, nil).Times(1)
+	s.taskExecutor.errs = map[int64]error{2: &types.BadRequestError{}}
+
+	s.NoError(s.messageHandler.retryMessagesForCluster(ctx, s.sourceCluster))
+	s.Len(s.taskExecutor.executedTasks, 3)
+	s.Equal(DLQReasonVersionConflict, s.messageHandler.getReason(s.sourceCluster, 2))
+}
+
+func (s *dlqHandlerSuite) TestRetryMessages_MaxAttempts() {
+	ctx := context.Background()
+	timeSource := clock.NewMockedTimeSource()
+	s.messageHandler.timeSource = timeSource
+	s.config.ReplicationDLQAutoRetryMaxAttempts = dynamicconfig.GetIntPropertyFn(1)
+	s.config.ReplicationDLQAutoRetryInterval = dynamicconfig.GetDurationPropertyFn(time.Minute)
+
+	s.mockClientBean.EXPECT().GetRemoteAdminClient(s.sourceCluster).Return(s.adminClient).AnyTimes()
+	s.executionManager.On("GetReplicationTasksFromDLQ", mock.Anything, mock.Anything).Return(&persistence.GetReplicationTasksFromDLQResponse{
+		Tasks: []*persistence.ReplicationTaskInfo{{DomainID: uuid.New(), TaskID: 1}},
+	}, nil).Times(2)
+	s.adminClient.EXPECT().GetDLQReplicationMessages(ctx, gomock.Any()).Return(&types.GetDLQReplicationMessagesResponse{
+		ReplicationTasks: []*types.ReplicationTask{{TaskType: types.ReplicationTaskTypeHistory.Ptr(), SourceTaskID: 1}},
+	}, nil).Times(1)
+	s.taskExecutor.err = &types.InternalServiceError{}
+
+	s.NoError(s.messageHandler.retryMessagesForCluster(ctx, s.sourceCluster))
+	timeSource.Advance(time.Hour)
+	s.NoError(s.messageHandler.retryMessagesForCluster(ctx, s.sourceCluster))
+	s.Len(s.taskExecutor.executedTasks, 1)
+	s.Equal(DLQReasonTransient, s.messageHandler.getReason(s.sourceCluster, 1))
+}
+
+func TestClassifyDLQError(t *testing.T) {
+	tests := map[string]struct {
+		err    error
+		reason string
+	}{
+		"retry task": {
+			err:    &types.RetryTaskV2Error{},
+			reason: DLQReasonMissingHistory,
+		},
+		"entity not exists": {
+			err:    &types.EntityNotExistsError{},
+			reason: DLQReasonMissingHistory,
+		},
+		"bad request": {
+			err:    &types.BadRequestError{Message: "event version mismatch"},
+			reason: DLQReasonVersionConflict,
+		},
+		"missing version histories": {
+			err:    execution.ErrMissingVersionHistories,
+			reason: DLQReasonVersionConflict,
+		},
+		"service busy": {
+			err:    &types.ServiceBusyError{},
+			reason: DLQReasonTransient,
+		},
+		"internal service error": {
+			err:    &types.InternalServiceError{},
+			reason: DLQReasonTransient,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tt.reason, classifyDLQError(tt.err))
+		})
+	}
+}
+
 type fakeTaskExecutor struct {
 	scope int
 	err   error
+	errs  map[int64]error
 
 	executedTasks []*types.ReplicationTask
 }
 
 func (e *fakeTaskExecutor) execute(replicationTask *types.ReplicationTask, forceApply bool) (int, error) {
 	e.executedTasks = append(e.executedTasks, replicationTask)
+	if err, ok := e.errs[replicationTask.SourceTaskID]; ok {
+		return e.scope, err
+	}
 	return e.scope, e.err
 }
@@ -0,0 +1,422 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package replication
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/clock"
+	commonconfig "github.com/uber/cadence/common/config"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/messaging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/quotas"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/history/config"
+)
+
+type (
+	// kafkaTaskFetcherImpl serves the replication messages the source cluster publishes to the messaging system.
+	// Messages are buffered per shard until the task processor of the shard reports it has processed them,
+	// which is then acked to the source cluster through the messaging system as well. Requests that cannot be
+	// served from the buffer, e.g. a request behind the buffered messages, ask the source cluster to publish
+	// the tasks again. The rpc fetcher is only used when the messages cannot be consumed.
+	kafkaTaskFetcherImpl struct {
+		status         int32
+		currentCluster string
+		sourceCluster  string
+		config         *config.Config
+		logger         log.Logger
+		scope          metrics.Scope
+		consumer       messaging.Consumer
+		ackProducer    messaging.Producer
+		fallback       TaskFetcher
+		timeSource     clock.TimeSource
+		requestChan    chan *request
+		shutdownCh     chan struct{}
+		shutdownWG     sync.WaitGroup
+		started        bool
+		streaming      bool
+
+		batchesByShard          map[int32][]*streamBatch
+		droppedLevelByShard     map[int32]int64
+		syncShardByShard        map[int32]*types.SyncShardStatus
+		replicationLevelByShard map[int32]int64
+		ackedLevelByShard       map[int32]int64
+		rewindTimeByShard       map[int32]time.Time
+
+		acksLock    sync.Mutex
+		pendingAcks map[int32]*messaging.ReplicationAckMessage
+	}
+
+	streamBatch struct {
+		message            messaging.Message
+		replicationMessage *messaging.ReplicationMessage
+	}
+)
+
+var _ TaskFetcher = (*kafkaTaskFetcherImpl)(nil)
+
+func isKafkaReplicationTransport(clusterInfo commonconfig.ClusterInformation) bool {
+	return clusterInfo.ReplicationTransport == commonconfig.ReplicationTransportKafka
+}
+
+// newKafkaTaskFetcher creates a new fetcher consuming the replication messages published by the source cluster.
+func newKafkaTaskFetcher(
+	logger log.Logger,
+	sourceCluster string,
+	currentCluster string,
+	config *config.Config,
+	messagingClient messaging.Client,
+	metricsClient metrics.Client,
+	hostName string,
+	fallback TaskFetcher,
+) (TaskFetcher, error) {
+	consumerName := fmt.Sprintf("%v-%v-%v", commonconfig.ReplicationKafkaApplication(sourceCluster), currentCluster, hostName)
+	consumer, err := messagingClient.NewConsumer(commonconfig.ReplicationKafkaApplication(sourceCluster), consumerName)
+	if err != nil {
+		return nil, err
+	}
+	ackProducer, err := messagingClient.NewProducer(commonconfig.ReplicationAckKafkaApplication(sourceCluster))
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaTaskFetcherImpl{
+		status:                  common.DaemonStatusInitialized,
+		currentCluster:          currentCluster,
+		sourceCluster:           sourceCluster,
+		config:                  config,
+		logger:                  logger.WithTags(tag.ClusterName(sourceCluster)),
+		scope:                   metricsClient.Scope(metrics.ReplicationTaskFetcherScope, metrics.TargetClusterTag(sourceCluster)),
+		consumer:                consumer,
+		ackProducer:             ackProducer,
+		fallback:                fallback,
+		timeSource:              clock.NewRealTimeSource(),
+		requestChan:             make(chan *request, requestChanBufferSize),
+		shutdownCh:              make(chan struct{}),
+		batchesByShard:          make(map[int32][]*streamBatch),
+		droppedLevelByShard:     make(map[int32]int64),
+		syncShardByShard:        make(map[int32]*types.SyncShardStatus),
+		replicationLevelByShard: make(map[int32]int64),
+		ackedLevelByShard:       make(map[int32]int64),
+		rewindTimeByShard:       make(map[int32]time.Time),
+		pendingAcks:             make(map[int32]*messaging.ReplicationAckMessage),
+	}, nil
+}
+
+// Start starts the fetcher
+func (f *kafkaTaskFetcherImpl) Start() {
+	if !atomic.CompareAndSwapInt32(&f.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+
+	f.fallback.Start()
+	if err := f.consumer.Start(); err != nil {
+		f.logger.Error("Failed to start replication message consumer, falling back to rpc.", tag.Error(err))
+	} else {
+		f.started = true
+		f.streaming = true
+	}
+	f.shutdownWG.Add(2)
+	go f.processLoop()
+	go f.ackLoop()
+	f.logger.Info("Replication kafka task fetcher started.")
+}
+
+// Stop stops the fetcher
+func (f *kafkaTaskFetcherImpl) Stop() {
+	if !atomic.CompareAndSwapInt32(&f.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+
+	close(f.shutdownCh)
+	f.shutdownWG.Wait()
+	if f.started {
+		f.consumer.Stop()
+	}
+	f.fallback.Stop()
+	f.logger.Info("Replication kafka task fetcher stopped.")
+}
+
+func (f *kafkaTaskFetcherImpl) processLoop() {
+	defer f.shutdownWG.Done()
+
+	var messageCh <-chan messaging.Message
+	if f.streaming {
+		messageCh = f.consumer.Messages()
+	}
+	for {
+		select {
+		case message, ok := <-messageCh:
+			if !ok {
+				f.logger.Warn("Replication message consumer closed, falling back to rpc.")
+				messageCh = nil
+				f.streaming = false
+				continue
+			}
+			f.handleMessage(message)
+		case request := <-f.requestChan:
+			f.handleRequest(request)
+		case <-f.shutdownCh:
+			return
+		}
+	}
+}
+
+func (f *kafkaTaskFetcherImpl) handleMessage(message messaging.Message) {
+	replicationMessage, err := messaging.DecodeReplicationMessage(message.Value())
+	if err != nil {
+		f.logger.Error("Failed to decode replication message.", tag.Error(err))
+		if err := message.Nack(); err != nil {
+			f.logger.Error("Failed to nack replication message.", tag.Error(err))
+		}
+		return
+	}
+	if replicationMessage.TargetCluster != f.currentCluster || replicationMessage.Messages == nil {
+		f.ackMessage(message)
+		return
+	}
+
+	shardID := replicationMessage.ShardID
+	if status := replicationMessage.Messages.SyncShardStatus; status != nil {
+		f.syncShardByShard[shardID] = status
+	}
+	f.replicationLevelByShard[shardID] = replicationMessage.ReplicationLevel
+	if len(replicationMessage.Messages.ReplicationTasks) == 0 &&
+		replicationMessage.Messages.LastRetrievedMessageID <= replicationMessage.PreviousMessageID {
+		// message only carries the sync shard status
+		f.ackMessage(message)
+		return
+	}
+
+	// batches published again, after a rewind or when the source shard is reloaded, replace the buffered
+	// batches they overlap
+	batches := f.batchesByShard[shardID]
+	for len(batches) > 0 && batches[len(batches)-1].replicationMessage.PreviousMessageID >= replicationMessage.PreviousMessageID {
+		f.ackMessage(batches[len(batches)-1].message)
+		batches = batches[:len(batches)-1]
+	}
+	batches = append(batches, &streamBatch{
+		message:            message,
+		replicationMessage: replicationMessage,
+	})
+	if maxBatches := f.config.ReplicationTaskStreamMaxBufferedBatchesPerShard(); len(batches) > maxBatches {
+		// the shard is either not owned by this host or falls behind, the dropped batches
+		// will be published again by the source cluster when needed
+		for _, dropped := range batches[:len(batches)-maxBatches] {
+			if level := dropped.replicationMessage.Messages.LastRetrievedMessageID; level > f.droppedLevelByShard[shardID] {
+				f.droppedLevelByShard[shardID] = level
+			}
+			f.ackMessage(dropped.message)
+			f.scope.IncCounter(metrics.ReplicationStreamBatchesDropped)
+		}
+		batches = batches[len(batches)-maxBatches:]
+	}
+	f.batchesByShard[shardID] = batches
+}
+
+func (f *kafkaTaskFetcherImpl) handleRequest(request *request) {
+	shardID := request.token.GetShardID()
+	readLevel := request.token.GetLastRetrievedMessageID()
+	if !f.streaming {
+		f.fallbackRequest(request)
+		return
+	}
+	if readLevel == common.EmptyMessageID {
+		// the first request of a shard starts from the replication level the source cluster publishes with
+		// the batches, which is the last message ID acked by this cluster
+		replicationLevel, ok := f.replicationLevelByShard[shardID]
+		if !ok {
+			f.rewind(request, common.EmptyMessageID)
+			return
+		}
+		readLevel = replicationLevel
+	}
+	f.ack(shardID, readLevel)
+
+	// the task processor has retrieved the batches up to its read level
+	batches := f.batchesByShard[shardID]
+	for len(batches) > 0 && batches[0].replicationMessage.Messages.LastRetrievedMessageID <= readLevel {
+		f.ackMessage(batches[0].message)
+		batches = batches[1:]
+	}
+	f.batchesByShard[shardID] = batches
+
+	if len(batches) == 0 {
+		if readLevel < f.droppedLevelByShard[shardID] {
+			f.rewind(request, readLevel)
+			return
+		}
+		f.respond(request, &types.ReplicationMessages{
+			LastRetrievedMessageID: readLevel,
+			SyncShardStatus:        f.syncShardByShard[shardID],
+		})
+		return
+	}
+	if batches[0].replicationMessage.PreviousMessageID > readLevel {
+		f.logger.Warn("Gap between replication read level and buffered messages.",
+			tag.ShardID(int(shardID)),
+			tag.ReadLevel(readLevel),
+		)
+		f.rewind(request, readLevel)
+		return
+	}
+
+	// batches published again after the source shard is reloaded may overlap with the previous ones
+	response := &types.ReplicationMessages{
+		LastRetrievedMessageID: readLevel,
+		SyncShardStatus:        f.syncShardByShard[shardID],
+	}
+	for _, batch := range batches {
+		for _, task := range batch.replicationMessage.Messages.ReplicationTasks {
+			if task.SourceTaskID > response.LastRetrievedMessageID {
+				response.ReplicationTasks = append(response.ReplicationTasks, task)
+			}
+		}
+		if level := batch.replicationMessage.Messages.LastRetrievedMessageID; level > response.LastRetrievedMessageID {
+			response.LastRetrievedMessageID = level
+		}
+	}
+	f.respond(request, response)
+}
+
+func (f *kafkaTaskFetcherImpl) fallbackRequest(request *request) {
+	f.scope.IncCounter(metrics.ReplicationStreamFallbackCount)
+	select {
+	case f.fallback.GetRequestChan() <- request:
+	case <-f.shutdownCh:
+	}
+}
+
+// rewind asks the source cluster to publish again the replication tasks after the read level, at most once
+// every ReplicationTaskFetcherErrorRetryWait, and responds without task until they are buffered
+func (f *kafkaTaskFetcherImpl) rewind(request *request, readLevel int64) {
+	shardID := request.token.GetShardID()
+	now := f.timeSource.Now()
+	if now.Sub(f.rewindTimeByShard[shardID]) >= f.config.ReplicationTaskFetcherErrorRetryWait() {
+		f.rewindTimeByShard[shardID] = now
+		f.scope.IncCounter(metrics.ReplicationStreamRewindCount)
+		f.addPendingAck(&messaging.ReplicationAckMessage{
+			SourceCluster:  f.sourceCluster,
+			TargetCluster:  f.currentCluster,
+			ShardID:        shardID,
+			AckedMessageID: readLevel,
+			Rewind:         true,
+		})
+	}
+	f.respond(request, &types.ReplicationMessages{
+		LastRetrievedMessageID: request.token.GetLastRetrievedMessageID(),
+		SyncShardStatus:        f.syncShardByShard[shardID],
+	})
+}
+
+// ack reports to the source cluster the read level of a shard, which the task processor has processed
+func (f *kafkaTaskFetcherImpl) ack(shardID int32, readLevel int64) {
+	if ackedLevel, ok := f.ackedLevelByShard[shardID]; ok && readLevel <= ackedLevel {
+		return
+	}
+	f.ackedLevelByShard[shardID] = readLevel
+	f.addPendingAck(&messaging.ReplicationAckMessage{
+		SourceCluster:  f.sourceCluster,
+		TargetCluster:  f.currentCluster,
+		ShardID:        shardID,
+		AckedMessageID: readLevel,
+	})
+}
+
+func (f *kafkaTaskFetcherImpl) addPendingAck(ack *messaging.ReplicationAckMessage) {
+	f.acksLock.Lock()
+	defer f.acksLock.Unlock()
+
+	if pending, ok := f.pendingAcks[ack.ShardID]; ok {
+		ack.Rewind = ack.Rewind || pending.Rewind
+		if pending.AckedMessageID > ack.AckedMessageID {
+			ack.AckedMessageID = pending.AckedMessageID
+		}
+	}
+	f.pendingAcks[ack.ShardID] = ack
+}
+
+// ackLoop publishes the pending acks every ReplicationTaskPublisherInterval, so that the processing of the
+// requests does not wait for the messaging system
+func (f *kafkaTaskFetcherImpl) ackLoop() {
+	defer f.shutdownWG.Done()
+
+	ticker := time.NewTicker(f.config.ReplicationTaskPublisherInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			f.publishAcks()
+		case <-f.shutdownCh:
+			return
+		}
+	}
+}
+
+func (f *kafkaTaskFetcherImpl) publishAcks() {
+	f.acksLock.Lock()
+	acks := f.pendingAcks
+	f.pendingAcks = make(map[int32]*messaging.ReplicationAckMessage)
+	f.acksLock.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTaskRequestTimeout)
+	defer cancel()
+	for _, ack := range acks {
+		if err := f.ackProducer.Publish(ctx, ack); err != nil {
+			f.logger.Warn("Failed to publish replication ack.", tag.ShardID(int(ack.ShardID)), tag.Error(err))
+			// the ack is published again with the next pending ack of the shard
+			f.addPendingAck(ack)
+		}
+	}
+}
+
+func (f *kafkaTaskFetcherImpl) respond(request *request, response *types.ReplicationMessages) {
+	request.respChan <- response
+	close(request.respChan)
+}
+
+func (f *kafkaTaskFetcherImpl) ackMessage(message messaging.Message) {
+	if err := message.Ack(); err != nil {
+		f.logger.Error("Failed to ack replication message.", tag.Error(err))
+	}
+}
+
+// GetSourceCluster returns the source cluster for the fetcher
+func (f *kafkaTaskFetcherImpl) GetSourceCluster() string {
+	return f.sourceCluster
+}
+
+// GetRequestChan returns the request chan for the fetcher
+func (f *kafkaTaskFetcherImpl) GetRequestChan() chan<- *request {
+	return f.requestChan
+}
+
+// GetRateLimiter returns the host level rate limiter for the fetcher
+func (f *kafkaTaskFetcherImpl) GetRateLimiter() *quotas.DynamicRateLimiter {
+	return f.fallback.GetRateLimiter()
+}
@@ -0,0 +1,373 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package replication
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/cluster"
+	commonconfig "github.com/uber/cadence/common/config"
+	"github.com/uber/cadence/common/dynamicconfig"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/messaging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/history/config"
+)
+
+type (
+	fakeMessage struct {
+		value  []byte
+		acked  bool
+		nacked bool
+	}
+
+	fakeConsumer struct {
+		messages chan messaging.Message
+	}
+
+	fakeMessagingClient struct {
+		consumer *fakeConsumer
+	}
+)
+
+func (m *fakeMessage) Value() []byte    { return m.value }
+func (m *fakeMessage) Partition() int32 { return 0 }
+func (m *fakeMessage) Offset() int64    { return 0 }
+func (m *fakeMessage) Ack() error       { m.acked = true; return nil }
+func (m *fakeMessage) Nack() error      { m.nacked = true; return nil }
+
+func (c *fakeConsumer) Start() error                       { return nil }
+func (c *fakeConsumer) Stop()                              {}
+func (c *fakeConsumer) Messages() <-chan messaging.Message { return c.messages }
+
+func (c *fakeMessagingClient) NewConsumer(_, _ string) (messaging.Consumer, error) {
+	return c.consumer, nil
+}
+
+func (c *fakeMessagingClient) NewProducer(_ string) (messaging.Producer, error) {
+	return &fakeProducer{}, nil
+}
+
+func newTestKafkaTaskFetcher(t *testing.T) (*kafkaTaskFetcherImpl, chan *request) {
+	fallbackRequestChan := make(chan *request, 10)
+	fallback := fakeTaskFetcher{sourceCluster: "active", requestChan: fallbackRequestChan}
+	fetcher, err := newKafkaTaskFetcher(
+		log.NewNoop(),
+		"active",
+		"standby",
+		config.NewForTest(),
+		&fakeMessagingClient{consumer: &fakeConsumer{messages: make(chan messaging.Message)}},
+		metrics.NewNoopMetricsClient(),
+		"host",
+		fallback,
+	)
+	require.NoError(t, err)
+	kafkaFetcher := fetcher.(*kafkaTaskFetcherImpl)
+	kafkaFetcher.streaming = true
+	return kafkaFetcher, fallbackRequestChan
+}
+
+func newTestStreamMessage(t *testing.T, targetCluster string, previousMessageID int64, taskIDs ...int64) *fakeMessage {
+	return newTestStreamMessageFor(t, targetCluster, 0, previousMessageID, taskIDs...)
+}
+
+func newTestStreamMessageAt(t *testing.T, replicationLevel int64, previousMessageID int64, taskIDs ...int64) *fakeMessage {
+	return newTestStreamMessageFor(t, "standby", replicationLevel, previousMessageID, taskIDs...)
+}
+
+func newTestStreamMessageFor(t *testing.T, targetCluster string, replicationLevel int64, previousMessageID int64, taskIDs ...int64) *fakeMessage {
+	messages := &types.ReplicationMessages{
+		LastRetrievedMessageID: previousMessageID,
+		SyncShardStatus:        &types.SyncShardStatus{Timestamp: common.Int64Ptr(previousMessageID)},
+	}
+	for _, taskID := range taskIDs {
+		messages.ReplicationTasks = append(messages.ReplicationTasks, &types.ReplicationTask{SourceTaskID: taskID})
+		messages.LastRetrievedMessageID = taskID
+	}
+	payload, err := messaging.EncodeReplicationMessage(&messaging.ReplicationMessage{
+		SourceCluster:     "active",
+		TargetCluster:     targetCluster,
+		ShardID:           1,
+		PreviousMessageID: previousMessageID,
+		ReplicationLevel:  replicationLevel,
+		Messages:          messages,
+	})
+	require.NoError(t, err)
+	return &fakeMessage{value: payload}
+}
+
+func sendTestRequest(fetcher *kafkaTaskFetcherImpl, lastRetrievedMessageID int64) (*request, chan *types.ReplicationMessages) {
+	respChan := make(chan *types.ReplicationMessages, 1)
+	req := &request{
+		token: &types.ReplicationToken{
+			ShardID:                1,
+			LastRetrievedMessageID: lastRetrievedMessageID,
+			LastProcessedMessageID: lastRetrievedMessageID,
+		},
+		respChan: respChan,
+	}
+	fetcher.handleRequest(req)
+	return req, respChan
+}
+
+func taskIDs(response *types.ReplicationMessages) []int64 {
+	var ids []int64
+	for _, task := range response.ReplicationTasks {
+		ids = append(ids, task.SourceTaskID)
+	}
+	return ids
+}
+
+func TestKafkaTaskFetcher_ServeFromBuffer(t *testing.T) {
+	fetcher, fallbackRequestChan := newTestKafkaTaskFetcher(t)
+
+	first := newTestStreamMessage(t, "standby", 5, 6, 7)
+	second := newTestStreamMessage(t, "standby", 7, 9)
+	fetcher.handleMessage(first)
+	fetcher.handleMessage(second)
+
+	_, respChan := sendTestRequest(fetcher, 5)
+	response := <-respChan
+	assert.Equal(t, []int64{6, 7, 9}, taskIDs(response))
+	assert.Equal(t, int64(9), response.LastRetrievedMessageID)
+	assert.Equal(t, int64(7), response.SyncShardStatus.GetTimestamp())
+	assert.Empty(t, fallbackRequestChan)
+	// batches stay buffered until the processor moves past them
+	assert.False(t, first.acked)
+
+	_, respChan = sendTestRequest(fetcher, 7)
+	response = <-respChan
+	assert.Equal(t, []int64{9}, taskIDs(response))
+	assert.True(t, first.acked)
+	assert.False(t, second.acked)
+
+	_, respChan = sendTestRequest(fetcher, 9)
+	response = <-respChan
+	assert.Empty(t, response.ReplicationTasks)
+	assert.Equal(t, int64(9), response.LastRetrievedMessageID)
+	assert.True(t, second.acked)
+}
+
+func TestKafkaTaskFetcher_SkipOverlappingTasks(t *testing.T) {
+	fetcher, _ := newTestKafkaTaskFetcher(t)
+
+	fetcher.handleMessage(newTestStreamMessage(t, "standby", 5, 6, 7))
+	fetcher.handleMessage(newTestStreamMessage(t, "standby", 6, 7, 8))
+
+	_, respChan := sendTestRequest(fetcher, 6)
+	response := <-respChan
+	assert.Equal(t, []int64{7, 8}, taskIDs(response))
+	assert.Equal(t, int64(8), response.LastRetrievedMessageID)
+}
+
+func TestKafkaTaskFetcher_IgnoreOtherMessages(t *testing.T) {
+	fetcher, _ := newTestKafkaTaskFetcher(t)
+
+	otherCluster := newTestStreamMessage(t, "other", 5, 6)
+	syncShardOnly := newTestStreamMessage(t, "standby", 5)
+	invalid := &fakeMessage{value: []byte("invalid")}
+	fetcher.handleMessage(otherCluster)
+	fetcher.handleMessage(syncShardOnly)
+	fetcher.handleMessage(invalid)
+
+	assert.True(t, otherCluster.acked)
+	assert.True(t, syncShardOnly.acked)
+	assert.True(t, invalid.nacked)
+	assert.Empty(t, fetcher.batchesByShard[1])
+
+	_, respChan := sendTestRequest(fetcher, 5)
+	response := <-respChan
+	assert.Empty(t, response.ReplicationTasks)
+	assert.Equal(t, int64(5), response.SyncShardStatus.GetTimestamp())
+}
+
+func TestKafkaTaskFetcher_Rewind(t *testing.T) {
+	fetcher, fallbackRequestChan := newTestKafkaTaskFetcher(t)
+	fetcher.config.ReplicationTaskStreamMaxBufferedBatchesPerShard = dynamicconfig.GetIntPropertyFn(1)
+	timeSource := clock.NewMockedTimeSource()
+	fetcher.timeSource = timeSource
+
+	dropped := newTestStreamMessage(t, "standby", 5, 6)
+	fetcher.handleMessage(dropped)
+	fetcher.handleMessage(newTestStreamMessage(t, "standby", 6, 7))
+	assert.True(t, dropped.acked)
+
+	// the buffered batch does not continue from the read level, the source cluster publishes the tasks again
+	_, respChan := sendTestRequest(fetcher, 5)
+	response := <-respChan
+	assert.Empty(t, response.ReplicationTasks)
+	assert.Equal(t, int64(5), response.LastRetrievedMessageID)
+	assert.Equal(t, &messaging.ReplicationAckMessage{
+		SourceCluster:  "active",
+		TargetCluster:  "standby",
+		ShardID:        1,
+		AckedMessageID: 5,
+		Rewind:         true,
+	}, fetcher.pendingAcks[1])
+
+	// the rewind is not requested again before the retry wait
+	fetcher.publishAcks()
+	_, respChan = sendTestRequest(fetcher, 5)
+	<-respChan
+	assert.Empty(t, fetcher.pendingAcks)
+
+	// the batches published again replace the buffered ones
+	timeSource.Advance(fetcher.config.ReplicationTaskFetcherErrorRetryWait())
+	fetcher.handleMessage(newTestStreamMessage(t, "standby", 5, 6, 7))
+	_, respChan = sendTestRequest(fetcher, 5)
+	assert.Equal(t, []int64{6, 7}, taskIDs(<-respChan))
+	assert.Empty(t, fallbackRequestChan)
+
+	// the dropped batch is behind the read level even when the buffer is empty
+	fetcher.droppedLevelByShard[1] = 8
+	_, respChan = sendTestRequest(fetcher, 7)
+	<-respChan
+	assert.True(t, fetcher.pendingAcks[1].Rewind)
+	assert.Empty(t, fallbackRequestChan)
+}
+
+func TestKafkaTaskFetcher_FirstRequest(t *testing.T) {
+	fetcher, fallbackRequestChan := newTestKafkaTaskFetcher(t)
+
+	// the replication level is not known yet, the source cluster publishes from it again
+	_, respChan := sendTestRequest(fetcher, common.EmptyMessageID)
+	response := <-respChan
+	assert.Empty(t, response.ReplicationTasks)
+	assert.Equal(t, int64(common.EmptyMessageID), response.LastRetrievedMessageID)
+	assert.Equal(t, &messaging.ReplicationAckMessage{
+		SourceCluster:  "active",
+		TargetCluster:  "standby",
+		ShardID:        1,
+		AckedMessageID: common.EmptyMessageID,
+		Rewind:         true,
+	}, fetcher.pendingAcks[1])
+
+	// the request starts from the replication level published with the batches
+	fetcher.handleMessage(newTestStreamMessageAt(t, 5, 5, 6, 7))
+	_, respChan = sendTestRequest(fetcher, common.EmptyMessageID)
+	response = <-respChan
+	assert.Equal(t, []int64{6, 7}, taskIDs(response))
+	assert.Equal(t, int64(7), response.LastRetrievedMessageID)
+	assert.Empty(t, fallbackRequestChan)
+}
+
+func TestKafkaTaskFetcher_Ack(t *testing.T) {
+	fetcher, _ := newTestKafkaTaskFetcher(t)
+	ackProducer := fetcher.ackProducer.(*fakeProducer)
+
+	fetcher.handleMessage(newTestStreamMessage(t, "standby", 5, 6, 7))
+	_, respChan := sendTestRequest(fetcher, 5)
+	<-respChan
+	_, respChan = sendTestRequest(fetcher, 7)
+	<-respChan
+	fetcher.publishAcks()
+	assert.Equal(t, []interface{}{&messaging.ReplicationAckMessage{
+		SourceCluster:  "active",
+		TargetCluster:  "standby",
+		ShardID:        1,
+		AckedMessageID: 7,
+	}}, ackProducer.published)
+
+	// the read level is acked only once
+	_, respChan = sendTestRequest(fetcher, 7)
+	<-respChan
+	fetcher.publishAcks()
+	assert.Len(t, ackProducer.published, 1)
+
+	// acks failed to be published are published again
+	ackProducer.err = errors.New("publish failed")
+	_, respChan = sendTestRequest(fetcher, 9)
+	<-respChan
+	fetcher.publishAcks()
+	assert.Equal(t, int64(9), fetcher.pendingAcks[1].AckedMessageID)
+}
+
+func TestKafkaTaskFetcher_FallbackWithoutStream(t *testing.T) {
+	fetcher, fallbackRequestChan := newTestKafkaTaskFetcher(t)
+	fetcher.streaming = false
+
+	req, _ := sendTestRequest(fetcher, common.EmptyMessageID)
+	assert.Equal(t, req, <-fallbackRequestChan)
+	assert.Empty(t, fetcher.pendingAcks)
+}
+
+func TestNewTaskFetchers_KafkaTransport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clusterMetadata := cluster.NewMetadata(
+		10,
+		"active",
+		"standby",
+		map[string]commonconfig.ClusterInformation{
+			"active":  {Enabled: true, InitialFailoverVersion: 0, ReplicationTransport: commonconfig.ReplicationTransportKafka},
+			"standby": {Enabled: true, InitialFailoverVersion: 1},
+			"other":   {Enabled: true, InitialFailoverVersion: 2},
+		},
+		func(string) bool { return false },
+		metrics.NewNoopMetricsClient(),
+		log.NewNoop(),
+	)
+	clientBean := client.NewMockBean(ctrl)
+	clientBean.EXPECT().GetRemoteAdminClient(gomock.Any()).Return(nil).Times(4)
+	messagingClient := &fakeMessagingClient{consumer: &fakeConsumer{messages: make(chan messaging.Message)}}
+
+	fetchers := NewTaskFetchers(
+		log.NewNoop(),
+		config.NewForTest(),
+		clusterMetadata,
+		clientBean,
+		messagingClient,
+		metrics.NewNoopMetricsClient(),
+		"host",
+	)
+	for _, fetcher := range fetchers.GetFetchers() {
+		switch fetcher.GetSourceCluster() {
+		case "active":
+			assert.IsType(t, &kafkaTaskFetcherImpl{}, fetcher)
+		default:
+			assert.IsType(t, &taskFetcherImpl{}, fetcher)
+		}
+	}
+
+	// without kafka, all the fetchers use rpc
+	fetchers = NewTaskFetchers(
+		log.NewNoop(),
+		config.NewForTest(),
+		clusterMetadata,
+		clientBean,
+		nil,
+		metrics.NewNoopMetricsClient(),
+		"host",
+	)
+	for _, fetcher := range fetchers.GetFetchers() {
+		assert.IsType(t, &taskFetcherImpl{}, fetcher)
+	}
+}
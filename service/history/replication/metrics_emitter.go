@@ -25,17 +25,21 @@ package replication
 import (
 	ctx "context"
 	"fmt"
+	"sort"
 	"strconv"
 	"sync/atomic"
 	"time"
 
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/config"
+	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/types"
 )
 
 const (
@@ -50,10 +54,16 @@ type (
 		remoteClusters map[string]config.ClusterInformation
 		shardData      metricsEmitterShardData
 		reader         taskReader
+		maxScanTasks   dynamicconfig.IntPropertyFn
+		emitDomainLag  dynamicconfig.BoolPropertyFn
 		scope          metrics.Scope
 		logger         log.Logger
 		status         int32
 		done           chan struct{}
+
+		// emittedDomains are the names of the domains with per domain metrics emitted for each remote cluster,
+		// so that the metrics can be reset once the domains are no longer lagging
+		emittedDomains map[string]map[string]string
 	}
 
 	// metricsEmitterShardData is for testing.
@@ -61,7 +71,9 @@ type (
 		GetLogger() log.Logger
 		GetClusterMetadata() cluster.Metadata
 		GetClusterReplicationLevel(cluster string) int64
+		GetTransferMaxReadLevel() int64
 		GetTimeSource() clock.TimeSource
+		GetDomainCache() cache.DomainCache
 	}
 )
 
@@ -71,6 +83,8 @@ func NewMetricsEmitter(
 	shardData metricsEmitterShardData,
 	reader taskReader,
 	metricsClient metrics.Client,
+	maxScanTasks dynamicconfig.IntPropertyFn,
+	emitDomainLag dynamicconfig.BoolPropertyFn,
 ) *MetricsEmitterImpl {
 	currentCluster := shardData.GetClusterMetadata().GetCurrentClusterName()
 	remoteClusters := shardData.GetClusterMetadata().GetRemoteClusterInfo()
@@ -91,9 +105,12 @@ func NewMetricsEmitter(
 		status:         common.DaemonStatusInitialized,
 		shardData:      shardData,
 		reader:         reader,
+		maxScanTasks:   maxScanTasks,
+		emitDomainLag:  emitDomainLag,
 		scope:          scope,
 		logger:         logger,
 		done:           make(chan struct{}),
+		emittedDomains: make(map[string]map[string]string),
 	}
 }
 
@@ -142,7 +159,112 @@ func (m *MetricsEmitterImpl) emitMetrics() {
 
 		scope.UpdateGauge(metrics.ReplicationLatency, float64(replicationLatency.Nanoseconds()))
 		logger.Debug(fmt.Sprintf("ReplicationLatency metric emitted: %v", float64(replicationLatency.Nanoseconds())))
+
+		m.emitDomainMetrics(remoteClusterName, scope)
+	}
+}
+
+// emitDomainMetrics scans the pending replication tasks of the shard, which is disabled by default as it reads
+// up to maxScanTasks tasks per shard and remote cluster every minute. DescribeReplicationStatus is always available.
+func (m *MetricsEmitterImpl) emitDomainMetrics(remoteClusterName string, scope metrics.Scope) {
+	if !m.emitDomainLag() || m.maxScanTasks() <= 0 {
+		return
+	}
+
+	status, err := m.GetDomainReplicationStatus(ctx.Background(), remoteClusterName)
+	if err != nil {
+		return
+	}
+
+	emittedDomains := make(map[string]string, len(status.Domains))
+	for _, domainStatus := range status.Domains {
+		domainScope := scope.Tagged(metrics.DomainTag(domainStatus.DomainName))
+		domainScope.UpdateGauge(metrics.ReplicationLatencyPerDomain, float64((time.Duration(domainStatus.LagInMilliseconds) * time.Millisecond).Nanoseconds()))
+		domainScope.UpdateGauge(metrics.ReplicationPendingTasksPerDomain, float64(domainStatus.PendingTaskCount))
+		emittedDomains[domainStatus.DomainID] = domainStatus.DomainName
 	}
+	for domainID, domainName := range m.emittedDomains[remoteClusterName] {
+		if _, ok := emittedDomains[domainID]; !ok {
+			domainScope := scope.Tagged(metrics.DomainTag(domainName))
+			domainScope.UpdateGauge(metrics.ReplicationLatencyPerDomain, 0)
+			domainScope.UpdateGauge(metrics.ReplicationPendingTasksPerDomain, 0)
+		}
+	}
+	m.emittedDomains[remoteClusterName] = emittedDomains
+}
+
+// GetDomainReplicationStatus returns the replication lag, in time and number of tasks, of each domain with replication
+// tasks pending for the remote cluster. Pending tasks are the ones between the replication level of the remote cluster
+// and the transfer max read level of the shard. At most maxScanTasks pending tasks are scanned, the status is
+// marked as incomplete if there are more.
+func (m *MetricsEmitterImpl) GetDomainReplicationStatus(
+	ctx ctx.Context,
+	remoteClusterName string,
+) (*types.ClusterReplicationStatus, error) {
+	logger := m.logger.WithTags(tag.RemoteCluster(remoteClusterName))
+	now := m.shardData.GetTimeSource().Now()
+	readLevel := m.shardData.GetClusterReplicationLevel(remoteClusterName)
+	maxReadLevel := m.shardData.GetTransferMaxReadLevel()
+	maxScanTasks := m.maxScanTasks()
+
+	status := &types.ClusterReplicationStatus{
+		ClusterName: remoteClusterName,
+	}
+	domains := make(map[string]*types.DomainReplicationStatus)
+	scannedTasks := 0
+scanLoop:
+	for readLevel < maxReadLevel {
+		tasks, hasMore, err := m.reader.Read(ctx, readLevel, maxReadLevel)
+		if err != nil {
+			logger.Error(fmt.Sprintf(
+				"Error reading when determining domain replication lag, readLevel=%v", readLevel),
+				tag.Error(err))
+			return nil, err
+		}
+
+		for _, task := range tasks {
+			if scannedTasks >= maxScanTasks {
+				status.Incomplete = true
+				break scanLoop
+			}
+			scannedTasks++
+			readLevel = task.TaskID
+
+			domainStatus, ok := domains[task.DomainID]
+			if !ok {
+				domainStatus = &types.DomainReplicationStatus{
+					DomainID: task.DomainID,
+				}
+				if domainName, err := m.shardData.GetDomainCache().GetDomainName(task.DomainID); err == nil {
+					domainStatus.DomainName = domainName
+				}
+				domains[task.DomainID] = domainStatus
+			}
+			domainStatus.PendingTaskCount++
+			if lag := now.Sub(time.Unix(0, task.CreationTime)).Milliseconds(); lag > domainStatus.LagInMilliseconds {
+				domainStatus.LagInMilliseconds = lag
+			}
+		}
+
+		if !hasMore || len(tasks) == 0 {
+			break
+		}
+		if scannedTasks >= maxScanTasks {
+			status.Incomplete = true
+			break
+		}
+	}
+
+	for _, domainStatus := range domains {
+		status.Domains = append(status.Domains, domainStatus)
+	}
+	sort.Slice(status.Domains, func(i, j int) bool {
+		if status.Domains[i].LagInMilliseconds != status.Domains[j].LagInMilliseconds {
+			return status.Domains[i].LagInMilliseconds > status.Domains[j].LagInMilliseconds
+		}
+		return status.Domains[i].DomainID < status.Domains[j].DomainID
+	})
+	return status, nil
 }
 
 func (m *MetricsEmitterImpl) determineReplicationLatency(remoteClusterName string) (time.Duration, error) {
@@ -23,18 +23,25 @@
 package replication
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/config"
+	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/testlogger"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
 )
 
 var (
@@ -60,7 +67,7 @@ func TestMetricsEmitter(t *testing.T) {
 	task2 := persistence.ReplicationTaskInfo{TaskID: 2, CreationTime: timeSource.Now().Add(-time.Minute).UnixNano()}
 	reader := fakeTaskReader{&task1, &task2}
 
-	metricsEmitter := NewMetricsEmitter(1, testShardData, reader, metrics.NewNoopMetricsClient())
+	metricsEmitter := NewMetricsEmitter(1, testShardData, reader, metrics.NewNoopMetricsClient(), dynamicconfig.GetIntPropertyFn(1000), dynamicconfig.GetBoolPropertyFn(true))
 	latency, err := metricsEmitter.determineReplicationLatency(cluster2)
 	assert.NoError(t, err)
 	assert.Equal(t, time.Hour, latency)
@@ -83,6 +90,96 @@ func TestMetricsEmitter(t *testing.T) {
 	assert.Equal(t, time.Hour, latency)
 }
 
+func TestMetricsEmitter_GetDomainReplicationStatus(t *testing.T) {
+	timeSource := clock.NewMockedTimeSource()
+	metadata := cluster.NewMetadata(0, cluster1, cluster1, map[string]config.ClusterInformation{
+		cluster1: {Enabled: true},
+		cluster2: {Enabled: true},
+	},
+		func(d string) bool { return false },
+		metrics.NewNoopMetricsClient(),
+		testlogger.New(t),
+	)
+	domainCache := cache.NewMockDomainCache(gomock.NewController(t))
+	domainCache.EXPECT().GetDomainName("domain1").Return("domain-name-1", nil).AnyTimes()
+	domainCache.EXPECT().GetDomainName("domain2").Return("", errors.New("domain not found")).AnyTimes()
+	testShardData := newTestShardData(timeSource, metadata)
+	testShardData.domainCache = domainCache
+	testShardData.maxReadLevel = 10
+
+	reader := fakeTaskReader{
+		{TaskID: 1, DomainID: "domain1", CreationTime: timeSource.Now().Add(-time.Hour).UnixNano()},
+		{TaskID: 2, DomainID: "domain2", CreationTime: timeSource.Now().Add(-2 * time.Hour).UnixNano()},
+		{TaskID: 3, DomainID: "domain1", CreationTime: timeSource.Now().Add(-time.Minute).UnixNano()},
+	}
+
+	metricsEmitter := NewMetricsEmitter(1, testShardData, reader, metrics.NewNoopMetricsClient(), dynamicconfig.GetIntPropertyFn(1000), dynamicconfig.GetBoolPropertyFn(true))
+	status, err := metricsEmitter.GetDomainReplicationStatus(context.Background(), cluster2)
+	require.NoError(t, err)
+	assert.Equal(t, &types.ClusterReplicationStatus{
+		ClusterName: cluster2,
+		Domains: []*types.DomainReplicationStatus{
+			{DomainID: "domain2", PendingTaskCount: 1, LagInMilliseconds: (2 * time.Hour).Milliseconds()},
+			{DomainID: "domain1", DomainName: "domain-name-1", PendingTaskCount: 2, LagInMilliseconds: time.Hour.Milliseconds()},
+		},
+	}, status)
+
+	// Scanning is capped and the status is marked as incomplete
+	metricsEmitter = NewMetricsEmitter(1, testShardData, reader, metrics.NewNoopMetricsClient(), dynamicconfig.GetIntPropertyFn(1), dynamicconfig.GetBoolPropertyFn(true))
+	status, err = metricsEmitter.GetDomainReplicationStatus(context.Background(), cluster2)
+	require.NoError(t, err)
+	assert.Equal(t, &types.ClusterReplicationStatus{
+		ClusterName: cluster2,
+		Domains: []*types.DomainReplicationStatus{
+			{DomainID: "domain1", DomainName: "domain-name-1", PendingTaskCount: 1, LagInMilliseconds: time.Hour.Milliseconds()},
+		},
+		Incomplete: true,
+	}, status)
+
+	// Nothing is pending once the remote cluster caught up
+	testShardData.clusterReplicationLevel[cluster2] = 10
+	status, err = metricsEmitter.GetDomainReplicationStatus(context.Background(), cluster2)
+	require.NoError(t, err)
+	assert.Equal(t, &types.ClusterReplicationStatus{ClusterName: cluster2}, status)
+
+	// Read errors are returned
+	metricsEmitter = NewMetricsEmitter(1, testShardData, fakeTaskReader(nil), metrics.NewNoopMetricsClient(), dynamicconfig.GetIntPropertyFn(1000), dynamicconfig.GetBoolPropertyFn(true))
+	testShardData.clusterReplicationLevel[cluster2] = 1
+	_, err = metricsEmitter.GetDomainReplicationStatus(context.Background(), cluster2)
+	assert.Error(t, err)
+}
+
+func TestMetricsEmitter_EmitDomainMetrics(t *testing.T) {
+	timeSource := clock.NewMockedTimeSource()
+	metadata := cluster.NewMetadata(0, cluster1, cluster1, map[string]config.ClusterInformation{
+		cluster1: {Enabled: true},
+		cluster2: {Enabled: true},
+	},
+		func(d string) bool { return false },
+		metrics.NewNoopMetricsClient(),
+		testlogger.New(t),
+	)
+	domainCache := cache.NewMockDomainCache(gomock.NewController(t))
+	domainCache.EXPECT().GetDomainName("domain1").Return("domain-name-1", nil).AnyTimes()
+	testShardData := newTestShardData(timeSource, metadata)
+	testShardData.domainCache = domainCache
+	testShardData.maxReadLevel = 10
+	reader := fakeTaskReader{
+		{TaskID: 1, DomainID: "domain1", CreationTime: timeSource.Now().Add(-time.Hour).UnixNano()},
+		{TaskID: 2, DomainID: "domain1", CreationTime: timeSource.Now().Add(-time.Minute).UnixNano()},
+	}
+	scope := metrics.NewNoopMetricsClient().Scope(metrics.ReplicationMetricEmitterScope)
+
+	// The periodic scan is disabled by default
+	metricsEmitter := NewMetricsEmitter(1, testShardData, reader, metrics.NewNoopMetricsClient(), dynamicconfig.GetIntPropertyFn(1000), dynamicconfig.GetBoolPropertyFn(false))
+	metricsEmitter.emitDomainMetrics(cluster2, scope)
+	assert.Empty(t, metricsEmitter.emittedDomains)
+
+	metricsEmitter = NewMetricsEmitter(1, testShardData, reader, metrics.NewNoopMetricsClient(), dynamicconfig.GetIntPropertyFn(1000), dynamicconfig.GetBoolPropertyFn(true))
+	metricsEmitter.emitDomainMetrics(cluster2, scope)
+	assert.Equal(t, map[string]map[string]string{cluster2: {"domain1": "domain-name-1"}}, metricsEmitter.emittedDomains)
+}
+
 type testShardData struct {
 	shardID                 int
 	logger                  log.Logger
@@ -90,6 +187,7 @@ type testShardData struct {
 	clusterReplicationLevel map[string]int64
 	timeSource              clock.TimeSource
 	metadata                cluster.Metadata
+	domainCache             cache.DomainCache
 }
 
 func newTestShardData(timeSource clock.TimeSource, metadata cluster.Metadata) testShardData {
@@ -121,3 +219,11 @@ func (t testShardData) GetTimeSource() clock.TimeSource {
 func (t testShardData) GetClusterMetadata() cluster.Metadata {
 	return t.metadata
 }
+
+func (t testShardData) GetTransferMaxReadLevel() int64 {
+	return t.maxReadLevel
+}
+
+func (t testShardData) GetDomainCache() cache.DomainCache {
+	return t.domainCache
+}
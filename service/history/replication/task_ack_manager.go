@@ -80,11 +80,23 @@ func NewTaskAckManager(
 	}
 }
 
+// GetTasks returns the replication tasks after lastReadTaskID for the polling cluster, which acknowledges
+// with lastReadTaskID the replication tasks it retrieved before
 func (t *TaskAckManager) GetTasks(ctx context.Context, pollingCluster string, lastReadTaskID int64) (*types.ReplicationMessages, error) {
 	if lastReadTaskID == common.EmptyMessageID {
 		lastReadTaskID = t.ackLevels.GetClusterReplicationLevel(pollingCluster)
 	}
 
+	messages, err := t.ReadTasks(ctx, pollingCluster, lastReadTaskID)
+	if err != nil {
+		return nil, err
+	}
+	t.Ack(pollingCluster, lastReadTaskID)
+	return messages, nil
+}
+
+// ReadTasks returns the replication tasks after lastReadTaskID for the polling cluster without acknowledging any task
+func (t *TaskAckManager) ReadTasks(ctx context.Context, pollingCluster string, lastReadTaskID int64) (*types.ReplicationMessages, error) {
 	taskGeneratedTimer := t.scope.StartTimer(metrics.TaskLatency)
 
 	tasks, hasMore, err := t.reader.Read(ctx, lastReadTaskID, t.ackLevels.GetTransferMaxReadLevel())
@@ -120,14 +132,6 @@ TaskInfoLoop:
 	t.scope.RecordTimer(metrics.ReplicationTasksReturned, time.Duration(len(replicationTasks)))
 	t.scope.RecordTimer(metrics.ReplicationTasksReturnedDiff, time.Duration(len(tasks)-len(replicationTasks)))
 
-	if err := t.ackLevels.UpdateClusterReplicationLevel(pollingCluster, lastReadTaskID); err != nil {
-		t.logger.Error("error updating replication level for shard", tag.Error(err), tag.OperationFailed)
-	}
-
-	if err := t.store.Ack(pollingCluster, lastReadTaskID); err != nil {
-		t.logger.Error("error updating replication level for hydrated task store", tag.Error(err), tag.OperationFailed)
-	}
-
 	t.logger.Debug("Get replication tasks", tag.SourceCluster(pollingCluster), tag.ShardReplicationAck(lastReadTaskID), tag.ReadLevel(readLevel))
 	return &types.ReplicationMessages{
 		ReplicationTasks:       replicationTasks,
@@ -135,3 +139,14 @@ TaskInfoLoop:
 		LastRetrievedMessageID: readLevel,
 	}, nil
 }
+
+// Ack moves the replication level of the polling cluster forward to lastTaskID
+func (t *TaskAckManager) Ack(pollingCluster string, lastTaskID int64) {
+	if err := t.ackLevels.UpdateClusterReplicationLevel(pollingCluster, lastTaskID); err != nil {
+		t.logger.Error("error updating replication level for shard", tag.Error(err), tag.OperationFailed)
+	}
+
+	if err := t.store.Ack(pollingCluster, lastTaskID); err != nil {
+		t.logger.Error("error updating replication level for hydrated task store", tag.Error(err), tag.OperationFailed)
+	}
+}
@@ -23,7 +23,6 @@ package replication
 import (
 	"context"
 
-	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
@@ -136,7 +135,7 @@ func (e *taskExecutorImpl) handleActivityTask(
 
 	var syncActivityAction func() error
 	// Check if the number of shards between clusters are equal. If not, redirect the request.
-	if e.shard.GetShardID() != common.WorkflowIDToHistoryShard(attr.WorkflowID, e.shard.GetConfig().NumberOfShards) {
+	if e.shard.GetShardID() != e.shard.GetConfig().GetShardID(attr.WorkflowID) {
 		syncActivityAction = func() error {
 			return e.shard.GetService().GetClientBean().GetHistoryClient().SyncActivity(ctx, request)
 		}
@@ -218,7 +217,7 @@ func (e *taskExecutorImpl) handleHistoryReplicationTaskV2(
 
 	var historyReplicationAction func() error
 	// Check if the number of shards between clusters are equal. If not, redirect the request.
-	if e.shard.GetShardID() != common.WorkflowIDToHistoryShard(attr.WorkflowID, e.shard.GetConfig().NumberOfShards) {
+	if e.shard.GetShardID() != e.shard.GetConfig().GetShardID(attr.WorkflowID) {
 		historyReplicationAction = func() error {
 			return e.shard.GetService().GetClientBean().GetHistoryClient().ReplicateEventsV2(ctx, request)
 		}
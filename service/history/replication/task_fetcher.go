@@ -32,6 +32,8 @@ import (
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/messaging"
+	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/quotas"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/history/config"
@@ -93,10 +95,13 @@ func NewTaskFetchers(
 	config *config.Config,
 	clusterMetadata cluster.Metadata,
 	clientBean client.Bean,
+	messagingClient messaging.Client,
+	metricsClient metrics.Client,
+	hostName string,
 ) TaskFetchers {
 	currentCluster := clusterMetadata.GetCurrentClusterName()
 	var fetchers []TaskFetcher
-	for clusterName := range clusterMetadata.GetRemoteClusterInfo() {
+	for clusterName, clusterInfo := range clusterMetadata.GetRemoteClusterInfo() {
 		remoteFrontendClient := clientBean.GetRemoteAdminClient(clusterName)
 		fetcher := newReplicationTaskFetcher(
 			logger,
@@ -105,6 +110,23 @@ func NewTaskFetchers(
 			config,
 			remoteFrontendClient,
 		)
+		if isKafkaReplicationTransport(clusterInfo) && messagingClient != nil {
+			kafkaFetcher, err := newKafkaTaskFetcher(
+				logger,
+				clusterName,
+				currentCluster,
+				config,
+				messagingClient,
+				metricsClient,
+				hostName,
+				fetcher,
+			)
+			if err != nil {
+				logger.Error("Failed to create replication kafka task fetcher, falling back to rpc.", tag.ClusterName(clusterName), tag.Error(err))
+			} else {
+				fetcher = kafkaFetcher
+			}
+		}
 		fetchers = append(fetchers, fetcher)
 	}
 
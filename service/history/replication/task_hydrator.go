@@ -54,6 +54,7 @@ type (
 	}
 
 	mutableState interface {
+		GetExecutionInfo() *persistence.WorkflowExecutionInfo
 		IsWorkflowExecutionRunning() bool
 		GetActivityInfo(int64) (*persistence.ActivityInfo, bool)
 		GetVersionHistories() *persistence.VersionHistories
@@ -61,10 +62,10 @@ type (
 )
 
 // NewImmediateTaskHydrator will enrich replication tasks with additional information that is immediately available.
-func NewImmediateTaskHydrator(isRunning bool, vh *persistence.VersionHistories, activities map[int64]*persistence.ActivityInfo, blob, nextBlob *persistence.DataBlob) TaskHydrator {
+func NewImmediateTaskHydrator(executionInfo *persistence.WorkflowExecutionInfo, vh *persistence.VersionHistories, activities map[int64]*persistence.ActivityInfo, blob, nextBlob *persistence.DataBlob) TaskHydrator {
 	return TaskHydrator{
 		history:    immediateHistoryProvider{blob: blob, nextBlob: nextBlob},
-		msProvider: immediateMutableStateProvider{immediateMutableState{isRunning, activities, vh}},
+		msProvider: immediateMutableStateProvider{immediateMutableState{executionInfo, activities, vh}},
 	}
 }
 
@@ -98,6 +99,11 @@ func (h TaskHydrator) Hydrate(ctx context.Context, task persistence.ReplicationT
 		return nil, err
 	}
 
+	// local-only workflows are not replicated to the other clusters
+	if execution.IsLocalOnlyWorkflow(ms.GetExecutionInfo()) {
+		return nil, nil
+	}
+
 	switch task.TaskType {
 	case persistence.ReplicationTaskTypeSyncActivity:
 		return hydrateSyncActivityTask(task, ms)
@@ -312,13 +318,16 @@ func (r immediateMutableStateProvider) GetMutableState(_ context.Context, _, _,
 }
 
 type immediateMutableState struct {
-	isRunning        bool
+	executionInfo    *persistence.WorkflowExecutionInfo
 	activities       map[int64]*persistence.ActivityInfo
 	versionHistories *persistence.VersionHistories
 }
 
+func (ms immediateMutableState) GetExecutionInfo() *persistence.WorkflowExecutionInfo {
+	return ms.executionInfo
+}
 func (ms immediateMutableState) IsWorkflowExecutionRunning() bool {
-	return ms.isRunning
+	return ms.executionInfo.IsRunning()
 }
 func (ms immediateMutableState) GetActivityInfo(id int64) (*persistence.ActivityInfo, bool) {
 	info, ok := ms.activities[id]
@@ -38,6 +38,7 @@ import (
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/definition"
 	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/partition"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/history/config"
@@ -344,6 +345,20 @@ func TestTaskHydrator_HydrateHistoryReplicationTask(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "skips local-only workflow",
+			task: task,
+			msProvider: &fakeMutableStateProvider{
+				workflows: map[definition.WorkflowIdentifier]mutableState{
+					testWorkflowIdentifier: &fakeMutableState{
+						versionHistories: &versionHistories,
+						partitionConfig:  map[string]string{partition.LocalOnlyKey: "true"},
+					},
+				},
+			},
+			history:    &fakeHistoryProvider{},
+			expectTask: nil,
+		},
 		{
 			name: "hydrates history with branch token from version histories",
 			task: taskWithoutBranchToken,
@@ -630,6 +645,7 @@ func TestImmediateTaskHydrator(t *testing.T) {
 		activities       map[int64]*persistence.ActivityInfo
 		blob             *persistence.DataBlob
 		nextRunBlob      *persistence.DataBlob
+		partitionConfig  map[string]string
 		task             persistence.ReplicationTaskInfo
 		expectResult     *types.ReplicationTask
 		expectErr        string
@@ -744,6 +760,19 @@ func TestImmediateTaskHydrator(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:             "history task - local-only workflow",
+			versionHistories: versionHistories,
+			blob:             persistence.NewDataBlobFromInternal(testDataBlob),
+			partitionConfig:  map[string]string{partition.LocalOnlyKey: "true"},
+			task: persistence.ReplicationTaskInfo{
+				TaskType:     persistence.ReplicationTaskTypeHistory,
+				FirstEventID: testFirstEventID,
+				Version:      testVersion,
+				BranchToken:  testBranchToken,
+			},
+			expectResult: nil,
+		},
 		{
 			name:             "history task - missing data blob",
 			versionHistories: versionHistories,
@@ -759,7 +788,11 @@ func TestImmediateTaskHydrator(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			h := NewImmediateTaskHydrator(true, tt.versionHistories, tt.activities, tt.blob, tt.nextRunBlob)
+			executionInfo := &persistence.WorkflowExecutionInfo{
+				State:           persistence.WorkflowStateRunning,
+				PartitionConfig: tt.partitionConfig,
+			}
+			h := NewImmediateTaskHydrator(executionInfo, tt.versionHistories, tt.activities, tt.blob, tt.nextRunBlob)
 			result, err := h.Hydrate(context.Background(), tt.task)
 
 			if tt.expectErr != "" {
@@ -797,8 +830,12 @@ type fakeMutableState struct {
 	isWorkflowExecutionRunning bool
 	versionHistories           *persistence.VersionHistories
 	activityInfos              map[int64]persistence.ActivityInfo
+	partitionConfig            map[string]string
 }
 
+func (ms fakeMutableState) GetExecutionInfo() *persistence.WorkflowExecutionInfo {
+	return &persistence.WorkflowExecutionInfo{PartitionConfig: ms.partitionConfig}
+}
 func (ms fakeMutableState) IsWorkflowExecutionRunning() bool {
 	return ms.isWorkflowExecutionRunning
 }
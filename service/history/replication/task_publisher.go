@@ -0,0 +1,414 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package replication
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/cluster"
+	commonconfig "github.com/uber/cadence/common/config"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/messaging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/history/config"
+)
+
+const (
+	publishTaskRequestTimeout = 60 * time.Second
+	ackChanBufferSize         = 10
+)
+
+type (
+	// TaskPublisher publishes the replication tasks of a shard for a remote cluster to the messaging system.
+	// It is used when the current cluster delivers replication tasks with the kafka replication transport.
+	TaskPublisher interface {
+		common.Daemon
+	}
+
+	// TaskPublishers is the host level part of the kafka replication transport: it holds the producer shared by
+	// the task publishers of the shards and consumes the acks the remote clusters publish for them.
+	TaskPublishers interface {
+		common.Daemon
+
+		NewTaskPublisher(
+			shardID int,
+			targetCluster string,
+			ackLevels ackLevelStore,
+			taskGetter replicationTaskGetter,
+			timeSource clock.TimeSource,
+			logger log.Logger,
+		) TaskPublisher
+	}
+
+	// taskPublishersImpl is the implementation of TaskPublishers.
+	taskPublishersImpl struct {
+		status         int32
+		currentCluster string
+		config         *config.Config
+		producer       messaging.Producer
+		consumer       messaging.Consumer
+		metricsClient  metrics.Client
+		logger         log.Logger
+		started        bool
+		shutdownCh     chan struct{}
+		stoppedCh      chan struct{}
+
+		sync.RWMutex
+		publishers map[publisherKey]*taskPublisherImpl
+	}
+
+	publisherKey struct {
+		shardID       int32
+		targetCluster string
+	}
+
+	// taskPublisherImpl is the implementation of publishing replication messages.
+	taskPublisherImpl struct {
+		status         int32
+		shardID        int
+		currentCluster string
+		targetCluster  string
+		config         *config.Config
+		ackLevels      ackLevelStore
+		taskGetter     replicationTaskGetter
+		producer       messaging.Producer
+		publishers     *taskPublishersImpl
+		timeSource     clock.TimeSource
+		scope          metrics.Scope
+		logger         log.Logger
+
+		lastPublishTime time.Time
+		ackCh           chan *messaging.ReplicationAckMessage
+
+		ctx       context.Context
+		cancelCtx context.CancelFunc
+		stoppedCh chan struct{}
+	}
+
+	replicationTaskGetter interface {
+		ReadTasks(ctx context.Context, pollingCluster string, lastReadTaskID int64) (*types.ReplicationMessages, error)
+		Ack(pollingCluster string, lastTaskID int64)
+	}
+)
+
+var _ TaskPublishers = (*taskPublishersImpl)(nil)
+var _ TaskPublisher = (*taskPublisherImpl)(nil)
+
+// NewTaskPublishers creates the host level part of the kafka replication transport.
+// It returns nil when the current cluster does not use the kafka replication transport.
+func NewTaskPublishers(
+	clusterMetadata cluster.Metadata,
+	messagingClient messaging.Client,
+	config *config.Config,
+	metricsClient metrics.Client,
+	hostName string,
+	logger log.Logger,
+) TaskPublishers {
+	currentCluster := clusterMetadata.GetCurrentClusterName()
+	if !isKafkaReplicationTransport(clusterMetadata.GetAllClusterInfo()[currentCluster]) {
+		return nil
+	}
+	if messagingClient == nil {
+		logger.Error("Kafka replication transport is configured without kafka, falling back to rpc.")
+		return nil
+	}
+	producer, err := messagingClient.NewProducer(commonconfig.ReplicationKafkaApplication(currentCluster))
+	if err != nil {
+		logger.Error("Failed to create replication task producer, falling back to rpc.", tag.Error(err))
+		return nil
+	}
+	// every host consumes all the acks, as any of them may own the shard an ack is for
+	ackApplication := commonconfig.ReplicationAckKafkaApplication(currentCluster)
+	consumer, err := messagingClient.NewConsumer(ackApplication, fmt.Sprintf("%v-%v", ackApplication, hostName))
+	if err != nil {
+		logger.Error("Failed to create replication ack consumer, falling back to rpc.", tag.Error(err))
+		return nil
+	}
+	return &taskPublishersImpl{
+		status:         common.DaemonStatusInitialized,
+		currentCluster: currentCluster,
+		config:         config,
+		producer:       producer,
+		consumer:       consumer,
+		metricsClient:  metricsClient,
+		logger:         logger,
+		shutdownCh:     make(chan struct{}),
+		stoppedCh:      make(chan struct{}),
+		publishers:     make(map[publisherKey]*taskPublisherImpl),
+	}
+}
+
+// Start starts consuming the acks of the remote clusters
+func (p *taskPublishersImpl) Start() {
+	if !atomic.CompareAndSwapInt32(&p.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+
+	if err := p.consumer.Start(); err != nil {
+		// replication levels do not move forward, the tasks are published again from them when shards are reloaded
+		p.logger.Error("Failed to start replication ack consumer.", tag.Error(err))
+		close(p.stoppedCh)
+		return
+	}
+	p.started = true
+	go p.ackLoop()
+	p.logger.Info("Replication task publishers started.")
+}
+
+// Stop stops consuming the acks of the remote clusters
+func (p *taskPublishersImpl) Stop() {
+	if !atomic.CompareAndSwapInt32(&p.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+
+	close(p.shutdownCh)
+	<-p.stoppedCh
+	if p.started {
+		p.consumer.Stop()
+	}
+	p.logger.Info("Replication task publishers stopped.")
+}
+
+// NewTaskPublisher creates a new replication task publisher of a shard for the target cluster.
+// Replication tasks are published from the replication level of the target cluster, which moves forward
+// only as the target cluster acks the tasks it processed.
+func (p *taskPublishersImpl) NewTaskPublisher(
+	shardID int,
+	targetCluster string,
+	ackLevels ackLevelStore,
+	taskGetter replicationTaskGetter,
+	timeSource clock.TimeSource,
+	logger log.Logger,
+) TaskPublisher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &taskPublisherImpl{
+		status:         common.DaemonStatusInitialized,
+		shardID:        shardID,
+		currentCluster: p.currentCluster,
+		targetCluster:  targetCluster,
+		config:         p.config,
+		ackLevels:      ackLevels,
+		taskGetter:     taskGetter,
+		producer:       p.producer,
+		publishers:     p,
+		timeSource:     timeSource,
+		scope: p.metricsClient.Scope(
+			metrics.ReplicationTaskPublisherScope,
+			metrics.TargetClusterTag(targetCluster),
+			metrics.InstanceTag(strconv.Itoa(shardID)),
+		),
+		logger:    logger.WithTags(tag.ShardID(shardID), tag.ClusterName(targetCluster)),
+		ackCh:     make(chan *messaging.ReplicationAckMessage, ackChanBufferSize),
+		ctx:       ctx,
+		cancelCtx: cancel,
+		stoppedCh: make(chan struct{}),
+	}
+}
+
+func (p *taskPublishersImpl) ackLoop() {
+	defer close(p.stoppedCh)
+
+	messageCh := p.consumer.Messages()
+	for {
+		select {
+		case message, ok := <-messageCh:
+			if !ok {
+				p.logger.Warn("Replication ack consumer closed.")
+				return
+			}
+			p.handleAck(message)
+		case <-p.shutdownCh:
+			return
+		}
+	}
+}
+
+func (p *taskPublishersImpl) handleAck(message messaging.Message) {
+	ack, err := messaging.DecodeReplicationAckMessage(message.Value())
+	if err != nil {
+		p.logger.Error("Failed to decode replication ack message.", tag.Error(err))
+		if err := message.Nack(); err != nil {
+			p.logger.Error("Failed to nack replication ack message.", tag.Error(err))
+		}
+		return
+	}
+
+	if ack.SourceCluster == p.currentCluster {
+		p.RLock()
+		publisher, ok := p.publishers[publisherKey{shardID: ack.ShardID, targetCluster: ack.TargetCluster}]
+		p.RUnlock()
+		if ok {
+			// acks are cumulative, so an ack can be dropped when the publisher falls behind
+			select {
+			case publisher.ackCh <- ack:
+			default:
+			}
+		}
+	}
+	if err := message.Ack(); err != nil {
+		p.logger.Error("Failed to ack replication ack message.", tag.Error(err))
+	}
+}
+
+func (p *taskPublishersImpl) register(publisher *taskPublisherImpl) {
+	p.Lock()
+	defer p.Unlock()
+	p.publishers[publisherKey{shardID: int32(publisher.shardID), targetCluster: publisher.targetCluster}] = publisher
+}
+
+func (p *taskPublishersImpl) unregister(publisher *taskPublisherImpl) {
+	p.Lock()
+	defer p.Unlock()
+	key := publisherKey{shardID: int32(publisher.shardID), targetCluster: publisher.targetCluster}
+	if p.publishers[key] == publisher {
+		delete(p.publishers, key)
+	}
+}
+
+// Start starts the publisher
+func (p *taskPublisherImpl) Start() {
+	if !atomic.CompareAndSwapInt32(&p.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+
+	p.publishers.register(p)
+	go p.publishLoop()
+	p.logger.Info("Replication task publisher started.")
+}
+
+// Stop stops the publisher
+func (p *taskPublisherImpl) Stop() {
+	if !atomic.CompareAndSwapInt32(&p.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+
+	p.publishers.unregister(p)
+	p.cancelCtx()
+	<-p.stoppedCh
+	p.logger.Info("Replication task publisher stopped.")
+}
+
+func (p *taskPublisherImpl) publishLoop() {
+	defer close(p.stoppedCh)
+
+	readLevel := p.ackLevels.GetClusterReplicationLevel(p.targetCluster)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			lastRetrievedMessageID, hasMore, err := p.publishTasks(readLevel)
+			switch {
+			case err != nil:
+				p.logger.Warn("Failed to publish replication tasks.", tag.Error(err), tag.ReadLevel(readLevel))
+				timer.Reset(backoff.JitDuration(
+					p.config.ReplicationTaskFetcherErrorRetryWait(),
+					p.config.ReplicationTaskFetcherTimerJitterCoefficient(),
+				))
+			case hasMore:
+				readLevel = lastRetrievedMessageID
+				timer.Reset(0)
+			default:
+				readLevel = lastRetrievedMessageID
+				timer.Reset(backoff.JitDuration(
+					p.config.ReplicationTaskPublisherInterval(),
+					p.config.ReplicationTaskFetcherTimerJitterCoefficient(),
+				))
+			}
+		case ack := <-p.ackCh:
+			readLevel = p.handleAck(ack, readLevel)
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+// handleAck moves the replication level of the target cluster forward to the acked message ID and
+// returns the level to publish from, which goes back to the replication level when the target cluster
+// asks for the tasks to be published again
+func (p *taskPublisherImpl) handleAck(ack *messaging.ReplicationAckMessage, readLevel int64) int64 {
+	replicationLevel := p.ackLevels.GetClusterReplicationLevel(p.targetCluster)
+	if ack.AckedMessageID > replicationLevel {
+		p.taskGetter.Ack(p.targetCluster, ack.AckedMessageID)
+		replicationLevel = ack.AckedMessageID
+	}
+	if ack.Rewind {
+		p.scope.IncCounter(metrics.ReplicationStreamRewindCount)
+		// publish at once, even if there is no new task, so that the target cluster learns the replication level
+		p.lastPublishTime = time.Time{}
+		return replicationLevel
+	}
+	if replicationLevel > readLevel {
+		return replicationLevel
+	}
+	return readLevel
+}
+
+// publishTasks reads the replication tasks after the read level and publishes them as one message.
+// When there is no new task, a message without task is still published every ShardSyncMinInterval
+// so that the target cluster keeps receiving the sync shard status.
+func (p *taskPublisherImpl) publishTasks(readLevel int64) (int64, bool, error) {
+	ctx, cancel := context.WithTimeout(p.ctx, publishTaskRequestTimeout)
+	defer cancel()
+
+	messages, err := p.taskGetter.ReadTasks(ctx, p.targetCluster, readLevel)
+	if err != nil {
+		return readLevel, false, err
+	}
+
+	now := p.timeSource.Now()
+	if messages.LastRetrievedMessageID <= readLevel && now.Sub(p.lastPublishTime) < p.config.ShardSyncMinInterval() {
+		return readLevel, messages.HasMore, nil
+	}
+	if messages.LastRetrievedMessageID < readLevel {
+		messages.LastRetrievedMessageID = readLevel
+	}
+	messages.SyncShardStatus = &types.SyncShardStatus{
+		Timestamp: common.Int64Ptr(now.UnixNano()),
+	}
+
+	if err := p.producer.Publish(ctx, &messaging.ReplicationMessage{
+		SourceCluster:     p.currentCluster,
+		TargetCluster:     p.targetCluster,
+		ShardID:           int32(p.shardID),
+		PreviousMessageID: readLevel,
+		ReplicationLevel:  p.ackLevels.GetClusterReplicationLevel(p.targetCluster),
+		Messages:          messages,
+	}); err != nil {
+		p.scope.IncCounter(metrics.ReplicationMessagesPublishFailed)
+		return readLevel, false, err
+	}
+
+	p.lastPublishTime = now
+	p.scope.IncCounter(metrics.ReplicationMessagesPublished)
+	p.scope.RecordTimer(metrics.ReplicationTasksReturned, time.Duration(len(messages.ReplicationTasks)))
+	return messages.LastRetrievedMessageID, messages.HasMore, nil
+}
@@ -0,0 +1,257 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package replication
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/messaging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/history/config"
+)
+
+type (
+	fakeTaskGetter struct {
+		messages  *types.ReplicationMessages
+		err       error
+		readCh    chan int64
+		ackLevels *fakeAckLevelStore
+	}
+
+	fakeProducer struct {
+		published []interface{}
+		err       error
+	}
+)
+
+func (g *fakeTaskGetter) ReadTasks(_ context.Context, _ string, lastReadTaskID int64) (*types.ReplicationMessages, error) {
+	if g.readCh != nil {
+		select {
+		case g.readCh <- lastReadTaskID:
+		default:
+		}
+	}
+	if g.err != nil {
+		return nil, g.err
+	}
+	return &types.ReplicationMessages{
+		ReplicationTasks:       g.messages.ReplicationTasks,
+		LastRetrievedMessageID: g.messages.LastRetrievedMessageID,
+		HasMore:                g.messages.HasMore,
+	}, nil
+}
+
+func (g *fakeTaskGetter) Ack(pollingCluster string, lastTaskID int64) {
+	_ = g.ackLevels.UpdateClusterReplicationLevel(pollingCluster, lastTaskID)
+}
+
+func (p *fakeProducer) Publish(_ context.Context, message interface{}) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, message)
+	return nil
+}
+
+func newTestTaskPublishers(producer messaging.Producer) *taskPublishersImpl {
+	return &taskPublishersImpl{
+		currentCluster: "active",
+		config:         config.NewForTest(),
+		producer:       producer,
+		metricsClient:  metrics.NewNoopMetricsClient(),
+		logger:         log.NewNoop(),
+		publishers:     make(map[publisherKey]*taskPublisherImpl),
+	}
+}
+
+func newTestTaskPublisher(getter *fakeTaskGetter, producer messaging.Producer, timeSource clock.TimeSource) *taskPublisherImpl {
+	getter.ackLevels = &fakeAckLevelStore{remote: map[string]int64{"standby": 5}}
+	return newTestTaskPublishers(producer).NewTaskPublisher(
+		1,
+		"standby",
+		getter.ackLevels,
+		getter,
+		timeSource,
+		log.NewNoop(),
+	).(*taskPublisherImpl)
+}
+
+func TestTaskPublisher_PublishTasks(t *testing.T) {
+	timeSource := clock.NewMockedTimeSourceAt(time.Unix(0, 100))
+	getter := &fakeTaskGetter{messages: &types.ReplicationMessages{
+		ReplicationTasks:       []*types.ReplicationTask{{SourceTaskID: 8}, {SourceTaskID: 10}},
+		LastRetrievedMessageID: 10,
+		HasMore:                true,
+	}}
+	producer := &fakeProducer{}
+	publisher := newTestTaskPublisher(getter, producer, timeSource)
+
+	level, hasMore, err := publisher.publishTasks(5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), level)
+	assert.True(t, hasMore)
+	require.Len(t, producer.published, 1)
+	assert.Equal(t, &messaging.ReplicationMessage{
+		SourceCluster:     "active",
+		TargetCluster:     "standby",
+		ShardID:           1,
+		PreviousMessageID: 5,
+		ReplicationLevel:  5,
+		Messages: &types.ReplicationMessages{
+			ReplicationTasks:       []*types.ReplicationTask{{SourceTaskID: 8}, {SourceTaskID: 10}},
+			LastRetrievedMessageID: 10,
+			HasMore:                true,
+			SyncShardStatus:        &types.SyncShardStatus{Timestamp: common.Int64Ptr(100)},
+		},
+	}, producer.published[0])
+}
+
+func TestTaskPublisher_PublishTasks_NoNewTask(t *testing.T) {
+	timeSource := clock.NewMockedTimeSourceAt(time.Unix(0, 100))
+	getter := &fakeTaskGetter{messages: &types.ReplicationMessages{LastRetrievedMessageID: 10}}
+	producer := &fakeProducer{}
+	publisher := newTestTaskPublisher(getter, producer, timeSource)
+
+	// the first message carries the sync shard status even without task
+	level, _, err := publisher.publishTasks(10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), level)
+	require.Len(t, producer.published, 1)
+	assert.Equal(t, int64(10), producer.published[0].(*messaging.ReplicationMessage).PreviousMessageID)
+
+	// nothing is published again until the shard sync interval elapses
+	_, _, err = publisher.publishTasks(10)
+	require.NoError(t, err)
+	assert.Len(t, producer.published, 1)
+
+	timeSource.Advance(publisher.config.ShardSyncMinInterval())
+	_, _, err = publisher.publishTasks(10)
+	require.NoError(t, err)
+	assert.Len(t, producer.published, 2)
+}
+
+func TestTaskPublisher_PublishTasks_Error(t *testing.T) {
+	timeSource := clock.NewMockedTimeSourceAt(time.Unix(0, 100))
+	getter := &fakeTaskGetter{messages: &types.ReplicationMessages{LastRetrievedMessageID: 10}}
+	producer := &fakeProducer{err: errors.New("publish failed")}
+	publisher := newTestTaskPublisher(getter, producer, timeSource)
+
+	level, _, err := publisher.publishTasks(5)
+	assert.Error(t, err)
+	assert.Equal(t, int64(5), level)
+
+	getter.err = errors.New("read failed")
+	level, _, err = publisher.publishTasks(5)
+	assert.Error(t, err)
+	assert.Equal(t, int64(5), level)
+}
+
+func TestTaskPublisher_StartStop(t *testing.T) {
+	getter := &fakeTaskGetter{
+		messages: &types.ReplicationMessages{LastRetrievedMessageID: 10},
+		readCh:   make(chan int64, 1),
+	}
+	producer := &fakeProducer{}
+	publisher := newTestTaskPublisher(getter, producer, clock.NewRealTimeSource())
+
+	publisher.Start()
+	defer publisher.Stop()
+	select {
+	case readLevel := <-getter.readCh:
+		// publishing starts from the replication level of the target cluster
+		assert.Equal(t, int64(5), readLevel)
+	case <-time.After(10 * time.Second):
+		t.Fatal("replication tasks are not read")
+	}
+}
+
+func TestTaskPublisher_HandleAck(t *testing.T) {
+	timeSource := clock.NewMockedTimeSourceAt(time.Unix(0, 100))
+	getter := &fakeTaskGetter{messages: &types.ReplicationMessages{LastRetrievedMessageID: 20}}
+	producer := &fakeProducer{}
+	publisher := newTestTaskPublisher(getter, producer, timeSource)
+
+	// publishing does not move the replication level forward
+	readLevel, _, err := publisher.publishTasks(5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(20), readLevel)
+	assert.Equal(t, int64(5), getter.ackLevels.GetClusterReplicationLevel("standby"))
+
+	// acks of the target cluster do
+	readLevel = publisher.handleAck(&messaging.ReplicationAckMessage{AckedMessageID: 10}, readLevel)
+	assert.Equal(t, int64(20), readLevel)
+	assert.Equal(t, int64(10), getter.ackLevels.GetClusterReplicationLevel("standby"))
+
+	// older acks are ignored
+	readLevel = publisher.handleAck(&messaging.ReplicationAckMessage{AckedMessageID: 8}, readLevel)
+	assert.Equal(t, int64(20), readLevel)
+	assert.Equal(t, int64(10), getter.ackLevels.GetClusterReplicationLevel("standby"))
+
+	// a rewind publishes again from the replication level, at once
+	readLevel = publisher.handleAck(&messaging.ReplicationAckMessage{AckedMessageID: common.EmptyMessageID, Rewind: true}, readLevel)
+	assert.Equal(t, int64(10), readLevel)
+	getter.messages = &types.ReplicationMessages{LastRetrievedMessageID: 10}
+	_, _, err = publisher.publishTasks(readLevel)
+	require.NoError(t, err)
+	require.Len(t, producer.published, 2)
+	assert.Equal(t, int64(10), producer.published[1].(*messaging.ReplicationMessage).PreviousMessageID)
+	assert.Equal(t, int64(10), producer.published[1].(*messaging.ReplicationMessage).ReplicationLevel)
+}
+
+func TestTaskPublishers_HandleAck(t *testing.T) {
+	getter := &fakeTaskGetter{messages: &types.ReplicationMessages{}}
+	publisher := newTestTaskPublisher(getter, &fakeProducer{}, clock.NewRealTimeSource())
+	publishers := publisher.publishers
+	publishers.register(publisher)
+
+	newAckMessage := func(ack *messaging.ReplicationAckMessage) *fakeMessage {
+		payload, err := messaging.EncodeReplicationAckMessage(ack)
+		require.NoError(t, err)
+		return &fakeMessage{value: payload}
+	}
+	ack := &messaging.ReplicationAckMessage{SourceCluster: "active", TargetCluster: "standby", ShardID: 1, AckedMessageID: 10}
+	otherShard := &messaging.ReplicationAckMessage{SourceCluster: "active", TargetCluster: "standby", ShardID: 2, AckedMessageID: 10}
+	otherCluster := &messaging.ReplicationAckMessage{SourceCluster: "other", TargetCluster: "standby", ShardID: 1, AckedMessageID: 10}
+	for _, message := range []*fakeMessage{newAckMessage(ack), newAckMessage(otherShard), newAckMessage(otherCluster)} {
+		publishers.handleAck(message)
+		assert.True(t, message.acked)
+	}
+	require.Len(t, publisher.ackCh, 1)
+	assert.Equal(t, ack, <-publisher.ackCh)
+
+	invalid := &fakeMessage{value: []byte("invalid")}
+	publishers.handleAck(invalid)
+	assert.True(t, invalid.nacked)
+
+	publishers.unregister(publisher)
+	publishers.handleAck(newAckMessage(ack))
+	assert.Empty(t, publisher.ackCh)
+}
@@ -114,9 +114,9 @@ func (r *workflowResetterImpl) ResetWorkflow(
 	if err != nil {
 		return err
 	}
-	resetWorkflowVersion := domainEntry.GetFailoverVersion()
-
 	currentMutableState := currentWorkflow.GetMutableState()
+	resetWorkflowVersion := execution.GetFailoverVersionForWorkflow(domainEntry, r.clusterMetadata, currentMutableState.GetExecutionInfo())
+
 	currentWorkflowTerminated := false
 	if currentMutableState.IsWorkflowExecutionRunning() {
 		if err := r.terminateWorkflow(
@@ -26,24 +26,28 @@ import (
 	"sync/atomic"
 
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/resource"
 	"github.com/uber/cadence/common/service"
 	"github.com/uber/cadence/service/history/config"
 	"github.com/uber/cadence/service/history/events"
+	"github.com/uber/cadence/service/history/loadtracker"
 )
 
 // Resource is the interface which expose common history resources
 type Resource interface {
 	resource.Resource
 	GetEventCache() events.Cache
+	GetLoadTracker() loadtracker.Tracker
 }
 
 type resourceImpl struct {
 	status int32
 
 	resource.Resource
-	eventCache events.Cache
+	eventCache  events.Cache
+	loadTracker loadtracker.Tracker
 }
 
 // Start starts all resources
@@ -81,6 +85,11 @@ func (h *resourceImpl) GetEventCache() events.Cache {
 	return h.eventCache
 }
 
+// GetLoadTracker return load tracker
+func (h *resourceImpl) GetLoadTracker() loadtracker.Tracker {
+	return h.loadTracker
+}
+
 // New create a new resource containing common history dependencies
 func New(
 	params *resource.Params,
@@ -128,8 +137,9 @@ func New(
 	)
 
 	historyResource = &resourceImpl{
-		Resource:   serviceResource,
-		eventCache: eventCache,
+		Resource:    serviceResource,
+		eventCache:  eventCache,
+		loadTracker: loadtracker.NewTracker(config, clock.NewRealTimeSource()),
 	}
 	return
 }
@@ -28,13 +28,15 @@ import (
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/resource"
 	"github.com/uber/cadence/service/history/events"
+	"github.com/uber/cadence/service/history/loadtracker"
 )
 
 type (
 	// Test is the test implementation used for testing
 	Test struct {
 		*resource.Test
-		EventCache *events.MockCache
+		EventCache  *events.MockCache
+		LoadTracker loadtracker.Tracker
 	}
 )
 
@@ -47,8 +49,9 @@ func NewTest(
 	serviceMetricsIndex metrics.ServiceIdx,
 ) *Test {
 	return &Test{
-		Test:       resource.NewTest(t, controller, serviceMetricsIndex),
-		EventCache: events.NewMockCache(controller),
+		Test:        resource.NewTest(t, controller, serviceMetricsIndex),
+		EventCache:  events.NewMockCache(controller),
+		LoadTracker: loadtracker.NewNoopTracker(),
 	}
 }
 
@@ -56,3 +59,8 @@ func NewTest(
 func (s *Test) GetEventCache() events.Cache {
 	return s.EventCache
 }
+
+// GetLoadTracker for testing
+func (s *Test) GetLoadTracker() loadtracker.Tracker {
+	return s.LoadTracker
+}
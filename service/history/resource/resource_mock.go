@@ -56,6 +56,7 @@ import (
 	persistence "github.com/uber/cadence/common/persistence"
 	client0 "github.com/uber/cadence/common/persistence/client"
 	events "github.com/uber/cadence/service/history/events"
+	loadtracker "github.com/uber/cadence/service/history/loadtracker"
 )
 
 // MockResource is a mock of Resource interface.
@@ -334,6 +335,20 @@ func (mr *MockResourceMockRecorder) GetHistoryRawClient() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoryRawClient", reflect.TypeOf((*MockResource)(nil).GetHistoryRawClient))
 }
 
+// GetHistoryShardMappingCache mocks base method.
+func (m *MockResource) GetHistoryShardMappingCache() cache.HistoryShardMappingCache {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistoryShardMappingCache")
+	ret0, _ := ret[0].(cache.HistoryShardMappingCache)
+	return ret0
+}
+
+// GetHistoryShardMappingCache indicates an expected call of GetHistoryShardMappingCache.
+func (mr *MockResourceMockRecorder) GetHistoryShardMappingCache() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoryShardMappingCache", reflect.TypeOf((*MockResource)(nil).GetHistoryShardMappingCache))
+}
+
 // GetHostInfo mocks base method.
 func (m *MockResource) GetHostInfo() membership.HostInfo {
 	m.ctrl.T.Helper()
@@ -390,6 +405,20 @@ func (mr *MockResourceMockRecorder) GetIsolationGroupStore() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIsolationGroupStore", reflect.TypeOf((*MockResource)(nil).GetIsolationGroupStore))
 }
 
+// GetLoadTracker mocks base method.
+func (m *MockResource) GetLoadTracker() loadtracker.Tracker {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadTracker")
+	ret0, _ := ret[0].(loadtracker.Tracker)
+	return ret0
+}
+
+// GetLoadTracker indicates an expected call of GetLoadTracker.
+func (mr *MockResourceMockRecorder) GetLoadTracker() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadTracker", reflect.TypeOf((*MockResource)(nil).GetLoadTracker))
+}
+
 // GetLogger mocks base method.
 func (m *MockResource) GetLogger() log.Logger {
 	m.ctrl.T.Helper()
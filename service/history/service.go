@@ -81,6 +81,7 @@ func NewService(
 	if err != nil {
 		return nil, err
 	}
+	serviceConfig.HistoryShardMapping = serviceResource.GetHistoryShardMappingCache().GetHistoryShardMapping
 
 	return &Service{
 		Resource: serviceResource,
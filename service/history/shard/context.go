@@ -1018,6 +1018,31 @@ func (s *contextImpl) closeShard() {
 	atomic.StoreInt64(&s.rangeID, s.shardInfo.RangeID)
 }
 
+// release persists the latest shard info, including queue ack levels, and gives up the
+// shard ownership so that the next owner can acquire the shard without stealing it.
+// No write is accepted by the shard after release.
+func (s *contextImpl) release() error {
+	s.Lock()
+	defer s.Unlock()
+
+	if !atomic.CompareAndSwapInt32(&s.closed, 0, 1) {
+		return ErrShardClosed
+	}
+
+	updatedShardInfo := s.shardInfo.Copy()
+	updatedShardInfo.Owner = ""
+	s.emitShardInfoMetricsLogsLocked()
+	err := s.GetShardManager().UpdateShard(context.Background(), &persistence.UpdateShardRequest{
+		ShardInfo:       updatedShardInfo,
+		PreviousRangeID: s.shardInfo.RangeID,
+	})
+
+	// fails any writes that may start after this point.
+	s.shardInfo.RangeID = -1
+	atomic.StoreInt64(&s.rangeID, s.shardInfo.RangeID)
+	return err
+}
+
 func (s *contextImpl) generateTransferTaskIDLocked() (int64, error) {
 	if err := s.updateRangeIfNeededLocked(); err != nil {
 		return -1, err
@@ -1311,6 +1336,12 @@ func (s *contextImpl) allocateTimerIDsLocked(
 			// this is because during failover, timer task should be created as active
 			// or otherwise, failover + active processing logic may not pick up the task.
 			currentCluster = domainEntry.GetReplicationConfig().ActiveClusterName
+			if domainEntry.IsActiveActive() {
+				// the timer tasks of active-active domains are allocated by the cluster of the task version
+				if clusterName, err := s.GetClusterMetadata().ClusterNameForFailoverVersion(task.GetVersion()); err == nil {
+					currentCluster = clusterName
+				}
+			}
 		}
 		readCursorTS := s.timerMaxReadLevelMap[currentCluster]
 		if ts.Before(readCursorTS) {
@@ -1485,7 +1516,7 @@ func (s *contextImpl) ValidateAndUpdateFailoverMarkers() ([]*types.FailoverMarke
 func acquireShard(
 	shardItem *historyShardsItem,
 	closeCallback func(int, *historyShardsItem),
-) (Context, error) {
+) (*contextImpl, error) {
 
 	var shardInfo *persistence.ShardInfo
 
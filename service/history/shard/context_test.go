@@ -148,6 +148,20 @@ func (s *contextTestSuite) TestRenewRangeLockedRetriesExceeded() {
 	s.Error(err)
 }
 
+func (s *contextTestSuite) TestRelease() {
+	s.mockShardManager.On("UpdateShard", mock.Anything, mock.MatchedBy(func(request *persistence.UpdateShardRequest) bool {
+		return request.ShardInfo.Owner == "" && request.PreviousRangeID == 1
+	})).Once().Return(nil)
+
+	err := s.context.release()
+	s.NoError(err)
+	s.True(s.context.isClosed())
+	s.Equal(int64(-1), s.context.getRangeID())
+
+	err = s.context.release()
+	s.Equal(ErrShardClosed, err)
+}
+
 func (s *contextTestSuite) TestReplicateFailoverMarkersSuccess() {
 	s.mockResource.ExecutionMgr.On("CreateFailoverMarkerTasks", mock.Anything, mock.Anything).Once().Return(nil)
 
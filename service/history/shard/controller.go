@@ -23,6 +23,7 @@
 package shard
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -48,6 +49,10 @@ const (
 
 var (
 	errShardIDOutOfBoundary = &workflow.BadRequestError{Message: "shard ID is out of boundary"}
+
+	// ErrWorkflowMoving is returned when the workflow is being moved to another shard by a shard split,
+	// requests and tasks of the workflow should be retried after the split is completed
+	ErrWorkflowMoving = &types.ServiceBusyError{Message: "workflow is being moved to another history shard"}
 )
 
 type (
@@ -67,6 +72,9 @@ type (
 		GetEngineForShard(shardID int) (engine.Engine, error)
 		RemoveEngineForShard(shardID int)
 
+		// MigrateShardExecutions copies or deletes the executions moved by a shard split
+		MigrateShardExecutions(ctx context.Context, request *types.MigrateShardExecutionsRequest) (*types.MigrateShardExecutionsResponse, error)
+
 		// Following methods describes the current status of the controller
 		// TODO: consider converting to a unified describe method
 		Status() int32
@@ -104,8 +112,9 @@ type (
 		engineFactory   EngineFactory
 
 		sync.RWMutex
-		status historyShardsItemStatus
-		engine engine.Engine
+		status       historyShardsItemStatus
+		engine       engine.Engine
+		shardContext *contextImpl
 	}
 )
 
@@ -193,10 +202,17 @@ func (c *controller) Stop() {
 
 func (c *controller) PrepareToStop() {
 	atomic.StoreInt32(&c.shuttingDown, 1)
+
+	if c.config.EnableGracefulShardHandoff() {
+		c.releaseShards(c.ShardIDs())
+	}
 }
 
 func (c *controller) GetEngine(workflowID string) (engine.Engine, error) {
 	shardID := c.config.GetShardID(workflowID)
+	if c.config.GetWorkflowOwnership(workflowID, shardID) == common.HistoryShardMoving {
+		return nil, ErrWorkflowMoving
+	}
 	return c.GetEngineForShard(shardID)
 }
 
@@ -254,6 +270,32 @@ func (c *controller) removeEngineForShard(shardID int, shardItem *historyShardsI
 	}
 }
 
+// releaseShards stops the engines of the given shards and releases them explicitly,
+// so that their new owners can acquire them right away
+func (c *controller) releaseShards(shardIDs []int32) {
+	var wg sync.WaitGroup
+	wg.Add(len(shardIDs))
+	for _, shardID := range shardIDs {
+		go func(shardID int) {
+			defer wg.Done()
+			c.releaseShard(shardID)
+		}(int(shardID))
+	}
+	wg.Wait()
+}
+
+func (c *controller) releaseShard(shardID int) {
+	shardItem, err := c.removeHistoryShardItem(shardID, nil)
+	if err != nil {
+		// shard is not owned by this host
+		return
+	}
+
+	c.metricsScope.IncCounter(metrics.ShardReleasedCounter)
+	c.logger.Info("Shard controller state changed", tag.LifeCycleStopping, tag.ComponentShard, tag.ShardID(shardID))
+	shardItem.releaseEngine()
+}
+
 func (c *controller) shardClosedCallback(shardID int, shardItem *historyShardsItem) {
 	c.metricsScope.IncCounter(metrics.ShardClosedCounter)
 	c.logger.Info("Shard controller state changed", tag.LifeCycleStopping, tag.ComponentShard, tag.ShardID(shardID))
@@ -261,10 +303,11 @@ func (c *controller) shardClosedCallback(shardID int, shardItem *historyShardsIt
 }
 
 func (c *controller) getOrCreateHistoryShardItem(shardID int) (*historyShardsItem, error) {
-	if shardID >= c.config.NumberOfShards || shardID < 0 { // zero based shard ID
+	numShards := c.config.GetTotalShards()
+	if shardID >= numShards || shardID < 0 { // zero based shard ID
 		c.logger.Error(fmt.Sprintf("Received shard ID: %v is larger than supported shard number %v",
 			shardID,
-			c.config.NumberOfShards,
+			numShards,
 		),
 		)
 		return nil, errShardIDOutOfBoundary
@@ -290,10 +333,14 @@ func (c *controller) getOrCreateHistoryShardItem(shardID int) (*historyShardsIte
 		// if item not valid then process to create a new one
 	}
 
+	info, err := c.GetMembershipResolver().Lookup(service.History, string(rune(shardID)))
 	if c.isShuttingDown() || atomic.LoadInt32(&c.status) == common.DaemonStatusStopped {
+		if err == nil && info.Identity() != c.GetHostInfo().Identity() {
+			// redirect the caller to the new owner while the host is draining
+			return nil, CreateShardOwnershipLostError(c.GetHostInfo(), info)
+		}
 		return nil, fmt.Errorf("controller for host '%v' shutting down", c.GetHostInfo().Identity())
 	}
-	info, err := c.GetMembershipResolver().Lookup(service.History, string(rune(shardID)))
 	if err != nil {
 		return nil, err
 	}
@@ -383,7 +430,8 @@ func (c *controller) acquireShards() {
 	sw := c.metricsScope.StartTimer(metrics.AcquireShardsLatency)
 	defer sw.Stop()
 
-	numShards := c.config.NumberOfShards
+	numShards := c.config.GetTotalShards()
+	gracefulShardHandoff := c.config.EnableGracefulShardHandoff()
 	shardActionCh := make(chan int, numShards)
 	// Submit all tasks to the channel.
 	for shardID := 0; shardID < numShards; shardID++ {
@@ -412,6 +460,9 @@ func (c *controller) acquireShards() {
 							c.metricsScope.IncCounter(metrics.GetEngineForShardErrorCounter)
 							c.logger.Error("Unable to create history shard engine", tag.Error(err1), tag.OperationFailed, tag.ShardID(shardID))
 						}
+					} else if gracefulShardHandoff {
+						// hand the shard off to its new owner instead of waiting for it to be stolen
+						c.releaseShard(shardID)
 					}
 				}
 			}
@@ -464,6 +515,7 @@ func (i *historyShardsItem) getOrCreateEngine(
 			i.GetMetricsClient().RecordTimer(metrics.ShardInfoScope, metrics.ShardItemAcquisitionLatency,
 				context.GetCurrentTime(i.GetClusterMetadata().GetCurrentClusterName()).Sub(context.GetLastUpdatedTime()))
 		}
+		i.shardContext = context
 		i.engine = i.engineFactory.CreateEngine(context)
 		i.engine.Start()
 		i.logger.Info("Shard engine state changed", tag.LifeCycleStarted, tag.ComponentShardEngine)
@@ -489,6 +541,33 @@ func (i *historyShardsItem) stopEngine() {
 		i.logger.Info("Shard engine state changed", tag.LifeCycleStopping, tag.ComponentShardEngine)
 		i.engine.Stop()
 		i.engine = nil
+		i.shardContext = nil
+		i.logger.Info("Shard engine state changed", tag.LifeCycleStopped, tag.ComponentShardEngine)
+		i.status = historyShardsItemStatusStopped
+	case historyShardsItemStatusStopped:
+		// no op
+	default:
+		panic(i.logInvalidStatus())
+	}
+}
+
+// releaseEngine stops the engine, flushing its queue ack levels to the shard context,
+// and then releases the shard so that the next owner does not need to steal it
+func (i *historyShardsItem) releaseEngine() {
+	i.Lock()
+	defer i.Unlock()
+
+	switch i.status {
+	case historyShardsItemStatusInitialized:
+		i.status = historyShardsItemStatusStopped
+	case historyShardsItemStatusStarted:
+		i.logger.Info("Shard engine state changed", tag.LifeCycleStopping, tag.ComponentShardEngine)
+		i.engine.Stop()
+		if err := i.shardContext.release(); err != nil {
+			i.logger.Warn("Failed to release shard, new owner will steal it", tag.Error(err))
+		}
+		i.engine = nil
+		i.shardContext = nil
 		i.logger.Info("Shard engine state changed", tag.LifeCycleStopped, tag.ComponentShardEngine)
 		i.status = historyShardsItemStatusStopped
 	case historyShardsItemStatusStopped:
@@ -27,10 +27,12 @@
 package shard
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
 
+	types "github.com/uber/cadence/common/types"
 	engine "github.com/uber/cadence/service/history/engine"
 )
 
@@ -124,6 +126,21 @@ func (mr *MockControllerMockRecorder) GetEngineForShard(shardID interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEngineForShard", reflect.TypeOf((*MockController)(nil).GetEngineForShard), shardID)
 }
 
+// MigrateShardExecutions mocks base method.
+func (m *MockController) MigrateShardExecutions(ctx context.Context, request *types.MigrateShardExecutionsRequest) (*types.MigrateShardExecutionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrateShardExecutions", ctx, request)
+	ret0, _ := ret[0].(*types.MigrateShardExecutionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MigrateShardExecutions indicates an expected call of MigrateShardExecutions.
+func (mr *MockControllerMockRecorder) MigrateShardExecutions(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateShardExecutions", reflect.TypeOf((*MockController)(nil).MigrateShardExecutions), ctx, request)
+}
+
 // NumShards mocks base method.
 func (m *MockController) NumShards() int {
 	m.ctrl.T.Helper()
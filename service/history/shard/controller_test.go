@@ -439,6 +439,50 @@ func (s *controllerSuite) TestAcquireShardRenewLookupFailed() {
 	}
 }
 
+func (s *controllerSuite) TestAcquireShardReleasedOnOwnershipChange() {
+	s.config.NumberOfShards = 1
+	s.config.EnableGracefulShardHandoff = dynamicconfig.GetBoolPropertyFn(true)
+	shardID := 0
+	otherHost := membership.NewHostInfo("other-host:1234")
+
+	s.setupMocksForAcquireShard(shardID, s.mockHistoryEngine, 5, 6)
+	s.shardController.acquireShards()
+	s.Equal(1, s.shardController.NumShards())
+
+	s.mockMembershipResolver.EXPECT().Lookup(service.History, string(rune(shardID))).Return(otherHost, nil).Times(1)
+	s.mockHistoryEngine.EXPECT().Stop().Times(1)
+	s.mockShardManager.On("UpdateShard", mock.Anything, mock.MatchedBy(func(request *persistence.UpdateShardRequest) bool {
+		return request.ShardInfo.Owner == "" && request.ShardInfo.RangeID == 6 && request.PreviousRangeID == 6
+	})).Return(nil).Once()
+	s.shardController.acquireShards()
+
+	s.Equal(0, s.shardController.NumShards())
+}
+
+func (s *controllerSuite) TestPrepareToStopReleasesShards() {
+	s.config.NumberOfShards = 1
+	s.config.EnableGracefulShardHandoff = dynamicconfig.GetBoolPropertyFn(true)
+	shardID := 0
+	otherHost := membership.NewHostInfo("other-host:1234")
+
+	s.setupMocksForAcquireShard(shardID, s.mockHistoryEngine, 5, 6)
+	s.shardController.acquireShards()
+
+	s.mockHistoryEngine.EXPECT().Stop().Times(1)
+	s.mockShardManager.On("UpdateShard", mock.Anything, mock.MatchedBy(func(request *persistence.UpdateShardRequest) bool {
+		return request.ShardInfo.Owner == ""
+	})).Return(nil).Once()
+	s.shardController.PrepareToStop()
+	s.Equal(0, s.shardController.NumShards())
+
+	// requests arriving while draining are redirected to the new owner
+	s.mockMembershipResolver.EXPECT().Lookup(service.History, string(rune(shardID))).Return(otherHost, nil).Times(1)
+	_, err := s.shardController.GetEngineForShard(shardID)
+	var ownershipLostErr *types.ShardOwnershipLostError
+	s.ErrorAs(err, &ownershipLostErr)
+	s.Equal(otherHost.Identity(), ownershipLostErr.Owner)
+}
+
 func (s *controllerSuite) TestHistoryEngineClosed() {
 	numShards := 4
 	s.config.NumberOfShards = numShards
@@ -0,0 +1,289 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shard
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/history/engine"
+)
+
+const (
+	defaultMigrateShardExecutionsPageSize = 100
+	migrateHistoryPageSize                = 100
+)
+
+// MigrateShardExecutions moves the executions of a split from the parent shard to the child shard, one page of
+// the parent shard's executions at a time. Before the split is completed, the executions are copied into the
+// child shard through the replication path of its engine, which rebuilds the mutable states and the tasks of the
+// executions, and skips the events that have already been copied. Runs with more than one version history branch
+// are refused. Once the split is completed, the executions are deleted from the parent shard. Must be called on the
+// host owning the child shard.
+func (c *controller) MigrateShardExecutions(
+	ctx context.Context,
+	request *types.MigrateShardExecutionsRequest,
+) (*types.MigrateShardExecutionsResponse, error) {
+	if c.config.HistoryShardMapping == nil {
+		return nil, &types.BadRequestError{Message: "history shard mapping is not enabled"}
+	}
+	mapping := c.config.HistoryShardMapping()
+	childShardID := int(request.GetShardID())
+	parentShardID := int(request.GetSourceShardID())
+	if err := validateShardSplitMigration(mapping, parentShardID, childShardID, request); err != nil {
+		return nil, err
+	}
+
+	// makes sure this host owns the child shard
+	childEngine, err := c.GetEngineForShard(childShardID)
+	if err != nil {
+		return nil, err
+	}
+	parentExecutionManager, err := c.GetExecutionManager(parentShardID)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := int(request.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultMigrateShardExecutionsPageSize
+	}
+	page, err := parentExecutionManager.ListConcreteExecutions(ctx, &persistence.ListConcreteExecutionsRequest{
+		PageSize:  pageSize,
+		PageToken: request.GetNextPageToken(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &types.MigrateShardExecutionsResponse{NextPageToken: page.PageToken}
+	for _, entity := range page.Executions {
+		info := entity.ExecutionInfo
+		if request.GetDeleteFromSource() {
+			if mapping.WorkflowIDToShard(info.WorkflowID) != childShardID {
+				continue
+			}
+			if err := c.deleteMigratedExecution(ctx, parentExecutionManager, parentShardID, entity); err != nil {
+				return nil, err
+			}
+			resp.DeletedCount++
+			continue
+		}
+
+		if !mapping.IsWorkflowMovingToShard(info.WorkflowID, childShardID) {
+			continue
+		}
+		if request.GetClosedOnly() && info.State != persistence.WorkflowStateCompleted {
+			continue
+		}
+		copied, err := c.copyMigratingExecution(ctx, childEngine, parentShardID, childShardID, entity)
+		if err != nil {
+			return nil, err
+		}
+		if copied {
+			resp.CopiedCount++
+		}
+	}
+	return resp, nil
+}
+
+func validateShardSplitMigration(
+	mapping *common.HistoryShardMapping,
+	parentShardID int,
+	childShardID int,
+	request *types.MigrateShardExecutionsRequest,
+) error {
+	var split *common.HistoryShardSplit
+	for i := range mapping.Splits {
+		if mapping.Splits[i].ParentShardID == parentShardID && mapping.Splits[i].ChildShardID == childShardID {
+			split = &mapping.Splits[i]
+			break
+		}
+	}
+	if split == nil {
+		return &types.BadRequestError{Message: fmt.Sprintf("shard %v is not split into shard %v", parentShardID, childShardID)}
+	}
+
+	switch {
+	case request.GetDeleteFromSource():
+		if split.State != common.HistoryShardSplitStateCompleted {
+			return &types.BadRequestError{Message: "executions can only be deleted from the parent shard after the split is completed"}
+		}
+	case request.GetClosedOnly():
+		if split.State == common.HistoryShardSplitStateCompleted {
+			return &types.BadRequestError{Message: "the split is already completed"}
+		}
+	default:
+		// open executions can only be copied consistently when they are no longer updated in the parent shard
+		if split.State != common.HistoryShardSplitStateFenced {
+			return &types.BadRequestError{Message: "open executions can only be copied after the split is fenced"}
+		}
+	}
+	return nil
+}
+
+func (c *controller) copyMigratingExecution(
+	ctx context.Context,
+	childEngine engine.Engine,
+	parentShardID int,
+	childShardID int,
+	entity *persistence.ListConcreteExecutionsEntity,
+) (bool, error) {
+	info := entity.ExecutionInfo
+	if entity.VersionHistories == nil {
+		return false, &types.InternalServiceError{
+			Message: fmt.Sprintf("workflow %v run %v has no version histories and can not be migrated", info.WorkflowID, info.RunID),
+		}
+	}
+	if err := validateMigratingBranches(entity); err != nil {
+		return false, err
+	}
+	domainName, err := c.GetDomainCache().GetDomainName(info.DomainID)
+	if err != nil {
+		return false, err
+	}
+	execution := types.WorkflowExecution{
+		WorkflowID: info.WorkflowID,
+		RunID:      info.RunID,
+	}
+
+	// skip the executions which have been copied by a previous round
+	childExecutionManager, err := c.GetExecutionManager(childShardID)
+	if err != nil {
+		return false, err
+	}
+	copied, err := childExecutionManager.GetWorkflowExecution(ctx, &persistence.GetWorkflowExecutionRequest{
+		DomainID:   info.DomainID,
+		Execution:  execution,
+		DomainName: domainName,
+	})
+	switch err.(type) {
+	case nil:
+		if copied.State.ExecutionInfo.NextEventID == info.NextEventID {
+			return false, nil
+		}
+	case *types.EntityNotExistsError:
+	default:
+		return false, err
+	}
+
+	versionHistory, err := entity.VersionHistories.GetCurrentVersionHistory()
+	if err != nil {
+		return false, err
+	}
+	var pageToken []byte
+	for {
+		resp, err := c.GetHistoryManager().ReadRawHistoryBranch(ctx, &persistence.ReadHistoryBranchRequest{
+			BranchToken:   versionHistory.GetBranchToken(),
+			MinEventID:    common.FirstEventID,
+			MaxEventID:    info.NextEventID,
+			PageSize:      migrateHistoryPageSize,
+			NextPageToken: pageToken,
+			ShardID:       common.IntPtr(parentShardID),
+			DomainName:    domainName,
+		})
+		if err != nil {
+			return false, err
+		}
+		for _, blob := range resp.HistoryEventBlobs {
+			if err := childEngine.ReplicateEventsV2(ctx, &types.ReplicateEventsV2Request{
+				DomainUUID:          info.DomainID,
+				WorkflowExecution:   &execution,
+				VersionHistoryItems: versionHistory.ToInternalType().Items,
+				Events:              blob.ToInternal(),
+			}); err != nil {
+				return false, err
+			}
+		}
+		pageToken = resp.NextPageToken
+		if len(pageToken) == 0 {
+			return true, nil
+		}
+	}
+}
+
+func (c *controller) deleteMigratedExecution(
+	ctx context.Context,
+	parentExecutionManager persistence.ExecutionManager,
+	parentShardID int,
+	entity *persistence.ListConcreteExecutionsEntity,
+) error {
+	info := entity.ExecutionInfo
+	// runs with more than one branch are never copied, so they are not deleted either
+	if err := validateMigratingBranches(entity); err != nil {
+		return err
+	}
+	domainName, err := c.GetDomainCache().GetDomainName(info.DomainID)
+	if err != nil {
+		return err
+	}
+
+	// the current execution record is only deleted if it points to this run
+	if err := parentExecutionManager.DeleteCurrentWorkflowExecution(ctx, &persistence.DeleteCurrentWorkflowExecutionRequest{
+		DomainID:   info.DomainID,
+		WorkflowID: info.WorkflowID,
+		RunID:      info.RunID,
+		DomainName: domainName,
+	}); err != nil {
+		return err
+	}
+	if err := parentExecutionManager.DeleteWorkflowExecution(ctx, &persistence.DeleteWorkflowExecutionRequest{
+		DomainID:   info.DomainID,
+		WorkflowID: info.WorkflowID,
+		RunID:      info.RunID,
+		DomainName: domainName,
+	}); err != nil {
+		return err
+	}
+	if entity.VersionHistories == nil {
+		return nil
+	}
+	for _, versionHistory := range entity.VersionHistories.Histories {
+		if err := c.GetHistoryManager().DeleteHistoryBranch(ctx, &persistence.DeleteHistoryBranchRequest{
+			BranchToken: versionHistory.GetBranchToken(),
+			ShardID:     common.IntPtr(parentShardID),
+			DomainName:  domainName,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateMigratingBranches makes sure the run has at most one version history branch. Only the current branch
+// is copied through the replication path, so the other branches of the run would be lost by the migration.
+func validateMigratingBranches(entity *persistence.ListConcreteExecutionsEntity) error {
+	if entity.VersionHistories == nil || len(entity.VersionHistories.Histories) <= 1 {
+		return nil
+	}
+	info := entity.ExecutionInfo
+	return &types.InternalServiceError{
+		Message: fmt.Sprintf(
+			"workflow %v run %v has %v version history branches and can not be migrated, only runs with a single branch can be migrated",
+			info.WorkflowID,
+			info.RunID,
+			len(entity.VersionHistories.Histories),
+		),
+	}
+}
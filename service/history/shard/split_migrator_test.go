@@ -0,0 +1,292 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shard
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/history/engine"
+)
+
+const (
+	testSplitDomainID   = "test-domain-id"
+	testSplitDomainName = "test-domain"
+)
+
+func (s *controllerSuite) TestMigrateShardExecutions_Validation() {
+	copying, err := common.NewHistoryShardMapping(2).Split(0)
+	s.NoError(err)
+
+	tests := map[string]struct {
+		mapping *common.HistoryShardMapping
+		request *types.MigrateShardExecutionsRequest
+	}{
+		"mapping not enabled": {
+			request: &types.MigrateShardExecutionsRequest{ShardID: 2, SourceShardID: 0, ClosedOnly: true},
+		},
+		"unknown split": {
+			mapping: copying,
+			request: &types.MigrateShardExecutionsRequest{ShardID: 2, SourceShardID: 1, ClosedOnly: true},
+		},
+		"open executions copied before fencing": {
+			mapping: copying,
+			request: &types.MigrateShardExecutionsRequest{ShardID: 2, SourceShardID: 0},
+		},
+		"deleted before completion": {
+			mapping: copying,
+			request: &types.MigrateShardExecutionsRequest{ShardID: 2, SourceShardID: 0, DeleteFromSource: true},
+		},
+	}
+
+	for name, test := range tests {
+		s.Run(name, func() {
+			s.config.HistoryShardMapping = nil
+			if test.mapping != nil {
+				mapping := test.mapping
+				s.config.HistoryShardMapping = func() *common.HistoryShardMapping { return mapping }
+			}
+			_, err := s.shardController.MigrateShardExecutions(context.Background(), test.request)
+			s.IsType(&types.BadRequestError{}, err)
+		})
+	}
+}
+
+func (s *controllerSuite) TestMigrateShardExecutions_CopyClosedExecutions() {
+	mapping, err := common.NewHistoryShardMapping(2).Split(0)
+	s.NoError(err)
+	s.config.NumberOfShards = 2
+	s.config.HistoryShardMapping = func() *common.HistoryShardMapping { return mapping }
+	s.addStartedShard(2, s.mockHistoryEngine)
+
+	movingWorkflowID, stayingWorkflowID := findSplitWorkflowIDs(mapping, 2)
+	movingClosed := newTestSplitExecution(movingWorkflowID, "run-1", persistence.WorkflowStateCompleted)
+	movingOpen := newTestSplitExecution(movingWorkflowID, "run-2", persistence.WorkflowStateRunning)
+	stayingClosed := newTestSplitExecution(stayingWorkflowID, "run-3", persistence.WorkflowStateCompleted)
+
+	s.mockResource.ExecutionMgr.On("ListConcreteExecutions", mock.Anything, &persistence.ListConcreteExecutionsRequest{
+		PageSize:  defaultMigrateShardExecutionsPageSize,
+		PageToken: []byte("token"),
+	}).Return(&persistence.ListConcreteExecutionsResponse{
+		Executions: []*persistence.ListConcreteExecutionsEntity{movingClosed, movingOpen, stayingClosed},
+		PageToken:  []byte("next-token"),
+	}, nil).Once()
+	s.mockResource.DomainCache.EXPECT().GetDomainName(testSplitDomainID).Return(testSplitDomainName, nil).Times(1)
+	s.mockResource.ExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).
+		Return(nil, &types.EntityNotExistsError{}).Once()
+	blob := &persistence.DataBlob{Encoding: common.EncodingTypeThriftRW, Data: []byte("events")}
+	s.mockResource.HistoryMgr.On("ReadRawHistoryBranch", mock.Anything, mock.MatchedBy(func(request *persistence.ReadHistoryBranchRequest) bool {
+		return *request.ShardID == 0 && request.MaxEventID == movingClosed.ExecutionInfo.NextEventID
+	})).Return(&persistence.ReadRawHistoryBranchResponse{
+		HistoryEventBlobs: []*persistence.DataBlob{blob},
+	}, nil).Once()
+	s.mockHistoryEngine.EXPECT().ReplicateEventsV2(gomock.Any(), &types.ReplicateEventsV2Request{
+		DomainUUID:          testSplitDomainID,
+		WorkflowExecution:   &types.WorkflowExecution{WorkflowID: movingWorkflowID, RunID: "run-1"},
+		VersionHistoryItems: []*types.VersionHistoryItem{{EventID: 9, Version: 0}},
+		Events:              blob.ToInternal(),
+	}).Return(nil).Times(1)
+
+	resp, err := s.shardController.MigrateShardExecutions(context.Background(), &types.MigrateShardExecutionsRequest{
+		ShardID:       2,
+		SourceShardID: 0,
+		ClosedOnly:    true,
+		NextPageToken: []byte("token"),
+	})
+	s.NoError(err)
+	s.Equal(&types.MigrateShardExecutionsResponse{
+		NextPageToken: []byte("next-token"),
+		CopiedCount:   1,
+	}, resp)
+}
+
+func (s *controllerSuite) TestMigrateShardExecutions_SkipCopiedExecutions() {
+	mapping, err := common.NewHistoryShardMapping(2).Split(0)
+	s.NoError(err)
+	mapping, err = mapping.FenceSplit()
+	s.NoError(err)
+	s.config.NumberOfShards = 2
+	s.config.HistoryShardMapping = func() *common.HistoryShardMapping { return mapping }
+	s.addStartedShard(2, s.mockHistoryEngine)
+
+	movingWorkflowID, _ := findSplitWorkflowIDs(mapping, 2)
+	moving := newTestSplitExecution(movingWorkflowID, "run-1", persistence.WorkflowStateRunning)
+	s.mockResource.ExecutionMgr.On("ListConcreteExecutions", mock.Anything, mock.Anything).Return(&persistence.ListConcreteExecutionsResponse{
+		Executions: []*persistence.ListConcreteExecutionsEntity{moving},
+	}, nil).Once()
+	s.mockResource.DomainCache.EXPECT().GetDomainName(testSplitDomainID).Return(testSplitDomainName, nil).Times(1)
+	s.mockResource.ExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{
+		State: &persistence.WorkflowMutableState{ExecutionInfo: moving.ExecutionInfo},
+	}, nil).Once()
+
+	resp, err := s.shardController.MigrateShardExecutions(context.Background(), &types.MigrateShardExecutionsRequest{
+		ShardID:       2,
+		SourceShardID: 0,
+	})
+	s.NoError(err)
+	s.Equal(&types.MigrateShardExecutionsResponse{}, resp)
+}
+
+func (s *controllerSuite) TestMigrateShardExecutions_DeleteFromSource() {
+	mapping, err := common.NewHistoryShardMapping(2).Split(0)
+	s.NoError(err)
+	mapping, err = mapping.FenceSplit()
+	s.NoError(err)
+	mapping, err = mapping.CompleteSplit()
+	s.NoError(err)
+	s.config.NumberOfShards = 2
+	s.config.HistoryShardMapping = func() *common.HistoryShardMapping { return mapping }
+	s.addStartedShard(2, s.mockHistoryEngine)
+
+	movedWorkflowID, stayingWorkflowID := findSplitWorkflowIDs(mapping, 2)
+	moved := newTestSplitExecution(movedWorkflowID, "run-1", persistence.WorkflowStateCompleted)
+	staying := newTestSplitExecution(stayingWorkflowID, "run-2", persistence.WorkflowStateCompleted)
+	s.mockResource.ExecutionMgr.On("ListConcreteExecutions", mock.Anything, mock.Anything).Return(&persistence.ListConcreteExecutionsResponse{
+		Executions: []*persistence.ListConcreteExecutionsEntity{moved, staying},
+	}, nil).Once()
+	s.mockResource.DomainCache.EXPECT().GetDomainName(testSplitDomainID).Return(testSplitDomainName, nil).Times(1)
+	s.mockResource.ExecutionMgr.On("DeleteCurrentWorkflowExecution", mock.Anything, &persistence.DeleteCurrentWorkflowExecutionRequest{
+		DomainID:   testSplitDomainID,
+		WorkflowID: movedWorkflowID,
+		RunID:      "run-1",
+		DomainName: testSplitDomainName,
+	}).Return(nil).Once()
+	s.mockResource.ExecutionMgr.On("DeleteWorkflowExecution", mock.Anything, &persistence.DeleteWorkflowExecutionRequest{
+		DomainID:   testSplitDomainID,
+		WorkflowID: movedWorkflowID,
+		RunID:      "run-1",
+		DomainName: testSplitDomainName,
+	}).Return(nil).Once()
+	s.mockResource.HistoryMgr.On("DeleteHistoryBranch", mock.Anything, &persistence.DeleteHistoryBranchRequest{
+		BranchToken: []byte("branch-token"),
+		ShardID:     common.IntPtr(0),
+		DomainName:  testSplitDomainName,
+	}).Return(nil).Once()
+
+	resp, err := s.shardController.MigrateShardExecutions(context.Background(), &types.MigrateShardExecutionsRequest{
+		ShardID:          2,
+		SourceShardID:    0,
+		DeleteFromSource: true,
+	})
+	s.NoError(err)
+	s.Equal(&types.MigrateShardExecutionsResponse{DeletedCount: 1}, resp)
+}
+
+func (s *controllerSuite) TestMigrateShardExecutions_MultipleBranches() {
+	mapping, err := common.NewHistoryShardMapping(2).Split(0)
+	s.NoError(err)
+	mapping, err = mapping.FenceSplit()
+	s.NoError(err)
+	s.config.NumberOfShards = 2
+	s.config.HistoryShardMapping = func() *common.HistoryShardMapping { return mapping }
+	s.addStartedShard(2, s.mockHistoryEngine)
+
+	movingWorkflowID, _ := findSplitWorkflowIDs(mapping, 2)
+	moving := newTestSplitExecution(movingWorkflowID, "run-1", persistence.WorkflowStateRunning)
+	_, _, err = moving.VersionHistories.AddVersionHistory(persistence.NewVersionHistory([]byte("other-branch-token"), []*persistence.VersionHistoryItem{
+		persistence.NewVersionHistoryItem(5, 0),
+		persistence.NewVersionHistoryItem(7, 1),
+	}))
+	s.NoError(err)
+	s.mockResource.ExecutionMgr.On("ListConcreteExecutions", mock.Anything, mock.Anything).Return(&persistence.ListConcreteExecutionsResponse{
+		Executions: []*persistence.ListConcreteExecutionsEntity{moving},
+	}, nil).Twice()
+
+	// only the current branch could be copied, so the run is neither copied nor deleted
+	_, err = s.shardController.MigrateShardExecutions(context.Background(), &types.MigrateShardExecutionsRequest{
+		ShardID:       2,
+		SourceShardID: 0,
+	})
+	s.IsType(&types.InternalServiceError{}, err)
+
+	mapping, err = mapping.CompleteSplit()
+	s.NoError(err)
+	_, err = s.shardController.MigrateShardExecutions(context.Background(), &types.MigrateShardExecutionsRequest{
+		ShardID:          2,
+		SourceShardID:    0,
+		DeleteFromSource: true,
+	})
+	s.IsType(&types.InternalServiceError{}, err)
+}
+
+// addStartedShard makes the controller own the shard without going through shard acquisition
+func (s *controllerSuite) addStartedShard(shardID int, engine engine.Engine) {
+	s.shardController.historyShards[shardID] = &historyShardsItem{
+		shardID: shardID,
+		status:  historyShardsItemStatusStarted,
+		engine:  engine,
+	}
+}
+
+// findSplitWorkflowIDs returns a workflow ID moved to the child shard and one staying in the parent shard
+func findSplitWorkflowIDs(mapping *common.HistoryShardMapping, childShardID int) (string, string) {
+	var moving, staying string
+	for i := 0; moving == "" || staying == ""; i++ {
+		workflowID := fmt.Sprintf("workflow-%v", i)
+		if mapping.IsWorkflowMovingToShard(workflowID, childShardID) || mapping.WorkflowIDToShard(workflowID) == childShardID {
+			moving = workflowID
+		} else if mapping.WorkflowIDToShard(workflowID) == 0 {
+			staying = workflowID
+		}
+	}
+	return moving, staying
+}
+
+func newTestSplitExecution(workflowID, runID string, state int) *persistence.ListConcreteExecutionsEntity {
+	versionHistory := persistence.NewVersionHistory([]byte("branch-token"), []*persistence.VersionHistoryItem{
+		persistence.NewVersionHistoryItem(9, 0),
+	})
+	return &persistence.ListConcreteExecutionsEntity{
+		ExecutionInfo: &persistence.WorkflowExecutionInfo{
+			DomainID:    testSplitDomainID,
+			WorkflowID:  workflowID,
+			RunID:       runID,
+			State:       state,
+			NextEventID: 10,
+		},
+		VersionHistories: persistence.NewVersionHistories(versionHistory),
+	}
+}
+
+func (s *controllerSuite) TestGetEngine_WorkflowMoving() {
+	mapping, err := common.NewHistoryShardMapping(2).Split(0)
+	s.NoError(err)
+	s.config.NumberOfShards = 2
+	s.config.HistoryShardMapping = func() *common.HistoryShardMapping { return mapping }
+	s.addStartedShard(0, s.mockHistoryEngine)
+	movingWorkflowID, _ := findSplitWorkflowIDs(mapping, 2)
+
+	// moving workflows are still served by the parent shard until the split is fenced
+	engine, err := s.shardController.GetEngine(movingWorkflowID)
+	s.NoError(err)
+	s.Equal(s.mockHistoryEngine, engine)
+
+	mapping, err = mapping.FenceSplit()
+	s.NoError(err)
+	_, err = s.shardController.GetEngine(movingWorkflowID)
+	s.Equal(ErrWorkflowMoving, err)
+}
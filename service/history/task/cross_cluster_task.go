@@ -122,6 +122,7 @@ type (
 		processingState processingState
 		priority        int
 		attempt         int
+		lastError       error
 
 		shard         shard.Context
 		timeSource    clock.TimeSource
@@ -353,6 +354,7 @@ func (t *crossClusterSourceTask) HandleErr(
 
 			t.Lock()
 			t.attempt++
+			t.lastError = err
 			attempt := t.attempt
 			t.Unlock()
 
@@ -742,9 +744,12 @@ func (t *crossClusterSourceTask) isValidLocked() bool {
 	}
 
 	// pending active state is treated as valid
-	sourceInvalid := sourceEntry.GetReplicationConfig().ActiveClusterName !=
-		t.shard.GetClusterMetadata().GetCurrentClusterName()
-	targetInvalid := targetEntry != nil && targetEntry.GetReplicationConfig().ActiveClusterName != t.targetCluster
+	// an active-active domain is valid in the active cluster of any of its regions
+	currentCluster := t.shard.GetClusterMetadata().GetCurrentClusterName()
+	sourceInvalid := sourceEntry.GetReplicationConfig().ActiveClusterName != currentCluster &&
+		!sourceEntry.IsActiveClusterOfRegion(currentCluster)
+	targetInvalid := targetEntry != nil && targetEntry.GetReplicationConfig().ActiveClusterName != t.targetCluster &&
+		!targetEntry.IsActiveClusterOfRegion(t.targetCluster)
 
 	if sourceInvalid || targetInvalid {
 		t.processingState = processingStateInvalidated
@@ -874,6 +879,7 @@ func (t *crossClusterTargetTask) HandleErr(
 
 	t.Lock()
 	t.attempt++
+	t.lastError = err
 	t.Unlock()
 
 	t.scope.IncCounter(metrics.TaskFailuresPerDomain)
@@ -940,6 +946,13 @@ func (t *crossClusterTaskBase) GetAttempt() int {
 	return t.attempt
 }
 
+func (t *crossClusterTaskBase) GetLastError() error {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.lastError
+}
+
 func (t *crossClusterTaskBase) GetQueueType() QueueType {
 	return QueueTypeCrossCluster
 }
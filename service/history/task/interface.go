@@ -51,6 +51,7 @@ type (
 		GetQueueType() QueueType
 		GetShard() shard.Context
 		GetAttempt() int
+		GetLastError() error
 		GetInfo() Info
 	}
 
@@ -99,6 +100,7 @@ type (
 	Redispatcher interface {
 		common.Daemon
 		AddTask(Task)
+		RedispatchTask(Task) (bool, error)
 		Redispatch(targetSize int)
 		Size() int
 	}
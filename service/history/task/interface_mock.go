@@ -250,6 +250,20 @@ func (mr *MockTaskMockRecorder) GetInfo() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInfo", reflect.TypeOf((*MockTask)(nil).GetInfo))
 }
 
+// GetLastError mocks base method.
+func (m *MockTask) GetLastError() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastError")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetLastError indicates an expected call of GetLastError.
+func (mr *MockTaskMockRecorder) GetLastError() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastError", reflect.TypeOf((*MockTask)(nil).GetLastError))
+}
+
 // GetQueueType mocks base method.
 func (m *MockTask) GetQueueType() QueueType {
 	m.ctrl.T.Helper()
@@ -548,6 +562,20 @@ func (mr *MockCrossClusterTaskMockRecorder) GetInfo() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInfo", reflect.TypeOf((*MockCrossClusterTask)(nil).GetInfo))
 }
 
+// GetLastError mocks base method.
+func (m *MockCrossClusterTask) GetLastError() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastError")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetLastError indicates an expected call of GetLastError.
+func (mr *MockCrossClusterTaskMockRecorder) GetLastError() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastError", reflect.TypeOf((*MockCrossClusterTask)(nil).GetLastError))
+}
+
 // GetQueueType mocks base method.
 func (m *MockCrossClusterTask) GetQueueType() QueueType {
 	m.ctrl.T.Helper()
@@ -1040,6 +1068,21 @@ func (mr *MockRedispatcherMockRecorder) Redispatch(targetSize interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redispatch", reflect.TypeOf((*MockRedispatcher)(nil).Redispatch), targetSize)
 }
 
+// RedispatchTask mocks base method.
+func (m *MockRedispatcher) RedispatchTask(arg0 Task) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedispatchTask", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedispatchTask indicates an expected call of RedispatchTask.
+func (mr *MockRedispatcherMockRecorder) RedispatchTask(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedispatchTask", reflect.TypeOf((*MockRedispatcher)(nil).RedispatchTask), arg0)
+}
+
 // Size mocks base method.
 func (m *MockRedispatcher) Size() int {
 	m.ctrl.T.Helper()
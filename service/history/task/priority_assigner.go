@@ -144,7 +144,9 @@ func (a *priorityAssignerImpl) getDomainInfo(domainID string) (string, bool, err
 		return "", true, nil
 	}
 
-	if domainEntry.IsGlobalDomain() && a.currentClusterName != domainEntry.GetReplicationConfig().ActiveClusterName {
+	// an active-active domain is active in the active cluster of any of its regions
+	if domainEntry.IsGlobalDomain() && a.currentClusterName != domainEntry.GetReplicationConfig().ActiveClusterName &&
+		!domainEntry.IsActiveClusterOfRegion(a.currentClusterName) {
 		return domainEntry.GetInfo().Name, false, nil
 	}
 	return domainEntry.GetInfo().Name, true, nil
@@ -27,6 +27,7 @@ import (
 	"sync/atomic"
 
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/metrics"
@@ -57,6 +58,7 @@ var (
 func NewProcessor(
 	priorityAssigner PriorityAssigner,
 	config *config.Config,
+	domainCache cache.DomainCache,
 	logger log.Logger,
 	metricsClient metrics.Client,
 ) (Processor, error) {
@@ -70,6 +72,7 @@ func NewProcessor(
 	if err != nil {
 		return nil, err
 	}
+	setDomainRoundRobinOptions(options, config, domainCache)
 	hostScheduler, err := createTaskScheduler(options, logger, metricsClient)
 	if err != nil {
 		return nil, err
@@ -88,6 +91,7 @@ func NewProcessor(
 		if err != nil {
 			return nil, err
 		}
+		setDomainRoundRobinOptions(shardOptions, config, domainCache)
 		logger.Debug("Shard level task scheduler is enabled", tag.Dynamic("scheduler_options", shardOptions.String()))
 	}
 
@@ -245,6 +249,40 @@ func (p *processorImpl) isRunning() bool {
 	return atomic.LoadInt32(&p.status) == common.DaemonStatusStarted
 }
 
+// setDomainRoundRobinOptions groups tasks by domain in the weighted round robin scheduler,
+// so that each domain is dispatched with its own weight and rate limit
+func setDomainRoundRobinOptions(
+	options *task.SchedulerOptions,
+	config *config.Config,
+	domainCache cache.DomainCache,
+) {
+	if options.WRRSchedulerOptions == nil || !config.TaskSchedulerEnableDomainRoundRobin() {
+		return
+	}
+
+	getDomainName := func(domainID string) string {
+		domainName, err := domainCache.GetDomainName(domainID)
+		if err != nil {
+			// fallback to the default value
+			return ""
+		}
+		return domainName
+	}
+
+	options.WRRSchedulerOptions.TaskToGroupFn = func(t task.PriorityTask) string {
+		if historyTask, ok := t.(Task); ok {
+			return historyTask.GetDomainID()
+		}
+		return ""
+	}
+	options.WRRSchedulerOptions.GroupWeightFn = func(domainID string) int {
+		return config.TaskSchedulerDomainRoundRobinWeight(getDomainName(domainID))
+	}
+	options.WRRSchedulerOptions.GroupRPSFn = func(domainID string) float64 {
+		return float64(config.TaskSchedulerDomainDispatchRPS(getDomainName(domainID)))
+	}
+}
+
 func createTaskScheduler(
 	options *task.SchedulerOptions,
 	logger log.Logger,
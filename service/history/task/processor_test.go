@@ -36,6 +36,7 @@ import (
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/task"
 	"github.com/uber/cadence/service/history/config"
+	"github.com/uber/cadence/service/history/constants"
 	"github.com/uber/cadence/service/history/shard"
 )
 
@@ -215,12 +216,39 @@ func (s *queueTaskProcessorSuite) TestNewSchedulerOptions_UnknownSchedulerType()
 	s.Nil(options)
 }
 
+func (s *queueTaskProcessorSuite) TestNewProcessor_DomainRoundRobin() {
+	config := config.NewForTest()
+	config.TaskSchedulerType = dynamicconfig.GetIntPropertyFn(int(task.SchedulerTypeWRR))
+	config.TaskSchedulerEnableDomainRoundRobin = dynamicconfig.GetBoolPropertyFn(true)
+	config.TaskSchedulerDomainRoundRobinWeight = dynamicconfig.GetIntPropertyFilteredByDomain(3)
+	config.TaskSchedulerDomainDispatchRPS = dynamicconfig.GetIntPropertyFilteredByDomain(100)
+	s.mockShard.Resource.DomainCache.EXPECT().GetDomainName(constants.TestDomainID).Return(constants.TestDomainName, nil).AnyTimes()
+
+	processor, err := NewProcessor(
+		s.mockPriorityAssigner,
+		config,
+		s.mockShard.Resource.DomainCache,
+		s.logger,
+		s.metricsClient,
+	)
+	s.NoError(err)
+
+	options := processor.(*processorImpl).options.WRRSchedulerOptions
+	s.NotNil(options.TaskToGroupFn)
+	mockTask := NewMockTask(s.controller)
+	mockTask.EXPECT().GetDomainID().Return(constants.TestDomainID).Times(1)
+	s.Equal(constants.TestDomainID, options.TaskToGroupFn(mockTask))
+	s.Equal(3, options.GroupWeightFn(constants.TestDomainID))
+	s.Equal(float64(100), options.GroupRPSFn(constants.TestDomainID))
+}
+
 func (s *queueTaskProcessorSuite) newTestQueueTaskProcessor() *processorImpl {
 	config := config.NewForTest()
 	config.TaskSchedulerShardWorkerCount = dynamicconfig.GetIntPropertyFn(1)
 	processor, err := NewProcessor(
 		s.mockPriorityAssigner,
 		config,
+		s.mockShard.Resource.DomainCache,
 		s.logger,
 		s.metricsClient,
 	)
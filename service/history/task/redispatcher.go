@@ -33,6 +33,7 @@ import (
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/types"
 )
 
 const (
@@ -42,6 +43,10 @@ const (
 	redispatchMaxBackoffInternval = 2 * time.Minute
 )
 
+var (
+	errTaskProcessorBusy = &types.ServiceBusyError{Message: "queue task processor is busy"}
+)
+
 type (
 	redispatchNotification struct {
 		targetSize int
@@ -157,6 +162,39 @@ func (r *redispatcherImpl) AddTask(task Task) {
 	r.setupTimerLocked()
 }
 
+// RedispatchTask immediately redispatches the given task if it's waiting in the redispatch queue,
+// regardless of its redispatch backoff. Returns false if the task is not found in the queue, and
+// an error if the task is found but can't be submitted, in which case the task is put back.
+func (r *redispatcherImpl) RedispatchTask(task Task) (bool, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for priority, queue := range r.taskQueues {
+		for idx, redispatchTask := range queue {
+			if redispatchTask.task != task {
+				continue
+			}
+
+			r.taskQueues[priority] = append(queue[:idx:idx], queue[idx+1:]...)
+			submitted, err := r.taskProcessor.TrySubmit(task)
+			if err == nil && !submitted {
+				err = errTaskProcessorBusy
+			}
+			if err != nil {
+				// put the task back and make it the first one to be redispatched
+				redispatchTask.redispatchTime = r.timeSource.Now()
+				newPriority := task.Priority()
+				r.taskQueues[newPriority] = append(r.taskQueues[newPriority], redispatchTask)
+				r.setupTimerLocked()
+				return true, err
+			}
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func (r *redispatcherImpl) Redispatch(targetSize int) {
 	doneCh := make(chan struct{})
 	ntf := redispatchNotification{
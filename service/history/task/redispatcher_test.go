@@ -190,6 +190,38 @@ func (s *redispatcherSuite) TestRedispatch_Random() {
 	s.True(s.redispatcher.Size() >= numTasks-dispatched)
 }
 
+func (s *redispatcherSuite) TestRedispatchTask() {
+	s.redispatcher.Start()
+
+	tasks := []*MockTask{}
+	for i := 0; i != 2; i++ {
+		mockTask := NewMockTask(s.controller)
+		mockTask.EXPECT().Priority().Return(0).AnyTimes()
+		mockTask.EXPECT().GetAttempt().Return(100).Times(1)
+		s.redispatcher.AddTask(mockTask)
+		tasks = append(tasks, mockTask)
+	}
+
+	s.mockProcessor.EXPECT().TrySubmit(NewMockTaskMatcher(tasks[0])).Return(true, nil).Times(1)
+	found, err := s.redispatcher.RedispatchTask(tasks[0])
+	s.True(found)
+	s.NoError(err)
+	s.Equal(1, s.redispatcher.Size())
+
+	// task is no longer in the redispatch queue
+	found, err = s.redispatcher.RedispatchTask(tasks[0])
+	s.False(found)
+	s.NoError(err)
+
+	// task will be put back if it can't be submitted
+	s.mockProcessor.EXPECT().TrySubmit(NewMockTaskMatcher(tasks[1])).Return(false, nil).MinTimes(1)
+	found, err = s.redispatcher.RedispatchTask(tasks[1])
+	s.True(found)
+	s.Equal(errTaskProcessorBusy, err)
+	s.Equal(1, s.redispatcher.Size())
+
+}
+
 func (s *redispatcherSuite) newTestRedispatcher() *redispatcherImpl {
 	return NewRedispatcher(
 		s.mockProcessor,
@@ -113,6 +113,7 @@ type (
 
 	pushActivityToMatchingInfo struct {
 		activityScheduleToStartTimeout int32
+		tasklist                       types.TaskList
 		partitionConfig                map[string]string
 	}
 
@@ -125,11 +126,13 @@ type (
 
 func newPushActivityToMatchingInfo(
 	activityScheduleToStartTimeout int32,
+	tasklist types.TaskList,
 	partitionConfig map[string]string,
 ) *pushActivityToMatchingInfo {
 
 	return &pushActivityToMatchingInfo{
 		activityScheduleToStartTimeout: activityScheduleToStartTimeout,
+		tasklist:                       tasklist,
 		partitionConfig:                partitionConfig,
 	}
 }
@@ -21,6 +21,8 @@
 package task
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sync"
@@ -55,6 +57,14 @@ func isRedispatchErr(err error) bool {
 	return errors.As(err, &redispatchErr)
 }
 
+// isTransientTaskErr returns true if the error is expected to go away on retry,
+// such tasks are never moved to the history task DLQ
+func isTransientTaskErr(err error) bool {
+	return common.IsContextTimeoutError(err) ||
+		common.IsServiceTransientError(err) ||
+		persistence.IsTransientError(err)
+}
+
 var (
 	// ErrTaskDiscarded is the error indicating that the timer / transfer task is pending for too long and discarded.
 	ErrTaskDiscarded = errors.New("passive task pending for too long")
@@ -71,6 +81,7 @@ type (
 		state              ctask.State
 		priority           int
 		attempt            int
+		lastError          error
 		timeSource         clock.TimeSource
 		submitTime         time.Time
 		logger             log.Logger
@@ -192,6 +203,17 @@ func (t *taskImpl) Execute() error {
 		t.scope = getOrCreateDomainTaggedScope(t.shard, t.scopeIdx, t.GetDomainID(), t.logger)
 	}
 
+	switch t.shard.GetConfig().GetWorkflowOwnership(t.GetWorkflowID(), t.shard.GetShardID()) {
+	case common.HistoryShardMoving:
+		// the workflow is being copied by a shard split, keep the task until the split is completed
+		return shard.ErrWorkflowMoving
+	case common.HistoryShardNotOwned:
+		// the workflow has been moved to another shard, which has its own copy of the task
+		logEvent(t.eventLogger, "Workflow is not owned by the shard")
+		t.shouldProcessTask = false
+		return nil
+	}
+
 	var err error
 	t.shouldProcessTask, err = t.taskFilter(t.Info)
 	if err != nil {
@@ -201,6 +223,10 @@ func (t *taskImpl) Execute() error {
 	}
 
 	executionStartTime := t.timeSource.Now()
+	if t.shouldProcessTask && t.GetAttempt() == 0 {
+		// time spent waiting in the task scheduler before the first execution
+		t.scope.RecordTimer(metrics.TaskScheduleLatencyPerDomain, executionStartTime.Sub(t.submitTime))
+	}
 
 	defer func() {
 		if t.shouldProcessTask {
@@ -222,6 +248,7 @@ func (t *taskImpl) HandleErr(err error) (retErr error) {
 			defer t.Unlock()
 
 			t.attempt++
+			t.lastError = err
 			if t.attempt > t.criticalRetryCount() {
 				t.scope.RecordTimer(metrics.TaskAttemptTimerPerDomain, time.Duration(t.attempt))
 				t.logger.Error("Critical error processing task, retrying.",
@@ -265,6 +292,10 @@ func (t *taskImpl) HandleErr(err error) (retErr error) {
 		return err
 	}
 
+	if err == shard.ErrWorkflowMoving {
+		return err
+	}
+
 	// this is a transient error
 	if isRedispatchErr(err) {
 		t.scope.IncCounter(metrics.TaskStandbyRetryCounterPerDomain)
@@ -316,12 +347,85 @@ func (t *taskImpl) HandleErr(err error) (retErr error) {
 		return nil
 	}
 
+	if t.shouldMoveToDLQ(err) {
+		if dlqErr := t.moveToDLQ(err); dlqErr == nil {
+			return nil
+		}
+	}
+
 	t.logger.Error("Fail to process task", tag.Error(err), tag.LifeCycleProcessingFailed)
 	return err
 }
 
+// shouldMoveToDLQ returns true if the error is non-transient and the current attempt
+// is the last one allowed by the max attempts policy
+func (t *taskImpl) shouldMoveToDLQ(err error) bool {
+	if err == nil || isTransientTaskErr(err) {
+		return false
+	}
+	maxAttempts := t.shard.GetConfig().TaskDLQMaxAttempts()
+	if maxAttempts <= 0 {
+		return false
+	}
+	return t.GetAttempt()+1 >= maxAttempts
+}
+
+// moveToDLQ persists the task into the history task DLQ, so that it no longer blocks the queue ack level
+func (t *taskImpl) moveToDLQ(taskErr error) error {
+	var taskCategory common.TaskType
+	switch t.queueType {
+	case QueueTypeActiveTransfer, QueueTypeStandbyTransfer:
+		taskCategory = common.TaskTypeTransfer
+	case QueueTypeActiveTimer, QueueTypeStandbyTimer:
+		taskCategory = common.TaskTypeTimer
+	default:
+		return fmt.Errorf("unsupported queue type for history task DLQ: %v", t.queueType)
+	}
+
+	message := &types.HistoryTaskDLQMessage{
+		ShardID:      int32(t.shard.GetShardID()),
+		TaskCategory: int32(taskCategory),
+		Task: &types.QueueTaskInfo{
+			DomainID:            t.GetDomainID(),
+			WorkflowID:          t.GetWorkflowID(),
+			RunID:               t.GetRunID(),
+			TaskID:              t.GetTaskID(),
+			TaskType:            int32(t.GetTaskType()),
+			VisibilityTimestamp: t.GetVisibilityTimestamp().UnixNano(),
+			Version:             t.GetVersion(),
+			State:               "Pending",
+			Attempt:             int32(t.GetAttempt() + 1),
+			LastError:           taskErr.Error(),
+		},
+		EnqueuedTimestamp: t.timeSource.Now().UnixNano(),
+	}
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), taskDefaultTimeout)
+	defer cancel()
+	queueManager, err := t.shard.GetService().GetPersistenceBean().GetHistoryTaskQueueManager(t.shard.GetShardID())
+	if err != nil {
+		return err
+	}
+	if err := queueManager.EnqueueMessageToDLQ(ctx, payload); err != nil {
+		t.logger.Error("Failed to move task to history task DLQ", tag.Error(err))
+		return err
+	}
+
+	t.scope.IncCounter(metrics.TaskMovedToDLQCounterPerDomain)
+	t.logger.Warn("Task moved to history task DLQ after reaching max attempts",
+		tag.Error(taskErr),
+		tag.TaskType(t.GetTaskType()),
+		tag.AttemptCount(t.GetAttempt()+1),
+	)
+	return nil
+}
+
 func (t *taskImpl) RetryErr(err error) bool {
-	if err == errWorkflowBusy || isRedispatchErr(err) || err == ErrTaskPendingActive || common.IsContextTimeoutError(err) {
+	if err == errWorkflowBusy || err == shard.ErrWorkflowMoving || isRedispatchErr(err) || err == ErrTaskPendingActive || common.IsContextTimeoutError(err) {
 		return false
 	}
 
@@ -395,6 +499,13 @@ func (t *taskImpl) GetAttempt() int {
 	return t.attempt
 }
 
+func (t *taskImpl) GetLastError() error {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.lastError
+}
+
 func (t *taskImpl) GetInfo() Info {
 	return t.Info
 }
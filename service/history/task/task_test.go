@@ -22,7 +22,9 @@ package task
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -31,6 +33,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/log"
@@ -83,6 +86,7 @@ func (s *taskSuite) SetupTest() {
 	s.mockTaskRedispatcher = NewMockRedispatcher(s.controller)
 	s.mockTaskInfo = NewMockInfo(s.controller)
 	s.mockTaskInfo.EXPECT().GetDomainID().Return(constants.TestDomainID).AnyTimes()
+	s.mockTaskInfo.EXPECT().GetWorkflowID().Return(constants.TestWorkflowID).AnyTimes()
 	s.mockShard.Resource.DomainCache.EXPECT().GetDomainName(constants.TestDomainID).Return(constants.TestDomainName, nil).AnyTimes()
 
 	s.logger = testlogger.New(s.Suite.T())
@@ -126,6 +130,46 @@ func (s *taskSuite) TestExecute_Success() {
 	s.NoError(err)
 }
 
+func (s *taskSuite) TestExecute_ShardSplit() {
+	parentShardID := s.mockShard.GetShardID()
+	mapping, err := common.NewHistoryShardMapping(parentShardID + 1).Split(parentShardID)
+	s.NoError(err)
+	config := s.mockShard.GetConfig()
+	config.HistoryShardMapping = func() *common.HistoryShardMapping { return mapping }
+	childShardID := mapping.SplitInProgress().ChildShardID
+	childShard := shard.NewTestContext(s.T(), s.controller, &persistence.ShardInfo{ShardID: childShardID, RangeID: 1}, config)
+	defer childShard.Finish(s.T())
+
+	var movingWorkflowID string
+	for i := 0; movingWorkflowID == ""; i++ {
+		if workflowID := fmt.Sprintf("workflow-%v", i); mapping.IsWorkflowMovingToShard(workflowID, childShardID) {
+			movingWorkflowID = workflowID
+		}
+	}
+
+	// the parent shard keeps processing the tasks until the split is fenced
+	parentTask := s.newTestTaskForWorkflow(s.mockShard, movingWorkflowID)
+	s.mockTaskExecutor.EXPECT().Execute(parentTask, true).Return(nil).Times(1)
+	s.NoError(parentTask.Execute())
+	// the tasks copied into the child shard wait for the split to complete
+	childTask := s.newTestTaskForWorkflow(childShard, movingWorkflowID)
+	s.Equal(shard.ErrWorkflowMoving, childTask.Execute())
+	s.Equal(shard.ErrWorkflowMoving, childTask.HandleErr(shard.ErrWorkflowMoving))
+	s.False(childTask.RetryErr(shard.ErrWorkflowMoving))
+
+	mapping, err = mapping.FenceSplit()
+	s.NoError(err)
+	s.Equal(shard.ErrWorkflowMoving, parentTask.Execute())
+
+	// once the split is completed, the tasks left in the parent shard are dropped
+	mapping, err = mapping.CompleteSplit()
+	s.NoError(err)
+	s.NoError(parentTask.Execute())
+	s.False(parentTask.shouldProcessTask)
+	s.mockTaskExecutor.EXPECT().Execute(childTask, true).Return(nil).Times(1)
+	s.NoError(childTask.Execute())
+}
+
 func (s *taskSuite) TestHandleErr_ErrEntityNotExists() {
 	taskBase := s.newTestTask(func(task Info) (bool, error) {
 		return true, nil
@@ -220,6 +264,81 @@ func (s *taskSuite) TestHandleErr_UnknownErr() {
 	s.Equal(err, taskBase.HandleErr(err))
 }
 
+func (s *taskSuite) TestHandleErr_MoveToDLQ() {
+	taskBase := s.newTestTask(func(task Info) (bool, error) {
+		return true, nil
+	}, nil)
+	s.mockShard.GetConfig().TaskDLQMaxAttempts = dynamicconfig.GetIntPropertyFn(5)
+
+	s.mockTaskInfo.EXPECT().GetTaskType().Return(123).AnyTimes()
+	s.mockTaskInfo.EXPECT().GetWorkflowID().Return(constants.TestWorkflowID).AnyTimes()
+	s.mockTaskInfo.EXPECT().GetRunID().Return(constants.TestRunID).AnyTimes()
+	s.mockTaskInfo.EXPECT().GetTaskID().Return(int64(1234)).AnyTimes()
+	s.mockTaskInfo.EXPECT().GetVisibilityTimestamp().Return(time.Now()).AnyTimes()
+	s.mockTaskInfo.EXPECT().GetVersion().Return(int64(1)).AnyTimes()
+
+	err := errors.New("some random error")
+
+	// not reaching max attempts yet
+	taskBase.attempt = 2
+	s.Equal(err, taskBase.HandleErr(err))
+
+	s.mockShard.Resource.HistoryTaskQueueManager.EXPECT().EnqueueMessageToDLQ(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, payload []byte) error {
+			var message types.HistoryTaskDLQMessage
+			s.NoError(json.Unmarshal(payload, &message))
+			s.Equal(int32(10), message.ShardID)
+			s.Equal(int32(common.TaskTypeTransfer), message.TaskCategory)
+			s.Equal(constants.TestDomainID, message.Task.DomainID)
+			s.Equal(int64(1234), message.Task.TaskID)
+			s.Equal(err.Error(), message.Task.LastError)
+			return nil
+		},
+	).Times(1)
+	taskBase.attempt = 4
+	s.NoError(taskBase.HandleErr(err))
+}
+
+func (s *taskSuite) TestHandleErr_MoveToDLQFailed() {
+	taskBase := s.newTestTask(func(task Info) (bool, error) {
+		return true, nil
+	}, nil)
+	s.mockShard.GetConfig().TaskDLQMaxAttempts = dynamicconfig.GetIntPropertyFn(5)
+
+	s.mockTaskInfo.EXPECT().GetTaskType().Return(123).AnyTimes()
+	s.mockTaskInfo.EXPECT().GetWorkflowID().Return(constants.TestWorkflowID).AnyTimes()
+	s.mockTaskInfo.EXPECT().GetRunID().Return(constants.TestRunID).AnyTimes()
+	s.mockTaskInfo.EXPECT().GetTaskID().Return(int64(1234)).AnyTimes()
+	s.mockTaskInfo.EXPECT().GetVisibilityTimestamp().Return(time.Now()).AnyTimes()
+	s.mockTaskInfo.EXPECT().GetVersion().Return(int64(1)).AnyTimes()
+	s.mockShard.Resource.HistoryTaskQueueManager.EXPECT().EnqueueMessageToDLQ(gomock.Any(), gomock.Any()).
+		Return(errors.New("some persistence error")).Times(1)
+
+	taskBase.attempt = 4
+	err := errors.New("some random error")
+	s.Equal(err, taskBase.HandleErr(err))
+}
+
+func (s *taskSuite) TestHandleErr_SkipDLQ() {
+	taskBase := s.newTestTask(func(task Info) (bool, error) {
+		return true, nil
+	}, nil)
+	s.mockShard.GetConfig().TaskDLQMaxAttempts = dynamicconfig.GetIntPropertyFn(5)
+	s.mockTaskInfo.EXPECT().GetTaskType().Return(123).AnyTimes()
+
+	// discarded tasks are dropped without being moved to DLQ
+	taskBase.attempt = 4
+	s.NoError(taskBase.HandleErr(ErrTaskDiscarded))
+
+	// transient errors keep the task in the queue
+	taskBase.attempt = 4
+	err := &types.ServiceBusyError{Message: "some transient error"}
+	s.Equal(err, taskBase.HandleErr(err))
+
+	taskBase.attempt = 4
+	s.Equal(context.DeadlineExceeded, taskBase.HandleErr(context.DeadlineExceeded))
+}
+
 func (s *taskSuite) TestTaskState() {
 	taskBase := s.newTestTask(func(task Info) (bool, error) {
 		return true, nil
@@ -303,6 +422,26 @@ func (s *taskSuite) TestRetryErr() {
 	s.Equal(true, taskBase.RetryErr(errWorkflowRateLimited))
 }
 
+func (s *taskSuite) newTestTaskForWorkflow(shard shard.Context, workflowID string) *taskImpl {
+	taskInfo := NewMockInfo(s.controller)
+	taskInfo.EXPECT().GetDomainID().Return(constants.TestDomainID).AnyTimes()
+	taskInfo.EXPECT().GetWorkflowID().Return(workflowID).AnyTimes()
+	taskBase := newTask(
+		shard,
+		taskInfo,
+		QueueTypeActiveTransfer,
+		0,
+		s.logger,
+		func(task Info) (bool, error) { return true, nil },
+		s.mockTaskExecutor,
+		s.mockTaskProcessor,
+		s.maxRetryCount,
+		func(_ Task) {},
+	)
+	taskBase.scope = s.mockShard.GetMetricsClient().Scope(0)
+	return taskBase
+}
+
 func (s *taskSuite) newTestTask(
 	taskFilter Filter,
 	redispatchFn func(task Task),
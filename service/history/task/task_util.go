@@ -191,6 +191,11 @@ func GetTimerTaskMetricScope(
 			return metrics.TimerActiveTaskWorkflowBackoffTimerScope
 		}
 		return metrics.TimerStandbyTaskWorkflowBackoffTimerScope
+	case persistence.TaskTypeWorkflowNoProgressTimeout:
+		if isActive {
+			return metrics.TimerActiveTaskWorkflowNoProgressTimeoutScope
+		}
+		return metrics.TimerStandbyTaskWorkflowNoProgressTimeoutScope
 	default:
 		if isActive {
 			return metrics.TimerActiveQueueProcessorScope
@@ -371,6 +376,77 @@ func loadMutableStateForTransferTask(
 	return msBuilder, nil
 }
 
+// loadActiveMutableStateForTransferTask loads the mutable state like loadMutableStateForTransferTask,
+// it returns nil, nil if the workflow of an active-active domain is active in another cluster
+func loadActiveMutableStateForTransferTask(
+	ctx context.Context,
+	wfContext execution.Context,
+	transferTask *persistence.TransferTaskInfo,
+	shard shard.Context,
+	metricsClient metrics.Client,
+	logger log.Logger,
+) (execution.MutableState, error) {
+
+	mutableState, err := loadMutableStateForTransferTask(ctx, wfContext, transferTask, metricsClient, logger)
+	if err != nil || mutableState == nil {
+		return nil, err
+	}
+	activeCluster, err := getActiveClusterForActiveActiveWorkflow(shard, mutableState)
+	if err != nil {
+		return nil, err
+	}
+	if activeCluster != "" && activeCluster != shard.GetClusterMetadata().GetCurrentClusterName() {
+		return nil, nil
+	}
+	return mutableState, nil
+}
+
+// loadActiveMutableStateForTimerTask loads the mutable state like loadMutableStateForTimerTask,
+// it returns nil, nil if the workflow of an active-active domain is active in another cluster
+func loadActiveMutableStateForTimerTask(
+	ctx context.Context,
+	wfContext execution.Context,
+	timerTask *persistence.TimerTaskInfo,
+	shard shard.Context,
+	metricsClient metrics.Client,
+	logger log.Logger,
+) (execution.MutableState, error) {
+
+	mutableState, err := loadMutableStateForTimerTask(ctx, wfContext, timerTask, metricsClient, logger)
+	if err != nil || mutableState == nil {
+		return nil, err
+	}
+	activeCluster, err := getActiveClusterForActiveActiveWorkflow(shard, mutableState)
+	if err != nil {
+		return nil, err
+	}
+	if activeCluster != "" && activeCluster != shard.GetClusterMetadata().GetCurrentClusterName() {
+		return nil, nil
+	}
+	return mutableState, nil
+}
+
+// getActiveClusterForActiveActiveWorkflow returns the cluster the workflow of an active-active domain is active in,
+// or empty for the workflows of other domains, whose activeness is checked by the task allocator. The task allocator
+// assigns the tasks of an active-active domain by the cluster of the task version and, on the failover of a region,
+// hands all the tasks of the domain to the failover processor, so executors check the region of the workflow
+// against the latest domain entry.
+func getActiveClusterForActiveActiveWorkflow(
+	shard shard.Context,
+	mutableState execution.MutableState,
+) (string, error) {
+
+	executionInfo := mutableState.GetExecutionInfo()
+	domainEntry, err := shard.GetDomainCache().GetDomainByID(executionInfo.DomainID)
+	if err != nil {
+		return "", err
+	}
+	if !domainEntry.IsActiveActive() {
+		return "", nil
+	}
+	return execution.GetActiveClusterForWorkflow(domainEntry, executionInfo), nil
+}
+
 // load mutable state, if mutable state's next event ID <= task ID, will attempt to refresh
 // if still mutable state's next event ID <= task ID, will return nil, nil
 // TODO: refactor loadMutableStateForXXXTask function implementation to avoid duplication
@@ -104,6 +104,10 @@ func (t *timerActiveTaskExecutor) Execute(
 		ctx, cancel := context.WithTimeout(t.ctx, taskDefaultTimeout)
 		defer cancel()
 		return t.executeWorkflowTimeoutTask(ctx, timerTask)
+	case persistence.TaskTypeWorkflowNoProgressTimeout:
+		ctx, cancel := context.WithTimeout(t.ctx, taskDefaultTimeout)
+		defer cancel()
+		return t.executeWorkflowNoProgressTimeoutTask(ctx, timerTask)
 	case persistence.TaskTypeActivityRetryTimer:
 		ctx, cancel := context.WithTimeout(t.ctx, taskDefaultTimeout)
 		defer cancel()
@@ -145,7 +149,7 @@ func (t *timerActiveTaskExecutor) executeUserTimerTimeoutTask(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := loadMutableStateForTimerTask(ctx, wfContext, task, t.metricsClient, t.logger)
+	mutableState, err := loadActiveMutableStateForTimerTask(ctx, wfContext, task, t.shard, t.metricsClient, t.logger)
 	if err != nil {
 		return err
 	}
@@ -290,7 +294,7 @@ func (t *timerActiveTaskExecutor) executeActivityTimeoutTask(
 		return fmt.Errorf("unable to find domainID: %v, err: %v", task.DomainID, err)
 	}
 
-	mutableState, err := loadMutableStateForTimerTask(ctx, wfContext, task, t.metricsClient, t.logger)
+	mutableState, err := loadActiveMutableStateForTimerTask(ctx, wfContext, task, t.shard, t.metricsClient, t.logger)
 	if err != nil {
 		return err
 	}
@@ -473,7 +477,7 @@ func (t *timerActiveTaskExecutor) executeDecisionTimeoutTask(
 		return fmt.Errorf("unable to find domainID: %v, err: %v", task.DomainID, err)
 	}
 
-	mutableState, err := loadMutableStateForTimerTask(ctx, wfContext, task, t.metricsClient, t.logger)
+	mutableState, err := loadActiveMutableStateForTimerTask(ctx, wfContext, task, t.shard, t.metricsClient, t.logger)
 	if err != nil {
 		return err
 	}
@@ -576,7 +580,7 @@ func (t *timerActiveTaskExecutor) executeWorkflowBackoffTimerTask(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := loadMutableStateForTimerTask(ctx, wfContext, task, t.metricsClient, t.logger)
+	mutableState, err := loadActiveMutableStateForTimerTask(ctx, wfContext, task, t.shard, t.metricsClient, t.logger)
 	if err != nil {
 		return err
 	}
@@ -617,7 +621,7 @@ func (t *timerActiveTaskExecutor) executeActivityRetryTimerTask(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := loadMutableStateForTimerTask(ctx, wfContext, task, t.metricsClient, t.logger)
+	mutableState, err := loadActiveMutableStateForTimerTask(ctx, wfContext, task, t.shard, t.metricsClient, t.logger)
 	if err != nil {
 		return err
 	}
@@ -708,7 +712,7 @@ func (t *timerActiveTaskExecutor) executeWorkflowTimeoutTask(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := loadMutableStateForTimerTask(ctx, wfContext, task, t.metricsClient, t.logger)
+	mutableState, err := loadActiveMutableStateForTimerTask(ctx, wfContext, task, t.shard, t.metricsClient, t.logger)
 	if err != nil {
 		return err
 	}
@@ -726,12 +730,34 @@ func (t *timerActiveTaskExecutor) executeWorkflowTimeoutTask(
 	}
 
 	eventBatchFirstEventID := mutableState.GetNextEventID()
+	return t.retryOrCloseWorkflow(
+		ctx,
+		wfContext,
+		mutableState,
+		eventBatchFirstEventID,
+		execution.TimerTypeToReason(execution.TimerTypeStartToClose),
+		func() error {
+			return timeoutWorkflow(mutableState, eventBatchFirstEventID)
+		},
+	)
+}
 
-	timeoutReason := execution.TimerTypeToReason(execution.TimerTypeStartToClose)
-	backoffInterval := mutableState.GetRetryBackoffDuration(timeoutReason)
+// retryOrCloseWorkflow continues the workflow as new if the retry or cron policy requires it,
+// otherwise the workflow is closed by closeWorkflow.
+func (t *timerActiveTaskExecutor) retryOrCloseWorkflow(
+	ctx context.Context,
+	wfContext execution.Context,
+	mutableState execution.MutableState,
+	eventBatchFirstEventID int64,
+	failureReason string,
+	closeWorkflow func() error,
+) error {
+
+	backoffInterval := mutableState.GetRetryBackoffDuration(failureReason)
 	continueAsNewInitiator := types.ContinueAsNewInitiatorRetryPolicy
 	if backoffInterval == backoff.NoBackoff {
 		// check if a cron backoff is needed
+		var err error
 		backoffInterval, err = mutableState.GetCronBackoffDuration(ctx)
 		if err != nil {
 			return err
@@ -741,7 +767,7 @@ func (t *timerActiveTaskExecutor) executeWorkflowTimeoutTask(
 	// ignore event id
 	isCanceled, _ := mutableState.IsCancelRequested()
 	if isCanceled || backoffInterval == backoff.NoBackoff {
-		if err := timeoutWorkflow(mutableState, eventBatchFirstEventID); err != nil {
+		if err := closeWorkflow(); err != nil {
 			return err
 		}
 
@@ -750,7 +776,7 @@ func (t *timerActiveTaskExecutor) executeWorkflowTimeoutTask(
 		return t.updateWorkflowExecution(ctx, wfContext, mutableState, false)
 	}
 
-	// workflow timeout, but a retry or cron is needed, so we do continue as new to retry or cron
+	// a retry or cron is needed, so we do continue as new to retry or cron
 	startEvent, err := mutableState.GetStartEvent(ctx)
 	if err != nil {
 		return err
@@ -766,7 +792,7 @@ func (t *timerActiveTaskExecutor) executeWorkflowTimeoutTask(
 		BackoffStartIntervalInSeconds:       common.Int32Ptr(int32(backoffInterval.Seconds())),
 		RetryPolicy:                         startAttributes.RetryPolicy,
 		Initiator:                           continueAsNewInitiator.Ptr(),
-		FailureReason:                       common.StringPtr(timeoutReason),
+		FailureReason:                       common.StringPtr(failureReason),
 		CronSchedule:                        mutableState.GetExecutionInfo().CronSchedule,
 		Header:                              startAttributes.Header,
 		Memo:                                startAttributes.Memo,
@@ -802,6 +828,114 @@ func (t *timerActiveTaskExecutor) executeWorkflowTimeoutTask(
 	)
 }
 
+func (t *timerActiveTaskExecutor) executeWorkflowNoProgressTimeoutTask(
+	ctx context.Context,
+	task *persistence.TimerTaskInfo,
+) (retError error) {
+
+	wfContext, release, err := t.executionCache.GetOrCreateWorkflowExecutionWithTimeout(
+		task.DomainID,
+		getWorkflowExecution(task),
+		taskGetExecutionContextTimeout,
+	)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return errWorkflowBusy
+		}
+		return err
+	}
+	defer func() { release(retError) }()
+
+	mutableState, err := loadActiveMutableStateForTimerTask(ctx, wfContext, task, t.shard, t.metricsClient, t.logger)
+	if err != nil {
+		return err
+	}
+	if mutableState == nil || !mutableState.IsWorkflowExecutionRunning() {
+		return nil
+	}
+
+	startVersion, err := mutableState.GetStartVersion()
+	if err != nil {
+		return err
+	}
+	ok, err := verifyTaskVersion(t.shard, t.logger, task.DomainID, startVersion, task.Version, task)
+	if err != nil || !ok {
+		return err
+	}
+
+	executionInfo := mutableState.GetExecutionInfo()
+	if executionInfo.NoProgressTimeout <= 0 ||
+		task.VisibilityTimestamp.Unix() < executionInfo.NoProgressTimerTimestamp.Unix() {
+		// a newer no progress timer has been armed, this task is stale
+		return nil
+	}
+
+	deadline := executionInfo.LastProgressTimestamp.Add(time.Duration(executionInfo.NoProgressTimeout) * time.Second)
+	if deadline.Unix() > task.VisibilityTimestamp.Unix() {
+		// a decision has completed since the timer was armed, re-arm it from that decision
+		if err := execution.NewMutableStateTaskGenerator(
+			t.shard.GetClusterMetadata(),
+			t.shard.GetDomainCache(),
+			mutableState,
+		).GenerateWorkflowNoProgressTimeoutTasks(startVersion); err != nil {
+			return err
+		}
+		return t.updateWorkflowExecution(ctx, wfContext, mutableState, false)
+	}
+
+	domainName := mutableState.GetDomainEntry().GetInfo().Name
+	policy := types.NoProgressTimeoutPolicy(executionInfo.NoProgressTimeoutPolicy)
+	t.metricsClient.Scope(
+		metrics.TimerActiveTaskWorkflowNoProgressTimeoutScope,
+		metrics.DomainTag(domainName),
+		metrics.WorkflowTypeTag(executionInfo.WorkflowTypeName),
+	).IncCounter(metrics.WorkflowNoProgressTimeoutCount)
+	t.logger.Warn("Workflow has not made progress within no progress timeout",
+		tag.WorkflowDomainName(domainName),
+		tag.WorkflowID(task.WorkflowID),
+		tag.WorkflowRunID(task.RunID),
+		tag.WorkflowType(executionInfo.WorkflowTypeName),
+		tag.Value(policy.String()),
+	)
+
+	if policy != types.NoProgressTimeoutPolicyFail {
+		if _, err := mutableState.AddWorkflowNoProgressTimedOutEvent(); err != nil {
+			return err
+		}
+		// schedule a decision so that the worker is notified, if there is already
+		// a pending decision the event will be delivered along with it
+		return t.updateWorkflowExecution(ctx, wfContext, mutableState, !mutableState.HasPendingDecision())
+	}
+
+	eventBatchFirstEventID := mutableState.GetNextEventID()
+	if decision, ok := mutableState.GetInFlightDecision(); ok {
+		if err := execution.FailDecision(
+			mutableState,
+			decision,
+			types.DecisionTaskFailedCauseForceCloseDecision,
+		); err != nil {
+			return err
+		}
+	}
+	if _, err := mutableState.AddWorkflowNoProgressTimedOutEvent(); err != nil {
+		return err
+	}
+	return t.retryOrCloseWorkflow(
+		ctx,
+		wfContext,
+		mutableState,
+		eventBatchFirstEventID,
+		common.FailureReasonWorkflowNoProgressTimedOut,
+		func() error {
+			_, err := mutableState.AddFailWorkflowEvent(eventBatchFirstEventID, &types.FailWorkflowExecutionDecisionAttributes{
+				Reason:  common.StringPtr(common.FailureReasonWorkflowNoProgressTimedOut),
+				Details: []byte("Workflow has not completed a decision within no progress timeout."),
+			})
+			return err
+		},
+	)
+}
+
 func (t *timerActiveTaskExecutor) updateWorkflowExecution(
 	ctx context.Context,
 	wfContext execution.Context,
@@ -1155,6 +1155,136 @@ func (s *timerActiveTaskExecutorSuite) TestWorkflowTimeout_Fire() {
 	s.False(running)
 }
 
+func (s *timerActiveTaskExecutorSuite) TestWorkflowNoProgressTimeout_Notify() {
+
+	workflowExecution, mutableState, decisionCompletionID, err := test.SetupWorkflowWithCompletedDecision(s.mockShard, s.domainID)
+	s.NoError(err)
+	s.setNoProgressTimeout(mutableState, types.NoProgressTimeoutPolicyNotify, s.timeSource.Now())
+
+	timerTask := s.newNoProgressTimeoutTask(workflowExecution, s.timeSource.Now())
+
+	persistenceMutableState, err := test.CreatePersistenceMutableState(mutableState, decisionCompletionID, mutableState.GetCurrentVersion())
+	s.NoError(err)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything, mock.Anything).Return(&persistence.AppendHistoryNodesResponse{}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+
+	err = s.timerActiveTaskExecutor.Execute(timerTask, true)
+	s.NoError(err)
+
+	mutableState = s.getMutableStateFromCache(s.domainID, workflowExecution.GetWorkflowID(), workflowExecution.GetRunID())
+	s.True(mutableState.IsWorkflowExecutionRunning())
+	s.True(mutableState.HasPendingDecision())
+	// the no progress period restarts from the recorded event
+	executionInfo := mutableState.GetExecutionInfo()
+	s.True(executionInfo.NoProgressTimerTimestamp.After(s.timeSource.Now()))
+	s.Equal(
+		executionInfo.LastProgressTimestamp.Add(time.Duration(executionInfo.NoProgressTimeout)*time.Second),
+		executionInfo.NoProgressTimerTimestamp,
+	)
+}
+
+func (s *timerActiveTaskExecutorSuite) TestWorkflowNoProgressTimeout_Fail() {
+
+	workflowExecution, mutableState, decisionCompletionID, err := test.SetupWorkflowWithCompletedDecision(s.mockShard, s.domainID)
+	s.NoError(err)
+	s.setNoProgressTimeout(mutableState, types.NoProgressTimeoutPolicyFail, s.timeSource.Now())
+
+	timerTask := s.newNoProgressTimeoutTask(workflowExecution, s.timeSource.Now())
+
+	persistenceMutableState, err := test.CreatePersistenceMutableState(mutableState, decisionCompletionID, mutableState.GetCurrentVersion())
+	s.NoError(err)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything, mock.Anything).Return(&persistence.AppendHistoryNodesResponse{}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+
+	err = s.timerActiveTaskExecutor.Execute(timerTask, true)
+	s.NoError(err)
+
+	mutableState = s.getMutableStateFromCache(s.domainID, workflowExecution.GetWorkflowID(), workflowExecution.GetRunID())
+	s.False(mutableState.IsWorkflowExecutionRunning())
+	s.Equal(persistence.WorkflowCloseStatusFailed, mutableState.GetExecutionInfo().CloseStatus)
+}
+
+func (s *timerActiveTaskExecutorSuite) TestWorkflowNoProgressTimeout_Fail_ContinueAsNew_Retry() {
+
+	workflowExecution, mutableState, decisionCompletionID, err := test.SetupWorkflowWithCompletedDecision(s.mockShard, s.domainID)
+	s.NoError(err)
+	s.setNoProgressTimeout(mutableState, types.NoProgressTimeoutPolicyFail, s.timeSource.Now())
+
+	// need to override the workflow retry policy
+	executionInfo := mutableState.GetExecutionInfo()
+	executionInfo.HasRetryPolicy = true
+	executionInfo.ExpirationTime = s.timeSource.Now().Add(1000 * time.Second)
+	executionInfo.MaximumAttempts = 10
+	executionInfo.InitialInterval = 1
+	executionInfo.MaximumInterval = 1
+	executionInfo.BackoffCoefficient = 1
+
+	timerTask := s.newNoProgressTimeoutTask(workflowExecution, s.timeSource.Now())
+
+	persistenceMutableState, err := test.CreatePersistenceMutableState(mutableState, decisionCompletionID, mutableState.GetCurrentVersion())
+	s.NoError(err)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+	// one for current workflow, one for new
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything, mock.Anything).Return(&persistence.AppendHistoryNodesResponse{}, nil).Times(2)
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+
+	err = s.timerActiveTaskExecutor.Execute(timerTask, true)
+	s.NoError(err)
+
+	state, closeStatus := s.getMutableStateFromCache(s.domainID, workflowExecution.GetWorkflowID(), workflowExecution.GetRunID()).GetWorkflowStateCloseStatus()
+	s.Equal(persistence.WorkflowStateCompleted, state)
+	s.Equal(persistence.WorkflowCloseStatusContinuedAsNew, closeStatus)
+}
+
+func (s *timerActiveTaskExecutorSuite) TestWorkflowNoProgressTimeout_Rearm() {
+
+	workflowExecution, mutableState, decisionCompletionID, err := test.SetupWorkflowWithCompletedDecision(s.mockShard, s.domainID)
+	s.NoError(err)
+	s.setNoProgressTimeout(mutableState, types.NoProgressTimeoutPolicyFail, s.timeSource.Now())
+	// a decision has completed after the timer was armed
+	executionInfo := mutableState.GetExecutionInfo()
+	executionInfo.LastProgressTimestamp = s.timeSource.Now().Add(-time.Second)
+
+	timerTask := s.newNoProgressTimeoutTask(workflowExecution, s.timeSource.Now())
+
+	persistenceMutableState, err := test.CreatePersistenceMutableState(mutableState, decisionCompletionID, mutableState.GetCurrentVersion())
+	s.NoError(err)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+
+	err = s.timerActiveTaskExecutor.Execute(timerTask, true)
+	s.NoError(err)
+
+	mutableState = s.getMutableStateFromCache(s.domainID, workflowExecution.GetWorkflowID(), workflowExecution.GetRunID())
+	s.True(mutableState.IsWorkflowExecutionRunning())
+	executionInfo = mutableState.GetExecutionInfo()
+	s.Equal(
+		executionInfo.LastProgressTimestamp.Add(time.Duration(executionInfo.NoProgressTimeout)*time.Second),
+		executionInfo.NoProgressTimerTimestamp,
+	)
+}
+
+func (s *timerActiveTaskExecutorSuite) TestWorkflowNoProgressTimeout_Stale() {
+
+	workflowExecution, mutableState, decisionCompletionID, err := test.SetupWorkflowWithCompletedDecision(s.mockShard, s.domainID)
+	s.NoError(err)
+	s.setNoProgressTimeout(mutableState, types.NoProgressTimeoutPolicyFail, s.timeSource.Now())
+
+	// a newer timer has been armed after this task was generated
+	timerTask := s.newNoProgressTimeoutTask(workflowExecution, s.timeSource.Now().Add(-time.Minute))
+
+	persistenceMutableState, err := test.CreatePersistenceMutableState(mutableState, decisionCompletionID, mutableState.GetCurrentVersion())
+	s.NoError(err)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+
+	err = s.timerActiveTaskExecutor.Execute(timerTask, true)
+	s.NoError(err)
+
+	s.True(s.getMutableStateFromCache(s.domainID, workflowExecution.GetWorkflowID(), workflowExecution.GetRunID()).IsWorkflowExecutionRunning())
+}
+
 func (s *timerActiveTaskExecutorSuite) TestWorkflowTimeout_ContinueAsNew_Retry() {
 
 	workflowExecution, mutableState, decisionCompletionID, err := test.SetupWorkflowWithCompletedDecision(s.mockShard, s.domainID)
@@ -1230,6 +1360,33 @@ func (s *timerActiveTaskExecutorSuite) TestWorkflowTimeout_ContinueAsNew_Cron()
 	s.Equal(persistence.WorkflowCloseStatusContinuedAsNew, closeStatus)
 }
 
+func (s *timerActiveTaskExecutorSuite) setNoProgressTimeout(
+	mutableState execution.MutableState,
+	policy types.NoProgressTimeoutPolicy,
+	timerTimestamp time.Time,
+) {
+	executionInfo := mutableState.GetExecutionInfo()
+	executionInfo.NoProgressTimeout = 60
+	executionInfo.NoProgressTimeoutPolicy = int32(policy)
+	executionInfo.LastProgressTimestamp = timerTimestamp.Add(-time.Minute)
+	executionInfo.NoProgressTimerTimestamp = timerTimestamp
+}
+
+func (s *timerActiveTaskExecutorSuite) newNoProgressTimeoutTask(
+	workflowExecution types.WorkflowExecution,
+	visibilityTimestamp time.Time,
+) Task {
+	return s.newTimerTaskFromInfo(&persistence.TimerTaskInfo{
+		Version:             s.version,
+		DomainID:            s.domainID,
+		WorkflowID:          workflowExecution.GetWorkflowID(),
+		RunID:               workflowExecution.GetRunID(),
+		TaskID:              int64(100),
+		TaskType:            persistence.TaskTypeWorkflowNoProgressTimeout,
+		VisibilityTimestamp: visibilityTimestamp,
+	})
+}
+
 func (s *timerActiveTaskExecutorSuite) getMutableStateFromCache(
 	domainID string,
 	workflowID string,
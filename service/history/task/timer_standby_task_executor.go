@@ -108,6 +108,10 @@ func (t *timerStandbyTaskExecutor) Execute(
 		ctx, cancel := context.WithTimeout(t.ctx, taskDefaultTimeout)
 		defer cancel()
 		return t.executeWorkflowTimeoutTask(ctx, timerTask)
+	case persistence.TaskTypeWorkflowNoProgressTimeout:
+		ctx, cancel := context.WithTimeout(t.ctx, taskDefaultTimeout)
+		defer cancel()
+		return t.executeWorkflowNoProgressTimeoutTask(ctx, timerTask)
 	case persistence.TaskTypeActivityRetryTimer:
 		// retry backoff timer should not get created on passive cluster
 		// TODO: add error logs
@@ -403,6 +407,76 @@ func (t *timerStandbyTaskExecutor) executeWorkflowTimeoutTask(
 	)
 }
 
+func (t *timerStandbyTaskExecutor) executeWorkflowNoProgressTimeoutTask(
+	ctx context.Context,
+	timerTask *persistence.TimerTaskInfo,
+) error {
+
+	actionFn := func(ctx context.Context, wfContext execution.Context, mutableState execution.MutableState) (interface{}, error) {
+
+		startVersion, err := mutableState.GetStartVersion()
+		if err != nil {
+			return nil, err
+		}
+		ok, err := verifyTaskVersion(t.shard, t.logger, timerTask.DomainID, startVersion, timerTask.Version, timerTask)
+		if err != nil || !ok {
+			return nil, err
+		}
+
+		executionInfo := mutableState.GetExecutionInfo()
+		if executionInfo.NoProgressTimeout <= 0 ||
+			timerTask.VisibilityTimestamp.Unix() < executionInfo.NoProgressTimerTimestamp.Unix() {
+			// a newer no progress timer has been armed, this task is stale
+			return nil, nil
+		}
+
+		deadline := executionInfo.LastProgressTimestamp.Add(time.Duration(executionInfo.NoProgressTimeout) * time.Second)
+		if deadline.Unix() <= timerTask.VisibilityTimestamp.Unix() {
+			// wait for the event recorded by the active cluster to be replicated
+			return getHistoryResendInfo(mutableState)
+		}
+
+		// a decision has completed since the timer was armed, passive logic
+		// need to explicitly re-arm the timer so it exists after a failover
+		lastWriteVersion, err := mutableState.GetLastWriteVersion()
+		if err != nil {
+			return nil, err
+		}
+		if err := execution.NewMutableStateTaskGenerator(
+			t.shard.GetClusterMetadata(),
+			t.shard.GetDomainCache(),
+			mutableState,
+		).GenerateWorkflowNoProgressTimeoutTasks(startVersion); err != nil {
+			return nil, err
+		}
+
+		now := t.getStandbyClusterTime()
+		// we need to handcraft some of the variables
+		// since the job being done here is write a timer task to DB
+		// also need to reset the current version.
+		if err := mutableState.UpdateCurrentVersion(lastWriteVersion, true); err != nil {
+			return nil, err
+		}
+
+		err = wfContext.UpdateWorkflowExecutionAsPassive(ctx, now)
+		return nil, err
+	}
+
+	return t.processTimer(
+		ctx,
+		timerTask,
+		actionFn,
+		getStandbyPostActionFn(
+			timerTask,
+			t.getCurrentTime,
+			t.config.StandbyTaskMissingEventsResendDelay(),
+			t.config.StandbyTaskMissingEventsDiscardDelay(),
+			t.fetchHistoryFromRemote,
+			standbyTimerTaskPostActionTaskDiscarded,
+		),
+	)
+}
+
 func (t *timerStandbyTaskExecutor) getStandbyClusterTime() time.Time {
 	// time of remote cluster in the shard is delayed by "StandbyClusterDelay"
 	// so to get the current accurate remote cluster time, need to add it back
@@ -442,6 +516,14 @@ func (t *timerStandbyTaskExecutor) processTimer(
 	if mutableState == nil {
 		return nil
 	}
+	activeCluster, err := getActiveClusterForActiveActiveWorkflow(t.shard, mutableState)
+	if err != nil {
+		return err
+	}
+	if activeCluster == t.shard.GetClusterMetadata().GetCurrentClusterName() {
+		// the region of the workflow is failed over to the current cluster, the task is processed as active
+		return nil
+	}
 
 	if !mutableState.IsWorkflowExecutionRunning() {
 		// workflow already finished, no need to process the timer
@@ -452,6 +534,17 @@ func (t *timerStandbyTaskExecutor) processTimer(
 	if err != nil {
 		return err
 	}
+	if historyResendInfo != nil && execution.IsLocalOnlyWorkflow(mutableState.GetExecutionInfo()) {
+		// local-only workflows are never replicated, so there is nothing to wait for or to fetch from the
+		// active cluster, the task is acked and regenerated by the task refresh once the domain fails back
+		t.logger.Debug("Skipping standby task of local-only workflow.",
+			tag.WorkflowDomainID(timerTask.DomainID),
+			tag.WorkflowID(timerTask.WorkflowID),
+			tag.WorkflowRunID(timerTask.RunID),
+			tag.TaskID(timerTask.TaskID),
+		)
+		return nil
+	}
 
 	release(nil)
 	return postActionFn(ctx, timerTask, historyResendInfo, t.logger)
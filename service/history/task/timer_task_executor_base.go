@@ -273,23 +273,50 @@ func (t *timerTaskExecutorBase) deleteWorkflowHistory(
 	msBuilder execution.MutableState,
 ) error {
 
-	op := func() error {
-		branchToken, err := msBuilder.GetCurrentBranchToken()
-		if err != nil {
+	branchTokens, err := getWorkflowBranchTokens(msBuilder)
+	if err != nil {
+		return err
+	}
+	domainName, err := t.shard.GetDomainCache().GetDomainName(task.DomainID)
+	if err != nil {
+		return err
+	}
+
+	// all branches are deleted, otherwise branches left behind by conflict resolution
+	// would never be cleaned up once the workflow execution record is deleted
+	for _, branchToken := range branchTokens {
+		op := func() error {
+			return t.shard.GetHistoryManager().DeleteHistoryBranch(ctx, &persistence.DeleteHistoryBranchRequest{
+				BranchToken: branchToken,
+				ShardID:     common.IntPtr(t.shard.GetShardID()),
+				DomainName:  domainName,
+			})
+		}
+		if err := t.throttleRetry.Do(ctx, op); err != nil {
 			return err
 		}
-		domainName, err := t.shard.GetDomainCache().GetDomainName(task.DomainID)
+	}
+	return nil
+}
+
+func getWorkflowBranchTokens(
+	msBuilder execution.MutableState,
+) ([][]byte, error) {
+
+	versionHistories := msBuilder.GetVersionHistories()
+	if versionHistories == nil {
+		branchToken, err := msBuilder.GetCurrentBranchToken()
 		if err != nil {
-			return err
+			return nil, err
 		}
-		return t.shard.GetHistoryManager().DeleteHistoryBranch(ctx, &persistence.DeleteHistoryBranchRequest{
-			BranchToken: branchToken,
-			ShardID:     common.IntPtr(t.shard.GetShardID()),
-			DomainName:  domainName,
-		})
+		return [][]byte{branchToken}, nil
+	}
 
+	branchTokens := make([][]byte, 0, len(versionHistories.Histories))
+	for _, versionHistory := range versionHistories.Histories {
+		branchTokens = append(branchTokens, versionHistory.GetBranchToken())
 	}
-	return t.throttleRetry.Do(ctx, op)
+	return branchTokens, nil
 }
 
 func (t *timerTaskExecutorBase) deleteWorkflowVisibility(
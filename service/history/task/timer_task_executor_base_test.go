@@ -21,6 +21,7 @@
 package task
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"testing"
@@ -135,6 +136,7 @@ func (s *timerQueueTaskExecutorBaseSuite) TestDeleteWorkflow_NoErr() {
 	s.mockExecutionManager.On("DeleteWorkflowExecution", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 	s.mockHistoryV2Manager.On("DeleteHistoryBranch", mock.Anything, mock.Anything).Return(nil).Once()
 	s.mockVisibilityManager.On("DeleteWorkflowExecution", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockMutableState.EXPECT().GetVersionHistories().Return(nil).Times(1)
 	s.mockMutableState.EXPECT().GetCurrentBranchToken().Return([]byte{1, 2, 3}, nil).Times(1)
 	s.mockMutableState.EXPECT().GetLastWriteVersion().Return(int64(1234), nil).AnyTimes()
 
@@ -142,6 +144,41 @@ func (s *timerQueueTaskExecutorBaseSuite) TestDeleteWorkflow_NoErr() {
 	s.NoError(err)
 }
 
+func (s *timerQueueTaskExecutorBaseSuite) TestDeleteWorkflow_MultipleBranches() {
+	task := &persistence.TimerTaskInfo{
+		TaskID:              12345,
+		VisibilityTimestamp: time.Now(),
+	}
+	executionInfo := types.WorkflowExecution{
+		WorkflowID: task.WorkflowID,
+		RunID:      task.RunID,
+	}
+	wfContext := execution.NewContext(task.DomainID, executionInfo, s.mockShard, s.mockExecutionManager, log.NewNoop())
+	versionHistories := &persistence.VersionHistories{
+		Histories: []*persistence.VersionHistory{
+			persistence.NewVersionHistory([]byte{1, 2, 3}, nil),
+			persistence.NewVersionHistory([]byte{4, 5, 6}, nil),
+		},
+	}
+
+	s.mockShard.Resource.DomainCache.EXPECT().GetDomainName(gomock.Any()).Return("Sample", nil).AnyTimes()
+
+	s.mockExecutionManager.On("DeleteCurrentWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionManager.On("DeleteWorkflowExecution", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockHistoryV2Manager.On("DeleteHistoryBranch", mock.Anything, mock.MatchedBy(func(request *persistence.DeleteHistoryBranchRequest) bool {
+		return bytes.Equal(request.BranchToken, []byte{1, 2, 3})
+	})).Return(nil).Once()
+	s.mockHistoryV2Manager.On("DeleteHistoryBranch", mock.Anything, mock.MatchedBy(func(request *persistence.DeleteHistoryBranchRequest) bool {
+		return bytes.Equal(request.BranchToken, []byte{4, 5, 6})
+	})).Return(nil).Once()
+	s.mockVisibilityManager.On("DeleteWorkflowExecution", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockMutableState.EXPECT().GetVersionHistories().Return(versionHistories).Times(1)
+	s.mockMutableState.EXPECT().GetLastWriteVersion().Return(int64(1234), nil).AnyTimes()
+
+	err := s.timerQueueTaskExecutorBase.deleteWorkflow(context.Background(), task, wfContext, s.mockMutableState)
+	s.NoError(err)
+}
+
 func (s *timerQueueTaskExecutorBaseSuite) TestArchiveHistory_NoErr_InlineArchivalFailed() {
 	s.mockWorkflowExecutionContext.EXPECT().LoadExecutionStats(gomock.Any()).Return(&persistence.ExecutionStats{
 		HistorySize: 1024,
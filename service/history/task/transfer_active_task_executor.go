@@ -182,7 +182,7 @@ func (t *transferActiveTaskExecutor) processActivityTask(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := loadMutableStateForTransferTask(ctx, wfContext, task, t.metricsClient, t.logger)
+	mutableState, err := loadActiveMutableStateForTransferTask(ctx, wfContext, task, t.shard, t.metricsClient, t.logger)
 	if err != nil {
 		return err
 	}
@@ -199,7 +199,13 @@ func (t *transferActiveTaskExecutor) processActivityTask(
 	if err != nil || !ok {
 		return err
 	}
+	if task.TaskList != ai.TaskList {
+		// the task list of the activity is updated after this task is generated,
+		// another task has been generated for the new task list
+		return nil
+	}
 
+	taskList := &types.TaskList{Name: ai.TaskList}
 	timeout := common.MinInt32(ai.ScheduleToStartTimeout, common.MaxTaskTimeout)
 	// release the context lock since we no longer need mutable state builder and
 	// the rest of logic is making RPC call, which takes time.
@@ -210,7 +216,7 @@ func (t *transferActiveTaskExecutor) processActivityTask(
 		return errWorkflowRateLimited
 	}
 
-	return t.pushActivity(ctx, task, timeout, mutableState.GetExecutionInfo().PartitionConfig)
+	return t.pushActivity(ctx, task, taskList, timeout, mutableState.GetExecutionInfo().PartitionConfig)
 }
 
 func (t *transferActiveTaskExecutor) processDecisionTask(
@@ -231,7 +237,7 @@ func (t *transferActiveTaskExecutor) processDecisionTask(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := loadMutableStateForTransferTask(ctx, wfContext, task, t.metricsClient, t.logger)
+	mutableState, err := loadActiveMutableStateForTransferTask(ctx, wfContext, task, t.shard, t.metricsClient, t.logger)
 	if err != nil {
 		return err
 	}
@@ -375,7 +381,7 @@ func (t *transferActiveTaskExecutor) processCloseExecutionTaskHelper(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := loadMutableStateForTransferTask(ctx, wfContext, task, t.metricsClient, t.logger)
+	mutableState, err := loadActiveMutableStateForTransferTask(ctx, wfContext, task, t.shard, t.metricsClient, t.logger)
 	if err != nil {
 		return err
 	}
@@ -589,7 +595,7 @@ func (t *transferActiveTaskExecutor) processCancelExecution(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := loadMutableStateForTransferTask(ctx, wfContext, task, t.metricsClient, t.logger)
+	mutableState, err := loadActiveMutableStateForTransferTask(ctx, wfContext, task, t.shard, t.metricsClient, t.logger)
 	if err != nil {
 		return err
 	}
@@ -705,7 +711,7 @@ func (t *transferActiveTaskExecutor) processSignalExecution(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := loadMutableStateForTransferTask(ctx, wfContext, task, t.metricsClient, t.logger)
+	mutableState, err := loadActiveMutableStateForTransferTask(ctx, wfContext, task, t.shard, t.metricsClient, t.logger)
 	if err != nil {
 		return err
 	}
@@ -835,7 +841,7 @@ func (t *transferActiveTaskExecutor) processStartChildExecution(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := loadMutableStateForTransferTask(ctx, wfContext, task, t.metricsClient, t.logger)
+	mutableState, err := loadActiveMutableStateForTransferTask(ctx, wfContext, task, t.shard, t.metricsClient, t.logger)
 	if err != nil {
 		return err
 	}
@@ -1008,7 +1014,7 @@ func (t *transferActiveTaskExecutor) processRecordWorkflowStartedOrUpsertHelper(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := loadMutableStateForTransferTask(ctx, wfContext, task, t.metricsClient, t.logger)
+	mutableState, err := loadActiveMutableStateForTransferTask(ctx, wfContext, task, t.shard, t.metricsClient, t.logger)
 	if err != nil {
 		return err
 	}
@@ -1110,7 +1116,7 @@ func (t *transferActiveTaskExecutor) processResetWorkflow(
 	}
 	defer func() { currentRelease(retError) }()
 
-	currentMutableState, err := loadMutableStateForTransferTask(ctx, currentContext, task, t.metricsClient, t.logger)
+	currentMutableState, err := loadActiveMutableStateForTransferTask(ctx, currentContext, task, t.shard, t.metricsClient, t.logger)
 	if err != nil {
 		return err
 	}
@@ -1198,7 +1204,7 @@ func (t *transferActiveTaskExecutor) processResetWorkflow(
 		}
 
 		defer func() { baseRelease(retError) }()
-		baseMutableState, err = loadMutableStateForTransferTask(ctx, baseContext, task, t.metricsClient, t.logger)
+		baseMutableState, err = loadActiveMutableStateForTransferTask(ctx, baseContext, task, t.shard, t.metricsClient, t.logger)
 		if err != nil {
 			return err
 		}
@@ -258,6 +258,41 @@ func (s *transferActiveTaskExecutorSuite) TestProcessActivityTask_Success() {
 	s.Nil(err)
 }
 
+func (s *transferActiveTaskExecutorSuite) TestProcessActivityTask_TaskListUpdated() {
+
+	workflowExecution, mutableState, decisionCompletionID, err := test.SetupWorkflowWithCompletedDecision(s.mockShard, s.domainID)
+	s.NoError(err)
+
+	event, _ := test.AddActivityTaskScheduledEvent(
+		mutableState,
+		decisionCompletionID,
+		"activity-1",
+		"some random activity type",
+		mutableState.GetExecutionInfo().TaskList,
+		[]byte{}, 1, 1, 1, 1,
+	)
+	mutableState.FlushBufferedEvents()
+
+	// the task is generated for the task list of the activity before the update
+	transferTask := s.newTransferTaskFromInfo(&persistence.TransferTaskInfo{
+		Version:        s.version,
+		DomainID:       s.domainID,
+		TargetDomainID: s.targetDomainID,
+		WorkflowID:     workflowExecution.GetWorkflowID(),
+		RunID:          workflowExecution.GetRunID(),
+		TaskID:         int64(59),
+		TaskList:       "some previous task list",
+		TaskType:       persistence.TransferTaskTypeActivityTask,
+		ScheduleID:     event.ID,
+	})
+
+	persistenceMutableState, err := test.CreatePersistenceMutableState(mutableState, event.ID, event.Version)
+	s.NoError(err)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+	err = s.transferActiveTaskExecutor.Execute(transferTask, true)
+	s.Nil(err)
+}
+
 func (s *transferActiveTaskExecutorSuite) TestProcessActivityTask_Ratelimits() {
 	workflowExecution, mutableState, decisionCompletionID, err := test.SetupWorkflowWithCompletedDecision(s.mockShard, s.domainID)
 	s.NoError(err)
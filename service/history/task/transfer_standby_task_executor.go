@@ -138,10 +138,15 @@ func (t *transferStandbyTaskExecutor) processActivityTask(
 		if err != nil || !ok {
 			return nil, err
 		}
+		if transferTask.TaskList != activityInfo.TaskList {
+			// another task has been generated for the new task list of the activity
+			return nil, nil
+		}
 
 		if activityInfo.StartedID == common.EmptyEventID {
 			return newPushActivityToMatchingInfo(
 				activityInfo.ScheduleToStartTimeout,
+				types.TaskList{Name: activityInfo.TaskList},
 				mutableState.GetExecutionInfo().PartitionConfig,
 			), nil
 		}
@@ -565,6 +570,14 @@ func (t *transferStandbyTaskExecutor) processTransfer(
 	if err != nil || mutableState == nil {
 		return err
 	}
+	activeCluster, err := getActiveClusterForActiveActiveWorkflow(t.shard, mutableState)
+	if err != nil {
+		return err
+	}
+	if activeCluster == t.shard.GetClusterMetadata().GetCurrentClusterName() {
+		// the region of the workflow is failed over to the current cluster, the task is processed as active
+		return nil
+	}
 
 	if !mutableState.IsWorkflowExecutionRunning() && !processTaskIfClosed {
 		// workflow already finished, no need to process the timer
@@ -575,6 +588,17 @@ func (t *transferStandbyTaskExecutor) processTransfer(
 	if err != nil {
 		return err
 	}
+	if historyResendInfo != nil && execution.IsLocalOnlyWorkflow(mutableState.GetExecutionInfo()) {
+		// local-only workflows are never replicated, so there is nothing to wait for or to fetch from the
+		// active cluster, the task is acked and regenerated by the task refresh once the domain fails back
+		t.logger.Debug("Skipping standby task of local-only workflow.",
+			tag.WorkflowDomainID(transferTask.DomainID),
+			tag.WorkflowID(transferTask.WorkflowID),
+			tag.WorkflowRunID(transferTask.RunID),
+			tag.TaskID(transferTask.TaskID),
+		)
+		return nil
+	}
 
 	release(nil)
 	return postActionFn(ctx, taskInfo, historyResendInfo, t.logger)
@@ -596,6 +620,7 @@ func (t *transferStandbyTaskExecutor) pushActivity(
 	return t.transferTaskExecutorBase.pushActivity(
 		ctx,
 		task.(*persistence.TransferTaskInfo),
+		&pushActivityInfo.tasklist,
 		timeout,
 		pushActivityInfo.partitionConfig,
 	)
@@ -41,6 +41,7 @@ import (
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/mocks"
 	"github.com/uber/cadence/common/ndc"
+	"github.com/uber/cadence/common/partition"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/history/config"
@@ -503,6 +504,51 @@ func (s *transferStandbyTaskExecutorSuite) TestProcessCancelExecution_Pending()
 	s.Equal(ErrTaskDiscarded, err)
 }
 
+func (s *transferStandbyTaskExecutorSuite) TestProcessCancelExecution_Pending_LocalOnly() {
+
+	workflowExecution, mutableState, decisionCompletionID, err := test.SetupWorkflowWithCompletedDecision(s.mockShard, s.domainID)
+	s.NoError(err)
+	mutableState.GetExecutionInfo().PartitionConfig = map[string]string{partition.LocalOnlyKey: "true"}
+	targetExecution := types.WorkflowExecution{
+		WorkflowID: "some random target workflow ID",
+		RunID:      uuid.New(),
+	}
+
+	event, _ := test.AddRequestCancelInitiatedEvent(
+		mutableState,
+		decisionCompletionID,
+		uuid.New(),
+		constants.TestTargetDomainName,
+		targetExecution.GetWorkflowID(),
+		targetExecution.GetRunID(),
+	)
+
+	now := time.Now()
+	transferTask := s.newTransferTaskFromInfo(&persistence.TransferTaskInfo{
+		Version:             s.version,
+		DomainID:            s.domainID,
+		WorkflowID:          workflowExecution.GetWorkflowID(),
+		RunID:               workflowExecution.GetRunID(),
+		VisibilityTimestamp: now,
+		TargetDomainID:      constants.TestTargetDomainID,
+		TargetWorkflowID:    targetExecution.GetWorkflowID(),
+		TargetRunID:         targetExecution.GetRunID(),
+		TaskID:              int64(59),
+		TaskList:            mutableState.GetExecutionInfo().TaskList,
+		TaskType:            persistence.TransferTaskTypeCancelExecution,
+		ScheduleID:          event.ID,
+	})
+
+	persistenceMutableState, err := test.CreatePersistenceMutableState(mutableState, event.ID, event.Version)
+	s.NoError(err)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+
+	// history of local-only workflows is never fetched from the active cluster
+	s.mockShard.SetCurrentTime(s.clusterName, now.Add(s.fetchHistoryDuration))
+	err = s.transferStandbyTaskExecutor.Execute(transferTask, true)
+	s.NoError(err)
+}
+
 func (s *transferStandbyTaskExecutorSuite) TestProcessCancelExecution_Success() {
 
 	workflowExecution, mutableState, decisionCompletionID, err := test.SetupWorkflowWithCompletedDecision(s.mockShard, s.domainID)
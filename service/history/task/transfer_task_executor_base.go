@@ -87,6 +87,7 @@ func newTransferTaskExecutorBase(
 func (t *transferTaskExecutorBase) pushActivity(
 	ctx context.Context,
 	task *persistence.TransferTaskInfo,
+	tasklist *types.TaskList,
 	activityScheduleToStartTimeout int32,
 	partitionConfig map[string]string,
 ) error {
@@ -105,7 +106,7 @@ func (t *transferTaskExecutorBase) pushActivity(
 			WorkflowID: task.WorkflowID,
 			RunID:      task.RunID,
 		},
-		TaskList:                      &types.TaskList{Name: task.TaskList},
+		TaskList:                      tasklist,
 		ScheduleID:                    task.ScheduleID,
 		ScheduleToStartTimeoutSeconds: common.Int32Ptr(activityScheduleToStartTimeout),
 		PartitionConfig:               partitionConfig,
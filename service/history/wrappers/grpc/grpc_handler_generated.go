@@ -52,6 +52,11 @@ func (g GRPCHandler) CountDLQMessages(ctx context.Context, request *historyv1.Co
 	return proto.FromHistoryCountDLQMessagesResponse(response), proto.FromError(err)
 }
 
+func (g GRPCHandler) DeleteWorkflowExecution(ctx context.Context, request *historyv1.DeleteWorkflowExecutionRequest) (*historyv1.DeleteWorkflowExecutionResponse, error) {
+	response, err := g.h.DeleteWorkflowExecution(ctx, proto.ToHistoryDeleteWorkflowExecutionRequest(request))
+	return proto.FromHistoryDeleteWorkflowExecutionResponse(response), proto.FromError(err)
+}
+
 func (g GRPCHandler) DescribeHistoryHost(ctx context.Context, request *historyv1.DescribeHistoryHostRequest) (*historyv1.DescribeHistoryHostResponse, error) {
 	response, err := g.h.DescribeHistoryHost(ctx, proto.ToHistoryDescribeHistoryHostRequest(request))
 	return proto.FromHistoryDescribeHistoryHostResponse(response), proto.FromError(err)
@@ -67,11 +72,21 @@ func (g GRPCHandler) DescribeQueue(ctx context.Context, request *historyv1.Descr
 	return proto.FromHistoryDescribeQueueResponse(response), proto.FromError(err)
 }
 
+func (g GRPCHandler) DescribeReplicationStatus(ctx context.Context, request *historyv1.DescribeReplicationStatusRequest) (*historyv1.DescribeReplicationStatusResponse, error) {
+	response, err := g.h.DescribeReplicationStatus(ctx, proto.ToHistoryDescribeReplicationStatusRequest(request))
+	return proto.FromHistoryDescribeReplicationStatusResponse(response), proto.FromError(err)
+}
+
 func (g GRPCHandler) DescribeWorkflowExecution(ctx context.Context, request *historyv1.DescribeWorkflowExecutionRequest) (*historyv1.DescribeWorkflowExecutionResponse, error) {
 	response, err := g.h.DescribeWorkflowExecution(ctx, proto.ToHistoryDescribeWorkflowExecutionRequest(request))
 	return proto.FromHistoryDescribeWorkflowExecutionResponse(response), proto.FromError(err)
 }
 
+func (g GRPCHandler) ExecuteQueueTask(ctx context.Context, request *historyv1.ExecuteQueueTaskRequest) (*historyv1.ExecuteQueueTaskResponse, error) {
+	err := g.h.ExecuteQueueTask(ctx, proto.ToHistoryExecuteQueueTaskRequest(request))
+	return &historyv1.ExecuteQueueTaskResponse{}, proto.FromError(err)
+}
+
 func (g GRPCHandler) GetCrossClusterTasks(ctx context.Context, request *historyv1.GetCrossClusterTasksRequest) (*historyv1.GetCrossClusterTasksResponse, error) {
 	response, err := g.h.GetCrossClusterTasks(ctx, proto.ToHistoryGetCrossClusterTasksRequest(request))
 	return proto.FromHistoryGetCrossClusterTasksResponse(response), proto.FromError(err)
@@ -97,11 +112,21 @@ func (g GRPCHandler) GetReplicationMessages(ctx context.Context, request *histor
 	return proto.FromHistoryGetReplicationMessagesResponse(response), proto.FromError(err)
 }
 
+func (g GRPCHandler) ListQueueTasks(ctx context.Context, request *historyv1.ListQueueTasksRequest) (*historyv1.ListQueueTasksResponse, error) {
+	response, err := g.h.ListQueueTasks(ctx, proto.ToHistoryListQueueTasksRequest(request))
+	return proto.FromHistoryListQueueTasksResponse(response), proto.FromError(err)
+}
+
 func (g GRPCHandler) MergeDLQMessages(ctx context.Context, request *historyv1.MergeDLQMessagesRequest) (*historyv1.MergeDLQMessagesResponse, error) {
 	response, err := g.h.MergeDLQMessages(ctx, proto.ToHistoryMergeDLQMessagesRequest(request))
 	return proto.FromHistoryMergeDLQMessagesResponse(response), proto.FromError(err)
 }
 
+func (g GRPCHandler) MigrateShardExecutions(ctx context.Context, request *historyv1.MigrateShardExecutionsRequest) (*historyv1.MigrateShardExecutionsResponse, error) {
+	response, err := g.h.MigrateShardExecutions(ctx, proto.ToHistoryMigrateShardExecutionsRequest(request))
+	return proto.FromHistoryMigrateShardExecutionsResponse(response), proto.FromError(err)
+}
+
 func (g GRPCHandler) NotifyFailoverMarkers(ctx context.Context, request *historyv1.NotifyFailoverMarkersRequest) (*historyv1.NotifyFailoverMarkersResponse, error) {
 	err := g.h.NotifyFailoverMarkers(ctx, proto.ToHistoryNotifyFailoverMarkersRequest(request))
 	return &historyv1.NotifyFailoverMarkersResponse{}, proto.FromError(err)
@@ -261,3 +286,13 @@ func (g GRPCHandler) TerminateWorkflowExecution(ctx context.Context, request *hi
 	err := g.h.TerminateWorkflowExecution(ctx, proto.ToHistoryTerminateWorkflowExecutionRequest(request))
 	return &historyv1.TerminateWorkflowExecutionResponse{}, proto.FromError(err)
 }
+
+func (g GRPCHandler) UpdateActivityOptions(ctx context.Context, request *historyv1.UpdateActivityOptionsRequest) (*historyv1.UpdateActivityOptionsResponse, error) {
+	err := g.h.UpdateActivityOptions(ctx, proto.ToHistoryUpdateActivityOptionsRequest(request))
+	return &historyv1.UpdateActivityOptionsResponse{}, proto.FromError(err)
+}
+
+func (g GRPCHandler) VerifyWorkflowExecution(ctx context.Context, request *historyv1.VerifyWorkflowExecutionRequest) (*historyv1.VerifyWorkflowExecutionResponse, error) {
+	response, err := g.h.VerifyWorkflowExecution(ctx, proto.ToHistoryVerifyWorkflowExecutionRequest(request))
+	return proto.FromHistoryVerifyWorkflowExecutionResponse(response), proto.FromError(err)
+}
@@ -80,6 +80,10 @@ func (h *historyHandler) CountDLQMessages(ctx context.Context, cp1 *types.CountD
 	return h.wrapped.CountDLQMessages(ctx, cp1)
 }
 
+func (h *historyHandler) DeleteWorkflowExecution(ctx context.Context, hp1 *types.HistoryDeleteWorkflowExecutionRequest) (dp1 *types.DeleteWorkflowExecutionResponse, err error) {
+	return h.wrapped.DeleteWorkflowExecution(ctx, hp1)
+}
+
 func (h *historyHandler) DescribeHistoryHost(ctx context.Context, dp1 *types.DescribeHistoryHostRequest) (dp2 *types.DescribeHistoryHostResponse, err error) {
 	return h.wrapped.DescribeHistoryHost(ctx, dp1)
 }
@@ -92,6 +96,10 @@ func (h *historyHandler) DescribeQueue(ctx context.Context, dp1 *types.DescribeQ
 	return h.wrapped.DescribeQueue(ctx, dp1)
 }
 
+func (h *historyHandler) DescribeReplicationStatus(ctx context.Context, dp1 *types.DescribeReplicationStatusRequest) (dp2 *types.DescribeReplicationStatusResponse, err error) {
+	return h.wrapped.DescribeReplicationStatus(ctx, dp1)
+}
+
 func (h *historyHandler) DescribeWorkflowExecution(ctx context.Context, hp1 *types.HistoryDescribeWorkflowExecutionRequest) (dp1 *types.DescribeWorkflowExecutionResponse, err error) {
 
 	if hp1 == nil {
@@ -119,6 +127,10 @@ func (h *historyHandler) DescribeWorkflowExecution(ctx context.Context, hp1 *typ
 	return h.wrapped.DescribeWorkflowExecution(ctx, hp1)
 }
 
+func (h *historyHandler) ExecuteQueueTask(ctx context.Context, ep1 *types.ExecuteQueueTaskRequest) (err error) {
+	return h.wrapped.ExecuteQueueTask(ctx, ep1)
+}
+
 func (h *historyHandler) GetCrossClusterTasks(ctx context.Context, gp1 *types.GetCrossClusterTasksRequest) (gp2 *types.GetCrossClusterTasksResponse, err error) {
 	return h.wrapped.GetCrossClusterTasks(ctx, gp1)
 }
@@ -143,10 +155,18 @@ func (h *historyHandler) Health(ctx context.Context) (hp1 *types.HealthStatus, e
 	return h.wrapped.Health(ctx)
 }
 
+func (h *historyHandler) ListQueueTasks(ctx context.Context, lp1 *types.ListQueueTasksRequest) (lp2 *types.ListQueueTasksResponse, err error) {
+	return h.wrapped.ListQueueTasks(ctx, lp1)
+}
+
 func (h *historyHandler) MergeDLQMessages(ctx context.Context, mp1 *types.MergeDLQMessagesRequest) (mp2 *types.MergeDLQMessagesResponse, err error) {
 	return h.wrapped.MergeDLQMessages(ctx, mp1)
 }
 
+func (h *historyHandler) MigrateShardExecutions(ctx context.Context, mp1 *types.MigrateShardExecutionsRequest) (mp2 *types.MigrateShardExecutionsResponse, err error) {
+	return h.wrapped.MigrateShardExecutions(ctx, mp1)
+}
+
 func (h *historyHandler) NotifyFailoverMarkers(ctx context.Context, np1 *types.NotifyFailoverMarkersRequest) (err error) {
 	return h.wrapped.NotifyFailoverMarkers(ctx, np1)
 }
@@ -357,3 +377,11 @@ func (h *historyHandler) SyncShardStatus(ctx context.Context, sp1 *types.SyncSha
 func (h *historyHandler) TerminateWorkflowExecution(ctx context.Context, hp1 *types.HistoryTerminateWorkflowExecutionRequest) (err error) {
 	return h.wrapped.TerminateWorkflowExecution(ctx, hp1)
 }
+
+func (h *historyHandler) UpdateActivityOptions(ctx context.Context, hp1 *types.HistoryUpdateActivityOptionsRequest) (err error) {
+	return h.wrapped.UpdateActivityOptions(ctx, hp1)
+}
+
+func (h *historyHandler) VerifyWorkflowExecution(ctx context.Context, vp1 *types.VerifyWorkflowExecutionRequest) (vp2 *types.VerifyWorkflowExecutionResponse, err error) {
+	return h.wrapped.VerifyWorkflowExecution(ctx, vp1)
+}
@@ -40,6 +40,11 @@ func (g ThriftHandler) CloseShard(ctx context.Context, Request *shared.CloseShar
 	return thrift.FromError(err)
 }
 
+func (g ThriftHandler) DeleteWorkflowExecution(ctx context.Context, DeleteRequest *history.DeleteWorkflowExecutionRequest) (dp1 *shared.DeleteWorkflowExecutionResponse, err error) {
+	response, err := g.h.DeleteWorkflowExecution(ctx, thrift.ToHistoryDeleteWorkflowExecutionRequest(DeleteRequest))
+	return thrift.FromHistoryDeleteWorkflowExecutionResponse(response), thrift.FromError(err)
+}
+
 func (g ThriftHandler) DescribeHistoryHost(ctx context.Context, Request *shared.DescribeHistoryHostRequest) (dp1 *shared.DescribeHistoryHostResponse, err error) {
 	response, err := g.h.DescribeHistoryHost(ctx, thrift.ToHistoryDescribeHistoryHostRequest(Request))
 	return thrift.FromHistoryDescribeHistoryHostResponse(response), thrift.FromError(err)
@@ -55,11 +60,21 @@ func (g ThriftHandler) DescribeQueue(ctx context.Context, Request *shared.Descri
 	return thrift.FromHistoryDescribeQueueResponse(response), thrift.FromError(err)
 }
 
+func (g ThriftHandler) DescribeReplicationStatus(ctx context.Context, Request *history.DescribeReplicationStatusRequest) (dp1 *shared.DescribeReplicationStatusResponse, err error) {
+	response, err := g.h.DescribeReplicationStatus(ctx, thrift.ToHistoryDescribeReplicationStatusRequest(Request))
+	return thrift.FromHistoryDescribeReplicationStatusResponse(response), thrift.FromError(err)
+}
+
 func (g ThriftHandler) DescribeWorkflowExecution(ctx context.Context, DescribeRequest *history.DescribeWorkflowExecutionRequest) (dp1 *shared.DescribeWorkflowExecutionResponse, err error) {
 	response, err := g.h.DescribeWorkflowExecution(ctx, thrift.ToHistoryDescribeWorkflowExecutionRequest(DescribeRequest))
 	return thrift.FromHistoryDescribeWorkflowExecutionResponse(response), thrift.FromError(err)
 }
 
+func (g ThriftHandler) ExecuteQueueTask(ctx context.Context, Request *shared.ExecuteQueueTaskRequest) (err error) {
+	err = g.h.ExecuteQueueTask(ctx, thrift.ToHistoryExecuteQueueTaskRequest(Request))
+	return thrift.FromError(err)
+}
+
 func (g ThriftHandler) GetCrossClusterTasks(ctx context.Context, Request *shared.GetCrossClusterTasksRequest) (gp1 *shared.GetCrossClusterTasksResponse, err error) {
 	response, err := g.h.GetCrossClusterTasks(ctx, thrift.ToHistoryGetCrossClusterTasksRequest(Request))
 	return thrift.FromHistoryGetCrossClusterTasksResponse(response), thrift.FromError(err)
@@ -85,11 +100,21 @@ func (g ThriftHandler) GetReplicationMessages(ctx context.Context, Request *repl
 	return thrift.FromHistoryGetReplicationMessagesResponse(response), thrift.FromError(err)
 }
 
+func (g ThriftHandler) ListQueueTasks(ctx context.Context, Request *shared.ListQueueTasksRequest) (lp1 *shared.ListQueueTasksResponse, err error) {
+	response, err := g.h.ListQueueTasks(ctx, thrift.ToHistoryListQueueTasksRequest(Request))
+	return thrift.FromHistoryListQueueTasksResponse(response), thrift.FromError(err)
+}
+
 func (g ThriftHandler) MergeDLQMessages(ctx context.Context, Request *replicator.MergeDLQMessagesRequest) (mp1 *replicator.MergeDLQMessagesResponse, err error) {
 	response, err := g.h.MergeDLQMessages(ctx, thrift.ToHistoryMergeDLQMessagesRequest(Request))
 	return thrift.FromHistoryMergeDLQMessagesResponse(response), thrift.FromError(err)
 }
 
+func (g ThriftHandler) MigrateShardExecutions(ctx context.Context, Request *history.MigrateShardExecutionsRequest) (mp1 *history.MigrateShardExecutionsResponse, err error) {
+	response, err := g.h.MigrateShardExecutions(ctx, thrift.ToHistoryMigrateShardExecutionsRequest(Request))
+	return thrift.FromHistoryMigrateShardExecutionsResponse(response), thrift.FromError(err)
+}
+
 func (g ThriftHandler) NotifyFailoverMarkers(ctx context.Context, Request *history.NotifyFailoverMarkersRequest) (err error) {
 	err = g.h.NotifyFailoverMarkers(ctx, thrift.ToHistoryNotifyFailoverMarkersRequest(Request))
 	return thrift.FromError(err)
@@ -249,3 +274,13 @@ func (g ThriftHandler) TerminateWorkflowExecution(ctx context.Context, Terminate
 	err = g.h.TerminateWorkflowExecution(ctx, thrift.ToHistoryTerminateWorkflowExecutionRequest(TerminateRequest))
 	return thrift.FromError(err)
 }
+
+func (g ThriftHandler) UpdateActivityOptions(ctx context.Context, UpdateRequest *history.UpdateActivityOptionsRequest) (err error) {
+	err = g.h.UpdateActivityOptions(ctx, thrift.ToHistoryUpdateActivityOptionsRequest(UpdateRequest))
+	return thrift.FromError(err)
+}
+
+func (g ThriftHandler) VerifyWorkflowExecution(ctx context.Context, Request *history.VerifyWorkflowExecutionRequest) (vp1 *shared.VerifyWorkflowExecutionResponse, err error) {
+	response, err := g.h.VerifyWorkflowExecution(ctx, thrift.ToHistoryVerifyWorkflowExecutionRequest(Request))
+	return thrift.FromHistoryVerifyWorkflowExecutionResponse(response), thrift.FromError(err)
+}
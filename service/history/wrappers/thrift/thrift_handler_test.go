@@ -80,6 +80,11 @@ func TestThriftHandler(t *testing.T) {
 		assert.Equal(t, shared.DescribeWorkflowExecutionResponse{}, *resp)
 		assert.Equal(t, expectedErr, err)
 	})
+	t.Run("ExecuteQueueTask", func(t *testing.T) {
+		h.EXPECT().ExecuteQueueTask(ctx, &types.ExecuteQueueTaskRequest{}).Return(internalErr).Times(1)
+		err := th.ExecuteQueueTask(ctx, &shared.ExecuteQueueTaskRequest{})
+		assert.Equal(t, expectedErr, err)
+	})
 	t.Run("GetCrossClusterTasks", func(t *testing.T) {
 		h.EXPECT().GetCrossClusterTasks(ctx, &types.GetCrossClusterTasksRequest{}).Return(&types.GetCrossClusterTasksResponse{}, internalErr).Times(1)
 		resp, err := th.GetCrossClusterTasks(ctx, &shared.GetCrossClusterTasksRequest{})
@@ -114,12 +119,36 @@ func TestThriftHandler(t *testing.T) {
 		assert.Equal(t, replicator.GetReplicationMessagesResponse{}, *resp)
 		assert.Equal(t, expectedErr, err)
 	})
+	t.Run("ListQueueTasks", func(t *testing.T) {
+		h.EXPECT().ListQueueTasks(ctx, &types.ListQueueTasksRequest{}).Return(&types.ListQueueTasksResponse{}, internalErr).Times(1)
+		resp, err := th.ListQueueTasks(ctx, &shared.ListQueueTasksRequest{})
+		assert.Equal(t, shared.ListQueueTasksResponse{}, *resp)
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("VerifyWorkflowExecution", func(t *testing.T) {
+		h.EXPECT().VerifyWorkflowExecution(ctx, &types.VerifyWorkflowExecutionRequest{}).Return(&types.VerifyWorkflowExecutionResponse{}, internalErr).Times(1)
+		resp, err := th.VerifyWorkflowExecution(ctx, &hist.VerifyWorkflowExecutionRequest{})
+		assert.Equal(t, shared.VerifyWorkflowExecutionResponse{Repaired: common.BoolPtr(false)}, *resp)
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("DescribeReplicationStatus", func(t *testing.T) {
+		h.EXPECT().DescribeReplicationStatus(ctx, &types.DescribeReplicationStatusRequest{}).Return(&types.DescribeReplicationStatusResponse{}, internalErr).Times(1)
+		resp, err := th.DescribeReplicationStatus(ctx, &hist.DescribeReplicationStatusRequest{})
+		assert.Equal(t, shared.DescribeReplicationStatusResponse{}, *resp)
+		assert.Equal(t, expectedErr, err)
+	})
 	t.Run("MergeDLQMessages", func(t *testing.T) {
 		h.EXPECT().MergeDLQMessages(ctx, &types.MergeDLQMessagesRequest{}).Return(&types.MergeDLQMessagesResponse{}, internalErr).Times(1)
 		resp, err := th.MergeDLQMessages(ctx, &replicator.MergeDLQMessagesRequest{})
 		assert.Equal(t, replicator.MergeDLQMessagesResponse{}, *resp)
 		assert.Equal(t, expectedErr, err)
 	})
+	t.Run("MigrateShardExecutions", func(t *testing.T) {
+		h.EXPECT().MigrateShardExecutions(ctx, &types.MigrateShardExecutionsRequest{}).Return(&types.MigrateShardExecutionsResponse{}, internalErr).Times(1)
+		resp, err := th.MigrateShardExecutions(ctx, &hist.MigrateShardExecutionsRequest{})
+		assert.Equal(t, hist.MigrateShardExecutionsResponse{CopiedCount: common.Int32Ptr(0), DeletedCount: common.Int32Ptr(0)}, *resp)
+		assert.Equal(t, expectedErr, err)
+	})
 	t.Run("NotifyFailoverMarkers", func(t *testing.T) {
 		h.EXPECT().NotifyFailoverMarkers(ctx, &types.NotifyFailoverMarkersRequest{}).Return(internalErr).Times(1)
 		err := th.NotifyFailoverMarkers(ctx, &hist.NotifyFailoverMarkersRequest{})
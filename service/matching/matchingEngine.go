@@ -940,6 +940,7 @@ func (e *matchingEngineImpl) createPollForDecisionTaskResponse(
 		taskToken := &common.TaskToken{
 			DomainID:        task.event.DomainID,
 			WorkflowID:      task.event.WorkflowID,
+			WorkflowType:    historyResponse.WorkflowType.GetName(),
 			RunID:           task.event.RunID,
 			ScheduleID:      historyResponse.GetScheduledEventID(),
 			ScheduleAttempt: historyResponse.GetAttempt(),
@@ -1526,10 +1526,11 @@ func (s *matchingEngineSuite) assertPollTaskResponse(taskType int, param *testPa
 		s.EqualValues(param.WorkflowExecution, actual.WorkflowExecution)
 	} else {
 		token := &common.TaskToken{
-			DomainID:   param.DomainID,
-			WorkflowID: param.WorkflowExecution.WorkflowID,
-			RunID:      param.WorkflowExecution.RunID,
-			ScheduleID: scheduleID,
+			DomainID:     param.DomainID,
+			WorkflowID:   param.WorkflowExecution.WorkflowID,
+			WorkflowType: param.WorkflowType.Name,
+			RunID:        param.WorkflowExecution.RunID,
+			ScheduleID:   scheduleID,
 		}
 		s.EqualValues(token, actual.TaskToken)
 		s.EqualValues(param.WorkflowExecution, actual.WorkflowExecution)
@@ -0,0 +1,230 @@
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package autofailover
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	healthCheckTimeout  = 10 * time.Second
+	listDomainsPageSize = 200
+)
+
+// HealthCheckActivity checks the health of the remote clusters. A remote cluster is unhealthy if its frontend
+// does not answer or if replication tasks cannot be fetched from it. For each unhealthy cluster, the controllers
+// of the other remote clusters are queried to find out whether they see the cluster unhealthy as well.
+func HealthCheckActivity(ctx context.Context) (*HealthCheckActivityResult, error) {
+	controller := getController(ctx)
+	result := &HealthCheckActivityResult{
+		ClusterErrors:      make(map[string]string),
+		Confirmations:      make(map[string][]string),
+		CheckInterval:      controller.cfg.HealthCheckInterval(),
+		ConfirmationWindow: controller.cfg.ConfirmationWindow(),
+	}
+	for clusterName := range controller.cfg.ClusterMetadata.GetRemoteClusterInfo() {
+		result.ClusterErrors[clusterName] = ""
+		if err := controller.checkClusterHealth(ctx, clusterName); err != nil {
+			controller.logger.Warn("Remote cluster health check failed", tag.ClusterName(clusterName), tag.Error(err))
+			result.ClusterErrors[clusterName] = err.Error()
+		}
+	}
+
+	var peerStates map[string]*ControllerState
+	for clusterName, clusterError := range result.ClusterErrors {
+		if clusterError == "" {
+			continue
+		}
+		if peerStates == nil {
+			peerStates = controller.getPeerStates(ctx, result.ClusterErrors)
+		}
+		for peer, peerState := range peerStates {
+			if clusterState, ok := peerState.Clusters[clusterName]; ok && !clusterState.Healthy {
+				result.Confirmations[clusterName] = append(result.Confirmations[clusterName], peer)
+			}
+		}
+		sort.Strings(result.Confirmations[clusterName])
+	}
+	return result, nil
+}
+
+// FailoverActivity fails over the domains marked for automatic failover from the source cluster
+// to the current cluster, or only records the decisions in dry-run mode
+func FailoverActivity(ctx context.Context, params *FailoverActivityParams) (*FailoverActivityResult, error) {
+	controller := getController(ctx)
+	currentCluster := controller.cfg.ClusterMetadata.GetCurrentClusterName()
+	frontendClient := controller.resource.GetClientBean().GetFrontendClient()
+	dryRun := controller.cfg.DryRun()
+
+	domains, err := getAllDomains(ctx, frontendClient)
+	if err != nil {
+		return nil, err
+	}
+	result := &FailoverActivityResult{}
+	for _, domain := range domains {
+		if getFailoverTarget(domain, params.SourceCluster) != currentCluster {
+			continue
+		}
+		decision := &FailoverDecision{
+			Domain:        domain.GetDomainInfo().GetName(),
+			SourceCluster: params.SourceCluster,
+			TargetCluster: currentCluster,
+			DryRun:        dryRun,
+			Time:          time.Now().UnixNano(),
+		}
+		if !dryRun {
+			if _, err := frontendClient.UpdateDomain(ctx, &types.UpdateDomainRequest{
+				Name:              decision.Domain,
+				ActiveClusterName: common.StringPtr(currentCluster),
+				Data:              map[string]string{common.DomainDataKeyForFailoverReason: params.Reason},
+			}); err != nil {
+				decision.Error = err.Error()
+			}
+		}
+		controller.logger.Info("Auto failover decision",
+			tag.WorkflowDomainName(decision.Domain),
+			tag.PrevActiveCluster(decision.SourceCluster),
+			tag.ClusterName(decision.TargetCluster),
+			tag.Bool(decision.DryRun),
+			tag.Value(decision.Error),
+		)
+		result.Decisions = append(result.Decisions, decision)
+	}
+	return result, nil
+}
+
+func (c *Controller) checkClusterHealth(ctx context.Context, clusterName string) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	clientBean := c.resource.GetClientBean()
+	if _, err := clientBean.GetRemoteFrontendClient(clusterName).GetClusterInfo(ctx); err != nil && !common.IsServiceBusyError(err) {
+		return fmt.Errorf("frontend health check failed: %v", err)
+	}
+	if _, err := clientBean.GetRemoteAdminClient(clusterName).GetReplicationMessages(ctx, &types.GetReplicationMessagesRequest{
+		ClusterName: c.cfg.ClusterMetadata.GetCurrentClusterName(),
+	}); err != nil && !common.IsServiceBusyError(err) {
+		return fmt.Errorf("replication fetch failed: %v", err)
+	}
+	return nil
+}
+
+// getPeerStates queries the controller of the healthy remote clusters and returns their state by cluster name,
+// a cluster whose controller cannot be queried is skipped
+func (c *Controller) getPeerStates(ctx context.Context, clusterErrors map[string]string) map[string]*ControllerState {
+	peerStates := make(map[string]*ControllerState)
+	for peer, peerError := range clusterErrors {
+		if peerError != "" {
+			continue
+		}
+		peerState, err := c.getPeerState(ctx, peer)
+		if err != nil {
+			c.logger.Warn("Failed to query the auto failover controller of remote cluster", tag.ClusterName(peer), tag.Error(err))
+			continue
+		}
+		peerStates[peer] = peerState
+	}
+	return peerStates
+}
+
+func (c *Controller) getPeerState(ctx context.Context, peer string) (*ControllerState, error) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	resp, err := c.resource.GetClientBean().GetRemoteFrontendClient(peer).QueryWorkflow(ctx, &types.QueryWorkflowRequest{
+		Domain:    common.SystemLocalDomainName,
+		Execution: &types.WorkflowExecution{WorkflowID: WorkflowID},
+		Query:     &types.WorkflowQuery{QueryType: QueryType},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var state ControllerState
+	if err := json.Unmarshal(resp.GetQueryResult(), &state); err != nil {
+		return nil, fmt.Errorf("invalid controller state: %v", err)
+	}
+	return &state, nil
+}
+
+// getFailoverTarget returns the cluster a domain should be failed over to when its active cluster is the
+// unhealthy source cluster, or empty if the domain is not marked for automatic failover. Every cluster
+// computes the same target, the preferred cluster of the domain if set, otherwise the first of its
+// other clusters by name, so that a single cluster takes the domain over.
+func getFailoverTarget(domain *types.DescribeDomainResponse, sourceCluster string) string {
+	if !domain.GetIsGlobalDomain() ||
+		domain.GetDomainInfo().GetStatus() != types.DomainStatusRegistered ||
+		domain.ReplicationConfiguration.GetActiveClusterName() != sourceCluster {
+		return ""
+	}
+	domainData := domain.GetDomainInfo().GetData()
+	if strings.ToLower(strings.TrimSpace(domainData[common.DomainDataKeyForAutoFailover])) != "true" {
+		return ""
+	}
+
+	var candidates []string
+	for _, clusterConfig := range domain.ReplicationConfiguration.GetClusters() {
+		if clusterName := clusterConfig.GetClusterName(); clusterName != sourceCluster {
+			candidates = append(candidates, clusterName)
+		}
+	}
+	sort.Strings(candidates)
+	preferredCluster := domainData[common.DomainDataKeyForPreferredCluster]
+	for _, candidate := range candidates {
+		if candidate == preferredCluster {
+			return candidate
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	return candidates[0]
+}
+
+func getAllDomains(ctx context.Context, frontendClient frontend.Client) ([]*types.DescribeDomainResponse, error) {
+	var domains []*types.DescribeDomainResponse
+	var token []byte
+	for more := true; more; more = len(token) > 0 {
+		resp, err := frontendClient.ListDomains(ctx, &types.ListDomainsRequest{
+			PageSize:      listDomainsPageSize,
+			NextPageToken: token,
+		})
+		if err != nil {
+			return nil, err
+		}
+		domains = append(domains, resp.GetDomains()...)
+		token = resp.GetNextPageToken()
+	}
+	return domains, nil
+}
+
+func getController(ctx context.Context) *Controller {
+	return ctx.Value(autoFailoverContextKey).(*Controller)
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package autofailover
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/worker"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/dynamicconfig"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/resource"
+	"github.com/uber/cadence/common/types"
+)
+
+type activitiesTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+	activityEnv  *testsuite.TestActivityEnvironment
+	mockResource *resource.Test
+	controller   *Controller
+}
+
+func TestActivitiesTestSuite(t *testing.T) {
+	suite.Run(t, new(activitiesTestSuite))
+}
+
+func (s *activitiesTestSuite) SetupTest() {
+	controller := gomock.NewController(s.T())
+	s.mockResource = resource.NewTest(s.T(), controller, metrics.Worker)
+
+	s.controller = New(s.mockResource, &BootstrapParams{
+		Config: Config{
+			ClusterMetadata:     cluster.GetTestClusterMetadata(true),
+			DryRun:              dynamicconfig.GetBoolPropertyFn(false),
+			HealthCheckInterval: dynamicconfig.GetDurationPropertyFn(time.Minute),
+			ConfirmationWindow:  dynamicconfig.GetDurationPropertyFn(time.Hour),
+		},
+	})
+	s.activityEnv = s.NewTestActivityEnvironment()
+	s.activityEnv.RegisterActivityWithOptions(HealthCheckActivity, activity.RegisterOptions{Name: healthCheckActivityName})
+	s.activityEnv.RegisterActivityWithOptions(FailoverActivity, activity.RegisterOptions{Name: failoverActivityName})
+	s.activityEnv.SetTestTimeout(time.Second * 5)
+	s.activityEnv.SetWorkerOptions(worker.Options{
+		BackgroundActivityContext: context.WithValue(context.Background(), autoFailoverContextKey, s.controller),
+	})
+}
+
+func (s *activitiesTestSuite) TearDownTest() {
+	s.mockResource.Finish(s.T())
+}
+
+func (s *activitiesTestSuite) TestHealthCheckActivity_Healthy() {
+	s.mockResource.RemoteFrontendClient.EXPECT().GetClusterInfo(gomock.Any()).Return(&types.ClusterInfo{}, nil)
+	s.mockResource.RemoteAdminClient.EXPECT().GetReplicationMessages(gomock.Any(), &types.GetReplicationMessagesRequest{
+		ClusterName: cluster.TestCurrentClusterName,
+	}).Return(nil, &types.ServiceBusyError{})
+
+	actResult, err := s.activityEnv.ExecuteActivity(healthCheckActivityName)
+	s.NoError(err)
+	var result HealthCheckActivityResult
+	s.NoError(actResult.Get(&result))
+	s.Equal(HealthCheckActivityResult{
+		ClusterErrors:      map[string]string{cluster.TestAlternativeClusterName: ""},
+		Confirmations:      map[string][]string{},
+		CheckInterval:      time.Minute,
+		ConfirmationWindow: time.Hour,
+	}, result)
+}
+
+func (s *activitiesTestSuite) TestHealthCheckActivity_Unhealthy() {
+	s.mockResource.RemoteFrontendClient.EXPECT().GetClusterInfo(gomock.Any()).Return(nil, errors.New("connection refused"))
+
+	actResult, err := s.activityEnv.ExecuteActivity(healthCheckActivityName)
+	s.NoError(err)
+	var result HealthCheckActivityResult
+	s.NoError(actResult.Get(&result))
+	s.Contains(result.ClusterErrors[cluster.TestAlternativeClusterName], "connection refused")
+	// there is no other remote cluster to confirm the failure
+	s.Empty(result.Confirmations[cluster.TestAlternativeClusterName])
+}
+
+func (s *activitiesTestSuite) TestGetPeerStates() {
+	peerState := &ControllerState{Clusters: map[string]*ClusterState{"c1": {LastError: "unavailable"}}}
+	queryResult, err := json.Marshal(peerState)
+	s.NoError(err)
+	s.mockResource.RemoteFrontendClient.EXPECT().QueryWorkflow(gomock.Any(), &types.QueryWorkflowRequest{
+		Domain:    common.SystemLocalDomainName,
+		Execution: &types.WorkflowExecution{WorkflowID: WorkflowID},
+		Query:     &types.WorkflowQuery{QueryType: QueryType},
+	}).Return(&types.QueryWorkflowResponse{QueryResult: queryResult}, nil)
+
+	// unhealthy clusters are not queried
+	peerStates := s.controller.getPeerStates(context.Background(), map[string]string{"c1": "err", "c2": ""})
+	s.Equal(map[string]*ControllerState{"c2": peerState}, peerStates)
+}
+
+func (s *activitiesTestSuite) TestGetPeerStates_QueryFailed() {
+	s.mockResource.RemoteFrontendClient.EXPECT().QueryWorkflow(gomock.Any(), gomock.Any()).
+		Return(nil, &types.EntityNotExistsError{})
+
+	s.Empty(s.controller.getPeerStates(context.Background(), map[string]string{"c1": "err", "c2": ""}))
+}
+
+func (s *activitiesTestSuite) TestFailoverActivity() {
+	s.mockResource.FrontendClient.EXPECT().ListDomains(gomock.Any(), &types.ListDomainsRequest{PageSize: listDomainsPageSize}).
+		Return(&types.ListDomainsResponse{
+			Domains: []*types.DescribeDomainResponse{
+				testDomain("auto", "true", cluster.TestAlternativeClusterName),
+				testDomain("manual", "", cluster.TestAlternativeClusterName),
+				testDomain("active", "true", cluster.TestCurrentClusterName),
+			},
+			NextPageToken: []byte("token"),
+		}, nil)
+	s.mockResource.FrontendClient.EXPECT().ListDomains(gomock.Any(), &types.ListDomainsRequest{PageSize: listDomainsPageSize, NextPageToken: []byte("token")}).
+		Return(&types.ListDomainsResponse{
+			Domains: []*types.DescribeDomainResponse{testDomain("busy", "true", cluster.TestAlternativeClusterName)},
+		}, nil)
+	s.mockResource.FrontendClient.EXPECT().UpdateDomain(gomock.Any(), &types.UpdateDomainRequest{
+		Name:              "auto",
+		ActiveClusterName: common.StringPtr(cluster.TestCurrentClusterName),
+		Data:              map[string]string{common.DomainDataKeyForFailoverReason: "reason"},
+	}).Return(&types.UpdateDomainResponse{}, nil)
+	s.mockResource.FrontendClient.EXPECT().UpdateDomain(gomock.Any(), &types.UpdateDomainRequest{
+		Name:              "busy",
+		ActiveClusterName: common.StringPtr(cluster.TestCurrentClusterName),
+		Data:              map[string]string{common.DomainDataKeyForFailoverReason: "reason"},
+	}).Return(nil, &types.ServiceBusyError{Message: "too frequent"})
+
+	actResult, err := s.activityEnv.ExecuteActivity(failoverActivityName, &FailoverActivityParams{
+		SourceCluster: cluster.TestAlternativeClusterName,
+		Reason:        "reason",
+	})
+	s.NoError(err)
+	var result FailoverActivityResult
+	s.NoError(actResult.Get(&result))
+	s.Len(result.Decisions, 2)
+	s.Equal("auto", result.Decisions[0].Domain)
+	s.Equal(cluster.TestAlternativeClusterName, result.Decisions[0].SourceCluster)
+	s.Equal(cluster.TestCurrentClusterName, result.Decisions[0].TargetCluster)
+	s.Empty(result.Decisions[0].Error)
+	s.Equal("busy", result.Decisions[1].Domain)
+	s.Contains(result.Decisions[1].Error, "too frequent")
+}
+
+func (s *activitiesTestSuite) TestFailoverActivity_DryRun() {
+	s.controller.cfg.DryRun = dynamicconfig.GetBoolPropertyFn(true)
+	s.mockResource.FrontendClient.EXPECT().ListDomains(gomock.Any(), gomock.Any()).
+		Return(&types.ListDomainsResponse{
+			Domains: []*types.DescribeDomainResponse{testDomain("auto", "true", cluster.TestAlternativeClusterName)},
+		}, nil)
+
+	actResult, err := s.activityEnv.ExecuteActivity(failoverActivityName, &FailoverActivityParams{SourceCluster: cluster.TestAlternativeClusterName})
+	s.NoError(err)
+	var result FailoverActivityResult
+	s.NoError(actResult.Get(&result))
+	s.Len(result.Decisions, 1)
+	s.True(result.Decisions[0].DryRun)
+}
+
+func (s *activitiesTestSuite) TestGetFailoverTarget() {
+	domain := testDomain("domain", " TRUE ", "c1")
+	domain.ReplicationConfiguration.Clusters = []*types.ClusterReplicationConfiguration{
+		{ClusterName: "c1"}, {ClusterName: "c3"}, {ClusterName: "c2"},
+	}
+	s.Equal("c2", getFailoverTarget(domain, "c1"))
+	s.Empty(getFailoverTarget(domain, "c2"))
+
+	domain.DomainInfo.Data[common.DomainDataKeyForPreferredCluster] = "c3"
+	s.Equal("c3", getFailoverTarget(domain, "c1"))
+
+	domain.IsGlobalDomain = false
+	s.Empty(getFailoverTarget(domain, "c1"))
+}
+
+func testDomain(name string, autoFailover string, activeCluster string) *types.DescribeDomainResponse {
+	return &types.DescribeDomainResponse{
+		DomainInfo: &types.DomainInfo{
+			Name:   name,
+			Status: types.DomainStatusRegistered.Ptr(),
+			Data:   map[string]string{common.DomainDataKeyForAutoFailover: autoFailover},
+		},
+		ReplicationConfiguration: &types.DomainReplicationConfiguration{
+			ActiveClusterName: activeCluster,
+			Clusters: []*types.ClusterReplicationConfiguration{
+				{ClusterName: cluster.TestCurrentClusterName},
+				{ClusterName: cluster.TestAlternativeClusterName},
+			},
+		},
+		IsGlobalDomain: true,
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package autofailover
+
+import (
+	"context"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/uber-go/tally"
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/activity"
+	cclient "go.uber.org/cadence/client"
+	"go.uber.org/cadence/worker"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/dynamicconfig"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/resource"
+	"github.com/uber/cadence/service/worker/workercommon"
+)
+
+const (
+	controllerStartUpDelay = 10 * time.Second
+)
+
+type (
+	// Config defines the configuration for auto failover
+	Config struct {
+		// ClusterMetadata contains the metadata for this cluster
+		ClusterMetadata cluster.Metadata
+		// DryRun only records the failover decisions without failing over domains
+		DryRun dynamicconfig.BoolPropertyFn
+		// HealthCheckInterval is the interval between two health checks of the remote clusters
+		HealthCheckInterval dynamicconfig.DurationPropertyFn
+		// ConfirmationWindow is how long a remote cluster must stay unhealthy before its domains are failed over
+		ConfirmationWindow dynamicconfig.DurationPropertyFn
+	}
+
+	// BootstrapParams contains the set of params needed to bootstrap
+	// auto failover controller
+	BootstrapParams struct {
+		// Config contains the configuration for auto failover
+		Config Config
+		// TallyScope is an instance of tally metrics scope
+		TallyScope tally.Scope
+	}
+
+	// Controller of cadence worker service, it fails over the domains marked for automatic failover
+	// from the remote clusters which lost their health to the current cluster
+	Controller struct {
+		cfg        Config
+		resource   resource.Resource
+		tallyScope tally.Scope
+		logger     log.Logger
+		worker     worker.Worker
+	}
+)
+
+var (
+	controllerWorkflowStartOptions = cclient.StartWorkflowOptions{
+		ID:                           WorkflowID,
+		TaskList:                     TaskListName,
+		ExecutionStartToCloseTimeout: 24 * 365 * time.Hour,
+		WorkflowIDReusePolicy:        cclient.WorkflowIDReusePolicyAllowDuplicate,
+	}
+)
+
+// New returns a new instance of Controller
+func New(
+	resource resource.Resource,
+	params *BootstrapParams,
+) *Controller {
+	return &Controller{
+		cfg:        params.Config,
+		resource:   resource,
+		tallyScope: params.TallyScope,
+		logger:     resource.GetLogger().WithTags(tag.ComponentAutoFailover),
+	}
+}
+
+// Start starts the worker and the controller workflow
+func (c *Controller) Start() error {
+	ctx := context.WithValue(context.Background(), autoFailoverContextKey, c)
+	workerOpts := worker.Options{
+		MetricsScope:              c.tallyScope,
+		BackgroundActivityContext: ctx,
+		Tracer:                    opentracing.GlobalTracer(),
+	}
+	controllerWorker := worker.New(c.resource.GetSDKClient(), common.SystemLocalDomainName, TaskListName, workerOpts)
+	controllerWorker.RegisterWorkflowWithOptions(ControllerWorkflow, workflow.RegisterOptions{Name: WorkflowTypeName})
+	controllerWorker.RegisterActivityWithOptions(HealthCheckActivity, activity.RegisterOptions{Name: healthCheckActivityName})
+	controllerWorker.RegisterActivityWithOptions(FailoverActivity, activity.RegisterOptions{Name: failoverActivityName})
+	if err := controllerWorker.Start(); err != nil {
+		return err
+	}
+	c.worker = controllerWorker
+
+	go workercommon.StartWorkflowWithRetry(WorkflowTypeName, controllerStartUpDelay, c.resource, c.startWorkflow)
+	return nil
+}
+
+// Stop stops the worker
+func (c *Controller) Stop() {
+	c.worker.Stop()
+}
+
+func (c *Controller) startWorkflow(client cclient.Client) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	_, err := client.StartWorkflow(ctx, controllerWorkflowStartOptions, WorkflowTypeName, &ControllerParams{})
+	if cadence.IsWorkflowExecutionAlreadyStartedError(err) {
+		return nil
+	}
+	if err != nil {
+		c.logger.Error("Failed to start auto failover controller workflow", tag.Error(err))
+	}
+	return err
+}
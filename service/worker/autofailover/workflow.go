@@ -0,0 +1,300 @@
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package autofailover
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+	"go.uber.org/zap"
+)
+
+type (
+	contextKey string
+)
+
+const (
+	autoFailoverContextKey contextKey = "autoFailoverContext"
+	// TaskListName tasklist
+	TaskListName = "cadence-sys-auto-failover-tasklist"
+	// WorkflowTypeName workflow type name
+	WorkflowTypeName = "cadence-sys-auto-failover-workflow"
+	// WorkflowID is the ID of the controller workflow, there is one per cluster
+	WorkflowID              = "cadence-auto-failover-controller"
+	healthCheckActivityName = "cadence-sys-auto-failover-health-check-activity"
+	failoverActivityName    = "cadence-sys-auto-failover-failover-activity"
+
+	checksPerRun         = 100
+	maxRecordedDecisions = 100
+	defaultCheckInterval = 30 * time.Second
+
+	// QueryType for auto failover workflow
+	QueryType = "state"
+	// ConfirmFailoverSignal is the signal an operator sends with the name of an unhealthy remote cluster
+	// to confirm that its domains can be failed over without the agreement of the other clusters
+	ConfirmFailoverSignal = "confirm-failover"
+)
+
+type (
+	// ControllerParams is the arg for ControllerWorkflow
+	ControllerParams struct {
+		// State is the state of the controller, it is set when the workflow continues as new
+		State *ControllerState
+	}
+
+	// ControllerState is the state of the controller, it is returned by the QueryType query
+	ControllerState struct {
+		// Clusters is the health of the remote clusters by cluster name
+		Clusters map[string]*ClusterState
+		// Decisions contains the most recent failover decisions, at most maxRecordedDecisions
+		Decisions []*FailoverDecision
+	}
+
+	// ClusterState is the health of a remote cluster as seen by the controller
+	ClusterState struct {
+		Healthy   bool
+		LastError string
+		// UnhealthySince is when the cluster was first seen unhealthy, in unix nanoseconds, zero if healthy
+		UnhealthySince int64
+		// FailedOver is set once the domains of the cluster are failed over, until the cluster is healthy again
+		FailedOver bool
+		// ConfirmedBy contains the other clusters whose controller also sees the cluster unhealthy
+		ConfirmedBy []string
+		// OperatorConfirmed is set by the ConfirmFailoverSignal, until the cluster is healthy again
+		OperatorConfirmed bool
+	}
+
+	// FailoverDecision records the failover of a domain made by the controller
+	FailoverDecision struct {
+		Domain        string
+		SourceCluster string
+		TargetCluster string
+		// DryRun is set if the domain was not failed over because the controller runs in dry-run mode
+		DryRun bool
+		// Error is set if the failover of the domain failed
+		Error string
+		// Time is when the decision was made, in unix nanoseconds
+		Time int64
+	}
+
+	// HealthCheckActivityResult result for health check activity
+	HealthCheckActivityResult struct {
+		// ClusterErrors contains the health check error of each remote cluster, empty if the cluster is healthy
+		ClusterErrors map[string]string
+		// Confirmations contains, for each unhealthy remote cluster, the other remote clusters
+		// whose controller also sees it unhealthy
+		Confirmations      map[string][]string
+		CheckInterval      time.Duration
+		ConfirmationWindow time.Duration
+	}
+
+	// FailoverActivityParams params for failover activity
+	FailoverActivityParams struct {
+		SourceCluster string
+		// Reason is recorded in the failover history of the domains
+		Reason string
+	}
+
+	// FailoverActivityResult result for failover activity
+	FailoverActivityResult struct {
+		Decisions []*FailoverDecision
+	}
+)
+
+// ControllerWorkflow checks the health of the remote clusters periodically and fails over the domains
+// marked for automatic failover from a remote cluster which stays unhealthy for the confirmation window.
+// A failure seen only from the current cluster is not enough: the failover also needs a majority of the
+// other clusters to agree, counting the controllers of the remote clusters that see the cluster unhealthy,
+// or an operator confirmation through the ConfirmFailoverSignal.
+func ControllerWorkflow(ctx workflow.Context, params *ControllerParams) error {
+	state := params.State
+	if state == nil {
+		state = &ControllerState{}
+	}
+	if state.Clusters == nil {
+		state.Clusters = make(map[string]*ClusterState)
+	}
+
+	err := workflow.SetQueryHandler(ctx, QueryType, func(input []byte) (*ControllerState, error) {
+		return state, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger := workflow.GetLogger(ctx)
+	confirmCh := workflow.GetSignalChannel(ctx, ConfirmFailoverSignal)
+	healthCheckCtx := workflow.WithActivityOptions(ctx, getHealthCheckActivityOptions())
+	failoverCtx := workflow.WithActivityOptions(ctx, getFailoverActivityOptions())
+	for i := 0; i < checksPerRun; i++ {
+		var clusterName string
+		for confirmCh.ReceiveAsync(&clusterName) {
+			if !state.confirmCluster(clusterName) {
+				logger.Warn("Ignored failover confirmation of a cluster which is not unhealthy", zap.String("cluster", clusterName))
+			}
+		}
+
+		var health HealthCheckActivityResult
+		if err := workflow.ExecuteActivity(healthCheckCtx, healthCheckActivityName).Get(ctx, &health); err != nil {
+			logger.Error("Failed to check the health of remote clusters", zap.Error(err))
+			if err := workflow.Sleep(ctx, defaultCheckInterval); err != nil {
+				return err
+			}
+			continue
+		}
+
+		now := workflow.Now(ctx)
+		for _, clusterName := range state.updateClusters(health.ClusterErrors, health.Confirmations, now, health.ConfirmationWindow) {
+			var result FailoverActivityResult
+			params := &FailoverActivityParams{
+				SourceCluster: clusterName,
+				Reason:        state.Clusters[clusterName].failoverReason(clusterName),
+			}
+			if err := workflow.ExecuteActivity(failoverCtx, failoverActivityName, params).Get(ctx, &result); err != nil {
+				// the failover is retried at the next health check
+				logger.Error("Failed to fail over domains", zap.String("cluster", clusterName), zap.Error(err))
+				continue
+			}
+			state.recordDecisions(clusterName, result.Decisions)
+		}
+
+		checkInterval := health.CheckInterval
+		if checkInterval <= 0 {
+			checkInterval = defaultCheckInterval
+		}
+		if err := workflow.Sleep(ctx, checkInterval); err != nil {
+			return err
+		}
+	}
+	return workflow.NewContinueAsNewError(ctx, WorkflowTypeName, &ControllerParams{State: state})
+}
+
+// updateClusters updates the health of the remote clusters and returns the clusters which stay unhealthy
+// for the confirmation window, are confirmed unhealthy and whose domains are not failed over yet
+func (s *ControllerState) updateClusters(
+	clusterErrors map[string]string,
+	confirmations map[string][]string,
+	now time.Time,
+	confirmationWindow time.Duration,
+) []string {
+	// the clusters other than the unhealthy one are the current cluster and its remote peers
+	peers := len(clusterErrors) - 1
+	var toFailover []string
+	for clusterName, clusterError := range clusterErrors {
+		clusterState, ok := s.Clusters[clusterName]
+		if !ok {
+			clusterState = &ClusterState{Healthy: true}
+			s.Clusters[clusterName] = clusterState
+		}
+		if clusterError == "" {
+			*clusterState = ClusterState{Healthy: true}
+			continue
+		}
+		if clusterState.Healthy {
+			clusterState.Healthy = false
+			clusterState.UnhealthySince = now.UnixNano()
+		}
+		clusterState.LastError = clusterError
+		clusterState.ConfirmedBy = confirmations[clusterName]
+		if !clusterState.FailedOver &&
+			now.Sub(time.Unix(0, clusterState.UnhealthySince)) >= confirmationWindow &&
+			(clusterState.OperatorConfirmed || hasQuorum(len(clusterState.ConfirmedBy), peers)) {
+			toFailover = append(toFailover, clusterName)
+		}
+	}
+	sort.Strings(toFailover)
+	return toFailover
+}
+
+// confirmCluster marks an unhealthy cluster as confirmed by an operator, it returns false if the cluster is not unhealthy
+func (s *ControllerState) confirmCluster(clusterName string) bool {
+	clusterState, ok := s.Clusters[clusterName]
+	if !ok || clusterState.Healthy {
+		return false
+	}
+	clusterState.OperatorConfirmed = true
+	return true
+}
+
+// hasQuorum returns true if the current cluster and the confirming peers are a majority of the clusters
+// other than the unhealthy one. At least one peer has to confirm, the current cluster alone is never a quorum.
+func hasQuorum(confirmations int, peers int) bool {
+	return confirmations > 0 && (confirmations+1)*2 > peers+1
+}
+
+func (s *ClusterState) failoverReason(clusterName string) string {
+	reason := fmt.Sprintf("auto failover: cluster %v unhealthy since %v: %v",
+		clusterName, time.Unix(0, s.UnhealthySince).UTC().Format(time.RFC3339), s.LastError)
+	if len(s.ConfirmedBy) > 0 {
+		reason += fmt.Sprintf(", confirmed by %v", strings.Join(s.ConfirmedBy, ","))
+	}
+	if s.OperatorConfirmed {
+		reason += ", confirmed by operator"
+	}
+	return reason
+}
+
+// recordDecisions records the failover decisions made for a cluster. The cluster is marked as failed over
+// unless a domain failed to be failed over, so that it is retried at the next health check.
+func (s *ControllerState) recordDecisions(clusterName string, decisions []*FailoverDecision) {
+	failedOver := true
+	for _, decision := range decisions {
+		if decision.Error != "" {
+			failedOver = false
+		}
+	}
+	s.Clusters[clusterName].FailedOver = failedOver
+
+	s.Decisions = append(s.Decisions, decisions...)
+	if len(s.Decisions) > maxRecordedDecisions {
+		s.Decisions = s.Decisions[len(s.Decisions)-maxRecordedDecisions:]
+	}
+}
+
+func getHealthCheckActivityOptions() workflow.ActivityOptions {
+	return workflow.ActivityOptions{
+		ScheduleToStartTimeout: 1 * time.Minute,
+		StartToCloseTimeout:    1 * time.Minute,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:    2 * time.Second,
+			BackoffCoefficient: 2,
+			MaximumInterval:    30 * time.Second,
+			ExpirationInterval: 5 * time.Minute,
+		},
+	}
+}
+
+func getFailoverActivityOptions() workflow.ActivityOptions {
+	return workflow.ActivityOptions{
+		ScheduleToStartTimeout: 1 * time.Minute,
+		StartToCloseTimeout:    10 * time.Minute,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:    2 * time.Second,
+			BackoffCoefficient: 2,
+			MaximumInterval:    1 * time.Minute,
+			ExpirationInterval: 10 * time.Minute,
+		},
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package autofailover
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/workflow"
+)
+
+type controllerWorkflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+	workflowEnv *testsuite.TestWorkflowEnvironment
+}
+
+func TestControllerWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(controllerWorkflowTestSuite))
+}
+
+func (s *controllerWorkflowTestSuite) SetupTest() {
+	s.workflowEnv = s.NewTestWorkflowEnvironment()
+	s.workflowEnv.RegisterWorkflowWithOptions(ControllerWorkflow, workflow.RegisterOptions{Name: WorkflowTypeName})
+	s.workflowEnv.RegisterActivityWithOptions(HealthCheckActivity, activity.RegisterOptions{Name: healthCheckActivityName})
+	s.workflowEnv.RegisterActivityWithOptions(FailoverActivity, activity.RegisterOptions{Name: failoverActivityName})
+}
+
+func (s *controllerWorkflowTestSuite) TearDownTest() {
+	s.workflowEnv.AssertExpectations(s.T())
+}
+
+func (s *controllerWorkflowTestSuite) TestWorkflow_FailoverAfterConfirmationWindow() {
+	// the remote cluster is unhealthy for the first 5 checks, then healthy again, the peer cluster sees it unhealthy too
+	checks := 0
+	s.workflowEnv.OnActivity(healthCheckActivityName, mock.Anything).Return(func(context.Context) (*HealthCheckActivityResult, error) {
+		checks++
+		clusterError := ""
+		confirmations := map[string][]string{}
+		if checks <= 5 {
+			clusterError = "unavailable"
+			confirmations["remote"] = []string{"peer"}
+		}
+		return &HealthCheckActivityResult{
+			ClusterErrors:      map[string]string{"remote": clusterError, "peer": ""},
+			Confirmations:      confirmations,
+			CheckInterval:      time.Minute,
+			ConfirmationWindow: 3 * time.Minute,
+		}, nil
+	})
+	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, mock.MatchedBy(func(params *FailoverActivityParams) bool {
+		return params.SourceCluster == "remote" && strings.Contains(params.Reason, "confirmed by peer")
+	})).
+		Return(&FailoverActivityResult{Decisions: []*FailoverDecision{{Domain: "domain", SourceCluster: "remote", TargetCluster: "local"}}}, nil).
+		Once()
+
+	s.workflowEnv.ExecuteWorkflow(WorkflowTypeName, &ControllerParams{})
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	var continueAsNewErr *workflow.ContinueAsNewError
+	s.ErrorAs(s.workflowEnv.GetWorkflowError(), &continueAsNewErr)
+	s.Equal(checksPerRun, checks)
+
+	queryResult, err := s.workflowEnv.QueryWorkflow(QueryType)
+	s.NoError(err)
+	var state ControllerState
+	s.NoError(queryResult.Get(&state))
+	s.Equal(&ClusterState{Healthy: true}, state.Clusters["remote"])
+	s.Len(state.Decisions, 1)
+	s.Equal("domain", state.Decisions[0].Domain)
+}
+
+func (s *controllerWorkflowTestSuite) TestWorkflow_FailoverAfterOperatorConfirmation() {
+	// the remote cluster is only seen unhealthy from the current cluster
+	s.workflowEnv.OnActivity(healthCheckActivityName, mock.Anything).Return(&HealthCheckActivityResult{
+		ClusterErrors:      map[string]string{"remote": "unavailable"},
+		CheckInterval:      time.Minute,
+		ConfirmationWindow: 3 * time.Minute,
+	}, nil)
+	s.workflowEnv.RegisterDelayedCallback(func() {
+		queryResult, err := s.workflowEnv.QueryWorkflow(QueryType)
+		s.NoError(err)
+		var state ControllerState
+		s.NoError(queryResult.Get(&state))
+		s.False(state.Clusters["remote"].FailedOver)
+		s.Empty(state.Decisions)
+		s.workflowEnv.SignalWorkflow(ConfirmFailoverSignal, "remote")
+	}, 10*time.Minute)
+	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, mock.MatchedBy(func(params *FailoverActivityParams) bool {
+		return params.SourceCluster == "remote" && strings.Contains(params.Reason, "confirmed by operator")
+	})).
+		Return(&FailoverActivityResult{Decisions: []*FailoverDecision{{Domain: "domain", SourceCluster: "remote", TargetCluster: "local"}}}, nil).
+		Once()
+
+	s.workflowEnv.ExecuteWorkflow(WorkflowTypeName, &ControllerParams{})
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	var continueAsNewErr *workflow.ContinueAsNewError
+	s.ErrorAs(s.workflowEnv.GetWorkflowError(), &continueAsNewErr)
+
+	queryResult, err := s.workflowEnv.QueryWorkflow(QueryType)
+	s.NoError(err)
+	var state ControllerState
+	s.NoError(queryResult.Get(&state))
+	s.True(state.Clusters["remote"].FailedOver)
+	s.True(state.Clusters["remote"].OperatorConfirmed)
+	s.Len(state.Decisions, 1)
+}
+
+func (s *controllerWorkflowTestSuite) TestWorkflow_HealthCheckError() {
+	checks := 0
+	s.workflowEnv.OnActivity(healthCheckActivityName, mock.Anything).Return(func(context.Context) (*HealthCheckActivityResult, error) {
+		checks++
+		return nil, errors.New("mockErr")
+	})
+
+	s.workflowEnv.ExecuteWorkflow(WorkflowTypeName, &ControllerParams{})
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	var continueAsNewErr *workflow.ContinueAsNewError
+	s.ErrorAs(s.workflowEnv.GetWorkflowError(), &continueAsNewErr)
+	// the activity is retried before the workflow moves on to the next check
+	s.GreaterOrEqual(checks, checksPerRun)
+}
+
+func (s *controllerWorkflowTestSuite) TestUpdateClusters() {
+	state := &ControllerState{Clusters: make(map[string]*ClusterState)}
+	now := time.Unix(1000, 0)
+
+	clusterErrors := map[string]string{"c1": "err", "c2": "", "c3": ""}
+	confirmations := map[string][]string{"c1": {"c2"}}
+	s.Empty(state.updateClusters(clusterErrors, confirmations, now, time.Minute))
+	s.Equal(&ClusterState{LastError: "err", UnhealthySince: now.UnixNano(), ConfirmedBy: []string{"c2"}}, state.Clusters["c1"])
+	s.Equal(&ClusterState{Healthy: true}, state.Clusters["c2"])
+
+	// a failure without the agreement of the other clusters is not enough
+	clusterErrors = map[string]string{"c1": "err", "c2": "err", "c3": ""}
+	s.Equal([]string{"c1"}, state.updateClusters(clusterErrors, confirmations, now.Add(time.Minute), 0))
+	s.True(state.confirmCluster("c2"))
+	s.False(state.confirmCluster("c3"))
+	s.Equal([]string{"c1", "c2"}, state.updateClusters(clusterErrors, confirmations, now.Add(time.Minute), 0))
+
+	// failed over clusters are not returned until they are healthy again
+	state.recordDecisions("c1", []*FailoverDecision{{Domain: "d1"}})
+	state.recordDecisions("c2", []*FailoverDecision{{Domain: "d2", Error: "failed"}})
+	s.Equal([]string{"c2"}, state.updateClusters(clusterErrors, confirmations, now.Add(2*time.Minute), time.Minute))
+
+	s.Empty(state.updateClusters(map[string]string{"c1": "", "c2": "", "c3": ""}, nil, now.Add(3*time.Minute), time.Minute))
+	s.False(state.Clusters["c1"].FailedOver)
+	s.False(state.Clusters["c2"].OperatorConfirmed)
+	s.Len(state.Decisions, 2)
+}
+
+func (s *controllerWorkflowTestSuite) TestHasQuorum() {
+	// two clusters: the current cluster alone is not a quorum
+	s.False(hasQuorum(0, 0))
+	// three clusters: the current cluster and one peer
+	s.False(hasQuorum(0, 1))
+	s.True(hasQuorum(1, 1))
+	// five clusters: the current cluster and at least two of the three peers
+	s.False(hasQuorum(1, 3))
+	s.True(hasQuorum(2, 3))
+}
+
+func (s *controllerWorkflowTestSuite) TestRecordDecisions_Bounded() {
+	state := &ControllerState{Clusters: map[string]*ClusterState{"c1": {}}}
+	for i := 0; i < maxRecordedDecisions+10; i++ {
+		state.recordDecisions("c1", []*FailoverDecision{{Time: int64(i)}})
+	}
+	s.Len(state.Decisions, maxRecordedDecisions)
+	s.Equal(int64(10), state.Decisions[0].Time)
+}
@@ -30,6 +30,7 @@ import (
 
 	"github.com/uber/cadence/client"
 	"github.com/uber/cadence/common"
+	cbatcher "github.com/uber/cadence/common/batcher"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/log"
@@ -95,6 +96,6 @@ func (s *Batcher) Start() error {
 		BackgroundActivityContext: ctx,
 		Tracer:                    opentracing.GlobalTracer(),
 	}
-	batchWorker := worker.New(s.svcClient, common.BatcherLocalDomainName, BatcherTaskListName, workerOpts)
+	batchWorker := worker.New(s.svcClient, common.BatcherLocalDomainName, cbatcher.BatcherTaskListName, workerOpts)
 	return batchWorker.Start()
 }
@@ -33,7 +33,9 @@ import (
 
 	"github.com/uber/cadence/client/admin"
 	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/common"
+	cbatcher "github.com/uber/cadence/common/batcher"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/metrics"
@@ -42,130 +44,23 @@ import (
 
 type (
 	contextKey string
+
+	taskDetail struct {
+		execution types.WorkflowExecution
+		attempts  int
+		// passing along the current heartbeat details to make heartbeat within a task so that it won't timeout
+		hbd cbatcher.HeartBeatDetails
+	}
 )
 
 const (
 	batcherContextKey contextKey = "batcherContext"
-	// BatcherTaskListName is the tasklist name
-	BatcherTaskListName = "cadence-sys-batcher-tasklist"
-	// BatchWFTypeName is the workflow type
-	BatchWFTypeName   = "cadence-sys-batch-workflow"
-	batchActivityName = "cadence-sys-batch-activity"
-	// InfiniteDuration is a long duration(20 yrs) we used for infinite workflow running
-	InfiniteDuration = 20 * 365 * 24 * time.Hour
+	batchActivityName            = "cadence-sys-batch-activity"
 
 	_nonRetriableReason = "non-retriable-error"
 
-	// DefaultRPS is the default RPS
-	DefaultRPS = 50
-	// DefaultConcurrency is the default concurrency
-	DefaultConcurrency = 5
-	// DefaultPageSize is the default page size
-	DefaultPageSize = 1000
-	// DefaultAttemptsOnRetryableError is the default value for AttemptsOnRetryableError
-	DefaultAttemptsOnRetryableError = 50
-	// DefaultActivityHeartBeatTimeout is the default value for ActivityHeartBeatTimeout
-	DefaultActivityHeartBeatTimeout = time.Second * 10
-)
-
-const (
-	// BatchTypeTerminate is batch type for terminating workflows
-	BatchTypeTerminate = "terminate"
-	// BatchTypeCancel is the batch type for canceling workflows
-	BatchTypeCancel = "cancel"
-	// BatchTypeSignal is batch type for signaling workflows
-	BatchTypeSignal = "signal"
-	// BatchTypeReplicate is batch type for replicating workflows
-	BatchTypeReplicate = "replicate"
-)
-
-// AllBatchTypes is the batch types we supported
-var AllBatchTypes = []string{BatchTypeTerminate, BatchTypeCancel, BatchTypeSignal, BatchTypeReplicate}
-
-type (
-	// TerminateParams is the parameters for terminating workflow
-	TerminateParams struct {
-		// this indicates whether to terminate children workflow. Default to true.
-		// TODO https://github.com/uber/cadence/issues/2159
-		// Ideally default should be childPolicy of the workflow. But it's currently totally broken.
-		TerminateChildren *bool
-	}
-
-	// CancelParams is the parameters for canceling workflow
-	CancelParams struct {
-		// this indicates whether to cancel children workflow. Default to true.
-		// TODO https://github.com/uber/cadence/issues/2159
-		// Ideally default should be childPolicy of the workflow. But it's currently totally broken.
-		CancelChildren *bool
-	}
-
-	// SignalParams is the parameters for signaling workflow
-	SignalParams struct {
-		SignalName string
-		Input      string
-	}
-
-	// ReplicateParams is the parameters for replicating workflow
-	ReplicateParams struct {
-		SourceCluster string
-		TargetCluster string
-	}
-
-	// BatchParams is the parameters for batch operation workflow
-	BatchParams struct {
-		// Target domain to execute batch operation
-		DomainName string
-		// To get the target workflows for processing
-		Query string
-		// Reason for the operation
-		Reason string
-		// Supporting: reset,terminate
-		BatchType string
-
-		// Below are all optional
-		// TerminateParams is params only for BatchTypeTerminate
-		TerminateParams TerminateParams
-		// CancelParams is params only for BatchTypeCancel
-		CancelParams CancelParams
-		// SignalParams is params only for BatchTypeSignal
-		SignalParams SignalParams
-		// ReplicateParams is params only for BatchTypeReplicate
-		ReplicateParams ReplicateParams
-		// RPS of processing. Default to DefaultRPS
-		// TODO we will implement smarter way than this static rate limiter: https://github.com/uber/cadence/issues/2138
-		RPS int
-		// Number of goroutines running in parallel to process
-		Concurrency int
-		// Number of workflows processed in a batch
-		PageSize int
-		// Number of attempts for each workflow to process in case of retryable error before giving up
-		AttemptsOnRetryableError int
-		// timeout for activity heartbeat
-		ActivityHeartBeatTimeout time.Duration
-		// errors that will not retry which consumes AttemptsOnRetryableError. Default to empty
-		NonRetryableErrors []string
-		// internal conversion for NonRetryableErrors
-		_nonRetryableErrors map[string]struct{}
-	}
-
-	// HeartBeatDetails is the struct for heartbeat details
-	HeartBeatDetails struct {
-		PageToken   []byte
-		CurrentPage int
-		// This is just an estimation for visibility
-		TotalEstimate int64
-		// Number of workflows processed successfully
-		SuccessCount int
-		// Number of workflows that give up due to errors.
-		ErrorCount int
-	}
-
-	taskDetail struct {
-		execution types.WorkflowExecution
-		attempts  int
-		// passing along the current heartbeat details to make heartbeat within a task so that it won't timeout
-		hbd HeartBeatDetails
-	}
+	// maxDeletedChildWorkflows is the max number of descendants deleted together with a workflow
+	maxDeletedChildWorkflows = 1000
 )
 
 var (
@@ -173,37 +68,37 @@ var (
 		InitialInterval:          10 * time.Second,
 		BackoffCoefficient:       1.7,
 		MaximumInterval:          5 * time.Minute,
-		ExpirationInterval:       InfiniteDuration,
+		ExpirationInterval:       cbatcher.InfiniteDuration,
 		NonRetriableErrorReasons: []string{_nonRetriableReason},
 	}
 
 	batchActivityOptions = workflow.ActivityOptions{
 		ScheduleToStartTimeout: 5 * time.Minute,
-		StartToCloseTimeout:    InfiniteDuration,
+		StartToCloseTimeout:    cbatcher.InfiniteDuration,
 		RetryPolicy:            &batchActivityRetryPolicy,
 	}
 )
 
 func init() {
-	workflow.RegisterWithOptions(BatchWorkflow, workflow.RegisterOptions{Name: BatchWFTypeName})
+	workflow.RegisterWithOptions(BatchWorkflow, workflow.RegisterOptions{Name: cbatcher.BatchWFTypeName})
 	activity.RegisterWithOptions(BatchActivity, activity.RegisterOptions{Name: batchActivityName})
 }
 
 // BatchWorkflow is the workflow that runs a batch job of resetting workflows
-func BatchWorkflow(ctx workflow.Context, batchParams BatchParams) (HeartBeatDetails, error) {
+func BatchWorkflow(ctx workflow.Context, batchParams cbatcher.BatchParams) (cbatcher.HeartBeatDetails, error) {
 	batchParams = setDefaultParams(batchParams)
 	err := validateParams(batchParams)
 	if err != nil {
-		return HeartBeatDetails{}, err
+		return cbatcher.HeartBeatDetails{}, err
 	}
 	batchActivityOptions.HeartbeatTimeout = batchParams.ActivityHeartBeatTimeout
 	opt := workflow.WithActivityOptions(ctx, batchActivityOptions)
-	var result HeartBeatDetails
+	var result cbatcher.HeartBeatDetails
 	err = workflow.ExecuteActivity(opt, batchActivityName, batchParams).Get(ctx, &result)
 	return result, err
 }
 
-func validateParams(params BatchParams) error {
+func validateParams(params cbatcher.BatchParams) error {
 	if params.BatchType == "" ||
 		params.Reason == "" ||
 		params.DomainName == "" ||
@@ -211,12 +106,12 @@ func validateParams(params BatchParams) error {
 		return fmt.Errorf("must provide required parameters: BatchType/Reason/DomainName/Query")
 	}
 	switch params.BatchType {
-	case BatchTypeSignal:
+	case cbatcher.BatchTypeSignal:
 		if params.SignalParams.SignalName == "" {
 			return fmt.Errorf("must provide signal name")
 		}
 		return nil
-	case BatchTypeReplicate:
+	case cbatcher.BatchTypeReplicate:
 		if params.ReplicateParams.SourceCluster == "" {
 			return fmt.Errorf("must provide source cluster")
 		}
@@ -224,36 +119,37 @@ func validateParams(params BatchParams) error {
 			return fmt.Errorf("must provide target cluster")
 		}
 		return nil
-	case BatchTypeCancel:
+	case cbatcher.BatchTypeUpdateActivityOptions:
+		if params.UpdateActivityOptionsParams.ActivityID == "" {
+			return fmt.Errorf("must provide activity ID")
+		}
+		return nil
+	case cbatcher.BatchTypeDelete:
+		fallthrough
+	case cbatcher.BatchTypeCancel:
 		fallthrough
-	case BatchTypeTerminate:
+	case cbatcher.BatchTypeTerminate:
 		return nil
 	default:
 		return fmt.Errorf("not supported batch type: %v", params.BatchType)
 	}
 }
 
-func setDefaultParams(params BatchParams) BatchParams {
+func setDefaultParams(params cbatcher.BatchParams) cbatcher.BatchParams {
 	if params.RPS <= 0 {
-		params.RPS = DefaultRPS
+		params.RPS = cbatcher.DefaultRPS
 	}
 	if params.Concurrency <= 0 {
-		params.Concurrency = DefaultConcurrency
+		params.Concurrency = cbatcher.DefaultConcurrency
 	}
 	if params.PageSize <= 0 {
-		params.PageSize = DefaultPageSize
+		params.PageSize = cbatcher.DefaultPageSize
 	}
 	if params.AttemptsOnRetryableError <= 0 {
-		params.AttemptsOnRetryableError = DefaultAttemptsOnRetryableError
+		params.AttemptsOnRetryableError = cbatcher.DefaultAttemptsOnRetryableError
 	}
 	if params.ActivityHeartBeatTimeout <= 0 {
-		params.ActivityHeartBeatTimeout = DefaultActivityHeartBeatTimeout
-	}
-	if len(params.NonRetryableErrors) > 0 {
-		params._nonRetryableErrors = make(map[string]struct{}, len(params.NonRetryableErrors))
-		for _, estr := range params.NonRetryableErrors {
-			params._nonRetryableErrors[estr] = struct{}{}
-		}
+		params.ActivityHeartBeatTimeout = cbatcher.DefaultActivityHeartBeatTimeout
 	}
 	if params.TerminateParams.TerminateChildren == nil {
 		params.TerminateParams.TerminateChildren = common.BoolPtr(true)
@@ -262,26 +158,30 @@ func setDefaultParams(params BatchParams) BatchParams {
 }
 
 // BatchActivity is activity for processing batch operation
-func BatchActivity(ctx context.Context, batchParams BatchParams) (HeartBeatDetails, error) {
+func BatchActivity(ctx context.Context, batchParams cbatcher.BatchParams) (cbatcher.HeartBeatDetails, error) {
 	batcher := ctx.Value(batcherContextKey).(*Batcher)
 	client := batcher.clientBean.GetFrontendClient()
 	var adminClient admin.Client
-	if batchParams.BatchType == BatchTypeReplicate {
+	if batchParams.BatchType == cbatcher.BatchTypeReplicate {
 		currentCluster := batcher.cfg.ClusterMetadata.GetCurrentClusterName()
 		if currentCluster != batchParams.ReplicateParams.SourceCluster {
-			return HeartBeatDetails{}, cadence.NewCustomError(_nonRetriableReason, fmt.Sprintf("the activity must run in the source cluster, current cluster is %s", currentCluster))
+			return cbatcher.HeartBeatDetails{}, cadence.NewCustomError(_nonRetriableReason, fmt.Sprintf("the activity must run in the source cluster, current cluster is %s", currentCluster))
 		}
 		adminClient = batcher.clientBean.GetRemoteAdminClient(batchParams.ReplicateParams.TargetCluster)
 	}
+	var historyClient history.Client
+	if batchParams.BatchType == cbatcher.BatchTypeDelete || batchParams.BatchType == cbatcher.BatchTypeUpdateActivityOptions {
+		historyClient = batcher.clientBean.GetHistoryClient()
+	}
 
 	domainResp, err := client.DescribeDomain(ctx, &types.DescribeDomainRequest{
 		Name: &batchParams.DomainName,
 	})
 	if err != nil {
-		return HeartBeatDetails{}, err
+		return cbatcher.HeartBeatDetails{}, err
 	}
 	domainID := domainResp.GetDomainInfo().GetUUID()
-	hbd := HeartBeatDetails{}
+	hbd := cbatcher.HeartBeatDetails{}
 	startOver := true
 	if activity.HasHeartbeatDetails(ctx) {
 		if err := activity.GetHeartbeatDetails(ctx, &hbd); err == nil {
@@ -299,7 +199,7 @@ func BatchActivity(ctx context.Context, batchParams BatchParams) (HeartBeatDetai
 			Query:  batchParams.Query,
 		})
 		if err != nil {
-			return HeartBeatDetails{}, err
+			return cbatcher.HeartBeatDetails{}, err
 		}
 		hbd.TotalEstimate = resp.GetCount()
 	}
@@ -307,7 +207,7 @@ func BatchActivity(ctx context.Context, batchParams BatchParams) (HeartBeatDetai
 	taskCh := make(chan taskDetail, batchParams.PageSize)
 	respCh := make(chan error, batchParams.PageSize)
 	for i := 0; i < batchParams.Concurrency; i++ {
-		go startTaskProcessor(ctx, batchParams, domainID, taskCh, respCh, rateLimiter, client, adminClient)
+		go startTaskProcessor(ctx, batchParams, domainID, taskCh, respCh, rateLimiter, client, adminClient, historyClient)
 	}
 
 	for {
@@ -321,7 +221,7 @@ func BatchActivity(ctx context.Context, batchParams BatchParams) (HeartBeatDetai
 			Query:         batchParams.Query,
 		})
 		if err != nil {
-			return HeartBeatDetails{}, err
+			return cbatcher.HeartBeatDetails{}, err
 		}
 		batchCount := len(resp.Executions)
 		if batchCount <= 0 {
@@ -353,7 +253,7 @@ func BatchActivity(ctx context.Context, batchParams BatchParams) (HeartBeatDetai
 					break Loop
 				}
 			case <-ctx.Done():
-				return HeartBeatDetails{}, ctx.Err()
+				return cbatcher.HeartBeatDetails{}, ctx.Err()
 			}
 		}
 
@@ -373,15 +273,20 @@ func BatchActivity(ctx context.Context, batchParams BatchParams) (HeartBeatDetai
 
 func startTaskProcessor(
 	ctx context.Context,
-	batchParams BatchParams,
+	batchParams cbatcher.BatchParams,
 	domainID string,
 	taskCh chan taskDetail,
 	respCh chan error,
 	limiter *rate.Limiter,
 	client frontend.Client,
 	adminClient admin.Client,
+	historyClient history.Client,
 ) {
 	batcher := ctx.Value(batcherContextKey).(*Batcher)
+	nonRetryableErrors := make(map[string]struct{}, len(batchParams.NonRetryableErrors))
+	for _, estr := range batchParams.NonRetryableErrors {
+		nonRetryableErrors[estr] = struct{}{}
+	}
 	for {
 		select {
 		case <-ctx.Done():
@@ -394,7 +299,7 @@ func startTaskProcessor(
 			requestID := uuid.New().String()
 
 			switch batchParams.BatchType {
-			case BatchTypeTerminate:
+			case cbatcher.BatchTypeTerminate:
 				err = processTask(ctx, limiter, task, batchParams, client,
 					batchParams.TerminateParams.TerminateChildren,
 					func(workflowID, runID string) error {
@@ -405,10 +310,10 @@ func startTaskProcessor(
 								RunID:      runID,
 							},
 							Reason:   batchParams.Reason,
-							Identity: BatchWFTypeName,
+							Identity: cbatcher.BatchWFTypeName,
 						})
 					})
-			case BatchTypeCancel:
+			case cbatcher.BatchTypeCancel:
 				err = processTask(ctx, limiter, task, batchParams, client,
 					batchParams.CancelParams.CancelChildren,
 					func(workflowID, runID string) error {
@@ -418,11 +323,11 @@ func startTaskProcessor(
 								WorkflowID: workflowID,
 								RunID:      runID,
 							},
-							Identity:  BatchWFTypeName,
+							Identity:  cbatcher.BatchWFTypeName,
 							RequestID: requestID,
 						})
 					})
-			case BatchTypeSignal:
+			case cbatcher.BatchTypeSignal:
 				err = processTask(ctx, limiter, task, batchParams, client, common.BoolPtr(false),
 					func(workflowID, runID string) error {
 						return client.SignalWorkflowExecution(ctx, &types.SignalWorkflowExecutionRequest{
@@ -431,13 +336,23 @@ func startTaskProcessor(
 								WorkflowID: workflowID,
 								RunID:      runID,
 							},
-							Identity:   BatchWFTypeName,
-							RequestID:  requestID,
+							Identity:   cbatcher.BatchWFTypeName,
+							RequestID:  getSignalRequestID(batchParams.SignalParams.RequestID, workflowID, runID, requestID),
 							SignalName: batchParams.SignalParams.SignalName,
-							Input:      []byte(batchParams.SignalParams.Input),
+							Input:      batchParams.SignalParams.Input,
 						})
 					})
-			case BatchTypeReplicate:
+			case cbatcher.BatchTypeUpdateActivityOptions:
+				err = processTask(ctx, limiter, task, batchParams, client, common.BoolPtr(false),
+					func(workflowID, runID string) error {
+						return historyClient.UpdateActivityOptions(ctx, &types.HistoryUpdateActivityOptionsRequest{
+							DomainUUID:    domainID,
+							UpdateRequest: getUpdateActivityOptionsRequest(batchParams, workflowID, runID),
+						})
+					})
+			case cbatcher.BatchTypeDelete:
+				err = processDeleteTask(ctx, limiter, task, batchParams, domainID, historyClient)
+			case cbatcher.BatchTypeReplicate:
 				err = processTask(ctx, limiter, task, batchParams, client, common.BoolPtr(false),
 					func(workflowID, runID string) error {
 						return adminClient.ResendReplicationTasks(ctx, &types.ResendReplicationTasksRequest{
@@ -452,7 +367,7 @@ func startTaskProcessor(
 				batcher.metricsClient.IncCounter(metrics.BatcherScope, metrics.BatcherProcessorFailures)
 				getActivityLogger(ctx).Error("Failed to process batch operation task", tag.Error(err))
 
-				_, ok := batchParams._nonRetryableErrors[err.Error()]
+				_, ok := nonRetryableErrors[err.Error()]
 				if ok || task.attempts >= batchParams.AttemptsOnRetryableError {
 					respCh <- err
 				} else {
@@ -472,7 +387,7 @@ func processTask(
 	ctx context.Context,
 	limiter *rate.Limiter,
 	task taskDetail,
-	batchParams BatchParams,
+	batchParams cbatcher.BatchParams,
 	client frontend.Client,
 	applyOnChild *bool,
 	procFn func(string, string) error,
@@ -527,6 +442,77 @@ func processTask(
 	return nil
 }
 
+// processDeleteTask deletes the workflow of the task through the history service. When requested, its descendants
+// are deleted with it, up to maxDeletedChildWorkflows of them. Descendants in other domains are not deleted since
+// the batch operation is only authorized for its own domain.
+func processDeleteTask(
+	ctx context.Context,
+	limiter *rate.Limiter,
+	task taskDetail,
+	batchParams cbatcher.BatchParams,
+	domainID string,
+	historyClient history.Client,
+) error {
+	wfs := []types.WorkflowExecution{task.execution}
+	for deleted := 0; len(wfs) > 0; deleted++ {
+		if deleted > maxDeletedChildWorkflows {
+			return fmt.Errorf("workflow %v has more than %v descendants to delete", task.execution.GetWorkflowID(), maxDeletedChildWorkflows)
+		}
+		wf := wfs[0]
+		wfs = wfs[1:]
+
+		err := limiter.Wait(ctx)
+		if err != nil {
+			return err
+		}
+		activity.RecordHeartbeat(ctx, task.hbd)
+
+		resp, err := historyClient.DeleteWorkflowExecution(ctx, &types.HistoryDeleteWorkflowExecutionRequest{
+			DomainUUID: domainID,
+			DeleteRequest: &types.DeleteWorkflowExecutionRequest{
+				Domain: batchParams.DomainName,
+				WorkflowExecution: &types.WorkflowExecution{
+					WorkflowID: wf.WorkflowID,
+					RunID:      wf.RunID,
+				},
+				TerminateIfRunning:    batchParams.DeleteParams.TerminateIfRunning,
+				IncludeChildWorkflows: batchParams.DeleteParams.DeleteChildren,
+				Reason:                batchParams.Reason,
+				Identity:              cbatcher.BatchWFTypeName,
+			},
+		})
+		if err != nil {
+			// EntityNotExistsError means wf is already deleted
+			if _, ok := err.(*types.EntityNotExistsError); ok {
+				continue
+			}
+			return err
+		}
+
+		for _, child := range resp.GetChildWorkflowExecutions() {
+			if child.GetDomain() != batchParams.DomainName {
+				getActivityLogger(ctx).Warn("Skipped deleting child workflow in another domain",
+					tag.WorkflowDomainName(child.GetDomain()),
+					tag.WorkflowID(child.GetWorkflowExecution().GetWorkflowID()),
+					tag.WorkflowRunID(child.GetWorkflowExecution().GetRunID()))
+				continue
+			}
+			wfs = append(wfs, *child.GetWorkflowExecution())
+		}
+	}
+
+	return nil
+}
+
+// getSignalRequestID returns a request ID derived from the batch request ID and the target execution,
+// falling back to the given default when the batch has no request ID
+func getSignalRequestID(batchRequestID, workflowID, runID, defaultRequestID string) string {
+	if batchRequestID == "" {
+		return defaultRequestID
+	}
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(batchRequestID+"/"+workflowID+"/"+runID)).String()
+}
+
 func isDone(ctx context.Context) bool {
 	select {
 	case <-ctx.Done():
@@ -545,3 +531,25 @@ func getActivityLogger(ctx context.Context) log.Logger {
 		tag.WorkflowDomainName(wfInfo.WorkflowDomain),
 	)
 }
+
+func getUpdateActivityOptionsRequest(batchParams cbatcher.BatchParams, workflowID, runID string) *types.UpdateActivityOptionsRequest {
+	params := batchParams.UpdateActivityOptionsParams
+	request := &types.UpdateActivityOptionsRequest{
+		Domain: batchParams.DomainName,
+		WorkflowExecution: &types.WorkflowExecution{
+			WorkflowID: workflowID,
+			RunID:      runID,
+		},
+		ActivityID:                    params.ActivityID,
+		ScheduleToCloseTimeoutSeconds: params.ScheduleToCloseTimeoutSeconds,
+		ScheduleToStartTimeoutSeconds: params.ScheduleToStartTimeoutSeconds,
+		StartToCloseTimeoutSeconds:    params.StartToCloseTimeoutSeconds,
+		HeartbeatTimeoutSeconds:       params.HeartbeatTimeoutSeconds,
+		RetryPolicy:                   params.RetryPolicy,
+		Identity:                      cbatcher.BatchWFTypeName,
+	}
+	if params.TaskList != "" {
+		request.TaskList = &types.TaskList{Name: params.TaskList}
+	}
+	return request
+}
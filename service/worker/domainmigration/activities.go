@@ -0,0 +1,323 @@
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package domainmigration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/zap"
+
+	"github.com/uber/cadence/client/admin"
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	errReasonValidationFailed = "domain migration validation failed"
+
+	historyPageSize = 100
+	identity        = "cadence-domain-migration"
+)
+
+// cut-over outcomes of an open execution
+const (
+	// cutOverTerminated the execution is terminated in the source domain and copied without the termination
+	cutOverTerminated cutOverOutcome = iota
+	// cutOverClosed the execution closed on its own before it was terminated, it is copied as it is
+	cutOverClosed
+	// cutOverLinked the execution has a parent or pending children, it is left running in the source domain
+	cutOverLinked
+)
+
+type cutOverOutcome int
+
+// ValidateActivity checks that the executions of the source domain can be migrated into the target domain
+// and returns the ID of the target domain
+func ValidateActivity(ctx context.Context, params *ValidateActivityParams) (*ValidateActivityResult, error) {
+	migrator := getMigrator(ctx)
+	sourceCluster := migrator.getSourceCluster(params.SourceCluster)
+	if params.SourceDomain == params.TargetDomain && sourceCluster == migrator.cfg.ClusterMetadata.GetCurrentClusterName() {
+		return nil, cadence.NewCustomError(errReasonValidationFailed, "source and target domains are the same")
+	}
+
+	sourceDomain, err := describeDomain(ctx, migrator.clientBean.GetRemoteFrontendClient(sourceCluster), params.SourceDomain)
+	if err != nil {
+		return nil, err
+	}
+	targetDomain, err := describeDomain(ctx, migrator.clientBean.GetFrontendClient(), params.TargetDomain)
+	if err != nil {
+		return nil, err
+	}
+	if sourceDomain.GetIsGlobalDomain() || targetDomain.GetIsGlobalDomain() {
+		return nil, cadence.NewCustomError(errReasonValidationFailed, "global domains are not supported, they are migrated by replication")
+	}
+	if targetDomain.GetDomainInfo().GetStatus() != types.DomainStatusRegistered {
+		return nil, cadence.NewCustomError(errReasonValidationFailed, fmt.Sprintf("target domain %v is not registered", params.TargetDomain))
+	}
+	return &ValidateActivityResult{
+		TargetDomainID: targetDomain.GetDomainInfo().GetUUID(),
+	}, nil
+}
+
+// CopyExecutionsActivity copies a page of executions of the source domain into the target domain
+func CopyExecutionsActivity(ctx context.Context, params *CopyExecutionsActivityParams) (*CopyExecutionsActivityResult, error) {
+	logger := activity.GetLogger(ctx)
+	migrator := getMigrator(ctx)
+	sourceCluster := migrator.getSourceCluster(params.SourceCluster)
+	frontendClient := migrator.clientBean.GetRemoteFrontendClient(sourceCluster)
+	executions, nextPageToken, err := listExecutions(ctx, frontendClient, params)
+	if err != nil {
+		return nil, err
+	}
+
+	copier := &executionCopier{
+		adminClient:    migrator.clientBean.GetRemoteAdminClient(sourceCluster),
+		historyClient:  migrator.clientBean.GetHistoryClient(),
+		serializer:     persistence.NewPayloadSerializer(),
+		sourceDomain:   params.SourceDomain,
+		targetDomainID: params.TargetDomainID,
+	}
+	result := &CopyExecutionsActivityResult{
+		NextPageToken: nextPageToken,
+	}
+	for i, execution := range executions {
+		activity.RecordHeartbeat(ctx, i)
+
+		if !params.Open && execution.GetCloseTime() < params.EarliestCloseTime {
+			result.Skipped++
+			continue
+		}
+		var err error
+		if params.TerminateSource {
+			var outcome cutOverOutcome
+			outcome, err = cutOver(ctx, frontendClient, copier, params, execution.GetExecution())
+			if err == nil && outcome == cutOverLinked {
+				logger.Warn("Workflow execution has a parent or pending children, it is left running in the source domain",
+					zap.String("workflow-id", execution.GetExecution().GetWorkflowID()),
+					zap.String("run-id", execution.GetExecution().GetRunID()))
+				result.LinkedExecutions = append(result.LinkedExecutions, execution.GetExecution())
+				continue
+			}
+			if err == nil && outcome == cutOverTerminated {
+				result.Terminated++
+			}
+		} else {
+			err = copier.copy(ctx, execution.GetExecution(), false)
+		}
+
+		var entityNotExistsErr *types.EntityNotExistsError
+		switch {
+		case err == nil:
+			result.Copied++
+		case errors.As(err, &entityNotExistsErr):
+			// history of the execution is deleted after the retention
+			result.Skipped++
+		default:
+			logger.Error("Failed to migrate workflow execution",
+				zap.String("workflow-id", execution.GetExecution().GetWorkflowID()),
+				zap.String("run-id", execution.GetExecution().GetRunID()),
+				zap.Error(err))
+			result.FailedExecutions = append(result.FailedExecutions, execution.GetExecution())
+		}
+	}
+	return result, nil
+}
+
+func listExecutions(
+	ctx context.Context,
+	frontendClient frontend.Client,
+	params *CopyExecutionsActivityParams,
+) ([]*types.WorkflowExecutionInfo, []byte, error) {
+
+	latestStartTime := params.LatestStartTime
+	if latestStartTime == 0 {
+		latestStartTime = time.Now().UnixNano()
+	}
+	startTimeFilter := &types.StartTimeFilter{
+		EarliestTime: common.Int64Ptr(0),
+		LatestTime:   common.Int64Ptr(latestStartTime),
+	}
+	if params.Open {
+		resp, err := frontendClient.ListOpenWorkflowExecutions(ctx, &types.ListOpenWorkflowExecutionsRequest{
+			Domain:          params.SourceDomain,
+			MaximumPageSize: int32(params.PageSize),
+			NextPageToken:   params.NextPageToken,
+			StartTimeFilter: startTimeFilter,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.GetExecutions(), resp.NextPageToken, nil
+	}
+	resp, err := frontendClient.ListClosedWorkflowExecutions(ctx, &types.ListClosedWorkflowExecutionsRequest{
+		Domain:          params.SourceDomain,
+		MaximumPageSize: int32(params.PageSize),
+		NextPageToken:   params.NextPageToken,
+		StartTimeFilter: startTimeFilter,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.GetExecutions(), resp.NextPageToken, nil
+}
+
+// cutOver terminates an open execution in the source domain, which fences it, and then copies it without the
+// termination. Executions with a parent or pending children are neither terminated, since the termination would be
+// propagated to them in the source domain, nor copied, so that they do not run in both domains.
+func cutOver(
+	ctx context.Context,
+	sourceFrontendClient frontend.Client,
+	copier *executionCopier,
+	params *CopyExecutionsActivityParams,
+	execution *types.WorkflowExecution,
+) (cutOverOutcome, error) {
+
+	resp, err := sourceFrontendClient.DescribeWorkflowExecution(ctx, &types.DescribeWorkflowExecutionRequest{
+		Domain:    params.SourceDomain,
+		Execution: execution,
+	})
+	if err != nil {
+		return cutOverClosed, err
+	}
+	info := resp.GetWorkflowExecutionInfo()
+	if (info != nil && info.ParentExecution != nil) || len(resp.PendingChildren) > 0 {
+		return cutOverLinked, nil
+	}
+
+	err = sourceFrontendClient.TerminateWorkflowExecution(ctx, &types.TerminateWorkflowExecutionRequest{
+		Domain:            params.SourceDomain,
+		WorkflowExecution: execution,
+		Reason:            fmt.Sprintf("migrated to domain %v", params.TargetDomain),
+		Identity:          identity,
+	})
+	var entityNotExistsErr *types.EntityNotExistsError
+	var alreadyCompletedErr *types.WorkflowExecutionAlreadyCompletedError
+	if errors.As(err, &entityNotExistsErr) || errors.As(err, &alreadyCompletedErr) {
+		return cutOverClosed, copier.copy(ctx, execution, false)
+	}
+	if err != nil {
+		return cutOverClosed, err
+	}
+	return cutOverTerminated, copier.copy(ctx, execution, true)
+}
+
+func describeDomain(ctx context.Context, frontendClient frontend.Client, domain string) (*types.DescribeDomainResponse, error) {
+	resp, err := frontendClient.DescribeDomain(ctx, &types.DescribeDomainRequest{
+		Name: common.StringPtr(domain),
+	})
+	var entityNotExistsErr *types.EntityNotExistsError
+	if errors.As(err, &entityNotExistsErr) {
+		return nil, cadence.NewCustomError(errReasonValidationFailed, fmt.Sprintf("domain %v does not exist", domain))
+	}
+	return resp, err
+}
+
+// executionCopier copies the history of executions through the replication path of the history service, which
+// rebuilds the mutable state and the visibility records of the executions in the target domain
+type executionCopier struct {
+	adminClient    admin.Client
+	historyClient  history.Client
+	serializer     persistence.PayloadSerializer
+	sourceDomain   string
+	targetDomainID string
+}
+
+// copy copies the history of an execution, up to its termination if skipTermination is set
+func (c *executionCopier) copy(ctx context.Context, execution *types.WorkflowExecution, skipTermination bool) error {
+	var pageToken []byte
+	for {
+		resp, err := c.adminClient.GetWorkflowExecutionRawHistoryV2(ctx, &types.GetWorkflowExecutionRawHistoryV2Request{
+			Domain:          c.sourceDomain,
+			Execution:       execution,
+			MaximumPageSize: historyPageSize,
+			NextPageToken:   pageToken,
+		})
+		if err != nil {
+			return err
+		}
+		for _, batch := range resp.GetHistoryBatches() {
+			versionHistoryItems := resp.GetVersionHistory().GetItems()
+			if skipTermination {
+				events, err := c.serializer.DeserializeBatchEvents(persistence.NewDataBlobFromInternal(batch))
+				if err != nil {
+					return err
+				}
+				if len(events) == 0 || isTerminationBatch(events) {
+					return nil
+				}
+				versionHistoryItems = truncateVersionHistoryItems(versionHistoryItems, events[len(events)-1].ID)
+			}
+			err := c.historyClient.ReplicateEventsV2(ctx, &types.ReplicateEventsV2Request{
+				DomainUUID:          c.targetDomainID,
+				WorkflowExecution:   execution,
+				VersionHistoryItems: versionHistoryItems,
+				Events:              batch,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		pageToken = resp.NextPageToken
+		if len(pageToken) == 0 {
+			return nil
+		}
+	}
+}
+
+func isTerminationBatch(events []*types.HistoryEvent) bool {
+	for _, event := range events {
+		if event.GetEventType() == types.EventTypeWorkflowExecutionTerminated {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateVersionHistoryItems returns the version history items of the branch ending at lastEventID
+func truncateVersionHistoryItems(items []*types.VersionHistoryItem, lastEventID int64) []*types.VersionHistoryItem {
+	var truncated []*types.VersionHistoryItem
+	for _, item := range items {
+		if item.EventID >= lastEventID {
+			return append(truncated, &types.VersionHistoryItem{EventID: lastEventID, Version: item.Version})
+		}
+		truncated = append(truncated, item)
+	}
+	return truncated
+}
+
+func getMigrator(ctx context.Context) *Migrator {
+	return ctx.Value(domainMigrationContextKey).(*Migrator)
+}
+
+func (m *Migrator) getSourceCluster(sourceCluster string) string {
+	if len(sourceCluster) == 0 {
+		return m.cfg.ClusterMetadata.GetCurrentClusterName()
+	}
+	return sourceCluster
+}
@@ -0,0 +1,330 @@
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package domainmigration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/worker"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/resource"
+	"github.com/uber/cadence/common/types"
+)
+
+type activitiesTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+	activityEnv  *testsuite.TestActivityEnvironment
+	mockResource *resource.Test
+}
+
+func TestActivitiesTestSuite(t *testing.T) {
+	suite.Run(t, new(activitiesTestSuite))
+}
+
+func (s *activitiesTestSuite) SetupTest() {
+	controller := gomock.NewController(s.T())
+	s.mockResource = resource.NewTest(s.T(), controller, metrics.Worker)
+
+	migrator := &Migrator{
+		cfg: Config{
+			ClusterMetadata: cluster.GetTestClusterMetadata(true),
+		},
+		clientBean: s.mockResource.ClientBean,
+	}
+	s.activityEnv = s.NewTestActivityEnvironment()
+	s.activityEnv.RegisterActivityWithOptions(ValidateActivity, activity.RegisterOptions{Name: validateActivityName})
+	s.activityEnv.RegisterActivityWithOptions(CopyExecutionsActivity, activity.RegisterOptions{Name: copyExecutionsActivityName})
+	s.activityEnv.SetTestTimeout(time.Second * 5)
+	s.activityEnv.SetWorkerOptions(worker.Options{
+		BackgroundActivityContext: context.WithValue(context.Background(), domainMigrationContextKey, migrator),
+	})
+}
+
+func (s *activitiesTestSuite) TearDownTest() {
+	s.mockResource.Finish(s.T())
+}
+
+func (s *activitiesTestSuite) TestValidateActivity_Success() {
+	s.mockResource.RemoteFrontendClient.EXPECT().DescribeDomain(gomock.Any(), &types.DescribeDomainRequest{Name: common.StringPtr("source")}).
+		Return(&types.DescribeDomainResponse{DomainInfo: &types.DomainInfo{Name: "source"}}, nil)
+	s.mockResource.FrontendClient.EXPECT().DescribeDomain(gomock.Any(), &types.DescribeDomainRequest{Name: common.StringPtr("target")}).
+		Return(&types.DescribeDomainResponse{DomainInfo: &types.DomainInfo{Name: "target", UUID: "tid", Status: types.DomainStatusRegistered.Ptr()}}, nil)
+
+	actResult, err := s.activityEnv.ExecuteActivity(validateActivityName, &ValidateActivityParams{
+		SourceDomain:  "source",
+		SourceCluster: cluster.TestAlternativeClusterName,
+		TargetDomain:  "target",
+	})
+	s.NoError(err)
+	var result ValidateActivityResult
+	s.NoError(actResult.Get(&result))
+	s.Equal("tid", result.TargetDomainID)
+}
+
+func (s *activitiesTestSuite) TestValidateActivity_SameDomain() {
+	_, err := s.activityEnv.ExecuteActivity(validateActivityName, &ValidateActivityParams{
+		SourceDomain: "domain",
+		TargetDomain: "domain",
+	})
+	s.ErrorContains(err, errReasonValidationFailed)
+}
+
+func (s *activitiesTestSuite) TestValidateActivity_DomainNotExists() {
+	s.mockResource.RemoteFrontendClient.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).
+		Return(nil, &types.EntityNotExistsError{})
+
+	_, err := s.activityEnv.ExecuteActivity(validateActivityName, &ValidateActivityParams{
+		SourceDomain: "source",
+		TargetDomain: "target",
+	})
+	s.ErrorContains(err, errReasonValidationFailed)
+}
+
+func (s *activitiesTestSuite) TestValidateActivity_GlobalDomain() {
+	s.mockResource.RemoteFrontendClient.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).
+		Return(&types.DescribeDomainResponse{DomainInfo: &types.DomainInfo{Name: "source"}, IsGlobalDomain: true}, nil)
+	s.mockResource.FrontendClient.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).
+		Return(&types.DescribeDomainResponse{DomainInfo: &types.DomainInfo{Name: "target", UUID: "tid", Status: types.DomainStatusRegistered.Ptr()}}, nil)
+
+	_, err := s.activityEnv.ExecuteActivity(validateActivityName, &ValidateActivityParams{
+		SourceDomain: "source",
+		TargetDomain: "target",
+	})
+	s.ErrorContains(err, errReasonValidationFailed)
+}
+
+func (s *activitiesTestSuite) TestCopyExecutionsActivity_Closed() {
+	executions := []*types.WorkflowExecution{
+		{WorkflowID: "wid1", RunID: "rid1"},
+		{WorkflowID: "wid2", RunID: "rid2"},
+		{WorkflowID: "wid3", RunID: "rid3"},
+		{WorkflowID: "wid4", RunID: "rid4"},
+	}
+	s.mockResource.RemoteFrontendClient.EXPECT().ListClosedWorkflowExecutions(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, request *types.ListClosedWorkflowExecutionsRequest, _ ...interface{}) (*types.ListClosedWorkflowExecutionsResponse, error) {
+			s.Equal("source", request.Domain)
+			s.Equal(int32(10), request.MaximumPageSize)
+			s.Equal([]byte("token"), request.NextPageToken)
+			s.Equal(int64(100), request.StartTimeFilter.GetLatestTime())
+			return &types.ListClosedWorkflowExecutionsResponse{
+				Executions: []*types.WorkflowExecutionInfo{
+					{Execution: executions[0], CloseTime: common.Int64Ptr(50)},
+					{Execution: executions[1], CloseTime: common.Int64Ptr(60)},
+					{Execution: executions[2], CloseTime: common.Int64Ptr(70)},
+					{Execution: executions[3], CloseTime: common.Int64Ptr(10)},
+				},
+				NextPageToken: []byte("next-token"),
+			}, nil
+		})
+	versionHistory := &types.VersionHistory{Items: []*types.VersionHistoryItem{{EventID: 5, Version: common.EmptyVersion}}}
+	batches := []*types.DataBlob{{Data: []byte("batch1")}, {Data: []byte("batch2")}}
+	s.mockResource.RemoteAdminClient.EXPECT().GetWorkflowExecutionRawHistoryV2(gomock.Any(), &types.GetWorkflowExecutionRawHistoryV2Request{
+		Domain:          "source",
+		Execution:       executions[0],
+		MaximumPageSize: historyPageSize,
+	}).Return(&types.GetWorkflowExecutionRawHistoryV2Response{
+		HistoryBatches: batches[:1],
+		VersionHistory: versionHistory,
+		NextPageToken:  []byte("history-token"),
+	}, nil)
+	s.mockResource.RemoteAdminClient.EXPECT().GetWorkflowExecutionRawHistoryV2(gomock.Any(), &types.GetWorkflowExecutionRawHistoryV2Request{
+		Domain:          "source",
+		Execution:       executions[0],
+		MaximumPageSize: historyPageSize,
+		NextPageToken:   []byte("history-token"),
+	}).Return(&types.GetWorkflowExecutionRawHistoryV2Response{
+		HistoryBatches: batches[1:],
+		VersionHistory: versionHistory,
+	}, nil)
+	for _, batch := range batches {
+		s.mockResource.HistoryClient.EXPECT().ReplicateEventsV2(gomock.Any(), &types.ReplicateEventsV2Request{
+			DomainUUID:          "tid",
+			WorkflowExecution:   executions[0],
+			VersionHistoryItems: versionHistory.Items,
+			Events:              batch,
+		}).Return(nil)
+	}
+	s.mockResource.RemoteAdminClient.EXPECT().GetWorkflowExecutionRawHistoryV2(gomock.Any(), gomock.Any()).
+		Return(nil, &types.EntityNotExistsError{})
+	s.mockResource.RemoteAdminClient.EXPECT().GetWorkflowExecutionRawHistoryV2(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("mockErr"))
+
+	actResult, err := s.activityEnv.ExecuteActivity(copyExecutionsActivityName, &CopyExecutionsActivityParams{
+		SourceDomain:      "source",
+		TargetDomain:      "target",
+		TargetDomainID:    "tid",
+		LatestStartTime:   100,
+		EarliestCloseTime: 20,
+		PageSize:          10,
+		NextPageToken:     []byte("token"),
+	})
+	s.NoError(err)
+	var result CopyExecutionsActivityResult
+	s.NoError(actResult.Get(&result))
+	s.Equal(CopyExecutionsActivityResult{
+		NextPageToken:    []byte("next-token"),
+		Copied:           1,
+		Skipped:          2,
+		FailedExecutions: executions[2:3],
+	}, result)
+}
+
+func (s *activitiesTestSuite) TestCopyExecutionsActivity_TerminateSource() {
+	executions := []*types.WorkflowExecution{
+		{WorkflowID: "wid1", RunID: "rid1"},
+		{WorkflowID: "wid2", RunID: "rid2"},
+	}
+	s.mockResource.RemoteFrontendClient.EXPECT().ListOpenWorkflowExecutions(gomock.Any(), gomock.Any()).
+		Return(&types.ListOpenWorkflowExecutionsResponse{
+			Executions: []*types.WorkflowExecutionInfo{
+				{Execution: executions[0]},
+				{Execution: executions[1]},
+			},
+		}, nil)
+	s.mockResource.RemoteFrontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).
+		Return(&types.DescribeWorkflowExecutionResponse{WorkflowExecutionInfo: &types.WorkflowExecutionInfo{}}, nil).Times(2)
+	serializer := persistence.NewPayloadSerializer()
+	serializeBatch := func(events ...*types.HistoryEvent) *types.DataBlob {
+		blob, err := serializer.SerializeBatchEvents(events, common.EncodingTypeThriftRW)
+		s.NoError(err)
+		return blob.ToInternal()
+	}
+	batches := []*types.DataBlob{
+		serializeBatch(
+			&types.HistoryEvent{ID: 1, Version: 1, EventType: types.EventTypeWorkflowExecutionStarted.Ptr()},
+			&types.HistoryEvent{ID: 2, Version: 1, EventType: types.EventTypeDecisionTaskScheduled.Ptr()},
+		),
+		serializeBatch(&types.HistoryEvent{ID: 3, Version: 2, EventType: types.EventTypeDecisionTaskStarted.Ptr()}),
+		serializeBatch(
+			&types.HistoryEvent{ID: 4, Version: 2, EventType: types.EventTypeDecisionTaskFailed.Ptr()},
+			&types.HistoryEvent{ID: 5, Version: 2, EventType: types.EventTypeWorkflowExecutionTerminated.Ptr()},
+		),
+	}
+	versionHistory := &types.VersionHistory{Items: []*types.VersionHistoryItem{{EventID: 2, Version: 1}, {EventID: 5, Version: 2}}}
+	// the first execution is terminated before its last copy, the termination is not copied
+	gomock.InOrder(
+		s.mockResource.RemoteFrontendClient.EXPECT().TerminateWorkflowExecution(gomock.Any(), &types.TerminateWorkflowExecutionRequest{
+			Domain:            "source",
+			WorkflowExecution: executions[0],
+			Reason:            "migrated to domain target",
+			Identity:          identity,
+		}).Return(nil),
+		s.mockResource.RemoteAdminClient.EXPECT().GetWorkflowExecutionRawHistoryV2(gomock.Any(), gomock.Any()).
+			Return(&types.GetWorkflowExecutionRawHistoryV2Response{HistoryBatches: batches, VersionHistory: versionHistory}, nil),
+		s.mockResource.HistoryClient.EXPECT().ReplicateEventsV2(gomock.Any(), &types.ReplicateEventsV2Request{
+			DomainUUID:          "tid",
+			WorkflowExecution:   executions[0],
+			VersionHistoryItems: []*types.VersionHistoryItem{{EventID: 2, Version: 1}},
+			Events:              batches[0],
+		}).Return(nil),
+		s.mockResource.HistoryClient.EXPECT().ReplicateEventsV2(gomock.Any(), &types.ReplicateEventsV2Request{
+			DomainUUID:          "tid",
+			WorkflowExecution:   executions[0],
+			VersionHistoryItems: []*types.VersionHistoryItem{{EventID: 2, Version: 1}, {EventID: 3, Version: 2}},
+			Events:              batches[1],
+		}).Return(nil),
+	)
+	// the second execution completed on its own, so its full history is copied
+	s.mockResource.RemoteFrontendClient.EXPECT().TerminateWorkflowExecution(gomock.Any(), &types.TerminateWorkflowExecutionRequest{
+		Domain:            "source",
+		WorkflowExecution: executions[1],
+		Reason:            "migrated to domain target",
+		Identity:          identity,
+	}).Return(&types.EntityNotExistsError{})
+	s.mockResource.RemoteAdminClient.EXPECT().GetWorkflowExecutionRawHistoryV2(gomock.Any(), &types.GetWorkflowExecutionRawHistoryV2Request{
+		Domain:          "source",
+		Execution:       executions[1],
+		MaximumPageSize: historyPageSize,
+	}).Return(&types.GetWorkflowExecutionRawHistoryV2Response{}, nil)
+
+	actResult, err := s.activityEnv.ExecuteActivity(copyExecutionsActivityName, &CopyExecutionsActivityParams{
+		SourceDomain:    "source",
+		TargetDomain:    "target",
+		TargetDomainID:  "tid",
+		Open:            true,
+		TerminateSource: true,
+		PageSize:        10,
+	})
+	s.NoError(err)
+	var result CopyExecutionsActivityResult
+	s.NoError(actResult.Get(&result))
+	s.Equal(CopyExecutionsActivityResult{
+		Copied:     2,
+		Terminated: 1,
+	}, result)
+}
+
+func (s *activitiesTestSuite) TestCopyExecutionsActivity_TerminateSource_LinkedExecutions() {
+	executions := []*types.WorkflowExecution{
+		{WorkflowID: "child", RunID: "rid1"},
+		{WorkflowID: "parent", RunID: "rid2"},
+	}
+	s.mockResource.RemoteFrontendClient.EXPECT().ListOpenWorkflowExecutions(gomock.Any(), gomock.Any()).
+		Return(&types.ListOpenWorkflowExecutionsResponse{
+			Executions: []*types.WorkflowExecutionInfo{
+				{Execution: executions[0]},
+				{Execution: executions[1]},
+			},
+		}, nil)
+	s.mockResource.RemoteFrontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), &types.DescribeWorkflowExecutionRequest{
+		Domain:    "source",
+		Execution: executions[0],
+	}).Return(&types.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &types.WorkflowExecutionInfo{ParentExecution: executions[1]},
+	}, nil)
+	s.mockResource.RemoteFrontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), &types.DescribeWorkflowExecutionRequest{
+		Domain:    "source",
+		Execution: executions[1],
+	}).Return(&types.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &types.WorkflowExecutionInfo{},
+		PendingChildren:       []*types.PendingChildExecutionInfo{{WorkflowID: "child"}},
+	}, nil)
+	// the linked executions are left running in the source domain and are not copied
+	s.mockResource.RemoteFrontendClient.EXPECT().TerminateWorkflowExecution(gomock.Any(), gomock.Any()).Times(0)
+	s.mockResource.RemoteAdminClient.EXPECT().GetWorkflowExecutionRawHistoryV2(gomock.Any(), gomock.Any()).Times(0)
+
+	actResult, err := s.activityEnv.ExecuteActivity(copyExecutionsActivityName, &CopyExecutionsActivityParams{
+		SourceDomain:    "source",
+		TargetDomain:    "target",
+		TargetDomainID:  "tid",
+		Open:            true,
+		TerminateSource: true,
+		PageSize:        10,
+	})
+	s.NoError(err)
+	var result CopyExecutionsActivityResult
+	s.NoError(actResult.Get(&result))
+	s.Equal(CopyExecutionsActivityResult{
+		LinkedExecutions: executions,
+	}, result)
+}
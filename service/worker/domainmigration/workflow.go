@@ -0,0 +1,341 @@
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package domainmigration
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/uber/cadence/common/types"
+)
+
+type (
+	contextKey string
+)
+
+const (
+	domainMigrationContextKey contextKey = "domainMigrationContext"
+	// TaskListName tasklist
+	TaskListName = "cadence-sys-domain-migration-tasklist"
+	// WorkflowTypeName workflow type name
+	WorkflowTypeName           = "cadence-sys-domain-migration-workflow"
+	validateActivityName       = "cadence-sys-domain-migration-validate-activity"
+	copyExecutionsActivityName = "cadence-sys-domain-migration-copy-executions-activity"
+
+	defaultPageSize             = 100
+	defaultPagesPerRun          = 50
+	maxReportedFailedExecutions = 100
+
+	errMsgParamsIsNil         = "params is nil"
+	errMsgSourceDomainIsEmpty = "sourceDomain is empty"
+	errMsgTargetDomainIsEmpty = "targetDomain is empty"
+
+	// QueryType for migration workflow
+	QueryType = "state"
+	// CutOverSignal signal name to start the cut-over
+	CutOverSignal = "cut-over"
+
+	// migration phases, they are run in the order below
+
+	// PhaseCopyClosed copies the executions which are closed when the migration starts
+	PhaseCopyClosed = "copy-closed"
+	// PhaseWaitCutOver waits for the cut-over signal, unless the cut-over is automatic
+	PhaseWaitCutOver = "wait-cut-over"
+	// PhaseCutOverClosed copies again the executions closed after the migration started
+	PhaseCutOverClosed = "cut-over-closed"
+	// PhaseCutOverOpen terminates the open executions in the source domain and copies them a last time
+	PhaseCutOverOpen = "cut-over-open"
+	// PhaseCompleted the migration is completed
+	PhaseCompleted = "completed"
+)
+
+type (
+	// MigrationParams is the arg for MigrationWorkflow
+	MigrationParams struct {
+		// SourceDomain is the domain executions are copied from
+		SourceDomain string
+		// SourceCluster is the cluster of the source domain, the current cluster is used if empty
+		SourceCluster string
+		// TargetDomain is the domain executions are copied into, it must be a domain of the current cluster
+		TargetDomain string
+		// PageSize is the number of executions copied by each activity
+		PageSize int
+		// PagesPerRun is the number of pages copied before the workflow continues as new
+		PagesPerRun int
+		// AutoCutOver starts the cut-over once all executions are copied instead of waiting for CutOverSignal
+		AutoCutOver bool
+		// Checkpoint is the progress of the migration, it is set when the workflow continues as new
+		Checkpoint *Checkpoint
+	}
+
+	// Checkpoint is the progress of a migration, the workflow resumes from it after continuing as new
+	Checkpoint struct {
+		Phase          string
+		TargetDomainID string
+		// StartTime is when the migration started, in unix nanoseconds
+		StartTime     int64
+		NextPageToken []byte
+		// CutOverRequested is set once CutOverSignal is received
+		CutOverRequested     bool
+		CopiedExecutions     int
+		SkippedExecutions    int
+		TerminatedExecutions int
+		FailedExecutions     int
+		// FailedExecutionSamples contains at most maxReportedFailedExecutions executions failed to be migrated
+		FailedExecutionSamples []*types.WorkflowExecution
+		// LinkedExecutions is the number of open executions left running in the source domain and not copied at the
+		// cut-over because they have a parent or pending children
+		LinkedExecutions int
+		// LinkedExecutionSamples contains at most maxReportedFailedExecutions of these executions
+		LinkedExecutionSamples []*types.WorkflowExecution
+	}
+
+	// ValidateActivityParams params for activity
+	ValidateActivityParams struct {
+		SourceDomain  string
+		SourceCluster string
+		TargetDomain  string
+	}
+
+	// ValidateActivityResult result for validate activity
+	ValidateActivityResult struct {
+		TargetDomainID string
+	}
+
+	// CopyExecutionsActivityParams params for activity
+	CopyExecutionsActivityParams struct {
+		SourceDomain   string
+		SourceCluster  string
+		TargetDomain   string
+		TargetDomainID string
+		// Open copies open executions instead of closed ones, it is only set with TerminateSource
+		Open bool
+		// LatestStartTime skips executions started after it, in unix nanoseconds, no limit if zero
+		LatestStartTime int64
+		// EarliestCloseTime skips closed executions closed before it, in unix nanoseconds
+		EarliestCloseTime int64
+		// TerminateSource terminates the executions in the source domain before they are copied a last time
+		TerminateSource bool
+		PageSize        int
+		NextPageToken   []byte
+	}
+
+	// CopyExecutionsActivityResult result for copy executions activity
+	CopyExecutionsActivityResult struct {
+		NextPageToken    []byte
+		Copied           int
+		Skipped          int
+		Terminated       int
+		FailedExecutions []*types.WorkflowExecution
+		// LinkedExecutions are the open executions with a parent or pending children, which are neither terminated
+		// nor copied
+		LinkedExecutions []*types.WorkflowExecution
+	}
+
+	// QueryResult for migration progress
+	QueryResult struct {
+		SourceDomain  string
+		SourceCluster string
+		TargetDomain  string
+		Checkpoint    *Checkpoint
+	}
+)
+
+// MigrationWorkflow is the workflow that copies all executions, open and closed, of a domain into another domain.
+// Executions are copied page by page and the workflow continues as new with its checkpoint every PagesPerRun pages.
+// Closed executions are copied first. Open executions are only copied at the cut-over, which terminates them in the
+// source domain and then copies them, without the termination, so that no event can be added to the source after
+// the copy and an execution never runs in both domains. Workflow workers of the source domain should be stopped
+// before the cut-over.
+// Parent and child executions reference the domain of each other in their events, so open executions with a parent
+// or pending children are left running in the source domain, are not copied and are reported as linked executions;
+// they have to be drained or migrated manually. Closed executions are copied as they are and their child and parent
+// events still reference the source domain.
+func MigrationWorkflow(ctx workflow.Context, params *MigrationParams) (*Checkpoint, error) {
+	if err := validateParams(params); err != nil {
+		return nil, err
+	}
+
+	checkpoint := params.Checkpoint
+	if checkpoint == nil {
+		ao := workflow.WithActivityOptions(ctx, getValidateActivityOptions())
+		validateActivityParams := &ValidateActivityParams{
+			SourceDomain:  params.SourceDomain,
+			SourceCluster: params.SourceCluster,
+			TargetDomain:  params.TargetDomain,
+		}
+		var result ValidateActivityResult
+		if err := workflow.ExecuteActivity(ao, validateActivityName, validateActivityParams).Get(ctx, &result); err != nil {
+			return nil, err
+		}
+		checkpoint = &Checkpoint{
+			Phase:          PhaseCopyClosed,
+			TargetDomainID: result.TargetDomainID,
+			StartTime:      workflow.Now(ctx).UnixNano(),
+		}
+	}
+
+	err := workflow.SetQueryHandler(ctx, QueryType, func(input []byte) (*QueryResult, error) {
+		return &QueryResult{
+			SourceDomain:  params.SourceDomain,
+			SourceCluster: params.SourceCluster,
+			TargetDomain:  params.TargetDomain,
+			Checkpoint:    checkpoint,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cutOverCh := workflow.GetSignalChannel(ctx, CutOverSignal)
+	ao := workflow.WithActivityOptions(ctx, getCopyExecutionsActivityOptions())
+	pages := 0
+	for checkpoint.Phase != PhaseCompleted {
+		if cutOverCh.ReceiveAsync(nil) {
+			checkpoint.CutOverRequested = true
+		}
+		if checkpoint.Phase == PhaseWaitCutOver {
+			if !params.AutoCutOver && !checkpoint.CutOverRequested {
+				cutOverCh.Receive(ctx, nil)
+				checkpoint.CutOverRequested = true
+			}
+			checkpoint.Phase = PhaseCutOverClosed
+			continue
+		}
+
+		if pages >= params.PagesPerRun {
+			params.Checkpoint = checkpoint
+			return nil, workflow.NewContinueAsNewError(ctx, WorkflowTypeName, params)
+		}
+
+		copyParams := &CopyExecutionsActivityParams{
+			SourceDomain:   params.SourceDomain,
+			SourceCluster:  params.SourceCluster,
+			TargetDomain:   params.TargetDomain,
+			TargetDomainID: checkpoint.TargetDomainID,
+			PageSize:       params.PageSize,
+			NextPageToken:  checkpoint.NextPageToken,
+		}
+		switch checkpoint.Phase {
+		case PhaseCopyClosed:
+			copyParams.LatestStartTime = checkpoint.StartTime
+		case PhaseCutOverClosed:
+			copyParams.EarliestCloseTime = checkpoint.StartTime
+		case PhaseCutOverOpen:
+			copyParams.Open = true
+			copyParams.TerminateSource = true
+		default:
+			return nil, fmt.Errorf("unknown migration phase: %v", checkpoint.Phase)
+		}
+
+		var result CopyExecutionsActivityResult
+		if err := workflow.ExecuteActivity(ao, copyExecutionsActivityName, copyParams).Get(ctx, &result); err != nil {
+			return nil, err
+		}
+		pages++
+		checkpoint.update(&result)
+	}
+	return checkpoint, nil
+}
+
+func (c *Checkpoint) update(result *CopyExecutionsActivityResult) {
+	c.CopiedExecutions += result.Copied
+	c.SkippedExecutions += result.Skipped
+	c.TerminatedExecutions += result.Terminated
+	c.FailedExecutions += len(result.FailedExecutions)
+	for _, execution := range result.FailedExecutions {
+		if len(c.FailedExecutionSamples) >= maxReportedFailedExecutions {
+			break
+		}
+		c.FailedExecutionSamples = append(c.FailedExecutionSamples, execution)
+	}
+	c.LinkedExecutions += len(result.LinkedExecutions)
+	for _, execution := range result.LinkedExecutions {
+		if len(c.LinkedExecutionSamples) >= maxReportedFailedExecutions {
+			break
+		}
+		c.LinkedExecutionSamples = append(c.LinkedExecutionSamples, execution)
+	}
+
+	c.NextPageToken = result.NextPageToken
+	if len(c.NextPageToken) != 0 {
+		return
+	}
+	switch c.Phase {
+	case PhaseCopyClosed:
+		c.Phase = PhaseWaitCutOver
+	case PhaseCutOverClosed:
+		c.Phase = PhaseCutOverOpen
+	case PhaseCutOverOpen:
+		c.Phase = PhaseCompleted
+	}
+}
+
+func getValidateActivityOptions() workflow.ActivityOptions {
+	return workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Second,
+		StartToCloseTimeout:    20 * time.Second,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:          2 * time.Second,
+			BackoffCoefficient:       2,
+			MaximumInterval:          1 * time.Minute,
+			ExpirationInterval:       10 * time.Minute,
+			NonRetriableErrorReasons: []string{errReasonValidationFailed},
+		},
+	}
+}
+
+func getCopyExecutionsActivityOptions() workflow.ActivityOptions {
+	return workflow.ActivityOptions{
+		ScheduleToStartTimeout: 1 * time.Minute,
+		StartToCloseTimeout:    10 * time.Minute,
+		HeartbeatTimeout:       1 * time.Minute,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:    5 * time.Second,
+			BackoffCoefficient: 2,
+			MaximumInterval:    5 * time.Minute,
+			ExpirationInterval: 24 * time.Hour,
+		},
+	}
+}
+
+func validateParams(params *MigrationParams) error {
+	if params == nil {
+		return errors.New(errMsgParamsIsNil)
+	}
+	if len(params.SourceDomain) == 0 {
+		return errors.New(errMsgSourceDomainIsEmpty)
+	}
+	if len(params.TargetDomain) == 0 {
+		return errors.New(errMsgTargetDomainIsEmpty)
+	}
+	if params.PageSize <= 0 {
+		params.PageSize = defaultPageSize
+	}
+	if params.PagesPerRun <= 0 {
+		params.PagesPerRun = defaultPagesPerRun
+	}
+	return nil
+}
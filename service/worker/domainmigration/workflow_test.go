@@ -0,0 +1,200 @@
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package domainmigration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/uber/cadence/common/types"
+)
+
+type migrationWorkflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+	workflowEnv *testsuite.TestWorkflowEnvironment
+}
+
+func TestMigrationWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(migrationWorkflowTestSuite))
+}
+
+func (s *migrationWorkflowTestSuite) SetupTest() {
+	s.workflowEnv = s.NewTestWorkflowEnvironment()
+	s.workflowEnv.RegisterWorkflowWithOptions(MigrationWorkflow, workflow.RegisterOptions{Name: WorkflowTypeName})
+	s.workflowEnv.RegisterActivityWithOptions(ValidateActivity, activity.RegisterOptions{Name: validateActivityName})
+	s.workflowEnv.RegisterActivityWithOptions(CopyExecutionsActivity, activity.RegisterOptions{Name: copyExecutionsActivityName})
+}
+
+func (s *migrationWorkflowTestSuite) TearDownTest() {
+	s.workflowEnv.AssertExpectations(s.T())
+}
+
+func (s *migrationWorkflowTestSuite) TestValidateParams() {
+	s.Error(validateParams(nil))
+	s.Error(validateParams(&MigrationParams{TargetDomain: "t"}))
+	s.Error(validateParams(&MigrationParams{SourceDomain: "s"}))
+
+	params := &MigrationParams{SourceDomain: "s", TargetDomain: "t"}
+	s.NoError(validateParams(params))
+	s.Equal(defaultPageSize, params.PageSize)
+	s.Equal(defaultPagesPerRun, params.PagesPerRun)
+}
+
+func (s *migrationWorkflowTestSuite) TestWorkflow_InvalidParams() {
+	s.workflowEnv.ExecuteWorkflow(WorkflowTypeName, &MigrationParams{})
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	s.Error(s.workflowEnv.GetWorkflowError())
+}
+
+func (s *migrationWorkflowTestSuite) TestWorkflow_ValidateActivityError() {
+	s.workflowEnv.OnActivity(validateActivityName, mock.Anything, mock.Anything).Return(nil, errors.New("mockErr"))
+
+	s.workflowEnv.ExecuteWorkflow(WorkflowTypeName, &MigrationParams{SourceDomain: "s", TargetDomain: "t"})
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	s.Error(s.workflowEnv.GetWorkflowError())
+}
+
+func (s *migrationWorkflowTestSuite) TestWorkflow_AutoCutOver() {
+	s.workflowEnv.OnActivity(validateActivityName, mock.Anything, mock.Anything).Return(&ValidateActivityResult{TargetDomainID: "tid"}, nil)
+	s.mockCopyExecutions(func(p *CopyExecutionsActivityParams) bool {
+		return !p.Open && p.LatestStartTime != 0 && p.EarliestCloseTime == 0 && p.NextPageToken == nil
+	}, &CopyExecutionsActivityResult{Copied: 2, NextPageToken: []byte("token")})
+	s.mockCopyExecutions(func(p *CopyExecutionsActivityParams) bool {
+		return !p.Open && p.LatestStartTime != 0 && p.EarliestCloseTime == 0 && string(p.NextPageToken) == "token"
+	}, &CopyExecutionsActivityResult{Copied: 1, Skipped: 1, FailedExecutions: []*types.WorkflowExecution{{WorkflowID: "wid", RunID: "rid"}}})
+	s.mockCopyExecutions(func(p *CopyExecutionsActivityParams) bool {
+		return !p.Open && p.LatestStartTime == 0 && p.EarliestCloseTime != 0
+	}, &CopyExecutionsActivityResult{Copied: 1, Skipped: 3})
+	// open executions are only copied at the cut-over, once they are terminated in the source domain
+	s.mockCopyExecutions(func(p *CopyExecutionsActivityParams) bool {
+		return p.Open && p.TerminateSource && p.LatestStartTime == 0
+	}, &CopyExecutionsActivityResult{Copied: 2, Terminated: 2})
+
+	s.workflowEnv.ExecuteWorkflow(WorkflowTypeName, &MigrationParams{SourceDomain: "s", TargetDomain: "t", AutoCutOver: true})
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	var result Checkpoint
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Equal(PhaseCompleted, result.Phase)
+	s.Equal("tid", result.TargetDomainID)
+	s.Equal(6, result.CopiedExecutions)
+	s.Equal(4, result.SkippedExecutions)
+	s.Equal(2, result.TerminatedExecutions)
+	s.Equal(1, result.FailedExecutions)
+	s.Equal([]*types.WorkflowExecution{{WorkflowID: "wid", RunID: "rid"}}, result.FailedExecutionSamples)
+}
+
+func (s *migrationWorkflowTestSuite) TestWorkflow_WaitCutOverSignal() {
+	s.workflowEnv.OnActivity(validateActivityName, mock.Anything, mock.Anything).Return(&ValidateActivityResult{TargetDomainID: "tid"}, nil)
+	s.mockCopyExecutions(func(p *CopyExecutionsActivityParams) bool {
+		return !p.TerminateSource
+	}, &CopyExecutionsActivityResult{Copied: 1})
+	s.mockCopyExecutions(func(p *CopyExecutionsActivityParams) bool {
+		return p.TerminateSource
+	}, &CopyExecutionsActivityResult{Copied: 1, Terminated: 1})
+
+	s.workflowEnv.RegisterDelayedCallback(func() {
+		s.assertQueryPhase(PhaseWaitCutOver)
+		s.workflowEnv.SignalWorkflow(CutOverSignal, nil)
+	}, time.Hour)
+
+	s.workflowEnv.ExecuteWorkflow(WorkflowTypeName, &MigrationParams{SourceDomain: "s", TargetDomain: "t"})
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	var result Checkpoint
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Equal(PhaseCompleted, result.Phase)
+	s.True(result.CutOverRequested)
+	s.Equal(3, result.CopiedExecutions)
+	s.Equal(1, result.TerminatedExecutions)
+}
+
+func (s *migrationWorkflowTestSuite) TestWorkflow_ContinueAsNew() {
+	s.workflowEnv.OnActivity(validateActivityName, mock.Anything, mock.Anything).Return(&ValidateActivityResult{TargetDomainID: "tid"}, nil)
+	s.mockCopyExecutions(func(p *CopyExecutionsActivityParams) bool {
+		return true
+	}, &CopyExecutionsActivityResult{Copied: 1, NextPageToken: []byte("token")})
+
+	s.workflowEnv.ExecuteWorkflow(WorkflowTypeName, &MigrationParams{SourceDomain: "s", TargetDomain: "t", PagesPerRun: 2})
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	var continueAsNewErr *workflow.ContinueAsNewError
+	s.ErrorAs(s.workflowEnv.GetWorkflowError(), &continueAsNewErr)
+}
+
+func (s *migrationWorkflowTestSuite) TestWorkflow_ResumeFromCheckpoint() {
+	s.mockCopyExecutions(func(p *CopyExecutionsActivityParams) bool {
+		return p.Open && p.TerminateSource && p.TargetDomainID == "tid" && string(p.NextPageToken) == "token"
+	}, &CopyExecutionsActivityResult{Copied: 1, Terminated: 1})
+
+	params := &MigrationParams{
+		SourceDomain: "s",
+		TargetDomain: "t",
+		Checkpoint: &Checkpoint{
+			Phase:            PhaseCutOverOpen,
+			TargetDomainID:   "tid",
+			NextPageToken:    []byte("token"),
+			CopiedExecutions: 10,
+		},
+	}
+	s.workflowEnv.ExecuteWorkflow(WorkflowTypeName, params)
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	var result Checkpoint
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Equal(PhaseCompleted, result.Phase)
+	s.Equal(11, result.CopiedExecutions)
+	s.Equal(1, result.TerminatedExecutions)
+}
+
+func (s *migrationWorkflowTestSuite) TestCheckpointUpdate() {
+	checkpoint := &Checkpoint{Phase: PhaseCopyClosed}
+	var failed []*types.WorkflowExecution
+	for i := 0; i < maxReportedFailedExecutions+1; i++ {
+		failed = append(failed, &types.WorkflowExecution{WorkflowID: "wid"})
+	}
+	checkpoint.update(&CopyExecutionsActivityResult{FailedExecutions: failed, LinkedExecutions: failed, NextPageToken: []byte("token")})
+	s.Equal(PhaseCopyClosed, checkpoint.Phase)
+	s.Equal(maxReportedFailedExecutions+1, checkpoint.FailedExecutions)
+	s.Len(checkpoint.FailedExecutionSamples, maxReportedFailedExecutions)
+	s.Equal(maxReportedFailedExecutions+1, checkpoint.LinkedExecutions)
+	s.Len(checkpoint.LinkedExecutionSamples, maxReportedFailedExecutions)
+
+	checkpoint.update(&CopyExecutionsActivityResult{})
+	s.Equal(PhaseWaitCutOver, checkpoint.Phase)
+	s.Nil(checkpoint.NextPageToken)
+}
+
+func (s *migrationWorkflowTestSuite) mockCopyExecutions(match func(*CopyExecutionsActivityParams) bool, result *CopyExecutionsActivityResult) {
+	s.workflowEnv.OnActivity(copyExecutionsActivityName, mock.Anything, mock.MatchedBy(match)).Return(result, nil)
+}
+
+func (s *migrationWorkflowTestSuite) assertQueryPhase(expectedPhase string) {
+	queryResult, err := s.workflowEnv.QueryWorkflow(QueryType)
+	s.NoError(err)
+	var res QueryResult
+	s.NoError(queryResult.Get(&res))
+	s.Equal(expectedPhase, res.Checkpoint.Phase)
+}
@@ -106,6 +106,9 @@ type (
 		DrillWaitTime time.Duration
 		// GracefulFailoverTimeoutInSeconds
 		GracefulFailoverTimeoutInSeconds *int32
+		// MaxReplicationLagInSeconds skips the domains lagging more than it in replication to the target cluster,
+		// domains with replication lag are only logged if it is 0. It is not checked on the rollback of a drill
+		MaxReplicationLagInSeconds int
 	}
 
 	// FailoverResult is workflow result
@@ -126,8 +129,10 @@ type (
 	// FailoverActivityParams params for activity
 	FailoverActivityParams struct {
 		Domains                          []string
+		SourceCluster                    string
 		TargetCluster                    string
 		GracefulFailoverTimeoutInSeconds *int32
+		MaxReplicationLagInSeconds       int
 	}
 
 	// FailoverActivityResult result for failover activity
@@ -251,17 +256,23 @@ func failoverDomainsByBatch(
 	batchSize := params.BatchFailoverSize
 	times := totalNumOfDomains/batchSize + 1
 	ao := workflow.WithActivityOptions(ctx, getFailoverActivityOptions())
-	targetCluster := params.TargetCluster
+	sourceCluster, targetCluster := params.SourceCluster, params.TargetCluster
+	maxReplicationLagInSeconds := params.MaxReplicationLagInSeconds
 	if reverseFailover {
-		targetCluster = params.SourceCluster
+		sourceCluster, targetCluster = params.TargetCluster, params.SourceCluster
+		// the replication lag is only known by the source cluster, which is the target cluster of the
+		// workflow on rollback, so the lag is not checked to fail back the domains
+		maxReplicationLagInSeconds = 0
 	}
 	for i := 0; i < times; i++ {
 		pauseSignalHandler()
 
 		failoverActivityParams := &FailoverActivityParams{
 			Domains:                          domains[i*batchSize : common.MinInt((i+1)*batchSize, totalNumOfDomains)],
+			SourceCluster:                    sourceCluster,
 			TargetCluster:                    targetCluster,
 			GracefulFailoverTimeoutInSeconds: params.GracefulFailoverTimeoutInSeconds,
+			MaxReplicationLagInSeconds:       maxReplicationLagInSeconds,
 		}
 		var actResult FailoverActivityResult
 		err := workflow.ExecuteActivity(ao, FailoverActivity, failoverActivityParams).Get(ctx, &actResult)
@@ -450,6 +461,14 @@ func FailoverActivity(ctx context.Context, params *FailoverActivityParams) (*Fai
 	domains := params.Domains
 	var successDomains []string
 	var failedDomains []string
+	maxReplicationLag := time.Duration(params.MaxReplicationLagInSeconds) * time.Second
+	replicationStatus, err := getReplicationStatus(ctx, params.SourceCluster, params.TargetCluster)
+	if err != nil {
+		logger.Warn("Failed to get replication lag of domains", zap.Error(err))
+		if maxReplicationLag > 0 {
+			return &FailoverActivityResult{FailedDomains: domains}, nil
+		}
+	}
 	for _, domain := range domains {
 		// Check if poller exist
 		if err := validateTaskListPollerInfo(ctx, params.TargetCluster, domain); err != nil {
@@ -457,6 +476,17 @@ func FailoverActivity(ctx context.Context, params *FailoverActivityParams) (*Fai
 			failedDomains = append(failedDomains, domain)
 			continue
 		}
+		if domainStatus, ok := replicationStatus[domain]; ok {
+			lag := time.Duration(domainStatus.LagInMilliseconds) * time.Millisecond
+			if maxReplicationLag > 0 && lag > maxReplicationLag {
+				logger.Error("Domain replication lag is above the max replication lag, skipping failover",
+					zap.String("domain", domain), zap.Duration("lag", lag), zap.Int64("pending-tasks", domainStatus.PendingTaskCount))
+				failedDomains = append(failedDomains, domain)
+				continue
+			}
+			logger.Warn("Failing over domain with pending replication tasks",
+				zap.String("domain", domain), zap.Duration("lag", lag), zap.Int64("pending-tasks", domainStatus.PendingTaskCount))
+		}
 		updateRequest := &types.UpdateDomainRequest{
 			Name:              domain,
 			ActiveClusterName: common.StringPtr(params.TargetCluster),
@@ -478,6 +508,29 @@ func FailoverActivity(ctx context.Context, params *FailoverActivityParams) (*Fai
 	}, nil
 }
 
+// getReplicationStatus returns the replication status to the target cluster of the domains with replication tasks
+// pending, by domain name. The lag is only known by the source cluster, where the replication tasks are generated,
+// so it is an error to call it from another cluster.
+func getReplicationStatus(ctx context.Context, sourceCluster, targetCluster string) (map[string]*types.DomainReplicationStatus, error) {
+	manager := ctx.Value(failoverManagerContextKey).(*FailoverManager)
+	if currentCluster := manager.cfg.ClusterMetadata.GetCurrentClusterName(); currentCluster != sourceCluster {
+		return nil, fmt.Errorf("replication lag to %s can only be checked from source cluster %s, not %s", targetCluster, sourceCluster, currentCluster)
+	}
+	resp, err := manager.clientBean.GetHistoryClient().DescribeReplicationStatus(ctx, &types.DescribeReplicationStatusRequest{
+		ClusterName: targetCluster,
+	})
+	if err != nil {
+		return nil, err
+	}
+	status := make(map[string]*types.DomainReplicationStatus)
+	for _, clusterStatus := range resp.GetClusters() {
+		for _, domainStatus := range clusterStatus.Domains {
+			status[domainStatus.DomainName] = domainStatus
+		}
+	}
+	return status, nil
+}
+
 func cleanupChannel(channel workflow.Channel) {
 	for {
 		if hasValue := channel.ReceiveAsync(nil); !hasValue {
@@ -157,6 +157,7 @@ func (s *failoverWorkflowTestSuite) TestWorkflow_Success_Batches() {
 	domains := []string{"d1", "d2", "d3"}
 	expectFailoverActivityParams1 := &FailoverActivityParams{
 		Domains:       []string{"d1", "d2"},
+		SourceCluster: "s",
 		TargetCluster: "t",
 	}
 	mockFailoverActivityResult1 := &FailoverActivityResult{
@@ -164,6 +165,7 @@ func (s *failoverWorkflowTestSuite) TestWorkflow_Success_Batches() {
 	}
 	expectFailoverActivityParams2 := &FailoverActivityParams{
 		Domains:       []string{"d3"},
+		SourceCluster: "s",
 		TargetCluster: "t",
 	}
 	mockFailoverActivityResult2 := &FailoverActivityResult{
@@ -577,6 +579,118 @@ func (s *failoverWorkflowTestSuite) TestFailoverActivity_NoPoller_Error() {
 	s.Equal([]string{"d1", "d2"}, result.FailedDomains)
 }
 
+func (s *failoverWorkflowTestSuite) TestFailoverActivity_ReplicationLag() {
+	env, mockResource := s.prepareTestActivityEnv()
+
+	domains := []string{"d1", "d2", "d3"}
+	taskListMap := map[string]*types.DescribeTaskListResponse{
+		"tl": {Pollers: []*types.PollerInfo{{Identity: "test"}}},
+	}
+	mockResource.HistoryClient.EXPECT().DescribeReplicationStatus(gomock.Any(), &types.DescribeReplicationStatusRequest{
+		ClusterName: "c2",
+	}).Return(&types.DescribeReplicationStatusResponse{
+		Clusters: []*types.ClusterReplicationStatus{
+			{
+				ClusterName: "c2",
+				Domains: []*types.DomainReplicationStatus{
+					{DomainName: "d1", PendingTaskCount: 100, LagInMilliseconds: (2 * time.Minute).Milliseconds()},
+					{DomainName: "d2", PendingTaskCount: 1, LagInMilliseconds: (10 * time.Second).Milliseconds()},
+				},
+			},
+		},
+	}, nil).Times(1)
+	mockResource.FrontendClient.EXPECT().GetTaskListsByDomain(gomock.Any(), gomock.Any()).Return(&types.GetTaskListsByDomainResponse{
+		DecisionTaskListMap: taskListMap,
+		ActivityTaskListMap: taskListMap,
+	}, nil).Times(len(domains))
+	mockResource.RemoteFrontendClient.EXPECT().GetTaskListsByDomain(gomock.Any(), gomock.Any()).Return(&types.GetTaskListsByDomainResponse{
+		DecisionTaskListMap: taskListMap,
+		ActivityTaskListMap: taskListMap,
+	}, nil).Times(len(domains))
+	mockResource.FrontendClient.EXPECT().UpdateDomain(gomock.Any(), &types.UpdateDomainRequest{
+		Name:              "d2",
+		ActiveClusterName: common.StringPtr("c2"),
+	}).Return(nil, nil).Times(1)
+	mockResource.FrontendClient.EXPECT().UpdateDomain(gomock.Any(), &types.UpdateDomainRequest{
+		Name:              "d3",
+		ActiveClusterName: common.StringPtr("c2"),
+	}).Return(nil, nil).Times(1)
+
+	params := &FailoverActivityParams{
+		Domains:                    domains,
+		SourceCluster:              mockResource.ClusterMetadata.GetCurrentClusterName(),
+		TargetCluster:              "c2",
+		MaxReplicationLagInSeconds: 60,
+	}
+
+	actResult, err := env.ExecuteActivity(failoverActivityName, params)
+	s.NoError(err)
+	var result FailoverActivityResult
+	s.NoError(actResult.Get(&result))
+	s.Equal([]string{"d2", "d3"}, result.SuccessDomains)
+	s.Equal([]string{"d1"}, result.FailedDomains)
+}
+
+func (s *failoverWorkflowTestSuite) TestFailoverActivity_ReplicationLagUnknown() {
+	env, mockResource := s.prepareTestActivityEnv()
+
+	domains := []string{"d1", "d2"}
+	mockResource.FrontendClient.EXPECT().UpdateDomain(gomock.Any(), gomock.Any()).Times(0)
+
+	// the replication lag is only known by the source cluster
+	params := &FailoverActivityParams{
+		Domains:                    domains,
+		SourceCluster:              "c3",
+		TargetCluster:              "c2",
+		MaxReplicationLagInSeconds: 60,
+	}
+	actResult, err := env.ExecuteActivity(failoverActivityName, params)
+	s.NoError(err)
+	var result FailoverActivityResult
+	s.NoError(actResult.Get(&result))
+	s.Empty(result.SuccessDomains)
+	s.Equal(domains, result.FailedDomains)
+
+	mockResource.HistoryClient.EXPECT().DescribeReplicationStatus(gomock.Any(), gomock.Any()).Return(nil, errors.New("mockErr")).Times(1)
+	params.SourceCluster = mockResource.ClusterMetadata.GetCurrentClusterName()
+	actResult, err = env.ExecuteActivity(failoverActivityName, params)
+	s.NoError(err)
+	s.NoError(actResult.Get(&result))
+	s.Empty(result.SuccessDomains)
+	s.Equal(domains, result.FailedDomains)
+}
+
+func (s *failoverWorkflowTestSuite) TestWorkflow_WithDrillWaitTime_RollbackSkipsReplicationLag() {
+	domains := []string{"d1"}
+	mockFailoverActivityResult := &FailoverActivityResult{
+		SuccessDomains: []string{"d1"},
+	}
+	s.workflowEnv.OnActivity(getDomainsActivityName, mock.Anything, mock.Anything).Return(domains, nil)
+	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, &FailoverActivityParams{
+		Domains:                    domains,
+		SourceCluster:              "s",
+		TargetCluster:              "t",
+		MaxReplicationLagInSeconds: 60,
+	}).Return(mockFailoverActivityResult, nil).Once()
+	// the lag is only known by the source cluster, so it is not checked on rollback
+	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, &FailoverActivityParams{
+		Domains:       domains,
+		SourceCluster: "t",
+		TargetCluster: "s",
+	}).Return(mockFailoverActivityResult, nil).Once()
+	params := &FailoverParams{
+		TargetCluster:              "t",
+		SourceCluster:              "s",
+		DrillWaitTime:              1 * time.Second,
+		MaxReplicationLagInSeconds: 60,
+	}
+	s.workflowEnv.ExecuteWorkflow(FailoverWorkflowTypeName, params)
+	var result FailoverResult
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Equal(domains, result.SuccessDomains)
+	s.Equal(domains, result.SuccessResetDomains)
+}
+
 func (s *failoverWorkflowTestSuite) TestGetOperator() {
 	operator := "testOperator"
 	s.workflowEnv.SetMemoOnStart(map[string]interface{}{
@@ -615,6 +729,7 @@ func (s *failoverWorkflowTestSuite) prepareTestActivityEnv() (*testsuite.TestAct
 	mockResource := resource.NewTest(s.T(), controller, metrics.Worker)
 
 	ctx := &FailoverManager{
+		cfg:        Config{ClusterMetadata: mockResource.ClusterMetadata},
 		svcClient:  mockResource.GetSDKClient(),
 		clientBean: mockResource.ClientBean,
 	}
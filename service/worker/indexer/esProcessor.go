@@ -26,8 +26,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/dgryski/go-farm"
+
 	"github.com/uber/cadence/.gen/go/indexer"
-	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/codec"
 	"github.com/uber/cadence/common/collection"
 	"github.com/uber/cadence/common/definition"
@@ -278,13 +279,14 @@ func (p *ESProcessorImpl) getMsgWithInfo(key string) (wid string, rid string, do
 	return msg.GetWorkflowID(), msg.GetRunID(), msg.GetDomainID()
 }
 
+// hashFn picks the bucket of mapToKafkaMsg, it is unrelated to history shards
 func (p *ESProcessorImpl) hashFn(key interface{}) uint32 {
 	id, ok := key.(string)
 	if !ok {
 		return 0
 	}
 	numOfShards := p.config.IndexerConcurrency()
-	return uint32(common.WorkflowIDToHistoryShard(id, numOfShards))
+	return farm.Fingerprint32([]byte(id)) % uint32(numOfShards)
 }
 
 // 409 - Version Conflict
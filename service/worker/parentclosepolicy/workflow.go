@@ -203,6 +203,13 @@ func ProcessorActivity(ctx context.Context, request Request) error {
 				*types.CancellationAlreadyRequestedError:
 				err = nil
 			case *types.DomainNotActiveError:
+				// the workflows of an active-active domain are active in the cluster of their region,
+				// which is the active cluster of the error
+				if cluster := err.(*types.DomainNotActiveError).ActiveCluster; cluster != "" {
+					remoteExecutions[cluster] = append(remoteExecutions[cluster], execution)
+					err = nil
+					break
+				}
 				var domainEntry *cache.DomainCacheEntry
 				if domainEntry, err = domainCache.GetDomainByID(domainID); err == nil {
 					cluster := domainEntry.GetReplicationConfig().ActiveClusterName
@@ -0,0 +1,225 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package replication
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.uber.org/cadence/client"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/dynamicconfig"
+	"github.com/uber/cadence/common/pagination"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/reconciliation/entity"
+	"github.com/uber/cadence/common/reconciliation/fetcher"
+	"github.com/uber/cadence/common/reconciliation/invariant"
+	"github.com/uber/cadence/common/reconciliation/store"
+	"github.com/uber/cadence/common/resource"
+	"github.com/uber/cadence/service/worker/scanner/shardscanner"
+)
+
+const (
+	// ScannerWFTypeName defines workflow type name for replication consistency scanner
+	ScannerWFTypeName   = "cadence-sys-replication-consistency-scanner-workflow"
+	wfid                = "cadence-sys-replication-consistency-scanner"
+	scannerTaskListName = "cadence-sys-replication-consistency-scanner-tasklist-0"
+
+	// FixerWFTypeName defines workflow type name for replication consistency fixer
+	FixerWFTypeName   = "cadence-sys-replication-consistency-fixer-workflow"
+	fixerTaskListName = "cadence-sys-replication-consistency-fixer-tasklist-0"
+	fixerwfid         = "cadence-sys-replication-consistency-fixer"
+	sampleRateKey     = "sample_rate"
+)
+
+// ScannerWorkflow starts replication consistency scanner.
+func ScannerWorkflow(
+	ctx workflow.Context,
+	params shardscanner.ScannerWorkflowParams,
+) error {
+	wf, err := shardscanner.NewScannerWorkflow(ctx, ScannerWFTypeName, params)
+	if err != nil {
+		return err
+	}
+
+	return wf.Start(ctx)
+}
+
+// FixerWorkflow starts replication consistency fixer.
+func FixerWorkflow(
+	ctx workflow.Context,
+	params shardscanner.FixerWorkflowParams,
+) error {
+	wf, err := shardscanner.NewFixerWorkflow(ctx, FixerWFTypeName, params)
+	if err != nil {
+		return err
+	}
+
+	return wf.Start(ctx)
+}
+
+// ScannerHooks provides hooks for replication consistency scanner.
+func ScannerHooks() *shardscanner.ScannerHooks {
+	h, err := shardscanner.NewScannerHooks(Manager, Iterator, Config)
+	if err != nil {
+		return nil
+	}
+
+	return h
+}
+
+// FixerHooks provides hooks needed for replication consistency fixer.
+func FixerHooks() *shardscanner.FixerHooks {
+	h, err := shardscanner.NewFixerHooks(FixerManager, FixerIterator, fixerCustomConfig)
+	if err != nil {
+		return nil
+	}
+	return h
+}
+
+func fixerCustomConfig(_ shardscanner.FixerContext) shardscanner.CustomScannerConfig {
+	// must be non-empty to pass backwards-compat check,
+	// "fixer enabled" means "run this one invariant's fixes".
+	return map[string]string{
+		string(invariant.ReplicationConsistent): "true",
+	}
+}
+
+// Manager provides invariant manager for replication consistency scanner.
+// Remote clusters are reached through the resource of the scanner context of the activity.
+func Manager(
+	ctx context.Context,
+	pr persistence.Retryer,
+	params shardscanner.ScanShardActivityParams,
+	cache cache.DomainCache,
+) invariant.Manager {
+	scannerContext, err := shardscanner.GetScannerContext(ctx)
+	if err != nil {
+		panic(fmt.Sprintf("failed to get scanner context: %v", err))
+	}
+
+	sampleRate, err := strconv.ParseFloat(params.ScannerConfig[sampleRateKey], 64)
+	if err != nil {
+		// config of a previous version, or overwritten without sample rate
+		sampleRate = 1
+	}
+	return invariant.NewInvariantManager(getInvariants(pr, cache, scannerContext.Resource, sampleRate))
+}
+
+// Iterator provides iterator for replication consistency scanner.
+func Iterator(
+	ctx context.Context,
+	pr persistence.Retryer,
+	params shardscanner.ScanShardActivityParams,
+) pagination.Iterator {
+	return fetcher.ConcreteExecutionIterator(ctx, pr, params.PageSize)
+}
+
+// FixerIterator provides iterator for replication consistency fixer.
+func FixerIterator(
+	ctx context.Context,
+	client blobstore.Client,
+	keys store.Keys,
+	_ shardscanner.FixShardActivityParams,
+) store.ScanOutputIterator {
+	return store.NewBlobstoreIterator(ctx, client, keys, &entity.ConcreteExecution{})
+}
+
+// FixerManager provides invariant manager for replication consistency fixer.
+// All corrupted executions are fixed, since only sampled executions are reported by the scanner.
+func FixerManager(
+	ctx context.Context,
+	pr persistence.Retryer,
+	_ shardscanner.FixShardActivityParams,
+	cache cache.DomainCache,
+) invariant.Manager {
+	fixerContext, err := shardscanner.GetFixerContext(ctx)
+	if err != nil {
+		panic(fmt.Sprintf("failed to get fixer context: %v", err))
+	}
+
+	return invariant.NewInvariantManager(getInvariants(pr, cache, fixerContext.Resource, 1))
+}
+
+// Config resolves dynamic config for replication consistency scanner.
+func Config(ctx shardscanner.ScannerContext) shardscanner.CustomScannerConfig {
+	res := shardscanner.CustomScannerConfig{}
+	res[sampleRateKey] = strconv.FormatFloat(
+		ctx.Config.DynamicCollection.GetFloat64Property(dynamicconfig.ReplicationConsistencyScannerSampleRate)(),
+		'f',
+		-1,
+		64,
+	)
+	return res
+}
+
+// ScannerConfig configures replication consistency scanner
+func ScannerConfig(dc *dynamicconfig.Collection) *shardscanner.ScannerConfig {
+	return &shardscanner.ScannerConfig{
+		ScannerWFTypeName: ScannerWFTypeName,
+		FixerWFTypeName:   FixerWFTypeName,
+		DynamicParams: shardscanner.DynamicParams{
+			ScannerEnabled:          dc.GetBoolProperty(dynamicconfig.ReplicationConsistencyScannerEnabled),
+			FixerEnabled:            dc.GetBoolProperty(dynamicconfig.ReplicationConsistencyFixerEnabled),
+			Concurrency:             dc.GetIntProperty(dynamicconfig.ReplicationConsistencyScannerConcurrency),
+			PageSize:                dc.GetIntProperty(dynamicconfig.ReplicationConsistencyScannerPersistencePageSize),
+			BlobstoreFlushThreshold: dc.GetIntProperty(dynamicconfig.ReplicationConsistencyScannerBlobstoreFlushThreshold),
+			ActivityBatchSize:       dc.GetIntProperty(dynamicconfig.ReplicationConsistencyScannerActivityBatchSize),
+			AllowDomain:             dc.GetBoolPropertyFilteredByDomain(dynamicconfig.ReplicationConsistencyFixerDomainAllow),
+		},
+		DynamicCollection: dc,
+		ScannerHooks:      ScannerHooks,
+		FixerHooks:        FixerHooks,
+
+		StartWorkflowOptions: client.StartWorkflowOptions{
+			ID:                           wfid,
+			TaskList:                     scannerTaskListName,
+			ExecutionStartToCloseTimeout: 20 * 365 * 24 * time.Hour,
+			WorkflowIDReusePolicy:        client.WorkflowIDReusePolicyAllowDuplicate,
+			CronSchedule:                 "* * * * *",
+		},
+		StartFixerOptions: client.StartWorkflowOptions{
+			ID:                           fixerwfid,
+			TaskList:                     fixerTaskListName,
+			ExecutionStartToCloseTimeout: 20 * 365 * 24 * time.Hour,
+			WorkflowIDReusePolicy:        client.WorkflowIDReusePolicyAllowDuplicate,
+			CronSchedule:                 "* * * * *",
+		},
+	}
+}
+
+func getInvariants(
+	pr persistence.Retryer,
+	cache cache.DomainCache,
+	res resource.Resource,
+	sampleRate float64,
+) []invariant.Invariant {
+	return []invariant.Invariant{
+		invariant.NewReplicationConsistent(pr, cache, res.GetClientBean(), res.GetClusterMetadata(), sampleRate),
+	}
+}
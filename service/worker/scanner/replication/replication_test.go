@@ -0,0 +1,78 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package replication
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/uber/cadence/common/dynamicconfig"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/reconciliation/invariant"
+	"github.com/uber/cadence/service/worker/scanner/shardscanner"
+)
+
+type replicationScannerSuite struct {
+	suite.Suite
+	controller *gomock.Controller
+}
+
+func TestReplicationScannerSuite(t *testing.T) {
+	suite.Run(t, new(replicationScannerSuite))
+}
+
+func (s *replicationScannerSuite) SetupTest() {
+	s.controller = gomock.NewController(s.T())
+}
+
+func (s *replicationScannerSuite) TearDownTest() {
+	s.controller.Finish()
+}
+
+func (s *replicationScannerSuite) TestScannerConfig_SetsHooks() {
+	dcClient := dynamicconfig.NewMockClient(s.controller)
+	dc := dynamicconfig.NewCollection(dcClient, log.NewNoop())
+
+	cfg := ScannerConfig(dc)
+	s.Equal(ScannerWFTypeName, cfg.ScannerWFTypeName, "scanner wf type name is set")
+	s.Equal(FixerWFTypeName, cfg.FixerWFTypeName, "fixer wf type name is set")
+	s.NotNil(cfg.ScannerHooks())
+	s.NotNil(cfg.FixerHooks())
+}
+
+func (s *replicationScannerSuite) TestConfig() {
+	dcClient := dynamicconfig.NewMockClient(s.controller)
+	dcClient.EXPECT().GetFloatValue(dynamicconfig.ReplicationConsistencyScannerSampleRate, gomock.Any()).Return(0.25, nil)
+	dc := dynamicconfig.NewCollection(dcClient, log.NewNoop())
+
+	s.Equal(
+		shardscanner.CustomScannerConfig{sampleRateKey: "0.25"},
+		Config(shardscanner.ScannerContext{Config: ScannerConfig(dc)}),
+	)
+	s.Equal(
+		shardscanner.CustomScannerConfig{string(invariant.ReplicationConsistent): "true"},
+		fixerCustomConfig(shardscanner.FixerContext{}),
+	)
+}
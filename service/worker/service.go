@@ -38,7 +38,9 @@ import (
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/worker/archiver"
 	"github.com/uber/cadence/service/worker/asyncworkflow"
+	"github.com/uber/cadence/service/worker/autofailover"
 	"github.com/uber/cadence/service/worker/batcher"
+	"github.com/uber/cadence/service/worker/domainmigration"
 	"github.com/uber/cadence/service/worker/esanalyzer"
 	"github.com/uber/cadence/service/worker/failovermanager"
 	"github.com/uber/cadence/service/worker/indexer"
@@ -46,9 +48,11 @@ import (
 	"github.com/uber/cadence/service/worker/replicator"
 	"github.com/uber/cadence/service/worker/scanner"
 	"github.com/uber/cadence/service/worker/scanner/executions"
+	"github.com/uber/cadence/service/worker/scanner/replication"
 	"github.com/uber/cadence/service/worker/scanner/shardscanner"
 	"github.com/uber/cadence/service/worker/scanner/tasklist"
 	"github.com/uber/cadence/service/worker/scanner/timers"
+	"github.com/uber/cadence/service/worker/shardsplit"
 )
 
 type (
@@ -73,6 +77,9 @@ type (
 		BatcherCfg                          *batcher.Config
 		ESAnalyzerCfg                       *esanalyzer.Config
 		failoverManagerCfg                  *failovermanager.Config
+		domainMigrationCfg                  *domainmigration.Config
+		shardSplitCfg                       *shardsplit.Config
+		autoFailoverCfg                     *autofailover.Config
 		ThrottledLogRPS                     dynamicconfig.IntPropertyFn
 		PersistenceGlobalMaxQPS             dynamicconfig.IntPropertyFn
 		PersistenceMaxQPS                   dynamicconfig.IntPropertyFn
@@ -80,6 +87,9 @@ type (
 		EnableParentClosePolicyWorker       dynamicconfig.BoolPropertyFn
 		NumParentClosePolicySystemWorkflows dynamicconfig.IntPropertyFn
 		EnableFailoverManager               dynamicconfig.BoolPropertyFn
+		EnableDomainMigrationWorker         dynamicconfig.BoolPropertyFn
+		EnableShardSplitWorker              dynamicconfig.BoolPropertyFn
+		EnableAutoFailover                  dynamicconfig.BoolPropertyFn
 		DomainReplicationMaxRetryDuration   dynamicconfig.DurationPropertyFn
 		EnableESAnalyzer                    dynamicconfig.BoolPropertyFn
 		EnableAsyncWorkflowConsumption      dynamicconfig.BoolPropertyFn
@@ -144,6 +154,7 @@ func NewConfig(params *resource.Params) *Config {
 				executions.ConcreteExecutionConfig(dc),
 				executions.CurrentExecutionConfig(dc),
 				timers.ScannerConfig(dc),
+				replication.ScannerConfig(dc),
 			},
 			MaxWorkflowRetentionInDays: dc.GetIntProperty(dynamicconfig.MaxRetentionDays),
 		},
@@ -155,6 +166,18 @@ func NewConfig(params *resource.Params) *Config {
 			AdminOperationToken: dc.GetStringProperty(dynamicconfig.AdminOperationToken),
 			ClusterMetadata:     params.ClusterMetadata,
 		},
+		domainMigrationCfg: &domainmigration.Config{
+			ClusterMetadata: params.ClusterMetadata,
+		},
+		shardSplitCfg: &shardsplit.Config{
+			NumberOfShards: params.PersistenceConfig.NumHistoryShards,
+		},
+		autoFailoverCfg: &autofailover.Config{
+			ClusterMetadata:     params.ClusterMetadata,
+			DryRun:              dc.GetBoolProperty(dynamicconfig.AutoFailoverDryRun),
+			HealthCheckInterval: dc.GetDurationProperty(dynamicconfig.AutoFailoverHealthCheckInterval),
+			ConfirmationWindow:  dc.GetDurationProperty(dynamicconfig.AutoFailoverConfirmationWindow),
+		},
 		ESAnalyzerCfg: &esanalyzer.Config{
 			ESAnalyzerPause:                          dc.GetBoolProperty(dynamicconfig.ESAnalyzerPause),
 			ESAnalyzerTimeWindow:                     dc.GetDurationProperty(dynamicconfig.ESAnalyzerTimeWindow),
@@ -175,6 +198,9 @@ func NewConfig(params *resource.Params) *Config {
 		NumParentClosePolicySystemWorkflows: dc.GetIntProperty(dynamicconfig.NumParentClosePolicySystemWorkflows),
 		EnableESAnalyzer:                    dc.GetBoolProperty(dynamicconfig.EnableESAnalyzer),
 		EnableFailoverManager:               dc.GetBoolProperty(dynamicconfig.EnableFailoverManager),
+		EnableDomainMigrationWorker:         dc.GetBoolProperty(dynamicconfig.EnableDomainMigrationWorker),
+		EnableShardSplitWorker:              dc.GetBoolProperty(dynamicconfig.EnableShardSplitWorker),
+		EnableAutoFailover:                  dc.GetBoolProperty(dynamicconfig.EnableAutoFailover),
 		ThrottledLogRPS:                     dc.GetIntProperty(dynamicconfig.WorkerThrottledLogRPS),
 		PersistenceGlobalMaxQPS:             dc.GetIntProperty(dynamicconfig.WorkerPersistenceGlobalMaxQPS),
 		PersistenceMaxQPS:                   dc.GetIntProperty(dynamicconfig.WorkerPersistenceMaxQPS),
@@ -235,6 +261,15 @@ func (s *Service) Start() {
 	if s.config.EnableFailoverManager() {
 		s.startFailoverManager()
 	}
+	if s.config.EnableDomainMigrationWorker() {
+		s.startDomainMigrator()
+	}
+	if s.config.EnableShardSplitWorker() {
+		s.startShardSplitter()
+	}
+	if s.config.EnableAutoFailover() {
+		s.startAutoFailoverController()
+	}
 
 	if s.config.EnableAsyncWorkflowConsumption() {
 		cm := s.startAsyncWorkflowConsumerManager()
@@ -400,6 +435,48 @@ func (s *Service) startFailoverManager() {
 	}
 }
 
+func (s *Service) startDomainMigrator() {
+	params := &domainmigration.BootstrapParams{
+		Config:        *s.config.domainMigrationCfg,
+		ServiceClient: s.params.PublicClient,
+		MetricsClient: s.GetMetricsClient(),
+		Logger:        s.GetLogger(),
+		TallyScope:    s.params.MetricScope,
+		ClientBean:    s.GetClientBean(),
+	}
+	if err := domainmigration.New(params).Start(); err != nil {
+		s.Stop()
+		s.GetLogger().Fatal("error starting domain migrator", tag.Error(err))
+	}
+}
+
+func (s *Service) startShardSplitter() {
+	params := &shardsplit.BootstrapParams{
+		Config:             *s.config.shardSplitCfg,
+		ServiceClient:      s.params.PublicClient,
+		MetricsClient:      s.GetMetricsClient(),
+		Logger:             s.GetLogger(),
+		TallyScope:         s.params.MetricScope,
+		ClientBean:         s.GetClientBean(),
+		ConfigStoreManager: s.GetPersistenceBean().GetConfigStoreManager(),
+	}
+	if err := shardsplit.New(params).Start(); err != nil {
+		s.Stop()
+		s.GetLogger().Fatal("error starting shard splitter", tag.Error(err))
+	}
+}
+
+func (s *Service) startAutoFailoverController() {
+	params := &autofailover.BootstrapParams{
+		Config:     *s.config.autoFailoverCfg,
+		TallyScope: s.params.MetricScope,
+	}
+	if err := autofailover.New(s.Resource, params).Start(); err != nil {
+		s.Stop()
+		s.GetLogger().Fatal("error starting auto failover controller", tag.Error(err))
+	}
+}
+
 func (s *Service) startAsyncWorkflowConsumerManager() common.Daemon {
 	cm := asyncworkflow.NewConsumerManager(
 		s.GetLogger(),
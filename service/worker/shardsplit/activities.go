@@ -0,0 +1,139 @@
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shardsplit
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/cadence"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+// StartSplitActivity starts splitting the given shard in the history shard mapping and returns the ID of the
+// child shard. The split of the shard already in progress is resumed, e.g. when the activity is retried.
+func StartSplitActivity(ctx context.Context, params *StartSplitActivityParams) (*StartSplitActivityResult, error) {
+	splitter := getSplitter(ctx)
+	mapping, err := splitter.fetchMapping(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if split := mapping.SplitInProgress(); split != nil {
+		if split.ParentShardID != params.ShardID {
+			return nil, cadence.NewCustomError(errReasonSplitNotAllowed, fmt.Sprintf("shard %v is being split", split.ParentShardID))
+		}
+		return &StartSplitActivityResult{ChildShardID: split.ChildShardID}, nil
+	}
+
+	newMapping, err := mapping.Split(params.ShardID)
+	if err != nil {
+		return nil, cadence.NewCustomError(errReasonSplitNotAllowed, err.Error())
+	}
+	if err := splitter.configStoreManager.UpdateHistoryShardMapping(ctx, &persistence.UpdateHistoryShardMappingRequest{
+		Mapping: newMapping,
+	}); err != nil {
+		return nil, err
+	}
+	split := newMapping.SplitInProgress()
+	splitter.logger.Info(fmt.Sprintf("Started splitting history shard %v into shard %v", split.ParentShardID, split.ChildShardID))
+	return &StartSplitActivityResult{ChildShardID: split.ChildShardID}, nil
+}
+
+// AdvanceSplitActivity fences or completes the split in the history shard mapping, it does nothing if the split
+// is already in the requested state
+func AdvanceSplitActivity(ctx context.Context, params *AdvanceSplitActivityParams) error {
+	splitter := getSplitter(ctx)
+	mapping, err := splitter.fetchMapping(ctx)
+	if err != nil {
+		return err
+	}
+	var state common.HistoryShardSplitState
+	found := false
+	for _, split := range mapping.Splits {
+		if split.ParentShardID == params.ParentShardID && split.ChildShardID == params.ChildShardID {
+			state = split.State
+			found = true
+			break
+		}
+	}
+	if !found {
+		return cadence.NewCustomError(errReasonSplitNotAllowed, fmt.Sprintf("shard %v is not split into shard %v", params.ParentShardID, params.ChildShardID))
+	}
+	if state == common.HistoryShardSplitStateCompleted || (!params.Complete && state == common.HistoryShardSplitStateFenced) {
+		return nil
+	}
+
+	var newMapping *common.HistoryShardMapping
+	if params.Complete {
+		newMapping, err = mapping.CompleteSplit()
+	} else {
+		newMapping, err = mapping.FenceSplit()
+	}
+	if err != nil {
+		return cadence.NewCustomError(errReasonSplitNotAllowed, err.Error())
+	}
+	if err := splitter.configStoreManager.UpdateHistoryShardMapping(ctx, &persistence.UpdateHistoryShardMappingRequest{
+		Mapping: newMapping,
+	}); err != nil {
+		return err
+	}
+	splitter.logger.Info(fmt.Sprintf("Advanced split of history shard %v into shard %v, completed: %v", params.ParentShardID, params.ChildShardID, params.Complete))
+	return nil
+}
+
+// MigrateExecutionsActivity copies or deletes a page of the executions moving from the parent shard to the child shard
+func MigrateExecutionsActivity(ctx context.Context, params *MigrateExecutionsActivityParams) (*MigrateExecutionsActivityResult, error) {
+	splitter := getSplitter(ctx)
+	resp, err := splitter.clientBean.GetHistoryClient().MigrateShardExecutions(ctx, &types.MigrateShardExecutionsRequest{
+		ShardID:          int32(params.ChildShardID),
+		SourceShardID:    int32(params.ParentShardID),
+		ClosedOnly:       params.ClosedOnly,
+		DeleteFromSource: params.DeleteFromSource,
+		PageSize:         int32(params.PageSize),
+		NextPageToken:    params.NextPageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &MigrateExecutionsActivityResult{
+		NextPageToken: resp.GetNextPageToken(),
+		Copied:        int(resp.GetCopiedCount()),
+		Deleted:       int(resp.GetDeletedCount()),
+	}, nil
+}
+
+func (s *Splitter) fetchMapping(ctx context.Context) (*common.HistoryShardMapping, error) {
+	resp, err := s.configStoreManager.FetchHistoryShardMapping(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Mapping == nil {
+		return common.NewHistoryShardMapping(s.cfg.NumberOfShards), nil
+	}
+	return resp.Mapping, nil
+}
+
+func getSplitter(ctx context.Context) *Splitter {
+	return ctx.Value(shardSplitContextKey).(*Splitter)
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shardsplit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/worker"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/resource"
+	"github.com/uber/cadence/common/types"
+)
+
+type activitiesTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+	activityEnv            *testsuite.TestActivityEnvironment
+	mockResource           *resource.Test
+	mockConfigStoreManager *persistence.MockConfigStoreManager
+}
+
+func TestActivitiesTestSuite(t *testing.T) {
+	suite.Run(t, new(activitiesTestSuite))
+}
+
+func (s *activitiesTestSuite) SetupTest() {
+	controller := gomock.NewController(s.T())
+	s.mockResource = resource.NewTest(s.T(), controller, metrics.Worker)
+	s.mockConfigStoreManager = persistence.NewMockConfigStoreManager(controller)
+
+	splitter := &Splitter{
+		cfg:                Config{NumberOfShards: 4},
+		clientBean:         s.mockResource.ClientBean,
+		configStoreManager: s.mockConfigStoreManager,
+		logger:             testlogger.New(s.T()),
+	}
+	s.activityEnv = s.NewTestActivityEnvironment()
+	s.activityEnv.RegisterActivityWithOptions(StartSplitActivity, activity.RegisterOptions{Name: startSplitActivityName})
+	s.activityEnv.RegisterActivityWithOptions(AdvanceSplitActivity, activity.RegisterOptions{Name: advanceSplitActivityName})
+	s.activityEnv.RegisterActivityWithOptions(MigrateExecutionsActivity, activity.RegisterOptions{Name: migrateExecutionsActivityName})
+	s.activityEnv.SetTestTimeout(time.Second * 5)
+	s.activityEnv.SetWorkerOptions(worker.Options{
+		BackgroundActivityContext: context.WithValue(context.Background(), shardSplitContextKey, splitter),
+	})
+}
+
+func (s *activitiesTestSuite) TearDownTest() {
+	s.mockResource.Finish(s.T())
+}
+
+func (s *activitiesTestSuite) TestStartSplitActivity_InitialMapping() {
+	s.mockConfigStoreManager.EXPECT().FetchHistoryShardMapping(gomock.Any()).Return(nil, nil)
+	s.mockConfigStoreManager.EXPECT().UpdateHistoryShardMapping(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *persistence.UpdateHistoryShardMappingRequest) error {
+			s.Equal(int64(1), request.Mapping.Version)
+			s.Equal([]common.HistoryShardSplit{{ParentShardID: 1, ChildShardID: 4, State: common.HistoryShardSplitStateCopying}}, request.Mapping.Splits)
+			return nil
+		})
+
+	actResult, err := s.activityEnv.ExecuteActivity(startSplitActivityName, &StartSplitActivityParams{ShardID: 1})
+	s.NoError(err)
+	var result StartSplitActivityResult
+	s.NoError(actResult.Get(&result))
+	s.Equal(4, result.ChildShardID)
+}
+
+func (s *activitiesTestSuite) TestStartSplitActivity_ResumeSplit() {
+	mapping, err := common.NewHistoryShardMapping(4).Split(1)
+	s.NoError(err)
+	s.mockConfigStoreManager.EXPECT().FetchHistoryShardMapping(gomock.Any()).
+		Return(&persistence.FetchHistoryShardMappingResponse{Mapping: mapping}, nil)
+
+	actResult, err := s.activityEnv.ExecuteActivity(startSplitActivityName, &StartSplitActivityParams{ShardID: 1})
+	s.NoError(err)
+	var result StartSplitActivityResult
+	s.NoError(actResult.Get(&result))
+	s.Equal(4, result.ChildShardID)
+}
+
+func (s *activitiesTestSuite) TestStartSplitActivity_OtherSplitInProgress() {
+	mapping, err := common.NewHistoryShardMapping(4).Split(2)
+	s.NoError(err)
+	s.mockConfigStoreManager.EXPECT().FetchHistoryShardMapping(gomock.Any()).
+		Return(&persistence.FetchHistoryShardMappingResponse{Mapping: mapping}, nil)
+
+	_, err = s.activityEnv.ExecuteActivity(startSplitActivityName, &StartSplitActivityParams{ShardID: 1})
+	var customErr *cadence.CustomError
+	s.ErrorAs(err, &customErr)
+	s.Equal(errReasonSplitNotAllowed, customErr.Reason())
+}
+
+func (s *activitiesTestSuite) TestAdvanceSplitActivity() {
+	copying, err := common.NewHistoryShardMapping(4).Split(1)
+	s.NoError(err)
+	fenced, err := copying.FenceSplit()
+	s.NoError(err)
+	completed, err := fenced.CompleteSplit()
+	s.NoError(err)
+
+	// fence
+	s.mockConfigStoreManager.EXPECT().FetchHistoryShardMapping(gomock.Any()).
+		Return(&persistence.FetchHistoryShardMappingResponse{Mapping: copying}, nil)
+	s.mockConfigStoreManager.EXPECT().UpdateHistoryShardMapping(gomock.Any(), &persistence.UpdateHistoryShardMappingRequest{Mapping: fenced}).Return(nil)
+	_, err = s.activityEnv.ExecuteActivity(advanceSplitActivityName, &AdvanceSplitActivityParams{ParentShardID: 1, ChildShardID: 4})
+	s.NoError(err)
+
+	// already fenced
+	s.mockConfigStoreManager.EXPECT().FetchHistoryShardMapping(gomock.Any()).
+		Return(&persistence.FetchHistoryShardMappingResponse{Mapping: fenced}, nil)
+	_, err = s.activityEnv.ExecuteActivity(advanceSplitActivityName, &AdvanceSplitActivityParams{ParentShardID: 1, ChildShardID: 4})
+	s.NoError(err)
+
+	// complete
+	s.mockConfigStoreManager.EXPECT().FetchHistoryShardMapping(gomock.Any()).
+		Return(&persistence.FetchHistoryShardMappingResponse{Mapping: fenced}, nil)
+	s.mockConfigStoreManager.EXPECT().UpdateHistoryShardMapping(gomock.Any(), &persistence.UpdateHistoryShardMappingRequest{Mapping: completed}).Return(nil)
+	_, err = s.activityEnv.ExecuteActivity(advanceSplitActivityName, &AdvanceSplitActivityParams{ParentShardID: 1, ChildShardID: 4, Complete: true})
+	s.NoError(err)
+
+	// already completed
+	s.mockConfigStoreManager.EXPECT().FetchHistoryShardMapping(gomock.Any()).
+		Return(&persistence.FetchHistoryShardMappingResponse{Mapping: completed}, nil)
+	_, err = s.activityEnv.ExecuteActivity(advanceSplitActivityName, &AdvanceSplitActivityParams{ParentShardID: 1, ChildShardID: 4, Complete: true})
+	s.NoError(err)
+}
+
+func (s *activitiesTestSuite) TestAdvanceSplitActivity_UnknownSplit() {
+	s.mockConfigStoreManager.EXPECT().FetchHistoryShardMapping(gomock.Any()).Return(nil, nil)
+
+	_, err := s.activityEnv.ExecuteActivity(advanceSplitActivityName, &AdvanceSplitActivityParams{ParentShardID: 1, ChildShardID: 4})
+	var customErr *cadence.CustomError
+	s.ErrorAs(err, &customErr)
+	s.Equal(errReasonSplitNotAllowed, customErr.Reason())
+}
+
+func (s *activitiesTestSuite) TestMigrateExecutionsActivity() {
+	s.mockResource.HistoryClient.EXPECT().MigrateShardExecutions(gomock.Any(), &types.MigrateShardExecutionsRequest{
+		ShardID:       4,
+		SourceShardID: 1,
+		ClosedOnly:    true,
+		PageSize:      10,
+		NextPageToken: []byte("token"),
+	}).Return(&types.MigrateShardExecutionsResponse{CopiedCount: 3, NextPageToken: []byte("next")}, nil)
+
+	actResult, err := s.activityEnv.ExecuteActivity(migrateExecutionsActivityName, &MigrateExecutionsActivityParams{
+		ParentShardID: 1,
+		ChildShardID:  4,
+		ClosedOnly:    true,
+		PageSize:      10,
+		NextPageToken: []byte("token"),
+	})
+	s.NoError(err)
+	var result MigrateExecutionsActivityResult
+	s.NoError(actResult.Get(&result))
+	s.Equal(3, result.Copied)
+	s.Equal([]byte("next"), result.NextPageToken)
+}
+
+func (s *activitiesTestSuite) TestMigrateExecutionsActivity_Error() {
+	s.mockResource.HistoryClient.EXPECT().MigrateShardExecutions(gomock.Any(), gomock.Any()).Return(nil, errors.New("mockErr"))
+
+	_, err := s.activityEnv.ExecuteActivity(migrateExecutionsActivityName, &MigrateExecutionsActivityParams{ParentShardID: 1, ChildShardID: 4})
+	s.Error(err)
+}
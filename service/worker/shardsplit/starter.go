@@ -0,0 +1,113 @@
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shardsplit
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/uber-go/tally"
+	"go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/worker"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	// Config defines the configuration for shard split
+	Config struct {
+		// NumberOfShards is the number of history shards the cluster was created with
+		NumberOfShards int
+	}
+
+	// BootstrapParams contains the set of params needed to bootstrap
+	// shard splitter
+	BootstrapParams struct {
+		// Config contains the configuration for shard split
+		Config Config
+		// ServiceClient is an instance of cadence service client
+		ServiceClient workflowserviceclient.Interface
+		// MetricsClient is an instance of metrics object for emitting stats
+		MetricsClient metrics.Client
+		Logger        log.Logger
+		// TallyScope is an instance of tally metrics scope
+		TallyScope tally.Scope
+		// ClientBean is an instance of client.Bean for a collection of clients
+		ClientBean client.Bean
+		// ConfigStoreManager persists the history shard mapping
+		ConfigStoreManager persistence.ConfigStoreManager
+	}
+
+	// Splitter of cadence worker service, it splits history shards and moves
+	// the executions of the split shards
+	Splitter struct {
+		cfg                Config
+		svcClient          workflowserviceclient.Interface
+		clientBean         client.Bean
+		configStoreManager persistence.ConfigStoreManager
+		metricsClient      metrics.Client
+		tallyScope         tally.Scope
+		logger             log.Logger
+		worker             worker.Worker
+	}
+)
+
+// New returns a new instance of Splitter
+func New(params *BootstrapParams) *Splitter {
+	return &Splitter{
+		cfg:                params.Config,
+		svcClient:          params.ServiceClient,
+		metricsClient:      params.MetricsClient,
+		tallyScope:         params.TallyScope,
+		logger:             params.Logger.WithTags(tag.ComponentShardSplit),
+		clientBean:         params.ClientBean,
+		configStoreManager: params.ConfigStoreManager,
+	}
+}
+
+// Start starts the worker
+func (s *Splitter) Start() error {
+	ctx := context.WithValue(context.Background(), shardSplitContextKey, s)
+	workerOpts := worker.Options{
+		MetricsScope:              s.tallyScope,
+		BackgroundActivityContext: ctx,
+		Tracer:                    opentracing.GlobalTracer(),
+	}
+	splitWorker := worker.New(s.svcClient, common.SystemLocalDomainName, TaskListName, workerOpts)
+	splitWorker.RegisterWorkflowWithOptions(SplitWorkflow, workflow.RegisterOptions{Name: WorkflowTypeName})
+	splitWorker.RegisterActivityWithOptions(StartSplitActivity, activity.RegisterOptions{Name: startSplitActivityName})
+	splitWorker.RegisterActivityWithOptions(AdvanceSplitActivity, activity.RegisterOptions{Name: advanceSplitActivityName})
+	splitWorker.RegisterActivityWithOptions(MigrateExecutionsActivity, activity.RegisterOptions{Name: migrateExecutionsActivityName})
+	s.worker = splitWorker
+	return splitWorker.Start()
+}
+
+// Stop stops the worker
+func (s *Splitter) Stop() {
+	s.worker.Stop()
+}
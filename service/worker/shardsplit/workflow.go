@@ -0,0 +1,323 @@
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shardsplit
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/uber/cadence/common/cache"
+)
+
+type (
+	contextKey string
+)
+
+const (
+	shardSplitContextKey contextKey = "shardSplitContext"
+	// TaskListName tasklist
+	TaskListName = "cadence-sys-shard-split-tasklist"
+	// WorkflowTypeName workflow type name
+	WorkflowTypeName = "cadence-sys-shard-split-workflow"
+	// WorkflowID is the ID of the split workflow, there is at most one split in progress at a time
+	WorkflowID                    = "cadence-sys-shard-split"
+	startSplitActivityName        = "cadence-sys-shard-split-start-activity"
+	advanceSplitActivityName      = "cadence-sys-shard-split-advance-activity"
+	migrateExecutionsActivityName = "cadence-sys-shard-split-migrate-executions-activity"
+
+	defaultPageSize    = 100
+	defaultPagesPerRun = 50
+
+	// mappingPropagationDelay is how long the workflow waits after updating the shard mapping, so that all
+	// history hosts and clients refresh their cached mapping before the next phase
+	mappingPropagationDelay = 3 * cache.HistoryShardMappingRefreshInterval
+
+	errMsgParamsIsNil        = "params is nil"
+	errMsgInvalidShardID     = "shardID is invalid"
+	errReasonSplitNotAllowed = "shard split is not allowed"
+
+	// QueryType for split workflow
+	QueryType = "state"
+	// CutOverSignal signal name to start the cut-over
+	CutOverSignal = "cut-over"
+
+	// split phases, they are run in the order below
+
+	// PhaseCopyClosed copies the closed executions moving to the child shard, while the parent shard still owns them
+	PhaseCopyClosed = "copy-closed"
+	// PhaseWaitCutOver waits for the cut-over signal, unless the cut-over is automatic
+	PhaseWaitCutOver = "wait-cut-over"
+	// PhaseFence fences the split, requests and tasks of the moving workflows are rejected until the split completes
+	PhaseFence = "fence"
+	// PhaseCopyAll copies all the moving executions, including the open ones, into the child shard
+	PhaseCopyAll = "copy-all"
+	// PhaseComplete completes the split, the moving workflows are owned by the child shard from then on
+	PhaseComplete = "complete"
+	// PhaseDeleteSource deletes the moved executions from the parent shard
+	PhaseDeleteSource = "delete-source"
+	// PhaseCompleted the split is completed
+	PhaseCompleted = "completed"
+)
+
+type (
+	// SplitParams is the arg for SplitWorkflow
+	SplitParams struct {
+		// ShardID is the history shard to split
+		ShardID int
+		// PageSize is the number of executions of the parent shard scanned by each activity
+		PageSize int
+		// PagesPerRun is the number of pages scanned before the workflow continues as new
+		PagesPerRun int
+		// AutoCutOver starts the cut-over once the closed executions are copied instead of waiting for CutOverSignal
+		AutoCutOver bool
+		// Checkpoint is the progress of the split, it is set when the workflow continues as new
+		Checkpoint *Checkpoint
+	}
+
+	// Checkpoint is the progress of a split, the workflow resumes from it after continuing as new
+	Checkpoint struct {
+		Phase         string
+		ParentShardID int
+		ChildShardID  int
+		NextPageToken []byte
+		// CutOverRequested is set once CutOverSignal is received
+		CutOverRequested  bool
+		CopiedExecutions  int
+		DeletedExecutions int
+	}
+
+	// StartSplitActivityParams params for activity
+	StartSplitActivityParams struct {
+		ShardID int
+	}
+
+	// StartSplitActivityResult result for start split activity
+	StartSplitActivityResult struct {
+		ChildShardID int
+	}
+
+	// AdvanceSplitActivityParams params for activity
+	AdvanceSplitActivityParams struct {
+		ParentShardID int
+		ChildShardID  int
+		// Complete completes the split instead of fencing it
+		Complete bool
+	}
+
+	// MigrateExecutionsActivityParams params for activity
+	MigrateExecutionsActivityParams struct {
+		ParentShardID    int
+		ChildShardID     int
+		ClosedOnly       bool
+		DeleteFromSource bool
+		PageSize         int
+		NextPageToken    []byte
+	}
+
+	// MigrateExecutionsActivityResult result for migrate executions activity
+	MigrateExecutionsActivityResult struct {
+		NextPageToken []byte
+		Copied        int
+		Deleted       int
+	}
+
+	// QueryResult for split progress
+	QueryResult struct {
+		ShardID     int
+		AutoCutOver bool
+		Checkpoint  *Checkpoint
+	}
+)
+
+// SplitWorkflow is the workflow that splits a history shard into two and moves the executions of the workflows
+// that change shard into the new child shard. Closed executions are copied first while the parent shard keeps
+// serving all its workflows. The cut-over then fences the moving workflows, whose requests and tasks are retried
+// until the split completes, copies all their executions a last time, hands them over to the child shard and
+// finally deletes them from the parent shard. Executions are scanned page by page and the workflow continues as
+// new with its checkpoint every PagesPerRun pages.
+func SplitWorkflow(ctx workflow.Context, params *SplitParams) (*Checkpoint, error) {
+	if err := validateParams(params); err != nil {
+		return nil, err
+	}
+
+	checkpoint := params.Checkpoint
+	if checkpoint == nil {
+		ao := workflow.WithActivityOptions(ctx, getUpdateMappingActivityOptions())
+		var result StartSplitActivityResult
+		if err := workflow.ExecuteActivity(ao, startSplitActivityName, &StartSplitActivityParams{ShardID: params.ShardID}).Get(ctx, &result); err != nil {
+			return nil, err
+		}
+		// the child shard has to be acquired by a history host before executions can be copied into it
+		if err := workflow.Sleep(ctx, mappingPropagationDelay); err != nil {
+			return nil, err
+		}
+		checkpoint = &Checkpoint{
+			Phase:         PhaseCopyClosed,
+			ParentShardID: params.ShardID,
+			ChildShardID:  result.ChildShardID,
+		}
+	}
+
+	err := workflow.SetQueryHandler(ctx, QueryType, func(input []byte) (*QueryResult, error) {
+		return &QueryResult{
+			ShardID:     params.ShardID,
+			AutoCutOver: params.AutoCutOver,
+			Checkpoint:  checkpoint,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cutOverCh := workflow.GetSignalChannel(ctx, CutOverSignal)
+	mappingCtx := workflow.WithActivityOptions(ctx, getUpdateMappingActivityOptions())
+	migrateCtx := workflow.WithActivityOptions(ctx, getMigrateExecutionsActivityOptions())
+	pages := 0
+	for checkpoint.Phase != PhaseCompleted {
+		if cutOverCh.ReceiveAsync(nil) {
+			checkpoint.CutOverRequested = true
+		}
+		switch checkpoint.Phase {
+		case PhaseWaitCutOver:
+			if !params.AutoCutOver && !checkpoint.CutOverRequested {
+				cutOverCh.Receive(ctx, nil)
+				checkpoint.CutOverRequested = true
+			}
+			checkpoint.Phase = PhaseFence
+			continue
+		case PhaseFence, PhaseComplete:
+			advanceParams := &AdvanceSplitActivityParams{
+				ParentShardID: checkpoint.ParentShardID,
+				ChildShardID:  checkpoint.ChildShardID,
+				Complete:      checkpoint.Phase == PhaseComplete,
+			}
+			if err := workflow.ExecuteActivity(mappingCtx, advanceSplitActivityName, advanceParams).Get(ctx, nil); err != nil {
+				return nil, err
+			}
+			// executions are only copied or deleted once no host serves them from the previous mapping
+			if err := workflow.Sleep(ctx, mappingPropagationDelay); err != nil {
+				return nil, err
+			}
+			checkpoint.advance()
+			continue
+		}
+
+		if pages >= params.PagesPerRun {
+			params.Checkpoint = checkpoint
+			return nil, workflow.NewContinueAsNewError(ctx, WorkflowTypeName, params)
+		}
+
+		migrateParams := &MigrateExecutionsActivityParams{
+			ParentShardID: checkpoint.ParentShardID,
+			ChildShardID:  checkpoint.ChildShardID,
+			PageSize:      params.PageSize,
+			NextPageToken: checkpoint.NextPageToken,
+		}
+		switch checkpoint.Phase {
+		case PhaseCopyClosed:
+			migrateParams.ClosedOnly = true
+		case PhaseCopyAll:
+		case PhaseDeleteSource:
+			migrateParams.DeleteFromSource = true
+		default:
+			return nil, fmt.Errorf("unknown split phase: %v", checkpoint.Phase)
+		}
+
+		var result MigrateExecutionsActivityResult
+		if err := workflow.ExecuteActivity(migrateCtx, migrateExecutionsActivityName, migrateParams).Get(ctx, &result); err != nil {
+			return nil, err
+		}
+		pages++
+		checkpoint.update(&result)
+	}
+	return checkpoint, nil
+}
+
+func (c *Checkpoint) update(result *MigrateExecutionsActivityResult) {
+	c.CopiedExecutions += result.Copied
+	c.DeletedExecutions += result.Deleted
+	c.NextPageToken = result.NextPageToken
+	if len(c.NextPageToken) == 0 {
+		c.advance()
+	}
+}
+
+func (c *Checkpoint) advance() {
+	switch c.Phase {
+	case PhaseCopyClosed:
+		c.Phase = PhaseWaitCutOver
+	case PhaseFence:
+		c.Phase = PhaseCopyAll
+	case PhaseCopyAll:
+		c.Phase = PhaseComplete
+	case PhaseComplete:
+		c.Phase = PhaseDeleteSource
+	case PhaseDeleteSource:
+		c.Phase = PhaseCompleted
+	}
+}
+
+func getUpdateMappingActivityOptions() workflow.ActivityOptions {
+	return workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Second,
+		StartToCloseTimeout:    20 * time.Second,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:          2 * time.Second,
+			BackoffCoefficient:       2,
+			MaximumInterval:          1 * time.Minute,
+			ExpirationInterval:       10 * time.Minute,
+			NonRetriableErrorReasons: []string{errReasonSplitNotAllowed},
+		},
+	}
+}
+
+func getMigrateExecutionsActivityOptions() workflow.ActivityOptions {
+	return workflow.ActivityOptions{
+		ScheduleToStartTimeout: 1 * time.Minute,
+		StartToCloseTimeout:    10 * time.Minute,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:    5 * time.Second,
+			BackoffCoefficient: 2,
+			MaximumInterval:    5 * time.Minute,
+			ExpirationInterval: 24 * time.Hour,
+		},
+	}
+}
+
+func validateParams(params *SplitParams) error {
+	if params == nil {
+		return errors.New(errMsgParamsIsNil)
+	}
+	if params.ShardID < 0 {
+		return errors.New(errMsgInvalidShardID)
+	}
+	if params.PageSize <= 0 {
+		params.PageSize = defaultPageSize
+	}
+	if params.PagesPerRun <= 0 {
+		params.PagesPerRun = defaultPagesPerRun
+	}
+	return nil
+}
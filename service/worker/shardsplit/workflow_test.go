@@ -0,0 +1,171 @@
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shardsplit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/workflow"
+)
+
+type splitWorkflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+	workflowEnv *testsuite.TestWorkflowEnvironment
+}
+
+func TestSplitWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(splitWorkflowTestSuite))
+}
+
+func (s *splitWorkflowTestSuite) SetupTest() {
+	s.workflowEnv = s.NewTestWorkflowEnvironment()
+	s.workflowEnv.RegisterWorkflowWithOptions(SplitWorkflow, workflow.RegisterOptions{Name: WorkflowTypeName})
+	s.workflowEnv.RegisterActivityWithOptions(StartSplitActivity, activity.RegisterOptions{Name: startSplitActivityName})
+	s.workflowEnv.RegisterActivityWithOptions(AdvanceSplitActivity, activity.RegisterOptions{Name: advanceSplitActivityName})
+	s.workflowEnv.RegisterActivityWithOptions(MigrateExecutionsActivity, activity.RegisterOptions{Name: migrateExecutionsActivityName})
+}
+
+func (s *splitWorkflowTestSuite) TearDownTest() {
+	s.workflowEnv.AssertExpectations(s.T())
+}
+
+func (s *splitWorkflowTestSuite) TestValidateParams() {
+	s.Error(validateParams(nil))
+	s.Error(validateParams(&SplitParams{ShardID: -1}))
+
+	params := &SplitParams{ShardID: 1}
+	s.NoError(validateParams(params))
+	s.Equal(defaultPageSize, params.PageSize)
+	s.Equal(defaultPagesPerRun, params.PagesPerRun)
+}
+
+func (s *splitWorkflowTestSuite) TestWorkflow_StartSplitActivityError() {
+	s.workflowEnv.OnActivity(startSplitActivityName, mock.Anything, mock.Anything).Return(nil, errors.New("mockErr"))
+
+	s.workflowEnv.ExecuteWorkflow(WorkflowTypeName, &SplitParams{ShardID: 1})
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	s.Error(s.workflowEnv.GetWorkflowError())
+}
+
+func (s *splitWorkflowTestSuite) TestWorkflow_AutoCutOver() {
+	s.workflowEnv.OnActivity(startSplitActivityName, mock.Anything, &StartSplitActivityParams{ShardID: 1}).
+		Return(&StartSplitActivityResult{ChildShardID: 4}, nil)
+	s.mockMigrateExecutions(func(p *MigrateExecutionsActivityParams) bool {
+		return p.ClosedOnly && p.NextPageToken == nil
+	}, &MigrateExecutionsActivityResult{Copied: 2, NextPageToken: []byte("token")})
+	s.mockMigrateExecutions(func(p *MigrateExecutionsActivityParams) bool {
+		return p.ClosedOnly && string(p.NextPageToken) == "token"
+	}, &MigrateExecutionsActivityResult{Copied: 1})
+	s.workflowEnv.OnActivity(advanceSplitActivityName, mock.Anything, &AdvanceSplitActivityParams{ParentShardID: 1, ChildShardID: 4}).
+		Return(nil).Once()
+	s.mockMigrateExecutions(func(p *MigrateExecutionsActivityParams) bool {
+		return !p.ClosedOnly && !p.DeleteFromSource
+	}, &MigrateExecutionsActivityResult{Copied: 3})
+	s.workflowEnv.OnActivity(advanceSplitActivityName, mock.Anything, &AdvanceSplitActivityParams{ParentShardID: 1, ChildShardID: 4, Complete: true}).
+		Return(nil).Once()
+	s.mockMigrateExecutions(func(p *MigrateExecutionsActivityParams) bool {
+		return p.DeleteFromSource && p.ParentShardID == 1 && p.ChildShardID == 4
+	}, &MigrateExecutionsActivityResult{Deleted: 5})
+
+	s.workflowEnv.ExecuteWorkflow(WorkflowTypeName, &SplitParams{ShardID: 1, AutoCutOver: true})
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	var result Checkpoint
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Equal(PhaseCompleted, result.Phase)
+	s.Equal(4, result.ChildShardID)
+	s.Equal(6, result.CopiedExecutions)
+	s.Equal(5, result.DeletedExecutions)
+}
+
+func (s *splitWorkflowTestSuite) TestWorkflow_WaitCutOverSignal() {
+	s.workflowEnv.OnActivity(startSplitActivityName, mock.Anything, mock.Anything).Return(&StartSplitActivityResult{ChildShardID: 4}, nil)
+	s.workflowEnv.OnActivity(advanceSplitActivityName, mock.Anything, mock.Anything).Return(nil).Twice()
+	s.mockMigrateExecutions(func(p *MigrateExecutionsActivityParams) bool {
+		return true
+	}, &MigrateExecutionsActivityResult{Copied: 1})
+
+	s.workflowEnv.RegisterDelayedCallback(func() {
+		s.assertQueryPhase(PhaseWaitCutOver)
+		s.workflowEnv.SignalWorkflow(CutOverSignal, nil)
+	}, time.Hour)
+
+	s.workflowEnv.ExecuteWorkflow(WorkflowTypeName, &SplitParams{ShardID: 1})
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	var result Checkpoint
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Equal(PhaseCompleted, result.Phase)
+	s.True(result.CutOverRequested)
+	s.Equal(3, result.CopiedExecutions)
+}
+
+func (s *splitWorkflowTestSuite) TestWorkflow_ContinueAsNew() {
+	s.workflowEnv.OnActivity(startSplitActivityName, mock.Anything, mock.Anything).Return(&StartSplitActivityResult{ChildShardID: 4}, nil)
+	s.mockMigrateExecutions(func(p *MigrateExecutionsActivityParams) bool {
+		return true
+	}, &MigrateExecutionsActivityResult{Copied: 1, NextPageToken: []byte("token")})
+
+	s.workflowEnv.ExecuteWorkflow(WorkflowTypeName, &SplitParams{ShardID: 1, PagesPerRun: 2})
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	var continueAsNewErr *workflow.ContinueAsNewError
+	s.ErrorAs(s.workflowEnv.GetWorkflowError(), &continueAsNewErr)
+}
+
+func (s *splitWorkflowTestSuite) TestWorkflow_ResumeFromCheckpoint() {
+	s.mockMigrateExecutions(func(p *MigrateExecutionsActivityParams) bool {
+		return p.DeleteFromSource && string(p.NextPageToken) == "token"
+	}, &MigrateExecutionsActivityResult{Deleted: 1})
+
+	params := &SplitParams{
+		ShardID: 1,
+		Checkpoint: &Checkpoint{
+			Phase:             PhaseDeleteSource,
+			ParentShardID:     1,
+			ChildShardID:      4,
+			NextPageToken:     []byte("token"),
+			DeletedExecutions: 10,
+		},
+	}
+	s.workflowEnv.ExecuteWorkflow(WorkflowTypeName, params)
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	var result Checkpoint
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Equal(PhaseCompleted, result.Phase)
+	s.Equal(11, result.DeletedExecutions)
+}
+
+func (s *splitWorkflowTestSuite) mockMigrateExecutions(match func(*MigrateExecutionsActivityParams) bool, result *MigrateExecutionsActivityResult) {
+	s.workflowEnv.OnActivity(migrateExecutionsActivityName, mock.Anything, mock.MatchedBy(match)).Return(result, nil)
+}
+
+func (s *splitWorkflowTestSuite) assertQueryPhase(expectedPhase string) {
+	queryResult, err := s.workflowEnv.QueryWorkflow(QueryType)
+	s.NoError(err)
+	var res QueryResult
+	s.NoError(queryResult.Get(&res))
+	s.Equal(expectedPhase, res.Checkpoint.Phase)
+}
@@ -289,7 +289,7 @@ func newAdminShardManagementCommands() []cli.Command {
 					Name: FlagTimerType,
 					Usage: "timer types: 0 - DecisionTimeoutTask, 1 - TaskTypeActivityTimeout, " +
 						"2 - TaskTypeUserTimer, 3 - TaskTypeWorkflowTimeout, 4 - TaskTypeDeleteHistoryEvent, " +
-						"5 - TaskTypeActivityRetryTimer, 6 - TaskTypeWorkflowBackoffTimer",
+						"5 - TaskTypeActivityRetryTimer, 6 - TaskTypeWorkflowBackoffTimer, 7 - TaskTypeWorkflowNoProgressTimeout",
 					Value: &cli.IntSlice{-1},
 				},
 				cli.BoolFlag{
@@ -324,6 +324,48 @@ func newAdminShardManagementCommands() []cli.Command {
 				AdminTimers(c)
 			},
 		},
+		{
+			Name:  "split",
+			Usage: "start a workflow which splits a history shard into two and moves the executions of the new shard",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagShardIDWithAlias,
+					Usage: "ID of the shard to split",
+				},
+				cli.IntFlag{
+					Name:  FlagPageSize,
+					Usage: "number of executions scanned by each activity of the split workflow",
+				},
+				cli.BoolFlag{
+					Name:  FlagAutoCutOver,
+					Usage: "cut over once the closed executions are copied, instead of waiting for the split-cut-over command",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminShardSplitStart(c)
+			},
+		},
+		{
+			Name:  "split-status",
+			Usage: "show the progress of the shard split workflow",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunID of the split workflow, the current run is used if not given",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminShardSplitStatus(c)
+			},
+		},
+		{
+			Name: "split-cut-over",
+			Usage: "cut over the shard split: requests and tasks of the moving workflows are rejected " +
+				"until their executions are copied into the new shard",
+			Action: func(c *cli.Context) {
+				AdminShardSplitCutOver(c)
+			},
+		},
 	}
 }
 
@@ -358,8 +400,8 @@ func newAdminHistoryHostCommands() []cli.Command {
 		{
 			Name:    "getshard",
 			Aliases: []string{"gsh"},
-			Usage:   "Get shardID for a workflowID",
-			Flags: []cli.Flag{
+			Usage:   "Get shardID for a workflowID, shards split since the cluster was created are read from the database",
+			Flags: append(getDBFlags(),
 				cli.StringFlag{
 					Name:  FlagWorkflowIDWithAlias,
 					Usage: "WorkflowID",
@@ -368,7 +410,7 @@ func newAdminHistoryHostCommands() []cli.Command {
 					Name:  FlagNumberOfShards,
 					Usage: "NumberOfShards for the cadence cluster(see config for numHistoryShards)",
 				},
-			},
+			),
 			Action: func(c *cli.Context) {
 				AdminGetShardID(c)
 			},
@@ -768,7 +810,7 @@ func getDLQFlags() []cli.Flag {
 		},
 		cli.StringFlag{
 			Name:  FlagDLQTypeWithAlias,
-			Usage: "Type of DLQ to manage. (Options: domain, history)",
+			Usage: "Type of DLQ to manage. (Options: domain, history, history-task)",
 			Value: "history",
 		},
 		cli.StringFlag{
@@ -1079,6 +1121,11 @@ func newAdminFailoverCommands() []cli.Command {
 					Usage: "Optional cron schedule on failover drill. Please specify failover drill wait time " +
 						"if this field is specific",
 				},
+				cli.IntFlag{
+					Name: FlagMaxReplicationLag,
+					Usage: "Optional max replication lag in seconds to the target cluster. " +
+						"Domains lagging more are not failed over, lagging domains are only logged if not set.",
+				},
 			},
 			Action: func(c *cli.Context) {
 				AdminFailoverStart(c)
@@ -363,7 +363,11 @@ func AdminGetShardID(c *cli.Context) {
 		ErrorAndExit("numberOfShards is required", nil)
 		return
 	}
-	shardID := common.WorkflowIDToHistoryShard(wid, numberOfShards)
+	shardMapping, err := initializeHistoryShardMapping(c, numberOfShards)
+	if err != nil {
+		ErrorAndExit("Failed to load history shard mapping", err)
+	}
+	shardID := shardMapping.WorkflowIDToShard(wid)
 	fmt.Printf("ShardID for workflowID: %v is %v \n", wid, shardID)
 }
 
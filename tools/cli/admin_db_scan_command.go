@@ -56,6 +56,10 @@ func AdminDBScan(c *cli.Context) {
 	}
 
 	numberOfShards := getRequiredIntOption(c, FlagNumberOfShards)
+	shardMapping, err := initializeHistoryShardMapping(c, numberOfShards)
+	if err != nil {
+		ErrorAndExit("Failed to load history shard mapping", err)
+	}
 	collectionSlice := c.StringSlice(FlagInvariantCollection)
 
 	var collections []invariant.Collection
@@ -107,7 +111,7 @@ func AdminDBScan(c *cli.Context) {
 	}
 
 	for _, e := range data {
-		execution, result := checkExecution(c, numberOfShards, e, invariants, ef)
+		execution, result := checkExecution(c, shardMapping, e, invariants, ef)
 		out := store.ScanOutputEntity{
 			Execution: execution,
 			Result:    result,
@@ -124,12 +128,12 @@ func AdminDBScan(c *cli.Context) {
 
 func checkExecution(
 	c *cli.Context,
-	numberOfShards int,
+	shardMapping *common.HistoryShardMapping,
 	req fetcher.ExecutionRequest,
 	invariants []executions.InvariantFactory,
 	fetcher executions.ExecutionFetcher,
 ) (interface{}, invariant.ManagerCheckResult) {
-	execManager := initializeExecutionStore(c, common.WorkflowIDToHistoryShard(req.WorkflowID, numberOfShards))
+	execManager := initializeExecutionStore(c, shardMapping.WorkflowIDToShard(req.WorkflowID))
 	defer execManager.Close()
 
 	historyV2Mgr := initializeHistoryManager(c)
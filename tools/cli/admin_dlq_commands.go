@@ -54,6 +54,7 @@ type DLQRow struct {
 	NextEventID     int64                      `json:"nextEventID"`
 	ScheduledID     int64                      `json:"scheduledID"`
 	ReplicationTask *types.ReplicationTask     `json:"replicationTask"`
+	HistoryTask     *types.QueueTaskInfo       `json:"historyTask,omitempty"`
 
 	// Those are deserialized variants from history replications task
 	Events       []*types.HistoryEvent `json:"events"`
@@ -128,7 +129,7 @@ func AdminGetDLQMessages(c *cli.Context) {
 	client := cFactory.ServerFrontendClient(c)
 	adminClient := cFactory.ServerAdminClient(c)
 
-	dlqType := toQueueType(getRequiredOption(c, FlagDLQType))
+	dlqType := toQueueType(c, getRequiredOption(c, FlagDLQType))
 	sourceCluster := getRequiredOption(c, FlagSourceCluster)
 
 	remainingMessageCount := common.EndMessageID
@@ -213,6 +214,29 @@ func AdminGetDLQMessages(c *cli.Context) {
 				}
 			}
 
+			for _, message := range resp.HistoryTasks {
+				task := message.GetTask()
+				if task == nil {
+					continue
+				}
+
+				rows = append(rows, DLQRow{
+					ShardID:     shardID,
+					DomainName:  getDomainName(task.DomainID),
+					DomainID:    task.DomainID,
+					WorkflowID:  task.WorkflowID,
+					RunID:       task.RunID,
+					TaskID:      task.TaskID,
+					Version:     task.Version,
+					HistoryTask: task,
+				})
+
+				remainingMessageCount--
+				if remainingMessageCount <= 0 {
+					return rows
+				}
+			}
+
 			if len(resp.NextPageToken) == 0 {
 				break
 			}
@@ -245,7 +269,7 @@ func AdminPurgeDLQMessages(c *cli.Context) {
 	for shardID := range getShards(c) {
 		ctx, cancel := newContext(c)
 		err := adminClient.PurgeDLQMessages(ctx, &types.PurgeDLQMessagesRequest{
-			Type:                  toQueueType(dlqType),
+			Type:                  toQueueType(c, dlqType),
 			SourceCluster:         sourceCluster,
 			ShardID:               int32(shardID),
 			InclusiveEndMessageID: lastMessageID,
@@ -273,7 +297,7 @@ func AdminMergeDLQMessages(c *cli.Context) {
 ShardIDLoop:
 	for shardID := range getShards(c) {
 		request := &types.MergeDLQMessagesRequest{
-			Type:                  toQueueType(dlqType),
+			Type:                  toQueueType(c, dlqType),
 			SourceCluster:         sourceCluster,
 			ShardID:               int32(shardID),
 			InclusiveEndMessageID: lastMessageID,
@@ -350,12 +374,17 @@ func readShardsFromStdin() chan int {
 	return shards
 }
 
-func toQueueType(dlqType string) *types.DLQType {
+func toQueueType(c *cli.Context, dlqType string) *types.DLQType {
 	switch dlqType {
 	case "domain":
 		return types.DLQTypeDomain.Ptr()
 	case "history":
 		return types.DLQTypeReplication.Ptr()
+	case "history-task":
+		if c.GlobalString(FlagTransport) == grpcTransport {
+			ErrorAndExit("The queue type is not supported.", fmt.Errorf("the history-task queue type is not supported over %v transport", grpcTransport))
+		}
+		return types.DLQTypeHistoryTask.Ptr()
 	default:
 		ErrorAndExit("The queue type is not supported.", fmt.Errorf("the queue type is not supported. Type: %v", dlqType))
 	}
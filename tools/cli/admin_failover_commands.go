@@ -53,6 +53,7 @@ type startParams struct {
 	domains                        []string
 	drillWaitTime                  int
 	cron                           string
+	maxReplicationLagInSeconds     int
 }
 
 // AdminFailoverStart start failover workflow
@@ -67,6 +68,7 @@ func AdminFailoverStart(c *cli.Context) {
 		domains:                        c.StringSlice(FlagFailoverDomains),
 		drillWaitTime:                  c.Int(FlagFailoverDrillWaitTime),
 		cron:                           c.String(FlagCronSchedule),
+		maxReplicationLagInSeconds:     c.Int(FlagMaxReplicationLag),
 	}
 	failoverStart(c, params)
 }
@@ -311,6 +313,7 @@ func failoverStart(c *cli.Context, params *startParams) {
 		Domains:                          domains,
 		DrillWaitTime:                    drillWaitTime,
 		GracefulFailoverTimeoutInSeconds: gracefulFailoverTimeoutInSeconds,
+		MaxReplicationLagInSeconds:       params.maxReplicationLagInSeconds,
 	}
 	input, err := json.Marshal(foParams)
 	if err != nil {
@@ -0,0 +1,131 @@
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pborman/uuid"
+	"github.com/urfave/cli"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/shardsplit"
+)
+
+const (
+	// the split workflow continues as new periodically, the timeout only bounds a single run
+	defaultShardSplitWorkflowTimeoutInSeconds = 24 * 60 * 60
+)
+
+// AdminShardSplitStart starts the workflow splitting a history shard
+func AdminShardSplitStart(c *cli.Context) {
+	if !c.IsSet(FlagShardID) {
+		ErrorAndExit("Option shard_id is required.", nil)
+	}
+	params := shardsplit.SplitParams{
+		ShardID:     c.Int(FlagShardID),
+		PageSize:    c.Int(FlagPageSize),
+		AutoCutOver: c.Bool(FlagAutoCutOver),
+	}
+	input, err := json.Marshal(params)
+	if err != nil {
+		ErrorAndExit("Failed to serialize shard split params", err)
+	}
+
+	client := getCadenceClient(c)
+	tcCtx, cancel := newContext(c)
+	defer cancel()
+	memo, err := getWorkflowMemo(map[string]interface{}{
+		common.MemoKeyForOperator: getOperator(),
+	})
+	if err != nil {
+		ErrorAndExit("Failed to serialize memo", err)
+	}
+	resp, err := client.StartWorkflowExecution(tcCtx, &types.StartWorkflowExecutionRequest{
+		Domain:                              common.SystemLocalDomainName,
+		RequestID:                           uuid.New(),
+		WorkflowID:                          shardsplit.WorkflowID,
+		WorkflowIDReusePolicy:               types.WorkflowIDReusePolicyAllowDuplicate.Ptr(),
+		TaskList:                            &types.TaskList{Name: shardsplit.TaskListName},
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(defaultShardSplitWorkflowTimeoutInSeconds),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(defaultDecisionTimeoutInSeconds),
+		Memo:                                memo,
+		WorkflowType:                        &types.WorkflowType{Name: shardsplit.WorkflowTypeName},
+		Input:                               input,
+	})
+	if err != nil {
+		ErrorAndExit("Failed to start shard split workflow", err)
+	}
+	fmt.Println("Shard split workflow started")
+	fmt.Println("wid: " + shardsplit.WorkflowID)
+	fmt.Println("rid: " + resp.GetRunID())
+}
+
+// AdminShardSplitStatus queries the progress of the shard split workflow
+func AdminShardSplitStatus(c *cli.Context) {
+	client := getCadenceClient(c)
+	tcCtx, cancel := newContext(c)
+	defer cancel()
+
+	queryResp, err := client.QueryWorkflow(tcCtx, &types.QueryWorkflowRequest{
+		Domain: common.SystemLocalDomainName,
+		Execution: &types.WorkflowExecution{
+			WorkflowID: shardsplit.WorkflowID,
+			RunID:      getRunID(c),
+		},
+		Query: &types.WorkflowQuery{
+			QueryType: shardsplit.QueryType,
+		},
+	})
+	if err != nil {
+		ErrorAndExit("Failed to query shard split workflow", err)
+	}
+	if queryResp.GetQueryResult() == nil {
+		ErrorAndExit("QueryResult has no value", nil)
+	}
+	var queryResult shardsplit.QueryResult
+	if err := json.Unmarshal(queryResp.GetQueryResult(), &queryResult); err != nil {
+		ErrorAndExit("Unable to deserialize QueryResult", err)
+	}
+	prettyPrintJSONObject(queryResult)
+}
+
+// AdminShardSplitCutOver signals the shard split workflow to start the cut-over
+func AdminShardSplitCutOver(c *cli.Context) {
+	client := getCadenceClient(c)
+	tcCtx, cancel := newContext(c)
+	defer cancel()
+
+	err := client.SignalWorkflowExecution(tcCtx, &types.SignalWorkflowExecutionRequest{
+		Domain: common.SystemLocalDomainName,
+		WorkflowExecution: &types.WorkflowExecution{
+			WorkflowID: shardsplit.WorkflowID,
+		},
+		SignalName: shardsplit.CutOverSignal,
+		Identity:   getCliIdentity(),
+	})
+	if err != nil {
+		ErrorAndExit("Failed to signal shard split workflow", err)
+	}
+	fmt.Println("Shard split cut-over started")
+}
@@ -154,6 +154,7 @@ func AdminTimers(c *cli.Context) {
 			persistence.TaskTypeDeleteHistoryEvent,
 			persistence.TaskTypeActivityRetryTimer,
 			persistence.TaskTypeWorkflowBackoffTimer,
+			persistence.TaskTypeWorkflowNoProgressTimeout,
 		}
 	}
 
@@ -21,6 +21,8 @@
 package cli
 
 import (
+	"context"
+	"encoding/json"
 	"strings"
 	"testing"
 	"time"
@@ -31,12 +33,14 @@ import (
 	"github.com/pborman/uuid"
 	"github.com/stretchr/testify/suite"
 	"github.com/urfave/cli"
+	"go.uber.org/yarpc"
 
 	"github.com/uber/cadence/client/admin"
 	"github.com/uber/cadence/client/frontend"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/shardsplit"
 )
 
 type cliAppSuite struct {
@@ -622,6 +626,12 @@ func (s *cliAppSuite) TestAdminDescribeWorkflow_Failed() {
 	s.Equal(1, errorCode)
 }
 
+func (s *cliAppSuite) TestAdminPurgeDLQMessages_HistoryTaskOverGRPC() {
+	s.serverAdminClient.EXPECT().PurgeDLQMessages(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	errorCode := s.RunErrorExitCode([]string{"", "--transport", "grpc", "admin", "dlq", "purge", "--dlq_type", "history-task", "--source_cluster", "active", "--shards", "1"})
+	s.Equal(1, errorCode)
+}
+
 func (s *cliAppSuite) TestAdminAddSearchAttribute() {
 	var promptMsg string
 	promptFn = func(msg string) {
@@ -647,6 +657,39 @@ func (s *cliAppSuite) TestAdminFailover() {
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestAdminShardSplit() {
+	resp := &types.StartWorkflowExecutionResponse{RunID: uuid.New()}
+	s.serverFrontendClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *types.StartWorkflowExecutionRequest, _ ...yarpc.CallOption) (*types.StartWorkflowExecutionResponse, error) {
+			s.Equal(shardsplit.WorkflowID, request.WorkflowID)
+			var params shardsplit.SplitParams
+			s.NoError(json.Unmarshal(request.Input, &params))
+			s.Equal(shardsplit.SplitParams{ShardID: 3, AutoCutOver: true}, params)
+			return resp, nil
+		})
+	err := s.app.Run([]string{"", "admin", "shard", "split", "--shard_id", "3", "--auto_cut_over"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminShardSplitStatus() {
+	result, err := json.Marshal(shardsplit.QueryResult{ShardID: 3, Checkpoint: &shardsplit.Checkpoint{Phase: shardsplit.PhaseCopyClosed}})
+	s.NoError(err)
+	s.serverFrontendClient.EXPECT().QueryWorkflow(gomock.Any(), gomock.Any()).Return(&types.QueryWorkflowResponse{QueryResult: result}, nil)
+	err = s.app.Run([]string{"", "admin", "shard", "split-status"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminShardSplitCutOver() {
+	s.serverFrontendClient.EXPECT().SignalWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *types.SignalWorkflowExecutionRequest, _ ...yarpc.CallOption) error {
+			s.Equal(shardsplit.CutOverSignal, request.SignalName)
+			s.Equal(shardsplit.WorkflowID, request.WorkflowExecution.WorkflowID)
+			return nil
+		})
+	err := s.app.Run([]string{"", "admin", "shard", "split-cut-over"})
+	s.Nil(err)
+}
+
 func (s *cliAppSuite) TestDescribeTaskList() {
 	resp := describeTaskListResponse
 	s.serverFrontendClient.EXPECT().DescribeTaskList(gomock.Any(), gomock.Any()).Return(resp, nil)
@@ -185,6 +185,31 @@ func initializeDomainManager(c *cli.Context) persistence.DomainManager {
 	return domainManager
 }
 
+// initializeHistoryShardMapping loads the history shard mapping from the config store,
+// the initial mapping is used if shards have never been split
+func initializeHistoryShardMapping(c *cli.Context, numberOfShards int) (*common.HistoryShardMapping, error) {
+	factory := getPersistenceFactory(c)
+	configStoreManager, err := factory.NewConfigStoreManager()
+	if err != nil {
+		return nil, err
+	}
+	defer configStoreManager.Close()
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := configStoreManager.FetchHistoryShardMapping(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Mapping == nil {
+		return common.NewHistoryShardMapping(numberOfShards), nil
+	}
+	if resp.Mapping.NumberOfShards != numberOfShards {
+		return nil, fmt.Errorf("history shard mapping has %v initial shards, but %v is given", resp.Mapping.NumberOfShards, numberOfShards)
+	}
+	return resp.Mapping, nil
+}
+
 var persistenceFactory client.Factory
 
 func getPersistenceFactory(c *cli.Context) client.Factory {
@@ -264,6 +264,12 @@ const (
 	FlagFailoverTimeoutWithAlias          = FlagFailoverTimeout + ", fts"
 	FlagActivityHeartBeatTimeout          = "heart_beat_timeout_seconds"
 	FlagActivityHeartBeatTimeoutWithAlias = FlagActivityHeartBeatTimeout + ", hbts"
+	FlagScheduleToCloseTimeout            = "schedule_to_close_timeout_seconds"
+	FlagScheduleToStartTimeout            = "schedule_to_start_timeout_seconds"
+	FlagStartToCloseTimeout               = "start_to_close_timeout_seconds"
+	FlagHeartbeatTimeout                  = "heartbeat_timeout_seconds"
+	FlagTerminateIfRunning                = "terminate_if_running"
+	FlagIncludeChildWorkflows             = "include_child_workflows"
 	FlagFailoverWaitTime                  = "failover_wait_time_second"
 	FlagFailoverWaitTimeWithAlias         = FlagFailoverWaitTime + ", fwts"
 	FlagFailoverBatchSize                 = "failover_batch_size"
@@ -272,7 +278,9 @@ const (
 	FlagFailoverDrillWaitTime             = "failover_drill_wait_second"
 	FlagFailoverDrillWaitTimeWithAlias    = FlagFailoverDrillWaitTime + ", fdws"
 	FlagFailoverDrill                     = "failover_drill"
+	FlagMaxReplicationLag                 = "max_replication_lag_seconds"
 	FlagFailoverDrillWithAlias            = FlagFailoverDrill + ", fd"
+	FlagAutoCutOver                       = "auto_cut_over"
 	FlagRetryInterval                     = "retry_interval"
 	FlagRetryAttempts                     = "retry_attempts"
 	FlagRetryExpiration                   = "retry_expiration"
@@ -288,6 +296,8 @@ const (
 	FlagBucketSize                        = "bucket_size"
 	DelayStartSeconds                     = "delay_start_seconds"
 	JitterStartSeconds                    = "jitter_start_seconds"
+	FlagNoProgressTimeout                 = "no_progress_timeout"
+	FlagNoProgressTimeoutPolicy           = "no_progress_timeout_policy"
 	FlagConnectionAttributes              = "conn_attrs"
 	FlagJWT                               = "jwt"
 	FlagJWTPrivateKey                     = "jwt-private-key"
@@ -485,6 +495,15 @@ func getFlagsForStart() []cli.Flag {
 			Name:  JitterStartSeconds,
 			Usage: "Optional workflow start jitter in seconds. If set, workflow start will be jittered between 0-n seconds (after delay)",
 		},
+		cli.IntFlag{
+			Name:  FlagNoProgressTimeout,
+			Usage: "Optional timeout in seconds for the workflow to complete a decision. If no decision completes within it, no_progress_timeout_policy is applied",
+		},
+		cli.StringFlag{
+			Name:  FlagNoProgressTimeoutPolicy,
+			Value: "notify",
+			Usage: "Optional policy when no_progress_timeout is reached. Valid values: notify (record an event and schedule a decision), fail (fail the workflow, honoring retry and cron policy)",
+		},
 	}
 }
 
@@ -26,7 +26,7 @@ import (
 
 	"github.com/urfave/cli"
 
-	"github.com/uber/cadence/service/worker/batcher"
+	"github.com/uber/cadence/common/batcher"
 )
 
 func newWorkflowCommands() []cli.Command {
@@ -531,6 +531,38 @@ func newBatchCommands() []cli.Command {
 					Name:  FlagTargetClusterWithAlias,
 					Usage: "Required for batch replicate",
 				},
+				cli.StringFlag{
+					Name:  FlagActivityIDWithAlias,
+					Usage: "Required for batch update_activity_options",
+				},
+				cli.StringFlag{
+					Name:  FlagTaskListWithAlias,
+					Usage: "Optional new task list of the activity for batch update_activity_options",
+				},
+				cli.IntFlag{
+					Name:  FlagScheduleToCloseTimeout,
+					Usage: "Optional new schedule to close timeout of the activity in seconds for batch update_activity_options",
+				},
+				cli.IntFlag{
+					Name:  FlagScheduleToStartTimeout,
+					Usage: "Optional new schedule to start timeout of the activity in seconds for batch update_activity_options",
+				},
+				cli.IntFlag{
+					Name:  FlagStartToCloseTimeout,
+					Usage: "Optional new start to close timeout of the activity in seconds for batch update_activity_options",
+				},
+				cli.IntFlag{
+					Name:  FlagHeartbeatTimeout,
+					Usage: "Optional new heartbeat timeout of the activity in seconds for batch update_activity_options",
+				},
+				cli.BoolFlag{
+					Name:  FlagTerminateIfRunning,
+					Usage: "Optional flag to terminate running workflows before deleting them for batch delete",
+				},
+				cli.BoolFlag{
+					Name:  FlagIncludeChildWorkflows,
+					Usage: "Optional flag to delete child workflows as well for batch delete",
+				},
 				cli.IntFlag{
 					Name:  FlagRPS,
 					Value: batcher.DefaultRPS,
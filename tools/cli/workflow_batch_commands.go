@@ -32,8 +32,8 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/batcher"
 	"github.com/uber/cadence/common/types"
-	"github.com/uber/cadence/service/worker/batcher"
 )
 
 // TerminateBatchJob stops abatch job
@@ -171,6 +171,15 @@ func StartBatchJob(c *cli.Context) {
 		sourceCluster = getRequiredOption(c, FlagSourceCluster)
 		targetCluster = getRequiredOption(c, FlagTargetCluster)
 	}
+	var updateActivityOptionsParams batcher.UpdateActivityOptionsParams
+	if batchType == batcher.BatchTypeUpdateActivityOptions {
+		updateActivityOptionsParams = getUpdateActivityOptionsParams(c)
+	}
+	var deleteParams batcher.DeleteParams
+	if batchType == batcher.BatchTypeDelete {
+		deleteParams.TerminateIfRunning = c.Bool(FlagTerminateIfRunning)
+		deleteParams.DeleteChildren = c.Bool(FlagIncludeChildWorkflows)
+	}
 	rps := c.Int(FlagRPS)
 	pageSize := c.Int(FlagPageSize)
 	concurrency := c.Int(FlagConcurrency)
@@ -219,17 +228,19 @@ func StartBatchJob(c *cli.Context) {
 		BatchType:  batchType,
 		SignalParams: batcher.SignalParams{
 			SignalName: sigName,
-			Input:      sigVal,
+			Input:      []byte(sigVal),
 		},
 		ReplicateParams: batcher.ReplicateParams{
 			SourceCluster: sourceCluster,
 			TargetCluster: targetCluster,
 		},
-		RPS:                      rps,
-		Concurrency:              concurrency,
-		PageSize:                 pageSize,
-		AttemptsOnRetryableError: retryAttempt,
-		ActivityHeartBeatTimeout: heartBeatTimeout,
+		UpdateActivityOptionsParams: updateActivityOptionsParams,
+		DeleteParams:                deleteParams,
+		RPS:                         rps,
+		Concurrency:                 concurrency,
+		PageSize:                    pageSize,
+		AttemptsOnRetryableError:    retryAttempt,
+		ActivityHeartBeatTimeout:    heartBeatTimeout,
 	}
 	input, err := json.Marshal(params)
 	if err != nil {
@@ -272,6 +283,26 @@ func StartBatchJob(c *cli.Context) {
 	prettyPrintJSONObject(output)
 }
 
+func getUpdateActivityOptionsParams(c *cli.Context) batcher.UpdateActivityOptionsParams {
+	params := batcher.UpdateActivityOptionsParams{
+		ActivityID: getRequiredOption(c, FlagActivityID),
+		TaskList:   c.String(FlagTaskList),
+	}
+	if c.IsSet(FlagScheduleToCloseTimeout) {
+		params.ScheduleToCloseTimeoutSeconds = common.Int32Ptr(int32(c.Int(FlagScheduleToCloseTimeout)))
+	}
+	if c.IsSet(FlagScheduleToStartTimeout) {
+		params.ScheduleToStartTimeoutSeconds = common.Int32Ptr(int32(c.Int(FlagScheduleToStartTimeout)))
+	}
+	if c.IsSet(FlagStartToCloseTimeout) {
+		params.StartToCloseTimeoutSeconds = common.Int32Ptr(int32(c.Int(FlagStartToCloseTimeout)))
+	}
+	if c.IsSet(FlagHeartbeatTimeout) {
+		params.HeartbeatTimeoutSeconds = common.Int32Ptr(int32(c.Int(FlagHeartbeatTimeout)))
+	}
+	return params
+}
+
 func validateBatchType(bt string) bool {
 	for _, b := range batcher.AllBatchTypes {
 		if b == bt {
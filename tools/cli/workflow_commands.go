@@ -337,6 +337,11 @@ func constructStartWorkflowRequest(c *cli.Context) *types.StartWorkflowExecution
 		startRequest.JitterStartSeconds = common.Int32Ptr(int32(c.Int(JitterStartSeconds)))
 	}
 
+	if c.IsSet(FlagNoProgressTimeout) {
+		startRequest.NoProgressTimeoutSeconds = common.Int32Ptr(int32(c.Int(FlagNoProgressTimeout)))
+		startRequest.NoProgressTimeoutPolicy = getNoProgressTimeoutPolicy(c.String(FlagNoProgressTimeoutPolicy))
+	}
+
 	headerFields := processHeader(c)
 	if len(headerFields) != 0 {
 		startRequest.Header = &types.Header{Fields: headerFields}
@@ -609,6 +614,8 @@ func constructSignalWithStartWorkflowRequest(c *cli.Context) *types.SignalWithSt
 		SignalInput:                         []byte(processJSONInputSignal(c)),
 		DelayStartSeconds:                   startRequest.DelayStartSeconds,
 		JitterStartSeconds:                  startRequest.JitterStartSeconds,
+		NoProgressTimeoutSeconds:            startRequest.NoProgressTimeoutSeconds,
+		NoProgressTimeoutPolicy:             startRequest.NoProgressTimeoutPolicy,
 	}
 }
 
@@ -837,6 +844,7 @@ type describeWorkflowExecutionResponse struct {
 	PendingActivities      []*pendingActivityInfo
 	PendingChildren        []*types.PendingChildExecutionInfo
 	PendingDecision        *pendingDecisionInfo
+	WorkflowSizeLimits     *types.WorkflowSizeLimits
 }
 
 // workflowExecutionInfo has same fields as types.WorkflowExecutionInfo, but has datetime instead of raw time
@@ -950,6 +958,7 @@ func convertDescribeWorkflowExecutionResponse(resp *types.DescribeWorkflowExecut
 		PendingActivities:      pendingActs,
 		PendingChildren:        resp.PendingChildren,
 		PendingDecision:        pendingDecision,
+		WorkflowSizeLimits:     resp.WorkflowSizeLimits,
 	}
 }
 
@@ -1376,6 +1385,14 @@ func getWorkflowIDReusePolicy(value int) *types.WorkflowIDReusePolicy {
 	return nil
 }
 
+func getNoProgressTimeoutPolicy(value string) *types.NoProgressTimeoutPolicy {
+	var policy types.NoProgressTimeoutPolicy
+	if err := policy.UnmarshalText([]byte(value)); err != nil || policy < types.NoProgressTimeoutPolicyNotify || policy > types.NoProgressTimeoutPolicyFail {
+		ErrorAndExit(fmt.Sprintf("Option %v value is not one of allowed values [notify, fail].", FlagNoProgressTimeoutPolicy), err)
+	}
+	return policy.Ptr()
+}
+
 // default will print decoded raw
 func printListResults(executions []*types.WorkflowExecutionInfo, inJSON bool, more bool) {
 	for i, execution := range executions {